@@ -7,8 +7,12 @@ import (
 	"github.com/randalmurphal/orc/internal/cli"
 )
 
+// version is set by the build via -ldflags "-X main.version=...".
+var version string
+
 func main() {
+	cli.SetVersion(version)
 	if err := cli.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 }
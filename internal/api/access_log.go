@@ -0,0 +1,170 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/randalmurphal/orc/internal/events"
+)
+
+// requestIDHeader carries the request ID. A valid incoming value is kept so
+// a trace spans the UI, proxies, and the server; otherwise one is generated.
+// It is echoed on every response, including errors.
+const requestIDHeader = "X-Request-ID"
+
+// maxRequestIDLen bounds client-supplied request IDs.
+const maxRequestIDLen = 128
+
+type requestIDContextKey struct{}
+
+// requestIDFromContext returns the ID assigned by requestIDMiddleware, or "".
+func requestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// validRequestID accepts short printable ASCII IDs, so client values are
+// safe to log and echo.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// requestIDMiddleware assigns every request an ID, exposes it to handlers
+// through the context, and writes an access log line with latency. Failed
+// requests (status >= 400) are always logged; successful ones are sampled
+// at server.access_log.sample_rate.
+func (s *Server) requestIDMiddleware(next http.Handler) http.Handler {
+	cfg := s.accessLogConfig()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.New().String()
+		}
+		w.Header().Set(requestIDHeader, id)
+		r = r.WithContext(context.WithValue(r.Context(), requestIDContextKey{}, id))
+
+		if !cfg.enabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		if rec.status < http.StatusBadRequest && (cfg.sampleRate <= 0 || rand.Float64() >= cfg.sampleRate) {
+			return
+		}
+		level := slog.LevelInfo
+		switch {
+		case rec.status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case rec.status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		s.logger.Log(r.Context(), level, "http request",
+			"request_id", id,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"bytes", rec.bytes,
+			"duration", time.Since(start),
+		)
+	})
+}
+
+type accessLogSettings struct {
+	enabled    bool
+	sampleRate float64
+}
+
+func (s *Server) accessLogConfig() accessLogSettings {
+	if s.orcConfig == nil {
+		return accessLogSettings{enabled: true, sampleRate: 1}
+	}
+	cfg := s.orcConfig.Server.AccessLog
+	return accessLogSettings{enabled: cfg.Enabled, sampleRate: cfg.SampleRate}
+}
+
+// statusRecorder captures the response status and size. It passes through
+// flushing (Connect streams) and hijacking (WebSockets).
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if !r.wroteHeader {
+		r.status = code
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	return h.Hijack()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// requestPublisher stamps events with the ID of the request that caused
+// them, so events from an executor run trace back to the RunTask call.
+type requestPublisher struct {
+	events.Publisher
+	requestID string
+}
+
+// withRequestID returns a publisher that tags events with ctx's request ID,
+// or pub unchanged when ctx has none.
+func withRequestID(ctx context.Context, pub events.Publisher) events.Publisher {
+	id := requestIDFromContext(ctx)
+	if id == "" || pub == nil {
+		return pub
+	}
+	return &requestPublisher{Publisher: pub, requestID: id}
+}
+
+func (p *requestPublisher) Publish(e events.Event) {
+	if e.RequestID == "" {
+		e.RequestID = p.requestID
+	}
+	p.Publisher.Publish(e)
+}
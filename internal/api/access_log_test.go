@@ -0,0 +1,122 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/events"
+)
+
+func newAccessLogTestServer(t *testing.T, sampleRate float64) (*Server, *bytes.Buffer) {
+	t.Helper()
+	var buf bytes.Buffer
+	cfg := config.Default()
+	cfg.Server.AccessLog.SampleRate = sampleRate
+	s := &Server{
+		mux:       http.NewServeMux(),
+		logger:    slog.New(slog.NewTextHandler(&buf, nil)),
+		orcConfig: cfg,
+	}
+	s.mux.HandleFunc("GET /ok", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(requestIDFromContext(r.Context())))
+	})
+	s.mux.HandleFunc("GET /fail", func(w http.ResponseWriter, r *http.Request) {
+		s.jsonError(w, "boom", http.StatusInternalServerError)
+	})
+	return s, &buf
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	s, _ := newAccessLogTestServer(t, 1)
+	handler := s.Handler()
+
+	tests := []struct {
+		name     string
+		incoming string
+		wantSame bool
+	}{
+		{"generated", "", false},
+		{"client supplied", "ui-1234", true},
+		{"invalid replaced", "bad id\n", false},
+		{"too long replaced", strings.Repeat("x", maxRequestIDLen+1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+			if tt.incoming != "" {
+				req.Header.Set(requestIDHeader, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			got := w.Header().Get(requestIDHeader)
+			if got == "" {
+				t.Fatal("response has no request ID")
+			}
+			if (got == tt.incoming) != tt.wantSame {
+				t.Errorf("request ID = %q, incoming %q", got, tt.incoming)
+			}
+			if w.Body.String() != got {
+				t.Errorf("handler saw request ID %q, header %q", w.Body.String(), got)
+			}
+		})
+	}
+}
+
+func TestAccessLogSampling(t *testing.T) {
+	s, buf := newAccessLogTestServer(t, 0)
+	handler := s.Handler()
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", nil))
+	if buf.Len() != 0 {
+		t.Errorf("successful request logged at sample rate 0: %s", buf.String())
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+	req.Header.Set(requestIDHeader, "trace-me")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Header().Get(requestIDHeader) != "trace-me" {
+		t.Errorf("error response request ID = %q, want trace-me", w.Header().Get(requestIDHeader))
+	}
+	line := buf.String()
+	for _, want := range []string{"level=ERROR", "request_id=trace-me", "path=/fail", "status=500", "duration="} {
+		if !strings.Contains(line, want) {
+			t.Errorf("access log %q missing %q", line, want)
+		}
+	}
+}
+
+func TestStatusRecorder_PassesThroughFlush(t *testing.T) {
+	w := httptest.NewRecorder()
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	rec.WriteHeader(http.StatusAccepted)
+	rec.WriteHeader(http.StatusTeapot) // ignored, like net/http
+	_, _ = rec.Write([]byte("abc"))
+	rec.Flush()
+	if rec.status != http.StatusAccepted || rec.bytes != 3 || !w.Flushed {
+		t.Errorf("status=%d bytes=%d flushed=%v", rec.status, rec.bytes, w.Flushed)
+	}
+}
+
+func TestWithRequestID_StampsEvents(t *testing.T) {
+	pub := events.NewMemoryPublisher()
+	defer pub.Close()
+	ch := pub.Subscribe("TASK-001")
+
+	ctx := context.WithValue(context.Background(), requestIDContextKey{}, "req-1")
+	withRequestID(ctx, pub).Publish(events.NewEvent(events.EventTaskUpdated, "TASK-001", nil))
+	if e := <-ch; e.RequestID != "req-1" {
+		t.Errorf("event request ID = %q, want req-1", e.RequestID)
+	}
+
+	if got := withRequestID(context.Background(), pub); got != events.Publisher(pub) {
+		t.Error("publisher without a request ID should be returned unchanged")
+	}
+}
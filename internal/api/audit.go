@@ -0,0 +1,262 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+// auditActorHeader names the actor of a request no API or tenant token
+// identifies, e.g. the user behind the web UI.
+const auditActorHeader = "X-Orc-Actor"
+
+// auditConfigProcedures are the RPCs that change orc config; their audit
+// entries carry the changed keys before and after.
+var auditConfigProcedures = map[string]bool{
+	"/orc.v1.ConfigService/UpdateConfig": true,
+}
+
+// auditEnabled reports whether mutating requests are recorded in the audit
+// log (team.activity_logging).
+func (s *Server) auditEnabled() bool {
+	return s.orcConfig != nil && s.orcConfig.Team.ActivityLogging
+}
+
+// requestActor identifies who made a request: the tenant or API token that
+// authenticated it, else the X-Orc-Actor header, else "local" over the Unix
+// socket and "api" otherwise.
+func (s *Server) requestActor(ctx context.Context, header http.Header) string {
+	if scope := tenantFromContext(ctx); scope != nil {
+		return "tenant:" + scope.Tenant.Name
+	}
+	if secret, ok := strings.CutPrefix(header.Get("Authorization"), "Bearer "); ok && s.globalDB != nil && s.tokenAuthEnabled() {
+		if tok, err := s.globalDB.ResolveAPIToken(strings.TrimSpace(secret)); err == nil && tok != nil {
+			return "token:" + tok.Name
+		}
+	}
+	if actor := header.Get(auditActorHeader); validRequestID(actor) {
+		return actor
+	}
+	if isLocalSocket(ctx) {
+		return "local"
+	}
+	return "api"
+}
+
+// recordAudit saves an audit entry, logging failures: auditing never fails
+// the request it records.
+func (s *Server) recordAudit(backend storage.Backend, entry *db.AuditEntry) {
+	if backend == nil || backend.DB() == nil {
+		return
+	}
+	if err := backend.DB().SaveAuditEntry(entry); err != nil {
+		s.logger.Warn("failed to record audit entry", "action", entry.Action, "error", err)
+	}
+}
+
+// auditConfigSnapshot loads the effective config of the project at workDir
+// for diffing a config change, or nil if it cannot be loaded.
+func auditConfigSnapshot(workDir string) *config.Config {
+	cfg, err := config.LoadFrom(workDir)
+	if err != nil {
+		return nil
+	}
+	return cfg
+}
+
+// setConfigDiff sets the entry's before/after values from the config
+// snapshots taken around a config change.
+func setConfigDiff(entry *db.AuditEntry, before, after *config.Config) {
+	if before == nil || after == nil {
+		return
+	}
+	old, updated := config.DiffValues(before, after)
+	if len(old) > 0 {
+		entry.Before, entry.After = old, updated
+	}
+}
+
+// auditTaskIDFromPath returns the task ID of a /api/tasks/{id}/... path.
+func auditTaskIDFromPath(path string) string {
+	rest, ok := strings.CutPrefix(path, "/api/tasks/")
+	if !ok {
+		return ""
+	}
+	id, _, _ := strings.Cut(rest, "/")
+	return id
+}
+
+// auditMiddleware records mutating REST requests in the audit log. Connect
+// RPCs are recorded by auditInterceptor.
+func (s *Server) auditMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !strings.HasPrefix(r.URL.Path, "/api/") || !s.auditEnabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		backend, workDir, err := s.resolveProjectBackend(r)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		configChange := strings.HasPrefix(r.URL.Path, "/api/config")
+		var before *config.Config
+		if configChange {
+			before = auditConfigSnapshot(workDir)
+		}
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		entry := &db.AuditEntry{
+			Actor:     s.requestActor(r.Context(), r.Header),
+			Source:    db.AuditSourceAPI,
+			Action:    r.Method + " " + r.URL.Path,
+			TaskID:    auditTaskIDFromPath(r.URL.Path),
+			Status:    strconv.Itoa(rec.status),
+			RequestID: requestIDFromContext(r.Context()),
+			CreatedAt: time.Now(),
+		}
+		if entry.TaskID == "" {
+			entry.TaskID = r.URL.Query().Get("task_id")
+		}
+		if configChange && rec.status < http.StatusBadRequest {
+			setConfigDiff(entry, before, auditConfigSnapshot(workDir))
+		}
+		s.recordAudit(backend, entry)
+	})
+}
+
+// auditInterceptor records mutating Connect RPCs (as classified by
+// isReadOnlyProcedure) in the audit log of the project they target.
+type auditInterceptor struct {
+	s *Server
+}
+
+var _ connect.Interceptor = (*auditInterceptor)(nil)
+
+func (i *auditInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		procedure := req.Spec().Procedure
+		if !i.s.auditEnabled() || isReadOnlyProcedure(procedure) {
+			return next(ctx, req)
+		}
+		backend, workDir, err := i.s.auditProject(req.Any())
+		if err != nil {
+			return next(ctx, req)
+		}
+
+		configChange := auditConfigProcedures[procedure]
+		var before *config.Config
+		if configChange {
+			before = auditConfigSnapshot(workDir)
+		}
+		resp, err := next(ctx, req)
+
+		entry := &db.AuditEntry{
+			Actor:     i.s.requestActor(ctx, req.Header()),
+			Source:    db.AuditSourceRPC,
+			Action:    strings.TrimPrefix(procedure, "/"),
+			TaskID:    auditTaskIDFromMessage(req.Any()),
+			Status:    "ok",
+			RequestID: requestIDFromContext(ctx),
+			CreatedAt: time.Now(),
+		}
+		if err != nil {
+			entry.Status = connect.CodeOf(err).String()
+		} else if configChange {
+			setConfigDiff(entry, before, auditConfigSnapshot(workDir))
+		}
+		i.s.recordAudit(backend, entry)
+		return resp, err
+	}
+}
+
+func (i *auditInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *auditInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// auditProject resolves the backend and work dir of the project an RPC
+// message names, or the server's own project.
+func (s *Server) auditProject(msg any) (storage.Backend, string, error) {
+	m, ok := msg.(interface{ GetProjectId() string })
+	if !ok || m.GetProjectId() == "" || s.projectCache == nil {
+		return s.backend, s.workDir, nil
+	}
+	backend, err := s.projectCache.GetBackend(m.GetProjectId())
+	if err != nil {
+		return nil, "", err
+	}
+	workDir, err := s.projectCache.GetProjectPath(m.GetProjectId())
+	if err != nil {
+		return nil, "", err
+	}
+	return backend, workDir, nil
+}
+
+// auditTaskIDFromMessage returns the task_id of an RPC message, if it has
+// one.
+func auditTaskIDFromMessage(msg any) string {
+	if m, ok := msg.(interface{ GetTaskId() string }); ok {
+		return m.GetTaskId()
+	}
+	return ""
+}
+
+// handleListAudit returns audit log entries, newest first.
+// GET /api/audit?actor=&task_id=&since=&until=&after_id=&limit=
+// since and until are RFC 3339 times; after_id returns entries newer than
+// that ID, oldest first, for following the log.
+func (s *Server) handleListAudit(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	q := db.AuditQuery{Actor: query.Get("actor"), TaskID: query.Get("task_id")}
+	for name, dst := range map[string]*time.Time{"since": &q.Since, "until": &q.Until} {
+		if v := query.Get(name); v != "" {
+			if *dst, err = time.Parse(time.RFC3339, v); err != nil {
+				s.jsonError(w, name+" must be an RFC 3339 time", http.StatusBadRequest)
+				return
+			}
+		}
+	}
+	if v := query.Get("after_id"); v != "" {
+		if q.AfterID, err = strconv.ParseInt(v, 10, 64); err != nil || q.AfterID < 0 {
+			s.jsonError(w, "after_id must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := query.Get("limit"); v != "" {
+		if q.Limit, err = strconv.Atoi(v); err != nil || q.Limit < 1 {
+			s.jsonError(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	entries, err := backend.DB().QueryAuditLog(q)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.jsonResponse(w, entries)
+}
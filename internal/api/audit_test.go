@@ -0,0 +1,81 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+func TestAuditMiddleware(t *testing.T) {
+	cfg := config.Default()
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir(), backend: storage.NewTestBackend(t), orcConfig: cfg}
+	s.registerRESTRoutes()
+	h := s.Handler()
+	do := func(method, path, body, actor string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		if actor != "" {
+			r.Header.Set(auditActorHeader, actor)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	do(http.MethodPut, "/api/context-documents/architecture.md", `{"content": "v1"}`, "alice")
+	do(http.MethodGet, "/api/context-documents", "", "alice")
+	do(http.MethodDelete, "/api/context-documents/missing.md", "", "")
+
+	w := do(http.MethodGet, "/api/audit", "", "")
+	var entries []db.AuditEntry
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &entries) != nil {
+		t.Fatalf("GET /api/audit status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if len(entries) != 2 {
+		t.Fatalf("audit entries = %+v, want the PUT and DELETE only", entries)
+	}
+	if e := entries[1]; e.Actor != "alice" || e.Source != db.AuditSourceAPI || e.Action != "PUT /api/context-documents/architecture.md" || e.Status != "200" || e.RequestID == "" {
+		t.Errorf("PUT entry = %+v", e)
+	}
+	if e := entries[0]; e.Actor != "api" || e.Status != "404" {
+		t.Errorf("DELETE entry = %+v", e)
+	}
+
+	w = do(http.MethodGet, "/api/audit?actor=alice", "", "")
+	if json.Unmarshal(w.Body.Bytes(), &entries) != nil || len(entries) != 1 {
+		t.Errorf("actor filter = %s", w.Body.String())
+	}
+	if w = do(http.MethodGet, "/api/audit?since=yesterday", "", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid since status = %d, want 400", w.Code)
+	}
+
+	// team.activity_logging off records nothing
+	cfg.Team.ActivityLogging = false
+	do(http.MethodDelete, "/api/context-documents/architecture.md", "", "")
+	w = do(http.MethodGet, "/api/audit", "", "")
+	if json.Unmarshal(w.Body.Bytes(), &entries) != nil || len(entries) != 2 {
+		t.Errorf("entries with activity logging off = %s", w.Body.String())
+	}
+}
+
+func TestAuditTaskID(t *testing.T) {
+	if got := auditTaskIDFromPath("/api/tasks/TASK-007/run"); got != "TASK-007" {
+		t.Errorf("auditTaskIDFromPath() = %q, want TASK-007", got)
+	}
+	if got := auditTaskIDFromPath("/api/initiatives/INIT-001"); got != "" {
+		t.Errorf("auditTaskIDFromPath() = %q, want empty", got)
+	}
+	if got := auditTaskIDFromMessage(&orcv1.RunTaskRequest{TaskId: "TASK-004"}); got != "TASK-004" {
+		t.Errorf("auditTaskIDFromMessage() = %q, want TASK-004", got)
+	}
+	if got := auditTaskIDFromMessage(&orcv1.DeleteInitiativeRequest{InitiativeId: "INIT-001"}); got != "" {
+		t.Errorf("initiative RPC task = %q, want empty", got)
+	}
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+// Error codes for token auth failures, returned in APIError.Code.
+const (
+	authCodeRequired          = "AUTH_REQUIRED"
+	authCodeInvalidToken      = "AUTH_INVALID_TOKEN"
+	authCodeInsufficientScope = "AUTH_INSUFFICIENT_SCOPE"
+	authCodeUnavailable       = "AUTH_UNAVAILABLE"
+)
+
+// authPublicPaths are served without a token so load balancers and
+// orchestrators can probe the server. Subpaths are public too.
+var authPublicPaths = []string{"/api/health"}
+
+func isAuthPublicPath(path string) bool {
+	for _, p := range authPublicPaths {
+		if path == p || strings.HasPrefix(path, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// authError is a rejected request: the HTTP status, a stable code, and a
+// message for the client.
+type authError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *authError) Error() string { return e.message }
+
+// connectCode maps the rejection onto the Connect error code with the same
+// HTTP status.
+func (e *authError) connectCode() connect.Code {
+	switch e.status {
+	case http.StatusUnauthorized:
+		return connect.CodeUnauthenticated
+	case http.StatusForbidden:
+		return connect.CodePermissionDenied
+	}
+	return connect.CodeUnavailable
+}
+
+type localSocketContextKey struct{}
+
+// isLocalSocket reports whether the request came over the Unix socket,
+// which is only reachable by the local user and needs no token.
+func isLocalSocket(ctx context.Context) bool {
+	local, _ := ctx.Value(localSocketContextKey{}).(bool)
+	return local
+}
+
+// tokenAuthEnabled reports whether API token auth is enforced. In
+// multi-tenant mode tenant tokens already authenticate every request.
+func (s *Server) tokenAuthEnabled() bool {
+	return s.orcConfig != nil && s.orcConfig.Server.Auth.Enabled && !s.tenancyEnabled()
+}
+
+// requiresToken reports whether a request needs a token: mutating requests
+// always do, reads only with server.auth.require_for_reads.
+func (s *Server) requiresToken(mutating bool) bool {
+	return mutating || s.orcConfig.Server.Auth.RequireForReads
+}
+
+// checkAPIToken validates an Authorization header for a request.
+func (s *Server) checkAPIToken(header string, mutating bool) (*db.APIToken, *authError) {
+	secret, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || strings.TrimSpace(secret) == "" {
+		return nil, &authError{http.StatusUnauthorized, authCodeRequired, "API token required (Authorization: Bearer <token>)"}
+	}
+	if s.globalDB == nil {
+		return nil, &authError{http.StatusServiceUnavailable, authCodeUnavailable, "token auth requires the global database"}
+	}
+	tok, err := s.globalDB.ResolveAPIToken(strings.TrimSpace(secret))
+	if err != nil {
+		s.logger.Error("resolve API token", "error", err)
+		return nil, &authError{http.StatusServiceUnavailable, authCodeUnavailable, "cannot verify API token"}
+	}
+	if tok == nil {
+		return nil, &authError{http.StatusUnauthorized, authCodeInvalidToken, "invalid or revoked API token"}
+	}
+	if mutating && !tok.CanWrite() {
+		return nil, &authError{http.StatusForbidden, authCodeInsufficientScope, "API token " + tok.Name + " is read-only"}
+	}
+	return tok, nil
+}
+
+// tokenAuthMiddleware enforces API tokens on REST and file requests.
+// Connect RPCs all use POST and are classified by authInterceptor.
+func (s *Server) tokenAuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions || isAuthPublicPath(r.URL.Path) || isConnectPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		mutating := r.Method != http.MethodGet && r.Method != http.MethodHead
+		apiPath := strings.HasPrefix(r.URL.Path, "/api/") || strings.HasPrefix(r.URL.Path, "/files/")
+		if !s.requiresToken(mutating) || (!mutating && !apiPath) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if _, authErr := s.checkAPIToken(r.Header.Get("Authorization"), mutating); authErr != nil {
+			writeAuthError(w, authErr)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeAuthError(w http.ResponseWriter, e *authError) {
+	if e.status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="orc"`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.status)
+	_ = json.NewEncoder(w).Encode(APIError{Error: e.message, Code: e.code})
+}
+
+// authInterceptor enforces API tokens on Connect RPCs. Mutating RPCs are
+// those isReadOnlyProcedure does not recognize, as in read-only mode.
+type authInterceptor struct {
+	s *Server
+}
+
+var _ connect.Interceptor = (*authInterceptor)(nil)
+
+func (i *authInterceptor) check(ctx context.Context, procedure string, header http.Header) error {
+	if !i.s.tokenAuthEnabled() || isLocalSocket(ctx) {
+		return nil
+	}
+	mutating := !isReadOnlyProcedure(procedure)
+	if !i.s.requiresToken(mutating) {
+		return nil
+	}
+	if _, authErr := i.s.checkAPIToken(header.Get("Authorization"), mutating); authErr != nil {
+		err := connect.NewError(authErr.connectCode(), errors.New(authErr.message))
+		err.Meta().Set("Orc-Error-Code", authErr.code)
+		return err
+	}
+	return nil
+}
+
+func (i *authInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if err := i.check(ctx, req.Spec().Procedure, req.Header()); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *authInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *authInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := i.check(ctx, conn.Spec().Procedure, conn.RequestHeader()); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+// newAuthTestServer returns a server with token auth enabled, a write token
+// and a read-only token, and stub routes under /api/test.
+func newAuthTestServer(t *testing.T) (s *Server, writeToken, readToken string) {
+	t.Helper()
+
+	globalDB, err := db.OpenGlobalAt(filepath.Join(t.TempDir(), "orc.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = globalDB.Close() })
+	if writeToken, err = globalDB.CreateAPIToken("ci", db.APITokenScopeWrite); err != nil {
+		t.Fatal(err)
+	}
+	if readToken, err = globalDB.CreateAPIToken("dashboard", db.APITokenScopeRead); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default()
+	cfg.Server.Auth.Enabled = true
+	s = &Server{
+		mux:       http.NewServeMux(),
+		logger:    slog.Default(),
+		orcConfig: cfg,
+		globalDB:  globalDB,
+	}
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+	s.mux.HandleFunc("GET /api/health", ok)
+	s.mux.HandleFunc("GET /api/test", ok)
+	s.mux.HandleFunc("POST /api/test", ok)
+	s.mux.HandleFunc("GET /", ok)
+	return s, writeToken, readToken
+}
+
+func TestTokenAuthMiddleware(t *testing.T) {
+	s, writeToken, readToken := newAuthTestServer(t)
+
+	tests := []struct {
+		name            string
+		method, path    string
+		token           string
+		requireForReads bool
+		wantCode        int
+		wantErrCode     string
+	}{
+		{"read without token", http.MethodGet, "/api/test", "", false, http.StatusOK, ""},
+		{"mutation without token", http.MethodPost, "/api/test", "", false, http.StatusUnauthorized, authCodeRequired},
+		{"mutation with unknown token", http.MethodPost, "/api/test", "orca_nope", false, http.StatusUnauthorized, authCodeInvalidToken},
+		{"mutation with read token", http.MethodPost, "/api/test", readToken, false, http.StatusForbidden, authCodeInsufficientScope},
+		{"mutation with write token", http.MethodPost, "/api/test", writeToken, false, http.StatusOK, ""},
+		{"read requires token", http.MethodGet, "/api/test", "", true, http.StatusUnauthorized, authCodeRequired},
+		{"read with read token", http.MethodGet, "/api/test", readToken, true, http.StatusOK, ""},
+		{"health is public", http.MethodGet, "/api/health", "", true, http.StatusOK, ""},
+		{"static UI is public", http.MethodGet, "/index.html", "", true, http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.orcConfig.Server.Auth.RequireForReads = tt.requireForReads
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			s.Handler().ServeHTTP(w, req)
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantCode, w.Body.String())
+			}
+			if tt.wantErrCode == "" {
+				return
+			}
+			var apiErr APIError
+			if err := json.Unmarshal(w.Body.Bytes(), &apiErr); err != nil || apiErr.Code != tt.wantErrCode {
+				t.Errorf("body = %s, want code %s", w.Body.String(), tt.wantErrCode)
+			}
+			if tt.wantCode == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+				t.Error("401 without WWW-Authenticate header")
+			}
+		})
+	}
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	s, writeToken, readToken := newAuthTestServer(t)
+	i := &authInterceptor{s: s}
+	header := func(token string) http.Header {
+		h := http.Header{}
+		if token != "" {
+			h.Set("Authorization", "Bearer "+token)
+		}
+		return h
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		procedure string
+		token     string
+		wantCode  connect.Code
+	}{
+		{"read RPC without token", ctx, "/orc.v1.TaskService/ListTasks", "", 0},
+		{"mutating RPC without token", ctx, "/orc.v1.TaskService/DeleteTask", "", connect.CodeUnauthenticated},
+		{"mutating RPC with read token", ctx, "/orc.v1.TaskService/DeleteTask", readToken, connect.CodePermissionDenied},
+		{"mutating RPC with write token", ctx, "/orc.v1.TaskService/DeleteTask", writeToken, 0},
+		{"local socket", context.WithValue(ctx, localSocketContextKey{}, true), "/orc.v1.TaskService/DeleteTask", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := i.check(tt.ctx, tt.procedure, header(tt.token))
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Fatalf("check() = %v, want nil", err)
+				}
+				return
+			}
+			if connect.CodeOf(err) != tt.wantCode {
+				t.Errorf("check() = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+
+	// Multi-tenant mode authenticates with tenant tokens instead.
+	s.orcConfig.Server.Tenancy.Enabled = true
+	if err := i.check(ctx, "/orc.v1.TaskService/DeleteTask", header("")); err != nil {
+		t.Errorf("check() with tenancy = %v, want nil", err)
+	}
+}
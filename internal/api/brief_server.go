@@ -85,7 +85,7 @@ func (s *briefServer) generateBrief(ctx context.Context, backend storage.Backend
 }
 
 func (s *briefServer) generateBriefWithOptions(ctx context.Context, backend storage.Backend, invalidateCache bool) (*brief.Brief, error) {
-	dbBackend, ok := backend.(*storage.DatabaseBackend)
+	dbBackend, ok := storage.DatabaseOf(backend)
 	if !ok {
 		return &brief.Brief{}, nil
 	}
@@ -0,0 +1,184 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// taskBudgetResponse is a task's budget and spend, and its initiative's when
+// the task belongs to one. A budget of 0 means none is set.
+type taskBudgetResponse struct {
+	TaskID              string  `json:"task_id"`
+	Status              string  `json:"status"`
+	BudgetUSD           float64 `json:"budget_usd"`
+	SpentUSD            float64 `json:"spent_usd"`
+	InitiativeID        string  `json:"initiative_id,omitempty"`
+	InitiativeBudgetUSD float64 `json:"initiative_budget_usd,omitempty"`
+	InitiativeSpentUSD  float64 `json:"initiative_spent_usd,omitempty"`
+	OverBudget          bool    `json:"over_budget"`
+	Resumed             bool    `json:"resumed,omitempty"`
+}
+
+// updateTaskBudgetRequest changes a task's budget and optionally its
+// initiative's. Omitted budgets are left as they are; 0 removes one.
+type updateTaskBudgetRequest struct {
+	BudgetUSD           *float64 `json:"budget_usd"`
+	InitiativeBudgetUSD *float64 `json:"initiative_budget_usd"`
+	// Resume restarts a paused task once it is within its budgets (default true).
+	Resume *bool `json:"resume"`
+}
+
+// handleGetTaskBudget returns a task's budget and spend.
+// GET /api/tasks/{id}/budget
+func (s *Server) handleGetTaskBudget(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	t, err := backend.LoadTask(r.PathValue("id"))
+	if err != nil {
+		s.jsonError(w, "task not found", http.StatusNotFound)
+		return
+	}
+	resp, err := taskBudgetStatus(backend, t)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.jsonResponse(w, resp)
+}
+
+// handleUpdateTaskBudget sets a task's budget (and its initiative's), then
+// resumes the task if it is paused and now within its budgets.
+// POST /api/tasks/{id}/budget
+func (s *Server) handleUpdateTaskBudget(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req updateTaskBudgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if (req.BudgetUSD != nil && *req.BudgetUSD < 0) || (req.InitiativeBudgetUSD != nil && *req.InitiativeBudgetUSD < 0) {
+		s.jsonError(w, "budgets must be non-negative amounts in USD", http.StatusBadRequest)
+		return
+	}
+
+	t, err := backend.LoadTask(r.PathValue("id"))
+	if err != nil {
+		s.jsonError(w, "task not found", http.StatusNotFound)
+		return
+	}
+	if req.InitiativeBudgetUSD != nil && t.GetInitiativeId() == "" {
+		s.jsonError(w, fmt.Sprintf("task %s is not part of an initiative", t.Id), http.StatusBadRequest)
+		return
+	}
+
+	if req.BudgetUSD != nil {
+		task.SetBudgetUSD(t, *req.BudgetUSD)
+		task.UpdateTimestampProto(t)
+		if err := backend.SaveTask(t); err != nil {
+			s.jsonError(w, fmt.Sprintf("save task: %v", err), http.StatusInternalServerError)
+			return
+		}
+		publishTaskUpdatedEvent(s.publisher, r.URL.Query().Get("project_id"), t)
+	}
+	if req.InitiativeBudgetUSD != nil {
+		if err := backend.DB().SetInitiativeBudget(t.GetInitiativeId(), *req.InitiativeBudgetUSD); err != nil {
+			s.jsonError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	resp, err := taskBudgetStatus(backend, t)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	resume := req.Resume == nil || *req.Resume
+	if resume && !resp.OverBudget && t.Status == orcv1.TaskStatus_TASK_STATUS_PAUSED {
+		if _, err := s.resumeTask(t.Id, r.URL.Query().Get("project_id")); err != nil {
+			s.jsonError(w, fmt.Sprintf("budget updated, but resume failed: %v", err), http.StatusInternalServerError)
+			return
+		}
+		resp.Resumed = true
+		resp.Status = orcv1.TaskStatus_TASK_STATUS_RUNNING.String()
+	}
+	s.jsonResponse(w, resp)
+}
+
+// handleSetInitiativeBudget sets the budget shared by an initiative's tasks.
+// PUT /api/initiatives/{id}/budget
+func (s *Server) handleSetInitiativeBudget(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req struct {
+		BudgetUSD float64 `json:"budget_usd"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.BudgetUSD < 0 {
+		s.jsonError(w, "budget_usd must be a non-negative amount in USD", http.StatusBadRequest)
+		return
+	}
+
+	id := r.PathValue("id")
+	pdb := backend.DB()
+	if init, err := pdb.GetInitiative(id); err != nil || init == nil {
+		s.jsonError(w, "initiative not found", http.StatusNotFound)
+		return
+	}
+	if err := pdb.SetInitiativeBudget(id, req.BudgetUSD); err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	spent, err := pdb.GetInitiativeCost(id)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.jsonResponse(w, map[string]any{
+		"initiative_id": id,
+		"budget_usd":    req.BudgetUSD,
+		"spent_usd":     spent,
+	})
+}
+
+// taskBudgetStatus reports t's budgets and spend, matching the executor's
+// check: a budget is exceeded once spend goes over it.
+func taskBudgetStatus(backend storage.Backend, t *orcv1.Task) (*taskBudgetResponse, error) {
+	resp := &taskBudgetResponse{
+		TaskID:    t.Id,
+		Status:    t.Status.String(),
+		BudgetUSD: task.GetBudgetUSD(t),
+		SpentUSD:  t.GetExecution().GetCost().GetTotalCostUsd(),
+	}
+	resp.OverBudget = resp.BudgetUSD > 0 && resp.SpentUSD > resp.BudgetUSD
+
+	if id := t.GetInitiativeId(); id != "" {
+		pdb := backend.DB()
+		limit, err := pdb.GetInitiativeBudget(id)
+		if err != nil {
+			return nil, err
+		}
+		spent, err := pdb.GetInitiativeCost(id)
+		if err != nil {
+			return nil, err
+		}
+		resp.InitiativeID = id
+		resp.InitiativeBudgetUSD = limit
+		resp.InitiativeSpentUSD = spent
+		resp.OverBudget = resp.OverBudget || (limit > 0 && spent > limit)
+	}
+	return resp, nil
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestHandleUpdateTaskBudget_RaisesAndResumes(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	if err := backend.DB().SaveInitiative(&db.Initiative{ID: "INIT-001", Title: "Budgeted", Status: "active"}); err != nil {
+		t.Fatal(err)
+	}
+	paused := task.NewProtoTask("TASK-001", "Over budget")
+	paused.Status = orcv1.TaskStatus_TASK_STATUS_PAUSED
+	paused.CurrentPhase = stringPtr("implement")
+	paused.InitiativeId = stringPtr("INIT-001")
+	task.SetBudgetUSD(paused, 2)
+	task.AddCostProto(paused.Execution, "implement", 2.5)
+	if err := backend.SaveTask(paused); err != nil {
+		t.Fatal(err)
+	}
+
+	var resumed []string
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir(), backend: backend}
+	s.SetTaskExecutor(func(_ context.Context, taskID, _ string) error {
+		resumed = append(resumed, taskID)
+		return nil
+	})
+	s.registerRESTRoutes()
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/tasks/TASK-001/budget", nil))
+	var resp taskBudgetResponse
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &resp) != nil {
+		t.Fatalf("GET status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if !resp.OverBudget || resp.BudgetUSD != 2 || resp.SpentUSD != 2.5 || resp.InitiativeID != "INIT-001" {
+		t.Errorf("GET response = %+v", resp)
+	}
+
+	// Raising the task budget while the initiative stays over its own keeps the task paused
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/tasks/TASK-001/budget",
+		strings.NewReader(`{"budget_usd": 10, "initiative_budget_usd": 1}`)))
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &resp) != nil {
+		t.Fatalf("POST status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if !resp.OverBudget || resp.Resumed || len(resumed) != 0 {
+		t.Errorf("still over the initiative budget: response = %+v, resumed = %v", resp, resumed)
+	}
+
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/tasks/TASK-001/budget",
+		strings.NewReader(`{"initiative_budget_usd": 0}`)))
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &resp) != nil {
+		t.Fatalf("POST status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if resp.OverBudget || !resp.Resumed || len(resumed) != 1 || resp.BudgetUSD != 10 {
+		t.Errorf("within budget: response = %+v, resumed = %v", resp, resumed)
+	}
+
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/tasks/TASK-001/budget", strings.NewReader(`{"budget_usd": -1}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("negative budget: status = %d, want 400", w.Code)
+	}
+}
+
+func TestHandleSetInitiativeBudget(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	if err := backend.DB().SaveInitiative(&db.Initiative{ID: "INIT-001", Title: "Budgeted", Status: "active"}); err != nil {
+		t.Fatal(err)
+	}
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir(), backend: backend}
+	s.registerRESTRoutes()
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/initiatives/INIT-001/budget", strings.NewReader(`{"budget_usd": 50}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if limit, err := backend.DB().GetInitiativeBudget("INIT-001"); err != nil || limit != 50 {
+		t.Errorf("stored budget = %v, %v; want 50", limit, err)
+	}
+
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/initiatives/INIT-404/budget", strings.NewReader(`{"budget_usd": 5}`)))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown initiative: status = %d, want 404", w.Code)
+	}
+}
+
+func TestUpdateTask_Budget(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewTestBackend(t)
+	if err := backend.SaveTask(task.NewProtoTask("TASK-001", "Budgeted")); err != nil {
+		t.Fatal(err)
+	}
+	server := NewTaskServer(backend, nil, nil, nil, "", nil, nil)
+
+	if _, err := server.UpdateTask(context.Background(), connect.NewRequest(&orcv1.UpdateTaskRequest{
+		TaskId:   "TASK-001",
+		Metadata: map[string]string{task.BudgetMetadataKey: "12.50", "other": "ignored"},
+	})); err != nil {
+		t.Fatalf("UpdateTask: %v", err)
+	}
+	loaded, err := backend.LoadTask("TASK-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := task.GetBudgetUSD(loaded); got != 12.5 {
+		t.Errorf("budget = %v, want 12.5", got)
+	}
+	if _, ok := loaded.Metadata["other"]; ok {
+		t.Error("UpdateTask applied a metadata key other than the budget")
+	}
+
+	_, err = server.UpdateTask(context.Background(), connect.NewRequest(&orcv1.UpdateTaskRequest{
+		TaskId:   "TASK-001",
+		Metadata: map[string]string{task.BudgetMetadataKey: "lots"},
+	}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("invalid budget: err = %v, want invalid argument", err)
+	}
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// maxBulkTasksBody bounds the POST /api/tasks/bulk request body.
+const maxBulkTasksBody = 4 << 20
+
+// bulkCreateTasksRequest is the POST /api/tasks/bulk body. A bare JSON
+// array of task specs is accepted as well.
+type bulkCreateTasksRequest struct {
+	Tasks []task.BulkTaskSpec `json:"tasks"`
+}
+
+// bulkCreatedTask reports one created task and the ref it was created for.
+type bulkCreatedTask struct {
+	Ref       string   `json:"ref,omitempty"`
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	BlockedBy []string `json:"blocked_by,omitempty"`
+	RelatedTo []string `json:"related_to,omitempty"`
+}
+
+// bulkCreateTasksResponse lists the created tasks in request order.
+type bulkCreateTasksResponse struct {
+	Tasks []bulkCreatedTask `json:"tasks"`
+}
+
+// handleBulkCreateTasks creates several tasks at once. blocked_by and
+// related_to may reference other tasks in the same request by ref; the
+// whole request is validated (including for dependency cycles) before any
+// task is created, and the tasks are saved in one transaction.
+// POST /api/tasks/bulk
+func (s *Server) handleBulkCreateTasks(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBulkTasksBody+1))
+	if err != nil {
+		s.jsonError(w, "read request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxBulkTasksBody {
+		s.jsonError(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	specs, err := decodeBulkTaskSpecs(body)
+	if err != nil {
+		s.jsonError(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tasks, err := storage.CreateBulkTasks(backend, specs)
+	if errors.Is(err, storage.ErrInvalidBulkTasks) {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if s.publisher != nil {
+		for _, t := range tasks {
+			s.publisher.Publish(events.NewEvent(events.EventTaskCreated, t.Id, t))
+		}
+	}
+
+	resp := bulkCreateTasksResponse{Tasks: make([]bulkCreatedTask, len(tasks))}
+	for i, t := range tasks {
+		resp.Tasks[i] = bulkCreatedTask{
+			Ref:       specs[i].Ref,
+			ID:        t.Id,
+			Title:     t.Title,
+			BlockedBy: t.BlockedBy,
+			RelatedTo: t.RelatedTo,
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	s.jsonResponse(w, resp)
+}
+
+// decodeBulkTaskSpecs accepts {"tasks": [...]} or a bare array.
+func decodeBulkTaskSpecs(body []byte) ([]task.BulkTaskSpec, error) {
+	dec := func(v any) error {
+		d := json.NewDecoder(bytes.NewReader(body))
+		d.DisallowUnknownFields()
+		return d.Decode(v)
+	}
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var specs []task.BulkTaskSpec
+		return specs, dec(&specs)
+	}
+	var req bulkCreateTasksRequest
+	return req.Tasks, dec(&req)
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func newBulkTaskTestServer(t *testing.T) (*Server, storage.Backend) {
+	t.Helper()
+	backend := storage.NewTestBackend(t)
+	if err := backend.SaveTask(task.NewProtoTask("TASK-001", "Existing")); err != nil {
+		t.Fatal(err)
+	}
+	pub := events.NewMemoryPublisher()
+	t.Cleanup(pub.Close)
+	s := &Server{
+		mux:       http.NewServeMux(),
+		logger:    slog.Default(),
+		workDir:   t.TempDir(),
+		backend:   backend,
+		publisher: pub,
+	}
+	s.registerRESTRoutes()
+	return s, backend
+}
+
+func postBulkTasks(s *Server, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/tasks/bulk", strings.NewReader(body)))
+	return w
+}
+
+func TestBulkCreateTasks(t *testing.T) {
+	s, backend := newBulkTaskTestServer(t)
+
+	w := postBulkTasks(s, `{"tasks": [
+		{"ref": "schema", "title": "Add table", "category": "feature"},
+		{"ref": "api", "title": "Endpoints", "blocked_by": ["schema", "TASK-001"]},
+		{"title": "Page", "priority": "high", "blocked_by": ["api"], "related_to": ["schema"]}
+	]}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	var resp bulkCreateTasksResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Tasks) != 3 {
+		t.Fatalf("tasks = %+v", resp.Tasks)
+	}
+	schema, api, page := resp.Tasks[0], resp.Tasks[1], resp.Tasks[2]
+	if schema.Ref != "schema" || api.Ref != "api" || page.Ref != "" {
+		t.Errorf("refs = %q %q %q", schema.Ref, api.Ref, page.Ref)
+	}
+	if strings.Join(api.BlockedBy, ",") != schema.ID+",TASK-001" {
+		t.Errorf("api blocked_by = %v", api.BlockedBy)
+	}
+
+	saved, err := backend.LoadTask(page.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(saved.BlockedBy, ",") != api.ID {
+		t.Errorf("saved page blocked_by = %v", saved.BlockedBy)
+	}
+	if strings.Join(page.RelatedTo, ",") != schema.ID {
+		t.Errorf("page related_to = %v", page.RelatedTo)
+	}
+	if saved.Priority.String() != "TASK_PRIORITY_HIGH" {
+		t.Errorf("saved priority = %s", saved.Priority)
+	}
+}
+
+func TestBulkCreateTasks_BareArray(t *testing.T) {
+	s, _ := newBulkTaskTestServer(t)
+	w := postBulkTasks(s, `[{"title": "One"}, {"title": "Two"}]`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+}
+
+func TestBulkCreateTasks_RejectsWithoutCreating(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"cycle", `{"tasks": [
+			{"ref": "a", "title": "A", "blocked_by": ["b"]},
+			{"ref": "b", "title": "B", "blocked_by": ["a"]}
+		]}`, "dependency cycle"},
+		{"unknown ref", `{"tasks": [{"title": "A", "blocked_by": ["nope"]}]}`, "nope"},
+		{"unknown initiative", `{"tasks": [{"title": "A"}, {"title": "B", "initiative_id": "INIT-404"}]}`, "INIT-404 not found"},
+		{"bad branch", `{"tasks": [{"title": "A", "target_branch": "a..b"}]}`, "target_branch"},
+		{"unknown field", `{"tasks": [{"title": "A", "blockers": ["TASK-001"]}]}`, "unknown field"},
+		{"empty", `{"tasks": []}`, "no tasks"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, backend := newBulkTaskTestServer(t)
+			w := postBulkTasks(s, tt.body)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400; body = %s", w.Code, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.wantErr) {
+				t.Errorf("body = %s, want %q", w.Body.String(), tt.wantErr)
+			}
+			all, err := backend.LoadAllTasks()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(all) != 1 {
+				t.Errorf("tasks after rejected request = %d, want only the existing one", len(all))
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
 	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/skills"
 )
 
 // resolveDestinationDir returns the base directory for the given scope.
@@ -324,7 +325,8 @@ func (s *configServer) scanSkills(baseDir string) ([]*orcv1.DiscoveredItem, erro
 		subEntries, err := os.ReadDir(skillDir)
 		if err == nil {
 			for _, sub := range subEntries {
-				if sub.IsDir() || sub.Name() == "SKILL.md" {
+				// Provenance metadata from `orc skills install` is not part of the skill
+				if sub.IsDir() || sub.Name() == "SKILL.md" || sub.Name() == skills.ProvenanceFile {
 					continue
 				}
 				subContent, err := os.ReadFile(filepath.Join(skillDir, sub.Name()))
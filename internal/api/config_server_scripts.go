@@ -0,0 +1,184 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/claude"
+)
+
+// scriptService returns the script registry (.claude/settings.json) for a project.
+func (s *configServer) scriptService(projectID string) (*claude.ScriptService, error) {
+	workDir, err := s.getWorkDir(projectID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return claude.NewScriptService(workDir), nil
+}
+
+// ListScripts returns the project's registered scripts.
+func (s *configServer) ListScripts(
+	ctx context.Context,
+	req *connect.Request[orcv1.ListScriptsRequest],
+) (*connect.Response[orcv1.ListScriptsResponse], error) {
+	svc, err := s.scriptService(req.Msg.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	scripts, err := svc.List()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("list scripts: %w", err))
+	}
+	return connect.NewResponse(&orcv1.ListScriptsResponse{Scripts: projectScriptsToProto(scripts)}), nil
+}
+
+// DiscoverScripts scans .claude/scripts/ and registers scripts that are not
+// registered yet. Existing registrations are left untouched.
+func (s *configServer) DiscoverScripts(
+	ctx context.Context,
+	req *connect.Request[orcv1.DiscoverScriptsRequest],
+) (*connect.Response[orcv1.DiscoverScriptsResponse], error) {
+	svc, err := s.scriptService(req.Msg.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	discovered, err := svc.Discover()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("discover scripts: %w", err))
+	}
+	for _, script := range discovered {
+		if svc.Exists(script.Name) {
+			continue
+		}
+		if err := svc.Create(script); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("register script %s: %w", script.Name, err))
+		}
+	}
+	return connect.NewResponse(&orcv1.DiscoverScriptsResponse{Scripts: projectScriptsToProto(discovered)}), nil
+}
+
+// GetScript returns a registered script by name.
+func (s *configServer) GetScript(
+	ctx context.Context,
+	req *connect.Request[orcv1.GetScriptRequest],
+) (*connect.Response[orcv1.GetScriptResponse], error) {
+	svc, err := s.scriptService(req.Msg.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	script, err := svc.Get(req.Msg.Name)
+	if err != nil {
+		return nil, scriptError(err)
+	}
+	return connect.NewResponse(&orcv1.GetScriptResponse{Script: projectScriptToProto(script)}), nil
+}
+
+// CreateScript registers a project script.
+func (s *configServer) CreateScript(
+	ctx context.Context,
+	req *connect.Request[orcv1.CreateScriptRequest],
+) (*connect.Response[orcv1.CreateScriptResponse], error) {
+	svc, err := s.scriptService(req.Msg.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	script := claude.ProjectScript{
+		Name:        req.Msg.Name,
+		Path:        req.Msg.Path,
+		Description: req.Msg.Description,
+		Language:    req.Msg.GetLanguage(),
+	}
+	if err := svc.Create(script); err != nil {
+		return nil, scriptError(err)
+	}
+	created, err := svc.Get(script.Name)
+	if err != nil {
+		return nil, scriptError(err)
+	}
+	return connect.NewResponse(&orcv1.CreateScriptResponse{Script: projectScriptToProto(created)}), nil
+}
+
+// UpdateScript changes the path, description or language of a registered script.
+func (s *configServer) UpdateScript(
+	ctx context.Context,
+	req *connect.Request[orcv1.UpdateScriptRequest],
+) (*connect.Response[orcv1.UpdateScriptResponse], error) {
+	svc, err := s.scriptService(req.Msg.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	script, err := svc.Get(req.Msg.Name)
+	if err != nil {
+		return nil, scriptError(err)
+	}
+	if req.Msg.Path != nil {
+		script.Path = *req.Msg.Path
+	}
+	if req.Msg.Description != nil {
+		script.Description = *req.Msg.Description
+	}
+	if req.Msg.Language != nil {
+		script.Language = *req.Msg.Language
+	}
+	if err := svc.Update(req.Msg.Name, *script); err != nil {
+		return nil, scriptError(err)
+	}
+	return connect.NewResponse(&orcv1.UpdateScriptResponse{Script: projectScriptToProto(script)}), nil
+}
+
+// DeleteScript removes a script registration. The script file is kept.
+func (s *configServer) DeleteScript(
+	ctx context.Context,
+	req *connect.Request[orcv1.DeleteScriptRequest],
+) (*connect.Response[orcv1.DeleteScriptResponse], error) {
+	svc, err := s.scriptService(req.Msg.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.Delete(req.Msg.Name); err != nil {
+		return nil, scriptError(err)
+	}
+	return connect.NewResponse(&orcv1.DeleteScriptResponse{
+		Message: fmt.Sprintf("script %s removed", req.Msg.Name),
+	}), nil
+}
+
+// scriptError maps ScriptService errors to Connect codes.
+func scriptError(err error) error {
+	switch {
+	case errors.Is(err, claude.ErrScriptNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, claude.ErrScriptAlreadyExists):
+		return connect.NewError(connect.CodeAlreadyExists, err)
+	case errors.Is(err, claude.ErrScriptNameRequired),
+		errors.Is(err, claude.ErrScriptPathRequired),
+		errors.Is(err, claude.ErrScriptDescriptionRequired):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
+
+func projectScriptToProto(s *claude.ProjectScript) *orcv1.Script {
+	script := &orcv1.Script{
+		Name:        s.Name,
+		Path:        s.Path,
+		Description: s.Description,
+	}
+	if s.Language != "" {
+		script.Language = &s.Language
+	}
+	return script
+}
+
+func projectScriptsToProto(scripts []claude.ProjectScript) []*orcv1.Script {
+	result := make([]*orcv1.Script, len(scripts))
+	for i := range scripts {
+		result[i] = projectScriptToProto(&scripts[i])
+	}
+	return result
+}
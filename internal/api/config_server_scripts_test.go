@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/claude"
+)
+
+func TestScriptRPCs_CRUD(t *testing.T) {
+	t.Parallel()
+	workDir := t.TempDir()
+	server := NewConfigServer(nil, nil, workDir, slog.Default())
+	ctx := context.Background()
+
+	lang := "bash"
+	created, err := server.CreateScript(ctx, connect.NewRequest(&orcv1.CreateScriptRequest{
+		Name: "reset-db", Path: "scripts/reset-db.sh", Description: "Recreate the dev database", Language: &lang,
+	}))
+	if err != nil {
+		t.Fatalf("CreateScript: %v", err)
+	}
+	if created.Msg.Script.GetLanguage() != "bash" {
+		t.Errorf("created = %+v", created.Msg.Script)
+	}
+	if _, err := server.CreateScript(ctx, connect.NewRequest(&orcv1.CreateScriptRequest{
+		Name: "reset-db", Path: "x.sh", Description: "dup",
+	})); connect.CodeOf(err) != connect.CodeAlreadyExists {
+		t.Errorf("duplicate create err = %v, want AlreadyExists", err)
+	}
+
+	// The run endpoint and SCRIPTS_LIST read the same registry
+	if _, err := claude.NewScriptService(workDir).Get("reset-db"); err != nil {
+		t.Errorf("script not in .claude/settings.json: %v", err)
+	}
+
+	desc := "Drop and reseed the dev database"
+	updated, err := server.UpdateScript(ctx, connect.NewRequest(&orcv1.UpdateScriptRequest{Name: "reset-db", Description: &desc}))
+	if err != nil {
+		t.Fatalf("UpdateScript: %v", err)
+	}
+	if updated.Msg.Script.Description != desc || updated.Msg.Script.Path != "scripts/reset-db.sh" {
+		t.Errorf("updated = %+v", updated.Msg.Script)
+	}
+
+	list, err := server.ListScripts(ctx, connect.NewRequest(&orcv1.ListScriptsRequest{}))
+	if err != nil || len(list.Msg.Scripts) != 1 {
+		t.Fatalf("ListScripts = %+v, %v", list, err)
+	}
+
+	if _, err := server.DeleteScript(ctx, connect.NewRequest(&orcv1.DeleteScriptRequest{Name: "reset-db"})); err != nil {
+		t.Fatalf("DeleteScript: %v", err)
+	}
+	if _, err := server.GetScript(ctx, connect.NewRequest(&orcv1.GetScriptRequest{Name: "reset-db"})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("get after delete err = %v, want NotFound", err)
+	}
+}
+
+func TestDiscoverScripts_RegistersNewScripts(t *testing.T) {
+	t.Parallel()
+	workDir := t.TempDir()
+	dir := filepath.Join(workDir, ".claude", "scripts")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "lint.sh"), []byte("#!/bin/sh\n# Run linters\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	server := NewConfigServer(nil, nil, workDir, slog.Default())
+
+	resp, err := server.DiscoverScripts(context.Background(), connect.NewRequest(&orcv1.DiscoverScriptsRequest{}))
+	if err != nil {
+		t.Fatalf("DiscoverScripts: %v", err)
+	}
+	if len(resp.Msg.Scripts) != 1 || resp.Msg.Scripts[0].Name != "lint" {
+		t.Fatalf("discovered = %+v", resp.Msg.Scripts)
+	}
+	list, err := server.ListScripts(context.Background(), connect.NewRequest(&orcv1.ListScriptsRequest{}))
+	if err != nil || len(list.Msg.Scripts) != 1 {
+		t.Errorf("after discover, ListScripts = %+v, %v", list, err)
+	}
+}
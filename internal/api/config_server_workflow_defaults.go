@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"connectrpc.com/connect"
@@ -44,7 +45,13 @@ func (s *configServer) UpdateWorkflowDefaults(
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("workflow_defaults is required"))
 	}
 
-	cfg, err := s.loadConfigForProject(req.Msg.ProjectId)
+	// Edit the project's own config file so settings merged in from the
+	// user or shared config are not copied into it.
+	configPath, err := s.getConfigPath(req.Msg.ProjectId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	cfg, err := config.LoadFile(configPath)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("load config: %w", err))
 	}
@@ -72,10 +79,15 @@ func (s *configServer) UpdateWorkflowDefaults(
 		cfg.WorkflowDefaults.Default = protoDefaults.Default
 	}
 
-	configPath := s.getConfigPath(req.Msg.ProjectId)
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("create config dir: %w", err))
+	}
 	if err := cfg.SaveTo(configPath); err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("save config: %w", err))
 	}
+	if req.Msg.ProjectId == "" && s.orcConfig != nil {
+		s.orcConfig.WorkflowDefaults = cfg.WorkflowDefaults
+	}
 
 	return connect.NewResponse(&orcv1.UpdateWorkflowDefaultsResponse{
 		WorkflowDefaults: &orcv1.WorkflowDefaults{
@@ -90,19 +102,27 @@ func (s *configServer) UpdateWorkflowDefaults(
 	}), nil
 }
 
-// loadConfigForProject loads configuration for a specific project, or returns server config if projectID is empty.
-// Project-specific config loading is not yet implemented; all callers receive the server-wide config.
-func (s *configServer) loadConfigForProject(_ string) (*config.Config, error) {
-	return s.orcConfig, nil
+// loadConfigForProject loads the merged configuration of a project's
+// directory, resolved through the project registry or the server's workDir.
+func (s *configServer) loadConfigForProject(projectID string) (*config.Config, error) {
+	workDir, err := s.getWorkDir(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if workDir == "" {
+		return nil, errors.New("no project directory configured")
+	}
+	return config.LoadFrom(workDir)
 }
 
-// getConfigPath returns the config file path for a project, using the server's work directory.
-func (s *configServer) getConfigPath(projectID string) string {
-	if s.workDir != "" {
-		return filepath.Join(s.workDir, config.OrcDir, config.ConfigFileName)
+// getConfigPath returns the path of a project's .orc/config.yaml.
+func (s *configServer) getConfigPath(projectID string) (string, error) {
+	workDir, err := s.getWorkDir(projectID)
+	if err != nil {
+		return "", err
 	}
-
-	// For project-specific configs, we'd construct the path differently
-	// For now, return the default path
-	return filepath.Join(config.OrcDir, config.ConfigFileName)
+	if workDir == "" {
+		return "", errors.New("no project directory configured")
+	}
+	return filepath.Join(workDir, config.OrcDir, config.ConfigFileName), nil
 }
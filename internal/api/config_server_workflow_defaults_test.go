@@ -277,9 +277,15 @@ func TestConfigServer_GetWorkflowDefaults_ProjectScope(t *testing.T) {
 		t.Fatalf("Failed to save project config: %v", err)
 	}
 
-	// Create server with project cache for multi-project support
+	// Create server rooted elsewhere, with the project reachable only
+	// through the project cache
 	backend := storage.NewTestBackend(t)
-	server := NewConfigServer(projectCfg, backend, tmpDir, nil)
+	serverDir := t.TempDir()
+	server := NewConfigServer(config.Default(), backend, serverDir, nil)
+	cache := NewProjectCache(1)
+	cache.entries["test-project"] = &cacheEntry{backend: backend, path: tmpDir}
+	cache.order = append(cache.order, "test-project")
+	server.(*configServer).SetProjectCache(cache)
 
 	// Test with project ID
 	req := connect.NewRequest(&orcv1.GetWorkflowDefaultsRequest{
@@ -298,6 +304,25 @@ func TestConfigServer_GetWorkflowDefaults_ProjectScope(t *testing.T) {
 	if defaults.Default != "project-default" {
 		t.Errorf("Project Default = %q, want %q", defaults.Default, "project-default")
 	}
+
+	// Updates are written to the project's config, not the server's
+	_, err = server.UpdateWorkflowDefaults(context.Background(), connect.NewRequest(&orcv1.UpdateWorkflowDefaultsRequest{
+		ProjectId:        "test-project",
+		WorkflowDefaults: &orcv1.WorkflowDefaults{Bug: "project-bug"},
+	}))
+	if err != nil {
+		t.Fatalf("UpdateWorkflowDefaults with project failed: %v", err)
+	}
+	saved, err := config.LoadFile(projectConfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load project config: %v", err)
+	}
+	if saved.WorkflowDefaults.Bug != "project-bug" || saved.WorkflowDefaults.Feature != "project-feature" {
+		t.Errorf("project workflow defaults = %+v", saved.WorkflowDefaults)
+	}
+	if _, err := os.Stat(filepath.Join(serverDir, config.OrcDir, config.ConfigFileName)); !os.IsNotExist(err) {
+		t.Errorf("server config should not be written, stat err = %v", err)
+	}
 }
 
 // contains is a helper to check if a string contains a substring.
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/randalmurphal/orc/internal/config"
+)
+
+// userConfigResponse is the JSON shape for GET/PUT /api/config/user.
+type userConfigResponse struct {
+	Path    string `json:"path"`
+	Exists  bool   `json:"exists"`
+	Content string `json:"content"`
+}
+
+// userConfigUpdateRequest is the JSON body for PUT /api/config/user.
+// Content is the full YAML document that replaces ~/.orc/config.yaml.
+type userConfigUpdateRequest struct {
+	Content string `json:"content"`
+}
+
+// configLayerValue is one layer of a config key's inheritance chain.
+type configLayerValue struct {
+	Level     string `json:"level"`
+	Source    string `json:"source"`
+	Path      string `json:"path"`
+	Value     string `json:"value,omitempty"`
+	IsSet     bool   `json:"is_set"`
+	IsWinning bool   `json:"is_winning"`
+}
+
+// configInheritanceEntry shows the effective value of a key and every layer that
+// could supply it.
+type configInheritanceEntry struct {
+	Key        string             `json:"key"`
+	Value      string             `json:"value"`
+	Source     string             `json:"source"`
+	SourcePath string             `json:"source_path,omitempty"`
+	Layers     []configLayerValue `json:"layers"`
+}
+
+// handleGetUserConfig returns the raw user-level config file.
+// GET /api/config/user
+func (s *Server) handleGetUserConfig(w http.ResponseWriter, r *http.Request) {
+	path, content, exists, err := config.ReadUserConfig()
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.jsonResponse(w, userConfigResponse{Path: path, Exists: exists, Content: string(content)})
+}
+
+// handlePutUserConfig validates and replaces the user-level config file.
+// PUT /api/config/user
+func (s *Server) handlePutUserConfig(w http.ResponseWriter, r *http.Request) {
+	var req userConfigUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.jsonError(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := config.ValidateConfigYAML([]byte(req.Content)); err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	path, err := config.WriteUserConfig([]byte(req.Content))
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.Info("user config updated", "path", path)
+	s.jsonResponse(w, userConfigResponse{Path: path, Exists: true, Content: req.Content})
+}
+
+// handleConfigInheritance shows, for each config key, the value at every layer
+// (defaults, shared, personal, runtime) and which layer wins.
+// GET /api/config/inheritance[?key=a&key=b][&project_id=...]
+func (s *Server) handleConfigInheritance(w http.ResponseWriter, r *http.Request) {
+	_, workDir, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	chains, err := config.NewLoader(workDir).GetInheritance(r.URL.Query()["key"])
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "unknown config key") {
+			s.jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	entries := make([]configInheritanceEntry, 0, len(chains))
+	for _, chain := range chains {
+		entry := configInheritanceEntry{
+			Key:        chain.Key,
+			Value:      chain.FinalValue,
+			Source:     string(chain.WinningFrom.Source),
+			SourcePath: chain.WinningFrom.Path,
+			Layers:     make([]configLayerValue, 0, len(chain.Entries)),
+		}
+		for _, e := range chain.Entries {
+			entry.Layers = append(entry.Layers, configLayerValue{
+				Level:     e.Level.String(),
+				Source:    string(e.Source),
+				Path:      e.Path,
+				Value:     e.Value,
+				IsSet:     e.IsSet,
+				IsWinning: e.IsWinning,
+			})
+		}
+		entries = append(entries, entry)
+	}
+
+	s.jsonResponse(w, map[string]any{"keys": entries})
+}
+
+// handleListConfigProfiles lists the built-in automation profiles followed by
+// the named presets in ~/.orc/profiles.
+// GET /api/config/profiles
+func (s *Server) handleListConfigProfiles(w http.ResponseWriter, r *http.Request) {
+	profiles, err := config.ListProfiles()
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.jsonResponse(w, map[string]any{"profiles": profiles})
+}
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newUserConfigTestServer(t *testing.T) *Server {
+	t.Helper()
+	s := &Server{
+		mux:     http.NewServeMux(),
+		logger:  slog.Default(),
+		workDir: t.TempDir(),
+	}
+	s.registerRESTRoutes()
+	return s
+}
+
+func TestUserConfigHandlers_PutThenGet(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	s := newUserConfigTestServer(t)
+
+	body := `{"content": "model: personal-model\n"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/config/user", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("PUT status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	data, err := os.ReadFile(filepath.Join(home, ".orc", "config.yaml"))
+	if err != nil {
+		t.Fatalf("read written config: %v", err)
+	}
+	if string(data) != "model: personal-model\n" {
+		t.Errorf("written config = %q", data)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/config/user", nil)
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET status = %d, body = %s", w.Code, w.Body.String())
+	}
+	var resp userConfigResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if !resp.Exists || resp.Content != "model: personal-model\n" {
+		t.Errorf("GET response = %+v", resp)
+	}
+}
+
+func TestUserConfigHandlers_PutInvalidReturns400(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	s := newUserConfigTestServer(t)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/config/user", strings.NewReader(`{"content": "bogus_key: 1\n"}`))
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".orc", "config.yaml")); !os.IsNotExist(err) {
+		t.Error("invalid config must not be written")
+	}
+}
+
+func TestConfigInheritanceHandler(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	s := newUserConfigTestServer(t)
+
+	if err := os.MkdirAll(filepath.Join(home, ".orc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".orc", "config.yaml"), []byte("model: personal-model\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/config/inheritance?key=model", nil)
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Keys []configInheritanceEntry `json:"keys"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Keys) != 1 {
+		t.Fatalf("got %d keys, want 1", len(resp.Keys))
+	}
+	entry := resp.Keys[0]
+	if entry.Value != "personal-model" || entry.Source != "personal" {
+		t.Errorf("entry = %+v, want personal-model from personal", entry)
+	}
+	if len(entry.Layers) < 3 {
+		t.Errorf("expected defaults/shared/personal layers, got %d", len(entry.Layers))
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/config/inheritance?key=no.such.key", nil)
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unknown key status = %d, want 400", w.Code)
+	}
+}
+
+func TestListConfigProfiles(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	profilesDir := filepath.Join(home, ".orc", "profiles")
+	if err := os.MkdirAll(profilesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	preset := "description: Strict gates with opus\nprofile: strict\nmodel: opus\n"
+	if err := os.WriteFile(filepath.Join(profilesDir, "careful.yaml"), []byte(preset), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s := newUserConfigTestServer(t)
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/config/profiles", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Profiles []struct {
+			Name        string   `json:"name"`
+			Description string   `json:"description"`
+			BuiltIn     bool     `json:"builtin"`
+			Keys        []string `json:"keys"`
+		} `json:"profiles"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Profiles) != 5 {
+		t.Fatalf("profiles = %+v, want 4 built-ins and careful", resp.Profiles)
+	}
+	if !resp.Profiles[0].BuiltIn || resp.Profiles[0].Name != "auto" {
+		t.Errorf("first profile = %+v, want built-in auto", resp.Profiles[0])
+	}
+	careful := resp.Profiles[4]
+	if careful.Name != "careful" || careful.BuiltIn || careful.Description != "Strict gates with opus" || len(careful.Keys) != 2 {
+		t.Errorf("careful = %+v", careful)
+	}
+}
+
+func TestUserConfigHandlers_RejectsCrossOriginWrites(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	s := newUserConfigTestServer(t)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/config/user", strings.NewReader(`{"content": "model: evil\n"}`))
+	req.Header.Set("Origin", "https://attacker.example")
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("cross-origin PUT status = %d, want 403", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Access-Control-Allow-Origin = %q on a mutating route", got)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".orc", "config.yaml")); !os.IsNotExist(err) {
+		t.Errorf("cross-origin PUT wrote the user config (stat err = %v)", err)
+	}
+
+	// The embedded UI is served from the same host
+	req = httptest.NewRequest(http.MethodPut, "/api/config/user", strings.NewReader(`{"content": "model: mine\n"}`))
+	req.Header.Set("Origin", "http://"+req.Host)
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("same-origin PUT status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/config/user", nil)
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, req)
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("GET Access-Control-Allow-Origin = %q, want *", got)
+	}
+
+	// Cross-origin reads with Authorization are preflighted
+	req = httptest.NewRequest(http.MethodOptions, "/api/config/user", nil)
+	req.Header.Set("Origin", "https://dashboard.example")
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	req.Header.Set("Access-Control-Request-Headers", "authorization")
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("preflight status = %d, want 200", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Headers"); !strings.Contains(got, "Authorization") {
+		t.Errorf("preflight Access-Control-Allow-Headers = %q, want Authorization", got)
+	}
+}
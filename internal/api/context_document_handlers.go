@@ -0,0 +1,150 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+// contextDocumentNamePattern limits document names to plain file names such
+// as architecture.md.
+var contextDocumentNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,127}$`)
+
+// saveContextDocumentRequest creates or updates a context document. Empty
+// phases includes the document in every phase.
+type saveContextDocumentRequest struct {
+	Content   string   `json:"content"`
+	Phases    []string `json:"phases"`
+	UpdatedBy string   `json:"updated_by"`
+}
+
+// handleListContextDocuments lists the project's context documents.
+// GET /api/context-documents
+func (s *Server) handleListContextDocuments(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	docs, err := backend.DB().ListContextDocuments()
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if docs == nil {
+		docs = []*db.ContextDocument{}
+	}
+	s.jsonResponse(w, docs)
+}
+
+// handleGetContextDocument returns a context document, or one of its
+// versions with ?version=N.
+// GET /api/context-documents/{name}
+func (s *Server) handleGetContextDocument(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	name := r.PathValue("name")
+	var doc *db.ContextDocument
+	if v := r.URL.Query().Get("version"); v != "" {
+		version, convErr := strconv.Atoi(v)
+		if convErr != nil || version < 1 {
+			s.jsonError(w, "version must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		doc, err = backend.DB().GetContextDocumentVersion(name, version)
+		if errors.Is(err, db.ErrContextDocumentNotFound) {
+			err, doc = nil, nil
+		}
+	} else {
+		doc, err = backend.DB().GetContextDocument(name)
+	}
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if doc == nil {
+		s.jsonError(w, fmt.Sprintf("context document %s not found", name), http.StatusNotFound)
+		return
+	}
+	s.jsonResponse(w, doc)
+}
+
+// handleSaveContextDocument creates a context document or saves a new
+// version of it.
+// PUT /api/context-documents/{name}
+func (s *Server) handleSaveContextDocument(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	name := r.PathValue("name")
+	if !contextDocumentNamePattern.MatchString(name) {
+		s.jsonError(w, "name must be a file name of letters, digits, '.', '_' or '-'", http.StatusBadRequest)
+		return
+	}
+	var req saveContextDocumentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	doc := &db.ContextDocument{Name: name, Content: req.Content, Phases: req.Phases, UpdatedBy: req.UpdatedBy}
+	if doc.UpdatedBy == "" {
+		doc.UpdatedBy = "api"
+	}
+	if err := backend.DB().SaveContextDocument(doc); err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.jsonResponse(w, doc)
+}
+
+// handleDeleteContextDocument removes a context document and its versions.
+// DELETE /api/context-documents/{name}
+func (s *Server) handleDeleteContextDocument(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := backend.DB().DeleteContextDocument(r.PathValue("name")); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, db.ErrContextDocumentNotFound) {
+			status = http.StatusNotFound
+		}
+		s.jsonError(w, err.Error(), status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleListContextDocumentVersions lists every version of a context
+// document, newest first.
+// GET /api/context-documents/{name}/versions
+func (s *Server) handleListContextDocumentVersions(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	name := r.PathValue("name")
+	versions, err := backend.DB().ListContextDocumentVersions(name)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(versions) == 0 {
+		s.jsonError(w, fmt.Sprintf("context document %s not found", name), http.StatusNotFound)
+		return
+	}
+	s.jsonResponse(w, versions)
+}
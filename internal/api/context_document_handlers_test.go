@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+func TestContextDocumentHandlers(t *testing.T) {
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir(), backend: storage.NewTestBackend(t)}
+	s.registerRESTRoutes()
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		s.mux.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	w := do(http.MethodPut, "/api/context-documents/architecture.md", `{"content": "v1", "updated_by": "alice"}`)
+	var doc db.ContextDocument
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &doc) != nil || doc.Version != 1 {
+		t.Fatalf("PUT status = %d, body = %s", w.Code, w.Body.String())
+	}
+	w = do(http.MethodPut, "/api/context-documents/architecture.md", `{"content": "v2", "phases": ["implement"]}`)
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &doc) != nil || doc.Version != 2 || doc.UpdatedBy != "api" {
+		t.Fatalf("second PUT status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	w = do(http.MethodGet, "/api/context-documents", "")
+	var docs []db.ContextDocument
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &docs) != nil || len(docs) != 1 || docs[0].Content != "v2" {
+		t.Errorf("GET list status = %d, body = %s", w.Code, w.Body.String())
+	}
+	w = do(http.MethodGet, "/api/context-documents/architecture.md?version=1", "")
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &doc) != nil || doc.Content != "v1" || doc.UpdatedBy != "alice" {
+		t.Errorf("GET version 1 status = %d, body = %s", w.Code, w.Body.String())
+	}
+	w = do(http.MethodGet, "/api/context-documents/architecture.md/versions", "")
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &docs) != nil || len(docs) != 2 || docs[0].Version != 2 {
+		t.Errorf("GET versions status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	for _, tc := range []struct {
+		method, path, body string
+		want               int
+	}{
+		{http.MethodPut, "/api/context-documents/.hidden", `{"content": "x"}`, http.StatusBadRequest},
+		{http.MethodGet, "/api/context-documents/architecture.md?version=9", "", http.StatusNotFound},
+		{http.MethodGet, "/api/context-documents/missing.md", "", http.StatusNotFound},
+		{http.MethodDelete, "/api/context-documents/architecture.md", "", http.StatusNoContent},
+		{http.MethodDelete, "/api/context-documents/architecture.md", "", http.StatusNotFound},
+		{http.MethodGet, "/api/context-documents/architecture.md/versions", "", http.StatusNotFound},
+	} {
+		if w := do(tc.method, tc.path, tc.body); w.Code != tc.want {
+			t.Errorf("%s %s status = %d, want %d (body %s)", tc.method, tc.path, w.Code, tc.want, w.Body.String())
+		}
+	}
+}
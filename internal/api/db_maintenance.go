@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/automation"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+// Automation metrics recorded after each maintenance run, usable by
+// threshold triggers.
+const (
+	dbSizeMetricName      = "db_size_bytes"
+	dbFreeSpaceMetricName = "db_free_bytes"
+)
+
+// dbMaintenanceState holds the latest scheduled maintenance reports.
+type dbMaintenanceState struct {
+	mu      sync.RWMutex
+	project *db.MaintenanceReport
+	global  *db.MaintenanceReport
+	ranAt   time.Time
+	err     string
+}
+
+// dbMaintenanceResponse is returned by GET /api/db/maintenance.
+type dbMaintenanceResponse struct {
+	Interval      string                `json:"interval"`
+	RetentionDays int                   `json:"retention_days"`
+	ProjectSize   *db.DatabaseSize      `json:"project_size,omitempty"`
+	GlobalSize    *db.DatabaseSize      `json:"global_size,omitempty"`
+	LastRun       *time.Time            `json:"last_run,omitempty"`
+	Project       *db.MaintenanceReport `json:"project,omitempty"`
+	Global        *db.MaintenanceReport `json:"global,omitempty"`
+	Error         string                `json:"error,omitempty"`
+}
+
+func (s *Server) dbMaintenanceConfig() config.DatabaseStorageConfig {
+	if s.orcConfig == nil {
+		return config.Default().Storage.Database
+	}
+	return s.orcConfig.Storage.Database
+}
+
+// startDBMaintenance runs database maintenance on the configured interval
+// until ctx is cancelled. Unlike the drift check it does not run at
+// startup: VACUUM blocks writers, and restarts should not trigger it.
+func (s *Server) startDBMaintenance(ctx context.Context) {
+	interval := s.dbMaintenanceConfig().MaintenanceInterval
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.runDBMaintenance(ctx)
+			}
+		}
+	}()
+}
+
+// runDBMaintenance maintains the project and global databases, stores the
+// reports, and records the size metrics.
+func (s *Server) runDBMaintenance(ctx context.Context) {
+	cfg := s.dbMaintenanceConfig()
+	var projectReport, globalReport *db.MaintenanceReport
+	var errs []error
+
+	if s.projectDB != nil {
+		report, err := s.projectDB.Maintain(ctx, db.MaintenanceOptions{RetentionDays: cfg.RetentionDays})
+		if err != nil && !errors.Is(err, db.ErrMaintenanceUnsupported) {
+			errs = append(errs, err)
+		}
+		projectReport = report
+	}
+	if s.globalDB != nil {
+		report, err := s.globalDB.Maintain(ctx, db.MaintenanceOptions{})
+		if err != nil && !errors.Is(err, db.ErrMaintenanceUnsupported) {
+			errs = append(errs, err)
+		}
+		globalReport = report
+	}
+	err := errors.Join(errs...)
+
+	s.dbMaintenance.mu.Lock()
+	s.dbMaintenance.project, s.dbMaintenance.global = projectReport, globalReport
+	s.dbMaintenance.ranAt = time.Now()
+	s.dbMaintenance.err = ""
+	if err != nil {
+		s.dbMaintenance.err = err.Error()
+	}
+	s.dbMaintenance.mu.Unlock()
+
+	if err != nil {
+		s.logger.Warn("database maintenance failed", "error", err)
+	}
+	for _, report := range []*db.MaintenanceReport{projectReport, globalReport} {
+		if report == nil {
+			continue
+		}
+		if !report.Healthy() {
+			s.logger.Warn("database integrity problems found", "path", report.Path,
+				"integrity", report.Integrity, "fts", report.FTS)
+		}
+		s.logger.Info("database maintenance complete", "path", report.Path,
+			"size_before", report.Before.Bytes, "size_after", report.After.Bytes,
+			"pruned_transcripts", report.PrunedTranscripts, "pruned_events", report.PrunedEvents,
+			"pruned_file_summaries", report.PrunedSummaries, "duration", report.Duration)
+	}
+
+	if projectReport != nil {
+		adapter := automation.NewProjectDBAdapter(s.projectDB)
+		for name, value := range map[string]int64{
+			dbSizeMetricName:      projectReport.After.Bytes,
+			dbFreeSpaceMetricName: projectReport.After.FreeBytes,
+		} {
+			if err := adapter.RecordMetric(ctx, &automation.Metric{Name: name, Value: float64(value)}); err != nil {
+				s.logger.Warn("record database size metric", "metric", name, "error", err)
+			}
+		}
+	}
+}
+
+// handleDBMaintenance returns the current database sizes and the latest
+// scheduled maintenance reports.
+// GET /api/db/maintenance
+func (s *Server) handleDBMaintenance(w http.ResponseWriter, r *http.Request) {
+	cfg := s.dbMaintenanceConfig()
+	resp := dbMaintenanceResponse{Interval: cfg.MaintenanceInterval.String(), RetentionDays: cfg.RetentionDays}
+
+	if s.projectDB != nil {
+		if size, err := s.projectDB.Size(r.Context()); err == nil {
+			resp.ProjectSize = &size
+		}
+	}
+	if s.globalDB != nil {
+		if size, err := s.globalDB.Size(r.Context()); err == nil {
+			resp.GlobalSize = &size
+		}
+	}
+
+	s.dbMaintenance.mu.RLock()
+	if !s.dbMaintenance.ranAt.IsZero() {
+		ranAt := s.dbMaintenance.ranAt
+		resp.LastRun = &ranAt
+	}
+	resp.Project, resp.Global, resp.Error = s.dbMaintenance.project, s.dbMaintenance.global, s.dbMaintenance.err
+	s.dbMaintenance.mu.RUnlock()
+
+	s.jsonResponse(w, resp)
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/automation"
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+func TestRunDBMaintenance_StoresReportAndMetrics(t *testing.T) {
+	pdb := db.NewTestProjectDB(t)
+	s := &Server{projectDB: pdb, logger: slog.Default()}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/db/maintenance", nil)
+	w := httptest.NewRecorder()
+	s.handleDBMaintenance(w, req)
+	var before dbMaintenanceResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &before); err != nil {
+		t.Fatal(err)
+	}
+	if before.LastRun != nil || before.ProjectSize == nil || before.ProjectSize.Bytes == 0 {
+		t.Errorf("before first run: last_run=%v project_size=%+v", before.LastRun, before.ProjectSize)
+	}
+
+	s.runDBMaintenance(context.Background())
+
+	w = httptest.NewRecorder()
+	s.handleDBMaintenance(w, req)
+	var resp dbMaintenanceResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error != "" || resp.LastRun == nil || resp.Project == nil || !resp.Project.Vacuumed {
+		t.Fatalf("after run: %+v", resp)
+	}
+	if resp.RetentionDays != 90 || resp.Interval != "24h0m0s" {
+		t.Errorf("config in response: retention=%d interval=%s", resp.RetentionDays, resp.Interval)
+	}
+
+	metric, err := automation.NewProjectDBAdapter(pdb).GetLatestMetric(context.Background(), dbSizeMetricName)
+	if err != nil || metric == nil || metric.Value <= 0 {
+		t.Errorf("%s metric = %+v, %v", dbSizeMetricName, metric, err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
 	"github.com/randalmurphal/orc/internal/events"
 	"github.com/randalmurphal/orc/internal/gate"
 	"github.com/randalmurphal/orc/internal/storage"
@@ -43,6 +44,29 @@ func resolvePendingDecision(
 		return nil, fmt.Errorf("pending decisions not available")
 	}
 
+	// Human gates the executor waits on resolve through their gate approval
+	if approval, err := backend.DB().GetGateApproval(decisionID); err == nil && approval != nil && approval.Status == db.GateApprovalPending {
+		resolvedApproval, err := resolveGateApproval(backend, pendingDecisions, publisher, projectID, decisionID, approved, reason, resolvedBy)
+		if err != nil {
+			return nil, err
+		}
+		resolved := &orcv1.ResolvedDecision{
+			Id:         decisionID,
+			TaskId:     resolvedApproval.TaskID,
+			Phase:      resolvedApproval.Phase,
+			Approved:   approved,
+			ResolvedBy: resolvedBy,
+			ResolvedAt: timestamppb.New(time.Now()),
+		}
+		if selectedOption != "" {
+			resolved.SelectedOption = &selectedOption
+		}
+		if reason != "" {
+			resolved.Reason = &reason
+		}
+		return resolved, nil
+	}
+
 	decision, ok := pendingDecisions.Get(projectID, decisionID)
 	if !ok {
 		return nil, fmt.Errorf("decision not found: %s", decisionID)
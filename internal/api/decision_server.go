@@ -179,7 +179,7 @@ func (s *decisionServer) ListResolvedDecisions(
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
-	dbBackend, ok := backend.(*storage.DatabaseBackend)
+	dbBackend, ok := storage.DatabaseOf(backend)
 	if !ok {
 		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("resolved decisions require database backend"))
 	}
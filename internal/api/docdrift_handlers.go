@@ -0,0 +1,201 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/automation"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/docdrift"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// docDriftRefreshTemplateID is recorded as the template of refresh tasks
+// created from the built-in template.
+const docDriftRefreshTemplateID = "builtin:claudemd-refresh"
+
+// automationTriggerMetadataKey is the task metadata key set by AutoTaskCreator.
+const automationTriggerMetadataKey = "automation_trigger_id"
+
+// docDriftState holds the latest scheduled drift report for the dashboard.
+type docDriftState struct {
+	mu     sync.RWMutex
+	report *docdrift.Report
+	err    string
+	// refreshTaskID is the last refresh task created for drift.
+	refreshTaskID string
+}
+
+// docDriftResponse is returned by GET /api/dashboard/docs-drift.
+type docDriftResponse struct {
+	Enabled       bool             `json:"enabled"`
+	Threshold     int              `json:"threshold"`
+	Drifted       bool             `json:"drifted"`
+	OverThreshold bool             `json:"over_threshold"`
+	Report        *docdrift.Report `json:"report,omitempty"`
+	Error         string           `json:"error,omitempty"`
+	RefreshTaskID string           `json:"refresh_task_id,omitempty"`
+}
+
+// docDriftConfig returns the drift check settings and managed sections.
+func (s *Server) docDriftConfig() (config.DocDriftConfig, []string) {
+	if s.orcConfig == nil {
+		d := config.Default().Documentation
+		return d.DriftCheck, d.Sections
+	}
+	return s.orcConfig.Documentation.DriftCheck, s.orcConfig.Documentation.Sections
+}
+
+// startDocDriftCheck runs the CLAUDE.md drift check immediately and then on
+// the configured interval until ctx is cancelled.
+func (s *Server) startDocDriftCheck(ctx context.Context) {
+	cfg, _ := s.docDriftConfig()
+	if !cfg.Enabled {
+		return
+	}
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = 6 * time.Hour
+	}
+
+	go func() {
+		s.runDocDriftCheck(ctx)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.runDocDriftCheck(ctx)
+			}
+		}
+	}()
+}
+
+// runDocDriftCheck checks the server's project, stores the report, records
+// the drift metric, and creates a refresh task when configured.
+func (s *Server) runDocDriftCheck(ctx context.Context) {
+	cfg, sections := s.docDriftConfig()
+	report, err := docdrift.Check(s.workDir, sections)
+
+	s.docDrift.mu.Lock()
+	s.docDrift.report = report
+	s.docDrift.err = ""
+	if err != nil {
+		s.docDrift.err = err.Error()
+	}
+	s.docDrift.mu.Unlock()
+
+	if err != nil {
+		s.logger.Warn("CLAUDE.md drift check failed", "error", err)
+		return
+	}
+	if report.Drifted() {
+		s.logger.Info("CLAUDE.md drift detected", "score", report.Score, "summary", report.Summary())
+	}
+
+	// A read-only server reports drift but records nothing.
+	if s.readOnly {
+		return
+	}
+
+	if s.projectDB != nil {
+		adapter := automation.NewProjectDBAdapter(s.projectDB)
+		if err := adapter.RecordMetric(ctx, &automation.Metric{Name: docdrift.MetricName, Value: float64(report.Score)}); err != nil {
+			s.logger.Warn("record CLAUDE.md drift metric", "error", err)
+		}
+	}
+
+	if cfg.CreateTask && overDriftThreshold(report, cfg.Threshold) {
+		if err := s.createDocRefreshTask(ctx, cfg, report); err != nil {
+			s.logger.Error("create CLAUDE.md refresh task", "error", err)
+		}
+	}
+}
+
+// overDriftThreshold reports whether the report reaches threshold. A
+// threshold of zero or less means any drift.
+func overDriftThreshold(report *docdrift.Report, threshold int) bool {
+	if report == nil || !report.Drifted() {
+		return false
+	}
+	return threshold <= 0 || report.Score >= threshold
+}
+
+// createDocRefreshTask creates an automation task to refresh the managed
+// sections unless one is still open.
+func (s *Server) createDocRefreshTask(ctx context.Context, cfg config.DocDriftConfig, report *docdrift.Report) error {
+	tasks, err := s.backend.LoadAllTasks()
+	if err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		if t.Metadata[automationTriggerMetadataKey] == docdrift.TriggerID && !task.IsDoneProto(t.Status) {
+			return nil
+		}
+	}
+
+	creator := automation.NewAutoTaskCreator(s.orcConfig, s.backend, s.logger,
+		automation.WithDBAdapter(automation.NewProjectDBAdapter(s.backend.DB())))
+	var taskID string
+	if cfg.Template != "" {
+		taskID, err = creator.CreateAutomationTask(ctx, cfg.Template, docdrift.TriggerID, report.Summary())
+	} else {
+		taskID, err = creator.CreateTaskFromTemplate(ctx, docDriftRefreshTemplateID, docdrift.RefreshTemplate(), docdrift.TriggerID, report.Summary())
+	}
+	if err != nil {
+		return err
+	}
+
+	s.docDrift.mu.Lock()
+	s.docDrift.refreshTaskID = taskID
+	s.docDrift.mu.Unlock()
+
+	if t, err := s.backend.LoadTask(taskID); err == nil && s.publisher != nil {
+		s.publisher.Publish(events.NewEvent(events.EventTaskCreated, taskID, t))
+	}
+	return nil
+}
+
+// handleDocDrift returns the latest CLAUDE.md drift report. With
+// ?refresh=true, or when no scheduled check has run yet, the check runs
+// synchronously. Other projects (?project_id) are always checked on demand.
+// GET /api/dashboard/docs-drift
+func (s *Server) handleDocDrift(w http.ResponseWriter, r *http.Request) {
+	cfg, sections := s.docDriftConfig()
+	resp := docDriftResponse{Enabled: cfg.Enabled, Threshold: cfg.Threshold}
+
+	if r.URL.Query().Get("project_id") != "" {
+		_, workDir, err := s.resolveProjectBackend(r)
+		if err != nil {
+			s.jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		report, err := docdrift.Check(workDir, sections)
+		if err != nil {
+			s.jsonError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp.Report = report
+	} else {
+		s.docDrift.mu.RLock()
+		cached := s.docDrift.report
+		s.docDrift.mu.RUnlock()
+		if cached == nil || r.URL.Query().Get("refresh") == "true" {
+			s.runDocDriftCheck(r.Context())
+		}
+		s.docDrift.mu.RLock()
+		resp.Report, resp.Error, resp.RefreshTaskID = s.docDrift.report, s.docDrift.err, s.docDrift.refreshTaskID
+		s.docDrift.mu.RUnlock()
+	}
+
+	if resp.Report != nil {
+		resp.Drifted = resp.Report.Drifted()
+		resp.OverThreshold = overDriftThreshold(resp.Report, cfg.Threshold)
+	}
+	s.jsonResponse(w, resp)
+}
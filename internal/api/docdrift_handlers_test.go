@@ -0,0 +1,113 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/docdrift"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+func newDocDriftTestServer(t *testing.T, claudeMD string) *Server {
+	t.Helper()
+	workDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(workDir, docdrift.ClaudeMDFile), []byte(claudeMD), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, "routes.go"), []byte(`package main
+
+func routes() { mux.HandleFunc("GET /api/users", h) }
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default()
+	cfg.Documentation.DriftCheck.CreateTask = true
+	cfg.Documentation.DriftCheck.Threshold = 2
+
+	backend := storage.NewTestBackend(t)
+	pub := events.NewMemoryPublisher()
+	t.Cleanup(pub.Close)
+	s := &Server{
+		mux:       http.NewServeMux(),
+		logger:    slog.Default(),
+		workDir:   workDir,
+		orcConfig: cfg,
+		backend:   backend,
+		projectDB: backend.DB(),
+		publisher: pub,
+	}
+	s.registerRESTRoutes()
+	return s
+}
+
+func getDocDrift(t *testing.T, s *Server, query string) docDriftResponse {
+	t.Helper()
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/dashboard/docs-drift"+query, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	var resp docDriftResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return resp
+}
+
+func TestDocDrift_InSync(t *testing.T) {
+	s := newDocDriftTestServer(t, "<!-- orc:auto:api-endpoints -->\n| GET | /api/users |\n<!-- /orc:auto:api-endpoints -->\n")
+
+	resp := getDocDrift(t, s, "")
+	if resp.Report == nil || resp.Drifted || resp.OverThreshold {
+		t.Fatalf("expected no drift, got %+v", resp)
+	}
+	if resp.RefreshTaskID != "" {
+		t.Errorf("no refresh task expected, got %s", resp.RefreshTaskID)
+	}
+}
+
+func TestDocDrift_OverThresholdCreatesOneRefreshTask(t *testing.T) {
+	s := newDocDriftTestServer(t, "<!-- orc:auto:api-endpoints -->\n"+
+		"| DELETE | /api/old |\n| PUT | /api/removed |\n"+
+		"<!-- /orc:auto:api-endpoints -->\n")
+
+	resp := getDocDrift(t, s, "")
+	if !resp.Drifted || !resp.OverThreshold {
+		t.Fatalf("expected drift over threshold, got %+v", resp)
+	}
+	if resp.Report.Score != 3 {
+		t.Errorf("score = %d, want 3 (two stale, one undocumented)", resp.Report.Score)
+	}
+	if resp.RefreshTaskID == "" {
+		t.Fatal("expected a refresh task to be created")
+	}
+
+	created, err := s.backend.LoadTask(resp.RefreshTaskID)
+	if err != nil {
+		t.Fatalf("load refresh task: %v", err)
+	}
+	if created.Metadata[automationTriggerMetadataKey] != docdrift.TriggerID || !created.IsAutomation {
+		t.Errorf("refresh task metadata = %v, automation = %v", created.Metadata, created.IsAutomation)
+	}
+
+	// An open refresh task suppresses duplicates on later checks.
+	again := getDocDrift(t, s, "?refresh=true")
+	if again.RefreshTaskID != resp.RefreshTaskID {
+		t.Errorf("refresh task = %s, want existing %s", again.RefreshTaskID, resp.RefreshTaskID)
+	}
+	tasks, err := s.backend.LoadAllTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 {
+		t.Errorf("tasks = %d, want 1", len(tasks))
+	}
+}
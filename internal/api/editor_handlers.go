@@ -0,0 +1,441 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/executor"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// editorTokenHeader carries an editor session token. It is separate from
+// Authorization so editor sessions work alongside tenant tokens.
+const editorTokenHeader = "X-Orc-Editor-Token"
+
+// editorSchemes are the URI schemes task summaries may build worktree links
+// for (?editor=). All of them accept <scheme>://file/<path>.
+var editorSchemes = map[string]bool{
+	"vscode":          true,
+	"vscode-insiders": true,
+	"vscodium":        true,
+	"cursor":          true,
+	"windsurf":        true,
+}
+
+// editorState holds the handshake secret and the sessions issued for it.
+// The secret is written to ~/.orc/editor.json, readable only by the local
+// user, so an editor extension proves it runs as that user by echoing it.
+type editorState struct {
+	mu       sync.Mutex
+	secret   string
+	sessions map[string]editorSession
+}
+
+type editorSession struct {
+	Client    string
+	CreatedAt time.Time
+}
+
+// editorHandshakeFile is the content of ~/.orc/editor.json.
+type editorHandshakeFile struct {
+	URL     string `json:"url"`
+	PID     int    `json:"pid"`
+	WorkDir string `json:"work_dir"`
+	Secret  string `json:"secret"`
+	Socket  string `json:"socket,omitempty"` // JSON-RPC socket, when listening
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// editorSecret returns the handshake secret, generating it on first use.
+func (s *Server) editorSecret() string {
+	s.editor.mu.Lock()
+	defer s.editor.mu.Unlock()
+	if s.editor.secret == "" {
+		s.editor.secret = randomHex(32)
+	}
+	return s.editor.secret
+}
+
+func editorHandshakePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir: %w", err)
+	}
+	return filepath.Join(home, ".orc", "editor.json"), nil
+}
+
+// writeEditorHandshakeFile records the server URL and handshake secret for
+// local editor extensions, readable only by the current user.
+func (s *Server) writeEditorHandshakeFile(addr net.Addr) error {
+	path, err := editorHandshakePath()
+	if err != nil {
+		return err
+	}
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return fmt.Errorf("parse listen address: %w", err)
+	}
+	if ip := net.ParseIP(host); ip == nil || ip.IsUnspecified() {
+		host = "127.0.0.1"
+	}
+	data, err := json.MarshalIndent(editorHandshakeFile{
+		URL:     "http://" + net.JoinHostPort(host, port),
+		PID:     os.Getpid(),
+		WorkDir: s.workDir,
+		Secret:  s.editorSecret(),
+		Socket:  s.socketPath,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create %s: %w", filepath.Dir(path), err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("write %s: %w", tmp, err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// removeEditorHandshakeFile deletes ~/.orc/editor.json if this process
+// wrote it; another server may have replaced it since.
+func (s *Server) removeEditorHandshakeFile() {
+	path, err := editorHandshakePath()
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	var f editorHandshakeFile
+	if json.Unmarshal(data, &f) == nil && f.PID == os.Getpid() {
+		_ = os.Remove(path)
+	}
+}
+
+// isLoopbackRequest reports whether the request came from this machine.
+func isLoopbackRequest(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+type editorHandshakeRequest struct {
+	Secret string `json:"secret"`
+	Client string `json:"client"`
+}
+
+type editorHandshakeResponse struct {
+	Token     string `json:"token"`
+	Client    string `json:"client"`
+	ProjectID string `json:"project_id,omitempty"`
+	WorkDir   string `json:"work_dir"`
+}
+
+// handleEditorHandshake exchanges the secret from ~/.orc/editor.json for an
+// editor session token. Only loopback clients may shake hands.
+// POST /api/editor/handshake
+func (s *Server) handleEditorHandshake(w http.ResponseWriter, r *http.Request) {
+	if !isLoopbackRequest(r) {
+		s.jsonError(w, "editor handshake is only available to local clients", http.StatusForbidden)
+		return
+	}
+	var req editorHandshakeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.jsonError(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	secret := s.editorSecret()
+	if subtle.ConstantTimeCompare([]byte(req.Secret), []byte(secret)) != 1 {
+		s.jsonError(w, "invalid handshake secret", http.StatusUnauthorized)
+		return
+	}
+	client := req.Client
+	if client == "" {
+		client = "editor"
+	}
+
+	token := randomHex(32)
+	s.editor.mu.Lock()
+	if s.editor.sessions == nil {
+		s.editor.sessions = make(map[string]editorSession)
+	}
+	s.editor.sessions[token] = editorSession{Client: client, CreatedAt: time.Now()}
+	s.editor.mu.Unlock()
+
+	s.logger.Info("editor session started", "client", client)
+	s.jsonResponse(w, editorHandshakeResponse{
+		Token:     token,
+		Client:    client,
+		ProjectID: s.defaultProjectID(),
+		WorkDir:   s.workDir,
+	})
+}
+
+// editorSessionFor returns the session for the request's editor token.
+func (s *Server) editorSessionFor(r *http.Request) (editorSession, bool) {
+	token := r.Header.Get(editorTokenHeader)
+	if token == "" {
+		return editorSession{}, false
+	}
+	s.editor.mu.Lock()
+	defer s.editor.mu.Unlock()
+	session, ok := s.editor.sessions[token]
+	return session, ok
+}
+
+// taskSummary is the lightweight task view returned to editor extensions.
+type taskSummary struct {
+	ID           string     `json:"id"`
+	Title        string     `json:"title"`
+	Status       string     `json:"status"`
+	CurrentPhase string     `json:"current_phase,omitempty"`
+	WorkflowID   string     `json:"workflow_id,omitempty"`
+	Branch       string     `json:"branch,omitempty"`
+	TargetBranch string     `json:"target_branch"`
+	PRURL        string     `json:"pr_url,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+	Links        taskLinks  `json:"links"`
+}
+
+// taskLinks are deep links for a task. Worktree uses the editor's URI
+// scheme and is empty when the worktree does not exist.
+type taskLinks struct {
+	Web      string `json:"web"`
+	Worktree string `json:"worktree,omitempty"`
+	Diff     string `json:"diff"`
+}
+
+// handleTaskSummary returns a task's status and deep links to its worktree,
+// diff, and web page (?editor=vscode selects the worktree URI scheme).
+// GET /api/tasks/{id}/summary
+func (s *Server) handleTaskSummary(w http.ResponseWriter, r *http.Request) {
+	backend, workDir, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	scheme := r.URL.Query().Get("editor")
+	if scheme == "" {
+		scheme = "vscode"
+	}
+	if !editorSchemes[scheme] {
+		s.jsonError(w, "unsupported editor: "+scheme, http.StatusBadRequest)
+		return
+	}
+	t, err := backend.LoadTask(r.PathValue("id"))
+	if err != nil || t == nil {
+		s.jsonError(w, "task not found", http.StatusNotFound)
+		return
+	}
+	s.jsonResponse(w, s.buildTaskSummary(r, backend, t, workDir, scheme))
+}
+
+func (s *Server) buildTaskSummary(r *http.Request, backend storage.Backend, t *orcv1.Task, workDir, editorScheme string) taskSummary {
+	cfg := s.orcConfig
+	if cfg == nil {
+		cfg = config.Default()
+	}
+	targetBranch := executor.ResolveTargetBranchWithGlobalDB(t, backend, s.globalDB, cfg)
+
+	origin := "http://" + r.Host
+	if r.TLS != nil {
+		origin = "https://" + r.Host
+	}
+	summary := taskSummary{
+		ID:           t.Id,
+		Title:        t.Title,
+		Status:       task.StatusFromProto(t.Status),
+		CurrentPhase: task.GetCurrentPhaseProto(t),
+		WorkflowID:   t.GetWorkflowId(),
+		Branch:       t.Branch,
+		TargetBranch: targetBranch,
+		PRURL:        task.GetPRURLProto(t),
+		Links: taskLinks{
+			Web:  origin + "/tasks/" + url.PathEscape(t.Id),
+			Diff: origin + "/api/tasks/" + url.PathEscape(t.Id) + "/diff?base=" + url.QueryEscape(targetBranch),
+		},
+	}
+	if t.UpdatedAt != nil {
+		updated := t.UpdatedAt.AsTime()
+		summary.UpdatedAt = &updated
+	}
+	if path := taskWorktreePath(cfg, workDir, t); path != "" {
+		path = filepath.ToSlash(path)
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path // Windows drive paths: vscode://file/C:/...
+		}
+		summary.Links.Worktree = editorScheme + "://file" + path
+	}
+	return summary
+}
+
+// taskWorktreePath returns the task's worktree directory if it exists.
+// Worktree directories are named after the task branch with slashes
+// replaced (see git.WorktreeDirNameWithPrefix).
+func taskWorktreePath(cfg *config.Config, workDir string, t *orcv1.Task) string {
+	if t.Branch == "" {
+		return ""
+	}
+	dir := filepath.Join(config.ResolveWorktreeDir(cfg.Worktree.Dir, workDir), strings.ReplaceAll(t.Branch, "/", "-"))
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return ""
+	}
+	return dir
+}
+
+// editorSelection is the editor context a task is created from.
+type editorSelection struct {
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+	Text      string `json:"text"`
+}
+
+type editorCreateTaskRequest struct {
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	File        string           `json:"file"`
+	Language    string           `json:"language"`
+	Selection   *editorSelection `json:"selection"`
+	WorkflowID  string           `json:"workflow_id"`
+	Category    string           `json:"category"`
+}
+
+// handleEditorCreateTask creates a task from the editor's current file and
+// selection. Requires an editor session token from the handshake.
+// POST /api/editor/tasks
+func (s *Server) handleEditorCreateTask(w http.ResponseWriter, r *http.Request) {
+	session, ok := s.editorSessionFor(r)
+	if !ok {
+		s.jsonError(w, "editor session token required ("+editorTokenHeader+")", http.StatusUnauthorized)
+		return
+	}
+	backend, workDir, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req editorCreateTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.jsonError(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Title) == "" {
+		s.jsonError(w, "title is required", http.StatusBadRequest)
+		return
+	}
+	file, err := editorRelativePath(workDir, req.File)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id, err := backend.GetNextTaskID()
+	if err != nil {
+		s.jsonError(w, fmt.Sprintf("generate task ID: %v", err), http.StatusInternalServerError)
+		return
+	}
+	t := task.NewProtoTask(id, strings.TrimSpace(req.Title))
+	if desc := editorTaskDescription(req.Description, file, req.Language, req.Selection); desc != "" {
+		t.Description = &desc
+	}
+	if req.WorkflowID != "" {
+		t.WorkflowId = &req.WorkflowID
+	}
+	if req.Category != "" {
+		t.Category = task.CategoryToProto(req.Category)
+	}
+	task.EnsureMetadataProto(t)
+	t.Metadata["source"] = "editor:" + session.Client
+	if file != "" {
+		t.Metadata["editor_file"] = file
+		if sel := req.Selection; sel != nil && sel.StartLine > 0 {
+			t.Metadata["editor_lines"] = fmt.Sprintf("%d-%d", sel.StartLine, max(sel.EndLine, sel.StartLine))
+		}
+	}
+
+	if err := backend.SaveTask(t); err != nil {
+		s.jsonError(w, fmt.Sprintf("save task: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if s.publisher != nil {
+		s.publisher.Publish(events.NewEvent(events.EventTaskCreated, t.Id, t))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/api/tasks/"+url.PathEscape(t.Id)+"/summary")
+	w.WriteHeader(http.StatusCreated)
+	s.jsonResponse(w, s.buildTaskSummary(r, backend, t, workDir, "vscode"))
+}
+
+// editorRelativePath returns file relative to the project root. Paths
+// outside the project are rejected.
+func editorRelativePath(workDir, file string) (string, error) {
+	if file == "" {
+		return "", nil
+	}
+	if filepath.IsAbs(file) {
+		rel, err := filepath.Rel(workDir, file)
+		if err != nil {
+			return "", fmt.Errorf("file %s is outside the project", file)
+		}
+		file = rel
+	}
+	file = filepath.ToSlash(filepath.Clean(file))
+	if file == ".." || strings.HasPrefix(file, "../") {
+		return "", fmt.Errorf("file %s is outside the project", file)
+	}
+	return file, nil
+}
+
+// editorTaskDescription appends the file and selected code to the user's
+// description so the agent sees the context the task was raised from.
+func editorTaskDescription(description, file, language string, sel *editorSelection) string {
+	var sb strings.Builder
+	sb.WriteString(strings.TrimSpace(description))
+	if file == "" {
+		return sb.String()
+	}
+	if sb.Len() > 0 {
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString("## Editor context\n\n")
+	fmt.Fprintf(&sb, "File: `%s`", file)
+	if sel != nil && sel.StartLine > 0 {
+		fmt.Fprintf(&sb, " (lines %d-%d)", sel.StartLine, max(sel.EndLine, sel.StartLine))
+	}
+	sb.WriteString("\n")
+	if sel != nil && strings.TrimSpace(sel.Text) != "" {
+		fence := "```"
+		for strings.Contains(sel.Text, fence) {
+			fence += "`"
+		}
+		fmt.Fprintf(&sb, "\n%s%s\n%s\n%s\n", fence, language, strings.TrimRight(sel.Text, "\n"), fence)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func newEditorTestServer(t *testing.T) *Server {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	backend := storage.NewTestBackend(t)
+	cfg := config.Default()
+	cfg.Worktree.Dir = t.TempDir()
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir(), backend: backend, orcConfig: cfg}
+	s.registerRESTRoutes()
+	return s
+}
+
+func editorRequest(s *Server, method, target, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.RemoteAddr = "127.0.0.1:50123"
+	if token != "" {
+		req.Header.Set(editorTokenHeader, token)
+	}
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestEditorHandshake(t *testing.T) {
+	s := newEditorTestServer(t)
+	if err := s.writeEditorHandshakeFile(&net.TCPAddr{IP: net.IPv4zero, Port: 8080}); err != nil {
+		t.Fatal(err)
+	}
+	path, _ := editorHandshakePath()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("handshake file mode = %v, want 0600", info.Mode().Perm())
+	}
+	data, _ := os.ReadFile(path)
+	var file editorHandshakeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+	if file.URL != "http://127.0.0.1:8080" || file.Secret == "" {
+		t.Errorf("handshake file = %+v", file)
+	}
+
+	if w := editorRequest(s, http.MethodPost, "/api/editor/handshake", `{"secret":"wrong"}`, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("wrong secret status = %d, want 401", w.Code)
+	}
+	remote := httptest.NewRequest(http.MethodPost, "/api/editor/handshake", strings.NewReader(`{"secret":"`+file.Secret+`"}`))
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, remote)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("remote handshake status = %d, want 403", w.Code)
+	}
+
+	w = editorRequest(s, http.MethodPost, "/api/editor/handshake", `{"secret":"`+file.Secret+`","client":"vscode"}`, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("handshake status = %d, body = %s", w.Code, w.Body.String())
+	}
+	var resp editorHandshakeResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if session, ok := s.editor.sessions[resp.Token]; !ok || session.Client != "vscode" {
+		t.Errorf("session for %q = %+v, %v", resp.Token, session, ok)
+	}
+
+	s.removeEditorHandshakeFile()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("handshake file not removed: %v", err)
+	}
+}
+
+func TestEditorCreateTaskAndSummary(t *testing.T) {
+	s := newEditorTestServer(t)
+	s.editor.sessions = map[string]editorSession{"tok": {Client: "vscode"}}
+
+	body := `{"title": "Handle nil config", "description": "Crashes on startup.", "file": "` +
+		filepath.Join(s.workDir, "internal", "app.go") + `", "language": "go",
+		"selection": {"start_line": 10, "end_line": 12, "text": "cfg := load()\ncfg.Run()"}, "category": "bug"}`
+	if w := editorRequest(s, http.MethodPost, "/api/editor/tasks", body, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("no token status = %d, want 401", w.Code)
+	}
+	w := editorRequest(s, http.MethodPost, "/api/editor/tasks", body, "tok")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, body = %s", w.Code, w.Body.String())
+	}
+	var created taskSummary
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatal(err)
+	}
+
+	tk, err := s.backend.LoadTask(created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc := task.GetDescriptionProto(tk)
+	for _, want := range []string{"Crashes on startup.", "File: `internal/app.go` (lines 10-12)", "```go\ncfg := load()\ncfg.Run()\n```"} {
+		if !strings.Contains(desc, want) {
+			t.Errorf("description missing %q:\n%s", want, desc)
+		}
+	}
+	if tk.Metadata["source"] != "editor:vscode" || tk.Metadata["editor_lines"] != "10-12" || task.CategoryFromProto(tk.Category) != "bug" {
+		t.Errorf("task = %+v", tk)
+	}
+
+	outside := `{"title": "x", "file": "../elsewhere.go"}`
+	if w := editorRequest(s, http.MethodPost, "/api/editor/tasks", outside, "tok"); w.Code != http.StatusBadRequest {
+		t.Errorf("outside file status = %d, want 400", w.Code)
+	}
+
+	// Summary links to the worktree once it exists
+	tk.Branch = "orc/" + tk.Id
+	if err := s.backend.SaveTask(tk); err != nil {
+		t.Fatal(err)
+	}
+	worktree := filepath.Join(s.orcConfig.Worktree.Dir, "orc-"+tk.Id)
+	if err := os.MkdirAll(worktree, 0755); err != nil {
+		t.Fatal(err)
+	}
+	w = editorRequest(s, http.MethodGet, "/api/tasks/"+tk.Id+"/summary?editor=cursor", "", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("summary status = %d, body = %s", w.Code, w.Body.String())
+	}
+	var summary taskSummary
+	if err := json.Unmarshal(w.Body.Bytes(), &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.Status != "created" || summary.TargetBranch != "main" {
+		t.Errorf("summary = %+v", summary)
+	}
+	if summary.Links.Worktree != "cursor://file"+filepath.ToSlash(worktree) {
+		t.Errorf("worktree link = %q", summary.Links.Worktree)
+	}
+	if summary.Links.Web != "http://example.com/tasks/"+tk.Id || summary.Links.Diff != "http://example.com/api/tasks/"+tk.Id+"/diff?base=main" {
+		t.Errorf("links = %+v", summary.Links)
+	}
+
+	if w := editorRequest(s, http.MethodGet, "/api/tasks/"+tk.Id+"/summary?editor=notepad", "", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("unknown editor status = %d, want 400", w.Code)
+	}
+	if w := editorRequest(s, http.MethodGet, "/api/tasks/TASK-999/summary", "", ""); w.Code != http.StatusNotFound {
+		t.Errorf("missing task status = %d, want 404", w.Code)
+	}
+}
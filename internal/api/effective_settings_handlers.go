@@ -0,0 +1,31 @@
+package api
+
+import (
+	"net/http"
+	"path/filepath"
+
+	"github.com/randalmurphal/orc/internal/executor"
+)
+
+// handleTaskEffectiveSettings returns the runtime settings (tools,
+// permission mode, hooks, MCP servers, env) each phase of a task last ran
+// with, for debugging permission issues. MCP secrets are redacted.
+// GET /api/tasks/{id}/effective-settings
+func (s *Server) handleTaskEffectiveSettings(w http.ResponseWriter, r *http.Request) {
+	backend, workDir, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	t, err := backend.LoadTask(r.PathValue("id"))
+	if err != nil || t == nil {
+		s.jsonError(w, "task not found", http.StatusNotFound)
+		return
+	}
+	settings, err := executor.LoadEffectiveSettings(filepath.Join(workDir, executor.RuntimeSettingsDir), t.Id)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.jsonResponse(w, settings)
+}
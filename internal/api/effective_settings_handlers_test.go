@@ -0,0 +1,50 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/executor"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestHandleTaskEffectiveSettings(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	if err := backend.SaveTask(task.NewProtoTask("TASK-001", "Ran")); err != nil {
+		t.Fatal(err)
+	}
+	workDir := t.TempDir()
+	recorded := filepath.Join(workDir, executor.RuntimeSettingsDir, "tasks", "TASK-001.json")
+	if err := os.MkdirAll(filepath.Dir(recorded), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(recorded, []byte(`{"task_id":"TASK-001","phases":{"implement":{"phase":"implement","provider":"claude","key":"abc","runtime_config":{"shared":{"allowed_tools":["Read"]}}}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: workDir, backend: backend}
+	s.registerRESTRoutes()
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/tasks/TASK-001/effective-settings", nil))
+	var resp executor.TaskEffectiveSettings
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &resp) != nil {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	implement := resp.Phases["implement"]
+	if implement == nil || implement.Key != "abc" || len(implement.RuntimeConfig.Shared.AllowedTools) != 1 {
+		t.Errorf("response = %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/tasks/TASK-404/effective-settings", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown task: status = %d, want 404", w.Code)
+	}
+}
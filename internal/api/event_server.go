@@ -77,7 +77,7 @@ func (s *eventServer) getProjectDB(projectID string) (*db.ProjectDB, error) {
 	if err != nil {
 		return nil, err
 	}
-	if dbBackend, ok := backend.(*storage.DatabaseBackend); ok {
+	if dbBackend, ok := storage.DatabaseOf(backend); ok {
 		return dbBackend.DB(), nil
 	}
 	return nil, fmt.Errorf("backend is not a DatabaseBackend")
@@ -294,11 +294,18 @@ func (s *eventServer) GetTimeline(
 	// Get total count
 	total, _ := pdb.CountEvents(opts)
 
+	// Phase confidence scores are attached to phase completion events
+	scores, err := pdb.GetPhaseConfidences(req.Msg.TaskId)
+	if err != nil {
+		s.logger.Warn("failed to load phase confidence scores", "task", req.Msg.TaskId, "error", err)
+	}
+
 	// Convert to timeline events
 	timelineEvents := make([]*orcv1.TimelineEvent, 0, len(dbEvents))
 	for _, e := range dbEvents {
 		te := dbEventToTimelineEvent(&e)
 		if te != nil {
+			attachPhaseConfidence(te, &e, scores)
 			timelineEvents = append(timelineEvents, te)
 		}
 	}
@@ -570,11 +577,12 @@ func internalEventToProto(e events.Event) *orcv1.Event {
 			return nil
 		}
 		details, _ := json.Marshal(map[string]any{
-			"phase":     line.Phase,
-			"type":      transcriptEventType(line.Type),
-			"content":   line.Content,
-			"timestamp": line.Timestamp.Format(time.RFC3339Nano),
-			"model":     line.Model,
+			"phase":        line.Phase,
+			"type":         transcriptEventType(line.Type),
+			"content":      line.Content,
+			"timestamp":    line.Timestamp.Format(time.RFC3339Nano),
+			"model":        line.Model,
+			"message_uuid": line.MessageUUID,
 			"tokens": map[string]int{
 				"input":  transcriptTokenValue(line.Tokens, func(t events.TokenUpdate) int { return t.InputTokens }),
 				"output": transcriptTokenValue(line.Tokens, func(t events.TokenUpdate) int { return t.OutputTokens }),
@@ -882,6 +890,31 @@ func dbEventToTimelineEvent(e *db.EventLogWithTitle) *orcv1.TimelineEvent {
 	return result
 }
 
+// attachPhaseConfidence sets the data of a phase completion event to the
+// event's own data plus the phase's confidence score and risk factors.
+func attachPhaseConfidence(te *orcv1.TimelineEvent, e *db.EventLogWithTitle, scores map[string]*db.PhaseConfidence) {
+	if e.EventType != "phase" || e.Phase == nil {
+		return
+	}
+	score := scores[*e.Phase]
+	data, _ := e.Data.(map[string]any)
+	if score == nil || getString(data, "status") != "completed" {
+		return
+	}
+
+	merged := make(map[string]any, len(data)+3)
+	for k, v := range data {
+		merged[k] = v
+	}
+	merged["confidence"] = score.Confidence
+	merged["risk_factors"] = score.RiskFactors
+	merged["confidence_escalated"] = score.Escalated
+	if encoded, err := json.Marshal(merged); err == nil {
+		str := string(encoded)
+		te.Data = &str
+	}
+}
+
 // protoTimelineTypeToString converts proto timeline type to db event type.
 func protoTimelineTypeToString(t orcv1.TimelineEventType) string {
 	switch t {
@@ -1032,3 +1032,34 @@ func TestDbEventToTimelineEvent_UsesDatabaseID(t *testing.T) {
 		t.Errorf("expected timeline event ID to be database ID %q, got %q", expectedID, timelineEvent.Id)
 	}
 }
+
+func TestAttachPhaseConfidence(t *testing.T) {
+	t.Parallel()
+	scores := map[string]*db.PhaseConfidence{
+		"implement": {PhaseID: "implement", Confidence: 0.42, RiskFactors: []string{"no tests"}, Escalated: true},
+	}
+	newEvent := func(phase, status string) *db.EventLogWithTitle {
+		return &db.EventLogWithTitle{EventLog: db.EventLog{
+			ID: 1, TaskID: "TASK-001", EventType: "phase", Phase: &phase,
+			Data: map[string]any{"phase": phase, "status": status},
+		}}
+	}
+
+	completed := newEvent("implement", "completed")
+	te := dbEventToTimelineEvent(completed)
+	attachPhaseConfidence(te, completed, scores)
+	require.NotNil(t, te.Data)
+	var data map[string]any
+	require.NoError(t, json.Unmarshal([]byte(*te.Data), &data))
+	require.Equal(t, 0.42, data["confidence"])
+	require.Equal(t, []any{"no tests"}, data["risk_factors"])
+	require.Equal(t, true, data["confidence_escalated"])
+	require.Equal(t, "completed", data["status"])
+
+	// Only completion events of scored phases carry a score.
+	for _, e := range []*db.EventLogWithTitle{newEvent("implement", "running"), newEvent("spec", "completed")} {
+		te := dbEventToTimelineEvent(e)
+		attachPhaseConfidence(te, e, scores)
+		require.Nil(t, te.Data, "phase %s", *e.Phase)
+	}
+}
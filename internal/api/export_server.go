@@ -417,10 +417,16 @@ func (s *ExportServer) importTask(backend storage.Backend, data []byte, _ string
 	if export.Task == nil {
 		return false, false, "no task found"
 	}
+	return s.importTaskExport(backend, &export, false, dryRun)
+}
 
+// importTaskExport imports a parsed task export. Unless force is set, an
+// existing task is only replaced by a newer export.
+// Returns (imported, skipped, errorMessage).
+func (s *ExportServer) importTaskExport(backend storage.Backend, export *exportData, force, dryRun bool) (bool, bool, string) {
 	// Check if task exists
 	existing, _ := backend.LoadTask(export.Task.Id)
-	if existing != nil {
+	if existing != nil && !force {
 		// Smart merge: compare timestamps
 		exportTime := time.Time{}
 		existingTime := time.Time{}
@@ -452,28 +458,7 @@ func (s *ExportServer) importTask(backend storage.Backend, data []byte, _ string
 		return false, false, fmt.Sprintf("save error: %v", err)
 	}
 
-	// Import transcripts with deduplication
-	if len(export.Transcripts) > 0 {
-		existingTranscripts, _ := backend.GetTranscripts(export.Task.Id)
-		transcriptKeys := make(map[string]bool)
-		for _, t := range existingTranscripts {
-			if t.MessageUUID != "" {
-				transcriptKeys[t.MessageUUID] = true
-			}
-		}
-
-		for i := range export.Transcripts {
-			t := &export.Transcripts[i]
-			if t.MessageUUID != "" && transcriptKeys[t.MessageUUID] {
-				continue // Skip duplicate
-			}
-			if err := backend.AddTranscript(t); err != nil {
-				s.logger.Warn("could not import transcript", "error", err)
-			} else if t.MessageUUID != "" {
-				transcriptKeys[t.MessageUUID] = true
-			}
-		}
-	}
+	s.importTranscripts(backend, export.Task.Id, export.Transcripts)
 
 	// Import gate decisions
 	for i := range export.GateDecisions {
@@ -510,9 +495,43 @@ func (s *ExportServer) importTask(backend storage.Backend, data []byte, _ string
 		}
 	}
 
+	// Import plan
+	if export.Plan != nil {
+		if err := backend.SavePlan(export.Plan); err != nil {
+			s.logger.Warn("could not import plan", "error", err)
+		}
+	}
+
 	return true, false, ""
 }
 
+// importTranscripts adds transcripts for a task, skipping messages that are
+// already present.
+func (s *ExportServer) importTranscripts(backend storage.Backend, taskID string, transcripts []storage.Transcript) {
+	if len(transcripts) == 0 {
+		return
+	}
+	existingTranscripts, _ := backend.GetTranscripts(taskID)
+	transcriptKeys := make(map[string]bool)
+	for _, t := range existingTranscripts {
+		if t.MessageUUID != "" {
+			transcriptKeys[t.MessageUUID] = true
+		}
+	}
+
+	for i := range transcripts {
+		t := &transcripts[i]
+		if t.MessageUUID != "" && transcriptKeys[t.MessageUUID] {
+			continue // Skip duplicate
+		}
+		if err := backend.AddTranscript(t); err != nil {
+			s.logger.Warn("could not import transcript", "error", err)
+		} else if t.MessageUUID != "" {
+			transcriptKeys[t.MessageUUID] = true
+		}
+	}
+}
+
 // importInitiative imports an initiative from YAML data.
 func (s *ExportServer) importInitiative(backend storage.Backend, data []byte, _ string, dryRun bool) (bool, bool, string) {
 	var export initiativeExportData
@@ -562,40 +581,41 @@ func writeTarFile(tw *tar.Writer, name string, data []byte) error {
 
 // Export data types (duplicated from CLI to avoid circular imports)
 type exportManifest struct {
-	Version             int       `yaml:"version"`
-	ExportedAt          time.Time `yaml:"exported_at"`
-	SourceHostname      string    `yaml:"source_hostname"`
-	SourceProject       string    `yaml:"source_project,omitempty"`
-	OrcVersion          string    `yaml:"orc_version,omitempty"`
-	TaskCount           int       `yaml:"task_count"`
-	InitiativeCount     int       `yaml:"initiative_count"`
-	IncludesState       bool      `yaml:"includes_state"`
-	IncludesTranscripts bool      `yaml:"includes_transcripts"`
+	Version             int       `yaml:"version" json:"version"`
+	ExportedAt          time.Time `yaml:"exported_at" json:"exported_at"`
+	SourceHostname      string    `yaml:"source_hostname" json:"source_hostname"`
+	SourceProject       string    `yaml:"source_project,omitempty" json:"source_project,omitempty"`
+	OrcVersion          string    `yaml:"orc_version,omitempty" json:"orc_version,omitempty"`
+	TaskCount           int       `yaml:"task_count" json:"task_count"`
+	InitiativeCount     int       `yaml:"initiative_count" json:"initiative_count"`
+	IncludesState       bool      `yaml:"includes_state" json:"includes_state"`
+	IncludesTranscripts bool      `yaml:"includes_transcripts" json:"includes_transcripts"`
 }
 
 type exportData struct {
-	Version        int                      `yaml:"version"`
-	ExportedAt     time.Time                `yaml:"exported_at"`
-	Task           *orcv1.Task              `yaml:"task"`
-	Spec           string                   `yaml:"spec,omitempty"`
-	Transcripts    []storage.Transcript     `yaml:"transcripts,omitempty"`
-	GateDecisions  []db.GateDecision        `yaml:"gate_decisions,omitempty"`
-	TaskComments   []storage.TaskComment    `yaml:"task_comments,omitempty"`
-	ReviewComments []storage.ReviewComment  `yaml:"review_comments,omitempty"`
-	Attachments    []attachmentExport       `yaml:"attachments,omitempty"`
+	Version        int                     `yaml:"version" json:"version"`
+	ExportedAt     time.Time               `yaml:"exported_at" json:"exported_at"`
+	Task           *orcv1.Task             `yaml:"task" json:"task"`
+	Spec           string                  `yaml:"spec,omitempty" json:"spec,omitempty"`
+	Plan           *db.Plan                `yaml:"plan,omitempty" json:"plan,omitempty"`
+	Transcripts    []storage.Transcript    `yaml:"transcripts,omitempty" json:"transcripts,omitempty"`
+	GateDecisions  []db.GateDecision       `yaml:"gate_decisions,omitempty" json:"gate_decisions,omitempty"`
+	TaskComments   []storage.TaskComment   `yaml:"task_comments,omitempty" json:"task_comments,omitempty"`
+	ReviewComments []storage.ReviewComment `yaml:"review_comments,omitempty" json:"review_comments,omitempty"`
+	Attachments    []attachmentExport      `yaml:"attachments,omitempty" json:"attachments,omitempty"`
 }
 
 type attachmentExport struct {
-	Filename    string `yaml:"filename"`
-	ContentType string `yaml:"content_type"`
-	SizeBytes   int64  `yaml:"size_bytes"`
-	IsImage     bool   `yaml:"is_image"`
-	Data        []byte `yaml:"data"`
+	Filename    string `yaml:"filename" json:"filename"`
+	ContentType string `yaml:"content_type" json:"content_type"`
+	SizeBytes   int64  `yaml:"size_bytes" json:"size_bytes"`
+	IsImage     bool   `yaml:"is_image" json:"is_image"`
+	Data        []byte `yaml:"data" json:"data"`
 }
 
 type initiativeExportData struct {
-	Version    int                      `yaml:"version"`
-	ExportedAt time.Time                `yaml:"exported_at"`
-	Type       string                   `yaml:"type"`
-	Initiative *initiative.Initiative   `yaml:"initiative"`
+	Version    int                    `yaml:"version"`
+	ExportedAt time.Time              `yaml:"exported_at"`
+	Type       string                 `yaml:"type"`
+	Initiative *initiative.Initiative `yaml:"initiative"`
 }
@@ -0,0 +1,222 @@
+package api
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/taskbundle"
+)
+
+// taskBundle is a single-task bundle written by `orc task export`, in its
+// tar.gz layout (manifest.yaml, tasks/<id>.yaml, workflow_runs/<run>.yaml)
+// or as one JSON document.
+type taskBundle = taskbundle.Bundle[exportManifest, exportData, *workflowRunExportData]
+
+// TaskMessage returns the exported task for taskbundle.
+func (d *exportData) TaskMessage() *orcv1.Task { return d.Task }
+
+// SetTaskMessage sets the exported task for taskbundle.
+func (d *exportData) SetTaskMessage(t *orcv1.Task) { d.Task = t }
+
+type workflowRunExportData struct {
+	Version     int                        `yaml:"version" json:"version"`
+	ExportedAt  time.Time                  `yaml:"exported_at" json:"exported_at"`
+	Type        string                     `yaml:"type" json:"type"`
+	WorkflowRun *db.WorkflowRun            `yaml:"workflow_run" json:"workflow_run"`
+	Phases      []*db.WorkflowRunPhase     `yaml:"phases,omitempty" json:"phases,omitempty"`
+	Outputs     []*storage.PhaseOutputInfo `yaml:"outputs,omitempty" json:"outputs,omitempty"`
+}
+
+// TaskImportResult represents the JSON response for a task bundle import.
+type TaskImportResult struct {
+	TaskID               string   `json:"task_id"`
+	Imported             bool     `json:"imported"`
+	Skipped              bool     `json:"skipped"`
+	Reason               string   `json:"reason,omitempty"`
+	WasRunning           bool     `json:"was_running,omitempty"`
+	WorkflowRunsImported int      `json:"workflow_runs_imported"`
+	Errors               []string `json:"errors,omitempty"`
+}
+
+// HandleImportTask handles POST /api/tasks/import requests.
+// The body is a bundle from `orc task export`, tar.gz or JSON. Merge rules
+// match POST /api/import: an existing task is only replaced by a newer
+// bundle unless force=true. A task that was running is imported as paused.
+func (s *ExportServer) HandleImportTask(w http.ResponseWriter, r *http.Request) {
+	backend, err := s.getBackend(r.URL.Query().Get("project_id"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to resolve backend: %v", err), http.StatusInternalServerError)
+		return
+	}
+	force := r.URL.Query().Get("force") == "true"
+
+	bundle, err := parseTaskBundle(http.MaxBytesReader(w, r.Body, maxImportFileSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := TaskImportResult{TaskID: bundle.Task.Task.Id}
+	wasRunning := bundle.Task.Task.Status == orcv1.TaskStatus_TASK_STATUS_RUNNING
+	// Transcripts reference workflow runs, so they are imported last.
+	transcripts := bundle.Task.Transcripts
+	bundle.Task.Transcripts = nil
+	imported, skipped, errMsg := s.importTaskExport(backend, bundle.Task, force, false)
+	result.Imported, result.Skipped = imported, skipped
+	switch {
+	case skipped:
+		result.Reason = errMsg
+		s.writeTaskImportResult(w, result, http.StatusConflict)
+		return
+	case errMsg != "":
+		result.Errors = append(result.Errors, errMsg)
+		s.writeTaskImportResult(w, result, http.StatusInternalServerError)
+		return
+	}
+	result.WasRunning = wasRunning
+
+	for _, run := range bundle.Runs {
+		if run.WorkflowRun == nil {
+			continue
+		}
+		ok, errMsg := s.importWorkflowRun(backend, run, force)
+		if ok {
+			result.WorkflowRunsImported++
+		} else if errMsg != "" {
+			result.Errors = append(result.Errors, fmt.Sprintf("workflow run %s: %s", run.WorkflowRun.ID, errMsg))
+		}
+	}
+	s.importTranscripts(backend, result.TaskID, transcripts)
+
+	s.writeTaskImportResult(w, result, http.StatusOK)
+}
+
+func (s *ExportServer) writeTaskImportResult(w http.ResponseWriter, result TaskImportResult, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		s.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// importWorkflowRun imports a workflow run with its phases and outputs.
+// Unless force is set, an existing run is only replaced by a newer export.
+// Returns (imported, errorMessage); a skipped run returns no message.
+func (s *ExportServer) importWorkflowRun(backend storage.Backend, export *workflowRunExportData, force bool) (bool, string) {
+	existing, _ := backend.GetWorkflowRun(export.WorkflowRun.ID)
+	if existing != nil && !force && !export.WorkflowRun.UpdatedAt.After(existing.UpdatedAt) {
+		return false, ""
+	}
+	if export.WorkflowRun.Status == "running" {
+		export.WorkflowRun.Status = "paused"
+	}
+	if err := backend.SaveWorkflowRun(export.WorkflowRun); err != nil {
+		return false, fmt.Sprintf("save error: %v", err)
+	}
+	for _, phase := range export.Phases {
+		if err := backend.SaveWorkflowRunPhase(phase); err != nil {
+			s.logger.Warn("could not import workflow run phase", "phase", phase.PhaseTemplateID, "error", err)
+		}
+	}
+	for _, output := range export.Outputs {
+		output.ID = 0 // IDs are local to each database
+		if err := backend.SavePhaseOutput(output); err != nil {
+			s.logger.Warn("could not import phase output", "phase", output.PhaseTemplateID, "error", err)
+		}
+	}
+	return true, ""
+}
+
+// parseTaskBundle reads a task bundle in either format, detected from the
+// gzip header.
+func parseTaskBundle(r io.Reader) (*taskBundle, error) {
+	reader := bufio.NewReader(r)
+	var bundle *taskBundle
+	var err error
+	if taskbundle.IsArchive(reader) {
+		bundle, err = parseTaskBundleArchive(reader)
+	} else {
+		bundle = &taskBundle{}
+		if decodeErr := json.NewDecoder(reader).Decode(bundle); decodeErr != nil {
+			err = fmt.Errorf("not a task bundle: %w", decodeErr)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if bundle.Manifest.Version == 0 || bundle.Task == nil || bundle.Task.Task == nil {
+		return nil, errors.New("not a task bundle (missing manifest or task)")
+	}
+	if bundle.Manifest.Version > exportFormatVersion {
+		return nil, fmt.Errorf("bundle format version %d is newer than supported version %d",
+			bundle.Manifest.Version, exportFormatVersion)
+	}
+	return bundle, nil
+}
+
+// parseTaskBundleArchive reads the tar.gz form of a task bundle.
+func parseTaskBundleArchive(r io.Reader) (*taskBundle, error) {
+	gzReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a task bundle: %w", err)
+	}
+	defer func() { _ = gzReader.Close() }()
+
+	bundle := &taskBundle{}
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read bundle: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(io.LimitReader(tarReader, maxImportFileSize))
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", header.Name, err)
+		}
+
+		name := filepath.ToSlash(header.Name)
+		switch {
+		case name == "manifest.yaml":
+			if err := yaml.Unmarshal(data, &bundle.Manifest); err != nil {
+				return nil, fmt.Errorf("parse manifest: %w", err)
+			}
+		case strings.HasPrefix(name, "tasks/"):
+			if bundle.Task != nil {
+				return nil, errors.New("archive contains more than one task; use POST /api/import for multi-task archives")
+			}
+			var export exportData
+			if err := yaml.Unmarshal(data, &export); err != nil {
+				return nil, fmt.Errorf("parse %s: %w", name, err)
+			}
+			bundle.Task = &export
+		case strings.HasPrefix(name, "workflow_runs/"):
+			var run workflowRunExportData
+			if err := yaml.Unmarshal(data, &run); err != nil {
+				return nil, fmt.Errorf("parse %s: %w", name, err)
+			}
+			bundle.Runs = append(bundle.Runs, &run)
+		}
+	}
+	return bundle, nil
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+func testTaskBundleJSON(t *testing.T, status orcv1.TaskStatus, updatedAt time.Time) []byte {
+	t.Helper()
+	taskID := "TASK-200"
+	bundle := taskBundle{
+		Manifest: exportManifest{Version: exportFormatVersion, ExportedAt: time.Now(), TaskCount: 1, IncludesState: true},
+		Task: &exportData{
+			Version:    exportFormatVersion,
+			ExportedAt: time.Now(),
+			Task: &orcv1.Task{
+				Id:        taskID,
+				Title:     "Bundled Task",
+				Status:    status,
+				CreatedAt: timestamppb.New(updatedAt),
+				UpdatedAt: timestamppb.New(updatedAt),
+			},
+			Spec: "# Spec",
+			Transcripts: []storage.Transcript{
+				{TaskID: taskID, Phase: "spec", WorkflowRunID: "RUN-200", MessageUUID: "m1", Type: "assistant", Content: "hello", Timestamp: updatedAt.UnixMilli()},
+			},
+		},
+		Runs: []*workflowRunExportData{{
+			Version:     exportFormatVersion,
+			Type:        "workflow_run",
+			WorkflowRun: &db.WorkflowRun{ID: "RUN-200", WorkflowID: "wf-bundle", ContextType: "task", TaskID: &taskID, Prompt: "p", Status: "running"},
+			Outputs: []*storage.PhaseOutputInfo{
+				{ID: 42, WorkflowRunID: "RUN-200", PhaseTemplateID: "spec", TaskID: &taskID, Content: "# Spec", OutputVarName: "SPEC_CONTENT", Source: "executor"},
+			},
+		}},
+	}
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatalf("marshal bundle: %v", err)
+	}
+	return data
+}
+
+func postTaskBundle(t *testing.T, server *ExportServer, query string, body []byte) (*httptest.ResponseRecorder, TaskImportResult) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/tasks/import"+query, bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	server.HandleImportTask(rec, req)
+
+	var result TaskImportResult
+	if rec.Code != http.StatusBadRequest {
+		if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+	}
+	return rec, result
+}
+
+func TestHandleImportTask_JSONBundle(t *testing.T) {
+	t.Parallel()
+
+	backend := storage.NewTestBackend(t)
+	if err := backend.SaveWorkflow(&db.Workflow{ID: "wf-bundle", Name: "Bundle"}); err != nil {
+		t.Fatal(err)
+	}
+	server := NewExportServer(backend, t.TempDir(), nil)
+
+	rec, result := postTaskBundle(t, server, "", testTaskBundleJSON(t, orcv1.TaskStatus_TASK_STATUS_RUNNING, time.Now()))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	if !result.Imported || !result.WasRunning || result.WorkflowRunsImported != 1 || len(result.Errors) != 0 {
+		t.Errorf("result = %+v", result)
+	}
+
+	got, err := backend.LoadTask("TASK-200")
+	if err != nil {
+		t.Fatalf("load imported task: %v", err)
+	}
+	if got.Status != orcv1.TaskStatus_TASK_STATUS_PAUSED {
+		t.Errorf("imported running task status = %v, want paused", got.Status)
+	}
+	if run, err := backend.GetWorkflowRun("RUN-200"); err != nil || run == nil || run.Status != "paused" {
+		t.Errorf("imported run = %+v, %v; want paused run", run, err)
+	}
+	if out, err := backend.GetPhaseOutput("RUN-200", "spec"); err != nil || out == nil || out.Content != "# Spec" {
+		t.Errorf("imported phase output = %+v, %v", out, err)
+	}
+	if transcripts, _ := backend.GetTranscripts("TASK-200"); len(transcripts) != 1 {
+		t.Errorf("imported %d transcripts, want 1", len(transcripts))
+	}
+}
+
+func TestHandleImportTask_TarBundle(t *testing.T) {
+	t.Parallel()
+
+	backend := storage.NewTestBackend(t)
+	server := NewExportServer(backend, t.TempDir(), nil)
+
+	archive := createTestArchive(t, []testTask{{ID: "TASK-201", Title: "Archived Task"}}, nil)
+	rec, result := postTaskBundle(t, server, "", archive)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	if result.TaskID != "TASK-201" || !result.Imported {
+		t.Errorf("result = %+v", result)
+	}
+	if got, err := backend.LoadTask("TASK-201"); err != nil || got.Title != "Archived Task" {
+		t.Errorf("imported task = %+v, %v", got, err)
+	}
+}
+
+func TestHandleImportTask_ExistingNewerTask(t *testing.T) {
+	t.Parallel()
+
+	backend := storage.NewTestBackend(t)
+	if err := backend.SaveWorkflow(&db.Workflow{ID: "wf-bundle", Name: "Bundle"}); err != nil {
+		t.Fatal(err)
+	}
+	server := NewExportServer(backend, t.TempDir(), nil)
+	if rec, _ := postTaskBundle(t, server, "", testTaskBundleJSON(t, orcv1.TaskStatus_TASK_STATUS_CREATED, time.Now())); rec.Code != http.StatusOK {
+		t.Fatalf("initial import status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	stale := testTaskBundleJSON(t, orcv1.TaskStatus_TASK_STATUS_COMPLETED, time.Now().Add(-time.Hour))
+	rec, result := postTaskBundle(t, server, "", stale)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409", rec.Code)
+	}
+	if !result.Skipped || result.Reason == "" {
+		t.Errorf("result = %+v", result)
+	}
+
+	rec, result = postTaskBundle(t, server, "?force=true", stale)
+	if rec.Code != http.StatusOK || !result.Imported {
+		t.Fatalf("forced import status = %d, result = %+v", rec.Code, result)
+	}
+	if got, _ := backend.LoadTask("TASK-200"); got.Status != orcv1.TaskStatus_TASK_STATUS_COMPLETED {
+		t.Errorf("forced import status = %v, want completed", got.Status)
+	}
+	if transcripts, _ := backend.GetTranscripts("TASK-200"); len(transcripts) != 1 {
+		t.Errorf("got %d transcripts after re-import, want 1", len(transcripts))
+	}
+}
+
+func TestHandleImportTask_RejectsInvalidBody(t *testing.T) {
+	t.Parallel()
+
+	server := NewExportServer(storage.NewTestBackend(t), t.TempDir(), nil)
+	for name, body := range map[string]string{
+		"garbage":        "not a bundle",
+		"missing task":   `{"manifest":{"version":1}}`,
+		"future version": `{"manifest":{"version":999},"task":{"task":{"id":"TASK-1"}}}`,
+	} {
+		rec, _ := postTaskBundle(t, server, "", []byte(body))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", name, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	"github.com/randalmurphal/orc/internal/config"
+)
+
+// serviceFeatures maps Connect services to the feature flag that gates them.
+// Calls to a disabled feature's service fail with unimplemented, which the UI
+// avoids by checking GET /api/features first.
+var serviceFeatures = map[string]string{
+	"orc.v1.InitiativeService": "initiatives",
+	"orc.v1.AutomationService": "automation",
+}
+
+// featuresResponse is the GET /api/features response body.
+type featuresResponse struct {
+	// Features maps each flag name to whether it is on, for simple checks
+	Features map[string]bool       `json:"features"`
+	Flags    []config.FeatureState `json:"flags"`
+	ReadOnly bool                  `json:"read_only"`
+}
+
+// handleFeatures reports which runtime features this server has enabled.
+// GET /api/features
+func (s *Server) handleFeatures(w http.ResponseWriter, r *http.Request) {
+	cfg := s.orcConfig
+	if cfg == nil {
+		cfg = config.Default()
+	}
+	flags := cfg.ResolveFeatures()
+	resp := featuresResponse{
+		Features: make(map[string]bool, len(flags)),
+		Flags:    flags,
+		ReadOnly: s.readOnly,
+	}
+	for _, f := range flags {
+		resp.Features[f.Name] = f.Enabled
+	}
+	s.jsonResponse(w, resp)
+}
+
+// featureDisabledError returns the error for a procedure whose service is
+// gated by a disabled feature, or nil.
+func (s *Server) featureDisabledError(procedure string) error {
+	if s.orcConfig == nil {
+		return nil
+	}
+	service := strings.Trim(procedure[:strings.LastIndex(procedure, "/")+1], "/")
+	feature, ok := serviceFeatures[service]
+	if !ok || s.orcConfig.FeatureEnabled(feature) {
+		return nil
+	}
+	return connect.NewError(connect.CodeUnimplemented, fmt.Errorf("feature %q is disabled on this server", feature))
+}
+
+// featureInterceptor rejects RPCs to services whose feature is disabled.
+type featureInterceptor struct {
+	s *Server
+}
+
+var _ connect.Interceptor = (*featureInterceptor)(nil)
+
+func (i *featureInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if err := i.s.featureDisabledError(req.Spec().Procedure); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *featureInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *featureInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := i.s.featureDisabledError(conn.Spec().Procedure); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
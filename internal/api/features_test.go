@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/gen/proto/orc/v1/orcv1connect"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/diff"
+	"github.com/randalmurphal/orc/internal/gate"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+func TestHandleFeatures(t *testing.T) {
+	cfg := config.Default()
+	cfg.FeatureFlags = map[string]bool{"initiatives": false}
+	s := &Server{logger: slog.Default(), orcConfig: cfg, readOnly: true}
+
+	rec := httptest.NewRecorder()
+	s.handleFeatures(rec, httptest.NewRequest(http.MethodGet, "/api/features", nil))
+	var resp featuresResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if enabled, ok := resp.Features["initiatives"]; !ok || enabled {
+		t.Errorf("initiatives = %v (present %v), want disabled", enabled, ok)
+	}
+	if len(resp.Flags) != len(config.Features) || !resp.ReadOnly {
+		t.Errorf("response = %+v", resp)
+	}
+}
+
+func TestFeatureInterceptor_RejectsDisabledService(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	cfg := config.Default()
+	s := &Server{
+		mux:              http.NewServeMux(),
+		backend:          backend,
+		logger:           slog.Default(),
+		orcConfig:        cfg,
+		workDir:          t.TempDir(),
+		projectDB:        backend.DB(),
+		runningTasks:     make(map[string]context.CancelFunc),
+		diffCache:        diff.NewCache(10),
+		pendingDecisions: gate.NewPendingDecisionStore(),
+		projectCache:     testProjectCacheForBackend("proj-001", backend),
+	}
+	s.registerConnectHandlers()
+	srv := httptest.NewServer(s.mux)
+	defer srv.Close()
+
+	client := orcv1connect.NewInitiativeServiceClient(srv.Client(), srv.URL)
+	req := connect.NewRequest(&orcv1.ListInitiativesRequest{ProjectId: "proj-001"})
+	if _, err := client.ListInitiatives(context.Background(), req); err != nil {
+		t.Fatalf("enabled feature: %v", err)
+	}
+
+	cfg.FeatureFlags = map[string]bool{"initiatives": false}
+	_, err := client.ListInitiatives(context.Background(), req)
+	if connect.CodeOf(err) != connect.CodeUnimplemented {
+		t.Errorf("disabled feature err = %v, want unimplemented", err)
+	}
+}
@@ -45,6 +45,7 @@ func (s *Server) registerFileRoutes() {
 	exportServer.SetProjectCache(s.projectCache)
 	s.mux.HandleFunc("POST /api/export", cors(exportServer.HandleExport))
 	s.mux.HandleFunc("POST /api/import", cors(exportServer.HandleImport))
+	s.mux.HandleFunc("POST /api/tasks/import", cors(exportServer.HandleImportTask))
 
 	// Static files (embedded frontend) - catch-all for non-API routes
 	s.mux.Handle("/", staticHandler())
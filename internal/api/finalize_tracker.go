@@ -298,9 +298,13 @@ func (s *Server) runFinalizeAsync(ctx context.Context, taskID string, _ *orcv1.T
 	s.publishFinalizeEvent(taskID, finState)
 
 	gitCfg := git.Config{
-		BranchPrefix: s.orcConfig.BranchPrefix,
-		CommitPrefix: s.orcConfig.CommitPrefix,
-		WorktreeDir:  config.ResolveWorktreeDir(s.orcConfig.Worktree.Dir, workDir),
+		BranchPrefix:      s.orcConfig.BranchPrefix,
+		CommitPrefix:      s.orcConfig.CommitPrefix,
+		WorktreeDir:       config.ResolveWorktreeDir(s.orcConfig.Worktree.Dir, workDir),
+		ProtectedBranches: s.orcConfig.ProtectedBranchList(),
+		IdentityName:      s.orcConfig.GitIdentity.Name,
+		IdentityEmail:     s.orcConfig.GitIdentity.Email,
+		BypassedHooks:     s.orcConfig.GitHooks.BypassedHooks(),
 	}
 	gitSvc, err := git.New(workDir, gitCfg)
 	if err != nil {
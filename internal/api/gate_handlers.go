@@ -0,0 +1,188 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/gate"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// pendingGateResponse is a human gate awaiting a decision.
+type pendingGateResponse struct {
+	*db.GateApproval
+	TaskTitle string `json:"task_title,omitempty"`
+}
+
+// resolveGateRequest approves or rejects a gate. Both fields are optional.
+type resolveGateRequest struct {
+	Comment    string `json:"comment"`
+	ResolvedBy string `json:"resolved_by"`
+}
+
+// handleListPendingGates lists the human gates awaiting a decision across
+// the project's tasks, oldest first.
+// GET /api/gates
+func (s *Server) handleListPendingGates(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	approvals, err := backend.DB().ListPendingGateApprovals()
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	gates := make([]pendingGateResponse, 0, len(approvals))
+	for _, a := range approvals {
+		resp := pendingGateResponse{GateApproval: a}
+		if t, err := backend.LoadTask(a.TaskID); err == nil && t != nil {
+			resp.TaskTitle = t.Title
+		}
+		gates = append(gates, resp)
+	}
+	s.jsonResponse(w, gates)
+}
+
+// handleApproveGate approves the pending gate of a task's phase.
+// POST /api/tasks/{id}/gates/{phase}/approve
+func (s *Server) handleApproveGate(w http.ResponseWriter, r *http.Request) {
+	s.handleResolveGate(w, r, true)
+}
+
+// handleRejectGate rejects the pending gate of a task's phase.
+// POST /api/tasks/{id}/gates/{phase}/reject
+func (s *Server) handleRejectGate(w http.ResponseWriter, r *http.Request) {
+	s.handleResolveGate(w, r, false)
+}
+
+func (s *Server) handleResolveGate(w http.ResponseWriter, r *http.Request, approved bool) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req resolveGateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		s.jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	taskID, phase := r.PathValue("id"), r.PathValue("phase")
+	approval, err := backend.DB().GetOpenGateApproval(taskID, phase)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if approval == nil || approval.Status != db.GateApprovalPending {
+		s.jsonError(w, fmt.Sprintf("no pending gate for task %s phase %s", taskID, phase), http.StatusNotFound)
+		return
+	}
+
+	projectID := r.URL.Query().Get("project_id")
+	if projectID == "" {
+		projectID = s.defaultProjectID()
+	}
+	resolvedBy := req.ResolvedBy
+	if resolvedBy == "" {
+		resolvedBy = "api"
+	}
+	resolved, err := resolveGateApproval(backend, s.pendingDecisions, s.publisher, projectID, approval.ID, approved, req.Comment, resolvedBy)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, db.ErrGateApprovalNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, db.ErrGateApprovalNotPending):
+			status = http.StatusConflict
+		}
+		s.jsonError(w, err.Error(), status)
+		return
+	}
+	s.jsonResponse(w, resolved)
+}
+
+// resolveGateApproval approves or rejects a pending gate approval. An
+// executor waiting at the gate picks the decision up and continues; when
+// none is (the task is blocked at the gate), the decision is applied to the
+// task here, as for a resolved pending decision.
+func resolveGateApproval(
+	backend storage.Backend,
+	pendingDecisions *gate.PendingDecisionStore,
+	publisher events.Publisher,
+	projectID string,
+	approvalID string,
+	approved bool,
+	comment string,
+	resolvedBy string,
+) (*db.GateApproval, error) {
+	pdb := backend.DB()
+	resolved, err := pdb.ResolveGateApproval(approvalID, approved, comment, resolvedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := backend.LoadTask(resolved.TaskID)
+	if err != nil || t == nil || t.Status != orcv1.TaskStatus_TASK_STATUS_BLOCKED || task.GetCurrentPhaseProto(t) != resolved.Phase {
+		return resolved, nil
+	}
+
+	now := time.Now()
+	originalTask := proto.Clone(t).(*orcv1.Task)
+	task.EnsureExecutionProto(t)
+	gateDecision := &orcv1.GateDecision{
+		Phase:     resolved.Phase,
+		GateType:  resolved.GateType,
+		Approved:  approved,
+		Timestamp: timestamppb.New(now),
+	}
+	if comment != "" {
+		gateDecision.Reason = &comment
+	}
+	t.Execution.Gates = append(t.Execution.Gates, gateDecision)
+	if approved {
+		t.Status = orcv1.TaskStatus_TASK_STATUS_PLANNED
+	} else {
+		t.Status = orcv1.TaskStatus_TASK_STATUS_FAILED
+	}
+	task.UpdateTimestampProto(t)
+	if err := transitionTaskWithAttentionSync(backend, publisher, projectID, originalTask, t, resolvedBy); err != nil {
+		return nil, fmt.Errorf("failed to update task attention state: %w", err)
+	}
+	if err := pdb.MarkGateApprovalApplied(resolved.ID); err != nil {
+		return nil, err
+	}
+
+	if pendingDecisions != nil {
+		pendingDecisions.Remove(projectID, resolved.ID)
+	}
+	if publisher != nil {
+		publisher.Publish(events.NewProjectEvent(
+			events.EventDecisionResolved,
+			projectID,
+			resolved.TaskID,
+			events.DecisionResolvedData{
+				DecisionID: resolved.ID,
+				TaskID:     resolved.TaskID,
+				Phase:      resolved.Phase,
+				Approved:   approved,
+				Reason:     comment,
+				ResolvedBy: resolvedBy,
+				ResolvedAt: now,
+			},
+		))
+	}
+	return resolved, nil
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func newGateTestServer(t *testing.T) (*Server, storage.Backend) {
+	t.Helper()
+	backend := storage.NewTestBackend(t)
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir(), backend: backend}
+	s.registerRESTRoutes()
+	return s, backend
+}
+
+func saveGateTask(t *testing.T, backend storage.Backend, id string, status orcv1.TaskStatus) {
+	t.Helper()
+	tk := task.NewProtoTask(id, "Gated "+id)
+	tk.Status = status
+	task.SetCurrentPhaseProto(tk, "review")
+	if err := backend.SaveTask(tk); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.DB().CreateGateApproval(&db.GateApproval{
+		ID: "gate-" + id, TaskID: id, Phase: "review", GateType: "human", Question: "Ship it?",
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHandleListPendingGates(t *testing.T) {
+	s, backend := newGateTestServer(t)
+	saveGateTask(t, backend, "TASK-001", orcv1.TaskStatus_TASK_STATUS_RUNNING)
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/gates", nil))
+	var gates []pendingGateResponse
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &gates) != nil {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if len(gates) != 1 || gates[0].TaskID != "TASK-001" || gates[0].TaskTitle != "Gated TASK-001" || gates[0].Question != "Ship it?" {
+		t.Errorf("gates = %s", w.Body.String())
+	}
+}
+
+func TestHandleApproveGate_RunningTaskLeavesTaskToExecutor(t *testing.T) {
+	s, backend := newGateTestServer(t)
+	saveGateTask(t, backend, "TASK-001", orcv1.TaskStatus_TASK_STATUS_RUNNING)
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/tasks/TASK-001/gates/review/approve",
+		strings.NewReader(`{"comment":"LGTM","resolved_by":"alice"}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	approval, err := backend.DB().GetGateApproval("gate-TASK-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if approval.Status != db.GateApprovalApproved || approval.Comment != "LGTM" || approval.ResolvedBy != "alice" {
+		t.Errorf("approval = %+v", approval)
+	}
+	if approval.AppliedAt != nil {
+		t.Error("approval for a running task should be left for the executor to apply")
+	}
+	tk, err := backend.LoadTask("TASK-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.Status != orcv1.TaskStatus_TASK_STATUS_RUNNING {
+		t.Errorf("task status = %v, want running", tk.Status)
+	}
+
+	// Only the first decision counts.
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/tasks/TASK-001/gates/review/reject", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("second decision: status = %d, want 404", w.Code)
+	}
+}
+
+func TestHandleRejectGate_BlockedTaskFails(t *testing.T) {
+	s, backend := newGateTestServer(t)
+	saveGateTask(t, backend, "TASK-002", orcv1.TaskStatus_TASK_STATUS_BLOCKED)
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/tasks/TASK-002/gates/review/reject", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	tk, err := backend.LoadTask("TASK-002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.Status != orcv1.TaskStatus_TASK_STATUS_FAILED {
+		t.Errorf("task status = %v, want failed", tk.Status)
+	}
+	if gates := tk.GetExecution().GetGates(); len(gates) != 1 || gates[0].Approved {
+		t.Errorf("gate decisions = %v, want one rejection", gates)
+	}
+	approval, err := backend.DB().GetGateApproval("gate-TASK-002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if approval.ResolvedBy != "api" || approval.AppliedAt == nil {
+		t.Errorf("approval = %+v, want resolved by api and applied", approval)
+	}
+}
+
+func TestHandleApproveGate_NoPendingGate(t *testing.T) {
+	s, _ := newGateTestServer(t)
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/tasks/TASK-404/gates/review/approve", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", w.Code)
+	}
+}
@@ -0,0 +1,432 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/db/driver"
+	"github.com/randalmurphal/orc/internal/events"
+)
+
+const (
+	// leaderLeaseName is the lease that decides which instance runs the
+	// background pollers.
+	leaderLeaseName = "server-leader"
+
+	// relayBatchSize bounds how many peer events are read per query.
+	relayBatchSize = 500
+
+	// serverEventRetention is how long relayed events stay in the shared log.
+	serverEventRetention = 10 * time.Minute
+
+	// relayWindow is how far behind the previous poll each poll re-scans.
+	// Event IDs are assigned at insert but become visible at commit, so on
+	// PostgreSQL a lower ID can appear after a higher one was relayed. The
+	// window must cover that commit lag plus clock skew between instances
+	// (created_at is the writer's clock).
+	relayWindow = 30 * time.Second
+
+	// haOutboxSize is how many events may wait to be written for peers
+	// before new ones are dropped.
+	haOutboxSize = 1024
+)
+
+// haCoordinator lets several server instances share one database. One
+// instance holds the leader lease and runs the background pollers; every
+// instance writes its events to a shared log and relays the events of the
+// others to its own subscribers, so clients can stream from any instance.
+type haCoordinator struct {
+	store        *db.GlobalDB
+	closeStore   bool
+	instanceID   string
+	leaseTTL     time.Duration
+	pollInterval time.Duration
+	logger       *slog.Logger
+
+	outbox  chan events.Event
+	deliver func(events.Event) // hands peer events to local subscribers
+	leader  atomic.Bool
+	wg      sync.WaitGroup
+}
+
+func newHACoordinator(store *db.GlobalDB, cfg config.HAConfig, logger *slog.Logger) *haCoordinator {
+	h := &haCoordinator{
+		store:        store,
+		instanceID:   cfg.InstanceID,
+		leaseTTL:     cfg.LeaseTTL,
+		pollInterval: cfg.EventPollInterval,
+		logger:       logger,
+		outbox:       make(chan events.Event, haOutboxSize),
+	}
+	if h.instanceID == "" {
+		host, _ := os.Hostname()
+		h.instanceID = fmt.Sprintf("%s:%d", host, os.Getpid())
+	}
+	if h.leaseTTL <= 0 {
+		h.leaseTTL = 15 * time.Second
+	}
+	if h.pollInterval <= 0 {
+		h.pollInterval = 500 * time.Millisecond
+	}
+	return h
+}
+
+// setupHA enables high-availability mode when configured. Coordination uses
+// the shared PostgreSQL database when database.dialect is "postgres", and
+// the global SQLite database otherwise (instances on the same host).
+func (s *Server) setupHA() {
+	if s.orcConfig == nil || !s.orcConfig.FeatureEnabled("high_availability") {
+		return
+	}
+
+	store, owned, err := s.openHAStore()
+	if err != nil {
+		s.logger.Error("high-availability mode disabled", "error", err)
+		return
+	}
+
+	s.ha = newHACoordinator(store, s.orcConfig.Server.HA, s.logger)
+	s.ha.closeStore = owned
+	pub := &haPublisher{Publisher: s.publisher, ha: s.ha}
+	s.ha.deliver = pub.deliverLocal
+	s.publisher = pub
+	s.logger.Info("high-availability mode enabled", "instance", s.ha.instanceID, "dialect", store.Dialect())
+}
+
+// openHAStore returns the database instances coordinate through and whether
+// the caller owns (and must close) it.
+func (s *Server) openHAStore() (*db.GlobalDB, bool, error) {
+	dbCfg := s.orcConfig.Database
+	if dbCfg.Dialect == string(driver.DialectPostgres) {
+		dsn := os.Getenv(dbCfg.DSNEnv)
+		if dsn == "" {
+			return nil, false, fmt.Errorf("database.dsn_env %q is not set", dbCfg.DSNEnv)
+		}
+		store, err := db.OpenGlobalWithDialect(dsn, driver.DialectPostgres)
+		if err != nil {
+			return nil, false, fmt.Errorf("open shared database: %w", err)
+		}
+		return store, true, nil
+	}
+	if s.globalDB == nil {
+		return nil, false, fmt.Errorf("global database is not available")
+	}
+	return s.globalDB, false, nil
+}
+
+// isLeader reports whether this instance runs the background pollers.
+// Without high-availability mode the server is always the leader.
+func (s *Server) isLeader() bool {
+	return s.ha == nil || s.ha.leader.Load()
+}
+
+// start runs leader election, event forwarding, and the peer relay until ctx
+// is cancelled. leaderFn runs while this instance holds the lease and must
+// return when its context is cancelled; a nil leaderFn never campaigns.
+func (h *haCoordinator) start(ctx context.Context, leaderFn func(context.Context)) {
+	// Only events published after startup are relayed; history comes from
+	// the event log.
+	relay := newEventRelay(time.Now())
+	relay.poll(h.store, h.instanceID, nil, time.Now(), h.logger)
+
+	if leaderFn != nil {
+		h.wg.Add(1)
+		go func() {
+			defer h.wg.Done()
+			h.runElection(ctx, leaderFn)
+		}()
+	}
+	h.wg.Add(2)
+	go func() {
+		defer h.wg.Done()
+		h.runForwarder(ctx)
+	}()
+	go func() {
+		defer h.wg.Done()
+		h.runRelay(ctx, relay)
+	}()
+}
+
+// wait blocks until the coordinator's loops have exited, then closes the
+// coordination database if the coordinator opened it.
+func (h *haCoordinator) wait() {
+	h.wg.Wait()
+	if h.closeStore {
+		if err := h.store.Close(); err != nil {
+			h.logger.Error("shared database close error", "error", err)
+		}
+	}
+}
+
+func (h *haCoordinator) runElection(ctx context.Context, leaderFn func(context.Context)) {
+	var leaderCancel context.CancelFunc
+	var leaderDone chan struct{}
+	stepDown := func() {
+		if leaderCancel == nil {
+			return
+		}
+		leaderCancel()
+		<-leaderDone
+		leaderCancel = nil
+		h.leader.Store(false)
+	}
+
+	ticker := time.NewTicker(h.leaseTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		held, err := h.store.TryAcquireLease(leaderLeaseName, h.instanceID, h.leaseTTL)
+		if err != nil {
+			// A leader that cannot renew must assume a peer will take over.
+			h.logger.Warn("leader lease renewal failed", "instance", h.instanceID, "error", err)
+			held = false
+		}
+		switch {
+		case held && leaderCancel == nil:
+			h.logger.Info("acquired leader lease", "instance", h.instanceID)
+			leaderCtx, cancel := context.WithCancel(ctx)
+			leaderCancel, leaderDone = cancel, make(chan struct{})
+			h.leader.Store(true)
+			go func(done chan struct{}) {
+				defer close(done)
+				leaderFn(leaderCtx)
+			}(leaderDone)
+		case !held && leaderCancel != nil:
+			h.logger.Warn("lost leader lease", "instance", h.instanceID)
+			stepDown()
+		}
+
+		select {
+		case <-ctx.Done():
+			wasLeader := leaderCancel != nil
+			stepDown()
+			if wasLeader {
+				// Hand over immediately instead of waiting for expiry.
+				if err := h.store.ReleaseLease(leaderLeaseName, h.instanceID); err != nil {
+					h.logger.Warn("release leader lease", "error", err)
+				}
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// forward queues an event for peer instances. Session metrics are
+// per-instance and are not shared.
+func (h *haCoordinator) forward(e events.Event) {
+	if e.Type == events.EventSessionUpdate {
+		return
+	}
+	select {
+	case h.outbox <- e:
+	default:
+		h.logger.Warn("dropping event for peer instances: outbox full", "type", e.Type, "task", e.TaskID)
+	}
+}
+
+func (h *haCoordinator) runForwarder(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-h.outbox:
+			payload, err := json.Marshal(e)
+			if err != nil {
+				h.logger.Debug("encode event for peers", "type", e.Type, "error", err)
+				continue
+			}
+			if _, err := h.store.AppendServerEvent(h.instanceID, string(payload)); err != nil {
+				h.logger.Warn("share event with peers", "type", e.Type, "error", err)
+			}
+		}
+	}
+}
+
+func (h *haCoordinator) runRelay(ctx context.Context, relay *eventRelay) {
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+	lastPrune := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		relay.poll(h.store, h.instanceID, h.deliver, time.Now(), h.logger)
+
+		if h.leader.Load() && time.Since(lastPrune) >= time.Minute {
+			lastPrune = time.Now()
+			if _, err := h.store.PruneServerEvents(time.Now().Add(-serverEventRetention)); err != nil {
+				h.logger.Warn("prune shared event log", "error", err)
+			}
+		}
+	}
+}
+
+// eventRelay tracks which peer events have been delivered. Each poll
+// re-scans from relayWindow before the previous poll and skips IDs already
+// seen, instead of trusting a high-water mark that out-of-order commits
+// would jump past.
+type eventRelay struct {
+	scanFrom time.Time
+	seen     map[int64]time.Time // event ID -> created_at
+}
+
+func newEventRelay(now time.Time) *eventRelay {
+	return &eventRelay{scanFrom: now.Add(-relayWindow), seen: make(map[int64]time.Time)}
+}
+
+// poll delivers every unseen peer event in the window. A nil deliver only
+// marks events as seen.
+func (r *eventRelay) poll(store *db.GlobalDB, instanceID string, deliver func(events.Event), now time.Time, logger *slog.Logger) {
+	var cursor int64
+	for {
+		batch, err := store.ListServerEventsSince(r.scanFrom, cursor, instanceID, relayBatchSize)
+		if err != nil {
+			// Keep the window where it is so the next poll retries it.
+			logger.Warn("read shared event log", "error", err)
+			return
+		}
+		for _, se := range batch {
+			cursor = se.ID
+			if _, ok := r.seen[se.ID]; ok {
+				continue
+			}
+			r.seen[se.ID] = se.CreatedAt
+			if deliver == nil {
+				continue
+			}
+			e, err := decodeServerEvent(se.Payload)
+			if err != nil {
+				logger.Debug("decode peer event", "id", se.ID, "origin", se.Origin, "error", err)
+				continue
+			}
+			deliver(e)
+		}
+		if len(batch) < relayBatchSize {
+			break
+		}
+	}
+
+	r.scanFrom = now.Add(-relayWindow)
+	for id, createdAt := range r.seen {
+		if createdAt.Before(r.scanFrom) {
+			delete(r.seen, id)
+		}
+	}
+}
+
+// decodeServerEvent restores an event written by a peer instance. Payloads
+// the stream converters switch on are decoded into their concrete types;
+// anything else arrives as generic JSON.
+func decodeServerEvent(payload string) (events.Event, error) {
+	var wire struct {
+		Type      events.EventType `json:"type"`
+		ProjectID string           `json:"project_id"`
+		TaskID    string           `json:"task_id"`
+		Data      json.RawMessage  `json:"data"`
+		Time      time.Time        `json:"time"`
+	}
+	if err := json.Unmarshal([]byte(payload), &wire); err != nil {
+		return events.Event{}, err
+	}
+
+	var data any
+	switch wire.Type {
+	case events.EventPhase:
+		data = &events.PhaseUpdate{}
+	case events.EventTokens:
+		data = &events.TokenUpdate{}
+	case events.EventTranscript:
+		data = &events.TranscriptLine{}
+	case events.EventActivity:
+		data = &events.ActivityUpdate{}
+	case events.EventError:
+		data = &events.ErrorData{}
+	case events.EventWarning:
+		data = &events.WarningData{}
+	case events.EventBudgetExceeded:
+		data = &events.BudgetExceededData{}
+	case events.EventAccountRotated:
+		data = &events.AccountRotatedData{}
+	case events.EventHeartbeat:
+		data = &events.HeartbeatData{}
+	case events.EventDecisionRequired:
+		data = &events.DecisionRequiredData{}
+	case events.EventDecisionResolved:
+		data = &events.DecisionResolvedData{}
+	}
+
+	e := events.Event{Type: wire.Type, ProjectID: wire.ProjectID, TaskID: wire.TaskID, Time: wire.Time}
+	if len(wire.Data) > 0 && string(wire.Data) != "null" {
+		if data == nil {
+			var generic any
+			if err := json.Unmarshal(wire.Data, &generic); err != nil {
+				return events.Event{}, err
+			}
+			e.Data = generic
+		} else {
+			if err := json.Unmarshal(wire.Data, data); err != nil {
+				return events.Event{}, err
+			}
+			e.Data = data
+		}
+	}
+	return e, nil
+}
+
+// haPublisher publishes events to local subscribers and shares them with
+// peer instances.
+type haPublisher struct {
+	events.Publisher
+	ha *haCoordinator
+}
+
+func (p *haPublisher) Publish(e events.Event) {
+	p.Publisher.Publish(e)
+	p.ha.forward(e)
+}
+
+// deliverLocal hands a peer's event to local subscribers only. The peer
+// already persisted it, so it bypasses the event log when possible.
+func (p *haPublisher) deliverLocal(e events.Event) {
+	if local, ok := p.Publisher.(interface{ PublishLocal(events.Event) }); ok {
+		local.PublishLocal(e)
+		return
+	}
+	p.Publisher.Publish(e)
+}
+
+// handleHAStatus reports this instance's role, for load balancer checks
+// and debugging failover.
+func (s *Server) handleHAStatus(w http.ResponseWriter, r *http.Request) {
+	resp := map[string]any{
+		"enabled": s.ha != nil,
+		"leader":  s.isLeader(),
+	}
+	if s.ha != nil {
+		resp["instance_id"] = s.ha.instanceID
+		lease, err := s.ha.store.GetLease(leaderLeaseName)
+		if err != nil {
+			s.jsonError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if lease != nil && lease.ExpiresAt.After(time.Now()) {
+			resp["leader_instance"] = lease.Holder
+			resp["lease_expires_at"] = lease.ExpiresAt
+		}
+	}
+	s.jsonResponse(w, resp)
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/events"
+)
+
+// newHATestPeer returns a coordinator and its publisher wired the way
+// setupHA does, coordinating through store.
+func newHATestPeer(t *testing.T, store *db.GlobalDB, id string) (*haCoordinator, *haPublisher) {
+	t.Helper()
+	h := newHACoordinator(store, config.HAConfig{
+		InstanceID:        id,
+		LeaseTTL:          300 * time.Millisecond,
+		EventPollInterval: 20 * time.Millisecond,
+	}, slog.Default())
+	local := events.NewMemoryPublisher()
+	t.Cleanup(local.Close)
+	pub := &haPublisher{Publisher: local, ha: h}
+	h.deliver = pub.deliverLocal
+	return h, pub
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestHACoordinator_LeaderElectionAndFailover(t *testing.T) {
+	store, err := db.OpenGlobalAt(filepath.Join(t.TempDir(), "orc.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	var running atomic.Int32
+	leaderFn := func(ctx context.Context) {
+		running.Add(1)
+		<-ctx.Done()
+		running.Add(-1)
+	}
+
+	a, _ := newHATestPeer(t, store, "a")
+	b, _ := newHATestPeer(t, store, "b")
+	ctxA, cancelA := context.WithCancel(context.Background())
+	ctxB, cancelB := context.WithCancel(context.Background())
+	defer cancelB()
+
+	a.start(ctxA, leaderFn)
+	waitFor(t, "a to lead", a.leader.Load)
+	b.start(ctxB, leaderFn)
+
+	// b stays a standby while a renews its lease.
+	time.Sleep(500 * time.Millisecond)
+	if b.leader.Load() {
+		t.Fatal("both instances are leaders")
+	}
+	if n := running.Load(); n != 1 {
+		t.Fatalf("leader tasks running on %d instances, want 1", n)
+	}
+
+	// Shutting a down hands the lease to b.
+	cancelA()
+	a.wait()
+	waitFor(t, "b to take over", b.leader.Load)
+	if n := running.Load(); n != 1 {
+		t.Errorf("leader tasks running on %d instances after failover, want 1", n)
+	}
+
+	cancelB()
+	b.wait()
+	if lease, _ := store.GetLease(leaderLeaseName); lease != nil {
+		t.Errorf("lease after shutdown = %+v, want released", lease)
+	}
+}
+
+func TestHACoordinator_RelaysPeerEvents(t *testing.T) {
+	store, err := db.OpenGlobalAt(filepath.Join(t.TempDir(), "orc.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	a, pubA := newHATestPeer(t, store, "a")
+	b, pubB := newHATestPeer(t, store, "b")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		a.wait()
+		b.wait()
+	}()
+	noop := func(ctx context.Context) { <-ctx.Done() }
+	a.start(ctx, noop)
+	b.start(ctx, noop)
+
+	chA := pubA.Subscribe("TASK-001")
+	chB := pubB.Subscribe("TASK-001")
+
+	pubA.Publish(events.NewEvent(events.EventPhase, "TASK-001", events.PhaseUpdate{Phase: "implement", Status: "running"}))
+
+	// a's own subscriber sees the event once, directly.
+	select {
+	case <-chA:
+	case <-time.After(time.Second):
+		t.Fatal("local subscriber did not receive event")
+	}
+
+	select {
+	case e := <-chB:
+		update, ok := e.Data.(*events.PhaseUpdate)
+		if !ok || update.Phase != "implement" || update.Status != "running" {
+			t.Errorf("relayed data = %#v, want *PhaseUpdate for implement", e.Data)
+		}
+		if internalEventToProto(e).GetPhaseChanged() == nil {
+			t.Error("relayed phase event does not convert to a stream event")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("peer subscriber did not receive relayed event")
+	}
+
+	// Events are not echoed back to their origin.
+	select {
+	case e := <-chA:
+		t.Errorf("origin received its own event back: %+v", e)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestHACoordinator_SessionUpdatesStayLocal(t *testing.T) {
+	store, err := db.OpenGlobalAt(filepath.Join(t.TempDir(), "orc.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	h, pub := newHATestPeer(t, store, "a")
+	pub.Publish(events.NewEvent(events.EventSessionUpdate, "", events.SessionUpdate{}))
+	pub.Publish(events.NewEvent(events.EventTaskUpdated, "TASK-001", nil))
+	if n := len(h.outbox); n != 1 {
+		t.Errorf("outbox has %d events, want only the task update", n)
+	}
+}
+
+// On PostgreSQL a lower event ID can commit after a higher one has been
+// relayed; the relay must still deliver it, exactly once.
+func TestEventRelay_DeliversLateLowerIDs(t *testing.T) {
+	store, err := db.OpenGlobalAt(filepath.Join(t.TempDir(), "orc.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	insert := func(id int64, taskID string) {
+		t.Helper()
+		payload := `{"type":"phase","task_id":"` + taskID + `","data":{}}`
+		if _, err := store.Exec(`INSERT INTO server_events (id, origin, payload, created_at) VALUES (?, 'peer', ?, ?)`,
+			id, payload, time.Now().UnixMilli()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var delivered []string
+	deliver := func(e events.Event) { delivered = append(delivered, e.TaskID) }
+	relay := newEventRelay(time.Now())
+
+	insert(100, "TASK-HIGH")
+	relay.poll(store, "self", deliver, time.Now(), slog.Default())
+	insert(50, "TASK-LATE")
+	relay.poll(store, "self", deliver, time.Now(), slog.Default())
+	relay.poll(store, "self", deliver, time.Now(), slog.Default())
+
+	if len(delivered) != 2 || delivered[0] != "TASK-HIGH" || delivered[1] != "TASK-LATE" {
+		t.Errorf("delivered = %v, want TASK-HIGH then TASK-LATE once each", delivered)
+	}
+}
@@ -0,0 +1,272 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/breaker"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/hosting"
+	"github.com/randalmurphal/orc/internal/util"
+)
+
+// healthPingTimeout bounds each database ping in a health check.
+const healthPingTimeout = 2 * time.Second
+
+// healthProbeTaskID is the event bus subscription used to probe it. Nothing
+// is published to it.
+const healthProbeTaskID = "__health_probe__"
+
+// Overall health states reported by /api/health/ready.
+const (
+	healthOK          = "ok"          // every dependency is up
+	healthDegraded    = "degraded"    // a non-critical dependency is down; affected work fails fast
+	healthUnavailable = "unavailable" // a critical dependency is down; the server can't do its job
+)
+
+// Component states.
+const (
+	componentOK       = "ok"
+	componentDegraded = "degraded" // usable, but its breaker is open or it is close to a limit
+	componentDown     = "down"
+	componentSkipped  = "skipped" // not configured for this server
+)
+
+// healthComponent is the result of checking one dependency. Critical
+// components being down makes the server unready.
+type healthComponent struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	Message   string  `json:"message,omitempty"`
+	LatencyMs float64 `json:"latency_ms,omitempty"`
+}
+
+// healthReport is the /api/health/ready response.
+type healthReport struct {
+	Status     string            `json:"status"`
+	Version    string            `json:"version,omitempty"`
+	CheckedAt  time.Time         `json:"checked_at"`
+	Components []healthComponent `json:"components"`
+	Breakers   []breaker.Status  `json:"breakers"`
+	// Events is the event bus delivery backlog and drop accounting.
+	Events *events.PublisherStats `json:"events,omitempty"`
+}
+
+// handleHealthLive answers as long as the process is serving requests. It
+// checks nothing else, so a liveness probe never restarts the server over an
+// outage elsewhere.
+func (s *Server) handleHealthLive(w http.ResponseWriter, r *http.Request) {
+	resp := map[string]any{"status": healthOK}
+	if !s.sessionStart.IsZero() {
+		resp["uptime_seconds"] = int64(time.Since(s.sessionStart).Seconds())
+	}
+	writeHealthJSON(w, http.StatusOK, resp)
+}
+
+// handleHealthReady reports every dependency and the circuit breakers
+// guarding claude, the hosting API, and the database. It answers 503 when a
+// critical component (database, disk, event bus) is down; other failures
+// mark the server degraded but ready. GET /api/health is an alias.
+func (s *Server) handleHealthReady(w http.ResponseWriter, r *http.Request) {
+	report := s.healthReport(r.Context())
+	status := http.StatusOK
+	if report.Status == healthUnavailable {
+		status = http.StatusServiceUnavailable
+	}
+	writeHealthJSON(w, status, report)
+}
+
+func (s *Server) healthReport(ctx context.Context) healthReport {
+	cfg := s.orcConfig
+	if cfg == nil {
+		cfg = config.Default()
+	}
+	report := healthReport{Status: healthOK, Version: s.version, CheckedAt: time.Now().UTC()}
+	report.Components = []healthComponent{
+		s.checkDatabase(ctx),
+		s.checkEventBus(),
+		s.checkDisk(cfg),
+		checkClaude(cfg),
+		s.checkHosting(cfg),
+	}
+	report.Breakers = breaker.All()
+	report.Events = s.eventStats()
+
+	for _, c := range report.Components {
+		switch {
+		case c.Status == componentDown && c.Critical:
+			report.Status = healthUnavailable
+		case c.Status == componentDown || c.Status == componentDegraded:
+			if report.Status == healthOK {
+				report.Status = healthDegraded
+			}
+		}
+	}
+	// Breakers without a component of their own (anything beyond the
+	// built-ins) still degrade the report while open.
+	for _, b := range report.Breakers {
+		if b.State == breaker.StateOpen && report.Status == healthOK {
+			report.Status = healthDegraded
+		}
+	}
+	return report
+}
+
+// checkDatabase pings the global and project databases. The outcome feeds
+// the database breaker, so a probe can also close it after recovery.
+func (s *Server) checkDatabase(ctx context.Context) healthComponent {
+	c := healthComponent{Name: "database", Status: componentOK, Critical: true}
+	var dbs []*db.DB
+	if s.globalDB != nil {
+		dbs = append(dbs, s.globalDB.DB)
+	}
+	if s.backend != nil {
+		if pdb := s.backend.DB(); pdb != nil {
+			dbs = append(dbs, pdb.DB)
+		}
+	}
+	if len(dbs) == 0 {
+		c.Status, c.Message = componentSkipped, "no database open"
+		return c
+	}
+
+	start := time.Now()
+	for _, d := range dbs {
+		pingCtx, cancel := context.WithTimeout(ctx, healthPingTimeout)
+		err := d.DB().PingContext(pingCtx)
+		cancel()
+		breaker.Get(breaker.Database).Record(err)
+		if err != nil {
+			c.Status, c.Message = componentDown, fmt.Sprintf("%s: %v", d.Path(), err)
+			break
+		}
+	}
+	c.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	if c.Status == componentOK {
+		if err := breaker.Get(breaker.Database).Check(); err != nil {
+			c.Status, c.Message = componentDown, err.Error()
+		}
+	}
+	return c
+}
+
+// checkEventBus verifies the publisher still accepts subscriptions; a
+// closed publisher hands back a closed channel.
+func (s *Server) checkEventBus() healthComponent {
+	c := healthComponent{Name: "event_bus", Status: componentOK, Critical: true}
+	if s.publisher == nil {
+		c.Status, c.Message = componentDown, "no event publisher"
+		return c
+	}
+	ch := s.publisher.Subscribe(healthProbeTaskID)
+	select {
+	case _, ok := <-ch:
+		if !ok {
+			c.Status, c.Message = componentDown, "event publisher is closed"
+		}
+	default:
+	}
+	s.publisher.Unsubscribe(healthProbeTaskID, ch)
+	if stats := s.eventStats(); stats != nil && c.Status == componentOK {
+		c.Message = fmt.Sprintf("%d subscribers, %d events queued for %d slow subscribers, %d dropped",
+			stats.Subscribers, stats.Queued, stats.SlowSubscribers, stats.Dropped)
+	}
+	return c
+}
+
+// eventStatsProvider is implemented by publishers that account for their
+// delivery backlog and dropped events.
+type eventStatsProvider interface {
+	Stats() events.PublisherStats
+}
+
+// eventStats returns the publisher's delivery stats, or nil if it keeps
+// none.
+func (s *Server) eventStats() *events.PublisherStats {
+	sp, ok := s.publisher.(eventStatsProvider)
+	if !ok {
+		return nil
+	}
+	stats := sp.Stats()
+	return &stats
+}
+
+// checkDisk compares free space where orc keeps its data with the worktree
+// preflight minimum (worktree.preflight.min_free_disk_mb).
+func (s *Server) checkDisk(cfg *config.Config) healthComponent {
+	c := healthComponent{Name: "disk", Status: componentOK, Critical: true}
+	dir := s.workDir
+	if dir == "" {
+		c.Status, c.Message = componentSkipped, "no project directory"
+		return c
+	}
+	free, err := util.FreeDiskBytes(dir)
+	if err != nil {
+		c.Status, c.Message = componentSkipped, fmt.Sprintf("free space unavailable: %v", err)
+		return c
+	}
+	freeMB := free / (1024 * 1024)
+	c.Message = fmt.Sprintf("%d MB free", freeMB)
+	if minMB := cfg.Worktree.Preflight.MinFreeDiskMB; minMB > 0 && freeMB < uint64(minMB) {
+		c.Status = componentDown
+		c.Message = fmt.Sprintf("%d MB free, below worktree.preflight.min_free_disk_mb (%d)", freeMB, minMB)
+	}
+	return c
+}
+
+// checkClaude looks for the claude CLI and reports its breaker.
+func checkClaude(cfg *config.Config) healthComponent {
+	c := healthComponent{Name: "claude", Status: componentOK}
+	if cfg.FakeModel != "" {
+		c.Status, c.Message = componentSkipped, "fake_model answers phases"
+		return c
+	}
+	path := cfg.ClaudePath
+	if path == "" {
+		path = "claude"
+	}
+	resolved, err := exec.LookPath(path)
+	if err != nil {
+		c.Status, c.Message = componentDown, fmt.Sprintf("claude CLI not found: %v", err)
+		return c
+	}
+	c.Message = resolved
+	if err := breaker.Get(breaker.Claude).Check(); err != nil {
+		c.Status, c.Message = componentDegraded, err.Error()
+	}
+	return c
+}
+
+// checkHosting confirms a hosting token is configured and reports the
+// hosting breaker. It makes no API call, so probes never spend rate limit.
+func (s *Server) checkHosting(cfg *config.Config) healthComponent {
+	c := healthComponent{Name: "hosting", Status: componentOK}
+	resolved, err := hosting.ResolveConfig(s.workDir, cfg)
+	if err != nil {
+		c.Status, c.Message = componentSkipped, err.Error()
+		return c
+	}
+	if _, err := hosting.ResolveTokenFromEnv(resolved.Config, resolved.ProviderType); err != nil {
+		c.Status, c.Message = componentDown, err.Error()
+		return c
+	}
+	c.Message = string(resolved.ProviderType) + " token set"
+	if err := breaker.Get(breaker.Hosting).Check(); err != nil {
+		c.Status, c.Message = componentDegraded, err.Error()
+	}
+	return c
+}
+
+func writeHealthJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
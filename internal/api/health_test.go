@@ -0,0 +1,142 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/breaker"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+func newHealthTestServer(t *testing.T) (*Server, storage.Backend) {
+	t.Helper()
+	backend := storage.NewTestBackend(t)
+	cfg := config.Default()
+	cfg.FakeModel = "fixtures" // no claude CLI in tests
+	pub := events.NewMemoryPublisher()
+	t.Cleanup(pub.Close)
+	return &Server{
+		logger:    slog.Default(),
+		backend:   backend,
+		orcConfig: cfg,
+		publisher: pub,
+		workDir:   t.TempDir(),
+	}, backend
+}
+
+func getReady(t *testing.T, s *Server) (int, healthReport) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.handleHealthReady(rec, httptest.NewRequest(http.MethodGet, "/api/health/ready", nil))
+	var report healthReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode health report: %v (%s)", err, rec.Body.String())
+	}
+	return rec.Code, report
+}
+
+func component(report healthReport, name string) healthComponent {
+	for _, c := range report.Components {
+		if c.Name == name {
+			return c
+		}
+	}
+	return healthComponent{}
+}
+
+func TestHandleHealthLive(t *testing.T) {
+	s := &Server{logger: slog.Default()}
+	rec := httptest.NewRecorder()
+	s.handleHealthLive(rec, httptest.NewRequest(http.MethodGet, "/api/health/live", nil))
+	if rec.Code != http.StatusOK || rec.Header().Get("Cache-Control") != "no-store" {
+		t.Fatalf("live: %d %v", rec.Code, rec.Header())
+	}
+}
+
+func TestHandleHealthReady(t *testing.T) {
+	s, _ := newHealthTestServer(t)
+
+	code, report := getReady(t, s)
+	if code != http.StatusOK || report.Status != healthOK {
+		t.Fatalf("healthy server: %d %+v", code, report)
+	}
+	for name, want := range map[string]string{
+		"database":  componentOK,
+		"event_bus": componentOK,
+		"disk":      componentOK,
+		"claude":    componentSkipped,
+		"hosting":   componentSkipped, // temp dir has no git remote
+	} {
+		if got := component(report, name); got.Status != want {
+			t.Errorf("%s = %+v, want %s", name, got, want)
+		}
+	}
+	names := map[string]bool{}
+	for _, b := range report.Breakers {
+		names[b.Name] = true
+	}
+	if !names[breaker.Claude] || !names[breaker.Hosting] || !names[breaker.Database] {
+		t.Errorf("breakers = %+v, want claude, hosting and database", report.Breakers)
+	}
+
+	// An open breaker for a non-critical dependency degrades but still serves
+	dep := breaker.Get("health_test_dependency")
+	for i := 0; i < breaker.DefaultThreshold; i++ {
+		dep.Record(errors.New("down"))
+	}
+	code, report = getReady(t, s)
+	if code != http.StatusOK || report.Status != healthDegraded {
+		t.Errorf("open breaker: %d %s, want 200 degraded", code, report.Status)
+	}
+	dep.Record(nil)
+
+	// A missing claude CLI is not critical
+	s.orcConfig.FakeModel = ""
+	s.orcConfig.ClaudePath = "/nonexistent/claude"
+	code, report = getReady(t, s)
+	if code != http.StatusOK || report.Status != healthDegraded || component(report, "claude").Status != componentDown {
+		t.Errorf("missing claude: %d %s %+v, want 200 degraded", code, report.Status, component(report, "claude"))
+	}
+}
+
+func TestHandleHealthReady_DatabaseDown(t *testing.T) {
+	s, backend := newHealthTestServer(t)
+	if err := backend.DB().Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	code, report := getReady(t, s)
+	if code != http.StatusServiceUnavailable || report.Status != healthUnavailable {
+		t.Fatalf("closed database: %d %s, want 503 unavailable", code, report.Status)
+	}
+	if c := component(report, "database"); c.Status != componentDown || !c.Critical || c.Message == "" {
+		t.Errorf("database = %+v, want critical and down with its error", c)
+	}
+	breaker.Get(breaker.Database).Record(nil)
+}
+
+func TestHandleHealthReady_EventBusClosed(t *testing.T) {
+	s, _ := newHealthTestServer(t)
+	s.publisher.Close()
+
+	code, report := getReady(t, s)
+	if code != http.StatusServiceUnavailable || component(report, "event_bus").Status != componentDown {
+		t.Fatalf("closed publisher: %d %+v, want 503 with event_bus down", code, component(report, "event_bus"))
+	}
+}
+
+func TestHandleHealthReady_LowDisk(t *testing.T) {
+	s, _ := newHealthTestServer(t)
+	s.orcConfig.Worktree.Preflight.MinFreeDiskMB = 1 << 40
+
+	code, report := getReady(t, s)
+	if code != http.StatusServiceUnavailable || component(report, "disk").Status != componentDown {
+		t.Fatalf("low disk: %d %+v, want 503 with disk down", code, component(report, "disk"))
+	}
+}
@@ -18,9 +18,11 @@ import (
 	"github.com/randalmurphal/orc/internal/config"
 	"github.com/randalmurphal/orc/internal/db"
 	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/executor"
 	"github.com/randalmurphal/orc/internal/hosting"
 	_ "github.com/randalmurphal/orc/internal/hosting/github"
 	_ "github.com/randalmurphal/orc/internal/hosting/gitlab"
+	"github.com/randalmurphal/orc/internal/initiative"
 	"github.com/randalmurphal/orc/internal/storage"
 	"github.com/randalmurphal/orc/internal/task"
 )
@@ -161,7 +163,7 @@ func (s *hostingServer) CreatePR(
 	if req.Msg.Base != nil && *req.Msg.Base != "" {
 		opts.Base = *req.Msg.Base
 	} else {
-		opts.Base = "main"
+		opts.Base = s.resolveTargetBranch(backend, t)
 	}
 
 	pr, err := provider.CreatePR(ctx, opts)
@@ -738,7 +740,7 @@ func (s *hostingServer) AutofixComment(
 		taskID := t.Id
 		errChan := make(chan error, 1)
 		go func() {
-			errChan <- s.taskExecutor(taskID, req.Msg.GetProjectId())
+			errChan <- s.taskExecutor(ctx, taskID, req.Msg.GetProjectId())
 		}()
 
 		// Wait briefly for immediate spawn failures, but don't block on slow executors
@@ -816,10 +818,29 @@ func buildAutofixRetryContext(comment *hosting.PRComment) string {
 
 // Helper functions
 
+// resolveTargetBranch picks the PR base for a task: the task's own override,
+// then its initiative's branch, then the project's completion settings.
+func (s *hostingServer) resolveTargetBranch(backend storage.Backend, t *orcv1.Task) string {
+	var init *initiative.Initiative
+	if initiativeID := task.GetInitiativeIDProto(t); initiativeID != "" {
+		loaded, err := backend.LoadInitiative(initiativeID)
+		if err != nil {
+			s.logger.Warn("failed to load initiative for target branch resolution",
+				"task", t.Id, "initiative_id", initiativeID, "error", err)
+		} else {
+			init = loaded
+		}
+	}
+	return executor.ResolveTargetBranch(t, init, s.config)
+}
+
 func buildPRBodyForTaskProto(t *orcv1.Task) string {
 	var sb strings.Builder
 	sb.WriteString("## Summary\n\n")
 	sb.WriteString(fmt.Sprintf("Task: **%s**\n\n", t.Title))
+	if targetBranch := task.GetTargetBranchProto(t); targetBranch != "" {
+		sb.WriteString(fmt.Sprintf("Target branch: `%s` (task override)\n\n", targetBranch))
+	}
 
 	desc := task.GetDescriptionProto(t)
 	if desc != "" {
@@ -22,6 +22,7 @@ import (
 type mockGitHubProvider struct {
 	GetPRCommentFunc   func(ctx context.Context, prNumber int, commentID int64) (*hosting.PRComment, error)
 	FindPRByBranchFunc func(ctx context.Context, branch string) (*hosting.PR, error)
+	StatusSummaryFunc  func(ctx context.Context, pr *hosting.PR) (*hosting.PRStatusSummary, error)
 	// Other methods can be added as needed
 }
 
@@ -88,6 +89,9 @@ func (m *mockGitHubProvider) ApprovePR(ctx context.Context, number int, body str
 }
 
 func (m *mockGitHubProvider) GetPRStatusSummary(ctx context.Context, pr *hosting.PR) (*hosting.PRStatusSummary, error) {
+	if m.StatusSummaryFunc != nil {
+		return m.StatusSummaryFunc(ctx, pr)
+	}
 	return nil, errors.New("not implemented")
 }
 
@@ -95,6 +99,14 @@ func (m *mockGitHubProvider) DeleteBranch(ctx context.Context, branch string) er
 	return errors.New("not implemented")
 }
 
+func (m *mockGitHubProvider) GetBranchProtection(ctx context.Context, branch string) (*hosting.BranchProtection, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockGitHubProvider) CreateIssue(ctx context.Context, opts hosting.IssueCreateOptions) (*hosting.Issue, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockGitHubProvider) CheckAuth(ctx context.Context) error {
 	return nil
 }
@@ -135,7 +147,7 @@ func TestAutofixComment_StartsExecution(t *testing.T) {
 
 	var executorCalled atomic.Bool
 	var executorTaskID string
-	mockExecutor := func(taskID, projectID string) error {
+	mockExecutor := func(_ context.Context, taskID, projectID string) error {
 		executorCalled.Store(true)
 		executorTaskID = taskID
 		return nil
@@ -217,7 +229,7 @@ func TestAutofixComment_FetchesComment(t *testing.T) {
 	}
 
 	commentBody := "Error handling is missing for the nil case"
-	mockExecutor := func(taskID, projectID string) error {
+	mockExecutor := func(_ context.Context, taskID, projectID string) error {
 		return nil
 	}
 
@@ -308,7 +320,7 @@ func TestAutofixComment_ReturnsImmediately(t *testing.T) {
 	// Slow executor to simulate real execution
 	executorStarted := make(chan struct{})
 	executorDone := make(chan struct{})
-	mockExecutor := func(taskID, projectID string) error {
+	mockExecutor := func(_ context.Context, taskID, projectID string) error {
 		close(executorStarted)
 		<-executorDone // Block until test says to finish
 		return nil
@@ -399,7 +411,7 @@ func TestAutofixComment_PublishesCompletionEvent(t *testing.T) {
 		t.Fatalf("save task: %v", err)
 	}
 
-	mockExecutor := func(taskID, projectID string) error {
+	mockExecutor := func(_ context.Context, taskID, projectID string) error {
 		return nil
 	}
 
@@ -475,7 +487,7 @@ func TestAutofixComment_TaskAlreadyRunning(t *testing.T) {
 		},
 	}
 
-	mockExecutor := func(taskID, projectID string) error {
+	mockExecutor := func(_ context.Context, taskID, projectID string) error {
 		t.Error("executor should not be called for already-running task")
 		return nil
 	}
@@ -802,7 +814,7 @@ func TestAutofixComment_TaskPaused_AllowsAutofix(t *testing.T) {
 	}
 
 	var executorCalled atomic.Bool
-	mockExecutor := func(taskID, projectID string) error {
+	mockExecutor := func(_ context.Context, taskID, projectID string) error {
 		executorCalled.Store(true)
 		return nil
 	}
@@ -875,7 +887,7 @@ func TestAutofixComment_ExecutorFails(t *testing.T) {
 	}
 
 	// Executor that always fails
-	mockExecutor := func(taskID, projectID string) error {
+	mockExecutor := func(_ context.Context, taskID, projectID string) error {
 		return errors.New("no Claude process available")
 	}
 
@@ -1024,7 +1036,7 @@ func TestAutofixComment_LongCommentTruncated(t *testing.T) {
 	// Create a comment body > 10KB
 	longBody := strings.Repeat("This is a very long comment. ", 500) // ~15KB
 
-	mockExecutor := func(taskID, projectID string) error {
+	mockExecutor := func(_ context.Context, taskID, projectID string) error {
 		return nil
 	}
 
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/randalmurphal/orc/internal/executor"
+)
+
+// handleInitiativeRollup returns the status rollup of an initiative's
+// integration branch: its member tasks, which have merged, and the
+// combined PR to the target branch.
+// GET /api/initiatives/{id}/rollup
+func (s *Server) handleInitiativeRollup(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id := r.PathValue("id")
+	if init, err := backend.LoadInitiative(id); err != nil || init == nil {
+		s.jsonError(w, "initiative not found", http.StatusNotFound)
+		return
+	}
+
+	completer := executor.NewInitiativeCompleter(nil, nil, backend, s.orcConfig, s.logger, "")
+	rollup, err := completer.SyncCombinedPR(r.Context(), id)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if rollup == nil {
+		s.jsonError(w, "initiative has no branch base", http.StatusBadRequest)
+		return
+	}
+	s.jsonResponse(w, rollup)
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/executor"
+	"github.com/randalmurphal/orc/internal/initiative"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestHandleInitiativeRollup(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	for _, init := range []*initiative.Initiative{
+		{ID: "INIT-001", Title: "Auth", Status: initiative.StatusActive, BranchBase: "feature/auth",
+			Tasks: []initiative.TaskRef{{ID: "TASK-001"}, {ID: "TASK-002"}}},
+		{ID: "INIT-002", Title: "No branch", Status: initiative.StatusActive},
+	} {
+		if err := backend.SaveInitiative(init); err != nil {
+			t.Fatal(err)
+		}
+	}
+	merged := task.NewProtoTask("TASK-001", "Schema")
+	merged.Status = orcv1.TaskStatus_TASK_STATUS_COMPLETED
+	task.SetInitiativeProto(merged, "INIT-001")
+	task.SetMergedInfoProto(merged, "https://github.com/o/r/pull/1", "feature/auth")
+	waiting := task.NewProtoTask("TASK-002", "Login")
+	task.SetInitiativeProto(waiting, "INIT-001")
+	for _, tk := range []*orcv1.Task{merged, waiting} {
+		if err := backend.SaveTask(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir(), backend: backend}
+	s.registerRESTRoutes()
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/initiatives/INIT-001/rollup", nil))
+	var rollup executor.InitiativeRollup
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &rollup) != nil {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if rollup.Branch != "feature/auth" || rollup.Target != "main" || rollup.Total != 2 || rollup.Merged != 1 {
+		t.Errorf("rollup = %+v", rollup)
+	}
+
+	for path, want := range map[string]int{
+		"/api/initiatives/INIT-002/rollup": http.StatusBadRequest,
+		"/api/initiatives/INIT-404/rollup": http.StatusNotFound,
+	} {
+		w := httptest.NewRecorder()
+		s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != want {
+			t.Errorf("GET %s status = %d, want %d", path, w.Code, want)
+		}
+	}
+}
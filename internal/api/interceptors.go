@@ -11,6 +11,7 @@ import (
 	"connectrpc.com/connect"
 
 	orcerrors "github.com/randalmurphal/orc/internal/errors"
+	"github.com/randalmurphal/orc/internal/i18n"
 )
 
 // LoggingInterceptor returns a Connect interceptor that logs RPC calls with
@@ -37,6 +38,7 @@ func LoggingInterceptor(logger *slog.Logger) connect.UnaryInterceptorFunc {
 				logger.Error("rpc failed",
 					"method", method,
 					"duration", duration,
+					"request_id", requestIDFromContext(ctx),
 					"error", err,
 				)
 			} else {
@@ -52,19 +54,34 @@ func LoggingInterceptor(logger *slog.Logger) connect.UnaryInterceptorFunc {
 }
 
 // ErrorInterceptor returns a Connect interceptor that maps internal errors
-// to appropriate Connect error codes.
+// to appropriate Connect error codes. Structured errors are rendered in the
+// locale requested by the Accept-Language header.
 func ErrorInterceptor() connect.UnaryInterceptorFunc {
 	return func(next connect.UnaryFunc) connect.UnaryFunc {
 		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
 			resp, err := next(ctx, req)
 			if err != nil {
-				return resp, mapError(err)
+				return resp, mapError(localizeError(err, req.Header().Get("Accept-Language")))
 			}
 			return resp, nil
 		}
 	}
 }
 
+// localizeError renders an OrcError in the best locale from an
+// Accept-Language header. Other errors, and headers naming no supported
+// locale, are returned unchanged in the server's locale.
+func localizeError(err error, acceptLanguage string) error {
+	locale := i18n.MatchAcceptLanguage(acceptLanguage)
+	if locale == "" {
+		return err
+	}
+	if orcErr := orcerrors.AsOrcError(err); orcErr != nil {
+		return orcErr.Localize(locale)
+	}
+	return err
+}
+
 // mapError converts internal errors to Connect errors with appropriate codes.
 func mapError(err error) error {
 	if err == nil {
@@ -135,7 +152,8 @@ func mapOrcErrorCode(code orcerrors.Code) connect.Code {
 	// Conflict / precondition errors
 	case orcerrors.CodeAlreadyInitialized,
 		orcerrors.CodeTaskRunning,
-		orcerrors.CodeGitBranchExists:
+		orcerrors.CodeGitBranchExists,
+		orcerrors.CodeGitDiverged:
 		return connect.CodeFailedPrecondition
 
 	// Timeout errors
@@ -143,7 +161,9 @@ func mapOrcErrorCode(code orcerrors.Code) connect.Code {
 		return connect.CodeDeadlineExceeded
 
 	// Unavailable errors
-	case orcerrors.CodeClaudeUnavailable:
+	case orcerrors.CodeClaudeUnavailable,
+		orcerrors.CodeGitRemoteUnreachable,
+		orcerrors.CodeDiskSpaceLow:
 		return connect.CodeUnavailable
 
 	// Internal errors (phase stuck, max retries)
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	orcerrors "github.com/randalmurphal/orc/internal/errors"
+)
+
+func TestErrorInterceptor_LocalizesOrcErrors(t *testing.T) {
+	t.Parallel()
+
+	failing := func(context.Context, connect.AnyRequest) (connect.AnyResponse, error) {
+		return nil, orcerrors.ErrTaskNotFound("TASK-001")
+	}
+	handler := ErrorInterceptor()(failing)
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           string
+	}{
+		{"no header keeps server locale", "", "task TASK-001 not found"},
+		{"supported locale", "de-DE,de;q=0.9,en;q=0.5", "Aufgabe TASK-001 nicht gefunden"},
+		{"unsupported locale keeps server locale", "fr-FR", "task TASK-001 not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := connect.NewRequest(&orcv1.GetTaskRequest{TaskId: "TASK-001"})
+			if tt.acceptLanguage != "" {
+				req.Header().Set("Accept-Language", tt.acceptLanguage)
+			}
+			_, err := handler(context.Background(), req)
+
+			var connectErr *connect.Error
+			if !errors.As(err, &connectErr) {
+				t.Fatalf("error = %v, want connect error", err)
+			}
+			if connectErr.Code() != connect.CodeNotFound {
+				t.Errorf("code = %v, want %v", connectErr.Code(), connect.CodeNotFound)
+			}
+			if connectErr.Message() != tt.want {
+				t.Errorf("message = %q, want %q", connectErr.Message(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/lessons"
+)
+
+// lessonsResponse is the lessons document with the lessons currently found
+// in the project's feedback. Document is null until there is a lesson.
+type lessonsResponse struct {
+	Document *db.ContextDocument `json:"document"`
+	Lessons  []lessons.Lesson    `json:"lessons"`
+}
+
+// lessonsConfig returns the brief.lessons settings, or the defaults when
+// the server has no config.
+func (s *Server) lessonsConfig() config.LessonsConfig {
+	if s.orcConfig == nil {
+		return config.Default().Brief.Lessons
+	}
+	return s.orcConfig.Brief.Lessons
+}
+
+// handleGetLessons returns the lessons document and current lessons. The
+// document is edited like any context document, via
+// PUT /api/context-documents/lessons.md.
+// GET /api/lessons
+func (s *Server) handleGetLessons(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	pdb := backend.DB()
+	current, err := lessons.Current(pdb, s.lessonsConfig(), time.Now())
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	doc, err := pdb.GetContextDocument(lessons.DocumentName)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.jsonLessons(w, doc, current)
+}
+
+// handleRefreshLessons regenerates the lessons document now rather than at
+// the next task run.
+// POST /api/lessons/refresh
+func (s *Server) handleRefreshLessons(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cfg := s.lessonsConfig()
+	if !cfg.Enabled {
+		s.jsonError(w, "lessons are disabled (brief.lessons.enabled)", http.StatusConflict)
+		return
+	}
+	doc, current, err := lessons.Refresh(backend.DB(), cfg, time.Now())
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.jsonLessons(w, doc, current)
+}
+
+func (s *Server) jsonLessons(w http.ResponseWriter, doc *db.ContextDocument, current []lessons.Lesson) {
+	if current == nil {
+		current = []lessons.Lesson{}
+	}
+	s.jsonResponse(w, lessonsResponse{Document: doc, Lessons: current})
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+func TestLessonsHandlers(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	cfg := config.Default()
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir(), backend: backend, orcConfig: cfg}
+	s.registerRESTRoutes()
+	do := func(method, path, body string) (*httptest.ResponseRecorder, lessonsResponse) {
+		w := httptest.NewRecorder()
+		s.mux.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		var resp lessonsResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		return w, resp
+	}
+
+	pdb := backend.DB()
+	for _, id := range []string{"TASK-001", "TASK-002"} {
+		if err := pdb.SaveTask(&db.Task{ID: id, Title: id, Status: "completed"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := pdb.CreateReviewComment(&db.ReviewComment{TaskID: id, Content: "Handle the returned error", Severity: db.SeverityIssue, Status: db.CommentStatusOpen}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w, resp := do(http.MethodGet, "/api/lessons", "")
+	if w.Code != http.StatusOK || resp.Document != nil || len(resp.Lessons) != 1 {
+		t.Fatalf("GET before refresh status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	w, resp = do(http.MethodPost, "/api/lessons/refresh", "")
+	if w.Code != http.StatusOK || resp.Document == nil || !strings.Contains(resp.Document.Content, "Handle the returned error (2 tasks: TASK-001, TASK-002)") {
+		t.Fatalf("POST refresh status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	// The document is edited through the context document API
+	edit := `{"content": "Keep functions short.\n\n` + strings.ReplaceAll(resp.Document.Content, "\n", `\n`) + `", "phases": ["implement"]}`
+	rec := httptest.NewRecorder()
+	s.mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/api/context-documents/lessons.md", strings.NewReader(edit)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PUT lessons.md status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	_, resp = do(http.MethodPost, "/api/lessons/refresh", "")
+	if resp.Document == nil || !strings.HasPrefix(resp.Document.Content, "Keep functions short.") || resp.Document.Version != 2 {
+		t.Errorf("refresh after edit = %+v", resp.Document)
+	}
+
+	cfg.Brief.Lessons.Enabled = false
+	if w, _ = do(http.MethodPost, "/api/lessons/refresh", ""); w.Code != http.StatusConflict {
+		t.Errorf("refresh with lessons disabled status = %d, want 409", w.Code)
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/randalmurphal/orc/internal/logs"
+)
+
+// defaultLogsTail is how many entries GET /api/logs returns without offset.
+const defaultLogsTail = 100
+
+// handleLogs returns server or task execution log entries.
+// GET /api/logs?task_id=&level=&phase=&component=&tail=&offset=
+//
+// Without offset the last tail entries are returned; with offset (from a
+// previous response) only entries logged since, which is how clients follow.
+func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	level, err := logs.ParseLevel(q.Get("level"))
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter := logs.Filter{Level: level, Phase: q.Get("phase"), Component: q.Get("component")}
+
+	_, workDir, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	path := logs.ServerPath(workDir)
+	if taskID := q.Get("task_id"); taskID != "" {
+		if path, err = logs.TaskPath(workDir, taskID); err != nil {
+			s.jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	var offset int64
+	tail := defaultLogsTail
+	if v := q.Get("offset"); v != "" {
+		if offset, err = strconv.ParseInt(v, 10, 64); err != nil || offset < 0 {
+			s.jsonError(w, "invalid offset", http.StatusBadRequest)
+			return
+		}
+		tail = 0
+	} else if v := q.Get("tail"); v != "" {
+		if tail, err = strconv.Atoi(v); err != nil || tail < 0 {
+			s.jsonError(w, "invalid tail", http.StatusBadRequest)
+			return
+		}
+	}
+
+	entries, next, err := logs.Read(path, offset, filter)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	entries = logs.Tail(entries, tail)
+	if entries == nil {
+		entries = []logs.Entry{}
+	}
+	s.jsonResponse(w, logs.Page{Entries: entries, Offset: next})
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/logs"
+)
+
+func TestHandleLogs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workDir := t.TempDir()
+	s := &Server{logger: slog.Default(), workDir: workDir}
+
+	h, err := logs.NewHandler(nil, logs.Options{Path: logs.ServerPath(workDir), Root: workDir, Component: "server", Level: slog.LevelDebug})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := slog.New(h)
+	logger.Debug("tick")
+	logger.Info("run started", "task_id", "TASK-001", "phase", "implement")
+	logger.Info("run finished", "task_id", "TASK-001", "phase", "review")
+	_ = h.Close()
+
+	get := func(query string) (int, logs.Page) {
+		rec := httptest.NewRecorder()
+		s.handleLogs(rec, httptest.NewRequest(http.MethodGet, "/api/logs?"+query, nil))
+		var page logs.Page
+		_ = json.Unmarshal(rec.Body.Bytes(), &page)
+		return rec.Code, page
+	}
+
+	if code, page := get("level=info"); code != http.StatusOK || len(page.Entries) != 2 {
+		t.Errorf("server log info = %d %+v", code, page)
+	}
+	code, page := get("task_id=TASK-001&phase=review")
+	if code != http.StatusOK || len(page.Entries) != 1 || page.Entries[0].Msg != "run finished" {
+		t.Errorf("task log filtered by phase = %d %+v", code, page)
+	}
+	if _, next := get("task_id=TASK-001&tail=1"); len(next.Entries) != 1 || next.Offset != page.Offset {
+		t.Errorf("tail=1 = %+v", next)
+	}
+	if _, since := get("task_id=TASK-001&offset=" + strconv.FormatInt(page.Offset, 10)); len(since.Entries) != 0 {
+		t.Errorf("nothing new since offset, got %+v", since)
+	}
+
+	for _, q := range []string{"level=loud", "task_id=../secrets", "offset=-1", "tail=x"} {
+		if code, _ := get(q); code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", q, code)
+		}
+	}
+}
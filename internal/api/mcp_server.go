@@ -36,13 +36,9 @@ func NewMCPServer(
 	}
 }
 
-// getProjectRoot returns the project root directory.
+// getProjectRoot returns the project root directory (the server's workDir).
 func (s *mcpServer) getProjectRoot() string {
-	if s.workDir != "" {
-		return s.workDir
-	}
-	cwd, _ := os.Getwd()
-	return cwd
+	return s.workDir
 }
 
 // ListMCPServers returns all MCP servers from .mcp.json.
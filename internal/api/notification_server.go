@@ -65,7 +65,7 @@ func (s *notificationServer) ListNotifications(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("get backend: %w", err))
 	}
 
-	dbBackend, ok := backend.(*storage.DatabaseBackend)
+	dbBackend, ok := storage.DatabaseOf(backend)
 	if !ok {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database backend required for notifications"))
 	}
@@ -101,7 +101,7 @@ func (s *notificationServer) DismissNotification(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("get backend: %w", err))
 	}
 
-	dbBackend, ok := backend.(*storage.DatabaseBackend)
+	dbBackend, ok := storage.DatabaseOf(backend)
 	if !ok {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database backend required for notifications"))
 	}
@@ -129,7 +129,7 @@ func (s *notificationServer) DismissAllNotifications(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("get backend: %w", err))
 	}
 
-	dbBackend, ok := backend.(*storage.DatabaseBackend)
+	dbBackend, ok := storage.DatabaseOf(backend)
 	if !ok {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database backend required for notifications"))
 	}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/onboarding"
+	"github.com/randalmurphal/orc/internal/project"
+)
+
+// onboardingResponse is returned by GET /api/projects/{id}/onboarding.
+type onboardingResponse struct {
+	*onboarding.Report
+	// SavedPath is the report `orc init` wrote, when it exists.
+	SavedPath string `json:"saved_path,omitempty"`
+}
+
+// handleProjectOnboarding returns a freshly generated onboarding report, so
+// prerequisites fixed since `orc init` show as done. With ?format=markdown
+// it returns the report as Markdown instead of JSON.
+// GET /api/projects/{id}/onboarding
+func (s *Server) handleProjectOnboarding(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if scope := tenantFromContext(r.Context()); scope != nil && !scope.Owns(id) {
+		s.jsonError(w, "project not found", http.StatusNotFound)
+		return
+	}
+	reg, err := project.LoadRegistry()
+	if err != nil {
+		s.jsonError(w, "load project registry: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	proj, err := reg.Get(id)
+	if err != nil {
+		s.jsonError(w, "project not found", http.StatusNotFound)
+		return
+	}
+
+	cfg, err := config.LoadFrom(proj.Path)
+	if err != nil {
+		cfg = nil
+	}
+	report, err := onboarding.Generate(proj.Path, cfg)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if r.URL.Query().Get("format") == "markdown" {
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		_, _ = w.Write(report.Markdown())
+		return
+	}
+	resp := onboardingResponse{Report: report}
+	if path := onboarding.Path(proj.Path); fileStatOK(path) {
+		resp.SavedPath = path
+	}
+	s.jsonResponse(w, resp)
+}
+
+// fileStatOK reports whether path exists.
+func fileStatOK(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
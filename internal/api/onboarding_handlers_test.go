@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/project"
+)
+
+func TestHandleProjectOnboarding(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dir := filepath.Join(home, "app")
+	if err := os.MkdirAll(filepath.Join(dir, ".orc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := project.RegisterProject(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: dir}
+	s.registerRESTRoutes()
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := get("/api/projects/" + p.ID + "/onboarding")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		ProjectPath   string                  `json:"project_path"`
+		Languages     []struct{ Name string } `json:"languages"`
+		Prerequisites []struct{ Name string } `json:"prerequisites"`
+		SavedPath     string                  `json:"saved_path"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.ProjectPath != dir || len(resp.Languages) != 1 || resp.Languages[0].Name != "go" {
+		t.Errorf("response = %+v", resp)
+	}
+	if len(resp.Prerequisites) == 0 {
+		t.Error("expected prerequisite checks")
+	}
+	if resp.SavedPath != "" {
+		t.Errorf("saved_path = %q before a report was written", resp.SavedPath)
+	}
+
+	w = get("/api/projects/" + p.ID + "/onboarding?format=markdown")
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/markdown") {
+		t.Errorf("content type = %q", ct)
+	}
+	if !strings.Contains(w.Body.String(), "## Prerequisites") {
+		t.Errorf("markdown body = %s", w.Body.String())
+	}
+
+	if w := get("/api/projects/nope/onboarding"); w.Code != http.StatusNotFound {
+		t.Errorf("unknown project: status = %d", w.Code)
+	}
+}
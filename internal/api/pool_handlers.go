@@ -0,0 +1,134 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/tokenpool"
+)
+
+// poolAccountRequest is the body of POST /api/pool/accounts.
+type poolAccountRequest struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// openTokenPool opens the pool at pool.config_path. The pool holds the
+// server operator's Claude credentials, so tenants cannot reach it.
+func (s *Server) openTokenPool(w http.ResponseWriter, r *http.Request) *tokenpool.Pool {
+	if tenantFromContext(r.Context()) != nil {
+		s.jsonError(w, "the token pool is managed by the server administrator", http.StatusForbidden)
+		return nil
+	}
+	configPath := config.Default().Pool.ConfigPath
+	if s.orcConfig != nil && s.orcConfig.Pool.ConfigPath != "" {
+		configPath = s.orcConfig.Pool.ConfigPath
+	}
+	pool, err := tokenpool.New(configPath, tokenpool.WithLogger(s.logger))
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return nil
+	}
+	return pool
+}
+
+// handlePoolStatus returns every account's rate-limit state and usage.
+// Tokens are never returned.
+// GET /api/pool
+func (s *Server) handlePoolStatus(w http.ResponseWriter, r *http.Request) {
+	pool := s.openTokenPool(w, r)
+	if pool == nil {
+		return
+	}
+	s.jsonResponse(w, pool.Report())
+}
+
+// handleAddPoolAccount adds an account with existing OAuth tokens.
+// POST /api/pool/accounts
+func (s *Server) handleAddPoolAccount(w http.ResponseWriter, r *http.Request) {
+	pool := s.openTokenPool(w, r)
+	if pool == nil {
+		return
+	}
+	var req poolAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ID == "" || req.AccessToken == "" {
+		s.jsonError(w, "id and access_token are required", http.StatusBadRequest)
+		return
+	}
+	if req.Name == "" {
+		req.Name = req.ID
+	}
+	err := pool.AddAccount(&tokenpool.Account{
+		ID:           req.ID,
+		Name:         req.Name,
+		AccessToken:  req.AccessToken,
+		RefreshToken: req.RefreshToken,
+		Enabled:      true,
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "already exists") {
+			status = http.StatusConflict
+		}
+		s.jsonError(w, err.Error(), status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(pool.Report())
+}
+
+// handleRemovePoolAccount removes an account and its state.
+// DELETE /api/pool/accounts/{id}
+func (s *Server) handleRemovePoolAccount(w http.ResponseWriter, r *http.Request) {
+	pool := s.openTokenPool(w, r)
+	if pool == nil {
+		return
+	}
+	if err := pool.RemoveAccount(r.PathValue("id")); err != nil {
+		status := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			status = http.StatusNotFound
+		}
+		s.jsonError(w, err.Error(), status)
+		return
+	}
+	s.jsonResponse(w, pool.Report())
+}
+
+// handleSwitchPoolAccount makes an enabled account the current one.
+// POST /api/pool/accounts/{id}/switch
+func (s *Server) handleSwitchPoolAccount(w http.ResponseWriter, r *http.Request) {
+	pool := s.openTokenPool(w, r)
+	if pool == nil {
+		return
+	}
+	if err := pool.SwitchTo(r.PathValue("id")); err != nil {
+		status := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			status = http.StatusNotFound
+		}
+		s.jsonError(w, err.Error(), status)
+		return
+	}
+	s.jsonResponse(w, pool.Report())
+}
+
+// handleResetPool clears the exhausted flag of every account.
+// POST /api/pool/reset
+func (s *Server) handleResetPool(w http.ResponseWriter, r *http.Request) {
+	pool := s.openTokenPool(w, r)
+	if pool == nil {
+		return
+	}
+	pool.ResetExhausted()
+	s.jsonResponse(w, pool.Report())
+}
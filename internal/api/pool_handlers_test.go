@@ -0,0 +1,102 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/tokenpool"
+)
+
+func newPoolTestServer(t *testing.T) (*Server, string) {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "pool.yaml")
+	s := &Server{
+		mux:       http.NewServeMux(),
+		logger:    slog.Default(),
+		orcConfig: &config.Config{Pool: config.PoolConfig{ConfigPath: configPath}},
+	}
+	s.registerRESTRoutes()
+	return s, configPath
+}
+
+func servePool(s *Server, method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return w
+}
+
+func TestPoolRoutes(t *testing.T) {
+	s, configPath := newPoolTestServer(t)
+
+	w := servePool(s, http.MethodPost, "/api/pool/accounts", `{"id":"work","access_token":"sk-ant-REDACTED"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("add status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "secret") {
+		t.Errorf("response leaks the token: %s", w.Body.String())
+	}
+	if w := servePool(s, http.MethodPost, "/api/pool/accounts", `{"id":"work","access_token":"x"}`); w.Code != http.StatusConflict {
+		t.Errorf("duplicate add status = %d, want 409", w.Code)
+	}
+	if w := servePool(s, http.MethodPost, "/api/pool/accounts", `{"id":"personal"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("add without token status = %d, want 400", w.Code)
+	}
+	servePool(s, http.MethodPost, "/api/pool/accounts", `{"id":"personal","access_token":"sk-ant-REDACTED"}`)
+
+	// The executor records a rate limit and usage on the current account
+	pool, err := tokenpool.New(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.RecordUsage("work", 1000, 200, 0.5)
+	pool.MarkExhausted("rate limit exceeded")
+
+	w = servePool(s, http.MethodGet, "/api/pool", "")
+	var report tokenpool.Report
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode: %v (%s)", err, w.Body.String())
+	}
+	if len(report.Accounts) != 2 || !report.SwitchOnRateLimit {
+		t.Fatalf("report = %+v", report)
+	}
+	work := report.Accounts[0]
+	if !work.Current || !work.Exhausted || work.RateLimitCount != 1 || work.Usage.InputTokens != 1000 || work.Usage.Phases != 1 {
+		t.Errorf("work account = %+v", work)
+	}
+
+	if w := servePool(s, http.MethodPost, "/api/pool/accounts/personal/switch", ""); w.Code != http.StatusOK {
+		t.Fatalf("switch status = %d, body = %s", w.Code, w.Body.String())
+	}
+	w = servePool(s, http.MethodPost, "/api/pool/reset", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Accounts[0].Exhausted || !report.Accounts[1].Current {
+		t.Errorf("after switch and reset = %+v", report.Accounts)
+	}
+
+	if w := servePool(s, http.MethodDelete, "/api/pool/accounts/work", ""); w.Code != http.StatusOK {
+		t.Fatalf("remove status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if w := servePool(s, http.MethodDelete, "/api/pool/accounts/work", ""); w.Code != http.StatusNotFound {
+		t.Errorf("second remove status = %d, want 404", w.Code)
+	}
+}
+
+func TestPoolRoutes_EmptyPool(t *testing.T) {
+	s, _ := newPoolTestServer(t)
+
+	w := servePool(s, http.MethodGet, "/api/pool", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"accounts":[]`) {
+		t.Errorf("body = %s, want an empty accounts list", w.Body.String())
+	}
+}
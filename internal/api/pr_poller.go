@@ -10,6 +10,7 @@ import (
 
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
 	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/executor"
 	"github.com/randalmurphal/orc/internal/hosting"
 	_ "github.com/randalmurphal/orc/internal/hosting/github"
 	_ "github.com/randalmurphal/orc/internal/hosting/gitlab"
@@ -201,6 +202,16 @@ func (p *PRPoller) pollTask(ctx context.Context, provider hosting.Provider, t *o
 		return err
 	}
 
+	// A PR merged into a release branch gets a linked back-port task
+	if newStatus == orcv1.PRStatus_PR_STATUS_MERGED && oldStatus != newStatus {
+		if bt, err := executor.CreateBackportTask(p.backend, p.orcConfig, t, pr.BaseBranch, ""); err != nil {
+			p.logger.Warn("failed to create back-port task", "task", t.Id, "release_branch", pr.BaseBranch, "error", err)
+		} else if bt != nil {
+			p.logger.Info("back-port task created", "task", t.Id, "backport", bt.Id, "release_branch", pr.BaseBranch)
+		}
+		executor.SyncInitiativeBranch(ctx, executor.NewInitiativeCompleter(nil, provider, p.backend, p.orcConfig, p.logger, p.workDir), t)
+	}
+
 	// Notify if status changed
 	if oldStatus != newStatus && p.onStatusChange != nil {
 		p.onStatusChange(t.Id, t.Pr)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
 	"github.com/randalmurphal/orc/internal/controlplane"
 	"github.com/randalmurphal/orc/internal/db"
 	"github.com/randalmurphal/orc/internal/hosting"
@@ -213,10 +214,13 @@ type emptyBackend struct{}
 
 func (b *emptyBackend) LoadAllTasks() ([]*orcv1.Task, error) { return nil, nil }
 func (b *emptyBackend) SaveTask(*orcv1.Task) error           { return nil }
+func (b *emptyBackend) SaveTasks([]*orcv1.Task) error        { return nil }
 func (b *emptyBackend) LoadTask(string) (*orcv1.Task, error) { return nil, nil }
 func (b *emptyBackend) DeleteTask(string) error              { return nil }
 func (b *emptyBackend) TaskExists(string) (bool, error)      { return false, nil }
 func (b *emptyBackend) GetNextTaskID() (string, error)       { return "", nil }
+func (b *emptyBackend) SavePlan(*db.Plan) error              { return nil }
+func (b *emptyBackend) LoadPlan(string) (*db.Plan, error)    { return nil, nil }
 func (b *emptyBackend) GetTaskActivityByDate(string, string) ([]storage.ActivityCount, error) {
 	return nil, nil
 }
@@ -504,3 +508,48 @@ func TestPRPoller_StopConcurrent(t *testing.T) {
 	// Wait for all goroutines to complete - this would panic without sync.Once
 	wg.Wait()
 }
+
+func TestPRPoller_MergedReleasePRCreatesBackport(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewTestBackend(t)
+	id, err := backend.GetNextTaskID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tsk := task.NewProtoTask(id, "Fix crash on empty config")
+	tsk.Branch = "orc/" + id
+	task.SetTargetBranchProto(tsk, "release/1.2")
+	task.SetPRInfoProto(tsk, "https://github.com/o/r/pull/7", 7)
+	if err := backend.SaveTask(tsk); err != nil {
+		t.Fatal(err)
+	}
+
+	poller := NewPRPoller(PRPollerConfig{Backend: backend, OrcConfig: config.Default()})
+	provider := &mockGitHubProvider{
+		FindPRByBranchFunc: func(ctx context.Context, branch string) (*hosting.PR, error) {
+			return &hosting.PR{Number: 7, State: "MERGED", BaseBranch: "release/1.2"}, nil
+		},
+		StatusSummaryFunc: func(ctx context.Context, pr *hosting.PR) (*hosting.PRStatusSummary, error) {
+			return &hosting.PRStatusSummary{ReviewStatus: "approved"}, nil
+		},
+	}
+	if err := poller.pollTask(context.Background(), provider, tsk); err != nil {
+		t.Fatalf("pollTask: %v", err)
+	}
+
+	reloaded, err := backend.LoadTask(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backportID := reloaded.Metadata["backport_task"]
+	if backportID == "" {
+		t.Fatal("merged release PR did not create a back-port task")
+	}
+	backport, err := backend.LoadTask(backportID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backport.GetTargetBranch() != "main" || backport.Metadata["backport_of"] != id {
+		t.Errorf("back-port = target %q, metadata %v", backport.GetTargetBranch(), backport.Metadata)
+	}
+}
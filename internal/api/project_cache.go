@@ -3,6 +3,7 @@ package api
 
 import (
 	"fmt"
+	"log/slog"
 	"sync"
 
 	"github.com/randalmurphal/orc/internal/config"
@@ -156,6 +157,25 @@ func (c *ProjectCache) Size() int {
 	return len(c.entries)
 }
 
+// newProjectBackend opens the backend for the storage mode configured in
+// the project at path, falling back to the defaults like the server does.
+// Backends are cached, so task directories are synced once per server run.
+func newProjectBackend(path string) (storage.Backend, error) {
+	cfg, err := config.LoadFrom(path)
+	if err != nil {
+		slog.Warn("failed to load project config, using defaults", "path", path, "error", err)
+		cfg = config.Default()
+	}
+	backend, err := storage.NewBackend(path, &cfg.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SyncTaskFiles(backend); err != nil {
+		slog.Warn("failed to sync task directories", "path", path, "error", err)
+	}
+	return backend, nil
+}
+
 // GetBackend returns a storage.Backend for the given project ID.
 // Creates the backend on first access using the cached ProjectDB.
 func (c *ProjectCache) GetBackend(projectID string) (storage.Backend, error) {
@@ -169,7 +189,7 @@ func (c *ProjectCache) GetBackend(projectID string) (storage.Backend, error) {
 			return entry.backend, nil
 		}
 		// Entry exists but no backend yet - create it
-		backend, err := storage.NewDatabaseBackend(entry.path, &config.StorageConfig{Mode: "database"})
+		backend, err := newProjectBackend(entry.path)
 		if err != nil {
 			return nil, fmt.Errorf("create backend: %w", err)
 		}
@@ -194,7 +214,7 @@ func (c *ProjectCache) GetBackend(projectID string) (storage.Backend, error) {
 	}
 
 	// Create backend
-	backend, err := storage.NewDatabaseBackend(proj.Path, &config.StorageConfig{Mode: "database"})
+	backend, err := newProjectBackend(proj.Path)
 	if err != nil {
 		_ = pdb.Close()
 		return nil, fmt.Errorf("create backend: %w", err)
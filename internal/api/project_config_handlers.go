@@ -0,0 +1,379 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/project"
+)
+
+// Project-scoped prompts, hooks, skills and Claude settings. Each handler
+// resolves {id} from the project registry and runs the ConfigService logic
+// against that project's directory, so one server can manage every
+// registered repo rather than only the one it was started in.
+
+// projectConfigServer returns a config service rooted at the project named
+// by the {id} path value. Writes the error response and returns nil when the
+// project is unknown or belongs to another tenant.
+func (s *Server) projectConfigServer(w http.ResponseWriter, r *http.Request) *configServer {
+	id := r.PathValue("id")
+	if scope := tenantFromContext(r.Context()); scope != nil && !scope.Owns(id) {
+		s.jsonError(w, "project not found", http.StatusNotFound)
+		return nil
+	}
+	reg, err := project.LoadRegistry()
+	if err != nil {
+		s.jsonError(w, "load project registry: "+err.Error(), http.StatusInternalServerError)
+		return nil
+	}
+	proj, err := reg.Get(id)
+	if err != nil {
+		s.jsonError(w, "project not found", http.StatusNotFound)
+		return nil
+	}
+	return &configServer{
+		orcConfig: s.orcConfig,
+		globalDB:  s.globalDB,
+		workDir:   proj.Path,
+		logger:    s.logger,
+	}
+}
+
+// writeConfigError writes a ConfigService error with the matching HTTP status.
+func (s *Server) writeConfigError(w http.ResponseWriter, err error) {
+	s.jsonError(w, connectErrorMessage(err), connectErrorStatus(err))
+}
+
+// connectErrorStatus maps a Connect error code to the matching HTTP status.
+func connectErrorStatus(err error) int {
+	switch connect.CodeOf(err) {
+	case connect.CodeInvalidArgument:
+		return http.StatusBadRequest
+	case connect.CodeNotFound:
+		return http.StatusNotFound
+	case connect.CodeAlreadyExists:
+		return http.StatusConflict
+	case connect.CodePermissionDenied:
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// connectErrorMessage returns the message of a Connect error without the
+// code prefix.
+func connectErrorMessage(err error) string {
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		return connectErr.Message()
+	}
+	return err.Error()
+}
+
+// GET /api/projects/{id}/prompts
+func (s *Server) handleListProjectPrompts(w http.ResponseWriter, r *http.Request) {
+	cs := s.projectConfigServer(w, r)
+	if cs == nil {
+		return
+	}
+	resp, err := cs.ListPrompts(r.Context(), connect.NewRequest(&orcv1.ListPromptsRequest{}))
+	if err != nil {
+		s.writeConfigError(w, err)
+		return
+	}
+	s.jsonResponse(w, resp.Msg)
+}
+
+// GET /api/projects/{id}/prompts/{phase}
+func (s *Server) handleGetProjectPrompt(w http.ResponseWriter, r *http.Request) {
+	cs := s.projectConfigServer(w, r)
+	if cs == nil {
+		return
+	}
+	resp, err := cs.GetPrompt(r.Context(), connect.NewRequest(&orcv1.GetPromptRequest{Phase: r.PathValue("phase")}))
+	if err != nil {
+		s.writeConfigError(w, err)
+		return
+	}
+	s.jsonResponse(w, resp.Msg)
+}
+
+// GET /api/projects/{id}/prompts/{phase}/default
+func (s *Server) handleGetProjectDefaultPrompt(w http.ResponseWriter, r *http.Request) {
+	cs := s.projectConfigServer(w, r)
+	if cs == nil {
+		return
+	}
+	resp, err := cs.GetDefaultPrompt(r.Context(), connect.NewRequest(&orcv1.GetDefaultPromptRequest{Phase: r.PathValue("phase")}))
+	if err != nil {
+		s.writeConfigError(w, err)
+		return
+	}
+	s.jsonResponse(w, resp.Msg)
+}
+
+// PUT /api/projects/{id}/prompts/{phase} with {"content": "..."}
+func (s *Server) handleUpdateProjectPrompt(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Content string `json:"content"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		s.jsonError(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	cs := s.projectConfigServer(w, r)
+	if cs == nil {
+		return
+	}
+	resp, err := cs.UpdatePrompt(r.Context(), connect.NewRequest(&orcv1.UpdatePromptRequest{
+		Phase:   r.PathValue("phase"),
+		Content: body.Content,
+	}))
+	if err != nil {
+		s.writeConfigError(w, err)
+		return
+	}
+	s.jsonResponse(w, resp.Msg)
+}
+
+// DELETE /api/projects/{id}/prompts/{phase}
+func (s *Server) handleDeleteProjectPrompt(w http.ResponseWriter, r *http.Request) {
+	cs := s.projectConfigServer(w, r)
+	if cs == nil {
+		return
+	}
+	resp, err := cs.DeletePrompt(r.Context(), connect.NewRequest(&orcv1.DeletePromptRequest{Phase: r.PathValue("phase")}))
+	if err != nil {
+		s.writeConfigError(w, err)
+		return
+	}
+	s.jsonResponse(w, resp.Msg)
+}
+
+// GET /api/projects/{id}/settings[?scope=project|global]
+// Without scope, returns the merged global and project settings.
+func (s *Server) handleGetProjectSettings(w http.ResponseWriter, r *http.Request) {
+	var scope orcv1.SettingsScope
+	switch r.URL.Query().Get("scope") {
+	case "":
+	case "project":
+		scope = orcv1.SettingsScope_SETTINGS_SCOPE_PROJECT
+	case "global":
+		scope = orcv1.SettingsScope_SETTINGS_SCOPE_GLOBAL
+	default:
+		s.jsonError(w, "scope must be project or global", http.StatusBadRequest)
+		return
+	}
+	cs := s.projectConfigServer(w, r)
+	if cs == nil {
+		return
+	}
+	resp, err := cs.GetSettings(r.Context(), connect.NewRequest(&orcv1.GetSettingsRequest{Scope: scope}))
+	if err != nil {
+		s.writeConfigError(w, err)
+		return
+	}
+	s.jsonResponse(w, resp.Msg)
+}
+
+// PUT /api/projects/{id}/settings with a Settings message; writes the
+// project's .claude/settings.json.
+func (s *Server) handleUpdateProjectSettings(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.jsonError(w, "read request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	settings := &orcv1.Settings{}
+	if err := protojson.Unmarshal(data, settings); err != nil {
+		s.jsonError(w, "invalid settings: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	cs := s.projectConfigServer(w, r)
+	if cs == nil {
+		return
+	}
+	resp, err := cs.UpdateSettings(r.Context(), connect.NewRequest(&orcv1.UpdateSettingsRequest{
+		Scope:    orcv1.SettingsScope_SETTINGS_SCOPE_PROJECT,
+		Settings: settings,
+	}))
+	if err != nil {
+		s.writeConfigError(w, err)
+		return
+	}
+	s.jsonResponse(w, resp.Msg)
+}
+
+// GET /api/projects/{id}/settings/hierarchy
+func (s *Server) handleGetProjectSettingsHierarchy(w http.ResponseWriter, r *http.Request) {
+	cs := s.projectConfigServer(w, r)
+	if cs == nil {
+		return
+	}
+	resp, err := cs.GetSettingsHierarchy(r.Context(), connect.NewRequest(&orcv1.GetSettingsHierarchyRequest{}))
+	if err != nil {
+		s.writeConfigError(w, err)
+		return
+	}
+	s.jsonResponse(w, resp.Msg)
+}
+
+// GET /api/projects/{id}/hooks lists hook scripts in the project's
+// .claude/hooks/ that are new or changed relative to the hook library.
+func (s *Server) handleListProjectHooks(w http.ResponseWriter, r *http.Request) {
+	s.listProjectClaudeItems(w, r, "hook")
+}
+
+// GET /api/projects/{id}/skills lists skills in the project's
+// .claude/skills/ that are new or changed relative to the skill library.
+func (s *Server) handleListProjectSkills(w http.ResponseWriter, r *http.Request) {
+	s.listProjectClaudeItems(w, r, "skill")
+}
+
+func (s *Server) listProjectClaudeItems(w http.ResponseWriter, r *http.Request, itemType string) {
+	cs := s.projectConfigServer(w, r)
+	if cs == nil {
+		return
+	}
+	items, err := scanProjectClaudeItems(r, cs, itemType, nil)
+	if err != nil {
+		s.writeConfigError(w, err)
+		return
+	}
+	s.jsonResponse(w, &orcv1.ScanClaudeDirResponse{Items: items})
+}
+
+// scanProjectClaudeItems scans the project's .claude/ directory for items of
+// one type, optionally restricted to the given names.
+func scanProjectClaudeItems(r *http.Request, cs *configServer, itemType string, names []string) ([]*orcv1.DiscoveredItem, error) {
+	resp, err := cs.ScanClaudeDir(r.Context(), connect.NewRequest(&orcv1.ScanClaudeDirRequest{
+		Source: orcv1.SettingsScope_SETTINGS_SCOPE_PROJECT,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	var items []*orcv1.DiscoveredItem
+	for _, item := range resp.Msg.Items {
+		if item.ItemType != itemType {
+			continue
+		}
+		if names != nil && !slices.Contains(names, item.Name) {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// projectItemsRequest is the body for hook and skill export/import:
+// library IDs to export, or discovered names to import.
+type projectItemsRequest struct {
+	IDs   []string `json:"ids"`
+	Names []string `json:"names"`
+}
+
+// POST /api/projects/{id}/hooks/export with {"ids": [...]}; writes library
+// hooks to the project's .claude/hooks/.
+func (s *Server) handleExportProjectHooks(w http.ResponseWriter, r *http.Request) {
+	var body projectItemsRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		s.jsonError(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	cs := s.projectConfigServer(w, r)
+	if cs == nil {
+		return
+	}
+	resp, err := cs.ExportHooks(r.Context(), connect.NewRequest(&orcv1.ExportHooksRequest{
+		HookIds:     body.IDs,
+		Destination: orcv1.SettingsScope_SETTINGS_SCOPE_PROJECT,
+	}))
+	if err != nil {
+		s.writeConfigError(w, err)
+		return
+	}
+	s.jsonResponse(w, resp.Msg)
+}
+
+// POST /api/projects/{id}/skills/export with {"ids": [...]}; writes library
+// skills to the project's .claude/skills/.
+func (s *Server) handleExportProjectSkills(w http.ResponseWriter, r *http.Request) {
+	var body projectItemsRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		s.jsonError(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	cs := s.projectConfigServer(w, r)
+	if cs == nil {
+		return
+	}
+	resp, err := cs.ExportSkills(r.Context(), connect.NewRequest(&orcv1.ExportSkillsRequest{
+		SkillIds:    body.IDs,
+		Destination: orcv1.SettingsScope_SETTINGS_SCOPE_PROJECT,
+	}))
+	if err != nil {
+		s.writeConfigError(w, err)
+		return
+	}
+	s.jsonResponse(w, resp.Msg)
+}
+
+// POST /api/projects/{id}/hooks/import with {"names": [...]}; adds the named
+// hooks from the project's .claude/hooks/ to the library.
+func (s *Server) handleImportProjectHooks(w http.ResponseWriter, r *http.Request) {
+	s.importProjectClaudeItems(w, r, "hook")
+}
+
+// POST /api/projects/{id}/skills/import with {"names": [...]}; adds the named
+// skills from the project's .claude/skills/ to the library.
+func (s *Server) handleImportProjectSkills(w http.ResponseWriter, r *http.Request) {
+	s.importProjectClaudeItems(w, r, "skill")
+}
+
+func (s *Server) importProjectClaudeItems(w http.ResponseWriter, r *http.Request, itemType string) {
+	var body projectItemsRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		s.jsonError(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(body.Names) == 0 {
+		s.jsonError(w, "names is required", http.StatusBadRequest)
+		return
+	}
+	cs := s.projectConfigServer(w, r)
+	if cs == nil {
+		return
+	}
+	items, err := scanProjectClaudeItems(r, cs, itemType, body.Names)
+	if err != nil {
+		s.writeConfigError(w, err)
+		return
+	}
+	if len(items) != len(body.Names) {
+		s.jsonError(w, "no new or changed "+itemType+" with that name in the project", http.StatusNotFound)
+		return
+	}
+
+	if itemType == "hook" {
+		resp, err := cs.ImportHooks(r.Context(), connect.NewRequest(&orcv1.ImportHooksRequest{Items: items}))
+		if err != nil {
+			s.writeConfigError(w, err)
+			return
+		}
+		s.jsonResponse(w, resp.Msg)
+		return
+	}
+	resp, err := cs.ImportSkills(r.Context(), connect.NewRequest(&orcv1.ImportSkillsRequest{Items: items}))
+	if err != nil {
+		s.writeConfigError(w, err)
+		return
+	}
+	s.jsonResponse(w, resp.Msg)
+}
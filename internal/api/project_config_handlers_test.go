@@ -0,0 +1,175 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/project"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+// newProjectConfigTestServer registers a project in a temp HOME and returns
+// a server started in a different directory, plus the project ID and path.
+func newProjectConfigTestServer(t *testing.T) (*Server, string, string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dir := filepath.Join(home, "other-repo")
+	if err := os.MkdirAll(filepath.Join(dir, ".orc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	p, err := project.RegisterProject(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{
+		mux:      http.NewServeMux(),
+		logger:   slog.Default(),
+		workDir:  t.TempDir(),
+		globalDB: storage.NewTestGlobalDB(t),
+	}
+	s.registerRESTRoutes()
+	return s, p.ID, dir
+}
+
+func serveProjectConfig(s *Server, method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return w
+}
+
+func TestProjectPromptRoutes(t *testing.T) {
+	s, id, dir := newProjectConfigTestServer(t)
+	base := "/api/projects/" + id + "/prompts"
+
+	w := serveProjectConfig(s, http.MethodPut, base+"/implement", `{"content":"Project prompt {{TASK_ID}}"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("PUT status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".orc", "prompts", "implement.md")); err != nil {
+		t.Errorf("override not written to the project: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(s.workDir, ".orc", "prompts", "implement.md")); err == nil {
+		t.Error("override written to the server working directory")
+	}
+
+	w = serveProjectConfig(s, http.MethodGet, base+"/implement", "")
+	var got struct {
+		Prompt struct {
+			Content  string `json:"content"`
+			IsCustom bool   `json:"isCustom"`
+		} `json:"prompt"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v (%s)", err, w.Body.String())
+	}
+	if got.Prompt.Content != "Project prompt {{TASK_ID}}" {
+		t.Errorf("prompt = %+v", got.Prompt)
+	}
+
+	if w := serveProjectConfig(s, http.MethodDelete, base+"/implement", ""); w.Code != http.StatusOK {
+		t.Fatalf("DELETE status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if w := serveProjectConfig(s, http.MethodDelete, base+"/implement", ""); w.Code != http.StatusNotFound {
+		t.Errorf("second DELETE status = %d, want 404", w.Code)
+	}
+	if w := serveProjectConfig(s, http.MethodPut, base+"/implement", `{"content":""}`); w.Code != http.StatusBadRequest {
+		t.Errorf("empty PUT status = %d, want 400", w.Code)
+	}
+}
+
+func TestProjectSettingsRoutes(t *testing.T) {
+	s, id, dir := newProjectConfigTestServer(t)
+	base := "/api/projects/" + id + "/settings"
+
+	w := serveProjectConfig(s, http.MethodPut, base, `{"permissions":{"Bash":true,"WebFetch":false}}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("PUT status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".claude", "settings.json")); err != nil {
+		t.Errorf("settings not written to the project: %v", err)
+	}
+
+	w = serveProjectConfig(s, http.MethodGet, base+"?scope=project", "")
+	var got struct {
+		Settings struct {
+			Permissions map[string]bool `json:"permissions"`
+		} `json:"settings"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v (%s)", err, w.Body.String())
+	}
+	if !got.Settings.Permissions["Bash"] {
+		t.Errorf("settings = %+v", got.Settings)
+	}
+
+	if w := serveProjectConfig(s, http.MethodGet, base+"?scope=bogus", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("bad scope status = %d, want 400", w.Code)
+	}
+}
+
+func TestProjectHookRoutes(t *testing.T) {
+	s, id, dir := newProjectConfigTestServer(t)
+	seedHook(t, s.globalDB, "hook-1", "guard", "PreToolUse", "#!/bin/sh\necho guard\n")
+	base := "/api/projects/" + id + "/hooks"
+
+	w := serveProjectConfig(s, http.MethodPost, base+"/export", `{"ids":["hook-1"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("export status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".claude", "hooks", "guard")); err != nil {
+		t.Errorf("hook not exported to the project: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ".claude", "hooks", "lint"), []byte("#!/bin/sh\nmake lint\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	w = serveProjectConfig(s, http.MethodGet, base, "")
+	var listed struct {
+		Items []struct {
+			Name   string `json:"name"`
+			Status string `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &listed); err != nil {
+		t.Fatalf("decode: %v (%s)", err, w.Body.String())
+	}
+	if len(listed.Items) != 1 || listed.Items[0].Name != "lint" || listed.Items[0].Status != "new" {
+		t.Errorf("listed hooks = %+v", listed.Items)
+	}
+
+	if w := serveProjectConfig(s, http.MethodPost, base+"/import", `{"names":["lint"]}`); w.Code != http.StatusOK {
+		t.Fatalf("import status = %d, body = %s", w.Code, w.Body.String())
+	}
+	hooks, err := s.globalDB.ListHookScripts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	imported := false
+	for _, h := range hooks {
+		imported = imported || h.Name == "lint"
+	}
+	if !imported {
+		t.Error("lint hook not imported into the library")
+	}
+	if w := serveProjectConfig(s, http.MethodPost, base+"/import", `{"names":["missing"]}`); w.Code != http.StatusNotFound {
+		t.Errorf("import of unknown hook status = %d, want 404", w.Code)
+	}
+}
+
+func TestProjectConfigRoutes_UnknownProject(t *testing.T) {
+	s, _, _ := newProjectConfigTestServer(t)
+	for _, path := range []string{"/api/projects/nope/prompts", "/api/projects/nope/settings", "/api/projects/nope/skills"} {
+		if w := serveProjectConfig(s, http.MethodGet, path, ""); w.Code != http.StatusNotFound {
+			t.Errorf("GET %s status = %d, want 404", path, w.Code)
+		}
+	}
+}
@@ -54,6 +54,7 @@ func (s *projectServer) ListProjects(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list projects: %w", err))
 	}
+	projects = filterProjectsForTenant(ctx, projects)
 
 	// Get default project ID
 	defaultID, _ := project.GetDefaultProject()
@@ -102,6 +103,11 @@ func (s *projectServer) GetDefaultProject(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get default project: %w", err))
 	}
 
+	// Tenants only see a default project they own
+	if scope := tenantFromContext(ctx); scope != nil && !scope.Owns(defaultID) {
+		defaultID = ""
+	}
+
 	resp := &orcv1.GetDefaultProjectResponse{}
 	if defaultID != "" {
 		resp.DefaultProjectId = &defaultID
@@ -216,6 +222,7 @@ func (s *projectServer) GetAllProjectsStatus(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("list projects: %w", err))
 	}
+	projects = filterProjectsForTenant(ctx, projects)
 
 	now := time.Now().UTC()
 	todayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
@@ -395,7 +402,7 @@ func (s *branchServer) ListBranches(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-	dbBackend, ok := backend.(*storage.DatabaseBackend)
+	dbBackend, ok := storage.DatabaseOf(backend)
 	if !ok {
 		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("branches require database backend"))
 	}
@@ -441,7 +448,7 @@ func (s *branchServer) GetBranch(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-	dbBackend, ok := backend.(*storage.DatabaseBackend)
+	dbBackend, ok := storage.DatabaseOf(backend)
 	if !ok {
 		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("branches require database backend"))
 	}
@@ -468,7 +475,7 @@ func (s *branchServer) UpdateBranchStatus(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-	dbBackend, ok := backend.(*storage.DatabaseBackend)
+	dbBackend, ok := storage.DatabaseOf(backend)
 	if !ok {
 		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("branches require database backend"))
 	}
@@ -505,7 +512,7 @@ func (s *branchServer) DeleteBranch(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-	dbBackend, ok := backend.(*storage.DatabaseBackend)
+	dbBackend, ok := storage.DatabaseOf(backend)
 	if !ok {
 		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("branches require database backend"))
 	}
@@ -543,7 +550,7 @@ func (s *branchServer) CleanupStaleBranches(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-	dbBackend, ok := backend.(*storage.DatabaseBackend)
+	dbBackend, ok := storage.DatabaseOf(backend)
 	if !ok {
 		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("branches require database backend"))
 	}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/randalmurphal/orc/internal/project"
+	"github.com/randalmurphal/orc/internal/projectsync"
+)
+
+// projectSyncRequest is the body of POST /api/projects/sync.
+type projectSyncRequest struct {
+	projectsync.Options
+	// Source is a registered project ID, or a template directory path.
+	// Empty uses the server's project. Paths are refused in multi-tenant mode.
+	Source string `json:"source,omitempty"`
+	// Projects are target project IDs or names, or ["all"].
+	Projects []string `json:"projects"`
+	DryRun   bool     `json:"dry_run"`
+}
+
+// projectSyncResponse lists the per-project plans and whether they were written.
+type projectSyncResponse struct {
+	Applied  bool                      `json:"applied"`
+	Projects []projectsync.ProjectPlan `json:"projects"`
+}
+
+// handleProjectSync previews or applies a config/prompt sync from one source
+// to many registered projects.
+// POST /api/projects/sync
+func (s *Server) handleProjectSync(w http.ResponseWriter, r *http.Request) {
+	var req projectSyncRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.jsonError(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(req.Projects) == 0 {
+		s.jsonError(w, "projects is required (project IDs or names, or [\"all\"])", http.StatusBadRequest)
+		return
+	}
+
+	reg, err := project.LoadRegistry()
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	scope := tenantFromContext(r.Context())
+
+	sourcePath := s.workDir
+	if req.Source != "" {
+		if p, err := reg.Get(req.Source); err == nil {
+			if scope != nil && !scope.Owns(p.ID) {
+				s.jsonError(w, "project not found: "+req.Source, http.StatusNotFound)
+				return
+			}
+			sourcePath = p.Path
+		} else if scope != nil {
+			s.jsonError(w, "source must be a project ID in multi-tenant mode", http.StatusBadRequest)
+			return
+		} else {
+			sourcePath = req.Source
+		}
+	}
+	sourceDir, err := projectsync.SourceDir(sourcePath)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	targets, err := projectsync.ResolveTargets(reg, req.Projects)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if scope != nil {
+		if len(req.Projects) != 1 || req.Projects[0] != projectsync.AllProjects {
+			for _, t := range targets {
+				if !scope.Owns(t.ID) {
+					s.jsonError(w, "project not found: "+t.ID, http.StatusNotFound)
+					return
+				}
+			}
+		}
+		targets = filterProjectsForTenant(r.Context(), targets)
+	}
+
+	plans, err := projectsync.Plan(sourceDir, targets, req.Options)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !req.DryRun {
+		if err := projectsync.Apply(plans); err != nil {
+			s.jsonError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, p := range plans {
+			if p.Changed() {
+				s.logger.Info("project synced", "project", p.ProjectID, "source", sourceDir)
+			}
+		}
+	}
+	s.jsonResponse(w, projectSyncResponse{Applied: !req.DryRun, Projects: plans})
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/project"
+	"github.com/randalmurphal/orc/internal/projectsync"
+)
+
+func TestHandleProjectSync_PreviewThenApply(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	var ids []string
+	for _, name := range []string{"source", "target"} {
+		dir := filepath.Join(home, name)
+		if err := os.MkdirAll(filepath.Join(dir, ".orc", "prompts"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		p, err := project.RegisterProject(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, p.ID)
+	}
+	source := filepath.Join(home, "source", ".orc")
+	if err := os.WriteFile(filepath.Join(source, "config.yaml"), []byte("gates:\n  default_type: ai\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(source, "prompts", "review.md"), []byte("Review strictly.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: filepath.Join(home, "source")}
+	s.registerRESTRoutes()
+
+	post := func(body map[string]any) projectSyncResponse {
+		t.Helper()
+		data, _ := json.Marshal(body)
+		w := httptest.NewRecorder()
+		s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/projects/sync", bytes.NewReader(data)))
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+		}
+		var resp projectSyncResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return resp
+	}
+
+	preview := post(map[string]any{"config": true, "prompts": true, "projects": []string{"all"}, "dry_run": true})
+	if preview.Applied || len(preview.Projects) != 1 || preview.Projects[0].ProjectID != ids[1] {
+		t.Fatalf("preview = %+v, want one unapplied plan for the target", preview)
+	}
+	if changes := preview.Projects[0].Changes; len(changes) != 2 || changes[0].Action != projectsync.ActionCreate || changes[0].Diff == "" {
+		t.Fatalf("changes = %+v", changes)
+	}
+	if _, err := os.Stat(filepath.Join(home, "target", ".orc", "config.yaml")); !os.IsNotExist(err) {
+		t.Fatal("dry run wrote the target config")
+	}
+
+	applied := post(map[string]any{"config": true, "prompts": true, "projects": []string{ids[1]}})
+	if !applied.Applied {
+		t.Fatal("expected applied response")
+	}
+	got, err := os.ReadFile(filepath.Join(home, "target", ".orc", "prompts", "review.md"))
+	if err != nil || string(got) != "Review strictly.\n" {
+		t.Errorf("review.md = %q, %v", got, err)
+	}
+}
+
+func TestHandleProjectSync_RequiresProjects(t *testing.T) {
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir()}
+	s.registerRESTRoutes()
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/projects/sync", bytes.NewReader([]byte(`{"config": true}`))))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", w.Code)
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+)
+
+// errReadOnly is returned for mutating requests in read-only mode.
+var errReadOnly = errors.New("server is in read-only mode")
+
+// readOnlyRPCPrefixes are the Connect method name prefixes that only read
+// state. Every other RPC is treated as mutating, so new RPCs are blocked in
+// read-only mode until they are known to be safe.
+var readOnlyRPCPrefixes = []string{"Get", "List", "Subscribe", "Stream", "Download"}
+
+// isReadOnlyProcedure reports whether a Connect procedure
+// ("/orc.v1.TaskService/ListTasks") only reads state.
+func isReadOnlyProcedure(procedure string) bool {
+	method := procedure[strings.LastIndex(procedure, "/")+1:]
+	for _, prefix := range readOnlyRPCPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// readOnlyMiddleware rejects REST and file requests that can change state.
+// Connect RPCs all use POST and are classified by readOnlyInterceptor.
+func (s *Server) readOnlyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			if !isConnectPath(r.URL.Path) {
+				s.jsonError(w, errReadOnly.Error(), http.StatusForbidden)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// readOnlyInterceptor rejects mutating Connect RPCs with permission_denied
+// (HTTP 403) in read-only mode. Reads and event streams pass.
+type readOnlyInterceptor struct {
+	s *Server
+}
+
+var _ connect.Interceptor = (*readOnlyInterceptor)(nil)
+
+func (i *readOnlyInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if i.s.readOnly && !isReadOnlyProcedure(req.Spec().Procedure) {
+			return nil, connect.NewError(connect.CodePermissionDenied, errReadOnly)
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *readOnlyInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *readOnlyInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if i.s.readOnly && !isReadOnlyProcedure(conn.Spec().Procedure) {
+			return connect.NewError(connect.CodePermissionDenied, errReadOnly)
+		}
+		return next(ctx, conn)
+	}
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/gen/proto/orc/v1/orcv1connect"
+)
+
+func TestIsReadOnlyProcedure(t *testing.T) {
+	tests := []struct {
+		procedure string
+		want      bool
+	}{
+		{"/orc.v1.TaskService/ListTasks", true},
+		{"/orc.v1.TaskService/GetTask", true},
+		{"/orc.v1.EventService/Subscribe", true},
+		{"/orc.v1.TranscriptService/StreamTranscript", true},
+		{"/orc.v1.TaskService/CreateTask", false},
+		{"/orc.v1.TaskService/RunTask", false},
+		{"/orc.v1.TaskService/ExportTask", false},
+		{"/orc.v1.ProjectService/AddProject", false},
+	}
+	for _, tt := range tests {
+		if got := isReadOnlyProcedure(tt.procedure); got != tt.want {
+			t.Errorf("isReadOnlyProcedure(%q) = %v, want %v", tt.procedure, got, tt.want)
+		}
+	}
+}
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), readOnly: true}
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {})
+	handler := s.Handler()
+
+	tests := []struct {
+		method, path string
+		wantCode     int
+	}{
+		{http.MethodGet, "/api/scripts", http.StatusOK},
+		{http.MethodOptions, "/api/scripts/lint/run", http.StatusOK},
+		{http.MethodPost, "/api/scripts/lint/run", http.StatusForbidden},
+		{http.MethodPut, "/api/config/user", http.StatusForbidden},
+		{http.MethodDelete, "/files/tasks/TASK-001/attachments/a.png", http.StatusForbidden},
+		// Connect RPCs are classified by readOnlyInterceptor.
+		{http.MethodPost, "/orc.v1.TaskService/ListTasks", http.StatusOK},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+		if w.Code != tt.wantCode {
+			t.Errorf("%s %s = %d, want %d", tt.method, tt.path, w.Code, tt.wantCode)
+		}
+	}
+}
+
+func TestReadOnlyInterceptor(t *testing.T) {
+	s := &Server{readOnly: true}
+	mux := http.NewServeMux()
+	mux.Handle(orcv1connect.NewTaskServiceHandler(orcv1connect.UnimplementedTaskServiceHandler{},
+		connect.WithInterceptors(&readOnlyInterceptor{s: s})))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := orcv1connect.NewTaskServiceClient(srv.Client(), srv.URL)
+
+	_, err := client.CreateTask(context.Background(), connect.NewRequest(&orcv1.CreateTaskRequest{Title: "x"}))
+	if connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("CreateTask code = %v, want permission_denied", connect.CodeOf(err))
+	}
+
+	// Reads reach the handler (which is unimplemented here).
+	_, err = client.ListTasks(context.Background(), connect.NewRequest(&orcv1.ListTasksRequest{}))
+	if connect.CodeOf(err) != connect.CodeUnimplemented {
+		t.Errorf("ListTasks code = %v, want unimplemented", connect.CodeOf(err))
+	}
+
+	s.readOnly = false
+	_, err = client.CreateTask(context.Background(), connect.NewRequest(&orcv1.CreateTaskRequest{Title: "x"}))
+	if connect.CodeOf(err) != connect.CodeUnimplemented {
+		t.Errorf("CreateTask without read-only code = %v, want unimplemented", connect.CodeOf(err))
+	}
+}
@@ -778,17 +778,12 @@ func resolvePromotedTaskWorkflow(pdb *db.ProjectDB, sourceTask *orcv1.Task, cate
 		return *sourceTask.WorkflowId, nil
 	}
 
+	// The project's own config, never the server process's working directory
 	cfg := config.Default()
-	projectDir := ""
-	if pdb != nil {
-		projectDir = pdb.ProjectDir()
-	}
-	if projectDir != "" {
-		if loaded, err := config.LoadFrom(projectDir); err == nil {
+	if pdb != nil && pdb.ProjectDir() != "" {
+		if loaded, err := config.LoadFrom(pdb.ProjectDir()); err == nil {
 			cfg = loaded
 		}
-	} else if loaded, err := config.Load(); err == nil {
-		cfg = loaded
 	}
 
 	workflowID, _ := cfg.ResolveWorkflow("", category)
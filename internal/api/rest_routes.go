@@ -0,0 +1,211 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// registerRESTRoutes sets up plain JSON HTTP routes under /api/.
+// These cover operations that are file- or process-oriented rather than
+// structured RPCs (user config files, inheritance inspection, and similar).
+func (s *Server) registerRESTRoutes() {
+	// User-level config (~/.orc/config.yaml) and inheritance explorer
+	s.mux.HandleFunc("GET /api/config/user", restCORS(s.handleGetUserConfig))
+	s.mux.HandleFunc("PUT /api/config/user", restCORS(s.handlePutUserConfig))
+	s.mux.HandleFunc("GET /api/config/inheritance", restCORS(s.handleConfigInheritance))
+
+	// Built-in automation profiles and named presets (~/.orc/profiles/*.yaml)
+	s.mux.HandleFunc("GET /api/config/profiles", restCORS(s.handleListConfigProfiles))
+
+	// Skills installed from an index (provenance is not part of the Skill proto)
+	s.mux.HandleFunc("GET /api/skills/installed", restCORS(s.handleListInstalledSkills))
+
+	// Workflow as resolved from files, with its extends chain
+	s.mux.HandleFunc("GET /api/workflows/{id}/resolution", restCORS(s.handleWorkflowResolution))
+
+	// Push config keys and prompt overrides to other registered projects
+	s.mux.HandleFunc("POST /api/projects/sync", restCORS(s.handleProjectSync))
+
+	// Detected stack, suggested commands, profile and missing prerequisites
+	s.mux.HandleFunc("GET /api/projects/{id}/onboarding", restCORS(s.handleProjectOnboarding))
+
+	// Prompts, hooks, skills and Claude settings of any registered project
+	s.mux.HandleFunc("GET /api/projects/{id}/prompts", restCORS(s.handleListProjectPrompts))
+	s.mux.HandleFunc("GET /api/projects/{id}/prompts/{phase}", restCORS(s.handleGetProjectPrompt))
+	s.mux.HandleFunc("GET /api/projects/{id}/prompts/{phase}/default", restCORS(s.handleGetProjectDefaultPrompt))
+	s.mux.HandleFunc("PUT /api/projects/{id}/prompts/{phase}", restCORS(s.handleUpdateProjectPrompt))
+	s.mux.HandleFunc("DELETE /api/projects/{id}/prompts/{phase}", restCORS(s.handleDeleteProjectPrompt))
+	s.mux.HandleFunc("GET /api/projects/{id}/settings", restCORS(s.handleGetProjectSettings))
+	s.mux.HandleFunc("PUT /api/projects/{id}/settings", restCORS(s.handleUpdateProjectSettings))
+	s.mux.HandleFunc("GET /api/projects/{id}/settings/hierarchy", restCORS(s.handleGetProjectSettingsHierarchy))
+	s.mux.HandleFunc("GET /api/projects/{id}/hooks", restCORS(s.handleListProjectHooks))
+	s.mux.HandleFunc("POST /api/projects/{id}/hooks/export", restCORS(s.handleExportProjectHooks))
+	s.mux.HandleFunc("POST /api/projects/{id}/hooks/import", restCORS(s.handleImportProjectHooks))
+	s.mux.HandleFunc("GET /api/projects/{id}/skills", restCORS(s.handleListProjectSkills))
+	s.mux.HandleFunc("POST /api/projects/{id}/skills/export", restCORS(s.handleExportProjectSkills))
+	s.mux.HandleFunc("POST /api/projects/{id}/skills/import", restCORS(s.handleImportProjectSkills))
+
+	// Task list for polling clients: changes and deletions since a checkpoint
+	s.mux.HandleFunc("GET /api/tasks", restCORS(s.handleListTasksDelta))
+
+	// Several tasks at once, with blocked_by between them, in one transaction
+	s.mux.HandleFunc("POST /api/tasks/bulk", restCORS(s.handleBulkCreateTasks))
+
+	// Planned/paused tasks untouched past tasks.stale.after
+	s.mux.HandleFunc("GET /api/tasks/stale", restCORS(s.handleListStaleTasks))
+
+	// Full blocked_by graph with critical path, for graph/Gantt views
+	s.mux.HandleFunc("GET /api/tasks/graph", restCORS(s.handleTaskGraph))
+
+	// Execution state snapshots and the diff between two of them
+	s.mux.HandleFunc("GET /api/tasks/{id}/state-snapshots", restCORS(s.handleListStateSnapshots))
+	s.mux.HandleFunc("GET /api/tasks/{id}/state-diff", restCORS(s.handleStateDiff))
+
+	// Complete task view in one request, with field selection
+	s.mux.HandleFunc("GET /api/tasks/{id}/full", restCORS(s.handleTaskFull))
+
+	// Plain-text event stream, one line per event, for screen readers and terminals
+	s.mux.HandleFunc("GET /api/tasks/{id}/stream.txt", restCORS(s.handleTaskStreamText))
+
+	// Task changes against the target branch, for review before a merge gate
+	s.mux.HandleFunc("GET /api/tasks/{id}/diff", restCORS(s.handleTaskDiff))
+
+	// Spec success criteria mapped to the code and tests that satisfy them
+	s.mux.HandleFunc("GET /api/tasks/{id}/traceability", restCORS(s.handleGetTraceability))
+	s.mux.HandleFunc("GET /api/tasks/{id}/traceability/reports", restCORS(s.handleListTraceabilityReports))
+
+	// Human gates awaiting a decision; the executor waiting at the gate continues
+	s.mux.HandleFunc("GET /api/gates", restCORS(s.handleListPendingGates))
+	s.mux.HandleFunc("POST /api/tasks/{id}/gates/{phase}/approve", restCORS(s.handleApproveGate))
+	s.mux.HandleFunc("POST /api/tasks/{id}/gates/{phase}/reject", restCORS(s.handleRejectGate))
+
+	// Per-task and per-initiative cost budgets; raising a task's budget resumes it
+	s.mux.HandleFunc("GET /api/tasks/{id}/budget", restCORS(s.handleGetTaskBudget))
+	s.mux.HandleFunc("POST /api/tasks/{id}/budget", restCORS(s.handleUpdateTaskBudget))
+	s.mux.HandleFunc("PUT /api/initiatives/{id}/budget", restCORS(s.handleSetInitiativeBudget))
+
+	// Member status of an initiative's integration branch and its combined PR
+	s.mux.HandleFunc("GET /api/initiatives/{id}/rollup", restCORS(s.handleInitiativeRollup))
+
+	// Versioned project context documents included in phase prompts
+	s.mux.HandleFunc("GET /api/context-documents", restCORS(s.handleListContextDocuments))
+	s.mux.HandleFunc("GET /api/context-documents/{name}", restCORS(s.handleGetContextDocument))
+	s.mux.HandleFunc("PUT /api/context-documents/{name}", restCORS(s.handleSaveContextDocument))
+	s.mux.HandleFunc("DELETE /api/context-documents/{name}", restCORS(s.handleDeleteContextDocument))
+	s.mux.HandleFunc("GET /api/context-documents/{name}/versions", restCORS(s.handleListContextDocumentVersions))
+
+	// Lessons document generated from recurring review feedback
+	s.mux.HandleFunc("GET /api/lessons", restCORS(s.handleGetLessons))
+	s.mux.HandleFunc("POST /api/lessons/refresh", restCORS(s.handleRefreshLessons))
+
+	// Audit log of mutating API calls, RPCs, and CLI commands
+	s.mux.HandleFunc("GET /api/audit", restCORS(s.handleListAudit))
+
+	// Runtime settings each phase of a task last ran with
+	s.mux.HandleFunc("GET /api/tasks/{id}/effective-settings", restCORS(s.handleTaskEffectiveSettings))
+
+	// Full-text search over task titles, descriptions, specs and transcripts
+	s.mux.HandleFunc("GET /api/search", restCORS(s.handleSearch))
+
+	// Active-time estimates and weekly velocity (not part of the dashboard protos)
+	s.mux.HandleFunc("GET /api/analytics/velocity", restCORS(s.handleVelocity))
+
+	// Runs of scripts registered through ConfigService.CreateScript
+	s.mux.HandleFunc("POST /api/scripts/{name}/run", restCORS(s.handleRunScript))
+	s.mux.HandleFunc("GET /api/scripts/{name}/runs", restCORS(s.handleListScriptRuns))
+	s.mux.HandleFunc("GET /api/scripts/{name}/runs/{id}", restCORS(s.handleGetScriptRun))
+
+	// Webhook receivers for task lifecycle events (~/.orc/webhooks.yaml;
+	// hooks from webhooks.endpoints are listed but read-only)
+	s.mux.HandleFunc("GET /api/webhooks", restCORS(s.handleListWebhooks))
+	s.mux.HandleFunc("POST /api/webhooks", restCORS(s.handleCreateWebhook))
+	s.mux.HandleFunc("GET /api/webhooks/{id}", restCORS(s.handleGetWebhook))
+	s.mux.HandleFunc("PUT /api/webhooks/{id}", restCORS(s.handleUpdateWebhook))
+	s.mux.HandleFunc("DELETE /api/webhooks/{id}", restCORS(s.handleDeleteWebhook))
+
+	// OAuth token pool (pool.config_path): account rate-limit state and usage
+	s.mux.HandleFunc("GET /api/pool", restCORS(s.handlePoolStatus))
+	s.mux.HandleFunc("POST /api/pool/accounts", restCORS(s.handleAddPoolAccount))
+	s.mux.HandleFunc("DELETE /api/pool/accounts/{id}", restCORS(s.handleRemovePoolAccount))
+	s.mux.HandleFunc("POST /api/pool/accounts/{id}/switch", restCORS(s.handleSwitchPoolAccount))
+	s.mux.HandleFunc("POST /api/pool/reset", restCORS(s.handleResetPool))
+
+	// CLAUDE.md drift against the codebase (not part of the DashboardStats proto)
+	s.mux.HandleFunc("GET /api/dashboard/docs-drift", restCORS(s.handleDocDrift))
+
+	// Instance role in high-availability mode (leader election state)
+	s.mux.HandleFunc("GET /api/ha/status", restCORS(s.handleHAStatus))
+	s.mux.HandleFunc("GET /api/db/maintenance", restCORS(s.handleDBMaintenance))
+
+	// Project SBOM with per-component task attribution (compliance.sbom)
+	s.mux.HandleFunc("GET /api/sbom", restCORS(s.handleProjectSBOM))
+
+	// Editor extensions: local handshake, task summary with deep links, and
+	// task creation from the current file/selection
+	s.mux.HandleFunc("POST /api/editor/handshake", restCORS(s.handleEditorHandshake))
+	s.mux.HandleFunc("POST /api/editor/tasks", restCORS(s.handleEditorCreateTask))
+	s.mux.HandleFunc("GET /api/tasks/{id}/summary", restCORS(s.handleTaskSummary))
+
+	// Shared run queue: slot usage and per-project queue depth (server.scheduler)
+	s.mux.HandleFunc("GET /api/scheduler/queue", restCORS(s.handleRunQueue))
+
+	// Server and per-task execution logs (`orc logs --url`)
+	s.mux.HandleFunc("GET /api/logs", restCORS(s.handleLogs))
+
+	// Runtime feature flags, so the UI only renders what this server serves
+	s.mux.HandleFunc("GET /api/features", restCORS(s.handleFeatures))
+
+	// Dependency checks and circuit breaker states for probes and dashboards
+	s.mux.HandleFunc("GET /api/health", restCORS(s.handleHealthReady))
+	s.mux.HandleFunc("GET /api/health/live", restCORS(s.handleHealthLive))
+	s.mux.HandleFunc("GET /api/health/ready", restCORS(s.handleHealthReady))
+
+	// CORS preflights: browsers send one before a cross-origin read that
+	// carries Authorization (server.auth.require_for_reads). The routes above
+	// are method-specific, so without this the mux answers them with 405.
+	s.mux.HandleFunc("OPTIONS /api/", restCORS(func(http.ResponseWriter, *http.Request) {}))
+}
+
+// restCORS wraps a JSON REST handler with CORS headers for browser clients.
+// Only reads are shared cross-origin. Mutating routes (PUT /api/config/user,
+// project settings, ...) send no CORS headers and reject requests whose
+// Origin is not this server, so a page open in the user's browser cannot
+// change local config with a no-preflight POST.
+func restCORS(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-Request-ID, "+auditActorHeader+", "+editorTokenHeader)
+			w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+		default:
+			if !isSameOrigin(r) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "cross-origin request rejected"})
+				return
+			}
+		}
+		h(w, r)
+	}
+}
+
+// isSameOrigin reports whether r carries no Origin header (CLI, editors,
+// scripts) or an Origin whose host matches the request host (the embedded UI).
+func isSameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return u.Host == r.Host
+}
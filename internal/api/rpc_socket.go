@@ -0,0 +1,483 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/randalmurphal/orc/internal/config"
+)
+
+// The socket speaks JSON-RPC 2.0: one JSON value per request, responses in
+// any order. Methods are Connect procedures named "Service.Method"
+// (TaskService.ListTasks) with the request message as params and the
+// response message as result, in protobuf JSON form. Server-streaming
+// methods send each message as an "rpc.stream" notification carrying the
+// call's id, then a result with the message count.
+const (
+	rpcVersion = "2.0"
+
+	rpcMethodDiscover = "rpc.discover"
+	rpcMethodCancel   = "rpc.cancel"
+	rpcMethodStream   = "rpc.stream"
+
+	rpcCodeParseError     = -32700
+	rpcCodeInvalidRequest = -32600
+	rpcCodeMethodNotFound = -32601
+	rpcCodeInvalidParams  = -32602
+	rpcCodeInternal       = -32603
+	rpcCodeServerError    = -32000
+
+	// rpcMaxStreamMessage bounds one enveloped stream message.
+	rpcMaxStreamMessage = 64 << 20
+)
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcNotification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// rpcMethod describes a Connect procedure reachable over the socket.
+type rpcMethod struct {
+	Name            string `json:"name"`
+	Procedure       string `json:"procedure"`
+	Input           string `json:"input"`
+	Output          string `json:"output"`
+	ServerStreaming bool   `json:"server_streaming,omitempty"`
+	ClientStreaming bool   `json:"client_streaming,omitempty"`
+}
+
+// rpcMethods lists every orc.v1 service method, keyed by "Service.Method".
+var rpcMethods = sync.OnceValue(func() map[string]rpcMethod {
+	methods := make(map[string]rpcMethod)
+	protoregistry.GlobalFiles.RangeFilesByPackage("orc.v1", func(fd protoreflect.FileDescriptor) bool {
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			svc := services.Get(i)
+			ms := svc.Methods()
+			for j := 0; j < ms.Len(); j++ {
+				md := ms.Get(j)
+				name := string(svc.Name()) + "." + string(md.Name())
+				methods[name] = rpcMethod{
+					Name:            name,
+					Procedure:       "/" + string(svc.FullName()) + "/" + string(md.Name()),
+					Input:           string(md.Input().FullName()),
+					Output:          string(md.Output().FullName()),
+					ServerStreaming: md.IsStreamingServer(),
+					ClientStreaming: md.IsStreamingClient(),
+				}
+			}
+		}
+		return true
+	})
+	return methods
+})
+
+// rpcSocketPath returns the configured socket path, defaulting to
+// ~/.orc/orc.sock.
+func rpcSocketPath(cfg config.SocketConfig) (string, error) {
+	if cfg.Path != "" {
+		return config.ExpandPath(cfg.Path), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir: %w", err)
+	}
+	return filepath.Join(home, ".orc", "orc.sock"), nil
+}
+
+// listenRPCSocket creates the Unix socket, replacing a stale socket file
+// left by a server that did not shut down cleanly. A socket another server
+// is still answering on is left alone.
+func listenRPCSocket(path string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("create %s: %w", filepath.Dir(path), err)
+	}
+	if conn, err := net.Dial("unix", path); err == nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("socket %s is in use by another server", path)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("remove stale socket: %w", err)
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("listen on %s: %w", path, err)
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("restrict socket permissions: %w", err)
+	}
+	return ln, nil
+}
+
+// startRPCSocket serves JSON-RPC on the configured Unix socket until ctx is
+// cancelled. It is skipped in multi-tenant mode, where every call needs a
+// tenant token. Failures are logged; the HTTP server runs regardless.
+func (s *Server) startRPCSocket(ctx context.Context) {
+	if s.orcConfig == nil || !s.orcConfig.Server.Socket.Enabled {
+		return
+	}
+	if s.tenancyEnabled() {
+		s.logger.Info("rpc socket disabled in multi-tenant mode")
+		return
+	}
+	path, err := rpcSocketPath(s.orcConfig.Server.Socket)
+	if err != nil {
+		s.logger.Warn("rpc socket unavailable", "error", err)
+		return
+	}
+	ln, err := listenRPCSocket(path)
+	if err != nil {
+		s.logger.Warn("rpc socket unavailable", "error", err)
+		return
+	}
+	s.socketPath = path
+	s.logger.Info("serving JSON-RPC on unix socket", "path", path)
+	s.backgroundWG.Add(1)
+	go func() {
+		defer s.backgroundWG.Done()
+		s.serveRPCSocket(ctx, ln)
+	}()
+}
+
+// serveRPCSocket accepts JSON-RPC connections until ctx is cancelled, then
+// closes the listener (which removes the socket file) and waits for open
+// connections to finish.
+func (s *Server) serveRPCSocket(ctx context.Context, ln net.Listener) {
+	var wg sync.WaitGroup
+	go func() {
+		<-ctx.Done()
+		_ = ln.Close()
+	}()
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() == nil {
+				s.logger.Warn("rpc socket accept failed", "error", err)
+			}
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.serveRPCConn(ctx, conn)
+		}()
+	}
+	wg.Wait()
+}
+
+// rpcConn is one socket connection. Calls run concurrently; writes are
+// serialized so each response or notification is a whole JSON value.
+type rpcConn struct {
+	s   *Server
+	ctx context.Context
+	enc *json.Encoder
+
+	writeMu sync.Mutex
+
+	callsMu sync.Mutex
+	calls   map[string]context.CancelFunc
+}
+
+func (c *rpcConn) write(v any) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_ = c.enc.Encode(v)
+}
+
+func (c *rpcConn) reply(id json.RawMessage, result any, rpcErr *rpcError) {
+	if id == nil {
+		return // Notification: no response
+	}
+	c.write(rpcResponse{JSONRPC: rpcVersion, ID: id, Result: result, Error: rpcErr})
+}
+
+func (s *Server) serveRPCConn(ctx context.Context, conn net.Conn) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer func() { _ = conn.Close() }()
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	c := &rpcConn{s: s, ctx: ctx, enc: json.NewEncoder(conn), calls: make(map[string]context.CancelFunc)}
+	dec := json.NewDecoder(bufio.NewReader(conn))
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if !errors.Is(err, io.EOF) && ctx.Err() == nil && !errors.Is(err, net.ErrClosed) {
+				c.write(rpcResponse{JSONRPC: rpcVersion, ID: json.RawMessage("null"),
+					Error: &rpcError{Code: rpcCodeParseError, Message: err.Error()}})
+			}
+			return
+		}
+		var req rpcRequest
+		if err := json.Unmarshal(raw, &req); err != nil || req.JSONRPC != rpcVersion || req.Method == "" {
+			c.write(rpcResponse{JSONRPC: rpcVersion, ID: requestIDOrNull(req.ID),
+				Error: &rpcError{Code: rpcCodeInvalidRequest, Message: "expected a JSON-RPC 2.0 request object"}})
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.handle(req)
+		}()
+	}
+}
+
+func requestIDOrNull(id json.RawMessage) json.RawMessage {
+	if id == nil {
+		return json.RawMessage("null")
+	}
+	return id
+}
+
+func (c *rpcConn) handle(req rpcRequest) {
+	switch req.Method {
+	case rpcMethodDiscover:
+		methods := make([]rpcMethod, 0, len(rpcMethods()))
+		for _, m := range rpcMethods() {
+			if !m.ClientStreaming {
+				methods = append(methods, m)
+			}
+		}
+		sort.Slice(methods, func(i, j int) bool { return methods[i].Name < methods[j].Name })
+		c.reply(req.ID, map[string]any{"methods": methods}, nil)
+		return
+	case rpcMethodCancel:
+		var p struct {
+			ID json.RawMessage `json:"id"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil || p.ID == nil {
+			c.reply(req.ID, nil, &rpcError{Code: rpcCodeInvalidParams, Message: "params.id is required"})
+			return
+		}
+		c.callsMu.Lock()
+		cancel, ok := c.calls[string(p.ID)]
+		c.callsMu.Unlock()
+		if ok {
+			cancel()
+		}
+		c.reply(req.ID, map[string]bool{"cancelled": ok}, nil)
+		return
+	}
+
+	m, ok := rpcMethods()[req.Method]
+	if !ok {
+		c.reply(req.ID, nil, &rpcError{Code: rpcCodeMethodNotFound, Message: "unknown method " + req.Method})
+		return
+	}
+	if m.ClientStreaming {
+		c.reply(req.ID, nil, &rpcError{Code: rpcCodeMethodNotFound, Message: req.Method + " is client-streaming, which the socket does not support"})
+		return
+	}
+
+	params := []byte(req.Params)
+	if len(bytes.TrimSpace(params)) == 0 || string(bytes.TrimSpace(params)) == "null" {
+		params = []byte("{}")
+	}
+
+	ctx, cancel := context.WithCancel(c.ctx)
+	defer cancel()
+	if req.ID != nil {
+		key := string(req.ID)
+		c.callsMu.Lock()
+		c.calls[key] = cancel
+		c.callsMu.Unlock()
+		defer func() {
+			c.callsMu.Lock()
+			delete(c.calls, key)
+			c.callsMu.Unlock()
+		}()
+	}
+
+	if m.ServerStreaming {
+		count, rpcErr := c.callStreaming(ctx, m, req.ID, params)
+		if rpcErr != nil {
+			c.reply(req.ID, nil, rpcErr)
+			return
+		}
+		c.reply(req.ID, map[string]int{"messages": count}, nil)
+		return
+	}
+	result, rpcErr := c.callUnary(ctx, m, params)
+	c.reply(req.ID, result, rpcErr)
+}
+
+// newProcedureRequest builds the in-process HTTP request for a procedure.
+// It goes through the server's full handler, so read-only mode and the
+// Connect interceptors apply as they do over HTTP. Token auth does not: the
+// socket is only reachable by the local user.
+func newProcedureRequest(ctx context.Context, m rpcMethod, contentType string, body []byte) *http.Request {
+	ctx = context.WithValue(ctx, localSocketContextKey{}, true)
+	r, _ := http.NewRequestWithContext(ctx, http.MethodPost, m.Procedure, bytes.NewReader(body))
+	r.Header.Set("Content-Type", contentType)
+	r.Header.Set("Connect-Protocol-Version", "1")
+	r.RemoteAddr = "unix"
+	return r
+}
+
+func (c *rpcConn) callUnary(ctx context.Context, m rpcMethod, params []byte) (json.RawMessage, *rpcError) {
+	w := &rpcResponseBuffer{header: make(http.Header), status: http.StatusOK}
+	c.s.Handler().ServeHTTP(w, newProcedureRequest(ctx, m, "application/json", params))
+	if w.status == http.StatusOK {
+		return json.RawMessage(bytes.TrimSpace(w.body.Bytes())), nil
+	}
+	return nil, rpcErrorFromConnect(w.status, w.body.Bytes())
+}
+
+// callStreaming runs a server-streaming procedure with the Connect
+// streaming protocol (enveloped messages) and forwards each message as an
+// rpc.stream notification.
+func (c *rpcConn) callStreaming(ctx context.Context, m rpcMethod, id json.RawMessage, params []byte) (int, *rpcError) {
+	envelope := make([]byte, 5+len(params))
+	binary.BigEndian.PutUint32(envelope[1:5], uint32(len(params)))
+	copy(envelope[5:], params)
+
+	pr, pw := io.Pipe()
+	w := &rpcStreamWriter{header: make(http.Header), pw: pw, status: http.StatusOK}
+	go func() {
+		c.s.Handler().ServeHTTP(w, newProcedureRequest(ctx, m, "application/connect+json", envelope))
+		_ = pw.Close()
+	}()
+	defer func() { _ = pr.Close() }()
+
+	reader := bufio.NewReader(pr)
+	count := 0
+	for {
+		var head [5]byte
+		if _, err := io.ReadFull(reader, head[:]); err != nil {
+			if w.status != http.StatusOK {
+				return count, &rpcError{Code: rpcCodeServerError, Message: http.StatusText(w.status)}
+			}
+			if ctx.Err() != nil {
+				return count, &rpcError{Code: rpcCodeServerError, Message: "cancelled", Data: map[string]string{"connect_code": "canceled"}}
+			}
+			return count, &rpcError{Code: rpcCodeInternal, Message: "stream ended without end-of-stream message"}
+		}
+		size := binary.BigEndian.Uint32(head[1:])
+		if size > rpcMaxStreamMessage {
+			return count, &rpcError{Code: rpcCodeInternal, Message: "stream message too large"}
+		}
+		msg := make([]byte, size)
+		if _, err := io.ReadFull(reader, msg); err != nil {
+			return count, &rpcError{Code: rpcCodeInternal, Message: "truncated stream message"}
+		}
+
+		if head[0]&0x02 != 0 { // End of stream
+			var end struct {
+				Error *connectErrorBody `json:"error"`
+			}
+			if json.Unmarshal(msg, &end) == nil && end.Error != nil {
+				return count, end.Error.rpcError()
+			}
+			return count, nil
+		}
+		count++
+		if id != nil {
+			c.write(rpcNotification{JSONRPC: rpcVersion, Method: rpcMethodStream,
+				Params: map[string]any{"id": id, "message": json.RawMessage(msg)}})
+		}
+	}
+}
+
+// connectErrorBody is the JSON error Connect returns for failed calls.
+type connectErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *connectErrorBody) rpcError() *rpcError {
+	code := rpcCodeServerError
+	switch e.Code {
+	case "unimplemented":
+		code = rpcCodeMethodNotFound
+	case "invalid_argument":
+		code = rpcCodeInvalidParams
+	}
+	msg := e.Message
+	if msg == "" {
+		msg = strings.ReplaceAll(e.Code, "_", " ")
+	}
+	return &rpcError{Code: code, Message: msg, Data: map[string]string{"connect_code": e.Code}}
+}
+
+func rpcErrorFromConnect(status int, body []byte) *rpcError {
+	var e connectErrorBody
+	if json.Unmarshal(body, &e) == nil && e.Code != "" {
+		return e.rpcError()
+	}
+	// Responses from middleware (read-only, tenancy) use {"error": "..."}
+	var plain struct {
+		Error string `json:"error"`
+	}
+	msg := http.StatusText(status)
+	if json.Unmarshal(body, &plain) == nil && plain.Error != "" {
+		msg = plain.Error
+	}
+	return &rpcError{Code: rpcCodeServerError, Message: msg, Data: map[string]int{"http_status": status}}
+}
+
+// rpcResponseBuffer collects a unary response in memory.
+type rpcResponseBuffer struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *rpcResponseBuffer) Header() http.Header         { return w.header }
+func (w *rpcResponseBuffer) WriteHeader(status int)      { w.status = status }
+func (w *rpcResponseBuffer) Write(p []byte) (int, error) { return w.body.Write(p) }
+
+// rpcStreamWriter hands a streaming response body to the reader as it is
+// written.
+type rpcStreamWriter struct {
+	header http.Header
+	pw     *io.PipeWriter
+	status int
+}
+
+func (w *rpcStreamWriter) Header() http.Header         { return w.header }
+func (w *rpcStreamWriter) WriteHeader(status int)      { w.status = status }
+func (w *rpcStreamWriter) Write(p []byte) (int, error) { return w.pw.Write(p) }
+func (w *rpcStreamWriter) Flush()                      {}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/diff"
+	"github.com/randalmurphal/orc/internal/gate"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+type rpcTestClient struct {
+	conn net.Conn
+	dec  *json.Decoder
+}
+
+func (c *rpcTestClient) call(t *testing.T, line string) map[string]any {
+	t.Helper()
+	if _, err := c.conn.Write([]byte(line + "\n")); err != nil {
+		t.Fatal(err)
+	}
+	var resp map[string]any
+	if err := c.dec.Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func rpcErrorCode(resp map[string]any) float64 {
+	e, _ := resp["error"].(map[string]any)
+	code, _ := e["code"].(float64)
+	return code
+}
+
+func newRPCTestClient(t *testing.T) (*Server, *rpcTestClient) {
+	t.Helper()
+	backend := storage.NewTestBackend(t)
+	s := &Server{
+		mux:              http.NewServeMux(),
+		backend:          backend,
+		logger:           slog.Default(),
+		orcConfig:        config.Default(),
+		workDir:          t.TempDir(),
+		projectDB:        backend.DB(),
+		runningTasks:     make(map[string]context.CancelFunc),
+		diffCache:        diff.NewCache(10),
+		pendingDecisions: gate.NewPendingDecisionStore(),
+		projectCache:     testProjectCacheForBackend("proj-001", backend),
+	}
+	s.registerConnectHandlers()
+
+	// Unix socket paths are length limited; t.TempDir() can exceed it.
+	dir, err := os.MkdirTemp("", "orc-rpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	path := filepath.Join(dir, "orc.sock")
+	ln, err := listenRPCSocket(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.serveRPCSocket(ctx, ln)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return s, &rpcTestClient{conn: conn, dec: json.NewDecoder(bufio.NewReader(conn))}
+}
+
+func TestRPCSocket_DiscoverAndUnaryCalls(t *testing.T) {
+	s, c := newRPCTestClient(t)
+
+	resp := c.call(t, `{"jsonrpc":"2.0","id":1,"method":"rpc.discover"}`)
+	result, _ := resp["result"].(map[string]any)
+	methods, _ := result["methods"].([]any)
+	var sawListTasks, sawSubscribe bool
+	for _, m := range methods {
+		m := m.(map[string]any)
+		switch m["name"] {
+		case "TaskService.ListTasks":
+			sawListTasks = m["procedure"] == "/orc.v1.TaskService/ListTasks"
+		case "EventService.Subscribe":
+			sawSubscribe = m["server_streaming"] == true
+		}
+	}
+	if !sawListTasks || !sawSubscribe {
+		t.Fatalf("discover result missing methods: %v", resp)
+	}
+
+	if err := s.backend.SaveTask(task.NewProtoTask("TASK-001", "Socket task")); err != nil {
+		t.Fatal(err)
+	}
+	resp = c.call(t, `{"jsonrpc":"2.0","id":"get","method":"TaskService.GetTask","params":{"projectId":"proj-001","taskId":"TASK-001"}}`)
+	if resp["id"] != "get" || resp["error"] != nil {
+		t.Fatalf("GetTask response = %v", resp)
+	}
+	got, _ := resp["result"].(map[string]any)
+	if tk, _ := got["task"].(map[string]any); tk["title"] != "Socket task" {
+		t.Errorf("GetTask result = %v", got)
+	}
+
+	resp = c.call(t, `{"jsonrpc":"2.0","id":2,"method":"TaskService.GetTask","params":{"projectId":"proj-001","taskId":"TASK-999"}}`)
+	data, _ := resp["error"].(map[string]any)["data"].(map[string]any)
+	if rpcErrorCode(resp) != rpcCodeServerError || data["connect_code"] != "not_found" {
+		t.Errorf("missing task response = %v", resp)
+	}
+}
+
+func TestRPCSocket_Errors(t *testing.T) {
+	_, c := newRPCTestClient(t)
+
+	tests := []struct {
+		name string
+		line string
+		code int
+	}{
+		{"unknown method", `{"jsonrpc":"2.0","id":1,"method":"TaskService.Nope"}`, rpcCodeMethodNotFound},
+		{"not json-rpc 2.0", `{"id":2,"method":"rpc.discover"}`, rpcCodeInvalidRequest},
+		{"bad params", `{"jsonrpc":"2.0","id":3,"method":"TaskService.GetTask","params":{"taskId":7}}`, rpcCodeInvalidParams},
+		{"cancel without id", `{"jsonrpc":"2.0","id":4,"method":"rpc.cancel","params":{}}`, rpcCodeInvalidParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := c.call(t, tt.line)
+			if rpcErrorCode(resp) != float64(tt.code) {
+				t.Errorf("response = %v, want code %d", resp, tt.code)
+			}
+		})
+	}
+
+	// Notifications get no response: the next reply is for the next call
+	resp := c.call(t, `{"jsonrpc":"2.0","method":"rpc.discover"}`+"\n"+`{"jsonrpc":"2.0","id":"after","method":"rpc.cancel","params":{"id":99}}`)
+	if resp["id"] != "after" {
+		t.Errorf("response = %v, want reply to the call after the notification", resp)
+	}
+}
+
+func TestListenRPCSocket_ReplacesStaleAndRefusesLive(t *testing.T) {
+	dir, err := os.MkdirTemp("", "orc-rpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	path := filepath.Join(dir, "orc.sock")
+
+	// A stale socket file: bound once, then abandoned without unlinking
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	_ = stale.Close()
+
+	ln, err := listenRPCSocket(path)
+	if err != nil {
+		t.Fatalf("replace stale socket: %v", err)
+	}
+	defer func() { _ = ln.Close() }()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("socket mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	if _, err := listenRPCSocket(path); err == nil || !strings.Contains(err.Error(), "in use") {
+		t.Errorf("second listen err = %v, want in use", err)
+	}
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/orchestrator"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+// newRunQueue builds the server-wide fair run queue from server.scheduler.
+func newRunQueue(cfg *config.Config) *orchestrator.FairQueue {
+	sched := cfg.Server.Scheduler
+	return orchestrator.NewFairQueue(sched.MaxRunningTasks,
+		orchestrator.FairnessPolicy(sched.Policy), sched.ProjectWeights)
+}
+
+// runQueueProject is the project key a task is queued under.
+func (s *Server) runQueueProject(projectID string) string {
+	if projectID == "" {
+		return s.defaultProjectID()
+	}
+	return projectID
+}
+
+// enqueueRun starts run in its own goroutine once the run queue grants the
+// task a slot. If ctx is cancelled while the task is still waiting, the
+// task leaves the queue and abandon releases what the caller reserved.
+func (s *Server) enqueueRun(ctx context.Context, backend storage.Backend, id, projectID string, run, abandon func()) {
+	if s.runQueue == nil {
+		go run()
+		return
+	}
+
+	key := s.runQueueProject(projectID)
+	stop := context.AfterFunc(ctx, func() {
+		if s.runQueue.Remove(id) {
+			s.logger.Info("queued task cancelled before it started", "task", id, "project", key)
+			abandon()
+		}
+	})
+	start := func() {
+		stop()
+		go func() {
+			defer s.runQueue.Done(key)
+			run()
+		}()
+	}
+
+	if queued := s.runQueue.Submit(key, id, start); queued {
+		// The task is RUNNING but has no executor yet; claim it for this
+		// server so stale-task detection does not treat it as orphaned.
+		hostname, _ := os.Hostname()
+		if err := backend.SetTaskExecutor(id, os.Getpid(), hostname); err != nil {
+			s.logger.Warn("failed to claim queued task", "task", id, "error", err)
+		}
+		s.logger.Info("task queued for a run slot", "task", id, "project", key)
+	}
+}
+
+// handleRunQueue reports run queue capacity and per-project queue depth.
+// In multi-tenant mode only the tenant's own projects are listed.
+func (s *Server) handleRunQueue(w http.ResponseWriter, r *http.Request) {
+	if s.runQueue == nil {
+		s.jsonResponse(w, orchestrator.FairQueueMetrics{Projects: []orchestrator.FairQueueProjectStats{}})
+		return
+	}
+	metrics := s.runQueue.Metrics()
+	if scope := tenantFromContext(r.Context()); scope != nil {
+		owned := metrics.Projects[:0]
+		for _, p := range metrics.Projects {
+			if scope.Owns(p.ProjectID) {
+				owned = append(owned, p)
+			}
+		}
+		metrics.Projects = owned
+	}
+	s.jsonResponse(w, metrics)
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/orchestrator"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestEnqueueRun_QueuesBeyondCapacity(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	for _, id := range []string{"TASK-001", "TASK-002", "TASK-003"} {
+		if err := backend.SaveTask(task.NewProtoTask(id, id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s := &Server{logger: slog.Default(), runQueue: orchestrator.NewFairQueue(1, orchestrator.FairnessRoundRobin, nil)}
+
+	release := make(chan struct{})
+	started := make(chan string, 3)
+	run := func(id string) func() {
+		return func() {
+			started <- id
+			<-release
+		}
+	}
+
+	s.enqueueRun(context.Background(), backend, "TASK-001", "proj-a", run("TASK-001"), func() {})
+	if got := <-started; got != "TASK-001" {
+		t.Fatalf("started %s, want TASK-001", got)
+	}
+
+	abandoned := make(chan struct{})
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	s.enqueueRun(cancelCtx, backend, "TASK-002", "proj-b", run("TASK-002"), func() { close(abandoned) })
+	s.enqueueRun(context.Background(), backend, "TASK-003", "proj-b", run("TASK-003"), func() {})
+
+	// Queued tasks are claimed by this server so they are not reaped as orphans.
+	queued, err := backend.LoadTask("TASK-002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queued.ExecutorPid != int32(os.Getpid()) {
+		t.Errorf("queued task executor pid = %d, want %d", queued.ExecutorPid, os.Getpid())
+	}
+
+	// Cancelling a queued task drops it from the queue.
+	cancel()
+	select {
+	case <-abandoned:
+	case <-time.After(time.Second):
+		t.Fatal("cancelled queued task was not abandoned")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/scheduler/queue", nil)
+	w := httptest.NewRecorder()
+	s.handleRunQueue(w, req)
+	var metrics orchestrator.FairQueueMetrics
+	if err := json.Unmarshal(w.Body.Bytes(), &metrics); err != nil {
+		t.Fatal(err)
+	}
+	if metrics.Capacity != 1 || metrics.Running != 1 || metrics.Queued != 1 {
+		t.Errorf("metrics = %+v, want capacity 1, running 1, queued 1", metrics)
+	}
+
+	close(release)
+	select {
+	case got := <-started:
+		if got != "TASK-003" {
+			t.Errorf("started %s after slot freed, want TASK-003", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("queued task did not start after slot freed")
+	}
+}
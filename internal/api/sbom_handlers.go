@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/compliance"
+	"github.com/randalmurphal/orc/internal/executor"
+)
+
+// handleProjectSBOM returns the project's software bill of materials at a
+// ref (default HEAD), with each component attributed to the completed tasks
+// whose recorded SBOM deltas introduced it at its current version.
+// GET /api/sbom?ref=main
+func (s *Server) handleProjectSBOM(w http.ResponseWriter, r *http.Request) {
+	backend, workDir, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ref := r.URL.Query().Get("ref")
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	sbom, err := compliance.ProjectSBOM(workDir, ref)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	tasks, err := backend.LoadAllTasks()
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for _, t := range tasks {
+		if t.Status != orcv1.TaskStatus_TASK_STATUS_COMPLETED {
+			continue
+		}
+		if delta := executor.LoadSBOMDelta(backend, t.Id); delta != nil {
+			sbom.Attribute(t.Id, delta)
+		}
+	}
+	s.jsonResponse(w, sbom)
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/compliance"
+	"github.com/randalmurphal/orc/internal/executor"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestHandleProjectSBOM(t *testing.T) {
+	workDir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"config", "user.email", "test@test.com"},
+		{"config", "user.name", "Test"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", workDir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(workDir, "requirements.txt"), []byte("requests==2.31.0\nsix==1.16.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{{"add", "."}, {"commit", "-m", "deps"}} {
+		if out, err := exec.Command("git", append([]string{"-C", workDir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	backend := storage.NewTestBackend(t)
+	delta := compliance.Diff(nil, []compliance.Dependency{
+		{Ecosystem: compliance.EcosystemPyPI, Name: "requests", Version: "2.31.0", Manifest: "requirements.txt"},
+	})
+	data, _ := json.Marshal(delta)
+	for _, id := range []string{"TASK-001", "TASK-002"} {
+		tk := task.NewProtoTask(id, "deps")
+		if id == "TASK-001" {
+			tk.Status = orcv1.TaskStatus_TASK_STATUS_COMPLETED
+		}
+		if err := backend.SaveTask(tk); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := backend.SaveAttachment(id, executor.SBOMDeltaAttachment, "application/json", data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: workDir, backend: backend}
+	s.registerRESTRoutes()
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/sbom", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	var sbom compliance.SBOM
+	if err := json.Unmarshal(w.Body.Bytes(), &sbom); err != nil {
+		t.Fatal(err)
+	}
+	if sbom.Format != compliance.SBOMFormat || len(sbom.Components) != 2 {
+		t.Fatalf("sbom = %+v, want both requirements", sbom)
+	}
+	for _, c := range sbom.Components {
+		switch c.Name {
+		case "requests":
+			if len(c.IntroducedBy) != 1 || c.IntroducedBy[0] != "TASK-001" {
+				t.Errorf("requests introduced_by = %v, want only the completed task", c.IntroducedBy)
+			}
+		case "six":
+			if len(c.IntroducedBy) != 0 {
+				t.Errorf("six introduced_by = %v, want none", c.IntroducedBy)
+			}
+		}
+	}
+
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/sbom?ref=no-such-ref", nil))
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("bad ref status = %d, want 422", w.Code)
+	}
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/randalmurphal/orc/internal/claude"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/scripts"
+)
+
+// scriptRunRequest is the JSON body for POST /api/scripts/{name}/run.
+type scriptRunRequest struct {
+	Params map[string]string `json:"params"`
+}
+
+// handleRunScript runs a script registered through the ConfigService script
+// RPCs: it validates parameters, records a run, and starts the script.
+// Output is published as script_output events keyed by the run ID, so
+// WebSocket clients subscribe with task_id set to the returned run ID. With
+// ?wait=true the response is sent after the script finishes.
+// POST /api/scripts/{name}/run
+func (s *Server) handleRunScript(w http.ResponseWriter, r *http.Request) {
+	backend, workDir, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req scriptRunRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.jsonError(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	script, err := claude.NewScriptService(workDir).Get(r.PathValue("name"))
+	if errors.Is(err, claude.ErrScriptNotFound) {
+		s.jsonError(w, "script not registered: "+r.PathValue("name"), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	bound, err := scripts.BindParams(req.Params)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := scripts.ResolvePath(workDir, script); err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pdb := backend.DB()
+	run := &db.ScriptRun{ScriptName: script.Name, Params: bound}
+	if err := pdb.CreateScriptRun(run); err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ctx := s.serverCtx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	projectID := r.URL.Query().Get("project_id")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.executeScriptRun(ctx, pdb, workDir, projectID, script, run)
+	}()
+
+	if r.URL.Query().Get("wait") == "true" {
+		<-done
+		s.jsonResponse(w, run)
+		return
+	}
+	JSONResponseStatus(w, run, http.StatusAccepted)
+}
+
+// executeScriptRun runs the script, streams its output, and stores the result.
+func (s *Server) executeScriptRun(ctx context.Context, pdb *db.ProjectDB, workDir, projectID string, script *claude.ProjectScript, run *db.ScriptRun) {
+	publish := func(eventType events.EventType, data any) {
+		if s.publisher != nil {
+			s.publisher.Publish(events.NewProjectEvent(eventType, projectID, run.ID, data))
+		}
+	}
+
+	result, err := scripts.Run(ctx, workDir, script, run.Params, func(stream, line string) {
+		publish(events.EventScriptOutput, events.ScriptOutputLine{
+			RunID: run.ID, Script: script.Name, Stream: stream, Line: line,
+		})
+	})
+
+	run.Status = db.ScriptRunSucceeded
+	if result != nil {
+		run.Output = result.Output
+		code := result.ExitCode
+		run.ExitCode = &code
+		if code != 0 {
+			run.Status = db.ScriptRunFailed
+		}
+	}
+	if err != nil {
+		run.Status = db.ScriptRunFailed
+		run.Error = err.Error()
+	}
+	if err := pdb.CompleteScriptRun(run); err != nil {
+		s.logger.Error("record script run", "run", run.ID, "script", script.Name, "error", err)
+	}
+	s.logger.Info("script run finished", "run", run.ID, "script", script.Name, "status", run.Status)
+
+	publish(events.EventScriptFinished, events.ScriptRunFinished{
+		RunID: run.ID, Script: script.Name, Status: run.Status, ExitCode: run.ExitCode, Error: run.Error,
+	})
+}
+
+// handleListScriptRuns returns recent runs of a script, newest first.
+// GET /api/scripts/{name}/runs?limit=N
+func (s *Server) handleListScriptRuns(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	limit := 20
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			s.jsonError(w, "invalid limit: "+v, http.StatusBadRequest)
+			return
+		}
+	}
+	runs, err := backend.DB().ListScriptRuns(r.PathValue("name"), limit)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if runs == nil {
+		runs = []*db.ScriptRun{}
+	}
+	s.jsonResponse(w, map[string]any{"runs": runs})
+}
+
+// handleGetScriptRun returns a single run, including its captured output.
+// GET /api/scripts/{name}/runs/{id}
+func (s *Server) handleGetScriptRun(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	run, err := backend.DB().GetScriptRun(r.PathValue("id"))
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if run == nil || run.ScriptName != r.PathValue("name") {
+		s.jsonError(w, "script run not found: "+r.PathValue("id"), http.StatusNotFound)
+		return
+	}
+	s.jsonResponse(w, run)
+}
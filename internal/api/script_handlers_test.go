@@ -0,0 +1,122 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/claude"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+func newScriptTestServer(t *testing.T) (*Server, *events.MemoryPublisher) {
+	t.Helper()
+	workDir := t.TempDir()
+	dir := filepath.Join(workDir, ".claude", "scripts")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "greet.sh"), []byte("#!/bin/sh\necho \"hello $ORC_PARAM_WHO\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Registered the same way ConfigService.CreateScript registers scripts
+	if err := claude.NewScriptService(workDir).Create(claude.ProjectScript{
+		Name: "greet", Path: ".claude/scripts/greet.sh", Description: "Say hello",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	pub := events.NewMemoryPublisher()
+	t.Cleanup(pub.Close)
+	s := &Server{
+		mux:       http.NewServeMux(),
+		logger:    slog.Default(),
+		workDir:   workDir,
+		backend:   storage.NewTestBackend(t),
+		publisher: pub,
+	}
+	s.registerRESTRoutes()
+	return s, pub
+}
+
+func TestRunScript_WaitRecordsRunAndStreamsOutput(t *testing.T) {
+	s, pub := newScriptTestServer(t)
+	sub := pub.Subscribe(events.GlobalTaskID)
+
+	body := bytes.NewBufferString(`{"params":{"who":"world"}}`)
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/scripts/greet/run?wait=true", body))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	var run db.ScriptRun
+	if err := json.Unmarshal(w.Body.Bytes(), &run); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if run.Status != db.ScriptRunSucceeded || !strings.Contains(run.Output, "hello world") {
+		t.Fatalf("run = %+v, want succeeded with output", run)
+	}
+
+	var sawOutput, sawFinished bool
+	timeout := time.After(time.Second)
+	for !(sawOutput && sawFinished) {
+		select {
+		case ev := <-sub:
+			if ev.TaskID != run.ID {
+				continue
+			}
+			switch ev.Type {
+			case events.EventScriptOutput:
+				sawOutput = true
+			case events.EventScriptFinished:
+				sawFinished = true
+			}
+		case <-timeout:
+			t.Fatalf("missing events: output=%v finished=%v", sawOutput, sawFinished)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/scripts/greet/runs/"+run.ID, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("get run status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/scripts/greet/runs", nil))
+	var list struct {
+		Runs []db.ScriptRun `json:"runs"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode runs: %v", err)
+	}
+	if len(list.Runs) != 1 || list.Runs[0].Params["who"] != "world" {
+		t.Errorf("runs = %+v, want one run with params", list.Runs)
+	}
+}
+
+func TestRunScript_RejectsInvalidParams(t *testing.T) {
+	s, _ := newScriptTestServer(t)
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/scripts/greet/run", bytes.NewBufferString(`{"params":{"who; rm -rf /":"x"}}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/scripts/missing/run", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unregistered script status = %d, want 404", w.Code)
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+// searchMaxLimit caps the limit parameter of GET /api/search.
+const searchMaxLimit = 200
+
+type searchResponse struct {
+	Query   string            `json:"query"`
+	Results []db.SearchResult `json:"results"`
+}
+
+// handleSearch runs a full-text search over task titles and descriptions,
+// specs, and transcripts. kind takes a comma-separated subset of task, spec
+// and transcript.
+// GET /api/search?q=&kind=&task=&limit=
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	params := r.URL.Query()
+	opts := db.SearchOpts{
+		Query:  strings.TrimSpace(params.Get("q")),
+		TaskID: params.Get("task"),
+	}
+	if opts.Query == "" {
+		s.jsonError(w, "q is required", http.StatusBadRequest)
+		return
+	}
+	if kinds := params.Get("kind"); kinds != "" {
+		for _, kind := range strings.Split(kinds, ",") {
+			opts.Kinds = append(opts.Kinds, strings.TrimSpace(kind))
+		}
+	}
+	if v := params.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > searchMaxLimit {
+			s.jsonError(w, "limit must be between 1 and "+strconv.Itoa(searchMaxLimit), http.StatusBadRequest)
+			return
+		}
+		opts.Limit = limit
+	}
+	for _, kind := range opts.Kinds {
+		if !slices.Contains(db.SearchKinds, kind) {
+			s.jsonError(w, "invalid kind: "+kind+" (must be task, spec, or transcript)", http.StatusBadRequest)
+			return
+		}
+	}
+
+	results, err := backend.DB().Search(opts)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.jsonResponse(w, searchResponse{Query: opts.Query, Results: results})
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+func TestHandleSearch(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	pdb := backend.DB()
+	if err := pdb.SaveTask(&db.Task{ID: "TASK-001", Title: "Rate limit webhooks", Status: "created", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pdb.AddTranscript(&db.Transcript{TaskID: "TASK-001", Phase: "implement", SessionID: "sess-1", MessageUUID: "m1", Type: "assistant", Role: "assistant", Content: "Added a token bucket to the webhook sender", Timestamp: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir(), backend: backend}
+	s.registerRESTRoutes()
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/search?q=webhooks", nil))
+	var resp searchResponse
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &resp) != nil {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if resp.Query != "webhooks" || len(resp.Results) != 1 || resp.Results[0].Kind != db.SearchKindTask || resp.Results[0].Snippet != "Rate limit <mark>webhooks</mark>" {
+		t.Errorf("response = %+v", resp)
+	}
+
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/search?q=bucket&kind=transcript&task=TASK-001", nil))
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.Results) != 1 || resp.Results[0].SessionID != "sess-1" || resp.Results[0].Phase != "implement" {
+		t.Errorf("transcript search: status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	for _, query := range []string{"", "?q=x&kind=commit", "?q=x&limit=0"} {
+		w = httptest.NewRecorder()
+		s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/search"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%q: status = %d, want 400", query, w.Code)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -24,8 +25,11 @@ import (
 	"github.com/randalmurphal/orc/internal/executor"
 	"github.com/randalmurphal/orc/internal/gate"
 	"github.com/randalmurphal/orc/internal/git"
+	"github.com/randalmurphal/orc/internal/logs"
+	"github.com/randalmurphal/orc/internal/orchestrator"
 	"github.com/randalmurphal/orc/internal/storage"
 	"github.com/randalmurphal/orc/internal/task"
+	"github.com/randalmurphal/orc/internal/webhooks"
 	"github.com/randalmurphal/orc/internal/workflow"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -62,15 +66,48 @@ type Server struct {
 	runningTasks   map[string]context.CancelFunc
 	runningTasksMu sync.RWMutex
 
+	// Running task count per tenant for max_running_tasks (guarded by runningTasksMu)
+	tenantRunning map[string]int
+
+	// Shared run slots, divided fairly between projects (server.scheduler)
+	runQueue *orchestrator.FairQueue
+
+	// Replaces the workflow executor when set (see SetTaskExecutor)
+	taskExecutor TaskExecutorFunc
+
 	// Diff cache for computed diffs
 	diffCache *diff.Cache
 
-	// PR status poller for periodic updates
-	prPoller *PRPoller
+	// Background pollers (PR status, doc drift), waited on at shutdown
+	backgroundWG sync.WaitGroup
+
+	// High-availability coordination (nil unless server.ha.enabled)
+	ha *haCoordinator
+
+	// Read-only mode rejects mutating requests with 403
+	readOnly bool
+
+	// orc version of the running binary
+	version string
 
 	// Automation service for trigger-based automation
 	automationSvc *automation.Service
 
+	// Latest scheduled CLAUDE.md drift report
+	docDrift docDriftState
+
+	// Latest scheduled database maintenance reports
+	dbMaintenance dbMaintenanceState
+
+	// Editor extension handshake secret and sessions
+	editor editorState
+
+	// Webhooks registered through /api/webhooks (nil if ~/.orc is unavailable)
+	webhookStore *webhooks.Store
+
+	// Unix socket serving JSON-RPC, empty when not listening
+	socketPath string
+
 	// Pending gate decisions (for human approval gates in API mode)
 	pendingDecisions *gate.PendingDecisionStore
 
@@ -95,16 +132,17 @@ type Event struct {
 // Config holds server configuration.
 type Config struct {
 	Addr            string
-	WorkDir         string // Project directory (defaults to ".")
+	WorkDir         string // Project directory (defaults to the project containing the CWD)
 	Logger          *slog.Logger
-	MaxPortAttempts int // Number of ports to try if initial port is busy (default: 10)
+	MaxPortAttempts int    // Number of ports to try if initial port is busy (default: 10)
+	ReadOnly        bool   // Reject mutating requests (also enabled by server.read_only)
+	Version         string // orc version, reported in opt-in telemetry
 }
 
 // DefaultConfig returns the default server configuration.
 func DefaultConfig() *Config {
 	return &Config{
 		Addr:            ":8080",
-		WorkDir:         ".",
 		Logger:          slog.Default(),
 		MaxPortAttempts: 10,
 	}
@@ -136,6 +174,11 @@ func New(cfg *Config) *Server {
 			logger.Warn("server started outside orc project", "workDir", workDir)
 		}
 	}
+	// Handlers resolve every project path from workDir, never the process
+	// working directory, so a relative WorkDir is pinned at startup
+	if abs, err := filepath.Abs(workDir); err == nil {
+		workDir = abs
+	}
 
 	// Load orc configuration from the work directory
 	orcCfg, err := config.LoadFrom(workDir)
@@ -144,13 +187,15 @@ func New(cfg *Config) *Server {
 		orcCfg = config.Default()
 	}
 
-	// Create storage backend (database-only mode)
-	storageCfg := &config.StorageConfig{Mode: "database"}
-	backend, err := storage.NewDatabaseBackend(workDir, storageCfg)
+	// Create storage backend for the project's storage mode
+	backend, err := storage.NewBackend(workDir, &orcCfg.Storage)
 	if err != nil {
 		// Fatal error - server cannot function without storage backend
 		panic(fmt.Sprintf("failed to create storage backend: %v", err))
 	}
+	if err := storage.SyncTaskFiles(backend); err != nil {
+		logger.Warn("failed to sync task directories", "error", err)
+	}
 
 	// Create event publisher with persistence
 	pub := events.NewPersistentPublisher(backend, "executor", logger)
@@ -189,6 +234,7 @@ func New(cfg *Config) *Server {
 		backend:          backend,
 		projectDB:        backend.DB(),
 		runningTasks:     make(map[string]context.CancelFunc),
+		runQueue:         newRunQueue(orcCfg),
 		diffCache:        diff.NewCache(100), // Cache up to 100 file diffs
 		automationSvc:    automationSvc,
 		pendingDecisions: gate.NewPendingDecisionStore(),
@@ -196,11 +242,10 @@ func New(cfg *Config) *Server {
 		serverCtxCancel:  serverCtxCancel,
 		sessionID:        uuid.New().String(),
 		sessionStart:     time.Now(),
+		readOnly:         cfg.ReadOnly || orcCfg.Server.ReadOnly,
+		version:          cfg.Version,
 	}
 
-	// Create WebSocket handler
-	s.wsHandler = NewWSHandler(pub, s, logger)
-
 	// Open global DB for cross-project resources and cost tracking
 	globalDB, err := db.OpenGlobal()
 	if err != nil {
@@ -208,6 +253,18 @@ func New(cfg *Config) *Server {
 	}
 	s.globalDB = globalDB
 
+	if store, err := webhooks.DefaultStore(); err != nil {
+		logger.Warn("webhook store unavailable", "error", err)
+	} else {
+		s.webhookStore = store
+	}
+
+	// Share events and elect a leader with peer instances (if enabled)
+	s.setupHA()
+
+	// Create WebSocket handler
+	s.wsHandler = NewWSHandler(s.publisher, s, logger)
+
 	// Seed built-in workflows and phase templates (into global DB)
 	if globalDB != nil {
 		if seeded, err := workflow.SeedBuiltins(globalDB); err != nil {
@@ -229,7 +286,7 @@ func New(cfg *Config) *Server {
 
 	// Create session broadcaster for real-time metrics
 	s.sessionBroadcaster = executor.NewSessionBroadcaster(
-		events.NewPublishHelper(pub),
+		events.NewPublishHelper(s.publisher),
 		backend,
 		globalDB,
 		workDir,
@@ -237,6 +294,7 @@ func New(cfg *Config) *Server {
 	)
 
 	s.registerFileRoutes()
+	s.registerRESTRoutes()
 	s.registerConnectHandlers()
 	return s
 }
@@ -288,7 +346,7 @@ func (s *Server) Start() error {
 		s.logger.Info("port in use, using alternative", "requested", basePort, "actual", actualPort)
 	}
 	s.logger.Info("starting API server", "addr", ln.Addr().String())
-	return http.Serve(ln, s.mux)
+	return http.Serve(ln, s.Handler())
 }
 
 // StartContext starts the API server with context for graceful shutdown.
@@ -308,7 +366,7 @@ func (s *Server) StartContext(ctx context.Context) error {
 	}
 
 	server := &http.Server{
-		Handler: s.mux,
+		Handler: s.Handler(),
 	}
 
 	// Cancel the default server context and replace with the provided one
@@ -323,34 +381,21 @@ func (s *Server) StartContext(ctx context.Context) error {
 	// deleted without proper cleanup (e.g., crashed processes, manual deletion).
 	s.pruneStaleWorktrees()
 
-	// Create and start PR status poller
-	s.prPoller = NewPRPoller(PRPollerConfig{
-		WorkDir:   s.workDir,
-		Interval:  60 * time.Second,
-		Logger:    s.logger,
-		OrcConfig: s.orcConfig,
-		Backend:   s.backend,
-		OnStatusChange: func(taskID string, pr *orcv1.PRInfo) {
-			// Publish task update event when PR status changes
-			s.logger.Info("PR status changed", "task", taskID, "status", pr.Status)
-			s.publisher.Publish(events.Event{
-				Type:   events.EventTaskUpdated,
-				TaskID: taskID,
-				Data:   map[string]any{"pr": pr},
-			})
-
-			// Auto-trigger finalize when PR is approved (if enabled in config)
-			if pr.Status == orcv1.PRStatus_PR_STATUS_APPROVED {
-				triggered, err := s.TriggerFinalizeOnApproval(taskID, "")
-				if err != nil {
-					s.logger.Error("failed to auto-trigger finalize", "task", taskID, "error", err)
-				} else if triggered {
-					s.logger.Info("finalize auto-triggered on PR approval", "task", taskID)
-				}
-			}
-		},
-	})
-	s.prPoller.Start(s.serverCtx)
+	// Background pollers run on one instance at a time in high-availability
+	// mode. A read-only server changes nothing, so it never runs them.
+	pollers := s.runBackgroundPollers
+	if s.readOnly {
+		pollers = nil
+	}
+	if s.ha != nil {
+		s.ha.start(s.serverCtx, pollers)
+	} else if pollers != nil {
+		s.backgroundWG.Add(1)
+		go func() {
+			defer s.backgroundWG.Done()
+			pollers(s.serverCtx)
+		}()
+	}
 
 	go func() {
 		<-ctx.Done()
@@ -360,11 +405,14 @@ func (s *Server) StartContext(ctx context.Context) error {
 		// Cancel all running finalize operations
 		finTracker.cancelAll()
 
-		// Stop PR poller
-		if s.prPoller != nil {
-			s.prPoller.Stop()
+		// Wait for background pollers and, in HA mode, hand over the leader lease
+		s.backgroundWG.Wait()
+		if s.ha != nil {
+			s.ha.wait()
 		}
 
+		s.removeEditorHandshakeFile()
+
 		// Stop session broadcaster
 		if s.sessionBroadcaster != nil {
 			s.sessionBroadcaster.Stop()
@@ -391,10 +439,76 @@ func (s *Server) StartContext(ctx context.Context) error {
 		}
 	}()
 
+	// Local JSON-RPC socket for terminal editors and IDE plugins
+	s.startRPCSocket(s.serverCtx)
+
+	// Let local editor extensions find this server and shake hands
+	if err := s.writeEditorHandshakeFile(ln.Addr()); err != nil {
+		s.logger.Warn("failed to write editor handshake file", "error", err)
+	}
+
 	s.logger.Info("starting API server", "addr", ln.Addr().String())
 	return server.Serve(ln)
 }
 
+// runBackgroundPollers runs the PR status poller, the CLAUDE.md drift
+// check, database maintenance, the stale task check, opt-in telemetry, and
+// webhook delivery until ctx is cancelled. In high-availability mode only
+// the leader runs them.
+func (s *Server) runBackgroundPollers(ctx context.Context) {
+	prPoller := NewPRPoller(PRPollerConfig{
+		WorkDir:   s.workDir,
+		Interval:  60 * time.Second,
+		Logger:    s.logger,
+		OrcConfig: s.orcConfig,
+		Backend:   s.backend,
+		OnStatusChange: func(taskID string, pr *orcv1.PRInfo) {
+			// Publish task update event when PR status changes
+			s.logger.Info("PR status changed", "task", taskID, "status", pr.Status)
+			s.publisher.Publish(events.Event{
+				Type:   events.EventTaskUpdated,
+				TaskID: taskID,
+				Data:   map[string]any{"pr": pr},
+			})
+
+			// Auto-trigger finalize when PR is approved (if enabled in config)
+			if pr.Status == orcv1.PRStatus_PR_STATUS_APPROVED {
+				triggered, err := s.TriggerFinalizeOnApproval(taskID, "")
+				if err != nil {
+					s.logger.Error("failed to auto-trigger finalize", "task", taskID, "error", err)
+				} else if triggered {
+					s.logger.Info("finalize auto-triggered on PR approval", "task", taskID)
+				}
+			}
+		},
+	})
+	prPoller.Start(ctx)
+
+	// Periodically compare managed CLAUDE.md sections with the code
+	s.startDocDriftCheck(ctx)
+
+	// Periodically vacuum, integrity-check, and prune the databases
+	s.startDBMaintenance(ctx)
+
+	// Periodically flag, notify about, or close stale planned/paused tasks
+	s.startStaleTaskCheck(ctx)
+
+	// Fire cron-based automation triggers
+	if s.automationSvc != nil {
+		go automation.NewScheduler(s.automationSvc).Run(ctx)
+	}
+
+	// Send the weekly usage report when the user opted in to telemetry
+	s.startTelemetry(ctx)
+
+	// POST task lifecycle events to webhooks. Peers relay their events to
+	// the leader, so each event is delivered once.
+	s.startWebhookDispatcher(ctx)
+
+	<-ctx.Done()
+	prPoller.Stop()
+}
+
 // Publish sends an event to the event publisher for WebSocket broadcast.
 // This converts legacy Event types to the events.Event format.
 func (s *Server) Publish(taskID string, event Event) {
@@ -697,6 +811,18 @@ func (s *Server) resumeTask(id string, projectID string) (map[string]any, error)
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
 
+	result := map[string]any{
+		"status":     "resumed",
+		"task_id":    id,
+		"from_phase": resumePhase,
+	}
+	if s.taskExecutor != nil {
+		if err := s.taskExecutor(context.Background(), id, projectID); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
 	// Prepare git ops and claude path (matches CLI behavior)
 	gitOps, claudePath, codexPath, err := s.prepareExecutorDeps(workDir)
 	if err != nil {
@@ -710,12 +836,14 @@ func (s *Server) resumeTask(id string, projectID string) (map[string]any, error)
 	s.runningTasks[id] = cancel
 	s.runningTasksMu.Unlock()
 
-	go func() {
-		defer func() {
-			s.runningTasksMu.Lock()
-			delete(s.runningTasks, id)
-			s.runningTasksMu.Unlock()
-		}()
+	untrack := func() {
+		s.runningTasksMu.Lock()
+		delete(s.runningTasks, id)
+		s.runningTasksMu.Unlock()
+	}
+
+	s.enqueueRun(ctx, backend, id, projectID, func() {
+		defer untrack()
 
 		// Get workflow ID from task - MUST be set
 		workflowID := t.GetWorkflowId()
@@ -759,18 +887,32 @@ func (s *Server) resumeTask(id string, projectID string) (map[string]any, error)
 		if err != nil {
 			s.logger.Error("task resume failed", "task", id, "error", err)
 		}
-	}()
+	}, untrack)
 
-	return map[string]any{
-		"status":     "resumed",
-		"task_id":    id,
-		"from_phase": resumePhase,
-	}, nil
+	return result, nil
+}
+
+// SetTaskExecutor replaces the workflow executor for every task the server
+// runs or resumes; `orc serve --demo` uses it to replay fake executions.
+// Call it before Start.
+func (s *Server) SetTaskExecutor(fn TaskExecutorFunc) {
+	s.taskExecutor = fn
+}
+
+// runTask starts a task with the executor set by SetTaskExecutor, or the
+// workflow executor otherwise.
+func (s *Server) runTask(ctx context.Context, id string, projectID string) error {
+	if s.taskExecutor != nil {
+		return s.taskExecutor(ctx, id, projectID)
+	}
+	return s.startTask(ctx, id, projectID)
 }
 
 // startTask starts a task execution (called by taskServer.RunTask).
-// This spawns a WorkflowExecutor goroutine similar to resumeTask.
-func (s *Server) startTask(id string, projectID string) error {
+// Executor logs and events carry the request ID from reqCtx.
+// This spawns a WorkflowExecutor goroutine similar to resumeTask, once the
+// run queue has a slot for it.
+func (s *Server) startTask(reqCtx context.Context, id string, projectID string) error {
 	backend := s.backend
 	workDir := s.workDir
 	if projectID != "" && s.projectCache != nil {
@@ -796,6 +938,12 @@ func (s *Server) startTask(id string, projectID string) error {
 		return fmt.Errorf("task has no workflow_id set")
 	}
 
+	// Count the task against its tenant's running-task quota
+	tenantID, err := s.reserveTenantSlot(projectID)
+	if err != nil {
+		return err
+	}
+
 	// Create cancellable context
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -810,19 +958,44 @@ func (s *Server) startTask(id string, projectID string) error {
 		s.runningTasksMu.Lock()
 		delete(s.runningTasks, id)
 		s.runningTasksMu.Unlock()
+		s.releaseTenantSlot(tenantID)
 		return fmt.Errorf("prepare executor deps: %w", err)
 	}
 
-	go func() {
-		defer func() {
-			s.runningTasksMu.Lock()
-			delete(s.runningTasks, id)
-			s.runningTasksMu.Unlock()
-		}()
+	logger, publisher := s.logger, withRequestID(reqCtx, s.publisher)
+	if requestID := requestIDFromContext(reqCtx); requestID != "" {
+		logger = logger.With("request_id", requestID)
+	}
+	// Keep a per-task execution log for `orc logs <task-id>`
+	taskLog, err := logs.NewHandler(logger.Handler(), logs.Options{
+		Root:      workDir,
+		TaskID:    id,
+		Component: "executor",
+		Level:     slog.LevelDebug,
+	})
+	if err != nil {
+		s.logger.Warn("failed to open task log", "task", id, "error", err)
+	} else {
+		logger = slog.New(taskLog)
+	}
+
+	release := func() {
+		if taskLog != nil {
+			_ = taskLog.Close()
+		}
+		s.runningTasksMu.Lock()
+		delete(s.runningTasks, id)
+		s.runningTasksMu.Unlock()
+		s.releaseTenantSlot(tenantID)
+	}
+
+	// Wait for a shared run slot (server.scheduler); starts now when one is free
+	s.enqueueRun(ctx, backend, id, projectID, func() {
+		defer release()
 
 		// Seed built-in workflows into project DB (FK constraints require it)
 		if _, err := workflow.SeedBuiltinsToProject(backend.DB()); err != nil {
-			s.logger.Error("failed to seed project workflows", "task", id, "error", err)
+			logger.Error("failed to seed project workflows", "task", id, "error", err)
 		}
 
 		// Create WorkflowExecutor
@@ -832,8 +1005,8 @@ func (s *Server) startTask(id string, projectID string) error {
 			s.globalDB,
 			s.orcConfig,
 			workDir,
-			executor.WithWorkflowPublisher(s.publisher),
-			executor.WithWorkflowLogger(s.logger),
+			executor.WithWorkflowPublisher(publisher),
+			executor.WithWorkflowLogger(logger),
 			executor.WithWorkflowAutomationService(s.automationSvc),
 			executor.WithWorkflowSessionBroadcaster(s.sessionBroadcaster),
 			executor.WithWorkflowGitOps(gitOps),
@@ -853,9 +1026,9 @@ func (s *Server) startTask(id string, projectID string) error {
 		// Run workflow
 		_, err := we.Run(ctx, workflowID, opts)
 		if err != nil {
-			s.logger.Error("task execution failed", "task", id, "error", err)
+			logger.Error("task execution failed", "task", id, "error", err)
 		}
-	}()
+	}, release)
 
 	return nil
 }
@@ -868,10 +1041,14 @@ func (s *Server) prepareExecutorDeps(projectDir string) (*git.Git, string, strin
 		cfg = config.Default()
 	}
 	gitCfg := git.Config{
-		BranchPrefix:   cfg.BranchPrefix,
-		CommitPrefix:   cfg.CommitPrefix,
-		WorktreeDir:    config.ResolveWorktreeDir(cfg.Worktree.Dir, projectDir),
-		ExecutorPrefix: cfg.ExecutorPrefix(),
+		BranchPrefix:      cfg.BranchPrefix,
+		CommitPrefix:      cfg.CommitPrefix,
+		WorktreeDir:       config.ResolveWorktreeDir(cfg.Worktree.Dir, projectDir),
+		ExecutorPrefix:    cfg.ExecutorPrefix(),
+		ProtectedBranches: cfg.ProtectedBranchList(),
+		IdentityName:      cfg.GitIdentity.Name,
+		IdentityEmail:     cfg.GitIdentity.Email,
+		BypassedHooks:     cfg.GitHooks.BypassedHooks(),
 	}
 	gitOps, err := git.New(projectDir, gitCfg)
 	if err != nil {
@@ -1043,10 +1220,14 @@ func (s *Server) GetSessionMetrics(projectID string) SessionMetricsResponse {
 func (s *Server) pruneStaleWorktrees() {
 	// Initialize git operations
 	gitCfg := git.Config{
-		BranchPrefix:   s.orcConfig.BranchPrefix,
-		CommitPrefix:   s.orcConfig.CommitPrefix,
-		WorktreeDir:    config.ResolveWorktreeDir(s.orcConfig.Worktree.Dir, s.workDir),
-		ExecutorPrefix: s.orcConfig.ExecutorPrefix(),
+		BranchPrefix:      s.orcConfig.BranchPrefix,
+		CommitPrefix:      s.orcConfig.CommitPrefix,
+		WorktreeDir:       config.ResolveWorktreeDir(s.orcConfig.Worktree.Dir, s.workDir),
+		ExecutorPrefix:    s.orcConfig.ExecutorPrefix(),
+		ProtectedBranches: s.orcConfig.ProtectedBranchList(),
+		IdentityName:      s.orcConfig.GitIdentity.Name,
+		IdentityEmail:     s.orcConfig.GitIdentity.Email,
+		BypassedHooks:     s.orcConfig.GitHooks.BypassedHooks(),
 	}
 	gitOps, err := git.New(s.workDir, gitCfg)
 	if err != nil {
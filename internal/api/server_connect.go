@@ -19,11 +19,16 @@ func (s *Server) registerConnectHandlers() {
 	interceptors := connect.WithInterceptors(
 		ErrorInterceptor(),
 		LoggingInterceptor(s.logger),
+		&tenantInterceptor{s: s},
+		&authInterceptor{s: s},
+		&readOnlyInterceptor{s: s},
+		&featureInterceptor{s: s},
+		&auditInterceptor{s: s},
 	)
 
 	// Create service implementations
 	// Use NewTaskServerWithExecutor to enable RunTask to spawn actual executor
-	taskSvc := NewTaskServerWithExecutor(s.backend, s.orcConfig, s.logger, s.publisher, s.workDir, s.diffCache, s.projectDB, s.startTask)
+	taskSvc := NewTaskServerWithExecutor(s.backend, s.orcConfig, s.logger, s.publisher, s.workDir, s.diffCache, s.projectDB, s.runTask)
 	taskSvc.SetProjectCache(s.projectCache)
 
 	initiativeSvc := NewInitiativeServerWithCache(s.backend, s.logger, s.publisher, s.projectCache)
@@ -50,7 +55,7 @@ func (s *Server) registerConnectHandlers() {
 		cs.SetProjectCache(s.projectCache)
 		cs.SetGlobalDB(s.globalDB)
 	}
-	hostingSvc := NewHostingServerWithExecutor(s.backend, s.workDir, s.logger, s.publisher, s.orcConfig, s.startTask, nil)
+	hostingSvc := NewHostingServerWithExecutor(s.backend, s.workDir, s.logger, s.publisher, s.orcConfig, s.runTask, nil)
 	if hs, ok := hostingSvc.(*hostingServer); ok {
 		hs.SetProjectCache(s.projectCache)
 	}
@@ -165,8 +170,8 @@ func corsHandler(h http.Handler) http.Handler {
 		// Set CORS headers
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Connect-Protocol-Version, Connect-Timeout-Ms, Grpc-Timeout, X-Grpc-Web, X-User-Agent")
-		w.Header().Set("Access-Control-Expose-Headers", "Grpc-Status, Grpc-Message, Grpc-Status-Details-Bin")
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, Connect-Protocol-Version, Connect-Timeout-Ms, Grpc-Timeout, X-Grpc-Web, X-User-Agent, X-Request-ID, "+auditActorHeader)
+		w.Header().Set("Access-Control-Expose-Headers", "Grpc-Status, Grpc-Message, Grpc-Status-Details-Bin, X-Request-ID")
 
 		// Handle preflight
 		if r.Method == "OPTIONS" {
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/randalmurphal/orc/internal/skills"
+)
+
+// installedSkillsResponse is the JSON shape for GET /api/skills/installed.
+type installedSkillsResponse struct {
+	Project []skills.InstalledSkill `json:"project"`
+	User    []skills.InstalledSkill `json:"user"`
+}
+
+// handleListInstalledSkills lists skills under the project's and the user's
+// .claude/skills directories, including where each was installed from.
+// GET /api/skills/installed[?project_id=...]
+func (s *Server) handleListInstalledSkills(w http.ResponseWriter, r *http.Request) {
+	_, workDir, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := installedSkillsResponse{
+		Project: []skills.InstalledSkill{},
+		User:    []skills.InstalledSkill{},
+	}
+	if workDir != "" {
+		projectSkills, err := skills.NewInstaller(workDir).List()
+		if err != nil {
+			s.jsonError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp.Project = append(resp.Project, projectSkills...)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		userSkills, err := skills.NewInstaller(home).List()
+		if err != nil {
+			s.jsonError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp.User = append(resp.User, userSkills...)
+	}
+
+	s.jsonResponse(w, resp)
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/skills"
+)
+
+func TestListInstalledSkills_ShowsProvenance(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workDir := t.TempDir()
+
+	installed := filepath.Join(workDir, ".claude", "skills", "go-testing")
+	local := filepath.Join(workDir, ".claude", "skills", "handmade")
+	for _, dir := range []string{installed, local} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, skills.SkillFile), []byte("# skill"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	prov := "name: go-testing\nversion: 1.0.0\nindex: https://example.com/index.yaml\npath: skills/go-testing\nsha256: abc\n"
+	if err := os.WriteFile(filepath.Join(installed, skills.ProvenanceFile), []byte(prov), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: workDir}
+	s.registerRESTRoutes()
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/skills/installed", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	var resp installedSkillsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Project) != 2 {
+		t.Fatalf("project skills = %+v, want 2", resp.Project)
+	}
+	if resp.Project[0].Name != "go-testing" || resp.Project[0].Provenance == nil ||
+		resp.Project[0].Provenance.Index != "https://example.com/index.yaml" {
+		t.Errorf("go-testing = %+v, want provenance from index", resp.Project[0])
+	}
+	if resp.Project[1].Provenance != nil {
+		t.Errorf("handmade skill should have no provenance, got %+v", resp.Project[1].Provenance)
+	}
+	if len(resp.User) != 0 {
+		t.Errorf("user skills = %+v, want none", resp.User)
+	}
+}
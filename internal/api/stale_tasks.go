@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/automation"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/i18n"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// staleTasksResponse is returned by GET /api/tasks/stale.
+type staleTasksResponse struct {
+	After  string           `json:"after"`
+	Action string           `json:"action"`
+	Tasks  []task.StaleTask `json:"tasks"`
+}
+
+func (s *Server) staleTasksConfig() config.StaleTasksConfig {
+	if s.orcConfig == nil {
+		return config.Default().Tasks.Stale
+	}
+	return s.orcConfig.Tasks.Stale
+}
+
+// startStaleTaskCheck looks for stale tasks immediately and then on the
+// configured interval until ctx is cancelled.
+func (s *Server) startStaleTaskCheck(ctx context.Context) {
+	cfg := s.staleTasksConfig()
+	if cfg.After <= 0 || s.backend == nil {
+		return
+	}
+	interval := cfg.CheckInterval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	go func() {
+		s.runStaleTaskCheck(ctx)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.runStaleTaskCheck(ctx)
+			}
+		}
+	}()
+}
+
+// runStaleTaskCheck applies tasks.stale.action to every stale task in the
+// server's project.
+func (s *Server) runStaleTaskCheck(ctx context.Context) {
+	cfg := s.staleTasksConfig()
+	stale, err := findStaleTasks(s.backend, cfg.After)
+	if err != nil {
+		s.logger.Warn("stale task check failed", "error", err)
+		return
+	}
+	for _, st := range stale {
+		switch cfg.Action {
+		case config.StaleActionNotify:
+			s.notifyStaleTask(ctx, st, cfg.After)
+		case config.StaleActionClose:
+			s.closeStaleTask(st, cfg.After)
+		default:
+			s.logger.Info("stale task", "task", st.ID, "status", st.Status, "idle_for", st.IdleFor)
+		}
+	}
+}
+
+// findStaleTasks loads the backend's tasks and returns the stale ones with
+// their assignees.
+func findStaleTasks(backend storage.Backend, after time.Duration) ([]task.StaleTask, error) {
+	tasks, err := backend.LoadAllTasks()
+	if err != nil {
+		return nil, fmt.Errorf("load tasks: %w", err)
+	}
+	stale := task.FindStaleTasks(tasks, after, time.Now())
+	if pdb := backend.DB(); pdb != nil {
+		for i := range stale {
+			if t, err := pdb.GetTask(stale[i].ID); err == nil && t != nil {
+				stale[i].AssignedTo = t.AssignedTo
+			}
+		}
+	}
+	return stale, nil
+}
+
+// notifyStaleTask raises one notification per stale episode; a task that is
+// touched and later goes stale again gets a new one.
+func (s *Server) notifyStaleTask(ctx context.Context, st task.StaleTask, after time.Duration) {
+	if s.projectDB == nil {
+		return
+	}
+	adapter := automation.NewProjectDBAdapter(s.projectDB)
+	id := fmt.Sprintf("notif-stale-%s-%d", st.ID, st.LastActivity.Unix())
+	exists, err := adapter.NotificationExists(ctx, id)
+	if err != nil {
+		s.logger.Warn("failed to check stale task notification", "task", st.ID, "error", err)
+		return
+	}
+	if exists {
+		return
+	}
+
+	message := i18n.T("notification.task_stale.message", st.ID, st.Status, st.IdleFor, task.FormatDuration(after))
+	if st.AssignedTo != "" {
+		message = fmt.Sprintf("@%s: %s", st.AssignedTo, message)
+	}
+	notif := &automation.Notification{
+		ID:         id,
+		Type:       automation.NotificationTypeTaskStale,
+		Title:      i18n.T("notification.task_stale.title", st.ID, st.Title),
+		Message:    message,
+		SourceType: automation.NotificationSourceTask,
+		SourceID:   st.ID,
+		CreatedAt:  time.Now(),
+	}
+	if err := adapter.CreateNotification(ctx, notif); err != nil {
+		s.logger.Warn("failed to create stale task notification", "task", st.ID, "error", err)
+		return
+	}
+	s.logger.Info("stale task notification raised", "task", st.ID, "assigned_to", st.AssignedTo)
+}
+
+// closeStaleTask closes a stale task the way orc close does, recording why.
+func (s *Server) closeStaleTask(st task.StaleTask, after time.Duration) {
+	t, err := s.backend.LoadTask(st.ID)
+	if err != nil {
+		s.logger.Warn("failed to load stale task", "task", st.ID, "error", err)
+		return
+	}
+	// Re-check: the task may have been resumed since the scan.
+	if len(task.FindStaleTasks([]*orcv1.Task{t}, after, time.Now())) == 0 {
+		return
+	}
+
+	now := time.Now()
+	t.Status = orcv1.TaskStatus_TASK_STATUS_CLOSED
+	task.SkipRemainingPhasesProto(t.Execution, "task closed as stale")
+	task.SetCurrentPhaseProto(t, "")
+	task.EnsureMetadataProto(t)
+	t.Metadata["closed"] = "true"
+	t.Metadata["closed_at"] = now.Format(time.RFC3339)
+	t.Metadata["close_message"] = fmt.Sprintf("Closed automatically: %s for %s without updates", st.Status, st.IdleFor)
+	t.Metadata["stale_closed"] = "true"
+	if err := s.backend.SaveTask(t); err != nil {
+		s.logger.Warn("failed to close stale task", "task", st.ID, "error", err)
+		return
+	}
+	s.publisher.Publish(events.NewEvent(events.EventTaskUpdated, t.Id, t))
+	s.logger.Info("stale task closed", "task", st.ID, "idle_for", st.IdleFor)
+}
+
+// handleListStaleTasks lists planned and paused tasks untouched for longer
+// than tasks.stale.after, or ?after=<duration> when given.
+// GET /api/tasks/stale
+func (s *Server) handleListStaleTasks(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cfg := s.staleTasksConfig()
+	after := cfg.After
+	if v := r.URL.Query().Get("after"); v != "" {
+		if after, err = time.ParseDuration(v); err != nil || after <= 0 {
+			s.jsonError(w, "invalid after: "+v, http.StatusBadRequest)
+			return
+		}
+	}
+
+	stale, err := findStaleTasks(backend, after)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if stale == nil {
+		stale = []task.StaleTask{}
+	}
+	s.jsonResponse(w, staleTasksResponse{After: after.String(), Action: cfg.Action, Tasks: stale})
+}
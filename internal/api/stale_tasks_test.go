@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/automation"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func newStaleTaskTestServer(t *testing.T, action string) *Server {
+	t.Helper()
+	backend := storage.NewTestBackend(t)
+	cfg := config.Default()
+	cfg.Tasks.Stale.After = 48 * time.Hour
+	cfg.Tasks.Stale.Action = action
+
+	old := time.Now().Add(-5 * 24 * time.Hour)
+	for _, tc := range []struct {
+		id     string
+		status orcv1.TaskStatus
+		at     time.Time
+	}{
+		{"TASK-001", orcv1.TaskStatus_TASK_STATUS_PAUSED, old},
+		{"TASK-002", orcv1.TaskStatus_TASK_STATUS_PLANNED, time.Now()},
+		{"TASK-003", orcv1.TaskStatus_TASK_STATUS_RUNNING, old},
+	} {
+		tk := task.NewProtoTask(tc.id, "Task "+tc.id)
+		tk.Status = tc.status
+		tk.CreatedAt = timestamppb.New(tc.at)
+		tk.UpdatedAt = nil
+		if err := backend.SaveTask(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := backend.DB().Exec(`UPDATE tasks SET assigned_to = 'alice', updated_at = ? WHERE id = 'TASK-001'`,
+		old.UTC().Format(time.RFC3339)); err != nil {
+		t.Fatal(err)
+	}
+
+	pub := events.NewMemoryPublisher()
+	t.Cleanup(pub.Close)
+	s := &Server{
+		mux:       http.NewServeMux(),
+		logger:    slog.Default(),
+		workDir:   t.TempDir(),
+		backend:   backend,
+		projectDB: backend.DB(),
+		orcConfig: cfg,
+		publisher: pub,
+	}
+	s.registerRESTRoutes()
+	return s
+}
+
+func TestListStaleTasks(t *testing.T) {
+	s := newStaleTaskTestServer(t, config.StaleActionFlag)
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/tasks/stale", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	var resp staleTasksResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Tasks) != 1 || resp.Tasks[0].ID != "TASK-001" || resp.Tasks[0].AssignedTo != "alice" {
+		t.Fatalf("tasks = %+v, want only TASK-001 assigned to alice", resp.Tasks)
+	}
+	if resp.After != "48h0m0s" || resp.Action != config.StaleActionFlag {
+		t.Errorf("after/action = %s/%s", resp.After, resp.Action)
+	}
+
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/tasks/stale?after=240h", nil))
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Tasks) != 0 {
+		t.Errorf("tasks with after=240h = %+v, want none", resp.Tasks)
+	}
+
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/tasks/stale?after=soon", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("invalid after status = %d, want 400", w.Code)
+	}
+}
+
+func TestStaleTaskCheck_NotifiesOnce(t *testing.T) {
+	s := newStaleTaskTestServer(t, config.StaleActionNotify)
+	ctx := context.Background()
+
+	s.runStaleTaskCheck(ctx)
+	s.runStaleTaskCheck(ctx)
+
+	notifs, err := automation.NewProjectDBAdapter(s.projectDB).GetActiveNotifications(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notifs) != 1 {
+		t.Fatalf("notifications = %d, want 1", len(notifs))
+	}
+	n := notifs[0]
+	if n.Type != automation.NotificationTypeTaskStale || n.SourceID != "TASK-001" {
+		t.Errorf("notification = %+v", n)
+	}
+	if !strings.HasPrefix(n.Message, "@alice: ") {
+		t.Errorf("message = %q, want it to mention the assignee", n.Message)
+	}
+
+	tk, err := s.backend.LoadTask("TASK-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.Status != orcv1.TaskStatus_TASK_STATUS_PAUSED {
+		t.Errorf("status = %v, notify must not change the task", tk.Status)
+	}
+}
+
+func TestStaleTaskCheck_Closes(t *testing.T) {
+	s := newStaleTaskTestServer(t, config.StaleActionClose)
+
+	s.runStaleTaskCheck(context.Background())
+
+	tk, err := s.backend.LoadTask("TASK-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.Status != orcv1.TaskStatus_TASK_STATUS_CLOSED || tk.Metadata["stale_closed"] != "true" {
+		t.Errorf("TASK-001 status = %v, metadata = %v, want closed as stale", tk.Status, tk.Metadata)
+	}
+	fresh, err := s.backend.LoadTask("TASK-002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fresh.Status != orcv1.TaskStatus_TASK_STATUS_PLANNED {
+		t.Errorf("TASK-002 status = %v, want untouched", fresh.Status)
+	}
+}
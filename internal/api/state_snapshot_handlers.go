@@ -0,0 +1,57 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+// handleListStateSnapshots lists the execution state snapshots of a task,
+// oldest first, without their state.
+// GET /api/tasks/{id}/state-snapshots
+func (s *Server) handleListStateSnapshots(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	snapshots, err := backend.DB().ListStateSnapshots(r.PathValue("id"))
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if snapshots == nil {
+		snapshots = []db.StateSnapshot{}
+	}
+	s.jsonResponse(w, snapshots)
+}
+
+// handleStateDiff shows what changed in a task's state between two
+// snapshots. from and to take a sequence number, first, latest, or an
+// RFC 3339 time, and default to first and latest.
+// GET /api/tasks/{id}/state-diff?from=&to=
+func (s *Server) handleStateDiff(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	from := r.URL.Query().Get("from")
+	if from == "" {
+		from = "first"
+	}
+	diff, err := backend.DB().DiffStateSnapshots(r.PathValue("id"), from, r.URL.Query().Get("to"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, db.ErrStateSnapshotNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, db.ErrInvalidSnapshotSelector):
+			status = http.StatusBadRequest
+		}
+		s.jsonError(w, err.Error(), status)
+		return
+	}
+	s.jsonResponse(w, diff)
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+func TestHandleStateDiff(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	pdb := backend.DB()
+	if err := pdb.SaveTask(&db.Task{ID: "TASK-001", Title: "t", Status: "running", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	for _, state := range []string{`{"current_phase":"spec"}`, `{"current_phase":"implement"}`} {
+		if _, err := pdb.RecordStateSnapshot(&db.StateSnapshot{TaskID: "TASK-001", Label: "save task", State: json.RawMessage(state)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir(), backend: backend}
+	s.registerRESTRoutes()
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/tasks/TASK-001/state-snapshots", nil))
+	var list []db.StateSnapshot
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &list) != nil || len(list) != 2 {
+		t.Fatalf("list: status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/tasks/TASK-001/state-diff?from=1&to=2", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("diff: status = %d, body = %s", w.Code, w.Body.String())
+	}
+	var diff db.StateDiff
+	if err := json.Unmarshal(w.Body.Bytes(), &diff); err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Changes) != 1 || diff.Changes[0].Path != "current_phase" || string(diff.Changes[0].To) != `"implement"` {
+		t.Errorf("changes = %+v", diff.Changes)
+	}
+
+	for query, want := range map[string]int{
+		"?from=7":         http.StatusNotFound,
+		"?from=yesterday": http.StatusBadRequest,
+	} {
+		w = httptest.NewRecorder()
+		s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/tasks/TASK-001/state-diff"+query, nil))
+		if w.Code != want {
+			t.Errorf("%s: status = %d, want %d", query, w.Code, want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+// handleListTasksDelta returns the task list for polling clients. Without
+// ?updated_since= it returns every task; with it, only tasks updated at or
+// after that time plus tombstones for tasks deleted since. Each response
+// carries the checkpoint to pass as updated_since on the next poll.
+// Timestamps have second precision, so a task may be returned twice but a
+// change is never missed.
+// GET /api/tasks?updated_since=<RFC 3339 time>
+func (s *Server) handleListTasksDelta(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var since time.Time
+	if v := r.URL.Query().Get("updated_since"); v != "" {
+		if since, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			s.jsonError(w, "invalid updated_since (want RFC 3339): "+v, http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Taken before loading, so changes made while loading show up next poll
+	checkpoint := time.Now().UTC()
+	all, err := backend.LoadAllTasks()
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if since.IsZero() {
+		if all == nil {
+			all = []*orcv1.Task{}
+		}
+		s.jsonResponse(w, map[string]any{
+			"tasks":      all,
+			"deleted":    []db.TaskTombstone{},
+			"checkpoint": checkpoint.Format(time.RFC3339Nano),
+			"full":       true,
+		})
+		return
+	}
+
+	cutoff := since.Truncate(time.Second)
+	changed := []*orcv1.Task{}
+	exists := make(map[string]bool, len(all))
+	for _, t := range all {
+		exists[t.Id] = true
+		updated := t.GetUpdatedAt()
+		if updated == nil {
+			updated = t.GetCreatedAt()
+		}
+		if updated == nil || !updated.AsTime().Before(cutoff) {
+			changed = append(changed, t)
+		}
+	}
+
+	tombstones, err := backend.DB().ListTaskTombstones(since)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	deleted := []db.TaskTombstone{}
+	for _, ts := range tombstones {
+		// A deleted ID can be reused by a new task
+		if !exists[ts.TaskID] {
+			deleted = append(deleted, ts)
+		}
+	}
+
+	s.jsonResponse(w, map[string]any{
+		"tasks":      changed,
+		"deleted":    deleted,
+		"checkpoint": checkpoint.Format(time.RFC3339Nano),
+		"full":       false,
+	})
+}
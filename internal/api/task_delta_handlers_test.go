@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestHandleListTasksDelta(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	old := time.Now().Add(-2 * time.Hour)
+	for _, id := range []string{"TASK-001", "TASK-002", "TASK-003"} {
+		tk := task.NewProtoTask(id, id)
+		tk.UpdatedAt = timestamppb.New(old)
+		if err := backend.SaveTask(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir(), backend: backend}
+	s.registerRESTRoutes()
+	type deltaResponse struct {
+		Tasks      []struct{ ID string } `json:"tasks"`
+		Deleted    []struct{ ID string } `json:"deleted"`
+		Checkpoint string                `json:"checkpoint"`
+		Full       bool                  `json:"full"`
+	}
+	get := func(path string) (*httptest.ResponseRecorder, deltaResponse) {
+		w := httptest.NewRecorder()
+		s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		var resp deltaResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		return w, resp
+	}
+
+	w, full := get("/api/tasks")
+	if w.Code != http.StatusOK || !full.Full || len(full.Tasks) != 3 || full.Checkpoint == "" {
+		t.Fatalf("full list status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	// Nothing changed since the checkpoint
+	_, delta := get("/api/tasks?updated_since=" + url.QueryEscape(full.Checkpoint))
+	if delta.Full || len(delta.Tasks) != 0 || len(delta.Deleted) != 0 {
+		t.Errorf("delta without changes = %+v", delta)
+	}
+
+	updated, _ := backend.LoadTask("TASK-002")
+	task.UpdateTimestampProto(updated)
+	if err := backend.SaveTask(updated); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.DeleteTask("TASK-003"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, delta = get("/api/tasks?updated_since=" + url.QueryEscape(full.Checkpoint))
+	if len(delta.Tasks) != 1 || delta.Tasks[0].ID != "TASK-002" {
+		t.Errorf("changed tasks = %+v, want TASK-002", delta.Tasks)
+	}
+	if len(delta.Deleted) != 1 || delta.Deleted[0].ID != "TASK-003" {
+		t.Errorf("deleted = %+v, want TASK-003", delta.Deleted)
+	}
+
+	// A deleted ID reused by a new task is returned as a task, not a tombstone
+	if err := backend.SaveTask(task.NewProtoTask("TASK-003", "reused")); err != nil {
+		t.Fatal(err)
+	}
+	_, delta = get("/api/tasks?updated_since=" + url.QueryEscape(full.Checkpoint))
+	if len(delta.Tasks) != 2 || len(delta.Deleted) != 0 {
+		t.Errorf("delta after reuse = %+v", delta)
+	}
+
+	if w, _ := get("/api/tasks?updated_since=yesterday"); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid updated_since status = %d, want 400", w.Code)
+	}
+}
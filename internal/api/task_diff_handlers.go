@@ -0,0 +1,160 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/diff"
+	"github.com/randalmurphal/orc/internal/executor"
+)
+
+// Page size of the task diff preview, in files.
+const (
+	defaultTaskDiffLimit = 50
+	maxTaskDiffLimit     = 500
+)
+
+// taskDiffFile is the file-level summary of one changed file.
+type taskDiffFile struct {
+	Path      string `json:"path"`
+	Status    string `json:"status"`
+	OldPath   string `json:"old_path,omitempty"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Binary    bool   `json:"binary"`
+}
+
+// taskDiffResponse is a page of a task's changes against its target branch.
+// Stats and TotalFiles cover the whole diff; Files and Diff cover the page.
+// Head is empty when the diff includes the worktree's uncommitted changes.
+type taskDiffResponse struct {
+	TaskID     string         `json:"task_id"`
+	Base       string         `json:"base"`
+	Head       string         `json:"head"`
+	Stats      diff.DiffStats `json:"stats"`
+	Files      []taskDiffFile `json:"files"`
+	Diff       string         `json:"diff"`
+	TotalFiles int            `json:"total_files"`
+	Offset     int            `json:"offset"`
+	Limit      int            `json:"limit"`
+	NextOffset int            `json:"next_offset,omitempty"`
+}
+
+// handleTaskDiff returns the unified diff and a per-file summary of a task's
+// changes against its target branch (?base= overrides it), for reviewing a
+// task before approving its merge gate. A task with a worktree is diffed
+// including uncommitted changes; a merged task shows its merge commit.
+// ?file= returns a single file's diff; ?offset= and ?limit= page through
+// the changed files of large diffs.
+// GET /api/tasks/{id}/diff
+func (s *Server) handleTaskDiff(w http.ResponseWriter, r *http.Request) {
+	backend, workDir, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	offset, limit := 0, defaultTaskDiffLimit
+	if v := query.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			s.jsonError(w, "invalid offset: "+v, http.StatusBadRequest)
+			return
+		}
+	}
+	if v := query.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 1 {
+			s.jsonError(w, "invalid limit: "+v, http.StatusBadRequest)
+			return
+		}
+		limit = min(limit, maxTaskDiffLimit)
+	}
+
+	t, err := backend.LoadTask(r.PathValue("id"))
+	if err != nil || t == nil {
+		s.jsonError(w, "task not found", http.StatusNotFound)
+		return
+	}
+	cfg := s.orcConfig
+	if cfg == nil {
+		cfg = config.Default()
+	}
+
+	target := query.Get("base")
+	if target == "" {
+		target = executor.ResolveTargetBranchWithGlobalDB(t, backend, s.globalDB, cfg)
+	}
+
+	ctx := r.Context()
+	var svc *diff.Service
+	var base, head string
+	switch worktree := taskWorktreePath(cfg, workDir, t); {
+	case t.Pr != nil && t.Pr.Merged && t.Pr.GetMergeCommitSha() != "":
+		svc = diff.NewService(workDir, nil)
+		base, head = t.Pr.GetMergeCommitSha()+"^", t.Pr.GetMergeCommitSha()
+	case worktree != "":
+		svc = diff.NewService(worktree, nil)
+		base = svc.MergeBase(ctx, svc.ResolveRef(ctx, target), "HEAD")
+	case t.Branch != "":
+		svc = diff.NewService(workDir, nil)
+		base, head = svc.ResolveRef(ctx, target), svc.ResolveRef(ctx, t.Branch)
+		if !svc.RefExists(ctx, head) {
+			s.jsonError(w, "task branch not found: "+t.Branch, http.StatusNotFound)
+			return
+		}
+	default:
+		s.jsonError(w, "task has no branch to diff", http.StatusNotFound)
+		return
+	}
+
+	files, err := svc.GetFileList(ctx, base, head)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	resp := taskDiffResponse{TaskID: t.Id, Base: base, Head: head, TotalFiles: len(files), Offset: offset, Limit: limit}
+	for _, f := range files {
+		resp.Stats.FilesChanged++
+		resp.Stats.Additions += f.Additions
+		resp.Stats.Deletions += f.Deletions
+	}
+
+	page := files[min(offset, len(files)):min(offset+limit, len(files))]
+	if file := query.Get("file"); file != "" {
+		page = nil
+		for _, f := range files {
+			if f.Path == file {
+				page = []diff.FileDiff{f}
+				break
+			}
+		}
+		if page == nil {
+			s.jsonError(w, "file not changed by task: "+file, http.StatusNotFound)
+			return
+		}
+		resp.Offset, resp.Limit = 0, 1
+	} else if offset+limit < len(files) {
+		resp.NextOffset = offset + limit
+	}
+
+	resp.Files = make([]taskDiffFile, 0, len(page))
+	paths := make([]string, 0, len(page))
+	for _, f := range page {
+		resp.Files = append(resp.Files, taskDiffFile{
+			Path: f.Path, Status: f.Status, OldPath: f.OldPath,
+			Additions: f.Additions, Deletions: f.Deletions, Binary: f.Binary,
+		})
+		// Both sides of a rename are needed for git to pair them up
+		if f.OldPath != "" {
+			paths = append(paths, f.OldPath)
+		}
+		paths = append(paths, f.Path)
+	}
+	if len(paths) > 0 {
+		if resp.Diff, err = svc.GetUnifiedDiff(ctx, base, head, paths...); err != nil {
+			s.jsonError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	s.jsonResponse(w, resp)
+}
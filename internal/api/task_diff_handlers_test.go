@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestHandleTaskDiff(t *testing.T) {
+	workDir := t.TempDir()
+	git := func(dir string, args ...string) {
+		t.Helper()
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git(workDir, "init", "-b", "main")
+	git(workDir, "config", "user.email", "test@test.com")
+	git(workDir, "config", "user.name", "Test")
+	write(workDir, "a.go", "package a\n")
+	git(workDir, "add", ".")
+	git(workDir, "commit", "-m", "initial")
+
+	git(workDir, "checkout", "-b", "orc/TASK-001")
+	write(workDir, "a.go", "package a\n\nfunc A() {}\n")
+	write(workDir, "b.go", "package a\n")
+	write(workDir, "c.go", "package a\n")
+	git(workDir, "add", ".")
+	git(workDir, "commit", "-m", "task changes")
+	git(workDir, "checkout", "main")
+
+	backend := storage.NewTestBackend(t)
+	cfg := config.Default()
+	cfg.Worktree.Dir = t.TempDir()
+	// TASK-003's branch was never created
+	for _, id := range []string{"TASK-001", "TASK-002", "TASK-003"} {
+		if err := backend.SaveTask(task.NewProtoTask(id, "diff")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: workDir, backend: backend, orcConfig: cfg}
+	s.registerRESTRoutes()
+	get := func(path string) (*httptest.ResponseRecorder, taskDiffResponse) {
+		w := httptest.NewRecorder()
+		s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		var resp taskDiffResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		return w, resp
+	}
+
+	w, resp := get("/api/tasks/TASK-001/diff")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if resp.Base != "main" || resp.Head != "orc/TASK-001" || resp.TotalFiles != 3 || len(resp.Files) != 3 || resp.NextOffset != 0 {
+		t.Fatalf("resp = %+v", resp)
+	}
+	if resp.Stats.FilesChanged != 3 || resp.Stats.Additions != 4 {
+		t.Errorf("stats = %+v", resp.Stats)
+	}
+	if resp.Files[0].Path != "a.go" || resp.Files[0].Status != "modified" || !strings.Contains(resp.Diff, "+func A() {}") {
+		t.Errorf("files = %+v, diff = %s", resp.Files, resp.Diff)
+	}
+
+	// Pages cover the files in order; the stats cover the whole diff
+	_, resp = get("/api/tasks/TASK-001/diff?limit=2")
+	if len(resp.Files) != 2 || resp.NextOffset != 2 || strings.Contains(resp.Diff, "c.go") {
+		t.Errorf("first page = %+v", resp)
+	}
+	_, resp = get("/api/tasks/TASK-001/diff?limit=2&offset=2")
+	if len(resp.Files) != 1 || resp.Files[0].Path != "c.go" || resp.NextOffset != 0 || resp.Stats.FilesChanged != 3 {
+		t.Errorf("last page = %+v", resp)
+	}
+
+	_, resp = get("/api/tasks/TASK-001/diff?file=b.go")
+	if len(resp.Files) != 1 || resp.Files[0].Status != "added" || !strings.Contains(resp.Diff, "b/b.go") || strings.Contains(resp.Diff, "a.go") {
+		t.Errorf("single file = %+v", resp)
+	}
+	if w, _ = get("/api/tasks/TASK-001/diff?file=missing.go"); w.Code != http.StatusNotFound {
+		t.Errorf("unchanged file status = %d, want 404", w.Code)
+	}
+
+	// A task with a worktree includes its uncommitted changes
+	worktree := filepath.Join(cfg.Worktree.Dir, "orc-TASK-002")
+	git(workDir, "worktree", "add", "-b", "orc/TASK-002", worktree)
+	write(worktree, "d.go", "package a\n")
+	git(worktree, "add", "d.go")
+	w, resp = get("/api/tasks/TASK-002/diff")
+	if w.Code != http.StatusOK || resp.Head != "" || resp.TotalFiles != 1 || resp.Files[0].Path != "d.go" {
+		t.Errorf("worktree status = %d, resp = %+v", w.Code, resp)
+	}
+
+	for path, want := range map[string]int{
+		"/api/tasks/TASK-003/diff":          http.StatusNotFound,
+		"/api/tasks/TASK-404/diff":          http.StatusNotFound,
+		"/api/tasks/TASK-001/diff?limit=0":  http.StatusBadRequest,
+		"/api/tasks/TASK-001/diff?offset=x": http.StatusBadRequest,
+	} {
+		if w, _ := get(path); w.Code != want {
+			t.Errorf("GET %s status = %d, want %d", path, w.Code, want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+// taskFullFields are the selectable parts of the full task view, in the
+// order they are loaded.
+var taskFullFields = []string{"task", "state", "plan", "transcripts", "review_comments", "attachments"}
+
+// Default and maximum transcript entries in the full task view; the same
+// bounds as transcript pagination.
+const (
+	defaultTaskFullTranscripts = 50
+	maxTaskFullTranscripts     = 200
+)
+
+// handleTaskFull returns the complete view of a task in one request, so the
+// dashboard does not make a round trip per part. ?fields= selects parts
+// (comma-separated, default all of taskFullFields). Transcripts are the
+// newest ?transcript_limit= entries, oldest first, optionally of one ?phase=.
+// GET /api/tasks/{id}/full
+func (s *Server) handleTaskFull(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	fields := taskFullFields
+	if v := query.Get("fields"); v != "" {
+		fields = nil
+		for _, f := range strings.Split(v, ",") {
+			f = strings.TrimSpace(f)
+			if !slices.Contains(taskFullFields, f) {
+				s.jsonError(w, "unknown field: "+f+" (valid: "+strings.Join(taskFullFields, ", ")+")", http.StatusBadRequest)
+				return
+			}
+			fields = append(fields, f)
+		}
+	}
+	transcriptLimit := defaultTaskFullTranscripts
+	if v := query.Get("transcript_limit"); v != "" {
+		if transcriptLimit, err = strconv.Atoi(v); err != nil || transcriptLimit < 1 {
+			s.jsonError(w, "invalid transcript_limit: "+v, http.StatusBadRequest)
+			return
+		}
+		transcriptLimit = min(transcriptLimit, maxTaskFullTranscripts)
+	}
+
+	t, err := backend.LoadTask(r.PathValue("id"))
+	if err != nil || t == nil {
+		s.jsonError(w, "task not found", http.StatusNotFound)
+		return
+	}
+
+	view := map[string]any{"id": t.Id}
+	for _, field := range fields {
+		switch field {
+		case "task":
+			view["task"] = t
+		case "state":
+			if t.Execution != nil {
+				view["state"] = t.Execution
+			}
+		case "plan":
+			view["plan"] = buildTaskPlan(backend, t)
+		case "transcripts":
+			transcripts, page, err := backend.GetTranscriptsPaginated(t.Id, storage.TranscriptPaginationOpts{
+				Phase:     query.Get("phase"),
+				Limit:     transcriptLimit,
+				Direction: "desc",
+			})
+			if err != nil {
+				s.jsonError(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			chunks := make([]any, len(transcripts))
+			for i, tr := range transcripts {
+				// Newest first from the query; the view reads oldest first
+				chunks[len(transcripts)-1-i] = transcriptToChunk(tr)
+			}
+			view["transcripts"] = chunks
+			view["transcripts_total"] = page.TotalCount
+		case "review_comments":
+			comments, err := backend.DB().ListReviewComments(t.Id, "")
+			if err != nil {
+				s.jsonError(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			protos := make([]any, len(comments))
+			for i := range comments {
+				protos[i] = reviewCommentToProto(&comments[i])
+			}
+			view["review_comments"] = protos
+		case "attachments":
+			attachments, err := backend.ListAttachments(t.Id)
+			if err != nil {
+				s.jsonError(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			protos := make([]any, len(attachments))
+			for i, a := range attachments {
+				protos[i] = attachmentToProto(a)
+			}
+			view["attachments"] = protos
+		}
+	}
+	s.jsonResponse(w, view)
+}
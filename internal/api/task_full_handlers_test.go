@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestHandleTaskFull(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	tk := task.NewProtoTask("TASK-001", "Full view")
+	task.EnsureExecutionProto(tk)
+	if err := backend.SaveTask(tk); err != nil {
+		t.Fatal(err)
+	}
+	for i, content := range []string{"first", "second", "third"} {
+		if err := backend.AddTranscript(&storage.Transcript{
+			TaskID: "TASK-001", Phase: "implement", Type: "assistant", Role: "assistant",
+			MessageUUID: "msg-" + content, Content: content, Timestamp: int64(1000 + i),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := backend.DB().CreateReviewComment(&db.ReviewComment{TaskID: "TASK-001", Content: "Handle the error", Severity: db.SeverityIssue, Status: db.CommentStatusOpen}); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir(), backend: backend}
+	s.registerRESTRoutes()
+	get := func(path string) (*httptest.ResponseRecorder, map[string]json.RawMessage) {
+		w := httptest.NewRecorder()
+		s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		var view map[string]json.RawMessage
+		_ = json.Unmarshal(w.Body.Bytes(), &view)
+		return w, view
+	}
+
+	w, view := get("/api/tasks/TASK-001/full")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	for _, field := range append([]string{"id"}, taskFullFields...) {
+		if _, ok := view[field]; !ok {
+			t.Errorf("full view missing %q: %s", field, w.Body.String())
+		}
+	}
+	var got struct {
+		Task        struct{ Title string }     `json:"task"`
+		Transcripts []struct{ Content string } `json:"transcripts"`
+		Comments    []struct{ Content string } `json:"review_comments"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Task.Title != "Full view" || len(got.Comments) != 1 || len(got.Transcripts) != 3 || got.Transcripts[0].Content != "first" {
+		t.Errorf("full view = %+v", got)
+	}
+
+	// Field selection; transcripts are the newest entries, oldest first
+	w, view = get("/api/tasks/TASK-001/full?fields=plan,transcripts&transcript_limit=2")
+	if _, ok := view["task"]; ok || view["plan"] == nil {
+		t.Errorf("selected view = %s", w.Body.String())
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if len(got.Transcripts) != 2 || got.Transcripts[0].Content != "second" || string(view["transcripts_total"]) != "3" {
+		t.Errorf("limited transcripts = %+v, total = %s", got.Transcripts, view["transcripts_total"])
+	}
+
+	for path, want := range map[string]int{
+		"/api/tasks/TASK-404/full":                     http.StatusNotFound,
+		"/api/tasks/TASK-001/full?fields=task,secrets": http.StatusBadRequest,
+		"/api/tasks/TASK-001/full?transcript_limit=0":  http.StatusBadRequest,
+	} {
+		if w, _ := get(path); w.Code != want {
+			t.Errorf("GET %s status = %d, want %d", path, w.Code, want)
+		}
+	}
+}
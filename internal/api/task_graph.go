@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// handleTaskGraph returns the blocked_by graph of the project's tasks with
+// status annotations, layout depth and the critical path. Filters:
+// ?initiative_id=<id> and ?status=<status>[,<status>...]; edges only join
+// tasks that pass the filters.
+// GET /api/tasks/graph
+func (s *Server) handleTaskGraph(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	initiativeID := query.Get("initiative_id")
+	statuses := make(map[orcv1.TaskStatus]bool)
+	for _, v := range strings.Split(query.Get("status"), ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		status, ok := task.ParseStatusProto(v)
+		if !ok {
+			s.jsonError(w, "invalid status: "+v, http.StatusBadRequest)
+			return
+		}
+		statuses[status] = true
+	}
+
+	tasks, err := backend.LoadAllTasks()
+	if err != nil {
+		s.jsonError(w, "load tasks: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	graph := task.BuildDependencyGraph(tasks, func(t *orcv1.Task) bool {
+		if initiativeID != "" && task.GetInitiativeIDProto(t) != initiativeID {
+			return false
+		}
+		return len(statuses) == 0 || statuses[t.Status]
+	})
+	s.jsonResponse(w, graph)
+}
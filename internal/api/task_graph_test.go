@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestHandleTaskGraph(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	for _, spec := range []struct {
+		id, initiative string
+		status         orcv1.TaskStatus
+		blockedBy      []string
+	}{
+		{"TASK-001", "INIT-001", orcv1.TaskStatus_TASK_STATUS_COMPLETED, nil},
+		{"TASK-002", "INIT-001", orcv1.TaskStatus_TASK_STATUS_PLANNED, []string{"TASK-001"}},
+		{"TASK-003", "", orcv1.TaskStatus_TASK_STATUS_PLANNED, []string{"TASK-002"}},
+	} {
+		tk := task.NewProtoTask(spec.id, "Task "+spec.id)
+		tk.Status = spec.status
+		tk.BlockedBy = spec.blockedBy
+		if spec.initiative != "" {
+			task.SetInitiativeProto(tk, spec.initiative)
+		}
+		if err := backend.SaveTask(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir(), backend: backend}
+	s.registerRESTRoutes()
+
+	get := func(query string) (*httptest.ResponseRecorder, task.DependencyGraph) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/tasks/graph"+query, nil))
+		var g task.DependencyGraph
+		if w.Code == http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), &g); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+		}
+		return w, g
+	}
+
+	w, g := get("")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if len(g.Nodes) != 3 || len(g.Edges) != 2 || len(g.CriticalPath) != 2 {
+		t.Errorf("full graph = %+v", g)
+	}
+
+	_, g = get("?initiative_id=INIT-001")
+	if len(g.Nodes) != 2 || len(g.Edges) != 1 {
+		t.Errorf("initiative graph = %+v", g)
+	}
+
+	_, g = get("?status=planned")
+	if len(g.Nodes) != 2 || len(g.Edges) != 1 || g.Edges[0].From != "TASK-002" {
+		t.Errorf("planned graph = %+v", g)
+	}
+
+	if w, _ := get("?status=sleeping"); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid status: code = %d", w.Code)
+	}
+}
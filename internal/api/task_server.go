@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"connectrpc.com/connect"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/randalmurphal/orc/internal/db"
 	"github.com/randalmurphal/orc/internal/diff"
 	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/executor"
 	"github.com/randalmurphal/orc/internal/git"
 	"github.com/randalmurphal/orc/internal/storage"
 	"github.com/randalmurphal/orc/internal/task"
@@ -23,9 +25,11 @@ import (
 )
 
 // TaskExecutorFunc is the callback type for spawning task executors.
-// It takes a task ID and spawns a WorkflowExecutor goroutine.
+// It takes a task ID and spawns a WorkflowExecutor goroutine. ctx is the
+// triggering request's context; it carries values such as the request ID,
+// and the execution outlives it.
 // Returns error if the executor fails to spawn (not execution errors).
-type TaskExecutorFunc func(taskID, projectID string) error
+type TaskExecutorFunc func(ctx context.Context, taskID, projectID string) error
 
 // TaskLifecycleTriggerRunner evaluates lifecycle triggers for task creation events.
 type TaskLifecycleTriggerRunner interface {
@@ -64,6 +68,58 @@ func (s *taskServer) getBackend(projectID string) (storage.Backend, error) {
 	return s.backend, nil
 }
 
+// projectDir returns the directory of the project a request addresses: the
+// registered project's path for a projectID, else the server's project root.
+// Handlers use it instead of the process working directory.
+func (s *taskServer) projectDir(projectID string) (string, error) {
+	if projectID != "" && s.projectCache != nil {
+		return s.projectCache.GetProjectPath(projectID)
+	}
+	if projectID != "" && s.projectCache == nil {
+		return "", fmt.Errorf("project_id specified but no project cache configured")
+	}
+	return s.projectRoot, nil
+}
+
+// validateTargetBranch checks a task's target branch override against the
+// project's branches and protected-branch policy. The existence check is
+// skipped when the project directory is not a git repository.
+func (s *taskServer) validateTargetBranch(projectID, workflowID, branch string) error {
+	root, err := s.projectDir(projectID)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid project: %w", err))
+	}
+	cfg := s.config
+	if cfg == nil {
+		cfg = config.Default()
+	}
+
+	var gitOps *git.Git
+	if root != "" {
+		g, err := git.New(root, git.Config{
+			BranchPrefix:      cfg.BranchPrefix,
+			CommitPrefix:      cfg.CommitPrefix,
+			WorktreeDir:       config.ResolveWorktreeDir(cfg.Worktree.Dir, root),
+			ExecutorPrefix:    cfg.ExecutorPrefix(),
+			ProtectedBranches: cfg.ProtectedBranchList(),
+			IdentityName:      cfg.GitIdentity.Name,
+			IdentityEmail:     cfg.GitIdentity.Email,
+			BypassedHooks:     cfg.GitHooks.BypassedHooks(),
+		})
+		if err == nil {
+			gitOps = g
+		}
+	}
+
+	if err := executor.ValidateTaskTargetBranch(gitOps, cfg, workflowID, branch); err != nil {
+		if errors.Is(err, executor.ErrInvalidTargetBranch) {
+			return connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	return nil
+}
+
 // NewTaskServer creates a new TaskService handler.
 // Note: Without an executor callback, RunTask will only update status (legacy behavior).
 // Use NewTaskServerWithExecutor for full execution support.
@@ -334,6 +390,15 @@ func (s *taskServer) CreateTask(
 		}
 	}
 
+	budget, hasBudget, err := requestBudgetUSD(req.Msg.Metadata)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	phaseModels, hasPhaseModels, err := requestPhaseModels(req.Msg.Metadata)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
 	backend, err := s.getBackend(req.Msg.GetProjectId())
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid project: %w", err))
@@ -396,6 +461,22 @@ func (s *taskServer) CreateTask(
 		t.PrReviewersSet = true
 	}
 
+	if hasBudget {
+		task.SetBudgetUSD(t, budget)
+	}
+	if hasPhaseModels {
+		task.SetPhaseModels(t, phaseModels)
+	}
+	if raw, ok := req.Msg.Metadata[task.RelatedTasksMetadataKey]; ok {
+		task.SetRelatedTasksDisabled(t, strings.EqualFold(raw, "off"))
+	}
+
+	if targetBranch := task.GetTargetBranchProto(t); targetBranch != "" {
+		if err := s.validateTargetBranch(req.Msg.GetProjectId(), task.GetWorkflowIDProto(t), targetBranch); err != nil {
+			return nil, err
+		}
+	}
+
 	// Save the task
 	if err := backend.SaveTask(t); err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("save task: %w", err))
@@ -431,6 +512,35 @@ func (s *taskServer) CreateTask(
 	}), nil
 }
 
+// requestBudgetUSD reads the task budget from create/update request
+// metadata. Besides the budget, only phase_models (see requestPhaseModels)
+// and the related_tasks opt-out are settable through the API.
+func requestBudgetUSD(metadata map[string]string) (float64, bool, error) {
+	raw, ok := metadata[task.BudgetMetadataKey]
+	if !ok {
+		return 0, false, nil
+	}
+	budget, err := task.ParseBudgetUSD(raw)
+	if err != nil {
+		return 0, false, fmt.Errorf("metadata.%s: %w", task.BudgetMetadataKey, err)
+	}
+	return budget, true, nil
+}
+
+// requestPhaseModels reads the per-phase model overrides from create/update
+// request metadata. An empty value clears them.
+func requestPhaseModels(metadata map[string]string) (map[string]string, bool, error) {
+	raw, ok := metadata[task.PhaseModelsMetadataKey]
+	if !ok {
+		return nil, false, nil
+	}
+	models, err := task.ParsePhaseModels(raw)
+	if err != nil {
+		return nil, false, fmt.Errorf("metadata.%s: %w", task.PhaseModelsMetadataKey, err)
+	}
+	return models, true, nil
+}
+
 // loadWorkflowTriggers loads and parses triggers from a workflow ID.
 // Returns nil if the workflow can't be loaded or has no triggers.
 func (s *taskServer) loadWorkflowTriggers(workflowID string) []workflow.WorkflowTrigger {
@@ -486,6 +596,15 @@ func (s *taskServer) UpdateTask(
 		}
 	}
 
+	budget, hasBudget, err := requestBudgetUSD(req.Msg.Metadata)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	phaseModels, hasPhaseModels, err := requestPhaseModels(req.Msg.Metadata)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
 	backend, err := s.getBackend(req.Msg.GetProjectId())
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid project: %w", err))
@@ -574,6 +693,28 @@ func (s *taskServer) UpdateTask(
 		}
 	}
 
+	// Budget (metadata budget_usd; "" or 0 removes it). The executor reads it
+	// after each iteration, so this also applies to a running task.
+	if hasBudget {
+		task.SetBudgetUSD(t, budget)
+	}
+	if hasPhaseModels {
+		task.SetPhaseModels(t, phaseModels)
+	}
+	if raw, ok := req.Msg.Metadata[task.RelatedTasksMetadataKey]; ok {
+		task.SetRelatedTasksDisabled(t, strings.EqualFold(raw, "off"))
+	}
+
+	// Re-check the target branch when it or the workflow (which decides the
+	// completion action) changes.
+	if req.Msg.TargetBranch != nil || req.Msg.WorkflowId != nil {
+		if targetBranch := task.GetTargetBranchProto(t); targetBranch != "" {
+			if err := s.validateTargetBranch(req.Msg.GetProjectId(), task.GetWorkflowIDProto(t), targetBranch); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Status change (TASK-776)
 	// Running task check is handled above; this only applies to non-running tasks
 	if req.Msg.Status != nil {
@@ -688,7 +829,14 @@ func (s *taskServer) GetTaskPlan(
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("task %s not found", req.Msg.TaskId))
 	}
 
-	// Build TaskPlan from execution state
+	return connect.NewResponse(&orcv1.GetTaskPlanResponse{
+		Plan: buildTaskPlan(backend, t),
+	}), nil
+}
+
+// buildTaskPlan builds a task's plan from its workflow phases and
+// execution state.
+func buildTaskPlan(backend storage.Backend, t *orcv1.Task) *orcv1.TaskPlan {
 	plan := &orcv1.TaskPlan{
 		Version: 1,
 	}
@@ -763,9 +911,7 @@ func (s *taskServer) GetTaskPlan(
 		}
 	}
 
-	return connect.NewResponse(&orcv1.GetTaskPlanResponse{
-		Plan: plan,
-	}), nil
+	return plan
 }
 
 // GetDependencies returns dependency information for a task.
@@ -9,6 +9,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
 	"github.com/randalmurphal/orc/internal/task"
 )
 
@@ -82,7 +83,7 @@ func (s *taskServer) RunTask(
 
 	// Spawn executor if callback is set
 	if s.taskExecutor != nil {
-		if err := s.taskExecutor(t.Id, req.Msg.GetProjectId()); err != nil {
+		if err := s.taskExecutor(ctx, t.Id, req.Msg.GetProjectId()); err != nil {
 			// Executor failed to spawn - revert status
 			revertedTask := proto.Clone(originalTask).(*orcv1.Task)
 			revertedTask.Status = originalStatus
@@ -94,7 +95,11 @@ func (s *taskServer) RunTask(
 						"task", t.Id, "error", saveErr)
 				}
 			}
-			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("spawn executor: %w", err))
+			code := connect.CodeInternal
+			if errors.Is(err, db.ErrTenantQuotaExceeded) {
+				code = connect.CodeResourceExhausted
+			}
+			return nil, connect.NewError(code, fmt.Errorf("spawn executor: %w", err))
 		}
 	}
 
@@ -30,7 +30,11 @@ func (s *taskServer) GetDiff(
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("task %s not found", req.Msg.TaskId))
 	}
 
-	diffSvc := diff.NewService(s.projectRoot, s.diffCache)
+	projectDir, err := s.projectDir(req.Msg.GetProjectId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid project: %w", err))
+	}
+	diffSvc := diff.NewService(projectDir, s.diffCache)
 
 	var result *diff.DiffResult
 
@@ -84,7 +88,11 @@ func (s *taskServer) GetDiffStats(
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("task %s not found", req.Msg.TaskId))
 	}
 
-	diffSvc := diff.NewService(s.projectRoot, s.diffCache)
+	projectDir, err := s.projectDir(req.Msg.GetProjectId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid project: %w", err))
+	}
+	diffSvc := diff.NewService(projectDir, s.diffCache)
 
 	var stats *diff.DiffStats
 
@@ -144,7 +152,11 @@ func (s *taskServer) GetFileDiff(
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("task %s not found", req.Msg.TaskId))
 	}
 
-	diffSvc := diff.NewService(s.projectRoot, s.diffCache)
+	projectDir, err := s.projectDir(req.Msg.GetProjectId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid project: %w", err))
+	}
+	diffSvc := diff.NewService(projectDir, s.diffCache)
 
 	var fileDiff *diff.FileDiff
 
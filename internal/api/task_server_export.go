@@ -33,7 +33,11 @@ func (s *taskServer) ExportTask(
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("task not found: %s", taskID))
 	}
 
-	cfg, err := config.Load()
+	projectDir, err := s.projectDir(req.Msg.GetProjectId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid project: %w", err))
+	}
+	cfg, err := config.LoadFrom(projectDir)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to load config: %w", err))
 	}
@@ -59,13 +63,13 @@ func (s *taskServer) ExportTask(
 		opts.ContextSummary = *req.Msg.ContextSummary
 	}
 
-	exportBackend, err := storage.NewBackend(s.projectRoot, &cfg.Storage)
+	exportBackend, err := storage.NewBackend(projectDir, &cfg.Storage)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create storage backend: %w", err))
 	}
 	defer func() { _ = exportBackend.Close() }()
 
-	exportSvc := storage.NewExportService(exportBackend, &cfg.Storage)
+	exportSvc := storage.NewExportService(exportBackend, projectDir, &cfg.Storage)
 
 	if req.Msg.ToBranch {
 		t, err := backend.LoadTask(taskID)
@@ -91,6 +95,6 @@ func (s *taskServer) ExportTask(
 	return connect.NewResponse(&orcv1.ExportTaskResponse{
 		Success:    true,
 		TaskId:     taskID,
-		ExportedTo: filepath.Join(task.ExportPath(s.projectRoot), taskID),
+		ExportedTo: filepath.Join(projectDir, task.OrcDir, task.ExportsDir, taskID),
 	}), nil
 }
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// The export lands in the server's project even when the process runs
+// from somewhere else, e.g. `orc serve` started by a service manager.
+func TestExportTask_UsesProjectRootNotWorkingDirectory(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	projectDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(projectDir, ".orc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, ".orc", "config.yaml"), []byte("version: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	backend, err := storage.NewDatabaseBackend(projectDir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = backend.Close() })
+	if err := backend.SaveTask(task.NewProtoTask("TASK-001", "Export me")); err != nil {
+		t.Fatal(err)
+	}
+
+	cwd := t.TempDir()
+	t.Chdir(cwd)
+
+	server := NewTaskServer(backend, nil, nil, nil, projectDir, nil, nil)
+	resp, err := server.ExportTask(context.Background(), connect.NewRequest(&orcv1.ExportTaskRequest{TaskId: "TASK-001"}))
+	if err != nil {
+		t.Fatalf("ExportTask: %v", err)
+	}
+
+	want := filepath.Join(projectDir, ".orc", "exports", "TASK-001")
+	if resp.Msg.ExportedTo != want {
+		t.Errorf("ExportedTo = %q, want %q", resp.Msg.ExportedTo, want)
+	}
+	if _, err := os.Stat(filepath.Join(want, "task.yaml")); err != nil {
+		t.Errorf("task.yaml not exported to the project: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cwd, ".orc")); !os.IsNotExist(err) {
+		t.Errorf("export wrote to the working directory (stat err = %v)", err)
+	}
+}
+
+func TestTaskServerProjectDir(t *testing.T) {
+	t.Parallel()
+	server := NewTaskServer(storage.NewTestBackend(t), nil, nil, nil, "/srv/project", nil, nil).(*taskServer)
+
+	dir, err := server.projectDir("")
+	if err != nil || dir != "/srv/project" {
+		t.Errorf("projectDir(\"\") = %q, %v; want the project root", dir, err)
+	}
+	if _, err := server.projectDir("proj-123"); err == nil {
+		t.Error("projectDir with a project ID but no project cache should fail")
+	}
+}
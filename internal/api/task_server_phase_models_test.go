@@ -0,0 +1,52 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestCreateTask_PhaseModels(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewTestBackend(t)
+	server := NewTaskServer(backend, nil, nil, nil, "", nil, nil)
+
+	resp, err := server.CreateTask(context.Background(), connect.NewRequest(&orcv1.CreateTaskRequest{
+		Title:    "Models per phase",
+		Metadata: map[string]string{task.PhaseModelsMetadataKey: `{"spec":"opus","implement":"sonnet","docs":"haiku"}`},
+	}))
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	loaded, err := backend.LoadTask(resp.Msg.Task.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if models := task.GetPhaseModels(loaded); models["spec"] != "opus" || models["implement"] != "sonnet" || models["docs"] != "haiku" {
+		t.Errorf("phase models = %v", models)
+	}
+
+	// An empty value clears the overrides
+	if _, err := server.UpdateTask(context.Background(), connect.NewRequest(&orcv1.UpdateTaskRequest{
+		TaskId:   loaded.Id,
+		Metadata: map[string]string{task.PhaseModelsMetadataKey: ""},
+	})); err != nil {
+		t.Fatalf("UpdateTask: %v", err)
+	}
+	if loaded, _ = backend.LoadTask(loaded.Id); task.GetPhaseModels(loaded) != nil {
+		t.Errorf("phase models not cleared: %v", loaded.Metadata)
+	}
+
+	_, err = server.CreateTask(context.Background(), connect.NewRequest(&orcv1.CreateTaskRequest{
+		Title:    "Bad models",
+		Metadata: map[string]string{task.PhaseModelsMetadataKey: "spec=opus"},
+	}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("invalid phase models: err = %v, want invalid argument", err)
+	}
+}
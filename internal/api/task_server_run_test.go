@@ -163,7 +163,7 @@ func TestRunTask_SpawnsExecutor(t *testing.T) {
 	var mu sync.Mutex
 
 	// Create mock executor callback
-	mockExecutor := func(taskID, projectID string) error {
+	mockExecutor := func(_ context.Context, taskID, projectID string) error {
 		mu.Lock()
 		calledTaskID = taskID
 		mu.Unlock()
@@ -224,7 +224,7 @@ func TestRunTask_ExecutorFailure_RevertsStatus(t *testing.T) {
 	}
 
 	// Mock executor that always fails
-	mockExecutor := func(taskID, projectID string) error {
+	mockExecutor := func(_ context.Context, taskID, projectID string) error {
 		return errors.New("executor spawn failed: no Claude process available")
 	}
 
@@ -271,7 +271,7 @@ func TestRunTask_FailedTaskResolvesFailedAttentionSignal(t *testing.T) {
 		Summary:       "Previous run failed.",
 	}))
 
-	server := NewTaskServerWithExecutor(backend, nil, nil, nil, "", nil, nil, func(taskID, projectID string) error {
+	server := NewTaskServerWithExecutor(backend, nil, nil, nil, "", nil, nil, func(_ context.Context, taskID, projectID string) error {
 		return nil
 	})
 
@@ -452,7 +452,7 @@ func TestRunTask_FailedTask_AllowsRetry(t *testing.T) {
 
 	// Track executor invocation
 	var executorCalled atomic.Bool
-	mockExecutor := func(taskID, projectID string) error {
+	mockExecutor := func(_ context.Context, taskID, projectID string) error {
 		executorCalled.Store(true)
 		return nil
 	}
@@ -655,7 +655,7 @@ func TestRunTask_DependencyCompleted_AllowsRun(t *testing.T) {
 	}
 
 	var executorCalled atomic.Bool
-	mockExecutor := func(taskID, projectID string) error {
+	mockExecutor := func(_ context.Context, taskID, projectID string) error {
 		executorCalled.Store(true)
 		return nil
 	}
@@ -701,7 +701,7 @@ func TestRunTask_ConcurrentCalls_SecondFails(t *testing.T) {
 	executorStarted := make(chan struct{})
 	executorDone := make(chan struct{})
 
-	mockExecutor := func(taskID, projectID string) error {
+	mockExecutor := func(_ context.Context, taskID, projectID string) error {
 		close(executorStarted) // Signal we started
 		<-executorDone         // Wait until test says to finish
 		return nil
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+// newTargetBranchRepo creates a git repo (no origin) with main and release/1.2.
+func newTargetBranchRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--initial-branch=main"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+		{"commit", "--allow-empty", "-m", "init"},
+		{"branch", "release/1.2"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	return dir
+}
+
+func TestCreateTask_TargetBranchValidation(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewTestBackend(t)
+	cfg := config.Default()
+	cfg.Completion.Action = "pr"
+	cfg.Completion.WeightActions = map[string]string{"implement-trivial": "merge"}
+	server := NewTaskServer(backend, cfg, nil, nil, newTargetBranchRepo(t), nil, nil)
+
+	workflowID := "implement-medium"
+	create := func(branch, workflowID string) (*orcv1.Task, error) {
+		resp, err := server.CreateTask(context.Background(), connect.NewRequest(&orcv1.CreateTaskRequest{
+			Title:        "Hotfix",
+			WorkflowId:   &workflowID,
+			TargetBranch: &branch,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg.Task, nil
+	}
+
+	created, err := create("release/1.2", workflowID)
+	if err != nil {
+		t.Fatalf("create with existing branch: %v", err)
+	}
+	if created.GetTargetBranch() != "release/1.2" {
+		t.Errorf("target branch = %q, want release/1.2", created.GetTargetBranch())
+	}
+
+	for name, tc := range map[string]struct{ branch, workflow string }{
+		"missing branch":                {"release/9.9", workflowID},
+		"protected branch direct merge": {"main", "implement-trivial"},
+	} {
+		if _, err := create(tc.branch, tc.workflow); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("%s: err = %v, want InvalidArgument", name, err)
+		}
+	}
+
+	missing := "release/9.9"
+	_, err = server.UpdateTask(context.Background(), connect.NewRequest(&orcv1.UpdateTaskRequest{
+		TaskId:       created.Id,
+		TargetBranch: &missing,
+	}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("update to missing branch: err = %v, want InvalidArgument", err)
+	}
+	reloaded, err := backend.LoadTask(created.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.GetTargetBranch() != "release/1.2" {
+		t.Errorf("rejected update was saved: target branch = %q", reloaded.GetTargetBranch())
+	}
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// maxStreamTextContent bounds transcript and tool text on one stream line.
+const maxStreamTextContent = 200
+
+// handleTaskStreamText streams a task's events as plain text, one line per
+// event, for screen readers and terminals (curl | tee). It reads the same
+// event bus as the SSE and Connect streams but skips streaming chunks,
+// execution state snapshots and heartbeats. The response ends when the task
+// completes or the client disconnects.
+// GET /api/tasks/{id}/stream.txt
+func (s *Server) handleTaskStreamText(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	taskID := r.PathValue("id")
+	t, err := backend.LoadTask(taskID)
+	if err != nil || t == nil {
+		http.Error(w, "task not found", http.StatusNotFound)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	eventChan := s.publisher.Subscribe(taskID)
+	defer s.publisher.Unsubscribe(taskID, eventChan)
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = fmt.Fprintf(w, "Streaming events for %s: %s (status %s)\n",
+		t.Id, singleLine(t.Title, maxStreamTextContent), task.StatusFromProto(t.Status))
+	flusher.Flush()
+
+	projectID := r.URL.Query().Get("project_id")
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-eventChan:
+			if !ok {
+				return
+			}
+			if projectID != "" && filterEventByProjectIDs(event, []string{projectID}) {
+				continue
+			}
+			line := formatEventText(event)
+			if line == "" {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "%s %s\n", event.Time.Format("15:04:05"), line); err != nil {
+				return
+			}
+			flusher.Flush()
+			if event.Type == events.EventComplete {
+				return
+			}
+		}
+	}
+}
+
+// formatEventText renders an event as one human-readable line, or "" for
+// events that are not worth a line of their own.
+func formatEventText(event events.Event) string {
+	switch data := event.Data.(type) {
+	case events.PhaseUpdate:
+		return formatPhaseText(data)
+	case events.TranscriptLine:
+		switch data.Type {
+		case "prompt":
+			return fmt.Sprintf("Prompt sent for phase %s, iteration %d", data.Phase, data.Iteration)
+		case "response":
+			return fmt.Sprintf("Response in phase %s: %s", data.Phase, singleLine(data.Content, maxStreamTextContent))
+		case "tool":
+			return fmt.Sprintf("Tool call in phase %s: %s", data.Phase, singleLine(data.Content, maxStreamTextContent))
+		case "error":
+			return fmt.Sprintf("Error in phase %s: %s", data.Phase, singleLine(data.Content, maxStreamTextContent))
+		}
+		return ""
+	case events.TokenUpdate:
+		return fmt.Sprintf("Tokens for phase %s: %d input, %d output", data.Phase, data.InputTokens, data.OutputTokens)
+	case events.ErrorData:
+		severity := "Error"
+		if data.Fatal {
+			severity = "Fatal error"
+		}
+		if data.Phase != "" {
+			return fmt.Sprintf("%s in phase %s: %s", severity, data.Phase, singleLine(data.Message, 0))
+		}
+		return fmt.Sprintf("%s: %s", severity, singleLine(data.Message, 0))
+	case events.CompleteData:
+		line := "Task " + data.Status
+		if data.Duration != "" {
+			line += " after " + data.Duration
+		}
+		if data.CommitSHA != "" {
+			line += ", commit " + shortSHA(data.CommitSHA)
+		}
+		return line
+	case events.ActivityUpdate:
+		if data.Activity == "" || data.Activity == "idle" || data.Activity == "streaming" {
+			return ""
+		}
+		return fmt.Sprintf("Activity in phase %s: %s", data.Phase, strings.ReplaceAll(data.Activity, "_", " "))
+	case events.WarningData:
+		if data.Phase != "" {
+			return fmt.Sprintf("Warning in phase %s: %s", data.Phase, singleLine(data.Message, 0))
+		}
+		return "Warning: " + singleLine(data.Message, 0)
+	case events.BudgetExceededData:
+		return fmt.Sprintf("Budget exceeded: spent $%.2f of the $%.2f %s limit, task paused", data.SpentUSD, data.LimitUSD, data.Scope)
+	case events.AccountRotatedData:
+		line := fmt.Sprintf("Switched account from %s to %s", data.FromAccount, data.ToAccount)
+		if data.Phase != "" {
+			line += " in phase " + data.Phase
+		}
+		return line
+	case events.FilesChangedUpdate:
+		return fmt.Sprintf("Files changed: %d files, %d additions, %d deletions", len(data.Files), data.TotalAdditions, data.TotalDeletions)
+	case events.DecisionRequiredData:
+		return fmt.Sprintf("Decision required at the %s gate: %s", data.Phase, singleLine(data.Question, maxStreamTextContent))
+	case events.DecisionResolvedData:
+		verdict := "rejected"
+		if data.Approved {
+			verdict = "approved"
+		}
+		line := fmt.Sprintf("Gate %s %s by %s", data.Phase, verdict, data.ResolvedBy)
+		if data.Reason != "" {
+			line += ": " + singleLine(data.Reason, maxStreamTextContent)
+		}
+		return line
+	case *orcv1.Task:
+		switch event.Type {
+		case events.EventTaskCreated:
+			return fmt.Sprintf("Task created: %s", singleLine(data.Title, maxStreamTextContent))
+		case events.EventTaskUpdated:
+			return fmt.Sprintf("Task updated, status %s", task.StatusFromProto(data.Status))
+		}
+		return ""
+	}
+	if event.Type == events.EventTaskDeleted {
+		return "Task deleted"
+	}
+	return ""
+}
+
+func formatPhaseText(data events.PhaseUpdate) string {
+	switch data.Status {
+	case "running":
+		return fmt.Sprintf("Phase %s started", data.Phase)
+	case "completed":
+		if data.CommitSHA != "" {
+			return fmt.Sprintf("Phase %s completed, commit %s", data.Phase, shortSHA(data.CommitSHA))
+		}
+		return fmt.Sprintf("Phase %s completed", data.Phase)
+	case "failed":
+		if data.Error != "" {
+			return fmt.Sprintf("Phase %s failed: %s", data.Phase, singleLine(data.Error, 0))
+		}
+		return fmt.Sprintf("Phase %s failed", data.Phase)
+	case "looping":
+		return fmt.Sprintf("Phase %s looping back to %s, loop %d", data.Phase, data.LoopTo, data.LoopCount)
+	}
+	return fmt.Sprintf("Phase %s %s", data.Phase, data.Status)
+}
+
+// singleLine collapses whitespace so text fits on one line, truncating it
+// to limit runes when limit is positive.
+func singleLine(s string, limit int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if limit > 0 {
+		if runes := []rune(s); len(runes) > limit {
+			return string(runes[:limit]) + "..."
+		}
+	}
+	return s
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestHandleTaskStreamText(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	if err := backend.SaveTask(task.NewProtoTask("TASK-001", "Stream me")); err != nil {
+		t.Fatal(err)
+	}
+	pub := events.NewMemoryPublisher()
+	defer pub.Close()
+
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir(), backend: backend, publisher: pub}
+	s.registerRESTRoutes()
+
+	w := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/tasks/TASK-001/stream.txt", nil))
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for pub.SubscriberCount("TASK-001") == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("handler did not subscribe")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	pub.Publish(events.NewEvent(events.EventPhase, "TASK-001", events.PhaseUpdate{Phase: "implement", Status: "running"}))
+	pub.Publish(events.NewEvent(events.EventTranscript, "TASK-001", events.TranscriptLine{Phase: "implement", Type: "chunk", Content: "partial"}))
+	pub.Publish(events.NewEvent(events.EventPhase, "TASK-002", events.PhaseUpdate{Phase: "spec", Status: "running"}))
+	pub.Publish(events.NewEvent(events.EventComplete, "TASK-001", events.CompleteData{Status: "completed", Duration: "5m0s"}))
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream did not end after the complete event")
+	}
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/plain; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3:\n%s", len(lines), w.Body.String())
+	}
+	if lines[0] != "Streaming events for TASK-001: Stream me (status created)" {
+		t.Errorf("header line = %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], " Phase implement started") {
+		t.Errorf("phase line = %q", lines[1])
+	}
+	if !strings.HasSuffix(lines[2], " Task completed after 5m0s") {
+		t.Errorf("complete line = %q", lines[2])
+	}
+}
+
+func TestHandleTaskStreamText_NotFound(t *testing.T) {
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir(), backend: storage.NewTestBackend(t), publisher: events.NewNopPublisher()}
+	s.registerRESTRoutes()
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/tasks/TASK-404/stream.txt", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", w.Code)
+	}
+}
+
+func TestFormatEventText(t *testing.T) {
+	tests := []struct {
+		name  string
+		event events.Event
+		want  string
+	}{
+		{
+			name:  "phase completed with commit",
+			event: events.NewEvent(events.EventPhase, "T", events.PhaseUpdate{Phase: "implement", Status: "completed", CommitSHA: "abcdef1234567"}),
+			want:  "Phase implement completed, commit abcdef1",
+		},
+		{
+			name:  "phase failed",
+			event: events.NewEvent(events.EventPhase, "T", events.PhaseUpdate{Phase: "review", Status: "failed", Error: "tests\nfailed"}),
+			want:  "Phase review failed: tests failed",
+		},
+		{
+			name:  "phase looping",
+			event: events.NewEvent(events.EventPhase, "T", events.PhaseUpdate{Phase: "review", Status: "looping", LoopTo: "implement", LoopCount: 2}),
+			want:  "Phase review looping back to implement, loop 2",
+		},
+		{
+			name:  "multi-line response collapsed",
+			event: events.NewEvent(events.EventTranscript, "T", events.TranscriptLine{Phase: "spec", Type: "response", Content: "Line one\n\nLine   two"}),
+			want:  "Response in phase spec: Line one Line two",
+		},
+		{
+			name:  "long tool call truncated",
+			event: events.NewEvent(events.EventTranscript, "T", events.TranscriptLine{Phase: "spec", Type: "tool", Content: strings.Repeat("x", 250)}),
+			want:  "Tool call in phase spec: " + strings.Repeat("x", maxStreamTextContent) + "...",
+		},
+		{
+			name:  "streaming chunk skipped",
+			event: events.NewEvent(events.EventTranscript, "T", events.TranscriptLine{Phase: "spec", Type: "chunk", Content: "par"}),
+			want:  "",
+		},
+		{
+			name:  "fatal error",
+			event: events.NewEvent(events.EventError, "T", events.ErrorData{Phase: "implement", Message: "boom", Fatal: true}),
+			want:  "Fatal error in phase implement: boom",
+		},
+		{
+			name:  "activity",
+			event: events.NewEvent(events.EventActivity, "T", events.ActivityUpdate{Phase: "implement", Activity: "waiting_api"}),
+			want:  "Activity in phase implement: waiting api",
+		},
+		{
+			name:  "idle activity skipped",
+			event: events.NewEvent(events.EventActivity, "T", events.ActivityUpdate{Phase: "implement", Activity: "idle"}),
+			want:  "",
+		},
+		{
+			name:  "heartbeat skipped",
+			event: events.NewEvent(events.EventHeartbeat, "T", events.HeartbeatData{Phase: "implement"}),
+			want:  "",
+		},
+		{
+			name:  "gate rejected",
+			event: events.NewEvent(events.EventDecisionResolved, "T", events.DecisionResolvedData{Phase: "review", ResolvedBy: "api", Reason: "needs tests"}),
+			want:  "Gate review rejected by api: needs tests",
+		},
+		{
+			name:  "task updated",
+			event: events.NewEvent(events.EventTaskUpdated, "T", &orcv1.Task{Id: "T", Status: orcv1.TaskStatus_TASK_STATUS_BLOCKED}),
+			want:  "Task updated, status blocked",
+		},
+		{
+			name:  "account rotated",
+			event: events.NewEvent(events.EventAccountRotated, "T", events.AccountRotatedData{Phase: "implement", FromAccount: "work", ToAccount: "personal", Reason: "rate limited"}),
+			want:  "Switched account from work to personal in phase implement",
+		},
+		{
+			name:  "budget exceeded",
+			event: events.NewEvent(events.EventBudgetExceeded, "T", events.BudgetExceededData{Scope: "task", SpentUSD: 5.5, LimitUSD: 5}),
+			want:  "Budget exceeded: spent $5.50 of the $5.00 task limit, task paused",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatEventText(tt.event); got != tt.want {
+				t.Errorf("formatEventText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/project"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/telemetry"
+)
+
+// telemetryCheckInterval is how often the server checks whether a usage
+// report is due. Reports themselves go out every telemetry.ReportInterval.
+const telemetryCheckInterval = time.Hour
+
+// startTelemetry sends the opt-in usage report whenever one is due. Nothing
+// is collected or sent unless the user ran `orc telemetry enable`.
+func (s *Server) startTelemetry(ctx context.Context) {
+	path, err := telemetry.Path()
+	if err != nil {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(telemetryCheckInterval)
+		defer ticker.Stop()
+		for {
+			s.sendTelemetryIfDue(ctx, path)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *Server) sendTelemetryIfDue(ctx context.Context, path string) {
+	state, err := telemetry.Load(path)
+	if err != nil || !state.Due(time.Now()) {
+		return
+	}
+	report, err := state.Build(s.version, s.telemetryBackends(), time.Now())
+	if err != nil {
+		s.logger.Debug("telemetry report skipped", "error", err)
+		return
+	}
+	if err := telemetry.Send(ctx, telemetry.Endpoint(), report); err != nil {
+		s.logger.Debug("telemetry report not sent", "error", err)
+		return
+	}
+	state.MarkSent(report)
+	if err := state.Save(path); err != nil {
+		s.logger.Warn("failed to save telemetry state", "error", err)
+	}
+}
+
+// telemetryBackends returns the backends of every registered project, or
+// just the server's own project when the registry is unavailable.
+func (s *Server) telemetryBackends() []storage.Backend {
+	reg, err := project.LoadRegistry()
+	if err != nil || s.projectCache == nil {
+		return []storage.Backend{s.backend}
+	}
+	var backends []storage.Backend
+	for _, p := range reg.ValidProjects() {
+		if b, err := s.projectCache.GetBackend(p.ID); err == nil {
+			backends = append(backends, b)
+		}
+	}
+	if len(backends) == 0 {
+		backends = append(backends, s.backend)
+	}
+	return backends
+}
@@ -0,0 +1,307 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/project"
+)
+
+// tenantScope is the authenticated tenant of a request in multi-tenant mode.
+type tenantScope struct {
+	Tenant   *db.Tenant
+	Projects map[string]bool
+}
+
+// Owns reports whether the tenant owns projectID.
+func (t *tenantScope) Owns(projectID string) bool {
+	return t.Projects[projectID]
+}
+
+type tenantContextKey struct{}
+
+// tenantFromContext returns the request's tenant scope, or nil outside
+// multi-tenant mode.
+func tenantFromContext(ctx context.Context) *tenantScope {
+	scope, _ := ctx.Value(tenantContextKey{}).(*tenantScope)
+	return scope
+}
+
+// filterProjectsForTenant drops projects the request's tenant does not own.
+// Outside multi-tenant mode the list is returned unchanged.
+func filterProjectsForTenant(ctx context.Context, projects []project.Project) []project.Project {
+	scope := tenantFromContext(ctx)
+	if scope == nil {
+		return projects
+	}
+	owned := make([]project.Project, 0, len(projects))
+	for _, p := range projects {
+		if scope.Owns(p.ID) {
+			owned = append(owned, p)
+		}
+	}
+	return owned
+}
+
+func (s *Server) tenancyEnabled() bool {
+	return s.orcConfig != nil && s.orcConfig.FeatureEnabled("multi_tenant")
+}
+
+// Handler returns the server's HTTP handler with server-wide middleware
+// applied.
+func (s *Server) Handler() http.Handler {
+	var h http.Handler = s.auditMiddleware(s.mux)
+	if s.readOnly {
+		h = s.readOnlyMiddleware(h)
+	}
+	if s.tokenAuthEnabled() {
+		h = s.tokenAuthMiddleware(h)
+	}
+	if s.tenancyEnabled() {
+		h = s.tenantMiddleware(h)
+	}
+	return s.requestIDMiddleware(h)
+}
+
+// defaultProjectID is the registry ID of the server's own project, used by
+// requests that do not name a project.
+func (s *Server) defaultProjectID() string {
+	id, err := project.ResolveProjectID(s.workDir)
+	if err != nil {
+		return ""
+	}
+	return id
+}
+
+// isConnectPath reports whether the request targets a Connect RPC service.
+// Connect requests carry project IDs in the message body, which
+// tenantInterceptor checks.
+func isConnectPath(path string) bool {
+	return strings.HasPrefix(path, "/orc.v1.")
+}
+
+// tenantMiddleware authenticates tenant API tokens and rejects requests for
+// projects outside the tenant. The static UI and health probes stay public;
+// the UI needs a token to load any data.
+func (s *Server) tenantMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions || r.URL.Path == "/api/health" || strings.HasPrefix(r.URL.Path, "/api/health/") || !(strings.HasPrefix(r.URL.Path, "/api/") ||
+			strings.HasPrefix(r.URL.Path, "/files/") || isConnectPath(r.URL.Path)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if s.globalDB == nil {
+			s.jsonError(w, "multi-tenant mode requires the global database", http.StatusServiceUnavailable)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			s.jsonError(w, "tenant API token required", http.StatusUnauthorized)
+			return
+		}
+		tenant, err := s.globalDB.ResolveTenantToken(token)
+		if err != nil {
+			s.jsonError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if tenant == nil {
+			s.jsonError(w, "invalid tenant API token", http.StatusUnauthorized)
+			return
+		}
+		ids, err := s.globalDB.ListTenantProjectIDs(tenant.ID)
+		if err != nil {
+			s.jsonError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		scope := &tenantScope{Tenant: tenant, Projects: make(map[string]bool, len(ids))}
+		for _, id := range ids {
+			scope.Projects[id] = true
+		}
+
+		projectID := r.URL.Query().Get("project_id")
+		if projectID == "" && !isConnectPath(r.URL.Path) {
+			projectID = s.defaultProjectID()
+		}
+		if projectID != "" && !scope.Owns(projectID) {
+			s.jsonError(w, "project not found", http.StatusNotFound)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tenantContextKey{}, scope)))
+	})
+}
+
+// tenantInterceptor enforces project ownership on Connect RPCs. The project
+// is read from the message's project_id field (or id for ProjectService RPCs
+// that take one); an empty value targets the server's own project. Messages
+// without such a field are not project-scoped.
+type tenantInterceptor struct {
+	s *Server
+}
+
+var _ connect.Interceptor = (*tenantInterceptor)(nil)
+
+func (i *tenantInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if !i.s.tenancyEnabled() {
+			return next(ctx, req)
+		}
+		scope := tenantFromContext(ctx)
+		if scope == nil {
+			return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("tenant API token required"))
+		}
+
+		if strings.HasSuffix(req.Spec().Procedure, "/AddProject") {
+			return i.addProject(ctx, scope, req, next)
+		}
+		if err := i.checkMessage(scope, req.Spec().Procedure, req.Any()); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *tenantInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *tenantInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if !i.s.tenancyEnabled() {
+			return next(ctx, conn)
+		}
+		scope := tenantFromContext(ctx)
+		if scope == nil {
+			return connect.NewError(connect.CodeUnauthenticated, errors.New("tenant API token required"))
+		}
+		return next(ctx, &tenantStreamConn{StreamingHandlerConn: conn, interceptor: i, scope: scope})
+	}
+}
+
+// tenantStreamConn checks every received stream message.
+type tenantStreamConn struct {
+	connect.StreamingHandlerConn
+	interceptor *tenantInterceptor
+	scope       *tenantScope
+}
+
+func (c *tenantStreamConn) Receive(msg any) error {
+	if err := c.StreamingHandlerConn.Receive(msg); err != nil {
+		return err
+	}
+	return c.interceptor.checkMessage(c.scope, c.Spec().Procedure, msg)
+}
+
+func (i *tenantInterceptor) checkMessage(scope *tenantScope, procedure string, msg any) error {
+	projectID, scoped := messageProjectID(procedure, msg)
+	if !scoped {
+		return nil
+	}
+	if projectID == "" {
+		projectID = i.s.defaultProjectID()
+	}
+	if projectID == "" || !scope.Owns(projectID) {
+		return connect.NewError(connect.CodeNotFound, fmt.Errorf("project not found"))
+	}
+	return nil
+}
+
+// addProject registers a project for the tenant, enforcing max_projects
+// before the project is created.
+func (i *tenantInterceptor) addProject(ctx context.Context, scope *tenantScope, req connect.AnyRequest, next connect.UnaryFunc) (connect.AnyResponse, error) {
+	if limit := scope.Tenant.MaxProjects; limit > 0 && len(scope.Projects) >= limit {
+		return nil, connect.NewError(connect.CodeResourceExhausted,
+			fmt.Errorf("%w: tenant %s owns %d of %d projects", db.ErrTenantQuotaExceeded, scope.Tenant.Name, len(scope.Projects), limit))
+	}
+	resp, err := next(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	if added, ok := resp.Any().(*orcv1.AddProjectResponse); ok && added.GetProject() != nil {
+		projectID := added.GetProject().GetId()
+		// Adding an already registered path returns the existing project,
+		// which must not move between tenants.
+		owner, err := i.s.globalDB.GetProjectTenantID(projectID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		if owner != "" && owner != scope.Tenant.ID {
+			return nil, connect.NewError(connect.CodePermissionDenied, errors.New("project belongs to another tenant"))
+		}
+		if err := i.s.globalDB.AssignProjectToTenant(scope.Tenant.ID, projectID); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("assign project to tenant: %w", err))
+		}
+	}
+	return resp, nil
+}
+
+// messageProjectID returns the project a request message targets and
+// whether the message is project-scoped at all.
+func messageProjectID(procedure string, msg any) (string, bool) {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return "", false
+	}
+	fields := m.ProtoReflect().Descriptor().Fields()
+	fd := fields.ByName("project_id")
+	if fd == nil && strings.Contains(procedure, ".ProjectService/") {
+		fd = fields.ByName("id")
+	}
+	if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+		return "", false
+	}
+	return m.ProtoReflect().Get(fd).String(), true
+}
+
+// reserveTenantSlot counts a starting task against its tenant's
+// max_running_tasks quota. It returns the tenant ID to pass to
+// releaseTenantSlot, or "" when no quota applies.
+func (s *Server) reserveTenantSlot(projectID string) (string, error) {
+	if !s.tenancyEnabled() || s.globalDB == nil {
+		return "", nil
+	}
+	if projectID == "" {
+		projectID = s.defaultProjectID()
+	}
+	tenantID, err := s.globalDB.GetProjectTenantID(projectID)
+	if err != nil || tenantID == "" {
+		return "", err
+	}
+	tenant, err := s.globalDB.GetTenant(tenantID)
+	if err != nil || tenant == nil {
+		return "", err
+	}
+
+	s.runningTasksMu.Lock()
+	defer s.runningTasksMu.Unlock()
+	if s.tenantRunning == nil {
+		s.tenantRunning = make(map[string]int)
+	}
+	if tenant.MaxRunningTasks > 0 && s.tenantRunning[tenantID] >= tenant.MaxRunningTasks {
+		return "", fmt.Errorf("%w: tenant %s is running %d of %d tasks",
+			db.ErrTenantQuotaExceeded, tenant.Name, s.tenantRunning[tenantID], tenant.MaxRunningTasks)
+	}
+	s.tenantRunning[tenantID]++
+	return tenantID, nil
+}
+
+func (s *Server) releaseTenantSlot(tenantID string) {
+	if tenantID == "" {
+		return
+	}
+	s.runningTasksMu.Lock()
+	defer s.runningTasksMu.Unlock()
+	if s.tenantRunning[tenantID] > 0 {
+		s.tenantRunning[tenantID]--
+	}
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/project"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+// newTenancyTestServer returns a multi-tenant server whose own project is
+// owned by tenant "a", plus a token for each of tenants "a" and "b".
+func newTenancyTestServer(t *testing.T) (s *Server, tokenA, tokenB string) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	workDir := t.TempDir()
+	proj, err := project.RegisterProject(workDir)
+	if err != nil {
+		t.Fatalf("register project: %v", err)
+	}
+
+	globalDB, err := db.OpenGlobalAt(filepath.Join(t.TempDir(), "orc.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = globalDB.Close() })
+
+	a := &db.Tenant{Name: "a", MaxRunningTasks: 1}
+	b := &db.Tenant{Name: "b"}
+	for _, tn := range []*db.Tenant{a, b} {
+		if err := globalDB.CreateTenant(tn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := globalDB.AssignProjectToTenant(a.ID, proj.ID); err != nil {
+		t.Fatal(err)
+	}
+	if tokenA, err = globalDB.CreateTenantToken(a.ID, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if tokenB, err = globalDB.CreateTenantToken(b.ID, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default()
+	cfg.Server.Tenancy.Enabled = true
+	cfg.Documentation.DriftCheck.CreateTask = false
+	pub := events.NewMemoryPublisher()
+	t.Cleanup(pub.Close)
+	s = &Server{
+		mux:          http.NewServeMux(),
+		logger:       slog.Default(),
+		workDir:      workDir,
+		orcConfig:    cfg,
+		backend:      storage.NewTestBackend(t),
+		globalDB:     globalDB,
+		publisher:    pub,
+		runningTasks: make(map[string]context.CancelFunc),
+	}
+	s.registerRESTRoutes()
+	return s, tokenA, tokenB
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	s, tokenA, tokenB := newTenancyTestServer(t)
+	handler := s.Handler()
+
+	tests := []struct {
+		name     string
+		token    string
+		query    string
+		wantCode int
+	}{
+		{"missing token", "", "", http.StatusUnauthorized},
+		{"unknown token", "orct_nope", "", http.StatusUnauthorized},
+		{"owner of default project", tokenA, "", http.StatusOK},
+		{"other tenant default project", tokenB, "", http.StatusNotFound},
+		{"other tenant explicit project", tokenB, "?project_id=" + s.defaultProjectID(), http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/dashboard/docs-drift"+tt.query, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.wantCode, w.Body.String())
+			}
+		})
+	}
+
+	// Static UI assets are served without a token.
+	s.mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {})
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/index.html", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("static asset status = %d, want 200", w.Code)
+	}
+}
+
+func TestTenantInterceptor_CheckMessage(t *testing.T) {
+	s, _, _ := newTenancyTestServer(t)
+	i := &tenantInterceptor{s: s}
+	owner := &tenantScope{Tenant: &db.Tenant{Name: "a"}, Projects: map[string]bool{s.defaultProjectID(): true}}
+	other := &tenantScope{Tenant: &db.Tenant{Name: "b"}, Projects: map[string]bool{}}
+
+	tests := []struct {
+		name      string
+		scope     *tenantScope
+		procedure string
+		msg       any
+		wantErr   bool
+	}{
+		{"owned project", owner, "/orc.v1.TaskService/ListTasks", &orcv1.ListTasksRequest{ProjectId: s.defaultProjectID()}, false},
+		{"empty project_id uses default", other, "/orc.v1.TaskService/ListTasks", &orcv1.ListTasksRequest{}, true},
+		{"foreign project", owner, "/orc.v1.TaskService/ListTasks", &orcv1.ListTasksRequest{ProjectId: "other"}, true},
+		{"project service id field", other, "/orc.v1.ProjectService/GetProject", &orcv1.GetProjectRequest{Id: s.defaultProjectID()}, true},
+		{"not project scoped", other, "/orc.v1.ProjectService/ListProjects", &orcv1.ListProjectsRequest{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := i.checkMessage(tt.scope, tt.procedure, tt.msg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && connect.CodeOf(err) != connect.CodeNotFound {
+				t.Errorf("code = %v, want NotFound", connect.CodeOf(err))
+			}
+		})
+	}
+}
+
+func TestFilterProjectsForTenant(t *testing.T) {
+	projects := []project.Project{{ID: "p1"}, {ID: "p2"}}
+	if got := filterProjectsForTenant(context.Background(), projects); len(got) != 2 {
+		t.Errorf("without tenant got %d projects, want 2", len(got))
+	}
+	ctx := context.WithValue(context.Background(), tenantContextKey{}, &tenantScope{Projects: map[string]bool{"p2": true}})
+	if got := filterProjectsForTenant(ctx, projects); len(got) != 1 || got[0].ID != "p2" {
+		t.Errorf("with tenant got %v, want [p2]", got)
+	}
+}
+
+func TestReserveTenantSlot_MaxRunningTasks(t *testing.T) {
+	s, _, _ := newTenancyTestServer(t)
+
+	tenantID, err := s.reserveTenantSlot("")
+	if err != nil || tenantID == "" {
+		t.Fatalf("first reservation = %q, %v", tenantID, err)
+	}
+	if _, err := s.reserveTenantSlot(""); !errors.Is(err, db.ErrTenantQuotaExceeded) {
+		t.Errorf("second reservation err = %v, want ErrTenantQuotaExceeded", err)
+	}
+	s.releaseTenantSlot(tenantID)
+	if _, err := s.reserveTenantSlot(""); err != nil {
+		t.Errorf("reservation after release: %v", err)
+	}
+}
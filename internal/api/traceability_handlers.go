@@ -0,0 +1,45 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// handleGetTraceability returns a task's latest traceability report: each
+// spec success criterion with the code and tests that satisfy it.
+// GET /api/tasks/{id}/traceability
+func (s *Server) handleGetTraceability(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	taskID := r.PathValue("id")
+	report, err := backend.DB().GetLatestTraceabilityReport(taskID)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if report == nil {
+		s.jsonError(w, fmt.Sprintf("no traceability report for task %s", taskID), http.StatusNotFound)
+		return
+	}
+	s.jsonResponse(w, report)
+}
+
+// handleListTraceabilityReports returns every traceability report of a
+// task, newest first, to follow coverage across phases.
+// GET /api/tasks/{id}/traceability/reports
+func (s *Server) handleListTraceabilityReports(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	reports, err := backend.DB().ListTraceabilityReports(r.PathValue("id"))
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.jsonResponse(w, reports)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+func TestTraceabilityHandlers(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir(), backend: backend}
+	s.registerRESTRoutes()
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	if w := get("/api/tasks/TASK-001/traceability"); w.Code != http.StatusNotFound {
+		t.Errorf("GET without report status = %d, want 404", w.Code)
+	}
+
+	pdb := backend.DB()
+	if err := pdb.SaveTask(&db.Task{ID: "TASK-001", Title: "Rate limiting", Status: "running"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, phase := range []string{"implement", "review"} {
+		if err := pdb.SaveTraceabilityReport(&db.TraceabilityReport{
+			TaskID: "TASK-001", PhaseID: phase,
+			Entries: []db.TraceabilityEntry{{CriterionID: "SC-1", Criterion: "Returns 429", Status: db.TraceabilitySatisfied}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := get("/api/tasks/TASK-001/traceability")
+	var report db.TraceabilityReport
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &report) != nil {
+		t.Fatalf("GET status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if report.PhaseID != "review" || report.Satisfied != 1 || report.Total != 1 || report.Entries[0].CriterionID != "SC-1" {
+		t.Errorf("latest report = %+v", report)
+	}
+
+	w = get("/api/tasks/TASK-001/traceability/reports")
+	var reports []db.TraceabilityReport
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &reports) != nil || len(reports) != 2 {
+		t.Errorf("GET reports status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if w = get("/api/tasks/TASK-404/traceability/reports"); w.Body.String() == "null\n" {
+		t.Errorf("reports of unknown task = null, want []")
+	}
+}
@@ -84,7 +84,7 @@ func (s *transcriptServer) getProjectDB(projectID string) (*db.ProjectDB, error)
 	if err != nil {
 		return nil, err
 	}
-	if dbBackend, ok := backend.(*storage.DatabaseBackend); ok {
+	if dbBackend, ok := storage.DatabaseOf(backend); ok {
 		return dbBackend.DB(), nil
 	}
 	return nil, fmt.Errorf("backend is not a DatabaseBackend")
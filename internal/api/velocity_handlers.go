@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+// velocityResponse is the body of GET /api/analytics/velocity.
+type velocityResponse struct {
+	Estimates []db.DurationEstimate `json:"estimates"`
+	Weekly    []db.VelocityPoint    `json:"weekly"`
+}
+
+// handleVelocity returns active-time estimates per workflow (labelled with
+// the weight mapped to it) and weekly throughput.
+// GET /api/analytics/velocity?weeks=N
+func (s *Server) handleVelocity(w http.ResponseWriter, r *http.Request) {
+	backend, _, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	weeks := 8
+	if v := r.URL.Query().Get("weeks"); v != "" {
+		if weeks, err = strconv.Atoi(v); err != nil || weeks < 1 || weeks > 104 {
+			s.jsonError(w, "invalid weeks: "+v, http.StatusBadRequest)
+			return
+		}
+	}
+
+	now := time.Now()
+	estimateSince := now.Add(-db.EstimateWindow)
+	since := estimateSince
+	if trendStart := now.AddDate(0, 0, -7*weeks); trendStart.Before(since) {
+		since = trendStart
+	}
+	samples, err := backend.DB().GetCompletedTaskTimes(since)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var recent []db.TaskTimeSample
+	for _, sample := range samples {
+		if !sample.CompletedAt.Before(estimateSince) {
+			recent = append(recent, sample)
+		}
+	}
+	estimates := db.EstimateDurations(recent)
+	if s.orcConfig != nil {
+		for i := range estimates {
+			estimates[i].Weight = s.orcConfig.Weights.WeightForWorkflow(estimates[i].WorkflowID)
+		}
+	}
+	s.jsonResponse(w, velocityResponse{
+		Estimates: estimates,
+		Weekly:    db.WeeklyVelocity(samples, weeks, now),
+	})
+}
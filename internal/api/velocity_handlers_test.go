@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+func TestHandleVelocity(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	pdb := backend.DB()
+	cfg := config.Default()
+	cfg.Weights.Medium = "implement-medium"
+
+	now := time.Now().UTC()
+	for i := range 3 {
+		id := fmt.Sprintf("TASK-%03d", i+1)
+		completed := now.Add(-time.Duration(i) * time.Hour)
+		if err := pdb.SaveTask(&db.Task{ID: id, Title: id, Status: "completed", WorkflowID: "implement-medium",
+			CreatedAt: now.Add(-24 * time.Hour), CompletedAt: &completed, TotalCostUSD: 2}); err != nil {
+			t.Fatal(err)
+		}
+		if err := pdb.RecordPhaseTiming(&db.PhaseTiming{TaskID: id, PhaseID: "implement", Status: "completed", ActiveMs: int64(i+1) * 600000}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: t.TempDir(), backend: backend, orcConfig: cfg}
+	s.registerRESTRoutes()
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/analytics/velocity?weeks=4", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	var resp velocityResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Estimates) != 1 || resp.Estimates[0].Weight != "medium" || resp.Estimates[0].MedianMs != 1200000 {
+		t.Errorf("estimates = %+v, want one medium estimate with 20m median", resp.Estimates)
+	}
+	if len(resp.Weekly) != 4 {
+		t.Fatalf("weekly = %d points, want 4", len(resp.Weekly))
+	}
+	total := 0
+	for _, p := range resp.Weekly {
+		total += p.Completed
+	}
+	if total != 3 {
+		t.Errorf("weekly completions = %d, want 3", total)
+	}
+
+	w = httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/analytics/velocity?weeks=0", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("weeks=0 status = %d, want 400", w.Code)
+	}
+}
@@ -0,0 +1,258 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/project"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/webhooks"
+)
+
+// webhookResponse is a hook as returned by /api/webhooks. The secret itself
+// is never returned.
+type webhookResponse struct {
+	webhooks.Hook
+	HasSecret bool `json:"has_secret"`
+}
+
+// webhookRequest is the body of POST /api/webhooks and PUT /api/webhooks/{id}.
+type webhookRequest struct {
+	Name   string   `json:"name"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	// Secret is the HMAC signing key. On update, omitting it keeps the
+	// current secret and "" removes it.
+	Secret *string `json:"secret"`
+}
+
+func newWebhookResponse(h webhooks.Hook) webhookResponse {
+	if h.Events == nil {
+		h.Events = []string{}
+	}
+	return webhookResponse{Hook: h, HasSecret: h.Secret != ""}
+}
+
+// startWebhookDispatcher delivers task lifecycle events to the configured
+// and registered webhooks until ctx is cancelled.
+func (s *Server) startWebhookDispatcher(ctx context.Context) {
+	var timeout time.Duration
+	if s.orcConfig != nil {
+		timeout = s.orcConfig.Webhooks.Timeout
+	}
+	d := webhooks.NewDispatcher(s.allWebhooks, s.orcConfig.RetryPolicy(), timeout, s.logger)
+	s.seedWebhookDispatcher(d)
+	go d.Run(ctx, s.publisher)
+}
+
+// seedWebhookDispatcher records the tasks of every project that are already
+// failed or merged, so a restart does not deliver those events again.
+func (s *Server) seedWebhookDispatcher(d *webhooks.Dispatcher) {
+	seed := func(projectID string, backend storage.Backend) {
+		tasks, err := backend.LoadAllTasks()
+		if err != nil {
+			s.logger.Warn("load tasks for webhooks", "project", projectID, "error", err)
+			return
+		}
+		d.Seed(projectID, tasks)
+	}
+
+	defaultID := s.defaultProjectID()
+	d.SetDefaultProject(defaultID)
+	if s.backend != nil {
+		seed(defaultID, s.backend)
+	}
+	reg, err := project.LoadRegistry()
+	if err != nil || s.projectCache == nil {
+		return
+	}
+	for _, p := range reg.ValidProjects() {
+		if p.ID == defaultID {
+			continue
+		}
+		if b, err := s.projectCache.GetBackend(p.ID); err == nil {
+			seed(p.ID, b)
+		}
+	}
+}
+
+// allWebhooks returns the hooks from config followed by the registered ones.
+func (s *Server) allWebhooks() ([]webhooks.Hook, error) {
+	hooks := webhooks.FromConfig(s.orcConfig)
+	if s.webhookStore == nil {
+		return hooks, nil
+	}
+	registered, err := s.webhookStore.List()
+	if err != nil {
+		return hooks, err
+	}
+	return append(hooks, registered...), nil
+}
+
+// webhookAccess rejects tenant requests: hooks receive events from every
+// project on the server, so only the server's own users may manage them.
+func (s *Server) webhookAccess(w http.ResponseWriter, r *http.Request) bool {
+	if tenantFromContext(r.Context()) != nil {
+		s.jsonError(w, "webhooks are managed by the server administrator", http.StatusForbidden)
+		return false
+	}
+	if s.webhookStore == nil {
+		s.jsonError(w, "webhook store is not available", http.StatusServiceUnavailable)
+		return false
+	}
+	return true
+}
+
+// findWebhook returns the hook with id from config or the store, or nil.
+func (s *Server) findWebhook(id string) (*webhooks.Hook, error) {
+	hooks, err := s.allWebhooks()
+	if err != nil {
+		return nil, err
+	}
+	for i := range hooks {
+		if hooks[i].ID == id {
+			return &hooks[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// handleListWebhooks lists configured and registered webhooks.
+// GET /api/webhooks
+func (s *Server) handleListWebhooks(w http.ResponseWriter, r *http.Request) {
+	if !s.webhookAccess(w, r) {
+		return
+	}
+	hooks, err := s.allWebhooks()
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	resp := make([]webhookResponse, 0, len(hooks))
+	for _, h := range hooks {
+		resp = append(resp, newWebhookResponse(h))
+	}
+	s.jsonResponse(w, map[string]any{"webhooks": resp})
+}
+
+// handleCreateWebhook registers a webhook.
+// POST /api/webhooks
+func (s *Server) handleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	if !s.webhookAccess(w, r) {
+		return
+	}
+	var req webhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	h := webhooks.Hook{Name: req.Name, URL: req.URL, Events: req.Events}
+	if req.Secret != nil {
+		h.Secret = *req.Secret
+	}
+	if err := h.Validate(); err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	created, err := s.webhookStore.Create(h)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(newWebhookResponse(*created))
+}
+
+// handleGetWebhook returns one webhook.
+// GET /api/webhooks/{id}
+func (s *Server) handleGetWebhook(w http.ResponseWriter, r *http.Request) {
+	if !s.webhookAccess(w, r) {
+		return
+	}
+	h, err := s.findWebhook(r.PathValue("id"))
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if h == nil {
+		s.jsonError(w, "webhook not found", http.StatusNotFound)
+		return
+	}
+	s.jsonResponse(w, newWebhookResponse(*h))
+}
+
+// handleUpdateWebhook replaces a registered webhook's settings. Hooks from
+// config are read-only.
+// PUT /api/webhooks/{id}
+func (s *Server) handleUpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	if !s.webhookAccess(w, r) {
+		return
+	}
+	current, ok := s.registeredWebhook(w, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	var req webhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	h := webhooks.Hook{ID: current.ID, Name: req.Name, URL: req.URL, Events: req.Events, Secret: current.Secret}
+	if req.Secret != nil {
+		h.Secret = *req.Secret
+	}
+	if err := h.Validate(); err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	updated, err := s.webhookStore.Update(h)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if updated == nil {
+		s.jsonError(w, "webhook not found", http.StatusNotFound)
+		return
+	}
+	s.jsonResponse(w, newWebhookResponse(*updated))
+}
+
+// handleDeleteWebhook removes a registered webhook. Hooks from config are
+// read-only.
+// DELETE /api/webhooks/{id}
+func (s *Server) handleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if !s.webhookAccess(w, r) {
+		return
+	}
+	current, ok := s.registeredWebhook(w, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	if _, err := s.webhookStore.Delete(current.ID); err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// registeredWebhook looks up a hook that can be changed through the API,
+// writing the error response when there is none.
+func (s *Server) registeredWebhook(w http.ResponseWriter, id string) (*webhooks.Hook, bool) {
+	h, err := s.findWebhook(id)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+	if h == nil {
+		s.jsonError(w, "webhook not found", http.StatusNotFound)
+		return nil, false
+	}
+	if h.Source == webhooks.SourceConfig {
+		s.jsonError(w, "webhook is defined in config (webhooks.endpoints); edit the config file instead", http.StatusConflict)
+		return nil, false
+	}
+	return h, true
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/webhooks"
+)
+
+func newWebhookTestServer(t *testing.T) *Server {
+	t.Helper()
+	cfg := config.Default()
+	cfg.Webhooks.Endpoints = []config.WebhookEndpoint{{Name: "ci", URL: "https://ci.example.com/orc"}}
+	s := &Server{
+		mux:          http.NewServeMux(),
+		logger:       slog.Default(),
+		workDir:      t.TempDir(),
+		orcConfig:    cfg,
+		webhookStore: webhooks.NewStore(filepath.Join(t.TempDir(), webhooks.StoreFile)),
+	}
+	s.registerRESTRoutes()
+	return s
+}
+
+func serveWebhookRequest(s *Server, method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return w
+}
+
+func TestWebhookHandlers_CRUD(t *testing.T) {
+	s := newWebhookTestServer(t)
+
+	w := serveWebhookRequest(s, http.MethodPost, "/api/webhooks",
+		`{"name":"slack","url":"https://hooks.example.com/x","secret":"s3cret","events":["failed","merged"]}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "s3cret") {
+		t.Error("create response leaked the secret")
+	}
+	var created webhookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.ID == "" || !created.HasSecret || created.Source != webhooks.SourceAPI {
+		t.Errorf("created = %+v", created)
+	}
+
+	w = serveWebhookRequest(s, http.MethodGet, "/api/webhooks", "")
+	var list struct {
+		Webhooks []webhookResponse `json:"webhooks"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Webhooks) != 2 || list.Webhooks[0].ID != "config-1" || list.Webhooks[1].ID != created.ID {
+		t.Errorf("list = %+v, want config hook then registered hook", list.Webhooks)
+	}
+
+	// Omitting the secret keeps it
+	w = serveWebhookRequest(s, http.MethodPut, "/api/webhooks/"+created.ID,
+		`{"name":"slack","url":"https://hooks.example.com/y","events":["created"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("update status = %d, body = %s", w.Code, w.Body.String())
+	}
+	var updated webhookResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &updated)
+	if updated.URL != "https://hooks.example.com/y" || !updated.HasSecret || len(updated.Events) != 1 {
+		t.Errorf("updated = %+v", updated)
+	}
+
+	w = serveWebhookRequest(s, http.MethodGet, "/api/webhooks/"+created.ID, "")
+	if w.Code != http.StatusOK {
+		t.Errorf("get status = %d", w.Code)
+	}
+
+	w = serveWebhookRequest(s, http.MethodDelete, "/api/webhooks/"+created.ID, "")
+	if w.Code != http.StatusNoContent {
+		t.Errorf("delete status = %d, body = %s", w.Code, w.Body.String())
+	}
+	w = serveWebhookRequest(s, http.MethodGet, "/api/webhooks/"+created.ID, "")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("get after delete status = %d, want 404", w.Code)
+	}
+}
+
+func TestWebhookHandlers_Errors(t *testing.T) {
+	s := newWebhookTestServer(t)
+
+	tests := []struct {
+		name, method, path, body string
+		want                     int
+	}{
+		{"invalid url", http.MethodPost, "/api/webhooks", `{"url":"ftp://example.com"}`, http.StatusBadRequest},
+		{"unknown event", http.MethodPost, "/api/webhooks", `{"url":"https://example.com","events":["done"]}`, http.StatusBadRequest},
+		{"bad json", http.MethodPost, "/api/webhooks", `{`, http.StatusBadRequest},
+		{"update config hook", http.MethodPut, "/api/webhooks/config-1", `{"url":"https://example.com"}`, http.StatusConflict},
+		{"delete config hook", http.MethodDelete, "/api/webhooks/config-1", "", http.StatusConflict},
+		{"update missing", http.MethodPut, "/api/webhooks/wh-missing", `{"url":"https://example.com"}`, http.StatusNotFound},
+		{"delete missing", http.MethodDelete, "/api/webhooks/wh-missing", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveWebhookRequest(s, tt.method, tt.path, tt.body)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestWebhookHandlers_TenantForbidden(t *testing.T) {
+	s := newWebhookTestServer(t)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/webhooks", nil)
+	r = r.WithContext(context.WithValue(r.Context(), tenantContextKey{}, &tenantScope{}))
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, r)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want 403", w.Code)
+	}
+}
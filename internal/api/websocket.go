@@ -31,6 +31,9 @@ type WSMessage struct {
 	TaskID string          `json:"task_id,omitempty"`
 	Action string          `json:"action,omitempty"` // pause, resume, cancel
 	Data   json.RawMessage `json:"data,omitempty"`
+	// TranscriptAfter is the UUID of the last transcript message the client
+	// received. Subscribing with it replays the messages stored since.
+	TranscriptAfter string `json:"transcript_after,omitempty"`
 }
 
 // WSHandler manages WebSocket connections.
@@ -176,7 +179,7 @@ func (h *WSHandler) handleMessage(c *wsConnection, data []byte) {
 
 	switch msg.Type {
 	case "subscribe":
-		h.handleSubscribe(c, msg.TaskID)
+		h.handleSubscribe(c, msg.TaskID, msg.TranscriptAfter)
 	case "unsubscribe":
 		h.handleUnsubscribe(c)
 	case "command":
@@ -191,7 +194,9 @@ func (h *WSHandler) handleMessage(c *wsConnection, data []byte) {
 
 // handleSubscribe subscribes the connection to a task's events.
 // Use taskID "*" to subscribe to all task events (global subscription).
-func (h *WSHandler) handleSubscribe(c *wsConnection, taskID string) {
+// With a transcriptAfter cursor, the transcript messages stored after it are
+// replayed before live events.
+func (h *WSHandler) handleSubscribe(c *wsConnection, taskID, transcriptAfter string) {
 	if taskID == "" {
 		h.sendError(c, "task_id required for subscribe (use \"*\" for all tasks)")
 		return
@@ -207,15 +212,21 @@ func (h *WSHandler) handleSubscribe(c *wsConnection, taskID string) {
 	c.unsubscribed = false
 	c.mu.Unlock()
 
-	// Start event forwarding goroutine
-	go h.forwardEvents(c)
-
 	// Send acknowledgment
 	h.sendJSON(c, map[string]any{
 		"type":    "subscribed",
 		"task_id": taskID,
 	})
 
+	// Live events queue on the subscription while missed messages replay
+	var replayed map[string]bool
+	if transcriptAfter != "" && taskID != events.GlobalTaskID {
+		replayed = h.replayTranscripts(c, taskID, transcriptAfter)
+	}
+
+	// Start event forwarding goroutine
+	go h.forwardEvents(c, replayed)
+
 	// For global subscriptions, send initial session_update so reconnecting
 	// clients have current metrics immediately
 	if taskID == events.GlobalTaskID && h.server != nil {
@@ -279,8 +290,9 @@ func (h *WSHandler) handleCommand(c *wsConnection, msg WSMessage) {
 	h.sendJSON(c, result)
 }
 
-// forwardEvents forwards events from the publisher to the WebSocket.
-func (h *WSHandler) forwardEvents(c *wsConnection) {
+// forwardEvents forwards events from the publisher to the WebSocket,
+// skipping transcript messages already replayed.
+func (h *WSHandler) forwardEvents(c *wsConnection, replayed map[string]bool) {
 	// Get a local reference to eventChan under lock
 	c.mu.Lock()
 	eventChan := c.eventChan
@@ -306,6 +318,9 @@ func (h *WSHandler) forwardEvents(c *wsConnection) {
 			if unsubscribed {
 				return
 			}
+			if len(replayed) > 0 && replayed[transcriptMessageUUID(event)] {
+				continue
+			}
 
 			wsEvent := map[string]any{
 				"type":    "event",
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+// replayTranscripts sends a task's transcript messages stored after the
+// message with UUID after, as the transcript events they were published as,
+// and returns the UUIDs it sent. An unknown cursor replays every message.
+func (h *WSHandler) replayTranscripts(c *wsConnection, taskID, after string) map[string]bool {
+	if h.server == nil || h.server.backend == nil {
+		return nil
+	}
+	transcripts, err := h.server.backend.GetTranscripts(taskID)
+	if err != nil {
+		h.logger.Warn("websocket transcript replay failed", "task_id", taskID, "error", err)
+		return nil
+	}
+
+	start := 0
+	for i, t := range transcripts {
+		if t.MessageUUID == after {
+			start = i + 1
+			break
+		}
+	}
+	replayed := make(map[string]bool)
+	for _, t := range transcripts[start:] {
+		line, ok := transcriptReplayLine(t)
+		if !ok {
+			continue
+		}
+		msg, err := json.Marshal(map[string]any{
+			"type":    "event",
+			"event":   string(events.EventTranscript),
+			"task_id": taskID,
+			"data":    line,
+			"time":    line.Timestamp,
+		})
+		if err != nil {
+			continue
+		}
+		// Unlike live events, replayed messages wait for buffer space
+		select {
+		case c.send <- msg:
+		case <-c.done:
+			return replayed
+		}
+		replayed[t.MessageUUID] = true
+	}
+	h.logger.Debug("websocket replayed transcript", "task_id", taskID, "messages", len(replayed))
+	return replayed
+}
+
+// transcriptReplayLine converts a stored transcript message to the line
+// published when it was written. Messages that are not streamed, such as
+// hook events, report false.
+func transcriptReplayLine(t storage.Transcript) (events.TranscriptLine, bool) {
+	line := events.TranscriptLine{
+		Phase:       t.Phase,
+		Iteration:   1,
+		Content:     t.Content,
+		Timestamp:   time.UnixMilli(t.Timestamp),
+		Model:       t.Model,
+		MessageUUID: t.MessageUUID,
+	}
+	switch t.Type {
+	case "user":
+		line.Type = "prompt"
+	case "assistant":
+		line.Type = "response"
+		line.Content = transcriptDisplayText(t.Content)
+		line.Tokens = &events.TokenUpdate{
+			Phase:                    t.Phase,
+			InputTokens:              t.InputTokens,
+			OutputTokens:             t.OutputTokens,
+			CacheCreationInputTokens: t.CacheCreationTokens,
+			CacheReadInputTokens:     t.CacheReadTokens,
+			TotalTokens:              t.InputTokens + t.OutputTokens + t.CacheCreationTokens + t.CacheReadTokens,
+		}
+	case "chunk", "tool", "tool_result":
+		line.Type = t.Type
+	default:
+		return events.TranscriptLine{}, false
+	}
+	return line, true
+}
+
+// transcriptDisplayText returns the text of a stored assistant message,
+// which is a JSON array of content blocks for structured messages.
+func transcriptDisplayText(content string) string {
+	var blocks []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if !strings.HasPrefix(strings.TrimSpace(content), "[") || json.Unmarshal([]byte(content), &blocks) != nil {
+		return content
+	}
+	var texts []string
+	for _, b := range blocks {
+		if b.Type == "text" && b.Text != "" {
+			texts = append(texts, b.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// transcriptMessageUUID returns the message UUID of a transcript event, or
+// "" for other events.
+func transcriptMessageUUID(event events.Event) string {
+	if event.Type != events.EventTranscript {
+		return ""
+	}
+	switch line := event.Data.(type) {
+	case events.TranscriptLine:
+		return line.MessageUUID
+	case *events.TranscriptLine:
+		return line.MessageUUID
+	}
+	return ""
+}
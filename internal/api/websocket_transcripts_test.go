@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestWSHandler_SubscribeReplaysTranscriptsAfterCursor(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewTestBackend(t)
+	if err := backend.SaveTask(task.NewProtoTask("TASK-001", "Streamed")); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().UnixMilli()
+	for i, tr := range []storage.Transcript{
+		{MessageUUID: "msg-1", Type: "user", Role: "user", Content: "prompt"},
+		{MessageUUID: "msg-2", Type: "assistant", Role: "assistant", Content: `[{"type":"text","text":"working"},{"type":"tool_use","name":"Read"}]`, OutputTokens: 7},
+		{MessageUUID: "msg-3", Type: "hook", Content: `{"hook_name":"lint"}`},
+		{MessageUUID: "msg-4", Type: "tool", Role: "assistant", Content: "Read\nmain.go"},
+	} {
+		tr.TaskID, tr.Phase, tr.Timestamp = "TASK-001", "implement", now+int64(i)
+		if err := backend.AddTranscript(&tr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pub := events.NewMemoryPublisher()
+	server := &Server{runningTasks: make(map[string]context.CancelFunc), backend: backend}
+	ts := httptest.NewServer(NewWSHandler(pub, server, nil))
+	defer ts.Close()
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer func() { _ = ws.Close() }()
+
+	if err := ws.WriteJSON(WSMessage{Type: "subscribe", TaskID: "TASK-001", TranscriptAfter: "msg-1"}); err != nil {
+		t.Fatal(err)
+	}
+	type wsEvent struct {
+		Type  string                `json:"type"`
+		Event string                `json:"event"`
+		Data  events.TranscriptLine `json:"data"`
+	}
+	read := func() wsEvent {
+		t.Helper()
+		_ = ws.SetReadDeadline(time.Now().Add(2 * time.Second))
+		var msg wsEvent
+		if err := ws.ReadJSON(&msg); err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		return msg
+	}
+
+	if msg := read(); msg.Type != "subscribed" {
+		t.Fatalf("first message = %+v, want subscribed", msg)
+	}
+	// The hook event is not part of the stream
+	if msg := read(); msg.Event != "transcript" || msg.Data.MessageUUID != "msg-2" || msg.Data.Type != "response" || msg.Data.Content != "working" || msg.Data.Tokens.OutputTokens != 7 {
+		t.Errorf("replayed message = %+v, want msg-2 response", msg)
+	}
+	if msg := read(); msg.Data.MessageUUID != "msg-4" || msg.Data.Type != "tool" {
+		t.Errorf("replayed message = %+v, want msg-4 tool", msg)
+	}
+
+	// Live messages that were already replayed are not sent twice
+	helper := events.NewPublishHelper(pub)
+	helper.TranscriptMessage("TASK-001", events.TranscriptLine{Phase: "implement", Type: "tool", MessageUUID: "msg-4"})
+	helper.TranscriptMessage("TASK-001", events.TranscriptLine{Phase: "implement", Type: "response", Content: "done", MessageUUID: "msg-5"})
+	if msg := read(); msg.Data.MessageUUID != "msg-5" {
+		t.Errorf("live message = %+v, want msg-5", msg)
+	}
+}
+
+func TestTranscriptDisplayText(t *testing.T) {
+	t.Parallel()
+	for content, want := range map[string]string{
+		"plain text": "plain text",
+		`[{"type":"text","text":"a"},{"type":"tool_use"},{"type":"text","text":"b"}]`: "a\nb",
+		"[not json": "[not json",
+	} {
+		if got := transcriptDisplayText(content); got != want {
+			t.Errorf("transcriptDisplayText(%q) = %q, want %q", content, got, want)
+		}
+	}
+}
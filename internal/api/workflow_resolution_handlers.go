@@ -0,0 +1,33 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+
+	"github.com/randalmurphal/orc/internal/workflow"
+)
+
+// handleWorkflowResolution returns a workflow as the resolver builds it from
+// files, including the extends chain and the phases each layer added,
+// overrode, or removed.
+// GET /api/workflows/{id}/resolution
+func (s *Server) handleWorkflowResolution(w http.ResponseWriter, r *http.Request) {
+	_, workDir, err := s.resolveProjectBackend(r)
+	if err != nil {
+		s.jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resolver := workflow.NewResolverFromOrcDir(filepath.Join(workDir, ".orc"))
+	rw, err := resolver.ResolveWorkflow(r.PathValue("id"))
+	if err != nil {
+		status := http.StatusUnprocessableEntity
+		if errors.Is(err, workflow.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		s.jsonError(w, err.Error(), status)
+		return
+	}
+	s.jsonResponse(w, rw)
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/workflow"
+)
+
+func TestHandleWorkflowResolution(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workDir := t.TempDir()
+	wfDir := filepath.Join(workDir, ".orc", "workflows")
+	if err := os.MkdirAll(wfDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	derived := "id: lean-small\nname: Lean Small\nextends: implement-small\nremove_phases: [docs]\n"
+	if err := os.WriteFile(filepath.Join(wfDir, "lean-small.yaml"), []byte(derived), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(wfDir, "broken.yaml"), []byte("id: broken\nextends: nope\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{mux: http.NewServeMux(), logger: slog.Default(), workDir: workDir}
+	s.registerRESTRoutes()
+	get := func(id string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		s.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/workflows/"+id+"/resolution", nil))
+		return w
+	}
+
+	w := get("lean-small")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	var rw workflow.ResolvedWorkflow
+	if err := json.Unmarshal(w.Body.Bytes(), &rw); err != nil {
+		t.Fatal(err)
+	}
+	if rw.Source != workflow.SourceProject || len(rw.Inheritance) != 2 || rw.Inheritance[0].ID != "implement-small" {
+		t.Errorf("resolution = %+v", rw)
+	}
+	if got := rw.Inheritance[1].RemovedPhases; len(got) != 1 || got[0] != "docs" {
+		t.Errorf("removed phases = %v, want [docs]", got)
+	}
+	for _, p := range rw.Workflow.Phases {
+		if p.PhaseTemplateID == "docs" {
+			t.Error("docs phase not removed")
+		}
+	}
+
+	if w := get("no-such-workflow"); w.Code != http.StatusNotFound {
+		t.Errorf("missing workflow status = %d, want 404", w.Code)
+	}
+	if w := get("broken"); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("broken base status = %d, want 422", w.Code)
+	}
+}
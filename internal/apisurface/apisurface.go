@@ -0,0 +1,221 @@
+// Package apisurface extracts the exported API of the Go packages in a
+// source tree as comparable signatures, so a change that must not alter the
+// public API can be checked.
+package apisurface
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Surface maps each exported identifier to its signature. Keys are
+// "<package dir>.<name>", with methods as "<package dir>.<Type>.<Method>";
+// the package dir is slash-separated and relative to the scanned root.
+type Surface map[string]string
+
+// Change is a declaration whose signature differs between two surfaces.
+type Change struct {
+	Name   string `json:"name"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// Delta is the difference between two surfaces.
+type Delta struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Changed []Change `json:"changed"`
+}
+
+// Empty reports whether the surfaces are identical.
+func (d *Delta) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Scan returns the exported API of the importable Go packages under root.
+// Tests, main packages, and directories other modules cannot import
+// (internal, testdata, vendor, hidden) are not API and are skipped.
+func Scan(root string) (Surface, error) {
+	surface := Surface{}
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (name == "internal" || name == "testdata" || name == "vendor" || name == "node_modules" ||
+				strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+		if file.Name.Name == "main" {
+			return nil
+		}
+		rel, err := filepath.Rel(root, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		addFile(surface, fset, filepath.ToSlash(rel), file)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return surface, nil
+}
+
+// Diff compares two surfaces.
+func Diff(before, after Surface) *Delta {
+	delta := &Delta{Added: []string{}, Removed: []string{}, Changed: []Change{}}
+	for name, sig := range after {
+		prev, ok := before[name]
+		switch {
+		case !ok:
+			delta.Added = append(delta.Added, name)
+		case prev != sig:
+			delta.Changed = append(delta.Changed, Change{Name: name, Before: prev, After: sig})
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			delta.Removed = append(delta.Removed, name)
+		}
+	}
+	sort.Strings(delta.Added)
+	sort.Strings(delta.Removed)
+	sort.Slice(delta.Changed, func(i, j int) bool { return delta.Changed[i].Name < delta.Changed[j].Name })
+	return delta
+}
+
+func addFile(surface Surface, fset *token.FileSet, dir string, file *ast.File) {
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if !decl.Name.IsExported() {
+				continue
+			}
+			name := decl.Name.Name
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				recv := receiverName(decl.Recv.List[0].Type)
+				if !ast.IsExported(recv) {
+					continue
+				}
+				name = recv + "." + name
+			}
+			fn := *decl
+			fn.Doc, fn.Body = nil, nil
+			surface[dir+"."+name] = render(fset, &fn)
+
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				addSpec(surface, fset, dir, decl.Tok, spec)
+			}
+		}
+	}
+}
+
+func addSpec(surface Surface, fset *token.FileSet, dir string, tok token.Token, spec ast.Spec) {
+	switch spec := spec.(type) {
+	case *ast.TypeSpec:
+		if !spec.Name.IsExported() {
+			return
+		}
+		ts := *spec
+		ts.Doc, ts.Comment = nil, nil
+		ts.Type = exportedOnly(ts.Type)
+		surface[dir+"."+spec.Name.Name] = "type " + render(fset, &ts)
+
+	case *ast.ValueSpec:
+		for i, name := range spec.Names {
+			if !name.IsExported() {
+				continue
+			}
+			sig := tok.String() + " " + name.Name
+			if spec.Type != nil {
+				sig += " " + render(fset, spec.Type)
+			}
+			// A constant's value is part of its contract
+			if tok == token.CONST && i < len(spec.Values) {
+				sig += " = " + render(fset, spec.Values[i])
+			}
+			surface[dir+"."+name.Name] = sig
+		}
+	}
+}
+
+// exportedOnly drops unexported fields from a struct type; they are not
+// part of the API.
+func exportedOnly(expr ast.Expr) ast.Expr {
+	st, ok := expr.(*ast.StructType)
+	if !ok || st.Fields == nil {
+		return expr
+	}
+	fields := &ast.FieldList{}
+	for _, f := range st.Fields.List {
+		field := *f
+		field.Doc, field.Comment = nil, nil
+		if len(f.Names) == 0 {
+			// Embedded: exported when the embedded type is
+			if ast.IsExported(receiverName(f.Type)) {
+				fields.List = append(fields.List, &field)
+			}
+			continue
+		}
+		var names []*ast.Ident
+		for _, n := range f.Names {
+			if n.IsExported() {
+				names = append(names, n)
+			}
+		}
+		if len(names) > 0 {
+			field.Names = names
+			fields.List = append(fields.List, &field)
+		}
+	}
+	return &ast.StructType{Fields: fields}
+}
+
+// receiverName returns the type name of a receiver or embedded field,
+// without pointer, package qualifier or type parameters.
+func receiverName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(t.X)
+	case *ast.IndexExpr:
+		return receiverName(t.X)
+	case *ast.IndexListExpr:
+		return receiverName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// render prints a node on one line with normalized spacing, so formatting
+// changes do not count as API changes.
+func render(fset *token.FileSet, node any) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, node); err != nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
@@ -0,0 +1,89 @@
+package apisurface
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	path := filepath.Join(root, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScan(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "pkg/store/store.go", `package store
+
+const Version = "1"
+const internalLimit = 5
+
+var ErrMissing error
+
+type Store struct {
+	Name  string
+	cache map[string]string
+}
+
+func New(name string) *Store { return &Store{Name: name} }
+
+func (s *Store) Get(key string) (string, error) { return s.cache[key], nil }
+
+func (s *Store) evict() {}
+
+type entry struct{}
+
+func (e entry) Exported() {}
+`)
+	writeFile(t, root, "pkg/store/store_test.go", "package store\n\nfunc TestHelper() {}\n")
+	writeFile(t, root, "internal/impl/impl.go", "package impl\n\nfunc Hidden() {}\n")
+	writeFile(t, root, "cmd/tool/main.go", "package main\n\nfunc Run() {}\n")
+
+	surface, err := Scan(root)
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	want := map[string]string{
+		"pkg/store.Version":    `const Version = "1"`,
+		"pkg/store.ErrMissing": "var ErrMissing error",
+		"pkg/store.Store":      "type Store struct { Name string }",
+		"pkg/store.New":        "func New(name string) *Store",
+		"pkg/store.Store.Get":  "func (s *Store) Get(key string) (string, error)",
+	}
+	if len(surface) != len(want) {
+		t.Errorf("Scan() = %v, want %d entries", surface, len(want))
+	}
+	for name, sig := range want {
+		if surface[name] != sig {
+			t.Errorf("surface[%q] = %q, want %q", name, surface[name], sig)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := Surface{"p.A": "func A()", "p.B": "func B(x int)", "p.C": "type C int"}
+	after := Surface{"p.A": "func A()", "p.B": "func B(x int64)", "p.D": "func D()"}
+
+	delta := Diff(before, after)
+	if len(delta.Added) != 1 || delta.Added[0] != "p.D" {
+		t.Errorf("Added = %v", delta.Added)
+	}
+	if len(delta.Removed) != 1 || delta.Removed[0] != "p.C" {
+		t.Errorf("Removed = %v", delta.Removed)
+	}
+	if len(delta.Changed) != 1 || delta.Changed[0].After != "func B(x int64)" {
+		t.Errorf("Changed = %v", delta.Changed)
+	}
+	if delta.Empty() {
+		t.Error("Empty() = true")
+	}
+	if d := Diff(before, before); !d.Empty() {
+		t.Errorf("Diff of equal surfaces = %+v", d)
+	}
+}
@@ -608,6 +608,16 @@ func (a *ProjectDBAdapter) GetActiveNotifications(ctx context.Context) ([]*Notif
 	return notifications, rows.Err()
 }
 
+// NotificationExists reports whether a notification with the given ID has
+// been created, dismissed or not.
+func (a *ProjectDBAdapter) NotificationExists(ctx context.Context, id string) (bool, error) {
+	var count int
+	if err := a.pdb.Driver().QueryRow(ctx, `SELECT COUNT(*) FROM notifications WHERE id = ?`, id).Scan(&count); err != nil {
+		return false, fmt.Errorf("check notification: %w", err)
+	}
+	return count > 0, nil
+}
+
 // DismissNotification marks a notification as dismissed.
 func (a *ProjectDBAdapter) DismissNotification(ctx context.Context, id string) error {
 	query := `UPDATE notifications SET dismissed = 1 WHERE id = ?`
@@ -235,7 +235,7 @@ func (e *ThresholdEvaluator) Evaluate(ctx context.Context, trigger *Trigger, eve
 }
 
 // ScheduleEvaluator evaluates schedule-based triggers.
-// Only available in team mode with a persistent server.
+// Schedules are fired by the Scheduler in the API server, not by events.
 type ScheduleEvaluator struct{}
 
 func (e *ScheduleEvaluator) Type() TriggerType {
@@ -243,8 +243,6 @@ func (e *ScheduleEvaluator) Type() TriggerType {
 }
 
 func (e *ScheduleEvaluator) Evaluate(ctx context.Context, trigger *Trigger, event *Event, svc *Service) (bool, string, error) {
-	// Schedule evaluation is handled by a separate scheduler
-	// This evaluator is called from the scheduler, not from events
-	// For now, return false - the scheduler will directly call fireTrigger
+	// The Scheduler fires schedule triggers directly via fireTrigger
 	return false, "", nil
 }
@@ -12,6 +12,12 @@ const (
 	NotificationTypeAutomationFailed = "automation_failed"
 	// NotificationTypeAutomationBlocked indicates a trigger is blocked by cooldown.
 	NotificationTypeAutomationBlocked = "automation_blocked"
+	// NotificationTypeTaskTimeout indicates a task was paused after exceeding timeouts.task_max.
+	NotificationTypeTaskTimeout = "task_timeout"
+	// NotificationTypeTaskStale indicates a planned or paused task has gone untouched past tasks.stale.after.
+	NotificationTypeTaskStale = "task_stale"
+	// NotificationTypeGateTimeout indicates a human gate has waited past gates.timeout.after.
+	NotificationTypeGateTimeout = "gate_timeout"
 )
 
 // NotificationSourceType constants for notification sources.
@@ -67,6 +73,11 @@ func (n *Notification) ToResponse() NotificationResponse {
 		resp.Actions = []NotificationAction{
 			{Label: "Dismiss", Action: "dismiss"},
 		}
+	case NotificationTypeTaskTimeout, NotificationTypeTaskStale, NotificationTypeGateTimeout:
+		resp.Actions = []NotificationAction{
+			{Label: "View Task", Href: "/tasks/" + n.SourceID},
+			{Label: "Dismiss", Action: "dismiss"},
+		}
 	default:
 		// Use provided actions if any
 		resp.Actions = n.Actions
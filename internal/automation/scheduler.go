@@ -0,0 +1,131 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/config"
+)
+
+// schedulerInterval is how often the scheduler checks for due triggers.
+// Cron resolution is one minute, so runs fire at most this late.
+const schedulerInterval = 30 * time.Second
+
+// Scheduler fires schedule triggers from a long-running process (the API
+// server). The last fire time is persisted with the trigger, so on restart
+// runs missed while the server was down are handled by the trigger's
+// catch-up policy: skip (default) ignores them, run_once fires one run.
+type Scheduler struct {
+	svc *Service
+	now func() time.Time
+
+	// last is the time each trigger last fired, or was first scheduled
+	last map[string]time.Time
+}
+
+// NewScheduler creates a scheduler for the service's schedule triggers.
+func NewScheduler(svc *Service) *Scheduler {
+	return &Scheduler{
+		svc:  svc,
+		now:  time.Now,
+		last: make(map[string]time.Time),
+	}
+}
+
+// Run checks schedule triggers immediately and then every schedulerInterval
+// until ctx is cancelled.
+func (sc *Scheduler) Run(ctx context.Context) {
+	sc.tick(ctx)
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sc.tick(ctx)
+		}
+	}
+}
+
+// tick fires every enabled schedule trigger that is due. Triggers added to
+// the config since the last tick are picked up here.
+func (sc *Scheduler) tick(ctx context.Context) {
+	if !sc.svc.cfg.AutomationEnabled() {
+		return
+	}
+	now := sc.now()
+	for _, triggerCfg := range sc.svc.cfg.GetEnabledTriggers() {
+		if ctx.Err() != nil {
+			return
+		}
+		if triggerCfg.Type != config.TriggerTypeSchedule {
+			continue
+		}
+		trigger := sc.svc.configToTrigger(&triggerCfg)
+		schedule, err := ParseSchedule(trigger.Condition.Schedule)
+		if err != nil {
+			sc.svc.logger.Warn("invalid trigger schedule", "trigger", trigger.ID, "error", err)
+			continue
+		}
+
+		last, known := sc.last[trigger.ID]
+		catchUp := false
+		if !known {
+			last = sc.lastFired(ctx, trigger, now)
+			catchUp = true
+		}
+		due := schedule.Next(last)
+		if due.IsZero() || due.After(now) {
+			sc.last[trigger.ID] = last
+			continue
+		}
+
+		reason := fmt.Sprintf("scheduled run (%s) due at %s", trigger.Condition.Schedule, due.Format(time.RFC3339))
+		// Runs that came due before this process started were missed
+		if catchUp {
+			if trigger.Condition.CatchUp != config.CatchUpRunOnce {
+				sc.svc.logger.Info("skipping missed scheduled run",
+					"trigger", trigger.ID,
+					"due", due)
+				sc.last[trigger.ID] = now
+				continue
+			}
+			reason = fmt.Sprintf("catch-up for missed scheduled run (%s) due at %s", trigger.Condition.Schedule, due.Format(time.RFC3339))
+		}
+
+		// Fire at most once per tick; later due times are computed from now
+		sc.last[trigger.ID] = now
+		if !sc.svc.checkCooldown(ctx, trigger) {
+			sc.svc.logger.Debug("trigger cooldown active", "trigger", trigger.ID)
+			continue
+		}
+		if err := sc.svc.fireTrigger(ctx, trigger, reason); err != nil {
+			sc.svc.logger.Error("error firing scheduled trigger",
+				"trigger", trigger.ID,
+				"error", err)
+		}
+	}
+}
+
+// lastFired returns the persisted last fire time of a trigger. A trigger
+// that has never fired counts from when it was first scheduled, so a new
+// trigger waits for its next run instead of firing immediately. The trigger
+// is saved on first sight so fires and executions can be recorded against it.
+func (sc *Scheduler) lastFired(ctx context.Context, trigger *Trigger, now time.Time) time.Time {
+	stored, err := sc.svc.db.LoadTrigger(ctx, trigger.ID)
+	if err != nil || stored == nil {
+		if err := sc.svc.db.SaveTrigger(ctx, trigger); err != nil {
+			sc.svc.logger.Warn("error saving scheduled trigger", "trigger", trigger.ID, "error", err)
+		}
+		return now
+	}
+	if stored.LastTriggeredAt != nil {
+		return stored.LastTriggeredAt.In(now.Location())
+	}
+	if !stored.CreatedAt.IsZero() {
+		return stored.CreatedAt.In(now.Location())
+	}
+	return now
+}
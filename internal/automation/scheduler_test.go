@@ -0,0 +1,78 @@
+package automation
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+func TestScheduler_FiresAndCatchesUp(t *testing.T) {
+	t.Parallel()
+
+	pdb, err := db.OpenProject(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("OpenProject failed: %v", err)
+	}
+	defer func() { _ = pdb.Close() }()
+	adapter := NewProjectDBAdapter(pdb)
+	ctx := context.Background()
+
+	cfg := config.Default()
+	cfg.Automation.Enabled = true
+	cfg.Automation.Triggers = []config.TriggerConfig{{
+		ID:        "nightly-deps",
+		Type:      config.TriggerTypeSchedule,
+		Enabled:   true,
+		Mode:      config.AutomationModeNotify,
+		Condition: config.TriggerConditionConfig{Schedule: "0 2 * * *"},
+		Action:    config.TriggerActionConfig{Template: "update-deps"},
+	}}
+	svc := NewService(cfg, adapter, slog.Default())
+
+	fires := func() int {
+		t.Helper()
+		trigger, err := adapter.LoadTrigger(ctx, "nightly-deps")
+		if err != nil {
+			t.Fatalf("LoadTrigger failed: %v", err)
+		}
+		return trigger.TriggerCount
+	}
+
+	base := time.Now()
+	sc := NewScheduler(svc)
+	sc.now = func() time.Time { return base }
+
+	// A new trigger waits for its first run
+	sc.tick(ctx)
+	if got := fires(); got != 0 {
+		t.Fatalf("fires after first tick = %d, want 0", got)
+	}
+	sc.now = func() time.Time { return base.Add(25 * time.Hour) }
+	sc.tick(ctx)
+	sc.tick(ctx)
+	if got := fires(); got != 1 {
+		t.Fatalf("fires after run came due = %d, want 1", got)
+	}
+
+	// Restart after missing runs: skip is the default policy
+	restarted := NewScheduler(svc)
+	restarted.now = func() time.Time { return base.Add(72 * time.Hour) }
+	restarted.tick(ctx)
+	if got := fires(); got != 1 {
+		t.Errorf("fires after restart with skip = %d, want 1", got)
+	}
+
+	cfg.Automation.Triggers[0].Condition.CatchUp = config.CatchUpRunOnce
+	restarted = NewScheduler(svc)
+	restarted.now = func() time.Time { return base.Add(72 * time.Hour) }
+	restarted.tick(ctx)
+	restarted.tick(ctx)
+	if got := fires(); got != 2 {
+		t.Errorf("fires after restart with run_once = %d, want 2", got)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/i18n"
 )
 
 // Event represents an event that can trigger automation.
@@ -127,11 +128,7 @@ func NewService(cfg *config.Config, db Database, logger *slog.Logger) *Service {
 	svc.RegisterEvaluator(&InitiativeEvaluator{})
 	svc.RegisterEvaluator(&EventEvaluator{})
 	svc.RegisterEvaluator(&ThresholdEvaluator{})
-
-	// Only register schedule evaluator in team mode
-	if cfg.IsTeamMode() {
-		svc.RegisterEvaluator(&ScheduleEvaluator{})
-	}
+	svc.RegisterEvaluator(&ScheduleEvaluator{})
 
 	return svc
 }
@@ -395,7 +392,7 @@ func (s *Service) fireTrigger(ctx context.Context, trigger *Trigger, reason stri
 		notif := &Notification{
 			ID:         fmt.Sprintf("notif-%s-%d", trigger.ID, now.Unix()),
 			Type:       NotificationTypeAutomationPending,
-			Title:      "Automation task pending approval",
+			Title:      i18n.T("notification.automation_pending.title"),
 			Message:    fmt.Sprintf("%s: %s", trigger.Description, reason),
 			SourceType: NotificationSourceTask,
 			SourceID:   taskID,
@@ -419,8 +416,8 @@ func (s *Service) fireTrigger(ctx context.Context, trigger *Trigger, reason stri
 		notif := &Notification{
 			ID:         fmt.Sprintf("notif-%s-%d", trigger.ID, now.Unix()),
 			Type:       NotificationTypeAutomationPending,
-			Title:      fmt.Sprintf("Trigger condition met: %s", trigger.Description),
-			Message:    fmt.Sprintf("Reason: %s. Template: %s", reason, trigger.Action.Template),
+			Title:      i18n.T("notification.trigger_met.title", trigger.Description),
+			Message:    i18n.T("notification.trigger_met.message", reason, trigger.Action.Template),
 			SourceType: NotificationSourceTrigger,
 			SourceID:   trigger.ID,
 			CreatedAt:  now,
@@ -468,6 +465,7 @@ func (s *Service) configToTrigger(cfg *config.TriggerConfig) *Trigger {
 			Operator:   cfg.Condition.Operator,
 			Value:      cfg.Condition.Value,
 			Schedule:   cfg.Condition.Schedule,
+			CatchUp:    cfg.Condition.CatchUp,
 		},
 		Action: Action{
 			Template: cfg.Action.Template,
@@ -72,7 +72,12 @@ func (c *AutoTaskCreator) CreateAutomationTask(ctx context.Context, templateID s
 	if tmpl == nil {
 		return "", fmt.Errorf("automation template not found: %s", templateID)
 	}
+	return c.CreateTaskFromTemplate(ctx, templateID, tmpl, triggerID, reason)
+}
 
+// CreateTaskFromTemplate creates an automation task from a template that is
+// not necessarily defined in config, such as a built-in one.
+func (c *AutoTaskCreator) CreateTaskFromTemplate(ctx context.Context, templateID string, tmpl *config.AutomationTemplateConfig, triggerID string, reason string) (string, error) {
 	// Generate automation task ID (AUTO-XXX)
 	taskID, err := c.nextAutoTaskID(ctx)
 	if err != nil {
@@ -16,7 +16,7 @@ const (
 	TriggerTypeInitiative TriggerType = "initiative" // Fire on initiative events
 	TriggerTypeEvent      TriggerType = "event"      // Fire on specific events (pr_merged, etc.)
 	TriggerTypeThreshold  TriggerType = "threshold"  // Fire when metric crosses value
-	TriggerTypeSchedule   TriggerType = "schedule"   // Fire on cron schedule (team mode only)
+	TriggerTypeSchedule   TriggerType = "schedule"   // Fire on cron schedule (run by the API server)
 )
 
 // ExecutionMode defines how automation tasks are executed.
@@ -71,8 +71,9 @@ type Condition struct {
 	Operator string  `json:"operator,omitempty" yaml:"operator,omitempty"` // lt, gt, eq
 	Value    float64 `json:"value,omitempty" yaml:"value,omitempty"`       // Threshold value
 
-	// Schedule-based (team mode only)
+	// Schedule-based (run by the API server)
 	Schedule string `json:"schedule,omitempty" yaml:"schedule,omitempty"` // Cron expression
+	CatchUp  string `json:"catch_up,omitempty" yaml:"catch_up,omitempty"` // skip (default) or run_once
 }
 
 // Action defines what happens when a trigger fires.
@@ -190,7 +191,7 @@ type Metric struct {
 // Notification represents a notification to the user.
 type Notification struct {
 	ID         string               `json:"id"`
-	Type       string               `json:"type"` // automation_pending, automation_failed, automation_blocked
+	Type       string               `json:"type"` // automation_pending, automation_failed, automation_blocked, task_timeout, task_stale
 	Title      string               `json:"title"`
 	Message    string               `json:"message,omitempty"`
 	SourceType string               `json:"source_type,omitempty"` // trigger, task
@@ -10,10 +10,16 @@ import (
 
 // orcGitignoreEntries are the entries orc adds to .gitignore.
 // Runtime state (DB, worktrees, exports) lives in ~/.orc/ now.
-// Only .mcp.json needs ignoring in the project directory.
+// In the project directory only .mcp.json, the machine-specific onboarding
+// report, the hermetic quality check caches and the task directories of the
+// hybrid storage mode need ignoring (files mode users who want them tracked
+// remove the entry).
 var orcGitignoreEntries = []string{
 	"# orc - Claude Code Task Orchestrator",
 	".mcp.json",
+	".orc/onboarding.md",
+	".orc/cache/",
+	".orc/tasks/",
 }
 
 // updateGitignore adds orc entries to .gitignore if not already present.
@@ -367,6 +367,7 @@ func PrintResult(r *Result) {
 		fmt.Printf("  /plugin install playwright@claude-plugins-official  # Frontend detected\n")
 	}
 	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  orc tutorial                 # Guided first task (new to orc? start here)\n")
 	fmt.Printf("  orc new \"task description\"  # Create a new task\n")
 	fmt.Printf("  orc serve                    # Start web UI at localhost:8080\n")
 	fmt.Printf("  orc setup                    # (Optional) Configure with Claude\n")
@@ -0,0 +1,236 @@
+// Package breaker trips a circuit after repeated failures of an external
+// dependency (the claude CLI, the hosting API, the database) so that callers
+// fail fast while it is down instead of each waiting out its own timeouts,
+// and reports every dependency's state for /api/health.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Names of the process-wide breakers.
+const (
+	Claude   = "claude"
+	Hosting  = "hosting"
+	Database = "database"
+)
+
+// Defaults for breakers created by Get.
+const (
+	DefaultThreshold = 5
+	DefaultCooldown  = 30 * time.Second
+)
+
+// State is a breaker's position.
+type State string
+
+const (
+	// StateClosed passes calls through.
+	StateClosed State = "closed"
+	// StateOpen rejects calls until the cooldown has passed.
+	StateOpen State = "open"
+	// StateHalfOpen lets one probe call through to test recovery.
+	StateHalfOpen State = "half_open"
+)
+
+// ErrOpen is matched (errors.Is) by every OpenError.
+var ErrOpen = errors.New("circuit open")
+
+// OpenError is returned for calls rejected by an open breaker.
+type OpenError struct {
+	Name      string
+	Failures  int
+	LastError string
+	RetryAt   time.Time
+}
+
+func (e *OpenError) Error() string {
+	msg := fmt.Sprintf("%s unavailable: circuit open after %d consecutive failures", e.Name, e.Failures)
+	if e.LastError != "" {
+		msg += " (last: " + e.LastError + ")"
+	}
+	return msg + fmt.Sprintf("; retrying after %s", e.RetryAt.Format(time.RFC3339))
+}
+
+// Unwrap lets errors.Is(err, ErrOpen) match.
+func (e *OpenError) Unwrap() error { return ErrOpen }
+
+// Status is a snapshot of a breaker for health reports.
+type Status struct {
+	Name                string     `json:"name"`
+	State               State      `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastFailureAt       *time.Time `json:"last_failure_at,omitempty"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	RetryAt             *time.Time `json:"retry_at,omitempty"`
+}
+
+// Breaker opens after Threshold consecutive failures. After Cooldown it lets
+// a single probe through; the probe's outcome closes or reopens it.
+type Breaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu          sync.Mutex
+	state       State
+	failures    int
+	lastErr     string
+	lastFailure time.Time
+	openedAt    time.Time
+	probing     bool
+}
+
+// New creates a closed breaker.
+func New(name string, threshold int, cooldown time.Duration) *Breaker {
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = DefaultCooldown
+	}
+	return &Breaker{name: name, threshold: threshold, cooldown: cooldown, now: time.Now, state: StateClosed}
+}
+
+// Name returns the dependency the breaker guards.
+func (b *Breaker) Name() string { return b.name }
+
+// Allow returns an *OpenError if the call should not be made. A nil return
+// must be followed by Record with the call's outcome.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Before(b.openedAt.Add(b.cooldown)) {
+			return b.openErrorLocked()
+		}
+		b.state = StateHalfOpen
+		b.probing = true
+		return nil
+	case StateHalfOpen:
+		if b.probing {
+			return b.openErrorLocked()
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Check returns an *OpenError while the breaker is open and cooling down,
+// without claiming the probe. Use it to refuse new work up front; calls to
+// the dependency itself go through Allow/Record or Do.
+func (b *Breaker) Check() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateOpen && b.now().Before(b.openedAt.Add(b.cooldown)) {
+		return b.openErrorLocked()
+	}
+	return nil
+}
+
+// Record reports a call's outcome. Context cancellation says nothing about
+// the dependency and only ends a pending probe.
+func (b *Breaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	switch {
+	case err == nil:
+		b.state = StateClosed
+		b.failures = 0
+	case errors.Is(err, context.Canceled):
+	default:
+		b.failures++
+		b.lastErr = err.Error()
+		b.lastFailure = b.now()
+		if b.state == StateHalfOpen || b.failures >= b.threshold {
+			b.state = StateOpen
+			b.openedAt = b.lastFailure
+		}
+	}
+}
+
+// Do runs fn if the breaker allows it and records the outcome.
+func (b *Breaker) Do(fn func() error) error {
+	if err := b.Allow(); err != nil {
+		return err
+	}
+	err := fn()
+	b.Record(err)
+	return err
+}
+
+// Status returns the breaker's current state.
+func (b *Breaker) Status() Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := Status{Name: b.name, State: b.state, ConsecutiveFailures: b.failures, LastError: b.lastErr}
+	if !b.lastFailure.IsZero() {
+		t := b.lastFailure
+		s.LastFailureAt = &t
+	}
+	if b.state != StateClosed {
+		opened, retry := b.openedAt, b.openedAt.Add(b.cooldown)
+		s.OpenedAt, s.RetryAt = &opened, &retry
+	}
+	return s
+}
+
+func (b *Breaker) openErrorLocked() error {
+	return &OpenError{
+		Name:      b.name,
+		Failures:  b.failures,
+		LastError: b.lastErr,
+		RetryAt:   b.openedAt.Add(b.cooldown),
+	}
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]*Breaker{}
+)
+
+// Get returns the process-wide breaker for a dependency, creating it with
+// the default threshold and cooldown.
+func Get(name string) *Breaker {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	b, ok := registry[name]
+	if !ok {
+		b = New(name, DefaultThreshold, DefaultCooldown)
+		registry[name] = b
+	}
+	return b
+}
+
+// All returns the status of every process-wide breaker, sorted by name.
+// Claude, Hosting and Database are always included.
+func All() []Status {
+	for _, name := range []string{Claude, Hosting, Database} {
+		Get(name)
+	}
+	registryMu.Lock()
+	breakers := make([]*Breaker, 0, len(registry))
+	for _, b := range registry {
+		breakers = append(breakers, b)
+	}
+	registryMu.Unlock()
+
+	statuses := make([]Status, 0, len(breakers))
+	for _, b := range breakers {
+		statuses = append(statuses, b.Status())
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
@@ -0,0 +1,135 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestBreaker(threshold int) (*Breaker, *time.Time) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	b := New("dep", threshold, time.Minute)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestBreaker_OpensAfterThreshold(t *testing.T) {
+	b, _ := newTestBreaker(3)
+	boom := errors.New("boom")
+
+	for i := 0; i < 2; i++ {
+		if err := b.Do(func() error { return boom }); !errors.Is(err, boom) {
+			t.Fatalf("call %d: err = %v, want boom", i, err)
+		}
+	}
+	if s := b.Status(); s.State != StateClosed || s.ConsecutiveFailures != 2 {
+		t.Fatalf("after 2 failures: %+v, want closed with 2 failures", s)
+	}
+
+	_ = b.Do(func() error { return boom })
+	called := false
+	err := b.Do(func() error { called = true; return nil })
+	var open *OpenError
+	if !errors.As(err, &open) || !errors.Is(err, ErrOpen) || called {
+		t.Fatalf("after threshold: err = %v, called = %v; want OpenError without calling", err, called)
+	}
+	if open.Failures != 3 || open.LastError != "boom" {
+		t.Errorf("OpenError = %+v, want 3 failures, last boom", open)
+	}
+	if s := b.Status(); s.State != StateOpen || s.RetryAt == nil {
+		t.Errorf("status = %+v, want open with retry_at", s)
+	}
+}
+
+func TestBreaker_SuccessResetsFailures(t *testing.T) {
+	b, _ := newTestBreaker(2)
+	_ = b.Do(func() error { return errors.New("boom") })
+	_ = b.Do(func() error { return nil })
+	_ = b.Do(func() error { return errors.New("boom") })
+	if s := b.Status(); s.State != StateClosed || s.ConsecutiveFailures != 1 {
+		t.Errorf("status = %+v, want closed with 1 failure", s)
+	}
+}
+
+func TestBreaker_HalfOpenProbe(t *testing.T) {
+	b, now := newTestBreaker(1)
+	_ = b.Do(func() error { return errors.New("boom") })
+
+	*now = now.Add(time.Minute)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("probe after cooldown rejected: %v", err)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("second call during probe: err = %v, want ErrOpen", err)
+	}
+
+	// A failed probe reopens for another full cooldown
+	b.Record(errors.New("still down"))
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("after failed probe: err = %v, want ErrOpen", err)
+	}
+
+	*now = now.Add(time.Minute)
+	if err := b.Do(func() error { return nil }); err != nil {
+		t.Fatalf("successful probe: %v", err)
+	}
+	if s := b.Status(); s.State != StateClosed || s.ConsecutiveFailures != 0 {
+		t.Errorf("after successful probe: %+v, want closed", s)
+	}
+}
+
+func TestBreaker_CancellationIsNotAFailure(t *testing.T) {
+	b, _ := newTestBreaker(1)
+	_ = b.Do(func() error { return context.Canceled })
+	if s := b.Status(); s.State != StateClosed || s.ConsecutiveFailures != 0 {
+		t.Errorf("status = %+v, want closed without failures", s)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	b, _ := newTestBreaker(2)
+	client := &http.Client{Transport: NewTransport(nil, b)}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		_ = resp.Body.Close()
+	}
+	if _, err := client.Get(srv.URL); !errors.Is(err, ErrOpen) {
+		t.Fatalf("after two 503s: err = %v, want ErrOpen", err)
+	}
+
+	b2, _ := newTestBreaker(1)
+	status = http.StatusNotFound
+	client = &http.Client{Transport: NewTransport(nil, b2)}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if s := b2.Status(); s.ConsecutiveFailures != 0 {
+		t.Errorf("404 counted as failure: %+v", s)
+	}
+}
+
+func TestAll_IncludesBuiltins(t *testing.T) {
+	names := map[string]bool{}
+	for _, s := range All() {
+		names[s.Name] = true
+	}
+	for _, want := range []string{Claude, Hosting, Database} {
+		if !names[want] {
+			t.Errorf("All() missing %s", want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package breaker
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Transport is an http.RoundTripper guarded by a breaker. Network errors
+// and 5xx responses count as failures; any other response as a success.
+type Transport struct {
+	// Base performs the requests (default: http.DefaultTransport).
+	Base    http.RoundTripper
+	Breaker *Breaker
+}
+
+// NewTransport wraps base with b.
+func NewTransport(base http.RoundTripper, b *Breaker) *Transport {
+	return &Transport{Base: base, Breaker: b}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if err := t.Breaker.Allow(); err != nil {
+		return nil, err
+	}
+	resp, err := base.RoundTrip(req)
+	switch {
+	case err != nil:
+		t.Breaker.Record(err)
+	case resp.StatusCode >= 500:
+		t.Breaker.Record(fmt.Errorf("%s %s: %s", req.Method, req.URL.Host, resp.Status))
+	default:
+		t.Breaker.Record(nil)
+	}
+	return resp, err
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+// readOnlyCommandNames are commands that only read state. Every other
+// runnable command is recorded in the audit log, so new commands are
+// audited until they are known to be read-only.
+var readOnlyCommandNames = map[string]bool{
+	"help": true, "version": true, "completion": true, "commands": true,
+	"status": true, "list": true, "show": true, "get": true, "log": true, "logs": true,
+	"diff": true, "deps": true, "search": true, "doctor": true, "costs": true,
+	"replay": true, "history": true, "metrics": true, "velocity": true, "report": true,
+	"watch": true, "query": true, "tail": true, "runs": true, "branches": true,
+	"available": true, "check": true, "validate": true, "drift": true, "state-diff": true,
+	"quota": true, "members": true, "serve": true,
+}
+
+// cliAudit is the audit entry of the running command, started before it
+// runs and saved by finishCLIAudit.
+var cliAudit *cliAuditRecord
+
+type cliAuditRecord struct {
+	entry  *db.AuditEntry
+	before *config.Config
+}
+
+// auditedCommand reports whether running cmd is recorded in the audit log.
+func auditedCommand(cmd *cobra.Command) bool {
+	return cmd.Runnable() && cmd != cmd.Root() && !readOnlyCommandNames[cmd.Name()]
+}
+
+// isConfigCommand reports whether cmd can change orc config; its audit
+// entry carries the changed keys before and after.
+func isConfigCommand(cmd *cobra.Command) bool {
+	return strings.HasPrefix(cmd.CommandPath(), "orc config ")
+}
+
+// startCLIAudit begins the audit entry for a mutating command. It runs
+// after flags are parsed, so --project is honored.
+func startCLIAudit(cmd *cobra.Command, args []string) {
+	cliAudit = nil
+	if !auditedCommand(cmd) {
+		return
+	}
+	action := cmd.CommandPath()
+	if isConfigCommand(cmd) {
+		// Values may be secrets; the config diff records them masked
+		if len(args) > 0 {
+			action += " " + args[0]
+		}
+	} else if len(args) > 0 {
+		action += " " + strings.Join(args, " ")
+	}
+	rec := &cliAuditRecord{entry: &db.AuditEntry{
+		Actor:  defaultDecisionActor(""),
+		Source: db.AuditSourceCLI,
+		Action: action,
+	}}
+	for _, arg := range args {
+		if taskIDPattern.MatchString(arg) {
+			rec.entry.TaskID = arg
+			break
+		}
+	}
+	if isConfigCommand(cmd) {
+		if projectRoot, err := ResolveProjectPath(); err == nil {
+			rec.before, _ = config.LoadFrom(projectRoot)
+		}
+	}
+	cliAudit = rec
+}
+
+// finishCLIAudit saves the running command's audit entry with its outcome,
+// if team.activity_logging is on. Failures are ignored: auditing never
+// fails a command.
+func finishCLIAudit(runErr error) {
+	rec := cliAudit
+	cliAudit = nil
+	if rec == nil {
+		return
+	}
+	projectRoot, err := ResolveProjectPath()
+	if err != nil {
+		return
+	}
+	cfg, err := config.LoadFrom(projectRoot)
+	if err != nil || !cfg.Team.ActivityLogging {
+		return
+	}
+	rec.entry.Status = "ok"
+	if runErr != nil {
+		rec.entry.Status = "error"
+	}
+	if rec.before != nil && runErr == nil {
+		if old, updated := config.DiffValues(rec.before, cfg); len(old) > 0 {
+			rec.entry.Before, rec.entry.After = old, updated
+		}
+	}
+	rec.entry.CreatedAt = time.Now()
+
+	backend, err := getBackend()
+	if err != nil {
+		return
+	}
+	defer func() { _ = backend.Close() }()
+	_ = backend.DB().SaveAuditEntry(rec.entry)
+}
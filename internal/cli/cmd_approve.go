@@ -64,11 +64,17 @@ See also:
 				return fmt.Errorf("task is not blocked (status: %s)", task.StatusFromProto(t.Status))
 			}
 
+			if err := settleGateApproval(backend.DB(), id, task.GetCurrentPhaseProto(t), true, ""); err != nil {
+				return fmt.Errorf("resolve pending gate: %w", err)
+			}
 			t.Status = orcv1.TaskStatus_TASK_STATUS_PLANNED
 			if err := backend.SaveTask(t); err != nil {
 				return fmt.Errorf("save task: %w", err)
 			}
 
+			if jsonOut {
+				return outputJSON(cmd, &orcv1.UpdateTaskResponse{Task: t})
+			}
 			fmt.Printf("✅ Task %s approved\n", id)
 			fmt.Printf("   Run: orc run %s to continue\n", id)
 			return nil
@@ -140,11 +146,17 @@ See also:
 				Timestamp: timestamppb.Now(),
 			})
 
+			if err := settleGateApproval(backend.DB(), id, task.GetCurrentPhaseProto(t), false, reason); err != nil {
+				return fmt.Errorf("resolve pending gate: %w", err)
+			}
 			t.Status = orcv1.TaskStatus_TASK_STATUS_FAILED
 			if err := backend.SaveTask(t); err != nil {
 				return fmt.Errorf("save task: %w", err)
 			}
 
+			if jsonOut {
+				return outputJSON(cmd, &orcv1.UpdateTaskResponse{Task: t})
+			}
 			fmt.Printf("❌ Task %s rejected: %s\n", id, reason)
 			return nil
 		},
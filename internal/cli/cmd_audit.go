@@ -0,0 +1,156 @@
+// Package cli implements the orc command-line interface.
+package cli
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+// newAuditCmd creates the audit command for inspecting the audit log.
+func newAuditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Inspect the audit log of mutating API calls and commands",
+		Long: `Inspect the audit log.
+
+With team.activity_logging on (the default), every mutating API call, RPC,
+and orc command is recorded in the project database: who made it, what it
+was, when, the task it targeted, and its outcome. Config changes also record
+the changed keys with their values before and after.
+
+The log is also served by GET /api/audit.
+
+Examples:
+  orc audit tail                        # last 20 entries
+  orc audit tail -f                     # follow new entries
+  orc audit tail --actor alice --since 24h
+  orc audit tail --task TASK-001 -n 50`,
+	}
+	cmd.AddCommand(newAuditTailCmd())
+	return cmd
+}
+
+func newAuditTailCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Show the latest audit log entries",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.RequireInit(); err != nil {
+				return err
+			}
+			lines, _ := cmd.Flags().GetInt("lines")
+			follow, _ := cmd.Flags().GetBool("follow")
+			interval, _ := cmd.Flags().GetDuration("interval")
+			sinceFlag, _ := cmd.Flags().GetString("since")
+			q := db.AuditQuery{Limit: lines}
+			q.Actor, _ = cmd.Flags().GetString("actor")
+			q.TaskID, _ = cmd.Flags().GetString("task")
+			if sinceFlag != "" {
+				since, err := parseAuditSince(sinceFlag, time.Now())
+				if err != nil {
+					return err
+				}
+				q.Since = since
+			}
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be positive")
+			}
+
+			backend, err := getBackend()
+			if err != nil {
+				return fmt.Errorf("get backend: %w", err)
+			}
+			defer func() { _ = backend.Close() }()
+			pdb := backend.DB()
+
+			entries, err := pdb.QueryAuditLog(q)
+			if err != nil {
+				return err
+			}
+			slices.Reverse(entries)
+			out := cmd.OutOrStdout()
+			for _, e := range entries {
+				printAuditEntry(out, e)
+			}
+			if !follow {
+				if len(entries) == 0 {
+					_, _ = fmt.Fprintln(out, "No audit entries.")
+				}
+				return nil
+			}
+
+			if len(entries) > 0 {
+				q.AfterID = entries[len(entries)-1].ID
+			} else if latest, err := pdb.QueryAuditLog(db.AuditQuery{Limit: 1}); err == nil && len(latest) > 0 {
+				q.AfterID = latest[0].ID
+			}
+			q.Limit = db.MaxAuditQueryLimit
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-cmd.Context().Done():
+					return nil
+				case <-ticker.C:
+				}
+				entries, err := pdb.QueryAuditLog(q)
+				if err != nil {
+					return err
+				}
+				for _, e := range entries {
+					printAuditEntry(out, e)
+					q.AfterID = e.ID
+				}
+			}
+		},
+	}
+	cmd.Flags().IntP("lines", "n", 20, "number of entries to show")
+	cmd.Flags().BoolP("follow", "f", false, "keep printing new entries as they are recorded")
+	cmd.Flags().Duration("interval", 2*time.Second, "poll interval with --follow")
+	cmd.Flags().String("actor", "", "only entries by this actor")
+	cmd.Flags().String("task", "", "only entries for this task ID")
+	cmd.Flags().String("since", "", "only entries since a duration ago (24h), date (YYYY-MM-DD), or RFC 3339 time")
+	return cmd
+}
+
+// parseAuditSince parses --since: a duration before now, a date, or an RFC
+// 3339 time.
+func parseAuditSince(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: want a duration (24h), date (YYYY-MM-DD), or RFC 3339 time", value)
+}
+
+// printAuditEntry writes one audit entry, followed by any config changes it
+// recorded.
+func printAuditEntry(w io.Writer, e *db.AuditEntry) {
+	task := e.TaskID
+	if task == "" {
+		task = "-"
+	}
+	_, _ = fmt.Fprintf(w, "%s  %-12s %-4s %-9s %s  [%s]\n",
+		e.CreatedAt.Local().Format("2006-01-02 15:04:05"), e.Actor, e.Source, task, e.Action, e.Status)
+	keys := make([]string, 0, len(e.After))
+	for k := range e.After {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		_, _ = fmt.Fprintf(w, "    %s: %q -> %q\n", k, e.Before[k], e.After[k])
+	}
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+func TestParseAuditSince(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	if got, err := parseAuditSince("2h", now); err != nil || !got.Equal(now.Add(-2*time.Hour)) {
+		t.Errorf("parseAuditSince(2h) = %v, %v", got, err)
+	}
+	if got, err := parseAuditSince("2026-03-01T00:00:00Z", now); err != nil || got.Day() != 1 {
+		t.Errorf("parseAuditSince(RFC 3339) = %v, %v", got, err)
+	}
+	if got, err := parseAuditSince("2026-03-02", now); err != nil || got.Day() != 2 {
+		t.Errorf("parseAuditSince(date) = %v, %v", got, err)
+	}
+	if _, err := parseAuditSince("yesterday", now); err == nil {
+		t.Error("parseAuditSince(yesterday) should fail")
+	}
+}
+
+func TestAuditedCommand(t *testing.T) {
+	for path, want := range map[string]bool{
+		"new":          true,
+		"run":          true,
+		"config set":   true,
+		"list":         false,
+		"show":         false,
+		"audit tail":   false,
+		"gate list":    false,
+		"gate approve": true,
+	} {
+		cmd := findSubcommand(rootCmd, path)
+		if cmd == nil {
+			t.Fatalf("command %q not found", path)
+		}
+		if got := auditedCommand(cmd); got != want {
+			t.Errorf("auditedCommand(%q) = %v, want %v", path, got, want)
+		}
+	}
+	if auditedCommand(findSubcommand(rootCmd, "audit")) {
+		t.Error("command group audit should not be audited")
+	}
+}
+
+func TestPrintAuditEntry(t *testing.T) {
+	var buf bytes.Buffer
+	printAuditEntry(&buf, &db.AuditEntry{
+		Actor: "alice", Source: db.AuditSourceCLI, Action: "orc config set profile", Status: "ok",
+		Before: map[string]string{"profile": "auto"}, After: map[string]string{"profile": "safe"},
+		CreatedAt: time.Now(),
+	})
+	out := buf.String()
+	for _, want := range []string{"alice", "orc config set profile", "[ok]", `profile: "auto" -> "safe"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
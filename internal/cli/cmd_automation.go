@@ -30,7 +30,7 @@ Automation enables automatic execution of maintenance and workflow tasks:
   • Initiative-based: Fire on initiative events
   • Event-based: Fire on specific events (pr_merged, task_completed)
   • Threshold-based: Fire when metrics cross values
-  • Schedule-based: Fire on cron expressions (team mode only)
+  • Schedule-based: Fire on cron expressions while 'orc serve' is running
 
 Commands:
   list       List all triggers with status
@@ -75,8 +75,8 @@ Example:
 				return fmt.Errorf("load config: %w", err)
 			}
 
-			if !cfg.Automation.Enabled {
-				fmt.Println("Automation is disabled in config (automation.enabled: false)")
+			if !cfg.AutomationEnabled() {
+				fmt.Println("Automation is disabled in config (automation.enabled or features.automation)")
 				return nil
 			}
 
@@ -217,9 +217,12 @@ Example:
 				fmt.Printf("    Value:    %.2f\n", trigger.Condition.Value)
 			case config.TriggerTypeSchedule:
 				fmt.Printf("    Schedule: %s\n", trigger.Condition.Schedule)
-				if !cfg.IsTeamMode() {
-					fmt.Println("    Note: Schedule triggers require team mode (shared database)")
+				catchUp := trigger.Condition.CatchUp
+				if catchUp == "" {
+					catchUp = config.CatchUpSkip
 				}
+				fmt.Printf("    Catch-up: %s\n", catchUp)
+				fmt.Println("    Note: Schedule triggers fire while the API server is running")
 			}
 
 			// Action details
@@ -260,7 +263,7 @@ Example:
 			}
 			defer func() { _ = backend.Close() }()
 
-			dbBackend, ok := backend.(*storage.DatabaseBackend)
+			dbBackend, ok := storage.DatabaseOf(backend)
 			if !ok {
 				return fmt.Errorf("database backend required")
 			}
@@ -437,8 +440,8 @@ Example:
 				return fmt.Errorf("load config: %w", err)
 			}
 
-			if !cfg.Automation.Enabled {
-				return fmt.Errorf("automation is disabled in config (automation.enabled: false)")
+			if !cfg.AutomationEnabled() {
+				return fmt.Errorf("automation is disabled in config (automation.enabled or features.automation)")
 			}
 
 			// Find trigger in config
@@ -480,7 +483,7 @@ Example:
 			}
 			defer func() { _ = backend.Close() }()
 
-			dbBackend, ok := backend.(*storage.DatabaseBackend)
+			dbBackend, ok := storage.DatabaseOf(backend)
 			if !ok {
 				return fmt.Errorf("database backend required for automation")
 			}
@@ -546,7 +549,7 @@ Example:
 			defer func() { _ = backend.Close() }()
 
 			// Load executions from database
-			dbBackend, ok := backend.(*storage.DatabaseBackend)
+			dbBackend, ok := storage.DatabaseOf(backend)
 			if !ok {
 				return fmt.Errorf("database backend required")
 			}
@@ -670,7 +673,7 @@ Example:
 			}
 			defer func() { _ = backend.Close() }()
 
-			dbBackend, ok := backend.(*storage.DatabaseBackend)
+			dbBackend, ok := storage.DatabaseOf(backend)
 			if !ok {
 				return fmt.Errorf("database backend required")
 			}
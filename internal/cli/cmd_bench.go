@@ -2,7 +2,6 @@ package cli
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"path/filepath"
 	"text/tabwriter"
@@ -34,6 +33,8 @@ Workflow:
   3. orc bench run --variant codex53-high-impl   Run a variant (uses frozen outputs)
   4. orc bench report                            View results and recommendations
 
+'orc bench server' is separate: it load-tests the API server itself.
+
 Data lives at ~/.orc/bench/ (bench.db, repos/, runs/).
 
 Adding a new model = editing suite.yaml. No code changes needed.`,
@@ -44,6 +45,7 @@ Adding a new model = editing suite.yaml. No code changes needed.`,
 	cmd.AddCommand(newBenchReportCmd())
 	cmd.AddCommand(newBenchJudgeCmd())
 	cmd.AddCommand(newBenchShowCmd())
+	cmd.AddCommand(newBenchServerCmd())
 
 	return cmd
 }
@@ -725,10 +727,3 @@ func openBenchStore() (*bench.Store, error) {
 	}
 	return store, nil
 }
-
-// outputJSON encodes v as indented JSON to the command's output.
-func outputJSON(cmd *cobra.Command, v any) error {
-	enc := json.NewEncoder(cmd.OutOrStdout())
-	enc.SetIndent("", "  ")
-	return enc.Encode(v)
-}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/loadtest"
+)
+
+func newBenchServerCmd() *cobra.Command {
+	var opts loadtest.Options
+
+	cmd := &cobra.Command{
+		Use:   "server",
+		Short: "Load-test the API server and report latency percentiles",
+		Long: `Start an API server on a throwaway project and drive it with concurrent
+clients, then report p50/p90/p99 latencies per operation.
+
+Each of --concurrency workers creates a task, updates it (a database write),
+and lists the project's tasks, until --tasks tasks exist. Meanwhile
+--subscribers event streams (default: one per worker) measure how long each
+task_created event takes to reach them; events that never arrive are
+counted as errors.
+
+The instance runs in a temporary directory with its own HOME, so the real
+registry, global database and projects are untouched. Background pollers are
+off, so only request handling is measured.
+
+Examples:
+  orc bench server
+  orc bench server --tasks 1000 --concurrency 32
+  orc bench server --subscribers 100   # many dashboards watching
+  orc bench server --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := os.MkdirTemp("", "orc-bench-server-")
+			if err != nil {
+				return fmt.Errorf("create load test sandbox: %w", err)
+			}
+			defer func() { _ = os.RemoveAll(root) }()
+			// Keep the registry and global database in the sandbox too
+			if err := os.Setenv("HOME", filepath.Join(root, "home")); err != nil {
+				return err
+			}
+
+			inst, err := loadtest.Start(root)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = inst.Close() }()
+
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			if !quiet {
+				fmt.Fprintf(cmd.OutOrStdout(), "Load-testing %s with %d tasks and %d workers...\n", inst.URL, opts.Tasks, opts.Concurrency)
+			}
+			rep, err := loadtest.Run(ctx, nil, inst.URL, inst.ProjectID, opts)
+			if err != nil {
+				return err
+			}
+
+			if jsonOut {
+				if err := outputJSON(cmd, rep); err != nil {
+					return err
+				}
+			} else {
+				printLoadReport(cmd, rep)
+			}
+			if n := rep.Errors(); n > 0 {
+				return fmt.Errorf("%d operation(s) failed", n)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Tasks, "tasks", 200, "number of tasks to create")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", 16, "number of concurrent client workers")
+	cmd.Flags().IntVar(&opts.Subscribers, "subscribers", 0, "number of event streams (default: --concurrency)")
+	cmd.Flags().Int32Var(&opts.PageSize, "page-size", 50, "tasks per ListTasks page")
+
+	return cmd
+}
+
+func printLoadReport(cmd *cobra.Command, rep *loadtest.Report) {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "\n%d tasks, %d workers, %d event streams in %.2fs\n\n",
+		rep.Tasks, rep.Concurrency, rep.Subscribers, rep.DurationMs/1000)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "OPERATION\tCOUNT\tERRORS\tPER SEC\tMEAN\tP50\tP90\tP99\tMAX")
+	for _, op := range rep.Operations {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.1f\t%.1fms\t%.1fms\t%.1fms\t%.1fms\t%.1fms\n",
+			op.Name, op.Count, op.Errors, op.PerSecond, op.MeanMs, op.P50Ms, op.P90Ms, op.P99Ms, op.MaxMs)
+	}
+	_ = w.Flush()
+
+	if rep.FirstError != "" {
+		fmt.Fprintf(out, "\nFirst error: %s\n", rep.FirstError)
+	}
+}
@@ -34,11 +34,13 @@ Configuration is loaded from multiple sources with this priority:
 Personal settings always override shared settings.
 
 Subcommands:
-  show        Show merged configuration
-  get         Get a specific config value
-  set         Set a config value
-  resolution  Show full resolution chain for a key
-  edit        Open config file in $EDITOR
+  show           Show merged configuration
+  get            Get a specific config value
+  set            Set a config value
+  resolution     Show full resolution chain for a key
+  edit           Open config file in $EDITOR
+  profiles       List built-in and named config profiles
+  apply-profile  Write a profile into a config file
 
 Examples:
   orc config show                  # Show merged config as YAML
@@ -49,7 +51,8 @@ Examples:
   orc config set --project profile safe   # Set in project config
   orc config resolution model      # Show resolution chain
   orc config edit                  # Open user config in $EDITOR
-  orc config edit --project        # Open project config`,
+  orc config edit --project        # Open project config
+  orc config apply-profile careful # Apply ~/.orc/profiles/careful.yaml`,
 	}
 
 	cmd.AddCommand(newConfigShowCmd())
@@ -57,6 +60,8 @@ Examples:
 	cmd.AddCommand(newConfigSetCmd())
 	cmd.AddCommand(newConfigResolutionCmd())
 	cmd.AddCommand(newConfigEditCmd())
+	cmd.AddCommand(newConfigProfilesCmd())
+	cmd.AddCommand(newConfigApplyProfileCmd())
 	cmd.AddCommand(newConfigDocsCmd())
 	cmd.AddCommand(newConfigCommandsCmd())
 
@@ -371,6 +376,7 @@ func printConfigWithSources(out io.Writer, tc *config.TrackedConfig) error {
 // newConfigEditCmd creates the 'config edit' subcommand.
 func newConfigEditCmd() *cobra.Command {
 	var (
+		editUser    bool
 		editProject bool
 		editShared  bool
 	)
@@ -380,16 +386,20 @@ func newConfigEditCmd() *cobra.Command {
 		Short: "Open config file in $EDITOR",
 		Long: `Open a configuration file in your default editor.
 
-By default, opens the user config (~/.orc/config.yaml).
+By default, opens the user config (~/.orc/config.yaml), whose values
+apply to every project unless a project overrides them.
 Use flags to specify a different target:
 
+  --user     Open ~/.orc/config.yaml (default)
   --project  Open .orc/config.yaml
   --shared   Open .orc/shared/config.yaml
 
-The file will be created if it doesn't exist.
+The file will be created if it doesn't exist. The user config is
+validated after the editor exits.
 
 Examples:
   orc config edit              # Open user config
+  orc config edit --user       # Open user config
   orc config edit --project    # Open project config
   orc config edit --shared     # Open shared config`,
 		Args: cobra.NoArgs,
@@ -404,11 +414,12 @@ Examples:
 				targetPath = filepath.Join(config.OrcDir, "shared", config.ConfigFileName)
 			default:
 				// Default to user config
-				home, err := os.UserHomeDir()
+				editUser = true
+				userPath, err := config.UserConfigPath()
 				if err != nil {
-					return fmt.Errorf("get home directory: %w", err)
+					return fmt.Errorf("get user config path: %w", err)
 				}
-				targetPath = filepath.Join(home, ".orc", config.ConfigFileName)
+				targetPath = userPath
 			}
 
 			// Ensure target directory exists
@@ -443,13 +454,24 @@ Examples:
 				return fmt.Errorf("run editor: %w", err)
 			}
 
+			if editUser {
+				content, err := os.ReadFile(targetPath)
+				if err != nil {
+					return fmt.Errorf("read user config: %w", err)
+				}
+				if err := config.ValidateConfigYAML(content); err != nil {
+					return fmt.Errorf("%s: %w", targetPath, err)
+				}
+			}
+
 			return nil
 		},
 	}
 
+	cmd.Flags().BoolVar(&editUser, "user", false, "Edit user config (~/.orc/config.yaml)")
 	cmd.Flags().BoolVar(&editProject, "project", false, "Edit project config (.orc/config.yaml)")
 	cmd.Flags().BoolVar(&editShared, "shared", false, "Edit shared config (.orc/shared/config.yaml)")
-	cmd.MarkFlagsMutuallyExclusive("project", "shared")
+	cmd.MarkFlagsMutuallyExclusive("user", "project", "shared")
 
 	return cmd
 }
@@ -469,10 +491,12 @@ func getConfigDocs() []ConfigDoc {
 	return []ConfigDoc{
 		// Core
 		{Key: "profile", Type: "string", Default: "auto", EnvVar: "ORC_PROFILE", Description: "Automation profile (auto, fast, safe, strict)", Category: "Core"},
+		{Key: "locale", Type: "string", Default: "en", EnvVar: "ORC_LOCALE", Description: "Language for CLI output, errors and notifications (en, de)", Category: "Core"},
 		{Key: "model", Type: "string", Default: "sonnet", EnvVar: "ORC_MODEL", Description: "Claude model to use", Category: "Core"},
 		{Key: "fallback_model", Type: "string", Default: "sonnet", EnvVar: "ORC_FALLBACK_MODEL", Description: "Fallback model when primary fails", Category: "Core"},
 		{Key: "max_turns", Type: "int", Default: "150", EnvVar: "ORC_MAX_TURNS", Description: "Maximum Claude CLI turns per phase", Category: "Core"},
 		{Key: "timeout", Type: "duration", Default: "30m", EnvVar: "ORC_TIMEOUT", Description: "Maximum time per phase", Category: "Core"},
+		{Key: "fake_model", Type: "string", Default: "", EnvVar: "ORC_FAKE_MODEL", Description: "Fixture directory answering phases instead of a model (tests/CI)", Category: "Core"},
 
 		// Gates
 		{Key: "gates.default_type", Type: "string", Default: "auto", EnvVar: "ORC_GATES_DEFAULT", Description: "Default gate type (auto, ai, human)", Category: "Gates"},
@@ -483,20 +507,91 @@ func getConfigDocs() []ConfigDoc {
 		// Retry
 		{Key: "retry.enabled", Type: "bool", Default: "true", EnvVar: "ORC_RETRY_ENABLED", Description: "Enable cross-phase retry", Category: "Retry"},
 		{Key: "retry.max_retries", Type: "int", Default: "5", EnvVar: "ORC_RETRY_MAX_RETRIES", Description: "Max retry attempts (deprecated: use executor.max_retries)", Category: "Retry"},
+		{Key: "retry.replan.enabled", Type: "bool", Default: "false", Description: "Re-plan instead of failing when a phase exhausts its retries", Category: "Retry"},
+		{Key: "retry.replan.max_replans", Type: "int", Default: "1", Description: "Max re-planning steps per task", Category: "Retry"},
+		{Key: "retry.replan.gate", Type: "string", Default: "human", Description: "How a revised plan is accepted: auto (retry immediately) or human (block until resumed)", Category: "Retry"},
 
 		// Execution
 		{Key: "executor.max_retries", Type: "int", Default: "5", EnvVar: "ORC_EXECUTOR_MAX_RETRIES", Description: "Max retry attempts when a phase fails", Category: "Execution"},
+		{Key: "workflow_defaults.<category>", Type: "string", Default: "", Description: "Default workflow for tasks of a category (feature, bug, refactor, chore, docs, test) created without --workflow", Category: "Execution"},
+		{Key: "prompt_variants", Type: "map[string]string", Default: "{bug: bugfix}", Description: "Prompt variant per task category; phases use <prompt>.<variant>.md when it exists", Category: "Execution"},
+		{Key: "phase_models", Type: "map[string]map[string]string", Default: "{}", Description: "Default model per phase, keyed by workflow ID (* = every workflow); task phase_models override it", Category: "Execution"},
+
+		// Timeouts
+		{Key: "timeouts.phase_max", Type: "duration", Default: "60m", EnvVar: "ORC_PHASE_MAX_TIMEOUT", Description: "Max time per phase (0 = unlimited)", Category: "Timeouts"},
+		{Key: "timeouts.task_max", Type: "map[string]duration", Default: "{}", EnvVar: "", Description: "Wall-clock limit per task run, keyed by weight, workflow ID, or default; expiry pauses the task and notifies", Category: "Timeouts"},
+
+		// Git
+		{Key: "git_identity.name", Type: "string", Default: "", EnvVar: "ORC_GIT_IDENTITY_NAME", Description: "Author/committer name for commits orc and its agents make (empty = user's git config)", Category: "Git"},
+		{Key: "git_identity.email", Type: "string", Default: "", EnvVar: "ORC_GIT_IDENTITY_EMAIL", Description: "Author/committer email for commits orc and its agents make", Category: "Git"},
+		{Key: "git_hooks.bypass", Type: "[]string", Default: "[]", Description: "Project git hooks skipped when orc and its agents commit, merge, rebase, or push (hook names or all)", Category: "Git"},
+		{Key: "git_hooks.approved", Type: "[]string", Default: "[]", Description: "Hooks that still run when git_hooks.bypass is [all]", Category: "Git"},
 
 		// Worktree
 		{Key: "worktree.enabled", Type: "bool", Default: "true", EnvVar: "ORC_WORKTREE_ENABLED", Description: "Enable git worktree isolation", Category: "Worktree"},
 		{Key: "worktree.dir", Type: "string", Default: "", EnvVar: "", Description: "Worktree directory (empty = ~/.orc/worktrees/<project-id>/)", Category: "Worktree"},
 		{Key: "worktree.cleanup_on_complete", Type: "bool", Default: "true", EnvVar: "", Description: "Remove worktree after success", Category: "Worktree"},
 		{Key: "worktree.cleanup_on_fail", Type: "bool", Default: "false", EnvVar: "", Description: "Remove worktree after failure", Category: "Worktree"},
+		{Key: "worktree.preflight.enabled", Type: "bool", Default: "true", EnvVar: "", Description: "Check repository state before a task starts", Category: "Worktree"},
+		{Key: "worktree.preflight.check_remote", Type: "bool", Default: "true", EnvVar: "", Description: "Require origin to be reachable before a task starts", Category: "Worktree"},
+		{Key: "worktree.preflight.min_free_disk_mb", Type: "int", Default: "1024", EnvVar: "", Description: "Free disk space required for worktrees (0 = no check)", Category: "Worktree"},
 
 		// Review
 		{Key: "review.enabled", Type: "bool", Default: "true", EnvVar: "", Description: "Enable code review phase", Category: "Review"},
 		{Key: "review.rounds", Type: "int", Default: "2", EnvVar: "", Description: "Number of review rounds", Category: "Review"},
 		{Key: "review.require_pass", Type: "bool", Default: "true", EnvVar: "", Description: "Require passing review to continue", Category: "Review"},
+		{Key: "review.chunking.enabled", Type: "bool", Default: "true", EnvVar: "", Description: "Split review of large diffs into per-directory chunks", Category: "Review"},
+		{Key: "review.chunking.max_lines", Type: "int", Default: "1500", EnvVar: "", Description: "Changed lines per review chunk", Category: "Review"},
+		{Key: "review.chunking.max_files", Type: "int", Default: "40", EnvVar: "", Description: "Changed files per review chunk", Category: "Review"},
+		{Key: "review.chunking.parallel", Type: "bool", Default: "false", EnvVar: "", Description: "Review chunks concurrently", Category: "Review"},
+
+		// Validation
+		{Key: "validation.model", Type: "string", Default: "haiku", EnvVar: "", Description: "Model used for validation calls, including confidence scoring", Category: "Validation"},
+		{Key: "validation.confidence.enabled", Type: "bool", Default: "false", EnvVar: "", Description: "Score each completed phase's confidence and risk factors", Category: "Validation"},
+		{Key: "validation.confidence.escalate_below", Type: "float", Default: "0.6", EnvVar: "", Description: "Confidence under which an auto gate escalates to human (0 = never)", Category: "Validation"},
+		{Key: "validation.traceability.enabled", Type: "bool", Default: "false", EnvVar: "", Description: "Map spec success criteria to the code and tests that satisfy them after each listed phase", Category: "Validation"},
+		{Key: "validation.traceability.phases", Type: "[]string", Default: "[implement, review]", EnvVar: "", Description: "Phases after which a traceability report is built", Category: "Validation"},
+
+		// Voting
+		{Key: "voting.enabled", Type: "bool", Default: "false", EnvVar: "", Description: "Run critical phases as parallel candidates and let a judge pick the best", Category: "Voting"},
+		{Key: "voting.candidates", Type: "int", Default: "3", EnvVar: "", Description: "Parallel candidates per voting phase (2-5)", Category: "Voting"},
+		{Key: "voting.phases", Type: "[]string", Default: "[design, implement]", EnvVar: "", Description: "Phases that vote", Category: "Voting"},
+		{Key: "voting.weights", Type: "[]string", Default: "[large]", EnvVar: "", Description: "Task weights or workflow IDs that vote", Category: "Voting"},
+		{Key: "voting.priorities", Type: "[]string", Default: "[critical]", EnvVar: "", Description: "Task priorities that vote regardless of weight", Category: "Voting"},
+		{Key: "voting.judge_model", Type: "string", Default: "opus", EnvVar: "", Description: "Model that compares candidates against the spec", Category: "Voting"},
+		{Key: "voting.allow_merge", Type: "bool", Default: "true", EnvVar: "", Description: "Let the judge merge strengths of other candidates into the winner", Category: "Voting"},
+
+		// Binary files
+		{Key: "binary_files.policy", Type: "string", Default: "allow", EnvVar: "", Description: "Binary files in task changes: allow (with size cap), block, or lfs", Category: "Binary Files"},
+		{Key: "binary_files.max_size_kb", Type: "int", Default: "5120", EnvVar: "", Description: "Largest binary file committed outside Git LFS (0 = no cap)", Category: "Binary Files"},
+		{Key: "binary_files.allow", Type: "[]string", Default: "[]", EnvVar: "", Description: "Glob patterns of binary files exempt from the policy", Category: "Binary Files"},
+
+		// Quality Checks
+		{Key: "quality_checks.parallelism", Type: "int", Default: "4", EnvVar: "", Description: "How many phase quality checks run at once (1 = one after another)", Category: "Quality Checks"},
+		{Key: "quality_checks.timeout", Type: "duration", Default: "2m", EnvVar: "", Description: "Timeout for each quality check without its own timeout_ms", Category: "Quality Checks"},
+		{Key: "quality_checks.hermetic.enabled", Type: "bool", Default: "false", EnvVar: "", Description: "Run checks with a fresh HOME/TMPDIR, allowlisted env, and caches under .orc/cache/", Category: "Quality Checks"},
+		{Key: "quality_checks.hermetic.env", Type: "[]string", Default: "[]", EnvVar: "", Description: "Extra environment variables passed through to hermetic checks", Category: "Quality Checks"},
+
+		// Sensitive paths
+		{Key: "sensitive_paths.deny", Type: "[]string", Default: "[]", EnvVar: "", Description: "Paths tasks must never modify (\"dir/\", full-path globs, or base-name globs like \"*.pem\")", Category: "Sensitive Paths"},
+		{Key: "sensitive_paths.allow", Type: "[]string", Default: "[]", EnvVar: "", Description: "Exceptions to sensitive_paths.deny", Category: "Sensitive Paths"},
+
+		// Compliance
+		{Key: "compliance.allowed_licenses", Type: "[]string", Default: "[]", EnvVar: "", Description: "SPDX licenses new dependencies may use (empty = any not denied)", Category: "Compliance"},
+		{Key: "compliance.denied_licenses", Type: "[]string", Default: "[AGPL-3.0, GPL-2.0, GPL-3.0, SSPL-1.0]", EnvVar: "", Description: "SPDX licenses that block the compliance phase", Category: "Compliance"},
+		{Key: "compliance.unknown_license", Type: "string", Default: "warn", EnvVar: "", Description: "Undetectable licenses: warn or block", Category: "Compliance"},
+		{Key: "compliance.approved_registries", Type: "[]string", Default: "[]", EnvVar: "", Description: "Hosts new dependencies may come from (empty = any)", Category: "Compliance"},
+		{Key: "compliance.sbom", Type: "bool", Default: "true", EnvVar: "", Description: "Record an SBOM delta on finalize and summarize it in the PR", Category: "Compliance"},
+
+		// Refactor safety
+		{Key: "refactor_safety.enabled", Type: "bool", Default: "true", EnvVar: "", Description: "Require refactor tasks to keep test results and public API unchanged", Category: "Refactor Safety"},
+		{Key: "refactor_safety.test_command", Type: "string", Default: "", EnvVar: "", Description: "Tests to compare before and after (empty = project tests command)", Category: "Refactor Safety"},
+		{Key: "refactor_safety.api", Type: "bool", Default: "true", EnvVar: "", Description: "Also compare exported Go API signatures", Category: "Refactor Safety"},
+
+		// Tasks
+		{Key: "tasks.stale.after", Type: "duration", Default: "336h", EnvVar: "", Description: "Time a planned or paused task can go untouched before it is stale (0 = disabled)", Category: "Tasks"},
+		{Key: "tasks.stale.check_interval", Type: "duration", Default: "1h", EnvVar: "", Description: "Time between stale task checks in orc serve", Category: "Tasks"},
+		{Key: "tasks.stale.action", Type: "string", Default: "flag", EnvVar: "", Description: "What happens to stale tasks: flag, notify (assignee named in a notification), or close", Category: "Tasks"},
 
 		// QA
 		{Key: "qa.enabled", Type: "bool", Default: "true", EnvVar: "", Description: "Enable QA phase", Category: "QA"},
@@ -509,13 +604,22 @@ func getConfigDocs() []ConfigDoc {
 
 		// Completion
 		{Key: "completion.action", Type: "string", Default: "pr", EnvVar: "", Description: "Action after completion (pr, merge, commit, none)", Category: "Completion"},
+		{Key: "completion.provider", Type: "string", Default: "auto", EnvVar: "", Description: "Hosting provider for PRs/MRs, CI and merge (auto, github, gitlab)", Category: "Completion"},
 		{Key: "completion.target_branch", Type: "string", Default: "main", EnvVar: "", Description: "Branch to merge into", Category: "Completion"},
 		{Key: "completion.delete_branch", Type: "bool", Default: "true", EnvVar: "", Description: "Delete task branch after merge", Category: "Completion"},
+		{Key: "completion.protected_branches", Type: "[]string", Default: "[main, master, develop, release]", EnvVar: "", Description: "Branches orc never pushes or merges to directly", Category: "Completion"},
+		{Key: "completion.verify_branch_protection", Type: "bool", Default: "true", EnvVar: "", Description: "Check remote branch protection before a direct merge", Category: "Completion"},
+		{Key: "completion.follow_ups.create", Type: "string", Default: "none", EnvVar: "", Description: "Create follow-ups as tracked work at completion: none, tasks, issues", Category: "Completion"},
+		{Key: "completion.follow_ups.labels", Type: "[]string", Default: "[]", EnvVar: "", Description: "Labels applied to follow-up issues", Category: "Completion"},
+		{Key: "completion.release.prefix", Type: "string", Default: "release/", EnvVar: "", Description: "Branch prefix that marks release branches", Category: "Completion"},
+		{Key: "completion.release.backport", Type: "string", Default: "cherry-pick", EnvVar: "", Description: "Linked task created after a release-branch merge: cherry-pick, merge, none", Category: "Completion"},
+		{Key: "completion.release.backport_to", Type: "string", Default: "main", EnvVar: "", Description: "Branch that back-port tasks target", Category: "Completion"},
+		{Key: "completion.release.workflow", Type: "string", Default: "implement-small", EnvVar: "", Description: "Workflow that runs back-port tasks", Category: "Completion"},
 		{Key: "completion.pr.auto_merge", Type: "bool", Default: "true", EnvVar: "", Description: "Enable auto-merge when PR approved", Category: "Completion"},
 
 		// Team
 		{Key: "team.name", Type: "string", Default: "", EnvVar: "ORC_TEAM_NAME", Description: "Organization/team name", Category: "Team"},
-		{Key: "team.activity_logging", Type: "bool", Default: "true", EnvVar: "ORC_TEAM_ACTIVITY_LOG", Description: "Log all actions as history", Category: "Team"},
+		{Key: "team.activity_logging", Type: "bool", Default: "true", EnvVar: "ORC_TEAM_ACTIVITY_LOG", Description: "Record mutating API calls and commands in the audit log", Category: "Team"},
 		{Key: "team.task_claiming", Type: "bool", Default: "false", EnvVar: "ORC_TEAM_TASK_CLAIMING", Description: "Enable task assignment", Category: "Team"},
 		{Key: "team.mode", Type: "string", Default: "local", EnvVar: "ORC_TEAM_MODE", Description: "Team mode (local, shared_db)", Category: "Team"},
 
@@ -531,6 +635,55 @@ func getConfigDocs() []ConfigDoc {
 		// Server
 		{Key: "server.host", Type: "string", Default: "localhost", EnvVar: "", Description: "API server host", Category: "Server"},
 		{Key: "server.port", Type: "int", Default: "8080", EnvVar: "", Description: "API server port", Category: "Server"},
+		{Key: "server.auth.enabled", Type: "bool", Default: "false", EnvVar: "ORC_AUTH_ENABLED", Description: "Require an API token (orc server token) on mutating requests", Category: "Server"},
+		{Key: "server.auth.require_for_reads", Type: "bool", Default: "false", EnvVar: "", Description: "Also require an API token for reads and event streams", Category: "Server"},
+		{Key: "server.read_only", Type: "bool", Default: "false", EnvVar: "ORC_READ_ONLY", Description: "Reject mutating API requests with 403 (dashboard-only instance)", Category: "Server"},
+		{Key: "server.access_log.enabled", Type: "bool", Default: "true", EnvVar: "ORC_ACCESS_LOG", Description: "Log method, path, status, and latency for each HTTP request", Category: "Server"},
+		{Key: "server.access_log.sample_rate", Type: "float", Default: "1.0", EnvVar: "ORC_ACCESS_LOG_SAMPLE", Description: "Fraction of successful requests logged (errors are always logged)", Category: "Server"},
+		{Key: "server.tenancy.enabled", Type: "bool", Default: "false", EnvVar: "ORC_TENANCY_ENABLED", Description: "Require tenant API tokens and scope each tenant to its own projects", Category: "Server"},
+		{Key: "server.ha.enabled", Type: "bool", Default: "false", EnvVar: "ORC_HA_ENABLED", Description: "Run as one of several server instances sharing the database, with leader election", Category: "Server"},
+		{Key: "server.ha.instance_id", Type: "string", Default: "hostname:pid", EnvVar: "ORC_HA_INSTANCE_ID", Description: "Instance identity used for the leader lease and event relay", Category: "Server"},
+		{Key: "server.ha.lease_ttl", Type: "duration", Default: "15s", Description: "Leader lease lifetime; a standby takes over after it expires", Category: "Server"},
+		{Key: "server.ha.event_poll_interval", Type: "duration", Default: "500ms", Description: "How often events from other instances are relayed to local subscribers", Category: "Server"},
+		{Key: "server.scheduler.max_running_tasks", Type: "int", Default: "0", Description: "Cap on tasks running at once across all projects (0 = unlimited); extra tasks wait in a fair queue", Category: "Server"},
+		{Key: "server.scheduler.policy", Type: "string", Default: "round_robin", Description: "How queued tasks are picked across projects when a run slot frees up: round_robin, weighted", Category: "Server"},
+		{Key: "server.scheduler.project_weights", Type: "map[string]int", Default: "{}", Description: "Per-project slot share for the weighted policy, keyed by project ID (unlisted projects weigh 1)", Category: "Server"},
+		{Key: "server.socket.enabled", Type: "bool", Default: "true", Description: "Serve the JSON-RPC API on a Unix domain socket for local editors", Category: "Server"},
+		{Key: "server.socket.path", Type: "string", Default: "~/.orc/orc.sock", Description: "Unix socket path for the local JSON-RPC API", Category: "Server"},
+		{Key: "features", Type: "map[string]bool", Default: "{}", EnvVar: "ORC_FEATURE_<NAME>", Description: "Feature flag overrides reported by GET /api/features (automation, high_availability, initiatives, multi_tenant, team_mode)", Category: "Server"},
+
+		// Skills
+		{Key: "skills.index", Type: "string", Default: "", EnvVar: "ORC_SKILLS_INDEX", Description: "Skill index (git repo or index.yaml URL) for orc skills install", Category: "Skills"},
+
+		// Updates
+		{Key: "updates.check", Type: "bool", Default: "true", EnvVar: "ORC_UPDATES_CHECK", Description: "Warn after commands when a newer orc release exists (checked at most daily)", Category: "Updates"},
+		{Key: "updates.feed_url", Type: "string", Default: "", EnvVar: "", Description: "Releases feed in GitHub releases API format (default: orc's GitHub releases)", Category: "Updates"},
+		{Key: "updates.auto_download", Type: "bool", Default: "false", EnvVar: "", Description: "Download and verify newer releases in the background for orc self-update", Category: "Updates"},
+		{Key: "updates.public_key", Type: "string", Default: "", EnvVar: "", Description: "Base64 ed25519 key; when set, releases must have a valid checksums.txt.sig", Category: "Updates"},
+
+		// Backoff
+		{Key: "backoff.max_attempts", Type: "int", Default: "4", EnvVar: "ORC_BACKOFF_MAX_ATTEMPTS", Description: "Tries per external call (hosting API, webhook, model API), including the first", Category: "Backoff"},
+		{Key: "backoff.initial", Type: "duration", Default: "1s", EnvVar: "ORC_BACKOFF_INITIAL", Description: "Delay before the first retry; also the first CI poll interval", Category: "Backoff"},
+		{Key: "backoff.max", Type: "duration", Default: "30s", EnvVar: "ORC_BACKOFF_MAX", Description: "Cap on the delay between retries", Category: "Backoff"},
+		{Key: "backoff.multiplier", Type: "float", Default: "2", EnvVar: "", Description: "Growth factor between consecutive retry delays", Category: "Backoff"},
+		{Key: "backoff.jitter", Type: "float", Default: "0.2", EnvVar: "", Description: "Randomize each delay by up to this fraction (0-1) so clients do not retry in lockstep", Category: "Backoff"},
+
+		// Webhooks
+		{Key: "webhooks.timeout", Type: "duration", Default: "10s", EnvVar: "", Description: "Time limit per webhook delivery attempt (endpoints: see webhooks.endpoints or /api/webhooks)", Category: "Webhooks"},
+
+		// Documentation
+		{Key: "documentation.drift_check.enabled", Type: "bool", Default: "true", EnvVar: "", Description: "Periodically compare managed CLAUDE.md sections with the code (orc serve)", Category: "Documentation"},
+		{Key: "documentation.drift_check.interval", Type: "duration", Default: "6h", EnvVar: "", Description: "Time between CLAUDE.md drift checks", Category: "Documentation"},
+		{Key: "documentation.drift_check.threshold", Type: "int", Default: "5", EnvVar: "", Description: "Drift score that triggers a docs refresh task", Category: "Documentation"},
+		{Key: "documentation.drift_check.create_task", Type: "bool", Default: "false", EnvVar: "", Description: "Create an automation task to refresh CLAUDE.md when the threshold is reached", Category: "Documentation"},
+		{Key: "documentation.drift_check.template", Type: "string", Default: "", EnvVar: "", Description: "Automation template for the refresh task (empty = built-in)", Category: "Documentation"},
+
+		// Storage
+		{Key: "storage.mode", Type: "string", Default: "database", EnvVar: "ORC_STORAGE_MODE", Description: "Storage mode: database, hybrid or files (task directories need storage.files.write_through)", Category: "Storage"},
+		{Key: "storage.files.write_through", Type: "bool", Default: "false", EnvVar: "ORC_STORAGE_FILES_WRITE_THROUGH", Description: "Write task directories under .orc/tasks/ in hybrid and files mode", Category: "Storage"},
+		{Key: "storage.files.cleanup_on_complete", Type: "bool", Default: "true", EnvVar: "ORC_STORAGE_FILES_CLEANUP", Description: "Remove a task's directory when it completes (hybrid mode)", Category: "Storage"},
+		{Key: "storage.database.retention_days", Type: "int", Default: "90", EnvVar: "ORC_STORAGE_DB_RETENTION_DAYS", Description: "Days to keep transcripts and event log entries before pruning (0 = keep forever)", Category: "Storage"},
+		{Key: "storage.database.maintenance_interval", Type: "duration", Default: "24h", EnvVar: "ORC_STORAGE_DB_MAINTENANCE_INTERVAL", Description: "Time between database maintenance runs in orc serve (0 = disabled)", Category: "Storage"},
 	}
 }
 
@@ -655,6 +808,7 @@ Examples:
   orc config commands list                      # Same as above
   orc config commands set tests "npm test"      # Set test command
   orc config commands set lint "golangci-lint run" --domain go
+  orc config commands set tests "npm test" --path web   # Per-directory profile
   orc config commands enable typecheck          # Enable a command
   orc config commands disable build             # Disable a command
   orc config commands delete custom_check       # Delete a command`,
@@ -717,7 +871,11 @@ func runConfigCommandsList(cmd *cobra.Command) error {
 		if c.Domain != "" {
 			domain = fmt.Sprintf(" [%s]", c.Domain)
 		}
-		_, _ = fmt.Fprintf(out, "  %s %-12s%s: %s\n", status, c.Name, domain, c.Command)
+		dir := ""
+		if c.Path != "" {
+			dir = fmt.Sprintf(" (%s/)", c.Path)
+		}
+		_, _ = fmt.Fprintf(out, "  %s %-12s%s%s: %s\n", status, c.Name, domain, dir, c.Command)
 	}
 
 	_, _ = fmt.Fprintln(out, "")
@@ -728,7 +886,7 @@ func runConfigCommandsList(cmd *cobra.Command) error {
 
 // newConfigCommandsSetCmd creates the 'config commands set' subcommand.
 func newConfigCommandsSetCmd() *cobra.Command {
-	var domain string
+	var domain, dir string
 
 	cmd := &cobra.Command{
 		Use:   "set <name> <command>",
@@ -738,8 +896,14 @@ func newConfigCommandsSetCmd() *cobra.Command {
 Standard command names: tests, lint, build, typecheck
 Custom commands can also be created for use with custom quality checks.
 
+With --path the command becomes a profile for that subdirectory of a
+monorepo. When every file a task changed is under a profile, quality checks
+run the most specific profile's command from its directory instead of the
+whole-repository command.
+
 Examples:
   orc config commands set tests "npm test"
+  orc config commands set tests "go test ./..." --path services/api
   orc config commands set lint "golangci-lint run ./..."
   orc config commands set typecheck "npx tsc --noEmit"
   orc config commands set custom_check "./scripts/validate.sh" --domain scripts`,
@@ -756,6 +920,7 @@ Examples:
 
 			projectCmd := &db.ProjectCommand{
 				Name:    name,
+				Path:    dir,
 				Command: command,
 				Domain:  domain,
 				Enabled: true,
@@ -771,13 +936,15 @@ Examples:
 	}
 
 	cmd.Flags().StringVar(&domain, "domain", "", "Optional domain for the command (e.g., go, node, python)")
+	cmd.Flags().StringVar(&dir, "path", "", "Subdirectory the command applies to (per-directory profile)")
 
 	return cmd
 }
 
 // newConfigCommandsEnableCmd creates the 'config commands enable' subcommand.
 func newConfigCommandsEnableCmd() *cobra.Command {
-	return &cobra.Command{
+	var dir string
+	cmd := &cobra.Command{
 		Use:   "enable <name>",
 		Short: "Enable a project command",
 		Long: `Enable a previously disabled project command.
@@ -795,7 +962,12 @@ Example:
 			}
 			defer func() { _ = backend.Close() }()
 
-			if err := backend.SetProjectCommandEnabled(name, true); err != nil {
+			if dir != "" {
+				err = backend.DB().SetProjectCommandEnabledAt(name, "", dir, true)
+			} else {
+				err = backend.SetProjectCommandEnabled(name, true)
+			}
+			if err != nil {
 				return fmt.Errorf("enable command: %w", err)
 			}
 
@@ -803,11 +975,14 @@ Example:
 			return nil
 		},
 	}
+	cmd.Flags().StringVar(&dir, "path", "", "Subdirectory of the per-directory profile")
+	return cmd
 }
 
 // newConfigCommandsDisableCmd creates the 'config commands disable' subcommand.
 func newConfigCommandsDisableCmd() *cobra.Command {
-	return &cobra.Command{
+	var dir string
+	cmd := &cobra.Command{
 		Use:   "disable <name>",
 		Short: "Disable a project command",
 		Long: `Disable a project command without deleting it.
@@ -827,7 +1002,12 @@ Example:
 			}
 			defer func() { _ = backend.Close() }()
 
-			if err := backend.SetProjectCommandEnabled(name, false); err != nil {
+			if dir != "" {
+				err = backend.DB().SetProjectCommandEnabledAt(name, "", dir, false)
+			} else {
+				err = backend.SetProjectCommandEnabled(name, false)
+			}
+			if err != nil {
 				return fmt.Errorf("disable command: %w", err)
 			}
 
@@ -835,11 +1015,14 @@ Example:
 			return nil
 		},
 	}
+	cmd.Flags().StringVar(&dir, "path", "", "Subdirectory of the per-directory profile")
+	return cmd
 }
 
 // newConfigCommandsDeleteCmd creates the 'config commands delete' subcommand.
 func newConfigCommandsDeleteCmd() *cobra.Command {
-	return &cobra.Command{
+	var dir string
+	cmd := &cobra.Command{
 		Use:   "delete <name>",
 		Short: "Delete a project command",
 		Long: `Delete a project command from the database.
@@ -859,7 +1042,12 @@ Example:
 			}
 			defer func() { _ = backend.Close() }()
 
-			if err := backend.DeleteProjectCommand(name); err != nil {
+			if dir != "" {
+				err = backend.DB().DeleteProjectCommandAt(name, "", dir)
+			} else {
+				err = backend.DeleteProjectCommand(name)
+			}
+			if err != nil {
 				return fmt.Errorf("delete command: %w", err)
 			}
 
@@ -867,4 +1055,6 @@ Example:
 			return nil
 		},
 	}
+	cmd.Flags().StringVar(&dir, "path", "", "Subdirectory of the per-directory profile")
+	return cmd
 }
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/config"
+)
+
+// newConfigProfilesCmd creates the 'config profiles' subcommand.
+func newConfigProfilesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "profiles",
+		Short: "List built-in and named config profiles",
+		Long: `List the built-in automation profiles and the named presets in
+~/.orc/profiles/*.yaml.
+
+A named preset is a partial config.yaml with an optional description:
+
+  # ~/.orc/profiles/careful.yaml
+  description: Strict gates and an opus model for risky changes
+  profile: strict
+  model: opus
+  validation:
+    enabled: true
+
+A preset that sets profile applies that automation profile first, then its
+own keys on top.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profiles, err := config.ListProfiles()
+			if err != nil {
+				return err
+			}
+			if jsonOut {
+				return outputJSON(cmd, profiles)
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "NAME\tTYPE\tKEYS\tDESCRIPTION")
+			for _, p := range profiles {
+				kind, keys := "built-in", "-"
+				if !p.BuiltIn {
+					kind = "preset"
+					keys = strings.Join(p.Keys, ", ")
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", p.Name, kind, keys, p.Description)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+// newConfigApplyProfileCmd creates the 'config apply-profile' subcommand.
+func newConfigApplyProfileCmd() *cobra.Command {
+	var (
+		applyProject bool
+		applyShared  bool
+		applyUser    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "apply-profile <name>",
+		Short: "Write a built-in or named profile into a config file",
+		Long: `Apply a profile to a config file so every run uses it.
+
+<name> is a built-in automation profile (auto, fast, safe, strict) or a
+named preset from ~/.orc/profiles/<name>.yaml. To use a profile for a
+single run instead, pass it to orc run --profile.
+
+By default, the profile is saved to the project config (.orc/config.yaml):
+
+  --project  Save to .orc/config.yaml (default)
+  --shared   Save to .orc/shared/config.yaml
+  --user     Save to ~/.orc/config.yaml
+
+Examples:
+  orc config apply-profile strict
+  orc config apply-profile careful --shared`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, err := config.LoadNamedProfile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var targetPath, targetName string
+			switch {
+			case applyUser:
+				home, err := os.UserHomeDir()
+				if err != nil {
+					return fmt.Errorf("get home directory: %w", err)
+				}
+				targetPath = filepath.Join(home, ".orc", config.ConfigFileName)
+				targetName = "~/.orc/config.yaml"
+			case applyShared:
+				projectRoot, err := ResolveProjectPath()
+				if err != nil {
+					return err
+				}
+				targetPath = filepath.Join(projectRoot, config.OrcDir, "shared", config.ConfigFileName)
+				targetName = ".orc/shared/config.yaml"
+			default:
+				projectRoot, err := ResolveProjectPath()
+				if err != nil {
+					return err
+				}
+				targetPath = filepath.Join(projectRoot, config.OrcDir, config.ConfigFileName)
+				targetName = ".orc/config.yaml"
+			}
+
+			cfg, err := config.LoadFile(targetPath)
+			if err != nil {
+				return fmt.Errorf("load config from %s: %w", targetPath, err)
+			}
+			profile.Apply(cfg)
+			if err := cfg.SaveTo(targetPath); err != nil {
+				return fmt.Errorf("save config: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Applied profile %s to %s\n", profile.Name, targetName)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&applyProject, "project", false, "Save to project config (.orc/config.yaml)")
+	cmd.Flags().BoolVar(&applyShared, "shared", false, "Save to shared config (.orc/shared/config.yaml)")
+	cmd.Flags().BoolVar(&applyUser, "user", false, "Save to user config (~/.orc/config.yaml)")
+	cmd.MarkFlagsMutuallyExclusive("project", "shared", "user")
+
+	return cmd
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+)
+
+// withProfileTestProject sets up a temp HOME holding a "careful" preset and
+// a project with an empty config, and returns the project config path.
+func withProfileTestProject(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("ORC_PROJECT_ROOT", "")
+
+	profilesDir := filepath.Join(home, ".orc", config.ProfilesDirName)
+	if err := os.MkdirAll(profilesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	preset := "description: Strict gates with opus\nprofile: strict\nmodel: opus\n"
+	if err := os.WriteFile(filepath.Join(profilesDir, "careful.yaml"), []byte(preset), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	projectDir := withTempDir(t)
+	configPath := filepath.Join(projectDir, ".orc", config.ConfigFileName)
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, []byte("version: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return configPath
+}
+
+func TestConfigApplyProfileCmd_WritesPresetToProject(t *testing.T) {
+	configPath := withProfileTestProject(t)
+
+	var buf bytes.Buffer
+	cmd := newConfigApplyProfileCmd()
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"careful"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("config apply-profile: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Applied profile careful to .orc/config.yaml") {
+		t.Errorf("output = %q", buf.String())
+	}
+
+	cfg, err := config.LoadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Profile != config.ProfileStrict || cfg.Model != "opus" {
+		t.Errorf("profile = %q, model = %q", cfg.Profile, cfg.Model)
+	}
+	if cfg.Gates.PhaseOverrides["spec"] != "human" {
+		t.Errorf("gates.phase_overrides = %v, want strict presets", cfg.Gates.PhaseOverrides)
+	}
+}
+
+func TestConfigApplyProfileCmd_UnknownProfile(t *testing.T) {
+	withProfileTestProject(t)
+
+	cmd := newConfigApplyProfileCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"reckless"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "profile not found") {
+		t.Errorf("err = %v, want profile not found", err)
+	}
+}
+
+func TestConfigProfilesCmd_JSONOutput(t *testing.T) {
+	withProfileTestProject(t)
+
+	oldJSON := jsonOut
+	jsonOut = true
+	defer func() { jsonOut = oldJSON }()
+
+	cmd := newConfigProfilesCmd()
+	cmd.SetArgs([]string{})
+	output := captureStdout(t, func() {
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("config profiles --json: %v", err)
+		}
+	})
+
+	var profiles []config.NamedProfile
+	if err := json.Unmarshal([]byte(output), &profiles); err != nil {
+		t.Fatalf("JSON parse failed: %v\nOutput: %s", err, output)
+	}
+	if len(profiles) != 5 || profiles[4].Name != "careful" || profiles[4].BuiltIn {
+		t.Errorf("profiles = %+v", profiles)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
 	"github.com/randalmurphal/orc/internal/db"
 )
 
@@ -37,7 +38,8 @@ Examples:
   orc costs --by provider         # Group by provider
   orc costs --user alice         # Filter to specific user
   orc costs --since 2026-01-01   # Filter by date
-  orc costs --project proj-orc   # Filter to specific project`,
+  orc costs --project proj-orc   # Filter to specific project
+  orc costs --json               # GetCostReportResponse (grouped by project unless --by)`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runCosts(cmd, userFilter, projectFilter, sinceFilter, byFilter)
 		},
@@ -87,6 +89,20 @@ func runCosts(cmd *cobra.Command, userFilter, projectFilter, sinceFilter, groupB
 		Since:     sinceTime,
 	}
 
+	if jsonOut {
+		// One GetCostReportResponse, as the API returns it; the default
+		// grouping is by project.
+		if groupBy == "" {
+			groupBy = "project"
+		}
+		filter.GroupBy = groupBy
+		result, err := gdb.GetCostReport(filter)
+		if err != nil {
+			return fmt.Errorf("get cost report: %w", err)
+		}
+		return outputJSON(cmd, costReportProto(result, gdb, projectFilter))
+	}
+
 	if groupBy == "" {
 		return displayDefaultCostReport(cmd, gdb, filter)
 	}
@@ -197,6 +213,22 @@ func resolveUserNames(gdb *db.GlobalDB, breakdowns []db.CostBreakdownEntry) map[
 	return names
 }
 
+// costReportProto converts a cost report to the API's response message,
+// with budget figures when filtered to a project.
+func costReportProto(result db.CostReportResult, gdb *db.GlobalDB, projectID string) *orcv1.GetCostReportResponse {
+	resp := &orcv1.GetCostReportResponse{TotalCostUsd: result.TotalCostUSD}
+	for _, b := range result.Breakdowns {
+		resp.Breakdowns = append(resp.Breakdowns, &orcv1.CostBreakdown{Key: b.Key, CostUsd: b.CostUSD})
+	}
+	if projectID != "" {
+		if status, err := gdb.GetBudgetStatus(projectID); err == nil && status != nil {
+			resp.BudgetLimitUsd = &status.MonthlyLimitUSD
+			resp.BudgetPercentUsed = &status.PercentUsed
+		}
+	}
+	return resp
+}
+
 func displayBudgetStatus(out io.Writer, gdb *db.GlobalDB, projectID string) {
 	status, err := gdb.GetBudgetStatus(projectID)
 	if err != nil {
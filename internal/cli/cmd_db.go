@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+// newDBCmd creates the db command.
+func newDBCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Database maintenance",
+		Long: `Inspect and maintain orc's SQLite databases.
+
+Commands:
+  maintain   Check integrity, prune old rows, VACUUM and ANALYZE`,
+	}
+	cmd.AddCommand(newDBMaintainCmd())
+	return cmd
+}
+
+func newDBMaintainCmd() *cobra.Command {
+	var noVacuum, global bool
+	var retentionDays int
+
+	cmd := &cobra.Command{
+		Use:   "maintain",
+		Short: "Check integrity, prune old rows, VACUUM and ANALYZE",
+		Long: `Maintain the project database (.orc/orc.db):
+
+  1. PRAGMA integrity_check
+  2. FTS5 integrity check of every full-text index (corrupt indexes are rebuilt)
+  3. Delete transcripts and event log entries older than
+     storage.database.retention_days (default 90; 0 keeps everything)
+  4. VACUUM to reclaim free pages, then ANALYZE to refresh query statistics
+
+orc serve runs the same job every storage.database.maintenance_interval
+(default 24h). VACUUM blocks writers while it runs; use --no-vacuum on a busy
+database.
+
+Exits non-zero when integrity problems remain.
+
+Examples:
+  orc db maintain                     # Maintain the project database
+  orc db maintain --global            # Also maintain ~/.orc/orc.db
+  orc db maintain --retention-days 30 # Prune more aggressively this time
+  orc db maintain --no-vacuum --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRoot, err := ResolveProjectPath()
+			if err != nil {
+				return err
+			}
+			if err := config.RequireInitAt(projectRoot); err != nil {
+				return err
+			}
+
+			if !cmd.Flags().Changed("retention-days") {
+				cfg, err := config.LoadFrom(projectRoot)
+				if err != nil {
+					cfg = config.Default()
+				}
+				retentionDays = cfg.Storage.Database.RetentionDays
+			}
+			opts := db.MaintenanceOptions{RetentionDays: retentionDays, SkipVacuum: noVacuum}
+			ctx := cmd.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			var reports []*db.MaintenanceReport
+			pdb, err := db.OpenProject(projectRoot)
+			if err != nil {
+				return fmt.Errorf("open database: %w", err)
+			}
+			report, err := pdb.Maintain(ctx, opts)
+			_ = pdb.Close()
+			if err != nil {
+				return fmt.Errorf("maintain project database: %w", err)
+			}
+			reports = append(reports, report)
+
+			if global {
+				err := withGlobalDB(func(g *db.GlobalDB) error {
+					report, err := g.Maintain(ctx, db.MaintenanceOptions{SkipVacuum: noVacuum})
+					if err != nil {
+						return fmt.Errorf("maintain global database: %w", err)
+					}
+					reports = append(reports, report)
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			if jsonOut {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(reports); err != nil {
+					return err
+				}
+			} else {
+				for i, r := range reports {
+					if i > 0 {
+						fmt.Println()
+					}
+					printMaintenanceReport(r)
+				}
+			}
+
+			for _, r := range reports {
+				if !r.Healthy() {
+					return fmt.Errorf("integrity problems found in %s", r.Path)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&noVacuum, "no-vacuum", false, "skip VACUUM (still runs checks, pruning, and ANALYZE)")
+	cmd.Flags().BoolVar(&global, "global", false, "also maintain the global database (~/.orc/orc.db)")
+	cmd.Flags().IntVar(&retentionDays, "retention-days", 0, "override storage.database.retention_days for this run (0 = no pruning)")
+	return cmd
+}
+
+func printMaintenanceReport(r *db.MaintenanceReport) {
+	fmt.Println(r.Path)
+	freed := r.Before.Bytes - r.After.Bytes
+	fmt.Printf("  Size:       %s -> %s (freed %s)\n", formatByteSize(r.Before.Bytes), formatByteSize(r.After.Bytes), formatByteSize(max(freed, 0)))
+	if r.After.WALBytes > 0 {
+		fmt.Printf("  WAL:        %s\n", formatByteSize(r.After.WALBytes))
+	}
+	fmt.Printf("  Integrity:  %s\n", strings.Join(r.Integrity, "; "))
+	if len(r.FTS) > 0 {
+		parts := make([]string, 0, len(r.FTS))
+		for _, c := range r.FTS {
+			switch {
+			case c.OK:
+				parts = append(parts, c.Table+" ok")
+			case c.Rebuilt:
+				parts = append(parts, c.Table+" rebuilt")
+			default:
+				parts = append(parts, c.Table+" FAILED")
+			}
+		}
+		fmt.Printf("  FTS:        %s\n", strings.Join(parts, ", "))
+	}
+	if r.PrunedTranscripts > 0 || r.PrunedEvents > 0 || r.PrunedSummaries > 0 {
+		fmt.Printf("  Pruned:     %d transcripts, %d events, %d file summaries\n",
+			r.PrunedTranscripts, r.PrunedEvents, r.PrunedSummaries)
+	}
+	if !r.Vacuumed {
+		fmt.Println("  Vacuum:     skipped")
+	}
+	fmt.Printf("  Duration:   %s\n", r.Duration.Round(time.Millisecond))
+}
+
+// formatByteSize formats n bytes with a binary unit (KiB, MiB, ...).
+func formatByteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDBMaintainCmd(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tmpDir := withTempDir(t)
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".orc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, ".orc", "config.yaml"), []byte("version: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newDBCmd()
+	cmd.SetArgs([]string{"maintain", "--global", "--retention-days", "7"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("db maintain: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".orc", "orc.db")); err != nil {
+		t.Errorf("project database not created: %v", err)
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	tests := map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1536:            "1.5 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+		3 << 30:         "3.0 GiB",
+	}
+	for n, want := range tests {
+		if got := formatByteSize(n); got != want {
+			t.Errorf("formatByteSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+// newDebugCmd creates the debug command
+func newDebugCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Inspect task internals when something went wrong",
+	}
+	cmd.AddCommand(newDebugStateDiffCmd())
+	return cmd
+}
+
+func newDebugStateDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state-diff <task-id> [from] [to]",
+		Short: "Show what changed in a task's execution state between two steps",
+		Long: `The executor snapshots a task's full state (status, current phase, phase
+states, gate decisions, tokens, cost, metadata, ...) every time it persists
+a step. Identical consecutive states are skipped and the newest 500 are kept.
+
+With only a task ID, list its snapshots: sequence number, time, phase,
+iteration, and the step that saved it. With from (and to, default latest),
+show every value that was added, removed, or changed between the two.
+
+from and to take a sequence number, first, latest, or an RFC 3339 time
+(the last snapshot taken at or before it).
+
+Examples:
+  orc debug state-diff TASK-001             # List snapshots
+  orc debug state-diff TASK-001 12 13       # What step 13 changed
+  orc debug state-diff TASK-001 first       # Everything since the first snapshot
+  orc debug state-diff TASK-001 2026-03-01T10:00:00Z latest --json`,
+		Args: cobra.RangeArgs(1, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			backend, err := getBackend()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = backend.Close() }()
+			pdb := backend.DB()
+			taskID := args[0]
+
+			if len(args) == 1 {
+				snapshots, err := pdb.ListStateSnapshots(taskID)
+				if err != nil {
+					return err
+				}
+				if jsonOut {
+					if snapshots == nil {
+						snapshots = []db.StateSnapshot{}
+					}
+					return outputJSON(cmd, snapshots)
+				}
+				printStateSnapshots(cmd.OutOrStdout(), taskID, snapshots)
+				return nil
+			}
+
+			to := "latest"
+			if len(args) == 3 {
+				to = args[2]
+			}
+			diff, err := pdb.DiffStateSnapshots(taskID, args[1], to)
+			if err != nil {
+				return err
+			}
+			if jsonOut {
+				return outputJSON(cmd, diff)
+			}
+			printStateDiff(cmd.OutOrStdout(), diff)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func printStateSnapshots(out io.Writer, taskID string, snapshots []db.StateSnapshot) {
+	if len(snapshots) == 0 {
+		fmt.Fprintf(out, "No state snapshots for %s (they are recorded while the task runs)\n", taskID)
+		return
+	}
+	fmt.Fprintf(out, "%5s  %-20s  %-14s  %4s  %s\n", "SEQ", "TIME", "PHASE", "ITER", "STEP")
+	for _, s := range snapshots {
+		fmt.Fprintf(out, "%5d  %-20s  %-14s  %4d  %s\n",
+			s.Seq, s.CreatedAt.Local().Format("2006-01-02 15:04:05"), s.PhaseID, s.Iteration, s.Label)
+	}
+}
+
+func printStateDiff(out io.Writer, diff *db.StateDiff) {
+	fmt.Fprintf(out, "%s: #%d (%s) -> #%d (%s)\n", diff.TaskID, diff.From.Seq, diff.From.Label, diff.To.Seq, diff.To.Label)
+	if len(diff.Changes) == 0 {
+		fmt.Fprintln(out, "No changes")
+		return
+	}
+	fmt.Fprintln(out)
+	for _, c := range diff.Changes {
+		switch c.Kind {
+		case "added":
+			fmt.Fprintf(out, "+ %s = %s\n", c.Path, c.To)
+		case "removed":
+			fmt.Fprintf(out, "- %s (was %s)\n", c.Path, c.From)
+		default:
+			fmt.Fprintf(out, "~ %s: %s -> %s\n", c.Path, c.From, c.To)
+		}
+	}
+}
@@ -59,6 +59,9 @@ Example:
 				return fmt.Errorf("delete task: %w", err)
 			}
 
+			if jsonOut {
+				return outputJSON(cmd, &orcv1.DeleteTaskResponse{Message: fmt.Sprintf("Deleted task %s", taskID)})
+			}
 			if !quiet {
 				fmt.Printf("Deleted task %s\n", taskID)
 			}
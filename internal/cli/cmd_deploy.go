@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/deploy"
+	"github.com/randalmurphal/orc/internal/project"
+)
+
+// newDeployCmd creates the deploy command
+func newDeployCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deploy",
+		Short: "Generate deployment files for the orc server",
+		Long: `Generate deployment files for running the orc server.
+
+Commands:
+  generate   Write a Dockerfile and compose file (--docker)`,
+	}
+	cmd.AddCommand(newDeployGenerateCmd())
+	return cmd
+}
+
+func newDeployGenerateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate --docker",
+		Short: "Write a Dockerfile, compose file and .env for 'orc serve'",
+		Long: `Write a Dockerfile, compose.yaml and .env that run 'orc serve' in a container.
+
+The image installs orc (this release, or the latest one for development
+builds), git and the claude CLI. Its user mirrors yours (UID, GID and home
+directory), and ~/.orc plus every mounted project appear at the same paths
+as on this machine, so the project registry, worktrees and file ownership
+all keep working. ~/.gitconfig and ~/.ssh are mounted read-only when present.
+
+.env holds ANTHROPIC_API_KEY and the hosting token; fill it in before
+starting. It is created with mode 0600 and never overwritten.
+
+With --postgres, compose also runs Postgres and the server uses it as its
+shared database (database.dialect: postgres, server.ha.enabled), so more orc
+replicas can be added later. This needs user.name to be set.
+
+Examples:
+  orc deploy generate --docker                       # Current project, into ~/.orc/deploy
+  orc deploy generate --docker --all-projects        # Mount every registered project
+  orc deploy generate --docker --postgres -o deploy  # With Postgres, into ./deploy
+  cd ~/.orc/deploy && docker compose up -d --build`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if docker, _ := cmd.Flags().GetBool("docker"); !docker {
+				return fmt.Errorf("choose a target: --docker")
+			}
+			opts, err := deployDockerOptions(cmd)
+			if err != nil {
+				return err
+			}
+			files, err := deploy.GenerateDocker(opts)
+			if err != nil {
+				return err
+			}
+
+			outDir, _ := cmd.Flags().GetString("output")
+			if outDir == "" {
+				outDir = filepath.Join(opts.HomeDir, ".orc", "deploy")
+			}
+			if outDir, err = filepath.Abs(config.ExpandPath(outDir)); err != nil {
+				return err
+			}
+			force, _ := cmd.Flags().GetBool("force")
+			written, kept, err := deploy.Write(outDir, files, force)
+			if err != nil {
+				return err
+			}
+
+			w := cmd.OutOrStdout()
+			for _, name := range written {
+				_, _ = fmt.Fprintf(w, "Wrote %s\n", filepath.Join(outDir, name))
+			}
+			for _, name := range kept {
+				_, _ = fmt.Fprintf(w, "Kept existing %s\n", filepath.Join(outDir, name))
+			}
+			_, _ = fmt.Fprintf(w, "\nMounted projects:\n")
+			for _, p := range opts.Projects {
+				_, _ = fmt.Fprintf(w, "  %s\n", p)
+			}
+			_, _ = fmt.Fprintf(w, "\nNext:\n")
+			_, _ = fmt.Fprintf(w, "  1. Set ANTHROPIC_API_KEY and the hosting token in %s\n", filepath.Join(outDir, deploy.EnvName))
+			_, _ = fmt.Fprintf(w, "  2. cd %s && docker compose up -d --build\n", outDir)
+			_, _ = fmt.Fprintf(w, "  3. Open http://localhost:%d (readiness: /api/health/ready)\n", opts.Port)
+			return nil
+		},
+	}
+	cmd.Flags().Bool("docker", false, "generate a Dockerfile and compose file")
+	cmd.Flags().StringP("output", "o", "", "directory to write to (default: ~/.orc/deploy)")
+	cmd.Flags().IntP("port", "p", 0, "port to serve and publish (default: server.port, or 8080)")
+	cmd.Flags().StringSlice("mount", nil, "additional project (ID or path) to mount; repeatable")
+	cmd.Flags().Bool("all-projects", false, "mount every registered project")
+	cmd.Flags().Bool("postgres", false, "add a Postgres service as the shared database")
+	cmd.Flags().Bool("force", false, "replace an existing Dockerfile and compose file")
+	return cmd
+}
+
+// deployDockerOptions collects the deployment from the current project,
+// the registry, config and the host user.
+func deployDockerOptions(cmd *cobra.Command) (deploy.DockerOptions, error) {
+	projectRoot, err := ResolveProjectPath()
+	if err != nil {
+		return deploy.DockerOptions{}, withExitCode(ExitNotInitialized, fmt.Errorf("not in an orc project (run 'orc init' first): %w", err))
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return deploy.DockerOptions{}, err
+	}
+	cfg, err := config.LoadFrom(projectRoot)
+	if err != nil {
+		cfg = config.Default()
+	}
+
+	opts := deploy.DockerOptions{
+		Version: version,
+		HomeDir: home,
+		UID:     os.Getuid(),
+		GID:     os.Getgid(),
+	}
+	if opts.Port, _ = cmd.Flags().GetInt("port"); opts.Port == 0 {
+		opts.Port = cfg.Server.Port
+	}
+	if opts.Port <= 0 {
+		opts.Port = 8080
+	}
+	if opts.Postgres, _ = cmd.Flags().GetBool("postgres"); opts.Postgres {
+		opts.UserName = cfg.User.Name
+	}
+
+	var others []string
+	mounts, _ := cmd.Flags().GetStringSlice("mount")
+	allProjects, _ := cmd.Flags().GetBool("all-projects")
+	if len(mounts) > 0 || allProjects {
+		reg, err := project.LoadRegistry()
+		if err != nil {
+			return opts, fmt.Errorf("load project registry: %w", err)
+		}
+		if allProjects {
+			for _, p := range reg.ValidProjects() {
+				others = append(others, p.Path)
+			}
+		}
+		for _, m := range mounts {
+			if p, err := reg.Get(m); err == nil {
+				others = append(others, p.Path)
+				continue
+			}
+			abs, err := filepath.Abs(config.ExpandPath(m))
+			if err != nil {
+				return opts, err
+			}
+			if info, err := os.Stat(abs); err != nil || !info.IsDir() {
+				return opts, fmt.Errorf("--mount %s: not a registered project or directory", m)
+			}
+			others = append(others, abs)
+		}
+	}
+	opts.Projects = deploy.SortedProjects(projectRoot, others)
+
+	for _, p := range opts.Projects {
+		if strings.HasPrefix(p+string(filepath.Separator), filepath.Join(home, ".orc")+string(filepath.Separator)) {
+			return opts, fmt.Errorf("project %s is inside ~/.orc, which is already mounted", p)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(home, ".gitconfig")); err == nil && !info.IsDir() {
+		opts.GitConfig = filepath.Join(home, ".gitconfig")
+	}
+	if info, err := os.Stat(filepath.Join(home, ".ssh")); err == nil && info.IsDir() {
+		opts.SSHDir = filepath.Join(home, ".ssh")
+	}
+	return opts, nil
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDeployGenerateCommand(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := withStatusTestDir(t)
+	if err := os.WriteFile(filepath.Join(dir, ".orc", "config.yaml"), []byte("version: 1\nserver:\n  port: 9191\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := newDeployCmd()
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(append([]string{"generate"}, args...))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	if _, err := run(); err == nil || !strings.Contains(err.Error(), "--docker") {
+		t.Fatalf("generate without a target: err = %v", err)
+	}
+
+	outDir := filepath.Join(t.TempDir(), "deploy")
+	if _, err := run("--docker", "-o", outDir); err != nil {
+		t.Fatalf("generate --docker: %v", err)
+	}
+	compose, err := os.ReadFile(filepath.Join(outDir, "compose.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"9191:9191"`, dir + ":" + dir} {
+		if !strings.Contains(string(compose), want) {
+			t.Errorf("compose.yaml missing %q:\n%s", want, compose)
+		}
+	}
+
+	if _, err := run("--docker", "-o", outDir); err == nil {
+		t.Error("regenerating without --force should fail")
+	}
+	if _, err := run("--docker", "-o", outDir, "--postgres"); err == nil || !strings.Contains(err.Error(), "user.name") {
+		t.Errorf("--postgres without user.name: err = %v", err)
+	}
+}
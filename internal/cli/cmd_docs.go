@@ -2,12 +2,15 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/randalmurphal/orc/internal/bootstrap"
 	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/docdrift"
 )
 
 // newDocsCmd creates the docs command
@@ -24,6 +27,7 @@ to your CLAUDE.md file. These sections are NOT automatically injected during
 Subcommands:
   inject     Add the orc workflow documentation section
   status     Check which sections are present
+  drift      Compare managed sections with the current code
 
 The injected sections are marked with HTML comments (<!-- orc:begin --> etc.)
 so they can be identified and updated.`,
@@ -31,6 +35,7 @@ so they can be identified and updated.`,
 
 	cmd.AddCommand(newDocsInjectCmd())
 	cmd.AddCommand(newDocsStatusCmd())
+	cmd.AddCommand(newDocsDriftCmd())
 
 	return cmd
 }
@@ -108,3 +113,87 @@ Example:
 
 	return cmd
 }
+
+// newDocsDriftCmd creates the docs drift subcommand
+func newDocsDriftCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drift",
+		Short: "Compare managed CLAUDE.md sections with the code",
+		Long: `Compare the managed auto-sections of CLAUDE.md with the current codebase.
+
+Sections are wrapped in <!-- orc:auto:<name> --> and <!-- /orc:auto:<name> -->
+markers. The api-endpoints section is compared with route registrations in
+source files, and the commands section with Makefile targets and package.json
+scripts. Configured sections without a checker are skipped.
+
+Stale entries are documented but no longer exist; undocumented entries exist
+in the code but are missing from the section. The server runs the same check
+on a schedule (documentation.drift_check).
+
+Exits with status 1 when drift reaches documentation.drift_check.threshold.
+
+Example:
+  orc docs drift
+  orc docs drift --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.RequireInit(); err != nil {
+				return err
+			}
+
+			projectRoot, err := ResolveProjectPath()
+			if err != nil {
+				return err
+			}
+			cfg, err := config.LoadFrom(projectRoot)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+
+			report, err := docdrift.Check(projectRoot, cfg.Documentation.Sections)
+			if err != nil {
+				return err
+			}
+
+			if jsonOut {
+				if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
+					return err
+				}
+			} else {
+				printDriftReport(report)
+			}
+
+			threshold := cfg.Documentation.DriftCheck.Threshold
+			if report.Drifted() && (threshold <= 0 || report.Score >= threshold) {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("CLAUDE.md drift score %d reaches threshold %d", report.Score, threshold)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func printDriftReport(report *docdrift.Report) {
+	if len(report.Sections) == 0 {
+		fmt.Println("No checkable managed sections configured.")
+		return
+	}
+	for _, s := range report.Sections {
+		switch {
+		case !s.Present:
+			fmt.Printf("  %-16s not present\n", s.Name)
+		case s.Drift() == 0:
+			fmt.Printf("  %-16s in sync\n", s.Name)
+		default:
+			fmt.Printf("  %-16s %d stale, %d undocumented\n", s.Name, len(s.Stale), len(s.Undocumented))
+			for _, e := range s.Stale {
+				fmt.Printf("      - %s\n", e)
+			}
+			for _, e := range s.Undocumented {
+				fmt.Printf("      + %s\n", e)
+			}
+		}
+	}
+	fmt.Printf("Drift score: %d\n", report.Score)
+}
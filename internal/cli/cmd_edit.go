@@ -170,7 +170,7 @@ Example:
 			if workflowChanged {
 				if newWorkflow != "" {
 					// Verify workflow exists
-					dbBackend, ok := backend.(*storage.DatabaseBackend)
+					dbBackend, ok := storage.DatabaseOf(backend)
 					if !ok {
 						return fmt.Errorf("workflow validation requires database backend")
 					}
@@ -258,6 +258,15 @@ Example:
 					changes = append(changes, "target_branch")
 				}
 			}
+			// Re-check the target branch when it or the workflow (which decides
+			// the completion action) changed
+			if targetBranchChanged || workflowChanged {
+				if targetBranch := task.GetTargetBranchProto(t); targetBranch != "" {
+					if err := validateTaskTargetBranch(task.GetWorkflowIDProto(t), targetBranch); err != nil {
+						return err
+					}
+				}
+			}
 
 			// Update branch name if flag was provided (even if empty, to allow clearing)
 			oldBranchName := ""
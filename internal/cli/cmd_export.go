@@ -238,7 +238,7 @@ func exportToBranchDir(taskID string, withState, withTranscripts, withContext bo
 	defer func() { _ = backend.Close() }()
 
 	// Create export service
-	exportSvc := storage.NewExportService(backend, &cfg.Storage)
+	exportSvc := storage.NewExportService(backend, projectRoot, &cfg.Storage)
 
 	// Build export options
 	opts := &storage.ExportOptions{
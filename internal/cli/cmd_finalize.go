@@ -178,7 +178,8 @@ Example:
 						disp.Warning(fmt.Sprintf("failed to save task on interrupt: %v", saveErr))
 					}
 					disp.TaskInterrupted()
-					return nil // Clean interrupt
+					cmd.SilenceErrors = true
+					return withExitCode(ExitInterrupted, ctx.Err()) // Clean interrupt
 				}
 
 				// Check if task is blocked (phases succeeded but completion failed)
@@ -190,11 +191,14 @@ Example:
 					}
 					blockedCtx := buildBlockedContextProto(t, cfg, projectRoot)
 					disp.TaskBlockedWithContext(task.GetTotalTokensProto(t), finalizeElapsedProto(t), "sync conflict", blockedCtx)
-					return nil // Not a fatal error - task execution succeeded
+					// Not a fatal error - task execution succeeded, but wrappers
+					// still need to tell blocked apart from done
+					cmd.SilenceErrors = true
+					return withExitCode(ExitTaskBlocked, executor.ErrTaskBlocked)
 				}
 
 				disp.TaskFailed(err)
-				return err
+				return withExitCode(ExitTaskFailed, err)
 			}
 
 			// Reload task for final display (execution state is in task.Execution)
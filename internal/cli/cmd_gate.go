@@ -0,0 +1,221 @@
+// Package cli implements the orc command-line interface.
+package cli
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// newGateCmd creates the gate command for deciding pending human gates.
+func newGateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gate",
+		Short: "List, approve, and reject pending human gates",
+		Long: `List, approve, and reject human gates awaiting a decision.
+
+When a phase with a human gate completes, the executor records a pending
+gate and waits on it. Approving or rejecting it here, in the web UI, or
+through the API lets the waiting executor continue. If no executor is
+waiting (the run was interrupted), approving moves the blocked task to
+planned and rejecting fails it, as with 'orc approve' and 'orc reject'.
+
+Examples:
+  orc gate list
+  orc gate approve TASK-001                     # the task's only pending gate
+  orc gate approve TASK-001 review -c "LGTM"
+  orc gate reject TASK-001 review -c "Missing tests"
+
+See also:
+  orc gates   - Inspect gate configuration per phase`,
+	}
+	cmd.AddCommand(newGateListCmd())
+	cmd.AddCommand(newGateResolveCmd(true))
+	cmd.AddCommand(newGateResolveCmd(false))
+	return cmd
+}
+
+func newGateListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List pending human gates across tasks",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.RequireInit(); err != nil {
+				return err
+			}
+			backend, err := getBackend()
+			if err != nil {
+				return fmt.Errorf("get backend: %w", err)
+			}
+			defer func() { _ = backend.Close() }()
+
+			approvals, err := backend.DB().ListPendingGateApprovals()
+			if err != nil {
+				return err
+			}
+			if jsonOut {
+				if approvals == nil {
+					approvals = []*db.GateApproval{}
+				}
+				return outputJSON(cmd, approvals)
+			}
+			if len(approvals) == 0 {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No pending gates.")
+				return nil
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "TASK\tPHASE\tWAITING\tQUESTION")
+			for _, a := range approvals {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.TaskID, a.Phase, time.Since(a.RequestedAt).Round(time.Second), a.Question)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func newGateResolveCmd(approved bool) *cobra.Command {
+	use, short := "approve", "Approve a task's pending gate"
+	if !approved {
+		use, short = "reject", "Reject a task's pending gate"
+	}
+	cmd := &cobra.Command{
+		Use:   use + " <task-id> [phase]",
+		Short: short,
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.RequireInit(); err != nil {
+				return err
+			}
+			backend, err := getBackend()
+			if err != nil {
+				return fmt.Errorf("get backend: %w", err)
+			}
+			defer func() { _ = backend.Close() }()
+
+			phase := ""
+			if len(args) == 2 {
+				phase = args[1]
+			}
+			comment, _ := cmd.Flags().GetString("comment")
+			approval, err := findPendingGate(backend.DB(), args[0], phase)
+			if err != nil {
+				return err
+			}
+			resolved, err := resolveGateFromCLI(backend, approval, approved, comment)
+			if err != nil {
+				return err
+			}
+
+			if jsonOut {
+				return outputJSON(cmd, resolved)
+			}
+			verb := "approved"
+			if !approved {
+				verb = "rejected"
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Gate %s for %s (phase %s)\n", verb, resolved.TaskID, resolved.Phase)
+			return nil
+		},
+	}
+	cmd.Flags().StringP("comment", "c", "", "comment recorded with the decision")
+	return cmd
+}
+
+// findPendingGate returns the pending gate of a task's phase, or the task's
+// only pending gate when phase is empty.
+func findPendingGate(pdb *db.ProjectDB, taskID, phase string) (*db.GateApproval, error) {
+	if phase != "" {
+		approval, err := pdb.GetOpenGateApproval(taskID, phase)
+		if err != nil {
+			return nil, err
+		}
+		if approval == nil || approval.Status != db.GateApprovalPending {
+			return nil, fmt.Errorf("no pending gate for task %s phase %s", taskID, phase)
+		}
+		return approval, nil
+	}
+
+	pending, err := pdb.ListPendingGateApprovals()
+	if err != nil {
+		return nil, err
+	}
+	var found []*db.GateApproval
+	for _, a := range pending {
+		if a.TaskID == taskID {
+			found = append(found, a)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("no pending gate for task %s", taskID)
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("task %s has %d pending gates; name the phase", taskID, len(found))
+	}
+}
+
+// resolveGateFromCLI approves or rejects a pending gate. A waiting executor
+// picks the decision up; a task blocked at the gate with no executor is
+// moved to planned or failed here.
+func resolveGateFromCLI(backend storage.Backend, approval *db.GateApproval, approved bool, comment string) (*db.GateApproval, error) {
+	pdb := backend.DB()
+	resolved, err := pdb.ResolveGateApproval(approval.ID, approved, comment, "cli")
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := backend.LoadTask(resolved.TaskID)
+	if err != nil || t.Status != orcv1.TaskStatus_TASK_STATUS_BLOCKED || task.GetCurrentPhaseProto(t) != resolved.Phase {
+		return resolved, nil
+	}
+	task.EnsureExecutionProto(t)
+	decision := &orcv1.GateDecision{
+		Phase:     resolved.Phase,
+		GateType:  resolved.GateType,
+		Approved:  approved,
+		Timestamp: timestamppb.Now(),
+	}
+	if comment != "" {
+		decision.Reason = &comment
+	}
+	t.Execution.Gates = append(t.Execution.Gates, decision)
+	if approved {
+		t.Status = orcv1.TaskStatus_TASK_STATUS_PLANNED
+	} else {
+		t.Status = orcv1.TaskStatus_TASK_STATUS_FAILED
+	}
+	if err := backend.SaveTask(t); err != nil {
+		return nil, fmt.Errorf("save task: %w", err)
+	}
+	if err := pdb.MarkGateApprovalApplied(resolved.ID); err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
+
+// settleGateApproval resolves and applies the pending gate of a task's
+// phase, if it has one, for commands that decide a blocked task directly,
+// so resuming the task does not wait on the gate again.
+func settleGateApproval(pdb *db.ProjectDB, taskID, phase string, approved bool, comment string) error {
+	approval, err := pdb.GetOpenGateApproval(taskID, phase)
+	if err != nil || approval == nil {
+		return err
+	}
+	if approval.Status == db.GateApprovalPending {
+		if _, err := pdb.ResolveGateApproval(approval.ID, approved, comment, "cli"); err != nil {
+			return err
+		}
+	}
+	return pdb.MarkGateApprovalApplied(approval.ID)
+}
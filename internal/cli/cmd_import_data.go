@@ -10,6 +10,7 @@ import (
 
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
 	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
 )
 
 // importFileWithMerge imports a task with smart merge logic.
@@ -41,12 +42,6 @@ func importData(data []byte, sourceName string, force, skipExisting bool) error
 		}
 	}
 
-	backend, err := getBackend()
-	if err != nil {
-		return fmt.Errorf("get backend: %w", err)
-	}
-	defer func() { _ = backend.Close() }()
-
 	var export ExportData
 	if err := yaml.Unmarshal(data, &export); err != nil {
 		return fmt.Errorf("parse yaml: %w", err)
@@ -54,6 +49,16 @@ func importData(data []byte, sourceName string, force, skipExisting bool) error
 	if export.Task == nil {
 		return fmt.Errorf("no task found in %s", sourceName)
 	}
+	return importTaskExport(&export, sourceName, force, skipExisting)
+}
+
+// importTaskExport imports a parsed task export with smart merge logic.
+func importTaskExport(export *ExportData, sourceName string, force, skipExisting bool) error {
+	backend, err := getBackend()
+	if err != nil {
+		return fmt.Errorf("get backend: %w", err)
+	}
+	defer func() { _ = backend.Close() }()
 
 	existing, _ := backend.LoadTask(export.Task.Id)
 	if existing != nil {
@@ -88,32 +93,7 @@ func importData(data []byte, sourceName string, force, skipExisting bool) error
 		return fmt.Errorf("save task: %w", err)
 	}
 
-	if len(export.Transcripts) > 0 {
-		existingTranscripts, _ := backend.GetTranscripts(export.Task.Id)
-		transcriptKeys := make(map[string]bool)
-		for _, t := range existingTranscripts {
-			if t.MessageUUID != "" {
-				transcriptKeys[t.MessageUUID] = true
-			}
-		}
-
-		var skipped int
-		for i := range export.Transcripts {
-			t := &export.Transcripts[i]
-			if t.MessageUUID != "" && transcriptKeys[t.MessageUUID] {
-				skipped++
-				continue
-			}
-			if err := backend.AddTranscript(t); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: could not import transcript: %v\n", err)
-			} else if t.MessageUUID != "" {
-				transcriptKeys[t.MessageUUID] = true
-			}
-		}
-		if skipped > 0 {
-			fmt.Fprintf(os.Stderr, "Info: skipped %d duplicate transcript(s)\n", skipped)
-		}
-	}
+	importTranscripts(backend, export.Task.Id, export.Transcripts)
 
 	for i := range export.GateDecisions {
 		if err := backend.SaveGateDecision(&export.GateDecisions[i]); err != nil {
@@ -140,6 +120,11 @@ func importData(data []byte, sourceName string, force, skipExisting bool) error
 			fmt.Fprintf(os.Stderr, "Warning: could not import spec: %v\n", err)
 		}
 	}
+	if export.Plan != nil {
+		if err := backend.SavePlan(export.Plan); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not import plan: %v\n", err)
+		}
+	}
 
 	action := "Imported"
 	if existing != nil {
@@ -153,6 +138,38 @@ func importData(data []byte, sourceName string, force, skipExisting bool) error
 	return nil
 }
 
+// importTranscripts adds transcripts for a task, skipping messages that
+// are already present.
+func importTranscripts(backend storage.Backend, taskID string, transcripts []storage.Transcript) {
+	if len(transcripts) == 0 {
+		return
+	}
+	existingTranscripts, _ := backend.GetTranscripts(taskID)
+	transcriptKeys := make(map[string]bool)
+	for _, t := range existingTranscripts {
+		if t.MessageUUID != "" {
+			transcriptKeys[t.MessageUUID] = true
+		}
+	}
+
+	var skipped int
+	for i := range transcripts {
+		t := &transcripts[i]
+		if t.MessageUUID != "" && transcriptKeys[t.MessageUUID] {
+			skipped++
+			continue
+		}
+		if err := backend.AddTranscript(t); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not import transcript: %v\n", err)
+		} else if t.MessageUUID != "" {
+			transcriptKeys[t.MessageUUID] = true
+		}
+	}
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Info: skipped %d duplicate transcript(s)\n", skipped)
+	}
+}
+
 // importInitiativeData imports an initiative with smart merge logic.
 func importInitiativeData(data []byte, sourceName string, force, skipExisting bool) error {
 	backend, err := getBackend()
@@ -300,7 +317,12 @@ func importWorkflowRunData(data []byte, sourceName string, force, skipExisting b
 	if export.WorkflowRun == nil {
 		return fmt.Errorf("no workflow run found in %s", sourceName)
 	}
+	return importWorkflowRunExport(&export, sourceName, force, skipExisting)
+}
 
+// importWorkflowRunExport imports a parsed workflow run with its phases and
+// outputs.
+func importWorkflowRunExport(export *WorkflowRunExportData, sourceName string, force, skipExisting bool) error {
 	backend, err := getBackend()
 	if err != nil {
 		return fmt.Errorf("get backend: %w", err)
@@ -331,6 +353,12 @@ func importWorkflowRunData(data []byte, sourceName string, force, skipExisting b
 			fmt.Fprintf(os.Stderr, "Warning: could not save workflow run phase %s: %v\n", phase.PhaseTemplateID, err)
 		}
 	}
+	for _, output := range export.Outputs {
+		output.ID = 0 // IDs are local to each database
+		if err := backend.SavePhaseOutput(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not save phase output %s: %v\n", output.PhaseTemplateID, err)
+		}
+	}
 
 	action := "Imported"
 	if existing != nil {
@@ -366,7 +394,7 @@ func importProjectCommandsData(data []byte, sourceName string, force, skipExisti
 
 	var imported, skipped int
 	for _, cmd := range export.Commands {
-		existing, _ := pdb.GetProjectCommand(cmd.Name)
+		existing, _ := pdb.GetProjectCommandAt(cmd.Name, cmd.Scope, cmd.Path)
 		if existing != nil {
 			if skipExisting {
 				skipped++
@@ -14,6 +14,7 @@ import (
 	"github.com/randalmurphal/orc/internal/config"
 	"github.com/randalmurphal/orc/internal/db"
 	"github.com/randalmurphal/orc/internal/detect"
+	"github.com/randalmurphal/orc/internal/onboarding"
 	"github.com/randalmurphal/orc/internal/storage"
 )
 
@@ -93,6 +94,9 @@ func runInstantInit(force bool, profile string) error {
 	}
 
 	bootstrap.PrintResult(result)
+	if cwd, err := os.Getwd(); err == nil {
+		writeOnboardingReport(cwd)
+	}
 	return nil
 }
 
@@ -160,10 +164,37 @@ func runWizardInit(projectPath string, force bool) error {
 
 	// Print success
 	printWizardResult(result, state)
+	writeOnboardingReport(projectPath)
 
 	return nil
 }
 
+// writeOnboardingReport saves .orc/onboarding.md and prints the
+// prerequisites still missing. Failures only warn: the project is
+// initialized either way.
+func writeOnboardingReport(projectPath string) {
+	cfg, err := config.LoadFrom(projectPath)
+	if err != nil {
+		cfg = nil
+	}
+	report, err := onboarding.Generate(projectPath, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not generate onboarding report: %v\n", err)
+		return
+	}
+	path, err := report.Save()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+
+	fmt.Printf("\nOnboarding report: %s\n", path)
+	fmt.Printf("  Recommended profile: %s\n", report.RecommendedProfile)
+	for _, c := range report.Missing() {
+		fmt.Printf("  ✗ %s: %s\n", c.Name, c.Detail)
+	}
+}
+
 // applyPostBootstrapConfig applies wizard configuration to the project config
 func applyPostBootstrapConfig(projectPath string, state *InitWizardState) error {
 	configPath := filepath.Join(projectPath, ".orc", "config.yaml")
@@ -367,6 +398,7 @@ func printWizardResult(result *bootstrap.Result, state *InitWizardState) {
 
 	fmt.Println()
 	fmt.Println("  Next steps:")
+	fmt.Println("    orc tutorial                 # Guided first task")
 	fmt.Println("    orc new \"task description\"  # Create a task")
 	fmt.Println("    orc serve                    # Start web UI")
 	fmt.Println()
@@ -11,10 +11,12 @@ import (
 
 	"github.com/spf13/cobra"
 
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
 	"github.com/randalmurphal/orc/internal/config"
 	"github.com/randalmurphal/orc/internal/executor"
 	"github.com/randalmurphal/orc/internal/git"
 	"github.com/randalmurphal/orc/internal/initiative"
+	"github.com/randalmurphal/orc/internal/storage"
 )
 
 func newInitiativeCmd() *cobra.Command {
@@ -104,7 +106,8 @@ Example:
   orc initiative new "User Authentication System"
   orc initiative new "API Refactor" --vision "Modern REST API with OpenAPI spec"
   orc initiative new "React Migration" --blocked-by INIT-001  # Depends on another initiative
-  orc initiative new "Auth Feature" --branch-base feature/auth --branch-prefix feature/auth-`,
+  orc initiative new "Auth Feature" --branch-base feature/auth --branch-prefix feature/auth-
+  orc initiative new "Search" --budget 50   # Pause its tasks once they spend $50 together`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := config.RequireInit(); err != nil {
@@ -123,6 +126,10 @@ Example:
 			blockedBy, _ := cmd.Flags().GetStringSlice("blocked-by")
 			branchBase, _ := cmd.Flags().GetString("branch-base")
 			branchPrefix, _ := cmd.Flags().GetString("branch-prefix")
+			budget, _ := cmd.Flags().GetFloat64("budget")
+			if budget < 0 {
+				return fmt.Errorf("--budget must be a non-negative amount in USD")
+			}
 
 			// Validate branch names if specified
 			if branchBase != "" {
@@ -185,6 +192,11 @@ Example:
 			if err := backend.SaveInitiative(init); err != nil {
 				return fmt.Errorf("save initiative: %w", err)
 			}
+			if budget > 0 {
+				if err := backend.DB().SetInitiativeBudget(id, budget); err != nil {
+					return fmt.Errorf("save initiative budget: %w", err)
+				}
+			}
 
 			if !quiet {
 				fmt.Printf("Initiative created: %s\n", id)
@@ -202,6 +214,9 @@ Example:
 				if branchPrefix != "" {
 					fmt.Printf("   Branch prefix: %s\n", branchPrefix)
 				}
+				if budget > 0 {
+					fmt.Printf("   Budget: $%.2f\n", budget)
+				}
 				fmt.Println("\nNext steps:")
 				fmt.Printf("  orc initiative add-task %s TASK-XXX  - Link tasks\n", id)
 				fmt.Printf("  orc initiative decide %s \"...\"       - Record decisions\n", id)
@@ -217,6 +232,7 @@ Example:
 	cmd.Flags().StringSlice("blocked-by", nil, "initiative IDs that must complete before this initiative")
 	cmd.Flags().String("branch-base", "", "target branch for tasks in this initiative (e.g., feature/auth)")
 	cmd.Flags().String("branch-prefix", "", "prefix for task branches (e.g., feature/auth-)")
+	cmd.Flags().Float64("budget", 0, "cost budget in USD shared by the initiative's tasks")
 
 	return cmd
 }
@@ -255,7 +271,7 @@ func newInitiativeListCmd() *cobra.Command {
 				initiatives = filtered
 			}
 
-			if len(initiatives) == 0 {
+			if len(initiatives) == 0 && !jsonOut {
 				fmt.Println("No initiatives found.")
 				fmt.Println("\nCreate one with: orc initiative new \"Title\"")
 				return nil
@@ -307,6 +323,14 @@ func newInitiativeListCmd() *cobra.Command {
 			}
 			initiative.PopulateComputedFields(initiatives)
 
+			if jsonOut {
+				protos, err := initiativeProtos(backend, initiatives)
+				if err != nil {
+					return err
+				}
+				return outputJSON(cmd, &orcv1.ListInitiativesResponse{Initiatives: protos})
+			}
+
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 			_, _ = fmt.Fprintln(w, "ID\tTITLE\tSTATUS\tTASKS\tOWNER")
 			_, _ = fmt.Fprintln(w, "--\t-----\t------\t-----\t-----")
@@ -537,6 +561,14 @@ func newInitiativeShowCmd() *cobra.Command {
 			}
 			initiative.PopulateComputedFields(allInits)
 
+			if jsonOut {
+				protos, err := initiativeProtos(backend, []*initiative.Initiative{init})
+				if err != nil {
+					return err
+				}
+				return outputJSON(cmd, &orcv1.GetInitiativeResponse{Initiative: protos[0]})
+			}
+
 			fmt.Printf("Initiative: %s\n", init.ID)
 			fmt.Printf("Title:      %s\n", init.Title)
 
@@ -654,3 +686,26 @@ func newInitiativeShowCmd() *cobra.Command {
 
 	return cmd
 }
+
+// initiativeProtos returns the API form of the given initiatives, in order,
+// with computed fields (Blocks) filled in.
+func initiativeProtos(backend storage.Backend, inits []*initiative.Initiative) ([]*orcv1.Initiative, error) {
+	all, err := backend.LoadAllInitiativesProto()
+	if err != nil {
+		return nil, fmt.Errorf("load initiatives: %w", err)
+	}
+	initiative.PopulateComputedFieldsProto(all)
+	byID := make(map[string]*orcv1.Initiative, len(all))
+	for _, p := range all {
+		byID[p.Id] = p
+	}
+	protos := make([]*orcv1.Initiative, 0, len(inits))
+	for _, init := range inits {
+		p, ok := byID[init.ID]
+		if !ok {
+			return nil, fmt.Errorf("initiative %s not found", init.ID)
+		}
+		protos = append(protos, p)
+	}
+	return protos, nil
+}
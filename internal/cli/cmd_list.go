@@ -35,7 +35,8 @@ Example:
   orc list --initiative INIT-001
   orc list --initiative unassigned
   orc list -n 5                      # Show 5 most recent tasks
-  orc list --status pending -n 10    # Show 10 most recent pending tasks`,
+  orc list --status pending -n 10    # Show 10 most recent pending tasks
+  orc list --json                    # ListTasksResponse, as the API returns it`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := config.RequireInit(); err != nil {
 				return err
@@ -72,7 +73,7 @@ Example:
 
 			out := cmd.OutOrStdout()
 
-			if len(tasks) == 0 {
+			if len(tasks) == 0 && !jsonOut {
 				_, _ = fmt.Fprintln(out, "No tasks found. Create one with: orc new \"Your task\"")
 				return nil
 			}
@@ -112,7 +113,7 @@ Example:
 				filtered = append(filtered, t)
 			}
 
-			if len(filtered) == 0 {
+			if len(filtered) == 0 && !jsonOut {
 				var filterDesc []string
 				if initiativeFilterActive {
 					if initiativeFilter == "" || strings.ToLower(initiativeFilter) == "unassigned" {
@@ -138,6 +139,10 @@ Example:
 				filtered = filtered[len(filtered)-limit:]
 			}
 
+			if jsonOut {
+				return outputJSON(cmd, &orcv1.ListTasksResponse{Tasks: filtered})
+			}
+
 			// Print tasks in table format
 			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
 			_, _ = fmt.Fprintln(w, "ID\tSTATUS\tWEIGHT\tPHASE\tTITLE")
@@ -0,0 +1,250 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/logs"
+)
+
+// logsPollInterval is how often --follow checks for new entries.
+const logsPollInterval = 500 * time.Millisecond
+
+// newLogsCmd creates the logs command
+func newLogsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs [task-id]",
+		Short: "Show server or task execution logs (use --follow to stream)",
+		Long: `Show structured logs written by the server and the executor.
+
+Without a task ID, shows the server log written by 'orc serve'. With a
+task ID, shows that task's execution log, written by 'orc run', 'orc resume'
+and server-started runs. Both live under ~/.orc/projects/<id>/logs/.
+
+For Claude's conversation during a task, use 'orc log' instead.
+
+Logs are read from the project directory. With --url they are read from a
+running server's API (GET /api/logs), e.g. when the server runs elsewhere.
+
+Examples:
+  orc logs                              # Recent server log entries
+  orc logs TASK-001 --follow            # Stream a task's execution log
+  orc logs TASK-001 --level debug       # Include debug records
+  orc logs TASK-001 --phase implement   # Only the implement phase
+  orc logs --component executor -n 200  # Executor records from the server log
+  orc logs --url http://build:8080 -f   # Follow a remote server's log`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			levelStr, _ := cmd.Flags().GetString("level")
+			level, err := logs.ParseLevel(levelStr)
+			if err != nil {
+				return err
+			}
+			phase, _ := cmd.Flags().GetString("phase")
+			component, _ := cmd.Flags().GetString("component")
+			tail, _ := cmd.Flags().GetInt("tail")
+			follow, _ := cmd.Flags().GetBool("follow")
+			serverURL, _ := cmd.Flags().GetString("url")
+
+			var taskID string
+			if len(args) == 1 {
+				taskID = args[0]
+			}
+			filter := logs.Filter{Level: level, Phase: phase, Component: component}
+
+			var src logSource
+			if serverURL != "" {
+				src = &apiLogSource{baseURL: strings.TrimSuffix(serverURL, "/"), taskID: taskID, filter: filter, levelStr: levelStr}
+			} else {
+				projectRoot, err := ResolveProjectPath()
+				if err != nil {
+					return err
+				}
+				path := logs.ServerPath(projectRoot)
+				if taskID != "" {
+					if path, err = logs.TaskPath(projectRoot, taskID); err != nil {
+						return err
+					}
+				}
+				src = &fileLogSource{path: path, filter: filter}
+			}
+
+			out := cmd.OutOrStdout()
+			entries, err := src.read(tail)
+			if err != nil {
+				return err
+			}
+			for _, e := range entries {
+				printLogEntry(out, e)
+			}
+			if !follow {
+				if len(entries) == 0 && !quiet {
+					fmt.Fprintln(out, "No log entries found")
+				}
+				return nil
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(logsPollInterval):
+					entries, err := src.read(0)
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "Warning: failed to read logs: %v\n", err)
+						continue
+					}
+					for _, e := range entries {
+						printLogEntry(out, e)
+					}
+				}
+			}
+		},
+	}
+
+	cmd.Flags().BoolP("follow", "f", false, "keep streaming new entries")
+	cmd.Flags().String("level", "", "minimum level: debug, info, warn, error (default: everything logged)")
+	cmd.Flags().String("phase", "", "only entries for this phase")
+	cmd.Flags().String("component", "", "only entries from this component (server, executor, ...)")
+	cmd.Flags().IntP("tail", "n", 100, "number of recent entries to show first (0 = all)")
+	cmd.Flags().String("url", "", "read from a running server's API instead of local files")
+
+	return cmd
+}
+
+// logSource yields new log entries on each read. The first read returns the
+// last tail entries; later reads return only entries logged since.
+type logSource interface {
+	read(tail int) ([]logs.Entry, error)
+}
+
+type fileLogSource struct {
+	path   string
+	filter logs.Filter
+	offset int64
+}
+
+func (s *fileLogSource) read(tail int) ([]logs.Entry, error) {
+	entries, next, err := logs.Read(s.path, s.offset, s.filter)
+	if err != nil {
+		return nil, err
+	}
+	s.offset = next
+	return logs.Tail(entries, tail), nil
+}
+
+type apiLogSource struct {
+	baseURL  string
+	taskID   string
+	filter   logs.Filter
+	levelStr string
+	offset   *int64
+}
+
+func (s *apiLogSource) read(tail int) ([]logs.Entry, error) {
+	q := url.Values{}
+	if s.taskID != "" {
+		q.Set("task_id", s.taskID)
+	}
+	if s.levelStr != "" {
+		q.Set("level", s.levelStr)
+	}
+	if s.filter.Phase != "" {
+		q.Set("phase", s.filter.Phase)
+	}
+	if s.filter.Component != "" {
+		q.Set("component", s.filter.Component)
+	}
+	if s.offset != nil {
+		q.Set("offset", strconv.FormatInt(*s.offset, 10))
+	} else {
+		q.Set("tail", strconv.Itoa(tail))
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(s.baseURL + "/api/logs?" + q.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("fetch logs: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		return nil, fmt.Errorf("fetch logs: %s: %s", resp.Status, apiErr.Error)
+	}
+	var body logs.Page
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode logs: %w", err)
+	}
+	s.offset = &body.Offset
+	return body.Entries, nil
+}
+
+// printLogEntry writes one entry as a single line:
+// 15:04:05 INFO  [executor] TASK-001/implement message key=value
+func printLogEntry(w io.Writer, e logs.Entry) {
+	var b strings.Builder
+	b.WriteString(e.Time.Local().Format("15:04:05"))
+	fmt.Fprintf(&b, " %-5s", e.Level)
+	if e.Component != "" {
+		fmt.Fprintf(&b, " [%s]", e.Component)
+	}
+	if e.TaskID != "" {
+		b.WriteString(" " + e.TaskID)
+		if e.Phase != "" {
+			b.WriteString("/" + e.Phase)
+		}
+	} else if e.Phase != "" {
+		b.WriteString(" " + e.Phase)
+	}
+	b.WriteString(" " + e.Msg)
+
+	keys := make([]string, 0, len(e.Attrs))
+	for k := range e.Attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := fmt.Sprint(e.Attrs[k])
+		if strings.ContainsAny(v, " \t\n") {
+			v = strconv.Quote(v)
+		}
+		fmt.Fprintf(&b, " %s=%s", k, v)
+	}
+	fmt.Fprintln(w, b.String())
+}
+
+// openTaskLog returns a logger that also writes to the execution log of
+// taskID (or, for a task not created yet, of whichever task each record
+// names). Call the returned func when the run ends.
+func openTaskLog(projectRoot, taskID string) (*slog.Logger, func()) {
+	h, err := logs.NewHandler(slog.Default().Handler(), logs.Options{
+		Root:      projectRoot,
+		TaskID:    taskID,
+		Component: "executor",
+		Level:     slog.LevelDebug,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: task log disabled: %v\n", err)
+		return slog.Default(), func() {}
+	}
+	return slog.New(h), func() { _ = h.Close() }
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLogsCommand_FiltersTaskLog(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := withStatusTestDir(t)
+
+	logger, closeLog := openTaskLog(dir, "TASK-001")
+	logger.Debug("context loaded", "phase", "implement", "files", 12)
+	logger.Info("phase started", "phase", "implement")
+	logger.Info("phase started", "phase", "review")
+	closeLog()
+
+	var out bytes.Buffer
+	cmd := newLogsCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"TASK-001", "--phase", "implement"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), out.String())
+	}
+	if !strings.Contains(lines[0], "DEBUG [executor] TASK-001/implement context loaded files=12") {
+		t.Errorf("line = %q", lines[0])
+	}
+
+	out.Reset()
+	cmd = newLogsCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"TASK-001", "--level", "info", "--tail", "1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(out.String()); !strings.HasSuffix(got, "TASK-001/review phase started") {
+		t.Errorf("tail output = %q", got)
+	}
+
+	cmd = newLogsCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--level", "loud"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("invalid level accepted")
+	}
+}
@@ -62,7 +62,8 @@ from changes to prompts, specs, or workflow.
 Examples:
   orc metrics                    # Show metrics for all tasks
   orc metrics --since 2025-01-01 # Show metrics since date
-  orc metrics --all              # Include tasks without issues`,
+  orc metrics --all              # Include tasks without issues
+  orc metrics velocity           # Active time per weight and weekly trend`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Find the project root (handles worktrees)
 			projectRoot, err := ResolveProjectPath()
@@ -107,6 +108,7 @@ Examples:
 
 	cmd.Flags().StringVar(&since, "since", "", "only include tasks created after this date (YYYY-MM-DD)")
 	cmd.Flags().BoolVar(&showAll, "all", false, "include tasks without quality issues")
+	cmd.AddCommand(newMetricsVelocityCmd())
 
 	return cmd
 }
@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// velocityReport is the JSON output of orc metrics velocity.
+type velocityReport struct {
+	Estimates []db.DurationEstimate `json:"estimates"`
+	Weekly    []db.VelocityPoint    `json:"weekly"`
+}
+
+// newMetricsVelocityCmd creates the metrics velocity subcommand.
+func newMetricsVelocityCmd() *cobra.Command {
+	var weeks int
+
+	cmd := &cobra.Command{
+		Use:   "velocity",
+		Short: "Show active time per weight and weekly throughput",
+		Long: `Show how long completed tasks actually ran and how throughput is trending.
+
+Active time is the time phases spent executing; time queued, paused, or
+waiting on gates is excluded. Estimates use tasks completed in the last 90
+days, grouped by workflow (labelled with the weight mapped to it), and show
+the middle half of tasks: 25th to 75th percentile of time and cost.
+
+Examples:
+  orc metrics velocity             # Estimates plus the last 8 weeks
+  orc metrics velocity --weeks 12  # Longer trend
+  orc metrics velocity --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			backend, err := getBackend()
+			if err != nil {
+				return fmt.Errorf("get backend: %w", err)
+			}
+			defer func() { _ = backend.Close() }()
+
+			cfg, err := config.Load()
+			if err != nil {
+				cfg = config.Default()
+			}
+
+			now := time.Now()
+			since := now.Add(-db.EstimateWindow)
+			if trendStart := now.AddDate(0, 0, -7*weeks); trendStart.Before(since) {
+				since = trendStart
+			}
+			samples, err := backend.DB().GetCompletedTaskTimes(since)
+			if err != nil {
+				return err
+			}
+
+			report := velocityReport{
+				Estimates: labelEstimates(db.EstimateDurations(samplesSince(samples, now.Add(-db.EstimateWindow))), cfg),
+				Weekly:    db.WeeklyVelocity(samples, weeks, now),
+			}
+			if jsonOut {
+				return outputJSON(cmd, report)
+			}
+			displayVelocity(report)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&weeks, "weeks", 8, "number of weeks in the trend")
+	return cmd
+}
+
+func displayVelocity(report velocityReport) {
+	fmt.Println("Estimates (last 90 days)")
+	fmt.Println("========================")
+	if len(report.Estimates) == 0 {
+		fmt.Println("Not enough completed tasks yet (need 3 per workflow).")
+	}
+	for _, e := range report.Estimates {
+		fmt.Printf("  %-24s %s (%d tasks)\n", estimateLabel(e), estimateRange(e), e.Samples)
+	}
+
+	fmt.Println()
+	fmt.Println("Weekly velocity")
+	fmt.Println("===============")
+	fmt.Printf("  %-10s %9s %12s %10s %9s\n", "Week", "Completed", "Active time", "Median", "Cost")
+	for _, p := range report.Weekly {
+		fmt.Printf("  %-10s %9d %12s %10s %9s\n",
+			p.WeekStart.Format("2006-01-02"), p.Completed,
+			formatActive(p.ActiveMs), formatActive(p.MedianActiveMs), fmt.Sprintf("$%.2f", p.CostUSD))
+	}
+}
+
+// estimateForWorkflow returns the estimate for new tasks on workflowID, or
+// nil when there is not enough history.
+func estimateForWorkflow(pdb *db.ProjectDB, cfg *config.Config, workflowID string) (*db.DurationEstimate, error) {
+	if pdb == nil || workflowID == "" {
+		return nil, nil
+	}
+	samples, err := pdb.GetCompletedTaskTimes(time.Now().Add(-db.EstimateWindow))
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range labelEstimates(db.EstimateDurations(samples), cfg) {
+		if e.WorkflowID == workflowID {
+			return &e, nil
+		}
+	}
+	return nil, nil
+}
+
+// formatEstimate renders an estimate for humans, e.g.
+// "medium tasks typically take 40–70 min, $2–4".
+func formatEstimate(e db.DurationEstimate) string {
+	return fmt.Sprintf("%s tasks typically take %s", estimateLabel(e), estimateRange(e))
+}
+
+func labelEstimates(estimates []db.DurationEstimate, cfg *config.Config) []db.DurationEstimate {
+	if cfg == nil {
+		return estimates
+	}
+	for i := range estimates {
+		estimates[i].Weight = cfg.Weights.WeightForWorkflow(estimates[i].WorkflowID)
+	}
+	return estimates
+}
+
+func samplesSince(samples []db.TaskTimeSample, since time.Time) []db.TaskTimeSample {
+	var recent []db.TaskTimeSample
+	for _, s := range samples {
+		if !s.CompletedAt.Before(since) {
+			recent = append(recent, s)
+		}
+	}
+	return recent
+}
+
+func estimateLabel(e db.DurationEstimate) string {
+	if e.Weight != "" {
+		return e.Weight
+	}
+	return e.WorkflowID
+}
+
+func estimateRange(e db.DurationEstimate) string {
+	return formatDurationRange(e.LowMs, e.HighMs) + ", " + formatCostRange(e.LowCostUSD, e.HighCostUSD)
+}
+
+// formatDurationRange renders a millisecond range in minutes, or hours for
+// long ranges.
+func formatDurationRange(lowMs, highMs int64) string {
+	lowMin := math.Round(float64(lowMs) / 60000)
+	highMin := math.Round(float64(highMs) / 60000)
+	if highMin < 1 {
+		return "under a minute"
+	}
+	if highMin >= 120 {
+		return joinRange(fmt.Sprintf("%.1f", lowMin/60), fmt.Sprintf("%.1f", highMin/60)) + " h"
+	}
+	return joinRange(fmt.Sprintf("%.0f", math.Max(lowMin, 1)), fmt.Sprintf("%.0f", highMin)) + " min"
+}
+
+// formatCostRange renders a USD range, with cents only for sub-dollar costs.
+func formatCostRange(low, high float64) string {
+	if high < 1 {
+		return "$" + joinRange(fmt.Sprintf("%.2f", low), fmt.Sprintf("%.2f", high))
+	}
+	return "$" + joinRange(fmt.Sprintf("%.0f", math.Max(math.Round(low), 0)), fmt.Sprintf("%.0f", math.Round(high)))
+}
+
+func joinRange(low, high string) string {
+	if low == high {
+		return low
+	}
+	return low + "–" + high
+}
+
+func formatActive(ms int64) string {
+	if ms <= 0 {
+		return "-"
+	}
+	return task.FormatDuration((time.Duration(ms) * time.Millisecond).Round(time.Minute))
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+func TestFormatEstimate(t *testing.T) {
+	tests := []struct {
+		name string
+		e    db.DurationEstimate
+		want string
+	}{
+		{
+			name: "weight label in minutes",
+			e:    db.DurationEstimate{WorkflowID: "implement-medium", Weight: "medium", LowMs: 40 * 60000, HighMs: 70 * 60000, LowCostUSD: 2.2, HighCostUSD: 3.8},
+			want: "medium tasks typically take 40–70 min, $2–4",
+		},
+		{
+			name: "workflow label in hours",
+			e:    db.DurationEstimate{WorkflowID: "deep", LowMs: 90 * 60000, HighMs: 180 * 60000, LowCostUSD: 8, HighCostUSD: 12},
+			want: "deep tasks typically take 1.5–3.0 h, $8–12",
+		},
+		{
+			name: "short and cheap",
+			e:    db.DurationEstimate{WorkflowID: "trivial", LowMs: 10000, HighMs: 20000, LowCostUSD: 0.05, HighCostUSD: 0.05},
+			want: "trivial tasks typically take under a minute, $0.05",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatEstimate(tt.e); got != tt.want {
+				t.Errorf("formatEstimate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/randalmurphal/orc/internal/db"
 	"github.com/randalmurphal/orc/internal/detect"
 	"github.com/randalmurphal/orc/internal/git"
+	"github.com/randalmurphal/orc/internal/storage"
 	"github.com/randalmurphal/orc/internal/task"
 	"github.com/randalmurphal/orc/internal/template"
 	"github.com/randalmurphal/orc/internal/workflow"
@@ -72,7 +73,7 @@ Use --workflow to assign a workflow. This determines which phases run:
                       Example: "Add validation for email field"
 
   crossmodel-standard Strict default for production work (DEFAULT)
-                      → plan → implement_codex → review → review_cross → [qa_e2e_test] → docs
+                      → plan → [reproduce] → implement_codex → review → review_cross → [qa_e2e_test] → docs
                       Example: "Add password reset flow"
 
   implement-medium    Legacy multi-phase design/TDD workflow
@@ -86,6 +87,7 @@ Use --workflow to assign a workflow. This determines which phases run:
 Key phases:
   • spec/tiny_spec  Creates Success Criteria + Testing requirements (REQUIRED for quality)
   • tdd_write       Writes failing tests BEFORE implementation (context isolation)
+  • reproduce       Bug tasks: failing regression test, must fail before and pass after implement
   • breakdown       Decomposes large tasks into checkboxed steps
   • review          Multi-agent code review with specialized reviewers (includes verification)
 
@@ -183,6 +185,10 @@ orc new "Implement refresh token rotation" --workflow crossmodel-standard -i INI
 # Trivial: Simple fix, no spec needed
 orc new "Fix typo: 'recieve' → 'receive'" --workflow implement-trivial
 
+# Hotfix: targets release/1.2; after merge a linked back-port task to main
+# is created (see completion.release)
+orc new "Fix crash on empty config" --workflow implement-small -c bug --release 1.2
+
 See also:
   orc run      - Execute a task (uses assigned workflow_id)
   orc show     - View task details and spec content
@@ -226,8 +232,12 @@ See also:
 			blockedBy, _ := cmd.Flags().GetStringSlice("blocked-by")
 			relatedTo, _ := cmd.Flags().GetStringSlice("related-to")
 			targetBranch, _ := cmd.Flags().GetString("target-branch")
+			release, _ := cmd.Flags().GetString("release")
 			beforeImages, _ := cmd.Flags().GetStringSlice("before-images")
 			qaMaxLoops, _ := cmd.Flags().GetInt("qa-max-loops")
+			budget, _ := cmd.Flags().GetFloat64("budget")
+			noRelatedTasks, _ := cmd.Flags().GetBool("no-related-tasks")
+			phaseModelFlags, _ := cmd.Flags().GetStringSlice("phase-model")
 			gateOverrides, _ := cmd.Flags().GetStringSlice("gate")
 			specContent, _ := cmd.Flags().GetString("spec-content")
 			// Branch control flags
@@ -239,6 +249,26 @@ See also:
 			prReviewers, _ := cmd.Flags().GetStringSlice("pr-reviewers")
 			prReviewersSet := cmd.Flags().Changed("pr-reviewers")
 
+			if budget < 0 {
+				return returnErr(fmt.Errorf("--budget must be a non-negative amount in USD"))
+			}
+			phaseModels, err := task.ParsePhaseModelFlags(phaseModelFlags)
+			if err != nil {
+				return returnErr(fmt.Errorf("--phase-model: %w", err))
+			}
+
+			// --release is shorthand for a target branch under completion.release.prefix
+			if release != "" {
+				if targetBranch != "" {
+					return returnErr(fmt.Errorf("--release and --target-branch are mutually exclusive"))
+				}
+				prefix := config.Default().Completion.Release.Prefix
+				if cfg, err := config.Load(); err == nil && cfg.Completion.Release.Prefix != "" {
+					prefix = cfg.Completion.Release.Prefix
+				}
+				targetBranch = prefix + strings.TrimPrefix(release, prefix)
+			}
+
 			// Validate target branch if specified
 			if targetBranch != "" {
 				if err := git.ValidateBranchName(targetBranch); err != nil {
@@ -411,6 +441,9 @@ See also:
 
 			// Set target branch if provided
 			if targetBranch != "" {
+				if err := validateTaskTargetBranch(workflowID, targetBranch); err != nil {
+					return returnErr(err)
+				}
 				task.SetTargetBranchProto(t, targetBranch)
 			}
 
@@ -428,6 +461,10 @@ See also:
 				task.SetPRReviewersProto(t, prReviewers)
 			}
 
+			task.SetBudgetUSD(t, budget)
+			task.SetPhaseModels(t, phaseModels)
+			task.SetRelatedTasksDisabled(t, noRelatedTasks)
+
 			// Set QA-specific task metadata
 			if len(beforeImages) > 0 || qaMaxLoops > 0 {
 				if t.Metadata == nil {
@@ -570,6 +607,9 @@ See also:
 			fmt.Printf("   Priority: %s\n", task.PriorityFromProto(t.Priority))
 			if t.WorkflowId != nil && *t.WorkflowId != "" {
 				fmt.Printf("   Workflow: %s\n", *t.WorkflowId)
+				if est := newTaskEstimate(backend, *t.WorkflowId); est != "" {
+					fmt.Printf("   Estimate: %s\n", est)
+				}
 			}
 			if tpl != nil {
 				fmt.Printf("   Template: %s\n", tpl.Name)
@@ -622,6 +662,12 @@ See also:
 			if qaMaxLoops > 0 {
 				fmt.Printf("   Max QA Iterations: %d\n", qaMaxLoops)
 			}
+			if budget > 0 {
+				fmt.Printf("   Budget: $%.2f\n", budget)
+			}
+			if len(phaseModelFlags) > 0 {
+				fmt.Printf("   Phase Models: %s\n", strings.Join(phaseModelFlags, ", "))
+			}
 			if len(gateOverrides) > 0 {
 				fmt.Printf("   Gate Overrides: %s\n", strings.Join(gateOverrides, ", "))
 			}
@@ -671,7 +717,7 @@ See also:
 		},
 	}
 	cmd.Flags().String("workflow", "", "workflow to use for execution (e.g., implement, qa-e2e)")
-	cmd.Flags().StringP("category", "c", "", "task category (feature, bug, refactor, chore, docs, test)")
+	cmd.Flags().StringP("category", "c", "", "task category (feature, bug, refactor, chore, docs, test; bugfix is an alias for bug)")
 	cmd.Flags().StringP("priority", "p", "", "task priority (critical, high, normal, low)")
 	cmd.Flags().StringP("description", "d", "", "task description")
 	cmd.Flags().StringP("template", "t", "", "use template (bugfix, feature, refactor, migration, spike)")
@@ -681,8 +727,12 @@ See also:
 	cmd.Flags().StringSlice("blocked-by", nil, "task IDs that must complete before this task")
 	cmd.Flags().StringSlice("related-to", nil, "task IDs related to this task")
 	cmd.Flags().String("target-branch", "", "override target branch for PR (instead of project default)")
+	cmd.Flags().String("release", "", "target a release branch by version (e.g., 1.2 → release/1.2)")
 	cmd.Flags().StringSlice("before-images", nil, "baseline images for visual comparison (QA E2E workflow)")
 	cmd.Flags().Int("qa-max-loops", 0, "max QA iterations before stopping (default: 3)")
+	cmd.Flags().Float64("budget", 0, "cost budget in USD; the task pauses once it spends more")
+	cmd.Flags().Bool("no-related-tasks", false, "don't inject outcomes of recent related tasks into prompts")
+	cmd.Flags().StringSlice("phase-model", nil, "model for a phase (phase=model, e.g., spec=opus, implement=sonnet)")
 	cmd.Flags().StringSlice("gate", nil, "gate overrides (phase:type, e.g., spec:human, review:ai)")
 	cmd.Flags().String("spec-content", "", "pre-populate spec content (enables spec phase auto-skip)")
 	// Branch control flags
@@ -723,3 +773,17 @@ func outputNewTaskJSON(cmd *cobra.Command, t *orcv1.Task) error {
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(output)
 }
+
+// newTaskEstimate describes how long tasks on the workflow have historically
+// taken, or "" when there is not enough history.
+func newTaskEstimate(backend storage.Backend, workflowID string) string {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.Default()
+	}
+	est, err := estimateForWorkflow(backend.DB(), cfg, workflowID)
+	if err != nil || est == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s (%d completed)", formatEstimate(*est), est.Samples)
+}
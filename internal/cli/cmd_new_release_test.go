@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNewCmd_ReleaseTargetsReleaseBranch(t *testing.T) {
+	tmpDir := withNewCmdTestDir(t)
+	backend := createTestBackendInDir(t, tmpDir)
+	_ = backend.Close()
+
+	cmd := newNewCmd()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"Fix crash on empty config", "--workflow", "implement-small", "--release", "1.2"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("orc new --release: %v\n%s", err, buf.String())
+	}
+
+	reopened := createTestBackendInDir(t, tmpDir)
+	defer func() { _ = reopened.Close() }()
+	tasks, err := reopened.LoadAllTasks()
+	if err != nil {
+		t.Fatalf("load tasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].GetTargetBranch() != "release/1.2" {
+		t.Fatalf("tasks = %v, want one task targeting release/1.2", tasks)
+	}
+}
+
+func TestNewCmd_ReleaseConflictsWithTargetBranch(t *testing.T) {
+	tmpDir := withNewCmdTestDir(t)
+	backend := createTestBackendInDir(t, tmpDir)
+	_ = backend.Close()
+
+	cmd := newNewCmd()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"Hotfix", "--workflow", "implement-small", "--release", "1.2", "--target-branch", "main"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Fatalf("err = %v, want mutually exclusive flags error", err)
+	}
+}
@@ -80,10 +80,17 @@ func newPoolInitCmd() *cobra.Command {
 }
 
 func newPoolAddCmd() *cobra.Command {
-	return &cobra.Command{
+	var accessToken, refreshToken string
+
+	cmd := &cobra.Command{
 		Use:   "add <name>",
 		Short: "Add a new account to the pool",
-		Args:  cobra.ExactArgs(1),
+		Long: `Add a new account to the pool.
+
+Without --token, runs 'claude login' in a scratch config directory and adds
+the account you log in with. With --token, adds an existing OAuth token
+without opening a browser (for servers and scripts).`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
 
@@ -92,51 +99,11 @@ func newPoolAddCmd() *cobra.Command {
 				cfg = config.Default()
 			}
 
-			// Create temp directory for auth
-			tempDir, err := os.MkdirTemp("", "orc-pool-"+name+"-")
-			if err != nil {
-				return fmt.Errorf("create temp dir: %w", err)
-			}
-			defer func() { _ = os.RemoveAll(tempDir) }()
-
-			claudeDir := filepath.Join(tempDir, ".claude")
-			if err := os.MkdirAll(claudeDir, 0700); err != nil {
-				return fmt.Errorf("create claude dir: %w", err)
-			}
-
-			fmt.Printf("Opening browser for authentication...\n")
-			fmt.Printf("Please log in with the account you want to add as '%s'\n\n", name)
-
-			// Run claude login with custom config dir
-			claudeCmd := exec.Command("claude", "login")
-			claudeCmd.Env = append(os.Environ(), "CLAUDE_CONFIG_DIR="+tempDir)
-			claudeCmd.Stdin = os.Stdin
-			claudeCmd.Stdout = os.Stdout
-			claudeCmd.Stderr = os.Stderr
-
-			if err := claudeCmd.Run(); err != nil {
-				return fmt.Errorf("claude login failed: %w", err)
-			}
-
-			// Read credentials from temp dir
-			credsPath := filepath.Join(claudeDir, ".credentials.json")
-			credsData, err := os.ReadFile(credsPath)
-			if err != nil {
-				return fmt.Errorf("read credentials: %w", err)
-			}
-
-			var creds struct {
-				ClaudeAiOauth struct {
-					AccessToken  string `json:"accessToken"`
-					RefreshToken string `json:"refreshToken"`
-				} `json:"claudeAiOauth"`
-			}
-			if err := json.Unmarshal(credsData, &creds); err != nil {
-				return fmt.Errorf("parse credentials: %w", err)
-			}
-
-			if creds.ClaudeAiOauth.AccessToken == "" {
-				return fmt.Errorf("no OAuth token found - login may have failed")
+			if accessToken == "" {
+				accessToken, refreshToken, err = claudeLoginTokens(name)
+				if err != nil {
+					return err
+				}
 			}
 
 			// Load or create pool
@@ -158,8 +125,8 @@ func newPoolAddCmd() *cobra.Command {
 			account := &tokenpool.Account{
 				ID:           name,
 				Name:         name,
-				AccessToken:  creds.ClaudeAiOauth.AccessToken,
-				RefreshToken: creds.ClaudeAiOauth.RefreshToken,
+				AccessToken:  accessToken,
+				RefreshToken: refreshToken,
 				Enabled:      true,
 			}
 
@@ -172,6 +139,64 @@ func newPoolAddCmd() *cobra.Command {
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&accessToken, "token", "", "OAuth access token to add instead of logging in")
+	cmd.Flags().StringVar(&refreshToken, "refresh-token", "", "OAuth refresh token (with --token)")
+
+	return cmd
+}
+
+// claudeLoginTokens runs 'claude login' against a temporary config directory
+// and returns the OAuth tokens of the account logged in with.
+func claudeLoginTokens(name string) (accessToken, refreshToken string, err error) {
+	// Create temp directory for auth
+	tempDir, err := os.MkdirTemp("", "orc-pool-"+name+"-")
+	if err != nil {
+		return "", "", fmt.Errorf("create temp dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	claudeDir := filepath.Join(tempDir, ".claude")
+	if err := os.MkdirAll(claudeDir, 0700); err != nil {
+		return "", "", fmt.Errorf("create claude dir: %w", err)
+	}
+
+	fmt.Printf("Opening browser for authentication...\n")
+	fmt.Printf("Please log in with the account you want to add as '%s'\n\n", name)
+
+	// Run claude login with custom config dir
+	claudeCmd := exec.Command("claude", "login")
+	claudeCmd.Env = append(os.Environ(), "CLAUDE_CONFIG_DIR="+tempDir)
+	claudeCmd.Stdin = os.Stdin
+	claudeCmd.Stdout = os.Stdout
+	claudeCmd.Stderr = os.Stderr
+
+	if err := claudeCmd.Run(); err != nil {
+		return "", "", fmt.Errorf("claude login failed: %w", err)
+	}
+
+	// Read credentials from temp dir
+	credsPath := filepath.Join(claudeDir, ".credentials.json")
+	credsData, err := os.ReadFile(credsPath)
+	if err != nil {
+		return "", "", fmt.Errorf("read credentials: %w", err)
+	}
+
+	var creds struct {
+		ClaudeAiOauth struct {
+			AccessToken  string `json:"accessToken"`
+			RefreshToken string `json:"refreshToken"`
+		} `json:"claudeAiOauth"`
+	}
+	if err := json.Unmarshal(credsData, &creds); err != nil {
+		return "", "", fmt.Errorf("parse credentials: %w", err)
+	}
+
+	if creds.ClaudeAiOauth.AccessToken == "" {
+		return "", "", fmt.Errorf("no OAuth token found - login may have failed")
+	}
+
+	return creds.ClaudeAiOauth.AccessToken, creds.ClaudeAiOauth.RefreshToken, nil
 }
 
 func newPoolListCmd() *cobra.Command {
@@ -222,6 +247,13 @@ func newPoolStatusCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "status",
 		Short: "Show detailed status of all accounts",
+		Long: `Show each account's rate-limit state and the work run on it.
+
+RATE LIMITS counts how often the account hit a rate limit; the executor
+switches to the next account when that happens and switch_on_rate_limit
+is set. PHASES, TOKENS and COST are the phase executions run on the account.
+
+With --json, prints the same document as GET /api/pool.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
@@ -233,44 +265,55 @@ func newPoolStatusCmd() *cobra.Command {
 				return fmt.Errorf("load pool: %w", err)
 			}
 
-			statuses := pool.Status()
-			if len(statuses) == 0 {
+			report := pool.Report()
+			if jsonOut {
+				return outputJSON(cmd, report)
+			}
+			if len(report.Accounts) == 0 {
 				fmt.Println("No accounts configured.")
 				return nil
 			}
 
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			_, _ = fmt.Fprintln(w, "ID\tSTATUS\tEXHAUSTED\tLAST ERROR")
-			_, _ = fmt.Fprintln(w, "--\t------\t---------\t----------")
+			_, _ = fmt.Fprintln(w, "ID\tSTATUS\tEXHAUSTED\tRATE LIMITS\tPHASES\tTOKENS\tCOST\tLAST USED\tLAST ERROR")
+			_, _ = fmt.Fprintln(w, "--\t------\t---------\t-----------\t------\t------\t----\t---------\t----------")
 
-			for _, s := range statuses {
+			for _, a := range report.Accounts {
 				status := "ready"
-				if s.IsCurrent {
+				if a.Current {
 					status = "active"
 				}
-				if !s.Account.Enabled {
+				if !a.Enabled {
 					status = "disabled"
 				}
 
 				exhausted := "-"
-				if s.State != nil && s.State.Exhausted {
+				if a.Exhausted {
 					exhausted = "yes"
-					if s.State.ExhaustedAt != nil {
-						exhausted = s.State.ExhaustedAt.Format("15:04:05")
+					if a.ExhaustedAt != nil {
+						exhausted = a.ExhaustedAt.Format("15:04:05")
 					}
 				}
 
+				lastUsed := "-"
+				if a.Usage.LastUsedAt != nil {
+					lastUsed = formatTimeAgo(*a.Usage.LastUsedAt)
+				}
+
 				lastError := "-"
-				if s.State != nil && s.State.LastError != "" {
-					lastError = truncate(s.State.LastError, 40)
+				if a.LastError != "" {
+					lastError = truncate(a.LastError, 40)
 				}
 
-				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Account.ID, status, exhausted, lastError)
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\t%s\t%s\t%s\n",
+					a.ID, status, exhausted, a.RateLimitCount, a.Usage.Phases,
+					a.Usage.InputTokens+a.Usage.OutputTokens, formatCost(a.Usage.CostUSD),
+					lastUsed, lastError)
 			}
 			_ = w.Flush()
 
-			fmt.Printf("\nStrategy: %s\n", pool.Strategy())
-			fmt.Printf("Auto-switch on rate limit: %v\n", pool.SwitchOnRateLimit())
+			fmt.Printf("\nStrategy: %s\n", report.Strategy)
+			fmt.Printf("Auto-switch on rate limit: %v\n", report.SwitchOnRateLimit)
 
 			return nil
 		},
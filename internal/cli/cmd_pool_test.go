@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/tokenpool"
+)
+
+// withPoolTestHome points HOME at a temp dir so the default pool path
+// (~/.orc/token-pool/pool.yaml) is isolated, and returns that path.
+func withPoolTestHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Chdir(t.TempDir())
+	return filepath.Join(home, ".orc", "token-pool", "pool.yaml")
+}
+
+func TestPoolAdd_WithToken(t *testing.T) {
+	poolPath := withPoolTestHome(t)
+
+	cmd := newPoolCmd()
+	cmd.SetArgs([]string{"add", "work", "--token", "sk-ant-oat01-work"})
+	captureStdout(t, func() {
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("pool add --token: %v", err)
+		}
+	})
+
+	pool, err := tokenpool.New(poolPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current := pool.Current(); current == nil || current.ID != "work" || current.Token() != "sk-ant-oat01-work" {
+		t.Errorf("current account = %+v", current)
+	}
+}
+
+func TestPoolStatus_JSONOutput(t *testing.T) {
+	poolPath := withPoolTestHome(t)
+
+	cfg := tokenpool.DefaultPoolConfig()
+	cfg.Accounts = append(cfg.Accounts, &tokenpool.Account{ID: "work", Name: "Work", AccessToken: "sk-ant-oat01-secret", Enabled: true})
+	if err := cfg.Save(poolPath); err != nil {
+		t.Fatal(err)
+	}
+	pool, err := tokenpool.New(poolPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.RecordUsage("work", 1200, 300, 0.42)
+	pool.MarkExhausted("rate limit exceeded")
+
+	oldJSON := jsonOut
+	jsonOut = true
+	defer func() { jsonOut = oldJSON }()
+
+	cmd := newPoolCmd()
+	cmd.SetArgs([]string{"status"})
+	output := captureStdout(t, func() {
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("pool status --json: %v", err)
+		}
+	})
+
+	if strings.Contains(output, "secret") {
+		t.Errorf("output leaks the token: %s", output)
+	}
+	var report tokenpool.Report
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		t.Fatalf("JSON parse failed: %v\nOutput: %s", err, output)
+	}
+	if len(report.Accounts) != 1 {
+		t.Fatalf("accounts = %+v", report.Accounts)
+	}
+	work := report.Accounts[0]
+	if !work.Exhausted || work.RateLimitCount != 1 || work.Usage.Phases != 1 || work.Usage.OutputTokens != 300 {
+		t.Errorf("work account = %+v", work)
+	}
+}
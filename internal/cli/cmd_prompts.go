@@ -0,0 +1,254 @@
+// Package cli implements the orc command-line interface.
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/prompt"
+)
+
+func newPromptsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prompts",
+		Short: "Install and manage shareable prompt packs",
+		Long: `Install and manage prompt packs: git repositories of phase prompt
+overrides and workflow customizations shared across many projects.
+
+A pack repository contains:
+  orc-pack.yaml      name, version, description
+  prompts/*.md       phase prompts, installed into .orc/prompts/
+  workflows/*.yaml   workflow or phase template exports (orc export format)
+
+Installed packs are pinned to an exact commit in .orc/prompt-packs.yaml.
+Commit that file so every clone of the project uses the same pack version.
+
+Commands:
+  install  Install a pack from a git repository
+  list     List installed packs and their pinned versions
+  sync     Reinstall every pack at its pinned commit
+  remove   Remove a pack's prompts and its lock entry`,
+	}
+
+	cmd.AddCommand(newPromptsInstallCmd())
+	cmd.AddCommand(newPromptsListCmd())
+	cmd.AddCommand(newPromptsSyncCmd())
+	cmd.AddCommand(newPromptsRemoveCmd())
+
+	return cmd
+}
+
+func newPromptsInstallCmd() *cobra.Command {
+	var (
+		ref   string
+		force bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "install <repo>[@ref]",
+		Short: "Install a prompt pack from a git repository",
+		Long: `Install a prompt pack from a git repository.
+
+The ref may be a branch, tag, or commit. The resolved commit is recorded in
+.orc/prompt-packs.yaml so the install is reproducible.
+
+Examples:
+  orc prompts install https://github.com/acme/orc-prompts
+  orc prompts install https://github.com/acme/orc-prompts@v1.2.0
+  orc prompts install git@github.com:acme/orc-prompts.git --ref 3f2a9c1`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repo, specRef := prompt.ParsePackSpec(args[0])
+			if ref == "" {
+				ref = specRef
+			} else if specRef != "" {
+				return fmt.Errorf("ref given twice: %q and --ref %q", specRef, ref)
+			}
+
+			svc, err := projectPromptService()
+			if err != nil {
+				return err
+			}
+			entry, err := installPromptPack(svc, repo, ref, force)
+			if err != nil {
+				return err
+			}
+
+			if jsonOut {
+				return json.NewEncoder(os.Stdout).Encode(entry)
+			}
+			fmt.Printf("Installed prompt pack %s", entry.Name)
+			if entry.Version != "" {
+				fmt.Printf(" %s", entry.Version)
+			}
+			fmt.Printf(" (%s) - %d prompt(s), %d workflow file(s)\n", shortCommit(entry.Commit), len(entry.Prompts), len(entry.Workflows))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&ref, "ref", "", "branch, tag, or commit to install")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "replace existing prompt overrides not owned by this pack")
+
+	return cmd
+}
+
+func newPromptsListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List installed prompt packs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			svc, err := projectPromptService()
+			if err != nil {
+				return err
+			}
+			lock, err := svc.LoadPackLock()
+			if err != nil {
+				return err
+			}
+
+			if jsonOut {
+				return json.NewEncoder(os.Stdout).Encode(map[string]any{"packs": lock.Packs})
+			}
+			if len(lock.Packs) == 0 {
+				fmt.Println("No prompt packs installed.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "NAME\tVERSION\tCOMMIT\tREPO\tPROMPTS")
+			for _, p := range lock.Packs {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", p.Name, p.Version, shortCommit(p.Commit), p.Repo, strings.Join(p.Prompts, ","))
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func newPromptsSyncCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sync",
+		Short: "Reinstall every prompt pack at its pinned commit",
+		Long: `Reinstall every pack listed in .orc/prompt-packs.yaml at the exact commit
+recorded there. Use after cloning a project or pulling a lock file change.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			svc, err := projectPromptService()
+			if err != nil {
+				return err
+			}
+			lock, err := svc.LoadPackLock()
+			if err != nil {
+				return err
+			}
+			if len(lock.Packs) == 0 {
+				fmt.Println("No prompt packs installed.")
+				return nil
+			}
+
+			for _, p := range lock.Packs {
+				entry, err := installPromptPack(svc, p.Repo, p.Commit, true)
+				if err != nil {
+					return fmt.Errorf("sync %s: %w", p.Name, err)
+				}
+				// Keep the human-readable ref the pack was originally pinned with.
+				entry.Ref = p.Ref
+				if err := svc.RecordPack(*entry); err != nil {
+					return err
+				}
+				fmt.Printf("Synced %s at %s\n", entry.Name, shortCommit(entry.Commit))
+			}
+			return nil
+		},
+	}
+}
+
+func newPromptsRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove an installed prompt pack",
+		Long: `Remove the prompt overrides a pack installed and drop it from
+.orc/prompt-packs.yaml. Workflows imported from the pack are kept; delete them
+with 'orc workflows delete' if no longer needed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := projectPromptService()
+			if err != nil {
+				return err
+			}
+			removed, err := svc.RemovePack(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Removed prompt pack %s (%d prompt(s))\n", removed.Name, len(removed.Prompts))
+			return nil
+		},
+	}
+}
+
+// projectPromptService returns a prompt service rooted at the project's .orc dir.
+func projectPromptService() (*prompt.Service, error) {
+	projectRoot, err := ResolveProjectPath()
+	if err != nil {
+		return nil, fmt.Errorf("not in an orc project (run 'orc init' first): %w", err)
+	}
+	return prompt.NewService(filepath.Join(projectRoot, config.OrcDir)), nil
+}
+
+// installPromptPack fetches a pack, installs its prompts and workflows, and
+// records the pinned commit in the lock file.
+func installPromptPack(svc *prompt.Service, repo, ref string, force bool) (*prompt.InstalledPack, error) {
+	pack, err := prompt.FetchPack(repo, ref)
+	if err != nil {
+		return nil, err
+	}
+	defer pack.Cleanup()
+
+	if err := svc.InstallPrompts(pack, force); err != nil {
+		return nil, err
+	}
+
+	// Pack workflows are the pinned source of truth, so they always replace
+	// the local copy regardless of timestamps.
+	var workflows []string
+	for _, path := range pack.WorkflowFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read pack workflow %s: %w", filepath.Base(path), err)
+		}
+		if err := importData(data, filepath.Base(path), true, false); err != nil {
+			return nil, fmt.Errorf("import pack workflow %s: %w", filepath.Base(path), err)
+		}
+		workflows = append(workflows, filepath.Base(path))
+	}
+
+	entry := prompt.InstalledPack{
+		Name:        pack.Manifest.Name,
+		Version:     pack.Manifest.Version,
+		Repo:        repo,
+		Ref:         ref,
+		Commit:      pack.Commit,
+		Prompts:     pack.Prompts,
+		Workflows:   workflows,
+		InstalledAt: time.Now().UTC(),
+	}
+	if err := svc.RecordPack(entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
@@ -243,7 +243,7 @@ func newRecommendationCLIClient() (recommendationCLIClient, string, error) {
 	}
 
 	client := &recommendationConnectClient{
-		client: orcv1connect.NewRecommendationServiceClient(http.DefaultClient, fmt.Sprintf("http://%s:%d", host, port)),
+		client: orcv1connect.NewRecommendationServiceClient(http.DefaultClient, fmt.Sprintf("http://%s:%d", host, port), serverClientOptions()...),
 	}
 	return client, projectID, nil
 }
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/executor"
+)
+
+// newReplayCmd creates the replay command
+func newReplayCmd() *cobra.Command {
+	var runID, exportPath, fromPath string
+
+	cmd := &cobra.Command{
+		Use:   "replay [task-id]",
+		Short: "Re-run a stored task run without a model to reproduce executor bugs",
+		Long: `Re-drive the executor from a task's recorded run. The model's answers
+are taken from the stored transcripts and gate decisions from the task's
+execution state, then fed through the real workflow executor in a scratch
+database. No model is called and the project is not touched.
+
+The replay prints the phase transitions and gate decisions it went through
+and reports where it ended differently from the recording, which points at
+bugs in gating, retry and state-transition logic.
+
+Use --export to save the recording as JSON (e.g. to attach to a bug report)
+and --from to replay such a file.
+
+Examples:
+  orc replay TASK-001                         # Replay the latest run
+  orc replay TASK-001 --run RUN-007           # Replay a specific run
+  orc replay TASK-001 --export task-001.json  # Save the recording
+  orc replay --from task-001.json             # Replay a saved recording
+  orc replay TASK-001 --json                  # Replay result as JSON`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (len(args) == 1) == (fromPath != "") {
+				return errors.New("give either a task ID or --from")
+			}
+
+			var rec *executor.Recording
+			if fromPath != "" {
+				data, err := os.ReadFile(fromPath)
+				if err != nil {
+					return fmt.Errorf("read recording: %w", err)
+				}
+				rec = &executor.Recording{}
+				if err := json.Unmarshal(data, rec); err != nil {
+					return fmt.Errorf("parse recording %s: %w", fromPath, err)
+				}
+			} else {
+				backend, err := getBackend()
+				if err != nil {
+					return err
+				}
+				defer func() { _ = backend.Close() }()
+				if rec, err = executor.LoadRecording(backend, args[0], runID); err != nil {
+					return err
+				}
+			}
+
+			if exportPath != "" {
+				data, err := json.MarshalIndent(rec, "", "  ")
+				if err != nil {
+					return fmt.Errorf("marshal recording: %w", err)
+				}
+				if err := os.WriteFile(exportPath, append(data, '\n'), 0644); err != nil {
+					return fmt.Errorf("write recording: %w", err)
+				}
+				if !quiet {
+					fmt.Fprintf(cmd.OutOrStdout(), "Recorded %d turn(s) and %d gate decision(s) of %s to %s\n",
+						len(rec.Turns), len(rec.Gates), rec.TaskID, exportPath)
+				}
+				return nil
+			}
+
+			gdb, err := db.OpenGlobal()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = gdb.Close() }()
+			// Outside a project, replay with defaults
+			cfg, err := config.Load()
+			if err != nil {
+				cfg = nil
+			}
+
+			res, err := executor.Replay(context.Background(), rec, executor.ReplayOptions{Workflows: gdb, Config: cfg})
+			if err != nil {
+				return err
+			}
+			if jsonOut {
+				if err := outputJSON(cmd, res); err != nil {
+					return err
+				}
+			} else {
+				printReplayResult(cmd, rec, res)
+			}
+			if len(res.Divergences) > 0 {
+				return fmt.Errorf("replay of %s diverged from the recording", rec.TaskID)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&runID, "run", "", "workflow run to replay (default: the latest)")
+	cmd.Flags().StringVar(&exportPath, "export", "", "write the recording to this file instead of replaying")
+	cmd.Flags().StringVar(&fromPath, "from", "", "replay a recording saved with --export")
+
+	return cmd
+}
+
+func printReplayResult(cmd *cobra.Command, rec *executor.Recording, res *executor.ReplayResult) {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Replay of %s (%s, %d recorded turns)\n\n", rec.TaskID, rec.WorkflowID, len(rec.Turns))
+	for _, line := range res.Trace {
+		fmt.Fprintf(out, "  %s\n", line)
+	}
+	fmt.Fprintf(out, "\nStatus: %s (recorded %s)\n", res.Status, rec.Status)
+	if res.Error != "" {
+		fmt.Fprintf(out, "Error:  %s\n", res.Error)
+	}
+	if len(res.Divergences) == 0 {
+		fmt.Fprintln(out, "Replay matches the recording")
+		return
+	}
+	fmt.Fprintln(out, "\nDivergences:")
+	for _, d := range res.Divergences {
+		fmt.Fprintf(out, "  - %s\n", d)
+	}
+}
@@ -457,6 +457,11 @@ Use --force to resume a task even if it appears to still be running.`,
 				executor.WithWorkflowTokenRates(executor.ProviderRatesForConfig(cfg)),
 			}
 
+			// Execution log for `orc logs`
+			taskLogger, closeTaskLog := openTaskLog(projectRoot, id)
+			defer closeTaskLog()
+			execOpts = append(execOpts, executor.WithWorkflowLogger(taskLogger))
+
 			// Create persistent publisher for database event logging
 			persistentPub := events.NewPersistentPublisher(backend, "cli", nil)
 			defer persistentPub.Close()
@@ -501,7 +506,8 @@ Use --force to resume a task even if it appears to still be running.`,
 			if err != nil {
 				if ctx.Err() != nil {
 					disp.TaskInterrupted()
-					return nil
+					cmd.SilenceErrors = true
+					return withExitCode(ExitInterrupted, ctx.Err())
 				}
 
 				// Check if task is blocked (phases succeeded but completion failed)
@@ -513,11 +519,14 @@ Use --force to resume a task even if it appears to still be running.`,
 					}
 					blockedCtx := buildBlockedContextProto(t, cfg, projectRoot)
 					disp.TaskBlockedWithContext(task.GetTotalTokensProto(t), taskElapsedProto(t), "sync conflict", blockedCtx)
-					return nil // Not a fatal error - task execution succeeded
+					// Not a fatal error - task execution succeeded, but wrappers
+					// still need to tell blocked apart from done
+					cmd.SilenceErrors = true
+					return withExitCode(ExitTaskBlocked, executor.ErrTaskBlocked)
 				}
 
 				disp.TaskFailed(err)
-				return err
+				return withExitCode(ExitTaskFailed, err)
 			}
 
 			// Reload task for summary (execution state is in task.Execution)
@@ -85,7 +85,7 @@ See also:
 	// Configuration flags
 	cmd.Flags().StringP("instructions", "i", "", "Additional instructions for this run")
 	cmd.Flags().StringP("category", "c", "feature", "Task category (feature, bug, refactor, chore, docs, test)")
-	cmd.Flags().StringP("profile", "p", "", "Automation profile (auto, fast, safe, strict)")
+	cmd.Flags().StringP("profile", "p", "", "Automation profile (auto, fast, safe, strict) or named preset from ~/.orc/profiles")
 	cmd.Flags().String("provider", "", "LLM provider override for this run (claude, codex)")
 	cmd.Flags().Bool("stream", false, "Stream Claude output in real-time")
 	cmd.Flags().Bool("force", false, "Run despite incomplete dependencies")
@@ -144,9 +144,13 @@ func runRun(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	// Apply profile if specified
+	// Apply a built-in profile or named preset for this run only
 	if profile != "" {
-		orcConfig.ApplyProfile(config.AutomationProfile(profile))
+		namedProfile, err := config.LoadNamedProfile(profile)
+		if err != nil {
+			return err
+		}
+		namedProfile.Apply(orcConfig)
 	}
 
 	// Open databases
@@ -282,6 +286,11 @@ func runRun(cmd *cobra.Command, args []string) error {
 		executor.WithWorkflowTokenRates(executor.ProviderRatesForConfig(orcConfig)),
 	}
 
+	// Execution log for `orc logs`; a new task is routed by its ID once created
+	taskLogger, closeTaskLog := openTaskLog(projectRoot, existingTaskID)
+	defer closeTaskLog()
+	execOpts = append(execOpts, executor.WithWorkflowLogger(taskLogger))
+
 	if skipGates {
 		execOpts = append(execOpts, executor.WithSkipGates(true))
 	}
@@ -365,9 +374,10 @@ func runRun(cmd *cobra.Command, args []string) error {
 		if ctx.Err() != nil {
 			disp.TaskInterrupted()
 			fmt.Println("\nUse 'orc runs' to see status, 'orc resume' to continue.")
-			return nil
+			cmd.SilenceErrors = true
+			return withExitCode(ExitInterrupted, ctx.Err())
 		}
-		return fmt.Errorf("workflow execution failed: %w", err)
+		return withExitCode(ExitTaskFailed, fmt.Errorf("workflow execution failed: %w", err))
 	}
 
 	// Display results
@@ -395,18 +405,18 @@ func checkTaskCanRunProto(t *orcv1.Task, force bool) error {
 
 	switch t.Status {
 	case orcv1.TaskStatus_TASK_STATUS_PAUSED:
-		return fmt.Errorf("task %s is paused\n\nTo resume: orc resume %s", t.Id, t.Id)
+		return withExitCode(ExitPrecondition, fmt.Errorf("task %s is paused\n\nTo resume: orc resume %s", t.Id, t.Id))
 	case orcv1.TaskStatus_TASK_STATUS_BLOCKED:
-		return fmt.Errorf("task %s is blocked and needs user input\n\nTo view: orc show %s", t.Id, t.Id)
+		return withExitCode(ExitTaskBlocked, fmt.Errorf("task %s is blocked and needs user input\n\nTo view: orc show %s", t.Id, t.Id))
 	case orcv1.TaskStatus_TASK_STATUS_COMPLETED:
 		if force {
 			return nil
 		}
-		return fmt.Errorf("task %s is already completed\n\nTo rerun: use --force flag", t.Id)
+		return withExitCode(ExitPrecondition, fmt.Errorf("task %s is already completed\n\nTo rerun: use --force flag", t.Id))
 	case orcv1.TaskStatus_TASK_STATUS_FAILED:
-		return fmt.Errorf("task %s has failed\n\nTo resume: orc resume %s\nTo view log: orc log %s", t.Id, t.Id, t.Id)
+		return withExitCode(ExitPrecondition, fmt.Errorf("task %s has failed\n\nTo resume: orc resume %s\nTo view log: orc log %s", t.Id, t.Id, t.Id))
 	default:
-		return fmt.Errorf("task cannot be run (status: %s)", task.StatusFromProto(t.Status))
+		return withExitCode(ExitPrecondition, fmt.Errorf("task cannot be run (status: %s)", task.StatusFromProto(t.Status)))
 	}
 }
 
@@ -450,5 +460,5 @@ func checkTaskDependenciesProto(backend storage.Backend, t *orcv1.Task, force bo
 		fmt.Printf("    - %s: %s (%s)\n", b.ID, b.Title, b.Status)
 	}
 	fmt.Println("\nUse --force to run anyway")
-	return fmt.Errorf("task is blocked by incomplete dependencies")
+	return withExitCode(ExitTaskBlocked, fmt.Errorf("task is blocked by incomplete dependencies"))
 }
@@ -14,23 +14,32 @@ import (
 	"github.com/randalmurphal/orc/internal/db"
 )
 
-// newSearchCmd creates the search command for full-text search across transcripts.
+// newSearchCmd creates the search command for full-text search across tasks,
+// specs, and transcripts.
 func newSearchCmd() *cobra.Command {
 	var limit int
 	var taskID string
+	var kinds []string
 
 	cmd := &cobra.Command{
 		Use:   "search <query>",
-		Short: "Search transcripts using full-text search",
-		Long: `Search across all task transcripts using full-text search.
+		Short: "Search tasks, specs, and transcripts using full-text search",
+		Long: `Search task titles and descriptions, specs, and transcripts using full-text search.
 
-Uses FTS5 (SQLite) or ILIKE (PostgreSQL) to find matching content.
-Returns up to 50 results by default, sorted by relevance.
+Uses FTS5 (SQLite) or tsvector indexes (PostgreSQL) to find matching content.
+Returns up to 50 results by default, best matches first.
+
+Kinds:
+  task        Task titles and descriptions
+  spec        Spec phase output
+  transcript  Agent transcripts
 
 Examples:
   orc search "error handling"
   orc search "authentication" --limit 10
-  orc search "API" --task TASK-001`,
+  orc search "API" --task TASK-001
+  orc search "rate limit" --kind task,spec
+  orc search "oauth" --json`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := config.RequireInit(); err != nil {
@@ -50,54 +59,49 @@ Examples:
 				// Provide user-friendly message for common error cases
 				if os.IsNotExist(err) || strings.Contains(err.Error(), "no such file") ||
 					strings.Contains(err.Error(), "database") {
-					return errors.New("no transcripts indexed yet - run a task first to enable search")
+					return errors.New("nothing indexed yet - create a task first to enable search")
 				}
 				return fmt.Errorf("open project database: %w", err)
 			}
 			defer func() { _ = pdb.Close() }()
 
-			// Perform search
-			matches, err := pdb.SearchTranscripts(query)
+			matches, err := pdb.Search(db.SearchOpts{
+				Query:  query,
+				Kinds:  kinds,
+				TaskID: taskID,
+				Limit:  limit,
+			})
 			if err != nil {
-				return fmt.Errorf("search transcripts: %w", err)
+				return fmt.Errorf("search: %w", err)
 			}
 
-			if len(matches) == 0 {
-				fmt.Printf("No matches found for: %s\n", query)
-				return nil
+			if jsonOut {
+				return outputJSON(cmd, matches)
 			}
 
-			// Filter by task ID if specified
-			if taskID != "" {
-				filtered := make([]db.TranscriptMatch, 0)
-				for _, m := range matches {
-					if m.TaskID == taskID {
-						filtered = append(filtered, m)
-					}
-				}
-				matches = filtered
-				if len(matches) == 0 {
+			if len(matches) == 0 {
+				if taskID != "" {
 					fmt.Printf("No matches found for \"%s\" in task %s\n", query, taskID)
-					return nil
+				} else {
+					fmt.Printf("No matches found for: %s\n", query)
 				}
-			}
-
-			// Apply limit
-			if limit > 0 && len(matches) > limit {
-				matches = matches[:limit]
+				return nil
 			}
 
 			// Print results
 			fmt.Printf("Found %d match(es) for: %s\n\n", len(matches), query)
 
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			_, _ = fmt.Fprintln(w, "TASK\tPHASE\tSNIPPET")
-			_, _ = fmt.Fprintln(w, "────\t─────\t───────")
+			_, _ = fmt.Fprintln(w, "KIND\tTASK\tWHERE\tSNIPPET")
+			_, _ = fmt.Fprintln(w, "────\t────\t─────\t───────")
 
 			for _, m := range matches {
-				// Clean up snippet - remove newlines and truncate
+				where := m.Field
+				if m.Phase != "" {
+					where = m.Phase
+				}
 				snippet := cleanSnippet(m.Snippet, 60)
-				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", m.TaskID, m.Phase, snippet)
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", m.Kind, m.TaskID, where, snippet)
 			}
 
 			_ = w.Flush()
@@ -107,6 +111,7 @@ Examples:
 
 	cmd.Flags().IntVarP(&limit, "limit", "n", 50, "Maximum number of results")
 	cmd.Flags().StringVarP(&taskID, "task", "t", "", "Filter results to a specific task")
+	cmd.Flags().StringSliceVarP(&kinds, "kind", "k", nil, "Only search these kinds (task, spec, transcript)")
 
 	return cmd
 }
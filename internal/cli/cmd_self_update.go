@@ -0,0 +1,273 @@
+package cli
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/selfupdate"
+)
+
+// updateCheckTimeout bounds the background release check.
+const updateCheckTimeout = 3 * time.Second
+
+// updateNoticeWait is how long a command waits at exit for a background
+// check still fetching the feed; afterwards the cached result is used.
+const updateNoticeWait = 500 * time.Millisecond
+
+// skipUpdateNotice suppresses the startup notice for commands that report
+// versions themselves.
+var skipUpdateNotice bool
+
+// updateRelease is a release in self-update JSON output.
+type updateRelease struct {
+	Version string `json:"version"`
+	URL     string `json:"url,omitempty"`
+	Notes   string `json:"notes,omitempty"`
+}
+
+// updateResult is the self-update JSON output.
+type updateResult struct {
+	Current         string          `json:"current"`
+	Latest          string          `json:"latest,omitempty"`
+	UpdateAvailable bool            `json:"update_available"`
+	Installed       bool            `json:"installed"`
+	Path            string          `json:"path,omitempty"`
+	Releases        []updateRelease `json:"releases"`
+}
+
+// newSelfUpdateCmd creates the self-update command
+func newSelfUpdateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "self-update",
+		Short: "Update orc to the latest release",
+		Long: `Check the release feed and replace this orc binary with the latest release.
+
+The changelog of every release since the installed version is shown first.
+The archive for this platform is verified against the release's checksums.txt
+before anything is replaced; when updates.public_key is set, checksums.txt
+must also carry a valid ed25519 signature (checksums.txt.sig).
+
+orc also checks for new releases in the background, at most once a day, and
+prints a notice after commands when one exists. Disable it with
+updates.check: false or ORC_UPDATES_CHECK=false. With updates.auto_download,
+a newer release is downloaded and verified in the background so this command
+installs it without downloading again.
+
+Examples:
+  orc self-update           # Show the changelog and install the latest release
+  orc self-update --check   # Only report whether an update is available
+  orc self-update --force   # Reinstall even if already up to date`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			skipUpdateNotice = true
+			checkOnly, _ := cmd.Flags().GetBool("check")
+			force, _ := cmd.Flags().GetBool("force")
+
+			cfg, err := config.Load()
+			if err != nil {
+				cfg = config.Default()
+			}
+			publicKey, err := selfupdate.ParsePublicKey(cfg.Updates.PublicKey)
+			if err != nil {
+				return err
+			}
+
+			client := selfupdate.NewClient(cfg.Updates.FeedURL)
+			res, err := client.Check(cmd.Context(), version)
+			if err != nil {
+				return err
+			}
+			if dir, err := selfupdate.Dir(); err == nil {
+				saveUpdateState(dir, res)
+			}
+
+			out := updateResult{Current: res.Current, UpdateAvailable: res.UpdateAvailable(), Releases: []updateRelease{}}
+			if res.Latest != nil {
+				out.Latest = res.Latest.Version()
+			}
+			for _, r := range res.Newer {
+				out.Releases = append(out.Releases, updateRelease{Version: r.Version(), URL: r.URL, Notes: strings.TrimSpace(r.Notes)})
+			}
+
+			w := cmd.OutOrStdout()
+			if !jsonOut {
+				printUpdateSummary(w, res)
+			}
+			if checkOnly || res.Latest == nil || (!res.UpdateAvailable() && !force) {
+				if jsonOut {
+					return outputJSON(cmd, out)
+				}
+				return nil
+			}
+
+			exe, err := selfupdate.Executable()
+			if err != nil {
+				return fmt.Errorf("locate orc binary: %w", err)
+			}
+			staged, err := stageRelease(cmd.Context(), client, res.Latest, publicKey)
+			if err != nil {
+				return err
+			}
+			if err := selfupdate.Install(staged, exe); err != nil {
+				return err
+			}
+			_ = os.Remove(staged)
+
+			out.Installed = true
+			out.Path = exe
+			if jsonOut {
+				return outputJSON(cmd, out)
+			}
+			_, _ = fmt.Fprintf(w, "Installed orc %s to %s\n", res.Latest.Version(), exe)
+			return nil
+		},
+	}
+	cmd.Flags().Bool("check", false, "only check for updates and show the changelog")
+	cmd.Flags().Bool("force", false, "reinstall the latest release even if already up to date")
+	return cmd
+}
+
+// printUpdateSummary prints the version comparison and changelog.
+func printUpdateSummary(w io.Writer, res *selfupdate.Result) {
+	if res.Latest == nil {
+		_, _ = fmt.Fprintln(w, "No releases found")
+		return
+	}
+	if !res.UpdateAvailable() {
+		_, _ = fmt.Fprintf(w, "orc %s is up to date (latest: %s)\n", res.Current, res.Latest.Version())
+		return
+	}
+	_, _ = fmt.Fprintf(w, "orc %s → %s\n", res.Current, res.Latest.Version())
+	for _, r := range res.Newer {
+		_, _ = fmt.Fprintf(w, "\n## %s", r.Version())
+		if !r.PublishedAt.IsZero() {
+			_, _ = fmt.Fprintf(w, " (%s)", r.PublishedAt.Format("2006-01-02"))
+		}
+		_, _ = fmt.Fprintln(w)
+		if notes := strings.TrimSpace(r.Notes); notes != "" {
+			_, _ = fmt.Fprintln(w, notes)
+		}
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// stageRelease returns a verified binary for rel, reusing a background
+// download when one exists.
+func stageRelease(ctx context.Context, client *selfupdate.Client, rel *selfupdate.Release, publicKey ed25519.PublicKey) (string, error) {
+	dir, err := selfupdate.Dir()
+	if err != nil {
+		return "", err
+	}
+	staged := selfupdate.StagedPath(dir, rel.Tag)
+	if _, err := os.Stat(staged); err == nil {
+		return staged, nil
+	}
+	if err := client.Download(ctx, rel, publicKey, staged); err != nil {
+		return "", err
+	}
+	return staged, nil
+}
+
+func saveUpdateState(dir string, res *selfupdate.Result) {
+	state := &selfupdate.State{CheckedAt: time.Now()}
+	if res.Latest != nil {
+		state.Latest = res.Latest.Version()
+		state.URL = res.Latest.URL
+	}
+	_ = selfupdate.SaveState(dir, state)
+}
+
+// startUpdateCheck starts the background release check and returns a func
+// that prints a notice to stderr if a newer release exists. The command is
+// never delayed by more than updateNoticeWait, and only on the one run per
+// day that queries the feed.
+func startUpdateCheck() func() {
+	if !updateCheckWanted() {
+		return func() {}
+	}
+	dir, err := selfupdate.Dir()
+	if err != nil {
+		return func() {}
+	}
+
+	done := make(chan *selfupdate.State, 1)
+	go func() {
+		done <- refreshUpdateState(dir)
+	}()
+
+	return func() {
+		if skipUpdateNotice || jsonOut || quiet {
+			return
+		}
+		var state *selfupdate.State
+		select {
+		case state = <-done:
+		case <-time.After(updateNoticeWait):
+			state = selfupdate.LoadState(dir)
+		}
+		if state == nil || state.Latest == "" || selfupdate.Compare(state.Latest, version) <= 0 {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "\nA new release of orc is available: %s → %s\n", strings.TrimPrefix(version, "v"), state.Latest)
+		fmt.Fprintln(os.Stderr, "Run 'orc self-update' to install it ('--check' shows the changelog).")
+		if state.URL != "" {
+			fmt.Fprintln(os.Stderr, state.URL)
+		}
+	}
+}
+
+// updateCheckWanted reports whether this run should check for releases:
+// release builds on an interactive terminal, outside CI.
+func updateCheckWanted() bool {
+	if !selfupdate.IsRelease(version) || os.Getenv("CI") != "" {
+		return false
+	}
+	return isatty.IsTerminal(os.Stderr.Fd())
+}
+
+// refreshUpdateState returns the cached check result, querying the feed
+// when it is stale. Returns nil when checks are disabled.
+func refreshUpdateState(dir string) *selfupdate.State {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.Default()
+	}
+	if !cfg.Updates.Check {
+		return nil
+	}
+	state := selfupdate.LoadState(dir)
+	if state.Fresh(time.Now()) {
+		return state
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+	defer cancel()
+	client := selfupdate.NewClient(cfg.Updates.FeedURL)
+	res, err := client.Check(ctx, version)
+	if err != nil {
+		// Retry tomorrow rather than on every command while offline
+		state.CheckedAt = time.Now()
+		_ = selfupdate.SaveState(dir, state)
+		return state
+	}
+	saveUpdateState(dir, res)
+	state = selfupdate.LoadState(dir)
+
+	if cfg.Updates.AutoDownload && res.UpdateAvailable() {
+		if publicKey, err := selfupdate.ParsePublicKey(cfg.Updates.PublicKey); err == nil {
+			// Runs until the command exits; a partial download is discarded
+			go func() {
+				_, _ = stageRelease(context.Background(), client, res.Latest, publicKey)
+			}()
+		}
+	}
+	return state
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/selfupdate"
+)
+
+func TestSelfUpdateCommand_CheckShowsChangelog(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := withStatusTestDir(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]selfupdate.Release{
+			{Tag: "v0.2.0", Notes: "- gate fixes"},
+			{Tag: "v0.3.0", Notes: "- faster runs", URL: "https://example.com/v0.3.0"},
+		})
+	}))
+	defer srv.Close()
+	cfg := "version: 1\nupdates:\n  feed_url: " + srv.URL + "\n"
+	if err := os.WriteFile(filepath.Join(dir, ".orc", "config.yaml"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	cmd := newSelfUpdateCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--check"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	got := out.String()
+	for _, want := range []string{"→ 0.3.0", "## 0.3.0", "- faster runs", "## 0.2.0", "- gate fixes"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "0.3.0\n") > strings.Index(got, "## 0.2.0") {
+		t.Errorf("changelog not newest first:\n%s", got)
+	}
+
+	// The check is cached for the startup notice
+	updatesDir, _ := selfupdate.Dir()
+	if state := selfupdate.LoadState(updatesDir); state.Latest != "0.3.0" || !state.Fresh(state.CheckedAt) {
+		t.Errorf("state = %+v", state)
+	}
+}
@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+// newServerCmd creates the server command for API server administration
+func newServerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "server",
+		Short: "Administer the API server",
+		Long: `Administer the API server started by 'orc serve'.
+
+Commands:
+  token      Issue, rotate, list, and revoke API tokens`,
+	}
+
+	cmd.AddCommand(newServerTokenCmd())
+
+	return cmd
+}
+
+func newServerTokenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Manage API tokens for server token auth",
+		Long: `Manage API tokens for the server.
+
+With server.auth.enabled, every mutating API request must carry a token
+(Authorization: Bearer <token>); with server.auth.require_for_reads, reads
+and event streams do too. /api/health is always public. Missing or invalid
+tokens get 401; read-only tokens get 403 on mutating requests.
+
+Tokens are stored as hashes in the global database (~/.orc/orc.db) and
+shared by every project served from this machine. CLI commands that talk to
+the server send the token from ORC_API_TOKEN.
+
+Example:
+  orc server token create ci
+  orc server token create dashboard --read-only
+  orc server token rotate ci`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withGlobalDB(runServerTokenList)
+		},
+	}
+
+	create := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Issue an API token (shown once)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			readOnly, _ := cmd.Flags().GetBool("read-only")
+			scope := db.APITokenScopeWrite
+			if readOnly {
+				scope = db.APITokenScopeRead
+			}
+			return withGlobalDB(func(g *db.GlobalDB) error {
+				token, err := g.CreateAPIToken(args[0], scope)
+				if err != nil {
+					return err
+				}
+				return printServerToken(args[0], scope, token)
+			})
+		},
+	}
+	create.Flags().Bool("read-only", false, "token can only read (403 on mutating requests)")
+	cmd.AddCommand(create)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "rotate <name>",
+		Short: "Replace a token's secret (the old one stops working)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withGlobalDB(func(g *db.GlobalDB) error {
+				token, err := g.RotateAPIToken(args[0])
+				if err != nil {
+					return err
+				}
+				return printServerToken(args[0], "", token)
+			})
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List API tokens",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withGlobalDB(runServerTokenList)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "revoke <name>",
+		Short: "Revoke an API token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withGlobalDB(func(g *db.GlobalDB) error {
+				if err := g.RevokeAPIToken(args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("Revoked token %s\n", args[0])
+				return nil
+			})
+		},
+	})
+
+	return cmd
+}
+
+func printServerToken(name, scope, token string) error {
+	if jsonOut {
+		out := map[string]string{"name": name, "token": token}
+		if scope != "" {
+			out["scope"] = scope
+		}
+		return json.NewEncoder(os.Stdout).Encode(out)
+	}
+	fmt.Println(token)
+	fmt.Fprintln(os.Stderr, "Store this token now; it cannot be shown again.")
+	return nil
+}
+
+func runServerTokenList(g *db.GlobalDB) error {
+	tokens, err := g.ListAPITokens()
+	if err != nil {
+		return err
+	}
+	if jsonOut {
+		if tokens == nil {
+			tokens = []db.APIToken{}
+		}
+		return json.NewEncoder(os.Stdout).Encode(tokens)
+	}
+	if len(tokens) == 0 {
+		fmt.Println("No API tokens. Create one with: orc server token create <name>")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tSCOPE\tCREATED\tROTATED\tLAST USED")
+	for _, tok := range tokens {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", tok.Name, tok.Scope,
+			tok.CreatedAt.Format("2006-01-02 15:04"), formatTokenTime(tok.RotatedAt), formatTokenTime(tok.LastUsedAt))
+	}
+	return w.Flush()
+}
+
+func formatTokenTime(ts *time.Time) string {
+	if ts == nil {
+		return "never"
+	}
+	return ts.Format("2006-01-02 15:04")
+}
+
+// apiTokenEnvVar holds the token CLI commands send to an orc server.
+const apiTokenEnvVar = "ORC_API_TOKEN"
+
+// serverClientOptions returns the Connect client options for talking to an
+// orc server: the ORC_API_TOKEN bearer token when set.
+func serverClientOptions() []connect.ClientOption {
+	token := strings.TrimSpace(os.Getenv(apiTokenEnvVar))
+	if token == "" {
+		return nil
+	}
+	return []connect.ClientOption{connect.WithInterceptors(bearerTokenInterceptor(token))}
+}
+
+// bearerTokenInterceptor adds an Authorization header to unary and
+// streaming client calls.
+type bearerTokenInterceptor string
+
+func (t bearerTokenInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		req.Header().Set("Authorization", "Bearer "+string(t))
+		return next(ctx, req)
+	}
+}
+
+func (t bearerTokenInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		conn.RequestHeader().Set("Authorization", "Bearer "+string(t))
+		return conn
+	}
+}
+
+func (t bearerTokenInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
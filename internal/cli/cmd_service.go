@@ -0,0 +1,320 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/service"
+)
+
+// serviceHealthTimeout bounds the readiness probe in `orc service status`.
+const serviceHealthTimeout = 2 * time.Second
+
+// serviceStatus is the service status JSON output.
+type serviceStatus struct {
+	*service.Status
+	// Health is the server's /api/health/ready status, or "unreachable".
+	Health string `json:"health,omitempty"`
+}
+
+// newServiceCmd creates the service command
+func newServiceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "service",
+		Short: "Run 'orc serve' as a systemd or launchd service",
+		Long: `Install, inspect and remove an OS service that keeps 'orc serve' running.
+
+On Linux this writes a systemd unit (a user unit in ~/.config/systemd/user
+by default, or a system unit in /etc/systemd/system with --system); on macOS
+a launchd agent in ~/Library/LaunchAgents. The service:
+
+  • runs 'orc serve' in the project directory on a fixed port
+  • loads tokens and database settings from an env file
+    (~/.orc/service/<name>.env, created with commented examples, mode 0600)
+  • appends stdout/stderr to ~/.orc/service/<name>.log
+  • restarts after crashes (5s delay; systemd gives up after 5 in 5 minutes)
+  • keeps the PATH of the installing shell, so claude and git are found
+
+User units only run while you are logged in unless lingering is enabled
+('loginctl enable-linger $USER').
+
+Commands:
+  install     Write the unit and start it
+  status      Show whether it is installed, running and healthy
+  uninstall   Stop it and remove the unit (env and log files are kept)`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServiceStatus(cmd)
+		},
+	}
+	cmd.PersistentFlags().String("name", service.DefaultName, "service name (systemd unit <name>.service, launchd label dev.orc.<name>)")
+	cmd.PersistentFlags().Bool("system", false, "system-wide systemd unit in /etc/systemd/system (needs root)")
+	cmd.AddCommand(newServiceInstallCmd())
+	cmd.AddCommand(newServiceStatusCmd())
+	cmd.AddCommand(newServiceUninstallCmd())
+	return cmd
+}
+
+func newServiceInstallCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install and start a service running 'orc serve' for this project",
+		Long: `Install and start a service running 'orc serve' for this project.
+
+An existing unit is only replaced with --force; the env file is never
+overwritten. With --dry-run the unit is printed instead of installed.
+
+Examples:
+  orc service install                       # User service on server.port (default 8080)
+  orc service install --port 9090 --force   # Change the port of an installed service
+  sudo orc service install --system --run-as orc
+  orc service install --dry-run             # Print the unit`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := serviceOptions(cmd)
+			if err != nil {
+				return err
+			}
+			w := cmd.OutOrStdout()
+			if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+				content, err := service.Render(opts)
+				if err != nil {
+					return err
+				}
+				_, err = w.Write(content)
+				return err
+			}
+
+			noStart, _ := cmd.Flags().GetBool("no-start")
+			force, _ := cmd.Flags().GetBool("force")
+			res, err := service.Install(opts, !noStart, force)
+			if res != nil {
+				for _, c := range res.Commands {
+					_, _ = fmt.Fprintf(w, "$ %s\n", strings.Join(c, " "))
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(w, "Installed %s\n", res.Path)
+			if res.EnvCreated {
+				_, _ = fmt.Fprintf(w, "Created env file %s; add tokens there and restart the service\n", res.EnvFile)
+			} else {
+				_, _ = fmt.Fprintf(w, "Env file:  %s\n", res.EnvFile)
+			}
+			_, _ = fmt.Fprintf(w, "Log file:  %s\n", res.LogFile)
+			if noStart {
+				_, _ = fmt.Fprintln(w, "Not started; it starts at the next boot or login")
+			} else {
+				_, _ = fmt.Fprintf(w, "Serving on port %d; check it with 'orc service status'\n", opts.Port)
+			}
+			if opts.Manager == service.Systemd && !opts.System {
+				_, _ = fmt.Fprintln(w, "To keep it running after logout: loginctl enable-linger $USER")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().IntP("port", "p", 0, "port to serve on (default: server.port, or 8080)")
+	cmd.Flags().Bool("read-only", false, "serve read-only (dashboard-only instance)")
+	cmd.Flags().String("env-file", "", "env file loaded before starting (default: ~/.orc/service/<name>.env)")
+	cmd.Flags().String("log-file", "", "file receiving server output (default: ~/.orc/service/<name>.log)")
+	cmd.Flags().String("run-as", "", "account a --system unit runs as (default: $SUDO_USER, or the current user)")
+	cmd.Flags().Bool("no-start", false, "install and enable without starting now")
+	cmd.Flags().Bool("force", false, "replace an existing unit")
+	cmd.Flags().Bool("dry-run", false, "print the unit instead of installing it")
+	return cmd
+}
+
+func newServiceStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show whether the service is installed, running and healthy",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServiceStatus(cmd)
+		},
+	}
+}
+
+func newServiceUninstallCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "uninstall",
+		Short: "Stop the service and remove its unit",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			unit, err := serviceUnit(cmd)
+			if err != nil {
+				return err
+			}
+			path, err := service.Uninstall(unit)
+			if errors.Is(err, service.ErrNotInstalled) {
+				return fmt.Errorf("no service installed at %s", path)
+			}
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Removed %s (env and log files kept)\n", path)
+			return nil
+		},
+	}
+}
+
+// serviceUnit resolves the unit from the persistent flags.
+func serviceUnit(cmd *cobra.Command) (service.Unit, error) {
+	manager, err := service.DefaultManager()
+	if err != nil {
+		return service.Unit{}, err
+	}
+	name, _ := cmd.Flags().GetString("name")
+	system, _ := cmd.Flags().GetBool("system")
+	return service.Unit{Manager: manager, Name: name, System: system}, nil
+}
+
+// serviceOptions builds install options from flags, config and the
+// environment.
+func serviceOptions(cmd *cobra.Command) (service.Options, error) {
+	unit, err := serviceUnit(cmd)
+	if err != nil {
+		return service.Options{}, err
+	}
+	projectRoot, err := ResolveProjectPath()
+	if err != nil {
+		return service.Options{}, withExitCode(ExitNotInitialized, fmt.Errorf("not in an orc project (run 'orc init' first): %w", err))
+	}
+	binary, err := os.Executable()
+	if err != nil {
+		return service.Options{}, fmt.Errorf("locate orc binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(binary); err == nil {
+		binary = resolved
+	}
+
+	opts := service.Options{
+		Unit:       unit,
+		Binary:     binary,
+		WorkDir:    projectRoot,
+		SearchPath: os.Getenv("PATH"),
+	}
+	opts.ReadOnly, _ = cmd.Flags().GetBool("read-only")
+	if opts.Port, _ = cmd.Flags().GetInt("port"); opts.Port == 0 {
+		if cfg, err := config.LoadFrom(projectRoot); err == nil {
+			opts.Port = cfg.Server.Port
+		}
+		if opts.Port <= 0 {
+			opts.Port = 8080
+		}
+	}
+
+	if opts.EnvFile, _ = cmd.Flags().GetString("env-file"); opts.EnvFile == "" {
+		if opts.EnvFile, err = service.DefaultEnvFile(unit.Name); err != nil {
+			return opts, err
+		}
+	}
+	if opts.LogFile, _ = cmd.Flags().GetString("log-file"); opts.LogFile == "" {
+		if opts.LogFile, err = service.DefaultLogFile(unit.Name); err != nil {
+			return opts, err
+		}
+	}
+	for _, p := range []*string{&opts.EnvFile, &opts.LogFile} {
+		if *p, err = filepath.Abs(config.ExpandPath(*p)); err != nil {
+			return opts, err
+		}
+	}
+
+	if unit.System {
+		opts.RunAs, _ = cmd.Flags().GetString("run-as")
+		if opts.RunAs == "" {
+			opts.RunAs = os.Getenv("SUDO_USER")
+		}
+		if opts.RunAs == "" {
+			if u, err := user.Current(); err == nil {
+				opts.RunAs = u.Username
+			}
+		}
+	}
+	return opts, nil
+}
+
+func runServiceStatus(cmd *cobra.Command) error {
+	unit, err := serviceUnit(cmd)
+	if err != nil {
+		return err
+	}
+	st, err := service.GetStatus(unit)
+	if err != nil {
+		return err
+	}
+	out := serviceStatus{Status: st}
+	if st.Running() && st.Port > 0 {
+		out.Health = probeServiceHealth(cmd.Context(), st.Port)
+	}
+	if jsonOut {
+		return outputJSON(cmd, out)
+	}
+
+	w := cmd.OutOrStdout()
+	if !st.Installed {
+		_, _ = fmt.Fprintf(w, "Service %q is not installed (%s)\n", st.Name, st.Path)
+		_, _ = fmt.Fprintln(w, "Install it with 'orc service install'")
+		return nil
+	}
+	_, _ = fmt.Fprintf(w, "Service:  %s (%s)\n", st.Name, st.Manager)
+	_, _ = fmt.Fprintf(w, "Unit:     %s\n", st.Path)
+	state := st.State
+	if st.SubState != "" {
+		state += " (" + st.SubState + ")"
+	}
+	_, _ = fmt.Fprintf(w, "State:    %s\n", state)
+	if st.PID > 0 {
+		_, _ = fmt.Fprintf(w, "PID:      %d\n", st.PID)
+	}
+	if st.Since != "" && st.Running() {
+		_, _ = fmt.Fprintf(w, "Since:    %s\n", st.Since)
+	}
+	if st.Restarts > 0 {
+		_, _ = fmt.Fprintf(w, "Restarts: %d\n", st.Restarts)
+	}
+	if st.LastExit != "" && !st.Running() {
+		_, _ = fmt.Fprintf(w, "Last exit: %s\n", st.LastExit)
+	}
+	if st.Port > 0 {
+		_, _ = fmt.Fprintf(w, "Port:     %d\n", st.Port)
+	}
+	if out.Health != "" {
+		_, _ = fmt.Fprintf(w, "Health:   %s\n", out.Health)
+	}
+	return nil
+}
+
+// probeServiceHealth asks the running server for its readiness status.
+func probeServiceHealth(ctx context.Context, port int) string {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, serviceHealthTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://127.0.0.1:%d/api/health/ready", port), nil)
+	if err != nil {
+		return "unreachable"
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "unreachable"
+	}
+	defer func() { _ = resp.Body.Close() }()
+	var report struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil || report.Status == "" {
+		return fmt.Sprintf("unknown (HTTP %d)", resp.StatusCode)
+	}
+	return report.Status
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestServiceCommand_DryRunAndStatus(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("orc service needs systemd or launchd")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := withStatusTestDir(t)
+	if err := os.WriteFile(filepath.Join(dir, ".orc", "config.yaml"), []byte("version: 1\nserver:\n  port: 9191\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		cmd := newServiceCmd()
+		cmd.SetOut(&out)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("service %v: %v", args, err)
+		}
+		return out.String()
+	}
+
+	unit := run("install", "--dry-run", "--read-only")
+	for _, want := range []string{"serve --port 9191 --max-port-attempts 1 --read-only", filepath.Join(home, ".orc", "service", "orc.env")} {
+		if !strings.Contains(unit, want) {
+			t.Errorf("dry-run unit missing %q:\n%s", want, unit)
+		}
+	}
+	if entries, _ := os.ReadDir(filepath.Join(home, ".orc")); len(entries) != 0 {
+		t.Errorf("dry run wrote files: %v", entries)
+	}
+
+	if got := run("status", "--name", "other"); !strings.Contains(got, `Service "other" is not installed`) {
+		t.Errorf("status of missing service:\n%s", got)
+	}
+}
@@ -0,0 +1,260 @@
+// Package cli implements the orc command-line interface.
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/skills"
+)
+
+func newSkillsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "skills",
+		Short: "Install vetted skills from a central index",
+		Long: `Install, update, and remove SKILL.md bundles from a central skill index.
+
+The index is a git repository with an index.yaml at its root, or an HTTP(S)
+URL of an index.yaml file. Configure it once in ~/.orc/config.yaml:
+
+  skills:
+    index: https://github.com/acme/orc-skills
+
+Skills are installed into .claude/skills/<name>/ (or ~/.claude/skills with
+--user) together with a .orc-provenance.yaml recording the index, version,
+commit, and SKILL.md checksum they came from.
+
+Commands:
+  available  List skills offered by the index
+  install    Install skills from the index
+  list       List installed skills and where they came from
+  update     Update index-installed skills
+  remove     Remove an installed skill`,
+	}
+
+	cmd.PersistentFlags().String("index", "", "skill index (overrides skills.index)")
+	cmd.PersistentFlags().Bool("user", false, "manage ~/.claude/skills instead of the project")
+
+	cmd.AddCommand(newSkillsAvailableCmd())
+	cmd.AddCommand(newSkillsInstallCmd())
+	cmd.AddCommand(newSkillsListCmd())
+	cmd.AddCommand(newSkillsUpdateCmd())
+	cmd.AddCommand(newSkillsRemoveCmd())
+
+	return cmd
+}
+
+func newSkillsAvailableCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "available",
+		Short: "List skills offered by the index",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			src, err := openSkillIndex(cmd)
+			if err != nil {
+				return err
+			}
+			defer src.Close()
+
+			if jsonOut {
+				return json.NewEncoder(os.Stdout).Encode(src.Index)
+			}
+			if len(src.Index.Skills) == 0 {
+				fmt.Printf("Index %s lists no skills.\n", src.Location)
+				return nil
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "NAME\tVERSION\tDESCRIPTION")
+			for _, e := range src.Index.Skills {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", e.Name, e.Version, e.Description)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func newSkillsInstallCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "install <name>...",
+		Short: "Install skills from the index",
+		Long: `Install one or more skills from the configured index.
+
+Reinstalling an index-installed skill replaces it. A skill that already exists
+but was not installed from an index is left alone unless --force is given.
+
+Examples:
+  orc skills install go-testing
+  orc skills install go-testing sql-review --user
+  orc skills install go-testing --index https://example.com/skills/index.yaml`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			installer, err := skillsInstaller(cmd)
+			if err != nil {
+				return err
+			}
+			src, err := openSkillIndex(cmd)
+			if err != nil {
+				return err
+			}
+			defer src.Close()
+
+			var installed []*skills.Provenance
+			for _, name := range args {
+				prov, err := installer.Install(src, name, force)
+				if err != nil {
+					return err
+				}
+				installed = append(installed, prov)
+				if !jsonOut {
+					fmt.Printf("Installed %s %s into %s\n", prov.Name, prov.Version, installer.SkillsDir())
+				}
+			}
+			if jsonOut {
+				return json.NewEncoder(os.Stdout).Encode(map[string]any{"installed": installed})
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "replace skills not installed from an index")
+	return cmd
+}
+
+func newSkillsListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List installed skills and where they came from",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			installer, err := skillsInstaller(cmd)
+			if err != nil {
+				return err
+			}
+			installed, err := installer.List()
+			if err != nil {
+				return err
+			}
+
+			if jsonOut {
+				return json.NewEncoder(os.Stdout).Encode(map[string]any{"skills": installed})
+			}
+			if len(installed) == 0 {
+				fmt.Printf("No skills in %s\n", installer.SkillsDir())
+				return nil
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "NAME\tVERSION\tINSTALLED FROM")
+			for _, sk := range installed {
+				version, from := "", "(local)"
+				if sk.Provenance != nil {
+					version, from = sk.Provenance.Version, sk.Provenance.Index
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", sk.Name, version, from)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func newSkillsUpdateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "update [name]...",
+		Short: "Update index-installed skills",
+		Long: `Re-fetch skills from the index and reinstall any whose version or
+SKILL.md content changed. With no names, updates every skill installed from
+the configured index.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			installer, err := skillsInstaller(cmd)
+			if err != nil {
+				return err
+			}
+			src, err := openSkillIndex(cmd)
+			if err != nil {
+				return err
+			}
+			defer src.Close()
+
+			results, err := installer.Update(src, args)
+			if err != nil {
+				return err
+			}
+			if jsonOut {
+				return json.NewEncoder(os.Stdout).Encode(map[string]any{"results": results})
+			}
+			if len(results) == 0 {
+				fmt.Printf("No skills installed from %s\n", src.Location)
+				return nil
+			}
+			for _, r := range results {
+				if r.Updated {
+					fmt.Printf("Updated %s %s -> %s\n", r.Name, r.From, r.To)
+				} else {
+					fmt.Printf("%s is up to date (%s)\n", r.Name, r.To)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func newSkillsRemoveCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove an installed skill",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			installer, err := skillsInstaller(cmd)
+			if err != nil {
+				return err
+			}
+			if err := installer.Remove(args[0], force); err != nil {
+				return err
+			}
+			fmt.Printf("Removed skill %s\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "remove skills not installed from an index")
+	return cmd
+}
+
+// skillsInstaller returns an installer for the project or, with --user, the
+// home directory.
+func skillsInstaller(cmd *cobra.Command) (*skills.Installer, error) {
+	user, _ := cmd.Flags().GetBool("user")
+	if user {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("get home directory: %w", err)
+		}
+		return skills.NewInstaller(home), nil
+	}
+	projectRoot, err := ResolveProjectPath()
+	if err != nil {
+		return nil, fmt.Errorf("not in an orc project (use --user or run 'orc init' first): %w", err)
+	}
+	return skills.NewInstaller(projectRoot), nil
+}
+
+// openSkillIndex opens --index, falling back to the skills.index config value.
+func openSkillIndex(cmd *cobra.Command) (*skills.Source, error) {
+	location, _ := cmd.Flags().GetString("index")
+	if location == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			return nil, fmt.Errorf("load config: %w", err)
+		}
+		location = cfg.Skills.Index
+	}
+	return skills.Open(location)
+}
@@ -37,6 +37,11 @@ PRIORITY ORDER:
   6. ⏸  Paused      Manually paused (use 'orc resume' to continue)
   7. 📋 Recent      Completed in last 24h
 
+Below the task count, an overview line shows today's cost, pending gates,
+failures in the last 24h, and run queue depth. Queue depth and gate
+decisions come from the server (server.host/server.port) when it is running.
+Running tasks show their current phase and iteration.
+
 DEPENDENCY AWARENESS:
   The status command shows dependency state alongside task state:
   • BLOCKED (waiting on deps) - has incomplete blocked_by tasks
@@ -54,6 +59,7 @@ Examples:
   orc st              # Short alias
   orc status --all    # Include completed tasks
   orc status --watch  # Auto-refresh every 5 seconds
+  orc status --json   # Tasks, summary counts and overview as JSON
 
 See also:
   orc deps     - View dependency relationships
@@ -259,9 +265,17 @@ func showStatus(cmd *cobra.Command, showAll bool) error {
 		return task.PriorityOrderFromProto(task.GetPriorityProto(ready[i])) < task.PriorityOrderFromProto(task.GetPriorityProto(ready[j]))
 	})
 
+	// Project-wide figures; the server, when running, adds queue depth and
+	// pending gate decisions
+	projectRoot, _ := ResolveProjectPath()
+	cfg, _ := config.Load()
+	projectID, _ := ResolveProjectID()
+	overview := buildStatusOverview(projectRoot, tasks, systemBlocked, fetchStatusServerInfo(statusServerURL(cfg), projectID))
+	iterations := currentIterations(backend, running)
+
 	// JSON output mode
 	if jsonOut {
-		return outputStatusJSON(cmd, tasks, orphaned, systemBlocked, running, depBlocked, ready, paused, recent, other)
+		return outputStatusJSON(cmd, tasks, orphaned, systemBlocked, running, depBlocked, ready, paused, recent, other, overview, iterations)
 	}
 
 	// Print sections with priority ordering
@@ -336,17 +350,20 @@ func showStatus(cmd *cobra.Command, showAll bool) error {
 			_, _ = fmt.Fprintln(out, "\u23f3 RUNNING")
 		}
 		_, _ = fmt.Fprintln(out)
-		cfg, _ := config.Load()
 		for _, t := range running {
 			phase := task.GetCurrentPhaseProto(t)
 			if phase == "" {
 				phase = "starting"
 			}
+			label := "[" + phase + "]"
+			if n := iterations[t.Id]; n > 0 {
+				label += fmt.Sprintf(" iter %d", n)
+			}
 			hbStatus := task.FormatHeartbeatStatus(t)
 			if hbStatus != "" {
-				_, _ = fmt.Fprintf(w, "  %s\t%s\t[%s] (%s)\n", t.Id, truncate(t.Title, 40), phase, hbStatus)
+				_, _ = fmt.Fprintf(w, "  %s\t%s\t%s (%s)\n", t.Id, truncate(t.Title, 40), label, hbStatus)
 			} else {
-				_, _ = fmt.Fprintf(w, "  %s\t%s\t[%s]\n", t.Id, truncate(t.Title, 40), phase)
+				_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\n", t.Id, truncate(t.Title, 40), label)
 			}
 			_ = w.Flush()
 			if cfg != nil && cfg.Worktree.Enabled {
@@ -479,6 +496,7 @@ func showStatus(cmd *cobra.Command, showAll bool) error {
 	summaryParts = append(summaryParts, fmt.Sprintf("%d completed", completed))
 
 	_, _ = fmt.Fprintf(out, "─── %d tasks (%s) ───\n", total, strings.Join(summaryParts, ", "))
+	_, _ = fmt.Fprintf(out, "    %s\n", formatStatusOverview(overview))
 
 	return nil
 }
@@ -563,6 +581,7 @@ type statusTaskJSON struct {
 	Priority   string `json:"priority,omitempty"`
 	Initiative string `json:"initiative,omitempty"`
 	Phase      string `json:"phase,omitempty"`
+	Iteration  int    `json:"iteration,omitempty"`
 }
 
 // statusSummaryJSON represents the summary in JSON output
@@ -581,8 +600,9 @@ type statusSummaryJSON struct {
 
 // statusOutputJSON represents the JSON output structure
 type statusOutputJSON struct {
-	Tasks   []statusTaskJSON  `json:"tasks"`
-	Summary statusSummaryJSON `json:"summary"`
+	Tasks    []statusTaskJSON  `json:"tasks"`
+	Summary  statusSummaryJSON `json:"summary"`
+	Overview statusOverview    `json:"overview"`
 }
 
 // convertTaskToJSON converts a proto task to JSON representation
@@ -599,11 +619,13 @@ func convertTaskToJSON(t *orcv1.Task) statusTaskJSON {
 }
 
 // outputStatusJSON outputs status as JSON
-func outputStatusJSON(cmd *cobra.Command, allTasks []*orcv1.Task, orphaned, systemBlocked, running, depBlocked, ready, paused, recent, other []*orcv1.Task) error {
+func outputStatusJSON(cmd *cobra.Command, allTasks []*orcv1.Task, orphaned, systemBlocked, running, depBlocked, ready, paused, recent, other []*orcv1.Task, overview statusOverview, iterations map[string]int) error {
 	// Convert all tasks to JSON format
 	var jsonTasks []statusTaskJSON
 	for _, t := range allTasks {
-		jsonTasks = append(jsonTasks, convertTaskToJSON(t))
+		jt := convertTaskToJSON(t)
+		jt.Iteration = iterations[t.Id]
+		jsonTasks = append(jsonTasks, jt)
 	}
 
 	// Count completed tasks
@@ -628,6 +650,7 @@ func outputStatusJSON(cmd *cobra.Command, allTasks []*orcv1.Task, orphaned, syst
 			Other:     len(other),
 			Completed: completed,
 		},
+		Overview: overview,
 	}
 
 	encoder := json.NewEncoder(cmd.OutOrStdout())
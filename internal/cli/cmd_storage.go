@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+// newStorageCmd creates the storage command.
+func newStorageCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "storage",
+		Short: "Manage task storage",
+		Long: `Manage how orc stores task data.
+
+Commands:
+  sync      Reconcile the database with the task directories
+  migrate   Move task directories into the database and switch to database mode`,
+	}
+	cmd.AddCommand(newStorageSyncCmd())
+	cmd.AddCommand(newStorageMigrateCmd())
+	return cmd
+}
+
+func newStorageSyncCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sync",
+		Short: "Reconcile the database with the task directories",
+		Long: `Reconcile the database with the task directories under .orc/tasks/.
+
+Only applies when storage.mode is files or hybrid and
+storage.files.write_through is set. Directories the database does not know
+are imported. A task.yaml, plan.yaml or spec.md whose content differs from
+what orc last wrote is applied as an edit (task.yaml edits of a running task
+are ignored). Tasks that should have a directory but have none get one.
+
+orc serve runs the same sync when it starts.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			backend, err := getBackend()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = backend.Close() }()
+
+			fb, ok := backend.(*storage.FileBackend)
+			if !ok {
+				return fmt.Errorf("task directories are off: set storage.mode to files or hybrid and storage.files.write_through to true")
+			}
+			if err := fb.Sync(); err != nil {
+				return fmt.Errorf("sync %s: %w", storage.TasksDir, err)
+			}
+			if !quiet {
+				fmt.Printf("Synced %s\n", storage.TasksDir)
+			}
+			return nil
+		},
+	}
+}
+
+func newStorageMigrateCmd() *cobra.Command {
+	var to string
+	var dryRun, force bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate --to database",
+		Short: "Move task directories into the database and switch to database mode",
+		Long: `Import the task directories kept by the files and hybrid storage modes
+into the database.
+
+Every directory under .orc/tasks/ with a task.yaml is read together with its
+plan.yaml, spec.md, transcripts and attachments (directories from older
+versions, with a state.yaml and transcripts/*.md, are read too). Each task
+is saved to the database and read back to verify it. Tasks already in the database are
+skipped unless --force is set. A task that was running is imported as
+paused. The YAML files are left in place; delete .orc/tasks/ once you are
+happy with the result.
+
+When the migration succeeds and .orc/config.yaml sets storage.mode to
+files or hybrid, it is switched to database.
+
+Note: transcripts older than storage.database.retention_days are pruned
+the next time the database is opened.
+
+Examples:
+  orc storage migrate --to database --dry-run   # Report only
+  orc storage migrate --to database
+  orc storage migrate --to database --force     # Overwrite existing tasks`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if to != string(config.StorageModeDatabase) {
+				return fmt.Errorf("unsupported target %q: only --to database is supported", to)
+			}
+			projectRoot, err := ResolveProjectPath()
+			if err != nil {
+				return err
+			}
+			if err := config.RequireInitAt(projectRoot); err != nil {
+				return err
+			}
+
+			tasksDir := filepath.Join(projectRoot, storage.TasksDir)
+			if _, err := os.Stat(tasksDir); os.IsNotExist(err) {
+				return fmt.Errorf("no task directories found at %s", tasksDir)
+			}
+
+			// Open the database alone: the files and hybrid backends would
+			// import and rewrite the directories being migrated.
+			backend, err := storage.NewDatabaseBackend(projectRoot, nil)
+			if err != nil {
+				return fmt.Errorf("open database: %w", err)
+			}
+			report, err := storage.MigrateFilesToDatabase(backend, tasksDir, storage.FilesMigrationOptions{
+				DryRun: dryRun,
+				Force:  force,
+			})
+			_ = backend.Close()
+			if err != nil {
+				return err
+			}
+
+			switched := false
+			if !dryRun && report.Failed == 0 {
+				if switched, err = switchProjectStorageMode(projectRoot); err != nil {
+					return err
+				}
+			}
+
+			if jsonOut {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(report); err != nil {
+					return err
+				}
+			} else {
+				printFilesMigrationReport(report)
+				if switched {
+					fmt.Println("Set storage.mode = database in .orc/config.yaml")
+				}
+			}
+
+			if report.Failed > 0 {
+				return fmt.Errorf("%d task(s) failed to migrate", report.Failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&to, "to", "", "target storage mode (database)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "report what would be migrated without writing")
+	cmd.Flags().BoolVar(&force, "force", false, "overwrite tasks that already exist in the database")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
+
+// switchProjectStorageMode changes storage.mode from files or hybrid to
+// database in the project config. It reports whether the file was changed.
+func switchProjectStorageMode(projectRoot string) (bool, error) {
+	path := filepath.Join(projectRoot, config.OrcDir, config.ConfigFileName)
+	cfg, err := config.LoadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("load project config: %w", err)
+	}
+	if mode := cfg.Storage.Mode; mode != config.StorageModeFiles && mode != config.StorageModeHybrid {
+		return false, nil
+	}
+	cfg.Storage.Mode = config.StorageModeDatabase
+	if err := cfg.SaveTo(path); err != nil {
+		return false, fmt.Errorf("save project config: %w", err)
+	}
+	return true, nil
+}
+
+func printFilesMigrationReport(r *storage.FilesMigrationReport) {
+	if len(r.Tasks) == 0 {
+		fmt.Printf("No task directories found in %s\n", r.Source)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TASK\tACTION\tSTATE\tPLAN\tSPEC\tTRANSCRIPTS\tNOTE")
+	for _, t := range r.Tasks {
+		note := t.Reason
+		if t.Verified {
+			note = "verified"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			t.TaskID, t.Action, yesNo(t.HasState), yesNo(t.HasPlan), yesNo(t.HasSpec), t.Transcripts, note)
+	}
+	_ = w.Flush()
+
+	verb := "Migrated"
+	if r.DryRun {
+		verb = "Would migrate"
+	}
+	fmt.Printf("\n%s %d task(s), skipped %d, failed %d\n", verb, r.Migrated, r.Skipped, r.Failed)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "-"
+}
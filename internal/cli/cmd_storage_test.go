@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+)
+
+func TestSwitchProjectStorageMode(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, ".orc", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("version: 1\nstorage:\n  mode: files\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	switched, err := switchProjectStorageMode(root)
+	if err != nil || !switched {
+		t.Fatalf("switchProjectStorageMode = %v, %v; want true, nil", switched, err)
+	}
+	cfg, err := config.LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Storage.Mode != config.StorageModeDatabase {
+		t.Errorf("storage.mode = %q, want database", cfg.Storage.Mode)
+	}
+
+	// Already database: nothing to do.
+	if switched, err := switchProjectStorageMode(root); err != nil || switched {
+		t.Errorf("second switch = %v, %v; want false, nil", switched, err)
+	}
+}
+
+func TestSwitchProjectStorageMode_Hybrid(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, ".orc", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("version: 1\nstorage:\n  mode: hybrid\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if switched, err := switchProjectStorageMode(root); err != nil || !switched {
+		t.Fatalf("switchProjectStorageMode = %v, %v; want true, nil", switched, err)
+	}
+	cfg, err := config.LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Storage.Mode != config.StorageModeDatabase {
+		t.Errorf("storage.mode = %q, want database", cfg.Storage.Mode)
+	}
+}
+
+func TestStorageMigrateCmd_RejectsUnknownTarget(t *testing.T) {
+	cmd := newStorageCmd()
+	cmd.SetArgs([]string{"migrate", "--to", "files"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error for --to files")
+	}
+}
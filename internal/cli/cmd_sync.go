@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/project"
+	"github.com/randalmurphal/orc/internal/projectsync"
+)
+
+func newSyncCmd() *cobra.Command {
+	var (
+		syncConfig  bool
+		syncPrompts bool
+		keys        []string
+		promptNames []string
+		projects    []string
+		from        string
+		dryRun      bool
+		yes         bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Push config and prompt overrides to other registered projects",
+		Long: `Copy selected configuration keys and prompt overrides from a source
+project, or a central template directory, to other registered projects.
+
+The source defaults to the current project. A template directory is laid out
+like .orc/: a config.yaml and a prompts/ directory of <phase>.md files.
+
+Config keys are merged into each target's .orc/config.yaml; keys not being
+synced, and their comments, are left alone. Without --keys every top-level key
+in the source config.yaml is copied. Prompt overrides replace the target's
+.orc/prompts/<phase>.md.
+
+A unified diff of every change is shown before anything is written.
+
+Examples:
+  orc sync --config --prompts --projects all --dry-run
+  orc sync --config --keys gates,completion.pr.labels --projects api,web
+  orc sync --prompts --prompt implement,review --projects all --from ~/orc-template
+  orc sync --config --projects all --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if len(projects) == 0 {
+				return fmt.Errorf("--projects is required (project IDs or names, or 'all')")
+			}
+			opts := projectsync.Options{Config: syncConfig, Keys: keys, Prompts: syncPrompts, PromptNames: promptNames}
+
+			if from == "" {
+				root, err := ResolveProjectPath()
+				if err != nil {
+					return fmt.Errorf("no source: run inside a project or pass --from: %w", err)
+				}
+				from = root
+			}
+			sourceDir, err := projectsync.SourceDir(from)
+			if err != nil {
+				return err
+			}
+
+			reg, err := project.LoadRegistry()
+			if err != nil {
+				return fmt.Errorf("load registry: %w", err)
+			}
+			targets, err := projectsync.ResolveTargets(reg, projects)
+			if err != nil {
+				return err
+			}
+			plans, err := projectsync.Plan(sourceDir, targets, opts)
+			if err != nil {
+				return err
+			}
+
+			if jsonOut {
+				if !dryRun {
+					if err := projectsync.Apply(plans); err != nil {
+						return err
+					}
+				}
+				return outputJSON(cmd, map[string]any{"applied": !dryRun, "projects": plans})
+			}
+
+			changed := displaySyncPlans(plans)
+			if changed == 0 {
+				fmt.Println("All projects are already in sync.")
+				return nil
+			}
+			if dryRun {
+				fmt.Printf("\nDry run: %d project(s) would change.\n", changed)
+				return nil
+			}
+			if !yes {
+				fmt.Printf("\nApply changes to %d project(s)? [y/N]: ", changed)
+				var input string
+				_, _ = fmt.Scanln(&input)
+				if input != "y" && input != "Y" {
+					fmt.Println("Aborted.")
+					return nil
+				}
+			}
+			if err := projectsync.Apply(plans); err != nil {
+				return err
+			}
+			fmt.Printf("Synced %d project(s).\n", changed)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&syncConfig, "config", false, "sync config keys from the source config.yaml")
+	cmd.Flags().BoolVar(&syncPrompts, "prompts", false, "sync prompt overrides from the source prompts/")
+	cmd.Flags().StringSliceVar(&keys, "keys", nil, "dotted config keys to sync (implies --config; default: all keys in the source)")
+	cmd.Flags().StringSliceVar(&promptNames, "prompt", nil, "phase prompts to sync (implies --prompts; default: all overrides)")
+	cmd.Flags().StringSliceVar(&projects, "projects", nil, "target project IDs or names, or 'all'")
+	cmd.Flags().StringVar(&from, "from", "", "source project root or template directory (default: current project)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show the diff without writing")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "apply without confirmation")
+
+	return cmd
+}
+
+// displaySyncPlans prints each project's changes with diffs and returns how
+// many projects would change.
+func displaySyncPlans(plans []projectsync.ProjectPlan) int {
+	changed := 0
+	for _, plan := range plans {
+		switch {
+		case plan.Error != "":
+			fmt.Printf("✗ %s (%s): %s\n", plan.Name, plan.Path, plan.Error)
+			continue
+		case !plan.Changed():
+			fmt.Printf("= %s: up to date\n", plan.Name)
+			continue
+		}
+		changed++
+		fmt.Printf("~ %s (%s)\n", plan.Name, plan.Path)
+		for _, c := range plan.Changes {
+			if c.Action == projectsync.ActionUnchanged {
+				continue
+			}
+			fmt.Printf("  %s %s\n", c.Action, c.Path)
+			if !quiet {
+				for _, line := range strings.Split(strings.TrimRight(c.Diff, "\n"), "\n") {
+					fmt.Printf("    %s\n", line)
+				}
+			}
+		}
+	}
+	return changed
+}
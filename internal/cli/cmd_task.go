@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/config"
+)
+
+// newTaskCmd creates the task command group.
+func newTaskCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "task",
+		Short: "Task operations: guided and bulk creation, bundles, cherry-picks and live progress",
+		Long: `Operations on tasks.
+
+Commands:
+  new           Create a task with a guided wizard (--interactive)
+  create        Create several tasks with dependencies from a file
+  export        Package a task into a portable bundle (.orc)
+  import        Import a task bundle
+  cherry-pick   Create a task that cherry-picks a commit or PR onto a branch
+  watch         Show a task's live progress in the terminal`,
+	}
+	cmd.AddCommand(newTaskNewCmd())
+	cmd.AddCommand(newTaskCreateCmd())
+	cmd.AddCommand(newTaskExportCmd())
+	cmd.AddCommand(newTaskImportCmd())
+	cmd.AddCommand(newTaskCherryPickCmd())
+	cmd.AddCommand(newTaskWatchCmd())
+	return cmd
+}
+
+func newTaskExportCmd() *cobra.Command {
+	var bundlePath string
+	var format string
+	var noTranscripts bool
+
+	cmd := &cobra.Command{
+		Use:   "export <task-id>",
+		Short: "Package a task into a portable bundle",
+		Long: `Package one task into a bundle that can be imported on another machine.
+
+The bundle holds the task definition and execution state, plan, spec,
+transcripts, comments, attachments, and every workflow run of the task with
+its phase state and phase outputs (artifacts). Use it to continue work on
+another machine or to share a failing task with a teammate for debugging.
+
+Bundles are tar.gz archives by default; 'orc import' accepts them too.
+--format json writes the same content as one JSON document, which is easy
+to attach to a bug report or POST to /api/tasks/import.
+
+Examples:
+  orc task export TASK-001                        # Writes TASK-001.orc
+  orc task export TASK-001 --bundle task.orc
+  orc task export TASK-001 --format json          # Writes TASK-001.json
+  orc task export TASK-001 --no-transcripts       # Smaller bundle`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			taskID := args[0]
+			if format != taskBundleFormatTar && format != taskBundleFormatJSON {
+				return fmt.Errorf("invalid --format %q (must be %s or %s)", format, taskBundleFormatTar, taskBundleFormatJSON)
+			}
+			projectRoot, err := ResolveProjectPath()
+			if err != nil {
+				return err
+			}
+			if err := config.RequireInitAt(projectRoot); err != nil {
+				return err
+			}
+
+			backend, err := getBackend()
+			if err != nil {
+				return fmt.Errorf("get backend: %w", err)
+			}
+			defer func() { _ = backend.Close() }()
+
+			bundle, err := buildTaskBundle(backend, taskID, !noTranscripts)
+			if err != nil {
+				return err
+			}
+			write := writeTaskBundle
+			if format == taskBundleFormatJSON {
+				write = writeTaskBundleJSON
+			}
+			if bundlePath == "" {
+				bundlePath = taskID + taskBundleExt
+				if format == taskBundleFormatJSON {
+					bundlePath = taskID + ".json"
+				}
+			}
+			if err := write(bundle, bundlePath); err != nil {
+				return err
+			}
+
+			fmt.Printf("Exported task %s to %s (%d workflow run(s), %d transcript(s), %d attachment(s))\n",
+				taskID, bundlePath, len(bundle.Runs), len(bundle.Task.Transcripts), len(bundle.Task.Attachments))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&bundlePath, "bundle", "", "bundle file to write (default: <task-id>.orc, or <task-id>.json with --format json)")
+	cmd.Flags().StringVar(&format, "format", taskBundleFormatTar, "bundle format: tar or json")
+	cmd.Flags().BoolVar(&noTranscripts, "no-transcripts", false, "leave out transcripts")
+	return cmd
+}
+
+func newTaskImportCmd() *cobra.Command {
+	var force bool
+	var skipExisting bool
+
+	cmd := &cobra.Command{
+		Use:   "import <bundle>",
+		Short: "Import a task bundle",
+		Long: `Import a bundle created by 'orc task export', in either format.
+
+Merge rules match 'orc import': a new task is imported; an existing task is
+replaced only when the bundle's copy is newer, unless --force is set.
+A task that was running when exported is imported as paused; continue it
+with 'orc resume <task-id>'.
+
+Examples:
+  orc task import task.orc
+  orc task import TASK-001.json
+  orc task import task.orc --force    # Overwrite the local copy`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRoot, err := ResolveProjectPath()
+			if err != nil {
+				return err
+			}
+			if err := config.RequireInitAt(projectRoot); err != nil {
+				return err
+			}
+
+			bundle, err := readTaskBundle(args[0])
+			if err != nil {
+				return err
+			}
+			if src := bundle.Manifest.SourceHostname; src != "" {
+				if host, _ := os.Hostname(); !strings.EqualFold(src, host) {
+					fmt.Printf("Bundle exported on %s at %s\n", src, bundle.Manifest.ExportedAt.Format("2006-01-02 15:04"))
+				}
+			}
+			return importTaskBundle(bundle, args[0], force, skipExisting)
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "overwrite an existing task even if it is newer")
+	cmd.Flags().BoolVar(&skipExisting, "skip-existing", false, "do nothing if the task already exists")
+	return cmd
+}
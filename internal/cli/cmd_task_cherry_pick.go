@@ -0,0 +1,264 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/hosting"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// cherryPickWorkflowID is the built-in workflow cherry-pick tasks run.
+const cherryPickWorkflowID = "cherry-pick"
+
+// Task metadata recorded on cherry-pick tasks.
+const (
+	cherryPickSourceMetadataKey = "cherry_pick_source" // Commit SHA or "PR #N"
+	cherryPickOntoMetadataKey   = "cherry_pick_onto"   // Branch the change is applied to
+)
+
+// prURLPattern matches GitHub pull request and GitLab merge request URLs.
+var prURLPattern = regexp.MustCompile(`/(?:pull|merge_requests)/(\d+)(?:[/?#]|$)`)
+
+// cherryPickSource is the change a cherry-pick task applies.
+type cherryPickSource struct {
+	PRNumber   int    // Set when the source is a PR
+	PRURL      string // PR web URL, when known
+	BaseBranch string // Branch the PR targets
+	HeadBranch string // Branch the PR was opened from
+	SHA        string // Commit to pick, or the PR's head commit
+	Subject    string // Commit subject or PR title
+}
+
+// label names the source in titles and metadata.
+func (s *cherryPickSource) label() string {
+	if s.PRNumber > 0 {
+		return fmt.Sprintf("PR #%d", s.PRNumber)
+	}
+	return shortSHA(s.SHA)
+}
+
+// parsePRRef reports the PR number when ref names a PR: "#123", a bare
+// number, or a pull/merge request URL.
+func parsePRRef(ref string) (int, bool) {
+	ref = strings.TrimSpace(ref)
+	if m := prURLPattern.FindStringSubmatch(ref); m != nil {
+		n, err := strconv.Atoi(m[1])
+		return n, err == nil && n > 0
+	}
+	digits := strings.TrimPrefix(ref, "#")
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return 0, false
+	}
+	// Short numeric commit prefixes are ambiguous; only "#N" or up to six
+	// digits count as a PR number.
+	if !strings.HasPrefix(ref, "#") && len(digits) > 6 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(digits)
+	return n, err == nil && n > 0
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+func newTaskCherryPickCmd() *cobra.Command {
+	var onto string
+	var priority string
+
+	cmd := &cobra.Command{
+		Use:   "cherry-pick <commit|PR> --onto <branch>",
+		Short: "Create a task that cherry-picks a change onto another branch",
+		Long: `Create a task that applies an existing commit or PR to another branch.
+
+The task runs the minimal 'cherry-pick' workflow: a single implement phase
+that cherry-picks the change with 'git cherry-pick -x', resolves conflicts
+while preserving the change's intent, and runs the project's tests, lint and
+build. On completion a PR is opened against the --onto branch.
+
+The source is a commit (anything 'git rev-parse' accepts) or a PR given as
+#N, a bare number, or a pull/merge request URL. PRs are looked up through the
+configured hosting provider.
+
+Examples:
+  orc task cherry-pick a1b2c3d --onto release/1.2
+  orc task cherry-pick '#482' --onto release/1.2
+  orc task cherry-pick https://github.com/org/repo/pull/482 --onto hotfix/2.0`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRoot, err := ResolveProjectPath()
+			if err != nil {
+				return err
+			}
+			if err := config.RequireInitAt(projectRoot); err != nil {
+				return err
+			}
+			cfg, err := config.LoadFrom(projectRoot)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			if err := validateTaskTargetBranch(cherryPickWorkflowID, onto); err != nil {
+				return err
+			}
+
+			src, err := resolveCherryPickSource(cmd, projectRoot, cfg, args[0])
+			if err != nil {
+				return err
+			}
+			if src.PRNumber > 0 && src.BaseBranch == onto {
+				return fmt.Errorf("PR #%d already targets %s", src.PRNumber, onto)
+			}
+
+			backend, err := getBackend()
+			if err != nil {
+				return fmt.Errorf("get backend: %w", err)
+			}
+			defer func() { _ = backend.Close() }()
+
+			id, err := backend.GetNextTaskID()
+			if err != nil {
+				return fmt.Errorf("generate task ID: %w", err)
+			}
+
+			title := fmt.Sprintf("Cherry-pick %s onto %s", src.label(), onto)
+			if src.Subject != "" {
+				title = fmt.Sprintf("Cherry-pick %s onto %s: %s", src.label(), onto, src.Subject)
+			}
+			spec := buildCherryPickSpec(src, onto)
+
+			t := task.NewProtoTask(id, title)
+			task.SetDescriptionProto(t, spec)
+			task.SetWorkflowIDProto(t, cherryPickWorkflowID)
+			t.Category = orcv1.TaskCategory_TASK_CATEGORY_CHORE
+			if priority != "" {
+				p, valid := task.ParsePriorityProto(priority)
+				if !valid {
+					return fmt.Errorf("invalid priority: %s (valid: critical, high, normal, low)", priority)
+				}
+				t.Priority = p
+			}
+			t.TargetBranch = &onto
+			t.Metadata[cherryPickSourceMetadataKey] = src.label()
+			if src.SHA != "" {
+				t.Metadata["cherry_pick_sha"] = src.SHA
+			}
+			t.Metadata[cherryPickOntoMetadataKey] = onto
+			t.Status = orcv1.TaskStatus_TASK_STATUS_PLANNED
+
+			if err := backend.SaveTask(t); err != nil {
+				return fmt.Errorf("save task: %w", err)
+			}
+			// The implement phase reads its instructions from the spec.
+			if err := backend.SaveSpecForTask(id, spec, cherryPickWorkflowID); err != nil {
+				return fmt.Errorf("save spec: %w", err)
+			}
+
+			fmt.Printf("Task created: %s\n", id)
+			fmt.Printf("   Title:    %s\n", title)
+			fmt.Printf("   Workflow: %s\n", cherryPickWorkflowID)
+			fmt.Printf("   Target Branch: %s\n", onto)
+			fmt.Println("\nNext steps:")
+			fmt.Printf("  orc run %s    - Execute the task\n", id)
+			fmt.Printf("  orc show %s   - View task details\n", id)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&onto, "onto", "", "branch to apply the change to (required)")
+	cmd.Flags().StringVarP(&priority, "priority", "p", "", "task priority (critical, high, normal, low)")
+	_ = cmd.MarkFlagRequired("onto")
+	return cmd
+}
+
+// resolveCherryPickSource looks up ref as a PR through the hosting provider
+// or as a commit in the local repository.
+func resolveCherryPickSource(cmd *cobra.Command, projectRoot string, cfg *config.Config, ref string) (*cherryPickSource, error) {
+	if n, ok := parsePRRef(ref); ok {
+		provider, err := hosting.NewProviderFromAppConfig(projectRoot, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("init hosting provider: %w", err)
+		}
+		pr, err := provider.GetPR(cmd.Context(), n)
+		if err != nil {
+			return nil, fmt.Errorf("get PR #%d: %w", n, err)
+		}
+		return &cherryPickSource{
+			PRNumber:   pr.Number,
+			PRURL:      pr.HTMLURL,
+			BaseBranch: pr.BaseBranch,
+			HeadBranch: pr.HeadBranch,
+			SHA:        pr.HeadSHA,
+			Subject:    pr.Title,
+		}, nil
+	}
+
+	gitOps, err := NewGitOpsFromConfig(projectRoot, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("init git: %w", err)
+	}
+	sha, err := gitOps.Context().RunGit("rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil || strings.TrimSpace(sha) == "" {
+		return nil, fmt.Errorf("%s is not a commit or PR in this repository", ref)
+	}
+	sha = strings.TrimSpace(sha)
+	subject, err := gitOps.Context().RunGit("log", "-1", "--format=%s", sha)
+	if err != nil {
+		return nil, fmt.Errorf("read commit %s: %w", shortSHA(sha), err)
+	}
+	return &cherryPickSource{SHA: sha, Subject: strings.TrimSpace(subject)}, nil
+}
+
+// buildCherryPickSpec writes the instructions the implement phase follows.
+func buildCherryPickSpec(src *cherryPickSource, onto string) string {
+	var sb strings.Builder
+	sb.WriteString("## Intent\n\n")
+	if src.PRNumber > 0 {
+		fmt.Fprintf(&sb, "Apply the change from PR #%d", src.PRNumber)
+		if src.Subject != "" {
+			fmt.Fprintf(&sb, " (%s)", src.Subject)
+		}
+		if src.PRURL != "" {
+			fmt.Fprintf(&sb, ", %s,", src.PRURL)
+		}
+		fmt.Fprintf(&sb, " to %s without changing its behaviour.\n\n", onto)
+	} else {
+		fmt.Fprintf(&sb, "Apply commit %s", src.SHA)
+		if src.Subject != "" {
+			fmt.Fprintf(&sb, " (%s)", src.Subject)
+		}
+		fmt.Fprintf(&sb, " to %s without changing its behaviour.\n\n", onto)
+	}
+
+	sb.WriteString("## Steps\n\n")
+	switch {
+	case src.PRNumber > 0 && src.HeadBranch != "" && src.SHA != "":
+		fmt.Fprintf(&sb, "1. Fetch the PR's commits: `git fetch origin %s %s` (head commit %s).\n", src.BaseBranch, src.HeadBranch, src.SHA)
+		fmt.Fprintf(&sb, "2. Cherry-pick the commits the PR adds over its base: `git cherry-pick -x $(git merge-base origin/%s %s)..%s`.\n", src.BaseBranch, src.SHA, src.SHA)
+	case src.PRNumber > 0:
+		fmt.Fprintf(&sb, "1. Fetch the PR's commits from origin.\n")
+		fmt.Fprintf(&sb, "2. Cherry-pick the commits PR #%d adds over its base with `git cherry-pick -x`.\n", src.PRNumber)
+	default:
+		sb.WriteString("1. Make sure the commit is available locally (`git fetch origin` if not).\n")
+		fmt.Fprintf(&sb, "2. Cherry-pick it: `git cherry-pick -x %s`.\n", src.SHA)
+	}
+	fmt.Fprintf(&sb, "3. Resolve conflicts by adapting the change to the code on %s. Keep the original intent; do not drop hunks to make the pick apply.\n", onto)
+	sb.WriteString("4. Make no changes beyond what the pick needs to build and pass on this branch.\n")
+
+	sb.WriteString("\n## Success Criteria\n\n")
+	fmt.Fprintf(&sb, "- The change is present on the task branch, based on %s, with `(cherry picked from commit ...)` trailers.\n", onto)
+	sb.WriteString("- No conflict markers remain.\n")
+
+	sb.WriteString("\n## Testing\n\n")
+	sb.WriteString("- Run the tests covering the picked change and the project's full test suite.\n")
+	return sb.String()
+}
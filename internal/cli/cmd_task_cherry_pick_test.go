@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupCherryPickRepo turns the project dir into a real git repository with
+// a release/1.2 branch and a fix commit on main. It returns the fix's SHA.
+func setupCherryPickRepo(t *testing.T, dir string) string {
+	t.Helper()
+	if err := os.RemoveAll(filepath.Join(dir, ".git")); err != nil {
+		t.Fatalf("remove placeholder .git: %v", err)
+	}
+	runDoctorGitCommand(t, dir, "init", "--initial-branch=main")
+	runDoctorGitCommand(t, dir, "config", "user.email", "test@example.com")
+	runDoctorGitCommand(t, dir, "config", "user.name", "Test")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Initial\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runDoctorGitCommand(t, dir, "add", "README.md")
+	runDoctorGitCommand(t, dir, "commit", "-m", "Initial commit")
+	runDoctorGitCommand(t, dir, "branch", "release/1.2")
+	if err := os.WriteFile(filepath.Join(dir, "fix.txt"), []byte("fixed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runDoctorGitCommand(t, dir, "add", "fix.txt")
+	runDoctorGitCommand(t, dir, "commit", "-m", "Fix crash on empty config")
+
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatalf("rev-parse HEAD: %v", err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestTaskCherryPickCmd_CreatesTaskForCommit(t *testing.T) {
+	tmpDir := withNewCmdTestDir(t)
+	sha := setupCherryPickRepo(t, tmpDir)
+	backend := createTestBackendInDir(t, tmpDir)
+	_ = backend.Close()
+
+	cmd := newTaskCmd()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"cherry-pick", sha[:7], "--onto", "release/1.2"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("orc task cherry-pick: %v\n%s", err, buf.String())
+	}
+
+	reopened := createTestBackendInDir(t, tmpDir)
+	defer func() { _ = reopened.Close() }()
+	tasks, err := reopened.LoadAllTasks()
+	if err != nil {
+		t.Fatalf("load tasks: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("tasks = %v, want 1", tasks)
+	}
+	ct := tasks[0]
+	if ct.GetWorkflowId() != cherryPickWorkflowID || ct.GetTargetBranch() != "release/1.2" {
+		t.Errorf("workflow = %q, target = %q; want cherry-pick onto release/1.2", ct.GetWorkflowId(), ct.GetTargetBranch())
+	}
+	wantTitle := "Cherry-pick " + sha[:8] + " onto release/1.2: Fix crash on empty config"
+	if ct.Title != wantTitle {
+		t.Errorf("title = %q, want %q", ct.Title, wantTitle)
+	}
+	if ct.Metadata[cherryPickSourceMetadataKey] != sha[:8] || ct.Metadata["cherry_pick_sha"] != sha || ct.Metadata[cherryPickOntoMetadataKey] != "release/1.2" {
+		t.Errorf("metadata = %v", ct.Metadata)
+	}
+
+	spec, err := reopened.GetSpecForTask(ct.Id)
+	if err != nil {
+		t.Fatalf("get spec: %v", err)
+	}
+	if !strings.Contains(spec, "git cherry-pick -x "+sha) {
+		t.Errorf("spec missing cherry-pick instruction:\n%s", spec)
+	}
+}
+
+func TestTaskCherryPickCmd_RejectsUnknownCommit(t *testing.T) {
+	tmpDir := withNewCmdTestDir(t)
+	setupCherryPickRepo(t, tmpDir)
+	backend := createTestBackendInDir(t, tmpDir)
+	_ = backend.Close()
+
+	cmd := newTaskCmd()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"cherry-pick", "no-such-ref", "--onto", "release/1.2"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "not a commit or PR") {
+		t.Fatalf("err = %v, want not a commit or PR", err)
+	}
+}
+
+func TestParsePRRef(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		ref    string
+		want   int
+		wantOK bool
+	}{
+		{"#482", 482, true},
+		{"482", 482, true},
+		{"https://github.com/org/repo/pull/482", 482, true},
+		{"https://github.com/org/repo/pull/482/files", 482, true},
+		{"https://gitlab.com/group/repo/-/merge_requests/17", 17, true},
+		{"a1b2c3d", 0, false},
+		{"1234567", 0, false}, // numeric commit prefix
+		{"#", 0, false},
+		{"HEAD~1", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parsePRRef(tt.ref)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parsePRRef(%q) = %d, %v; want %d, %v", tt.ref, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestBuildCherryPickSpec_PR(t *testing.T) {
+	t.Parallel()
+	spec := buildCherryPickSpec(&cherryPickSource{
+		PRNumber:   482,
+		PRURL:      "https://github.com/org/repo/pull/482",
+		BaseBranch: "main",
+		HeadBranch: "orc/TASK-010",
+		SHA:        "abc123",
+		Subject:    "Fix crash",
+	}, "release/1.2")
+	for _, want := range []string{
+		"PR #482 (Fix crash)",
+		"git fetch origin main orc/TASK-010",
+		"git cherry-pick -x $(git merge-base origin/main abc123)..abc123",
+		"adapting the change to the code on release/1.2",
+	} {
+		if !strings.Contains(spec, want) {
+			t.Errorf("spec missing %q:\n%s", want, spec)
+		}
+	}
+}
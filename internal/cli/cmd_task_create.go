@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// bulkTaskFile is the --from-file format; a bare list of tasks is accepted
+// as well.
+type bulkTaskFile struct {
+	Tasks []task.BulkTaskSpec `yaml:"tasks"`
+}
+
+// bulkCreateResult is one line of `orc task create --json` output.
+type bulkCreateResult struct {
+	Ref       string   `json:"ref,omitempty"`
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Workflow  string   `json:"workflow,omitempty"`
+	BlockedBy []string `json:"blocked_by,omitempty"`
+}
+
+func newTaskCreateCmd() *cobra.Command {
+	var fromFile string
+
+	cmd := &cobra.Command{
+		Use:   "create --from-file <file>",
+		Short: "Create several tasks, with dependencies between them, from a file",
+		Long: `Create tasks from a YAML or JSON file in one step.
+
+Give a task a ref to let later entries in the same file list it in
+blocked_by or related_to; entries may also name existing task IDs. The whole
+file is checked first (titles, categories, priorities, unknown refs,
+initiatives, dependency cycles) and the tasks are then created in a single
+transaction, so either all of them exist afterwards or none does.
+
+Tasks without a workflow get the configured default for their category,
+as with 'orc new'. Use '-' to read the file from stdin.
+
+File format:
+  tasks:
+    - ref: schema
+      title: Add invoices table
+      category: feature
+    - ref: api
+      title: Invoice CRUD endpoints
+      blocked_by: [schema]
+    - title: Invoice list page
+      workflow: implement-small
+      priority: high
+      blocked_by: [api, TASK-041]
+
+Other fields: description, queue (active, backlog), initiative_id,
+target_branch, related_to.
+
+The same payload can be sent to POST /api/tasks/bulk.
+
+Examples:
+  orc task create --from-file tasks.yaml
+  orc task create --from-file - --json < tasks.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if fromFile == "" {
+				return fmt.Errorf("--from-file is required")
+			}
+			projectRoot, err := ResolveProjectPath()
+			if err != nil {
+				return err
+			}
+			if err := config.RequireInitAt(projectRoot); err != nil {
+				return err
+			}
+
+			specs, err := readBulkTaskFile(cmd.InOrStdin(), fromFile)
+			if err != nil {
+				return err
+			}
+			if err := applyBulkWorkflowDefaults(specs); err != nil {
+				return err
+			}
+
+			backend, err := getBackend()
+			if err != nil {
+				return fmt.Errorf("get backend: %w", err)
+			}
+			defer func() { _ = backend.Close() }()
+
+			tasks, err := storage.CreateBulkTasks(backend, specs)
+			if err != nil {
+				return err
+			}
+
+			results := make([]bulkCreateResult, len(tasks))
+			for i, t := range tasks {
+				results[i] = bulkCreateResult{
+					Ref:       specs[i].Ref,
+					ID:        t.Id,
+					Title:     t.Title,
+					Workflow:  task.GetWorkflowIDProto(t),
+					BlockedBy: t.BlockedBy,
+				}
+			}
+			if jsonOut {
+				return outputJSON(cmd, results)
+			}
+
+			w := cmd.OutOrStdout()
+			_, _ = fmt.Fprintf(w, "Created %d task(s)\n", len(results))
+			for _, r := range results {
+				line := fmt.Sprintf("  %s  %s", r.ID, r.Title)
+				if r.Ref != "" {
+					line += fmt.Sprintf(" [%s]", r.Ref)
+				}
+				if len(r.BlockedBy) > 0 {
+					line += "  blocked by " + strings.Join(r.BlockedBy, ", ")
+				}
+				_, _ = fmt.Fprintln(w, line)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&fromFile, "from-file", "f", "", "YAML or JSON file with the tasks ('-' for stdin)")
+	return cmd
+}
+
+// readBulkTaskFile parses a --from-file document: {tasks: [...]} or a
+// bare list, in YAML or JSON.
+func readBulkTaskFile(stdin io.Reader, path string) ([]task.BulkTaskSpec, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var specs []task.BulkTaskSpec
+	if len(root.Content) > 0 && root.Content[0].Kind == yaml.SequenceNode {
+		err = dec.Decode(&specs)
+	} else {
+		var file bulkTaskFile
+		err = dec.Decode(&file)
+		specs = file.Tasks
+	}
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return specs, nil
+}
+
+// applyBulkWorkflowDefaults fills in the configured default workflow for
+// specs that name none, the way 'orc new' does.
+func applyBulkWorkflowDefaults(specs []task.BulkTaskSpec) error {
+	var cfg *config.Config
+	for i := range specs {
+		if specs[i].Workflow != "" {
+			continue
+		}
+		if cfg == nil {
+			loaded, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			cfg = loaded
+		}
+		workflowID, _ := cfg.ResolveWorkflow("", specs[i].Category)
+		if workflowID == "" {
+			return fmt.Errorf("task %d (%s): no workflow given and no default workflow configured\n\nSet workflow_defaults.default, or add a workflow field to the task", i+1, specs[i].Title)
+		}
+		specs[i].Workflow = workflowID
+	}
+	return nil
+}
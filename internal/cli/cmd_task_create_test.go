@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadBulkTaskFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	yamlFile := write("tasks.yaml", `tasks:
+  - ref: schema
+    title: Add table
+  - title: Endpoints
+    blocked_by: [schema, TASK-001]
+`)
+	specs, err := readBulkTaskFile(nil, yamlFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(specs) != 2 || specs[0].Ref != "schema" || strings.Join(specs[1].BlockedBy, ",") != "schema,TASK-001" {
+		t.Errorf("yaml specs = %+v", specs)
+	}
+
+	specs, err = readBulkTaskFile(strings.NewReader(`[{"title": "One", "initiative_id": "INIT-001"}]`), "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(specs) != 1 || specs[0].InitiativeID != "INIT-001" {
+		t.Errorf("json specs = %+v", specs)
+	}
+
+	if _, err := readBulkTaskFile(nil, write("bad.yaml", "tasks:\n  - title: A\n    blockers: [b]\n")); err == nil {
+		t.Error("unknown field should fail")
+	}
+}
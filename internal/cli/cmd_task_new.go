@@ -0,0 +1,389 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	llmkit "github.com/randalmurphal/llmkit/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/executor"
+	"github.com/randalmurphal/orc/internal/git"
+	"github.com/randalmurphal/orc/internal/llmutil"
+	"github.com/randalmurphal/orc/internal/wizard"
+	"github.com/randalmurphal/orc/internal/workflow"
+)
+
+// taskWeights are the size estimates offered by the wizard, smallest first.
+var taskWeights = []string{"trivial", "small", "medium", "large"}
+
+// taskWeightWorkflows maps a size estimate to the built-in workflow for it,
+// the same mapping the legacy weight filters use.
+var taskWeightWorkflows = map[string]string{
+	"trivial": "implement-trivial",
+	"small":   "implement-small",
+	"medium":  "implement-medium",
+	"large":   "implement-large",
+}
+
+// suggestWeightValue is the weight option that asks the model for an estimate.
+const suggestWeightValue = "suggest"
+
+// taskWeightSuggestTimeout bounds the size suggestion call.
+const taskWeightSuggestTimeout = 90 * time.Second
+
+// taskWizardAnswers are the values collected by 'orc task new --interactive'.
+type taskWizardAnswers struct {
+	Title        string
+	Description  string
+	Weight       string // Size estimate; only used to preselect the workflow
+	WorkflowID   string
+	BlockedBy    []string
+	TargetBranch string // Empty uses the project default
+}
+
+// newArgs returns the 'orc new' arguments that create the task.
+func (a *taskWizardAnswers) newArgs() []string {
+	args := []string{a.Title, "--workflow", a.WorkflowID}
+	if a.Description != "" {
+		args = append(args, "--description", a.Description)
+	}
+	if len(a.BlockedBy) > 0 {
+		args = append(args, "--blocked-by", strings.Join(a.BlockedBy, ","))
+	}
+	if a.TargetBranch != "" {
+		args = append(args, "--target-branch", a.TargetBranch)
+	}
+	return args
+}
+
+// taskWeightSuggestion is the model's size estimate for a task.
+type taskWeightSuggestion struct {
+	Weight string `json:"weight"`
+	Reason string `json:"reason"`
+}
+
+const taskWeightSchema = `{
+  "type": "object",
+  "properties": {
+    "weight": {
+      "type": "string",
+      "enum": ["trivial", "small", "medium", "large"],
+      "description": "Estimated size of the task"
+    },
+    "reason": {
+      "type": "string",
+      "description": "One sentence explaining the estimate"
+    }
+  },
+  "required": ["weight", "reason"]
+}`
+
+func buildTaskWeightPrompt(title, description string) string {
+	var sb strings.Builder
+	sb.WriteString("Estimate the size of this software task for an AI coding agent.\n\n")
+	sb.WriteString("- trivial: one-liner fixes, typos, config tweaks\n")
+	sb.WriteString("- small: bug fixes, small features, isolated changes\n")
+	sb.WriteString("- medium: features touching several files that need a short spec\n")
+	sb.WriteString("- large: complex features, multi-file changes, new systems\n\n")
+	fmt.Fprintf(&sb, "## Title\n\n%s\n", title)
+	if description != "" {
+		fmt.Fprintf(&sb, "\n## Description\n\n%s\n", description)
+	}
+	return sb.String()
+}
+
+// suggestTaskWeight asks validation.model for a size estimate.
+func suggestTaskWeight(ctx context.Context, cfg *config.Config, workDir, title, description string) (*taskWeightSuggestion, error) {
+	clientCfg, err := llmkit.BuildConfig(executor.ProviderClaude, cfg.Validation.Model, workDir, llmkit.RuntimeConfig{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build suggestion client config: %w", err)
+	}
+	claudePath := cfg.ClaudePath
+	if claudePath == "" {
+		claudePath = "claude"
+	}
+	clientCfg.BinaryPath = executor.ResolveClaudePath(claudePath)
+
+	client, err := llmkit.New(executor.ProviderClaude, clientCfg)
+	if err != nil {
+		return nil, fmt.Errorf("create suggestion client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	result, err := llmutil.ExecuteWithSchema[taskWeightSuggestion](ctx, client, buildTaskWeightPrompt(title, description), taskWeightSchema)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(taskWeights, result.Data.Weight) {
+		return nil, fmt.Errorf("unexpected weight %q", result.Data.Weight)
+	}
+	return &result.Data, nil
+}
+
+// parseTaskIDList splits a comma- or space-separated list of task IDs.
+func parseTaskIDList(s string) []string {
+	var ids []string
+	for _, id := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }) {
+		ids = append(ids, strings.ToUpper(id))
+	}
+	return ids
+}
+
+func newTaskNewCmd() *cobra.Command {
+	var interactive bool
+
+	cmd := &cobra.Command{
+		Use:   "new [title] --interactive",
+		Short: "Create a task with a guided wizard",
+		Long: `Create a task by answering a few questions instead of passing flags.
+
+The wizard asks for the title, description and size, can ask the validation
+model (haiku by default) to suggest a size from the description, then
+preselects the workflow for that size. Blockers and a target branch are
+optional. A summary is shown before the task is created.
+
+The answers are passed to 'orc new', so the result is the same as creating
+the task with flags.
+
+Examples:
+  orc task new --interactive
+  orc task new "Add rate limiting" --interactive    # Title prefilled`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !interactive {
+				return fmt.Errorf("orc task new requires --interactive; use 'orc new <title>' to create a task from flags")
+			}
+			projectRoot, err := ResolveProjectPath()
+			if err != nil {
+				return err
+			}
+			if err := config.RequireInitAt(projectRoot); err != nil {
+				return err
+			}
+			cfg, err := config.Load()
+			if err != nil {
+				cfg = config.Default()
+			}
+
+			existingIDs, err := loadTaskIDs()
+			if err != nil {
+				return err
+			}
+			workflows, err := loadWizardWorkflows()
+			if err != nil {
+				return err
+			}
+
+			answers := &taskWizardAnswers{}
+			if len(args) > 0 {
+				answers.Title = args[0]
+			}
+			if err := runTaskDetailsWizard(cfg, answers); err != nil {
+				return err
+			}
+
+			if answers.Weight == suggestWeightValue {
+				answers.Weight = ""
+				fmt.Printf("Asking %s for a size estimate...\n", cfg.Validation.Model)
+				ctx, cancel := context.WithTimeout(cmd.Context(), taskWeightSuggestTimeout)
+				suggestion, err := suggestTaskWeight(ctx, cfg, projectRoot, answers.Title, answers.Description)
+				cancel()
+				if err != nil {
+					fmt.Printf("Warning: size suggestion failed: %v\n", err)
+				} else {
+					answers.Weight = suggestion.Weight
+					fmt.Printf("Suggested size: %s (%s)\n", suggestion.Weight, suggestion.Reason)
+				}
+			}
+
+			confirmed, err := runTaskOptionsWizard(cfg, answers, workflows, existingIDs)
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Println("Task not created.")
+				return nil
+			}
+
+			newCmd := newNewCmd()
+			newCmd.SetArgs(answers.newArgs())
+			newCmd.SetOut(cmd.OutOrStdout())
+			newCmd.SetErr(cmd.ErrOrStderr())
+			return newCmd.ExecuteContext(cmd.Context())
+		},
+	}
+
+	cmd.Flags().BoolVar(&interactive, "interactive", false, "create the task with a guided wizard")
+	return cmd
+}
+
+// loadTaskIDs returns the IDs of existing tasks, for validating blockers.
+func loadTaskIDs() (map[string]bool, error) {
+	backend, err := getBackend()
+	if err != nil {
+		return nil, fmt.Errorf("get backend: %w", err)
+	}
+	defer func() { _ = backend.Close() }()
+
+	tasks, err := backend.LoadAllTasks()
+	if err != nil {
+		return nil, fmt.Errorf("load tasks: %w", err)
+	}
+	ids := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		ids[t.Id] = true
+	}
+	return ids, nil
+}
+
+// loadWizardWorkflows returns the workflows a task can be assigned, seeding
+// the built-ins the same way 'orc new' does.
+func loadWizardWorkflows() ([]*db.Workflow, error) {
+	gdb, err := db.OpenGlobal()
+	if err != nil {
+		return nil, fmt.Errorf("open global database: %w", err)
+	}
+	defer func() { _ = gdb.Close() }()
+
+	if _, err := workflow.SeedBuiltins(gdb); err != nil {
+		return nil, fmt.Errorf("seed workflows: %w", err)
+	}
+	workflows, err := gdb.ListWorkflows()
+	if err != nil {
+		return nil, fmt.Errorf("list workflows: %w", err)
+	}
+	if len(workflows) == 0 {
+		return nil, fmt.Errorf("no workflows available; run 'orc workflows' to check")
+	}
+	return workflows, nil
+}
+
+// runTaskDetailsWizard asks for the title, description and size.
+func runTaskDetailsWizard(cfg *config.Config, answers *taskWizardAnswers) error {
+	weightOptions := []wizard.SelectOption{{
+		Value:       suggestWeightValue,
+		Label:       "Suggest from description",
+		Description: fmt.Sprintf("Ask %s to estimate", cfg.Validation.Model),
+	}}
+	descriptions := map[string]string{
+		"trivial": "One-liner fixes, typos, config tweaks",
+		"small":   "Bug fixes, small features, isolated changes",
+		"medium":  "Multi-file features that need a spec",
+		"large":   "Complex features, multi-file changes, new systems",
+	}
+	for _, weight := range taskWeights {
+		weightOptions = append(weightOptions, wizard.SelectOption{Value: weight, Label: weight, Description: descriptions[weight]})
+	}
+
+	w := wizard.New(
+		wizard.NewInputStep("title", "Title").
+			WithDescription("A short, specific summary of the change").
+			WithDefault(answers.Title).
+			WithPlaceholder("Add pagination to user list API").
+			WithValidate(func(v string) error {
+				if strings.TrimSpace(v) == "" {
+					return fmt.Errorf("title is required")
+				}
+				return nil
+			}),
+		wizard.NewInputStep("description", "Description").
+			WithDescription("The problem, what success looks like, and any constraints. It flows into every phase prompt.").
+			WithPlaceholder("optional"),
+		wizard.NewSelectStep("weight", "Size", weightOptions).
+			WithDescription("How big is the change? The size preselects a workflow.").
+			WithDefault(suggestWeightValue),
+	)
+	if err := w.Run(); err != nil {
+		return fmt.Errorf("wizard cancelled: %w", err)
+	}
+
+	state := w.State()
+	answers.Title, _ = state["title"].(string)
+	answers.Description, _ = state["description"].(string)
+	answers.Weight, _ = state["weight"].(string)
+	return nil
+}
+
+// runTaskOptionsWizard asks for the workflow, blockers and target branch,
+// shows a summary, and reports whether the user confirmed creation.
+func runTaskOptionsWizard(cfg *config.Config, answers *taskWizardAnswers, workflows []*db.Workflow, existingIDs map[string]bool) (bool, error) {
+	defaultWorkflow, _ := cfg.ResolveWorkflow("", "")
+	if wf, ok := taskWeightWorkflows[answers.Weight]; ok {
+		defaultWorkflow = wf
+	}
+	var workflowOptions []wizard.SelectOption
+	for _, wf := range workflows {
+		label := wf.ID
+		if wf.ID == defaultWorkflow {
+			label += " (suggested)"
+		}
+		workflowOptions = append(workflowOptions, wizard.SelectOption{Value: wf.ID, Label: label, Description: wf.Description})
+	}
+
+	w := wizard.New(
+		wizard.NewSelectStep("workflow", "Workflow", workflowOptions).
+			WithDescription("Which phases should run?").
+			WithDefault(defaultWorkflow),
+		wizard.NewInputStep("blocked_by", "Blocked By").
+			WithDescription("Tasks that must complete first (comma-separated)").
+			WithPlaceholder("none").
+			WithValidate(func(v string) error {
+				for _, id := range parseTaskIDList(v) {
+					if !existingIDs[id] {
+						return fmt.Errorf("task %s not found", id)
+					}
+				}
+				return nil
+			}),
+		wizard.NewInputStep("target_branch", "Target Branch").
+			WithDescription("Branch the PR targets").
+			WithPlaceholder("project default").
+			WithValidate(func(v string) error {
+				if v = strings.TrimSpace(v); v == "" {
+					return nil
+				}
+				return git.ValidateBranchName(v)
+			}),
+		wizard.NewDisplayStep("summary", "Summary", func(s wizard.State) string {
+			var b strings.Builder
+			fmt.Fprintf(&b, "  Title:       %s\n", answers.Title)
+			if answers.Description != "" {
+				fmt.Fprintf(&b, "  Description: %s\n", answers.Description)
+			}
+			if answers.Weight != "" {
+				fmt.Fprintf(&b, "  Size:        %s\n", answers.Weight)
+			}
+			if wf, ok := s["workflow"].(string); ok {
+				fmt.Fprintf(&b, "  Workflow:    %s\n", wf)
+			}
+			blockedBy, _ := s["blocked_by"].(string)
+			if ids := parseTaskIDList(blockedBy); len(ids) > 0 {
+				fmt.Fprintf(&b, "  Blocked by:  %s\n", strings.Join(ids, ", "))
+			}
+			if branch, _ := s["target_branch"].(string); branch != "" {
+				fmt.Fprintf(&b, "  Target:      %s\n", branch)
+			}
+			b.WriteString("\nPress enter to continue.")
+			return b.String()
+		}),
+		wizard.NewConfirmStep("confirm", "Create this task?").WithDefault(true),
+	)
+	if err := w.Run(); err != nil {
+		return false, fmt.Errorf("wizard cancelled: %w", err)
+	}
+
+	state := w.State()
+	answers.WorkflowID, _ = state["workflow"].(string)
+	if v, ok := state["blocked_by"].(string); ok {
+		answers.BlockedBy = parseTaskIDList(v)
+	}
+	answers.TargetBranch, _ = state["target_branch"].(string)
+	confirmed, _ := state["confirm"].(bool)
+	return confirmed, nil
+}
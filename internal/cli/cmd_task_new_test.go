@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTaskWizardAnswers_NewArgs(t *testing.T) {
+	answers := &taskWizardAnswers{Title: "Add pagination", WorkflowID: "implement-small"}
+	if got := answers.newArgs(); !slices.Equal(got, []string{"Add pagination", "--workflow", "implement-small"}) {
+		t.Errorf("minimal args = %v", got)
+	}
+
+	answers.Description = "Limit and offset"
+	answers.BlockedBy = []string{"TASK-001", "TASK-002"}
+	answers.TargetBranch = "release/1.2"
+	want := []string{"Add pagination", "--workflow", "implement-small",
+		"--description", "Limit and offset",
+		"--blocked-by", "TASK-001,TASK-002",
+		"--target-branch", "release/1.2"}
+	if got := answers.newArgs(); !slices.Equal(got, want) {
+		t.Errorf("args = %v, want %v", got, want)
+	}
+}
+
+func TestParseTaskIDList(t *testing.T) {
+	if got := parseTaskIDList(" task-001, TASK-002 TASK-003,,"); !slices.Equal(got, []string{"TASK-001", "TASK-002", "TASK-003"}) {
+		t.Errorf("parseTaskIDList = %v", got)
+	}
+	if got := parseTaskIDList(""); len(got) != 0 {
+		t.Errorf("empty list = %v", got)
+	}
+}
+
+func TestBuildTaskWeightPrompt(t *testing.T) {
+	prompt := buildTaskWeightPrompt("Add caching layer", "Cache API responses in Redis")
+	for _, want := range []string{"Add caching layer", "Cache API responses in Redis", "trivial", "large"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+	for weight := range taskWeightWorkflows {
+		if !slices.Contains(taskWeights, weight) {
+			t.Errorf("workflow mapping has unknown weight %q", weight)
+		}
+	}
+}
+
+func TestTaskNewCmd_RequiresInteractive(t *testing.T) {
+	cmd := newTaskNewCmd()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"Some task"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--interactive") {
+		t.Errorf("expected --interactive error, got %v", err)
+	}
+}
+
+// TestTaskWizardAnswers_CreateTask verifies the wizard's answers create the
+// task through 'orc new'.
+func TestTaskWizardAnswers_CreateTask(t *testing.T) {
+	tmpDir := withNewCmdTestDir(t)
+	backend := createTestBackendInDir(t, tmpDir)
+	_ = backend.Close()
+
+	blocker := newNewCmd()
+	blocker.SetOut(&bytes.Buffer{})
+	blocker.SetArgs((&taskWizardAnswers{Title: "Blocker", WorkflowID: "implement-trivial"}).newArgs())
+	if err := blocker.Execute(); err != nil {
+		t.Fatalf("create blocker: %v", err)
+	}
+
+	answers := &taskWizardAnswers{
+		Title:       "Wizard task",
+		Description: "Created from answers",
+		WorkflowID:  "implement-small",
+		BlockedBy:   []string{"TASK-001"},
+	}
+	cmd := newNewCmd()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(answers.newArgs())
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("create task: %v\n%s", err, buf.String())
+	}
+
+	reopened := createTestBackendInDir(t, tmpDir)
+	defer func() { _ = reopened.Close() }()
+	created, err := reopened.LoadTask("TASK-002")
+	if err != nil {
+		t.Fatalf("load created task: %v", err)
+	}
+	if created.Title != "Wizard task" || created.GetDescription() != "Created from answers" ||
+		created.GetWorkflowId() != "implement-small" || !slices.Equal(created.BlockedBy, []string{"TASK-001"}) {
+		t.Errorf("created task = %+v", created)
+	}
+}
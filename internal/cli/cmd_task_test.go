@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestTaskBundleRoundTrip(t *testing.T) {
+	for _, format := range []string{taskBundleFormatTar, taskBundleFormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			testTaskBundleRoundTrip(t, format)
+		})
+	}
+}
+
+func testTaskBundleRoundTrip(t *testing.T, format string) {
+	t.Setenv("HOME", t.TempDir())
+	tmpDir := withTempDir(t)
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".orc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, ".orc", "config.yaml"), []byte("version: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	backend, err := getBackend()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tk := task.NewProtoTask("TASK-001", "Portable task")
+	tk.Status = orcv1.TaskStatus_TASK_STATUS_RUNNING
+	taskID := tk.Id
+	mustDo(t, backend.SaveTask(tk))
+	mustDo(t, backend.SaveWorkflow(&db.Workflow{ID: "wf-bundle", Name: "Bundle"}))
+	mustDo(t, backend.SaveWorkflowRun(&db.WorkflowRun{
+		ID: "RUN-001", WorkflowID: "wf-bundle", ContextType: "task", TaskID: &taskID, Prompt: "p", Status: "running",
+	}))
+	mustDo(t, backend.SavePhaseOutput(&storage.PhaseOutputInfo{
+		WorkflowRunID: "RUN-001", PhaseTemplateID: "spec", TaskID: &taskID, Content: "# Spec", OutputVarName: "SPEC_CONTENT", Source: "executor",
+	}))
+	mustDo(t, backend.AddTranscript(&storage.Transcript{
+		TaskID: taskID, Phase: "spec", WorkflowRunID: "RUN-001", MessageUUID: "m1", Type: "assistant", Content: "hello", Timestamp: time.Now().UnixMilli(),
+	}))
+	_, err = backend.SaveAttachment(taskID, "notes.txt", "text/plain", []byte("notes"))
+	mustDo(t, err)
+	_ = backend.Close()
+
+	bundlePath := filepath.Join(t.TempDir(), "task.orc")
+	export := newTaskCmd()
+	export.SetArgs([]string{"export", taskID, "--bundle", bundlePath, "--format", format})
+	if err := export.Execute(); err != nil {
+		t.Fatalf("task export: %v", err)
+	}
+
+	// Simulate a machine without the task.
+	backend, err = getBackend()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustDo(t, backend.DeleteWorkflowRun("RUN-001"))
+	mustDo(t, backend.DeleteTask(taskID))
+	_ = backend.Close()
+
+	imp := newTaskCmd()
+	imp.SetArgs([]string{"import", bundlePath})
+	if err := imp.Execute(); err != nil {
+		t.Fatalf("task import: %v", err)
+	}
+
+	backend, err = getBackend()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = backend.Close() }()
+	got, err := backend.LoadTask(taskID)
+	if err != nil {
+		t.Fatalf("load imported task: %v", err)
+	}
+	if got.Status != orcv1.TaskStatus_TASK_STATUS_PAUSED {
+		t.Errorf("imported running task status = %v, want paused", got.Status)
+	}
+	if run, err := backend.GetWorkflowRun("RUN-001"); err != nil || run == nil || run.Status != "paused" {
+		t.Errorf("imported run = %+v, %v; want paused run", run, err)
+	}
+	if out, err := backend.GetPhaseOutput("RUN-001", "spec"); err != nil || out == nil || out.Content != "# Spec" {
+		t.Errorf("imported phase output = %+v, %v", out, err)
+	}
+	if transcripts, _ := backend.GetTranscripts(taskID); len(transcripts) != 1 {
+		t.Errorf("imported %d transcripts, want 1", len(transcripts))
+	}
+	if _, data, err := backend.GetAttachment(taskID, "notes.txt"); err != nil || string(data) != "notes" {
+		t.Errorf("imported attachment = %q, %v", data, err)
+	}
+
+	// Importing again is a no-op: the local copy is now newer.
+	again := newTaskCmd()
+	again.SetArgs([]string{"import", bundlePath})
+	if err := again.Execute(); err == nil {
+		t.Error("re-importing an older bundle should be skipped")
+	}
+}
+
+func TestTaskBundleJSON_TaskRoundTripsThroughImport(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tmpDir := withTempDir(t)
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".orc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, ".orc", "config.yaml"), []byte("version: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	backend, err := getBackend()
+	if err != nil {
+		t.Fatal(err)
+	}
+	description := "Carries proto-only fields"
+	commit := "abc123"
+	tk := task.NewProtoTask("TASK-002", "Proto task")
+	tk.Description = &description
+	tk.Priority = orcv1.TaskPriority_TASK_PRIORITY_HIGH
+	tk.StartedAt = timestamppb.New(time.Now().Add(-time.Hour).Truncate(time.Second))
+	tk.Execution.Phases["spec"] = &orcv1.PhaseState{
+		Status:     orcv1.PhaseStatus_PHASE_STATUS_COMPLETED,
+		StartedAt:  tk.StartedAt,
+		Iterations: 2,
+		CommitSha:  &commit,
+	}
+	mustDo(t, backend.SaveTask(tk))
+	_ = backend.Close()
+
+	bundlePath := filepath.Join(t.TempDir(), "task.json")
+	export := newTaskCmd()
+	export.SetArgs([]string{"export", tk.Id, "--bundle", bundlePath, "--format", taskBundleFormatJSON})
+	if err := export.Execute(); err != nil {
+		t.Fatalf("task export: %v", err)
+	}
+	data, err := os.ReadFile(bundlePath)
+	mustDo(t, err)
+	for _, want := range []string{fmt.Sprintf(`"version": %d`, ExportFormatVersion), `"priority": "TASK_PRIORITY_HIGH"`, `"commit_sha": "abc123"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("bundle missing %s:\n%s", want, data)
+		}
+	}
+
+	backend, err = getBackend()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustDo(t, backend.DeleteTask(tk.Id))
+	_ = backend.Close()
+
+	imp := newTaskCmd()
+	imp.SetArgs([]string{"import", bundlePath})
+	if err := imp.Execute(); err != nil {
+		t.Fatalf("task import: %v", err)
+	}
+
+	backend, err = getBackend()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = backend.Close() }()
+	got, err := backend.LoadTask(tk.Id)
+	if err != nil {
+		t.Fatalf("load imported task: %v", err)
+	}
+	if got.GetDescription() != description || got.Priority != tk.Priority || !got.StartedAt.AsTime().Equal(tk.StartedAt.AsTime()) {
+		t.Errorf("imported task = %v", got)
+	}
+	if phase := got.Execution.Phases["spec"]; phase == nil || phase.Status != orcv1.PhaseStatus_PHASE_STATUS_COMPLETED || phase.GetCommitSha() != commit || phase.Iterations != 2 {
+		t.Errorf("imported spec phase = %v", phase)
+	}
+}
+
+func TestReadTaskBundle_RejectsNonBundle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "task.orc")
+	if err := os.WriteFile(path, []byte("task: {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readTaskBundle(path); err == nil {
+		t.Error("expected error for a non-archive file")
+	}
+}
+
+func TestTaskExport_RejectsUnknownFormat(t *testing.T) {
+	cmd := newTaskCmd()
+	cmd.SetArgs([]string{"export", "TASK-001", "--format", "zip"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error for an unknown format")
+	}
+}
+
+func mustDo(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
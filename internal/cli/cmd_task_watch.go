@@ -0,0 +1,312 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"connectrpc.com/connect"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/gen/proto/orc/v1/orcv1connect"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+const (
+	// watchPollInterval is how often the local event log is read.
+	watchPollInterval = time.Second
+
+	// watchLogOverlap re-reads this much of the event log on every poll.
+	// Publishers write events in batches, so a row can land after newer
+	// rows were already read.
+	watchLogOverlap = 15 * time.Second
+)
+
+func newTaskWatchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch <task-id>",
+		Short: "Show a task's live progress in the terminal",
+		Long: `Show a task's progress live: phase status, token usage, the current
+tool call, and pending gate decisions.
+
+Progress is read from the project's event log, which both 'orc run' and
+'orc serve' write, so watch works from a second terminal wherever the task
+runs on this machine. Events are written in batches and can appear a few
+seconds late. With --url, events are streamed from a running server instead,
+e.g. when the task runs on another machine.
+
+Watching stops when the task completes, fails or is closed; press q to stop
+earlier. With --plain, or when output is not a terminal, each change is
+printed as one line instead of a live view.
+
+Examples:
+  orc task watch TASK-001
+  orc task watch TASK-001 --plain > progress.log
+  orc task watch TASK-001 --url http://build:8080`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			taskID := args[0]
+			serverURL, _ := cmd.Flags().GetString("url")
+
+			var src watchSource
+			if serverURL != "" {
+				projectID, _ := ResolveProjectID()
+				src = &serverWatchSource{
+					baseURL:   strings.TrimSuffix(serverURL, "/"),
+					projectID: projectID,
+					taskID:    taskID,
+				}
+			} else {
+				projectRoot, err := ResolveProjectPath()
+				if err != nil {
+					return err
+				}
+				if err := config.RequireInitAt(projectRoot); err != nil {
+					return err
+				}
+				backend, err := getBackend()
+				if err != nil {
+					return err
+				}
+				defer func() { _ = backend.Close() }()
+				if _, err := backend.LoadTask(taskID); err != nil {
+					return fmt.Errorf("load task %s: %w", taskID, err)
+				}
+				src = &logWatchSource{backend: backend, taskID: taskID}
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+			updates := make(chan watchUpdate, 64)
+			go src.run(ctx, updates)
+
+			state := newTaskWatchState(taskID)
+			if plain || !isatty.IsTerminal(os.Stdout.Fd()) {
+				return watchPlain(cmd.OutOrStdout(), state, updates)
+			}
+			return watchTUI(ctx, state, updates)
+		},
+	}
+
+	cmd.Flags().String("url", "", "stream events from a running server instead of the local event log")
+
+	return cmd
+}
+
+// watchSource produces the updates `orc task watch` displays.
+type watchSource interface {
+	// run sends updates until ctx is done or an error was sent, then
+	// closes out.
+	run(ctx context.Context, out chan<- watchUpdate)
+}
+
+// sendWatchUpdate delivers u unless ctx is done first.
+func sendWatchUpdate(ctx context.Context, out chan<- watchUpdate, u watchUpdate) bool {
+	select {
+	case out <- u:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// logWatchSource polls the project's event log. The first poll replays the
+// task's history, so the view starts with everything that already happened.
+type logWatchSource struct {
+	backend storage.Backend
+	taskID  string
+}
+
+func (s *logWatchSource) run(ctx context.Context, out chan<- watchUpdate) {
+	defer close(out)
+
+	var lastID int64
+	var since *time.Time
+	for {
+		logs, err := s.backend.QueryEvents(db.QueryEventsOptions{TaskID: s.taskID, Since: since})
+		if err != nil {
+			sendWatchUpdate(ctx, out, watchUpdate{err: fmt.Errorf("read events for %s: %w", s.taskID, err)})
+			return
+		}
+		// Newest first; deliver in order
+		for i := len(logs) - 1; i >= 0; i-- {
+			if logs[i].ID <= lastID {
+				continue
+			}
+			lastID = logs[i].ID
+			if ev, ok := watchEventFromLog(logs[i]); ok {
+				if !sendWatchUpdate(ctx, out, watchUpdate{event: &ev}) {
+					return
+				}
+			}
+		}
+		if len(logs) > 0 {
+			from := logs[0].CreatedAt.Add(-watchLogOverlap)
+			since = &from
+		}
+
+		// Read the task after its events so a final status is seen last
+		t, err := s.backend.LoadTask(s.taskID)
+		if err != nil {
+			sendWatchUpdate(ctx, out, watchUpdate{err: fmt.Errorf("load task %s: %w", s.taskID, err)})
+			return
+		}
+		if !sendWatchUpdate(ctx, out, watchUpdate{task: t}) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchPollInterval):
+		}
+	}
+}
+
+// serverWatchSource streams events from a running server and reloads the
+// task whenever its state changes.
+type serverWatchSource struct {
+	baseURL   string
+	projectID string
+	taskID    string
+}
+
+func (s *serverWatchSource) run(ctx context.Context, out chan<- watchUpdate) {
+	defer close(out)
+
+	tasks := orcv1connect.NewTaskServiceClient(http.DefaultClient, s.baseURL, serverClientOptions()...)
+	refresh := func() bool {
+		resp, err := tasks.GetTask(ctx, connect.NewRequest(&orcv1.GetTaskRequest{ProjectId: s.projectID, TaskId: s.taskID}))
+		if err != nil {
+			if ctx.Err() == nil {
+				sendWatchUpdate(ctx, out, watchUpdate{err: fmt.Errorf("get task %s from %s: %w", s.taskID, s.baseURL, err)})
+			}
+			return false
+		}
+		return sendWatchUpdate(ctx, out, watchUpdate{task: resp.Msg.Task})
+	}
+	if !refresh() {
+		return
+	}
+
+	req := &orcv1.SubscribeRequest{TaskId: &s.taskID}
+	if s.projectID != "" {
+		req.ProjectIds = []string{s.projectID}
+	}
+	stream, err := orcv1connect.NewEventServiceClient(http.DefaultClient, s.baseURL, serverClientOptions()...).Subscribe(ctx, connect.NewRequest(req))
+	if err != nil {
+		sendWatchUpdate(ctx, out, watchUpdate{err: fmt.Errorf("subscribe to %s events: %w", s.taskID, err)})
+		return
+	}
+	defer func() { _ = stream.Close() }()
+
+	for stream.Receive() {
+		msg := stream.Msg().GetEvent()
+		if ev, ok := watchEventFromProto(msg); ok {
+			if !sendWatchUpdate(ctx, out, watchUpdate{event: &ev}) {
+				return
+			}
+		}
+		if msg.GetTaskUpdated() != nil || msg.GetPhaseChanged() != nil {
+			if !refresh() {
+				return
+			}
+		}
+	}
+	if err := stream.Err(); err != nil && ctx.Err() == nil {
+		sendWatchUpdate(ctx, out, watchUpdate{err: fmt.Errorf("event stream from %s: %w", s.baseURL, err)})
+	}
+}
+
+// watchPlain prints one line per change until the task finishes.
+func watchPlain(out io.Writer, state *taskWatchState, updates <-chan watchUpdate) error {
+	for u := range updates {
+		if u.err != nil {
+			return u.err
+		}
+		for _, line := range state.apply(u) {
+			_, _ = fmt.Fprintf(out, "%s %s\n", time.Now().Format("15:04:05"), line)
+		}
+		if state.done {
+			_, _ = fmt.Fprintln(out, state.summary())
+			return nil
+		}
+	}
+	return nil
+}
+
+// watchClosedMsg tells the live view that its source stopped.
+type watchClosedMsg struct{}
+
+// taskWatchModel is the live `orc task watch` view.
+type taskWatchModel struct {
+	state   *taskWatchState
+	updates <-chan watchUpdate
+	styles  watchStyles
+	err     error
+}
+
+func waitForWatchUpdate(updates <-chan watchUpdate) tea.Cmd {
+	return func() tea.Msg {
+		u, ok := <-updates
+		if !ok {
+			return watchClosedMsg{}
+		}
+		return u
+	}
+}
+
+func (m *taskWatchModel) Init() tea.Cmd {
+	return waitForWatchUpdate(m.updates)
+}
+
+func (m *taskWatchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+	case watchUpdate:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, tea.Quit
+		}
+		m.state.apply(msg)
+		if m.state.done {
+			return m, tea.Quit
+		}
+		return m, waitForWatchUpdate(m.updates)
+	case watchClosedMsg:
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m *taskWatchModel) View() string {
+	view := m.state.render(m.styles)
+	if !m.state.done {
+		view += "\n" + m.styles.subtle.Render("q: stop watching") + "\n"
+	}
+	return view
+}
+
+// watchTUI runs the live view until the task finishes or the user quits.
+func watchTUI(ctx context.Context, state *taskWatchState, updates <-chan watchUpdate) error {
+	m := &taskWatchModel{state: state, updates: updates, styles: defaultWatchStyles()}
+	if _, err := tea.NewProgram(m, tea.WithContext(ctx)).Run(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("watch view: %w", err)
+	}
+	return m.err
+}
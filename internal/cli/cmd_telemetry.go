@@ -0,0 +1,245 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/project"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/telemetry"
+)
+
+// telemetryStatus is the telemetry status JSON output.
+type telemetryStatus struct {
+	Enabled      bool              `json:"enabled"`
+	Active       bool              `json:"active"`
+	DisabledBy   string            `json:"disabled_by,omitempty"`
+	InstallID    string            `json:"install_id,omitempty"`
+	Endpoint     string            `json:"endpoint"`
+	LastSentAt   *time.Time        `json:"last_sent_at,omitempty"`
+	NextReportAt *time.Time        `json:"next_report_at,omitempty"`
+	NextReport   *telemetry.Report `json:"next_report"`
+	LastReport   *telemetry.Report `json:"last_report,omitempty"`
+}
+
+// newTelemetryCmd creates the telemetry command
+func newTelemetryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "telemetry",
+		Short: "Manage opt-in anonymous usage reporting",
+		Long: `Manage opt-in anonymous usage reporting.
+
+Telemetry is off unless you enable it. When enabled, 'orc serve' sends one
+report a week to help the maintainers prioritize. A report holds only
+aggregate counts across your registered projects:
+
+  • tasks created, completed and failed, by category and built-in workflow
+  • phase runs, failures and durations, by built-in phase ("custom" otherwise)
+  • failed phases by error category (rate_limited, timeout, git, ...)
+  • orc version, OS and architecture, and a random install ID
+
+Never code, prompts, transcripts, task titles, file paths, project or
+custom workflow names, or error messages. 'orc telemetry status' prints the
+exact payload of the next report; the last one sent is kept in
+~/.orc/telemetry.json.
+
+DO_NOT_TRACK=1 or ORC_TELEMETRY=off disables reporting regardless of this
+setting. ORC_TELEMETRY_ENDPOINT overrides where reports go.
+
+Commands:
+  status    Show the setting and the next report's payload
+  enable    Opt in
+  disable   Opt out and forget the install ID`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTelemetryStatus(cmd, false)
+		},
+	}
+	cmd.AddCommand(newTelemetryStatusCmd())
+	cmd.AddCommand(newTelemetryEnableCmd())
+	cmd.AddCommand(newTelemetryDisableCmd())
+	return cmd
+}
+
+func newTelemetryStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the telemetry setting and the exact next report",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			last, _ := cmd.Flags().GetBool("last")
+			return runTelemetryStatus(cmd, last)
+		},
+	}
+	cmd.Flags().Bool("last", false, "show the last report sent instead of the next one")
+	return cmd
+}
+
+func newTelemetryEnableCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "enable",
+		Short: "Opt in to anonymous usage reporting",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, state, err := loadTelemetryState()
+			if err != nil {
+				return err
+			}
+			if !state.Enabled {
+				if err := state.Enable(time.Now()); err != nil {
+					return err
+				}
+				if err := state.Save(path); err != nil {
+					return err
+				}
+			}
+			w := cmd.OutOrStdout()
+			_, _ = fmt.Fprintln(w, "Telemetry enabled. Thank you!")
+			_, _ = fmt.Fprintln(w, "'orc serve' sends an anonymous aggregate report weekly; see it with 'orc telemetry status'.")
+			if by, off := telemetry.EnvDisabled(); off {
+				_, _ = fmt.Fprintf(w, "Note: %s is set, so nothing is sent until it is unset.\n", by)
+			}
+			return nil
+		},
+	}
+}
+
+func newTelemetryDisableCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable",
+		Short: "Opt out of usage reporting",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, state, err := loadTelemetryState()
+			if err != nil {
+				return err
+			}
+			state.Disable(time.Now())
+			if err := state.Save(path); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Telemetry disabled. No reports will be sent.")
+			return nil
+		},
+	}
+}
+
+func loadTelemetryState() (string, *telemetry.State, error) {
+	path, err := telemetry.Path()
+	if err != nil {
+		return "", nil, err
+	}
+	state, err := telemetry.Load(path)
+	if err != nil {
+		return "", nil, err
+	}
+	return path, state, nil
+}
+
+func runTelemetryStatus(cmd *cobra.Command, showLast bool) error {
+	_, state, err := loadTelemetryState()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	next, err := buildTelemetryPreview(state, now)
+	if err != nil {
+		return err
+	}
+
+	status := telemetryStatus{
+		Enabled:    state.Enabled,
+		Active:     state.Active(),
+		InstallID:  state.InstallID,
+		Endpoint:   telemetry.Endpoint(),
+		NextReport: next,
+		LastReport: state.LastReport,
+	}
+	if by, off := telemetry.EnvDisabled(); off {
+		status.DisabledBy = by
+	}
+	if !state.LastSentAt.IsZero() {
+		status.LastSentAt = &state.LastSentAt
+	}
+	if status.Active {
+		at := state.LastSentAt
+		if at.IsZero() {
+			at = state.DecidedAt
+		}
+		at = at.Add(telemetry.ReportInterval)
+		status.NextReportAt = &at
+	}
+	if jsonOut {
+		return outputJSON(cmd, status)
+	}
+
+	w := cmd.OutOrStdout()
+	switch {
+	case status.Active:
+		_, _ = fmt.Fprintf(w, "Telemetry: enabled (install ID %s)\n", state.InstallID)
+	case state.Enabled:
+		_, _ = fmt.Fprintf(w, "Telemetry: enabled, but turned off by %s\n", status.DisabledBy)
+	default:
+		_, _ = fmt.Fprintln(w, "Telemetry: disabled (enable with 'orc telemetry enable')")
+	}
+	_, _ = fmt.Fprintf(w, "Endpoint:  %s\n", status.Endpoint)
+	if status.LastSentAt != nil {
+		_, _ = fmt.Fprintf(w, "Last sent: %s\n", status.LastSentAt.Local().Format(time.RFC1123))
+	}
+	if status.NextReportAt != nil {
+		_, _ = fmt.Fprintf(w, "Next due:  %s (sent by 'orc serve')\n", status.NextReportAt.Local().Format(time.RFC1123))
+	}
+
+	if showLast {
+		if state.LastReport == nil {
+			_, _ = fmt.Fprintln(w, "\nNo report has been sent.")
+			return nil
+		}
+		_, _ = fmt.Fprintln(w, "\nLast report sent (exactly as sent):")
+		return printTelemetryReport(w, state.LastReport)
+	}
+	if state.Enabled {
+		_, _ = fmt.Fprintln(w, "\nNext report (exactly what will be sent; counts grow until then):")
+	} else {
+		_, _ = fmt.Fprintln(w, "\nThis is exactly what would be sent if enabled:")
+	}
+	return printTelemetryReport(w, next)
+}
+
+func printTelemetryReport(w io.Writer, report *telemetry.Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// buildTelemetryPreview builds the next report from the registered projects,
+// as 'orc serve' would, falling back to the current project.
+func buildTelemetryPreview(state *telemetry.State, now time.Time) (*telemetry.Report, error) {
+	var backends []storage.Backend
+	defer func() {
+		for _, b := range backends {
+			_ = b.Close()
+		}
+	}()
+	if reg, err := project.LoadRegistry(); err == nil {
+		for _, p := range reg.ValidProjects() {
+			cfg, err := config.LoadFrom(p.Path)
+			if err != nil {
+				continue
+			}
+			if b, err := storage.NewBackend(p.Path, &cfg.Storage); err == nil {
+				backends = append(backends, b)
+			}
+		}
+	}
+	if len(backends) == 0 {
+		if b, err := getBackend(); err == nil {
+			backends = append(backends, b)
+		}
+	}
+	return state.Build(version, backends, now)
+}
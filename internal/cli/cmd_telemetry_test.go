@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/telemetry"
+)
+
+func TestTelemetryCommand_EnableStatusDisable(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DO_NOT_TRACK", "")
+	t.Setenv("ORC_TELEMETRY", "")
+	withStatusTestDir(t)
+
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		cmd := newTelemetryCmd()
+		cmd.SetOut(&out)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("telemetry %v: %v", args, err)
+		}
+		return out.String()
+	}
+
+	if got := run("status"); !strings.Contains(got, "Telemetry: disabled") || !strings.Contains(got, `"schema_version": 1`) {
+		t.Errorf("status before enabling:\n%s", got)
+	}
+
+	run("enable")
+	path, _ := telemetry.Path()
+	state, err := telemetry.Load(path)
+	if err != nil || !state.Active() {
+		t.Fatalf("state after enable = %+v, %v", state, err)
+	}
+	if got := run("status"); !strings.Contains(got, "enabled (install ID "+state.InstallID+")") || !strings.Contains(got, `"install_id": "`+state.InstallID+`"`) {
+		t.Errorf("status after enabling:\n%s", got)
+	}
+
+	run("disable")
+	if state, _ = telemetry.Load(path); state.Enabled || state.InstallID != "" {
+		t.Errorf("state after disable = %+v", state)
+	}
+}
@@ -0,0 +1,365 @@
+// Package cli implements the orc command-line interface.
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/project"
+)
+
+// newTenantCmd creates the tenant command for multi-tenant server mode
+func newTenantCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tenant",
+		Short: "Manage tenants for multi-tenant server mode",
+		Long: `Manage tenants sharing one orc server.
+
+With server.tenancy.enabled, every API request must carry a tenant token
+(Authorization: Bearer <token>) and can only reach projects owned by that
+tenant. Tenants, tokens, quotas, and project ownership are stored in the
+global database (~/.orc/orc.db).
+
+Commands:
+  list       List tenants and their quotas
+  create     Create a tenant
+  quota      Change a tenant's quotas
+  delete     Delete a tenant (projects are kept)
+  assign     Give a tenant ownership of a project
+  unassign   Remove a project from its tenant
+  token      Issue, list, and revoke tenant API tokens
+
+Example:
+  orc tenant create payments --max-projects 5 --max-running 2
+  orc tenant assign payments ~/repos/billing
+  orc tenant token create payments ci`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withGlobalDB(runTenantList)
+		},
+	}
+
+	cmd.AddCommand(newTenantListCmd())
+	cmd.AddCommand(newTenantCreateCmd())
+	cmd.AddCommand(newTenantQuotaCmd())
+	cmd.AddCommand(newTenantDeleteCmd())
+	cmd.AddCommand(newTenantAssignCmd())
+	cmd.AddCommand(newTenantUnassignCmd())
+	cmd.AddCommand(newTenantTokenCmd())
+
+	return cmd
+}
+
+// withGlobalDB opens the global database for the duration of fn.
+func withGlobalDB(fn func(g *db.GlobalDB) error) error {
+	g, err := db.OpenGlobal()
+	if err != nil {
+		return fmt.Errorf("open global database: %w", err)
+	}
+	defer func() { _ = g.Close() }()
+	return fn(g)
+}
+
+// requireTenant looks up a tenant by ID or name.
+func requireTenant(g *db.GlobalDB, idOrName string) (*db.Tenant, error) {
+	t, err := g.GetTenant(idOrName)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, fmt.Errorf("tenant %s not found", idOrName)
+	}
+	return t, nil
+}
+
+func formatQuota(n int) string {
+	if n <= 0 {
+		return "unlimited"
+	}
+	return fmt.Sprint(n)
+}
+
+type tenantListEntry struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	MaxProjects     int      `json:"max_projects"`
+	MaxRunningTasks int      `json:"max_running_tasks"`
+	Projects        []string `json:"projects"`
+}
+
+func runTenantList(g *db.GlobalDB) error {
+	tenants, err := g.ListTenants()
+	if err != nil {
+		return err
+	}
+
+	entries := make([]tenantListEntry, 0, len(tenants))
+	for _, t := range tenants {
+		ids, err := g.ListTenantProjectIDs(t.ID)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, tenantListEntry{
+			ID: t.ID, Name: t.Name, MaxProjects: t.MaxProjects, MaxRunningTasks: t.MaxRunningTasks, Projects: ids,
+		})
+	}
+
+	if jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+	if len(entries) == 0 {
+		fmt.Println("No tenants. Create one with 'orc tenant create <name>'.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tID\tPROJECTS\tMAX PROJECTS\tMAX RUNNING")
+	for _, e := range entries {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", e.Name, e.ID, len(e.Projects),
+			formatQuota(e.MaxProjects), formatQuota(e.MaxRunningTasks))
+	}
+	return w.Flush()
+}
+
+func newTenantListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List tenants and their quotas",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withGlobalDB(runTenantList)
+		},
+	}
+}
+
+func newTenantCreateCmd() *cobra.Command {
+	var maxProjects, maxRunning int
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a tenant",
+		Long: `Create a tenant. Quotas of 0 mean unlimited.
+
+Example:
+  orc tenant create payments --max-projects 5 --max-running 2`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withGlobalDB(func(g *db.GlobalDB) error {
+				t := &db.Tenant{Name: args[0], MaxProjects: maxProjects, MaxRunningTasks: maxRunning}
+				if err := g.CreateTenant(t); err != nil {
+					return err
+				}
+				fmt.Printf("Created tenant %s (%s)\n", t.Name, t.ID)
+				fmt.Printf("Issue a token with: orc tenant token create %s <token-name>\n", t.Name)
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().IntVar(&maxProjects, "max-projects", 0, "maximum projects the tenant can own (0 = unlimited)")
+	cmd.Flags().IntVar(&maxRunning, "max-running", 0, "maximum concurrently running tasks (0 = unlimited)")
+
+	return cmd
+}
+
+func newTenantQuotaCmd() *cobra.Command {
+	var maxProjects, maxRunning int
+
+	cmd := &cobra.Command{
+		Use:   "quota <tenant>",
+		Short: "Change a tenant's quotas",
+		Long: `Change a tenant's quotas. Flags that are not given keep their current value.
+
+Example:
+  orc tenant quota payments --max-running 4`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withGlobalDB(func(g *db.GlobalDB) error {
+				t, err := requireTenant(g, args[0])
+				if err != nil {
+					return err
+				}
+				if cmd.Flags().Changed("max-projects") {
+					t.MaxProjects = maxProjects
+				}
+				if cmd.Flags().Changed("max-running") {
+					t.MaxRunningTasks = maxRunning
+				}
+				if err := g.UpdateTenantQuotas(t.ID, t.MaxProjects, t.MaxRunningTasks); err != nil {
+					return err
+				}
+				fmt.Printf("Tenant %s: max projects %s, max running tasks %s\n",
+					t.Name, formatQuota(t.MaxProjects), formatQuota(t.MaxRunningTasks))
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().IntVar(&maxProjects, "max-projects", 0, "maximum projects the tenant can own (0 = unlimited)")
+	cmd.Flags().IntVar(&maxRunning, "max-running", 0, "maximum concurrently running tasks (0 = unlimited)")
+
+	return cmd
+}
+
+func newTenantDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <tenant>",
+		Short: "Delete a tenant, its tokens, and its project assignments",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withGlobalDB(func(g *db.GlobalDB) error {
+				t, err := requireTenant(g, args[0])
+				if err != nil {
+					return err
+				}
+				if err := g.DeleteTenant(t.ID); err != nil {
+					return err
+				}
+				fmt.Printf("Deleted tenant %s\n", t.Name)
+				return nil
+			})
+		},
+	}
+}
+
+func newTenantAssignCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "assign <tenant> <project>",
+		Short: "Give a tenant ownership of a registered project",
+		Long: `Give a tenant ownership of a registered project (ID, name, or path).
+A project belongs to at most one tenant; assigning it again moves it.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reg, err := project.LoadRegistry()
+			if err != nil {
+				return fmt.Errorf("load registry: %w", err)
+			}
+			proj, err := reg.Get(args[1])
+			if err != nil {
+				return err
+			}
+			return withGlobalDB(func(g *db.GlobalDB) error {
+				t, err := requireTenant(g, args[0])
+				if err != nil {
+					return err
+				}
+				if err := g.AssignProjectToTenant(t.ID, proj.ID); err != nil {
+					return err
+				}
+				fmt.Printf("Project %s (%s) now belongs to tenant %s\n", proj.Name, proj.ID, t.Name)
+				return nil
+			})
+		},
+	}
+}
+
+func newTenantUnassignCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unassign <project>",
+		Short: "Remove a project from its tenant",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectID := args[0]
+			if reg, err := project.LoadRegistry(); err == nil {
+				if proj, err := reg.Get(args[0]); err == nil {
+					projectID = proj.ID
+				}
+			}
+			return withGlobalDB(func(g *db.GlobalDB) error {
+				if err := g.UnassignProject(projectID); err != nil {
+					return err
+				}
+				fmt.Printf("Project %s is no longer assigned to a tenant\n", projectID)
+				return nil
+			})
+		},
+	}
+}
+
+func newTenantTokenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Manage tenant API tokens",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "create <tenant> <name>",
+		Short: "Issue an API token (shown once)",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withGlobalDB(func(g *db.GlobalDB) error {
+				t, err := requireTenant(g, args[0])
+				if err != nil {
+					return err
+				}
+				token, err := g.CreateTenantToken(t.ID, args[1])
+				if err != nil {
+					return err
+				}
+				if jsonOut {
+					return json.NewEncoder(os.Stdout).Encode(map[string]string{
+						"tenant": t.Name, "name": args[1], "token": token,
+					})
+				}
+				fmt.Println(token)
+				fmt.Fprintln(os.Stderr, "Store this token now; it cannot be shown again.")
+				return nil
+			})
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list <tenant>",
+		Short: "List a tenant's tokens",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withGlobalDB(func(g *db.GlobalDB) error {
+				t, err := requireTenant(g, args[0])
+				if err != nil {
+					return err
+				}
+				tokens, err := g.ListTenantTokens(t.ID)
+				if err != nil {
+					return err
+				}
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				_, _ = fmt.Fprintln(w, "NAME\tCREATED\tLAST USED")
+				for _, tok := range tokens {
+					lastUsed := "never"
+					if tok.LastUsedAt != nil {
+						lastUsed = tok.LastUsedAt.Format("2006-01-02 15:04")
+					}
+					_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", tok.Name, tok.CreatedAt.Format("2006-01-02 15:04"), lastUsed)
+				}
+				return w.Flush()
+			})
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "revoke <tenant> <name>",
+		Short: "Revoke a tenant's token",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withGlobalDB(func(g *db.GlobalDB) error {
+				t, err := requireTenant(g, args[0])
+				if err != nil {
+					return err
+				}
+				if err := g.RevokeTenantToken(t.ID, args[1]); err != nil {
+					return err
+				}
+				fmt.Printf("Revoked token %s for tenant %s\n", args[1], t.Name)
+				return nil
+			})
+		},
+	})
+
+	return cmd
+}
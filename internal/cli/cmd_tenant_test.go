@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/project"
+)
+
+func TestTenantCommands(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	proj, err := project.RegisterProject(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) error {
+		cmd := newTenantCmd()
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+	if err := run("create", "payments", "--max-running", "2"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := run("assign", "payments", proj.Path); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	if err := run("quota", "payments", "--max-projects", "3"); err != nil {
+		t.Fatalf("quota: %v", err)
+	}
+	if err := run("assign", "missing", proj.ID); err == nil {
+		t.Error("assigning to an unknown tenant should fail")
+	}
+
+	err = withGlobalDB(func(g *db.GlobalDB) error {
+		tenant, err := g.GetTenant("payments")
+		if err != nil {
+			return err
+		}
+		if tenant.MaxProjects != 3 || tenant.MaxRunningTasks != 2 {
+			t.Errorf("quotas = %d/%d, want 3/2 (unchanged flags keep their value)", tenant.MaxProjects, tenant.MaxRunningTasks)
+		}
+		owner, err := g.GetProjectTenantID(proj.ID)
+		if err != nil {
+			return err
+		}
+		if owner != tenant.ID {
+			t.Errorf("project owner = %q, want %s", owner, tenant.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
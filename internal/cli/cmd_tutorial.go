@@ -0,0 +1,512 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/gate"
+	"github.com/randalmurphal/orc/internal/workflow"
+)
+
+// tutorialCommand starts the orc and git processes the tutorial runs.
+// Replaced in tests.
+var tutorialCommand = exec.Command
+
+const (
+	tutorialDefaultBranch   = "orc-tutorial"
+	tutorialDefaultWorkflow = "implement-small"
+
+	tutorialTaskTitle       = "Add ORC_TUTORIAL.md describing how orc works"
+	tutorialTaskDescription = `Create ORC_TUTORIAL.md in the repository root with the heading "Working with orc" ` +
+		`followed by two or three sentences: orc runs each task through a workflow of phases, every phase ends at a gate, ` +
+		`and the finished work lands on a branch for review. Do not change any other file. ` +
+		`This is the sample task created by 'orc tutorial'.`
+)
+
+// tutorialPhaseNotes explains the built-in phase templates. Other phases fall
+// back to their template description.
+var tutorialPhaseNotes = map[string]string{
+	"tiny_spec": "Claude writes a short specification: what changes, how success is measured and which tests " +
+		"prove it. Every later phase receives it, so a vague spec leads to vague work.",
+	"spec": "Claude writes a full specification with intent, success criteria and a testing plan. Later " +
+		"phases are checked against it.",
+	"plan": "Claude decides what to change and what to test before touching code, and records the success " +
+		"criteria the reviews use.",
+	"tdd_write": "A separate session writes failing tests from the spec alone, so the tests are not shaped by " +
+		"the implementation.",
+	"breakdown": "Large tasks are split into checkboxed steps the implement phase works through.",
+	"implement": "Claude edits code in the task's own worktree until the spec is met. orc then runs the " +
+		"project's quality checks (tests, lint, build); a failing check sends the phase back with the output " +
+		"instead of moving on.",
+	"implement_codex": "Like implement, but the code is written by the Codex provider so a different model " +
+		"reviews it afterwards.",
+	"review": "A fresh session reviews the diff against the spec. When it finds problems the workflow loops " +
+		"back to implement with the findings, up to the loop limit.",
+	"review_cross": "A second, independent review by a different model than the one that wrote the code.",
+	"docs":         "Claude updates documentation the change affects.",
+	"qa_e2e_test":  "For frontend changes, a browser session exercises the feature and reports what breaks.",
+}
+
+// tutorialGateNotes explains what each gate type does when a phase ends.
+var tutorialGateNotes = map[gate.GateType]string{
+	gate.GateAuto:  "orc checks the phase result itself (completion status, quality checks) and continues.",
+	gate.GateAI:    "a separate agent reads the phase output and approves it, or sends it back for another attempt.",
+	gate.GateHuman: "the run stops and the task is BLOCKED until you run 'orc approve <task>' (or 'orc reject'), then 'orc run <task>'.",
+	gate.GateSkip:  "nothing is checked; the next phase starts straight away.",
+}
+
+// tutorialGateSources names where a resolved gate type came from.
+var tutorialGateSources = map[string]string{
+	"task_override":   "a task override",
+	"weight_override": "gates.weight_overrides",
+	"phase_override":  "gates.phase_overrides",
+	"phase_gate":      "the phase template",
+	"disabled":        "a disabled phase",
+	"default":         "gates.default_type",
+}
+
+func newTutorialCmd() *cobra.Command {
+	var (
+		branch     string
+		workflowID string
+		yes        bool
+		dryRun     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "tutorial",
+		Short: "Walk through creating, running and finalizing a sample task",
+		Long: `Guided first task: create, run and finalize a tiny sample task step by step,
+with an explanation of every phase and gate along the way.
+
+The tutorial runs the same commands you would (orc new, orc run, orc show,
+orc diff) and prints each one before running it. Nothing reaches your main
+branch or your remote:
+
+  • The task runs with completion action 'commit', so its work stays on the
+    task's own branch; no push, no pull request.
+  • Finalizing merges the task branch into a scratch branch (default
+    orc-tutorial) created from your current HEAD, in a temporary worktree.
+    Your checkout is never switched.
+
+The sample task asks Claude to add ORC_TUTORIAL.md, so running it costs a
+few cents of usage. Use --dry-run to read the whole walkthrough without
+running anything.
+
+Examples:
+  orc tutorial
+  orc tutorial --dry-run
+  orc tutorial --workflow implement-trivial --branch try-orc`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRoot, err := ResolveProjectPath()
+			if err != nil {
+				return err
+			}
+			if err := config.RequireInitAt(projectRoot); err != nil {
+				return err
+			}
+			cfg, err := config.LoadFrom(projectRoot)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+
+			tut := &tutorial{
+				out:         cmd.OutOrStdout(),
+				in:          bufio.NewReader(cmd.InOrStdin()),
+				projectRoot: projectRoot,
+				branch:      branch,
+				workflowID:  workflowID,
+				yes:         yes,
+				dryRun:      dryRun,
+			}
+			if !dryRun {
+				if tut.orcBin, err = os.Executable(); err != nil {
+					return fmt.Errorf("locate orc binary: %w", err)
+				}
+				if err := tut.preflight(cfg); err != nil {
+					return err
+				}
+			}
+			return tut.run(cfg)
+		},
+	}
+
+	cmd.Flags().StringVar(&branch, "branch", tutorialDefaultBranch, "scratch branch the sample task is finalized into")
+	cmd.Flags().StringVarP(&workflowID, "workflow", "w", tutorialDefaultWorkflow, "workflow to run the sample task with")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "don't pause between steps")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "explain every step without running anything")
+	return cmd
+}
+
+// tutorial holds the state of one `orc tutorial` session.
+type tutorial struct {
+	out         io.Writer
+	in          *bufio.Reader
+	projectRoot string
+	orcBin      string
+	branch      string
+	workflowID  string
+	yes         bool
+	dryRun      bool
+
+	step   int
+	taskID string
+}
+
+// preflight checks what the tutorial needs before it changes anything.
+func (t *tutorial) preflight(cfg *config.Config) error {
+	if err := t.git("rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return fmt.Errorf("the tutorial needs a git repository with at least one commit: %w", err)
+	}
+	if err := t.git("rev-parse", "--verify", "--quiet", "refs/heads/"+t.branch); err == nil {
+		return fmt.Errorf("branch %s already exists\n\nDelete it (git branch -D %s) or pick another with --branch", t.branch, t.branch)
+	}
+	claudePath := cfg.ClaudePath
+	if claudePath == "" {
+		claudePath = "claude"
+	}
+	if _, err := exec.LookPath(claudePath); err != nil {
+		return fmt.Errorf("claude CLI not found (%s): install it with 'npm install -g @anthropic-ai/claude-code'", claudePath)
+	}
+	return nil
+}
+
+func (t *tutorial) run(cfg *config.Config) error {
+	t.printf("Welcome to orc. This tutorial takes one small task from creation to a merged\n")
+	t.printf("branch. Each step explains what orc is doing and shows the command it runs.\n")
+	if t.dryRun {
+		t.printf("\nDry run: commands are shown but not run.\n")
+	}
+
+	phases, err := tutorialPhases(cfg, t.workflowID)
+	if err != nil {
+		return err
+	}
+
+	steps := []struct {
+		title string
+		fn    func() error
+	}{
+		{"Create a scratch branch", t.stepBranch},
+		{"Create the task", t.stepCreate},
+		{"How the task will run", func() error { t.explainPhases(phases); return nil }},
+		{"Run the task", t.stepRun},
+		{"Inspect the result", t.stepInspect},
+		{"Finalize into the scratch branch", t.stepFinalize},
+	}
+	for _, s := range steps {
+		t.step++
+		t.printf("\n── Step %d/%d: %s ──\n\n", t.step, len(steps), s.title)
+		if err := s.fn(); err != nil {
+			return err
+		}
+		if t.step < len(steps) && !t.pause() {
+			t.printf("\nStopped. %s\n", t.leftovers())
+			return nil
+		}
+	}
+
+	t.printf("\n── Done ──\n\n")
+	t.printf("You created, ran and finalized a task. For real work:\n")
+	t.printf("  orc new \"<title>\" -d \"<what and why>\"   # describe the change well; it feeds every phase\n")
+	t.printf("  orc run <task-id>                       # completes with your configured action (default: a PR)\n")
+	t.printf("  orc serve                               # watch runs, gates and costs in the web UI\n")
+	t.printf("\nClean up: %s\n", t.leftovers())
+	return nil
+}
+
+func (t *tutorial) stepBranch() error {
+	t.printf("orc never works on your checkout directly. Every task gets its own branch and\n")
+	t.printf("worktree, and completion decides where that branch goes: a pull request, a\n")
+	t.printf("direct merge, or just a commit. For this tutorial the work is merged into a\n")
+	t.printf("scratch branch made from your current HEAD, so main stays untouched.\n\n")
+	return t.show(nil, "git", "branch", t.branch, "HEAD")
+}
+
+func (t *tutorial) stepCreate() error {
+	t.printf("A task is a title plus a description. The description flows into every phase\n")
+	t.printf("prompt, so say what should change and what \"done\" looks like. The workflow\n")
+	t.printf("decides which phases run; the category (here: docs) shapes how Claude\n")
+	t.printf("approaches the work.\n\n")
+
+	args := []string{"new", tutorialTaskTitle, "--workflow", t.workflowID, "--category", "docs", "--description", tutorialTaskDescription}
+	t.printCommand(nil, "orc", args...)
+	if t.dryRun {
+		t.taskID = "TASK-XXX"
+		return nil
+	}
+	out, err := t.capture(t.orcBin, append(args, "--json")...)
+	if err != nil {
+		return fmt.Errorf("create sample task: %w", err)
+	}
+	var created newTaskJSON
+	if err := json.Unmarshal(out, &created); err != nil || created.TaskID == "" {
+		return fmt.Errorf("create sample task: unexpected output %q", strings.TrimSpace(string(out)))
+	}
+	t.taskID = created.TaskID
+	t.printf("Task created: %s (workflow %s)\n", t.taskID, created.WorkflowID)
+	return nil
+}
+
+// explainPhases describes each phase of the workflow and the gate that ends it.
+func (t *tutorial) explainPhases(phases []tutorialPhase) {
+	t.printf("Workflow %s runs %d phase(s) in order.\n", t.workflowID, len(phases))
+	t.printf("Each phase is a fresh Claude session with its own prompt; outputs such as\n")
+	t.printf("the spec are passed on to later phases, and orc checkpoints after each one\n")
+	t.printf("so an interrupted run resumes where it stopped.\n")
+	for i, p := range phases {
+		t.printf("\n  %d. %s (%s)\n", i+1, p.name, p.id)
+		t.printf("%s", wrapIndented(p.note, "     ", 75))
+		t.printf("%s", wrapIndented(fmt.Sprintf("Gate: %s, from %s — %s", p.gate, tutorialGateSources[p.gateSource], tutorialGateNotes[p.gate]), "     ", 75))
+	}
+	t.printf("\nA gate decides whether the run may move past a phase. Gates come from the phase\n")
+	t.printf("template and can be overridden per project (gates.phase_overrides), per task\n")
+	t.printf("(orc new --gate review:human) or for the whole run (orc run --skip-gates).\n")
+	t.printf("'orc gates list' shows what applies to your default workflow.\n")
+}
+
+func (t *tutorial) stepRun() error {
+	t.printf("orc creates the task branch and a worktree, then runs the phases above.\n")
+	t.printf("Progress is printed as it goes; this usually takes a few minutes.\n")
+	t.printf("ORC_COMPLETION_ACTION=commit overrides completion for this run only: the work\n")
+	t.printf("is committed on the task branch and nothing is pushed. Ctrl+C pauses the\n")
+	t.printf("task; 'orc resume %s' continues it.\n\n", t.taskID)
+
+	env := []string{"ORC_COMPLETION_ACTION=commit"}
+	if err := t.show(env, "orc", "run", t.taskID); err != nil {
+		return fmt.Errorf("run sample task: %w\n\nSee what happened with 'orc show %s' and 'orc log %s'", err, t.taskID, t.taskID)
+	}
+	return nil
+}
+
+func (t *tutorial) stepInspect() error {
+	t.printf("'orc show' lists each phase with its status, cost and tokens; 'orc diff'\n")
+	t.printf("shows what the task branch changed. 'orc log' and 'orc logs' hold the full\n")
+	t.printf("transcripts when you need to see why Claude did something.\n\n")
+	if err := t.show(nil, "orc", "show", t.taskID); err != nil {
+		return err
+	}
+	t.printf("\n")
+	return t.show(nil, "orc", "diff", t.taskID, "--stat")
+}
+
+func (t *tutorial) stepFinalize() error {
+	t.printf("Finalizing lands the task branch on its target. In normal runs completion does\n")
+	t.printf("this: it syncs the branch with the target, then opens a PR or merges\n")
+	t.printf("(completion.action). 'orc finalize <task>' repeats that sync for tasks\n")
+	t.printf("that ended BLOCKED, e.g. on a merge conflict. Here the task branch is merged\n")
+	t.printf("into %s in a temporary worktree, the way a 'merge' completion would.\n\n", t.branch)
+
+	taskBranch := "orc/" + t.taskID
+	if !t.dryRun {
+		backend, err := getBackend()
+		if err != nil {
+			return fmt.Errorf("get backend: %w", err)
+		}
+		tk, err := backend.LoadTask(t.taskID)
+		_ = backend.Close()
+		if err != nil {
+			return fmt.Errorf("load task: %w", err)
+		}
+		if tk.Branch == "" {
+			return fmt.Errorf("task %s has no branch to finalize", t.taskID)
+		}
+		taskBranch = tk.Branch
+	}
+
+	worktree := filepath.Join(os.TempDir(), "orc-tutorial-"+strings.ReplaceAll(t.branch, "/", "-"))
+	if err := t.show(nil, "git", "worktree", "add", worktree, t.branch); err != nil {
+		return err
+	}
+	mergeErr := t.show(nil, "git", "-C", worktree, "merge", "--no-ff", "--no-edit", taskBranch)
+	if err := t.show(nil, "git", "worktree", "remove", "--force", worktree); err != nil && mergeErr == nil {
+		return err
+	}
+	if mergeErr != nil {
+		return fmt.Errorf("merge %s into %s: %w", taskBranch, t.branch, mergeErr)
+	}
+	if !t.dryRun {
+		t.printf("\n%s now holds the sample task. Look at it with: git log --oneline %s -3\n", t.branch, t.branch)
+	}
+	return nil
+}
+
+// leftovers says how to remove what the tutorial created.
+func (t *tutorial) leftovers() string {
+	if t.taskID == "" || t.dryRun {
+		return fmt.Sprintf("git branch -D %s", t.branch)
+	}
+	return fmt.Sprintf("orc delete %s && git branch -D %s", t.taskID, t.branch)
+}
+
+// pause waits for Enter between steps and reports whether to continue.
+func (t *tutorial) pause() bool {
+	if t.yes || t.dryRun {
+		return true
+	}
+	t.printf("\nPress Enter to continue, or q to stop: ")
+	line, err := t.in.ReadString('\n')
+	if err != nil && line == "" {
+		return false
+	}
+	return !strings.EqualFold(strings.TrimSpace(line), "q")
+}
+
+// show prints a command and, unless this is a dry run, runs it with its
+// output going to the terminal. "orc" runs the current binary.
+func (t *tutorial) show(env []string, name string, args ...string) error {
+	t.printCommand(env, name, args...)
+	if t.dryRun {
+		return nil
+	}
+	if name == "orc" {
+		name = t.orcBin
+	}
+	c := tutorialCommand(name, args...)
+	c.Dir = t.projectRoot
+	c.Env = append(os.Environ(), env...)
+	c.Stdin = os.Stdin
+	c.Stdout = t.out
+	c.Stderr = t.out
+	return c.Run()
+}
+
+// capture runs a command and returns its stdout.
+func (t *tutorial) capture(name string, args ...string) ([]byte, error) {
+	c := tutorialCommand(name, args...)
+	c.Dir = t.projectRoot
+	var stderr bytes.Buffer
+	c.Stderr = &stderr
+	out, err := c.Output()
+	if err != nil && stderr.Len() > 0 {
+		return out, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, err
+}
+
+// git runs a quiet git command in the project.
+func (t *tutorial) git(args ...string) error {
+	_, err := t.capture("git", args...)
+	return err
+}
+
+func (t *tutorial) printCommand(env []string, name string, args ...string) {
+	parts := append([]string{}, env...)
+	parts = append(parts, name)
+	for _, a := range args {
+		if strings.ContainsAny(a, " \"'") {
+			a = fmt.Sprintf("%q", a)
+		}
+		parts = append(parts, a)
+	}
+	t.printf("$ %s\n", strings.Join(parts, " "))
+}
+
+func (t *tutorial) printf(format string, a ...any) {
+	_, _ = fmt.Fprintf(t.out, format, a...)
+}
+
+// tutorialPhase is one workflow phase as the tutorial explains it.
+type tutorialPhase struct {
+	id         string
+	name       string
+	note       string
+	gate       gate.GateType
+	gateSource string
+}
+
+// tutorialPhases loads the workflow's phases and resolves the gate that
+// applies to each, the same way 'orc gates list' does.
+func tutorialPhases(cfg *config.Config, workflowID string) ([]tutorialPhase, error) {
+	gdb, err := db.OpenGlobal()
+	if err != nil {
+		return nil, fmt.Errorf("open global database: %w", err)
+	}
+	defer func() { _ = gdb.Close() }()
+
+	if _, err := workflow.SeedBuiltins(gdb); err != nil {
+		return nil, fmt.Errorf("seed workflows: %w", err)
+	}
+	wf, err := gdb.GetWorkflow(workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("get workflow: %w", err)
+	}
+	if wf == nil {
+		return nil, fmt.Errorf("workflow not found: %s\n\nRun 'orc workflows' to see available workflows", workflowID)
+	}
+	if wf.CompletionAction == "pr" || wf.CompletionAction == "merge" {
+		return nil, fmt.Errorf("workflow %s always completes with %q, which would push; pick a workflow without its own completion action", workflowID, wf.CompletionAction)
+	}
+	wfPhases, err := gdb.GetWorkflowPhases(workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("get phases: %w", err)
+	}
+
+	phaseGates := make(map[string]*db.PhaseGate, len(wfPhases))
+	phases := make([]tutorialPhase, 0, len(wfPhases))
+	for _, ph := range wfPhases {
+		tmpl, err := gdb.GetPhaseTemplate(ph.PhaseTemplateID)
+		if err != nil {
+			return nil, fmt.Errorf("load phase template %s: %w", ph.PhaseTemplateID, err)
+		}
+		p := tutorialPhase{id: ph.PhaseTemplateID, name: ph.PhaseTemplateID, note: tutorialPhaseNotes[ph.PhaseTemplateID]}
+		gateType := ph.GateTypeOverride
+		if tmpl != nil {
+			p.name = tmpl.Name
+			if p.note == "" {
+				p.note = tmpl.Description
+			}
+			if gateType == "" {
+				gateType = tmpl.GateType
+			}
+		}
+		if gateType != "" {
+			phaseGates[ph.PhaseTemplateID] = &db.PhaseGate{PhaseID: ph.PhaseTemplateID, GateType: gateType, Enabled: true}
+		}
+		phases = append(phases, p)
+	}
+
+	resolver := gate.NewResolver(cfg, gate.WithPhaseGates(phaseGates))
+	for i := range phases {
+		result := resolver.Resolve(phases[i].id, "")
+		phases[i].gate, phases[i].gateSource = result.GateType, result.Source
+	}
+	return phases, nil
+}
+
+// wrapIndented word-wraps s to width columns, prefix included.
+func wrapIndented(s, prefix string, width int) string {
+	var b strings.Builder
+	line := 0
+	for _, word := range strings.Fields(s) {
+		switch {
+		case line == 0:
+			b.WriteString(prefix)
+			line = len(prefix)
+		case line+1+len(word) > width:
+			b.WriteString("\n" + prefix)
+			line = len(prefix)
+		default:
+			b.WriteString(" ")
+			line++
+		}
+		b.WriteString(word)
+		line += len(word)
+	}
+	if line > 0 {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func runTutorialCmd(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	cmd := newTutorialCmd()
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestTutorialDryRun(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	withStatusTestDir(t)
+
+	out, err := runTutorialCmd(t, "", "--dry-run")
+	if err != nil {
+		t.Fatalf("tutorial --dry-run: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"Step 1/6: Create a scratch branch",
+		"$ git branch orc-tutorial HEAD",
+		"$ orc new \"Add ORC_TUTORIAL.md describing how orc works\" --workflow implement-small --category docs",
+		"1. Lightweight Spec (tiny_spec)",
+		"Gate: auto, from the phase template",
+		"$ ORC_COMPLETION_ACTION=commit orc run TASK-XXX",
+		"$ git -C " + filepath.Join(os.TempDir(), "orc-tutorial-orc-tutorial") + " merge --no-ff --no-edit orc/TASK-XXX",
+		"Clean up: git branch -D orc-tutorial",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	if _, err := runTutorialCmd(t, "", "--dry-run", "--workflow", "no-such-workflow"); err == nil || !strings.Contains(err.Error(), "workflow not found") {
+		t.Errorf("unknown workflow: err = %v", err)
+	}
+}
+
+func TestTutorialGateOverride(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := withStatusTestDir(t)
+	cfg := "version: 1\ngates:\n  phase_overrides:\n    review: human\n"
+	if err := os.WriteFile(filepath.Join(dir, ".orc", "config.yaml"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runTutorialCmd(t, "", "--dry-run")
+	if err != nil {
+		t.Fatalf("tutorial --dry-run: %v", err)
+	}
+	if !strings.Contains(out, "Gate: human, from gates.phase_overrides — the run stops") {
+		t.Errorf("review gate override not explained:\n%s", out)
+	}
+}
+
+func TestTutorialRunsCommands(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := withStatusTestDir(t)
+	if err := os.WriteFile(filepath.Join(dir, ".orc", "config.yaml"), []byte("version: 1\nclaude_path: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	backend := createStatusTestBackend(t, dir)
+	tk := task.NewProtoTask("TASK-001", "sample")
+	tk.Branch = "orc/TASK-001"
+	if err := backend.SaveTask(tk); err != nil {
+		t.Fatal(err)
+	}
+	_ = backend.Close()
+
+	var calls []string
+	tutorialCommand = func(name string, args ...string) *exec.Cmd {
+		call := filepath.Base(name) + " " + strings.Join(args, " ")
+		calls = append(calls, call)
+		switch {
+		case strings.Contains(call, "refs/heads/orc-tutorial"):
+			return exec.Command("false")
+		case strings.Contains(call, " new "):
+			return exec.Command("printf", "%s", `{"task_id": "TASK-001", "workflow_id": "implement-small"}`)
+		}
+		return exec.Command("true")
+	}
+	t.Cleanup(func() { tutorialCommand = exec.Command })
+
+	out, err := runTutorialCmd(t, "\n\n\n\n\n")
+	if err != nil {
+		t.Fatalf("tutorial: %v\n%s", err, out)
+	}
+	joined := strings.Join(calls, "\n")
+	for _, want := range []string{
+		"git branch orc-tutorial HEAD",
+		"--json",
+		"run TASK-001",
+		"show TASK-001",
+		"merge --no-ff --no-edit orc/TASK-001",
+		"worktree remove --force",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("commands missing %q:\n%s", want, joined)
+		}
+	}
+	if !strings.Contains(out, "Clean up: orc delete TASK-001 && git branch -D orc-tutorial") {
+		t.Errorf("cleanup hint missing:\n%s", out)
+	}
+
+	calls = nil
+	out, err = runTutorialCmd(t, "\nq\n")
+	if err != nil {
+		t.Fatalf("tutorial stopped early: %v", err)
+	}
+	if strings.Contains(strings.Join(calls, "\n"), "run TASK-001") || !strings.Contains(out, "Stopped.") {
+		t.Errorf("q should stop before running the task:\ncalls: %v\n%s", calls, out)
+	}
+}
@@ -7,13 +7,28 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// version is the orc release version, overridden at build time by SetVersion.
+var version = "0.1.0-dev"
+
+// SetVersion records the version stamped into the binary by the build
+// (-X main.version). Empty values keep the development default.
+func SetVersion(v string) {
+	if v != "" {
+		version = v
+	}
+}
+
 // newVersionCmd creates the version command
 func newVersionCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
 		Short: "Show orc version",
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Println("orc version 0.1.0-dev")
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if jsonOut {
+				return outputJSON(cmd, map[string]string{"version": version})
+			}
+			_, err := fmt.Fprintln(cmd.OutOrStdout(), "orc version "+version)
+			return err
 		},
 	}
 }
@@ -17,7 +17,7 @@ func getBackend() (storage.Backend, error) {
 	// Find project root (works from worktrees too)
 	projectRoot, err := ResolveProjectPath()
 	if err != nil {
-		return nil, fmt.Errorf("not in an orc project (run 'orc init' first): %w", err)
+		return nil, withExitCode(ExitNotInitialized, fmt.Errorf("not in an orc project (run 'orc init' first): %w", err))
 	}
 
 	// Load config for storage settings
@@ -27,7 +27,7 @@ func getBackend() (storage.Backend, error) {
 		storageCfg = &cfg.Storage
 	}
 
-	return storage.NewDatabaseBackend(projectRoot, storageCfg)
+	return storage.NewBackend(projectRoot, storageCfg)
 }
 
 // Helper functions
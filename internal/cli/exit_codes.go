@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	llmkit "github.com/randalmurphal/llmkit/v2"
+	"github.com/randalmurphal/llmkit/v2/claude"
+	"github.com/randalmurphal/llmkit/v2/codex"
+
+	"github.com/randalmurphal/orc/internal/config"
+	orcerrors "github.com/randalmurphal/orc/internal/errors"
+	"github.com/randalmurphal/orc/internal/executor"
+)
+
+// Process exit codes. Wrappers and CI branch on these, so existing values
+// must never change meaning.
+const (
+	ExitOK             = 0
+	ExitError          = 1 // Anything not covered below
+	ExitPrecondition   = 2 // Task not in a state the command accepts
+	ExitTaskFailed     = 3
+	ExitInterrupted    = 4
+	ExitTaskBlocked    = 5 // Blocked at a gate or by dependencies
+	ExitRateLimited    = 6
+	ExitConfigError    = 7
+	ExitNotInitialized = 8
+)
+
+// exitError attaches an exit code to an error without changing its message.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// withExitCode tags err with an exit code. A more specific cause found by
+// ExitCode (for example a rate limit behind a task failure) still wins.
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: code, err: err}
+}
+
+// ExitCode maps an error returned by Execute to the process exit code.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	if orcErr := orcerrors.AsOrcError(err); orcErr != nil {
+		switch orcErr.Code {
+		case orcerrors.CodeNotInitialized:
+			return ExitNotInitialized
+		case orcerrors.CodeConfigInvalid, orcerrors.CodeConfigMissing:
+			return ExitConfigError
+		}
+	}
+	switch {
+	case errors.Is(err, config.ErrNotInitialized):
+		return ExitNotInitialized
+	case errors.Is(err, config.ErrInvalidConfig):
+		return ExitConfigError
+	case isRateLimited(err):
+		return ExitRateLimited
+	case errors.Is(err, executor.ErrTaskBlocked):
+		return ExitTaskBlocked
+	}
+	var blocked *executor.PhaseBlockedError
+	if errors.As(err, &blocked) {
+		return ExitTaskBlocked
+	}
+	var tagged *exitError
+	if errors.As(err, &tagged) {
+		return tagged.code
+	}
+	if errors.Is(err, context.Canceled) {
+		return ExitInterrupted
+	}
+	return ExitError
+}
+
+// isRateLimited reports whether err comes from a provider rate limit. The
+// provider CLIs often surface it only in their output, so the message is
+// checked as well, the same way llmkit classifies CLI failures.
+func isRateLimited(err error) bool {
+	if errors.Is(err, llmkit.ErrRateLimited) || errors.Is(err, claude.ErrRateLimited) || errors.Is(err, codex.ErrRateLimited) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "rate limit") || strings.Contains(msg, "rate_limit") ||
+		strings.Contains(msg, "too many requests") || strings.Contains(msg, "429")
+}
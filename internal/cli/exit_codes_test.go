@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/randalmurphal/llmkit/v2/claude"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	orcerrors "github.com/randalmurphal/orc/internal/errors"
+	"github.com/randalmurphal/orc/internal/executor"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"plain error", errors.New("boom"), ExitError},
+		{"not initialized", fmt.Errorf("load: %w", config.RequireInitAt(t.TempDir())), ExitNotInitialized},
+		{"orc error not initialized", orcerrors.ErrNotInitialized(), ExitNotInitialized},
+		{"invalid config", fmt.Errorf("load config: %w", fmt.Errorf("%w: bad", config.ErrInvalidConfig)), ExitConfigError},
+		{"task blocked", fmt.Errorf("run: %w", executor.ErrTaskBlocked), ExitTaskBlocked},
+		{"rate limited sentinel", withExitCode(ExitTaskFailed, fmt.Errorf("phase implement: %w", claude.ErrRateLimited)), ExitRateLimited},
+		{"rate limited message", withExitCode(ExitTaskFailed, errors.New("claude exited: 429 Too Many Requests")), ExitRateLimited},
+		{"tagged task failed", withExitCode(ExitTaskFailed, errors.New("phase failed")), ExitTaskFailed},
+		{"interrupted", context.Canceled, ExitInterrupted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckTaskCanRunProto_ExitCodes(t *testing.T) {
+	tests := []struct {
+		status orcv1.TaskStatus
+		want   int
+	}{
+		{orcv1.TaskStatus_TASK_STATUS_COMPLETED, ExitPrecondition},
+		{orcv1.TaskStatus_TASK_STATUS_FAILED, ExitPrecondition},
+	}
+	for _, tt := range tests {
+		tk := task.NewProtoTask("TASK-001", "Exit codes")
+		tk.Status = tt.status
+		err := checkTaskCanRunProto(tk, false)
+		if got := ExitCode(err); got != tt.want {
+			t.Errorf("status %v: ExitCode(%v) = %d, want %d", tt.status, err, got, tt.want)
+		}
+	}
+}
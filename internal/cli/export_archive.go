@@ -736,6 +736,9 @@ func buildExportDataWithBackend(backend storage.Backend, t *orcv1.Task, withStat
 	if spec, err := backend.GetSpecForTask(t.Id); err == nil {
 		export.Spec = spec
 	}
+	if plan, err := backend.LoadPlan(t.Id); err == nil {
+		export.Plan = plan
+	}
 
 	// Load gate decisions if state export is requested
 	if withState {
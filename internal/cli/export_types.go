@@ -21,52 +21,54 @@ const maxImportFileSize = 100 * 1024 * 1024
 
 // ExportManifest contains metadata about an export archive.
 type ExportManifest struct {
-	Version              int       `yaml:"version"`
-	ExportedAt           time.Time `yaml:"exported_at"`
-	SourceHostname       string    `yaml:"source_hostname"`
-	SourceProject        string    `yaml:"source_project,omitempty"`
-	OrcVersion           string    `yaml:"orc_version,omitempty"`
-	TaskCount            int       `yaml:"task_count"`
-	InitiativeCount      int       `yaml:"initiative_count"`
-	WorkflowCount        int       `yaml:"workflow_count,omitempty"`
-	PhaseTemplateCount   int       `yaml:"phase_template_count,omitempty"`
-	WorkflowRunCount     int       `yaml:"workflow_run_count,omitempty"`
-	ProjectCommandCount  int       `yaml:"project_command_count,omitempty"`
-	IncludesState        bool      `yaml:"includes_state"`
-	IncludesTranscripts  bool      `yaml:"includes_transcripts"`
-	IncludesWorkflows    bool      `yaml:"includes_workflows,omitempty"`
-	IncludesRuns         bool      `yaml:"includes_runs,omitempty"`
+	Version             int       `yaml:"version" json:"version"`
+	ExportedAt          time.Time `yaml:"exported_at" json:"exported_at"`
+	SourceHostname      string    `yaml:"source_hostname" json:"source_hostname"`
+	SourceProject       string    `yaml:"source_project,omitempty" json:"source_project,omitempty"`
+	OrcVersion          string    `yaml:"orc_version,omitempty" json:"orc_version,omitempty"`
+	TaskID              string    `yaml:"task_id,omitempty" json:"task_id,omitempty"` // set for single-task bundles
+	TaskCount           int       `yaml:"task_count" json:"task_count"`
+	InitiativeCount     int       `yaml:"initiative_count" json:"initiative_count"`
+	WorkflowCount       int       `yaml:"workflow_count,omitempty" json:"workflow_count,omitempty"`
+	PhaseTemplateCount  int       `yaml:"phase_template_count,omitempty" json:"phase_template_count,omitempty"`
+	WorkflowRunCount    int       `yaml:"workflow_run_count,omitempty" json:"workflow_run_count,omitempty"`
+	ProjectCommandCount int       `yaml:"project_command_count,omitempty" json:"project_command_count,omitempty"`
+	IncludesState       bool      `yaml:"includes_state" json:"includes_state"`
+	IncludesTranscripts bool      `yaml:"includes_transcripts" json:"includes_transcripts"`
+	IncludesWorkflows   bool      `yaml:"includes_workflows,omitempty" json:"includes_workflows,omitempty"`
+	IncludesRuns        bool      `yaml:"includes_runs,omitempty" json:"includes_runs,omitempty"`
 }
 
 // ExportData contains all data for a task export.
 type ExportData struct {
 	// Metadata for format versioning
-	Version    int       `yaml:"version"`
-	ExportedAt time.Time `yaml:"exported_at"`
+	Version    int       `yaml:"version" json:"version"`
+	ExportedAt time.Time `yaml:"exported_at" json:"exported_at"`
 
 	// Core task data (includes execution state in Task.Execution)
-	Task *orcv1.Task `yaml:"task"`
-	Spec string      `yaml:"spec,omitempty"`
+	Task *orcv1.Task `yaml:"task" json:"task"`
+	Spec string      `yaml:"spec,omitempty" json:"spec,omitempty"`
+	Plan *db.Plan    `yaml:"plan,omitempty" json:"plan,omitempty"`
 
 	// Execution history
-	Transcripts   []storage.Transcript `yaml:"transcripts,omitempty"`
-	GateDecisions []db.GateDecision    `yaml:"gate_decisions,omitempty"`
+	Transcripts   []storage.Transcript `yaml:"transcripts,omitempty" json:"transcripts,omitempty"`
+	GateDecisions []db.GateDecision    `yaml:"gate_decisions,omitempty" json:"gate_decisions,omitempty"`
 
 	// Collaboration data
-	TaskComments   []storage.TaskComment   `yaml:"task_comments,omitempty"`
-	ReviewComments []storage.ReviewComment `yaml:"review_comments,omitempty"`
+	TaskComments   []storage.TaskComment   `yaml:"task_comments,omitempty" json:"task_comments,omitempty"`
+	ReviewComments []storage.ReviewComment `yaml:"review_comments,omitempty" json:"review_comments,omitempty"`
 
 	// Attachments (binary data base64 encoded in YAML)
-	Attachments []AttachmentExport `yaml:"attachments,omitempty"`
+	Attachments []AttachmentExport `yaml:"attachments,omitempty" json:"attachments,omitempty"`
 }
 
 // AttachmentExport represents an attachment for export.
 type AttachmentExport struct {
-	Filename    string `yaml:"filename"`
-	ContentType string `yaml:"content_type"`
-	SizeBytes   int64  `yaml:"size_bytes"`
-	IsImage     bool   `yaml:"is_image"`
-	Data        []byte `yaml:"data"` // base64 encoded in YAML
+	Filename    string `yaml:"filename" json:"filename"`
+	ContentType string `yaml:"content_type" json:"content_type"`
+	SizeBytes   int64  `yaml:"size_bytes" json:"size_bytes"`
+	IsImage     bool   `yaml:"is_image" json:"is_image"`
+	Data        []byte `yaml:"data" json:"data"` // base64 encoded in YAML
 }
 
 // WorkflowExportData contains all data for a workflow export.
@@ -75,8 +77,8 @@ type WorkflowExportData struct {
 	ExportedAt time.Time `yaml:"exported_at"`
 	Type       string    `yaml:"type"` // "workflow"
 
-	Workflow  *db.Workflow         `yaml:"workflow"`
-	Phases    []*db.WorkflowPhase  `yaml:"phases,omitempty"`
+	Workflow  *db.Workflow           `yaml:"workflow"`
+	Phases    []*db.WorkflowPhase    `yaml:"phases,omitempty"`
 	Variables []*db.WorkflowVariable `yaml:"variables,omitempty"`
 }
 
@@ -91,12 +93,15 @@ type PhaseTemplateExportData struct {
 
 // WorkflowRunExportData contains all data for a workflow run export.
 type WorkflowRunExportData struct {
-	Version    int       `yaml:"version"`
-	ExportedAt time.Time `yaml:"exported_at"`
-	Type       string    `yaml:"type"` // "workflow_run"
+	Version    int       `yaml:"version" json:"version"`
+	ExportedAt time.Time `yaml:"exported_at" json:"exported_at"`
+	Type       string    `yaml:"type" json:"type"` // "workflow_run"
+
+	WorkflowRun *db.WorkflowRun        `yaml:"workflow_run" json:"workflow_run"`
+	Phases      []*db.WorkflowRunPhase `yaml:"phases,omitempty" json:"phases,omitempty"`
 
-	WorkflowRun *db.WorkflowRun        `yaml:"workflow_run"`
-	Phases      []*db.WorkflowRunPhase `yaml:"phases,omitempty"`
+	// Outputs are the phase artifacts (spec, review findings, ...) of the run.
+	Outputs []*storage.PhaseOutputInfo `yaml:"outputs,omitempty" json:"outputs,omitempty"`
 }
 
 // ProjectCommandsExportData contains project commands for export.
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/executor"
 	"github.com/randalmurphal/orc/internal/git"
 )
 
@@ -17,10 +18,31 @@ func NewGitOpsFromConfig(projectRoot string, cfg *config.Config) (*git.Git, erro
 		cfg = config.Default()
 	}
 	gitCfg := git.Config{
-		BranchPrefix:   cfg.BranchPrefix,
-		CommitPrefix:   cfg.CommitPrefix,
-		WorktreeDir:    config.ResolveWorktreeDir(cfg.Worktree.Dir, projectRoot),
-		ExecutorPrefix: cfg.ExecutorPrefix(),
+		BranchPrefix:      cfg.BranchPrefix,
+		CommitPrefix:      cfg.CommitPrefix,
+		WorktreeDir:       config.ResolveWorktreeDir(cfg.Worktree.Dir, projectRoot),
+		ExecutorPrefix:    cfg.ExecutorPrefix(),
+		ProtectedBranches: cfg.ProtectedBranchList(),
+		IdentityName:      cfg.GitIdentity.Name,
+		IdentityEmail:     cfg.GitIdentity.Email,
+		BypassedHooks:     cfg.GitHooks.BypassedHooks(),
 	}
 	return git.New(projectRoot, gitCfg)
 }
+
+// validateTaskTargetBranch checks a --target-branch value against the
+// project's branches and protected-branch policy. When the project is not a
+// git repository only the name and policy checks run.
+func validateTaskTargetBranch(workflowID, branch string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.Default()
+	}
+	var gitOps *git.Git
+	if projectRoot, err := ResolveProjectPath(); err == nil {
+		if g, err := NewGitOpsFromConfig(projectRoot, cfg); err == nil {
+			gitOps = g
+		}
+	}
+	return executor.ValidateTaskTargetBranch(gitOps, cfg, workflowID, branch)
+}
@@ -17,13 +17,14 @@ import (
 
 // errorJSON represents an error in JSON output
 type errorJSON struct {
-	Error string `json:"error"`
+	Error    string `json:"error"`
+	ExitCode int    `json:"exit_code"`
 }
 
 // outputJSONError outputs an error as JSON to the command's output stream.
 // This should be called when jsonOut is true and an error occurs.
 func outputJSONError(cmd *cobra.Command, err error) {
-	output := errorJSON{Error: err.Error()}
+	output := errorJSON{Error: err.Error(), ExitCode: ExitCode(err)}
 	encoder := json.NewEncoder(cmd.OutOrStdout())
 	encoder.SetIndent("", "  ")
 	_ = encoder.Encode(output)
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
 	"github.com/randalmurphal/orc/internal/initiative"
@@ -491,3 +492,113 @@ func newWorkflowsListCmd() *cobra.Command {
 // Note: orc show --json already works (cmd_show.go:119). Tests exist in
 // cmd_show_test.go. We don't need additional tests for show --json.
 // =============================================================================
+
+// =============================================================================
+// --json support registry and API-shaped output
+// =============================================================================
+
+func TestJSONCommands_AllResolve(t *testing.T) {
+	for _, path := range jsonCommands {
+		cmd := findSubcommand(rootCmd, path)
+		if cmd == nil {
+			t.Errorf("jsonCommands entry %q is not a command", path)
+			continue
+		}
+		if !supportsJSON(cmd) {
+			t.Errorf("%q is not annotated for --json", path)
+		}
+	}
+}
+
+func TestCheckOutputFlags_RejectsUnsupportedCommand(t *testing.T) {
+	oldJsonOut, oldQuiet := jsonOut, quiet
+	jsonOut = true
+	defer func() { jsonOut, quiet = oldJsonOut, oldQuiet }()
+
+	root := &cobra.Command{Use: "orc"}
+	textOnly := &cobra.Command{Use: "serve"}
+	withJSON := &cobra.Command{Use: "list", Annotations: map[string]string{jsonAnnotation: "true"}}
+	root.AddCommand(textOnly, withJSON)
+
+	var out bytes.Buffer
+	textOnly.SetOut(&out)
+	err := checkOutputFlags(textOnly, nil)
+	if err == nil || !strings.Contains(err.Error(), "orc serve does not support --json") {
+		t.Fatalf("err = %v, want unsupported error", err)
+	}
+	var result map[string]any
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil || result["error"] == "" {
+		t.Errorf("error output should be a JSON error object, got %q", out.String())
+	}
+
+	if err := checkOutputFlags(withJSON, nil); err != nil {
+		t.Errorf("annotated command rejected: %v", err)
+	}
+	if !quiet {
+		t.Error("--json should imply --quiet")
+	}
+}
+
+func TestListCommand_JSONOutput_MatchesAPI(t *testing.T) {
+	tmpDir := withStatusTestDir(t)
+	backend := createStatusTestBackend(t, tmpDir)
+	t1 := task.NewProtoTask("TASK-001", "Listed task")
+	t1.Status = orcv1.TaskStatus_TASK_STATUS_RUNNING
+	if err := backend.SaveTask(t1); err != nil {
+		t.Fatalf("save task: %v", err)
+	}
+	_ = backend.Close()
+
+	oldJsonOut := jsonOut
+	jsonOut = true
+	defer func() { jsonOut = oldJsonOut }()
+
+	cmd := newListCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+
+	var resp orcv1.ListTasksResponse
+	if err := protojson.Unmarshal(out.Bytes(), &resp); err != nil {
+		t.Fatalf("output is not a ListTasksResponse: %v\nOutput: %s", err, out.String())
+	}
+	if len(resp.Tasks) != 1 || resp.Tasks[0].Id != "TASK-001" || resp.Tasks[0].Status != orcv1.TaskStatus_TASK_STATUS_RUNNING {
+		t.Errorf("tasks = %v", resp.Tasks)
+	}
+	// Field names and enums follow the API's JSON mapping
+	if !strings.Contains(out.String(), `"status": "TASK_STATUS_RUNNING"`) {
+		t.Errorf("output should use API enum names:\n%s", out.String())
+	}
+
+	// No matches is an empty response, not a text message
+	out.Reset()
+	cmd = newListCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--status", "completed"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+	if err := protojson.Unmarshal(out.Bytes(), &resp); err != nil || len(resp.Tasks) != 0 {
+		t.Errorf("filtered output = %q, want an empty ListTasksResponse", out.String())
+	}
+}
+
+func TestVersionCommand_JSONOutput(t *testing.T) {
+	oldJsonOut := jsonOut
+	jsonOut = true
+	defer func() { jsonOut = oldJsonOut }()
+
+	cmd := newVersionCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+	var result map[string]string
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil || result["version"] != version {
+		t.Errorf("output = %q", out.String())
+	}
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// jsonAnnotation marks commands that honor --json.
+const jsonAnnotation = "orc.json"
+
+// jsonCommands are the commands (by path below the root) with --json output.
+// Commands not listed reject --json instead of printing text a script would
+// have to scrape.
+var jsonCommands = []string{
+	"status", "list", "show", "new", "deps", "delete",
+	"approve", "reject", "costs", "brief", "version", "self-update", "projects", "sync",
+	"workflows", "gates list", "gates show",
+	"initiative list", "initiative show", "initiative notes",
+	"recommendation list", "comment list", "prompts list", "prompts install",
+	"skills list", "skills available", "skills install", "skills update",
+	"template list", "template show", "template save",
+	"docs drift", "db maintain", "storage migrate", "metrics velocity",
+	"tenant", "tenant list", "tenant token create",
+	"bench show", "bench report", "bench curate list", "bench server",
+	"telemetry", "telemetry status", "replay", "debug state-diff",
+	"service", "service status", "task create", "search", "pool status",
+	"config profiles",
+}
+
+// markJSONCommands annotates the commands in jsonCommands under root.
+func markJSONCommands(root *cobra.Command) {
+	for _, path := range jsonCommands {
+		if cmd := findSubcommand(root, path); cmd != nil {
+			if cmd.Annotations == nil {
+				cmd.Annotations = map[string]string{}
+			}
+			cmd.Annotations[jsonAnnotation] = "true"
+		}
+	}
+}
+
+// findSubcommand resolves a space-separated command path below root.
+func findSubcommand(root *cobra.Command, path string) *cobra.Command {
+	cmd, rest, err := root.Find(strings.Fields(path))
+	if err != nil || len(rest) > 0 || cmd == root {
+		return nil
+	}
+	return cmd
+}
+
+// supportsJSON reports whether cmd honors --json. Help and completion are
+// always allowed.
+func supportsJSON(cmd *cobra.Command) bool {
+	if cmd.Annotations[jsonAnnotation] == "true" {
+		return true
+	}
+	switch cmd.Name() {
+	case "help", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return true
+	}
+	return cmd.Parent() != nil && cmd.Parent().Name() == "completion"
+}
+
+// checkOutputFlags runs before every command. --json implies --quiet so
+// progress text never mixes into the JSON document on stdout.
+func checkOutputFlags(cmd *cobra.Command, _ []string) error {
+	if !jsonOut {
+		return nil
+	}
+	quiet = true
+	if !supportsJSON(cmd) {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		err := fmt.Errorf("%s does not support --json", cmd.CommandPath())
+		outputJSONError(cmd, err)
+		return err
+	}
+	return nil
+}
+
+// outputJSON encodes v as indented JSON to the command's output. Protobuf
+// messages (typically the matching API response message) use the API's
+// JSON mapping, so --json output and API responses share one schema.
+func outputJSON(cmd *cobra.Command, v any) error {
+	if m, ok := v.(proto.Message); ok {
+		data, err := protojson.Marshal(m)
+		if err != nil {
+			return fmt.Errorf("marshal %T: %w", m, err)
+		}
+		// protojson varies its whitespace between runs; re-indent so the
+		// output is byte-stable
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, "", "  "); err != nil {
+			return fmt.Errorf("indent %T: %w", m, err)
+		}
+		buf.WriteByte('\n')
+		_, err = buf.WriteTo(cmd.OutOrStdout())
+		return err
+	}
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -56,7 +56,7 @@ func ResolveProjectID() (string, error) {
 	}
 
 	// 4. Not in a project directory
-	return "", fmt.Errorf("not in an orc project; use --project or cd to a project directory")
+	return "", withExitCode(ExitNotInitialized, fmt.Errorf("not in an orc project; use --project or cd to a project directory"))
 }
 
 // ResolveProjectPath returns the project path for the resolved project.
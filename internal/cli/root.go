@@ -7,6 +7,9 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/i18n"
 )
 
 var (
@@ -45,15 +48,27 @@ Features:
 
 Quick start:
   orc init                    Initialize orc in current project
+  orc tutorial                Guided walkthrough of a first task
   orc new "Fix login bug"     Create a new task
   orc run TASK-001            Execute the task
   orc status                  Show current state`,
 	SilenceUsage: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkOutputFlags(cmd, args); err != nil {
+			return err
+		}
+		startCLIAudit(cmd, args)
+		return nil
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() error {
-	return rootCmd.Execute()
+	notifyUpdate := startUpdateCheck()
+	err := rootCmd.Execute()
+	finishCLIAudit(err)
+	notifyUpdate()
+	return err
 }
 
 func init() {
@@ -63,7 +78,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is .orc/config.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "suppress non-essential output")
-	rootCmd.PersistentFlags().BoolVar(&jsonOut, "json", false, "output as JSON")
+	rootCmd.PersistentFlags().BoolVar(&jsonOut, "json", false, "output as JSON in the API's schema (implies --quiet; commands without JSON output reject it)")
 	rootCmd.PersistentFlags().BoolVar(&plain, "plain", false, "plain output without emoji (for terminal compatibility)")
 	rootCmd.PersistentFlags().StringVarP(&projectFlag, "project", "P", "", "project ID, name, or path (default: current directory)")
 
@@ -82,6 +97,7 @@ func init() {
 
 	// Core Commands
 	addCmd(newInitCmd(), groupCore)
+	addCmd(newTutorialCmd(), groupCore)
 	addCmd(newNewCmd(), groupCore)
 	addCmd(newRunCmd(), groupCore)
 	addCmd(newStatusCmd(), groupCore)
@@ -98,6 +114,7 @@ func init() {
 
 	// Inspection
 	addCmd(newLogCmd(), groupInspection)
+	addCmd(newLogsCmd(), groupInspection)
 	addCmd(newDiffCmd(), groupInspection)
 	addCmd(newDepsCmd(), groupInspection)
 	addCmd(newSearchCmd(), groupInspection)
@@ -105,7 +122,9 @@ func init() {
 	addCmd(newRecommendationCmd(), groupInspection)
 	addCmd(newBriefCmd(), groupInspection)
 	addCmd(newCostsCmd(), groupInspection)
+	addCmd(newReplayCmd(), groupInspection)
 	addCmd(newScratchpadCmd(), groupInspection)
+	addCmd(newDebugCmd(), groupInspection)
 
 	// Phase Control
 	addCmd(newRewindCmd(), groupPhaseControl)
@@ -116,6 +135,7 @@ func init() {
 	addCmd(newApproveCmd(), groupPhaseControl)
 	addCmd(newRejectCmd(), groupPhaseControl)
 	addCmd(newGatesCmd(), groupPhaseControl)
+	addCmd(newGateCmd(), groupPhaseControl)
 	addCmd(newReleaseCmd(), groupPhaseControl)
 
 	// Planning & Orchestration
@@ -129,7 +149,12 @@ func init() {
 	addCmd(newConstitutionCmd(), groupConfig)
 	addCmd(newDocsCmd(), groupConfig)
 	addCmd(newTemplateCmd(), groupConfig)
+	addCmd(newPromptsCmd(), groupConfig)
+	addCmd(newSyncCmd(), groupConfig)
+	addCmd(newSkillsCmd(), groupConfig)
 	addCmd(newServeCmd(), groupConfig)
+	addCmd(newServiceCmd(), groupConfig)
+	addCmd(newDeployCmd(), groupConfig)
 
 	// Git & Branches
 	addCmd(newStagingCmd(), groupGit)
@@ -138,17 +163,27 @@ func init() {
 	// Import/Export
 	addCmd(newExportCmd(), groupImportExport)
 	addCmd(newImportCmd(), groupImportExport)
+	addCmd(newTaskCmd(), groupImportExport)
 
 	// Team & Advanced
 	addCmd(newKnowledgeCmd(), groupAdvanced)
 	addCmd(newTeamCmd(), groupAdvanced)
+	addCmd(newAuditCmd(), groupAdvanced)
+	addCmd(newTenantCmd(), groupAdvanced)
+	addCmd(newServerCmd(), groupAdvanced)
+	addCmd(newDBCmd(), groupAdvanced)
+	addCmd(newStorageCmd(), groupAdvanced)
 	addCmd(newPoolCmd(), groupAdvanced)
 	addCmd(newAutomationCmd(), groupAdvanced)
 	addCmd(newCommentCmd(), groupAdvanced)
 	addCmd(newBenchCmd(), groupAdvanced)
 	addCmd(newProjectsCmd(), groupAdvanced)
 	addCmd(newVersionCmd(), groupAdvanced)
+	addCmd(newSelfUpdateCmd(), groupAdvanced)
+	addCmd(newTelemetryCmd(), groupAdvanced)
 	addCmd(newGoodbyeCmd(), groupAdvanced)
+
+	markJSONCommands(rootCmd)
 }
 
 // addCmd adds a command to root with the specified group
@@ -177,4 +212,8 @@ func initConfig() {
 			fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 		}
 	}
+	// Errors, prompts and notifications render in the configured locale
+	if cfg, err := config.Load(); err == nil {
+		i18n.SetLocale(cfg.Locale)
+	}
 }
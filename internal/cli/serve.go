@@ -3,14 +3,18 @@ package cli
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 
 	"github.com/spf13/cobra"
 
 	"github.com/randalmurphal/orc/internal/api"
 	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/demo"
+	"github.com/randalmurphal/orc/internal/logs"
 )
 
 // newServeCmd creates the serve command for the API server
@@ -29,16 +33,56 @@ If the requested port is in use, the server will try subsequent ports
 up to max-port-attempts times (default: 10). For example, if port 8080
 is busy, it will try 8081, 8082, etc.
 
+With --read-only (or server.read_only), every request that could change
+state is rejected with 403 while reads and event streams keep working, and
+no background pollers run. Use it to expose a dashboard-only instance.
+
+With --demo, the server runs against a throwaway sandbox instead of the
+current project: a generated git repo with synthetic tasks in every state
+(running, paused, blocked, failed, completed, ...), an initiative, costs and
+transcripts. Running a task replays canned execution events instead of
+calling a model, and one task replays continuously so the dashboard stays
+live. Nothing touches your repos, registry or tokens, and the sandbox is
+deleted on exit.
+
+Server logs, and each task run's execution log, are also written under
+~/.orc/projects/<id>/logs/; view them with 'orc logs'.
+
 Example:
   orc serve              # Start on default port 8080
-  orc serve --port 3000  # Start on custom port`,
+  orc serve --port 3000  # Start on custom port
+  orc serve --read-only  # Dashboard-only instance
+  orc serve --demo       # Explore the UI with synthetic tasks`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			port, _ := cmd.Flags().GetInt("port")
 			maxPortAttempts, _ := cmd.Flags().GetInt("max-port-attempts")
 			addr := fmt.Sprintf(":%d", port)
 
+			projectRoot, rootErr := config.FindProjectRoot()
+			demoMode, _ := cmd.Flags().GetBool("demo")
+			var demoProject *demo.Project
+			if demoMode {
+				root, err := os.MkdirTemp("", "orc-demo-")
+				if err != nil {
+					return fmt.Errorf("create demo sandbox: %w", err)
+				}
+				defer func() { _ = os.RemoveAll(root) }()
+				// Keep the registry, global database and telemetry in the sandbox too
+				if err := os.Setenv("HOME", filepath.Join(root, "home")); err != nil {
+					return err
+				}
+				demoProject, err = demo.Setup(root)
+				if err != nil {
+					return err
+				}
+				projectRoot, rootErr = demoProject.Dir, nil
+			}
+
 			// Load orc config for defaults
 			orcCfg, err := config.Load()
+			if demoProject != nil {
+				orcCfg, err = config.LoadFrom(demoProject.Dir)
+			}
 			if err != nil {
 				// Use defaults if config not available
 				orcCfg = config.Default()
@@ -52,14 +96,49 @@ Example:
 				}
 			}
 
+			readOnly, _ := cmd.Flags().GetBool("read-only")
 			cfg := &api.Config{
 				Addr:            addr,
 				MaxPortAttempts: maxPortAttempts,
+				ReadOnly:        readOnly,
+				Version:         version,
+			}
+			if demoProject != nil {
+				cfg.WorkDir = demoProject.Dir
+			}
+
+			// Persist server logs for `orc logs`. The console handler can't be
+			// slog's default one: once this becomes the default, that one
+			// would log back into itself.
+			if rootErr == nil {
+				console := slog.NewTextHandler(os.Stderr, nil)
+				serverLog, err := logs.NewHandler(console, logs.Options{
+					Path:      logs.ServerPath(projectRoot),
+					Component: "server",
+				})
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: server log disabled: %v\n", err)
+				} else {
+					defer func() { _ = serverLog.Close() }()
+					cfg.Logger = slog.New(serverLog)
+					slog.SetDefault(cfg.Logger)
+				}
 			}
 
 			server := api.New(cfg)
+			var player *demo.Player
+			if demoProject != nil {
+				player = demo.NewPlayer(server.Backend(), server.Publisher(), cfg.Logger)
+				server.SetTaskExecutor(player.Run)
+			}
 
 			fmt.Printf("Starting API server (port %d, will try up to %d ports if busy)...\n", port, maxPortAttempts)
+			if readOnly || orcCfg.Server.ReadOnly {
+				fmt.Println("Read-only mode: mutating requests are rejected")
+			}
+			if demoProject != nil {
+				fmt.Printf("Demo mode: sandbox project at %s (deleted on exit); task runs are replayed, no model is called\n", demoProject.Dir)
+			}
 			fmt.Println("Press Ctrl+C to stop")
 
 			// Handle graceful shutdown
@@ -75,12 +154,17 @@ Example:
 				cancel()
 			}()
 
+			if player != nil {
+				go player.Loop(ctx, demoProject.LiveTaskID, demoProject.ID)
+			}
 			return server.StartContext(ctx)
 		},
 	}
 
 	cmd.Flags().IntP("port", "p", 8080, "port to listen on")
 	cmd.Flags().Int("max-port-attempts", 10, "max ports to try if initial port is busy")
+	cmd.Flags().Bool("read-only", false, "reject mutating requests (dashboard-only instance)")
+	cmd.Flags().Bool("demo", false, "serve a throwaway sandbox project with synthetic tasks and replayed execution")
 
 	return cmd
 }
@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/gen/proto/orc/v1/orcv1connect"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/orchestrator"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// statusServerTimeout bounds each request to a running server, so status
+// stays fast when nothing is listening.
+const statusServerTimeout = 500 * time.Millisecond
+
+// statusOverview is the project-wide part of `orc status`. Pending gate
+// decisions and queue depth live in the server's memory, so they are only
+// known when a server is running.
+type statusOverview struct {
+	CostTodayUSD float64 `json:"cost_today_usd"`
+	PendingGates int     `json:"pending_gates"`
+	Failed24h    int     `json:"failed_24h"`
+	Server       bool    `json:"server"`
+	QueueDepth   *int    `json:"queue_depth,omitempty"`
+}
+
+// statusServerInfo is what a running server adds to the overview.
+type statusServerInfo struct {
+	QueueDepth      int
+	PendingGateTask []string // Task IDs with a pending gate decision
+}
+
+// buildStatusOverview gathers the overview for the given (filtered) tasks.
+// blocked are the tasks already waiting on human input.
+func buildStatusOverview(projectRoot string, tasks, blocked []*orcv1.Task, server *statusServerInfo) statusOverview {
+	ov := statusOverview{CostTodayUSD: costToday(projectRoot)}
+
+	dayAgo := time.Now().Add(-24 * time.Hour)
+	for _, t := range tasks {
+		if t.Status == orcv1.TaskStatus_TASK_STATUS_FAILED && t.UpdatedAt != nil && t.UpdatedAt.AsTime().After(dayAgo) {
+			ov.Failed24h++
+		}
+	}
+
+	gated := make(map[string]bool, len(blocked))
+	for _, t := range blocked {
+		gated[t.Id] = true
+	}
+	if server != nil {
+		ov.Server = true
+		depth := server.QueueDepth
+		ov.QueueDepth = &depth
+		shown := make(map[string]bool, len(tasks))
+		for _, t := range tasks {
+			shown[t.Id] = true
+		}
+		for _, id := range server.PendingGateTask {
+			if shown[id] {
+				gated[id] = true
+			}
+		}
+	}
+	ov.PendingGates = len(gated)
+	return ov
+}
+
+// costToday returns today's (UTC) spend for the project from the global
+// cost log, or 0 when there is no global database yet.
+func costToday(projectRoot string) float64 {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return 0
+	}
+	if _, err := os.Stat(filepath.Join(home, ".orc", "orc.db")); err != nil {
+		return 0 // Don't create the global DB just to report $0
+	}
+	gdb, err := db.OpenGlobal()
+	if err != nil {
+		return 0
+	}
+	defer func() { _ = gdb.Close() }()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	summary, err := gdb.GetCostSummary(projectRoot, today)
+	if err != nil || summary == nil {
+		return 0
+	}
+	return summary.TotalCostUSD
+}
+
+// statusServerURL returns the base URL of the project's configured server.
+func statusServerURL(cfg *config.Config) string {
+	host, port := "127.0.0.1", 8080
+	if cfg != nil {
+		if cfg.Server.Host != "" {
+			host = cfg.Server.Host
+		}
+		if cfg.Server.Port != 0 {
+			port = cfg.Server.Port
+		}
+	}
+	return fmt.Sprintf("http://%s:%d", host, port)
+}
+
+// fetchStatusServerInfo asks a running server for its run queue and pending
+// gate decisions. It returns nil when no server answers.
+func fetchStatusServerInfo(baseURL, projectID string) *statusServerInfo {
+	client := &http.Client{Timeout: statusServerTimeout}
+	resp, err := client.Get(strings.TrimSuffix(baseURL, "/") + "/api/scheduler/queue")
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	var metrics orchestrator.FairQueueMetrics
+	if err := json.NewDecoder(resp.Body).Decode(&metrics); err != nil {
+		return nil
+	}
+	info := &statusServerInfo{QueueDepth: metrics.Queued}
+
+	ctx, cancel := context.WithTimeout(context.Background(), statusServerTimeout)
+	defer cancel()
+	decisions := orcv1connect.NewDecisionServiceClient(client, baseURL, serverClientOptions()...)
+	list, err := decisions.ListPendingDecisions(ctx, connect.NewRequest(&orcv1.ListPendingDecisionsRequest{ProjectId: projectID}))
+	if err == nil {
+		for _, d := range list.Msg.Decisions {
+			info.PendingGateTask = append(info.PendingGateTask, d.TaskId)
+		}
+	}
+	return info
+}
+
+// currentIterations maps running task IDs to the iteration of their current
+// phase, from the task's running workflow run.
+func currentIterations(backend storage.Backend, running []*orcv1.Task) map[string]int {
+	iterations := make(map[string]int)
+	if len(running) == 0 {
+		return iterations
+	}
+	runs, err := backend.GetRunningWorkflowsByTask()
+	if err != nil {
+		return iterations
+	}
+	for _, t := range running {
+		run, ok := runs[t.Id]
+		phase := task.GetCurrentPhaseProto(t)
+		if !ok || phase == "" {
+			continue
+		}
+		phases, err := backend.GetWorkflowRunPhases(run.ID)
+		if err != nil {
+			continue
+		}
+		for _, p := range phases {
+			if p.PhaseTemplateID == phase && p.Iterations > 0 {
+				iterations[t.Id] = p.Iterations
+			}
+		}
+	}
+	return iterations
+}
+
+// formatStatusOverview renders the overview as one compact line.
+func formatStatusOverview(ov statusOverview) string {
+	parts := []string{
+		"today " + formatCost(ov.CostTodayUSD),
+		fmt.Sprintf("%d gates pending", ov.PendingGates),
+		fmt.Sprintf("%d failed (24h)", ov.Failed24h),
+	}
+	if ov.QueueDepth != nil {
+		parts = append(parts, fmt.Sprintf("%d queued", *ov.QueueDepth))
+	} else {
+		parts = append(parts, "server not running")
+	}
+	if plain {
+		return strings.Join(parts, ", ")
+	}
+	return strings.Join(parts, " · ")
+}
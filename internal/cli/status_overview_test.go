@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestBuildStatusOverview(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	failed := task.NewProtoTask("TASK-001", "Failed today")
+	failed.Status = orcv1.TaskStatus_TASK_STATUS_FAILED
+	failed.UpdatedAt = timestamppb.New(time.Now().Add(-time.Hour))
+	oldFailure := task.NewProtoTask("TASK-002", "Failed last week")
+	oldFailure.Status = orcv1.TaskStatus_TASK_STATUS_FAILED
+	oldFailure.UpdatedAt = timestamppb.New(time.Now().Add(-7 * 24 * time.Hour))
+	blocked := task.NewProtoTask("TASK-003", "Blocked at gate")
+	blocked.Status = orcv1.TaskStatus_TASK_STATUS_BLOCKED
+	gated := task.NewProtoTask("TASK-004", "Waiting on a decision")
+	gated.Status = orcv1.TaskStatus_TASK_STATUS_RUNNING
+	tasks := []*orcv1.Task{failed, oldFailure, blocked, gated}
+
+	ov := buildStatusOverview(t.TempDir(), tasks, []*orcv1.Task{blocked}, nil)
+	if ov.Failed24h != 1 || ov.PendingGates != 1 || ov.Server || ov.QueueDepth != nil {
+		t.Errorf("overview without server = %+v", ov)
+	}
+	if got := formatStatusOverview(ov); !strings.Contains(got, "server not running") {
+		t.Errorf("formatted = %q", got)
+	}
+
+	// Decisions for tasks outside the shown set are not counted
+	server := &statusServerInfo{QueueDepth: 3, PendingGateTask: []string{"TASK-003", "TASK-004", "TASK-999"}}
+	ov = buildStatusOverview(t.TempDir(), tasks, []*orcv1.Task{blocked}, server)
+	if ov.PendingGates != 2 || !ov.Server || ov.QueueDepth == nil || *ov.QueueDepth != 3 {
+		t.Errorf("overview with server = %+v", ov)
+	}
+	if got := formatStatusOverview(ov); !strings.Contains(got, "2 gates pending") || !strings.Contains(got, "3 queued") {
+		t.Errorf("formatted = %q", got)
+	}
+}
+
+func TestFetchStatusServerInfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/scheduler/queue" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"capacity": 2, "running": 2, "queued": 4, "projects": []}`))
+	}))
+	defer srv.Close()
+
+	info := fetchStatusServerInfo(srv.URL, "proj-001")
+	if info == nil || info.QueueDepth != 4 || len(info.PendingGateTask) != 0 {
+		t.Errorf("info = %+v, want queue depth 4 and no decisions", info)
+	}
+
+	srv.Close()
+	if info := fetchStatusServerInfo(srv.URL, "proj-001"); info != nil {
+		t.Errorf("info with no server = %+v, want nil", info)
+	}
+}
@@ -0,0 +1,272 @@
+package cli
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/taskbundle"
+)
+
+// taskBundleExt is the conventional extension of a single-task bundle.
+// Bundles are tar.gz archives in the export archive layout.
+const taskBundleExt = ".orc"
+
+// Task bundle formats for `orc task export --format`.
+const (
+	taskBundleFormatTar  = "tar"
+	taskBundleFormatJSON = "json"
+)
+
+// taskBundle is the parsed content of a single-task bundle. Its JSON form
+// is the whole bundle in one document, for attaching to bug reports and
+// for POST /api/tasks/import.
+type taskBundle = taskbundle.Bundle[ExportManifest, ExportData, *WorkflowRunExportData]
+
+// TaskMessage returns the exported task for taskbundle.
+func (d *ExportData) TaskMessage() *orcv1.Task { return d.Task }
+
+// SetTaskMessage sets the exported task for taskbundle.
+func (d *ExportData) SetTaskMessage(t *orcv1.Task) { d.Task = t }
+
+// buildTaskBundle collects everything needed to continue a task on another
+// machine: the task with its execution state, plan, spec, transcripts,
+// comments, and attachments, plus its workflow runs with phase state and
+// phase outputs.
+func buildTaskBundle(backend storage.Backend, taskID string, withTranscripts bool) (*taskBundle, error) {
+	t, err := backend.LoadTask(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("load task: %w", err)
+	}
+
+	runs, err := backend.ListWorkflowRuns(db.WorkflowRunListOpts{TaskID: taskID})
+	if err != nil {
+		return nil, fmt.Errorf("list workflow runs: %w", err)
+	}
+	// Oldest first, so the latest run wins when imported.
+	sort.Slice(runs, func(i, j int) bool { return runs[i].CreatedAt.Before(runs[j].CreatedAt) })
+
+	bundle := &taskBundle{Task: buildExportDataWithBackend(backend, t, true, withTranscripts)}
+	for _, run := range runs {
+		phases, err := backend.GetWorkflowRunPhases(run.ID)
+		if err != nil {
+			return nil, fmt.Errorf("load phases for run %s: %w", run.ID, err)
+		}
+		outputs, err := backend.GetAllPhaseOutputs(run.ID)
+		if err != nil {
+			return nil, fmt.Errorf("load outputs for run %s: %w", run.ID, err)
+		}
+		bundle.Runs = append(bundle.Runs, &WorkflowRunExportData{
+			Version:     ExportFormatVersion,
+			ExportedAt:  time.Now(),
+			Type:        "workflow_run",
+			WorkflowRun: run,
+			Phases:      phases,
+			Outputs:     outputs,
+		})
+	}
+
+	hostname, _ := os.Hostname()
+	bundle.Manifest = ExportManifest{
+		Version:             ExportFormatVersion,
+		ExportedAt:          time.Now(),
+		SourceHostname:      hostname,
+		SourceProject:       backend.DB().ProjectDir(),
+		OrcVersion:          runtime.Version(),
+		TaskID:              taskID,
+		TaskCount:           1,
+		WorkflowRunCount:    len(bundle.Runs),
+		IncludesState:       true,
+		IncludesTranscripts: withTranscripts,
+		IncludesRuns:        len(bundle.Runs) > 0,
+	}
+	return bundle, nil
+}
+
+// writeTaskBundle writes a bundle as a tar.gz archive: manifest.yaml,
+// tasks/<id>.yaml, and workflow_runs/<run>.yaml. The layout matches
+// `orc export --all-tasks`, so `orc import` also accepts bundles.
+func writeTaskBundle(bundle *taskBundle, archivePath string) error {
+	file, err := os.Create(archivePath)
+	if err != nil {
+		return fmt.Errorf("create bundle: %w", err)
+	}
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	write := func(name string, v any) error {
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("marshal %s: %w", name, err)
+		}
+		if err := writeTarFile(tarWriter, name, data); err != nil {
+			return fmt.Errorf("write %s: %w", name, err)
+		}
+		return nil
+	}
+
+	err = write("manifest.yaml", bundle.Manifest)
+	if err == nil {
+		err = write(path.Join("tasks", bundle.Task.Task.Id+".yaml"), bundle.Task)
+	}
+	for _, run := range bundle.Runs {
+		if err != nil {
+			break
+		}
+		err = write(path.Join("workflow_runs", run.WorkflowRun.ID+".yaml"), run)
+	}
+	for _, closer := range []io.Closer{tarWriter, gzipWriter, file} {
+		if cerr := closer.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("close bundle: %w", cerr)
+		}
+	}
+	if err != nil {
+		_ = os.Remove(archivePath)
+	}
+	return err
+}
+
+// writeTaskBundleJSON writes a bundle as a single indented JSON document.
+func writeTaskBundleJSON(bundle *taskBundle, bundlePath string) error {
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal bundle: %w", err)
+	}
+	if err := os.WriteFile(bundlePath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	return nil
+}
+
+// readTaskBundle parses a bundle written by writeTaskBundle or
+// writeTaskBundleJSON; the format is detected from the content.
+func readTaskBundle(bundlePath string) (*taskBundle, error) {
+	file, err := os.Open(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("open bundle: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	reader := bufio.NewReader(file)
+	var bundle *taskBundle
+	if taskbundle.IsArchive(reader) {
+		bundle, err = readTaskBundleArchive(reader, bundlePath)
+	} else {
+		bundle = &taskBundle{}
+		if err := json.NewDecoder(io.LimitReader(reader, maxImportFileSize)).Decode(bundle); err != nil {
+			return nil, fmt.Errorf("%s is not a task bundle: %w", bundlePath, err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if bundle.Manifest.Version == 0 || bundle.Task == nil || bundle.Task.Task == nil {
+		return nil, fmt.Errorf("%s is not a task bundle (missing manifest or task)", bundlePath)
+	}
+	if bundle.Manifest.Version > ExportFormatVersion {
+		return nil, fmt.Errorf("bundle format version %d is newer than supported version %d; upgrade orc",
+			bundle.Manifest.Version, ExportFormatVersion)
+	}
+	return bundle, nil
+}
+
+// readTaskBundleArchive parses the tar.gz form of a bundle.
+func readTaskBundleArchive(r io.Reader, archivePath string) (*taskBundle, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a task bundle: %w", archivePath, err)
+	}
+	defer func() { _ = gzipReader.Close() }()
+
+	bundle := &taskBundle{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read bundle: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(io.LimitReader(tarReader, maxImportFileSize))
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", header.Name, err)
+		}
+
+		name := filepath.ToSlash(header.Name)
+		switch {
+		case name == "manifest.yaml":
+			if err := yaml.Unmarshal(data, &bundle.Manifest); err != nil {
+				return nil, fmt.Errorf("parse manifest: %w", err)
+			}
+		case strings.HasPrefix(name, "tasks/"):
+			if bundle.Task != nil {
+				return nil, fmt.Errorf("%s contains more than one task; use 'orc import' for multi-task archives", archivePath)
+			}
+			var export ExportData
+			if err := yaml.Unmarshal(data, &export); err != nil {
+				return nil, fmt.Errorf("parse %s: %w", name, err)
+			}
+			bundle.Task = &export
+		case strings.HasPrefix(name, "workflow_runs/"):
+			var run WorkflowRunExportData
+			if err := yaml.Unmarshal(data, &run); err != nil {
+				return nil, fmt.Errorf("parse %s: %w", name, err)
+			}
+			bundle.Runs = append(bundle.Runs, &run)
+		}
+	}
+	return bundle, nil
+}
+
+// importTaskBundle imports a bundle with the same merge rules as
+// `orc import`. The task is saved first, then its workflow runs, then the
+// transcripts that reference them.
+func importTaskBundle(bundle *taskBundle, sourceName string, force, skipExisting bool) error {
+	transcripts := bundle.Task.Transcripts
+	bundle.Task.Transcripts = nil
+	if err := importTaskExport(bundle.Task, sourceName, force, skipExisting); err != nil {
+		return err
+	}
+
+	for _, run := range bundle.Runs {
+		if run.WorkflowRun == nil {
+			continue
+		}
+		if err := importWorkflowRunExport(run, sourceName, force, skipExisting); err != nil {
+			// The task is already imported; report the run and go on.
+			level := "Warning"
+			if strings.Contains(err.Error(), "skipped") {
+				level = "Info"
+			}
+			fmt.Fprintf(os.Stderr, "%s: %v\n", level, err)
+		}
+	}
+
+	backend, err := getBackend()
+	if err != nil {
+		return fmt.Errorf("get backend: %w", err)
+	}
+	defer func() { _ = backend.Close() }()
+	importTranscripts(backend, bundle.Task.Task.Id, transcripts)
+	return nil
+}
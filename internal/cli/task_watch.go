@@ -0,0 +1,489 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// watchToolWidth bounds the tool call summary shown by `orc task watch`.
+const watchToolWidth = 100
+
+// watchUpdate is one message from a watch source: an event, a fresh copy of
+// the task, or an error that ends the watch.
+type watchUpdate struct {
+	event *events.Event
+	task  *orcv1.Task
+	err   error
+}
+
+// taskWatchState is what `orc task watch` knows about a task. It is built
+// from the task record and the task's events, whichever source they come
+// from.
+type taskWatchState struct {
+	taskID   string
+	title    string
+	status   orcv1.TaskStatus
+	phases   []*watchPhase
+	current  string
+	activity string
+	tool     string
+	gate     *events.DecisionRequiredData
+	lastErr  string
+	done     bool
+}
+
+// watchPhase is one phase row of the watch view.
+type watchPhase struct {
+	name   string
+	status string // running, completed, failed, skipped, looping; empty until seen
+
+	// input and output add up per-message usage from the live transcript;
+	// savedInput and savedOutput are the phase totals recorded on the task.
+	input, output           int
+	savedInput, savedOutput int
+}
+
+func newTaskWatchState(taskID string) *taskWatchState {
+	return &taskWatchState{taskID: taskID}
+}
+
+// tokens returns the phase's best known input and output token counts.
+func (p *watchPhase) tokens() (int, int) {
+	return max(p.input, p.savedInput), max(p.output, p.savedOutput)
+}
+
+// phase returns the named phase, adding it in order of appearance. It
+// returns nil for an empty name.
+func (s *taskWatchState) phase(name string) *watchPhase {
+	if name == "" {
+		return nil
+	}
+	for _, p := range s.phases {
+		if p.name == name {
+			return p
+		}
+	}
+	p := &watchPhase{name: name}
+	s.phases = append(s.phases, p)
+	return p
+}
+
+// tokens returns the task's input and output token totals.
+func (s *taskWatchState) tokens() (int, int) {
+	var in, out int
+	for _, p := range s.phases {
+		i, o := p.tokens()
+		in += i
+		out += o
+	}
+	return in, out
+}
+
+// apply folds an update into the state and returns one line per notable
+// change, for plain output.
+func (s *taskWatchState) apply(u watchUpdate) []string {
+	switch {
+	case u.task != nil:
+		return s.applyTask(u.task)
+	case u.event != nil:
+		return s.applyEvent(*u.event)
+	}
+	return nil
+}
+
+// applyTask takes status, title and recorded phase totals from the task.
+func (s *taskWatchState) applyTask(t *orcv1.Task) []string {
+	var lines []string
+	if s.status != t.Status {
+		if s.status != orcv1.TaskStatus_TASK_STATUS_UNSPECIFIED {
+			lines = append(lines, "status "+task.StatusFromProto(t.Status))
+		}
+		s.status = t.Status
+	}
+	s.title = t.Title
+
+	phases := t.GetExecution().GetPhases()
+	names := make([]string, 0, len(phases))
+	for name := range phases {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := phases[names[i]].GetStartedAt().AsTime(), phases[names[j]].GetStartedAt().AsTime()
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		ps := phases[name]
+		p := s.phase(name)
+		p.savedInput = int(ps.GetTokens().GetInputTokens())
+		p.savedOutput = int(ps.GetTokens().GetOutputTokens())
+		switch ps.Status {
+		case orcv1.PhaseStatus_PHASE_STATUS_COMPLETED:
+			p.status = "completed"
+		case orcv1.PhaseStatus_PHASE_STATUS_SKIPPED:
+			p.status = "skipped"
+		}
+	}
+
+	if t.Status == orcv1.TaskStatus_TASK_STATUS_RUNNING && t.GetCurrentPhase() != "" {
+		s.current = t.GetCurrentPhase()
+		if p := s.phase(s.current); p.status == "" {
+			p.status = "running"
+		}
+	}
+	switch t.Status {
+	case orcv1.TaskStatus_TASK_STATUS_COMPLETED, orcv1.TaskStatus_TASK_STATUS_FAILED, orcv1.TaskStatus_TASK_STATUS_CLOSED:
+		s.done = true
+		if p := s.phase(s.current); p != nil && p.status == "running" && t.Status == orcv1.TaskStatus_TASK_STATUS_FAILED {
+			p.status = "failed"
+		}
+		s.activity, s.tool = "", ""
+	}
+	if t.Status != orcv1.TaskStatus_TASK_STATUS_BLOCKED {
+		s.gate = nil
+	}
+	return lines
+}
+
+// applyEvent folds one task event into the state.
+func (s *taskWatchState) applyEvent(e events.Event) []string {
+	switch data := e.Data.(type) {
+	case *events.PhaseUpdate:
+		p := s.phase(data.Phase)
+		if p == nil {
+			return nil
+		}
+		status := data.Status
+		if status == "started" {
+			status = "running"
+		}
+		if p.status == status && data.Error == "" {
+			return nil
+		}
+		p.status = status
+		if status == "running" {
+			s.current = data.Phase
+			s.activity, s.tool = "", ""
+		} else if s.current == data.Phase {
+			s.activity, s.tool = "", ""
+		}
+		line := "phase " + data.Phase + " " + status
+		if data.Error != "" {
+			line += ": " + data.Error
+		}
+		return []string{line}
+
+	case *events.TokenUpdate:
+		if p := s.phase(s.phaseOrCurrent(data.Phase)); p != nil {
+			p.savedInput = max(p.savedInput, data.InputTokens)
+			p.savedOutput = max(p.savedOutput, data.OutputTokens)
+		}
+
+	case *events.TranscriptLine:
+		p := s.phase(s.phaseOrCurrent(data.Phase))
+		if p != nil && data.Tokens != nil {
+			p.input += data.Tokens.InputTokens
+			p.output += data.Tokens.OutputTokens
+		}
+		if data.Type == "tool" {
+			s.tool = summarizeToolCall(data.Content)
+			return []string{"tool " + s.tool}
+		}
+
+	case *events.ActivityUpdate:
+		s.activity = data.Activity
+
+	case *events.DecisionRequiredData:
+		s.gate = data
+		line := "gate " + data.Phase + " waiting for a decision"
+		if data.Question != "" {
+			line += ": " + data.Question
+		}
+		return []string{line}
+
+	case *events.DecisionResolvedData:
+		s.gate = nil
+		verdict := "rejected"
+		if data.Approved {
+			verdict = "approved"
+		}
+		return []string{"gate " + data.Phase + " " + verdict}
+
+	case *events.ErrorData:
+		s.lastErr = data.Message
+		if p := s.phase(data.Phase); p != nil && data.Fatal {
+			p.status = "failed"
+		}
+		return []string{"error: " + data.Message}
+	}
+	return nil
+}
+
+func (s *taskWatchState) phaseOrCurrent(phase string) string {
+	if phase != "" {
+		return phase
+	}
+	return s.current
+}
+
+// summarizeToolCall reduces a transcript tool entry ("Name\n{args}") to the
+// tool name and its most telling argument, on one line.
+func summarizeToolCall(content string) string {
+	name, args, _ := strings.Cut(content, "\n")
+	summary := strings.TrimSpace(name)
+
+	var fields map[string]any
+	if json.Unmarshal([]byte(args), &fields) == nil {
+		for _, key := range []string{"command", "file_path", "path", "pattern", "url", "query", "description"} {
+			if v, ok := fields[key].(string); ok && v != "" {
+				summary += " " + strings.Join(strings.Fields(v), " ")
+				break
+			}
+		}
+	}
+	if len(summary) > watchToolWidth {
+		summary = summary[:watchToolWidth-3] + "..."
+	}
+	return summary
+}
+
+// summary is the one-line outcome printed when plain output ends.
+func (s *taskWatchState) summary() string {
+	in, out := s.tokens()
+	return fmt.Sprintf("%s %s (%d input / %d output tokens)", s.taskID, task.StatusFromProto(s.status), in, out)
+}
+
+// watchStyles colors the watch view; the zero value renders plain text.
+type watchStyles struct {
+	title, subtle, running, success, failure, highlight lipgloss.Style
+}
+
+func defaultWatchStyles() watchStyles {
+	return watchStyles{
+		title:     lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205")),
+		subtle:    lipgloss.NewStyle().Foreground(lipgloss.Color("241")),
+		running:   lipgloss.NewStyle().Foreground(lipgloss.Color("170")).Bold(true),
+		success:   lipgloss.NewStyle().Foreground(lipgloss.Color("46")),
+		failure:   lipgloss.NewStyle().Foreground(lipgloss.Color("196")),
+		highlight: lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true),
+	}
+}
+
+// render draws the watch view.
+func (s *taskWatchState) render(st watchStyles) string {
+	var b strings.Builder
+
+	header := s.taskID
+	if s.title != "" {
+		header += "  " + s.title
+	}
+	b.WriteString(st.title.Render(header) + "\n")
+
+	in, out := s.tokens()
+	status := task.StatusFromProto(s.status)
+	if s.status == orcv1.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		status = "unknown"
+	}
+	fmt.Fprintf(&b, "Status: %s   Tokens: %d input / %d output\n\n", status, in, out)
+
+	if len(s.phases) == 0 {
+		b.WriteString(st.subtle.Render("  No phases started yet") + "\n")
+	}
+	for _, p := range s.phases {
+		pin, pout := p.tokens()
+		label := p.status
+		if label == "" {
+			label = "pending"
+		}
+		if p.name == s.current && p.status == "running" && s.activity != "" {
+			label += " (" + strings.ReplaceAll(s.activity, "_", " ") + ")"
+		}
+		row := fmt.Sprintf("  %s %-16s %-28s %8d in %8d out", watchPhaseMarker(p.status), p.name, label, pin, pout)
+		switch p.status {
+		case "running", "looping":
+			row = st.running.Render(row)
+		case "completed":
+			row = st.success.Render(row)
+		case "failed":
+			row = st.failure.Render(row)
+		case "", "skipped":
+			row = st.subtle.Render(row)
+		}
+		b.WriteString(row + "\n")
+	}
+
+	if s.tool != "" {
+		b.WriteString("\nTool: " + s.tool + "\n")
+	}
+	if s.gate != nil {
+		gate := fmt.Sprintf("\nGate: %s is waiting for a %s decision", s.gate.Phase, s.gate.GateType)
+		b.WriteString(st.highlight.Render(gate) + "\n")
+		if s.gate.Question != "" {
+			b.WriteString("  " + s.gate.Question + "\n")
+		}
+		fmt.Fprintf(&b, "  Decide with: orc approve %s  |  orc reject %s\n", s.taskID, s.taskID)
+	}
+	if s.lastErr != "" {
+		b.WriteString("\n" + st.failure.Render("Error: "+s.lastErr) + "\n")
+	}
+	return b.String()
+}
+
+// watchPhaseMarker is the status column of a phase row, respecting --plain.
+func watchPhaseMarker(status string) string {
+	if plain {
+		switch status {
+		case "completed":
+			return "[x]"
+		case "running", "looping":
+			return "[>]"
+		case "failed":
+			return "[!]"
+		case "skipped":
+			return "[-]"
+		}
+		return "[ ]"
+	}
+	switch status {
+	case "completed":
+		return "✓"
+	case "running", "looping":
+		return "●"
+	case "failed":
+		return "✗"
+	case "skipped":
+		return "–"
+	}
+	return "○"
+}
+
+// watchEventFromLog restores a persisted event with the payload type the
+// watch state expects. Events it does not display are reported as !ok.
+func watchEventFromLog(e db.EventLog) (events.Event, bool) {
+	var data any
+	switch events.EventType(e.EventType) {
+	case events.EventPhase:
+		data = &events.PhaseUpdate{}
+	case events.EventTokens:
+		data = &events.TokenUpdate{}
+	case events.EventTranscript:
+		data = &events.TranscriptLine{}
+	case events.EventActivity:
+		data = &events.ActivityUpdate{}
+	case events.EventError:
+		data = &events.ErrorData{}
+	case events.EventDecisionRequired:
+		data = &events.DecisionRequiredData{}
+	case events.EventDecisionResolved:
+		data = &events.DecisionResolvedData{}
+	default:
+		return events.Event{}, false
+	}
+	raw, err := json.Marshal(e.Data)
+	if err != nil || json.Unmarshal(raw, data) != nil {
+		return events.Event{}, false
+	}
+	return events.Event{Type: events.EventType(e.EventType), TaskID: e.TaskID, Data: data, Time: e.CreatedAt}, true
+}
+
+// watchEventFromProto converts an event from the server's event stream.
+// Live transcript lines arrive as activity events with JSON details.
+func watchEventFromProto(e *orcv1.Event) (events.Event, bool) {
+	ev := events.Event{TaskID: e.GetTaskId(), Time: e.GetTimestamp().AsTime()}
+	switch {
+	case e.GetPhaseChanged() != nil:
+		pc := e.GetPhaseChanged()
+		status := "running"
+		switch pc.Status {
+		case orcv1.PhaseStatus_PHASE_STATUS_COMPLETED:
+			status = "completed"
+		case orcv1.PhaseStatus_PHASE_STATUS_SKIPPED:
+			status = "skipped"
+		}
+		ev.Type = events.EventPhase
+		ev.Data = &events.PhaseUpdate{Phase: pc.PhaseName, Status: status, Error: pc.GetError()}
+
+	case e.GetTokensUpdated() != nil:
+		tu := e.GetTokensUpdated()
+		ev.Type = events.EventTokens
+		ev.Data = &events.TokenUpdate{
+			Phase:        tu.GetPhaseId(),
+			InputTokens:  int(tu.GetTokens().GetInputTokens()),
+			OutputTokens: int(tu.GetTokens().GetOutputTokens()),
+		}
+
+	case e.GetActivity() != nil:
+		act := e.GetActivity()
+		if act.Details == nil {
+			ev.Type = events.EventActivity
+			ev.Data = &events.ActivityUpdate{
+				Phase:    act.PhaseId,
+				Activity: strings.ToLower(strings.TrimPrefix(act.Activity.String(), "ACTIVITY_STATE_")),
+			}
+			break
+		}
+		var details struct {
+			Phase   string `json:"phase"`
+			Type    string `json:"type"`
+			Content string `json:"content"`
+			Tokens  struct {
+				Input  int `json:"input"`
+				Output int `json:"output"`
+			} `json:"tokens"`
+		}
+		if json.Unmarshal([]byte(act.GetDetails()), &details) != nil {
+			return events.Event{}, false
+		}
+		line := &events.TranscriptLine{Phase: details.Phase, Type: details.Type, Content: details.Content}
+		if details.Tokens.Input != 0 || details.Tokens.Output != 0 {
+			line.Tokens = &events.TokenUpdate{InputTokens: details.Tokens.Input, OutputTokens: details.Tokens.Output}
+		}
+		ev.Type = events.EventTranscript
+		ev.Data = line
+
+	case e.GetDecisionRequired() != nil:
+		dr := e.GetDecisionRequired()
+		ev.Type = events.EventDecisionRequired
+		ev.Data = &events.DecisionRequiredData{
+			DecisionID: dr.DecisionId,
+			TaskID:     dr.TaskId,
+			TaskTitle:  dr.TaskTitle,
+			Phase:      dr.Phase,
+			GateType:   dr.GateType,
+			Question:   dr.Question,
+			Context:    dr.Context,
+		}
+
+	case e.GetDecisionResolved() != nil:
+		dr := e.GetDecisionResolved()
+		ev.Type = events.EventDecisionResolved
+		ev.Data = &events.DecisionResolvedData{
+			DecisionID: dr.DecisionId,
+			TaskID:     dr.TaskId,
+			Phase:      dr.Phase,
+			Approved:   dr.Approved,
+			Reason:     dr.GetReason(),
+			ResolvedBy: dr.ResolvedBy,
+		}
+
+	case e.GetError() != nil:
+		ee := e.GetError()
+		ev.Type = events.EventError
+		ev.Data = &events.ErrorData{Phase: ee.GetPhase(), Message: ee.Error}
+
+	default:
+		return events.Event{}, false
+	}
+	return ev, true
+}
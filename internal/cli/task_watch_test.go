@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func watchEvent(typ events.EventType, data any) watchUpdate {
+	ev := events.NewEvent(typ, "TASK-001", data)
+	return watchUpdate{event: &ev}
+}
+
+func TestTaskWatchState_Apply(t *testing.T) {
+	s := newTaskWatchState("TASK-001")
+
+	running := task.NewProtoTask("TASK-001", "Fix crash on empty input")
+	running.Status = orcv1.TaskStatus_TASK_STATUS_RUNNING
+	s.apply(watchUpdate{task: running})
+
+	s.apply(watchEvent(events.EventPhase, &events.PhaseUpdate{Phase: "spec", Status: "running"}))
+	s.apply(watchEvent(events.EventTranscript, &events.TranscriptLine{
+		Phase: "spec", Type: "response", Tokens: &events.TokenUpdate{InputTokens: 100, OutputTokens: 20},
+	}))
+	s.apply(watchEvent(events.EventPhase, &events.PhaseUpdate{Phase: "spec", Status: "completed"}))
+	s.apply(watchEvent(events.EventPhase, &events.PhaseUpdate{Phase: "implement", Status: "running"}))
+	s.apply(watchEvent(events.EventActivity, &events.ActivityUpdate{Phase: "implement", Activity: "running_tool"}))
+	lines := s.apply(watchEvent(events.EventTranscript, &events.TranscriptLine{
+		Phase: "implement", Type: "tool", Content: "Bash\n{\n  \"command\": \"go test ./...\"\n}",
+	}))
+	if len(lines) != 1 || lines[0] != "tool Bash go test ./..." {
+		t.Errorf("tool lines = %q", lines)
+	}
+	// Recorded phase totals win over the live count when larger
+	s.apply(watchEvent(events.EventTokens, &events.TokenUpdate{Phase: "spec", InputTokens: 150, OutputTokens: 10}))
+
+	if len(s.phases) != 2 || s.phases[0].status != "completed" || s.phases[1].status != "running" {
+		t.Fatalf("phases = %+v %+v", s.phases[0], s.phases[1])
+	}
+	if in, out := s.phases[0].tokens(); in != 150 || out != 20 {
+		t.Errorf("spec tokens = %d/%d, want 150/20", in, out)
+	}
+	if s.current != "implement" || s.tool != "Bash go test ./..." {
+		t.Errorf("current = %q, tool = %q", s.current, s.tool)
+	}
+
+	s.apply(watchEvent(events.EventDecisionRequired, &events.DecisionRequiredData{Phase: "review", GateType: "human", Question: "Ship it?"}))
+	view := s.render(watchStyles{})
+	for _, want := range []string{"Fix crash on empty input", "implement", "running (running tool)", "Tool: Bash go test ./...", "Ship it?", "orc approve TASK-001"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	failed := task.NewProtoTask("TASK-001", "Fix crash on empty input")
+	failed.Status = orcv1.TaskStatus_TASK_STATUS_FAILED
+	lines = s.apply(watchUpdate{task: failed})
+	if !s.done || s.gate != nil || s.phases[1].status != "failed" {
+		t.Errorf("after failure: done = %v, gate = %v, implement = %q", s.done, s.gate, s.phases[1].status)
+	}
+	if len(lines) != 1 || lines[0] != "status failed" {
+		t.Errorf("status lines = %q", lines)
+	}
+}
+
+func TestWatchPlain_FromEventLog(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	tsk := task.NewProtoTask("TASK-001", "Add login")
+	tsk.Status = orcv1.TaskStatus_TASK_STATUS_COMPLETED
+	if err := backend.SaveTask(tsk); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	phase := "implement"
+	for i, e := range []*db.EventLog{
+		{TaskID: "TASK-001", Phase: &phase, EventType: string(events.EventPhase), Data: events.PhaseUpdate{Phase: phase, Status: "running"}},
+		{TaskID: "TASK-001", Phase: &phase, EventType: string(events.EventTranscript), Data: events.TranscriptLine{Phase: phase, Type: "tool", Content: "Edit\n{\"file_path\":\"login.go\"}"}},
+		{TaskID: "TASK-001", Phase: &phase, EventType: string(events.EventPhase), Data: events.PhaseUpdate{Phase: phase, Status: "completed"}},
+	} {
+		e.Source = "cli"
+		e.CreatedAt = now.Add(time.Duration(i) * time.Millisecond)
+		if err := backend.SaveEvent(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	updates := make(chan watchUpdate, 16)
+	go (&logWatchSource{backend: backend, taskID: "TASK-001"}).run(t.Context(), updates)
+
+	var out bytes.Buffer
+	if err := watchPlain(&out, newTaskWatchState("TASK-001"), updates); err != nil {
+		t.Fatalf("watchPlain() error: %v", err)
+	}
+	got := out.String()
+	for _, want := range []string{"phase implement running", "tool Edit login.go", "phase implement completed", "TASK-001 completed"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "tool Edit") > strings.Index(got, "phase implement completed") {
+		t.Errorf("events out of order:\n%s", got)
+	}
+}
+
+func TestWatchEventFromProto(t *testing.T) {
+	details := `{"phase":"implement","type":"tool","content":"Read\n{\"file_path\":\"main.go\"}","tokens":{"input":5,"output":7}}`
+	ev, ok := watchEventFromProto(&orcv1.Event{Payload: &orcv1.Event_Activity{Activity: &orcv1.ActivityEvent{
+		PhaseId: "implement", Activity: orcv1.ActivityState_ACTIVITY_STATE_STREAMING, Details: &details,
+	}}})
+	line, _ := ev.Data.(*events.TranscriptLine)
+	if !ok || line == nil || line.Type != "tool" || line.Tokens == nil || line.Tokens.OutputTokens != 7 {
+		t.Errorf("transcript activity = %+v, %v", ev.Data, ok)
+	}
+
+	ev, ok = watchEventFromProto(&orcv1.Event{Payload: &orcv1.Event_Activity{Activity: &orcv1.ActivityEvent{
+		PhaseId: "implement", Activity: orcv1.ActivityState_ACTIVITY_STATE_RUNNING_TOOL,
+	}}})
+	if act, _ := ev.Data.(*events.ActivityUpdate); !ok || act == nil || act.Activity != "running_tool" {
+		t.Errorf("activity = %+v, %v", ev.Data, ok)
+	}
+
+	ev, ok = watchEventFromProto(&orcv1.Event{Payload: &orcv1.Event_PhaseChanged{PhaseChanged: &orcv1.PhaseChangedEvent{
+		PhaseName: "review", Status: orcv1.PhaseStatus_PHASE_STATUS_PENDING,
+	}}})
+	if pu, _ := ev.Data.(*events.PhaseUpdate); !ok || pu == nil || pu.Status != "running" {
+		t.Errorf("phase changed = %+v, %v", ev.Data, ok)
+	}
+
+	if _, ok := watchEventFromProto(&orcv1.Event{Payload: &orcv1.Event_Heartbeat{Heartbeat: &orcv1.HeartbeatEvent{}}}); ok {
+		t.Error("heartbeat should not be displayed")
+	}
+}
+
+func TestSummarizeToolCall(t *testing.T) {
+	if got := summarizeToolCall("TodoWrite"); got != "TodoWrite" {
+		t.Errorf("no args = %q", got)
+	}
+	long := summarizeToolCall("Bash\n{\"command\":\"" + strings.Repeat("x", 200) + "\"}")
+	if len(long) != watchToolWidth || !strings.HasSuffix(long, "...") {
+		t.Errorf("long command not truncated: %q", long)
+	}
+}
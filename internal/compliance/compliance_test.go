@@ -0,0 +1,216 @@
+package compliance
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+)
+
+func writeFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	p := filepath.Join(root, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func depNames(deps []Dependency) string {
+	names := make([]string, len(deps))
+	for i, d := range deps {
+		names[i] = d.Name + "@" + d.Version
+	}
+	return strings.Join(names, ",")
+}
+
+func TestScan_Manifests(t *testing.T) {
+	files := map[string]string{
+		"go.mod": `module example.com/app
+
+go 1.22
+
+require github.com/spf13/cobra v1.8.0
+
+require (
+	golang.org/x/sync v0.6.0 // indirect
+)
+`,
+		"web/package.json": `{"dependencies": {"left-pad": "^1.0.0"}}`,
+		"web/package-lock.json": `{"lockfileVersion": 3, "packages": {
+			"": {"name": "web"},
+			"node_modules/left-pad": {"version": "1.3.0", "resolved": "https://registry.npmjs.org/left-pad/-/left-pad-1.3.0.tgz", "license": "WTFPL"},
+			"node_modules/@scope/util": {"version": "2.0.0", "resolved": "https://npm.internal.example/@scope/util.tgz", "license": {"type": "MIT"}},
+			"node_modules/local": {"link": true}
+		}}`,
+		"tools/package.json":             `{"devDependencies": {"eslint": "^9.0.0"}}`,
+		"node_modules/x/package.json":    `{"dependencies": {"ignored": "1"}}`,
+		"requirements.txt":               "--index-url https://pypi.internal.example/simple\nRequests==2.31.0  # http\nflask>=3.0\n# comment\n-r dev.txt\nmylib @ https://files.example.com/mylib.whl\n",
+		"README.md":                      "not a manifest",
+		"vendor/github.com/a/b/go.mod":   "module github.com/a/b\n",
+		"deeply/nested/requirements.txt": "pyyaml===6.0.1\n",
+	}
+	var paths []string
+	for p := range files {
+		paths = append(paths, p)
+	}
+	deps, err := Scan(paths, func(name string) ([]byte, error) { return []byte(files[name]), nil })
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	byName := make(map[string]Dependency)
+	for _, d := range deps {
+		byName[d.Name] = d
+	}
+	if len(deps) != 9 {
+		t.Errorf("deps = %s, want 9", depNames(deps))
+	}
+	checks := []struct {
+		name, version, license, source string
+	}{
+		{"github.com/spf13/cobra", "v1.8.0", "", "github.com"},
+		{"golang.org/x/sync", "v0.6.0", "", "golang.org"},
+		{"left-pad", "1.3.0", "WTFPL", "registry.npmjs.org"},
+		{"@scope/util", "2.0.0", "MIT", "npm.internal.example"},
+		{"eslint", "^9.0.0", "", ""},
+		{"requests", "2.31.0", "", "pypi.internal.example"},
+		{"flask", ">=3.0", "", "pypi.internal.example"},
+		{"mylib", "https://files.example.com/mylib.whl", "", "files.example.com"},
+		{"pyyaml", "6.0.1", "", "pypi.org"},
+	}
+	for _, c := range checks {
+		d, ok := byName[c.name]
+		if !ok {
+			t.Errorf("missing %s", c.name)
+			continue
+		}
+		if d.Version != c.version || d.License != c.license || d.Source != c.source {
+			t.Errorf("%s = %+v, want version %q license %q source %q", c.name, d, c.version, c.license, c.source)
+		}
+	}
+	if _, ok := byName["ignored"]; ok {
+		t.Error("scanned a manifest under node_modules")
+	}
+}
+
+func TestDetectLicense(t *testing.T) {
+	tests := map[string]string{
+		"MIT License\n\nPermission is hereby granted, free of charge, to any person":                          "MIT",
+		"Apache License\n                           Version 2.0, January 2004":                                "Apache-2.0",
+		"GNU GENERAL PUBLIC LICENSE\n Version 3, 29 June 2007":                                                "GPL-3.0",
+		"GNU LESSER GENERAL PUBLIC LICENSE\nVersion 2.1, February 1999 ... GNU General Public License":        "LGPL-2.1",
+		"Redistribution and use in source and binary forms ... Neither the name of Google Inc. nor the names": "BSD-3-Clause",
+		"Redistribution and use in source and binary forms, with or without modification":                     "BSD-2-Clause",
+		"All rights reserved. Proprietary.":                                                                   "",
+	}
+	for text, want := range tests {
+		if got := DetectLicense(text); got != want {
+			t.Errorf("DetectLicense(%.40q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	before := []Dependency{
+		{Ecosystem: EcosystemNPM, Name: "old", Version: "1.0.0", License: "MIT", Manifest: "package-lock.json"},
+		{Ecosystem: EcosystemNPM, Name: "gone", Version: "1.0.0", License: "MIT", Manifest: "package-lock.json"},
+		{Ecosystem: EcosystemNPM, Name: "same", Version: "1.0.0", License: "GPL-3.0", Manifest: "package-lock.json"},
+	}
+	after := []Dependency{
+		{Ecosystem: EcosystemNPM, Name: "old", Version: "2.0.0", License: "AGPL-3.0-only", Manifest: "package-lock.json"},
+		{Ecosystem: EcosystemNPM, Name: "same", Version: "1.0.0", License: "GPL-3.0", Manifest: "package-lock.json"},
+		{Ecosystem: EcosystemNPM, Name: "dual", Version: "1.0.0", License: "(GPL-3.0-or-later OR MIT)", Source: "registry.npmjs.org", Manifest: "package-lock.json"},
+		{Ecosystem: EcosystemNPM, Name: "both", Version: "1.0.0", License: "MIT AND GPL-2.0+", Manifest: "package-lock.json"},
+		{Ecosystem: EcosystemGo, Name: "example.com/mystery", Version: "v1.0.0", Source: "example.com", Manifest: "go.mod"},
+		{Ecosystem: EcosystemGo, Name: "github.com/x/y", Version: "v1.0.0", License: "Apache-2.0 WITH LLVM-exception", Source: "github.com", Manifest: "go.mod"},
+	}
+	delta := Diff(before, after)
+	if len(delta.Added) != 4 {
+		t.Errorf("added = %s", depNames(delta.Added))
+	}
+	if len(delta.Updated) != 1 || delta.Updated[0].Name != "old" || delta.Updated[0].PreviousVersion != "1.0.0" {
+		t.Errorf("updated = %+v", delta.Updated)
+	}
+	if depNames(delta.Removed) != "gone@1.0.0" {
+		t.Errorf("removed = %s", depNames(delta.Removed))
+	}
+
+	cfg := config.Default().Compliance
+	cfg.ApprovedRegistries = []string{"npmjs.org", "github.com"}
+	report := Evaluate(delta, cfg)
+
+	var violations []string
+	for _, v := range report.Violations {
+		violations = append(violations, v.Dependency.Name+":"+v.Rule)
+	}
+	got := strings.Join(violations, ",")
+	for _, want := range []string{"old:license", "both:license", "example.com/mystery:registry"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("violations = %s, missing %s", got, want)
+		}
+	}
+	if strings.Contains(got, "dual") || strings.Contains(got, "github.com/x/y") || strings.Contains(got, "same") {
+		t.Errorf("violations = %s, want dual-licensed, WITH-exception and unchanged deps to pass", got)
+	}
+	if len(report.Warnings) != 1 || report.Warnings[0].Dependency.Name != "example.com/mystery" {
+		t.Errorf("warnings = %+v, want the unknown license as a warning", report.Warnings)
+	}
+
+	cfg.UnknownLicense = config.ComplianceUnknownBlock
+	cfg.AllowedLicenses = []string{"MIT", "Apache-2.0"}
+	cfg.ApprovedRegistries = nil
+	report = Evaluate(delta, cfg)
+	if len(report.Violations) != 3 || len(report.Warnings) != 0 {
+		t.Errorf("strict report = %+v, want old, both and the unknown license to block", report)
+	}
+	if out := FormatReport(report, delta); !strings.Contains(out, "### Violations") || !strings.Contains(out, "`both`") {
+		t.Errorf("FormatReport:\n%s", out)
+	}
+}
+
+func TestComputeDelta_WorktreeAgainstBase(t *testing.T) {
+	root := t.TempDir()
+	git(t, root, "init", "--initial-branch=main")
+	git(t, root, "config", "user.email", "test@example.com")
+	git(t, root, "config", "user.name", "Test")
+	writeFile(t, root, "requirements.txt", "requests==2.30.0\nsix==1.16.0\n")
+	git(t, root, "add", ".")
+	git(t, root, "commit", "-m", "init")
+
+	writeFile(t, root, "requirements.txt", "requests==2.31.0\n")
+	writeFile(t, root, "package.json", `{"dependencies": {"lib": "^1.0.0"}}`)
+	writeFile(t, root, "node_modules/lib/package.json", `{"name": "lib", "license": "ISC"}`)
+	writeFile(t, root, ".gitignore", "node_modules/\n")
+
+	delta, err := ComputeDelta(root, "HEAD")
+	if err != nil {
+		t.Fatalf("ComputeDelta: %v", err)
+	}
+	if len(delta.Added) != 1 || delta.Added[0].Name != "lib" || delta.Added[0].License != "ISC" {
+		t.Errorf("added = %+v, want lib with license from node_modules", delta.Added)
+	}
+	if len(delta.Updated) != 1 || delta.Updated[0].Name != "requests" || delta.Updated[0].PreviousVersion != "2.30.0" {
+		t.Errorf("updated = %+v", delta.Updated)
+	}
+	if depNames(delta.Removed) != "six@1.16.0" {
+		t.Errorf("removed = %s", depNames(delta.Removed))
+	}
+	if delta.Format != DeltaFormat || delta.BaseRef != "HEAD" {
+		t.Errorf("header = %q %q", delta.Format, delta.BaseRef)
+	}
+}
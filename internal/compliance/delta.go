@@ -0,0 +1,117 @@
+package compliance
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DeltaFormat identifies the JSON layout of Delta.
+const DeltaFormat = "orc-sbom-delta/v1"
+
+// UpdatedDependency is a dependency whose version changed.
+type UpdatedDependency struct {
+	Dependency
+	PreviousVersion string `json:"previous_version,omitempty"`
+	PreviousLicense string `json:"previous_license,omitempty"`
+}
+
+// Delta is the software bill of materials change between a base commit and
+// the worktree: what a task adds, updates and removes.
+type Delta struct {
+	Format      string              `json:"format"`
+	BaseRef     string              `json:"base_ref"`
+	GeneratedAt time.Time           `json:"generated_at"`
+	Added       []Dependency        `json:"added"`
+	Updated     []UpdatedDependency `json:"updated"`
+	Removed     []Dependency        `json:"removed"`
+}
+
+// Empty reports whether the change touches no dependencies.
+func (d *Delta) Empty() bool {
+	return len(d.Added) == 0 && len(d.Updated) == 0 && len(d.Removed) == 0
+}
+
+// Introduced returns the dependencies the change brings in at a new version:
+// additions followed by updates. These are the ones policy applies to.
+func (d *Delta) Introduced() []Dependency {
+	deps := make([]Dependency, 0, len(d.Added)+len(d.Updated))
+	deps = append(deps, d.Added...)
+	for _, u := range d.Updated {
+		deps = append(deps, u.Dependency)
+	}
+	return deps
+}
+
+// ComputeDelta compares the dependencies declared at baseRef with those in
+// the git worktree at root, resolving licenses for new and updated entries.
+func ComputeDelta(root, baseRef string) (*Delta, error) {
+	basePaths, err := RefFiles(root, baseRef)
+	if err != nil {
+		return nil, fmt.Errorf("list files at %s: %w", baseRef, err)
+	}
+	before, err := Scan(basePaths, ReadRef(root, baseRef))
+	if err != nil {
+		return nil, fmt.Errorf("scan %s: %w", baseRef, err)
+	}
+	paths, err := WorktreeFiles(root)
+	if err != nil {
+		return nil, fmt.Errorf("list worktree files: %w", err)
+	}
+	after, err := Scan(paths, ReadWorktree(root))
+	if err != nil {
+		return nil, fmt.Errorf("scan worktree: %w", err)
+	}
+
+	delta := Diff(before, after)
+	delta.BaseRef = baseRef
+	introduced := make([]*Dependency, 0, len(delta.Added)+len(delta.Updated))
+	for i := range delta.Added {
+		introduced = append(introduced, &delta.Added[i])
+	}
+	for i := range delta.Updated {
+		introduced = append(introduced, &delta.Updated[i].Dependency)
+	}
+	ResolveLicenses(root, introduced)
+	return delta, nil
+}
+
+// Diff compares two scans. Dependencies are matched by manifest directory,
+// ecosystem and name; a version change is an update.
+func Diff(before, after []Dependency) *Delta {
+	delta := &Delta{
+		Format:      DeltaFormat,
+		GeneratedAt: time.Now().UTC(),
+		Added:       []Dependency{},
+		Updated:     []UpdatedDependency{},
+		Removed:     []Dependency{},
+	}
+	old := make(map[string]Dependency, len(before))
+	for _, d := range before {
+		old[d.key()] = d
+	}
+	current := make(map[string]bool, len(after))
+	for _, d := range after {
+		current[d.key()] = true
+		prev, ok := old[d.key()]
+		switch {
+		case !ok:
+			delta.Added = append(delta.Added, d)
+		case prev.Version != d.Version:
+			delta.Updated = append(delta.Updated, UpdatedDependency{Dependency: d, PreviousVersion: prev.Version, PreviousLicense: prev.License})
+		}
+	}
+	for _, d := range before {
+		if !current[d.key()] {
+			delta.Removed = append(delta.Removed, d)
+		}
+	}
+	sortDeps(delta.Added)
+	sortDeps(delta.Removed)
+	sort.Slice(delta.Updated, func(i, j int) bool { return delta.Updated[i].key() < delta.Updated[j].key() })
+	return delta
+}
+
+func sortDeps(deps []Dependency) {
+	sort.Slice(deps, func(i, j int) bool { return deps[i].key() < deps[j].key() })
+}
@@ -0,0 +1,248 @@
+package compliance
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// licenseFiles are the names checked, in order, for a package's license text.
+var licenseFiles = []string{"LICENSE", "LICENSE.md", "LICENSE.txt", "LICENCE", "LICENCE.md", "COPYING", "COPYING.md"}
+
+// licenseSignatures identify common licenses by distinctive phrases. Order
+// matters: more specific texts come before texts they contain.
+var licenseSignatures = []struct {
+	id      string
+	phrases []string
+}{
+	{"AGPL-3.0", []string{"gnu affero general public license"}},
+	{"LGPL-3.0", []string{"gnu lesser general public license", "version 3"}},
+	{"LGPL-2.1", []string{"gnu lesser general public license", "version 2.1"}},
+	{"GPL-3.0", []string{"gnu general public license", "version 3"}},
+	{"GPL-2.0", []string{"gnu general public license", "version 2"}},
+	{"MPL-2.0", []string{"mozilla public license", "2.0"}},
+	{"Apache-2.0", []string{"apache license", "version 2.0"}},
+	{"SSPL-1.0", []string{"server side public license"}},
+	{"BSD-3-Clause", []string{"redistribution and use in source and binary forms", "neither the name"}},
+	{"BSD-3-Clause", []string{"redistribution and use in source and binary forms", "names of its contributors"}},
+	{"BSD-2-Clause", []string{"redistribution and use in source and binary forms"}},
+	{"ISC", []string{"permission to use, copy, modify, and/or distribute this software for any purpose"}},
+	{"MIT", []string{"permission is hereby granted, free of charge"}},
+	{"Unlicense", []string{"this is free and unencumbered software released into the public domain"}},
+}
+
+// DetectLicense identifies the SPDX ID of a license text, or returns "".
+func DetectLicense(text string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	for _, sig := range licenseSignatures {
+		matched := true
+		for _, phrase := range sig.phrases {
+			if !strings.Contains(normalized, phrase) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return sig.id
+		}
+	}
+	return ""
+}
+
+// ResolveLicenses fills in missing licenses from locally installed copies:
+// node_modules next to the manifest for npm, and the module cache for Go.
+// Dependencies that are not installed keep an empty license.
+func ResolveLicenses(root string, deps []*Dependency) {
+	modCache := goModCache()
+	for _, d := range deps {
+		if d.License != "" {
+			continue
+		}
+		switch d.Ecosystem {
+		case EcosystemNPM:
+			dir := filepath.Join(root, filepath.FromSlash(path.Dir(d.Manifest)), "node_modules", filepath.FromSlash(d.Name))
+			d.License = installedNPMLicense(dir)
+		case EcosystemGo:
+			if modCache == "" {
+				continue
+			}
+			if escaped, ok := escapeModulePath(d.Name + "@" + d.Version); ok {
+				d.License = licenseInDir(filepath.Join(modCache, filepath.FromSlash(escaped)))
+			}
+		}
+	}
+}
+
+func installedNPMLicense(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err == nil {
+		var pkg struct {
+			License json.RawMessage `json:"license"`
+		}
+		if json.Unmarshal(data, &pkg) == nil {
+			if l := npmLicense(pkg.License); l != "" {
+				return l
+			}
+		}
+	}
+	return licenseInDir(dir)
+}
+
+func licenseInDir(dir string) string {
+	for _, name := range licenseFiles {
+		if data, err := os.ReadFile(filepath.Join(dir, name)); err == nil {
+			return DetectLicense(string(data))
+		}
+	}
+	return ""
+}
+
+// goModCache locates the Go module cache without invoking the go tool.
+func goModCache() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	if gopath := os.Getenv("GOPATH"); gopath != "" {
+		return filepath.Join(filepath.SplitList(gopath)[0], "pkg", "mod")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, "go", "pkg", "mod")
+	}
+	return ""
+}
+
+// escapeModulePath applies the module cache's case encoding, where an
+// uppercase letter is stored as "!" followed by its lowercase form.
+func escapeModulePath(p string) (string, bool) {
+	var sb strings.Builder
+	for _, r := range p {
+		switch {
+		case r == '!':
+			return "", false
+		case unicode.IsUpper(r):
+			sb.WriteByte('!')
+			sb.WriteRune(unicode.ToLower(r))
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String(), true
+}
+
+// licenseIDsEqual compares SPDX IDs ignoring case and the -only, -or-later,
+// and + suffixes, so "GPL-3.0" matches "GPL-3.0-or-later".
+func licenseIDsEqual(a, b string) bool {
+	return baseLicenseID(a) == baseLicenseID(b)
+}
+
+func baseLicenseID(id string) string {
+	id = strings.ToUpper(strings.TrimSpace(id))
+	id = strings.TrimSuffix(id, "+")
+	id = strings.TrimSuffix(id, "-ONLY")
+	return strings.TrimSuffix(id, "-OR-LATER")
+}
+
+// licenseExpr is a parsed SPDX license expression.
+type licenseExpr struct {
+	id       string // Leaf license ID; empty for operators
+	op       string // "AND" or "OR"
+	children []*licenseExpr
+}
+
+// satisfiedBy reports whether the expression can be complied with using
+// only licenses accepted by ok: one side of OR, or every side of AND.
+func (e *licenseExpr) satisfiedBy(ok func(id string) bool) bool {
+	switch e.op {
+	case "OR":
+		for _, c := range e.children {
+			if c.satisfiedBy(ok) {
+				return true
+			}
+		}
+		return false
+	case "AND":
+		for _, c := range e.children {
+			if !c.satisfiedBy(ok) {
+				return false
+			}
+		}
+		return true
+	}
+	return ok(e.id)
+}
+
+// parseLicenseExpr parses an SPDX expression with AND binding tighter than
+// OR. "X WITH exception" is treated as X. Returns nil when malformed.
+func parseLicenseExpr(s string) *licenseExpr {
+	s = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(s)
+	p := &exprParser{tokens: strings.Fields(s)}
+	e := p.parseOr()
+	if e == nil || p.pos != len(p.tokens) {
+		return nil
+	}
+	return e
+}
+
+type exprParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *exprParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return strings.ToUpper(p.tokens[p.pos])
+	}
+	return ""
+}
+
+func (p *exprParser) parseOr() *licenseExpr {
+	return p.parseBinary("OR", p.parseAnd)
+}
+
+func (p *exprParser) parseAnd() *licenseExpr {
+	return p.parseBinary("AND", p.parseTerm)
+}
+
+func (p *exprParser) parseBinary(op string, next func() *licenseExpr) *licenseExpr {
+	first := next()
+	if first == nil {
+		return nil
+	}
+	e := &licenseExpr{op: op, children: []*licenseExpr{first}}
+	for p.peek() == op {
+		p.pos++
+		c := next()
+		if c == nil {
+			return nil
+		}
+		e.children = append(e.children, c)
+	}
+	if len(e.children) == 1 {
+		return first
+	}
+	return e
+}
+
+func (p *exprParser) parseTerm() *licenseExpr {
+	switch tok := p.peek(); tok {
+	case "", ")", "AND", "OR", "WITH":
+		return nil
+	case "(":
+		p.pos++
+		e := p.parseOr()
+		if e == nil || p.peek() != ")" {
+			return nil
+		}
+		p.pos++
+		return e
+	}
+	e := &licenseExpr{id: p.tokens[p.pos]}
+	p.pos++
+	if p.peek() == "WITH" && p.pos+1 < len(p.tokens) {
+		p.pos += 2
+	}
+	return e
+}
@@ -0,0 +1,379 @@
+// Package compliance scans dependency manifests and checks the dependencies a
+// change introduces against license and registry policy.
+package compliance
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Ecosystems reported in Dependency.Ecosystem.
+const (
+	EcosystemGo   = "go"
+	EcosystemNPM  = "npm"
+	EcosystemPyPI = "pypi"
+)
+
+// Dependency is one package declared by a manifest.
+type Dependency struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Version   string `json:"version,omitempty"`
+	License   string `json:"license,omitempty"` // SPDX expression; empty when unknown
+	Source    string `json:"source,omitempty"`  // Host the package resolves from; empty when unknown
+	Manifest  string `json:"manifest"`          // Repo-relative manifest that declares it
+}
+
+// key identifies a dependency across two scans of the same repository.
+func (d Dependency) key() string {
+	return path.Dir(d.Manifest) + "\x00" + d.Ecosystem + "\x00" + d.Name
+}
+
+// ReadFunc returns the content of a repo-relative file, or an error
+// satisfying errors.Is(err, fs.ErrNotExist) when it does not exist.
+type ReadFunc func(name string) ([]byte, error)
+
+// manifestNames are the files Scan understands.
+var manifestNames = map[string]bool{
+	"go.mod":            true,
+	"package.json":      true,
+	"package-lock.json": true,
+	"requirements.txt":  true,
+}
+
+// skippedDirs hold vendored or installed copies of dependencies, whose
+// manifests are not the project's own.
+var skippedDirs = []string{"node_modules", "vendor", ".git"}
+
+// isManifest reports whether a repo-relative path is a project manifest.
+func isManifest(p string) bool {
+	if !manifestNames[path.Base(p)] {
+		return false
+	}
+	for _, part := range strings.Split(path.Dir(p), "/") {
+		for _, skip := range skippedDirs {
+			if part == skip {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Scan parses every manifest among paths. A package.json next to a
+// package-lock.json is ignored in favour of the lockfile, which pins
+// versions and records licenses and registries.
+func Scan(paths []string, read ReadFunc) ([]Dependency, error) {
+	var manifests []string
+	lockDirs := make(map[string]bool)
+	for _, p := range paths {
+		p = filepath.ToSlash(p)
+		if !isManifest(p) {
+			continue
+		}
+		manifests = append(manifests, p)
+		if path.Base(p) == "package-lock.json" {
+			lockDirs[path.Dir(p)] = true
+		}
+	}
+	sort.Strings(manifests)
+
+	var deps []Dependency
+	for _, m := range manifests {
+		if path.Base(m) == "package.json" && lockDirs[path.Dir(m)] {
+			continue
+		}
+		data, err := read(m)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", m, err)
+		}
+		parsed, err := parseManifest(m, data)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", m, err)
+		}
+		deps = append(deps, parsed...)
+	}
+	return deps, nil
+}
+
+func parseManifest(name string, data []byte) ([]Dependency, error) {
+	switch path.Base(name) {
+	case "go.mod":
+		return parseGoMod(name, data), nil
+	case "package-lock.json":
+		return parsePackageLock(name, data)
+	case "package.json":
+		return parsePackageJSON(name, data)
+	case "requirements.txt":
+		return parseRequirements(name, data), nil
+	}
+	return nil, nil
+}
+
+// parseGoMod reads require directives, both single-line and block form.
+// The source is the host in the module path.
+func parseGoMod(name string, data []byte) []Dependency {
+	var deps []Dependency
+	inBlock := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		switch {
+		case line == "":
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case line == "require (":
+			inBlock = true
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "require "))
+		case !inBlock:
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		deps = append(deps, Dependency{
+			Ecosystem: EcosystemGo,
+			Name:      fields[0],
+			Version:   fields[1],
+			Source:    goModuleHost(fields[0]),
+			Manifest:  name,
+		})
+	}
+	return deps
+}
+
+func goModuleHost(module string) string {
+	host, _, _ := strings.Cut(module, "/")
+	if !strings.Contains(host, ".") {
+		return ""
+	}
+	return host
+}
+
+// parsePackageLock reads lockfile v2/v3 "packages" entries.
+func parsePackageLock(name string, data []byte) ([]Dependency, error) {
+	var lock struct {
+		Packages map[string]struct {
+			Version  string          `json:"version"`
+			Resolved string          `json:"resolved"`
+			License  json.RawMessage `json:"license"`
+			Link     bool            `json:"link"`
+		} `json:"packages"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+	var deps []Dependency
+	for key, pkg := range lock.Packages {
+		i := strings.LastIndex(key, "node_modules/")
+		if i < 0 || pkg.Link {
+			continue // The root project or a workspace link
+		}
+		deps = append(deps, Dependency{
+			Ecosystem: EcosystemNPM,
+			Name:      key[i+len("node_modules/"):],
+			Version:   pkg.Version,
+			License:   npmLicense(pkg.License),
+			Source:    urlHost(pkg.Resolved),
+			Manifest:  name,
+		})
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Name < deps[j].Name })
+	return deps, nil
+}
+
+// parsePackageJSON reads declared dependency ranges when no lockfile exists.
+func parsePackageJSON(name string, data []byte) ([]Dependency, error) {
+	var pkg struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, err
+	}
+	seen := make(map[string]string)
+	for _, group := range []map[string]string{pkg.Dependencies, pkg.DevDependencies, pkg.OptionalDependencies} {
+		for n, v := range group {
+			seen[n] = v
+		}
+	}
+	deps := make([]Dependency, 0, len(seen))
+	for n, v := range seen {
+		deps = append(deps, Dependency{Ecosystem: EcosystemNPM, Name: n, Version: v, Manifest: name})
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Name < deps[j].Name })
+	return deps, nil
+}
+
+// npmLicense accepts the string form and the deprecated {"type": ...} form.
+func npmLicense(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return normalizeDeclaredLicense(s)
+	}
+	var obj struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(raw, &obj) == nil {
+		return normalizeDeclaredLicense(obj.Type)
+	}
+	return ""
+}
+
+// normalizeDeclaredLicense drops values that are not SPDX expressions, such
+// as "SEE LICENSE IN LICENSE.txt".
+func normalizeDeclaredLicense(s string) string {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(strings.ToUpper(s), "SEE LICEN") {
+		return ""
+	}
+	return s
+}
+
+var requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(\[[^\]]*\])?\s*(?:(===|==|~=|>=|<=|!=|>|<)\s*([^\s;,]+))?`)
+
+// parseRequirements reads pip requirement lines. The source is the host of
+// --index-url (pypi.org by default) or of a direct "name @ URL" reference.
+func parseRequirements(name string, data []byte) []Dependency {
+	source := "pypi.org"
+	var deps []Dependency
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "-") {
+			if value, ok := cutOption(line, "--index-url", "-i"); ok {
+				source = urlHost(value)
+			}
+			continue
+		}
+		if pkg, ref, ok := strings.Cut(line, " @ "); ok {
+			deps = append(deps, Dependency{
+				Ecosystem: EcosystemPyPI,
+				Name:      normalizePyPIName(strings.TrimSpace(pkg)),
+				Version:   strings.TrimSpace(ref),
+				Source:    urlHost(strings.TrimSpace(ref)),
+				Manifest:  name,
+			})
+			continue
+		}
+		m := requirementPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		version := m[4]
+		if m[3] != "" && m[3] != "==" && m[3] != "===" {
+			version = m[3] + m[4]
+		}
+		deps = append(deps, Dependency{
+			Ecosystem: EcosystemPyPI,
+			Name:      normalizePyPIName(m[1]),
+			Version:   version,
+			Source:    source,
+			Manifest:  name,
+		})
+	}
+	return deps
+}
+
+// cutOption returns the value of "--opt value" or "--opt=value" for any of
+// the given option names.
+func cutOption(line string, names ...string) (string, bool) {
+	for _, n := range names {
+		if rest, ok := strings.CutPrefix(line, n); ok && (rest == "" || rest[0] == ' ' || rest[0] == '=') {
+			return strings.TrimSpace(strings.TrimLeft(rest, " =")), true
+		}
+	}
+	return "", false
+}
+
+func normalizePyPIName(n string) string {
+	return strings.ToLower(strings.NewReplacer("_", "-", ".", "-").Replace(n))
+}
+
+func urlHost(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// WorktreeFiles lists tracked and untracked (not ignored) files in the git
+// worktree at root.
+func WorktreeFiles(root string) ([]string, error) {
+	return gitLines(root, "ls-files", "-z", "--cached", "--others", "--exclude-standard")
+}
+
+// RefFiles lists the files in the tree of a git ref.
+func RefFiles(root, ref string) ([]string, error) {
+	return gitLines(root, "ls-tree", "-r", "-z", "--name-only", ref)
+}
+
+// ReadWorktree reads files from the checkout at root.
+func ReadWorktree(root string) ReadFunc {
+	return func(name string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+	}
+}
+
+// ReadRef reads files as they are in a git ref. Files that do not exist in
+// the ref report fs.ErrNotExist.
+func ReadRef(root, ref string) ReadFunc {
+	return func(name string) ([]byte, error) {
+		out, err := exec.Command("git", "-C", root, "show", ref+":"+name).Output()
+		if err != nil {
+			return nil, fmt.Errorf("git show %s:%s: %w", ref, name, fs.ErrNotExist)
+		}
+		return out, nil
+	}
+}
+
+func gitLines(root string, args ...string) ([]string, error) {
+	out, err := exec.Command("git", append([]string{"-C", root}, args...)...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	var lines []string
+	for _, l := range strings.Split(string(out), "\x00") {
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines, nil
+}
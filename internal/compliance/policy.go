@@ -0,0 +1,126 @@
+package compliance
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/randalmurphal/orc/internal/config"
+)
+
+// Policy rules reported in Finding.Rule.
+const (
+	RuleLicense  = "license"
+	RuleRegistry = "registry"
+)
+
+// Finding is a dependency that breaks, or could not be checked against, the
+// compliance policy.
+type Finding struct {
+	Dependency Dependency `json:"dependency"`
+	Rule       string     `json:"rule"`
+	Reason     string     `json:"reason"`
+}
+
+// Report is the outcome of checking a delta. Violations block; warnings are
+// recorded only.
+type Report struct {
+	Checked    int       `json:"checked"`
+	Violations []Finding `json:"violations"`
+	Warnings   []Finding `json:"warnings"`
+}
+
+// Passed reports whether no dependency violates the policy.
+func (r *Report) Passed() bool {
+	return len(r.Violations) == 0
+}
+
+// Evaluate checks every dependency the delta adds or updates against cfg.
+func Evaluate(delta *Delta, cfg config.ComplianceConfig) *Report {
+	report := &Report{Violations: []Finding{}, Warnings: []Finding{}}
+	for _, d := range delta.Introduced() {
+		report.Checked++
+		if f, blocking, ok := checkLicense(d, cfg); ok {
+			if blocking {
+				report.Violations = append(report.Violations, f)
+			} else {
+				report.Warnings = append(report.Warnings, f)
+			}
+		}
+		if f, ok := checkRegistry(d, cfg.ApprovedRegistries); ok {
+			report.Violations = append(report.Violations, f)
+		}
+	}
+	return report
+}
+
+// checkLicense returns a finding when d's license is unknown or not
+// acceptable, and whether that finding blocks.
+func checkLicense(d Dependency, cfg config.ComplianceConfig) (Finding, bool, bool) {
+	if d.License == "" {
+		blocking := cfg.UnknownLicense == config.ComplianceUnknownBlock
+		return Finding{Dependency: d, Rule: RuleLicense, Reason: "license could not be determined"}, blocking, true
+	}
+	expr := parseLicenseExpr(d.License)
+	if expr == nil {
+		blocking := cfg.UnknownLicense == config.ComplianceUnknownBlock
+		return Finding{Dependency: d, Rule: RuleLicense, Reason: fmt.Sprintf("unrecognized license expression %q", d.License)}, blocking, true
+	}
+	if expr.satisfiedBy(func(id string) bool { return licenseAllowed(id, cfg) }) {
+		return Finding{}, false, false
+	}
+	reason := fmt.Sprintf("license %s is denied by compliance.denied_licenses", d.License)
+	if len(cfg.AllowedLicenses) > 0 {
+		reason = fmt.Sprintf("license %s is not in compliance.allowed_licenses", d.License)
+	}
+	return Finding{Dependency: d, Rule: RuleLicense, Reason: reason}, true, true
+}
+
+func licenseAllowed(id string, cfg config.ComplianceConfig) bool {
+	match := func(candidate string) bool { return licenseIDsEqual(candidate, id) }
+	if slices.ContainsFunc(cfg.DeniedLicenses, match) {
+		return false
+	}
+	return len(cfg.AllowedLicenses) == 0 || slices.ContainsFunc(cfg.AllowedLicenses, match)
+}
+
+// checkRegistry returns a finding when d comes from a host that is not
+// approved. An approved host also covers its subdomains. Dependencies with
+// an unknown source are not checked.
+func checkRegistry(d Dependency, approved []string) (Finding, bool) {
+	if len(approved) == 0 || d.Source == "" {
+		return Finding{}, false
+	}
+	host := strings.ToLower(d.Source)
+	for _, a := range approved {
+		a = strings.ToLower(a)
+		if host == a || strings.HasSuffix(host, "."+a) {
+			return Finding{}, false
+		}
+	}
+	return Finding{Dependency: d, Rule: RuleRegistry, Reason: fmt.Sprintf("source %s is not in compliance.approved_registries", d.Source)}, true
+}
+
+// FormatReport renders a report and its delta as markdown for phase output.
+func FormatReport(report *Report, delta *Delta) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Dependency compliance\n\n%d added, %d updated, %d removed; %d checked.\n",
+		len(delta.Added), len(delta.Updated), len(delta.Removed), report.Checked)
+	writeFindings(&sb, "Violations", report.Violations)
+	writeFindings(&sb, "Warnings", report.Warnings)
+	if report.Passed() {
+		sb.WriteString("\nNo policy violations.\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func writeFindings(sb *strings.Builder, title string, findings []Finding) {
+	if len(findings) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "\n### %s\n\n", title)
+	for _, f := range findings {
+		d := f.Dependency
+		fmt.Fprintf(sb, "- `%s` %s (%s, %s): %s\n", d.Name, d.Version, d.Ecosystem, d.Manifest, f.Reason)
+	}
+}
@@ -0,0 +1,102 @@
+package compliance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SBOMFormat identifies the JSON layout of SBOM.
+const SBOMFormat = "orc-sbom/v1"
+
+// Component is one dependency in a project SBOM, with the tasks whose
+// recorded deltas brought it in at its current version.
+type Component struct {
+	Dependency
+	IntroducedBy []string `json:"introduced_by,omitempty"`
+}
+
+// SBOM is the software bill of materials of a project at a commit.
+type SBOM struct {
+	Format      string      `json:"format"`
+	Ref         string      `json:"ref"`
+	GeneratedAt time.Time   `json:"generated_at"`
+	Components  []Component `json:"components"`
+}
+
+// ProjectSBOM lists the dependencies declared at ref in the repository at
+// root, resolving licenses the manifests do not record.
+func ProjectSBOM(root, ref string) (*SBOM, error) {
+	paths, err := RefFiles(root, ref)
+	if err != nil {
+		return nil, fmt.Errorf("list files at %s: %w", ref, err)
+	}
+	deps, err := Scan(paths, ReadRef(root, ref))
+	if err != nil {
+		return nil, fmt.Errorf("scan %s: %w", ref, err)
+	}
+	sortDeps(deps)
+	ptrs := make([]*Dependency, len(deps))
+	for i := range deps {
+		ptrs[i] = &deps[i]
+	}
+	ResolveLicenses(root, ptrs)
+
+	sbom := &SBOM{Format: SBOMFormat, Ref: ref, GeneratedAt: time.Now().UTC(), Components: make([]Component, len(deps))}
+	for i, d := range deps {
+		sbom.Components[i] = Component{Dependency: d}
+	}
+	return sbom, nil
+}
+
+// Attribute records taskID against every component the delta added or
+// updated to the version the SBOM still contains.
+func (s *SBOM) Attribute(taskID string, delta *Delta) {
+	introduced := make(map[string]string)
+	for _, d := range delta.Introduced() {
+		introduced[d.key()] = d.Version
+	}
+	for i := range s.Components {
+		c := &s.Components[i]
+		if v, ok := introduced[c.key()]; ok && v == c.Version {
+			c.IntroducedBy = append(c.IntroducedBy, taskID)
+		}
+	}
+}
+
+// FormatDeltaSummary renders a delta as a markdown section for a PR body.
+// It returns "" when the delta touches no dependencies.
+func FormatDeltaSummary(delta *Delta) string {
+	if delta == nil || delta.Empty() {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Dependency changes\n\n%d added, %d updated, %d removed.\n",
+		len(delta.Added), len(delta.Updated), len(delta.Removed))
+	if len(delta.Added) > 0 {
+		sb.WriteString("\n**Added**\n")
+		for _, d := range delta.Added {
+			fmt.Fprintf(&sb, "- `%s` %s (%s)\n", d.Name, d.Version, licenseLabel(d.License))
+		}
+	}
+	if len(delta.Updated) > 0 {
+		sb.WriteString("\n**Updated**\n")
+		for _, u := range delta.Updated {
+			fmt.Fprintf(&sb, "- `%s` %s → %s (%s)\n", u.Name, u.PreviousVersion, u.Version, licenseLabel(u.License))
+		}
+	}
+	if len(delta.Removed) > 0 {
+		sb.WriteString("\n**Removed**\n")
+		for _, d := range delta.Removed {
+			fmt.Fprintf(&sb, "- `%s` %s\n", d.Name, d.Version)
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func licenseLabel(license string) string {
+	if license == "" {
+		return "license unknown"
+	}
+	return license
+}
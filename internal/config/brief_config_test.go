@@ -5,7 +5,9 @@
 package config
 
 import (
+	"slices"
 	"testing"
+	"time"
 )
 
 // =============================================================================
@@ -26,6 +28,22 @@ func TestConfig_BriefSettings_Defaults(t *testing.T) {
 	if cfg.Brief.StaleThreshold != 3 {
 		t.Errorf("Brief.StaleThreshold default = %d, want 3", cfg.Brief.StaleThreshold)
 	}
+
+	related := cfg.Brief.RelatedTasks
+	if !related.Enabled || related.Limit != 5 || related.MaxAge != 30*24*time.Hour {
+		t.Errorf("Brief.RelatedTasks defaults = %+v", related)
+	}
+	if !slices.Equal(related.Phases, []string{"spec", "tiny_spec", "implement"}) {
+		t.Errorf("Brief.RelatedTasks.Phases default = %v", related.Phases)
+	}
+
+	lessons := cfg.Brief.Lessons
+	if !lessons.Enabled || lessons.MinOccurrences != 2 || lessons.MaxBytes != 4096 || lessons.MaxAge != 90*24*time.Hour {
+		t.Errorf("Brief.Lessons defaults = %+v", lessons)
+	}
+	if !slices.Equal(lessons.Phases, []string{"implement", "review"}) {
+		t.Errorf("Brief.Lessons.Phases default = %v", lessons.Phases)
+	}
 }
 
 func TestConfig_BriefSettings_GetValue(t *testing.T) {
@@ -20,6 +20,9 @@ type Config struct {
 	// Automation profile (auto, fast, safe, strict)
 	Profile AutomationProfile `yaml:"profile"`
 
+	// Locale for CLI output, error messages and notifications (e.g. en, de)
+	Locale string `yaml:"locale"`
+
 	// Gate configuration
 	Gates GateConfig `yaml:"gates"`
 
@@ -84,12 +87,39 @@ type Config struct {
 	// Quality policy configuration
 	QualityPolicy QualityPolicyConfig `yaml:"quality_policy"`
 
+	// Voting configuration for running critical phases several times
+	Voting VotingConfig `yaml:"voting"`
+
+	// Binary file policy for task changes
+	BinaryFiles BinaryFilesConfig `yaml:"binary_files"`
+
+	// How phase quality checks run (concurrency, default timeout)
+	QualityChecks QualityChecksConfig `yaml:"quality_checks"`
+
+	// Paths the executor must never modify
+	SensitivePaths SensitivePathsConfig `yaml:"sensitive_paths"`
+
+	// License and dependency-source policy for compliance phases
+	Compliance ComplianceConfig `yaml:"compliance"`
+
+	// Behavior-preservation checks for refactor tasks
+	RefactorSafety RefactorSafetyConfig `yaml:"refactor_safety"`
+
 	// Weights configuration - maps task weights to workflow IDs
 	Weights WeightsConfig `yaml:"weights"`
 
 	// Workflow defaults - maps task categories to workflow IDs
 	WorkflowDefaults WorkflowDefaults `yaml:"workflow_defaults"`
 
+	// PromptVariants maps task categories to prompt variants. A phase of a
+	// task in a mapped category uses <prompt>.<variant>.md when one exists.
+	PromptVariants map[string]string `yaml:"prompt_variants,omitempty"`
+
+	// PhaseModels maps workflow IDs to per-phase default models, e.g.
+	// {implement-medium: {spec: opus, implement: sonnet}}. The "*" key
+	// applies to every workflow. A task's own phase_models take precedence.
+	PhaseModels map[string]map[string]string `yaml:"phase_models,omitempty"`
+
 	// Artifact skip configuration
 	ArtifactSkip ArtifactSkipConfig `yaml:"artifact_skip"`
 
@@ -129,6 +159,23 @@ type Config struct {
 	// Automation configuration for triggers and templates
 	Automation AutomationConfig `yaml:"automation"`
 
+	// Skills index configuration for `orc skills install`
+	Skills SkillsConfig `yaml:"skills"`
+
+	// Updates configuration for `orc self-update` and the startup version check
+	Updates UpdatesConfig `yaml:"updates"`
+
+	// Backoff configures retries of external calls (hosting APIs, CI
+	// polling, webhooks, model APIs)
+	Backoff BackoffConfig `yaml:"backoff"`
+
+	// Webhooks configures POST notifications for task lifecycle events
+	Webhooks WebhooksConfig `yaml:"webhooks"`
+
+	// FeatureFlags overrides runtime feature flags by name (see Features).
+	// Unset features follow the settings they derive from.
+	FeatureFlags map[string]bool `yaml:"features,omitempty"`
+
 	// Provider is the default LLM provider for all phases (default: "claude")
 	// Supported: "claude", "codex"
 	Provider string `yaml:"provider,omitempty"`
@@ -147,11 +194,19 @@ type Config struct {
 	// Git settings
 	BranchPrefix string `yaml:"branch_prefix"`
 	CommitPrefix string `yaml:"commit_prefix"`
+	// GitIdentity overrides the author/committer of commits orc and its agents make
+	GitIdentity GitIdentityConfig `yaml:"git_identity"`
+	// GitHooks selects project git hooks skipped when orc and its agents commit
+	GitHooks GitHooksConfig `yaml:"git_hooks"`
 
 	// Claude CLI settings
 	ClaudePath                 string `yaml:"claude_path"`
 	CodexPath                  string `yaml:"codex_path,omitempty"`
 	DangerouslySkipPermissions bool   `yaml:"dangerously_skip_permissions"`
+	// FakeModel is a directory of fixture files that answer every phase and
+	// AI gate instead of a provider, for tests and CI. Relative paths are
+	// resolved against the project root. Empty uses the real providers.
+	FakeModel string `yaml:"fake_model,omitempty"`
 
 	// Template paths
 	TemplatesDir string `yaml:"templates_dir"`
@@ -2,7 +2,7 @@ package config
 
 // AutomationEnabled returns true if automation is enabled.
 func (c *Config) AutomationEnabled() bool {
-	return c.Automation.Enabled
+	return c.FeatureEnabled("automation")
 }
 
 // GetTriggerMode returns the effective execution mode for a trigger.
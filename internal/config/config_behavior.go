@@ -40,7 +40,9 @@ func (c *Config) ApplyProfile(profile AutomationProfile) {
 	c.Gates = ProfilePresets(profile)
 	c.Completion.Finalize = FinalizePresets(profile)
 	c.Completion.PR.AutoApprove = PRAutoApprovePreset(profile)
+	confidence, traceability := c.Validation.Confidence, c.Validation.Traceability
 	c.Validation = ValidationPresets(profile)
+	c.Validation.Confidence, c.Validation.Traceability = confidence, traceability
 }
 
 // ExecutorPrefix returns the prefix for branch/worktree naming based on mode.
@@ -101,6 +103,34 @@ func (c *Config) workflowDefaultsMatchBuiltins() bool {
 	return c.WorkflowDefaults == builtins
 }
 
+// PromptVariant returns the prompt variant for a task category, or "" when
+// tasks of that category use the base prompts. "bugfix" is accepted as an
+// alias for the bug category.
+func (c *Config) PromptVariant(category string) string {
+	if c == nil {
+		return ""
+	}
+	if category == "bugfix" {
+		category = "bug"
+	}
+	return c.PromptVariants[category]
+}
+
+// AllWorkflowsKey is the phase_models key that applies to every workflow.
+const AllWorkflowsKey = "*"
+
+// PhaseModel returns the configured default model for a phase of a workflow,
+// falling back to the "*" (every workflow) entry, or "" when none is set.
+func (c *Config) PhaseModel(workflowID, phaseID string) string {
+	if c == nil {
+		return ""
+	}
+	if model := c.PhaseModels[workflowID][phaseID]; model != "" {
+		return model
+	}
+	return c.PhaseModels[AllWorkflowsKey][phaseID]
+}
+
 // IsTeamMode returns true if orc is configured for team mode (shared database).
 func (c *Config) IsTeamMode() bool {
 	return c.Database.Driver == "postgres" || c.Team.Mode == "shared_db"
@@ -1,6 +1,11 @@
 package config
 
-import "time"
+import (
+	"time"
+
+	"github.com/randalmurphal/orc/internal/i18n"
+	"github.com/randalmurphal/orc/internal/retry"
+)
 
 // Default returns the default configuration.
 // Default is AUTOMATION-FIRST: all gates auto, retry enabled.
@@ -8,12 +13,17 @@ func Default() *Config {
 	return &Config{
 		Version: 1,
 		Profile: ProfileAuto,
+		Locale:  i18n.DefaultLocale,
 		Gates: GateConfig{
 			DefaultType:          "auto",
 			AutoApproveOnSuccess: true,
 			RetryOnFailure:       true,
 			MaxRetries:           5,
 			// No phase or weight overrides by default - everything is auto
+			Timeout: GateTimeoutConfig{
+				Action:         GateTimeoutNotify,
+				ApproveWeights: []string{"trivial", "small"},
+			},
 		},
 		Retry: RetryConfig{
 			Enabled:    true,
@@ -23,20 +33,32 @@ func Default() *Config {
 			RetryMap: map[string]string{
 				"review": "implement",
 			},
+			Replan: ReplanConfig{
+				Enabled:    false,
+				MaxReplans: 1,
+				Gate:       "human",
+			},
 		},
 		Worktree: WorktreeConfig{
 			Enabled:           true,
 			Dir:               "", // Empty = use ~/.orc/worktrees/<project-id>/
 			CleanupOnComplete: true,
 			CleanupOnFail:     false, // Keep for debugging
+			Preflight: PreflightConfig{
+				Enabled:       true,
+				CheckRemote:   true,
+				MinFreeDiskMB: 1024,
+			},
 		},
 		Completion: CompletionConfig{
-			Action:        "pr",
-			TargetBranch:  "main",
-			DeleteBranch:  true,
-			WaitForCI:     false,            // Off by default — opt-in for auto CI polling
-			CITimeout:     10 * time.Minute, // 10 minute default timeout
-			MergeOnCIPass: false,            // Off by default — opt-in for auto merge
+			Action:                 "pr",
+			TargetBranch:           "main",
+			DeleteBranch:           true,
+			ProtectedBranches:      append([]string(nil), DefaultProtectedBranches...),
+			VerifyBranchProtection: true,
+			WaitForCI:              false,            // Off by default — opt-in for auto CI polling
+			CITimeout:              10 * time.Minute, // 10 minute default timeout
+			MergeOnCIPass:          false,            // Off by default — opt-in for auto merge
 			PR: PRConfig{
 				Title:               "[orc] {{TASK_TITLE}}",
 				BodyTemplate:        "templates/pr-body.md",
@@ -60,6 +82,15 @@ func Default() *Config {
 				MaxConflictFiles: 0,                      // No limit by default
 				SkipForWeights:   []string{"trivial"},    // Skip sync for trivial tasks
 			},
+			FollowUps: FollowUpsConfig{
+				Create: "none",
+			},
+			Release: ReleaseConfig{
+				Prefix:     "release/",
+				Backport:   "cherry-pick",
+				BackportTo: "main",
+				Workflow:   "implement-small",
+			},
 			Finalize: FinalizeConfig{
 				Enabled:               true,  // Finalize phase enabled by default
 				AutoTrigger:           true,  // Auto-trigger after validate
@@ -91,6 +122,8 @@ func Default() *Config {
 			MaxRetries:          5,  // Default retry limit for phase failures
 			ParallelTasks:       2,  // Default parallel tasks for UI
 			CostLimit:           25, // Default cost limit ($25/day) for UI
+			IncrementalContext:  true,
+			SelectiveRetryTests: true,
 		},
 		Pool: PoolConfig{
 			Enabled:    false, // Disabled by default
@@ -104,6 +137,26 @@ func Default() *Config {
 				Enabled: false,
 				Type:    "token",
 			},
+			ReadOnly: false,
+			AccessLog: AccessLogConfig{
+				Enabled:    true,
+				SampleRate: 1.0,
+			},
+			Tenancy: TenancyConfig{
+				Enabled: false,
+			},
+			HA: HAConfig{
+				Enabled:           false,
+				LeaseTTL:          15 * time.Second,
+				EventPollInterval: 500 * time.Millisecond,
+			},
+			Scheduler: SchedulerConfig{
+				MaxRunningTasks: 0,
+				Policy:          "round_robin",
+			},
+			Socket: SocketConfig{
+				Enabled: true,
+			},
 		},
 		Team: TeamConfig{
 			Name:            "",    // Auto-detected from username
@@ -141,6 +194,27 @@ func Default() *Config {
 			ValidateSpecs:    true,                // Haiku validates spec quality
 			ValidateCriteria: true,                // Haiku validates success criteria on completion
 			FailOnAPIError:   true,                // Fail properly on API errors (resumable)
+			Confidence: ConfidenceConfig{
+				Enabled:       false,
+				EscalateBelow: 0.6,
+			},
+			Traceability: TraceabilityConfig{
+				Enabled: false,
+				Phases:  []string{"implement", "review"},
+			},
+		},
+		Updates: UpdatesConfig{
+			Check: true,
+		},
+		Backoff: BackoffConfig{
+			MaxAttempts: retry.DefaultMaxAttempts,
+			Initial:     retry.DefaultInitial,
+			Max:         retry.DefaultMax,
+			Multiplier:  retry.DefaultMultiplier,
+			Jitter:      retry.DefaultJitter,
+		},
+		Webhooks: WebhooksConfig{
+			Timeout: 10 * time.Second,
 		},
 		Documentation: DocumentationConfig{
 			Enabled:            true,
@@ -148,6 +222,11 @@ func Default() *Config {
 			UpdateOn:           []string{"feature", "api_change"},
 			SkipForWeights:     []string{"trivial"},
 			Sections:           []string{"api-endpoints", "commands", "config-options"},
+			DriftCheck: DocDriftConfig{
+				Enabled:   true,
+				Interval:  6 * time.Hour,
+				Threshold: 5,
+			},
 		},
 		Timeouts: TimeoutsConfig{
 			PhaseMax:          60 * time.Minute,
@@ -166,6 +245,11 @@ func Default() *Config {
 			Enabled:     true,
 			Rounds:      2,
 			RequirePass: true,
+			Chunking: ReviewChunkingConfig{
+				Enabled:  true,
+				MaxLines: 1500,
+				MaxFiles: 40,
+			},
 		},
 		Plan: PlanConfig{
 			MinimumSections: []string{"intent", "success_criteria", "testing"},
@@ -178,6 +262,32 @@ func Default() *Config {
 			BrowserQAFromPlan:           true,
 			FailClosedOnMissingProvider: true,
 		},
+		Voting: VotingConfig{
+			Enabled:    false,
+			Candidates: 3,
+			Phases:     []string{"design", "implement"},
+			Weights:    []string{"large"},
+			Priorities: []string{"critical"},
+			JudgeModel: "opus",
+			AllowMerge: true,
+		},
+		BinaryFiles: BinaryFilesConfig{
+			Policy:    BinaryPolicyAllow,
+			MaxSizeKB: 5120,
+		},
+		QualityChecks: QualityChecksConfig{
+			Parallelism: 4,
+			Timeout:     2 * time.Minute,
+		},
+		Compliance: ComplianceConfig{
+			DeniedLicenses: []string{"AGPL-3.0", "GPL-2.0", "GPL-3.0", "SSPL-1.0"},
+			UnknownLicense: ComplianceUnknownWarn,
+			SBOM:           true,
+		},
+		RefactorSafety: RefactorSafetyConfig{
+			Enabled: true,
+			API:     true,
+		},
 		Weights: WeightsConfig{
 			Trivial: "implement-trivial",
 			Small:   "implement-small",
@@ -193,6 +303,9 @@ func Default() *Config {
 			Test:     "crossmodel-standard",
 			Default:  "crossmodel-standard",
 		},
+		PromptVariants: map[string]string{
+			"bug": "bugfix",
+		},
 		ArtifactSkip: ArtifactSkipConfig{
 			Enabled:  true,                                 // Check for existing artifacts
 			AutoSkip: false,                                // Prompt user by default
@@ -205,6 +318,11 @@ func Default() *Config {
 		},
 		Tasks: TasksConfig{
 			DisableAutoCommit: false, // Auto-commit enabled by default
+			Stale: StaleTasksConfig{
+				After:         14 * 24 * time.Hour, // Two weeks untouched
+				CheckInterval: time.Hour,
+				Action:        StaleActionFlag,
+			},
 		},
 		Diagnostics: DiagnosticsConfig{
 			ResourceTracking: ResourceTrackingConfig{
@@ -216,6 +334,19 @@ func Default() *Config {
 		Brief: BriefConfig{
 			MaxTokens:      3000,
 			StaleThreshold: 3,
+			RelatedTasks: RelatedTasksConfig{
+				Enabled: true,
+				Limit:   5,
+				MaxAge:  30 * 24 * time.Hour,
+				Phases:  []string{"spec", "tiny_spec", "implement"},
+			},
+			Lessons: LessonsConfig{
+				Enabled:        true,
+				MinOccurrences: 2,
+				MaxBytes:       4096,
+				MaxAge:         90 * 24 * time.Hour,
+				Phases:         []string{"implement", "review"},
+			},
 		},
 		MCP: MCPConfig{
 			Playwright: PlaywrightConfig{
@@ -245,13 +376,15 @@ func Default() *Config {
 			},
 		},
 		Storage: StorageConfig{
-			Mode: StorageModeHybrid, // Best of both worlds for solo devs
+			Mode: StorageModeDatabase, // No task files unless files/hybrid is chosen
 			Files: FileStorageConfig{
-				CleanupOnComplete: true, // Keep .orc/tasks/ clean
+				CleanupOnComplete: true,  // Keep .orc/tasks/ clean
+				WriteThrough:      false, // Task directories are opt-in
 			},
 			Database: DatabaseStorageConfig{
-				CacheTranscripts: true, // FTS search enabled by default
-				RetentionDays:    90,   // Auto-cleanup old entries
+				CacheTranscripts:    true,           // FTS search enabled by default
+				RetentionDays:       90,             // Auto-cleanup old entries
+				MaintenanceInterval: 24 * time.Hour, // Daily vacuum and integrity check
 			},
 			Export: ExportConfig{
 				Enabled:        false, // Nothing exported by default
@@ -1,6 +1,10 @@
 package config
 
-import "time"
+import (
+	"time"
+
+	"github.com/randalmurphal/orc/internal/retry"
+)
 
 // ShouldSyncForWeight returns true if sync should be performed for this weight.
 func (c *Config) ShouldSyncForWeight(weight string) bool {
@@ -71,6 +75,17 @@ func (c *Config) CIPollInterval() time.Duration {
 	return c.Completion.CI.PollInterval
 }
 
+// CIPollPolicy returns the pacing for CI status polls: they start at
+// backoff.initial and back off to the CI poll interval.
+func (c *Config) CIPollPolicy() retry.Policy {
+	p := c.RetryPolicy()
+	p.Max = c.CIPollInterval()
+	if p.Initial <= 0 || p.Initial > p.Max {
+		p.Initial = p.Max
+	}
+	return p
+}
+
 // MergeMethod returns the configured merge method, defaulting to "squash".
 func (c *Config) MergeMethod() string {
 	method := c.Completion.CI.MergeMethod
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -85,6 +86,9 @@ func IsInitializedAt(basePath string) bool {
 	return err == nil
 }
 
+// ErrNotInitialized is returned by RequireInit when there is no .orc directory.
+var ErrNotInitialized = errors.New("not an orc project")
+
 // RequireInit returns an error if orc is not initialized in the current directory.
 func RequireInit() error {
 	if envRoot := os.Getenv("ORC_PROJECT_ROOT"); envRoot != "" {
@@ -96,7 +100,7 @@ func RequireInit() error {
 // RequireInitAt returns an error if orc is not initialized at the specified base path.
 func RequireInitAt(basePath string) error {
 	if !IsInitializedAt(basePath) {
-		return fmt.Errorf("not an orc project (no %s directory). Run 'orc init' first", OrcDir)
+		return fmt.Errorf("%w (no %s directory). Run 'orc init' first", ErrNotInitialized, OrcDir)
 	}
 	return nil
 }
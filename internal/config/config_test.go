@@ -495,6 +495,13 @@ func TestSaveTo_InvalidPath(t *testing.T) {
 	}
 }
 
+func TestDefault_StorageMode(t *testing.T) {
+	cfg := Default()
+	if cfg.Storage.Mode != StorageModeDatabase {
+		t.Errorf("Storage.Mode = %s, want database", cfg.Storage.Mode)
+	}
+}
+
 func TestDefault_DatabaseConfig(t *testing.T) {
 	cfg := Default()
 
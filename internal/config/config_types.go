@@ -2,8 +2,12 @@ package config
 
 import (
 	"fmt"
+	"path"
+	"slices"
 	"strings"
 	"time"
+
+	"github.com/randalmurphal/orc/internal/retry"
 )
 
 // AutomationProfile defines preset automation configurations.
@@ -57,6 +61,63 @@ type GateConfig struct {
 
 	// MaxRetries - max times to retry a phase from previous phase (default: 2)
 	MaxRetries int `yaml:"max_retries"`
+
+	// Timeout escalates human gates left waiting for a decision
+	Timeout GateTimeoutConfig `yaml:"timeout"`
+}
+
+// Gate timeout actions (gates.timeout.action).
+const (
+	GateTimeoutNotify   = "notify"   // Raise a notification, again every timeout
+	GateTimeoutReassign = "reassign" // Hand the gate to reassign_to and notify them
+	GateTimeoutApprove  = "approve"  // Approve low-risk tasks; others are notified
+	GateTimeoutFail     = "fail"     // Reject the gate, failing the task
+)
+
+// GateTimeoutConfig defines what happens when a human gate waits longer than
+// its timeout. The executor waiting at the gate applies it.
+type GateTimeoutConfig struct {
+	// After is how long a human gate may wait before escalating (0 = never, default)
+	After time.Duration `yaml:"after"`
+	// Action is notify, reassign, approve, or fail (default: notify)
+	Action string `yaml:"action"`
+	// ReassignTo is who a reassigned gate is handed to
+	ReassignTo string `yaml:"reassign_to,omitempty"`
+	// ApproveWeights are the task weights the approve action may auto-approve
+	// (default: trivial, small). Gates of other tasks are notified instead.
+	ApproveWeights []string `yaml:"approve_weights,omitempty"`
+	// PhaseOverrides replaces after, action, or reassign_to per phase
+	// e.g., {"review": {"after": "4h", "action": "fail"}}
+	PhaseOverrides map[string]GateTimeoutOverride `yaml:"phase_overrides,omitempty"`
+}
+
+// GateTimeoutOverride is a per-phase gate timeout. Unset fields fall back to
+// gates.timeout.
+type GateTimeoutOverride struct {
+	After      time.Duration `yaml:"after,omitempty"`
+	Action     string        `yaml:"action,omitempty"`
+	ReassignTo string        `yaml:"reassign_to,omitempty"`
+}
+
+// ForPhase returns the gate timeout for phase, with its override applied.
+func (c GateTimeoutConfig) ForPhase(phase string) GateTimeoutConfig {
+	out := c
+	out.PhaseOverrides = nil
+	if o, ok := c.PhaseOverrides[phase]; ok {
+		if o.After != 0 {
+			out.After = o.After
+		}
+		if o.Action != "" {
+			out.Action = o.Action
+		}
+		if o.ReassignTo != "" {
+			out.ReassignTo = o.ReassignTo
+		}
+	}
+	if out.Action == "" {
+		out.Action = GateTimeoutNotify
+	}
+	return out
 }
 
 // RetryConfig defines cross-phase retry behavior.
@@ -70,6 +131,24 @@ type RetryConfig struct {
 
 	// MaxRetries per phase before giving up
 	MaxRetries int `yaml:"max_retries"`
+
+	// Replan configures the re-planning step taken when a phase exhausts its retries
+	Replan ReplanConfig `yaml:"replan"`
+}
+
+// ReplanConfig defines the re-planning step that runs when a phase has used up
+// its retries. Instead of failing, the executor asks the model to revise the
+// approach from the accumulated failure context and retries with the new plan.
+type ReplanConfig struct {
+	// Enabled turns on re-planning after retry exhaustion (default: false)
+	Enabled bool `yaml:"enabled"`
+
+	// MaxReplans is how many times a task may be re-planned (default: 1)
+	MaxReplans int `yaml:"max_replans"`
+
+	// Gate controls how a revised plan is accepted: "auto" retries with it
+	// immediately, "human" blocks the task until it is resumed (default: human)
+	Gate string `yaml:"gate"`
 }
 
 // WorktreeConfig defines worktree isolation settings.
@@ -85,6 +164,25 @@ type WorktreeConfig struct {
 
 	// CleanupOnFail removes worktree after failure (default: false for debugging)
 	CleanupOnFail bool `yaml:"cleanup_on_fail"`
+
+	// Preflight configures repository checks run before a task's worktree is set up
+	Preflight PreflightConfig `yaml:"preflight"`
+}
+
+// PreflightConfig defines the repository hygiene checks run before a task
+// starts, so a broken repo state fails fast instead of mid-phase.
+type PreflightConfig struct {
+	// Enabled runs the checks: no merge/rebase in progress in the main
+	// checkout, no uncommitted changes on a checked-out target branch, and
+	// no local target branch diverged from origin (default: true)
+	Enabled bool `yaml:"enabled"`
+
+	// CheckRemote requires origin, when configured, to be reachable (default: true)
+	CheckRemote bool `yaml:"check_remote"`
+
+	// MinFreeDiskMB is the free space required where worktrees are created
+	// (default: 1024). 0 disables the check.
+	MinFreeDiskMB int `yaml:"min_free_disk_mb"`
 }
 
 // PRConfig defines pull request settings.
@@ -141,7 +239,8 @@ type CIConfig struct {
 	// CITimeout is the maximum time to wait for CI checks to pass (default: 10m)
 	CITimeout time.Duration `yaml:"ci_timeout"`
 
-	// PollInterval is how often to check CI status (default: 30s)
+	// PollInterval is the longest wait between CI status checks. Checks
+	// start at backoff.initial and back off to this (default: 30s)
 	PollInterval time.Duration `yaml:"poll_interval"`
 
 	// MergeOnCIPass enables direct merge after CI passes (default: false)
@@ -285,12 +384,27 @@ type CompletionConfig struct {
 	// Action defines what happens on completion: "pr", "merge", "commit", "none" (default: "pr")
 	Action string `yaml:"action"`
 
+	// Provider selects the hosting provider that opens, polls, approves and
+	// merges the PR (GitHub pull request / GitLab merge request): "github",
+	// "gitlab", or "auto"/empty to use hosting.provider (detected from the
+	// git remote when unset). Self-managed GitLab also needs hosting.base_url.
+	Provider string `yaml:"provider,omitempty"`
+
 	// TargetBranch is the branch to merge into (default: "main")
 	TargetBranch string `yaml:"target_branch"`
 
 	// DeleteBranch deletes task branch after merge (default: true)
 	DeleteBranch bool `yaml:"delete_branch"`
 
+	// ProtectedBranches are branches orc never pushes or merges to directly
+	// (default: main, master, develop, release). Empty uses the defaults.
+	ProtectedBranches []string `yaml:"protected_branches,omitempty"`
+
+	// VerifyBranchProtection checks the hosting provider's branch protection
+	// (required reviews, status checks, push restrictions) before a direct
+	// merge and fails early when the push would be rejected (default: true)
+	VerifyBranchProtection bool `yaml:"verify_branch_protection"`
+
 	// WaitForCI waits for CI checks to pass before merging after finalize (default: false)
 	// When enabled, after finalize completes, orc will poll PR checks until they pass
 	// (or timeout), then merge the PR directly via the hosting provider API.
@@ -319,6 +433,51 @@ type CompletionConfig struct {
 	// WeightActions allows per-weight action overrides
 	// e.g., {"trivial": "merge", "small": "merge"} to skip PR for lightweight tasks
 	WeightActions map[string]string `yaml:"weight_actions,omitempty"`
+
+	// FollowUps controls what is created from deferred follow-up work
+	FollowUps FollowUpsConfig `yaml:"follow_ups"`
+
+	// Release controls how changes merged to release branches reach mainline
+	Release ReleaseConfig `yaml:"release"`
+}
+
+// ReleaseConfig controls release-branch hotfix flows. When a task targeting
+// a release branch is merged, orc creates a linked task that carries the
+// change back to BackportTo so the fix is not lost on the next release.
+type ReleaseConfig struct {
+	// Prefix identifies release branches (default: "release/"). Also used by
+	// `orc new --release <version>` to build the target branch.
+	Prefix string `yaml:"prefix"`
+
+	// Backport is "cherry-pick" (pick the task's commits onto BackportTo),
+	// "merge" (back-merge the release branch into BackportTo), or "none".
+	// Default: "cherry-pick"
+	Backport string `yaml:"backport"`
+
+	// BackportTo is the branch back-port tasks target (default: "main")
+	BackportTo string `yaml:"backport_to"`
+
+	// Workflow runs back-port tasks (default: "implement-small")
+	Workflow string `yaml:"workflow"`
+}
+
+// IsReleaseBranch reports whether branch is a release branch under
+// completion.release.prefix.
+func (c *Config) IsReleaseBranch(branch string) bool {
+	prefix := c.Completion.Release.Prefix
+	return prefix != "" && strings.HasPrefix(branch, prefix) && len(branch) > len(prefix)
+}
+
+// FollowUpsConfig controls follow-up work that agents defer during
+// implement and review. Follow-ups are always recorded on the task; Create
+// decides whether completion also turns them into tracked work.
+type FollowUpsConfig struct {
+	// Create is "none" (record only), "tasks" (pending orc tasks in the
+	// backlog), or "issues" (hosting provider issues). Default: "none"
+	Create string `yaml:"create"`
+
+	// Labels are applied to created issues
+	Labels []string `yaml:"labels,omitempty"`
 }
 
 // BudgetConfig defines cost budget settings.
@@ -365,6 +524,19 @@ type ExecutionConfig struct {
 	// CostLimit is the daily spending limit in dollars before pausing.
 	// Range: 0-100, default 25. Used by the UI for execution settings.
 	CostLimit int `yaml:"cost_limit"`
+
+	// IncrementalContext adds the diff of the previous iteration and a capped
+	// running summary of earlier iterations to implement-phase continuation
+	// prompts, so the agent does not re-read the files it changed.
+	// Default: true
+	IncrementalContext bool `yaml:"incremental_context"`
+
+	// SelectiveRetryTests narrows the tests quality check on retry
+	// iterations to the Go packages or Jest tests affected by files changed
+	// since the last run. The full suite still runs before the phase
+	// completes.
+	// Default: true
+	SelectiveRetryTests bool `yaml:"selective_retry_tests"`
 }
 
 // PoolConfig defines token pool settings for automatic account switching.
@@ -377,12 +549,18 @@ type PoolConfig struct {
 }
 
 // AuthConfig defines authentication settings for the server.
+// Tokens are issued with `orc server token` and sent as
+// "Authorization: Bearer <token>". /api/health stays public.
 type AuthConfig struct {
-	// Enabled enables authentication
+	// Enabled requires an API token on every mutating request (default: false)
 	Enabled bool `yaml:"enabled"`
 
-	// Type is the authentication type: "token" or "oidc"
+	// Type is the authentication type; only "token" is supported
 	Type string `yaml:"type"`
+
+	// RequireForReads also requires a token for reads and event streams.
+	// The static web UI is still served without one (default: false)
+	RequireForReads bool `yaml:"require_for_reads"`
 }
 
 // ServerConfig defines server configuration for team mode.
@@ -399,6 +577,96 @@ type ServerConfig struct {
 
 	// Auth configuration
 	Auth AuthConfig `yaml:"auth"`
+
+	// ReadOnly rejects every mutating request with 403 while keeping reads
+	// and event streams, for dashboard-only instances (default: false)
+	ReadOnly bool `yaml:"read_only"`
+
+	// AccessLog configures per-request HTTP access logging
+	AccessLog AccessLogConfig `yaml:"access_log"`
+
+	// Tenancy configures multi-tenant mode for shared deployments
+	Tenancy TenancyConfig `yaml:"tenancy"`
+
+	// HA configures running several server instances against one database
+	HA HAConfig `yaml:"ha"`
+
+	// Scheduler configures the shared run queue for tasks started through the server
+	Scheduler SchedulerConfig `yaml:"scheduler"`
+
+	// Socket configures the local JSON-RPC interface on a Unix domain socket
+	Socket SocketConfig `yaml:"socket"`
+}
+
+// SocketConfig defines the Unix-domain-socket JSON-RPC interface. It mirrors
+// the Connect services for editors and plugins on the same machine; the
+// socket is created mode 0600, so only the local user can connect.
+type SocketConfig struct {
+	// Enabled listens on the socket alongside the HTTP server (default: true)
+	Enabled bool `yaml:"enabled"`
+
+	// Path is the socket file (default: ~/.orc/orc.sock)
+	Path string `yaml:"path,omitempty"`
+}
+
+// AccessLogConfig defines HTTP access logging. Every request gets an
+// X-Request-ID (kept from the client when valid) that is echoed on the
+// response and attached to executor logs and events for runs it starts.
+type AccessLogConfig struct {
+	// Enabled logs method, path, status, size, and latency per request (default: true)
+	Enabled bool `yaml:"enabled"`
+
+	// SampleRate is the fraction of successful requests logged, 0.0-1.0
+	// (default: 1.0). Requests with status >= 400 are always logged.
+	SampleRate float64 `yaml:"sample_rate"`
+}
+
+// TenancyConfig defines multi-tenant server mode.
+// Tenants, their API tokens, quotas, and project ownership live in the
+// global database and are managed with `orc tenant`.
+type TenancyConfig struct {
+	// Enabled requires a tenant API token on every request and restricts each
+	// tenant to the projects it owns (default: false)
+	Enabled bool `yaml:"enabled"`
+}
+
+// HAConfig defines high-availability server mode.
+// Instances coordinate through the shared database (PostgreSQL when
+// database.dialect is "postgres", otherwise the global SQLite database):
+// one instance holds the leader lease and runs the background pollers, and
+// every instance relays the others' events to its own stream subscribers.
+type HAConfig struct {
+	// Enabled turns on leader election and cross-instance event relay (default: false)
+	Enabled bool `yaml:"enabled"`
+
+	// InstanceID identifies this instance in leases and events (default: hostname:pid)
+	InstanceID string `yaml:"instance_id,omitempty"`
+
+	// LeaseTTL is how long the leader lease lasts without renewal (default: 15s).
+	// The leader renews at a third of this; a standby takes over after it expires.
+	LeaseTTL time.Duration `yaml:"lease_ttl"`
+
+	// EventPollInterval is how often events from other instances are relayed (default: 500ms)
+	EventPollInterval time.Duration `yaml:"event_poll_interval"`
+}
+
+// SchedulerConfig defines how a shared server divides its run slots
+// between projects. Tasks started while every slot is busy wait in a
+// per-project queue; the policy picks which project runs next when a slot
+// frees up, so one project's burst cannot starve the others.
+type SchedulerConfig struct {
+	// MaxRunningTasks caps concurrently running tasks across all projects
+	// (default: 0 = unlimited, tasks start immediately)
+	MaxRunningTasks int `yaml:"max_running_tasks"`
+
+	// Policy chooses the next project to run: round_robin | weighted (default: round_robin)
+	// "round_robin" rotates through projects with queued tasks
+	// "weighted" gives each project a share of slots proportional to its weight
+	Policy string `yaml:"policy"`
+
+	// ProjectWeights maps project ID to its share under the weighted policy.
+	// Projects not listed have weight 1.
+	ProjectWeights map[string]int `yaml:"project_weights,omitempty"`
 }
 
 // TeamConfig defines organization/team settings.
@@ -408,7 +676,8 @@ type TeamConfig struct {
 	// Name is the organization name (defaults to username or "Personal")
 	Name string `yaml:"name,omitempty"`
 
-	// ActivityLogging enables activity log for all actions (default: true)
+	// ActivityLogging records every mutating API call, RPC, and CLI command
+	// in the project's audit log (default: true)
 	// Useful even for solo users as a history/audit trail
 	ActivityLogging bool `yaml:"activity_logging"`
 
@@ -515,6 +784,35 @@ type ValidationConfig struct {
 	// true (default): Fail the task properly (resumable) - quality over speed
 	// false: Fail open - continue execution without validation (legacy behavior)
 	FailOnAPIError bool `yaml:"fail_on_api_error"`
+
+	// Confidence configures confidence scoring of completed phases
+	Confidence ConfidenceConfig `yaml:"confidence"`
+
+	// Traceability configures spec-to-implementation traceability reports
+	Traceability TraceabilityConfig `yaml:"traceability"`
+}
+
+// TraceabilityConfig defines traceability reports. After each listed phase
+// completes, the validation model maps every success criterion of the
+// task's spec to the code and tests that satisfy it.
+type TraceabilityConfig struct {
+	// Enabled builds a report after each listed phase (default: false)
+	Enabled bool `yaml:"enabled"`
+
+	// Phases are the phases after which a report is built (default: implement, review)
+	Phases []string `yaml:"phases"`
+}
+
+// ConfidenceConfig defines confidence scoring for completed phases. The
+// validation model rates each phase's output and lists risk factors; a low
+// score escalates an auto gate to a human gate.
+type ConfidenceConfig struct {
+	// Enabled scores every completed phase (default: false)
+	Enabled bool `yaml:"enabled"`
+
+	// EscalateBelow is the score (0-1) under which an auto gate escalates
+	// to human approval (default: 0.6). Zero disables escalation.
+	EscalateBelow float64 `yaml:"escalate_below"`
 }
 
 // DocumentationConfig defines documentation phase configuration.
@@ -529,6 +827,26 @@ type DocumentationConfig struct {
 	SkipForWeights []string `yaml:"skip_for_weights,omitempty"`
 	// Sections specifies which auto-sections to maintain
 	Sections []string `yaml:"sections,omitempty"`
+	// DriftCheck configures the scheduled comparison of managed sections
+	// against the codebase
+	DriftCheck DocDriftConfig `yaml:"drift_check"`
+}
+
+// DocDriftConfig defines the scheduled CLAUDE.md drift check run by the API server.
+type DocDriftConfig struct {
+	// Enabled runs the check in the background while the server is up (default: true)
+	Enabled bool `yaml:"enabled"`
+	// Interval between checks (default: 6h)
+	Interval time.Duration `yaml:"interval"`
+	// Threshold is the drift score (stale + undocumented entries) at which a
+	// refresh task is created (default: 5)
+	Threshold int `yaml:"threshold"`
+	// CreateTask creates an automation task to refresh the docs when the
+	// threshold is reached (default: false)
+	CreateTask bool `yaml:"create_task"`
+	// Template names an automation template for the refresh task.
+	// Empty uses the built-in docs refresh template.
+	Template string `yaml:"template,omitempty"`
 }
 
 // TimeoutsConfig defines timeout settings for phases.
@@ -546,6 +864,30 @@ type TimeoutsConfig struct {
 	// IdleTimeout is the duration after which to warn about no streaming activity (default: 2m)
 	// This helps detect stuck API calls before the turn timeout.
 	IdleTimeout time.Duration `yaml:"idle_timeout"`
+	// TaskMax is the wall-clock limit for one run of a whole task, keyed by
+	// weight (trivial, small, medium, large), workflow ID, or "default"
+	// (empty = unlimited). On expiry the task is paused, a progress summary
+	// is recorded, and a notification asks a human whether to resume.
+	TaskMax map[string]time.Duration `yaml:"task_max,omitempty"`
+}
+
+// TaskMaxFor returns the whole-task wall-clock limit for a workflow: an
+// entry for the workflow ID wins, then the weight mapped to that workflow
+// (see WeightsConfig), then "default". Zero means unlimited.
+func (c *Config) TaskMaxFor(workflowID string) time.Duration {
+	limits := c.Timeouts.TaskMax
+	if len(limits) == 0 {
+		return 0
+	}
+	if d, ok := limits[workflowID]; ok {
+		return d
+	}
+	for _, weight := range []string{"trivial", "small", "medium", "large"} {
+		if d, ok := limits[weight]; ok && c.Weights.GetWorkflowID(weight) == workflowID {
+			return d
+		}
+	}
+	return limits["default"]
 }
 
 // QAConfig defines QA session configuration.
@@ -568,6 +910,22 @@ type ReviewConfig struct {
 	Rounds int `yaml:"rounds"`
 	// RequirePass requires review to pass before continuing (default: true)
 	RequirePass bool `yaml:"require_pass"`
+	// Chunking splits the review of a large diff into smaller reviews
+	Chunking ReviewChunkingConfig `yaml:"chunking"`
+}
+
+// ReviewChunkingConfig defines how large diffs are split for review.
+// A diff over either limit is reviewed one chunk at a time, with files
+// grouped by directory, and the chunk findings are merged.
+type ReviewChunkingConfig struct {
+	// Enabled splits large diffs into chunks (default: true)
+	Enabled bool `yaml:"enabled"`
+	// MaxLines is the changed lines (additions + deletions) per chunk (default: 1500)
+	MaxLines int `yaml:"max_lines"`
+	// MaxFiles is the changed files per chunk (default: 40)
+	MaxFiles int `yaml:"max_files"`
+	// Parallel reviews chunks concurrently instead of one after another (default: false)
+	Parallel bool `yaml:"parallel"`
 }
 
 // PlanConfig defines spec content validation configuration.
@@ -597,6 +955,247 @@ type QualityPolicyConfig struct {
 	FailClosedOnMissingProvider bool `yaml:"fail_closed_on_missing_provider"`
 }
 
+// VotingConfig defines multi-answer voting for critical phases. A voting
+// phase runs several candidates in parallel, each in its own worktree and
+// branch, and a judge model picks (or merges) the best one.
+type VotingConfig struct {
+	// Enabled turns on voting mode (default: false)
+	Enabled bool `yaml:"enabled"`
+	// Candidates is how many attempts run in parallel (default: 3, range: 2-5)
+	Candidates int `yaml:"candidates"`
+	// Phases lists the phases that vote (default: [design, implement])
+	Phases []string `yaml:"phases,omitempty"`
+	// Weights lists the task weights or workflow IDs that vote (default: [large])
+	Weights []string `yaml:"weights,omitempty"`
+	// Priorities lists the task priorities that vote regardless of weight (default: [critical])
+	Priorities []string `yaml:"priorities,omitempty"`
+	// JudgeModel is the model that compares candidates against the spec (default: opus)
+	JudgeModel string `yaml:"judge_model"`
+	// AllowMerge lets the judge ask for one follow-up pass on the winner that
+	// folds in strengths of the other candidates (default: true)
+	AllowMerge bool `yaml:"allow_merge"`
+}
+
+// Binary file policies (binary_files.policy).
+const (
+	BinaryPolicyAllow = "allow" // Commit binaries up to max_size_kb
+	BinaryPolicyBlock = "block" // Never commit binaries
+	BinaryPolicyLFS   = "lfs"   // Binaries must be tracked by Git LFS
+)
+
+// GitIdentityConfig sets the author and committer of commits orc makes —
+// checkpoints, auto-commits, merges — and of commits agents make inside task
+// worktrees, independently of the user's own git config. Both fields must be
+// set together; when unset, git's normal identity resolution applies.
+type GitIdentityConfig struct {
+	// Name is the author/committer name, e.g. "orc-bot" (default: unset)
+	Name string `yaml:"name"`
+	// Email is the author/committer email, e.g. "bot@company.com" (default: unset)
+	Email string `yaml:"email"`
+}
+
+// IsSet reports whether an identity override is configured.
+func (g GitIdentityConfig) IsSet() bool {
+	return g.Name != "" && g.Email != ""
+}
+
+// Env returns the GIT_AUTHOR_* and GIT_COMMITTER_* variables that apply the
+// identity to a git process, or nil when no identity is configured.
+func (g GitIdentityConfig) Env() map[string]string {
+	if !g.IsSet() {
+		return nil
+	}
+	return map[string]string{
+		"GIT_AUTHOR_NAME":     g.Name,
+		"GIT_AUTHOR_EMAIL":    g.Email,
+		"GIT_COMMITTER_NAME":  g.Name,
+		"GIT_COMMITTER_EMAIL": g.Email,
+	}
+}
+
+// GitHooksConfig selects project git hooks that are skipped when orc commits,
+// merges, rebases, and pushes, and when agents commit in task worktrees, for
+// repos whose hooks are slow or interactive. Every other hook still runs.
+// Commits orc makes name the hooks skipped for them in an Orc-Hooks-Bypassed
+// trailer.
+type GitHooksConfig struct {
+	// Bypass lists hooks to skip, e.g. [pre-commit, commit-msg], or [all]
+	// (default: none)
+	Bypass []string `yaml:"bypass"`
+	// Approved lists hooks that still run when Bypass is [all] (default: none)
+	Approved []string `yaml:"approved"`
+}
+
+// BypassedHooks resolves Bypass and Approved to the hook names to skip.
+func (h GitHooksConfig) BypassedHooks() []string {
+	if !slices.Contains(h.Bypass, "all") {
+		return h.Bypass
+	}
+	var hooks []string
+	for _, hook := range ValidGitHooks {
+		if !slices.Contains(h.Approved, hook) {
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks
+}
+
+// BinaryFilesConfig defines how binary files a task adds or changes are
+// treated. Violations fail the phase's quality checks, so the agent removes
+// the file (or moves it to LFS) before anything is committed.
+type BinaryFilesConfig struct {
+	// Policy is allow, block, or lfs (default: allow)
+	Policy string `yaml:"policy"`
+	// MaxSizeKB caps each binary file committed outside Git LFS (default: 5120).
+	// 0 disables the cap.
+	MaxSizeKB int `yaml:"max_size_kb"`
+	// Allow lists glob patterns of binary files exempt from the policy,
+	// e.g. "docs/images/*.png" (default: none)
+	Allow []string `yaml:"allow,omitempty"`
+}
+
+// QualityChecksConfig controls how a phase's quality checks run. The checks
+// (lint, typecheck, tests, ...) are independent commands, so they run
+// concurrently and are combined into one result that fails if any blocking
+// check fails.
+type QualityChecksConfig struct {
+	// Parallelism is how many checks run at once (default: 4). 1 runs them
+	// one after another.
+	Parallelism int `yaml:"parallelism"`
+	// Timeout limits each check that sets no timeout_ms of its own
+	// (default: 2m)
+	Timeout time.Duration `yaml:"timeout"`
+	// Hermetic isolates check commands from the machine running orc
+	Hermetic HermeticChecksConfig `yaml:"hermetic"`
+}
+
+// HermeticChecksConfig runs quality check commands in an isolated
+// environment so state on the developer's machine does not leak into
+// results. Each command gets a fresh HOME and TMPDIR, only allowlisted
+// environment variables, and tool caches (Go, npm, yarn, pip, ...) under
+// .orc/cache/ in the project.
+type HermeticChecksConfig struct {
+	// Enabled turns on hermetic check environments (default: false)
+	Enabled bool `yaml:"enabled"`
+	// Env lists environment variables passed through to checks in addition
+	// to PATH, LANG, LC_ALL, TERM, TZ and USER (e.g. GOPROXY, GOFLAGS).
+	Env []string `yaml:"env,omitempty"`
+}
+
+// SensitivePathsConfig lists paths the executor must never modify. Task
+// changes are checked by diff inspection before orc commits, so the guard
+// holds regardless of which tools the agent is allowed to use.
+type SensitivePathsConfig struct {
+	// Deny lists path patterns: "dir/" matches everything below dir,
+	// patterns containing a slash match the full repo-relative path, and
+	// others match the base name at any depth, e.g. ".github/workflows/",
+	// "infra/", ".env*", "*.pem" (default: none)
+	Deny []string `yaml:"deny,omitempty"`
+	// Allow exempts paths that match Deny, e.g. ".github/workflows/docs.yml"
+	Allow []string `yaml:"allow,omitempty"`
+}
+
+// DeniedBy returns the Deny pattern that protects p (a slash-separated,
+// repo-relative path), or "" when p may be modified.
+func (c SensitivePathsConfig) DeniedBy(p string) string {
+	for _, pattern := range c.Allow {
+		if matchPathPattern(pattern, p) {
+			return ""
+		}
+	}
+	for _, pattern := range c.Deny {
+		if matchPathPattern(pattern, p) {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// matchPathPattern matches p against a sensitive_paths pattern. A trailing
+// "/" or "/**" matches the directory and everything below it.
+func matchPathPattern(pattern, p string) bool {
+	if dir, ok := strings.CutSuffix(pattern, "/**"); ok {
+		pattern = dir + "/"
+	}
+	if dir, ok := strings.CutSuffix(pattern, "/"); ok {
+		for ancestor := path.Dir(p); ancestor != "."; ancestor = path.Dir(ancestor) {
+			if matched, _ := path.Match(dir, ancestor); matched {
+				return true
+			}
+		}
+		return false
+	}
+	if strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, p)
+		return matched
+	}
+	matched, _ := path.Match(pattern, path.Base(p))
+	return matched
+}
+
+// Unknown-license handling (compliance.unknown_license).
+const (
+	ComplianceUnknownWarn  = "warn"  // Record the dependency, do not block
+	ComplianceUnknownBlock = "block" // Treat an undetectable license as a violation
+)
+
+// ComplianceConfig is the policy a compliance phase applies to dependencies
+// a task adds or updates.
+type ComplianceConfig struct {
+	// AllowedLicenses lists acceptable SPDX license IDs. When set, any other
+	// license is a violation (default: none, meaning any license not denied)
+	AllowedLicenses []string `yaml:"allowed_licenses,omitempty"`
+	// DeniedLicenses lists SPDX license IDs that are never acceptable. IDs
+	// match regardless of -only/-or-later suffixes
+	// (default: AGPL-3.0, GPL-2.0, GPL-3.0, SSPL-1.0)
+	DeniedLicenses []string `yaml:"denied_licenses,omitempty"`
+	// UnknownLicense is warn or block for dependencies whose license cannot
+	// be determined (default: warn)
+	UnknownLicense string `yaml:"unknown_license"`
+	// ApprovedRegistries lists the hosts dependencies may come from, e.g.
+	// "registry.npmjs.org", "pypi.org", "github.com". Go modules are checked
+	// by the host in their module path (default: none, meaning any source)
+	ApprovedRegistries []string `yaml:"approved_registries,omitempty"`
+	// SBOM records the task's dependency delta against the target branch
+	// when it is finalized, stores it as a task attachment and summarizes
+	// it in the PR body (default: true)
+	SBOM bool `yaml:"sbom"`
+}
+
+// RefactorSafetyConfig defines the behavior-preservation checks for tasks in
+// the refactor category. Before the implementation phase changes anything,
+// orc records the test results and the exported Go API; when the phase
+// completes both must be unchanged.
+type RefactorSafetyConfig struct {
+	// Enabled runs the checks for refactor tasks (default: true)
+	Enabled bool `yaml:"enabled"`
+	// TestCommand runs the tests whose results are compared
+	// (default: the project's "tests" command)
+	TestCommand string `yaml:"test_command,omitempty"`
+	// API compares the exported API of the project's importable Go packages
+	// (default: true)
+	API bool `yaml:"api"`
+}
+
+// VotingApplies reports whether a phase of a task on the given workflow and
+// priority runs in voting mode. Entries in Weights match a workflow ID
+// directly or through the weight mapped to it (see WeightsConfig).
+func (c *Config) VotingApplies(phaseID, workflowID, priority string) bool {
+	v := c.Voting
+	if !v.Enabled || v.Candidates < 2 || !slices.Contains(v.Phases, phaseID) {
+		return false
+	}
+	if priority != "" && slices.Contains(v.Priorities, priority) {
+		return true
+	}
+	for _, w := range v.Weights {
+		if w == workflowID || (workflowID != "" && c.Weights.GetWorkflowID(w) == workflowID) {
+			return true
+		}
+	}
+	return false
+}
+
 // WeightsConfig defines which workflow to use for each task weight.
 // This replaces the hardcoded WeightToWorkflowID() function.
 type WeightsConfig struct {
@@ -658,6 +1257,20 @@ func (w WeightsConfig) GetWorkflowID(weight string) string {
 	}
 }
 
+// WeightForWorkflow returns the weight whose workflow is workflowID, or ""
+// when no weight maps to it.
+func (w WeightsConfig) WeightForWorkflow(workflowID string) string {
+	if workflowID == "" {
+		return ""
+	}
+	for _, weight := range []string{"trivial", "small", "medium", "large"} {
+		if w.GetWorkflowID(weight) == workflowID {
+			return weight
+		}
+	}
+	return ""
+}
+
 // GetDefaultWorkflow returns the default workflow ID for a given task category.
 // If the category-specific workflow is empty, returns the general default.
 // Returns empty string if no default is configured at all.
@@ -666,7 +1279,7 @@ func (w WorkflowDefaults) GetDefaultWorkflow(category string) string {
 	switch category {
 	case "feature":
 		categoryWorkflow = w.Feature
-	case "bug":
+	case "bug", "bugfix":
 		categoryWorkflow = w.Bug
 	case "refactor":
 		categoryWorkflow = w.Refactor
@@ -695,7 +1308,7 @@ func (w *WorkflowDefaults) SetDefaultWorkflow(category, workflowID string) {
 	switch category {
 	case "feature":
 		w.Feature = workflowID
-	case "bug":
+	case "bug", "bugfix":
 		w.Bug = workflowID
 	case "refactor":
 		w.Refactor = workflowID
@@ -802,6 +1415,28 @@ type TasksConfig struct {
 	// DisableAutoCommit disables automatic git commits on task creation/modification (default: false)
 	// When enabled, task files are not auto-committed and must be committed manually.
 	DisableAutoCommit bool `yaml:"disable_auto_commit"`
+
+	// Stale controls detection of tasks left planned or paused
+	Stale StaleTasksConfig `yaml:"stale"`
+}
+
+// Stale task actions (tasks.stale.action).
+const (
+	StaleActionFlag   = "flag"   // List in GET /api/tasks/stale and log only
+	StaleActionNotify = "notify" // Also raise a notification naming the assignee
+	StaleActionClose  = "close"  // Close the task
+)
+
+// StaleTasksConfig defines when a planned or paused task counts as stale and
+// what orc serve does about it.
+type StaleTasksConfig struct {
+	// After is how long a planned or paused task may go without updates
+	// before it is stale (0 = disabled, default: 336h)
+	After time.Duration `yaml:"after"`
+	// CheckInterval is how often orc serve looks for stale tasks (default: 1h)
+	CheckInterval time.Duration `yaml:"check_interval"`
+	// Action is what happens to stale tasks: flag, notify, or close (default: flag)
+	Action string `yaml:"action"`
 }
 
 // ResourceTrackingConfig defines resource tracking configuration for diagnostics.
@@ -870,6 +1505,51 @@ type BriefConfig struct {
 
 	// StaleThreshold is the number of new completed tasks before the brief is regenerated (default: 3)
 	StaleThreshold int `yaml:"stale_threshold"`
+
+	// RelatedTasks injects outcomes of recently completed tasks that touched
+	// the same files or packages into phase prompts ({{RELATED_TASKS}})
+	RelatedTasks RelatedTasksConfig `yaml:"related_tasks"`
+
+	// Lessons maintains the lessons.md context document from feedback the
+	// team keeps giving: rejected human gates and review comments
+	Lessons LessonsConfig `yaml:"lessons"`
+}
+
+// RelatedTasksConfig configures related-task context. A task opts out on its
+// own with the related_tasks=off metadata (orc new --no-related-tasks).
+type RelatedTasksConfig struct {
+	// Enabled turns related-task context on (default: true)
+	Enabled bool `yaml:"enabled"`
+
+	// Limit is the maximum number of related tasks included (default: 5)
+	Limit int `yaml:"limit"`
+
+	// MaxAge is how long ago a task may have completed to count (default: 720h, 0 = no limit)
+	MaxAge time.Duration `yaml:"max_age"`
+
+	// Phases are the phases whose prompts include related tasks (default: spec, tiny_spec, implement)
+	Phases []string `yaml:"phases"`
+}
+
+// LessonsConfig configures the lessons document. Feedback recurs when
+// similar rejection reasons or review comments appear on several tasks.
+type LessonsConfig struct {
+	// Enabled turns lessons maintenance on (default: true)
+	Enabled bool `yaml:"enabled"`
+
+	// MinOccurrences is the number of tasks that must receive similar
+	// feedback before it becomes a lesson (default: 2)
+	MinOccurrences int `yaml:"min_occurrences"`
+
+	// MaxBytes bounds the size of the lessons document (default: 4096)
+	MaxBytes int `yaml:"max_bytes"`
+
+	// MaxAge is how old feedback may be to count (default: 2160h, 0 = no limit)
+	MaxAge time.Duration `yaml:"max_age"`
+
+	// Phases are the phases whose prompts include a newly created lessons
+	// document (default: implement, review)
+	Phases []string `yaml:"phases"`
 }
 
 // MCPConfig defines MCP (Model Context Protocol) server configuration.
@@ -902,10 +1582,17 @@ const (
 	TriggerTypeEvent TriggerType = "event"
 	// TriggerTypeThreshold fires when metric crosses value
 	TriggerTypeThreshold TriggerType = "threshold"
-	// TriggerTypeSchedule fires on cron schedule (team mode only)
+	// TriggerTypeSchedule fires on cron schedule (run by the API server)
 	TriggerTypeSchedule TriggerType = "schedule"
 )
 
+const (
+	// CatchUpSkip ignores scheduled runs missed while the server was down
+	CatchUpSkip = "skip"
+	// CatchUpRunOnce fires a single run on startup for any missed runs
+	CatchUpRunOnce = "run_once"
+)
+
 // TriggerConditionConfig defines when a trigger fires.
 type TriggerConditionConfig struct {
 	// Count-based
@@ -922,8 +1609,9 @@ type TriggerConditionConfig struct {
 	Operator string  `yaml:"operator,omitempty"` // lt, gt, eq
 	Value    float64 `yaml:"value,omitempty"`    // Threshold value
 
-	// Schedule-based (team mode only)
+	// Schedule-based (run by the API server)
 	Schedule string `yaml:"schedule,omitempty"` // Cron expression
+	CatchUp  string `yaml:"catch_up,omitempty"` // skip (default) or run_once
 }
 
 // TriggerActionConfig defines what happens when a trigger fires.
@@ -1055,6 +1743,108 @@ type HostingConfig struct {
 	TokenEnvVar string `yaml:"token_env_var" json:"token_env_var,omitempty"`
 }
 
+// SkillsConfig defines where vetted skills are installed from.
+type SkillsConfig struct {
+	// Index is a git repository (containing index.yaml) or an HTTP(S) URL of
+	// an index.yaml file listing installable SKILL.md bundles.
+	// Usually set in ~/.orc/config.yaml so every project shares one index.
+	Index string `yaml:"index" json:"index,omitempty"`
+}
+
+// UpdatesConfig controls the release check and `orc self-update`.
+type UpdatesConfig struct {
+	// Check enables the non-blocking check for newer releases after commands
+	// (at most once a day). Default: true
+	Check bool `yaml:"check" json:"check"`
+
+	// FeedURL is the releases feed (GitHub releases API format).
+	// Default: the randalmurphal/orc GitHub releases.
+	FeedURL string `yaml:"feed_url" json:"feed_url,omitempty"`
+
+	// AutoDownload downloads and verifies a newer release in the background
+	// when the check finds one, so `orc self-update` installs it without
+	// downloading. Default: false
+	AutoDownload bool `yaml:"auto_download" json:"auto_download"`
+
+	// PublicKey is a base64 ed25519 public key. When set, releases must ship
+	// checksums.txt.sig signed with the matching private key.
+	PublicKey string `yaml:"public_key" json:"public_key,omitempty"`
+}
+
+// BackoffConfig controls how calls to external services are retried: GitHub
+// and GitLab API requests, CI status polling, merge retries, webhook
+// delivery, and embedding model requests.
+type BackoffConfig struct {
+	// MaxAttempts is the total number of tries per call, including the
+	// first. Default: 4
+	MaxAttempts int `yaml:"max_attempts" json:"max_attempts"`
+
+	// Initial is the delay before the first retry. Default: 1s
+	Initial time.Duration `yaml:"initial" json:"initial"`
+
+	// Max caps the delay between tries. Default: 30s
+	Max time.Duration `yaml:"max" json:"max"`
+
+	// Multiplier is the growth factor between consecutive delays. Default: 2
+	Multiplier float64 `yaml:"multiplier" json:"multiplier"`
+
+	// Jitter randomizes each delay by up to this fraction of it (0-1).
+	// Default: 0.2
+	Jitter float64 `yaml:"jitter" json:"jitter"`
+}
+
+// RetryPolicy returns the configured backoff as a retry policy. Unset
+// fields fall back to the retry package defaults.
+func (c *Config) RetryPolicy() retry.Policy {
+	if c == nil {
+		return retry.DefaultPolicy()
+	}
+	return retry.Policy{
+		MaxAttempts: c.Backoff.MaxAttempts,
+		Initial:     c.Backoff.Initial,
+		Max:         c.Backoff.Max,
+		Multiplier:  c.Backoff.Multiplier,
+		Jitter:      c.Backoff.Jitter,
+	}
+}
+
+// Webhook events (webhooks.endpoints[].events).
+const (
+	WebhookEventCreated        = "created"         // Task created
+	WebhookEventPhaseCompleted = "phase_completed" // A phase finished successfully
+	WebhookEventGatePending    = "gate_pending"    // A human gate is waiting for a decision
+	WebhookEventFailed         = "failed"          // Task failed
+	WebhookEventMerged         = "merged"          // Task's PR was merged
+)
+
+// WebhooksConfig defines URLs that orc serve notifies of task lifecycle
+// events. Endpoints registered through /api/webhooks are delivered alongside
+// these.
+type WebhooksConfig struct {
+	// Endpoints receive a JSON POST for each event they subscribe to.
+	Endpoints []WebhookEndpoint `yaml:"endpoints,omitempty" json:"endpoints,omitempty"`
+
+	// Timeout bounds each delivery attempt. Failed attempts are retried
+	// with the backoff settings. Default: 10s
+	Timeout time.Duration `yaml:"timeout" json:"timeout"`
+}
+
+// WebhookEndpoint is one configured webhook receiver.
+type WebhookEndpoint struct {
+	// Name identifies the endpoint in logs and the API.
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+
+	// URL receives the POSTs. Must be http or https.
+	URL string `yaml:"url" json:"url"`
+
+	// SecretEnv names the environment variable holding the HMAC signing
+	// secret. Deliveries are unsigned when empty.
+	SecretEnv string `yaml:"secret_env,omitempty" json:"secret_env,omitempty"`
+
+	// Events limits deliveries to these events. Empty means all of them.
+	Events []string `yaml:"events,omitempty" json:"events,omitempty"`
+}
+
 // ProvidersConfig defines provider-specific defaults.
 type ProvidersConfig struct {
 	Codex CodexProviderConfig                      `yaml:"codex,omitempty"`
@@ -1166,11 +1956,13 @@ type DatabaseConfig struct {
 type StorageMode string
 
 const (
-	// StorageModeHybrid uses YAML files as primary with SQLite cache for search
+	// StorageModeHybrid uses the database with YAML copies of active tasks
+	// under .orc/tasks/
 	StorageModeHybrid StorageMode = "hybrid"
-	// StorageModeFiles uses YAML files only (minimal, git-friendly)
+	// StorageModeFiles uses the database with YAML copies of every task
+	// under .orc/tasks/ (git-friendly)
 	StorageModeFiles StorageMode = "files"
-	// StorageModeDatabase uses database as primary (team/enterprise)
+	// StorageModeDatabase uses the database only (team/enterprise)
 	StorageModeDatabase StorageMode = "database"
 )
 
@@ -1179,6 +1971,11 @@ type FileStorageConfig struct {
 	// CleanupOnComplete removes task files after successful completion
 	// Default: true (keeps .orc/tasks/ clean)
 	CleanupOnComplete bool `yaml:"cleanup_on_complete"`
+
+	// WriteThrough turns on the task directories of the files and hybrid
+	// modes; without it those modes behave like database mode
+	// Default: false
+	WriteThrough bool `yaml:"write_through"`
 }
 
 // DatabaseStorageConfig defines database storage settings.
@@ -1190,6 +1987,12 @@ type DatabaseStorageConfig struct {
 	// RetentionDays is how long to keep entries before cleanup
 	// Default: 90
 	RetentionDays int `yaml:"retention_days"`
+
+	// MaintenanceInterval is how often orc serve runs database maintenance
+	// (integrity and FTS checks, retention pruning, VACUUM, ANALYZE).
+	// Zero disables the periodic job; `orc db maintain` still works.
+	// Default: 24h
+	MaintenanceInterval time.Duration `yaml:"maintenance_interval"`
 }
 
 // ExportPreset defines a preset export configuration.
@@ -1230,7 +2033,7 @@ type ExportConfig struct {
 // This is separate from DatabaseConfig which handles connection settings.
 type StorageConfig struct {
 	// Mode is the storage mode: hybrid | files | database
-	// Default: hybrid (best of both worlds for solo devs)
+	// Default: database
 	Mode StorageMode `yaml:"mode"`
 
 	// Files contains file storage settings
@@ -1371,6 +2174,54 @@ var (
 	// ValidCompletionActions are the allowed values for completion.action
 	ValidCompletionActions = []string{"pr", "merge", "commit", "none", ""}
 
+	// ValidGitHooks are the client-side hooks git_hooks.bypass and
+	// git_hooks.approved accept.
+	ValidGitHooks = []string{
+		"applypatch-msg", "pre-applypatch", "post-applypatch",
+		"pre-commit", "pre-merge-commit", "prepare-commit-msg", "commit-msg", "post-commit",
+		"pre-rebase", "post-checkout", "post-merge", "pre-push", "post-rewrite",
+		"reference-transaction", "pre-auto-gc",
+	}
+
+	// ValidFollowUpCreateModes are the allowed values for completion.follow_ups.create
+	ValidFollowUpCreateModes = []string{"none", "tasks", "issues", ""}
+
+	// ValidReleaseBackportModes are the allowed values for completion.release.backport
+	ValidReleaseBackportModes = []string{"cherry-pick", "merge", "none", ""}
+
+	// ValidReplanGates are the allowed values for retry.replan.gate
+	ValidReplanGates = []string{"auto", "human", ""}
+
+	// ValidBinaryPolicies are the allowed values for binary_files.policy
+	ValidBinaryPolicies = []string{BinaryPolicyAllow, BinaryPolicyBlock, BinaryPolicyLFS, ""}
+
+	// ValidGateTimeoutActions are the allowed values for gates.timeout.action
+	ValidGateTimeoutActions = []string{GateTimeoutNotify, GateTimeoutReassign, GateTimeoutApprove, GateTimeoutFail, ""}
+
+	// ValidStaleActions are the allowed values for tasks.stale.action
+	ValidStaleActions = []string{StaleActionFlag, StaleActionNotify, StaleActionClose, ""}
+
+	// ValidWebhookEvents are the allowed values for webhooks.endpoints[].events
+	ValidWebhookEvents = []string{
+		WebhookEventCreated,
+		WebhookEventPhaseCompleted,
+		WebhookEventGatePending,
+		WebhookEventFailed,
+		WebhookEventMerged,
+	}
+
+	// ValidTaskCategories are the allowed keys of prompt_variants
+	ValidTaskCategories = []string{"feature", "bug", "refactor", "chore", "docs", "test"}
+
+	// MaxVotingCandidates caps voting.candidates
+	MaxVotingCandidates = 5
+
+	// ValidCatchUpPolicies are the allowed values for a schedule trigger's condition.catch_up
+	ValidCatchUpPolicies = []string{CatchUpSkip, CatchUpRunOnce, ""}
+
+	// ValidSchedulerPolicies are the allowed values for server.scheduler.policy
+	ValidSchedulerPolicies = []string{"round_robin", "weighted", ""}
+
 	// ValidSyncStrategies are the allowed values for completion.sync.strategy
 	ValidSyncStrategies = []string{
 		string(SyncStrategyNone),
@@ -2,12 +2,26 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"path"
+	"regexp"
+	"slices"
 	"strings"
+
+	"github.com/randalmurphal/orc/internal/i18n"
 )
 
+// promptVariantPattern matches prompt variant names, which become part of
+// prompt filenames (<phase>.<variant>.md).
+var promptVariantPattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
 // Validate checks if config values are valid.
 func (c *Config) Validate() error {
+	if c.Locale != "" && i18n.Normalize(c.Locale) == "" {
+		return fmt.Errorf("invalid locale: %s (must be one of: %v)",
+			c.Locale, i18n.Supported())
+	}
 	if c.Team.Visibility != "" && !contains(ValidVisibilities, c.Team.Visibility) {
 		return fmt.Errorf("invalid team.visibility: %s (must be one of: %v)",
 			c.Team.Visibility, ValidVisibilities)
@@ -17,6 +31,29 @@ func (c *Config) Validate() error {
 			c.Team.Mode, ValidModes)
 	}
 
+	if err := c.validateFeatures(); err != nil {
+		return err
+	}
+
+	for workflowID, models := range c.PhaseModels {
+		for phaseID, model := range models {
+			if strings.TrimSpace(model) == "" {
+				return fmt.Errorf("phase_models.%s.%s: model must not be empty", workflowID, phaseID)
+			}
+		}
+	}
+
+	for category, variant := range c.PromptVariants {
+		if !contains(ValidTaskCategories, category) {
+			return fmt.Errorf("invalid prompt_variants key: %s (must be one of: %v)",
+				category, ValidTaskCategories)
+		}
+		if variant != "" && !promptVariantPattern.MatchString(variant) {
+			return fmt.Errorf("invalid prompt_variants.%s: %q (use lowercase letters, digits, '-' and '_')",
+				category, variant)
+		}
+	}
+
 	if !IsValidLLMProvider(c.Provider) {
 		return fmt.Errorf("invalid provider: %s (must be one of: claude, codex)",
 			c.Provider)
@@ -27,11 +64,40 @@ func (c *Config) Validate() error {
 			c.Hosting.Provider)
 	}
 
+	if c.Server.Auth.Enabled && c.Server.Auth.Type != "" && c.Server.Auth.Type != "token" {
+		return fmt.Errorf("unsupported server.auth.type: %s (must be: token)", c.Server.Auth.Type)
+	}
+
+	if c.Completion.Provider != "" && !contains(ValidHostingProviders, c.Completion.Provider) {
+		return fmt.Errorf("invalid completion.provider: %s (must be one of: auto, github, gitlab)",
+			c.Completion.Provider)
+	}
+
 	if c.Completion.Action != "" && !contains(ValidCompletionActions, c.Completion.Action) {
 		return fmt.Errorf("invalid completion.action: %s (must be one of: pr, merge, commit, none)",
 			c.Completion.Action)
 	}
 
+	if !contains(ValidFollowUpCreateModes, c.Completion.FollowUps.Create) {
+		return fmt.Errorf("invalid completion.follow_ups.create: %s (must be one of: none, tasks, issues)",
+			c.Completion.FollowUps.Create)
+	}
+
+	if !contains(ValidReleaseBackportModes, c.Completion.Release.Backport) {
+		return fmt.Errorf("invalid completion.release.backport: %s (must be one of: cherry-pick, merge, none)",
+			c.Completion.Release.Backport)
+	}
+	if c.Completion.Release.Backport != "" && c.Completion.Release.Backport != "none" {
+		if c.Completion.Release.BackportTo == "" {
+			return fmt.Errorf("completion.release.backport_to is required when completion.release.backport is %s",
+				c.Completion.Release.Backport)
+		}
+		if c.IsReleaseBranch(c.Completion.Release.BackportTo) {
+			return fmt.Errorf("completion.release.backport_to %q cannot be a release branch (prefix %q)",
+				c.Completion.Release.BackportTo, c.Completion.Release.Prefix)
+		}
+	}
+
 	if !contains(ValidSyncStrategies, string(c.Completion.Sync.Strategy)) {
 		return fmt.Errorf("invalid completion.sync.strategy: %s (must be one of: none, phase, completion, detect)",
 			c.Completion.Sync.Strategy)
@@ -42,7 +108,7 @@ func (c *Config) Validate() error {
 		if targetBranch == "" {
 			targetBranch = "main"
 		}
-		if isProtectedBranch(targetBranch) {
+		if c.IsProtectedBranch(targetBranch) {
 			return fmt.Errorf("completion.action 'merge' is blocked for protected branch '%s'; "+
 				"use 'pr' action instead to ensure code review before merging to protected branches",
 				targetBranch)
@@ -55,13 +121,169 @@ func (c *Config) Validate() error {
 			if targetBranch == "" {
 				targetBranch = "main"
 			}
-			if isProtectedBranch(targetBranch) {
+			if c.IsProtectedBranch(targetBranch) {
 				return fmt.Errorf("completion.weight_actions[%s]='merge' is blocked for protected branch '%s'; "+
 					"use 'pr' action instead", weight, targetBranch)
 			}
 		}
 	}
 
+	if rate := c.Server.AccessLog.SampleRate; rate < 0 || rate > 1 {
+		return fmt.Errorf("server.access_log.sample_rate must be between 0 and 1, got %v", rate)
+	}
+
+	if err := c.validateScheduler(); err != nil {
+		return err
+	}
+
+	for _, trigger := range c.Automation.Triggers {
+		if !contains(ValidCatchUpPolicies, trigger.Condition.CatchUp) {
+			return fmt.Errorf("invalid automation trigger %s condition.catch_up: %s (must be skip or run_once)", trigger.ID, trigger.Condition.CatchUp)
+		}
+	}
+
+	if c.Retry.Replan.MaxReplans < 0 {
+		return fmt.Errorf("retry.replan.max_replans must be >= 0, got %d", c.Retry.Replan.MaxReplans)
+	}
+	if !contains(ValidReplanGates, c.Retry.Replan.Gate) {
+		return fmt.Errorf("invalid retry.replan.gate: %s (must be auto or human)", c.Retry.Replan.Gate)
+	}
+
+	if c.Voting.Enabled && (c.Voting.Candidates < 2 || c.Voting.Candidates > MaxVotingCandidates) {
+		return fmt.Errorf("voting.candidates must be between 2 and %d, got %d", MaxVotingCandidates, c.Voting.Candidates)
+	}
+
+	if c.Review.Chunking.Enabled && (c.Review.Chunking.MaxLines < 1 || c.Review.Chunking.MaxFiles < 1) {
+		return fmt.Errorf("review.chunking.max_lines and review.chunking.max_files must be >= 1 when chunking is enabled, got %d and %d",
+			c.Review.Chunking.MaxLines, c.Review.Chunking.MaxFiles)
+	}
+
+	if err := c.GitIdentity.validate(); err != nil {
+		return err
+	}
+	if err := c.GitHooks.validate(); err != nil {
+		return err
+	}
+
+	if c.Worktree.Preflight.MinFreeDiskMB < 0 {
+		return fmt.Errorf("worktree.preflight.min_free_disk_mb must be >= 0, got %d", c.Worktree.Preflight.MinFreeDiskMB)
+	}
+
+	if !contains(ValidBinaryPolicies, c.BinaryFiles.Policy) {
+		return fmt.Errorf("invalid binary_files.policy: %s (must be allow, block, or lfs)", c.BinaryFiles.Policy)
+	}
+	if c.BinaryFiles.MaxSizeKB < 0 {
+		return fmt.Errorf("binary_files.max_size_kb must be >= 0, got %d", c.BinaryFiles.MaxSizeKB)
+	}
+	for _, pattern := range c.BinaryFiles.Allow {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid binary_files.allow pattern %q: %w", pattern, err)
+		}
+	}
+	if c.QualityChecks.Parallelism < 0 {
+		return fmt.Errorf("quality_checks.parallelism must be >= 0, got %d", c.QualityChecks.Parallelism)
+	}
+	if c.QualityChecks.Timeout < 0 {
+		return fmt.Errorf("quality_checks.timeout must be >= 0, got %s", c.QualityChecks.Timeout)
+	}
+	for _, name := range c.QualityChecks.Hermetic.Env {
+		if name == "" || strings.ContainsAny(name, "= ") {
+			return fmt.Errorf("invalid quality_checks.hermetic.env entry %q: must be a variable name", name)
+		}
+	}
+	for _, pattern := range c.SensitivePaths.Deny {
+		if err := validatePathPattern(pattern); err != nil {
+			return fmt.Errorf("invalid sensitive_paths.deny pattern %q: %w", pattern, err)
+		}
+	}
+	for _, pattern := range c.SensitivePaths.Allow {
+		if err := validatePathPattern(pattern); err != nil {
+			return fmt.Errorf("invalid sensitive_paths.allow pattern %q: %w", pattern, err)
+		}
+	}
+
+	switch c.Compliance.UnknownLicense {
+	case "", ComplianceUnknownWarn, ComplianceUnknownBlock:
+	default:
+		return fmt.Errorf("invalid compliance.unknown_license: %s (must be warn or block)", c.Compliance.UnknownLicense)
+	}
+
+	if c.Gates.Timeout.After < 0 {
+		return fmt.Errorf("gates.timeout.after must not be negative")
+	}
+	if !contains(ValidGateTimeoutActions, c.Gates.Timeout.Action) {
+		return fmt.Errorf("invalid gates.timeout.action: %s (must be notify, reassign, approve, or fail)", c.Gates.Timeout.Action)
+	}
+	for phase, o := range c.Gates.Timeout.PhaseOverrides {
+		if o.After < 0 {
+			return fmt.Errorf("gates.timeout.phase_overrides.%s.after must not be negative", phase)
+		}
+		if !contains(ValidGateTimeoutActions, o.Action) {
+			return fmt.Errorf("invalid gates.timeout.phase_overrides.%s.action: %s (must be notify, reassign, approve, or fail)", phase, o.Action)
+		}
+	}
+	if t := c.Gates.Timeout; t.Action == GateTimeoutReassign && t.ReassignTo == "" {
+		return fmt.Errorf("gates.timeout.reassign_to is required for the reassign action")
+	}
+	for phase := range c.Gates.Timeout.PhaseOverrides {
+		if t := c.Gates.Timeout.ForPhase(phase); t.Action == GateTimeoutReassign && t.ReassignTo == "" {
+			return fmt.Errorf("gates.timeout.phase_overrides.%s: reassign_to is required for the reassign action", phase)
+		}
+	}
+
+	if c.Tasks.Stale.After < 0 {
+		return fmt.Errorf("tasks.stale.after must not be negative")
+	}
+	if c.Tasks.Stale.CheckInterval < 0 {
+		return fmt.Errorf("tasks.stale.check_interval must not be negative")
+	}
+	if !contains(ValidStaleActions, c.Tasks.Stale.Action) {
+		return fmt.Errorf("invalid tasks.stale.action: %s (must be flag, notify, or close)", c.Tasks.Stale.Action)
+	}
+
+	if c.Backoff.MaxAttempts < 0 {
+		return fmt.Errorf("backoff.max_attempts must be >= 0, got %d", c.Backoff.MaxAttempts)
+	}
+	if c.Backoff.Initial < 0 || c.Backoff.Max < 0 {
+		return fmt.Errorf("backoff.initial and backoff.max must not be negative")
+	}
+	if c.Backoff.Initial > 0 && c.Backoff.Max > 0 && c.Backoff.Max < c.Backoff.Initial {
+		return fmt.Errorf("backoff.max (%v) must be >= backoff.initial (%v)", c.Backoff.Max, c.Backoff.Initial)
+	}
+	if m := c.Backoff.Multiplier; m != 0 && m < 1 {
+		return fmt.Errorf("backoff.multiplier must be >= 1, got %v", m)
+	}
+	if j := c.Backoff.Jitter; j < 0 || j > 1 {
+		return fmt.Errorf("backoff.jitter must be between 0 and 1, got %v", j)
+	}
+
+	if c.Webhooks.Timeout < 0 {
+		return fmt.Errorf("webhooks.timeout must not be negative")
+	}
+	for i, ep := range c.Webhooks.Endpoints {
+		if err := ValidateWebhookURL(ep.URL); err != nil {
+			return fmt.Errorf("webhooks.endpoints[%d].url: %w", i, err)
+		}
+		for _, ev := range ep.Events {
+			if !contains(ValidWebhookEvents, ev) {
+				return fmt.Errorf("invalid webhooks.endpoints[%d].events entry: %s (must be one of %s)", i, ev, strings.Join(ValidWebhookEvents, ", "))
+			}
+		}
+	}
+
+	if below := c.Validation.Confidence.EscalateBelow; below < 0 || below > 1 {
+		return fmt.Errorf("validation.confidence.escalate_below must be between 0 and 1, got %v", below)
+	}
+
+	for key, limit := range c.Timeouts.TaskMax {
+		if strings.TrimSpace(key) == "" {
+			return fmt.Errorf("invalid timeouts.task_max: key cannot be empty")
+		}
+		if limit < 0 {
+			return fmt.Errorf("timeouts.task_max.%s must be >= 0, got %v", key, limit)
+		}
+	}
+
 	if !c.Worktree.Enabled {
 		return fmt.Errorf("worktree.enabled cannot be set to false; " +
 			"worktree isolation is required for safe parallel task execution and branch protection; " +
@@ -108,6 +330,25 @@ func (c *Config) validateProviderRates() error {
 	return nil
 }
 
+func (c *Config) validateScheduler() error {
+	sched := c.Server.Scheduler
+	if sched.MaxRunningTasks < 0 {
+		return fmt.Errorf("server.scheduler.max_running_tasks must be >= 0, got %d", sched.MaxRunningTasks)
+	}
+	if !contains(ValidSchedulerPolicies, sched.Policy) {
+		return fmt.Errorf("invalid server.scheduler.policy: %s (must be round_robin or weighted)", sched.Policy)
+	}
+	for projectID, weight := range sched.ProjectWeights {
+		if strings.TrimSpace(projectID) == "" {
+			return fmt.Errorf("invalid server.scheduler.project_weights: project key cannot be empty")
+		}
+		if weight < 1 {
+			return fmt.Errorf("server.scheduler.project_weights.%s must be >= 1, got %d", projectID, weight)
+		}
+	}
+	return nil
+}
+
 func (c *Config) validateFinalize() error {
 	finalize := c.Completion.Finalize
 
@@ -130,13 +371,33 @@ func (c *Config) validateFinalize() error {
 	return nil
 }
 
-func isProtectedBranch(branch string) bool {
-	for _, p := range DefaultProtectedBranches {
-		if branch == p {
-			return true
-		}
+// ProtectedBranchList returns completion.protected_branches, or
+// DefaultProtectedBranches when none are configured.
+func (c *Config) ProtectedBranchList() []string {
+	if len(c.Completion.ProtectedBranches) == 0 {
+		return DefaultProtectedBranches
 	}
-	return false
+	return c.Completion.ProtectedBranches
+}
+
+// IsProtectedBranch reports whether branch is in the configured protected list.
+func (c *Config) IsProtectedBranch(branch string) bool {
+	return contains(c.ProtectedBranchList(), branch)
+}
+
+// ValidateWebhookURL checks that raw is an absolute http or https URL.
+func ValidateWebhookURL(raw string) error {
+	if raw == "" {
+		return fmt.Errorf("url is required")
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https URL")
+	}
+	return nil
 }
 
 func contains(slice []string, s string) bool {
@@ -191,6 +452,9 @@ func (c *Config) validateStorage() error {
 	if c.Storage.Database.RetentionDays < 0 || c.Storage.Database.RetentionDays > 3650 {
 		return fmt.Errorf("storage.database.retention_days must be between 0 and 3650")
 	}
+	if c.Storage.Database.MaintenanceInterval < 0 {
+		return fmt.Errorf("storage.database.maintenance_interval must not be negative")
+	}
 
 	return nil
 }
@@ -223,3 +487,51 @@ func (c *Config) ShouldValidateCriteria(weight string) bool {
 	}
 	return c.ShouldValidateForWeight(weight)
 }
+
+// validate checks git_identity is either unset or a complete name/email pair
+// git will accept.
+func (g GitIdentityConfig) validate() error {
+	if g.Name == "" && g.Email == "" {
+		return nil
+	}
+	if g.Name == "" || g.Email == "" {
+		return fmt.Errorf("git_identity.name and git_identity.email must be set together")
+	}
+	if strings.ContainsAny(g.Name, "<>\n") {
+		return fmt.Errorf("invalid git_identity.name %q: must not contain '<', '>', or newlines", g.Name)
+	}
+	if strings.ContainsAny(g.Email, "<>\n ") || !strings.Contains(g.Email, "@") {
+		return fmt.Errorf("invalid git_identity.email %q: must be a bare address like bot@company.com", g.Email)
+	}
+	return nil
+}
+
+// validate checks git_hooks names only known client-side hooks, and that
+// approved hooks only qualify a bypass of all hooks.
+func (h GitHooksConfig) validate() error {
+	for _, hook := range h.Bypass {
+		if hook != "all" && !slices.Contains(ValidGitHooks, hook) {
+			return fmt.Errorf("invalid git_hooks.bypass entry %q (must be all or one of: %s)", hook, strings.Join(ValidGitHooks, ", "))
+		}
+	}
+	for _, hook := range h.Approved {
+		if !slices.Contains(ValidGitHooks, hook) {
+			return fmt.Errorf("invalid git_hooks.approved entry %q (must be one of: %s)", hook, strings.Join(ValidGitHooks, ", "))
+		}
+	}
+	if len(h.Approved) > 0 && !slices.Contains(h.Bypass, "all") {
+		return fmt.Errorf("git_hooks.approved requires git_hooks.bypass: [all]; otherwise list only the hooks to skip in git_hooks.bypass")
+	}
+	return nil
+}
+
+// validatePathPattern checks a sensitive_paths pattern, which is a glob with
+// an optional trailing "/" or "/**".
+func validatePathPattern(pattern string) error {
+	glob := strings.TrimSuffix(strings.TrimSuffix(pattern, "**"), "/")
+	if glob == "" {
+		return fmt.Errorf("empty pattern")
+	}
+	_, err := path.Match(glob, "")
+	return err
+}
@@ -10,12 +10,14 @@ import (
 // EnvVarMapping defines the mapping between environment variables and config paths.
 var EnvVarMapping = map[string]string{
 	"ORC_PROFILE":               "profile",
+	"ORC_LOCALE":                "locale",
 	"ORC_PROVIDER":              "provider",
 	"ORC_MODEL":                 "model",
 	"ORC_MAX_TURNS":             "max_turns",
 	"ORC_CODEX_PATH":            "codex_path",
 	"ORC_TIMEOUT":               "timeout",
 	"ORC_CLAUDE_PATH":           "claude_path",
+	"ORC_FAKE_MODEL":            "fake_model",
 	"ORC_RETRY_ENABLED":         "retry.enabled",
 	"ORC_RETRY_MAX_RETRIES":     "retry.max_retries",
 	"ORC_EXECUTOR_MAX_RETRIES":  "executor.max_retries",
@@ -26,15 +28,28 @@ var EnvVarMapping = map[string]string{
 	"ORC_COMPLETION_ACTION":     "completion.action",
 	"ORC_BRANCH_PREFIX":         "branch_prefix",
 	"ORC_COMMIT_PREFIX":         "commit_prefix",
+	"ORC_GIT_IDENTITY_NAME":     "git_identity.name",
+	"ORC_GIT_IDENTITY_EMAIL":    "git_identity.email",
 	"ORC_POOL_ENABLED":          "pool.enabled",
 	"ORC_HOSTING_ACCOUNT":       "hosting.account",
 	"ORC_HOSTING_PROVIDER":      "hosting.provider",
 	"ORC_HOSTING_BASE_URL":      "hosting.base_url",
 	"ORC_HOSTING_TOKEN_ENV_VAR": "hosting.token_env_var",
+	"ORC_SKILLS_INDEX":          "skills.index",
+	"ORC_UPDATES_CHECK":         "updates.check",
+	"ORC_BACKOFF_MAX_ATTEMPTS":  "backoff.max_attempts",
+	"ORC_BACKOFF_INITIAL":       "backoff.initial",
+	"ORC_BACKOFF_MAX":           "backoff.max",
 	"ORC_HOST":                  "server.host",
 	"ORC_PORT":                  "server.port",
 	"ORC_AUTH_ENABLED":          "server.auth.enabled",
 	"ORC_AUTH_TYPE":             "server.auth.type",
+	"ORC_READ_ONLY":             "server.read_only",
+	"ORC_ACCESS_LOG":            "server.access_log.enabled",
+	"ORC_ACCESS_LOG_SAMPLE":     "server.access_log.sample_rate",
+	"ORC_TENANCY_ENABLED":       "server.tenancy.enabled",
+	"ORC_HA_ENABLED":            "server.ha.enabled",
+	"ORC_HA_INSTANCE_ID":        "server.ha.instance_id",
 	"ORC_TEAM_NAME":             "team.name",
 	"ORC_TEAM_ACTIVITY_LOG":     "team.activity_logging",
 	"ORC_TEAM_TASK_CLAIMING":    "team.task_claiming",
@@ -60,6 +75,9 @@ var EnvVarMapping = map[string]string{
 	"ORC_SUBTASKS_AUTO_APPROVE": "subtasks.auto_approve",
 	"ORC_SUBTASKS_MAX_PENDING":  "subtasks.max_pending",
 	// Database settings
+	"ORC_USER_NAME":   "user.name",
+	"ORC_DB_DIALECT":  "database.dialect",
+	"ORC_DB_DSN_ENV":  "database.dsn_env",
 	"ORC_DB_DRIVER":   "database.driver",
 	"ORC_DB_PASSWORD": "database.postgres.password",
 	"ORC_DB_HOST":     "database.postgres.host",
@@ -68,16 +86,18 @@ var EnvVarMapping = map[string]string{
 	"ORC_DB_USER":     "database.postgres.user",
 	"ORC_DB_SSL_MODE": "database.postgres.ssl_mode",
 	// Storage settings
-	"ORC_STORAGE_MODE":               "storage.mode",
-	"ORC_STORAGE_FILES_CLEANUP":      "storage.files.cleanup_on_complete",
-	"ORC_STORAGE_DB_CACHE":           "storage.database.cache_transcripts",
-	"ORC_STORAGE_DB_RETENTION_DAYS":  "storage.database.retention_days",
-	"ORC_STORAGE_EXPORT_ENABLED":     "storage.export.enabled",
-	"ORC_STORAGE_EXPORT_PRESET":      "storage.export.preset",
-	"ORC_STORAGE_EXPORT_TASK":        "storage.export.task_definition",
-	"ORC_STORAGE_EXPORT_STATE":       "storage.export.final_state",
-	"ORC_STORAGE_EXPORT_TRANSCRIPTS": "storage.export.transcripts",
-	"ORC_STORAGE_EXPORT_CONTEXT":     "storage.export.context_summary",
+	"ORC_STORAGE_MODE":                    "storage.mode",
+	"ORC_STORAGE_FILES_CLEANUP":           "storage.files.cleanup_on_complete",
+	"ORC_STORAGE_FILES_WRITE_THROUGH":     "storage.files.write_through",
+	"ORC_STORAGE_DB_CACHE":                "storage.database.cache_transcripts",
+	"ORC_STORAGE_DB_RETENTION_DAYS":       "storage.database.retention_days",
+	"ORC_STORAGE_DB_MAINTENANCE_INTERVAL": "storage.database.maintenance_interval",
+	"ORC_STORAGE_EXPORT_ENABLED":          "storage.export.enabled",
+	"ORC_STORAGE_EXPORT_PRESET":           "storage.export.preset",
+	"ORC_STORAGE_EXPORT_TASK":             "storage.export.task_definition",
+	"ORC_STORAGE_EXPORT_STATE":            "storage.export.final_state",
+	"ORC_STORAGE_EXPORT_TRANSCRIPTS":      "storage.export.transcripts",
+	"ORC_STORAGE_EXPORT_CONTEXT":          "storage.export.context_summary",
 }
 
 // ApplyEnvVars applies environment variable overrides to a TrackedConfig.
@@ -96,6 +116,7 @@ func ApplyEnvVars(tc *TrackedConfig) []string {
 			overridden = append(overridden, configPath)
 		}
 	}
+	overridden = append(overridden, applyFeatureEnvVars(tc)...)
 
 	return overridden
 }
@@ -106,6 +127,8 @@ func applyEnvVar(cfg *Config, path string, value string) bool {
 	switch path {
 	case "profile":
 		cfg.Profile = AutomationProfile(value)
+	case "locale":
+		cfg.Locale = value
 	case "provider":
 		cfg.Provider = value
 	case "model":
@@ -122,6 +145,8 @@ func applyEnvVar(cfg *Config, path string, value string) bool {
 		cfg.ClaudePath = ExpandPath(value)
 	case "codex_path":
 		cfg.CodexPath = ExpandPath(value)
+	case "fake_model":
+		cfg.FakeModel = ExpandPath(value)
 	case "retry.enabled":
 		cfg.Retry.Enabled = parseBool(value)
 	case "retry.max_retries":
@@ -148,6 +173,10 @@ func applyEnvVar(cfg *Config, path string, value string) bool {
 		cfg.BranchPrefix = value
 	case "commit_prefix":
 		cfg.CommitPrefix = value
+	case "git_identity.name":
+		cfg.GitIdentity.Name = value
+	case "git_identity.email":
+		cfg.GitIdentity.Email = value
 	case "pool.enabled":
 		cfg.Pool.Enabled = parseBool(value)
 	case "hosting.account":
@@ -158,6 +187,22 @@ func applyEnvVar(cfg *Config, path string, value string) bool {
 		cfg.Hosting.BaseURL = value
 	case "hosting.token_env_var":
 		cfg.Hosting.TokenEnvVar = value
+	case "skills.index":
+		cfg.Skills.Index = value
+	case "updates.check":
+		cfg.Updates.Check = parseBool(value)
+	case "backoff.max_attempts":
+		if v, err := strconv.Atoi(value); err == nil {
+			cfg.Backoff.MaxAttempts = v
+		}
+	case "backoff.initial":
+		if d, err := time.ParseDuration(value); err == nil {
+			cfg.Backoff.Initial = d
+		}
+	case "backoff.max":
+		if d, err := time.ParseDuration(value); err == nil {
+			cfg.Backoff.Max = d
+		}
 	case "server.host":
 		cfg.Server.Host = value
 	case "server.port":
@@ -168,6 +213,20 @@ func applyEnvVar(cfg *Config, path string, value string) bool {
 		cfg.Server.Auth.Enabled = parseBool(value)
 	case "server.auth.type":
 		cfg.Server.Auth.Type = value
+	case "server.read_only":
+		cfg.Server.ReadOnly = parseBool(value)
+	case "server.access_log.enabled":
+		cfg.Server.AccessLog.Enabled = parseBool(value)
+	case "server.access_log.sample_rate":
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			cfg.Server.AccessLog.SampleRate = v
+		}
+	case "server.tenancy.enabled":
+		cfg.Server.Tenancy.Enabled = parseBool(value)
+	case "server.ha.enabled":
+		cfg.Server.HA.Enabled = parseBool(value)
+	case "server.ha.instance_id":
+		cfg.Server.HA.InstanceID = value
 	case "team.name":
 		cfg.Team.Name = value
 	case "team.activity_logging":
@@ -227,6 +286,12 @@ func applyEnvVar(cfg *Config, path string, value string) bool {
 			cfg.Subtasks.MaxPending = v
 		}
 	// Database settings
+	case "user.name":
+		cfg.User.Name = value
+	case "database.dialect":
+		cfg.Database.Dialect = value
+	case "database.dsn_env":
+		cfg.Database.DSNEnv = value
 	case "database.driver":
 		cfg.Database.Driver = value
 	case "database.postgres.password":
@@ -248,12 +313,18 @@ func applyEnvVar(cfg *Config, path string, value string) bool {
 		cfg.Storage.Mode = StorageMode(value)
 	case "storage.files.cleanup_on_complete":
 		cfg.Storage.Files.CleanupOnComplete = parseBool(value)
+	case "storage.files.write_through":
+		cfg.Storage.Files.WriteThrough = parseBool(value)
 	case "storage.database.cache_transcripts":
 		cfg.Storage.Database.CacheTranscripts = parseBool(value)
 	case "storage.database.retention_days":
 		if v, err := strconv.Atoi(value); err == nil {
 			cfg.Storage.Database.RetentionDays = v
 		}
+	case "storage.database.maintenance_interval":
+		if d, err := time.ParseDuration(value); err == nil {
+			cfg.Storage.Database.MaintenanceInterval = d
+		}
 	case "storage.export.enabled":
 		cfg.Storage.Export.Enabled = parseBool(value)
 	case "storage.export.preset":
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// FeatureEnvPrefix prefixes per-feature environment overrides, e.g.
+// ORC_FEATURE_AUTOMATION=false.
+const FeatureEnvPrefix = "ORC_FEATURE_"
+
+// Feature is a runtime feature flag. Its default comes from the rest of the
+// config, so existing settings keep working; features.<name> overrides it.
+// Server-side behavior checks FeatureEnabled, so an override both changes
+// what GET /api/features reports and what the server does.
+type Feature struct {
+	Name        string
+	Description string
+	Default     func(*Config) bool
+}
+
+// Features lists every known feature flag, sorted by name.
+var Features = []Feature{
+	{Name: "automation", Description: "Triggers, automation templates and auto-created tasks", Default: func(c *Config) bool { return c.Automation.Enabled }},
+	{Name: "high_availability", Description: "Leader election between server instances", Default: func(c *Config) bool { return c.Server.HA.Enabled }},
+	{Name: "initiatives", Description: "Initiatives grouping related tasks", Default: func(*Config) bool { return true }},
+	{Name: "multi_tenant", Description: "Tenant-scoped projects and API tokens", Default: func(c *Config) bool { return c.Server.Tenancy.Enabled }},
+	// No server behavior: tells the UI whether to show assignment and claiming
+	{Name: "team_mode", Description: "Multi-user coordination: shared database, task claiming and assignment", Default: func(c *Config) bool { return c.IsTeamMode() || c.Team.TaskClaiming }},
+}
+
+// FeatureState is the resolved value of one feature flag.
+type FeatureState struct {
+	Name        string `json:"name"`
+	Enabled     bool   `json:"enabled"`
+	Overridden  bool   `json:"overridden"` // Set by features.<name> or ORC_FEATURE_<NAME>
+	Description string `json:"description"`
+}
+
+// LookupFeature returns the known feature with the given name.
+func LookupFeature(name string) (Feature, bool) {
+	for _, f := range Features {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Feature{}, false
+}
+
+// FeatureEnabled reports whether a feature is on. Unknown names are off.
+func (c *Config) FeatureEnabled(name string) bool {
+	f, ok := LookupFeature(name)
+	if !ok {
+		return false
+	}
+	if v, ok := c.FeatureFlags[name]; ok {
+		return v
+	}
+	return f.Default(c)
+}
+
+// ResolveFeatures returns the state of every known feature.
+func (c *Config) ResolveFeatures() []FeatureState {
+	states := make([]FeatureState, 0, len(Features))
+	for _, f := range Features {
+		_, overridden := c.FeatureFlags[f.Name]
+		states = append(states, FeatureState{
+			Name:        f.Name,
+			Enabled:     c.FeatureEnabled(f.Name),
+			Overridden:  overridden,
+			Description: f.Description,
+		})
+	}
+	return states
+}
+
+// validateFeatures rejects overrides for features that don't exist, which
+// are almost always typos.
+func (c *Config) validateFeatures() error {
+	var unknown []string
+	for name := range c.FeatureFlags {
+		if _, ok := LookupFeature(name); !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	known := make([]string, len(Features))
+	for i, f := range Features {
+		known[i] = f.Name
+	}
+	return fmt.Errorf("unknown features: %s (known: %s)", strings.Join(unknown, ", "), strings.Join(known, ", "))
+}
+
+// applyFeatureEnvVars applies ORC_FEATURE_<NAME> overrides and returns the
+// config paths they set.
+func applyFeatureEnvVars(tc *TrackedConfig) []string {
+	var overridden []string
+	for _, f := range Features {
+		value := os.Getenv(FeatureEnvPrefix + strings.ToUpper(f.Name))
+		if value == "" {
+			continue
+		}
+		if tc.Config.FeatureFlags == nil {
+			tc.Config.FeatureFlags = make(map[string]bool)
+		}
+		tc.Config.FeatureFlags[f.Name] = parseBool(value)
+		tc.SetSource("features", SourceEnv)
+		overridden = append(overridden, "features."+f.Name)
+	}
+	return overridden
+}
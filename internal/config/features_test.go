@@ -0,0 +1,77 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFeatureEnabled_DefaultsFollowConfig(t *testing.T) {
+	cfg := Default()
+	if !cfg.FeatureEnabled("initiatives") {
+		t.Error("initiatives should be on by default")
+	}
+	if cfg.FeatureEnabled("multi_tenant") {
+		t.Error("multi_tenant should follow server.tenancy.enabled (off)")
+	}
+	cfg.Server.Tenancy.Enabled = true
+	if !cfg.FeatureEnabled("multi_tenant") {
+		t.Error("multi_tenant should follow server.tenancy.enabled (on)")
+	}
+
+	cfg.FeatureFlags = map[string]bool{"automation": !cfg.Automation.Enabled}
+	if cfg.AutomationEnabled() == cfg.Automation.Enabled {
+		t.Error("features.automation should override automation.enabled")
+	}
+	if cfg.FeatureEnabled("no_such_feature") {
+		t.Error("unknown features should be off")
+	}
+
+	var overridden []string
+	for _, f := range cfg.ResolveFeatures() {
+		if f.Overridden {
+			overridden = append(overridden, f.Name)
+		}
+	}
+	if len(overridden) != 1 || overridden[0] != "automation" {
+		t.Errorf("overridden = %v, want [automation]", overridden)
+	}
+}
+
+func TestLoadWithSources_FeatureFlags(t *testing.T) {
+	tmpDir := t.TempDir()
+	fakeHome := filepath.Join(tmpDir, "home")
+	_ = os.MkdirAll(filepath.Join(fakeHome, ".orc"), 0755)
+	t.Setenv("HOME", fakeHome)
+
+	// Personal config flips one flag; the project's other flag is kept
+	_ = os.WriteFile(filepath.Join(fakeHome, ".orc", "config.yaml"),
+		[]byte("features:\n  team_mode: true\n"), 0644)
+	orcDir := filepath.Join(tmpDir, ".orc")
+	_ = os.MkdirAll(orcDir, 0755)
+	_ = os.WriteFile(filepath.Join(orcDir, "config.yaml"),
+		[]byte("features:\n  initiatives: false\n  team_mode: false\n"), 0644)
+	t.Setenv("ORC_FEATURE_AUTOMATION", "false")
+
+	tc, err := LoadWithSourcesFrom(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadWithSourcesFrom failed: %v", err)
+	}
+	cfg := tc.Config
+	if cfg.FeatureEnabled("initiatives") || !cfg.FeatureEnabled("team_mode") || cfg.FeatureEnabled("automation") {
+		t.Errorf("features = %v", cfg.FeatureFlags)
+	}
+	if tc.GetSource("features") != SourceEnv {
+		t.Errorf("features source = %q, want env", tc.GetSource("features"))
+	}
+}
+
+func TestValidate_UnknownFeature(t *testing.T) {
+	cfg := Default()
+	cfg.FeatureFlags = map[string]bool{"initatives": false}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "unknown features: initatives") {
+		t.Errorf("Validate() = %v, want unknown feature error", err)
+	}
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -10,6 +11,9 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// ErrInvalidConfig is returned when the merged configuration fails validation.
+var ErrInvalidConfig = errors.New("config validation failed")
+
 // Loader handles loading and merging configuration from multiple sources.
 // It implements the 3-level configuration hierarchy:
 //
@@ -105,7 +109,7 @@ func (l *Loader) Load() (*TrackedConfig, error) {
 
 	// Validate the merged configuration
 	if err := tc.Config.Validate(); err != nil {
-		return nil, fmt.Errorf("config validation failed: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
 	}
 
 	return tc, nil
@@ -203,6 +207,10 @@ func mergeConfigWithPath(tc *TrackedConfig, fileCfg *Config, raw map[string]inte
 		cfg.Profile = fileCfg.Profile
 		tc.SetSourceWithPath("profile", source, path)
 	}
+	if _, ok := raw["locale"]; ok {
+		cfg.Locale = fileCfg.Locale
+		tc.SetSourceWithPath("locale", source, path)
+	}
 	if _, ok := raw["model"]; ok {
 		cfg.Model = fileCfg.Model
 		tc.SetSourceWithPath("model", source, path)
@@ -231,6 +239,26 @@ func mergeConfigWithPath(tc *TrackedConfig, fileCfg *Config, raw map[string]inte
 		cfg.CommitPrefix = fileCfg.CommitPrefix
 		tc.SetSourceWithPath("commit_prefix", source, path)
 	}
+	if rawIdentity, ok := raw["git_identity"].(map[string]interface{}); ok {
+		if _, ok := rawIdentity["name"]; ok {
+			cfg.GitIdentity.Name = fileCfg.GitIdentity.Name
+			tc.SetSourceWithPath("git_identity.name", source, path)
+		}
+		if _, ok := rawIdentity["email"]; ok {
+			cfg.GitIdentity.Email = fileCfg.GitIdentity.Email
+			tc.SetSourceWithPath("git_identity.email", source, path)
+		}
+	}
+	if rawHooks, ok := raw["git_hooks"].(map[string]interface{}); ok {
+		if _, ok := rawHooks["bypass"]; ok {
+			cfg.GitHooks.Bypass = fileCfg.GitHooks.Bypass
+			tc.SetSourceWithPath("git_hooks.bypass", source, path)
+		}
+		if _, ok := rawHooks["approved"]; ok {
+			cfg.GitHooks.Approved = fileCfg.GitHooks.Approved
+			tc.SetSourceWithPath("git_hooks.approved", source, path)
+		}
+	}
 	if _, ok := raw["claude_path"]; ok {
 		cfg.ClaudePath = ExpandPath(fileCfg.ClaudePath)
 		tc.SetSourceWithPath("claude_path", source, path)
@@ -239,6 +267,10 @@ func mergeConfigWithPath(tc *TrackedConfig, fileCfg *Config, raw map[string]inte
 		cfg.CodexPath = ExpandPath(fileCfg.CodexPath)
 		tc.SetSourceWithPath("codex_path", source, path)
 	}
+	if _, ok := raw["fake_model"]; ok {
+		cfg.FakeModel = ExpandPath(fileCfg.FakeModel)
+		tc.SetSourceWithPath("fake_model", source, path)
+	}
 	if _, ok := raw["dangerously_skip_permissions"]; ok {
 		cfg.DangerouslySkipPermissions = fileCfg.DangerouslySkipPermissions
 		tc.SetSourceWithPath("dangerously_skip_permissions", source, path)
@@ -255,8 +287,44 @@ func mergeConfigWithPath(tc *TrackedConfig, fileCfg *Config, raw map[string]inte
 		cfg.Workflow = fileCfg.Workflow
 		tc.SetSourceWithPath("workflow", source, path)
 	}
+	if _, ok := raw["features"]; ok {
+		// Merged per feature, so personal config can flip one project flag
+		if cfg.FeatureFlags == nil {
+			cfg.FeatureFlags = make(map[string]bool)
+		}
+		for name, enabled := range fileCfg.FeatureFlags {
+			cfg.FeatureFlags[name] = enabled
+		}
+		tc.SetSourceWithPath("features", source, path)
+	}
+
+	if _, ok := raw["prompt_variants"]; ok {
+		// Merged per category, so a later file can add or clear one mapping
+		if cfg.PromptVariants == nil {
+			cfg.PromptVariants = make(map[string]string)
+		}
+		for category, variant := range fileCfg.PromptVariants {
+			cfg.PromptVariants[category] = variant
+		}
+		tc.SetSourceWithPath("prompt_variants", source, path)
+	}
+
+	if _, ok := raw["phase_models"]; ok {
+		// Merged per workflow, so a project file can add a workflow's
+		// phase models without repeating the user's
+		if cfg.PhaseModels == nil {
+			cfg.PhaseModels = make(map[string]map[string]string)
+		}
+		for workflowID, models := range fileCfg.PhaseModels {
+			cfg.PhaseModels[workflowID] = models
+		}
+		tc.SetSourceWithPath("phase_models", source, path)
+	}
 
 	// Nested configs
+	if rawDefaults, ok := raw["workflow_defaults"].(map[string]interface{}); ok {
+		mergeWorkflowDefaultsWithPath(cfg, fileCfg, rawDefaults, tc, source, path)
+	}
 	if rawGates, ok := raw["gates"].(map[string]interface{}); ok {
 		mergeGatesConfigWithPath(cfg, fileCfg, rawGates, tc, source, path)
 	}
@@ -290,6 +358,9 @@ func mergeConfigWithPath(tc *TrackedConfig, fileCfg *Config, raw map[string]inte
 	if rawIdentity, ok := raw["identity"].(map[string]interface{}); ok {
 		mergeIdentityConfigWithPath(cfg, fileCfg, rawIdentity, tc, source, path)
 	}
+	if rawUser, ok := raw["user"].(map[string]interface{}); ok {
+		mergeUserConfigWithPath(cfg, fileCfg, rawUser, tc, source, path)
+	}
 	if rawDatabase, ok := raw["database"].(map[string]interface{}); ok {
 		mergeDatabaseConfigWithPath(cfg, fileCfg, rawDatabase, tc, source, path)
 	}
@@ -302,6 +373,54 @@ func mergeConfigWithPath(tc *TrackedConfig, fileCfg *Config, raw map[string]inte
 	if rawHosting, ok := raw["hosting"].(map[string]interface{}); ok {
 		mergeHostingConfigWithPath(cfg, fileCfg, rawHosting, tc, source, path)
 	}
+	if rawSkills, ok := raw["skills"].(map[string]interface{}); ok {
+		mergeSkillsConfigWithPath(cfg, fileCfg, rawSkills, tc, source, path)
+	}
+	if rawUpdates, ok := raw["updates"].(map[string]interface{}); ok {
+		mergeUpdatesConfigWithPath(cfg, fileCfg, rawUpdates, tc, source, path)
+	}
+	if rawBackoff, ok := raw["backoff"].(map[string]interface{}); ok {
+		mergeBackoffConfigWithPath(cfg, fileCfg, rawBackoff, tc, source, path)
+	}
+	if rawWebhooks, ok := raw["webhooks"].(map[string]interface{}); ok {
+		mergeWebhooksConfigWithPath(cfg, fileCfg, rawWebhooks, tc, source, path)
+	}
+	if rawDocs, ok := raw["documentation"].(map[string]interface{}); ok {
+		mergeDocumentationConfigWithPath(cfg, fileCfg, rawDocs, tc, source, path)
+	}
+	if rawStorage, ok := raw["storage"].(map[string]interface{}); ok {
+		mergeStorageConfigWithPath(cfg, fileCfg, rawStorage, tc, source, path)
+	}
+	if rawTimeouts, ok := raw["timeouts"].(map[string]interface{}); ok {
+		mergeTimeoutsConfigWithPath(cfg, fileCfg, rawTimeouts, tc, source, path)
+	}
+	if rawValidation, ok := raw["validation"].(map[string]interface{}); ok {
+		mergeValidationConfigWithPath(cfg, fileCfg, rawValidation, tc, source, path)
+	}
+	if rawVoting, ok := raw["voting"].(map[string]interface{}); ok {
+		mergeVotingConfigWithPath(cfg, fileCfg, rawVoting, tc, source, path)
+	}
+	if rawBinary, ok := raw["binary_files"].(map[string]interface{}); ok {
+		mergeBinaryFilesConfigWithPath(cfg, fileCfg, rawBinary, tc, source, path)
+	}
+	if rawChecks, ok := raw["quality_checks"].(map[string]interface{}); ok {
+		mergeQualityChecksConfigWithPath(cfg, fileCfg, rawChecks, tc, source, path)
+	}
+	if rawSensitive, ok := raw["sensitive_paths"].(map[string]interface{}); ok {
+		mergeSensitivePathsConfigWithPath(cfg, fileCfg, rawSensitive, tc, source, path)
+	}
+	if rawCompliance, ok := raw["compliance"].(map[string]interface{}); ok {
+		mergeComplianceConfigWithPath(cfg, fileCfg, rawCompliance, tc, source, path)
+	}
+	if rawReview, ok := raw["review"].(map[string]interface{}); ok {
+		mergeReviewConfigWithPath(cfg, fileCfg, rawReview, tc, source, path)
+	}
+	if rawRefactor, ok := raw["refactor_safety"].(map[string]interface{}); ok {
+		mergeRefactorSafetyConfigWithPath(cfg, fileCfg, rawRefactor, tc, source, path)
+	}
+	if rawTasks, ok := raw["tasks"].(map[string]interface{}); ok {
+		mergeTasksConfigWithPath(cfg, fileCfg, rawTasks, tc, source, path)
+	}
 }
 
 func mergeGatesConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
@@ -329,6 +448,28 @@ func mergeGatesConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]inter
 		cfg.Gates.WeightOverrides = fileCfg.Gates.WeightOverrides
 		tc.SetSourceWithPath("gates.weight_overrides", source, path)
 	}
+	if rawTimeout, ok := raw["timeout"].(map[string]interface{}); ok {
+		if _, ok := rawTimeout["after"]; ok {
+			cfg.Gates.Timeout.After = fileCfg.Gates.Timeout.After
+			tc.SetSourceWithPath("gates.timeout.after", source, path)
+		}
+		if _, ok := rawTimeout["action"]; ok {
+			cfg.Gates.Timeout.Action = fileCfg.Gates.Timeout.Action
+			tc.SetSourceWithPath("gates.timeout.action", source, path)
+		}
+		if _, ok := rawTimeout["reassign_to"]; ok {
+			cfg.Gates.Timeout.ReassignTo = fileCfg.Gates.Timeout.ReassignTo
+			tc.SetSourceWithPath("gates.timeout.reassign_to", source, path)
+		}
+		if _, ok := rawTimeout["approve_weights"]; ok {
+			cfg.Gates.Timeout.ApproveWeights = fileCfg.Gates.Timeout.ApproveWeights
+			tc.SetSourceWithPath("gates.timeout.approve_weights", source, path)
+		}
+		if _, ok := rawTimeout["phase_overrides"]; ok {
+			cfg.Gates.Timeout.PhaseOverrides = fileCfg.Gates.Timeout.PhaseOverrides
+			tc.SetSourceWithPath("gates.timeout.phase_overrides", source, path)
+		}
+	}
 }
 
 func mergeRetryConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
@@ -344,6 +485,20 @@ func mergeRetryConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]inter
 		cfg.Retry.RetryMap = fileCfg.Retry.RetryMap
 		tc.SetSourceWithPath("retry.retry_map", source, path)
 	}
+	if rawReplan, ok := raw["replan"].(map[string]interface{}); ok {
+		if _, ok := rawReplan["enabled"]; ok {
+			cfg.Retry.Replan.Enabled = fileCfg.Retry.Replan.Enabled
+			tc.SetSourceWithPath("retry.replan.enabled", source, path)
+		}
+		if _, ok := rawReplan["max_replans"]; ok {
+			cfg.Retry.Replan.MaxReplans = fileCfg.Retry.Replan.MaxReplans
+			tc.SetSourceWithPath("retry.replan.max_replans", source, path)
+		}
+		if _, ok := rawReplan["gate"]; ok {
+			cfg.Retry.Replan.Gate = fileCfg.Retry.Replan.Gate
+			tc.SetSourceWithPath("retry.replan.gate", source, path)
+		}
+	}
 }
 
 func mergeWorktreeConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
@@ -363,6 +518,20 @@ func mergeWorktreeConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]in
 		cfg.Worktree.CleanupOnFail = fileCfg.Worktree.CleanupOnFail
 		tc.SetSourceWithPath("worktree.cleanup_on_fail", source, path)
 	}
+	if rawPreflight, ok := raw["preflight"].(map[string]interface{}); ok {
+		if _, ok := rawPreflight["enabled"]; ok {
+			cfg.Worktree.Preflight.Enabled = fileCfg.Worktree.Preflight.Enabled
+			tc.SetSourceWithPath("worktree.preflight.enabled", source, path)
+		}
+		if _, ok := rawPreflight["check_remote"]; ok {
+			cfg.Worktree.Preflight.CheckRemote = fileCfg.Worktree.Preflight.CheckRemote
+			tc.SetSourceWithPath("worktree.preflight.check_remote", source, path)
+		}
+		if _, ok := rawPreflight["min_free_disk_mb"]; ok {
+			cfg.Worktree.Preflight.MinFreeDiskMB = fileCfg.Worktree.Preflight.MinFreeDiskMB
+			tc.SetSourceWithPath("worktree.preflight.min_free_disk_mb", source, path)
+		}
+	}
 }
 
 func mergeCompletionConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
@@ -370,6 +539,10 @@ func mergeCompletionConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]
 		cfg.Completion.Action = fileCfg.Completion.Action
 		tc.SetSourceWithPath("completion.action", source, path)
 	}
+	if _, ok := raw["provider"]; ok {
+		cfg.Completion.Provider = fileCfg.Completion.Provider
+		tc.SetSourceWithPath("completion.provider", source, path)
+	}
 	if _, ok := raw["target_branch"]; ok {
 		cfg.Completion.TargetBranch = fileCfg.Completion.TargetBranch
 		tc.SetSourceWithPath("completion.target_branch", source, path)
@@ -378,6 +551,42 @@ func mergeCompletionConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]
 		cfg.Completion.DeleteBranch = fileCfg.Completion.DeleteBranch
 		tc.SetSourceWithPath("completion.delete_branch", source, path)
 	}
+	if _, ok := raw["protected_branches"]; ok {
+		cfg.Completion.ProtectedBranches = fileCfg.Completion.ProtectedBranches
+		tc.SetSourceWithPath("completion.protected_branches", source, path)
+	}
+	if _, ok := raw["verify_branch_protection"]; ok {
+		cfg.Completion.VerifyBranchProtection = fileCfg.Completion.VerifyBranchProtection
+		tc.SetSourceWithPath("completion.verify_branch_protection", source, path)
+	}
+	if rawFollowUps, ok := raw["follow_ups"].(map[string]interface{}); ok {
+		if _, ok := rawFollowUps["create"]; ok {
+			cfg.Completion.FollowUps.Create = fileCfg.Completion.FollowUps.Create
+			tc.SetSourceWithPath("completion.follow_ups.create", source, path)
+		}
+		if _, ok := rawFollowUps["labels"]; ok {
+			cfg.Completion.FollowUps.Labels = fileCfg.Completion.FollowUps.Labels
+			tc.SetSourceWithPath("completion.follow_ups.labels", source, path)
+		}
+	}
+	if rawRelease, ok := raw["release"].(map[string]interface{}); ok {
+		if _, ok := rawRelease["prefix"]; ok {
+			cfg.Completion.Release.Prefix = fileCfg.Completion.Release.Prefix
+			tc.SetSourceWithPath("completion.release.prefix", source, path)
+		}
+		if _, ok := rawRelease["backport"]; ok {
+			cfg.Completion.Release.Backport = fileCfg.Completion.Release.Backport
+			tc.SetSourceWithPath("completion.release.backport", source, path)
+		}
+		if _, ok := rawRelease["backport_to"]; ok {
+			cfg.Completion.Release.BackportTo = fileCfg.Completion.Release.BackportTo
+			tc.SetSourceWithPath("completion.release.backport_to", source, path)
+		}
+		if _, ok := rawRelease["workflow"]; ok {
+			cfg.Completion.Release.Workflow = fileCfg.Completion.Release.Workflow
+			tc.SetSourceWithPath("completion.release.workflow", source, path)
+		}
+	}
 	// PR config is nested further
 	if rawPR, ok := raw["pr"].(map[string]interface{}); ok {
 		if _, ok := rawPR["title"]; ok {
@@ -475,6 +684,14 @@ func mergeExecutionConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]i
 		cfg.Execution.MaxRetries = fileCfg.Execution.MaxRetries
 		tc.SetSourceWithPath("execution.max_retries", source, path)
 	}
+	if _, ok := raw["incremental_context"]; ok {
+		cfg.Execution.IncrementalContext = fileCfg.Execution.IncrementalContext
+		tc.SetSourceWithPath("execution.incremental_context", source, path)
+	}
+	if _, ok := raw["selective_retry_tests"]; ok {
+		cfg.Execution.SelectiveRetryTests = fileCfg.Execution.SelectiveRetryTests
+		tc.SetSourceWithPath("execution.selective_retry_tests", source, path)
+	}
 }
 
 func mergeBudgetConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
@@ -512,6 +729,20 @@ func mergeServerConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]inte
 		cfg.Server.Port = fileCfg.Server.Port
 		tc.SetSourceWithPath("server.port", source, path)
 	}
+	if _, ok := raw["read_only"]; ok {
+		cfg.Server.ReadOnly = fileCfg.Server.ReadOnly
+		tc.SetSourceWithPath("server.read_only", source, path)
+	}
+	if rawAccessLog, ok := raw["access_log"].(map[string]interface{}); ok {
+		if _, ok := rawAccessLog["enabled"]; ok {
+			cfg.Server.AccessLog.Enabled = fileCfg.Server.AccessLog.Enabled
+			tc.SetSourceWithPath("server.access_log.enabled", source, path)
+		}
+		if _, ok := rawAccessLog["sample_rate"]; ok {
+			cfg.Server.AccessLog.SampleRate = fileCfg.Server.AccessLog.SampleRate
+			tc.SetSourceWithPath("server.access_log.sample_rate", source, path)
+		}
+	}
 	// Auth is nested
 	if rawAuth, ok := raw["auth"].(map[string]interface{}); ok {
 		if _, ok := rawAuth["enabled"]; ok {
@@ -522,6 +753,58 @@ func mergeServerConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]inte
 			cfg.Server.Auth.Type = fileCfg.Server.Auth.Type
 			tc.SetSourceWithPath("server.auth.type", source, path)
 		}
+		if _, ok := rawAuth["require_for_reads"]; ok {
+			cfg.Server.Auth.RequireForReads = fileCfg.Server.Auth.RequireForReads
+			tc.SetSourceWithPath("server.auth.require_for_reads", source, path)
+		}
+	}
+	if rawTenancy, ok := raw["tenancy"].(map[string]interface{}); ok {
+		if _, ok := rawTenancy["enabled"]; ok {
+			cfg.Server.Tenancy.Enabled = fileCfg.Server.Tenancy.Enabled
+			tc.SetSourceWithPath("server.tenancy.enabled", source, path)
+		}
+	}
+	if rawHA, ok := raw["ha"].(map[string]interface{}); ok {
+		if _, ok := rawHA["enabled"]; ok {
+			cfg.Server.HA.Enabled = fileCfg.Server.HA.Enabled
+			tc.SetSourceWithPath("server.ha.enabled", source, path)
+		}
+		if _, ok := rawHA["instance_id"]; ok {
+			cfg.Server.HA.InstanceID = fileCfg.Server.HA.InstanceID
+			tc.SetSourceWithPath("server.ha.instance_id", source, path)
+		}
+		if _, ok := rawHA["lease_ttl"]; ok {
+			cfg.Server.HA.LeaseTTL = fileCfg.Server.HA.LeaseTTL
+			tc.SetSourceWithPath("server.ha.lease_ttl", source, path)
+		}
+		if _, ok := rawHA["event_poll_interval"]; ok {
+			cfg.Server.HA.EventPollInterval = fileCfg.Server.HA.EventPollInterval
+			tc.SetSourceWithPath("server.ha.event_poll_interval", source, path)
+		}
+	}
+	if rawScheduler, ok := raw["scheduler"].(map[string]interface{}); ok {
+		if _, ok := rawScheduler["max_running_tasks"]; ok {
+			cfg.Server.Scheduler.MaxRunningTasks = fileCfg.Server.Scheduler.MaxRunningTasks
+			tc.SetSourceWithPath("server.scheduler.max_running_tasks", source, path)
+		}
+		if _, ok := rawScheduler["policy"]; ok {
+			cfg.Server.Scheduler.Policy = fileCfg.Server.Scheduler.Policy
+			tc.SetSourceWithPath("server.scheduler.policy", source, path)
+		}
+		if _, ok := rawScheduler["project_weights"]; ok {
+			cfg.Server.Scheduler.ProjectWeights = fileCfg.Server.Scheduler.ProjectWeights
+			tc.SetSourceWithPath("server.scheduler.project_weights", source, path)
+		}
+	}
+	if rawSocket, ok := raw["socket"].(map[string]interface{}); ok {
+		if _, ok := rawSocket["enabled"]; ok {
+			cfg.Server.Socket.Enabled = fileCfg.Server.Socket.Enabled
+			tc.SetSourceWithPath("server.socket.enabled", source, path)
+		}
+		if _, ok := rawSocket["path"]; ok {
+			cfg.Server.Socket.Path = fileCfg.Server.Socket.Path
+			tc.SetSourceWithPath("server.socket.path", source, path)
+		}
 	}
 }
 
@@ -544,6 +827,116 @@ func mergeHostingConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]int
 	}
 }
 
+func mergeSkillsConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	if _, ok := raw["index"]; ok {
+		cfg.Skills.Index = fileCfg.Skills.Index
+		tc.SetSourceWithPath("skills.index", source, path)
+	}
+}
+
+func mergeUpdatesConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	if _, ok := raw["check"]; ok {
+		cfg.Updates.Check = fileCfg.Updates.Check
+		tc.SetSourceWithPath("updates.check", source, path)
+	}
+	if _, ok := raw["feed_url"]; ok {
+		cfg.Updates.FeedURL = fileCfg.Updates.FeedURL
+		tc.SetSourceWithPath("updates.feed_url", source, path)
+	}
+	if _, ok := raw["auto_download"]; ok {
+		cfg.Updates.AutoDownload = fileCfg.Updates.AutoDownload
+		tc.SetSourceWithPath("updates.auto_download", source, path)
+	}
+	if _, ok := raw["public_key"]; ok {
+		cfg.Updates.PublicKey = fileCfg.Updates.PublicKey
+		tc.SetSourceWithPath("updates.public_key", source, path)
+	}
+}
+
+func mergeWorkflowDefaultsWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	fields := []struct {
+		key      string
+		dst, src *string
+	}{
+		{"feature", &cfg.WorkflowDefaults.Feature, &fileCfg.WorkflowDefaults.Feature},
+		{"bug", &cfg.WorkflowDefaults.Bug, &fileCfg.WorkflowDefaults.Bug},
+		{"refactor", &cfg.WorkflowDefaults.Refactor, &fileCfg.WorkflowDefaults.Refactor},
+		{"chore", &cfg.WorkflowDefaults.Chore, &fileCfg.WorkflowDefaults.Chore},
+		{"docs", &cfg.WorkflowDefaults.Docs, &fileCfg.WorkflowDefaults.Docs},
+		{"test", &cfg.WorkflowDefaults.Test, &fileCfg.WorkflowDefaults.Test},
+		{"default", &cfg.WorkflowDefaults.Default, &fileCfg.WorkflowDefaults.Default},
+	}
+	for _, f := range fields {
+		if _, ok := raw[f.key]; ok {
+			*f.dst = *f.src
+			tc.SetSourceWithPath("workflow_defaults."+f.key, source, path)
+		}
+	}
+}
+
+func mergeWebhooksConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	if _, ok := raw["endpoints"]; ok {
+		cfg.Webhooks.Endpoints = fileCfg.Webhooks.Endpoints
+		tc.SetSourceWithPath("webhooks.endpoints", source, path)
+	}
+	if _, ok := raw["timeout"]; ok {
+		cfg.Webhooks.Timeout = fileCfg.Webhooks.Timeout
+		tc.SetSourceWithPath("webhooks.timeout", source, path)
+	}
+}
+
+func mergeBackoffConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	if _, ok := raw["max_attempts"]; ok {
+		cfg.Backoff.MaxAttempts = fileCfg.Backoff.MaxAttempts
+		tc.SetSourceWithPath("backoff.max_attempts", source, path)
+	}
+	if _, ok := raw["initial"]; ok {
+		cfg.Backoff.Initial = fileCfg.Backoff.Initial
+		tc.SetSourceWithPath("backoff.initial", source, path)
+	}
+	if _, ok := raw["max"]; ok {
+		cfg.Backoff.Max = fileCfg.Backoff.Max
+		tc.SetSourceWithPath("backoff.max", source, path)
+	}
+	if _, ok := raw["multiplier"]; ok {
+		cfg.Backoff.Multiplier = fileCfg.Backoff.Multiplier
+		tc.SetSourceWithPath("backoff.multiplier", source, path)
+	}
+	if _, ok := raw["jitter"]; ok {
+		cfg.Backoff.Jitter = fileCfg.Backoff.Jitter
+		tc.SetSourceWithPath("backoff.jitter", source, path)
+	}
+}
+
+func mergeDocumentationConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	rawDrift, ok := raw["drift_check"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	drift := &cfg.Documentation.DriftCheck
+	fileDrift := fileCfg.Documentation.DriftCheck
+	if _, ok := rawDrift["enabled"]; ok {
+		drift.Enabled = fileDrift.Enabled
+		tc.SetSourceWithPath("documentation.drift_check.enabled", source, path)
+	}
+	if _, ok := rawDrift["interval"]; ok {
+		drift.Interval = fileDrift.Interval
+		tc.SetSourceWithPath("documentation.drift_check.interval", source, path)
+	}
+	if _, ok := rawDrift["threshold"]; ok {
+		drift.Threshold = fileDrift.Threshold
+		tc.SetSourceWithPath("documentation.drift_check.threshold", source, path)
+	}
+	if _, ok := rawDrift["create_task"]; ok {
+		drift.CreateTask = fileDrift.CreateTask
+		tc.SetSourceWithPath("documentation.drift_check.create_task", source, path)
+	}
+	if _, ok := rawDrift["template"]; ok {
+		drift.Template = fileDrift.Template
+		tc.SetSourceWithPath("documentation.drift_check.template", source, path)
+	}
+}
+
 func mergeTeamConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
 	if _, ok := raw["name"]; ok {
 		cfg.Team.Name = fileCfg.Team.Name
@@ -597,7 +990,26 @@ func mergeIdentityConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]in
 	}
 }
 
+func mergeUserConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	if _, ok := raw["name"]; ok {
+		cfg.User.Name = fileCfg.User.Name
+		tc.SetSourceWithPath("user.name", source, path)
+	}
+	if _, ok := raw["email"]; ok {
+		cfg.User.Email = fileCfg.User.Email
+		tc.SetSourceWithPath("user.email", source, path)
+	}
+}
+
 func mergeDatabaseConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	if _, ok := raw["dialect"]; ok {
+		cfg.Database.Dialect = fileCfg.Database.Dialect
+		tc.SetSourceWithPath("database.dialect", source, path)
+	}
+	if _, ok := raw["dsn_env"]; ok {
+		cfg.Database.DSNEnv = fileCfg.Database.DSNEnv
+		tc.SetSourceWithPath("database.dsn_env", source, path)
+	}
 	if _, ok := raw["driver"]; ok {
 		cfg.Database.Driver = fileCfg.Database.Driver
 		tc.SetSourceWithPath("database.driver", source, path)
@@ -646,6 +1058,263 @@ func mergeDatabaseConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]in
 	}
 }
 
+func mergeStorageConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	if _, ok := raw["mode"]; ok {
+		cfg.Storage.Mode = fileCfg.Storage.Mode
+		tc.SetSourceWithPath("storage.mode", source, path)
+	}
+	if rawFiles, ok := raw["files"].(map[string]interface{}); ok {
+		if _, ok := rawFiles["cleanup_on_complete"]; ok {
+			cfg.Storage.Files.CleanupOnComplete = fileCfg.Storage.Files.CleanupOnComplete
+			tc.SetSourceWithPath("storage.files.cleanup_on_complete", source, path)
+		}
+		if _, ok := rawFiles["write_through"]; ok {
+			cfg.Storage.Files.WriteThrough = fileCfg.Storage.Files.WriteThrough
+			tc.SetSourceWithPath("storage.files.write_through", source, path)
+		}
+	}
+	rawDatabase, ok := raw["database"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	if _, ok := rawDatabase["cache_transcripts"]; ok {
+		cfg.Storage.Database.CacheTranscripts = fileCfg.Storage.Database.CacheTranscripts
+		tc.SetSourceWithPath("storage.database.cache_transcripts", source, path)
+	}
+	if _, ok := rawDatabase["retention_days"]; ok {
+		cfg.Storage.Database.RetentionDays = fileCfg.Storage.Database.RetentionDays
+		tc.SetSourceWithPath("storage.database.retention_days", source, path)
+	}
+	if _, ok := rawDatabase["maintenance_interval"]; ok {
+		cfg.Storage.Database.MaintenanceInterval = fileCfg.Storage.Database.MaintenanceInterval
+		tc.SetSourceWithPath("storage.database.maintenance_interval", source, path)
+	}
+}
+
+func mergeTimeoutsConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	if _, ok := raw["phase_max"]; ok {
+		cfg.Timeouts.PhaseMax = fileCfg.Timeouts.PhaseMax
+		tc.SetSourceWithPath("timeouts.phase_max", source, path)
+	}
+	if _, ok := raw["turn_max"]; ok {
+		cfg.Timeouts.TurnMax = fileCfg.Timeouts.TurnMax
+		tc.SetSourceWithPath("timeouts.turn_max", source, path)
+	}
+	if _, ok := raw["idle_warning"]; ok {
+		cfg.Timeouts.IdleWarning = fileCfg.Timeouts.IdleWarning
+		tc.SetSourceWithPath("timeouts.idle_warning", source, path)
+	}
+	if _, ok := raw["heartbeat_interval"]; ok {
+		cfg.Timeouts.HeartbeatInterval = fileCfg.Timeouts.HeartbeatInterval
+		tc.SetSourceWithPath("timeouts.heartbeat_interval", source, path)
+	}
+	if _, ok := raw["idle_timeout"]; ok {
+		cfg.Timeouts.IdleTimeout = fileCfg.Timeouts.IdleTimeout
+		tc.SetSourceWithPath("timeouts.idle_timeout", source, path)
+	}
+	if _, ok := raw["task_max"]; ok {
+		cfg.Timeouts.TaskMax = fileCfg.Timeouts.TaskMax
+		tc.SetSourceWithPath("timeouts.task_max", source, path)
+	}
+}
+
+func mergeValidationConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	if _, ok := raw["enabled"]; ok {
+		cfg.Validation.Enabled = fileCfg.Validation.Enabled
+		tc.SetSourceWithPath("validation.enabled", source, path)
+	}
+	if _, ok := raw["model"]; ok {
+		cfg.Validation.Model = fileCfg.Validation.Model
+		tc.SetSourceWithPath("validation.model", source, path)
+	}
+	if rawConfidence, ok := raw["confidence"].(map[string]interface{}); ok {
+		if _, ok := rawConfidence["enabled"]; ok {
+			cfg.Validation.Confidence.Enabled = fileCfg.Validation.Confidence.Enabled
+			tc.SetSourceWithPath("validation.confidence.enabled", source, path)
+		}
+		if _, ok := rawConfidence["escalate_below"]; ok {
+			cfg.Validation.Confidence.EscalateBelow = fileCfg.Validation.Confidence.EscalateBelow
+			tc.SetSourceWithPath("validation.confidence.escalate_below", source, path)
+		}
+	}
+	if rawTraceability, ok := raw["traceability"].(map[string]interface{}); ok {
+		if _, ok := rawTraceability["enabled"]; ok {
+			cfg.Validation.Traceability.Enabled = fileCfg.Validation.Traceability.Enabled
+			tc.SetSourceWithPath("validation.traceability.enabled", source, path)
+		}
+		if _, ok := rawTraceability["phases"]; ok {
+			cfg.Validation.Traceability.Phases = fileCfg.Validation.Traceability.Phases
+			tc.SetSourceWithPath("validation.traceability.phases", source, path)
+		}
+	}
+}
+
+func mergeVotingConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	if _, ok := raw["enabled"]; ok {
+		cfg.Voting.Enabled = fileCfg.Voting.Enabled
+		tc.SetSourceWithPath("voting.enabled", source, path)
+	}
+	if _, ok := raw["candidates"]; ok {
+		cfg.Voting.Candidates = fileCfg.Voting.Candidates
+		tc.SetSourceWithPath("voting.candidates", source, path)
+	}
+	if _, ok := raw["phases"]; ok {
+		cfg.Voting.Phases = fileCfg.Voting.Phases
+		tc.SetSourceWithPath("voting.phases", source, path)
+	}
+	if _, ok := raw["weights"]; ok {
+		cfg.Voting.Weights = fileCfg.Voting.Weights
+		tc.SetSourceWithPath("voting.weights", source, path)
+	}
+	if _, ok := raw["priorities"]; ok {
+		cfg.Voting.Priorities = fileCfg.Voting.Priorities
+		tc.SetSourceWithPath("voting.priorities", source, path)
+	}
+	if _, ok := raw["judge_model"]; ok {
+		cfg.Voting.JudgeModel = fileCfg.Voting.JudgeModel
+		tc.SetSourceWithPath("voting.judge_model", source, path)
+	}
+	if _, ok := raw["allow_merge"]; ok {
+		cfg.Voting.AllowMerge = fileCfg.Voting.AllowMerge
+		tc.SetSourceWithPath("voting.allow_merge", source, path)
+	}
+}
+
+func mergeBinaryFilesConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	if _, ok := raw["policy"]; ok {
+		cfg.BinaryFiles.Policy = fileCfg.BinaryFiles.Policy
+		tc.SetSourceWithPath("binary_files.policy", source, path)
+	}
+	if _, ok := raw["max_size_kb"]; ok {
+		cfg.BinaryFiles.MaxSizeKB = fileCfg.BinaryFiles.MaxSizeKB
+		tc.SetSourceWithPath("binary_files.max_size_kb", source, path)
+	}
+	if _, ok := raw["allow"]; ok {
+		cfg.BinaryFiles.Allow = fileCfg.BinaryFiles.Allow
+		tc.SetSourceWithPath("binary_files.allow", source, path)
+	}
+}
+
+func mergeQualityChecksConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	if _, ok := raw["parallelism"]; ok {
+		cfg.QualityChecks.Parallelism = fileCfg.QualityChecks.Parallelism
+		tc.SetSourceWithPath("quality_checks.parallelism", source, path)
+	}
+	if _, ok := raw["timeout"]; ok {
+		cfg.QualityChecks.Timeout = fileCfg.QualityChecks.Timeout
+		tc.SetSourceWithPath("quality_checks.timeout", source, path)
+	}
+	if rawHermetic, ok := raw["hermetic"].(map[string]interface{}); ok {
+		if _, ok := rawHermetic["enabled"]; ok {
+			cfg.QualityChecks.Hermetic.Enabled = fileCfg.QualityChecks.Hermetic.Enabled
+			tc.SetSourceWithPath("quality_checks.hermetic.enabled", source, path)
+		}
+		if _, ok := rawHermetic["env"]; ok {
+			cfg.QualityChecks.Hermetic.Env = fileCfg.QualityChecks.Hermetic.Env
+			tc.SetSourceWithPath("quality_checks.hermetic.env", source, path)
+		}
+	}
+}
+
+func mergeSensitivePathsConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	if _, ok := raw["deny"]; ok {
+		cfg.SensitivePaths.Deny = fileCfg.SensitivePaths.Deny
+		tc.SetSourceWithPath("sensitive_paths.deny", source, path)
+	}
+	if _, ok := raw["allow"]; ok {
+		cfg.SensitivePaths.Allow = fileCfg.SensitivePaths.Allow
+		tc.SetSourceWithPath("sensitive_paths.allow", source, path)
+	}
+}
+
+func mergeComplianceConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	if _, ok := raw["allowed_licenses"]; ok {
+		cfg.Compliance.AllowedLicenses = fileCfg.Compliance.AllowedLicenses
+		tc.SetSourceWithPath("compliance.allowed_licenses", source, path)
+	}
+	if _, ok := raw["denied_licenses"]; ok {
+		cfg.Compliance.DeniedLicenses = fileCfg.Compliance.DeniedLicenses
+		tc.SetSourceWithPath("compliance.denied_licenses", source, path)
+	}
+	if _, ok := raw["unknown_license"]; ok {
+		cfg.Compliance.UnknownLicense = fileCfg.Compliance.UnknownLicense
+		tc.SetSourceWithPath("compliance.unknown_license", source, path)
+	}
+	if _, ok := raw["approved_registries"]; ok {
+		cfg.Compliance.ApprovedRegistries = fileCfg.Compliance.ApprovedRegistries
+		tc.SetSourceWithPath("compliance.approved_registries", source, path)
+	}
+	if _, ok := raw["sbom"]; ok {
+		cfg.Compliance.SBOM = fileCfg.Compliance.SBOM
+		tc.SetSourceWithPath("compliance.sbom", source, path)
+	}
+}
+
+func mergeReviewConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	if _, ok := raw["enabled"]; ok {
+		cfg.Review.Enabled = fileCfg.Review.Enabled
+		tc.SetSourceWithPath("review.enabled", source, path)
+	}
+	if _, ok := raw["rounds"]; ok {
+		cfg.Review.Rounds = fileCfg.Review.Rounds
+		tc.SetSourceWithPath("review.rounds", source, path)
+	}
+	if _, ok := raw["require_pass"]; ok {
+		cfg.Review.RequirePass = fileCfg.Review.RequirePass
+		tc.SetSourceWithPath("review.require_pass", source, path)
+	}
+	if rawChunking, ok := raw["chunking"].(map[string]interface{}); ok {
+		if _, ok := rawChunking["enabled"]; ok {
+			cfg.Review.Chunking.Enabled = fileCfg.Review.Chunking.Enabled
+			tc.SetSourceWithPath("review.chunking.enabled", source, path)
+		}
+		if _, ok := rawChunking["max_lines"]; ok {
+			cfg.Review.Chunking.MaxLines = fileCfg.Review.Chunking.MaxLines
+			tc.SetSourceWithPath("review.chunking.max_lines", source, path)
+		}
+		if _, ok := rawChunking["max_files"]; ok {
+			cfg.Review.Chunking.MaxFiles = fileCfg.Review.Chunking.MaxFiles
+			tc.SetSourceWithPath("review.chunking.max_files", source, path)
+		}
+		if _, ok := rawChunking["parallel"]; ok {
+			cfg.Review.Chunking.Parallel = fileCfg.Review.Chunking.Parallel
+			tc.SetSourceWithPath("review.chunking.parallel", source, path)
+		}
+	}
+}
+
+func mergeRefactorSafetyConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	if _, ok := raw["enabled"]; ok {
+		cfg.RefactorSafety.Enabled = fileCfg.RefactorSafety.Enabled
+		tc.SetSourceWithPath("refactor_safety.enabled", source, path)
+	}
+	if _, ok := raw["test_command"]; ok {
+		cfg.RefactorSafety.TestCommand = fileCfg.RefactorSafety.TestCommand
+		tc.SetSourceWithPath("refactor_safety.test_command", source, path)
+	}
+	if _, ok := raw["api"]; ok {
+		cfg.RefactorSafety.API = fileCfg.RefactorSafety.API
+		tc.SetSourceWithPath("refactor_safety.api", source, path)
+	}
+}
+
+func mergeTasksConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
+	if rawStale, ok := raw["stale"].(map[string]interface{}); ok {
+		if _, ok := rawStale["after"]; ok {
+			cfg.Tasks.Stale.After = fileCfg.Tasks.Stale.After
+			tc.SetSourceWithPath("tasks.stale.after", source, path)
+		}
+		if _, ok := rawStale["check_interval"]; ok {
+			cfg.Tasks.Stale.CheckInterval = fileCfg.Tasks.Stale.CheckInterval
+			tc.SetSourceWithPath("tasks.stale.check_interval", source, path)
+		}
+		if _, ok := rawStale["action"]; ok {
+			cfg.Tasks.Stale.Action = fileCfg.Tasks.Stale.Action
+			tc.SetSourceWithPath("tasks.stale.action", source, path)
+		}
+	}
+}
+
 func mergeBriefConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
 	if _, ok := raw["max_tokens"]; ok {
 		cfg.Brief.MaxTokens = fileCfg.Brief.MaxTokens
@@ -655,6 +1324,46 @@ func mergeBriefConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]inter
 		cfg.Brief.StaleThreshold = fileCfg.Brief.StaleThreshold
 		tc.SetSourceWithPath("brief.stale_threshold", source, path)
 	}
+	if rawRelated, ok := raw["related_tasks"].(map[string]interface{}); ok {
+		if _, ok := rawRelated["enabled"]; ok {
+			cfg.Brief.RelatedTasks.Enabled = fileCfg.Brief.RelatedTasks.Enabled
+			tc.SetSourceWithPath("brief.related_tasks.enabled", source, path)
+		}
+		if _, ok := rawRelated["limit"]; ok {
+			cfg.Brief.RelatedTasks.Limit = fileCfg.Brief.RelatedTasks.Limit
+			tc.SetSourceWithPath("brief.related_tasks.limit", source, path)
+		}
+		if _, ok := rawRelated["max_age"]; ok {
+			cfg.Brief.RelatedTasks.MaxAge = fileCfg.Brief.RelatedTasks.MaxAge
+			tc.SetSourceWithPath("brief.related_tasks.max_age", source, path)
+		}
+		if _, ok := rawRelated["phases"]; ok {
+			cfg.Brief.RelatedTasks.Phases = fileCfg.Brief.RelatedTasks.Phases
+			tc.SetSourceWithPath("brief.related_tasks.phases", source, path)
+		}
+	}
+	if rawLessons, ok := raw["lessons"].(map[string]interface{}); ok {
+		if _, ok := rawLessons["enabled"]; ok {
+			cfg.Brief.Lessons.Enabled = fileCfg.Brief.Lessons.Enabled
+			tc.SetSourceWithPath("brief.lessons.enabled", source, path)
+		}
+		if _, ok := rawLessons["min_occurrences"]; ok {
+			cfg.Brief.Lessons.MinOccurrences = fileCfg.Brief.Lessons.MinOccurrences
+			tc.SetSourceWithPath("brief.lessons.min_occurrences", source, path)
+		}
+		if _, ok := rawLessons["max_bytes"]; ok {
+			cfg.Brief.Lessons.MaxBytes = fileCfg.Brief.Lessons.MaxBytes
+			tc.SetSourceWithPath("brief.lessons.max_bytes", source, path)
+		}
+		if _, ok := rawLessons["max_age"]; ok {
+			cfg.Brief.Lessons.MaxAge = fileCfg.Brief.Lessons.MaxAge
+			tc.SetSourceWithPath("brief.lessons.max_age", source, path)
+		}
+		if _, ok := rawLessons["phases"]; ok {
+			cfg.Brief.Lessons.Phases = fileCfg.Brief.Lessons.Phases
+			tc.SetSourceWithPath("brief.lessons.phases", source, path)
+		}
+	}
 }
 
 func mergeProvidersConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]interface{}, tc *TrackedConfig, source ConfigSource, path string) {
@@ -677,13 +1386,19 @@ func mergeProvidersConfigWithPath(cfg *Config, fileCfg *Config, raw map[string]i
 // markDefaults marks all config paths as having SourceDefault.
 func markDefaults(tc *TrackedConfig) {
 	paths := []string{
-		"version", "profile", "provider", "model", "fallback_model", "max_turns", "timeout",
-		"branch_prefix", "commit_prefix", "claude_path", "codex_path", "dangerously_skip_permissions",
-		"templates_dir", "enable_checkpoints",
+		"version", "profile", "locale", "provider", "model", "fallback_model", "max_turns", "timeout",
+		"branch_prefix", "commit_prefix", "git_identity.name", "git_identity.email", "git_hooks.bypass", "git_hooks.approved", "claude_path", "codex_path", "fake_model", "dangerously_skip_permissions",
+		"templates_dir", "enable_checkpoints", "features",
 		"gates.default_type", "gates.auto_approve_on_success", "gates.retry_on_failure", "gates.max_retries",
+		"gates.timeout.after", "gates.timeout.action", "gates.timeout.approve_weights",
 		"retry.enabled", "retry.max_retries", "retry.retry_map",
+		"retry.replan.enabled", "retry.replan.max_replans", "retry.replan.gate",
 		"worktree.enabled", "worktree.dir", "worktree.cleanup_on_complete", "worktree.cleanup_on_fail",
-		"completion.action", "completion.target_branch", "completion.delete_branch",
+		"worktree.preflight.enabled", "worktree.preflight.check_remote", "worktree.preflight.min_free_disk_mb",
+		"completion.action", "completion.provider", "completion.target_branch", "completion.delete_branch",
+		"completion.protected_branches", "completion.verify_branch_protection",
+		"completion.follow_ups.create", "completion.follow_ups.labels",
+		"completion.release.prefix", "completion.release.backport", "completion.release.backport_to", "completion.release.workflow",
 		"completion.pr.title", "completion.pr.body_template", "completion.pr.labels",
 		"completion.pr.team_reviewers", "completion.pr.assignees", "completion.pr.maintainer_can_modify",
 		"completion.pr.auto_merge", "completion.pr.auto_approve", "completion.pr.draft",
@@ -692,19 +1407,59 @@ func markDefaults(tc *TrackedConfig) {
 		"completion.ci.merge_commit_template", "completion.ci.squash_commit_template",
 		"completion.ci.verify_sha_on_merge",
 		"execution.use_session_execution", "execution.session_persistence", "execution.checkpoint_interval", "execution.max_retries",
+		"execution.incremental_context",
+		"execution.selective_retry_tests",
 		"budget.threshold_usd", "budget.alert_on_exceed", "budget.pause_on_exceed",
 		"pool.enabled", "pool.config_path",
-		"server.host", "server.port", "server.auth.enabled", "server.auth.type",
+		"server.host", "server.port", "server.auth.enabled", "server.auth.type", "server.auth.require_for_reads", "server.read_only",
+		"server.access_log.enabled", "server.access_log.sample_rate", "server.tenancy.enabled",
+		"server.ha.enabled", "server.ha.instance_id", "server.ha.lease_ttl", "server.ha.event_poll_interval",
+		"server.scheduler.max_running_tasks", "server.scheduler.policy", "server.scheduler.project_weights",
+		"server.socket.enabled", "server.socket.path",
 		"team.name", "team.activity_logging", "team.task_claiming", "team.visibility", "team.mode", "team.server_url",
 		"task_id.mode", "task_id.prefix_source",
 		"identity.initials", "identity.display_name", "identity.email",
+		"user.name", "user.email",
+		"database.dialect", "database.dsn_env",
 		"database.driver", "database.sqlite.path", "database.sqlite.global_path",
 		"database.postgres.host", "database.postgres.port", "database.postgres.database",
 		"database.postgres.user", "database.postgres.password", "database.postgres.ssl_mode",
 		"database.postgres.pool_max",
 		"brief.max_tokens", "brief.stale_threshold",
+		"brief.related_tasks.enabled", "brief.related_tasks.limit", "brief.related_tasks.max_age", "brief.related_tasks.phases",
+		"brief.lessons.enabled", "brief.lessons.min_occurrences", "brief.lessons.max_bytes", "brief.lessons.max_age", "brief.lessons.phases",
+		"timeouts.phase_max", "timeouts.turn_max", "timeouts.idle_warning",
+		"timeouts.heartbeat_interval", "timeouts.idle_timeout", "timeouts.task_max",
+		"validation.enabled", "validation.model",
+		"validation.confidence.enabled", "validation.confidence.escalate_below",
+		"validation.traceability.enabled", "validation.traceability.phases",
+		"voting.enabled", "voting.candidates", "voting.phases", "voting.weights",
+		"voting.priorities", "voting.judge_model", "voting.allow_merge",
+		"binary_files.policy", "binary_files.max_size_kb", "binary_files.allow",
+		"quality_checks.parallelism", "quality_checks.timeout",
+		"quality_checks.hermetic.enabled", "quality_checks.hermetic.env",
+		"sensitive_paths.deny", "sensitive_paths.allow",
+		"compliance.allowed_licenses", "compliance.denied_licenses",
+		"compliance.unknown_license", "compliance.approved_registries", "compliance.sbom",
+		"review.enabled", "review.rounds", "review.require_pass",
+		"review.chunking.enabled", "review.chunking.max_lines", "review.chunking.max_files", "review.chunking.parallel",
+		"refactor_safety.enabled", "refactor_safety.test_command", "refactor_safety.api",
+		"tasks.stale.after", "tasks.stale.check_interval", "tasks.stale.action",
 		"providers.codex.path", "providers.codex.reasoning_effort",
 		"providers.rates",
+		"skills.index",
+		"updates.check", "updates.feed_url", "updates.auto_download", "updates.public_key",
+		"backoff.max_attempts", "backoff.initial", "backoff.max", "backoff.multiplier", "backoff.jitter",
+		"webhooks.endpoints", "webhooks.timeout",
+		"workflow_defaults.feature", "workflow_defaults.bug", "workflow_defaults.refactor",
+		"workflow_defaults.chore", "workflow_defaults.docs", "workflow_defaults.test",
+		"workflow_defaults.default", "prompt_variants", "phase_models",
+		"documentation.drift_check.enabled", "documentation.drift_check.interval",
+		"documentation.drift_check.threshold", "documentation.drift_check.create_task",
+		"documentation.drift_check.template",
+		"storage.mode", "storage.files.cleanup_on_complete", "storage.files.write_through",
+		"storage.database.cache_transcripts", "storage.database.retention_days",
+		"storage.database.maintenance_interval",
 	}
 
 	for _, path := range paths {
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestLoadWithSources_DefaultsOnly(t *testing.T) {
@@ -231,6 +232,100 @@ hosting:
 	}
 }
 
+func TestLoadWithSources_DocDriftConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", filepath.Join(tmpDir, "nonexistent"))
+
+	orcDir := filepath.Join(tmpDir, ".orc")
+	_ = os.MkdirAll(orcDir, 0755)
+	_ = os.WriteFile(filepath.Join(orcDir, "config.yaml"), []byte(`
+documentation:
+  drift_check:
+    interval: 30m
+    create_task: true
+`), 0644)
+
+	tc, err := LoadWithSourcesFrom(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadWithSourcesFrom failed: %v", err)
+	}
+
+	drift := tc.Config.Documentation.DriftCheck
+	if drift.Interval != 30*time.Minute || !drift.CreateTask {
+		t.Fatalf("DriftCheck = %+v, want interval 30m with create_task", drift)
+	}
+	if !drift.Enabled || drift.Threshold != 5 {
+		t.Fatalf("unset drift_check keys should keep defaults, got %+v", drift)
+	}
+	if tc.GetSource("documentation.drift_check.create_task") != SourceShared {
+		t.Fatalf("create_task source = %q, want %q", tc.GetSource("documentation.drift_check.create_task"), SourceShared)
+	}
+}
+
+func TestLoadWithSources_TimeoutsConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", filepath.Join(tmpDir, "nonexistent"))
+
+	orcDir := filepath.Join(tmpDir, ".orc")
+	_ = os.MkdirAll(orcDir, 0755)
+	_ = os.WriteFile(filepath.Join(orcDir, "config.yaml"), []byte(`
+timeouts:
+  phase_max: 20m
+  task_max:
+    large: 4h
+    default: 1h
+`), 0644)
+
+	tc, err := LoadWithSourcesFrom(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadWithSourcesFrom failed: %v", err)
+	}
+
+	cfg := tc.Config
+	if cfg.Timeouts.PhaseMax != 20*time.Minute || cfg.Timeouts.TurnMax != 10*time.Minute {
+		t.Fatalf("Timeouts = %+v, want phase_max 20m and default turn_max", cfg.Timeouts)
+	}
+	if got := cfg.TaskMaxFor("implement-large"); got != 4*time.Hour {
+		t.Errorf("TaskMaxFor(implement-large) = %v, want 4h", got)
+	}
+	if got := cfg.TaskMaxFor("implement-small"); got != time.Hour {
+		t.Errorf("TaskMaxFor(implement-small) = %v, want default 1h", got)
+	}
+	if tc.GetSource("timeouts.task_max") != SourceShared {
+		t.Fatalf("task_max source = %q, want %q", tc.GetSource("timeouts.task_max"), SourceShared)
+	}
+}
+
+func TestLoadWithSources_StorageConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", filepath.Join(tmpDir, "nonexistent"))
+
+	orcDir := filepath.Join(tmpDir, ".orc")
+	_ = os.MkdirAll(orcDir, 0755)
+	_ = os.WriteFile(filepath.Join(orcDir, "config.yaml"), []byte(`
+storage:
+  mode: hybrid
+  files:
+    write_through: true
+`), 0644)
+
+	tc, err := LoadWithSourcesFrom(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadWithSourcesFrom failed: %v", err)
+	}
+
+	storage := tc.Config.Storage
+	if storage.Mode != StorageModeHybrid || !storage.Files.WriteThrough {
+		t.Fatalf("Storage = %+v, want hybrid with write_through", storage)
+	}
+	if !storage.Files.CleanupOnComplete || storage.Database.RetentionDays != 90 {
+		t.Fatalf("unset storage keys should keep defaults, got %+v", storage)
+	}
+	if tc.GetSource("storage.files.write_through") != SourceShared {
+		t.Fatalf("write_through source = %q, want %q", tc.GetSource("storage.files.write_through"), SourceShared)
+	}
+}
+
 // TestLoadWithSources_PersonalBeatsShared verifies the key 4-level hierarchy behavior:
 // Personal settings (user preferences) override shared settings (team defaults).
 func TestLoadWithSources_PersonalBeatsShared(t *testing.T) {
@@ -645,6 +740,13 @@ func TestApplyEnvVars_Database(t *testing.T) {
 		check    func(*Config) bool
 		wantPath string
 	}{
+		{
+			name:     "db_dialect",
+			envVar:   "ORC_DB_DIALECT",
+			value:    "postgres",
+			check:    func(c *Config) bool { return c.Database.Dialect == "postgres" },
+			wantPath: "database.dialect",
+		},
 		{
 			name:     "db_driver",
 			envVar:   "ORC_DB_DRIVER",
@@ -739,9 +841,14 @@ func TestLoadWithSources_DatabaseConfig(t *testing.T) {
 	// Create shared config with database settings
 	orcDir := filepath.Join(tmpDir, ".orc")
 	_ = os.MkdirAll(orcDir, 0755)
+	t.Setenv("TEAM_DSN", "postgres://team_user@db.team.local/team_orc")
 	dbConfig := `
+user:
+  name: alice
 database:
   driver: postgres
+  dialect: postgres
+  dsn_env: TEAM_DSN
   postgres:
     host: db.team.local
     port: 5432
@@ -760,6 +867,9 @@ database:
 	if tc.Config.Database.Driver != "postgres" {
 		t.Errorf("Database.Driver = %q, want postgres", tc.Config.Database.Driver)
 	}
+	if tc.Config.Database.Dialect != "postgres" || tc.Config.Database.DSNEnv != "TEAM_DSN" {
+		t.Errorf("Database dialect/dsn_env = %q/%q, want postgres/TEAM_DSN", tc.Config.Database.Dialect, tc.Config.Database.DSNEnv)
+	}
 	if tc.Config.Database.Postgres.Host != "db.team.local" {
 		t.Errorf("Database.Postgres.Host = %q, want db.team.local", tc.Config.Database.Postgres.Host)
 	}
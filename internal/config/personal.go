@@ -0,0 +1,84 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/randalmurphal/orc/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+// UserConfigPath returns the path to the user-level config (~/.orc/config.yaml).
+// Values in this file apply to every project as personal-level defaults.
+func UserConfigPath() (string, error) {
+	globalDir, err := project.GlobalPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(globalDir, ConfigFileName), nil
+}
+
+// ReadUserConfig returns the raw contents of the user-level config file.
+// A missing file is not an error: it returns empty content and exists=false.
+func ReadUserConfig() (path string, content []byte, exists bool, err error) {
+	path, err = UserConfigPath()
+	if err != nil {
+		return "", nil, false, err
+	}
+	content, err = os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return path, nil, false, nil
+		}
+		return path, nil, false, fmt.Errorf("read user config: %w", err)
+	}
+	return path, content, true, nil
+}
+
+// ValidateConfigYAML parses content as a partial config layered over defaults
+// and runs full validation. Unknown keys are rejected so typos surface
+// immediately instead of being silently ignored by the loader.
+func ValidateConfigYAML(content []byte) error {
+	cfg := Default()
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parse config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	return nil
+}
+
+// WriteUserConfig validates content and atomically replaces the user-level
+// config file. Only the keys present in content are written, so unset keys
+// keep inheriting from project config and built-in defaults.
+func WriteUserConfig(content []byte) (string, error) {
+	if err := ValidateConfigYAML(content); err != nil {
+		return "", err
+	}
+
+	path, err := UserConfigPath()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("create user config directory: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, content, 0644); err != nil {
+		return "", fmt.Errorf("write temp user config: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("rename user config: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadUserConfig_Missing(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	path, content, exists, err := ReadUserConfig()
+	if err != nil {
+		t.Fatalf("ReadUserConfig() error: %v", err)
+	}
+	if exists {
+		t.Error("exists = true, want false for missing file")
+	}
+	if len(content) != 0 {
+		t.Errorf("content = %q, want empty", content)
+	}
+	if want := filepath.Join(home, ".orc", ConfigFileName); path != want {
+		t.Errorf("path = %q, want %q", path, want)
+	}
+}
+
+func TestWriteUserConfig_RoundTrip(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	content := []byte("profile: safe\nmodel: personal-model\n")
+	path, err := WriteUserConfig(content)
+	if err != nil {
+		t.Fatalf("WriteUserConfig() error: %v", err)
+	}
+
+	_, got, exists, err := ReadUserConfig()
+	if err != nil {
+		t.Fatalf("ReadUserConfig() error: %v", err)
+	}
+	if !exists {
+		t.Fatal("exists = false after write")
+	}
+	if string(got) != string(content) {
+		t.Errorf("content = %q, want %q", got, content)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("temp file should not remain after write")
+	}
+}
+
+func TestWriteUserConfig_RejectsUnknownKey(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	_, err := WriteUserConfig([]byte("not_a_real_key: true\n"))
+	if err == nil {
+		t.Fatal("WriteUserConfig() should reject unknown keys")
+	}
+	if !strings.Contains(err.Error(), "parse config") {
+		t.Errorf("error = %v, want parse error", err)
+	}
+
+	if _, _, exists, _ := ReadUserConfig(); exists {
+		t.Error("invalid config must not be written")
+	}
+}
+
+func TestValidateConfigYAML_Empty(t *testing.T) {
+	t.Parallel()
+
+	if err := ValidateConfigYAML(nil); err != nil {
+		t.Errorf("empty config should be valid, got %v", err)
+	}
+}
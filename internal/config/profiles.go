@@ -0,0 +1,206 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/randalmurphal/orc/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+// ProfilesDirName is the directory under ~/.orc holding named config presets.
+const ProfilesDirName = "profiles"
+
+// ErrProfileNotFound is returned when no built-in or named preset matches.
+var ErrProfileNotFound = errors.New("profile not found")
+
+var profileNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// builtinProfiles lists the automation profiles in display order.
+var builtinProfiles = []struct {
+	profile     AutomationProfile
+	description string
+}{
+	{ProfileAuto, "Fully automated, no human intervention"},
+	{ProfileFast, "Minimal gates, speed over safety"},
+	{ProfileSafe, "Auto gates with human approval for merge"},
+	{ProfileStrict, "Human gates on spec/review/merge for critical projects"},
+}
+
+// NamedProfile is a config preset that can be applied on top of the loaded
+// configuration. Built-in profiles map to an AutomationProfile; named presets
+// are partial config.yaml files in ~/.orc/profiles/<name>.yaml.
+type NamedProfile struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	BuiltIn     bool     `json:"builtin"`
+	Path        string   `json:"path,omitempty"`
+	Keys        []string `json:"keys,omitempty"`
+
+	// content is the preset YAML without the description key.
+	content []byte
+	raw     map[string]interface{}
+}
+
+// ProfilesDir returns the directory holding named config presets (~/.orc/profiles).
+func ProfilesDir() (string, error) {
+	globalDir, err := project.GlobalPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(globalDir, ProfilesDirName), nil
+}
+
+// IsBuiltinProfile reports whether name is one of the built-in automation profiles.
+func IsBuiltinProfile(name string) bool {
+	for _, b := range builtinProfiles {
+		if string(b.profile) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ListProfiles returns the built-in automation profiles followed by the named
+// presets in ~/.orc/profiles, sorted by name. Presets that shadow a built-in
+// name are skipped since the built-in always wins.
+func ListProfiles() ([]*NamedProfile, error) {
+	profiles := make([]*NamedProfile, 0, len(builtinProfiles))
+	for _, b := range builtinProfiles {
+		profiles = append(profiles, builtinProfile(b.profile, b.description))
+	}
+
+	dir, err := ProfilesDir()
+	if err != nil {
+		return nil, err
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("list profiles: %w", err)
+	}
+	sort.Strings(matches)
+	for _, path := range matches {
+		name := strings.TrimSuffix(filepath.Base(path), ".yaml")
+		if IsBuiltinProfile(name) || !profileNamePattern.MatchString(name) {
+			continue
+		}
+		p, err := loadProfileFile(name, path)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+	return profiles, nil
+}
+
+// LoadNamedProfile resolves name to a built-in automation profile or to the
+// preset at ~/.orc/profiles/<name>.yaml.
+func LoadNamedProfile(name string) (*NamedProfile, error) {
+	for _, b := range builtinProfiles {
+		if string(b.profile) == name {
+			return builtinProfile(b.profile, b.description), nil
+		}
+	}
+	if !profileNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid profile name %q", name)
+	}
+
+	dir, err := ProfilesDir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, name+".yaml")
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s (built-in: auto, fast, safe, strict; presets: %s)", ErrProfileNotFound, name, dir)
+		}
+		return nil, fmt.Errorf("stat profile %s: %w", path, err)
+	}
+	return loadProfileFile(name, path)
+}
+
+// Apply layers the profile onto cfg. A preset that sets `profile:` applies
+// that automation profile first, so its own gate and finalize keys refine
+// the built-in presets rather than being replaced by them.
+func (p *NamedProfile) Apply(cfg *Config) {
+	if p.BuiltIn {
+		cfg.ApplyProfile(AutomationProfile(p.Name))
+		return
+	}
+
+	var fileCfg Config
+	if err := yaml.Unmarshal(p.content, &fileCfg); err != nil {
+		// Content was validated when the preset was loaded.
+		return
+	}
+	if _, ok := p.raw["profile"]; ok {
+		cfg.ApplyProfile(fileCfg.Profile)
+	}
+
+	tc := NewTrackedConfig()
+	tc.Config = cfg
+	mergeConfigWithPath(tc, &fileCfg, p.raw, SourceFlag, p.Path)
+}
+
+func builtinProfile(profile AutomationProfile, description string) *NamedProfile {
+	return &NamedProfile{
+		Name:        string(profile),
+		Description: description,
+		BuiltIn:     true,
+	}
+}
+
+// loadProfileFile parses and validates a preset file. The optional top-level
+// description key is metadata and is stripped before validation.
+func loadProfileFile(name, path string) (*NamedProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read profile %s: %w", path, err)
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse profile %s: %w", path, err)
+	}
+	if raw == nil {
+		raw = map[string]interface{}{}
+	}
+
+	var description string
+	if d, ok := raw["description"]; ok {
+		description = fmt.Sprint(d)
+		delete(raw, "description")
+	}
+
+	if base, ok := raw["profile"]; ok && !IsBuiltinProfile(fmt.Sprint(base)) {
+		return nil, fmt.Errorf("profile %s: profile must be a built-in automation profile (auto, fast, safe, strict), got %v", name, base)
+	}
+
+	content, err := yaml.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("marshal profile %s: %w", path, err)
+	}
+	if err := ValidateConfigYAML(content); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", name, err)
+	}
+
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return &NamedProfile{
+		Name:        name,
+		Description: description,
+		Path:        path,
+		Keys:        keys,
+		content:     content,
+		raw:         raw,
+	}, nil
+}
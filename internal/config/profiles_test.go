@@ -0,0 +1,114 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeProfile writes ~/.orc/profiles/<name>.yaml under a temp HOME.
+func writeProfile(t *testing.T, home, name, content string) {
+	t.Helper()
+	dir := filepath.Join(home, ".orc", ProfilesDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNamedProfile_ApplyLayersOverBaseProfile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	writeProfile(t, home, "careful", `description: Strict gates with opus
+profile: strict
+model: opus
+gates:
+  max_retries: 2
+`)
+
+	p, err := LoadNamedProfile("careful")
+	if err != nil {
+		t.Fatalf("LoadNamedProfile: %v", err)
+	}
+	if p.BuiltIn || p.Description != "Strict gates with opus" {
+		t.Errorf("profile = %+v", p)
+	}
+
+	cfg := Default()
+	p.Apply(cfg)
+	if cfg.Profile != ProfileStrict || cfg.Model != "opus" {
+		t.Errorf("profile = %q, model = %q", cfg.Profile, cfg.Model)
+	}
+	if cfg.Gates.MaxRetries != 2 {
+		t.Errorf("gates.max_retries = %d, want 2 from the preset", cfg.Gates.MaxRetries)
+	}
+	if cfg.Gates.PhaseOverrides["spec"] != "human" {
+		t.Errorf("gates.phase_overrides = %v, want the strict presets kept", cfg.Gates.PhaseOverrides)
+	}
+}
+
+func TestLoadNamedProfile_BuiltIn(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	p, err := LoadNamedProfile("safe")
+	if err != nil {
+		t.Fatalf("LoadNamedProfile: %v", err)
+	}
+	cfg := Default()
+	p.Apply(cfg)
+	if !p.BuiltIn || cfg.Profile != ProfileSafe {
+		t.Errorf("builtin = %v, profile = %q", p.BuiltIn, cfg.Profile)
+	}
+}
+
+func TestLoadNamedProfile_Errors(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	writeProfile(t, home, "typo", "modle: opus\n")
+	writeProfile(t, home, "nested", "profile: careful\n")
+
+	if _, err := LoadNamedProfile("missing"); !errors.Is(err, ErrProfileNotFound) {
+		t.Errorf("missing: err = %v, want ErrProfileNotFound", err)
+	}
+	if _, err := LoadNamedProfile("../config"); err == nil {
+		t.Error("path traversal in the name should be rejected")
+	}
+	if _, err := LoadNamedProfile("typo"); err == nil {
+		t.Error("unknown key should be rejected")
+	}
+	if _, err := LoadNamedProfile("nested"); err == nil {
+		t.Error("a preset based on another preset should be rejected")
+	}
+}
+
+func TestListProfiles(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	writeProfile(t, home, "quick", "profile: fast\n")
+	writeProfile(t, home, "careful", "model: opus\n")
+	writeProfile(t, home, "strict", "model: shadowed\n")
+
+	profiles, err := ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles: %v", err)
+	}
+	var names []string
+	for _, p := range profiles {
+		names = append(names, p.Name)
+	}
+	want := []string{"auto", "fast", "safe", "strict", "careful", "quick"}
+	if len(names) != len(want) {
+		t.Fatalf("names = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("names = %v, want %v", names, want)
+		}
+	}
+	if careful := profiles[4]; careful.BuiltIn || len(careful.Keys) != 1 || careful.Keys[0] != "model" {
+		t.Errorf("careful = %+v", careful)
+	}
+}
@@ -30,6 +30,37 @@ type ResolutionChain struct {
 // GetResolutionChain returns the full resolution chain for a config key.
 // This shows values at all levels and which one "wins".
 func (l *Loader) GetResolutionChain(key string) (*ResolutionChain, error) {
+	tc, err := l.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	return l.resolutionChain(key, tc), nil
+}
+
+// GetInheritance returns the resolution chain for every given key, loading the
+// merged config once. An empty keys slice means every known config path.
+func (l *Loader) GetInheritance(keys []string) ([]*ResolutionChain, error) {
+	if len(keys) == 0 {
+		keys = AllConfigPaths()
+	}
+
+	tc, err := l.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	chains := make([]*ResolutionChain, 0, len(keys))
+	for _, key := range keys {
+		if _, err := tc.Config.GetValue(key); err != nil {
+			return nil, err
+		}
+		chains = append(chains, l.resolutionChain(key, tc))
+	}
+	return chains, nil
+}
+
+// resolutionChain builds the chain for key against an already-loaded config.
+func (l *Loader) resolutionChain(key string, tc *TrackedConfig) *ResolutionChain {
 	chain := &ResolutionChain{
 		Key:     key,
 		Entries: make([]ResolutionEntry, 0),
@@ -104,12 +135,7 @@ func (l *Loader) GetResolutionChain(key string) (*ResolutionChain, error) {
 		IsSet:  false, // Flags are only known at runtime
 	})
 
-	// Determine winner by loading full config
-	tc, err := l.Load()
-	if err != nil {
-		return nil, fmt.Errorf("load config: %w", err)
-	}
-
+	// Determine winner from the merged config
 	chain.FinalValue, _ = tc.Config.GetValue(key)
 	chain.WinningFrom = tc.GetTrackedSource(key)
 
@@ -123,7 +149,7 @@ func (l *Loader) GetResolutionChain(key string) (*ResolutionChain, error) {
 		}
 	}
 
-	return chain, nil
+	return chain
 }
 
 // getEnvVarForPath returns the environment variable name for a config path.
@@ -203,3 +203,46 @@ func TestSplitKeyPath(t *testing.T) {
 		})
 	}
 }
+
+func TestLoader_GetInheritance(t *testing.T) {
+	tmpDir := t.TempDir()
+	projectDir := filepath.Join(tmpDir, "project")
+	userDir := filepath.Join(tmpDir, "user")
+	_ = os.MkdirAll(filepath.Join(projectDir, OrcDir), 0755)
+	_ = os.MkdirAll(userDir, 0755)
+
+	if err := os.WriteFile(filepath.Join(projectDir, OrcDir, ConfigFileName), []byte("profile: safe\n"), 0644); err != nil {
+		t.Fatalf("write shared config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(userDir, ConfigFileName), []byte("model: personal-model\n"), 0644); err != nil {
+		t.Fatalf("write user config: %v", err)
+	}
+
+	loader := &Loader{projectDir: projectDir, userDir: userDir}
+
+	chains, err := loader.GetInheritance([]string{"model", "profile"})
+	if err != nil {
+		t.Fatalf("GetInheritance: %v", err)
+	}
+	if len(chains) != 2 {
+		t.Fatalf("got %d chains, want 2", len(chains))
+	}
+	if chains[0].FinalValue != "personal-model" || chains[0].WinningFrom.Source != SourcePersonal {
+		t.Errorf("model = %q from %s, want personal-model from personal", chains[0].FinalValue, chains[0].WinningFrom.Source)
+	}
+	if chains[1].FinalValue != "safe" || chains[1].WinningFrom.Source != SourceShared {
+		t.Errorf("profile = %q from %s, want safe from shared", chains[1].FinalValue, chains[1].WinningFrom.Source)
+	}
+
+	all, err := loader.GetInheritance(nil)
+	if err != nil {
+		t.Fatalf("GetInheritance(nil): %v", err)
+	}
+	if len(all) != len(AllConfigPaths()) {
+		t.Errorf("got %d chains, want one per config path (%d)", len(all), len(AllConfigPaths()))
+	}
+
+	if _, err := loader.GetInheritance([]string{"no.such.key"}); err == nil {
+		t.Error("GetInheritance should fail for unknown key")
+	}
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"slices"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -17,6 +19,18 @@ func TestConfig_Validate(t *testing.T) {
 			cfg:     Default(),
 			wantErr: false,
 		},
+		// Locale validation
+		{
+			name:    "valid locale with region",
+			cfg:     &Config{Locale: "de-DE", Worktree: WorktreeConfig{Enabled: true}},
+			wantErr: false,
+		},
+		{
+			name:      "unsupported locale",
+			cfg:       &Config{Locale: "xx", Worktree: WorktreeConfig{Enabled: true}},
+			wantErr:   true,
+			errSubstr: "invalid locale",
+		},
 		// Visibility validation
 		{
 			name: "valid visibility: all",
@@ -442,6 +456,28 @@ func TestConfig_Validate_HostingProvider(t *testing.T) {
 	}
 }
 
+func TestConfig_Validate_CompletionProvider(t *testing.T) {
+	t.Parallel()
+
+	for _, provider := range []string{"", "auto", "github", "gitlab"} {
+		cfg := &Config{
+			Completion: CompletionConfig{Provider: provider},
+			Worktree:   WorktreeConfig{Enabled: true},
+		}
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Validate() with completion.provider %q error = %v", provider, err)
+		}
+	}
+
+	cfg := &Config{
+		Completion: CompletionConfig{Provider: "bitbucket"},
+		Worktree:   WorktreeConfig{Enabled: true},
+	}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "completion.provider") {
+		t.Errorf("Validate() error = %v, want completion.provider error", err)
+	}
+}
+
 func TestConfig_ShouldValidateForWeight(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -543,3 +579,532 @@ func TestConfig_ShouldValidateSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestIsProtectedBranch(t *testing.T) {
+	t.Parallel()
+
+	defaults := &Config{}
+	if !defaults.IsProtectedBranch("main") || defaults.IsProtectedBranch("feature/x") {
+		t.Error("empty protected_branches should fall back to DefaultProtectedBranches")
+	}
+
+	custom := &Config{Completion: CompletionConfig{ProtectedBranches: []string{"release"}}}
+	if !custom.IsProtectedBranch("release") {
+		t.Error("release should be protected when configured")
+	}
+	if custom.IsProtectedBranch("main") {
+		t.Error("configured list should replace the defaults, not extend them")
+	}
+}
+
+func TestConfig_Validate_Replan(t *testing.T) {
+	t.Parallel()
+
+	cfg := Default()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("default config invalid: %v", err)
+	}
+
+	cfg.Retry.Replan.Gate = "ai"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "retry.replan.gate") {
+		t.Errorf("expected retry.replan.gate error, got %v", err)
+	}
+
+	cfg = Default()
+	cfg.Retry.Replan.MaxReplans = -1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "retry.replan.max_replans") {
+		t.Errorf("expected retry.replan.max_replans error, got %v", err)
+	}
+}
+
+func TestConfig_Validate_VotingCandidates(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{1, MaxVotingCandidates + 1} {
+		cfg := Default()
+		cfg.Voting.Enabled = true
+		cfg.Voting.Candidates = n
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "voting.candidates") {
+			t.Errorf("candidates=%d: expected voting.candidates error, got %v", n, err)
+		}
+	}
+
+	// Disabled voting ignores the candidate count.
+	cfg := Default()
+	cfg.Voting.Candidates = 0
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("disabled voting: %v", err)
+	}
+}
+
+func TestConfig_Validate_ReviewChunking(t *testing.T) {
+	t.Parallel()
+
+	cfg := Default()
+	cfg.Review.Chunking.MaxLines = 0
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "review.chunking") {
+		t.Errorf("expected review.chunking error, got %v", err)
+	}
+
+	// Disabled chunking ignores the limits.
+	cfg.Review.Chunking.Enabled = false
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("disabled chunking: %v", err)
+	}
+}
+
+func TestConfig_Validate_ReleaseBackport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr string
+	}{
+		{"defaults", func(c *Config) {}, ""},
+		{"unknown mode", func(c *Config) { c.Completion.Release.Backport = "rebase" }, "completion.release.backport"},
+		{"missing backport_to", func(c *Config) { c.Completion.Release.BackportTo = "" }, "backport_to is required"},
+		{"backport_to is a release branch", func(c *Config) { c.Completion.Release.BackportTo = "release/2.0" }, "cannot be a release branch"},
+		{"disabled ignores backport_to", func(c *Config) {
+			c.Completion.Release.Backport = "none"
+			c.Completion.Release.BackportTo = ""
+		}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_IsReleaseBranch(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	for branch, want := range map[string]bool{
+		"release/1.2": true,
+		"release/":    false,
+		"main":        false,
+		"releases/1":  false,
+	} {
+		if got := cfg.IsReleaseBranch(branch); got != want {
+			t.Errorf("IsReleaseBranch(%q) = %v, want %v", branch, got, want)
+		}
+	}
+}
+
+func TestConfig_Validate_BinaryFiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mutate  func(*Config)
+		wantErr string
+	}{
+		{"default", func(c *Config) {}, ""},
+		{"lfs", func(c *Config) { c.BinaryFiles.Policy = BinaryPolicyLFS }, ""},
+		{"unknown policy", func(c *Config) { c.BinaryFiles.Policy = "strip" }, "binary_files.policy"},
+		{"negative cap", func(c *Config) { c.BinaryFiles.MaxSizeKB = -1 }, "binary_files.max_size_kb"},
+		{"bad pattern", func(c *Config) { c.BinaryFiles.Allow = []string{"assets/[*.png"} }, "binary_files.allow"},
+	}
+	for _, tt := range tests {
+		cfg := Default()
+		tt.mutate(cfg)
+		err := cfg.Validate()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: err = %v, want %s error", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestConfig_Validate_SensitivePaths(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mutate  func(*Config)
+		wantErr string
+	}{
+		{"default", func(c *Config) {}, ""},
+		{"dirs and globs", func(c *Config) {
+			c.SensitivePaths.Deny = []string{".github/workflows/", "infra/**", ".env*", "*.pem"}
+			c.SensitivePaths.Allow = []string{".github/workflows/docs.yml"}
+		}, ""},
+		{"bad deny", func(c *Config) { c.SensitivePaths.Deny = []string{"secrets/[x"} }, "sensitive_paths.deny"},
+		{"empty deny", func(c *Config) { c.SensitivePaths.Deny = []string{"/"} }, "sensitive_paths.deny"},
+		{"bad allow", func(c *Config) { c.SensitivePaths.Allow = []string{"[a"} }, "sensitive_paths.allow"},
+	}
+	for _, tt := range tests {
+		cfg := Default()
+		tt.mutate(cfg)
+		err := cfg.Validate()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: err = %v, want %s error", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSensitivePathsConfig_DeniedBy(t *testing.T) {
+	t.Parallel()
+
+	cfg := SensitivePathsConfig{
+		Deny:  []string{".github/workflows/", "infra/**", "*/secrets/", ".env*", "*.pem", "config/prod.yaml"},
+		Allow: []string{".github/workflows/docs.yml"},
+	}
+	tests := map[string]string{
+		".github/workflows/ci.yml":   ".github/workflows/",
+		".github/workflows/docs.yml": "",
+		".github/CODEOWNERS":         "",
+		"infra/k8s/deploy.yaml":      "infra/**",
+		"infrastructure/main.tf":     "",
+		"svc/secrets/key.txt":        "*/secrets/",
+		".env":                       ".env*",
+		"web/.env.local":             ".env*",
+		"certs/server.pem":           "*.pem",
+		"config/prod.yaml":           "config/prod.yaml",
+		"web/config/prod.yaml":       "",
+		"internal/api/server.go":     "",
+	}
+	for p, want := range tests {
+		if got := cfg.DeniedBy(p); got != want {
+			t.Errorf("DeniedBy(%q) = %q, want %q", p, got, want)
+		}
+	}
+}
+
+func TestConfig_Validate_WorktreePreflight(t *testing.T) {
+	t.Parallel()
+
+	cfg := Default()
+	cfg.Worktree.Preflight.MinFreeDiskMB = -1
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "worktree.preflight.min_free_disk_mb") {
+		t.Errorf("err = %v, want min_free_disk_mb error", err)
+	}
+
+	cfg.Worktree.Preflight.MinFreeDiskMB = 0
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("min_free_disk_mb 0 (disabled): unexpected error %v", err)
+	}
+}
+
+func TestConfig_Validate_GitIdentity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		identity GitIdentityConfig
+		wantErr  string
+	}{
+		{"unset", GitIdentityConfig{}, ""},
+		{"complete", GitIdentityConfig{Name: "orc-bot", Email: "bot@company.com"}, ""},
+		{"name only", GitIdentityConfig{Name: "orc-bot"}, "set together"},
+		{"email only", GitIdentityConfig{Email: "bot@company.com"}, "set together"},
+		{"bracketed email", GitIdentityConfig{Name: "orc-bot", Email: "<bot@company.com>"}, "git_identity.email"},
+		{"not an address", GitIdentityConfig{Name: "orc-bot", Email: "bot"}, "git_identity.email"},
+		{"name with email", GitIdentityConfig{Name: "orc-bot <bot@company.com>", Email: "bot@company.com"}, "git_identity.name"},
+	}
+	for _, tt := range tests {
+		cfg := Default()
+		cfg.GitIdentity = tt.identity
+		err := cfg.Validate()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: err = %v, want %s error", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestConfig_Validate_QualityChecks(t *testing.T) {
+	t.Parallel()
+
+	cfg := Default()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("default config: %v", err)
+	}
+
+	cfg.QualityChecks.Parallelism = -1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "quality_checks.parallelism") {
+		t.Errorf("negative parallelism: err = %v", err)
+	}
+
+	cfg = Default()
+	cfg.QualityChecks.Timeout = -time.Second
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "quality_checks.timeout") {
+		t.Errorf("negative timeout: err = %v", err)
+	}
+
+	cfg = Default()
+	cfg.QualityChecks.Hermetic.Env = []string{"GOPROXY", "NODE_OPTIONS=--max-old-space-size=4096"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "quality_checks.hermetic.env") {
+		t.Errorf("env entry with value: err = %v", err)
+	}
+}
+
+func TestConfig_Validate_GitHooks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		hooks   GitHooksConfig
+		wantErr string
+	}{
+		{"unset", GitHooksConfig{}, ""},
+		{"named hooks", GitHooksConfig{Bypass: []string{"pre-commit", "commit-msg"}}, ""},
+		{"all with approved", GitHooksConfig{Bypass: []string{"all"}, Approved: []string{"pre-push"}}, ""},
+		{"unknown hook", GitHooksConfig{Bypass: []string{"pre-comit"}}, "git_hooks.bypass"},
+		{"unknown approved", GitHooksConfig{Bypass: []string{"all"}, Approved: []string{"lint"}}, "git_hooks.approved"},
+		{"approved without all", GitHooksConfig{Bypass: []string{"pre-commit"}, Approved: []string{"pre-push"}}, "requires git_hooks.bypass"},
+	}
+	for _, tt := range tests {
+		cfg := Default()
+		cfg.GitHooks = tt.hooks
+		err := cfg.Validate()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: err = %v, want %s error", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestGitHooksConfig_BypassedHooks(t *testing.T) {
+	t.Parallel()
+
+	named := GitHooksConfig{Bypass: []string{"pre-commit"}}
+	if got := named.BypassedHooks(); !slices.Equal(got, []string{"pre-commit"}) {
+		t.Errorf("BypassedHooks = %v, want [pre-commit]", got)
+	}
+
+	all := GitHooksConfig{Bypass: []string{"all"}, Approved: []string{"pre-push", "commit-msg"}}
+	got := all.BypassedHooks()
+	if len(got) != len(ValidGitHooks)-2 {
+		t.Errorf("BypassedHooks = %v, want every hook but the 2 approved", got)
+	}
+	if slices.Contains(got, "pre-push") || slices.Contains(got, "commit-msg") || !slices.Contains(got, "pre-commit") {
+		t.Errorf("BypassedHooks = %v, want approved hooks excluded", got)
+	}
+}
+
+func TestConfig_Validate_StaleTasks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		stale   StaleTasksConfig
+		wantErr string
+	}{
+		{"defaults", Default().Tasks.Stale, ""},
+		{"disabled", StaleTasksConfig{}, ""},
+		{"close", StaleTasksConfig{After: time.Hour, Action: StaleActionClose}, ""},
+		{"negative after", StaleTasksConfig{After: -time.Hour}, "tasks.stale.after"},
+		{"negative interval", StaleTasksConfig{After: time.Hour, CheckInterval: -time.Minute}, "tasks.stale.check_interval"},
+		{"unknown action", StaleTasksConfig{After: time.Hour, Action: "delete"}, "tasks.stale.action"},
+	}
+	for _, tt := range tests {
+		cfg := Default()
+		cfg.Tasks.Stale = tt.stale
+		err := cfg.Validate()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: err = %v, want %s error", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestConfig_Validate_GateTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		timeout GateTimeoutConfig
+		wantErr string
+	}{
+		{"defaults", Default().Gates.Timeout, ""},
+		{"fail", GateTimeoutConfig{After: time.Hour, Action: GateTimeoutFail}, ""},
+		{"reassign", GateTimeoutConfig{After: time.Hour, Action: GateTimeoutReassign, ReassignTo: "bob"}, ""},
+		{"negative after", GateTimeoutConfig{After: -time.Hour}, "gates.timeout.after"},
+		{"unknown action", GateTimeoutConfig{After: time.Hour, Action: "escalate"}, "gates.timeout.action"},
+		{"reassign without assignee", GateTimeoutConfig{After: time.Hour, Action: GateTimeoutReassign}, "gates.timeout.reassign_to"},
+		{"override action", GateTimeoutConfig{PhaseOverrides: map[string]GateTimeoutOverride{"review": {Action: "skip"}}}, "gates.timeout.phase_overrides.review.action"},
+		{"override reassign without assignee", GateTimeoutConfig{PhaseOverrides: map[string]GateTimeoutOverride{"review": {Action: GateTimeoutReassign}}}, "gates.timeout.phase_overrides.review"},
+	}
+	for _, tt := range tests {
+		cfg := Default()
+		cfg.Gates.Timeout = tt.timeout
+		err := cfg.Validate()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: err = %v, want %s error", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestGateTimeoutConfig_ForPhase(t *testing.T) {
+	t.Parallel()
+
+	cfg := GateTimeoutConfig{
+		After:      24 * time.Hour,
+		ReassignTo: "alice",
+		PhaseOverrides: map[string]GateTimeoutOverride{
+			"review": {After: 4 * time.Hour, Action: GateTimeoutReassign},
+		},
+	}
+	if got := cfg.ForPhase("spec"); got.After != 24*time.Hour || got.Action != GateTimeoutNotify || got.PhaseOverrides != nil {
+		t.Errorf("ForPhase(spec) = %+v, want the defaults with notify", got)
+	}
+	if got := cfg.ForPhase("review"); got.After != 4*time.Hour || got.Action != GateTimeoutReassign || got.ReassignTo != "alice" {
+		t.Errorf("ForPhase(review) = %+v, want 4h reassign to alice", got)
+	}
+}
+
+func TestConfig_Validate_ComplianceUnknownLicense(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range []string{"", ComplianceUnknownWarn, ComplianceUnknownBlock} {
+		cfg := Default()
+		cfg.Compliance.UnknownLicense = v
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("unknown_license %q: unexpected error %v", v, err)
+		}
+	}
+	cfg := Default()
+	cfg.Compliance.UnknownLicense = "ignore"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "compliance.unknown_license") {
+		t.Errorf("err = %v, want compliance.unknown_license error", err)
+	}
+}
+
+func TestConfig_Validate_Backoff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		backoff   BackoffConfig
+		errSubstr string
+	}{
+		{name: "defaults are valid", backoff: Default().Backoff},
+		{name: "zero value is valid", backoff: BackoffConfig{}},
+		{name: "negative attempts", backoff: BackoffConfig{MaxAttempts: -1}, errSubstr: "backoff.max_attempts"},
+		{name: "max below initial", backoff: BackoffConfig{Initial: time.Minute, Max: time.Second}, errSubstr: "backoff.max"},
+		{name: "shrinking multiplier", backoff: BackoffConfig{Multiplier: 0.5}, errSubstr: "backoff.multiplier"},
+		{name: "jitter above one", backoff: BackoffConfig{Jitter: 1.5}, errSubstr: "backoff.jitter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := Default()
+			cfg.Backoff = tt.backoff
+			err := cfg.Validate()
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestConfig_CIPollPolicy(t *testing.T) {
+	t.Parallel()
+
+	cfg := Default()
+	cfg.Backoff.Initial = 2 * time.Second
+	cfg.Completion.CI.PollInterval = 20 * time.Second
+	p := cfg.CIPollPolicy()
+	if p.Initial != 2*time.Second || p.Max != 20*time.Second {
+		t.Errorf("CIPollPolicy() = %+v, want initial 2s backing off to 20s", p)
+	}
+
+	// An initial delay longer than the poll interval is capped by it
+	cfg.Completion.CI.PollInterval = time.Second
+	p = cfg.CIPollPolicy()
+	if p.Initial != time.Second || p.Max != time.Second {
+		t.Errorf("CIPollPolicy() = %+v, want a fixed 1s interval", p)
+	}
+}
+
+func TestConfig_Validate_Webhooks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		webhooks  WebhooksConfig
+		errSubstr string
+	}{
+		{name: "defaults are valid", webhooks: Default().Webhooks},
+		{name: "endpoint with events", webhooks: WebhooksConfig{Endpoints: []WebhookEndpoint{
+			{URL: "https://hooks.example.com/orc", Events: []string{WebhookEventFailed, WebhookEventMerged}},
+		}}},
+		{name: "missing url", webhooks: WebhooksConfig{Endpoints: []WebhookEndpoint{{}}}, errSubstr: "webhooks.endpoints[0].url"},
+		{name: "non-http url", webhooks: WebhooksConfig{Endpoints: []WebhookEndpoint{{URL: "ftp://example.com"}}}, errSubstr: "http or https"},
+		{name: "unknown event", webhooks: WebhooksConfig{Endpoints: []WebhookEndpoint{
+			{URL: "https://hooks.example.com/orc", Events: []string{"task_done"}},
+		}}, errSubstr: "task_done"},
+		{name: "negative timeout", webhooks: WebhooksConfig{Timeout: -time.Second}, errSubstr: "webhooks.timeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := Default()
+			cfg.Webhooks = tt.webhooks
+			err := cfg.Validate()
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
@@ -220,32 +220,59 @@ func AllConfigPaths() []string {
 	return []string{
 		"version",
 		"profile",
+		"locale",
 		"model",
 		"fallback_model",
 		"max_turns",
 		"timeout",
 		"branch_prefix",
 		"commit_prefix",
+		"git_identity.name",
+		"git_identity.email",
+		"git_hooks.bypass",
+		"git_hooks.approved",
 		"claude_path",
+		"fake_model",
 		"dangerously_skip_permissions",
 		"templates_dir",
 		"enable_checkpoints",
+		"features",
 		"gates.default_type",
 		"gates.auto_approve_on_success",
 		"gates.retry_on_failure",
 		"gates.max_retries",
 		"gates.phase_overrides",
 		"gates.weight_overrides",
+		"gates.timeout.after",
+		"gates.timeout.action",
+		"gates.timeout.reassign_to",
+		"gates.timeout.approve_weights",
+		"gates.timeout.phase_overrides",
 		"retry.enabled",
 		"retry.max_retries",
 		"retry.retry_map",
+		"retry.replan.enabled",
+		"retry.replan.max_replans",
+		"retry.replan.gate",
 		"worktree.enabled",
 		"worktree.dir",
 		"worktree.cleanup_on_complete",
 		"worktree.cleanup_on_fail",
+		"worktree.preflight.enabled",
+		"worktree.preflight.check_remote",
+		"worktree.preflight.min_free_disk_mb",
 		"completion.action",
+		"completion.provider",
 		"completion.target_branch",
 		"completion.delete_branch",
+		"completion.protected_branches",
+		"completion.verify_branch_protection",
+		"completion.follow_ups.create",
+		"completion.follow_ups.labels",
+		"completion.release.prefix",
+		"completion.release.backport",
+		"completion.release.backport_to",
+		"completion.release.workflow",
 		"completion.pr.title",
 		"completion.pr.body_template",
 		"completion.pr.labels",
@@ -275,12 +302,43 @@ func AllConfigPaths() []string {
 		"review.enabled",
 		"review.rounds",
 		"review.require_pass",
+		"review.chunking.enabled",
+		"review.chunking.max_lines",
+		"review.chunking.max_files",
+		"review.chunking.parallel",
 		"quality_policy.mode",
 		"quality_policy.human_gate_risk_threshold",
 		"quality_policy.post_review_human_gate",
 		"quality_policy.finalize_requires_human",
 		"quality_policy.browser_qa_from_plan",
 		"quality_policy.fail_closed_on_missing_provider",
+		"voting.enabled",
+		"voting.candidates",
+		"voting.phases",
+		"voting.weights",
+		"voting.priorities",
+		"voting.judge_model",
+		"voting.allow_merge",
+		"binary_files.policy",
+		"binary_files.max_size_kb",
+		"binary_files.allow",
+		"quality_checks.parallelism",
+		"quality_checks.timeout",
+		"quality_checks.hermetic.enabled",
+		"quality_checks.hermetic.env",
+		"sensitive_paths.deny",
+		"sensitive_paths.allow",
+		"compliance.allowed_licenses",
+		"compliance.denied_licenses",
+		"compliance.unknown_license",
+		"compliance.approved_registries",
+		"compliance.sbom",
+		"refactor_safety.enabled",
+		"refactor_safety.test_command",
+		"refactor_safety.api",
+		"tasks.stale.after",
+		"tasks.stale.check_interval",
+		"tasks.stale.action",
 		"workflow_defaults.feature",
 		"workflow_defaults.bug",
 		"workflow_defaults.refactor",
@@ -288,22 +346,74 @@ func AllConfigPaths() []string {
 		"workflow_defaults.docs",
 		"workflow_defaults.test",
 		"workflow_defaults.default",
+		"prompt_variants",
+		"phase_models",
 		"execution.use_session_execution",
 		"execution.session_persistence",
 		"execution.checkpoint_interval",
+		"execution.incremental_context",
+		"execution.selective_retry_tests",
 		"budget.threshold_usd",
 		"budget.alert_on_exceed",
 		"budget.pause_on_exceed",
+		"timeouts.phase_max",
+		"timeouts.turn_max",
+		"timeouts.idle_warning",
+		"timeouts.heartbeat_interval",
+		"timeouts.idle_timeout",
+		"timeouts.task_max",
+		"validation.enabled",
+		"validation.model",
+		"validation.confidence.enabled",
+		"validation.confidence.escalate_below",
+		"validation.traceability.enabled",
+		"validation.traceability.phases",
 		"pool.enabled",
 		"pool.config_path",
 		"hosting.account",
 		"hosting.provider",
 		"hosting.base_url",
 		"hosting.token_env_var",
+		"skills.index",
+		"updates.check",
+		"updates.feed_url",
+		"updates.auto_download",
+		"updates.public_key",
+		"backoff.max_attempts",
+		"backoff.initial",
+		"backoff.max",
+		"backoff.multiplier",
+		"backoff.jitter",
+		"webhooks.timeout",
+		"documentation.drift_check.enabled",
+		"documentation.drift_check.interval",
+		"documentation.drift_check.threshold",
+		"documentation.drift_check.create_task",
+		"documentation.drift_check.template",
+		"storage.mode",
+		"storage.files.cleanup_on_complete",
+		"storage.files.write_through",
+		"storage.database.cache_transcripts",
+		"storage.database.retention_days",
+		"storage.database.maintenance_interval",
 		"server.host",
 		"server.port",
 		"server.auth.enabled",
 		"server.auth.type",
+		"server.auth.require_for_reads",
+		"server.read_only",
+		"server.access_log.enabled",
+		"server.access_log.sample_rate",
+		"server.tenancy.enabled",
+		"server.ha.enabled",
+		"server.ha.instance_id",
+		"server.ha.lease_ttl",
+		"server.ha.event_poll_interval",
+		"server.scheduler.max_running_tasks",
+		"server.scheduler.policy",
+		"server.scheduler.project_weights",
+		"server.socket.enabled",
+		"server.socket.path",
 		"team.name",
 		"team.activity_logging",
 		"team.task_claiming",
@@ -315,6 +425,10 @@ func AllConfigPaths() []string {
 		"identity.email",
 		"task_id.mode",
 		"task_id.prefix_source",
+		"user.name",
+		"user.email",
+		"database.dialect",
+		"database.dsn_env",
 		"database.driver",
 		"database.sqlite.path",
 		"database.sqlite.global_path",
@@ -327,3 +441,26 @@ func AllConfigPaths() []string {
 		"database.postgres.pool_max",
 	}
 }
+
+// secretConfigPaths are config paths whose values DiffValues masks.
+var secretConfigPaths = map[string]bool{
+	"database.postgres.password": true,
+}
+
+// DiffValues returns the config paths whose values differ between before
+// and after, with their old and new values. Secret values are masked.
+func DiffValues(before, after *Config) (old, updated map[string]string) {
+	old, updated = map[string]string{}, map[string]string{}
+	for _, path := range AllConfigPaths() {
+		b, errB := before.GetValue(path)
+		a, errA := after.GetValue(path)
+		if errB != nil || errA != nil || a == b {
+			continue
+		}
+		if secretConfigPaths[path] {
+			b, a = "********", "********"
+		}
+		old[path], updated[path] = b, a
+	}
+	return old, updated
+}
@@ -578,3 +578,21 @@ func TestConfig_SetValue_Finalize(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffValues(t *testing.T) {
+	before := Default()
+	after := Default()
+	after.Profile = ProfileSafe
+	after.Database.Postgres.Password = "hunter2"
+
+	old, updated := DiffValues(before, after)
+	if len(old) != 2 || len(updated) != 2 {
+		t.Fatalf("DiffValues() = %v -> %v, want profile and password", old, updated)
+	}
+	if old["profile"] != string(ProfileAuto) || updated["profile"] != string(ProfileSafe) {
+		t.Errorf("profile diff = %q -> %q", old["profile"], updated["profile"])
+	}
+	if updated["database.postgres.password"] != "********" {
+		t.Errorf("password not masked: %q", updated["database.postgres.password"])
+	}
+}
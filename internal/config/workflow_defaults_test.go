@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -355,3 +356,76 @@ func TestWorkflowDefaults_ResolutionPriority(t *testing.T) {
 		})
 	}
 }
+
+// TestLoadWithSources_WorkflowDefaultsAndPromptVariants tests that the
+// category mappings in config files reach the loaded config.
+func TestLoadWithSources_WorkflowDefaultsAndPromptVariants(t *testing.T) {
+	tmpDir := t.TempDir()
+	fakeHome := filepath.Join(tmpDir, "home")
+	_ = os.MkdirAll(filepath.Join(fakeHome, ".orc"), 0755)
+	t.Setenv("HOME", fakeHome)
+
+	orcDir := filepath.Join(tmpDir, ".orc")
+	_ = os.MkdirAll(orcDir, 0755)
+	_ = os.WriteFile(filepath.Join(orcDir, "config.yaml"), []byte(`workflow_defaults:
+  bug: hotfix
+prompt_variants:
+  docs: docs-only
+`), 0644)
+
+	tc, err := LoadWithSourcesFrom(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadWithSourcesFrom failed: %v", err)
+	}
+	cfg := tc.Config
+
+	if got, source := cfg.ResolveWorkflow("", "bug"); got != "hotfix" || source != "category_default" {
+		t.Errorf("ResolveWorkflow(bug) = %q, %q; want hotfix from category_default", got, source)
+	}
+	if got, _ := cfg.ResolveWorkflow("", "bugfix"); got != "hotfix" {
+		t.Errorf("ResolveWorkflow(bugfix) = %q, want the bug default", got)
+	}
+	if cfg.WorkflowDefaults.Feature != "crossmodel-standard" {
+		t.Errorf("unset category changed: feature = %q", cfg.WorkflowDefaults.Feature)
+	}
+	if tc.GetSource("workflow_defaults.bug") == SourceDefault || tc.GetSource("workflow_defaults.feature") != SourceDefault {
+		t.Errorf("workflow_defaults sources: bug=%q feature=%q", tc.GetSource("workflow_defaults.bug"), tc.GetSource("workflow_defaults.feature"))
+	}
+
+	// Variants merge per category, keeping the built-in bug variant
+	if cfg.PromptVariant("bug") != "bugfix" || cfg.PromptVariant("bugfix") != "bugfix" || cfg.PromptVariant("docs") != "docs-only" {
+		t.Errorf("prompt_variants = %v", cfg.PromptVariants)
+	}
+	if cfg.PromptVariant("feature") != "" {
+		t.Errorf("PromptVariant(feature) = %q, want none", cfg.PromptVariant("feature"))
+	}
+}
+
+func TestValidate_PromptVariants(t *testing.T) {
+	tests := []struct {
+		name     string
+		variants map[string]string
+		wantErr  string
+	}{
+		{"defaults", map[string]string{"bug": "bugfix"}, ""},
+		{"disabled", map[string]string{"bug": ""}, ""},
+		{"unknown category", map[string]string{"bugs": "bugfix"}, "invalid prompt_variants key"},
+		{"path in variant", map[string]string{"docs": "../docs"}, "invalid prompt_variants.docs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.PromptVariants = tt.variants
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package db
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// APITokenPrefix marks server API tokens so they are recognizable in logs
+// and secret scanners.
+const APITokenPrefix = "orca_"
+
+// API token scopes. Read tokens pass read-only requests when the server
+// requires tokens for reads; write tokens pass everything.
+const (
+	APITokenScopeRead  = "read"
+	APITokenScopeWrite = "write"
+)
+
+// APIToken describes a server API token without its secret.
+type APIToken struct {
+	Name       string
+	Scope      string
+	CreatedAt  time.Time
+	RotatedAt  *time.Time
+	LastUsedAt *time.Time
+}
+
+// CanWrite reports whether the token may make mutating requests.
+func (t *APIToken) CanWrite() bool {
+	return t.Scope != APITokenScopeRead
+}
+
+func newAPITokenSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return APITokenPrefix + hex.EncodeToString(buf), nil
+}
+
+// CreateAPIToken issues a new server API token and returns it. Only the hash
+// is stored, so the token cannot be shown again.
+func (g *GlobalDB) CreateAPIToken(name, scope string) (string, error) {
+	if strings.TrimSpace(name) == "" {
+		return "", fmt.Errorf("token name is required")
+	}
+	if scope != APITokenScopeRead && scope != APITokenScopeWrite {
+		return "", fmt.Errorf("invalid token scope %q (must be read or write)", scope)
+	}
+	existing, err := g.getAPIToken(name)
+	if err != nil {
+		return "", err
+	}
+	if existing != nil {
+		return "", fmt.Errorf("token %s already exists (use rotate to replace it)", name)
+	}
+	token, err := newAPITokenSecret()
+	if err != nil {
+		return "", err
+	}
+	_, err = g.Exec(`
+		INSERT INTO api_tokens (name, token_hash, scope, created_at)
+		VALUES (?, ?, ?, ?)
+	`, name, hashTenantToken(token), scope, time.Now().Format(time.RFC3339))
+	if err != nil {
+		return "", fmt.Errorf("create token %s: %w", name, err)
+	}
+	return token, nil
+}
+
+// RotateAPIToken replaces the secret of an existing token and returns the
+// new one. The old secret stops working immediately.
+func (g *GlobalDB) RotateAPIToken(name string) (string, error) {
+	token, err := newAPITokenSecret()
+	if err != nil {
+		return "", err
+	}
+	res, err := g.Exec(`UPDATE api_tokens SET token_hash = ?, rotated_at = ? WHERE name = ?`,
+		hashTenantToken(token), time.Now().Format(time.RFC3339), name)
+	if err != nil {
+		return "", fmt.Errorf("rotate token %s: %w", name, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return "", fmt.Errorf("token %s not found", name)
+	}
+	return token, nil
+}
+
+// RevokeAPIToken deletes a token by name.
+func (g *GlobalDB) RevokeAPIToken(name string) error {
+	res, err := g.Exec(`DELETE FROM api_tokens WHERE name = ?`, name)
+	if err != nil {
+		return fmt.Errorf("revoke token %s: %w", name, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("token %s not found", name)
+	}
+	return nil
+}
+
+// ListAPITokens returns all server API tokens.
+func (g *GlobalDB) ListAPITokens() ([]APIToken, error) {
+	rows, err := g.Query(`
+		SELECT name, scope, created_at, rotated_at, last_used_at
+		FROM api_tokens ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list tokens: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var tokens []APIToken
+	for rows.Next() {
+		tok, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *tok)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// ResolveAPIToken returns the token matching secret and records its use.
+// Returns (nil, nil) if the token is unknown.
+func (g *GlobalDB) ResolveAPIToken(secret string) (*APIToken, error) {
+	hash := hashTenantToken(secret)
+	tok, err := scanAPIToken(g.QueryRow(`
+		SELECT name, scope, created_at, rotated_at, last_used_at
+		FROM api_tokens WHERE token_hash = ?
+	`, hash))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolve API token: %w", err)
+	}
+	_, _ = g.Exec(`UPDATE api_tokens SET last_used_at = ? WHERE token_hash = ?`,
+		time.Now().Format(time.RFC3339), hash)
+	return tok, nil
+}
+
+func (g *GlobalDB) getAPIToken(name string) (*APIToken, error) {
+	tok, err := scanAPIToken(g.QueryRow(`
+		SELECT name, scope, created_at, rotated_at, last_used_at
+		FROM api_tokens WHERE name = ?
+	`, name))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get token %s: %w", name, err)
+	}
+	return tok, nil
+}
+
+func scanAPIToken(row interface{ Scan(...any) error }) (*APIToken, error) {
+	var tok APIToken
+	var createdAt string
+	var rotatedAt, lastUsed sql.NullString
+	if err := row.Scan(&tok.Name, &tok.Scope, &createdAt, &rotatedAt, &lastUsed); err != nil {
+		return nil, err
+	}
+	tok.CreatedAt = parseTenantTime(createdAt)
+	if rotatedAt.Valid {
+		ts := parseTenantTime(rotatedAt.String)
+		tok.RotatedAt = &ts
+	}
+	if lastUsed.Valid {
+		ts := parseTenantTime(lastUsed.String)
+		tok.LastUsedAt = &ts
+	}
+	return &tok, nil
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAPITokens(t *testing.T) {
+	t.Parallel()
+	g := openTenantTestDB(t)
+
+	token, err := g.CreateAPIToken("ci", APITokenScopeWrite)
+	if err != nil {
+		t.Fatalf("CreateAPIToken: %v", err)
+	}
+	if !strings.HasPrefix(token, APITokenPrefix) {
+		t.Errorf("token %q missing prefix", token)
+	}
+	if _, err := g.CreateAPIToken("ci", APITokenScopeWrite); err == nil {
+		t.Error("duplicate token name should fail")
+	}
+	if _, err := g.CreateAPIToken("dash", "admin"); err == nil {
+		t.Error("unknown scope should fail")
+	}
+	readToken, err := g.CreateAPIToken("dash", APITokenScopeRead)
+	if err != nil {
+		t.Fatalf("CreateAPIToken read: %v", err)
+	}
+
+	got, err := g.ResolveAPIToken(token)
+	if err != nil || got == nil || got.Name != "ci" || !got.CanWrite() {
+		t.Fatalf("ResolveAPIToken = %+v, %v", got, err)
+	}
+	if got, _ := g.ResolveAPIToken(readToken); got == nil || got.CanWrite() {
+		t.Errorf("read token resolved to %+v", got)
+	}
+	if got, err := g.ResolveAPIToken("orca_unknown"); got != nil || err != nil {
+		t.Errorf("unknown token = %+v, %v", got, err)
+	}
+
+	rotated, err := g.RotateAPIToken("ci")
+	if err != nil {
+		t.Fatalf("RotateAPIToken: %v", err)
+	}
+	if got, _ := g.ResolveAPIToken(token); got != nil {
+		t.Error("old token still resolves after rotation")
+	}
+	if got, _ := g.ResolveAPIToken(rotated); got == nil || got.Scope != APITokenScopeWrite {
+		t.Errorf("rotated token resolved to %+v", got)
+	}
+	if _, err := g.RotateAPIToken("missing"); err == nil {
+		t.Error("rotating a missing token should fail")
+	}
+
+	tokens, err := g.ListAPITokens()
+	if err != nil || len(tokens) != 2 {
+		t.Fatalf("ListAPITokens = %+v, %v", tokens, err)
+	}
+	if tokens[0].Name != "ci" || tokens[0].RotatedAt == nil || tokens[0].LastUsedAt == nil {
+		t.Errorf("ci token = %+v, want rotated and used", tokens[0])
+	}
+
+	if err := g.RevokeAPIToken("ci"); err != nil {
+		t.Fatalf("RevokeAPIToken: %v", err)
+	}
+	if got, _ := g.ResolveAPIToken(rotated); got != nil {
+		t.Error("revoked token still resolves")
+	}
+	if err := g.RevokeAPIToken("ci"); err == nil {
+		t.Error("revoking twice should fail")
+	}
+}
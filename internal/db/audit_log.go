@@ -0,0 +1,161 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Audit entry sources.
+const (
+	AuditSourceAPI = "api" // REST request
+	AuditSourceRPC = "rpc" // Connect RPC
+	AuditSourceCLI = "cli" // orc command
+)
+
+// DefaultAuditQueryLimit and MaxAuditQueryLimit bound QueryAuditLog results.
+const (
+	DefaultAuditQueryLimit = 100
+	MaxAuditQueryLimit     = 1000
+)
+
+// AuditEntry records one mutating API call or CLI command. Before and After
+// hold the config keys a config change modified, with their old and new
+// values.
+type AuditEntry struct {
+	ID        int64             `json:"id"`
+	Actor     string            `json:"actor"`
+	Source    string            `json:"source"`
+	Action    string            `json:"action"`
+	TaskID    string            `json:"task_id,omitempty"`
+	Status    string            `json:"status,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
+	Before    map[string]string `json:"before,omitempty"`
+	After     map[string]string `json:"after,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// AuditQuery filters QueryAuditLog. Zero fields do not filter.
+type AuditQuery struct {
+	Actor  string
+	TaskID string
+	Since  time.Time
+	Until  time.Time
+	// AfterID returns only entries newer than this ID, oldest first, for
+	// following the log.
+	AfterID int64
+	Limit   int
+}
+
+// SaveAuditEntry appends an entry to the audit log.
+func (p *ProjectDB) SaveAuditEntry(e *AuditEntry) error {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	before, err := marshalAuditValues(e.Before)
+	if err != nil {
+		return err
+	}
+	after, err := marshalAuditValues(e.After)
+	if err != nil {
+		return err
+	}
+	if _, err := p.Exec(`
+		INSERT INTO audit_log (actor, source, action, task_id, status, request_id, before_values, after_values, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, e.Actor, e.Source, e.Action, e.TaskID, e.Status, e.RequestID, before, after,
+		e.CreatedAt.UTC().Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("save audit entry: %w", err)
+	}
+	return nil
+}
+
+// QueryAuditLog returns audit entries matching q, newest first (oldest first
+// with AfterID).
+func (p *ProjectDB) QueryAuditLog(q AuditQuery) ([]*AuditEntry, error) {
+	var where []string
+	var args []any
+	if q.Actor != "" {
+		where = append(where, "actor = ?")
+		args = append(args, q.Actor)
+	}
+	if q.TaskID != "" {
+		where = append(where, "task_id = ?")
+		args = append(args, q.TaskID)
+	}
+	if !q.Since.IsZero() {
+		where = append(where, "created_at >= ?")
+		args = append(args, q.Since.UTC().Format(time.RFC3339))
+	}
+	if !q.Until.IsZero() {
+		where = append(where, "created_at <= ?")
+		args = append(args, q.Until.UTC().Format(time.RFC3339))
+	}
+	order := "DESC"
+	if q.AfterID > 0 {
+		where = append(where, "id > ?")
+		args = append(args, q.AfterID)
+		order = "ASC"
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultAuditQueryLimit
+	}
+	limit = min(limit, MaxAuditQueryLimit)
+
+	query := `SELECT id, actor, source, action, task_id, status, request_id, before_values, after_values, created_at FROM audit_log`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY id %s LIMIT %d", order, limit)
+
+	rows, err := p.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query audit log: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	entries := []*AuditEntry{}
+	for rows.Next() {
+		var e AuditEntry
+		var before, after sql.NullString
+		var createdAt string
+		if err := rows.Scan(&e.ID, &e.Actor, &e.Source, &e.Action, &e.TaskID, &e.Status, &e.RequestID, &before, &after, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan audit entry: %w", err)
+		}
+		if e.Before, err = unmarshalAuditValues(before); err != nil {
+			return nil, err
+		}
+		if e.After, err = unmarshalAuditValues(after); err != nil {
+			return nil, err
+		}
+		e.CreatedAt = parseTimestamp(createdAt)
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+func marshalAuditValues(values map[string]string) (*string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("marshal audit values: %w", err)
+	}
+	s := string(data)
+	return &s, nil
+}
+
+func unmarshalAuditValues(s sql.NullString) (map[string]string, error) {
+	if !s.Valid || s.String == "" {
+		return nil, nil
+	}
+	var values map[string]string
+	if err := json.Unmarshal([]byte(s.String), &values); err != nil {
+		return nil, fmt.Errorf("parse audit values: %w", err)
+	}
+	return values, nil
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProjectDB_AuditLog(t *testing.T) {
+	t.Parallel()
+	pdb := NewTestProjectDB(t)
+	now := time.Now()
+
+	entries := []*AuditEntry{
+		{Actor: "alice", Source: AuditSourceCLI, Action: "orc run TASK-001", TaskID: "TASK-001", Status: "ok", CreatedAt: now.Add(-2 * time.Hour)},
+		{Actor: "token:ci", Source: AuditSourceRPC, Action: "orc.v1.TaskService/UpdateTask", TaskID: "TASK-002", Status: "ok", CreatedAt: now.Add(-time.Hour)},
+		{
+			Actor: "alice", Source: AuditSourceAPI, Action: "PUT /api/config/user", Status: "200",
+			Before: map[string]string{"model": "sonnet"}, After: map[string]string{"model": "opus"},
+			CreatedAt: now,
+		},
+	}
+	for _, e := range entries {
+		if err := pdb.SaveAuditEntry(e); err != nil {
+			t.Fatalf("SaveAuditEntry: %v", err)
+		}
+	}
+
+	all, err := pdb.QueryAuditLog(AuditQuery{})
+	if err != nil {
+		t.Fatalf("QueryAuditLog: %v", err)
+	}
+	if len(all) != 3 || all[0].Action != "PUT /api/config/user" {
+		t.Fatalf("QueryAuditLog() = %d entries, first %+v", len(all), all[0])
+	}
+	if all[0].Before["model"] != "sonnet" || all[0].After["model"] != "opus" {
+		t.Errorf("config diff = %v -> %v", all[0].Before, all[0].After)
+	}
+	if all[2].Before != nil {
+		t.Errorf("entry without diff has Before = %v", all[2].Before)
+	}
+
+	tests := []struct {
+		name string
+		q    AuditQuery
+		want int
+	}{
+		{"actor", AuditQuery{Actor: "alice"}, 2},
+		{"task", AuditQuery{TaskID: "TASK-002"}, 1},
+		{"since", AuditQuery{Since: now.Add(-90 * time.Minute)}, 2},
+		{"until", AuditQuery{Until: now.Add(-90 * time.Minute)}, 1},
+		{"limit", AuditQuery{Limit: 2}, 2},
+		{"after id", AuditQuery{AfterID: all[2].ID}, 2},
+	}
+	for _, tt := range tests {
+		got, err := pdb.QueryAuditLog(tt.q)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if len(got) != tt.want {
+			t.Errorf("%s: %d entries, want %d", tt.name, len(got), tt.want)
+		}
+	}
+
+	// Following the log returns the oldest new entries first
+	after, err := pdb.QueryAuditLog(AuditQuery{AfterID: all[2].ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after[0].ID != all[1].ID {
+		t.Errorf("AfterID order = %d first, want %d", after[0].ID, all[1].ID)
+	}
+}
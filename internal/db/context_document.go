@@ -0,0 +1,190 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ErrContextDocumentNotFound is returned when a context document or one of
+// its versions does not exist.
+var ErrContextDocumentNotFound = errors.New("context document not found")
+
+// ContextDocument is a project-level document included in the prompts of
+// the phases it lists. No phases means every phase.
+type ContextDocument struct {
+	Name      string    `json:"name"`
+	Content   string    `json:"content"`
+	Phases    []string  `json:"phases"`
+	Version   int       `json:"version"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AppliesTo reports whether the document is included in phase's prompt.
+func (d *ContextDocument) AppliesTo(phase string) bool {
+	return len(d.Phases) == 0 || slices.Contains(d.Phases, phase)
+}
+
+// SaveContextDocument stores doc as the next version of the document named
+// doc.Name. Saving content and phases identical to the current version
+// keeps that version. doc is updated with the stored version.
+func (p *ProjectDB) SaveContextDocument(doc *ContextDocument) error {
+	if doc.Phases == nil {
+		doc.Phases = []string{}
+	}
+	phasesJSON, err := json.Marshal(doc.Phases)
+	if err != nil {
+		return fmt.Errorf("marshal context document phases: %w", err)
+	}
+
+	return p.RunInTx(context.Background(), func(tx *TxOps) error {
+		current, err := scanContextDocument(tx.QueryRow(`
+			SELECT name, content, phases, version, updated_by, created_at, updated_at
+			FROM context_documents WHERE name = ?
+		`, doc.Name))
+		if err != nil && !errors.Is(err, ErrContextDocumentNotFound) {
+			return err
+		}
+		now := time.Now().UTC()
+		if current != nil {
+			if current.Content == doc.Content && slices.Equal(current.Phases, doc.Phases) {
+				*doc = *current
+				return nil
+			}
+			doc.Version = current.Version + 1
+			doc.CreatedAt = current.CreatedAt
+		} else {
+			doc.Version = 1
+			doc.CreatedAt = now
+		}
+		doc.UpdatedAt = now
+
+		if _, err := tx.Exec(`
+			INSERT INTO context_document_versions (name, version, content, phases, updated_by, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, doc.Name, doc.Version, doc.Content, string(phasesJSON), doc.UpdatedBy, now.Format(time.RFC3339)); err != nil {
+			return fmt.Errorf("save context document %s version: %w", doc.Name, err)
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO context_documents (name, content, phases, version, updated_by, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(name) DO UPDATE SET
+				content = excluded.content,
+				phases = excluded.phases,
+				version = excluded.version,
+				updated_by = excluded.updated_by,
+				updated_at = excluded.updated_at
+		`, doc.Name, doc.Content, string(phasesJSON), doc.Version, doc.UpdatedBy,
+			doc.CreatedAt.Format(time.RFC3339), doc.UpdatedAt.Format(time.RFC3339)); err != nil {
+			return fmt.Errorf("save context document %s: %w", doc.Name, err)
+		}
+		return nil
+	})
+}
+
+// GetContextDocument returns the current version of a context document, or
+// nil if there is none.
+func (p *ProjectDB) GetContextDocument(name string) (*ContextDocument, error) {
+	doc, err := scanContextDocument(p.QueryRow(`
+		SELECT name, content, phases, version, updated_by, created_at, updated_at
+		FROM context_documents WHERE name = ?
+	`, name))
+	if errors.Is(err, ErrContextDocumentNotFound) {
+		return nil, nil
+	}
+	return doc, err
+}
+
+// GetContextDocumentVersion returns one stored version of a context
+// document.
+func (p *ProjectDB) GetContextDocumentVersion(name string, version int) (*ContextDocument, error) {
+	doc, err := scanContextDocument(p.QueryRow(`
+		SELECT name, content, phases, version, updated_by, created_at, created_at
+		FROM context_document_versions WHERE name = ? AND version = ?
+	`, name, version))
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// ListContextDocuments returns the current version of every context
+// document, by name.
+func (p *ProjectDB) ListContextDocuments() ([]*ContextDocument, error) {
+	rows, err := p.Query(`
+		SELECT name, content, phases, version, updated_by, created_at, updated_at
+		FROM context_documents ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list context documents: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanContextDocuments(rows)
+}
+
+// ListContextDocumentVersions returns every stored version of a context
+// document, newest first.
+func (p *ProjectDB) ListContextDocumentVersions(name string) ([]*ContextDocument, error) {
+	rows, err := p.Query(`
+		SELECT name, content, phases, version, updated_by, created_at, created_at
+		FROM context_document_versions WHERE name = ? ORDER BY version DESC
+	`, name)
+	if err != nil {
+		return nil, fmt.Errorf("list context document versions %s: %w", name, err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanContextDocuments(rows)
+}
+
+// DeleteContextDocument removes a context document and its versions.
+func (p *ProjectDB) DeleteContextDocument(name string) error {
+	return p.RunInTx(context.Background(), func(tx *TxOps) error {
+		res, err := tx.Exec(`DELETE FROM context_documents WHERE name = ?`, name)
+		if err != nil {
+			return fmt.Errorf("delete context document %s: %w", name, err)
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return fmt.Errorf("%w: %s", ErrContextDocumentNotFound, name)
+		}
+		if _, err := tx.Exec(`DELETE FROM context_document_versions WHERE name = ?`, name); err != nil {
+			return fmt.Errorf("delete context document %s versions: %w", name, err)
+		}
+		return nil
+	})
+}
+
+func scanContextDocuments(rows *sql.Rows) ([]*ContextDocument, error) {
+	var docs []*ContextDocument
+	for rows.Next() {
+		doc, err := scanContextDocument(rows)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return docs, rows.Err()
+}
+
+func scanContextDocument(row interface{ Scan(...any) error }) (*ContextDocument, error) {
+	var doc ContextDocument
+	var phasesJSON, createdAt, updatedAt string
+	err := row.Scan(&doc.Name, &doc.Content, &phasesJSON, &doc.Version, &doc.UpdatedBy, &createdAt, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrContextDocumentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scan context document: %w", err)
+	}
+	if err := json.Unmarshal([]byte(phasesJSON), &doc.Phases); err != nil {
+		return nil, fmt.Errorf("parse context document %s phases: %w", doc.Name, err)
+	}
+	doc.CreatedAt = parseTimestamp(createdAt)
+	doc.UpdatedAt = parseTimestamp(updatedAt)
+	return &doc, nil
+}
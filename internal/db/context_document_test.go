@@ -0,0 +1,78 @@
+package db
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestProjectDB_ContextDocuments(t *testing.T) {
+	t.Parallel()
+	pdb := NewTestProjectDB(t)
+
+	if doc, err := pdb.GetContextDocument("architecture.md"); err != nil || doc != nil {
+		t.Fatalf("GetContextDocument() before save = %+v, %v", doc, err)
+	}
+
+	doc := &ContextDocument{Name: "architecture.md", Content: "v1", UpdatedBy: "alice"}
+	if err := pdb.SaveContextDocument(doc); err != nil {
+		t.Fatalf("SaveContextDocument: %v", err)
+	}
+	if doc.Version != 1 || doc.CreatedAt.IsZero() {
+		t.Errorf("first save = %+v", doc)
+	}
+
+	// Identical content and phases keep the current version
+	same := &ContextDocument{Name: "architecture.md", Content: "v1"}
+	if err := pdb.SaveContextDocument(same); err != nil {
+		t.Fatal(err)
+	}
+	if same.Version != 1 || same.UpdatedBy != "alice" {
+		t.Errorf("unchanged save = %+v, want version 1 by alice", same)
+	}
+
+	doc = &ContextDocument{Name: "architecture.md", Content: "v2", Phases: []string{"spec", "implement"}, UpdatedBy: "bob"}
+	if err := pdb.SaveContextDocument(doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Version != 2 {
+		t.Errorf("second save version = %d, want 2", doc.Version)
+	}
+	if err := pdb.SaveContextDocument(&ContextDocument{Name: "conventions.md", Content: "gofmt"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := pdb.GetContextDocument("architecture.md")
+	if err != nil || got == nil {
+		t.Fatalf("GetContextDocument() = %+v, %v", got, err)
+	}
+	if got.Content != "v2" || got.Version != 2 || !got.AppliesTo("spec") || got.AppliesTo("review") {
+		t.Errorf("GetContextDocument() = %+v", got)
+	}
+
+	docs, err := pdb.ListContextDocuments()
+	if err != nil || len(docs) != 2 || docs[0].Name != "architecture.md" || !docs[1].AppliesTo("review") {
+		t.Fatalf("ListContextDocuments() = %+v, %v", docs, err)
+	}
+
+	versions, err := pdb.ListContextDocumentVersions("architecture.md")
+	if err != nil || len(versions) != 2 || versions[0].Version != 2 || versions[1].Content != "v1" {
+		t.Fatalf("ListContextDocumentVersions() = %+v, %v", versions, err)
+	}
+	v1, err := pdb.GetContextDocumentVersion("architecture.md", 1)
+	if err != nil || v1.Content != "v1" || v1.UpdatedBy != "alice" {
+		t.Errorf("GetContextDocumentVersion(1) = %+v, %v", v1, err)
+	}
+	if _, err := pdb.GetContextDocumentVersion("architecture.md", 3); !errors.Is(err, ErrContextDocumentNotFound) {
+		t.Errorf("GetContextDocumentVersion(3) error = %v, want ErrContextDocumentNotFound", err)
+	}
+
+	if err := pdb.DeleteContextDocument("architecture.md"); err != nil {
+		t.Fatalf("DeleteContextDocument: %v", err)
+	}
+	if versions, _ := pdb.ListContextDocumentVersions("architecture.md"); len(versions) != 0 {
+		t.Errorf("versions after delete = %d", len(versions))
+	}
+	if err := pdb.DeleteContextDocument("architecture.md"); !errors.Is(err, ErrContextDocumentNotFound) {
+		t.Errorf("second delete error = %v, want ErrContextDocumentNotFound", err)
+	}
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// fileSummaryBatch bounds the hashes bound into one IN (...) query.
+const fileSummaryBatch = 500
+
+// FileSummary is a cached summary of one file's content, keyed by the git
+// blob hash of that content.
+type FileSummary struct {
+	ContentHash string
+	Language    string
+	Summary     string
+}
+
+// GetFileSummaries returns the cached summaries for the given content hashes,
+// keyed by hash, and marks them used. Hashes without a summary are absent
+// from the result.
+func (p *ProjectDB) GetFileSummaries(hashes []string) (map[string]FileSummary, error) {
+	found := make(map[string]FileSummary, len(hashes))
+	now := time.Now().UTC().Format(time.RFC3339)
+	for start := 0; start < len(hashes); start += fileSummaryBatch {
+		batch := hashes[start:min(start+fileSummaryBatch, len(hashes))]
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(batch)), ",")
+		args := make([]any, len(batch))
+		for i, h := range batch {
+			args[i] = h
+		}
+
+		rows, err := p.Query(fmt.Sprintf(`
+			SELECT content_hash, language, summary FROM file_summaries
+			WHERE content_hash IN (%s)
+		`, placeholders), args...)
+		if err != nil {
+			return nil, fmt.Errorf("get file summaries: %w", err)
+		}
+		for rows.Next() {
+			var s FileSummary
+			if err := rows.Scan(&s.ContentHash, &s.Language, &s.Summary); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("scan file summary: %w", err)
+			}
+			found[s.ContentHash] = s
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("iterate file summaries: %w", err)
+		}
+
+		if _, err := p.Exec(fmt.Sprintf(`
+			UPDATE file_summaries SET last_used_at = ? WHERE content_hash IN (%s)
+		`, placeholders), append([]any{now}, args...)...); err != nil {
+			return nil, fmt.Errorf("touch file summaries: %w", err)
+		}
+	}
+	return found, nil
+}
+
+// SaveFileSummaries stores summaries, replacing any cached for the same
+// content hash.
+func (p *ProjectDB) SaveFileSummaries(summaries []FileSummary) error {
+	if len(summaries) == 0 {
+		return nil
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	return p.RunInTx(context.Background(), func(tx *TxOps) error {
+		for _, s := range summaries {
+			if _, err := tx.Exec(`
+				INSERT INTO file_summaries (content_hash, language, summary, created_at, last_used_at)
+				VALUES (?, ?, ?, ?, ?)
+				ON CONFLICT (content_hash) DO UPDATE SET
+					language = excluded.language,
+					summary = excluded.summary,
+					last_used_at = excluded.last_used_at
+			`, s.ContentHash, s.Language, s.Summary, now, now); err != nil {
+				return fmt.Errorf("save file summary %s: %w", s.ContentHash, err)
+			}
+		}
+		return nil
+	})
+}
+
+// PruneFileSummaries deletes summaries not used within the given duration,
+// such as those for content no longer in any tracked file. Returns the number
+// of deleted rows.
+func (p *ProjectDB) PruneFileSummaries(unusedFor time.Duration) (int64, error) {
+	cutoff := time.Now().Add(-unusedFor).UTC().Format(time.RFC3339)
+
+	result, err := p.Exec(`DELETE FROM file_summaries WHERE last_used_at < ?`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("prune file summaries: %w", err)
+	}
+
+	deleted, _ := result.RowsAffected()
+	return deleted, nil
+}
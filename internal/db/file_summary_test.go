@@ -0,0 +1,63 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestProjectDB_FileSummaries(t *testing.T) {
+	t.Parallel()
+	pdb := NewTestProjectDB(t)
+
+	// More hashes than one IN batch
+	var hashes []string
+	var summaries []FileSummary
+	for i := range fileSummaryBatch + 10 {
+		h := fmt.Sprintf("%040d", i)
+		hashes = append(hashes, h)
+		if i%2 == 0 {
+			summaries = append(summaries, FileSummary{ContentHash: h, Language: "go", Summary: fmt.Sprintf("summary %d", i)})
+		}
+	}
+	if err := pdb.SaveFileSummaries(summaries); err != nil {
+		t.Fatalf("SaveFileSummaries: %v", err)
+	}
+
+	got, err := pdb.GetFileSummaries(hashes)
+	if err != nil {
+		t.Fatalf("GetFileSummaries: %v", err)
+	}
+	if len(got) != len(summaries) {
+		t.Fatalf("got %d summaries, want %d", len(got), len(summaries))
+	}
+	if s := got[hashes[fileSummaryBatch+2]]; s.Summary != fmt.Sprintf("summary %d", fileSummaryBatch+2) || s.Language != "go" {
+		t.Errorf("summary in second batch = %+v", s)
+	}
+	if _, ok := got[hashes[1]]; ok {
+		t.Error("unsaved hash returned a summary")
+	}
+
+	// Saving the same content again replaces the summary
+	if err := pdb.SaveFileSummaries([]FileSummary{{ContentHash: hashes[0], Language: "go", Summary: "updated"}}); err != nil {
+		t.Fatal(err)
+	}
+	got, err = pdb.GetFileSummaries(hashes[:1])
+	if err != nil || got[hashes[0]].Summary != "updated" {
+		t.Errorf("after resave: %+v, err = %v", got, err)
+	}
+
+	// Pruning drops only summaries unused for longer than the window
+	stale := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+	if _, err := pdb.Exec(`UPDATE file_summaries SET last_used_at = ? WHERE content_hash != ?`, stale, hashes[0]); err != nil {
+		t.Fatal(err)
+	}
+	n, err := pdb.PruneFileSummaries(24 * time.Hour)
+	if err != nil || n != int64(len(summaries)-1) {
+		t.Errorf("PruneFileSummaries = %d, %v; want %d", n, err, len(summaries)-1)
+	}
+	got, err = pdb.GetFileSummaries(hashes)
+	if err != nil || len(got) != 1 {
+		t.Errorf("after prune: %d summaries, err = %v", len(got), err)
+	}
+}
@@ -0,0 +1,196 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Gate approval statuses.
+const (
+	GateApprovalPending  = "pending"
+	GateApprovalApproved = "approved"
+	GateApprovalRejected = "rejected"
+)
+
+var (
+	// ErrGateApprovalNotFound is returned when resolving an unknown gate approval.
+	ErrGateApprovalNotFound = errors.New("gate approval not found")
+	// ErrGateApprovalNotPending is returned when resolving a gate approval
+	// that was already approved or rejected.
+	ErrGateApprovalNotPending = errors.New("gate approval is not pending")
+)
+
+// GateApproval is a human gate awaiting, or given, a decision. The executor
+// waits on the row; any channel may resolve it.
+type GateApproval struct {
+	ID          string     `json:"id"`
+	TaskID      string     `json:"task_id"`
+	Phase       string     `json:"phase"`
+	GateType    string     `json:"gate_type"`
+	Question    string     `json:"question,omitempty"`
+	Context     string     `json:"context,omitempty"`
+	Status      string     `json:"status"`
+	Comment     string     `json:"comment,omitempty"`
+	ResolvedBy  string     `json:"resolved_by,omitempty"`
+	Assignee    string     `json:"assignee,omitempty"`
+	Escalations int        `json:"escalations,omitempty"`
+	RequestedAt time.Time  `json:"requested_at"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+	EscalatedAt *time.Time `json:"escalated_at,omitempty"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+}
+
+const gateApprovalColumns = `id, task_id, phase, gate_type, question, context, status, comment, resolved_by, assignee, escalations, requested_at, resolved_at, escalated_at, applied_at`
+
+// CreateGateApproval inserts a pending gate approval.
+func (p *ProjectDB) CreateGateApproval(a *GateApproval) error {
+	if a.Status == "" {
+		a.Status = GateApprovalPending
+	}
+	if a.RequestedAt.IsZero() {
+		a.RequestedAt = time.Now().UTC()
+	}
+	_, err := p.Exec(`
+		INSERT INTO gate_approvals (id, task_id, phase, gate_type, question, context, status, requested_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, a.ID, a.TaskID, a.Phase, a.GateType, a.Question, a.Context, a.Status, a.RequestedAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("create gate approval: %w", err)
+	}
+	return nil
+}
+
+// GetGateApproval returns a gate approval by ID, or nil if it does not exist.
+func (p *ProjectDB) GetGateApproval(id string) (*GateApproval, error) {
+	a, err := scanGateApproval(p.QueryRow(`SELECT `+gateApprovalColumns+` FROM gate_approvals WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get gate approval %s: %w", id, err)
+	}
+	return a, nil
+}
+
+// GetOpenGateApproval returns the latest gate approval for a task's phase
+// that the executor has not applied yet, pending or resolved, or nil.
+func (p *ProjectDB) GetOpenGateApproval(taskID, phase string) (*GateApproval, error) {
+	a, err := scanGateApproval(p.QueryRow(`
+		SELECT `+gateApprovalColumns+` FROM gate_approvals
+		WHERE task_id = ? AND phase = ? AND applied_at IS NULL
+		ORDER BY requested_at DESC, id DESC LIMIT 1
+	`, taskID, phase))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get open gate approval: %w", err)
+	}
+	return a, nil
+}
+
+// ListPendingGateApprovals returns every pending gate approval, oldest first.
+func (p *ProjectDB) ListPendingGateApprovals() ([]*GateApproval, error) {
+	rows, err := p.Query(`
+		SELECT `+gateApprovalColumns+` FROM gate_approvals
+		WHERE status = ? ORDER BY requested_at, id
+	`, GateApprovalPending)
+	if err != nil {
+		return nil, fmt.Errorf("list pending gate approvals: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var approvals []*GateApproval
+	for rows.Next() {
+		a, err := scanGateApproval(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan gate approval: %w", err)
+		}
+		approvals = append(approvals, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate gate approvals: %w", err)
+	}
+	return approvals, nil
+}
+
+// ResolveGateApproval approves or rejects a pending gate approval and
+// returns it. It fails with ErrGateApprovalNotFound or
+// ErrGateApprovalNotPending, so only the first decision counts.
+func (p *ProjectDB) ResolveGateApproval(id string, approved bool, comment, resolvedBy string) (*GateApproval, error) {
+	status := GateApprovalRejected
+	if approved {
+		status = GateApprovalApproved
+	}
+	result, err := p.Exec(`
+		UPDATE gate_approvals SET status = ?, comment = ?, resolved_by = ?, resolved_at = ?
+		WHERE id = ? AND status = ?
+	`, status, nullableString(comment), nullableString(resolvedBy), time.Now().UTC().Format(time.RFC3339), id, GateApprovalPending)
+	if err != nil {
+		return nil, fmt.Errorf("resolve gate approval %s: %w", id, err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("resolve gate approval %s: %w", id, err)
+	} else if n == 0 {
+		existing, err := p.GetGateApproval(id)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, fmt.Errorf("%w: %s", ErrGateApprovalNotFound, id)
+		}
+		return nil, fmt.Errorf("%w: %s is %s", ErrGateApprovalNotPending, id, existing.Status)
+	}
+	return p.GetGateApproval(id)
+}
+
+// MarkGateApprovalApplied records that the executor acted on a resolved
+// gate approval, so it is not applied again.
+func (p *ProjectDB) MarkGateApprovalApplied(id string) error {
+	if _, err := p.Exec(`UPDATE gate_approvals SET applied_at = ? WHERE id = ?`, time.Now().UTC().Format(time.RFC3339), id); err != nil {
+		return fmt.Errorf("mark gate approval %s applied: %w", id, err)
+	}
+	return nil
+}
+
+// RecordGateApprovalEscalation counts a timeout escalation of a pending gate
+// approval, handing it to assignee when one is given.
+func (p *ProjectDB) RecordGateApprovalEscalation(id, assignee string) error {
+	_, err := p.Exec(`
+		UPDATE gate_approvals SET escalations = escalations + 1, escalated_at = ?, assignee = COALESCE(?, assignee)
+		WHERE id = ? AND status = ?
+	`, time.Now().UTC().Format(time.RFC3339), nullableString(assignee), id, GateApprovalPending)
+	if err != nil {
+		return fmt.Errorf("record gate approval %s escalation: %w", id, err)
+	}
+	return nil
+}
+
+func scanGateApproval(scanner interface{ Scan(dest ...any) error }) (*GateApproval, error) {
+	var a GateApproval
+	var comment, resolvedBy, assignee, resolvedAt, escalatedAt, appliedAt sql.NullString
+	var requestedAt string
+	if err := scanner.Scan(&a.ID, &a.TaskID, &a.Phase, &a.GateType, &a.Question, &a.Context, &a.Status,
+		&comment, &resolvedBy, &assignee, &a.Escalations, &requestedAt, &resolvedAt, &escalatedAt, &appliedAt); err != nil {
+		return nil, err
+	}
+	a.Comment = comment.String
+	a.ResolvedBy = resolvedBy.String
+	a.Assignee = assignee.String
+	a.RequestedAt = parseTimestamp(requestedAt)
+	if resolvedAt.Valid {
+		t := parseTimestamp(resolvedAt.String)
+		a.ResolvedAt = &t
+	}
+	if escalatedAt.Valid {
+		t := parseTimestamp(escalatedAt.String)
+		a.EscalatedAt = &t
+	}
+	if appliedAt.Valid {
+		t := parseTimestamp(appliedAt.String)
+		a.AppliedAt = &t
+	}
+	return &a, nil
+}
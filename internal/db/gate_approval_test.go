@@ -0,0 +1,65 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestProjectDB_GateApprovals(t *testing.T) {
+	t.Parallel()
+	pdb := NewTestProjectDB(t)
+	if err := pdb.SaveTask(&Task{ID: "TASK-001", Title: "Gated", Status: "running", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	if a, err := pdb.GetOpenGateApproval("TASK-001", "review"); err != nil || a != nil {
+		t.Fatalf("GetOpenGateApproval() before any = %+v, %v", a, err)
+	}
+	if err := pdb.CreateGateApproval(&GateApproval{ID: "gate_1", TaskID: "TASK-001", Phase: "review", GateType: "human", Question: "Approve?"}); err != nil {
+		t.Fatalf("CreateGateApproval: %v", err)
+	}
+
+	pending, err := pdb.ListPendingGateApprovals()
+	if err != nil || len(pending) != 1 || pending[0].ID != "gate_1" || pending[0].Status != GateApprovalPending {
+		t.Fatalf("ListPendingGateApprovals() = %+v, %v", pending, err)
+	}
+
+	if err := pdb.RecordGateApprovalEscalation("gate_1", "bob"); err != nil {
+		t.Fatalf("RecordGateApprovalEscalation: %v", err)
+	}
+	if err := pdb.RecordGateApprovalEscalation("gate_1", ""); err != nil {
+		t.Fatalf("RecordGateApprovalEscalation: %v", err)
+	}
+	if a, err := pdb.GetGateApproval("gate_1"); err != nil || a.Escalations != 2 || a.Assignee != "bob" || a.EscalatedAt == nil {
+		t.Fatalf("escalated approval = %+v, %v; want 2 escalations assigned to bob", a, err)
+	}
+
+	resolved, err := pdb.ResolveGateApproval("gate_1", true, "looks good", "cli")
+	if err != nil {
+		t.Fatalf("ResolveGateApproval: %v", err)
+	}
+	if resolved.Status != GateApprovalApproved || resolved.Comment != "looks good" || resolved.ResolvedBy != "cli" || resolved.ResolvedAt == nil {
+		t.Errorf("resolved = %+v", resolved)
+	}
+	if _, err := pdb.ResolveGateApproval("gate_1", false, "", "api"); !errors.Is(err, ErrGateApprovalNotPending) {
+		t.Errorf("second resolution error = %v, want ErrGateApprovalNotPending", err)
+	}
+	if _, err := pdb.ResolveGateApproval("gate_404", true, "", "api"); !errors.Is(err, ErrGateApprovalNotFound) {
+		t.Errorf("unknown approval error = %v, want ErrGateApprovalNotFound", err)
+	}
+	if pending, _ := pdb.ListPendingGateApprovals(); len(pending) != 0 {
+		t.Errorf("still pending after resolution: %+v", pending)
+	}
+
+	// A resolved approval stays open until the executor applies it
+	if open, err := pdb.GetOpenGateApproval("TASK-001", "review"); err != nil || open == nil || open.ID != "gate_1" {
+		t.Fatalf("GetOpenGateApproval() = %+v, %v; want gate_1", open, err)
+	}
+	if err := pdb.MarkGateApprovalApplied("gate_1"); err != nil {
+		t.Fatalf("MarkGateApprovalApplied: %v", err)
+	}
+	if open, err := pdb.GetOpenGateApproval("TASK-001", "review"); err != nil || open != nil {
+		t.Errorf("GetOpenGateApproval() after apply = %+v, %v; want none", open, err)
+	}
+}
@@ -82,6 +82,49 @@ func (p *ProjectDB) GetGateDecisions(taskID string) ([]GateDecision, error) {
 	return decisions, nil
 }
 
+// ListRejectedGateDecisions returns rejected decisions of the given gate
+// type that give a reason, across all tasks, oldest first.
+func (p *ProjectDB) ListRejectedGateDecisions(gateType string) ([]GateDecision, error) {
+	rows, err := p.Query(`
+		SELECT id, task_id, phase, gate_type, approved, reason, decided_by, decided_at
+		FROM gate_decisions
+		WHERE gate_type = ? AND approved = 0 AND reason IS NOT NULL AND reason != ''
+		ORDER BY decided_at
+	`, gateType)
+	if err != nil {
+		return nil, fmt.Errorf("list rejected gate decisions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var decisions []GateDecision
+	for rows.Next() {
+		var d GateDecision
+		var approved int
+		var reason, decidedBy sql.NullString
+		var decidedAt string
+
+		if err := rows.Scan(&d.ID, &d.TaskID, &d.Phase, &d.GateType, &approved, &reason, &decidedBy, &decidedAt); err != nil {
+			return nil, fmt.Errorf("scan gate decision: %w", err)
+		}
+
+		d.Approved = approved == 1
+		d.Reason = reason.String
+		if decidedBy.Valid {
+			d.DecidedBy = decidedBy.String
+		}
+		if ts, err := time.Parse(time.RFC3339, decidedAt); err == nil {
+			d.DecidedAt = ts
+		}
+
+		decisions = append(decisions, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate gate decisions: %w", err)
+	}
+
+	return decisions, nil
+}
+
 // GetGateDecisionForPhase retrieves the gate decision for a specific phase.
 func (p *ProjectDB) GetGateDecisionForPhase(taskID, phase string) (*GateDecision, error) {
 	row := p.QueryRow(`
@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/randalmurphal/orc/internal/db/driver"
 )
 
 // GlobalDB provides operations on the global database (~/.orc/orc.db).
@@ -39,6 +41,23 @@ func OpenGlobalAt(path string) (*GlobalDB, error) {
 	return &GlobalDB{DB: db}, nil
 }
 
+// OpenGlobalWithDialect opens a global database with a specific dialect,
+// such as a shared PostgreSQL database for high-availability server mode.
+// For SQLite the DSN is a file path.
+func OpenGlobalWithDialect(dsn string, dialect driver.Dialect) (*GlobalDB, error) {
+	db, err := OpenWithDialect(dsn, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Migrate("global"); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("migrate global db: %w", err)
+	}
+
+	return &GlobalDB{DB: db}, nil
+}
+
 // Project represents a registered project.
 type Project struct {
 	ID        string
@@ -142,8 +142,8 @@ func (g *GlobalDB) SaveWorkflow(w *Workflow) error {
 	basedOn := sqlNullString(w.BasedOn)
 
 	_, err := g.Exec(`
-		INSERT INTO workflows (id, name, description, default_model, default_provider, default_thinking, completion_action, target_branch, is_builtin, based_on, triggers, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO workflows (id, name, description, default_model, default_provider, default_thinking, completion_action, target_branch, is_builtin, based_on, triggers, done_criteria, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			name = excluded.name,
 			description = excluded.description,
@@ -154,9 +154,10 @@ func (g *GlobalDB) SaveWorkflow(w *Workflow) error {
 			target_branch = excluded.target_branch,
 			based_on = excluded.based_on,
 			triggers = excluded.triggers,
+			done_criteria = excluded.done_criteria,
 			updated_at = excluded.updated_at
 	`, w.ID, w.Name, w.Description, w.DefaultModel, w.DefaultProvider, w.DefaultThinking, w.CompletionAction,
-		w.TargetBranch, w.IsBuiltin, basedOn, w.Triggers, w.CreatedAt.Format(time.RFC3339), time.Now().Format(time.RFC3339))
+		w.TargetBranch, w.IsBuiltin, basedOn, w.Triggers, w.DoneCriteria, w.CreatedAt.Format(time.RFC3339), time.Now().Format(time.RFC3339))
 	if err != nil {
 		return fmt.Errorf("save workflow: %w", err)
 	}
@@ -166,7 +167,7 @@ func (g *GlobalDB) SaveWorkflow(w *Workflow) error {
 // GetWorkflow retrieves a workflow by ID from global DB, including its phases.
 func (g *GlobalDB) GetWorkflow(id string) (*Workflow, error) {
 	row := g.QueryRow(`
-		SELECT id, name, description, default_model, default_provider, default_thinking, completion_action, target_branch, is_builtin, based_on, triggers, created_at, updated_at
+		SELECT id, name, description, default_model, default_provider, default_thinking, completion_action, target_branch, is_builtin, based_on, triggers, done_criteria, created_at, updated_at
 		FROM workflows WHERE id = ?
 	`, id)
 
@@ -190,7 +191,7 @@ func (g *GlobalDB) GetWorkflow(id string) (*Workflow, error) {
 // ListWorkflows returns all workflows from global DB.
 func (g *GlobalDB) ListWorkflows() ([]*Workflow, error) {
 	rows, err := g.Query(`
-		SELECT id, name, description, default_model, default_provider, default_thinking, completion_action, target_branch, is_builtin, based_on, triggers, created_at, updated_at
+		SELECT id, name, description, default_model, default_provider, default_thinking, completion_action, target_branch, is_builtin, based_on, triggers, done_criteria, created_at, updated_at
 		FROM workflows
 		ORDER BY is_builtin DESC, name ASC
 	`)
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// GetInitiativeBudget returns the spending limit in USD for an initiative,
+// or 0 when it has none.
+func (p *ProjectDB) GetInitiativeBudget(initiativeID string) (float64, error) {
+	var limit float64
+	err := p.QueryRow(`SELECT limit_usd FROM initiative_budgets WHERE initiative_id = ?`, initiativeID).Scan(&limit)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("get initiative budget: %w", err)
+	}
+	return limit, nil
+}
+
+// SetInitiativeBudget sets the spending limit in USD for an initiative.
+// A limit of 0 or less removes the budget.
+func (p *ProjectDB) SetInitiativeBudget(initiativeID string, limitUSD float64) error {
+	if limitUSD <= 0 {
+		if _, err := p.Exec(`DELETE FROM initiative_budgets WHERE initiative_id = ?`, initiativeID); err != nil {
+			return fmt.Errorf("clear initiative budget: %w", err)
+		}
+		return nil
+	}
+	_, err := p.Exec(`
+		INSERT INTO initiative_budgets (initiative_id, limit_usd, updated_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (initiative_id) DO UPDATE SET
+			limit_usd = excluded.limit_usd,
+			updated_at = excluded.updated_at
+	`, initiativeID, limitUSD, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("set initiative budget: %w", err)
+	}
+	return nil
+}
+
+// GetInitiativeCost returns the total recorded cost in USD of the tasks in
+// an initiative.
+func (p *ProjectDB) GetInitiativeCost(initiativeID string) (float64, error) {
+	var total float64
+	err := p.QueryRow(`
+		SELECT COALESCE(SUM(total_cost_usd), 0) FROM tasks WHERE initiative_id = ?
+	`, initiativeID).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("get initiative cost: %w", err)
+	}
+	return total, nil
+}
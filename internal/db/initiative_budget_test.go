@@ -0,0 +1,49 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProjectDB_InitiativeBudget(t *testing.T) {
+	t.Parallel()
+	pdb := NewTestProjectDB(t)
+
+	if err := pdb.SaveInitiative(&Initiative{ID: "INIT-001", Title: "Test", Status: "active"}); err != nil {
+		t.Fatal(err)
+	}
+	if limit, err := pdb.GetInitiativeBudget("INIT-001"); err != nil || limit != 0 {
+		t.Fatalf("unset budget = %v, %v; want 0", limit, err)
+	}
+
+	if err := pdb.SetInitiativeBudget("INIT-001", 25); err != nil {
+		t.Fatalf("SetInitiativeBudget: %v", err)
+	}
+	if err := pdb.SetInitiativeBudget("INIT-001", 40); err != nil {
+		t.Fatalf("SetInitiativeBudget (raise): %v", err)
+	}
+	if limit, err := pdb.GetInitiativeBudget("INIT-001"); err != nil || limit != 40 {
+		t.Errorf("budget = %v, %v; want 40", limit, err)
+	}
+
+	// Cost sums the tasks in the initiative only
+	for _, task := range []*Task{
+		{ID: "TASK-001", Title: "a", Status: "completed", InitiativeID: "INIT-001", TotalCostUSD: 1.5, CreatedAt: time.Now()},
+		{ID: "TASK-002", Title: "b", Status: "running", InitiativeID: "INIT-001", TotalCostUSD: 2.25, CreatedAt: time.Now()},
+		{ID: "TASK-003", Title: "c", Status: "running", TotalCostUSD: 10, CreatedAt: time.Now()},
+	} {
+		if err := pdb.SaveTask(task); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cost, err := pdb.GetInitiativeCost("INIT-001"); err != nil || cost != 3.75 {
+		t.Errorf("GetInitiativeCost = %v, %v; want 3.75", cost, err)
+	}
+
+	if err := pdb.SetInitiativeBudget("INIT-001", 0); err != nil {
+		t.Fatalf("SetInitiativeBudget (clear): %v", err)
+	}
+	if limit, err := pdb.GetInitiativeBudget("INIT-001"); err != nil || limit != 0 {
+		t.Errorf("cleared budget = %v, %v; want 0", limit, err)
+	}
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// InitiativePR is the combined PR of an initiative's integration branch.
+type InitiativePR struct {
+	InitiativeID string    `json:"initiative_id"`
+	PRNumber     int       `json:"pr_number"`
+	PRURL        string    `json:"pr_url,omitempty"`
+	HeadBranch   string    `json:"head_branch"`
+	BaseBranch   string    `json:"base_branch"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// SaveInitiativePR records an initiative's combined PR, replacing any
+// earlier one.
+func (p *ProjectDB) SaveInitiativePR(pr *InitiativePR) error {
+	now := time.Now().UTC()
+	if pr.CreatedAt.IsZero() {
+		pr.CreatedAt = now
+	}
+	pr.UpdatedAt = now
+	_, err := p.Exec(`
+		INSERT INTO initiative_prs (initiative_id, pr_number, pr_url, head_branch, base_branch, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(initiative_id) DO UPDATE SET
+			pr_number = excluded.pr_number,
+			pr_url = excluded.pr_url,
+			head_branch = excluded.head_branch,
+			base_branch = excluded.base_branch,
+			updated_at = excluded.updated_at
+	`, pr.InitiativeID, pr.PRNumber, pr.PRURL, pr.HeadBranch, pr.BaseBranch,
+		pr.CreatedAt.Format(time.RFC3339), pr.UpdatedAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("save initiative PR %s: %w", pr.InitiativeID, err)
+	}
+	return nil
+}
+
+// GetInitiativePR returns an initiative's combined PR, or nil if it has none.
+func (p *ProjectDB) GetInitiativePR(initiativeID string) (*InitiativePR, error) {
+	var pr InitiativePR
+	var createdAt, updatedAt string
+	err := p.QueryRow(`
+		SELECT initiative_id, pr_number, pr_url, head_branch, base_branch, created_at, updated_at
+		FROM initiative_prs WHERE initiative_id = ?
+	`, initiativeID).Scan(&pr.InitiativeID, &pr.PRNumber, &pr.PRURL, &pr.HeadBranch, &pr.BaseBranch, &createdAt, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get initiative PR %s: %w", initiativeID, err)
+	}
+	pr.CreatedAt = parseTimestamp(createdAt)
+	pr.UpdatedAt = parseTimestamp(updatedAt)
+	return &pr, nil
+}
@@ -0,0 +1,31 @@
+package db
+
+import (
+	"testing"
+)
+
+func TestProjectDB_InitiativePR(t *testing.T) {
+	t.Parallel()
+	pdb := NewTestProjectDB(t)
+	if err := pdb.SaveInitiative(&Initiative{ID: "INIT-001", Title: "Auth", Status: "active", BranchBase: "feature/auth"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if pr, err := pdb.GetInitiativePR("INIT-001"); err != nil || pr != nil {
+		t.Fatalf("GetInitiativePR() before save = %+v, %v", pr, err)
+	}
+	if err := pdb.SaveInitiativePR(&InitiativePR{InitiativeID: "INIT-001", PRNumber: 7, PRURL: "https://example.com/pr/7", HeadBranch: "feature/auth", BaseBranch: "main"}); err != nil {
+		t.Fatalf("SaveInitiativePR: %v", err)
+	}
+	if err := pdb.SaveInitiativePR(&InitiativePR{InitiativeID: "INIT-001", PRNumber: 9, HeadBranch: "feature/auth", BaseBranch: "main"}); err != nil {
+		t.Fatalf("SaveInitiativePR replace: %v", err)
+	}
+
+	pr, err := pdb.GetInitiativePR("INIT-001")
+	if err != nil {
+		t.Fatalf("GetInitiativePR: %v", err)
+	}
+	if pr == nil || pr.PRNumber != 9 || pr.HeadBranch != "feature/auth" || pr.BaseBranch != "main" || pr.CreatedAt.IsZero() {
+		t.Errorf("GetInitiativePR() = %+v", pr)
+	}
+}
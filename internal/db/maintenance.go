@@ -0,0 +1,248 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/db/driver"
+)
+
+// ErrMaintenanceUnsupported is returned by Maintain for non-SQLite databases,
+// whose server handles vacuuming and statistics itself.
+var ErrMaintenanceUnsupported = errors.New("database maintenance is only supported for SQLite")
+
+// MaintenanceOptions controls a maintenance run.
+type MaintenanceOptions struct {
+	// RetentionDays prunes transcripts and event log entries older than this
+	// many days. Zero keeps everything. Only applies to project databases.
+	RetentionDays int
+
+	// SkipVacuum skips VACUUM, which rewrites the whole file and blocks
+	// writers while it runs. ANALYZE still runs.
+	SkipVacuum bool
+}
+
+// DatabaseSize describes how much space a SQLite database uses.
+type DatabaseSize struct {
+	// Bytes is the size of the main database file (page_count * page_size).
+	Bytes int64 `json:"bytes"`
+	// FreeBytes is space held by free pages that VACUUM would reclaim.
+	FreeBytes int64 `json:"free_bytes"`
+	// WALBytes is the size of the write-ahead log file, if any.
+	WALBytes int64 `json:"wal_bytes"`
+}
+
+// FTSCheck is the integrity result for one full-text index.
+type FTSCheck struct {
+	Table string `json:"table"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+	// Rebuilt is set when a corrupt index was rebuilt from its content table.
+	Rebuilt bool `json:"rebuilt,omitempty"`
+}
+
+// MaintenanceReport summarizes a maintenance run.
+type MaintenanceReport struct {
+	Path              string        `json:"path"`
+	Before            DatabaseSize  `json:"before"`
+	After             DatabaseSize  `json:"after"`
+	Integrity         []string      `json:"integrity"`
+	FTS               []FTSCheck    `json:"fts"`
+	PrunedTranscripts int64         `json:"pruned_transcripts"`
+	PrunedEvents      int64         `json:"pruned_events"`
+	PrunedSummaries   int64         `json:"pruned_file_summaries"`
+	Vacuumed          bool          `json:"vacuumed"`
+	Duration          time.Duration `json:"duration_ns"`
+}
+
+// Healthy reports whether the integrity check and every FTS check passed
+// (an FTS index that was rebuilt counts as healthy).
+func (r *MaintenanceReport) Healthy() bool {
+	if len(r.Integrity) != 1 || r.Integrity[0] != "ok" {
+		return false
+	}
+	for _, c := range r.FTS {
+		if !c.OK && !c.Rebuilt {
+			return false
+		}
+	}
+	return true
+}
+
+// maxIntegrityMessages bounds how many integrity_check problems are kept.
+const maxIntegrityMessages = 20
+
+// Size returns the current size of a SQLite database.
+func (d *DB) Size(ctx context.Context) (DatabaseSize, error) {
+	if d.Dialect() != driver.DialectSQLite {
+		return DatabaseSize{}, ErrMaintenanceUnsupported
+	}
+	var pageCount, pageSize, freePages int64
+	if err := d.QueryRowContext(ctx, `PRAGMA page_count`).Scan(&pageCount); err != nil {
+		return DatabaseSize{}, fmt.Errorf("read page_count: %w", err)
+	}
+	if err := d.QueryRowContext(ctx, `PRAGMA page_size`).Scan(&pageSize); err != nil {
+		return DatabaseSize{}, fmt.Errorf("read page_size: %w", err)
+	}
+	if err := d.QueryRowContext(ctx, `PRAGMA freelist_count`).Scan(&freePages); err != nil {
+		return DatabaseSize{}, fmt.Errorf("read freelist_count: %w", err)
+	}
+	size := DatabaseSize{Bytes: pageCount * pageSize, FreeBytes: freePages * pageSize}
+	if d.path != "" && d.path != ":memory:" {
+		if info, err := os.Stat(d.path + "-wal"); err == nil {
+			size.WALBytes = info.Size()
+		}
+	}
+	return size, nil
+}
+
+// Maintain checks integrity of the database and its FTS indexes, rebuilds
+// corrupt FTS indexes, then runs VACUUM and ANALYZE. SQLite only.
+func (d *DB) Maintain(ctx context.Context, opts MaintenanceOptions) (*MaintenanceReport, error) {
+	return d.maintain(ctx, opts, nil)
+}
+
+// Maintain prunes transcripts, event log entries, and file summaries unused
+// past the retention window before running the common maintenance steps.
+func (p *ProjectDB) Maintain(ctx context.Context, opts MaintenanceOptions) (*MaintenanceReport, error) {
+	return p.maintain(ctx, opts, func(report *MaintenanceReport) error {
+		if opts.RetentionDays <= 0 {
+			return nil
+		}
+		retention := time.Duration(opts.RetentionDays) * 24 * time.Hour
+		n, err := p.CleanupOldTranscripts(retention)
+		if err != nil {
+			return err
+		}
+		report.PrunedTranscripts = n
+		if report.PrunedEvents, err = p.CleanupOldEvents(retention); err != nil {
+			return err
+		}
+		if report.PrunedSummaries, err = p.PruneFileSummaries(retention); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// CleanupOldEvents deletes event log entries older than the given duration.
+// Returns the number of deleted rows.
+func (p *ProjectDB) CleanupOldEvents(olderThan time.Duration) (int64, error) {
+	cutoff := time.Now().Add(-olderThan).UTC().Format("2006-01-02 15:04:05")
+
+	result, err := p.Exec(`DELETE FROM event_log WHERE created_at < ?`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("cleanup old events: %w", err)
+	}
+
+	deleted, _ := result.RowsAffected()
+	return deleted, nil
+}
+
+func (d *DB) maintain(ctx context.Context, opts MaintenanceOptions, prune func(*MaintenanceReport) error) (*MaintenanceReport, error) {
+	if d.Dialect() != driver.DialectSQLite {
+		return nil, ErrMaintenanceUnsupported
+	}
+	start := time.Now()
+	report := &MaintenanceReport{Path: d.path}
+
+	var err error
+	if report.Before, err = d.Size(ctx); err != nil {
+		return nil, err
+	}
+	if report.Integrity, err = d.integrityCheck(ctx); err != nil {
+		return nil, err
+	}
+	if report.FTS, err = d.checkFTS(ctx); err != nil {
+		return nil, err
+	}
+	if prune != nil {
+		if err := prune(report); err != nil {
+			return nil, err
+		}
+	}
+
+	if !opts.SkipVacuum {
+		if _, err := d.ExecContext(ctx, `VACUUM`); err != nil {
+			return nil, fmt.Errorf("vacuum: %w", err)
+		}
+		report.Vacuumed = true
+	}
+	if _, err := d.ExecContext(ctx, `ANALYZE`); err != nil {
+		return nil, fmt.Errorf("analyze: %w", err)
+	}
+	// Fold the WAL back into the main file and truncate it.
+	if _, err := d.ExecContext(ctx, `PRAGMA wal_checkpoint(TRUNCATE)`); err != nil {
+		return nil, fmt.Errorf("checkpoint wal: %w", err)
+	}
+
+	if report.After, err = d.Size(ctx); err != nil {
+		return nil, err
+	}
+	report.Duration = time.Since(start)
+	return report, nil
+}
+
+// integrityCheck runs PRAGMA integrity_check and returns its messages,
+// which are just "ok" for a healthy database.
+func (d *DB) integrityCheck(ctx context.Context) ([]string, error) {
+	rows, err := d.QueryContext(ctx, fmt.Sprintf(`PRAGMA integrity_check(%d)`, maxIntegrityMessages))
+	if err != nil {
+		return nil, fmt.Errorf("integrity check: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var messages []string
+	for rows.Next() {
+		var msg string
+		if err := rows.Scan(&msg); err != nil {
+			return nil, fmt.Errorf("scan integrity check: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+	return messages, rows.Err()
+}
+
+// checkFTS runs the FTS5 integrity-check command on every full-text index
+// and rebuilds the ones that fail from their content tables.
+func (d *DB) checkFTS(ctx context.Context) ([]FTSCheck, error) {
+	rows, err := d.QueryContext(ctx, `
+		SELECT name FROM sqlite_master
+		WHERE type = 'table' AND LOWER(sql) LIKE 'create virtual table%using fts5%'
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list fts tables: %w", err)
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("scan fts table: %w", err)
+		}
+		tables = append(tables, name)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list fts tables: %w", err)
+	}
+
+	checks := make([]FTSCheck, 0, len(tables))
+	for _, table := range tables {
+		check := FTSCheck{Table: table, OK: true}
+		if _, err := d.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %[1]s(%[1]s) VALUES('integrity-check')`, table)); err != nil {
+			check.OK = false
+			check.Error = err.Error()
+			if _, err := d.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %[1]s(%[1]s) VALUES('rebuild')`, table)); err != nil {
+				return nil, fmt.Errorf("rebuild %s: %w", table, err)
+			}
+			check.Rebuilt = true
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
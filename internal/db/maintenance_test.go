@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestProjectDB_Maintain(t *testing.T) {
+	t.Parallel()
+	pdb, err := OpenProjectAtPath(filepath.Join(t.TempDir(), ".orc", "orc.db"))
+	if err != nil {
+		t.Fatalf("OpenProjectAtPath: %v", err)
+	}
+	t.Cleanup(func() { _ = pdb.Close() })
+
+	if err := pdb.SaveTask(&Task{ID: "TASK-001", Title: "Test", Status: "running", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("SaveTask: %v", err)
+	}
+	old := time.Now().AddDate(0, 0, -40)
+	for i, ts := range []time.Time{old, time.Now()} {
+		if err := pdb.AddTranscript(&Transcript{TaskID: "TASK-001", Phase: "implement", Type: "assistant", MessageUUID: fmt.Sprintf("msg-%d", i), Content: "line", Timestamp: ts}); err != nil {
+			t.Fatalf("AddTranscript %d: %v", i, err)
+		}
+		if err := pdb.SaveEvent(&EventLog{TaskID: "TASK-001", EventType: "phase", Source: "test", CreatedAt: ts}); err != nil {
+			t.Fatalf("SaveEvent %d: %v", i, err)
+		}
+	}
+
+	report, err := pdb.Maintain(context.Background(), MaintenanceOptions{RetentionDays: 30})
+	if err != nil {
+		t.Fatalf("Maintain: %v", err)
+	}
+	if !report.Healthy() {
+		t.Errorf("report not healthy: integrity=%v fts=%+v", report.Integrity, report.FTS)
+	}
+	if report.PrunedTranscripts != 1 || report.PrunedEvents != 1 {
+		t.Errorf("pruned transcripts=%d events=%d, want 1 each", report.PrunedTranscripts, report.PrunedEvents)
+	}
+	if !report.Vacuumed || report.Before.Bytes == 0 || report.After.Bytes == 0 {
+		t.Errorf("vacuumed=%v before=%+v after=%+v", report.Vacuumed, report.Before, report.After)
+	}
+	if report.After.FreeBytes != 0 {
+		t.Errorf("free bytes after VACUUM = %d, want 0", report.After.FreeBytes)
+	}
+
+	fts := map[string]bool{}
+	for _, c := range report.FTS {
+		fts[c.Table] = c.OK
+	}
+	for _, table := range []string{"transcripts_fts", "artifact_index_fts"} {
+		if ok, found := fts[table]; !found || !ok {
+			t.Errorf("FTS check for %s: found=%v ok=%v", table, found, ok)
+		}
+	}
+
+	transcripts, err := pdb.GetTranscripts("TASK-001")
+	if err != nil || len(transcripts) != 1 {
+		t.Errorf("transcripts after prune = %d, %v; want 1", len(transcripts), err)
+	}
+}
+
+func TestDB_Maintain_SkipVacuumAndNoRetention(t *testing.T) {
+	t.Parallel()
+	pdb, err := OpenProjectAtPath(filepath.Join(t.TempDir(), ".orc", "orc.db"))
+	if err != nil {
+		t.Fatalf("OpenProjectAtPath: %v", err)
+	}
+	t.Cleanup(func() { _ = pdb.Close() })
+
+	if err := pdb.SaveTask(&Task{ID: "TASK-001", Title: "Test", Status: "running", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("SaveTask: %v", err)
+	}
+	if err := pdb.AddTranscript(&Transcript{TaskID: "TASK-001", Phase: "implement", Type: "assistant", Content: "old", Timestamp: time.Now().AddDate(-1, 0, 0)}); err != nil {
+		t.Fatalf("AddTranscript: %v", err)
+	}
+
+	report, err := pdb.Maintain(context.Background(), MaintenanceOptions{SkipVacuum: true})
+	if err != nil {
+		t.Fatalf("Maintain: %v", err)
+	}
+	if report.Vacuumed || report.PrunedTranscripts != 0 {
+		t.Errorf("vacuumed=%v pruned=%d, want neither", report.Vacuumed, report.PrunedTranscripts)
+	}
+}
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PhaseConfidence is the validation model's confidence assessment of a
+// completed phase. A phase that is re-run keeps only its latest score.
+type PhaseConfidence struct {
+	TaskID      string    `json:"task_id"`
+	PhaseID     string    `json:"phase_id"`
+	Confidence  float64   `json:"confidence"`
+	RiskFactors []string  `json:"risk_factors"`
+	Rationale   string    `json:"rationale,omitempty"`
+	Model       string    `json:"model,omitempty"`
+	Escalated   bool      `json:"escalated"`
+	ScoredAt    time.Time `json:"scored_at"`
+}
+
+// SavePhaseConfidence records the confidence score for a phase, replacing
+// any earlier score for the same task and phase.
+func (p *ProjectDB) SavePhaseConfidence(c *PhaseConfidence) error {
+	if c.ScoredAt.IsZero() {
+		c.ScoredAt = time.Now().UTC()
+	}
+	if c.RiskFactors == nil {
+		c.RiskFactors = []string{}
+	}
+	risks, err := json.Marshal(c.RiskFactors)
+	if err != nil {
+		return fmt.Errorf("marshal risk factors: %w", err)
+	}
+
+	_, err = p.Exec(`
+		INSERT INTO phase_confidence (task_id, phase_id, confidence, risk_factors, rationale, model, escalated, scored_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(task_id, phase_id) DO UPDATE SET
+			confidence = excluded.confidence,
+			risk_factors = excluded.risk_factors,
+			rationale = excluded.rationale,
+			model = excluded.model,
+			escalated = excluded.escalated,
+			scored_at = excluded.scored_at
+	`, c.TaskID, c.PhaseID, c.Confidence, string(risks), c.Rationale, c.Model, c.Escalated, c.ScoredAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("save phase confidence %s/%s: %w", c.TaskID, c.PhaseID, err)
+	}
+	return nil
+}
+
+// GetPhaseConfidences returns the confidence scores for a task keyed by phase ID.
+func (p *ProjectDB) GetPhaseConfidences(taskID string) (map[string]*PhaseConfidence, error) {
+	rows, err := p.Query(`
+		SELECT task_id, phase_id, confidence, risk_factors, rationale, model, escalated, scored_at
+		FROM phase_confidence WHERE task_id = ?
+	`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("get phase confidences: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	scores := make(map[string]*PhaseConfidence)
+	for rows.Next() {
+		var c PhaseConfidence
+		var risks, scoredAt string
+		if err := rows.Scan(&c.TaskID, &c.PhaseID, &c.Confidence, &risks, &c.Rationale, &c.Model, &c.Escalated, &scoredAt); err != nil {
+			return nil, fmt.Errorf("scan phase confidence: %w", err)
+		}
+		if err := json.Unmarshal([]byte(risks), &c.RiskFactors); err != nil {
+			return nil, fmt.Errorf("unmarshal risk factors for %s/%s: %w", c.TaskID, c.PhaseID, err)
+		}
+		c.ScoredAt = parseTimestamp(scoredAt)
+		scores[c.PhaseID] = &c
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate phase confidences: %w", err)
+	}
+	return scores, nil
+}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProjectDB_PhaseConfidence(t *testing.T) {
+	t.Parallel()
+	pdb := NewTestProjectDB(t)
+	if err := pdb.SaveTask(&Task{ID: "TASK-001", Title: "Task 1", Status: "pending", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("SaveTask failed: %v", err)
+	}
+
+	if err := pdb.SavePhaseConfidence(&PhaseConfidence{TaskID: "TASK-001", PhaseID: "implement", Confidence: 0.4, RiskFactors: []string{"no tests"}}); err != nil {
+		t.Fatalf("SavePhaseConfidence failed: %v", err)
+	}
+	// Re-scoring a phase replaces its earlier score.
+	if err := pdb.SavePhaseConfidence(&PhaseConfidence{TaskID: "TASK-001", PhaseID: "implement", Confidence: 0.9, Model: "haiku", Escalated: true}); err != nil {
+		t.Fatalf("SavePhaseConfidence failed: %v", err)
+	}
+	if err := pdb.SavePhaseConfidence(&PhaseConfidence{TaskID: "TASK-001", PhaseID: "spec", Confidence: 0.7, RiskFactors: []string{"vague scope", "new dependency"}}); err != nil {
+		t.Fatalf("SavePhaseConfidence failed: %v", err)
+	}
+
+	scores, err := pdb.GetPhaseConfidences("TASK-001")
+	if err != nil {
+		t.Fatalf("GetPhaseConfidences failed: %v", err)
+	}
+	if len(scores) != 2 {
+		t.Fatalf("scores = %v, want 2 phases", scores)
+	}
+	impl := scores["implement"]
+	if impl.Confidence != 0.9 || impl.Model != "haiku" || !impl.Escalated || len(impl.RiskFactors) != 0 || impl.ScoredAt.IsZero() {
+		t.Errorf("implement = %+v, want latest score", impl)
+	}
+	if spec := scores["spec"]; len(spec.RiskFactors) != 2 || spec.RiskFactors[1] != "new dependency" {
+		t.Errorf("spec risk factors = %v", spec.RiskFactors)
+	}
+
+	none, err := pdb.GetPhaseConfidences("TASK-404")
+	if err != nil || len(none) != 0 {
+		t.Errorf("GetPhaseConfidences(missing) = %v, %v; want empty", none, err)
+	}
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VoteCandidate is one attempt of a voting phase as recorded in its comparison.
+type VoteCandidate struct {
+	Index     int     `json:"index"`
+	Branch    string  `json:"branch"`
+	CommitSHA string  `json:"commit_sha,omitempty"`
+	Summary   string  `json:"summary,omitempty"`
+	Score     float64 `json:"score"`
+	Notes     string  `json:"notes,omitempty"`
+	CostUSD   float64 `json:"cost_usd"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// PhaseVote records how a voting phase's candidates compared and which one
+// the judge picked.
+type PhaseVote struct {
+	ID         string          `json:"id"`
+	TaskID     string          `json:"task_id"`
+	PhaseID    string          `json:"phase_id"`
+	RunID      string          `json:"run_id,omitempty"`
+	JudgeModel string          `json:"judge_model,omitempty"`
+	Winner     int             `json:"winner"`
+	Merged     bool            `json:"merged"`
+	Rationale  string          `json:"rationale,omitempty"`
+	Candidates []VoteCandidate `json:"candidates"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// SavePhaseVote records a voting phase comparison. ID and CreatedAt are
+// assigned when empty.
+func (p *ProjectDB) SavePhaseVote(v *PhaseVote) error {
+	if v.ID == "" {
+		v.ID = "VOTE-" + uuid.New().String()[:8]
+	}
+	if v.CreatedAt.IsZero() {
+		v.CreatedAt = time.Now().UTC()
+	}
+	if v.Candidates == nil {
+		v.Candidates = []VoteCandidate{}
+	}
+	candidates, err := json.Marshal(v.Candidates)
+	if err != nil {
+		return fmt.Errorf("marshal vote candidates: %w", err)
+	}
+
+	_, err = p.Exec(`
+		INSERT INTO phase_votes (id, task_id, phase_id, run_id, judge_model, winner, merged, rationale, candidates, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, v.ID, v.TaskID, v.PhaseID, v.RunID, v.JudgeModel, v.Winner, v.Merged, v.Rationale, string(candidates), v.CreatedAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("save phase vote %s/%s: %w", v.TaskID, v.PhaseID, err)
+	}
+	return nil
+}
+
+// GetPhaseVotes returns a task's voting comparisons, oldest first.
+func (p *ProjectDB) GetPhaseVotes(taskID string) ([]*PhaseVote, error) {
+	rows, err := p.Query(`
+		SELECT id, task_id, phase_id, run_id, judge_model, winner, merged, rationale, candidates, created_at
+		FROM phase_votes WHERE task_id = ?
+		ORDER BY created_at, id
+	`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("get phase votes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var votes []*PhaseVote
+	for rows.Next() {
+		var v PhaseVote
+		var candidates, createdAt string
+		if err := rows.Scan(&v.ID, &v.TaskID, &v.PhaseID, &v.RunID, &v.JudgeModel, &v.Winner, &v.Merged, &v.Rationale, &candidates, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan phase vote: %w", err)
+		}
+		if err := json.Unmarshal([]byte(candidates), &v.Candidates); err != nil {
+			return nil, fmt.Errorf("unmarshal candidates for vote %s: %w", v.ID, err)
+		}
+		v.CreatedAt = parseTimestamp(createdAt)
+		votes = append(votes, &v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate phase votes: %w", err)
+	}
+	return votes, nil
+}
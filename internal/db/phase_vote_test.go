@@ -0,0 +1,54 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProjectDB_PhaseVotes(t *testing.T) {
+	t.Parallel()
+	pdb := NewTestProjectDB(t)
+	if err := pdb.SaveTask(&Task{ID: "TASK-001", Title: "Task 1", Status: "pending", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("SaveTask failed: %v", err)
+	}
+
+	vote := &PhaseVote{
+		TaskID:     "TASK-001",
+		PhaseID:    "implement",
+		RunID:      "RUN-1",
+		JudgeModel: "opus",
+		Winner:     2,
+		Merged:     true,
+		Rationale:  "candidate 2 covers the rollback path",
+		Candidates: []VoteCandidate{
+			{Index: 1, Branch: "orc/TASK-001-vote-1", CommitSHA: "aaa", Score: 0.5, CostUSD: 0.4},
+			{Index: 2, Branch: "orc/TASK-001-vote-2", CommitSHA: "bbb", Score: 0.9, CostUSD: 0.6},
+			{Index: 3, Branch: "orc/TASK-001-vote-3", Error: "max turns reached"},
+		},
+	}
+	if err := pdb.SavePhaseVote(vote); err != nil {
+		t.Fatalf("SavePhaseVote failed: %v", err)
+	}
+	if vote.ID == "" {
+		t.Fatal("SavePhaseVote did not assign an ID")
+	}
+
+	votes, err := pdb.GetPhaseVotes("TASK-001")
+	if err != nil {
+		t.Fatalf("GetPhaseVotes failed: %v", err)
+	}
+	if len(votes) != 1 {
+		t.Fatalf("votes = %v, want 1", votes)
+	}
+	got := votes[0]
+	if got.ID != vote.ID || got.Winner != 2 || !got.Merged || got.JudgeModel != "opus" || got.CreatedAt.IsZero() {
+		t.Errorf("vote = %+v", got)
+	}
+	if len(got.Candidates) != 3 || got.Candidates[1].CommitSHA != "bbb" || got.Candidates[2].Error != "max turns reached" {
+		t.Errorf("candidates = %+v", got.Candidates)
+	}
+
+	if votes, err := pdb.GetPhaseVotes("TASK-404"); err != nil || len(votes) != 0 {
+		t.Errorf("GetPhaseVotes(unknown) = %v, %v; want none", votes, err)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"path"
+	"strings"
 	"time"
 )
 
@@ -21,9 +23,14 @@ import (
 //   - tests (scope="") - global test runner
 //   - tests (scope="go") - go test ./...
 //   - tests (scope="frontend") - npm test
+//
+// Path makes a command a per-directory profile for monorepos: it applies to
+// tasks whose changes fall under that directory and runs from it. Commands
+// with an empty path cover the whole repository.
 type ProjectCommand struct {
 	Name         string    `json:"name"`          // Command name: 'tests', 'lint', 'build', 'typecheck', or custom
 	Scope        string    `json:"scope"`         // Language/stack scope: '', 'go', 'frontend', 'python', etc.
+	Path         string    `json:"path"`          // Repo-relative directory: '', 'services/api', 'web', etc.
 	Domain       string    `json:"domain"`        // 'code' or 'custom'
 	Command      string    `json:"command"`       // Full command: 'go test ./...'
 	ShortCommand string    `json:"short_command"` // Optional short variant: 'go test -short ./...'
@@ -45,25 +52,38 @@ func ScopedCommandKey(name, scope string) string {
 	return name + ":" + scope
 }
 
+// NormalizeCommandPath cleans a project command path to the stored form:
+// slash-separated and relative to the repository root, with "" for the root.
+func NormalizeCommandPath(dir string) string {
+	dir = path.Clean(strings.ReplaceAll(strings.TrimSpace(dir), "\\", "/"))
+	dir = strings.Trim(dir, "/")
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
 // SaveProjectCommand creates or updates a project command.
-// The unique key is (name, scope), allowing the same command name with different scopes.
+// The unique key is (name, scope, path), allowing the same command name with
+// different scopes and per-directory profiles.
 func (p *ProjectDB) SaveProjectCommand(cmd *ProjectCommand) error {
 	now := time.Now().UTC().Format(time.RFC3339)
+	cmd.Path = NormalizeCommandPath(cmd.Path)
 
 	_, err := p.Exec(`
-		INSERT INTO project_commands (name, scope, domain, command, short_command, enabled, description, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, COALESCE((SELECT created_at FROM project_commands WHERE name = ? AND scope = ?), ?), ?)
-		ON CONFLICT(name, scope) DO UPDATE SET
+		INSERT INTO project_commands (name, scope, path, domain, command, short_command, enabled, description, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, COALESCE((SELECT created_at FROM project_commands WHERE name = ? AND scope = ? AND path = ?), ?), ?)
+		ON CONFLICT(name, scope, path) DO UPDATE SET
 			domain = excluded.domain,
 			command = excluded.command,
 			short_command = excluded.short_command,
 			enabled = excluded.enabled,
 			description = excluded.description,
 			updated_at = excluded.updated_at
-	`, cmd.Name, cmd.Scope, cmd.Domain, cmd.Command, cmd.ShortCommand, cmd.Enabled, cmd.Description,
-		cmd.Name, cmd.Scope, now, now)
+	`, cmd.Name, cmd.Scope, cmd.Path, cmd.Domain, cmd.Command, cmd.ShortCommand, cmd.Enabled, cmd.Description,
+		cmd.Name, cmd.Scope, cmd.Path, now, now)
 	if err != nil {
-		return fmt.Errorf("save project command %s (scope=%s): %w", cmd.Name, cmd.Scope, err)
+		return fmt.Errorf("save project command %s (scope=%s, path=%s): %w", cmd.Name, cmd.Scope, cmd.Path, err)
 	}
 
 	return nil
@@ -76,21 +96,29 @@ func (p *ProjectDB) GetProjectCommand(name string) (*ProjectCommand, error) {
 	return p.GetProjectCommandScoped(name, "")
 }
 
-// GetProjectCommandScoped retrieves a project command by name and scope.
+// GetProjectCommandScoped retrieves a whole-repository project command by
+// name and scope.
 // Returns ErrProjectCommandNotFound if the command doesn't exist.
 func (p *ProjectDB) GetProjectCommandScoped(name, scope string) (*ProjectCommand, error) {
+	return p.GetProjectCommandAt(name, scope, "")
+}
+
+// GetProjectCommandAt retrieves a project command by name, scope and path.
+// Returns ErrProjectCommandNotFound if the command doesn't exist.
+func (p *ProjectDB) GetProjectCommandAt(name, scope, dir string) (*ProjectCommand, error) {
+	dir = NormalizeCommandPath(dir)
 	row := p.QueryRow(`
-		SELECT name, scope, domain, command, short_command, enabled, description, created_at, updated_at
+		SELECT name, scope, path, domain, command, short_command, enabled, description, created_at, updated_at
 		FROM project_commands
-		WHERE name = ? AND scope = ?
-	`, name, scope)
+		WHERE name = ? AND scope = ? AND path = ?
+	`, name, scope, dir)
 
 	cmd, err := scanProjectCommand(row)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, ErrProjectCommandNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("get project command %s (scope=%s): %w", name, scope, err)
+		return nil, fmt.Errorf("get project command %s (scope=%s, path=%s): %w", name, scope, dir, err)
 	}
 
 	return cmd, nil
@@ -99,9 +127,9 @@ func (p *ProjectDB) GetProjectCommandScoped(name, scope string) (*ProjectCommand
 // ListProjectCommands returns all project commands ordered by name and scope.
 func (p *ProjectDB) ListProjectCommands() ([]*ProjectCommand, error) {
 	rows, err := p.Query(`
-		SELECT name, scope, domain, command, short_command, enabled, description, created_at, updated_at
+		SELECT name, scope, path, domain, command, short_command, enabled, description, created_at, updated_at
 		FROM project_commands
-		ORDER BY name ASC, scope ASC
+		ORDER BY name ASC, scope ASC, path ASC
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("list project commands: %w", err)
@@ -124,10 +152,10 @@ func (p *ProjectDB) ListProjectCommands() ([]*ProjectCommand, error) {
 // Use "" for global commands, "go" for Go-specific, "frontend" for frontend, etc.
 func (p *ProjectDB) ListProjectCommandsByScope(scope string) ([]*ProjectCommand, error) {
 	rows, err := p.Query(`
-		SELECT name, scope, domain, command, short_command, enabled, description, created_at, updated_at
+		SELECT name, scope, path, domain, command, short_command, enabled, description, created_at, updated_at
 		FROM project_commands
 		WHERE scope = ?
-		ORDER BY name ASC
+		ORDER BY name ASC, path ASC
 	`, scope)
 	if err != nil {
 		return nil, fmt.Errorf("list project commands by scope %s: %w", scope, err)
@@ -149,10 +177,10 @@ func (p *ProjectDB) ListProjectCommandsByScope(scope string) ([]*ProjectCommand,
 // ListProjectCommandsByDomain returns all project commands for a specific domain.
 func (p *ProjectDB) ListProjectCommandsByDomain(domain string) ([]*ProjectCommand, error) {
 	rows, err := p.Query(`
-		SELECT name, scope, domain, command, short_command, enabled, description, created_at, updated_at
+		SELECT name, scope, path, domain, command, short_command, enabled, description, created_at, updated_at
 		FROM project_commands
 		WHERE domain = ?
-		ORDER BY name ASC, scope ASC
+		ORDER BY name ASC, scope ASC, path ASC
 	`, domain)
 	if err != nil {
 		return nil, fmt.Errorf("list project commands by domain %s: %w", domain, err)
@@ -174,10 +202,10 @@ func (p *ProjectDB) ListProjectCommandsByDomain(domain string) ([]*ProjectComman
 // ListEnabledProjectCommands returns all enabled project commands.
 func (p *ProjectDB) ListEnabledProjectCommands() ([]*ProjectCommand, error) {
 	rows, err := p.Query(`
-		SELECT name, scope, domain, command, short_command, enabled, description, created_at, updated_at
+		SELECT name, scope, path, domain, command, short_command, enabled, description, created_at, updated_at
 		FROM project_commands
 		WHERE enabled = TRUE
-		ORDER BY name ASC, scope ASC
+		ORDER BY name ASC, scope ASC, path ASC
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("list enabled project commands: %w", err)
@@ -196,8 +224,9 @@ func (p *ProjectDB) ListEnabledProjectCommands() ([]*ProjectCommand, error) {
 	return commands, rows.Err()
 }
 
-// GetProjectCommandsMap returns all project commands as a map keyed by "name:scope".
-// For global commands (scope=""), the key is just "name".
+// GetProjectCommandsMap returns the whole-repository project commands as a
+// map keyed by "name:scope". For global commands (scope=""), the key is just
+// "name". Per-directory profiles are left out; see ListProjectCommands.
 // Useful for quick lookups when executing quality checks.
 func (p *ProjectDB) GetProjectCommandsMap() (map[string]*ProjectCommand, error) {
 	commands, err := p.ListProjectCommands()
@@ -207,6 +236,9 @@ func (p *ProjectDB) GetProjectCommandsMap() (map[string]*ProjectCommand, error)
 
 	result := make(map[string]*ProjectCommand, len(commands))
 	for _, cmd := range commands {
+		if cmd.Path != "" {
+			continue
+		}
 		key := ScopedCommandKey(cmd.Name, cmd.Scope)
 		result[key] = cmd
 	}
@@ -214,14 +246,15 @@ func (p *ProjectDB) GetProjectCommandsMap() (map[string]*ProjectCommand, error)
 	return result, nil
 }
 
-// GetProjectCommandsForScope returns commands that apply to a specific scope.
+// GetProjectCommandsForScope returns whole-repository commands that apply to
+// a specific scope.
 // This includes both scope-specific commands AND global (scope="") commands.
 // Scope-specific commands take precedence over global ones.
 func (p *ProjectDB) GetProjectCommandsForScope(scope string) (map[string]*ProjectCommand, error) {
 	rows, err := p.Query(`
-		SELECT name, scope, domain, command, short_command, enabled, description, created_at, updated_at
+		SELECT name, scope, path, domain, command, short_command, enabled, description, created_at, updated_at
 		FROM project_commands
-		WHERE scope = ? OR scope = ''
+		WHERE (scope = ? OR scope = '') AND path = ''
 		ORDER BY name ASC, scope DESC
 	`, scope)
 	if err != nil {
@@ -250,11 +283,18 @@ func (p *ProjectDB) DeleteProjectCommand(name string) error {
 	return p.DeleteProjectCommandScoped(name, "")
 }
 
-// DeleteProjectCommandScoped removes a project command by name and scope.
+// DeleteProjectCommandScoped removes a whole-repository project command by
+// name and scope.
 func (p *ProjectDB) DeleteProjectCommandScoped(name, scope string) error {
-	result, err := p.Exec("DELETE FROM project_commands WHERE name = ? AND scope = ?", name, scope)
+	return p.DeleteProjectCommandAt(name, scope, "")
+}
+
+// DeleteProjectCommandAt removes a project command by name, scope and path.
+func (p *ProjectDB) DeleteProjectCommandAt(name, scope, dir string) error {
+	dir = NormalizeCommandPath(dir)
+	result, err := p.Exec("DELETE FROM project_commands WHERE name = ? AND scope = ? AND path = ?", name, scope, dir)
 	if err != nil {
-		return fmt.Errorf("delete project command %s (scope=%s): %w", name, scope, err)
+		return fmt.Errorf("delete project command %s (scope=%s, path=%s): %w", name, scope, dir, err)
 	}
 
 	rows, _ := result.RowsAffected()
@@ -271,17 +311,25 @@ func (p *ProjectDB) SetProjectCommandEnabled(name string, enabled bool) error {
 	return p.SetProjectCommandEnabledScoped(name, "", enabled)
 }
 
-// SetProjectCommandEnabledScoped enables or disables a project command by name and scope.
+// SetProjectCommandEnabledScoped enables or disables a whole-repository
+// project command by name and scope.
 func (p *ProjectDB) SetProjectCommandEnabledScoped(name, scope string, enabled bool) error {
+	return p.SetProjectCommandEnabledAt(name, scope, "", enabled)
+}
+
+// SetProjectCommandEnabledAt enables or disables a project command by name,
+// scope and path.
+func (p *ProjectDB) SetProjectCommandEnabledAt(name, scope, dir string, enabled bool) error {
 	now := time.Now().UTC().Format(time.RFC3339)
+	dir = NormalizeCommandPath(dir)
 
 	result, err := p.Exec(`
 		UPDATE project_commands
 		SET enabled = ?, updated_at = ?
-		WHERE name = ? AND scope = ?
-	`, enabled, now, name, scope)
+		WHERE name = ? AND scope = ? AND path = ?
+	`, enabled, now, name, scope, dir)
 	if err != nil {
-		return fmt.Errorf("set project command enabled %s (scope=%s): %w", name, scope, err)
+		return fmt.Errorf("set project command enabled %s (scope=%s, path=%s): %w", name, scope, dir, err)
 	}
 
 	rows, _ := result.RowsAffected()
@@ -302,6 +350,7 @@ func scanProjectCommand(row rowScanner) (*ProjectCommand, error) {
 	err := row.Scan(
 		&cmd.Name,
 		&scope,
+		&cmd.Path,
 		&cmd.Domain,
 		&cmd.Command,
 		&shortCommand,
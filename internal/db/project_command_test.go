@@ -0,0 +1,64 @@
+package db
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestProjectCommand_PathProfiles(t *testing.T) {
+	pdb := NewTestProjectDB(t)
+
+	if err := pdb.SaveProjectCommand(&ProjectCommand{Name: "tests", Command: "make test", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pdb.SaveProjectCommand(&ProjectCommand{Name: "tests", Path: "./services/api/", Command: "go test ./...", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	profile, err := pdb.GetProjectCommandAt("tests", "", "services/api")
+	if err != nil {
+		t.Fatalf("get profile: %v", err)
+	}
+	if profile.Path != "services/api" || profile.Command != "go test ./..." {
+		t.Errorf("profile = %+v", profile)
+	}
+	if global, err := pdb.GetProjectCommand("tests"); err != nil || global.Command != "make test" {
+		t.Errorf("whole-repository command = %+v, %v", global, err)
+	}
+
+	all, err := pdb.ListProjectCommands()
+	if err != nil || len(all) != 2 {
+		t.Fatalf("list = %d commands, %v; want 2", len(all), err)
+	}
+	m, err := pdb.GetProjectCommandsMap()
+	if err != nil || len(m) != 1 || m["tests"].Path != "" {
+		t.Errorf("commands map = %+v, %v; want only the whole-repository command", m, err)
+	}
+
+	if err := pdb.SetProjectCommandEnabledAt("tests", "", "services/api", false); err != nil {
+		t.Fatal(err)
+	}
+	if profile, _ := pdb.GetProjectCommandAt("tests", "", "services/api"); profile == nil || profile.Enabled {
+		t.Errorf("profile should be disabled: %+v", profile)
+	}
+	if err := pdb.DeleteProjectCommandAt("tests", "", "services/api"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pdb.GetProjectCommandAt("tests", "", "services/api"); !errors.Is(err, ErrProjectCommandNotFound) {
+		t.Errorf("after delete: err = %v, want ErrProjectCommandNotFound", err)
+	}
+}
+
+func TestNormalizeCommandPath(t *testing.T) {
+	for in, want := range map[string]string{
+		"":               "",
+		".":              "",
+		"./web/":         "web",
+		"services\\api":  "services/api",
+		"/services//api": "services/api",
+	} {
+		if got := NormalizeCommandPath(in); got != want {
+			t.Errorf("NormalizeCommandPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
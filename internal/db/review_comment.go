@@ -146,6 +146,38 @@ func (p *ProjectDB) ListReviewCommentsByRound(taskID string, round int) ([]Revie
 	return comments, nil
 }
 
+// ListAllReviewComments returns the review comments of every task created
+// at or after since, oldest first. A zero since returns all comments.
+func (p *ProjectDB) ListAllReviewComments(since time.Time) ([]ReviewComment, error) {
+	rows, err := p.Query(`
+		SELECT id, task_id, review_round, file_path, line_number, content, severity, status, created_at, resolved_at, resolved_by
+		FROM review_comments ORDER BY created_at, id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list all review comments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var comments []ReviewComment
+	for rows.Next() {
+		c, err := scanReviewCommentRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		// created_at formats differ between drivers, so filter after parsing
+		if !since.IsZero() && c.CreatedAt.Before(since) {
+			continue
+		}
+		comments = append(comments, *c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate review comments: %w", err)
+	}
+
+	return comments, nil
+}
+
 // UpdateReviewComment updates a comment.
 func (p *ProjectDB) UpdateReviewComment(c *ReviewComment) error {
 	_, err := p.Exec(`
@@ -0,0 +1,33 @@
+-- Global database migration 014: Tenants for multi-tenant server mode
+-- A tenant owns projects; tasks inherit the tenant of their project because
+-- each project keeps its own database. API tokens are stored as SHA-256
+-- hashes and resolve to exactly one tenant.
+
+CREATE TABLE IF NOT EXISTS tenants (
+    id TEXT PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL,
+    max_projects INTEGER DEFAULT 0,
+    max_running_tasks INTEGER DEFAULT 0,
+    created_at TEXT DEFAULT (datetime('now'))
+);
+
+CREATE TABLE IF NOT EXISTS tenant_tokens (
+    token_hash TEXT PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    created_at TEXT DEFAULT (datetime('now')),
+    last_used_at TEXT,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_tenant_tokens_tenant ON tenant_tokens(tenant_id);
+
+-- project_id is the primary key: a project belongs to at most one tenant.
+CREATE TABLE IF NOT EXISTS tenant_projects (
+    project_id TEXT PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    created_at TEXT DEFAULT (datetime('now')),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_tenant_projects_tenant ON tenant_projects(tenant_id);
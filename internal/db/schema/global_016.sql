@@ -0,0 +1,6 @@
+-- Global database migration 016: Workflow-level done criteria
+-- JSON array of definition-of-done checks (coverage floor, docs updated,
+-- changelog entry, no TODOs added, custom command) evaluated before the
+-- completion action runs.
+
+ALTER TABLE workflows ADD COLUMN done_criteria TEXT DEFAULT '';
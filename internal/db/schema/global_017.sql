@@ -0,0 +1,13 @@
+-- Global database migration 017: API tokens for server token auth
+-- With server.auth.enabled, mutating API requests need a bearer token from
+-- this table. Tokens are stored as SHA-256 hashes; rotation replaces the
+-- hash and keeps the name and scope. Scope "read" tokens cannot mutate.
+
+CREATE TABLE IF NOT EXISTS api_tokens (
+    name TEXT PRIMARY KEY,
+    token_hash TEXT UNIQUE NOT NULL,
+    scope TEXT NOT NULL DEFAULT 'write',
+    created_at TEXT DEFAULT (datetime('now')),
+    rotated_at TEXT,
+    last_used_at TEXT
+);
@@ -0,0 +1,20 @@
+-- Global database migration 015: Coordination for high-availability server mode
+-- Server instances sharing a database elect a leader through a named lease
+-- and relay events to each other through an append-only log. Times are Unix
+-- milliseconds so lease comparisons are identical across dialects.
+
+CREATE TABLE IF NOT EXISTS server_leases (
+    name TEXT PRIMARY KEY,
+    holder TEXT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    acquired_at BIGINT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS server_events (
+    id BIGSERIAL PRIMARY KEY,
+    origin TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_server_events_created ON server_events(created_at);
@@ -0,0 +1,16 @@
+-- Migration 074: Runs of registered project scripts (.orc/scripts/registry.yaml)
+-- started from the API, with their parameters, exit status, and captured output.
+
+CREATE TABLE IF NOT EXISTS script_runs (
+    id TEXT PRIMARY KEY,
+    script_name TEXT NOT NULL,
+    params_json TEXT,
+    status TEXT NOT NULL DEFAULT 'running',
+    exit_code INTEGER,
+    output TEXT NOT NULL DEFAULT '',
+    error TEXT,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_script_runs_script ON script_runs(script_name, started_at DESC);
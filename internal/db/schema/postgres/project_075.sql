@@ -0,0 +1,4 @@
+-- Migration 075: Workflow-level done criteria
+-- Mirrors global_016 so project and global workflow tables stay identical.
+
+ALTER TABLE workflows ADD COLUMN done_criteria TEXT DEFAULT '';
@@ -0,0 +1,15 @@
+-- Migration 076: Confidence scores for completed phases
+-- Kept outside the phases table, which is rewritten on every task save.
+
+CREATE TABLE IF NOT EXISTS phase_confidence (
+    task_id TEXT NOT NULL,
+    phase_id TEXT NOT NULL,
+    confidence DOUBLE PRECISION NOT NULL,
+    risk_factors TEXT NOT NULL DEFAULT '[]',
+    rationale TEXT NOT NULL DEFAULT '',
+    model TEXT NOT NULL DEFAULT '',
+    escalated BOOLEAN NOT NULL DEFAULT FALSE,
+    scored_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (task_id, phase_id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
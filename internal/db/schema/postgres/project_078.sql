@@ -0,0 +1,16 @@
+-- Migration 078: Active execution time per phase attempt
+-- One row per phase execution. Queue, pause, and gate-wait time fall between
+-- rows, so a task's active time is the sum of its rows.
+
+CREATE TABLE IF NOT EXISTS phase_timings (
+    id BIGSERIAL PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    phase_id TEXT NOT NULL,
+    run_id TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMPTZ NOT NULL,
+    active_ms BIGINT NOT NULL DEFAULT 0,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_phase_timings_task ON phase_timings(task_id);
@@ -0,0 +1,46 @@
+-- Migration 080: Full-text search over tasks and specs
+-- GET /api/search and `orc search` match task titles and descriptions and
+-- spec content alongside transcripts, using tsvector columns like
+-- transcripts.search_vector.
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+UPDATE tasks
+SET search_vector = to_tsvector('english', COALESCE(title, '') || ' ' || COALESCE(description, ''))
+WHERE search_vector IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_fts ON tasks USING GIN (search_vector);
+
+CREATE OR REPLACE FUNCTION tasks_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := to_tsvector('english', COALESCE(NEW.title, '') || ' ' || COALESCE(NEW.description, ''));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_tasks_search_vector ON tasks;
+CREATE TRIGGER trg_tasks_search_vector
+    BEFORE INSERT OR UPDATE OF title, description ON tasks
+    FOR EACH ROW
+    EXECUTE FUNCTION tasks_search_vector_update();
+
+ALTER TABLE phase_outputs ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+UPDATE phase_outputs
+SET search_vector = to_tsvector('english', COALESCE(content, ''))
+WHERE search_vector IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_phase_outputs_fts ON phase_outputs USING GIN (search_vector);
+
+CREATE OR REPLACE FUNCTION phase_outputs_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := to_tsvector('english', COALESCE(NEW.content, ''));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_phase_outputs_search_vector ON phase_outputs;
+CREATE TRIGGER trg_phase_outputs_search_vector
+    BEFORE INSERT OR UPDATE OF content ON phase_outputs
+    FOR EACH ROW
+    EXECUTE FUNCTION phase_outputs_search_vector_update();
@@ -0,0 +1,9 @@
+-- Migration 083: Per-directory project command profiles
+-- path scopes a command to a subdirectory of a monorepo ('' = whole repo).
+-- The executor picks the most specific profile for the files a task changed
+-- and runs its command from that directory.
+
+ALTER TABLE project_commands ADD COLUMN IF NOT EXISTS path TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE project_commands DROP CONSTRAINT project_commands_pkey;
+ALTER TABLE project_commands ADD PRIMARY KEY (name, scope, path);
@@ -0,0 +1,8 @@
+-- Migration 085: Gate approval escalation
+-- A human gate that waits past gates.timeout.after is escalated: the
+-- executor notifies again, reassigns it, or decides it. escalations counts
+-- the timeouts handled so far, and assignee is who the gate was handed to.
+
+ALTER TABLE gate_approvals ADD COLUMN IF NOT EXISTS assignee TEXT;
+ALTER TABLE gate_approvals ADD COLUMN IF NOT EXISTS escalations INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE gate_approvals ADD COLUMN IF NOT EXISTS escalated_at TIMESTAMPTZ;
@@ -0,0 +1,24 @@
+-- Migration 087: Context documents
+-- Project-level documents (architecture.md, conventions.md, ...) managed
+-- through the API and included in the prompts of the phases they list, for
+-- every task. Each save is kept as a numbered version.
+
+CREATE TABLE IF NOT EXISTS context_documents (
+    name TEXT PRIMARY KEY,
+    content TEXT NOT NULL,
+    phases TEXT NOT NULL DEFAULT '[]',
+    version INTEGER NOT NULL,
+    updated_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS context_document_versions (
+    name TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    content TEXT NOT NULL,
+    phases TEXT NOT NULL DEFAULT '[]',
+    updated_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (name, version)
+);
@@ -0,0 +1,17 @@
+-- Migration 089: Spec-to-implementation traceability reports
+-- One row per report: each success criterion of the task's spec mapped to
+-- the code and tests that satisfy it, as judged by the validation model.
+
+CREATE TABLE IF NOT EXISTS traceability_reports (
+    id BIGSERIAL PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    run_id TEXT NOT NULL DEFAULT '',
+    phase_id TEXT NOT NULL,
+    spec_hash TEXT NOT NULL DEFAULT '',
+    entries TEXT NOT NULL DEFAULT '[]',
+    model TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_traceability_reports_task ON traceability_reports(task_id, id);
@@ -0,0 +1,10 @@
+-- Migration 090: Task tombstones
+-- One row per deleted task, so polling clients syncing the task list by
+-- delta learn about deletions.
+
+CREATE TABLE IF NOT EXISTS task_tombstones (
+    task_id TEXT PRIMARY KEY,
+    deleted_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_tombstones_deleted_at ON task_tombstones(deleted_at);
@@ -0,0 +1,18 @@
+-- Migration 077: Voting-mode comparisons
+-- One row per voting phase run: every candidate's outcome and the judge's pick.
+
+CREATE TABLE IF NOT EXISTS phase_votes (
+    id TEXT PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    phase_id TEXT NOT NULL,
+    run_id TEXT NOT NULL DEFAULT '',
+    judge_model TEXT NOT NULL DEFAULT '',
+    winner INTEGER NOT NULL,
+    merged INTEGER NOT NULL DEFAULT 0,
+    rationale TEXT NOT NULL DEFAULT '',
+    candidates TEXT NOT NULL DEFAULT '[]',
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_phase_votes_task ON phase_votes(task_id, created_at);
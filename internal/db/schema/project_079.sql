@@ -0,0 +1,19 @@
+-- Migration 079: Execution state snapshots
+-- The executor stores the full task state each time it persists a step, so
+-- `orc debug state-diff` can show which step changed what. Identical
+-- consecutive states are skipped and only the newest snapshots are kept.
+
+CREATE TABLE IF NOT EXISTS task_state_snapshots (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_id TEXT NOT NULL,
+    seq INTEGER NOT NULL,
+    label TEXT NOT NULL DEFAULT '',
+    phase_id TEXT NOT NULL DEFAULT '',
+    iteration INTEGER NOT NULL DEFAULT 0,
+    state TEXT NOT NULL,
+    state_hash TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_state_snapshots_seq ON task_state_snapshots(task_id, seq);
@@ -0,0 +1,57 @@
+-- Migration 080: Full-text search over tasks and specs
+-- GET /api/search and `orc search` match task titles and descriptions and
+-- spec content alongside transcripts. tasks has no stable integer rowid, so
+-- tasks_fts stores its own copy keyed by task_id; phase_outputs_fts follows
+-- the transcripts_fts external-content pattern.
+
+CREATE VIRTUAL TABLE IF NOT EXISTS tasks_fts USING fts5(
+    task_id UNINDEXED,
+    title,
+    description
+);
+
+INSERT INTO tasks_fts(task_id, title, description)
+SELECT id, title, COALESCE(description, '') FROM tasks;
+
+CREATE TRIGGER IF NOT EXISTS tasks_fts_ai AFTER INSERT ON tasks BEGIN
+    INSERT INTO tasks_fts(task_id, title, description)
+    VALUES (NEW.id, NEW.title, COALESCE(NEW.description, ''));
+END;
+
+CREATE TRIGGER IF NOT EXISTS tasks_fts_ad AFTER DELETE ON tasks BEGIN
+    DELETE FROM tasks_fts WHERE task_id = OLD.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS tasks_fts_au AFTER UPDATE OF id, title, description ON tasks BEGIN
+    DELETE FROM tasks_fts WHERE task_id = OLD.id;
+    INSERT INTO tasks_fts(task_id, title, description)
+    VALUES (NEW.id, NEW.title, COALESCE(NEW.description, ''));
+END;
+
+CREATE VIRTUAL TABLE IF NOT EXISTS phase_outputs_fts USING fts5(
+    content,
+    task_id UNINDEXED,
+    phase_template_id UNINDEXED,
+    output_var_name UNINDEXED,
+    content=phase_outputs,
+    content_rowid=id
+);
+
+INSERT INTO phase_outputs_fts(phase_outputs_fts) VALUES ('rebuild');
+
+CREATE TRIGGER IF NOT EXISTS phase_outputs_ai AFTER INSERT ON phase_outputs BEGIN
+    INSERT INTO phase_outputs_fts(rowid, content, task_id, phase_template_id, output_var_name)
+    VALUES (NEW.id, NEW.content, NEW.task_id, NEW.phase_template_id, NEW.output_var_name);
+END;
+
+CREATE TRIGGER IF NOT EXISTS phase_outputs_ad AFTER DELETE ON phase_outputs BEGIN
+    INSERT INTO phase_outputs_fts(phase_outputs_fts, rowid, content, task_id, phase_template_id, output_var_name)
+    VALUES ('delete', OLD.id, OLD.content, OLD.task_id, OLD.phase_template_id, OLD.output_var_name);
+END;
+
+CREATE TRIGGER IF NOT EXISTS phase_outputs_au AFTER UPDATE ON phase_outputs BEGIN
+    INSERT INTO phase_outputs_fts(phase_outputs_fts, rowid, content, task_id, phase_template_id, output_var_name)
+    VALUES ('delete', OLD.id, OLD.content, OLD.task_id, OLD.phase_template_id, OLD.output_var_name);
+    INSERT INTO phase_outputs_fts(rowid, content, task_id, phase_template_id, output_var_name)
+    VALUES (NEW.id, NEW.content, NEW.task_id, NEW.phase_template_id, NEW.output_var_name);
+END;
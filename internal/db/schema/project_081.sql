@@ -0,0 +1,15 @@
+-- Migration 081: File summary cache
+-- Per-file summaries (language, size, top-level symbols) keyed by the git
+-- blob hash of the file content. Every task in the project reads the same
+-- cache, so only files whose content changed are summarized again.
+-- last_used_at drives pruning during database maintenance.
+
+CREATE TABLE IF NOT EXISTS file_summaries (
+    content_hash TEXT PRIMARY KEY,
+    language TEXT NOT NULL DEFAULT '',
+    summary TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    last_used_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_file_summaries_last_used ON file_summaries(last_used_at);
@@ -0,0 +1,11 @@
+-- Migration 082: Initiative cost budgets
+-- Spending limit shared by every task in an initiative. The executor sums
+-- tasks.total_cost_usd for the initiative after each iteration and pauses the
+-- running task once the limit is exceeded. Task budgets live in task metadata.
+
+CREATE TABLE IF NOT EXISTS initiative_budgets (
+    initiative_id TEXT PRIMARY KEY,
+    limit_usd REAL NOT NULL,
+    updated_at TEXT NOT NULL,
+    FOREIGN KEY (initiative_id) REFERENCES initiatives(id) ON DELETE CASCADE
+);
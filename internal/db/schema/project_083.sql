@@ -0,0 +1,45 @@
+-- Migration 083: Per-directory project command profiles
+-- path scopes a command to a subdirectory of a monorepo ('' = whole repo).
+-- The executor picks the most specific profile for the files a task changed
+-- and runs its command from that directory.
+--
+-- SQLite can't change a primary key, so the table is rebuilt. The copy reads
+-- from the old table, so make sure it exists.
+
+CREATE TABLE IF NOT EXISTS project_commands (
+    name TEXT NOT NULL,
+    scope TEXT NOT NULL DEFAULT '',
+    domain TEXT NOT NULL DEFAULT 'code',
+    command TEXT NOT NULL,
+    short_command TEXT,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    description TEXT,
+    created_at TEXT DEFAULT (datetime('now')),
+    updated_at TEXT DEFAULT (datetime('now')),
+    PRIMARY KEY (name, scope)
+);
+
+CREATE TABLE IF NOT EXISTS project_commands_new (
+    name TEXT NOT NULL,
+    scope TEXT NOT NULL DEFAULT '',
+    path TEXT NOT NULL DEFAULT '',       -- '', 'services/api', 'web', etc.
+    domain TEXT NOT NULL DEFAULT 'code',
+    command TEXT NOT NULL,
+    short_command TEXT,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    description TEXT,
+    created_at TEXT DEFAULT (datetime('now')),
+    updated_at TEXT DEFAULT (datetime('now')),
+    PRIMARY KEY (name, scope, path)
+);
+
+INSERT OR IGNORE INTO project_commands_new (name, scope, path, domain, command, short_command, enabled, description, created_at, updated_at)
+SELECT name, scope, '', domain, command, short_command, enabled, description, created_at, updated_at
+FROM project_commands;
+
+DROP TABLE IF EXISTS project_commands;
+ALTER TABLE project_commands_new RENAME TO project_commands;
+
+CREATE INDEX IF NOT EXISTS idx_project_commands_domain ON project_commands(domain);
+CREATE INDEX IF NOT EXISTS idx_project_commands_enabled ON project_commands(enabled);
+CREATE INDEX IF NOT EXISTS idx_project_commands_scope ON project_commands(name, scope);
@@ -0,0 +1,24 @@
+-- Migration 084: Gate approvals
+-- One row per human gate the executor is waiting on. The executor creates
+-- the row, polls it until it is approved or rejected from any channel (API,
+-- CLI, decision UI), then stamps applied_at once it has acted on it. A row
+-- resolved while no executor was waiting is applied when the task resumes.
+
+CREATE TABLE IF NOT EXISTS gate_approvals (
+    id TEXT PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    phase TEXT NOT NULL,
+    gate_type TEXT NOT NULL DEFAULT 'human',
+    question TEXT NOT NULL DEFAULT '',
+    context TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending',  -- pending, approved, rejected
+    comment TEXT,
+    resolved_by TEXT,
+    requested_at TEXT NOT NULL,
+    resolved_at TEXT,
+    applied_at TEXT,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_gate_approvals_status ON gate_approvals(status);
+CREATE INDEX IF NOT EXISTS idx_gate_approvals_task_phase ON gate_approvals(task_id, phase);
@@ -0,0 +1,16 @@
+-- Migration 086: Initiative combined PRs
+-- An initiative with a branch_base collects its member tasks' PRs on that
+-- integration branch. The combined PR from the integration branch to the
+-- project target is opened once the first member merges and its body is
+-- kept up to date with the members' status rollup.
+
+CREATE TABLE IF NOT EXISTS initiative_prs (
+    initiative_id TEXT PRIMARY KEY,
+    pr_number INTEGER NOT NULL,
+    pr_url TEXT NOT NULL DEFAULT '',
+    head_branch TEXT NOT NULL,
+    base_branch TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    FOREIGN KEY (initiative_id) REFERENCES initiatives(id) ON DELETE CASCADE
+);
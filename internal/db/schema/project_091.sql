@@ -0,0 +1,12 @@
+-- Migration 091: Task file hashes
+-- One row per file the files and hybrid storage modes write under
+-- .orc/tasks/<id>/, holding the SHA-256 of what orc last wrote, so an edit
+-- is told apart from a checkout that only touched the file.
+
+CREATE TABLE IF NOT EXISTS task_file_hashes (
+    task_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    hash TEXT NOT NULL,
+    PRIMARY KEY (task_id, name),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
@@ -0,0 +1,158 @@
+package db
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Script run statuses.
+const (
+	ScriptRunRunning   = "running"
+	ScriptRunSucceeded = "succeeded"
+	ScriptRunFailed    = "failed"
+)
+
+// ScriptRun records one execution of a registered project script.
+type ScriptRun struct {
+	ID          string            `json:"id"`
+	ScriptName  string            `json:"script_name"`
+	Params      map[string]string `json:"params,omitempty"`
+	Status      string            `json:"status"`
+	ExitCode    *int              `json:"exit_code,omitempty"`
+	Output      string            `json:"output"`
+	Error       string            `json:"error,omitempty"`
+	StartedAt   time.Time         `json:"started_at"`
+	CompletedAt *time.Time        `json:"completed_at,omitempty"`
+}
+
+// CreateScriptRun inserts a new run in the running state.
+func (p *ProjectDB) CreateScriptRun(r *ScriptRun) error {
+	if r.ID == "" {
+		r.ID = generateScriptRunID()
+	}
+	if r.Status == "" {
+		r.Status = ScriptRunRunning
+	}
+	if r.StartedAt.IsZero() {
+		r.StartedAt = time.Now().UTC()
+	}
+	paramsJSON, err := json.Marshal(r.Params)
+	if err != nil {
+		return fmt.Errorf("marshal script run params: %w", err)
+	}
+
+	_, err = p.Exec(`
+		INSERT INTO script_runs (id, script_name, params_json, status, output, started_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, r.ID, r.ScriptName, string(paramsJSON), r.Status, r.Output, r.StartedAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("create script run: %w", err)
+	}
+	return nil
+}
+
+// CompleteScriptRun stores the final status, exit code, and output of a run.
+func (p *ProjectDB) CompleteScriptRun(r *ScriptRun) error {
+	if r.CompletedAt == nil {
+		now := time.Now().UTC()
+		r.CompletedAt = &now
+	}
+	_, err := p.Exec(`
+		UPDATE script_runs
+		SET status = ?, exit_code = ?, output = ?, error = ?, completed_at = ?
+		WHERE id = ?
+	`, r.Status, r.ExitCode, r.Output, nullableString(r.Error), formatNullableTime(r.CompletedAt), r.ID)
+	if err != nil {
+		return fmt.Errorf("complete script run %s: %w", r.ID, err)
+	}
+	return nil
+}
+
+// GetScriptRun returns a run by ID, or nil if it does not exist.
+func (p *ProjectDB) GetScriptRun(id string) (*ScriptRun, error) {
+	row := p.QueryRow(`
+		SELECT id, script_name, params_json, status, exit_code, output, error, started_at, completed_at
+		FROM script_runs WHERE id = ?
+	`, id)
+	r, err := scanScriptRun(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get script run %s: %w", id, err)
+	}
+	return r, nil
+}
+
+// ListScriptRuns returns the most recent runs of a script, newest first.
+// A limit of zero or less returns every run.
+func (p *ProjectDB) ListScriptRuns(scriptName string, limit int) ([]*ScriptRun, error) {
+	query := `
+		SELECT id, script_name, params_json, status, exit_code, output, error, started_at, completed_at
+		FROM script_runs WHERE script_name = ?
+		ORDER BY started_at DESC, id DESC`
+	args := []any{scriptName}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := p.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list script runs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var runs []*ScriptRun
+	for rows.Next() {
+		r, err := scanScriptRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan script run: %w", err)
+		}
+		runs = append(runs, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate script runs: %w", err)
+	}
+	return runs, nil
+}
+
+func scanScriptRun(scanner interface{ Scan(dest ...any) error }) (*ScriptRun, error) {
+	var r ScriptRun
+	var paramsJSON, errMsg, completedAt sql.NullString
+	var exitCode sql.NullInt64
+	var startedAt string
+
+	if err := scanner.Scan(&r.ID, &r.ScriptName, &paramsJSON, &r.Status, &exitCode, &r.Output, &errMsg, &startedAt, &completedAt); err != nil {
+		return nil, err
+	}
+	if paramsJSON.Valid && paramsJSON.String != "" && paramsJSON.String != "null" {
+		if err := json.Unmarshal([]byte(paramsJSON.String), &r.Params); err != nil {
+			return nil, fmt.Errorf("unmarshal params for script run %s: %w", r.ID, err)
+		}
+	}
+	if exitCode.Valid {
+		code := int(exitCode.Int64)
+		r.ExitCode = &code
+	}
+	r.Error = errMsg.String
+	r.StartedAt = parseTimestamp(startedAt)
+	if completedAt.Valid {
+		t := parseTimestamp(completedAt.String)
+		r.CompletedAt = &t
+	}
+	return &r, nil
+}
+
+// generateScriptRunID generates a unique ID for a script run.
+func generateScriptRunID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic("crypto/rand.Read failed: " + err.Error())
+	}
+	return "SR-" + hex.EncodeToString(b)[:12]
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProjectDB_ScriptRuns(t *testing.T) {
+	t.Parallel()
+	pdb := NewTestProjectDB(t)
+
+	first := &ScriptRun{
+		ScriptName: "vacuum",
+		Params:     map[string]string{"table": "tasks"},
+		StartedAt:  time.Now().UTC().Add(-time.Minute),
+	}
+	if err := pdb.CreateScriptRun(first); err != nil {
+		t.Fatalf("CreateScriptRun failed: %v", err)
+	}
+	if first.ID == "" || first.Status != ScriptRunRunning {
+		t.Fatalf("run = %+v, want generated ID and running status", first)
+	}
+
+	second := &ScriptRun{ScriptName: "vacuum"}
+	if err := pdb.CreateScriptRun(second); err != nil {
+		t.Fatalf("CreateScriptRun failed: %v", err)
+	}
+
+	code := 0
+	first.Status = ScriptRunSucceeded
+	first.ExitCode = &code
+	first.Output = "done\n"
+	if err := pdb.CompleteScriptRun(first); err != nil {
+		t.Fatalf("CompleteScriptRun failed: %v", err)
+	}
+
+	got, err := pdb.GetScriptRun(first.ID)
+	if err != nil {
+		t.Fatalf("GetScriptRun failed: %v", err)
+	}
+	if got == nil {
+		t.Fatal("GetScriptRun returned nil")
+	}
+	if got.Status != ScriptRunSucceeded || got.ExitCode == nil || *got.ExitCode != 0 {
+		t.Errorf("status/exit = %s/%v, want succeeded/0", got.Status, got.ExitCode)
+	}
+	if got.Output != "done\n" || got.Params["table"] != "tasks" || got.CompletedAt == nil {
+		t.Errorf("run = %+v, want output, params, and completed_at", got)
+	}
+
+	runs, err := pdb.ListScriptRuns("vacuum", 0)
+	if err != nil {
+		t.Fatalf("ListScriptRuns failed: %v", err)
+	}
+	if len(runs) != 2 || runs[0].ID != second.ID {
+		t.Fatalf("runs = %+v, want newest first", runs)
+	}
+
+	limited, err := pdb.ListScriptRuns("vacuum", 1)
+	if err != nil {
+		t.Fatalf("ListScriptRuns failed: %v", err)
+	}
+	if len(limited) != 1 {
+		t.Errorf("limited runs = %d, want 1", len(limited))
+	}
+
+	missing, err := pdb.GetScriptRun("SR-missing")
+	if err != nil || missing != nil {
+		t.Errorf("GetScriptRun(missing) = %v, %v; want nil, nil", missing, err)
+	}
+}
@@ -0,0 +1,252 @@
+package db
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/randalmurphal/orc/internal/db/driver"
+)
+
+// Search result kinds, also accepted as SearchOpts.Kinds filters.
+const (
+	SearchKindTask       = "task"
+	SearchKindSpec       = "spec"
+	SearchKindTranscript = "transcript"
+)
+
+// SearchKinds lists every kind Search covers.
+var SearchKinds = []string{SearchKindTask, SearchKindSpec, SearchKindTranscript}
+
+// searchDefaultLimit caps results when SearchOpts.Limit is 0.
+const searchDefaultLimit = 50
+
+// SearchResult is one full-text match. Snippet marks matched terms with
+// <mark></mark>. Rank is higher for better matches.
+type SearchResult struct {
+	Kind      string  `json:"kind"`
+	TaskID    string  `json:"task_id"`
+	TaskTitle string  `json:"task_title,omitempty"`
+	Field     string  `json:"field"` // title, description, spec, transcript
+	Phase     string  `json:"phase,omitempty"`
+	SessionID string  `json:"session_id,omitempty"`
+	Snippet   string  `json:"snippet"`
+	Rank      float64 `json:"rank"`
+}
+
+// SearchOpts filters a full-text search.
+type SearchOpts struct {
+	Query  string
+	Kinds  []string // empty = all kinds
+	TaskID string   // only matches for this task
+	Limit  int      // 0 = 50
+}
+
+// Search runs a full-text search over task titles and descriptions, spec
+// content, and transcripts, and returns the best matches across all of them.
+func (p *ProjectDB) Search(opts SearchOpts) ([]SearchResult, error) {
+	if strings.TrimSpace(opts.Query) == "" {
+		return []SearchResult{}, nil
+	}
+	for _, kind := range opts.Kinds {
+		if !slices.Contains(SearchKinds, kind) {
+			return nil, fmt.Errorf("invalid search kind %q (must be one of %s)", kind, strings.Join(SearchKinds, ", "))
+		}
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = searchDefaultLimit
+	}
+
+	searchers := map[string]func(SearchOpts) ([]SearchResult, error){
+		SearchKindTask:       p.searchTasks,
+		SearchKindSpec:       p.searchSpecs,
+		SearchKindTranscript: p.searchTranscriptResults,
+	}
+	results := []SearchResult{}
+	for _, kind := range SearchKinds {
+		if len(opts.Kinds) > 0 && !slices.Contains(opts.Kinds, kind) {
+			continue
+		}
+		found, err := searchers[kind](opts)
+		if err != nil {
+			return nil, fmt.Errorf("search %ss: %w", kind, err)
+		}
+		results = append(results, found...)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Rank > results[j].Rank })
+	if len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+	return results, nil
+}
+
+func (p *ProjectDB) searchTasks(opts SearchOpts) ([]SearchResult, error) {
+	var query string
+	var args []any
+	if p.Dialect() == driver.DialectSQLite {
+		query = `
+			SELECT f.task_id, t.title,
+			       highlight(tasks_fts, 1, '<mark>', '</mark>'),
+			       snippet(tasks_fts, 2, '<mark>', '</mark>', '...', 32), -rank
+			FROM tasks_fts f
+			JOIN tasks t ON t.id = f.task_id
+			WHERE tasks_fts MATCH ?`
+		args = append(args, ftsPhrase(opts.Query))
+		if opts.TaskID != "" {
+			query += ` AND f.task_id = ?`
+			args = append(args, opts.TaskID)
+		}
+		query += ` ORDER BY rank LIMIT ?`
+	} else {
+		query = `
+			SELECT t.id, t.title,
+			       ts_headline('english', t.title, plainto_tsquery('english', $1),
+			           'StartSel=<mark>, StopSel=</mark>, HighlightAll=true'),
+			       ts_headline('english', COALESCE(t.description, ''), plainto_tsquery('english', $1),
+			           'StartSel=<mark>, StopSel=</mark>, MaxFragments=1, MaxWords=32, MinWords=8'),
+			       ts_rank(t.search_vector, plainto_tsquery('english', $1))
+			FROM tasks t
+			WHERE t.search_vector @@ plainto_tsquery('english', $1)`
+		args = append(args, opts.Query)
+		if opts.TaskID != "" {
+			query += ` AND t.id = $2`
+			args = append(args, opts.TaskID)
+		}
+		query += fmt.Sprintf(` ORDER BY 5 DESC LIMIT $%d`, len(args)+1)
+	}
+	args = append(args, opts.Limit)
+
+	rows, err := p.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []SearchResult
+	for rows.Next() {
+		r := SearchResult{Kind: SearchKindTask}
+		var titleHighlight, descSnippet string
+		if err := rows.Scan(&r.TaskID, &r.TaskTitle, &titleHighlight, &descSnippet, &r.Rank); err != nil {
+			return nil, fmt.Errorf("scan task match: %w", err)
+		}
+		// Prefer the field that actually contains the match
+		if strings.Contains(descSnippet, "<mark>") {
+			r.Field, r.Snippet = "description", descSnippet
+		} else {
+			r.Field, r.Snippet = "title", titleHighlight
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+func (p *ProjectDB) searchSpecs(opts SearchOpts) ([]SearchResult, error) {
+	var query string
+	var args []any
+	if p.Dialect() == driver.DialectSQLite {
+		query = `
+			SELECT f.task_id, COALESCE(t.title, ''), f.phase_template_id,
+			       snippet(phase_outputs_fts, 0, '<mark>', '</mark>', '...', 32), -rank
+			FROM phase_outputs_fts f
+			LEFT JOIN tasks t ON t.id = f.task_id
+			WHERE phase_outputs_fts MATCH ? AND f.output_var_name = 'SPEC_CONTENT' AND f.task_id IS NOT NULL`
+		args = append(args, ftsPhrase(opts.Query))
+		if opts.TaskID != "" {
+			query += ` AND f.task_id = ?`
+			args = append(args, opts.TaskID)
+		}
+		query += ` ORDER BY rank LIMIT ?`
+	} else {
+		query = `
+			SELECT po.task_id, COALESCE(t.title, ''), po.phase_template_id,
+			       ts_headline('english', po.content, plainto_tsquery('english', $1),
+			           'StartSel=<mark>, StopSel=</mark>, MaxFragments=1, MaxWords=32, MinWords=8'),
+			       ts_rank(po.search_vector, plainto_tsquery('english', $1))
+			FROM phase_outputs po
+			LEFT JOIN tasks t ON t.id = po.task_id
+			WHERE po.search_vector @@ plainto_tsquery('english', $1)
+			  AND po.output_var_name = 'SPEC_CONTENT' AND po.task_id IS NOT NULL`
+		args = append(args, opts.Query)
+		if opts.TaskID != "" {
+			query += ` AND po.task_id = $2`
+			args = append(args, opts.TaskID)
+		}
+		query += fmt.Sprintf(` ORDER BY 5 DESC LIMIT $%d`, len(args)+1)
+	}
+	args = append(args, opts.Limit)
+
+	rows, err := p.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []SearchResult
+	for rows.Next() {
+		r := SearchResult{Kind: SearchKindSpec, Field: "spec"}
+		if err := rows.Scan(&r.TaskID, &r.TaskTitle, &r.Phase, &r.Snippet, &r.Rank); err != nil {
+			return nil, fmt.Errorf("scan spec match: %w", err)
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+func (p *ProjectDB) searchTranscriptResults(opts SearchOpts) ([]SearchResult, error) {
+	var query string
+	var args []any
+	if p.Dialect() == driver.DialectSQLite {
+		query = `
+			SELECT f.task_id, COALESCE(t.title, ''), f.phase, f.session_id,
+			       snippet(transcripts_fts, 0, '<mark>', '</mark>', '...', 32), -rank
+			FROM transcripts_fts f
+			LEFT JOIN tasks t ON t.id = f.task_id
+			WHERE transcripts_fts MATCH ?`
+		args = append(args, ftsPhrase(opts.Query))
+		if opts.TaskID != "" {
+			query += ` AND f.task_id = ?`
+			args = append(args, opts.TaskID)
+		}
+		query += ` ORDER BY rank LIMIT ?`
+	} else {
+		query = `
+			SELECT tr.task_id, COALESCE(t.title, ''), tr.phase, tr.session_id,
+			       ts_headline('english', tr.content, plainto_tsquery('english', $1),
+			           'StartSel=<mark>, StopSel=</mark>, MaxFragments=1, MaxWords=32, MinWords=8'),
+			       ts_rank(tr.search_vector, plainto_tsquery('english', $1))
+			FROM transcripts tr
+			LEFT JOIN tasks t ON t.id = tr.task_id
+			WHERE tr.search_vector @@ plainto_tsquery('english', $1)`
+		args = append(args, opts.Query)
+		if opts.TaskID != "" {
+			query += ` AND tr.task_id = $2`
+			args = append(args, opts.TaskID)
+		}
+		query += fmt.Sprintf(` ORDER BY 6 DESC LIMIT $%d`, len(args)+1)
+	}
+	args = append(args, opts.Limit)
+
+	rows, err := p.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []SearchResult
+	for rows.Next() {
+		r := SearchResult{Kind: SearchKindTranscript, Field: "transcript"}
+		if err := rows.Scan(&r.TaskID, &r.TaskTitle, &r.Phase, &r.SessionID, &r.Snippet, &r.Rank); err != nil {
+			return nil, fmt.Errorf("scan transcript match: %w", err)
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// ftsPhrase quotes a query as a single FTS5 phrase, so user input cannot
+// inject FTS5 syntax.
+func ftsPhrase(query string) string {
+	return `"` + escapeQuotes(query) + `"`
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProjectDB_Search(t *testing.T) {
+	t.Parallel()
+	pdb := NewTestProjectDB(t)
+
+	if err := pdb.SaveWorkflow(&Workflow{ID: "wf-search", Name: "search"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, task := range []*Task{
+		{ID: "TASK-001", Title: "Add OAuth login", Description: "Support GitHub sign-in", WorkflowID: "wf-search", Status: "running", CreatedAt: time.Now()},
+		{ID: "TASK-002", Title: "Fix flaky tests", Description: "The retry helper races with the OAuth refresh", WorkflowID: "wf-search", Status: "created", CreatedAt: time.Now()},
+	} {
+		if err := pdb.SaveTask(task); err != nil {
+			t.Fatalf("SaveTask failed: %v", err)
+		}
+	}
+	taskID := "TASK-001"
+	if err := pdb.SaveWorkflowRun(&WorkflowRun{ID: "RUN-001", WorkflowID: "wf-search", ContextType: "task", TaskID: &taskID, Prompt: "p", Status: "running", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pdb.SavePhaseOutput(&PhaseOutput{WorkflowRunID: "RUN-001", PhaseTemplateID: "spec", TaskID: &taskID, Content: "## Intent\nUsers sign in with OAuth tokens stored server-side.", OutputVarName: "SPEC_CONTENT", ArtifactType: "spec"}); err != nil {
+		t.Fatalf("SavePhaseOutput failed: %v", err)
+	}
+	if err := pdb.SavePhaseOutput(&PhaseOutput{WorkflowRunID: "RUN-001", PhaseTemplateID: "tdd_write", TaskID: &taskID, Content: "OAuth tests", OutputVarName: "TDD_TESTS_CONTENT"}); err != nil {
+		t.Fatalf("SavePhaseOutput failed: %v", err)
+	}
+	if err := pdb.AddTranscript(&Transcript{TaskID: "TASK-001", Phase: "implement", SessionID: "sess-1", MessageUUID: "m1", Type: "assistant", Role: "assistant", Content: "Wired the OAuth callback handler", Timestamp: time.Now()}); err != nil {
+		t.Fatalf("AddTranscript failed: %v", err)
+	}
+
+	results, err := pdb.Search(SearchOpts{Query: "oauth"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	got := map[string]SearchResult{}
+	for _, r := range results {
+		got[r.Kind+":"+r.TaskID+":"+r.Field] = r
+		if !strings.Contains(r.Snippet, "<mark>") {
+			t.Errorf("snippet without highlight: %+v", r)
+		}
+	}
+	if len(results) != 4 {
+		t.Fatalf("results = %+v, want title, description, spec and transcript matches", results)
+	}
+	if r, ok := got["task:TASK-001:title"]; !ok || r.Snippet != "Add <mark>OAuth</mark> login" {
+		t.Errorf("title match = %+v", r)
+	}
+	if _, ok := got["task:TASK-002:description"]; !ok {
+		t.Errorf("missing description match: %+v", results)
+	}
+	if r, ok := got["spec:TASK-001:spec"]; !ok || r.Phase != "spec" || r.TaskTitle != "Add OAuth login" {
+		t.Errorf("spec match = %+v", r)
+	}
+	if r, ok := got["transcript:TASK-001:transcript"]; !ok || r.Phase != "implement" || r.SessionID != "sess-1" {
+		t.Errorf("transcript match = %+v", r)
+	}
+
+	// Filters
+	results, err = pdb.Search(SearchOpts{Query: "oauth", TaskID: "TASK-002"})
+	if err != nil || len(results) != 1 || results[0].TaskID != "TASK-002" {
+		t.Errorf("task filter: results = %+v, err = %v", results, err)
+	}
+	results, err = pdb.Search(SearchOpts{Query: "oauth", Kinds: []string{SearchKindSpec, SearchKindTranscript}, Limit: 1})
+	if err != nil || len(results) != 1 || results[0].Kind == SearchKindTask {
+		t.Errorf("kind filter: results = %+v, err = %v", results, err)
+	}
+	if _, err := pdb.Search(SearchOpts{Query: "oauth", Kinds: []string{"commit"}}); err == nil {
+		t.Error("unknown kind should fail")
+	}
+
+	// Index follows updates and deletes; FTS syntax in queries is literal
+	if err := pdb.SaveTask(&Task{ID: "TASK-002", Title: "Fix flaky tests", Description: "retry helper", WorkflowID: "wf-search", Status: "created", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pdb.DeleteTask("TASK-001"); err != nil {
+		t.Fatal(err)
+	}
+	results, err = pdb.Search(SearchOpts{Query: "oauth", Kinds: []string{SearchKindTask}})
+	if err != nil || len(results) != 0 {
+		t.Errorf("after update and delete: results = %+v, err = %v", results, err)
+	}
+	if _, err := pdb.Search(SearchOpts{Query: `flaky" OR "x`}); err != nil {
+		t.Errorf("quoted query: %v", err)
+	}
+}
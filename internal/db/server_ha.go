@@ -0,0 +1,141 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// ServerLease is a named lease held by one server instance until it expires.
+type ServerLease struct {
+	Name       string
+	Holder     string
+	ExpiresAt  time.Time
+	AcquiredAt time.Time
+}
+
+// ServerEvent is an event one server instance published for the others.
+type ServerEvent struct {
+	ID        int64
+	Origin    string
+	Payload   string
+	CreatedAt time.Time
+}
+
+// TryAcquireLease takes or renews the named lease for holder. It succeeds
+// when the lease is free, expired, or already held by holder, and reports
+// whether holder owns the lease afterwards.
+func (g *GlobalDB) TryAcquireLease(name, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	p := g.Placeholder
+	res, err := g.Exec(fmt.Sprintf(`
+		INSERT INTO server_leases (name, holder, expires_at, acquired_at)
+		VALUES (%s, %s, %s, %s)
+		ON CONFLICT (name) DO UPDATE SET
+			holder = excluded.holder,
+			expires_at = excluded.expires_at,
+			acquired_at = CASE WHEN server_leases.holder = excluded.holder
+				THEN server_leases.acquired_at ELSE excluded.acquired_at END
+		WHERE server_leases.holder = excluded.holder OR server_leases.expires_at < %s
+	`, p(1), p(2), p(3), p(4), p(5)),
+		name, holder, now.Add(ttl).UnixMilli(), now.UnixMilli(), now.UnixMilli())
+	if err != nil {
+		return false, fmt.Errorf("acquire lease %s: %w", name, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("acquire lease %s: %w", name, err)
+	}
+	return n > 0, nil
+}
+
+// ReleaseLease gives up the named lease if holder still owns it, so a
+// standby can take over without waiting for expiry.
+func (g *GlobalDB) ReleaseLease(name, holder string) error {
+	_, err := g.Exec(fmt.Sprintf(`DELETE FROM server_leases WHERE name = %s AND holder = %s`,
+		g.Placeholder(1), g.Placeholder(2)), name, holder)
+	if err != nil {
+		return fmt.Errorf("release lease %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetLease returns the current state of the named lease.
+// Returns (nil, nil) if nobody has ever held it.
+func (g *GlobalDB) GetLease(name string) (*ServerLease, error) {
+	rows, err := g.Query(fmt.Sprintf(`
+		SELECT name, holder, expires_at, acquired_at FROM server_leases WHERE name = %s
+	`, g.Placeholder(1)), name)
+	if err != nil {
+		return nil, fmt.Errorf("get lease %s: %w", name, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	var l ServerLease
+	var expiresAt, acquiredAt int64
+	if err := rows.Scan(&l.Name, &l.Holder, &expiresAt, &acquiredAt); err != nil {
+		return nil, fmt.Errorf("scan lease %s: %w", name, err)
+	}
+	l.ExpiresAt = time.UnixMilli(expiresAt)
+	l.AcquiredAt = time.UnixMilli(acquiredAt)
+	return &l, nil
+}
+
+// AppendServerEvent records an event for other server instances and returns
+// its ID.
+func (g *GlobalDB) AppendServerEvent(origin, payload string) (int64, error) {
+	var id int64
+	err := g.QueryRow(fmt.Sprintf(`
+		INSERT INTO server_events (origin, payload, created_at) VALUES (%s, %s, %s)
+		RETURNING id
+	`, g.Placeholder(1), g.Placeholder(2), g.Placeholder(3)),
+		origin, payload, time.Now().UnixMilli()).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("append server event: %w", err)
+	}
+	return id, nil
+}
+
+// ListServerEventsSince returns up to limit events created at or after since
+// with an ID greater than afterID that were published by instances other
+// than excludeOrigin, in ID order. afterID is a page cursor within the
+// window, not a high-water mark: IDs can commit out of order, so callers
+// re-scan a trailing window and de-duplicate by ID.
+func (g *GlobalDB) ListServerEventsSince(since time.Time, afterID int64, excludeOrigin string, limit int) ([]ServerEvent, error) {
+	p := g.Placeholder
+	rows, err := g.Query(fmt.Sprintf(`
+		SELECT id, origin, payload, created_at FROM server_events
+		WHERE created_at >= %s AND id > %s AND origin <> %s
+		ORDER BY id
+		LIMIT %s
+	`, p(1), p(2), p(3), p(4)), since.UnixMilli(), afterID, excludeOrigin, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list server events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var events []ServerEvent
+	for rows.Next() {
+		var e ServerEvent
+		var createdAt int64
+		if err := rows.Scan(&e.ID, &e.Origin, &e.Payload, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan server event: %w", err)
+		}
+		e.CreatedAt = time.UnixMilli(createdAt)
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// PruneServerEvents deletes events older than the cutoff and returns how
+// many were removed.
+func (g *GlobalDB) PruneServerEvents(olderThan time.Time) (int64, error) {
+	res, err := g.Exec(fmt.Sprintf(`DELETE FROM server_events WHERE created_at < %s`, g.Placeholder(1)),
+		olderThan.UnixMilli())
+	if err != nil {
+		return 0, fmt.Errorf("prune server events: %w", err)
+	}
+	return res.RowsAffected()
+}
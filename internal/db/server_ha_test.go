@@ -0,0 +1,88 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestServerLease(t *testing.T) {
+	t.Parallel()
+	g := openTenantTestDB(t)
+
+	ok, err := g.TryAcquireLease("scheduler", "a", time.Minute)
+	if err != nil || !ok {
+		t.Fatalf("first acquire = %v, %v; want true", ok, err)
+	}
+	if ok, _ := g.TryAcquireLease("scheduler", "b", time.Minute); ok {
+		t.Error("b acquired a lease held by a")
+	}
+	first, err := g.GetLease("scheduler")
+	if err != nil || first == nil || first.Holder != "a" {
+		t.Fatalf("GetLease = %+v, %v", first, err)
+	}
+
+	// Renewal keeps the original acquisition time.
+	if ok, _ := g.TryAcquireLease("scheduler", "a", time.Minute); !ok {
+		t.Error("holder could not renew its lease")
+	}
+	renewed, _ := g.GetLease("scheduler")
+	if !renewed.AcquiredAt.Equal(first.AcquiredAt) {
+		t.Errorf("renewal changed acquired_at from %v to %v", first.AcquiredAt, renewed.AcquiredAt)
+	}
+
+	// An expired lease can be taken over.
+	if ok, _ := g.TryAcquireLease("scheduler", "a", -time.Second); !ok {
+		t.Fatal("holder could not shorten its lease")
+	}
+	if ok, _ := g.TryAcquireLease("scheduler", "b", time.Minute); !ok {
+		t.Error("b could not take over an expired lease")
+	}
+
+	// Release only applies to the current holder.
+	if err := g.ReleaseLease("scheduler", "a"); err != nil {
+		t.Fatal(err)
+	}
+	if l, _ := g.GetLease("scheduler"); l == nil || l.Holder != "b" {
+		t.Errorf("non-holder release changed lease to %+v", l)
+	}
+	if err := g.ReleaseLease("scheduler", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if l, _ := g.GetLease("scheduler"); l != nil {
+		t.Errorf("lease after release = %+v, want none", l)
+	}
+}
+
+func TestServerEvents(t *testing.T) {
+	t.Parallel()
+	g := openTenantTestDB(t)
+
+	start := time.Now().Add(-time.Second)
+	first, err := g.AppendServerEvent("a", `{"n":1}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.AppendServerEvent("b", `{"n":2}`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.AppendServerEvent("b", `{"n":3}`); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := g.ListServerEventsSince(start, 0, "b", 10)
+	if err != nil || len(events) != 1 || events[0].ID != first {
+		t.Fatalf("events excluding b = %+v, %v; want only %d", events, err, first)
+	}
+	events, _ = g.ListServerEventsSince(start, first, "a", 1)
+	if len(events) != 1 || events[0].Payload != `{"n":2}` {
+		t.Errorf("limited events after %d = %+v", first, events)
+	}
+	if events, _ := g.ListServerEventsSince(time.Now().Add(time.Minute), 0, "a", 10); len(events) != 0 {
+		t.Errorf("events after the window = %+v, want none", events)
+	}
+
+	n, err := g.PruneServerEvents(time.Now().Add(time.Minute))
+	if err != nil || n != 3 {
+		t.Errorf("PruneServerEvents = %d, %v; want 3", n, err)
+	}
+}
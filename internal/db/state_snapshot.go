@@ -0,0 +1,282 @@
+package db
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// MaxStateSnapshots is how many snapshots are kept per task; older ones are
+// pruned as new ones are recorded.
+const MaxStateSnapshots = 500
+
+// ErrStateSnapshotNotFound is returned when a selector matches no snapshot.
+var ErrStateSnapshotNotFound = errors.New("state snapshot not found")
+
+// ErrInvalidSnapshotSelector is returned for a malformed snapshot selector.
+var ErrInvalidSnapshotSelector = errors.New("invalid snapshot selector")
+
+// StateSnapshot is the full state of a task after one executor step. Seq
+// numbers a task's snapshots from 1 in the order they were taken.
+type StateSnapshot struct {
+	ID        int64           `json:"id"`
+	TaskID    string          `json:"task_id"`
+	Seq       int             `json:"seq"`
+	Label     string          `json:"label"`
+	PhaseID   string          `json:"phase_id,omitempty"`
+	Iteration int             `json:"iteration"`
+	State     json.RawMessage `json:"state,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// RecordStateSnapshot stores a snapshot unless its state is identical to the
+// task's latest one, and reports whether it was stored. State must be
+// canonical JSON (sorted keys) for the comparison to hold.
+func (p *ProjectDB) RecordStateSnapshot(s *StateSnapshot) (bool, error) {
+	sum := sha256.Sum256(s.State)
+	hash := hex.EncodeToString(sum[:])
+
+	var lastSeq int
+	var lastHash string
+	err := p.QueryRow(`
+		SELECT seq, state_hash FROM task_state_snapshots
+		WHERE task_id = ? ORDER BY seq DESC LIMIT 1
+	`, s.TaskID).Scan(&lastSeq, &lastHash)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, fmt.Errorf("get latest state snapshot for %s: %w", s.TaskID, err)
+	}
+	if lastHash == hash {
+		return false, nil
+	}
+
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = time.Now().UTC()
+	}
+	s.Seq = lastSeq + 1
+	_, err = p.Exec(`
+		INSERT INTO task_state_snapshots (task_id, seq, label, phase_id, iteration, state, state_hash, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, s.TaskID, s.Seq, s.Label, s.PhaseID, s.Iteration, string(s.State), hash, s.CreatedAt.UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return false, fmt.Errorf("record state snapshot for %s: %w", s.TaskID, err)
+	}
+	if s.Seq > MaxStateSnapshots {
+		if _, err := p.Exec(`DELETE FROM task_state_snapshots WHERE task_id = ? AND seq <= ?`,
+			s.TaskID, s.Seq-MaxStateSnapshots); err != nil {
+			return true, fmt.Errorf("prune state snapshots for %s: %w", s.TaskID, err)
+		}
+	}
+	return true, nil
+}
+
+// ListStateSnapshots returns a task's snapshots, oldest first, without their
+// state.
+func (p *ProjectDB) ListStateSnapshots(taskID string) ([]StateSnapshot, error) {
+	rows, err := p.Query(`
+		SELECT id, task_id, seq, label, phase_id, iteration, created_at
+		FROM task_state_snapshots WHERE task_id = ? ORDER BY seq
+	`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("list state snapshots for %s: %w", taskID, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var snapshots []StateSnapshot
+	for rows.Next() {
+		var s StateSnapshot
+		var createdAt string
+		if err := rows.Scan(&s.ID, &s.TaskID, &s.Seq, &s.Label, &s.PhaseID, &s.Iteration, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan state snapshot: %w", err)
+		}
+		s.CreatedAt = parseSnapshotTime(createdAt)
+		snapshots = append(snapshots, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate state snapshots: %w", err)
+	}
+	return snapshots, nil
+}
+
+// GetStateSnapshot returns one snapshot with its state, or nil if the task
+// has no snapshot with that sequence number.
+func (p *ProjectDB) GetStateSnapshot(taskID string, seq int) (*StateSnapshot, error) {
+	var s StateSnapshot
+	var state, createdAt string
+	err := p.QueryRow(`
+		SELECT id, task_id, seq, label, phase_id, iteration, state, created_at
+		FROM task_state_snapshots WHERE task_id = ? AND seq = ?
+	`, taskID, seq).Scan(&s.ID, &s.TaskID, &s.Seq, &s.Label, &s.PhaseID, &s.Iteration, &state, &createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get state snapshot %s#%d: %w", taskID, seq, err)
+	}
+	s.State = json.RawMessage(state)
+	s.CreatedAt = parseSnapshotTime(createdAt)
+	return &s, nil
+}
+
+// ResolveStateSnapshot finds the snapshot a selector names: a sequence number,
+// "first", "latest", or an RFC 3339 time (the last snapshot taken at or
+// before it).
+func (p *ProjectDB) ResolveStateSnapshot(taskID, selector string) (*StateSnapshot, error) {
+	snapshots, err := p.ListStateSnapshots(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("task %s has no state snapshots: %w", taskID, ErrStateSnapshotNotFound)
+	}
+
+	seq := 0
+	switch selector {
+	case "first":
+		seq = snapshots[0].Seq
+	case "latest", "":
+		seq = snapshots[len(snapshots)-1].Seq
+	default:
+		if n, err := strconv.Atoi(selector); err == nil {
+			seq = n
+			break
+		}
+		at, err := time.Parse(time.RFC3339, selector)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: want a sequence number, first, latest, or an RFC 3339 time", ErrInvalidSnapshotSelector, selector)
+		}
+		for _, s := range snapshots {
+			if s.CreatedAt.After(at) {
+				break
+			}
+			seq = s.Seq
+		}
+		if seq == 0 {
+			return nil, fmt.Errorf("task %s has no state snapshot at or before %s: %w", taskID, selector, ErrStateSnapshotNotFound)
+		}
+	}
+
+	s, err := p.GetStateSnapshot(taskID, seq)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, fmt.Errorf("task %s has no state snapshot %d (have %d-%d): %w",
+			taskID, seq, snapshots[0].Seq, snapshots[len(snapshots)-1].Seq, ErrStateSnapshotNotFound)
+	}
+	return s, nil
+}
+
+func parseSnapshotTime(s string) time.Time {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t
+	}
+	return parseTimestamp(s)
+}
+
+// StateDiff is what changed in a task's state between two snapshots. From and
+// To carry no state, only which snapshots were compared.
+type StateDiff struct {
+	TaskID  string        `json:"task_id"`
+	From    StateSnapshot `json:"from"`
+	To      StateSnapshot `json:"to"`
+	Changes []StateChange `json:"changes"`
+}
+
+// DiffStateSnapshots resolves two snapshot selectors (see
+// ResolveStateSnapshot) and diffs their states.
+func (p *ProjectDB) DiffStateSnapshots(taskID, from, to string) (*StateDiff, error) {
+	a, err := p.ResolveStateSnapshot(taskID, from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := p.ResolveStateSnapshot(taskID, to)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := DiffStates(a.State, b.State)
+	if err != nil {
+		return nil, fmt.Errorf("diff state snapshots %d and %d: %w", a.Seq, b.Seq, err)
+	}
+	if changes == nil {
+		changes = []StateChange{}
+	}
+	a.State, b.State = nil, nil
+	return &StateDiff{TaskID: taskID, From: *a, To: *b, Changes: changes}, nil
+}
+
+// StateChange is one difference between two snapshots. Path addresses the
+// value with dots for object keys and [i] for array elements.
+type StateChange struct {
+	Path string          `json:"path"`
+	Kind string          `json:"kind"` // "added", "removed", or "changed"
+	From json.RawMessage `json:"from,omitempty"`
+	To   json.RawMessage `json:"to,omitempty"`
+}
+
+// DiffStates compares two JSON states leaf by leaf and returns the changes
+// sorted by path.
+func DiffStates(from, to json.RawMessage) ([]StateChange, error) {
+	var a, b any
+	if err := json.Unmarshal(from, &a); err != nil {
+		return nil, fmt.Errorf("decode from state: %w", err)
+	}
+	if err := json.Unmarshal(to, &b); err != nil {
+		return nil, fmt.Errorf("decode to state: %w", err)
+	}
+	before := map[string]json.RawMessage{}
+	after := map[string]json.RawMessage{}
+	flattenState("", a, before)
+	flattenState("", b, after)
+
+	var changes []StateChange
+	for path, old := range before {
+		cur, ok := after[path]
+		switch {
+		case !ok:
+			changes = append(changes, StateChange{Path: path, Kind: "removed", From: old})
+		case !bytes.Equal(old, cur):
+			changes = append(changes, StateChange{Path: path, Kind: "changed", From: old, To: cur})
+		}
+	}
+	for path, cur := range after {
+		if _, ok := before[path]; !ok {
+			changes = append(changes, StateChange{Path: path, Kind: "added", To: cur})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// flattenState records every leaf of v under its path. Empty objects and
+// arrays count as leaves so that clearing a map still shows up.
+func flattenState(path string, v any, out map[string]json.RawMessage) {
+	switch val := v.(type) {
+	case map[string]any:
+		if len(val) > 0 {
+			for k, child := range val {
+				p := k
+				if path != "" {
+					p = path + "." + k
+				}
+				flattenState(p, child, out)
+			}
+			return
+		}
+	case []any:
+		if len(val) > 0 {
+			for i, child := range val {
+				flattenState(path+"["+strconv.Itoa(i)+"]", child, out)
+			}
+			return
+		}
+	}
+	data, _ := json.Marshal(v)
+	out[path] = data
+}
@@ -0,0 +1,103 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestProjectDB_StateSnapshots(t *testing.T) {
+	t.Parallel()
+	pdb := NewTestProjectDB(t)
+	if err := pdb.SaveTask(&Task{ID: "TASK-001", Title: "t", Status: "running", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("SaveTask failed: %v", err)
+	}
+
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	states := []struct {
+		label string
+		state string
+	}{
+		{"save task status running", `{"status":"TASK_STATUS_RUNNING"}`},
+		{"save task", `{"status":"TASK_STATUS_RUNNING"}`}, // unchanged, skipped
+		{"save gate decision state", `{"execution":{"gates":[{"approved":true}]},"status":"TASK_STATUS_RUNNING"}`},
+		{"save task status failed", `{"execution":{"error":"boom","gates":[{"approved":true}]},"status":"TASK_STATUS_FAILED"}`},
+	}
+	stored := 0
+	for i, s := range states {
+		ok, err := pdb.RecordStateSnapshot(&StateSnapshot{
+			TaskID: "TASK-001", Label: s.label, PhaseID: "implement",
+			State: json.RawMessage(s.state), CreatedAt: start.Add(time.Duration(i) * time.Minute),
+		})
+		if err != nil {
+			t.Fatalf("RecordStateSnapshot failed: %v", err)
+		}
+		if ok {
+			stored++
+		}
+	}
+	if stored != 3 {
+		t.Fatalf("stored %d snapshots, want 3 (duplicate skipped)", stored)
+	}
+
+	list, err := pdb.ListStateSnapshots("TASK-001")
+	if err != nil {
+		t.Fatalf("ListStateSnapshots failed: %v", err)
+	}
+	if len(list) != 3 || list[0].Seq != 1 || list[2].Seq != 3 || list[2].Label != "save task status failed" || list[0].State != nil {
+		t.Fatalf("snapshots = %+v", list)
+	}
+
+	diff, err := pdb.DiffStateSnapshots("TASK-001", "first", "latest")
+	if err != nil {
+		t.Fatalf("DiffStateSnapshots failed: %v", err)
+	}
+	want := []StateChange{
+		{Path: "execution.error", Kind: "added", To: json.RawMessage(`"boom"`)},
+		{Path: "execution.gates[0].approved", Kind: "added", To: json.RawMessage(`true`)},
+		{Path: "status", Kind: "changed", From: json.RawMessage(`"TASK_STATUS_RUNNING"`), To: json.RawMessage(`"TASK_STATUS_FAILED"`)},
+	}
+	if len(diff.Changes) != len(want) {
+		t.Fatalf("changes = %+v, want %+v", diff.Changes, want)
+	}
+	for i, c := range diff.Changes {
+		if c.Path != want[i].Path || c.Kind != want[i].Kind || string(c.From) != string(want[i].From) || string(c.To) != string(want[i].To) {
+			t.Errorf("change %d = %+v, want %+v", i, c, want[i])
+		}
+	}
+
+	// A time selects the last snapshot taken at or before it
+	diff, err = pdb.DiffStateSnapshots("TASK-001", "2026-03-01T10:02:30Z", "3")
+	if err != nil {
+		t.Fatalf("DiffStateSnapshots by time failed: %v", err)
+	}
+	if diff.From.Seq != 2 || len(diff.Changes) != 2 {
+		t.Errorf("diff from %d with %d changes, want from 2 with 2", diff.From.Seq, len(diff.Changes))
+	}
+
+	if _, err := pdb.ResolveStateSnapshot("TASK-001", "9"); !errors.Is(err, ErrStateSnapshotNotFound) {
+		t.Errorf("missing seq error = %v, want ErrStateSnapshotNotFound", err)
+	}
+	if _, err := pdb.ResolveStateSnapshot("TASK-001", "yesterday"); !errors.Is(err, ErrInvalidSnapshotSelector) {
+		t.Errorf("bad selector error = %v, want ErrInvalidSnapshotSelector", err)
+	}
+	if _, err := pdb.ResolveStateSnapshot("TASK-002", "latest"); !errors.Is(err, ErrStateSnapshotNotFound) {
+		t.Errorf("no snapshots error = %v, want ErrStateSnapshotNotFound", err)
+	}
+}
+
+func TestDiffStates_RemovedAndEmpty(t *testing.T) {
+	t.Parallel()
+	changes, err := DiffStates(
+		json.RawMessage(`{"metadata":{"a":"1"},"phases":{"spec":{"status":"completed"}}}`),
+		json.RawMessage(`{"metadata":{},"phases":{"spec":{"status":"completed"}}}`),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 || changes[0].Path != "metadata" || changes[0].Kind != "added" ||
+		changes[1].Path != "metadata.a" || changes[1].Kind != "removed" {
+		t.Errorf("changes = %+v", changes)
+	}
+}
@@ -202,13 +202,25 @@ func (p *ProjectDB) GetTask(id string) (*Task, error) {
 	return t, nil
 }
 
-// DeleteTask removes a task and its phases/transcripts.
+// DeleteTask removes a task and its phases/transcripts, and records a
+// tombstone for delta sync (see ListTaskTombstones).
 func (p *ProjectDB) DeleteTask(id string) error {
-	_, err := p.Exec("DELETE FROM tasks WHERE id = ?", id)
-	if err != nil {
-		return fmt.Errorf("delete task: %w", err)
-	}
-	return nil
+	return p.RunInTx(context.Background(), func(tx *TxOps) error {
+		result, err := tx.Exec("DELETE FROM tasks WHERE id = ?", id)
+		if err != nil {
+			return fmt.Errorf("delete task: %w", err)
+		}
+		if rows, _ := result.RowsAffected(); rows == 0 {
+			return nil
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO task_tombstones (task_id, deleted_at) VALUES (?, ?)
+			ON CONFLICT(task_id) DO UPDATE SET deleted_at = excluded.deleted_at
+		`, id, time.Now().UTC().Format(time.RFC3339)); err != nil {
+			return fmt.Errorf("record task tombstone: %w", err)
+		}
+		return nil
+	})
 }
 
 // ListOpts provides filtering and pagination options.
@@ -0,0 +1,45 @@
+package db
+
+import "fmt"
+
+// GetTaskFileHashes returns the recorded hash of each task file of a task,
+// keyed by file name.
+func (p *ProjectDB) GetTaskFileHashes(taskID string) (map[string]string, error) {
+	rows, err := p.Query(`SELECT name, hash FROM task_file_hashes WHERE task_id = ?`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("get task file hashes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	hashes := make(map[string]string)
+	for rows.Next() {
+		var name, hash string
+		if err := rows.Scan(&name, &hash); err != nil {
+			return nil, fmt.Errorf("scan task file hash: %w", err)
+		}
+		hashes[name] = hash
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate task file hashes: %w", err)
+	}
+	return hashes, nil
+}
+
+// SetTaskFileHash records the hash of a task file as orc wrote it.
+func (p *ProjectDB) SetTaskFileHash(taskID, name, hash string) error {
+	if _, err := p.Exec(`
+		INSERT INTO task_file_hashes (task_id, name, hash) VALUES (?, ?, ?)
+		ON CONFLICT(task_id, name) DO UPDATE SET hash = excluded.hash
+	`, taskID, name, hash); err != nil {
+		return fmt.Errorf("set task file hash: %w", err)
+	}
+	return nil
+}
+
+// DeleteTaskFileHash forgets the hash of a task file orc removed.
+func (p *ProjectDB) DeleteTaskFileHash(taskID, name string) error {
+	if _, err := p.Exec(`DELETE FROM task_file_hashes WHERE task_id = ? AND name = ?`, taskID, name); err != nil {
+		return fmt.Errorf("delete task file hash: %w", err)
+	}
+	return nil
+}
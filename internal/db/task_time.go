@@ -0,0 +1,212 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// EstimateWindow is how far back completed tasks count toward estimates, so
+// estimates follow changes in prompts, models, and the codebase.
+const EstimateWindow = 90 * 24 * time.Hour
+
+// minEstimateSamples is the number of completed tasks a workflow needs before
+// its history is used as an estimate.
+const minEstimateSamples = 3
+
+// PhaseTiming is the active execution time of one phase attempt.
+type PhaseTiming struct {
+	ID        int64     `json:"id"`
+	TaskID    string    `json:"task_id"`
+	PhaseID   string    `json:"phase_id"`
+	RunID     string    `json:"run_id,omitempty"`
+	Status    string    `json:"status"`
+	StartedAt time.Time `json:"started_at"`
+	ActiveMs  int64     `json:"active_ms"`
+}
+
+// RecordPhaseTiming stores a phase attempt's active time.
+func (p *ProjectDB) RecordPhaseTiming(pt *PhaseTiming) error {
+	if pt.StartedAt.IsZero() {
+		pt.StartedAt = time.Now().UTC()
+	}
+	_, err := p.Exec(`
+		INSERT INTO phase_timings (task_id, phase_id, run_id, status, started_at, active_ms)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, pt.TaskID, pt.PhaseID, pt.RunID, pt.Status, pt.StartedAt.UTC().Format(time.RFC3339), pt.ActiveMs)
+	if err != nil {
+		return fmt.Errorf("record phase timing %s/%s: %w", pt.TaskID, pt.PhaseID, err)
+	}
+	return nil
+}
+
+// GetTaskActiveTime returns the total active execution time recorded for a
+// task across all phase attempts and runs.
+func (p *ProjectDB) GetTaskActiveTime(taskID string) (time.Duration, error) {
+	var ms int64
+	err := p.QueryRow(`SELECT COALESCE(SUM(active_ms), 0) FROM phase_timings WHERE task_id = ?`, taskID).Scan(&ms)
+	if err != nil {
+		return 0, fmt.Errorf("get task active time: %w", err)
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// TaskTimeSample is a completed task's active time and cost.
+type TaskTimeSample struct {
+	TaskID      string
+	WorkflowID  string
+	ActiveMs    int64
+	CostUSD     float64
+	CompletedAt time.Time
+}
+
+// GetCompletedTaskTimes returns completed tasks with recorded active time that
+// finished at or after since (zero for all), newest first.
+func (p *ProjectDB) GetCompletedTaskTimes(since time.Time) ([]TaskTimeSample, error) {
+	rows, err := p.Query(`
+		SELECT t.id, COALESCE(t.workflow_id, ''), SUM(pt.active_ms), t.total_cost_usd, t.completed_at
+		FROM tasks t
+		JOIN phase_timings pt ON pt.task_id = t.id
+		WHERE t.status = 'completed' AND t.completed_at IS NOT NULL
+		GROUP BY t.id, t.workflow_id, t.total_cost_usd, t.completed_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("get completed task times: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var samples []TaskTimeSample
+	for rows.Next() {
+		var s TaskTimeSample
+		var completedAt sql.NullString
+		if err := rows.Scan(&s.TaskID, &s.WorkflowID, &s.ActiveMs, &s.CostUSD, &completedAt); err != nil {
+			return nil, fmt.Errorf("scan task time: %w", err)
+		}
+		s.CompletedAt = parseTimestamp(completedAt.String)
+		if s.CompletedAt.IsZero() || s.ActiveMs <= 0 || s.CompletedAt.Before(since) {
+			continue
+		}
+		samples = append(samples, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate task times: %w", err)
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].CompletedAt.After(samples[j].CompletedAt) })
+	return samples, nil
+}
+
+// DurationEstimate summarizes how long and how much completed tasks of one
+// workflow took. Low and High bound the middle half of tasks (25th to 75th
+// percentile).
+type DurationEstimate struct {
+	WorkflowID    string  `json:"workflow_id"`
+	Weight        string  `json:"weight,omitempty"`
+	Samples       int     `json:"samples"`
+	MedianMs      int64   `json:"median_ms"`
+	LowMs         int64   `json:"low_ms"`
+	HighMs        int64   `json:"high_ms"`
+	MedianCostUSD float64 `json:"median_cost_usd"`
+	LowCostUSD    float64 `json:"low_cost_usd"`
+	HighCostUSD   float64 `json:"high_cost_usd"`
+}
+
+// EstimateDurations groups samples by workflow and summarizes each workflow
+// with at least minEstimateSamples completed tasks. Results are sorted by
+// workflow ID.
+func EstimateDurations(samples []TaskTimeSample) []DurationEstimate {
+	byWorkflow := make(map[string][]TaskTimeSample)
+	for _, s := range samples {
+		if s.WorkflowID != "" {
+			byWorkflow[s.WorkflowID] = append(byWorkflow[s.WorkflowID], s)
+		}
+	}
+
+	estimates := make([]DurationEstimate, 0, len(byWorkflow))
+	for wf, group := range byWorkflow {
+		if len(group) < minEstimateSamples {
+			continue
+		}
+		durations := make([]float64, len(group))
+		costs := make([]float64, len(group))
+		for i, s := range group {
+			durations[i] = float64(s.ActiveMs)
+			costs[i] = s.CostUSD
+		}
+		sort.Float64s(durations)
+		sort.Float64s(costs)
+		estimates = append(estimates, DurationEstimate{
+			WorkflowID:    wf,
+			Samples:       len(group),
+			MedianMs:      int64(percentile(durations, 0.5)),
+			LowMs:         int64(percentile(durations, 0.25)),
+			HighMs:        int64(percentile(durations, 0.75)),
+			MedianCostUSD: percentile(costs, 0.5),
+			LowCostUSD:    percentile(costs, 0.25),
+			HighCostUSD:   percentile(costs, 0.75),
+		})
+	}
+	sort.Slice(estimates, func(i, j int) bool { return estimates[i].WorkflowID < estimates[j].WorkflowID })
+	return estimates
+}
+
+// VelocityPoint is one week of completed work.
+type VelocityPoint struct {
+	WeekStart      time.Time `json:"week_start"`
+	Completed      int       `json:"completed"`
+	ActiveMs       int64     `json:"active_ms"`
+	MedianActiveMs int64     `json:"median_active_ms"`
+	CostUSD        float64   `json:"cost_usd"`
+}
+
+// WeeklyVelocity buckets samples into the given number of weeks ending with
+// the week containing now (weeks start Monday, UTC), oldest first. Weeks
+// without completions are included with zero counts.
+func WeeklyVelocity(samples []TaskTimeSample, weeks int, now time.Time) []VelocityPoint {
+	if weeks <= 0 {
+		return nil
+	}
+	current := weekStart(now)
+	points := make([]VelocityPoint, weeks)
+	durations := make([][]float64, weeks)
+	for i := range points {
+		points[i].WeekStart = current.AddDate(0, 0, -7*(weeks-1-i))
+	}
+	for _, s := range samples {
+		idx := weeks - 1 - int(current.Sub(weekStart(s.CompletedAt)).Hours()/(24*7))
+		if idx < 0 || idx >= weeks {
+			continue
+		}
+		points[idx].Completed++
+		points[idx].ActiveMs += s.ActiveMs
+		points[idx].CostUSD += s.CostUSD
+		durations[idx] = append(durations[idx], float64(s.ActiveMs))
+	}
+	for i := range points {
+		sort.Float64s(durations[i])
+		points[i].MedianActiveMs = int64(percentile(durations[i], 0.5))
+	}
+	return points
+}
+
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7 // Monday = 0
+	return day.AddDate(0, 0, -offset)
+}
+
+// percentile returns the q-th quantile of sorted values using linear
+// interpolation between closest ranks. Empty input returns 0.
+func percentile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := q * float64(len(sorted)-1)
+	lower := int(pos)
+	if lower+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	frac := pos - float64(lower)
+	return sorted[lower] + frac*(sorted[lower+1]-sorted[lower])
+}
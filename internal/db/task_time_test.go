@@ -0,0 +1,125 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestProjectDB_PhaseTimings(t *testing.T) {
+	t.Parallel()
+	pdb := NewTestProjectDB(t)
+
+	now := time.Now().UTC()
+	for i, wf := range []string{"medium", "medium", "small"} {
+		completed := now.Add(-time.Duration(i) * time.Hour)
+		task := &Task{
+			ID: fmt.Sprintf("TASK-%03d", i+1), Title: "t", Status: "completed", WorkflowID: wf,
+			CreatedAt: now.Add(-48 * time.Hour), CompletedAt: &completed, TotalCostUSD: float64(i + 1),
+		}
+		if err := pdb.SaveTask(task); err != nil {
+			t.Fatalf("SaveTask failed: %v", err)
+		}
+	}
+	open := &Task{ID: "TASK-004", Title: "open", Status: "running", WorkflowID: "medium", CreatedAt: now}
+	if err := pdb.SaveTask(open); err != nil {
+		t.Fatalf("SaveTask failed: %v", err)
+	}
+
+	timings := []PhaseTiming{
+		{TaskID: "TASK-001", PhaseID: "implement", Status: "completed", ActiveMs: 30 * 60000},
+		{TaskID: "TASK-001", PhaseID: "review", Status: "completed", ActiveMs: 10 * 60000},
+		{TaskID: "TASK-002", PhaseID: "implement", Status: "failed", ActiveMs: 5 * 60000},
+		{TaskID: "TASK-004", PhaseID: "implement", Status: "completed", ActiveMs: 60000},
+	}
+	for i := range timings {
+		if err := pdb.RecordPhaseTiming(&timings[i]); err != nil {
+			t.Fatalf("RecordPhaseTiming failed: %v", err)
+		}
+	}
+
+	active, err := pdb.GetTaskActiveTime("TASK-001")
+	if err != nil {
+		t.Fatalf("GetTaskActiveTime failed: %v", err)
+	}
+	if active != 40*time.Minute {
+		t.Errorf("active = %v, want 40m", active)
+	}
+
+	samples, err := pdb.GetCompletedTaskTimes(time.Time{})
+	if err != nil {
+		t.Fatalf("GetCompletedTaskTimes failed: %v", err)
+	}
+	// TASK-003 has no timings and TASK-004 is not completed.
+	if len(samples) != 2 {
+		t.Fatalf("samples = %+v, want 2", samples)
+	}
+	if samples[0].TaskID != "TASK-001" || samples[0].ActiveMs != 40*60000 || samples[0].CostUSD != 1 {
+		t.Errorf("samples[0] = %+v, want TASK-001 with 40m and $1", samples[0])
+	}
+
+	recent, err := pdb.GetCompletedTaskTimes(now.Add(-30 * time.Minute))
+	if err != nil {
+		t.Fatalf("GetCompletedTaskTimes failed: %v", err)
+	}
+	if len(recent) != 1 || recent[0].TaskID != "TASK-001" {
+		t.Errorf("recent = %+v, want only TASK-001", recent)
+	}
+}
+
+func TestEstimateDurations(t *testing.T) {
+	t.Parallel()
+	var samples []TaskTimeSample
+	for i, mins := range []int64{40, 50, 60, 70, 80} {
+		samples = append(samples, TaskTimeSample{WorkflowID: "medium", ActiveMs: mins * 60000, CostUSD: float64(i + 1)})
+	}
+	samples = append(samples,
+		TaskTimeSample{WorkflowID: "small", ActiveMs: 60000},
+		TaskTimeSample{WorkflowID: "small", ActiveMs: 60000},
+		TaskTimeSample{ActiveMs: 60000},
+	)
+
+	got := EstimateDurations(samples)
+	if len(got) != 1 {
+		t.Fatalf("estimates = %+v, want only medium (small has too few samples)", got)
+	}
+	e := got[0]
+	if e.WorkflowID != "medium" || e.Samples != 5 {
+		t.Errorf("estimate = %+v", e)
+	}
+	if e.LowMs != 50*60000 || e.MedianMs != 60*60000 || e.HighMs != 70*60000 {
+		t.Errorf("durations = %d/%d/%d, want 50m/60m/70m", e.LowMs, e.MedianMs, e.HighMs)
+	}
+	if e.LowCostUSD != 2 || e.MedianCostUSD != 3 || e.HighCostUSD != 4 {
+		t.Errorf("costs = %v/%v/%v, want 2/3/4", e.LowCostUSD, e.MedianCostUSD, e.HighCostUSD)
+	}
+}
+
+func TestWeeklyVelocity(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC) // Wednesday
+	samples := []TaskTimeSample{
+		{ActiveMs: 10 * 60000, CostUSD: 1, CompletedAt: time.Date(2026, 3, 9, 0, 30, 0, 0, time.UTC)}, // this Monday
+		{ActiveMs: 30 * 60000, CostUSD: 2, CompletedAt: time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)}, // this week
+		{ActiveMs: 20 * 60000, CostUSD: 1, CompletedAt: time.Date(2026, 3, 8, 23, 0, 0, 0, time.UTC)}, // last Sunday
+		{ActiveMs: 90 * 60000, CostUSD: 9, CompletedAt: time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)}, // outside window
+	}
+
+	points := WeeklyVelocity(samples, 3, now)
+	if len(points) != 3 {
+		t.Fatalf("points = %d, want 3", len(points))
+	}
+	if want := time.Date(2026, 2, 23, 0, 0, 0, 0, time.UTC); !points[0].WeekStart.Equal(want) {
+		t.Errorf("first week = %v, want %v", points[0].WeekStart, want)
+	}
+	if points[0].Completed != 0 || points[0].MedianActiveMs != 0 {
+		t.Errorf("empty week = %+v", points[0])
+	}
+	if points[1].Completed != 1 || points[1].ActiveMs != 20*60000 {
+		t.Errorf("last week = %+v, want 1 task with 20m", points[1])
+	}
+	current := points[2]
+	if current.Completed != 2 || current.ActiveMs != 40*60000 || current.MedianActiveMs != 20*60000 || current.CostUSD != 3 {
+		t.Errorf("this week = %+v, want 2 tasks, 40m total, 20m median, $3", current)
+	}
+}
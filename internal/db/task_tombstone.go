@@ -0,0 +1,42 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// TaskTombstone records that a task was deleted.
+type TaskTombstone struct {
+	TaskID    string    `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// ListTaskTombstones returns the tasks deleted at or after since, oldest
+// first. Deletion times have second precision, so since is truncated to the
+// second: a client passing its last checkpoint may see a deletion twice but
+// never misses one.
+func (p *ProjectDB) ListTaskTombstones(since time.Time) ([]TaskTombstone, error) {
+	rows, err := p.Query(`
+		SELECT task_id, deleted_at FROM task_tombstones
+		WHERE deleted_at >= ? ORDER BY deleted_at, task_id
+	`, since.UTC().Truncate(time.Second).Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("list task tombstones: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	tombstones := []TaskTombstone{}
+	for rows.Next() {
+		var ts TaskTombstone
+		var deletedAt string
+		if err := rows.Scan(&ts.TaskID, &deletedAt); err != nil {
+			return nil, fmt.Errorf("scan task tombstone: %w", err)
+		}
+		ts.DeletedAt = parseTimestamp(deletedAt)
+		tombstones = append(tombstones, ts)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate task tombstones: %w", err)
+	}
+	return tombstones, nil
+}
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProjectDB_TaskTombstones(t *testing.T) {
+	t.Parallel()
+	pdb := NewTestProjectDB(t)
+	for _, id := range []string{"TASK-001", "TASK-002"} {
+		if err := pdb.SaveTask(&Task{ID: id, Title: id, Status: "planned"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	before := time.Now()
+	if err := pdb.DeleteTask("TASK-001"); err != nil {
+		t.Fatalf("DeleteTask: %v", err)
+	}
+	// Deleting a task that does not exist leaves no tombstone
+	if err := pdb.DeleteTask("TASK-404"); err != nil {
+		t.Fatalf("DeleteTask(missing): %v", err)
+	}
+
+	tombstones, err := pdb.ListTaskTombstones(before)
+	if err != nil {
+		t.Fatalf("ListTaskTombstones: %v", err)
+	}
+	if len(tombstones) != 1 || tombstones[0].TaskID != "TASK-001" || tombstones[0].DeletedAt.Before(before.Truncate(time.Second)) {
+		t.Fatalf("ListTaskTombstones() = %+v, want TASK-001", tombstones)
+	}
+	if got, _ := pdb.GetTask("TASK-001"); got != nil {
+		t.Error("task still exists after delete")
+	}
+
+	later, err := pdb.ListTaskTombstones(time.Now().Add(time.Hour))
+	if err != nil || len(later) != 0 {
+		t.Errorf("ListTaskTombstones(future) = %+v, %v; want none", later, err)
+	}
+}
@@ -0,0 +1,329 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TenantTokenPrefix marks tenant API tokens so they are recognizable in logs
+// and secret scanners.
+const TenantTokenPrefix = "orct_"
+
+// ErrTenantQuotaExceeded is returned when an operation would exceed a
+// tenant's configured quota.
+var ErrTenantQuotaExceeded = errors.New("tenant quota exceeded")
+
+// Tenant is a team sharing a multi-tenant orc server. Quotas of zero mean
+// unlimited.
+type Tenant struct {
+	ID              string
+	Name            string
+	MaxProjects     int
+	MaxRunningTasks int
+	CreatedAt       time.Time
+}
+
+// TenantToken describes an API token without its secret.
+type TenantToken struct {
+	TenantID   string
+	Name       string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+func parseTenantTime(s string) time.Time {
+	if ts, err := time.Parse(time.RFC3339, s); err == nil {
+		return ts
+	}
+	if ts, err := time.Parse("2006-01-02 15:04:05", s); err == nil {
+		return ts
+	}
+	return time.Time{}
+}
+
+// hashTenantToken returns the stored form of a token.
+func hashTenantToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// runInTx runs fn in a global database transaction, committing when fn
+// returns nil.
+func (g *GlobalDB) runInTx(fn func(tx *TxOps) error) error {
+	ctx := context.Background()
+	tx, err := g.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := fn(&TxOps{tx: tx, dialect: g.Dialect(), now: g.Driver().Now(), ctx: ctx}); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// CreateTenant registers a new tenant. Names are unique.
+func (g *GlobalDB) CreateTenant(t *Tenant) error {
+	if t.Name == "" {
+		return fmt.Errorf("create tenant: name is required")
+	}
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = time.Now()
+	}
+	_, err := g.Exec(`
+		INSERT INTO tenants (id, name, max_projects, max_running_tasks, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, t.ID, t.Name, t.MaxProjects, t.MaxRunningTasks, t.CreatedAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("create tenant %s: %w", t.Name, err)
+	}
+	return nil
+}
+
+// UpdateTenantQuotas sets a tenant's quotas.
+func (g *GlobalDB) UpdateTenantQuotas(id string, maxProjects, maxRunningTasks int) error {
+	res, err := g.Exec(`UPDATE tenants SET max_projects = ?, max_running_tasks = ? WHERE id = ?`,
+		maxProjects, maxRunningTasks, id)
+	if err != nil {
+		return fmt.Errorf("update tenant %s: %w", id, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("tenant %s not found", id)
+	}
+	return nil
+}
+
+func scanTenant(row interface{ Scan(...any) error }) (*Tenant, error) {
+	var t Tenant
+	var createdAt string
+	if err := row.Scan(&t.ID, &t.Name, &t.MaxProjects, &t.MaxRunningTasks, &createdAt); err != nil {
+		return nil, err
+	}
+	t.CreatedAt = parseTenantTime(createdAt)
+	return &t, nil
+}
+
+// GetTenant retrieves a tenant by ID or name.
+// Returns (nil, nil) if the tenant doesn't exist.
+func (g *GlobalDB) GetTenant(idOrName string) (*Tenant, error) {
+	t, err := scanTenant(g.QueryRow(`
+		SELECT id, name, max_projects, max_running_tasks, created_at
+		FROM tenants WHERE id = ? OR name = ?
+	`, idOrName, idOrName))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get tenant %s: %w", idOrName, err)
+	}
+	return t, nil
+}
+
+// ListTenants returns all tenants ordered by name.
+func (g *GlobalDB) ListTenants() ([]Tenant, error) {
+	rows, err := g.Query(`
+		SELECT id, name, max_projects, max_running_tasks, created_at
+		FROM tenants ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list tenants: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var tenants []Tenant
+	for rows.Next() {
+		t, err := scanTenant(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan tenant: %w", err)
+		}
+		tenants = append(tenants, *t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tenants: %w", err)
+	}
+	return tenants, nil
+}
+
+// DeleteTenant removes a tenant with its tokens and project assignments.
+// The projects themselves are not touched.
+func (g *GlobalDB) DeleteTenant(id string) error {
+	return g.runInTx(func(tx *TxOps) error {
+		for _, q := range []string{
+			`DELETE FROM tenant_tokens WHERE tenant_id = ?`,
+			`DELETE FROM tenant_projects WHERE tenant_id = ?`,
+			`DELETE FROM tenants WHERE id = ?`,
+		} {
+			if _, err := tx.Exec(q, id); err != nil {
+				return fmt.Errorf("delete tenant %s: %w", id, err)
+			}
+		}
+		return nil
+	})
+}
+
+// CreateTenantToken issues a new API token for the tenant and returns it.
+// Only the hash is stored, so the token cannot be shown again.
+func (g *GlobalDB) CreateTenantToken(tenantID, name string) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	token := TenantTokenPrefix + hex.EncodeToString(buf)
+	_, err := g.Exec(`
+		INSERT INTO tenant_tokens (token_hash, tenant_id, name, created_at)
+		VALUES (?, ?, ?, ?)
+	`, hashTenantToken(token), tenantID, name, time.Now().Format(time.RFC3339))
+	if err != nil {
+		return "", fmt.Errorf("create token for tenant %s: %w", tenantID, err)
+	}
+	return token, nil
+}
+
+// RevokeTenantToken deletes a tenant's token by name.
+func (g *GlobalDB) RevokeTenantToken(tenantID, name string) error {
+	res, err := g.Exec(`DELETE FROM tenant_tokens WHERE tenant_id = ? AND name = ?`, tenantID, name)
+	if err != nil {
+		return fmt.Errorf("revoke token %s: %w", name, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("token %s not found", name)
+	}
+	return nil
+}
+
+// ListTenantTokens returns the tokens issued to a tenant.
+func (g *GlobalDB) ListTenantTokens(tenantID string) ([]TenantToken, error) {
+	rows, err := g.Query(`
+		SELECT tenant_id, name, created_at, last_used_at
+		FROM tenant_tokens WHERE tenant_id = ? ORDER BY name
+	`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list tokens: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var tokens []TenantToken
+	for rows.Next() {
+		var tok TenantToken
+		var createdAt string
+		var lastUsed sql.NullString
+		if err := rows.Scan(&tok.TenantID, &tok.Name, &createdAt, &lastUsed); err != nil {
+			return nil, fmt.Errorf("scan token: %w", err)
+		}
+		tok.CreatedAt = parseTenantTime(createdAt)
+		if lastUsed.Valid {
+			ts := parseTenantTime(lastUsed.String)
+			tok.LastUsedAt = &ts
+		}
+		tokens = append(tokens, tok)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// ResolveTenantToken returns the tenant owning token and records its use.
+// Returns (nil, nil) if the token is unknown.
+func (g *GlobalDB) ResolveTenantToken(token string) (*Tenant, error) {
+	hash := hashTenantToken(token)
+	t, err := scanTenant(g.QueryRow(`
+		SELECT t.id, t.name, t.max_projects, t.max_running_tasks, t.created_at
+		FROM tenant_tokens k JOIN tenants t ON t.id = k.tenant_id
+		WHERE k.token_hash = ?
+	`, hash))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolve tenant token: %w", err)
+	}
+	_, _ = g.Exec(`UPDATE tenant_tokens SET last_used_at = ? WHERE token_hash = ?`,
+		time.Now().Format(time.RFC3339), hash)
+	return t, nil
+}
+
+// AssignProjectToTenant makes tenantID the owner of projectID, replacing any
+// previous owner. Fails with ErrTenantQuotaExceeded when the tenant already
+// owns max_projects other projects.
+func (g *GlobalDB) AssignProjectToTenant(tenantID, projectID string) error {
+	return g.runInTx(func(tx *TxOps) error {
+		var maxProjects, owned int
+		if err := tx.QueryRow(`SELECT max_projects FROM tenants WHERE id = ?`, tenantID).Scan(&maxProjects); err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("tenant %s not found", tenantID)
+			}
+			return fmt.Errorf("get tenant %s: %w", tenantID, err)
+		}
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM tenant_projects WHERE tenant_id = ? AND project_id != ?`,
+			tenantID, projectID).Scan(&owned); err != nil {
+			return fmt.Errorf("count tenant projects: %w", err)
+		}
+		if maxProjects > 0 && owned >= maxProjects {
+			return fmt.Errorf("%w: tenant %s already owns %d of %d projects", ErrTenantQuotaExceeded, tenantID, owned, maxProjects)
+		}
+		if _, err := tx.Exec(`DELETE FROM tenant_projects WHERE project_id = ?`, projectID); err != nil {
+			return fmt.Errorf("clear project owner: %w", err)
+		}
+		if _, err := tx.Exec(`INSERT INTO tenant_projects (project_id, tenant_id, created_at) VALUES (?, ?, ?)`,
+			projectID, tenantID, time.Now().Format(time.RFC3339)); err != nil {
+			return fmt.Errorf("assign project %s: %w", projectID, err)
+		}
+		return nil
+	})
+}
+
+// UnassignProject removes a project from its tenant.
+func (g *GlobalDB) UnassignProject(projectID string) error {
+	if _, err := g.Exec(`DELETE FROM tenant_projects WHERE project_id = ?`, projectID); err != nil {
+		return fmt.Errorf("unassign project %s: %w", projectID, err)
+	}
+	return nil
+}
+
+// GetProjectTenantID returns the ID of the tenant owning projectID, or ""
+// when the project is not assigned.
+func (g *GlobalDB) GetProjectTenantID(projectID string) (string, error) {
+	var tenantID string
+	err := g.QueryRow(`SELECT tenant_id FROM tenant_projects WHERE project_id = ?`, projectID).Scan(&tenantID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get project tenant %s: %w", projectID, err)
+	}
+	return tenantID, nil
+}
+
+// ListTenantProjectIDs returns the IDs of projects owned by tenantID.
+func (g *GlobalDB) ListTenantProjectIDs(tenantID string) ([]string, error) {
+	rows, err := g.Query(`SELECT project_id FROM tenant_projects WHERE tenant_id = ? ORDER BY project_id`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list tenant projects: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan tenant project: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
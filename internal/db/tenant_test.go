@@ -0,0 +1,105 @@
+package db
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func openTenantTestDB(t *testing.T) *GlobalDB {
+	t.Helper()
+	g, err := OpenGlobalAt(filepath.Join(t.TempDir(), "orc.db"))
+	if err != nil {
+		t.Fatalf("OpenGlobalAt: %v", err)
+	}
+	t.Cleanup(func() { _ = g.Close() })
+	return g
+}
+
+func TestTenantTokens(t *testing.T) {
+	t.Parallel()
+	g := openTenantTestDB(t)
+
+	tenant := &Tenant{Name: "payments", MaxRunningTasks: 2}
+	if err := g.CreateTenant(tenant); err != nil {
+		t.Fatalf("CreateTenant: %v", err)
+	}
+	if err := g.CreateTenant(&Tenant{Name: "payments"}); err == nil {
+		t.Error("duplicate tenant name should fail")
+	}
+
+	token, err := g.CreateTenantToken(tenant.ID, "ci")
+	if err != nil {
+		t.Fatalf("CreateTenantToken: %v", err)
+	}
+	if !strings.HasPrefix(token, TenantTokenPrefix) {
+		t.Errorf("token %q missing prefix", token)
+	}
+
+	got, err := g.ResolveTenantToken(token)
+	if err != nil || got == nil || got.ID != tenant.ID || got.MaxRunningTasks != 2 {
+		t.Fatalf("ResolveTenantToken = %+v, %v", got, err)
+	}
+	if got, _ := g.ResolveTenantToken(token + "x"); got != nil {
+		t.Error("unknown token should not resolve")
+	}
+
+	tokens, err := g.ListTenantTokens(tenant.ID)
+	if err != nil || len(tokens) != 1 || tokens[0].LastUsedAt == nil {
+		t.Fatalf("ListTenantTokens = %+v, %v; want one used token", tokens, err)
+	}
+
+	if err := g.RevokeTenantToken(tenant.ID, "ci"); err != nil {
+		t.Fatalf("RevokeTenantToken: %v", err)
+	}
+	if got, _ := g.ResolveTenantToken(token); got != nil {
+		t.Error("revoked token should not resolve")
+	}
+}
+
+func TestTenantProjects(t *testing.T) {
+	t.Parallel()
+	g := openTenantTestDB(t)
+
+	a := &Tenant{Name: "a", MaxProjects: 1}
+	b := &Tenant{Name: "b"}
+	for _, tn := range []*Tenant{a, b} {
+		if err := g.CreateTenant(tn); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := g.AssignProjectToTenant(a.ID, "proj-1"); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	// Reassigning the same project does not count against the quota.
+	if err := g.AssignProjectToTenant(a.ID, "proj-1"); err != nil {
+		t.Fatalf("reassign: %v", err)
+	}
+	if err := g.AssignProjectToTenant(a.ID, "proj-2"); !errors.Is(err, ErrTenantQuotaExceeded) {
+		t.Errorf("second project err = %v, want ErrTenantQuotaExceeded", err)
+	}
+
+	// A project has a single owner.
+	if err := g.AssignProjectToTenant(b.ID, "proj-1"); err != nil {
+		t.Fatal(err)
+	}
+	owner, err := g.GetProjectTenantID("proj-1")
+	if err != nil || owner != b.ID {
+		t.Errorf("owner = %q, %v; want %s", owner, err, b.ID)
+	}
+	if ids, _ := g.ListTenantProjectIDs(a.ID); len(ids) != 0 {
+		t.Errorf("tenant a projects = %v, want none", ids)
+	}
+
+	if err := g.DeleteTenant(b.ID); err != nil {
+		t.Fatalf("DeleteTenant: %v", err)
+	}
+	if owner, _ := g.GetProjectTenantID("proj-1"); owner != "" {
+		t.Errorf("owner after delete = %q, want none", owner)
+	}
+	if got, _ := g.GetTenant("b"); got != nil {
+		t.Error("deleted tenant still found")
+	}
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Traceability statuses of a success criterion.
+const (
+	TraceabilitySatisfied  = "satisfied"  // code and tests cover the criterion
+	TraceabilityPartial    = "partial"    // covered in part, or without tests
+	TraceabilityMissing    = "missing"    // nothing implements the criterion
+	TraceabilityUnverified = "unverified" // the validation model did not assess it
+)
+
+// TraceabilityEntry maps one spec success criterion to the code and tests
+// that satisfy it.
+type TraceabilityEntry struct {
+	CriterionID string   `json:"criterion_id"`
+	Criterion   string   `json:"criterion"`
+	Status      string   `json:"status"`
+	Code        []string `json:"code"`
+	Tests       []string `json:"tests"`
+	Notes       string   `json:"notes,omitempty"`
+}
+
+// TraceabilityReport is the traceability matrix of a task's spec, built
+// after a phase completed. Satisfied and Total summarize the entries.
+type TraceabilityReport struct {
+	ID        int64               `json:"id"`
+	TaskID    string              `json:"task_id"`
+	RunID     string              `json:"run_id,omitempty"`
+	PhaseID   string              `json:"phase_id"`
+	SpecHash  string              `json:"spec_hash,omitempty"`
+	Entries   []TraceabilityEntry `json:"entries"`
+	Model     string              `json:"model,omitempty"`
+	Satisfied int                 `json:"satisfied"`
+	Total     int                 `json:"total"`
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+// Dropped returns the entries whose criterion is neither satisfied nor
+// partially satisfied.
+func (r *TraceabilityReport) Dropped() []TraceabilityEntry {
+	var dropped []TraceabilityEntry
+	for _, e := range r.Entries {
+		if e.Status != TraceabilitySatisfied && e.Status != TraceabilityPartial {
+			dropped = append(dropped, e)
+		}
+	}
+	return dropped
+}
+
+func (r *TraceabilityReport) summarize() {
+	r.Satisfied, r.Total = 0, len(r.Entries)
+	for _, e := range r.Entries {
+		if e.Status == TraceabilitySatisfied {
+			r.Satisfied++
+		}
+	}
+}
+
+// SaveTraceabilityReport appends a traceability report for a task. Earlier
+// reports are kept, so a task's coverage can be followed across phases.
+func (p *ProjectDB) SaveTraceabilityReport(r *TraceabilityReport) error {
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = time.Now()
+	}
+	if r.Entries == nil {
+		r.Entries = []TraceabilityEntry{}
+	}
+	entries, err := json.Marshal(r.Entries)
+	if err != nil {
+		return fmt.Errorf("marshal traceability entries: %w", err)
+	}
+	r.summarize()
+
+	if _, err := p.Exec(`
+		INSERT INTO traceability_reports (task_id, run_id, phase_id, spec_hash, entries, model, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, r.TaskID, r.RunID, r.PhaseID, r.SpecHash, string(entries), r.Model,
+		r.CreatedAt.UTC().Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("save traceability report for %s: %w", r.TaskID, err)
+	}
+	return nil
+}
+
+// ListTraceabilityReports returns a task's traceability reports, newest
+// first.
+func (p *ProjectDB) ListTraceabilityReports(taskID string) ([]*TraceabilityReport, error) {
+	rows, err := p.Query(`
+		SELECT id, task_id, run_id, phase_id, spec_hash, entries, model, created_at
+		FROM traceability_reports WHERE task_id = ? ORDER BY id DESC
+	`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("list traceability reports: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	reports := []*TraceabilityReport{}
+	for rows.Next() {
+		var r TraceabilityReport
+		var entries, createdAt string
+		if err := rows.Scan(&r.ID, &r.TaskID, &r.RunID, &r.PhaseID, &r.SpecHash, &entries, &r.Model, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan traceability report: %w", err)
+		}
+		if err := json.Unmarshal([]byte(entries), &r.Entries); err != nil {
+			return nil, fmt.Errorf("parse traceability entries of report %d: %w", r.ID, err)
+		}
+		r.CreatedAt = parseTimestamp(createdAt)
+		r.summarize()
+		reports = append(reports, &r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate traceability reports: %w", err)
+	}
+	return reports, nil
+}
+
+// GetLatestTraceabilityReport returns a task's newest traceability report,
+// or nil if it has none.
+func (p *ProjectDB) GetLatestTraceabilityReport(taskID string) (*TraceabilityReport, error) {
+	reports, err := p.ListTraceabilityReports(taskID)
+	if err != nil || len(reports) == 0 {
+		return nil, err
+	}
+	return reports[0], nil
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProjectDB_TraceabilityReports(t *testing.T) {
+	t.Parallel()
+	pdb := NewTestProjectDB(t)
+	if err := pdb.SaveTask(&Task{ID: "TASK-001", Title: "Rate limiting", Status: "running"}); err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := pdb.GetLatestTraceabilityReport("TASK-001")
+	if err != nil || latest != nil {
+		t.Fatalf("GetLatestTraceabilityReport() before save = %+v, %v", latest, err)
+	}
+
+	now := time.Now()
+	first := &TraceabilityReport{
+		TaskID: "TASK-001", RunID: "RUN-001", PhaseID: "implement", SpecHash: "abc", Model: "haiku",
+		Entries: []TraceabilityEntry{
+			{CriterionID: "SC-1", Criterion: "Returns 429", Status: TraceabilitySatisfied, Code: []string{"internal/api/limit.go"}, Tests: []string{"TestLimit"}},
+			{CriterionID: "SC-2", Criterion: "Limit resets", Status: TraceabilityMissing},
+		},
+		CreatedAt: now.Add(-time.Hour),
+	}
+	second := &TraceabilityReport{
+		TaskID: "TASK-001", RunID: "RUN-001", PhaseID: "review",
+		Entries: []TraceabilityEntry{
+			{CriterionID: "SC-1", Criterion: "Returns 429", Status: TraceabilitySatisfied},
+			{CriterionID: "SC-2", Criterion: "Limit resets", Status: TraceabilityPartial},
+		},
+		CreatedAt: now,
+	}
+	for _, r := range []*TraceabilityReport{first, second} {
+		if err := pdb.SaveTraceabilityReport(r); err != nil {
+			t.Fatalf("SaveTraceabilityReport: %v", err)
+		}
+	}
+
+	reports, err := pdb.ListTraceabilityReports("TASK-001")
+	if err != nil {
+		t.Fatalf("ListTraceabilityReports: %v", err)
+	}
+	if len(reports) != 2 || reports[0].PhaseID != "review" {
+		t.Fatalf("ListTraceabilityReports() = %+v, want newest first", reports)
+	}
+	old := reports[1]
+	if old.Satisfied != 1 || old.Total != 2 || old.Model != "haiku" || old.Entries[0].Tests[0] != "TestLimit" {
+		t.Errorf("first report = %+v", old)
+	}
+	if dropped := old.Dropped(); len(dropped) != 1 || dropped[0].CriterionID != "SC-2" {
+		t.Errorf("Dropped() = %+v, want SC-2", dropped)
+	}
+	if dropped := reports[0].Dropped(); len(dropped) != 0 {
+		t.Errorf("partial criterion counted as dropped: %+v", dropped)
+	}
+
+	latest, err = pdb.GetLatestTraceabilityReport("TASK-001")
+	if err != nil || latest == nil || latest.ID != reports[0].ID {
+		t.Errorf("GetLatestTraceabilityReport() = %+v, %v", latest, err)
+	}
+}
@@ -131,6 +131,22 @@ func openLegacyWorkflowDB(t *testing.T, dbPath, schemaType string, latestApplied
 		t.Fatalf("create legacy workflows index: %v", err)
 	}
 
+	// Later project migrations add search indexes over these tables.
+	if schemaType == "project" {
+		if _, err := rawDB.Exec(`
+			CREATE TABLE tasks (id TEXT PRIMARY KEY, title TEXT NOT NULL, description TEXT);
+			CREATE TABLE phase_outputs (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				task_id TEXT,
+				phase_template_id TEXT NOT NULL,
+				output_var_name TEXT NOT NULL,
+				content TEXT NOT NULL
+			);
+		`); err != nil {
+			t.Fatalf("create legacy task tables: %v", err)
+		}
+	}
+
 	return rawDB
 }
 
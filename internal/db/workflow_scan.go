@@ -105,11 +105,11 @@ func scanWorkflow(row rowScanner) (*Workflow, error) {
 	w := &Workflow{}
 	var createdAt, updatedAt string
 	var description, defaultModel, completionAction, targetBranch, basedOn, triggers sql.NullString
-	var defaultProvider sql.NullString
+	var defaultProvider, doneCriteria sql.NullString
 
 	err := row.Scan(
 		&w.ID, &w.Name, &description, &defaultModel, &defaultProvider, &w.DefaultThinking,
-		&completionAction, &targetBranch, &w.IsBuiltin, &basedOn, &triggers, &createdAt, &updatedAt,
+		&completionAction, &targetBranch, &w.IsBuiltin, &basedOn, &triggers, &doneCriteria, &createdAt, &updatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -122,6 +122,7 @@ func scanWorkflow(row rowScanner) (*Workflow, error) {
 	w.TargetBranch = targetBranch.String
 	w.BasedOn = basedOn.String
 	w.Triggers = triggers.String
+	w.DoneCriteria = doneCriteria.String
 	w.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
 	w.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
 	return w, nil
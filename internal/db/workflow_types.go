@@ -16,6 +16,10 @@ type QualityCheck struct {
 	Command   string `json:"command,omitempty"`
 	OnFailure string `json:"on_failure,omitempty"`
 	TimeoutMs int    `json:"timeout_ms,omitempty"`
+	// ExpectFailure inverts the check: it passes only when the command
+	// exits non-zero (e.g. a regression test run before the fix). Timeouts
+	// still fail.
+	ExpectFailure bool `json:"expect_failure,omitempty"`
 }
 
 // ParseQualityChecks parses a JSON string into a slice of QualityCheck.
@@ -86,6 +90,7 @@ type Workflow struct {
 	IsBuiltin        bool      `json:"is_builtin"`
 	BasedOn          string    `json:"based_on,omitempty"`
 	Triggers         string    `json:"triggers,omitempty"`
+	DoneCriteria     string    `json:"done_criteria,omitempty"` // JSON array of workflow.DoneCriterion
 	CreatedAt        time.Time `json:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at"`
 
@@ -0,0 +1,307 @@
+// Package demo builds the sandbox behind `orc serve --demo`: a throwaway git
+// project seeded with synthetic tasks in every state, and a Player that fakes
+// task execution by replaying canned phase events. Nothing in it reaches a
+// model or the user's repositories.
+package demo
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/bootstrap"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/initiative"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+	"github.com/randalmurphal/orc/internal/workflow"
+)
+
+// NoModel is the claude_path and codex_path of the demo project, so anything
+// that would start a real model fails immediately instead.
+const NoModel = "orc-demo-has-no-model"
+
+// Project is a seeded demo project.
+type Project struct {
+	Dir string
+	ID  string
+	// LiveTaskID is the running task the Player keeps replaying.
+	LiveTaskID string
+}
+
+// Setup creates the demo project under root (a temporary directory the caller
+// removes), registers it, and seeds it. HOME should point inside root too, so
+// the registry and global database are sandboxed as well.
+func Setup(root string) (*Project, error) {
+	dir := filepath.Join(root, "demo-app")
+	if err := writeRepo(dir); err != nil {
+		return nil, fmt.Errorf("create demo repo: %w", err)
+	}
+
+	result, err := bootstrap.Run(bootstrap.Options{
+		WorkDir:          dir,
+		SkipClaudeMD:     true,
+		SkipHooks:        true,
+		SkipGitignore:    true,
+		SkipConstitution: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("init demo project: %w", err)
+	}
+
+	cfg, err := config.LoadFile(result.ConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("load demo config: %w", err)
+	}
+	cfg.ClaudePath = NoModel
+	cfg.CodexPath = NoModel
+	cfg.FeatureFlags = map[string]bool{"automation": false}
+	cfg.Documentation.DriftCheck.Enabled = false
+	if err := cfg.SaveTo(result.ConfigPath); err != nil {
+		return nil, fmt.Errorf("write demo config: %w", err)
+	}
+
+	backend, err := storage.NewBackend(dir, &cfg.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("open demo database: %w", err)
+	}
+	defer func() { _ = backend.Close() }()
+	liveID, err := Seed(backend, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("seed demo project: %w", err)
+	}
+	return &Project{Dir: dir, ID: result.ProjectID, LiveTaskID: liveID}, nil
+}
+
+// writeRepo creates a tiny git repository for the demo tasks to belong to.
+func writeRepo(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	files := map[string]string{
+		"README.md": "# demo-app\n\nA sample service for exploring orc. Nothing here is real.\n",
+		"go.mod":    "module example.com/demo-app\n\ngo 1.24\n",
+		"main.go":   "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hello from demo-app\")\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"add", "-A"},
+		{"-c", "user.name=orc demo", "-c", "user.email=demo@orc.invalid", "commit", "-q", "-m", "Initial commit"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("git %s: %w: %s", args[0], err, out)
+		}
+	}
+	return nil
+}
+
+// seedTask describes one synthetic task.
+type seedTask struct {
+	title       string
+	description string
+	category    orcv1.TaskCategory
+	priority    orcv1.TaskPriority
+	workflow    string
+	status      orcv1.TaskStatus
+	// done is how many workflow phases completed; for failed and paused
+	// tasks the next phase is the one that failed or was interrupted.
+	done      int
+	failure   string
+	blockedBy []string
+	inInit    bool
+	age       time.Duration
+	live      bool
+}
+
+var seedTasks = []seedTask{
+	{title: "Add rate limiting to the public API", description: "Limit each API key to 100 requests per minute and return 429 with Retry-After.",
+		category: orcv1.TaskCategory_TASK_CATEGORY_FEATURE, workflow: "implement-medium", status: orcv1.TaskStatus_TASK_STATUS_COMPLETED, done: -1, inInit: true, age: 72 * time.Hour},
+	{title: "Fix off-by-one in report pagination", description: "The last page of /reports repeats the first item of the previous page.",
+		category: orcv1.TaskCategory_TASK_CATEGORY_BUG, priority: orcv1.TaskPriority_TASK_PRIORITY_HIGH, workflow: "implement-small", status: orcv1.TaskStatus_TASK_STATUS_COMPLETED, done: -1, age: 50 * time.Hour},
+	{title: "Add a dark mode toggle to settings", description: "Persist the choice per user and respect prefers-color-scheme by default.",
+		category: orcv1.TaskCategory_TASK_CATEGORY_FEATURE, workflow: "implement-small", status: orcv1.TaskStatus_TASK_STATUS_RUNNING, age: 2 * time.Hour, live: true},
+	{title: "Move the session store to Redis", description: "Replace the in-process session map so the service can run more than one replica.",
+		category: orcv1.TaskCategory_TASK_CATEGORY_REFACTOR, workflow: "implement-medium", status: orcv1.TaskStatus_TASK_STATUS_FAILED, done: 2,
+		failure: "tests failed: 3 failing in session/store_test.go (connection refused: redis:6379)", inInit: true, age: 26 * time.Hour},
+	{title: "Document the rate limiter", description: "Explain limits, headers and how to request a higher quota.",
+		category: orcv1.TaskCategory_TASK_CATEGORY_DOCS, priority: orcv1.TaskPriority_TASK_PRIORITY_LOW, workflow: "docs", status: orcv1.TaskStatus_TASK_STATUS_BLOCKED, blockedBy: []string{"TASK-004"}, inInit: true, age: 25 * time.Hour},
+	{title: "Split config loading out of main.go", description: "Move flag and env parsing into a config package with tests.",
+		category: orcv1.TaskCategory_TASK_CATEGORY_REFACTOR, workflow: "implement-small", status: orcv1.TaskStatus_TASK_STATUS_PAUSED, done: 1, age: 8 * time.Hour},
+	{title: "Add CSV export to reports", description: "GET /reports/{id}.csv streams the report rows as CSV.",
+		category: orcv1.TaskCategory_TASK_CATEGORY_FEATURE, workflow: "implement-small", status: orcv1.TaskStatus_TASK_STATUS_PLANNED, age: 5 * time.Hour},
+	{title: "Investigate the flaky websocket reconnect test", description: "TestReconnect fails about 1 in 20 runs on CI.",
+		category: orcv1.TaskCategory_TASK_CATEGORY_TEST, priority: orcv1.TaskPriority_TASK_PRIORITY_HIGH, workflow: "implement-trivial", status: orcv1.TaskStatus_TASK_STATUS_CREATED, age: 3 * time.Hour},
+	{title: "Bump Go to 1.24", description: "Update go.mod, CI images and the Dockerfile.",
+		category: orcv1.TaskCategory_TASK_CATEGORY_CHORE, workflow: "implement-trivial", status: orcv1.TaskStatus_TASK_STATUS_CREATED, age: time.Hour},
+	{title: "Rewrite the service in Rust", description: "Closed: not worth the migration cost right now.",
+		category: orcv1.TaskCategory_TASK_CATEGORY_REFACTOR, priority: orcv1.TaskPriority_TASK_PRIORITY_LOW, workflow: "implement-large", status: orcv1.TaskStatus_TASK_STATUS_CLOSED, age: 96 * time.Hour},
+}
+
+// Seed fills backend with the demo initiative, tasks, workflow runs and
+// transcripts, with times relative to now, and returns the ID of the live
+// task.
+func Seed(backend storage.Backend, now time.Time) (string, error) {
+	if _, err := workflow.SeedBuiltinsToProject(backend.DB()); err != nil {
+		return "", fmt.Errorf("seed workflows: %w", err)
+	}
+
+	hardening := initiative.New("INIT-001", "Public API hardening")
+	hardening.Status = initiative.StatusActive
+	hardening.Vision = "Make the public API safe to open to third parties: limits, scalability and docs."
+
+	var liveID string
+	for i, s := range seedTasks {
+		id := fmt.Sprintf("TASK-%03d", i+1)
+		if err := seedOne(backend, id, s, now); err != nil {
+			return "", fmt.Errorf("%s: %w", id, err)
+		}
+		if s.inInit {
+			hardening.AddTask(id, s.title, s.blockedBy)
+		}
+		if s.live {
+			liveID = id
+		}
+	}
+	if err := backend.SaveInitiative(hardening); err != nil {
+		return "", fmt.Errorf("save initiative: %w", err)
+	}
+	return liveID, nil
+}
+
+func seedOne(backend storage.Backend, id string, s seedTask, now time.Time) error {
+	created := now.Add(-s.age)
+	t := task.NewProtoTask(id, s.title)
+	t.Description = &s.description
+	t.Category = s.category
+	if s.priority != orcv1.TaskPriority_TASK_PRIORITY_UNSPECIFIED {
+		t.Priority = s.priority
+	}
+	wf := s.workflow
+	t.WorkflowId = &wf
+	t.BlockedBy = s.blockedBy
+	t.CreatedAt = timestamppb.New(created)
+	t.UpdatedAt = t.CreatedAt
+	if s.inInit {
+		task.SetInitiativeProto(t, "INIT-001")
+	}
+	t.Status = s.status
+
+	phases, err := workflowPhases(backend, s.workflow)
+	if err != nil {
+		return err
+	}
+	done := s.done
+	if done < 0 {
+		done = len(phases)
+	}
+	stopped := s.status == orcv1.TaskStatus_TASK_STATUS_FAILED || s.status == orcv1.TaskStatus_TASK_STATUS_PAUSED
+	if err := backend.SaveTask(t); err != nil || (done == 0 && !stopped && !s.live) {
+		return err
+	}
+
+	// Record the finished phases as if they ran right after the task was created
+	run, err := newRun(backend, t, created.Add(5*time.Minute))
+	if err != nil {
+		return err
+	}
+	at := *run.StartedAt
+	for _, p := range phases[:done] {
+		at, err = recordPhase(backend, run, t, p, at)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case s.live:
+		// The Player replays it from the start
+		t.StartedAt = timestamppb.New(at)
+		t.ExecutorPid = int32(os.Getpid())
+	case stopped && done < len(phases):
+		phase := phases[done]
+		task.SetCurrentPhaseProto(t, phase)
+		task.StartPhaseProto(t.Execution, phase)
+		t.Execution.Phases[phase].StartedAt = timestamppb.New(at)
+		wrp := &db.WorkflowRunPhase{WorkflowRunID: run.ID, PhaseTemplateID: phase, Status: "pending", StartedAt: &at}
+		if s.status == orcv1.TaskStatus_TASK_STATUS_FAILED {
+			task.FailPhaseProto(t.Execution, phase, fmt.Errorf("%s", s.failure))
+			wrp.Status, wrp.Error = "failed", s.failure
+			run.Status, run.Error = "failed", s.failure
+		} else {
+			task.InterruptPhaseProto(t.Execution, phase)
+			run.Status = "paused"
+		}
+		if err := backend.SaveWorkflowRunPhase(wrp); err != nil {
+			return err
+		}
+		t.StartedAt = timestamppb.New(*run.StartedAt)
+		at = at.Add(4 * time.Minute)
+		t.CompletedAt = timestamppb.New(at)
+	default:
+		t.StartedAt = timestamppb.New(*run.StartedAt)
+		t.CompletedAt = timestamppb.New(at)
+		run.Status = "completed"
+		run.CompletedAt = &at
+	}
+	t.UpdatedAt = timestamppb.New(at)
+	if s.status == orcv1.TaskStatus_TASK_STATUS_PAUSED {
+		t.CompletedAt = nil
+	}
+	if err := backend.SaveWorkflowRun(run); err != nil {
+		return err
+	}
+	return backend.SaveTask(t)
+}
+
+// newRun starts a workflow run record for t.
+func newRun(backend storage.Backend, t *orcv1.Task, start time.Time) (*db.WorkflowRun, error) {
+	id, err := backend.GetNextWorkflowRunID()
+	if err != nil {
+		return nil, err
+	}
+	taskID := t.Id
+	run := &db.WorkflowRun{
+		ID:          id,
+		WorkflowID:  t.GetWorkflowId(),
+		ContextType: "task",
+		TaskID:      &taskID,
+		Prompt:      task.GetDescriptionProto(t),
+		Status:      "running",
+		StartedAt:   &start,
+		StartedBy:   "demo",
+		CreatedAt:   start,
+		UpdatedAt:   start,
+	}
+	if err := backend.SaveWorkflowRun(run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// workflowPhases returns a workflow's phase template IDs in order.
+func workflowPhases(backend storage.Backend, workflowID string) ([]string, error) {
+	phases, err := backend.GetWorkflowPhases(workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("load %s phases: %w", workflowID, err)
+	}
+	sort.Slice(phases, func(i, j int) bool { return phases[i].Sequence < phases[j].Sequence })
+	ids := make([]string, len(phases))
+	for i, p := range phases {
+		ids[i] = p.PhaseTemplateID
+	}
+	return ids, nil
+}
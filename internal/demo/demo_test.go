@@ -0,0 +1,147 @@
+package demo
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+func TestSeed_CoversEveryState(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	liveID, err := Seed(backend, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tasks, err := backend.LoadAllTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != len(seedTasks) {
+		t.Fatalf("seeded %d tasks, want %d", len(tasks), len(seedTasks))
+	}
+	statuses := map[orcv1.TaskStatus]bool{}
+	for _, tk := range tasks {
+		statuses[tk.Status] = true
+	}
+	for _, want := range []orcv1.TaskStatus{
+		orcv1.TaskStatus_TASK_STATUS_CREATED,
+		orcv1.TaskStatus_TASK_STATUS_PLANNED,
+		orcv1.TaskStatus_TASK_STATUS_RUNNING,
+		orcv1.TaskStatus_TASK_STATUS_PAUSED,
+		orcv1.TaskStatus_TASK_STATUS_BLOCKED,
+		orcv1.TaskStatus_TASK_STATUS_FAILED,
+		orcv1.TaskStatus_TASK_STATUS_COMPLETED,
+		orcv1.TaskStatus_TASK_STATUS_CLOSED,
+	} {
+		if !statuses[want] {
+			t.Errorf("no seeded task is %s", want)
+		}
+	}
+
+	done, err := backend.LoadTask("TASK-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if done.GetExecution().GetCost().GetTotalCostUsd() == 0 || done.GetExecution().GetTokens().GetTotalTokens() == 0 {
+		t.Errorf("completed task has no cost or tokens: %+v", done.GetExecution())
+	}
+	transcripts, err := backend.GetTranscripts("TASK-001")
+	if err != nil || len(transcripts) == 0 {
+		t.Errorf("completed task transcripts = %d, %v", len(transcripts), err)
+	}
+	if liveID == "" {
+		t.Error("no live task")
+	}
+}
+
+func TestPlayer_ReplaysToCompletion(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	liveID, err := Seed(backend, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pub := events.NewMemoryPublisher(events.WithBufferSize(1000))
+	defer pub.Close()
+	ch := pub.Subscribe(liveID)
+	player := NewPlayer(backend, pub, nil)
+	player.Step = 0
+	if err := player.Replay(context.Background(), liveID, "proj-demo"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := backend.LoadTask(liveID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != orcv1.TaskStatus_TASK_STATUS_COMPLETED {
+		t.Errorf("status = %s, want completed", got.Status)
+	}
+	phases, _ := workflowPhases(backend, got.GetWorkflowId())
+	for _, phase := range phases {
+		if ps := got.GetExecution().GetPhases()[phase]; ps == nil || ps.Status != orcv1.PhaseStatus_PHASE_STATUS_COMPLETED {
+			t.Errorf("phase %s = %+v, want completed", phase, ps)
+		}
+	}
+
+	seen := map[events.EventType]bool{}
+	for len(ch) > 0 {
+		ev := <-ch
+		seen[ev.Type] = true
+		if ev.ProjectID != "proj-demo" {
+			t.Errorf("%s event has project %q", ev.Type, ev.ProjectID)
+		}
+	}
+	for _, want := range []events.EventType{events.EventPhase, events.EventTranscript, events.EventTokens, events.EventTaskUpdated, events.EventComplete} {
+		if !seen[want] {
+			t.Errorf("no %s event published", want)
+		}
+	}
+}
+
+func TestPlayer_StopsWhenPaused(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	liveID, err := Seed(backend, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tk, _ := backend.LoadTask(liveID)
+	tk.Status = orcv1.TaskStatus_TASK_STATUS_PAUSED
+	if err := backend.SaveTask(tk); err != nil {
+		t.Fatal(err)
+	}
+
+	player := NewPlayer(backend, nil, nil)
+	player.Step = 0
+	if err := player.Replay(context.Background(), liveID, ""); err != errStopped {
+		t.Errorf("Replay of a paused task = %v, want errStopped", err)
+	}
+}
+
+func TestSetup_CreatesSandboxedProject(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("HOME", filepath.Join(root, "home"))
+
+	proj, err := Setup(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(proj.Dir, root) || proj.ID == "" || proj.LiveTaskID == "" {
+		t.Fatalf("project = %+v", proj)
+	}
+	cfg, err := config.LoadFrom(proj.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ClaudePath != NoModel || cfg.FeatureEnabled("automation") {
+		t.Errorf("demo config claude_path=%q automation=%v", cfg.ClaudePath, cfg.FeatureEnabled("automation"))
+	}
+}
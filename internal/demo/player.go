@@ -0,0 +1,260 @@
+package demo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// DefaultStep is the pause between replayed events.
+const DefaultStep = 1500 * time.Millisecond
+
+// errStopped means the task left the running state (paused, closed, ...)
+// mid-replay.
+var errStopped = errors.New("task is no longer running")
+
+// Player fakes task execution: it walks the task's workflow phases,
+// publishing the phase, activity, transcript and token events a real run
+// would, and records the results so the task ends up completed.
+type Player struct {
+	backend   storage.Backend
+	publisher events.Publisher
+	logger    *slog.Logger
+	// Step is the pause between replayed events.
+	Step time.Duration
+}
+
+// NewPlayer creates a player for the demo project's backend.
+func NewPlayer(backend storage.Backend, publisher events.Publisher, logger *slog.Logger) *Player {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Player{backend: backend, publisher: publisher, logger: logger, Step: DefaultStep}
+}
+
+// Run replays the task in the background. It matches api.TaskExecutorFunc,
+// so the server can use it in place of the workflow executor.
+func (p *Player) Run(_ context.Context, taskID, projectID string) error {
+	if _, err := p.backend.LoadTask(taskID); err != nil {
+		return fmt.Errorf("task not found: %w", err)
+	}
+	go func() {
+		if err := p.Replay(context.Background(), taskID, projectID); err != nil && !errors.Is(err, errStopped) {
+			p.logger.Error("demo replay failed", "task", taskID, "error", err)
+		}
+	}()
+	return nil
+}
+
+// Loop keeps the live task running: it replays it, waits a little, and
+// starts it over, until ctx is done. If someone pauses the task, Loop waits
+// for it to run again instead.
+func (p *Player) Loop(ctx context.Context, taskID, projectID string) {
+	for {
+		err := p.Replay(ctx, taskID, projectID)
+		if err != nil && !errors.Is(err, errStopped) && ctx.Err() == nil {
+			p.logger.Error("demo replay failed", "task", taskID, "error", err)
+		}
+		if !p.sleep(ctx, 10*p.Step) {
+			return
+		}
+		t, err := p.backend.LoadTask(taskID)
+		if err != nil {
+			return
+		}
+		if t.Status != orcv1.TaskStatus_TASK_STATUS_COMPLETED {
+			// Paused or failed by the user; resuming restarts the replay
+			continue
+		}
+		t.Execution = task.InitProtoExecutionState()
+		t.CurrentPhase = nil
+		t.CompletedAt = nil
+		task.MarkStartedProto(t)
+		if err := p.save(t, projectID); err != nil {
+			p.logger.Error("demo replay reset failed", "task", taskID, "error", err)
+			return
+		}
+	}
+}
+
+// Replay runs the task's remaining phases with Step between events and
+// marks it completed. It returns errStopped if the task stops running
+// before then.
+func (p *Player) Replay(ctx context.Context, taskID, projectID string) error {
+	t, err := p.backend.LoadTask(taskID)
+	if err != nil {
+		return err
+	}
+	if t.Status != orcv1.TaskStatus_TASK_STATUS_RUNNING {
+		return errStopped
+	}
+	phases, err := workflowPhases(p.backend, t.GetWorkflowId())
+	if err != nil {
+		return err
+	}
+	started := time.Now()
+	run, err := newRun(p.backend, t, started)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Runs end as completed, or as paused when stopped early
+		if run.Status == "running" {
+			run.Status = "paused"
+		}
+		_ = p.backend.SaveWorkflowRun(run)
+	}()
+
+	for _, phase := range phases {
+		if ps := t.GetExecution().GetPhases()[phase]; ps != nil && ps.Status == orcv1.PhaseStatus_PHASE_STATUS_COMPLETED {
+			continue
+		}
+		if err := p.replayPhase(ctx, run, taskID, projectID, phase); err != nil {
+			return err
+		}
+	}
+
+	t, err = p.reload(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	task.MarkCompletedProto(t)
+	if err := p.save(t, projectID); err != nil {
+		return err
+	}
+	now := time.Now()
+	run.Status = "completed"
+	run.CompletedAt = &now
+	p.publish(events.EventComplete, projectID, taskID, events.CompleteData{
+		Status:   "completed",
+		Duration: now.Sub(started).Round(time.Second).String(),
+	})
+	return nil
+}
+
+func (p *Player) replayPhase(ctx context.Context, run *db.WorkflowRun, taskID, projectID, phase string) error {
+	t, err := p.reload(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	task.SetCurrentPhaseProto(t, phase)
+	task.StartPhaseProto(t.Execution, phase)
+	if err := p.save(t, projectID); err != nil {
+		return err
+	}
+	p.publish(events.EventPhase, projectID, taskID, events.PhaseUpdate{Phase: phase, Status: "running"})
+	p.publish(events.EventActivity, projectID, taskID, events.ActivityUpdate{Phase: phase, Activity: "streaming"})
+
+	lines := script(phase, t.Title)
+	for i, line := range lines {
+		if !p.sleep(ctx, p.Step) {
+			return ctx.Err()
+		}
+		if _, err := p.reload(ctx, taskID); err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := p.backend.AddTranscript(transcript(run, t, phase, i, line, now)); err != nil {
+			return err
+		}
+		p.publish(events.EventTranscript, projectID, taskID, events.TranscriptLine{
+			Phase:     phase,
+			Iteration: 1,
+			Type:      line.kind,
+			Content:   line.text,
+			Timestamp: now,
+			Model:     demoModel,
+		})
+	}
+
+	t, err = p.reload(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if err := completePhase(p.backend, run, t, phase, lines, start, time.Now()); err != nil {
+		return err
+	}
+	if err := p.save(t, projectID); err != nil {
+		return err
+	}
+	usage := t.Execution.Phases[phase].Tokens
+	p.publish(events.EventTokens, projectID, taskID, events.TokenUpdate{
+		Phase:                phase,
+		InputTokens:          int(usage.InputTokens),
+		OutputTokens:         int(usage.OutputTokens),
+		CacheReadInputTokens: int(usage.CacheReadInputTokens),
+		TotalTokens:          int(usage.TotalTokens),
+	})
+	p.publish(events.EventPhase, projectID, taskID, events.PhaseUpdate{
+		Phase:     phase,
+		Status:    "completed",
+		CommitSHA: fakeCommit(taskID, phase),
+	})
+	return nil
+}
+
+// reload loads the task, failing with errStopped once it is not running.
+func (p *Player) reload(ctx context.Context, taskID string) (*orcv1.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	t, err := p.backend.LoadTask(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if t.Status != orcv1.TaskStatus_TASK_STATUS_RUNNING {
+		return nil, errStopped
+	}
+	return t, nil
+}
+
+// save persists the task with a fresh heartbeat, so it is never mistaken
+// for an orphan, and broadcasts the update.
+func (p *Player) save(t *orcv1.Task, projectID string) error {
+	t.ExecutorPid = int32(os.Getpid())
+	t.LastHeartbeat = timestamppb.Now()
+	task.UpdateTimestampProto(t)
+	if err := p.backend.SaveTask(t); err != nil {
+		return err
+	}
+	p.publish(events.EventTaskUpdated, projectID, t.Id, t)
+	return nil
+}
+
+func (p *Player) publish(eventType events.EventType, projectID, taskID string, data any) {
+	if p.publisher == nil {
+		return
+	}
+	if projectID != "" {
+		p.publisher.Publish(events.NewProjectEvent(eventType, projectID, taskID, data))
+		return
+	}
+	p.publisher.Publish(events.NewEvent(eventType, taskID, data))
+}
+
+// sleep waits d, reporting false if ctx ended first.
+func (p *Player) sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
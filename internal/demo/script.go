@@ -0,0 +1,182 @@
+package demo
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// demoModel is reported as the model of every scripted response.
+const demoModel = "demo-model"
+
+// scriptLine is one canned transcript entry.
+type scriptLine struct {
+	kind string // prompt, response, tool (as in events.TranscriptLine)
+	text string
+}
+
+// phaseScripts are the canned transcripts of the common phases; other
+// phases get genericScript.
+var phaseScripts = map[string][]scriptLine{
+	"spec": {
+		{"response", "Reading the codebase to understand where {title} fits."},
+		{"tool", "Grep \"func main\" --type go"},
+		{"response", "The change is self-contained. Writing the spec with success criteria and a test plan."},
+		{"tool", "Write .orc/specs/spec.md"},
+		{"response", "Spec complete: 4 success criteria, 6 tests."},
+	},
+	"tiny_spec": {
+		{"response", "Scoping {title}."},
+		{"tool", "Read main.go"},
+		{"response", "Small change. Success criteria: behavior covered by a new test; existing tests keep passing."},
+	},
+	"tdd_write": {
+		{"response", "Writing failing tests for {title} first."},
+		{"tool", "Write main_test.go"},
+		{"tool", "Bash go test ./..."},
+		{"response", "2 new tests fail as expected."},
+	},
+	"implement": {
+		{"response", "Implementing {title}."},
+		{"tool", "Read main.go"},
+		{"tool", "Edit main.go"},
+		{"response", "Core change done. Running the tests."},
+		{"tool", "Bash go test ./..."},
+		{"response", "ok  \texample.com/demo-app\t0.412s"},
+		{"tool", "Bash git commit -am \"Implement change\""},
+		{"response", "Implementation complete; all tests pass."},
+	},
+	"review": {
+		{"response", "Reviewing the diff for {title}."},
+		{"tool", "Bash git diff main...HEAD"},
+		{"response", "No blocking issues. One suggestion: add a comment on the retry constant. Approved."},
+	},
+	"docs": {
+		{"response", "Updating documentation for {title}."},
+		{"tool", "Edit README.md"},
+		{"response", "README updated."},
+	},
+	"qa": {
+		{"response", "Exercising {title} end to end."},
+		{"tool", "Bash go run . --help"},
+		{"response", "Manual checks pass; no regressions found."},
+	},
+}
+
+var genericScript = []scriptLine{
+	{"response", "Working on {title}."},
+	{"tool", "Bash go build ./..."},
+	{"response", "Phase complete."},
+}
+
+// script returns the transcript of phase for a task titled title, starting
+// with the prompt the phase would send.
+func script(phase, title string) []scriptLine {
+	lines := []scriptLine{{"prompt", "Run the " + phase + " phase for: " + title}}
+	canned, ok := phaseScripts[phase]
+	if !ok {
+		canned = genericScript
+	}
+	for _, l := range canned {
+		lines = append(lines, scriptLine{l.kind, strings.ReplaceAll(l.text, "{title}", title)})
+	}
+	return lines
+}
+
+// transcript converts a script line to its stored form.
+func transcript(run *db.WorkflowRun, t *orcv1.Task, phase string, n int, line scriptLine, at time.Time) *storage.Transcript {
+	rec := &storage.Transcript{
+		TaskID:        t.Id,
+		Phase:         phase,
+		SessionID:     "demo-" + run.ID + "-" + phase,
+		WorkflowRunID: run.ID,
+		MessageUUID:   fmt.Sprintf("demo-%s-%s-%d", run.ID, phase, n),
+		Type:          "assistant",
+		Role:          "assistant",
+		Content:       line.text,
+		Model:         demoModel,
+		Timestamp:     at.UnixMilli(),
+	}
+	if line.kind == "prompt" {
+		rec.Type, rec.Role, rec.Model = "user", "user", ""
+	}
+	return rec
+}
+
+// phaseUsage is the fake token usage of a phase, scaled by script length.
+func phaseUsage(lines []scriptLine) *orcv1.TokenUsage {
+	n := int32(len(lines))
+	return &orcv1.TokenUsage{
+		InputTokens:          4200 * n,
+		OutputTokens:         650 * n,
+		CacheReadInputTokens: 9000 * n,
+	}
+}
+
+// phaseCost prices usage at typical large-model rates.
+func phaseCost(u *orcv1.TokenUsage) float64 {
+	return float64(u.InputTokens)*3/1e6 + float64(u.OutputTokens)*15/1e6 + float64(u.CacheReadInputTokens)*0.3/1e6
+}
+
+// fakeCommit returns a stable fake commit SHA for a phase.
+func fakeCommit(taskID, phase string) string {
+	sum := sha1.Sum([]byte(taskID + "/" + phase))
+	return hex.EncodeToString(sum[:])
+}
+
+// completePhase records a finished phase on the task and the run.
+func completePhase(backend storage.Backend, run *db.WorkflowRun, t *orcv1.Task, phase string, lines []scriptLine, start, end time.Time) error {
+	usage := phaseUsage(lines)
+	cost := phaseCost(usage)
+	sha := fakeCommit(t.Id, phase)
+
+	task.SetPhaseTokensProto(t.Execution, phase, usage)
+	task.AddCostProto(t.Execution, phase, cost)
+	task.CompletePhaseProto(t.Execution, phase, sha)
+	ps := t.Execution.Phases[phase]
+	ps.StartedAt = timestamppb.New(start)
+	ps.CompletedAt = timestamppb.New(end)
+	ps.Iterations = 1
+
+	run.CurrentPhase = phase
+	run.TotalCostUSD += cost
+	run.TotalInputTokens += int(usage.InputTokens)
+	run.TotalOutputTokens += int(usage.OutputTokens)
+	run.UpdatedAt = end
+	return backend.SaveWorkflowRunPhase(&db.WorkflowRunPhase{
+		WorkflowRunID:   run.ID,
+		PhaseTemplateID: phase,
+		Status:          "completed",
+		Iterations:      1,
+		StartedAt:       &start,
+		CompletedAt:     &end,
+		CommitSHA:       sha,
+		InputTokens:     int(usage.InputTokens),
+		OutputTokens:    int(usage.OutputTokens),
+		CostUSD:         cost,
+		SessionID:       "demo-" + run.ID + "-" + phase,
+	})
+}
+
+// recordPhase writes a whole phase at once, as if it ran from start, and
+// returns when it ended.
+func recordPhase(backend storage.Backend, run *db.WorkflowRun, t *orcv1.Task, phase string, start time.Time) (time.Time, error) {
+	lines := script(phase, t.Title)
+	at := start
+	for i, line := range lines {
+		at = at.Add(40 * time.Second)
+		if err := backend.AddTranscript(transcript(run, t, phase, i, line, at)); err != nil {
+			return at, err
+		}
+	}
+	return at, completePhase(backend, run, t, phase, lines, start, at)
+}
@@ -0,0 +1,201 @@
+package detect
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// MaxFileSummariesBytes bounds the file summaries injected into prompts.
+const MaxFileSummariesBytes = 8 * 1024
+
+// maxParsedFileBytes is the largest file whose declarations are extracted;
+// larger files are summarized by line count only.
+const maxParsedFileBytes = 512 * 1024
+
+// maxSummaryDecls bounds the declarations listed for one file.
+const maxSummaryDecls = 12
+
+// sourceLanguages maps file extensions to the languages summarized.
+var sourceLanguages = map[string]string{
+	".go": "go", ".py": "python", ".rb": "ruby", ".rs": "rust", ".java": "java", ".kt": "kotlin",
+	".js": "javascript", ".jsx": "javascript", ".mjs": "javascript",
+	".ts": "typescript", ".tsx": "typescript",
+	".c": "c", ".h": "c", ".cpp": "cpp", ".hpp": "cpp", ".cs": "csharp", ".swift": "swift", ".php": "php",
+}
+
+// topLevelDecl matches an unindented declaration in the non-Go languages.
+var topLevelDecl = regexp.MustCompile(`^(?:export\s+)?(?:default\s+)?(?:pub(?:\([^)]*\))?\s+)?` +
+	`(?:(?:public|private|internal|abstract|final|static|sealed|data|async)\s+)*` +
+	`(?:def|class|function|interface|type|struct|enum|trait|fn|module|object|impl)\s+([A-Za-z_$][\w$]*)`)
+
+// SourceFile is a tracked source file and the git blob hash of its content.
+type SourceFile struct {
+	Path string
+	Hash string
+}
+
+// SourceFiles lists git-tracked files under root in a summarized language,
+// sorted by path, skipping dependency directories. Hashes come from the git
+// index, except for files with unstaged edits, which are hashed from disk
+// (and dropped when deleted). Returns nil when root is not a git repository.
+func SourceFiles(root string) []SourceFile {
+	out, err := exec.Command("git", "-C", root, "ls-files", "-s", "-z").Output()
+	if err != nil {
+		return nil
+	}
+	modified := make(map[string]bool)
+	if out, err := exec.Command("git", "-C", root, "ls-files", "-m", "-z").Output(); err == nil {
+		for _, path := range strings.Split(string(out), "\x00") {
+			modified[path] = true
+		}
+	}
+
+	var files []SourceFile
+	for _, entry := range strings.Split(string(out), "\x00") {
+		// <mode> <hash> <stage>\t<path>
+		meta, path, ok := strings.Cut(entry, "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) != 3 || fields[0] == "160000" || fields[2] != "0" || SourceLanguage(path) == "" || inSkippedDir(path) {
+			continue
+		}
+		hash := fields[1]
+		if modified[path] {
+			src, err := os.ReadFile(filepath.Join(root, path))
+			if err != nil {
+				continue
+			}
+			hash = BlobHash(src)
+		}
+		files = append(files, SourceFile{Path: path, Hash: hash})
+	}
+	return files
+}
+
+// SourceLanguage returns the language summarized for path, or "" when its
+// extension is not a known source language.
+func SourceLanguage(path string) string {
+	return sourceLanguages[strings.ToLower(filepath.Ext(path))]
+}
+
+// BlobHash returns the git blob hash of content, the key git ls-files
+// reports for a file with that content.
+func BlobHash(content []byte) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "blob %d\x00", len(content))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// SummarizeFile returns a one-line summary of a source file: its language,
+// line count, and top-level declarations. The summary depends only on path's
+// extension and src, so callers can cache it by BlobHash(src).
+func SummarizeFile(path string, src []byte) string {
+	lang := SourceLanguage(path)
+	lines := bytes.Count(src, []byte("\n"))
+	if len(src) > 0 && src[len(src)-1] != '\n' {
+		lines++
+	}
+	summary := fmt.Sprintf("%s, %d lines", lang, lines)
+	if len(src) > maxParsedFileBytes {
+		return summary
+	}
+
+	var decls []string
+	if lang == "go" {
+		decls = goDecls(src)
+	} else {
+		for _, line := range strings.Split(string(src), "\n") {
+			if m := topLevelDecl.FindStringSubmatch(line); m != nil {
+				decls = append(decls, m[1])
+			}
+		}
+	}
+	if len(decls) == 0 {
+		return summary
+	}
+	if len(decls) > maxSummaryDecls {
+		decls = append(decls[:maxSummaryDecls], fmt.Sprintf("+%d more", len(decls)-maxSummaryDecls))
+	}
+	return summary + "; " + strings.Join(decls, ", ")
+}
+
+// goDecls lists the top-level types and functions of a Go file. Methods are
+// written Type.Method. Returns nil when the file does not parse.
+func goDecls(src []byte) []string {
+	f, err := parser.ParseFile(token.NewFileSet(), "", src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil
+	}
+	var decls []string
+	for _, d := range f.Decls {
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			name := d.Name.Name
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				recv := d.Recv.List[0].Type
+				if star, ok := recv.(*ast.StarExpr); ok {
+					recv = star.X
+				}
+				if idx, ok := recv.(*ast.IndexExpr); ok {
+					recv = idx.X
+				}
+				if ident, ok := recv.(*ast.Ident); ok {
+					name = ident.Name + "." + name
+				}
+			}
+			decls = append(decls, name)
+		case *ast.GenDecl:
+			if d.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range d.Specs {
+				decls = append(decls, spec.(*ast.TypeSpec).Name.Name)
+			}
+		}
+	}
+	return decls
+}
+
+// FormatFileSummaries renders one "path: summary" line per file, in order,
+// cut at maxBytes (MaxFileSummariesBytes when <= 0). summaries is keyed by
+// content hash; files without a summary are skipped.
+func FormatFileSummaries(files []SourceFile, summaries map[string]string, maxBytes int) string {
+	if maxBytes <= 0 {
+		maxBytes = MaxFileSummariesBytes
+	}
+	var sb strings.Builder
+	for i, f := range files {
+		summary, ok := summaries[f.Hash]
+		if !ok {
+			continue
+		}
+		line := f.Path + ": " + summary + "\n"
+		if sb.Len()+len(line) > maxBytes {
+			fmt.Fprintf(&sb, "... (%d more files)\n", len(files)-i)
+			break
+		}
+		sb.WriteString(line)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// inSkippedDir reports whether path is inside a dependency or build directory.
+func inSkippedDir(path string) bool {
+	parts := strings.Split(filepath.ToSlash(path), "/")
+	for _, dir := range parts[:len(parts)-1] {
+		if repoMapSkipDirs[dir] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package detect
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSummarizeFile(t *testing.T) {
+	goSrc := `package api
+
+type Server struct{}
+
+type List[T any] []T
+
+func NewServer() *Server { return nil }
+
+func (s *Server) Start() error { return nil }
+
+func (l List[T]) Len() int { return len(l) }
+
+var x = 1
+`
+	if got, want := SummarizeFile("api/server.go", []byte(goSrc)), "go, 13 lines; Server, List, NewServer, Server.Start, List.Len"; got != want {
+		t.Errorf("go summary = %q, want %q", got, want)
+	}
+
+	pySrc := "import os\n\nclass Client:\n    def get(self):\n        pass\n\nasync def main():\n    pass"
+	if got, want := SummarizeFile("client.py", []byte(pySrc)), "python, 8 lines; Client, main"; got != want {
+		t.Errorf("python summary = %q, want %q", got, want)
+	}
+
+	tsSrc := "export default class App {}\nexport interface Props {}\nexport async function load() {}\n"
+	if got, want := SummarizeFile("app.ts", []byte(tsSrc)), "typescript, 3 lines; App, Props, load"; got != want {
+		t.Errorf("typescript summary = %q, want %q", got, want)
+	}
+
+	var many strings.Builder
+	for i := range maxSummaryDecls + 3 {
+		many.WriteString("def f" + string(rune('a'+i)) + "():\n    pass\n")
+	}
+	if got := SummarizeFile("many.py", []byte(many.String())); !strings.HasSuffix(got, ", +3 more") {
+		t.Errorf("long summary = %q", got)
+	}
+	if got := SummarizeFile("broken.go", []byte("package x\nfunc (")); got != "go, 2 lines" {
+		t.Errorf("unparsable go summary = %q", got)
+	}
+}
+
+func TestSourceFiles_BlobHashes(t *testing.T) {
+	dir := t.TempDir()
+	for path, content := range map[string]string{
+		"main.go":                     "package main\n",
+		"README.md":                   "# readme\n",
+		"web/app.ts":                  "export function app() {}\n",
+		"node_modules/pkg/index.js":   "module.exports = 1\n",
+		"internal/api/server_test.go": "package api\n",
+	} {
+		full := filepath.Join(dir, path)
+		_ = os.MkdirAll(filepath.Dir(full), 0755)
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, args := range [][]string{{"init", "-q"}, {"add", "-f", "."}} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	files := SourceFiles(dir)
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path)
+		src, _ := os.ReadFile(filepath.Join(dir, f.Path))
+		if f.Hash != BlobHash(src) {
+			t.Errorf("%s: index hash %s != BlobHash %s", f.Path, f.Hash, BlobHash(src))
+		}
+	}
+	if got, want := strings.Join(paths, ","), "internal/api/server_test.go,main.go,web/app.ts"; got != want {
+		t.Errorf("SourceFiles = %s, want %s", got, want)
+	}
+	if SourceFiles(t.TempDir()) != nil {
+		t.Error("SourceFiles outside a git repository should be nil")
+	}
+}
+
+func TestFormatFileSummaries(t *testing.T) {
+	files := []SourceFile{{Path: "a.go", Hash: "1"}, {Path: "b.go", Hash: "2"}, {Path: "c.go", Hash: "3"}}
+	summaries := map[string]string{"1": "go, 1 lines", "3": "go, 3 lines"}
+
+	if got, want := FormatFileSummaries(files, summaries, 0), "a.go: go, 1 lines\nc.go: go, 3 lines"; got != want {
+		t.Errorf("FormatFileSummaries =\n%s\nwant\n%s", got, want)
+	}
+	if got, want := FormatFileSummaries(files, summaries, 20), "a.go: go, 1 lines\n... (1 more files)"; got != want {
+		t.Errorf("truncated =\n%s\nwant\n%s", got, want)
+	}
+}
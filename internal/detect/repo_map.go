@@ -0,0 +1,121 @@
+// Package detect provides project type and technology detection.
+// This file builds a compact directory overview of a repository for prompts.
+package detect
+
+import (
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MaxRepoMapBytes bounds the repo map injected into prompts.
+const MaxRepoMapBytes = 4 * 1024
+
+// repoMapSkipDirs are never descended into when the tree is walked without git.
+var repoMapSkipDirs = map[string]bool{
+	"node_modules": true, "vendor": true, "dist": true, "build": true, "target": true, "__pycache__": true,
+}
+
+// RepoMap returns a two-level directory overview of root with file counts,
+// followed by top-level files. Tracked files come from git ls-files when root
+// is a git repository, so ignored output never appears; otherwise the tree is
+// walked, skipping hidden and dependency directories. The result is cut at
+// maxBytes (MaxRepoMapBytes when <= 0). Returns "" when no files are found.
+func RepoMap(root string, maxBytes int) string {
+	if maxBytes <= 0 {
+		maxBytes = MaxRepoMapBytes
+	}
+	files := repoFiles(root)
+	if len(files) == 0 {
+		return ""
+	}
+
+	type dirCount struct {
+		total int
+		subs  map[string]int
+	}
+	dirs := make(map[string]*dirCount)
+	var topFiles []string
+	for _, f := range files {
+		parts := strings.Split(filepath.ToSlash(f), "/")
+		if len(parts) == 1 {
+			topFiles = append(topFiles, parts[0])
+			continue
+		}
+		d := dirs[parts[0]]
+		if d == nil {
+			d = &dirCount{subs: make(map[string]int)}
+			dirs[parts[0]] = d
+		}
+		d.total++
+		if len(parts) > 2 {
+			d.subs[parts[1]]++
+		}
+	}
+
+	var sb strings.Builder
+	for _, name := range sortedKeys(dirs) {
+		d := dirs[name]
+		fmt.Fprintf(&sb, "%s/ (%d files)\n", name, d.total)
+		for _, sub := range sortedKeys(d.subs) {
+			fmt.Fprintf(&sb, "  %s/ (%d)\n", sub, d.subs[sub])
+		}
+	}
+	sort.Strings(topFiles)
+	for _, f := range topFiles {
+		sb.WriteString(f + "\n")
+	}
+
+	out := strings.TrimSuffix(sb.String(), "\n")
+	if len(out) > maxBytes {
+		cut := strings.LastIndex(out[:maxBytes], "\n")
+		if cut < 0 {
+			cut = maxBytes
+		}
+		out = out[:cut] + "\n... (truncated)"
+	}
+	return out
+}
+
+// repoFiles lists project-relative file paths under root.
+func repoFiles(root string) []string {
+	if out, err := exec.Command("git", "-C", root, "ls-files", "-z").Output(); err == nil {
+		var files []string
+		for _, f := range strings.Split(string(out), "\x00") {
+			if f != "" {
+				files = append(files, f)
+			}
+		}
+		return files
+	}
+
+	var files []string
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(d.Name(), ".") || repoMapSkipDirs[d.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if rel, relErr := filepath.Rel(root, path); relErr == nil {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	return files
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,34 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRepoMap_WalksWithoutGit(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{
+		"go.mod", "README.md",
+		"internal/api/server.go", "internal/api/routes.go", "internal/cli/root.go", "internal/doc.go",
+		"node_modules/left-pad/index.js", ".git/HEAD", ".orc/config.yaml",
+	} {
+		path := filepath.Join(dir, f)
+		_ = os.MkdirAll(filepath.Dir(path), 0755)
+		_ = os.WriteFile(path, []byte("x"), 0644)
+	}
+
+	got := RepoMap(dir, 0)
+	want := "internal/ (4 files)\n  api/ (2)\n  cli/ (1)\nREADME.md\ngo.mod"
+	if got != want {
+		t.Errorf("RepoMap =\n%s\nwant\n%s", got, want)
+	}
+
+	if truncated := RepoMap(dir, 30); !strings.HasSuffix(truncated, "... (truncated)") || strings.Contains(truncated, "go.mod") {
+		t.Errorf("truncated map = %q", truncated)
+	}
+	if empty := RepoMap(t.TempDir(), 0); empty != "" {
+		t.Errorf("empty dir map = %q", empty)
+	}
+}
@@ -87,6 +87,13 @@ func (s *Service) ResolveRef(ctx context.Context, ref string) string {
 	return ref
 }
 
+// RefExists reports whether ref resolves to a commit.
+func (s *Service) RefExists(ctx context.Context, ref string) bool {
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	cmd.Dir = s.repoPath
+	return cmd.Run() == nil
+}
+
 // ShouldIncludeWorkingTree checks if the diff should include uncommitted changes.
 // This is true when:
 // 1. head branch has not diverged from base (same commit)
@@ -228,12 +235,18 @@ func (s *Service) GetFileList(ctx context.Context, base, head string) ([]FileDif
 	return files, nil
 }
 
+// fileCacheKey keys a file diff by repository as well as refs, so one cache
+// can serve several projects.
+func (s *Service) fileCacheKey(base, head, filePath string) string {
+	return fmt.Sprintf("%s:%s..%s:%s", s.repoPath, base, head, filePath)
+}
+
 // GetFileDiff returns the diff for a single file with hunks.
 // If head is empty, compares base against the working tree (uncommitted changes).
 func (s *Service) GetFileDiff(ctx context.Context, base, head, filePath string) (*FileDiff, error) {
 	// Check cache first (don't cache working tree diffs as they can change)
 	if s.cache != nil && head != "" {
-		cacheKey := fmt.Sprintf("%s..%s:%s", base, head, filePath)
+		cacheKey := s.fileCacheKey(base, head, filePath)
 		if cached := s.cache.Get(cacheKey); cached != nil {
 			return cached, nil
 		}
@@ -269,7 +282,7 @@ func (s *Service) GetFileDiff(ctx context.Context, base, head, filePath string)
 
 	// Cache result if cache available (don't cache working tree diffs)
 	if s.cache != nil && head != "" {
-		cacheKey := fmt.Sprintf("%s..%s:%s", base, head, filePath)
+		cacheKey := s.fileCacheKey(base, head, filePath)
 		s.cache.Set(cacheKey, diff)
 	}
 
@@ -308,6 +321,46 @@ func (s *Service) GetFullDiff(ctx context.Context, base, head string) (*DiffResu
 	}, nil
 }
 
+// GetUnifiedDiff returns the raw unified diff text, limited to paths when
+// any are given. If head is empty, compares base against the working tree
+// (uncommitted changes).
+func (s *Service) GetUnifiedDiff(ctx context.Context, base, head string, paths ...string) (string, error) {
+	args := []string{"diff", "--no-color", "-M"}
+	if head == "" {
+		args = append(args, base)
+	} else {
+		args = append(args, base+"..."+head)
+	}
+	cmd := exec.CommandContext(ctx, "git", append(append(args, "--"), paths...)...)
+	cmd.Dir = s.repoPath
+	output, err := cmd.Output()
+	if err != nil && head != "" {
+		// Try without three-dot notation
+		args = append(args[:len(args)-1], base, head, "--")
+		cmd = exec.CommandContext(ctx, "git", append(args, paths...)...)
+		cmd.Dir = s.repoPath
+		output, err = cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("git diff: %w", err)
+		}
+	} else if err != nil {
+		return "", fmt.Errorf("git diff: %w", err)
+	}
+	return string(output), nil
+}
+
+// MergeBase returns the best common ancestor of a and b, or a if there is
+// none (unrelated histories).
+func (s *Service) MergeBase(ctx context.Context, a, b string) string {
+	cmd := exec.CommandContext(ctx, "git", "merge-base", a, b)
+	cmd.Dir = s.repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return a
+	}
+	return strings.TrimSpace(string(output))
+}
+
 // GetMergeCommitDiff returns the diff for a merge commit showing what changes were merged.
 // Uses the merge commit's first parent to determine the base.
 func (s *Service) GetMergeCommitDiff(ctx context.Context, mergeCommitSHA string) (*DiffResult, error) {
@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -325,6 +326,67 @@ func TestGetFileDiff(t *testing.T) {
 	}
 }
 
+func TestGetUnifiedDiff(t *testing.T) {
+	dir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	for _, args := range [][]string{{"branch", "-M", "main"}, {"checkout", "-b", "feature"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if err := cmd.Run(); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte("modified\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "added.txt"), []byte("new\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	for _, args := range [][]string{{"add", "."}, {"commit", "-m", "feature changes"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if err := cmd.Run(); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	// Uncommitted change, only visible when diffing the working tree
+	if err := os.WriteFile(filepath.Join(dir, "added.txt"), []byte("new\nmore\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	svc := NewService(dir, nil)
+	ctx := context.Background()
+
+	full, err := svc.GetUnifiedDiff(ctx, "main", "feature")
+	if err != nil {
+		t.Fatalf("GetUnifiedDiff failed: %v", err)
+	}
+	if !strings.Contains(full, "diff --git a/added.txt b/added.txt") || !strings.Contains(full, "+modified") {
+		t.Errorf("full diff missing a file:\n%s", full)
+	}
+	if strings.Contains(full, "+more") {
+		t.Error("branch diff should not include uncommitted changes")
+	}
+
+	one, err := svc.GetUnifiedDiff(ctx, "main", "feature", "file.txt")
+	if err != nil {
+		t.Fatalf("GetUnifiedDiff(file.txt) failed: %v", err)
+	}
+	if strings.Contains(one, "added.txt") || !strings.Contains(one, "+modified") {
+		t.Errorf("path-limited diff:\n%s", one)
+	}
+
+	base := svc.MergeBase(ctx, "main", "HEAD")
+	working, err := svc.GetUnifiedDiff(ctx, base, "")
+	if err != nil {
+		t.Fatalf("GetUnifiedDiff(working tree) failed: %v", err)
+	}
+	if !strings.Contains(working, "+more") {
+		t.Errorf("working tree diff missing uncommitted change:\n%s", working)
+	}
+}
+
 func TestDetectSyntax(t *testing.T) {
 	tests := []struct {
 		path     string
@@ -781,3 +843,42 @@ func TestMergeCommitDiff(t *testing.T) {
 		}
 	})
 }
+
+func TestGetFileDiff_SharedCacheAcrossRepos(t *testing.T) {
+	cache := NewCache(10)
+	ctx := context.Background()
+
+	lineAdded := func(content string) string {
+		dir, cleanup := setupTestRepo(t)
+		t.Cleanup(cleanup)
+		if err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		cmd := exec.Command("git", "commit", "-am", "change")
+		cmd.Dir = dir
+		if err := cmd.Run(); err != nil {
+			t.Fatalf("failed to commit: %v", err)
+		}
+
+		diff, err := NewService(dir, cache).GetFileDiff(ctx, "HEAD~1", "HEAD", "file.txt")
+		if err != nil {
+			t.Fatalf("GetFileDiff failed: %v", err)
+		}
+		for _, hunk := range diff.Hunks {
+			for _, line := range hunk.Lines {
+				if line.Type == "addition" {
+					return line.Content
+				}
+			}
+		}
+		return ""
+	}
+
+	if got := lineAdded("first repo\n"); got != "first repo" {
+		t.Errorf("first repo added line = %q", got)
+	}
+	// Same refs and path in another repository must not hit the first entry
+	if got := lineAdded("second repo\n"); got != "second repo" {
+		t.Errorf("second repo added line = %q, want its own diff", got)
+	}
+}
@@ -0,0 +1,118 @@
+package docdrift
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	documentedMakePattern   = regexp.MustCompile(`\bmake\s+([A-Za-z0-9][\w.-]*)`)
+	documentedScriptPattern = regexp.MustCompile(`\b(?:npm|pnpm|yarn|bun)\s+run\s+([\w:.-]+)`)
+	makeTargetPattern       = regexp.MustCompile(`^([A-Za-z0-9][\w.-]*)\s*:([^=]|$)`)
+)
+
+// documentedCommands returns "make <target>" and "npm run <script>" entries
+// mentioned in the section. Every JavaScript package runner is reported as
+// npm so pnpm, yarn, and bun invocations compare equal.
+func documentedCommands(body string) map[string]bool {
+	found := make(map[string]bool)
+	for _, m := range documentedMakePattern.FindAllStringSubmatch(body, -1) {
+		found["make "+m[1]] = true
+	}
+	for _, m := range documentedScriptPattern.FindAllStringSubmatch(body, -1) {
+		found["npm run "+m[1]] = true
+	}
+	return found
+}
+
+// codeCommands collects Makefile targets and package.json scripts from the
+// project root and its immediate subdirectories (e.g. web/package.json).
+func codeCommands(projectRoot string) (map[string]bool, error) {
+	found := make(map[string]bool)
+	dirs := []string{projectRoot}
+	entries, err := os.ReadDir(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("read project root: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") && !skippedDirs[e.Name()] {
+			dirs = append(dirs, filepath.Join(projectRoot, e.Name()))
+		}
+	}
+
+	for _, dir := range dirs {
+		targets, err := makeTargets(filepath.Join(dir, "Makefile"))
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range targets {
+			found["make "+t] = true
+		}
+		scripts, err := packageScripts(filepath.Join(dir, "package.json"))
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range scripts {
+			found["npm run "+s] = true
+		}
+	}
+	return found, nil
+}
+
+func makeTargets(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var targets []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if m := makeTargetPattern.FindStringSubmatch(scanner.Text()); m != nil {
+			targets = append(targets, m[1])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return targets, nil
+}
+
+func packageScripts(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	scripts := make([]string, 0, len(pkg.Scripts))
+	for name := range pkg.Scripts {
+		scripts = append(scripts, name)
+	}
+	return scripts, nil
+}
+
+func checkCommands(projectRoot, body string) ([]string, []string, error) {
+	actual, err := codeCommands(projectRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	stale, undocumented := diff(documentedCommands(body), actual)
+	return stale, undocumented, nil
+}
@@ -0,0 +1,136 @@
+package docdrift
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseSections(t *testing.T) {
+	content := `# Project
+
+<!-- orc:auto:api-endpoints -->
+| GET | /api/users |
+<!-- /orc:auto:api-endpoints -->
+
+<!-- orc:auto:commands -->
+make test
+<!-- /orc:auto:other -->
+`
+	sections := ParseSections(content)
+	if _, ok := sections["api-endpoints"]; !ok {
+		t.Fatalf("api-endpoints section not parsed: %v", sections)
+	}
+	if _, ok := sections["commands"]; ok {
+		t.Error("section with mismatched closing marker should be ignored")
+	}
+}
+
+func TestCheck_APIEndpoints(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ClaudeMDFile), `<!-- orc:auto:api-endpoints -->
+| Method | Path | Description |
+|--------|------|-------------|
+| GET | /api/users | List users |
+| GET | /api/users/:id | Get user |
+| DELETE | /api/legacy | Removed |
+<!-- /orc:auto:api-endpoints -->
+`)
+	writeFile(t, filepath.Join(root, "server", "routes.go"), `package server
+
+func routes() {
+	mux.HandleFunc("GET /api/users", listUsers)
+	mux.HandleFunc("GET /api/users/{id}", getUser)
+	mux.HandleFunc("POST /api/users", createUser)
+}
+`)
+	// Test files and node_modules are not part of the public surface.
+	writeFile(t, filepath.Join(root, "server", "routes_test.go"), `mux.HandleFunc("GET /api/test-only", h)`)
+	writeFile(t, filepath.Join(root, "node_modules", "x", "index.js"), `app.get('/vendor', h)`)
+
+	report, err := Check(root, []string{SectionAPIEndpoints})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if len(report.Sections) != 1 || !report.Sections[0].Present {
+		t.Fatalf("sections = %+v", report.Sections)
+	}
+	s := report.Sections[0]
+	if want := []string{"DELETE /api/legacy"}; !reflect.DeepEqual(s.Stale, want) {
+		t.Errorf("stale = %v, want %v", s.Stale, want)
+	}
+	if want := []string{"POST /api/users"}; !reflect.DeepEqual(s.Undocumented, want) {
+		t.Errorf("undocumented = %v, want %v", s.Undocumented, want)
+	}
+	if report.Score != 2 || !report.Drifted() {
+		t.Errorf("score = %d, drifted = %v", report.Score, report.Drifted())
+	}
+}
+
+func TestCheck_Commands(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ClaudeMDFile), "<!-- orc:auto:commands -->\n"+
+		"- `make test` runs tests\n- `make deploy` deploys\n- `cd web && bun run build`\n"+
+		"<!-- /orc:auto:commands -->\n")
+	writeFile(t, filepath.Join(root, "Makefile"), "VERSION := 1\n.PHONY: test lint\ntest:\n\tgo test ./...\nlint: test\n\tgolangci-lint run\n")
+	writeFile(t, filepath.Join(root, "web", "package.json"), `{"scripts": {"build": "vite build"}}`)
+
+	report, err := Check(root, []string{SectionCommands})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	s := report.Sections[0]
+	if want := []string{"make deploy"}; !reflect.DeepEqual(s.Stale, want) {
+		t.Errorf("stale = %v, want %v", s.Stale, want)
+	}
+	if want := []string{"make lint"}; !reflect.DeepEqual(s.Undocumented, want) {
+		t.Errorf("undocumented = %v, want %v", s.Undocumented, want)
+	}
+}
+
+func TestCheck_AbsentSectionsAndFile(t *testing.T) {
+	root := t.TempDir()
+
+	report, err := Check(root, []string{SectionAPIEndpoints})
+	if err != nil {
+		t.Fatalf("Check without CLAUDE.md: %v", err)
+	}
+	if report.Drifted() || len(report.Sections) != 0 {
+		t.Errorf("missing CLAUDE.md should produce an empty report, got %+v", report)
+	}
+
+	writeFile(t, filepath.Join(root, ClaudeMDFile), "# No managed sections\n")
+	writeFile(t, filepath.Join(root, "main.go"), `mux.HandleFunc("GET /api/x", h)`)
+	report, err = Check(root, []string{SectionAPIEndpoints, "config-options"})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if len(report.Sections) != 1 || report.Sections[0].Present {
+		t.Fatalf("sections = %+v, want one absent api-endpoints section", report.Sections)
+	}
+	if report.Drifted() {
+		t.Error("absent sections must not count as drift")
+	}
+}
+
+func TestReportSummary(t *testing.T) {
+	r := &Report{Score: 3, Sections: []SectionReport{
+		{Name: SectionAPIEndpoints, Present: true, Stale: []string{"GET /a"}, Undocumented: []string{"GET /b", "GET /c"}},
+		{Name: SectionCommands, Present: true},
+	}}
+	want := "CLAUDE.md drift score 3 (api-endpoints: 1 stale, 2 undocumented)"
+	if got := r.Summary(); got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+}
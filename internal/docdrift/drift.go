@@ -0,0 +1,117 @@
+package docdrift
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Section names with a drift checker. Other configured sections (for
+// example config-options) are maintained by the docs phase but not checked.
+const (
+	SectionAPIEndpoints = "api-endpoints"
+	SectionCommands     = "commands"
+)
+
+// MetricName is the automation metric recorded with each report's score, so
+// threshold triggers can react to drift.
+const MetricName = "claudemd_drift"
+
+// SectionReport describes drift in one managed section.
+type SectionReport struct {
+	Name string `json:"name"`
+	// Present is false when the section is configured but CLAUDE.md has no
+	// markers for it; absent sections never count as drift.
+	Present bool `json:"present"`
+	// Stale lists entries documented in the section that no longer exist.
+	Stale []string `json:"stale,omitempty"`
+	// Undocumented lists entries found in the codebase but not in the section.
+	Undocumented []string `json:"undocumented,omitempty"`
+}
+
+// Drift returns the number of mismatched entries in the section.
+func (s *SectionReport) Drift() int {
+	return len(s.Stale) + len(s.Undocumented)
+}
+
+// Report is the result of comparing CLAUDE.md against the codebase.
+type Report struct {
+	File      string          `json:"file"`
+	CheckedAt time.Time       `json:"checked_at"`
+	Sections  []SectionReport `json:"sections"`
+	// Score is the total number of mismatched entries across all sections.
+	Score int `json:"score"`
+}
+
+// Drifted reports whether any checked section is out of date.
+func (r *Report) Drifted() bool {
+	return r.Score > 0
+}
+
+// checker compares a section body against the project and returns the
+// stale and undocumented entries.
+type checker func(projectRoot, body string) (stale, undocumented []string, err error)
+
+var checkers = map[string]checker{
+	SectionAPIEndpoints: checkAPIEndpoints,
+	SectionCommands:     checkCommands,
+}
+
+// Check compares the named managed sections of projectRoot/CLAUDE.md with the
+// current codebase. Sections without a checker are skipped. A missing
+// CLAUDE.md yields an empty report.
+func Check(projectRoot string, sections []string) (*Report, error) {
+	report := &Report{
+		File:      filepath.Join(projectRoot, ClaudeMDFile),
+		CheckedAt: time.Now().UTC(),
+		Sections:  []SectionReport{},
+	}
+
+	data, err := os.ReadFile(report.File)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return report, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", ClaudeMDFile, err)
+	}
+	bodies := ParseSections(string(data))
+
+	for _, name := range sections {
+		check, ok := checkers[name]
+		if !ok {
+			continue
+		}
+		section := SectionReport{Name: name}
+		body, present := bodies[name]
+		if present {
+			section.Present = true
+			section.Stale, section.Undocumented, err = check(projectRoot, body)
+			if err != nil {
+				return nil, fmt.Errorf("check section %s: %w", name, err)
+			}
+		}
+		report.Score += section.Drift()
+		report.Sections = append(report.Sections, section)
+	}
+	return report, nil
+}
+
+// diff returns the keys only in documented (stale) and only in actual
+// (undocumented), both sorted.
+func diff(documented, actual map[string]bool) (stale, undocumented []string) {
+	for k := range documented {
+		if !actual[k] {
+			stale = append(stale, k)
+		}
+	}
+	for k := range actual {
+		if !documented[k] {
+			undocumented = append(undocumented, k)
+		}
+	}
+	sort.Strings(stale)
+	sort.Strings(undocumented)
+	return stale, undocumented
+}
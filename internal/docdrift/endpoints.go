@@ -0,0 +1,101 @@
+package docdrift
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// documentedEndpointPattern matches "GET /api/x" in prose and "| GET | /api/x |"
+// in tables.
+var documentedEndpointPattern = regexp.MustCompile("\\b(GET|POST|PUT|PATCH|DELETE)\\b[\\s|`]*(/[^\\s|`)\"',]*)")
+
+// Route registrations recognised in source files:
+//   - Go 1.22 ServeMux patterns: mux.HandleFunc("GET /api/x", ...)
+//   - router methods: r.Get("/x", ...), app.post('/x', ...), @app.get("/x")
+var (
+	muxPatternRoute = regexp.MustCompile(`"(GET|POST|PUT|PATCH|DELETE) (/[^"\s]*)"`)
+	methodCallRoute = regexp.MustCompile("\\.(?i:(get|post|put|patch|delete))\\(\\s*[\"'`](/[^\"'`\\s]*)[\"'`]")
+)
+
+// routeSourceExts are the file types scanned for route registrations.
+var routeSourceExts = map[string]bool{".go": true, ".ts": true, ".js": true, ".py": true}
+
+// skippedDirs are never scanned for routes.
+var skippedDirs = map[string]bool{"node_modules": true, "vendor": true, "dist": true, "build": true, "testdata": true}
+
+var (
+	braceParam = regexp.MustCompile(`\{[^}/]*\}`)
+	colonParam = regexp.MustCompile(`:[A-Za-z_][\w]*`)
+	angleParam = regexp.MustCompile(`<[^>/]*>`)
+)
+
+// normalizeEndpoint makes equivalent routes compare equal regardless of how
+// path parameters are spelled ({id}, :id, <id>) or trailing slashes.
+func normalizeEndpoint(method, path string) string {
+	path = braceParam.ReplaceAllString(path, "{}")
+	path = colonParam.ReplaceAllString(path, "{}")
+	path = angleParam.ReplaceAllString(path, "{}")
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return strings.ToUpper(method) + " " + path
+}
+
+func documentedEndpoints(body string) map[string]bool {
+	found := make(map[string]bool)
+	for _, m := range documentedEndpointPattern.FindAllStringSubmatch(body, -1) {
+		found[normalizeEndpoint(m[1], m[2])] = true
+	}
+	return found
+}
+
+// codeEndpoints scans source files under projectRoot for route registrations.
+func codeEndpoints(projectRoot string) (map[string]bool, error) {
+	found := make(map[string]bool)
+	err := filepath.WalkDir(projectRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != projectRoot && (strings.HasPrefix(name, ".") || skippedDirs[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !routeSourceExts[filepath.Ext(name)] || isTestFile(name) {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, m := range muxPatternRoute.FindAllStringSubmatch(string(data), -1) {
+			found[normalizeEndpoint(m[1], m[2])] = true
+		}
+		for _, m := range methodCallRoute.FindAllStringSubmatch(string(data), -1) {
+			found[normalizeEndpoint(m[1], m[2])] = true
+		}
+		return nil
+	})
+	return found, err
+}
+
+func isTestFile(name string) bool {
+	return strings.HasSuffix(name, "_test.go") ||
+		strings.Contains(name, ".test.") ||
+		strings.Contains(name, ".spec.") ||
+		strings.HasPrefix(name, "test_")
+}
+
+func checkAPIEndpoints(projectRoot, body string) ([]string, []string, error) {
+	actual, err := codeEndpoints(projectRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	stale, undocumented := diff(documentedEndpoints(body), actual)
+	return stale, undocumented, nil
+}
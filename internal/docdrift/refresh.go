@@ -0,0 +1,39 @@
+package docdrift
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/randalmurphal/orc/internal/config"
+)
+
+// TriggerID identifies drift refresh tasks in automation task metadata.
+const TriggerID = "claudemd-drift"
+
+// RefreshTemplate is the automation template used for refresh tasks when
+// documentation.drift_check.template does not name a configured one.
+func RefreshTemplate() *config.AutomationTemplateConfig {
+	return &config.AutomationTemplateConfig{
+		Title: "Refresh managed CLAUDE.md sections",
+		Description: "Regenerate the <!-- orc:auto:* --> sections of CLAUDE.md from the current code. " +
+			"Only edit content between the auto-section markers.",
+		Weight:   "small",
+		Category: "docs",
+	}
+}
+
+// Summary describes the drift in one line per drifted section, suitable as
+// an automation task reason.
+func (r *Report) Summary() string {
+	var parts []string
+	for _, s := range r.Sections {
+		if s.Drift() == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s: %d stale, %d undocumented", s.Name, len(s.Stale), len(s.Undocumented)))
+	}
+	if len(parts) == 0 {
+		return "no drift"
+	}
+	return fmt.Sprintf("CLAUDE.md drift score %d (%s)", r.Score, strings.Join(parts, "; "))
+}
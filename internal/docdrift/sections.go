@@ -0,0 +1,33 @@
+// Package docdrift detects when managed CLAUDE.md sections no longer match
+// the codebase they describe.
+//
+// Managed sections are wrapped in auto-section markers maintained by the docs
+// phase:
+//
+//	<!-- orc:auto:api-endpoints -->
+//	| GET | /api/users | List users |
+//	<!-- /orc:auto:api-endpoints -->
+package docdrift
+
+import (
+	"regexp"
+)
+
+// ClaudeMDFile is the project-relative file that holds managed sections.
+const ClaudeMDFile = "CLAUDE.md"
+
+var sectionPattern = regexp.MustCompile(`(?s)<!--\s*orc:auto:([\w-]+)\s*-->(.*?)<!--\s*/orc:auto:([\w-]+)\s*-->`)
+
+// ParseSections returns the body of every well-formed auto-section keyed by
+// section name. A section whose closing marker names a different section is
+// ignored.
+func ParseSections(content string) map[string]string {
+	sections := make(map[string]string)
+	for _, m := range sectionPattern.FindAllStringSubmatch(content, -1) {
+		if m[1] != m[3] {
+			continue
+		}
+		sections[m[1]] = m[2]
+	}
+	return sections
+}
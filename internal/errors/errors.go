@@ -3,8 +3,9 @@ package errors
 
 import (
 	"encoding/json"
-	"fmt"
 	"strings"
+
+	"github.com/randalmurphal/orc/internal/i18n"
 )
 
 // Code represents a unique error code.
@@ -32,8 +33,13 @@ const (
 	CodeConfigMissing Code = "CONFIG_MISSING"
 
 	// Git errors
-	CodeGitDirty        Code = "GIT_DIRTY"
-	CodeGitBranchExists Code = "GIT_BRANCH_EXISTS"
+	CodeGitDirty             Code = "GIT_DIRTY"
+	CodeGitBranchExists      Code = "GIT_BRANCH_EXISTS"
+	CodeGitRemoteUnreachable Code = "GIT_REMOTE_UNREACHABLE"
+	CodeGitDiverged          Code = "GIT_DIVERGED"
+
+	// Environment errors
+	CodeDiskSpaceLow Code = "DISK_SPACE_LOW"
 )
 
 // Category groups error codes for HTTP status mapping.
@@ -51,19 +57,22 @@ const (
 
 // codeCategories maps error codes to their categories.
 var codeCategories = map[Code]Category{
-	CodeNotInitialized:     CategoryBadRequest,
-	CodeAlreadyInitialized: CategoryConflict,
-	CodeTaskNotFound:       CategoryNotFound,
-	CodeTaskInvalidState:   CategoryBadRequest,
-	CodeTaskRunning:        CategoryConflict,
-	CodeClaudeUnavailable:  CategoryUnavailable,
-	CodeClaudeTimeout:      CategoryTimeout,
-	CodePhaseStuck:         CategoryInternal,
-	CodeMaxRetries:         CategoryInternal,
-	CodeConfigInvalid:      CategoryBadRequest,
-	CodeConfigMissing:      CategoryBadRequest,
-	CodeGitDirty:           CategoryBadRequest,
-	CodeGitBranchExists:    CategoryConflict,
+	CodeNotInitialized:       CategoryBadRequest,
+	CodeAlreadyInitialized:   CategoryConflict,
+	CodeTaskNotFound:         CategoryNotFound,
+	CodeTaskInvalidState:     CategoryBadRequest,
+	CodeTaskRunning:          CategoryConflict,
+	CodeClaudeUnavailable:    CategoryUnavailable,
+	CodeClaudeTimeout:        CategoryTimeout,
+	CodePhaseStuck:           CategoryInternal,
+	CodeMaxRetries:           CategoryInternal,
+	CodeConfigInvalid:        CategoryBadRequest,
+	CodeConfigMissing:        CategoryBadRequest,
+	CodeGitDirty:             CategoryBadRequest,
+	CodeGitBranchExists:      CategoryConflict,
+	CodeGitRemoteUnreachable: CategoryUnavailable,
+	CodeGitDiverged:          CategoryConflict,
+	CodeDiskSpaceLow:         CategoryUnavailable,
 }
 
 // HTTPStatus returns the HTTP status code for a category.
@@ -92,6 +101,40 @@ type OrcError struct {
 	Fix     string `json:"fix,omitempty"`
 	DocsURL string `json:"docs_url,omitempty"`
 	Cause   error  `json:"-"`
+
+	// key and args name the catalog messages What, Why and Fix were rendered
+	// from, so Localize can render them again in another locale.
+	key    string
+	args   []any
+	locale string
+}
+
+// newError builds an error whose What, Why and Fix come from the
+// errors.<key>.what/why/fix catalog messages in the process locale.
+func newError(code Code, key, docsURL string, args ...any) *OrcError {
+	e := &OrcError{Code: code, DocsURL: docsURL, key: key, args: args}
+	e.render(i18n.Locale())
+	return e
+}
+
+func (e *OrcError) render(locale string) {
+	prefix := "errors." + e.key + "."
+	e.What = i18n.Translate(locale, prefix+"what", e.args...)
+	e.Why = i18n.Translate(locale, prefix+"why", e.args...)
+	e.Fix = i18n.Translate(locale, prefix+"fix", e.args...)
+	e.locale = locale
+}
+
+// Localize returns a copy of the error with its messages in the given
+// locale. Errors not built by a constructor in this package, such as those
+// from Wrap, are returned unchanged.
+func (e *OrcError) Localize(locale string) *OrcError {
+	if e.key == "" {
+		return e
+	}
+	localized := *e
+	localized.render(locale)
+	return &localized
 }
 
 // Error implements the error interface.
@@ -116,19 +159,27 @@ func (e *OrcError) Unwrap() error {
 
 // UserMessage returns a user-friendly message for CLI output.
 func (e *OrcError) UserMessage() string {
+	locale := e.locale
+	if locale == "" {
+		locale = i18n.Locale()
+	}
+	label := func(name string) string {
+		return i18n.Translate(locale, "errors.label."+name)
+	}
+
 	var b strings.Builder
-	b.WriteString("Error: ")
+	b.WriteString(label("error") + ": ")
 	b.WriteString(e.What)
 	if e.Why != "" {
-		b.WriteString("\n\nWhy: ")
+		b.WriteString("\n\n" + label("why") + ": ")
 		b.WriteString(e.Why)
 	}
 	if e.Fix != "" {
-		b.WriteString("\n\nFix: ")
+		b.WriteString("\n\n" + label("fix") + ": ")
 		b.WriteString(e.Fix)
 	}
 	if e.DocsURL != "" {
-		b.WriteString("\n\nDocs: ")
+		b.WriteString("\n\n" + label("docs") + ": ")
 		b.WriteString(e.DocsURL)
 	}
 	return b.String()
@@ -173,159 +224,98 @@ func (e *OrcError) Is(target error) bool {
 
 // WithCause returns a copy of the error with the given cause.
 func (e *OrcError) WithCause(err error) *OrcError {
-	return &OrcError{
-		Code:    e.Code,
-		What:    e.What,
-		Why:     e.Why,
-		Fix:     e.Fix,
-		DocsURL: e.DocsURL,
-		Cause:   err,
-	}
+	withCause := *e
+	withCause.Cause = err
+	return &withCause
 }
 
 // --- Error constructors ---
 
 // ErrNotInitialized returns an error for uninitialized orc directory.
 func ErrNotInitialized() *OrcError {
-	return &OrcError{
-		Code:    CodeNotInitialized,
-		What:    "orc is not initialized in this directory",
-		Why:     "No .orc/ directory found in the current path or its parents",
-		Fix:     "Run 'orc init' to initialize orc in this directory",
-		DocsURL: "https://github.com/randalmurphal/orc#quick-start",
-	}
+	return newError(CodeNotInitialized, "not_initialized", "https://github.com/randalmurphal/orc#quick-start")
 }
 
 // ErrAlreadyInitialized returns an error when orc is already initialized.
 func ErrAlreadyInitialized(path string) *OrcError {
-	return &OrcError{
-		Code:    CodeAlreadyInitialized,
-		What:    "orc is already initialized",
-		Why:     fmt.Sprintf("Found existing .orc/ directory at %s", path),
-		Fix:     "Use 'orc init --force' to reinitialize, or remove .orc/ manually",
-		DocsURL: "https://github.com/randalmurphal/orc#initialization",
-	}
+	return newError(CodeAlreadyInitialized, "already_initialized", "https://github.com/randalmurphal/orc#initialization", path)
 }
 
 // ErrTaskNotFound returns an error when a task doesn't exist.
 func ErrTaskNotFound(id string) *OrcError {
-	return &OrcError{
-		Code:    CodeTaskNotFound,
-		What:    fmt.Sprintf("task %s not found", id),
-		Why:     "No task with this ID exists in the current project",
-		Fix:     "Run 'orc status' to list available tasks, or create one with 'orc new'",
-		DocsURL: "https://github.com/randalmurphal/orc#tasks",
-	}
+	return newError(CodeTaskNotFound, "task_not_found", "https://github.com/randalmurphal/orc#tasks", id)
 }
 
 // ErrTaskInvalidState returns an error when a task is in an invalid state.
 func ErrTaskInvalidState(id, current, expected string) *OrcError {
-	return &OrcError{
-		Code:    CodeTaskInvalidState,
-		What:    fmt.Sprintf("task %s is in state '%s', expected '%s'", id, current, expected),
-		Why:     "The requested operation cannot be performed in the current task state",
-		Fix:     fmt.Sprintf("Task must be in '%s' state. Check 'orc status %s' for current state", expected, id),
-		DocsURL: "https://github.com/randalmurphal/orc#task-states",
-	}
+	return newError(CodeTaskInvalidState, "task_invalid_state", "https://github.com/randalmurphal/orc#task-states", id, current, expected)
 }
 
 // ErrTaskRunning returns an error when a task is already running.
 func ErrTaskRunning(id string) *OrcError {
-	return &OrcError{
-		Code:    CodeTaskRunning,
-		What:    fmt.Sprintf("task %s is already running", id),
-		Why:     "Cannot start a task that is already in progress",
-		Fix:     fmt.Sprintf("Use 'orc pause %s' to pause, or wait for completion", id),
-		DocsURL: "https://github.com/randalmurphal/orc#task-states",
-	}
+	return newError(CodeTaskRunning, "task_running", "https://github.com/randalmurphal/orc#task-states", id)
 }
 
 // ErrClaudeUnavailable returns an error when Claude CLI is not accessible.
 func ErrClaudeUnavailable() *OrcError {
-	return &OrcError{
-		Code:    CodeClaudeUnavailable,
-		What:    "Claude CLI is not available",
-		Why:     "Could not find or execute the 'claude' command",
-		Fix:     "Install Claude Code CLI: https://claude.ai/claude-code",
-		DocsURL: "https://github.com/randalmurphal/orc#requirements",
-	}
+	return newError(CodeClaudeUnavailable, "claude_unavailable", "https://github.com/randalmurphal/orc#requirements")
 }
 
 // ErrClaudeTimeout returns an error when Claude times out.
 func ErrClaudeTimeout(phase string, duration string) *OrcError {
-	return &OrcError{
-		Code:    CodeClaudeTimeout,
-		What:    fmt.Sprintf("Claude timed out during %s phase", phase),
-		Why:     fmt.Sprintf("No response received after %s", duration),
-		Fix:     "Increase timeout in config, or check Claude's status. Resume with 'orc resume'",
-		DocsURL: "https://github.com/randalmurphal/orc#timeouts",
-	}
+	return newError(CodeClaudeTimeout, "claude_timeout", "https://github.com/randalmurphal/orc#timeouts", phase, duration)
 }
 
 // ErrPhaseStuck returns an error when a phase is stuck.
 func ErrPhaseStuck(phase, reason string) *OrcError {
-	return &OrcError{
-		Code:    CodePhaseStuck,
-		What:    fmt.Sprintf("phase %s is stuck", phase),
-		Why:     reason,
-		Fix:     "Review the phase transcript with 'orc logs', then either fix and resume or rewind",
-		DocsURL: "https://github.com/randalmurphal/orc#troubleshooting",
-	}
+	return newError(CodePhaseStuck, "phase_stuck", "https://github.com/randalmurphal/orc#troubleshooting", phase, reason)
 }
 
 // ErrMaxRetries returns an error when max retries are exceeded.
 func ErrMaxRetries(phase string, attempts int) *OrcError {
-	return &OrcError{
-		Code:    CodeMaxRetries,
-		What:    fmt.Sprintf("phase %s failed after %d attempts", phase, attempts),
-		Why:     "Maximum retry attempts exceeded without successful completion",
-		Fix:     "Review transcripts with 'orc logs', fix issues manually, then rewind and retry",
-		DocsURL: "https://github.com/randalmurphal/orc#retries",
-	}
+	return newError(CodeMaxRetries, "max_retries", "https://github.com/randalmurphal/orc#retries", phase, attempts)
 }
 
 // ErrConfigInvalid returns an error for invalid configuration.
 func ErrConfigInvalid(field, reason string) *OrcError {
-	return &OrcError{
-		Code:    CodeConfigInvalid,
-		What:    fmt.Sprintf("invalid configuration: %s", field),
-		Why:     reason,
-		Fix:     "Check .orc/config.yaml and fix the invalid field",
-		DocsURL: "https://github.com/randalmurphal/orc#configuration",
-	}
+	return newError(CodeConfigInvalid, "config_invalid", "https://github.com/randalmurphal/orc#configuration", field, reason)
 }
 
 // ErrConfigMissing returns an error for missing configuration.
 func ErrConfigMissing(field string) *OrcError {
-	return &OrcError{
-		Code:    CodeConfigMissing,
-		What:    fmt.Sprintf("missing required configuration: %s", field),
-		Why:     "This field is required but not set in configuration",
-		Fix:     fmt.Sprintf("Add '%s' to .orc/config.yaml", field),
-		DocsURL: "https://github.com/randalmurphal/orc#configuration",
-	}
+	return newError(CodeConfigMissing, "config_missing", "https://github.com/randalmurphal/orc#configuration", field)
 }
 
 // ErrGitDirty returns an error when working directory has uncommitted changes.
 func ErrGitDirty() *OrcError {
-	return &OrcError{
-		Code:    CodeGitDirty,
-		What:    "working directory has uncommitted changes",
-		Why:     "Cannot start task execution with uncommitted changes",
-		Fix:     "Commit or stash your changes before running a task",
-		DocsURL: "https://github.com/randalmurphal/orc#git-integration",
-	}
+	return newError(CodeGitDirty, "git_dirty", "https://github.com/randalmurphal/orc#git-integration")
 }
 
 // ErrGitBranchExists returns an error when branch already exists.
 func ErrGitBranchExists(branch string) *OrcError {
-	return &OrcError{
-		Code:    CodeGitBranchExists,
-		What:    fmt.Sprintf("branch '%s' already exists", branch),
-		Why:     "Cannot create task branch because it already exists",
-		Fix:     fmt.Sprintf("Delete the existing branch with 'git branch -d %s' or use a different task name", branch),
-		DocsURL: "https://github.com/randalmurphal/orc#git-integration",
-	}
+	return newError(CodeGitBranchExists, "git_branch_exists", "https://github.com/randalmurphal/orc#git-integration", branch)
+}
+
+// ErrGitRepoNotClean returns an error when the main checkout is in a state
+// that would break task setup, such as an unfinished merge.
+func ErrGitRepoNotClean(reason, fix string) *OrcError {
+	return newError(CodeGitDirty, "git_repo_not_clean", "https://github.com/randalmurphal/orc#git-integration", reason, fix)
+}
+
+// ErrGitRemoteUnreachable returns an error when a git remote cannot be reached.
+func ErrGitRemoteUnreachable(remote string) *OrcError {
+	return newError(CodeGitRemoteUnreachable, "git_remote_unreachable", "https://github.com/randalmurphal/orc#git-integration", remote)
+}
+
+// ErrGitDiverged returns an error when a local branch and its remote
+// counterpart both have commits the other lacks.
+func ErrGitDiverged(branch, remote string, ahead, behind int) *OrcError {
+	return newError(CodeGitDiverged, "git_diverged", "https://github.com/randalmurphal/orc#git-integration", branch, remote, ahead, behind)
+}
+
+// ErrDiskSpaceLow returns an error when free disk space is below the required minimum.
+func ErrDiskSpaceLow(path string, freeMB, requiredMB uint64) *OrcError {
+	return newError(CodeDiskSpaceLow, "disk_space_low", "https://github.com/randalmurphal/orc#git-integration", path, freeMB, requiredMB)
 }
 
 // AsOrcError attempts to convert an error to an OrcError.
@@ -3,6 +3,7 @@ package errors
 import (
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -218,6 +219,17 @@ func TestErrGitBranchExistsError(t *testing.T) {
 	}
 }
 
+func TestErrGitRepoNotCleanError(t *testing.T) {
+	err := ErrGitRepoNotClean("A merge is in progress", "Run git merge --abort")
+
+	if err.Code != CodeGitDirty {
+		t.Errorf("Code = %v, want %v", err.Code, CodeGitDirty)
+	}
+	if err.Fix != "Run git merge --abort" {
+		t.Errorf("Fix = %q", err.Fix)
+	}
+}
+
 func TestErrorCodeUniqueness(t *testing.T) {
 	codes := []Code{
 		CodeNotInitialized,
@@ -233,6 +245,9 @@ func TestErrorCodeUniqueness(t *testing.T) {
 		CodeConfigMissing,
 		CodeGitDirty,
 		CodeGitBranchExists,
+		CodeGitRemoteUnreachable,
+		CodeGitDiverged,
+		CodeDiskSpaceLow,
 	}
 
 	seen := make(map[Code]bool)
@@ -262,6 +277,9 @@ func TestHTTPStatus(t *testing.T) {
 		{ErrConfigMissing("x"), 400},
 		{ErrGitDirty(), 400},
 		{ErrGitBranchExists("x"), 409},
+		{ErrGitRemoteUnreachable("origin"), 503},
+		{ErrGitDiverged("main", "origin", 1, 2), 409},
+		{ErrDiskSpaceLow("/tmp", 10, 1024), 503},
 	}
 
 	for _, tt := range tests {
@@ -362,3 +380,42 @@ func TestWrap(t *testing.T) {
 		t.Errorf("Code = %v, want UNKNOWN", err.Code)
 	}
 }
+
+func TestLocalize(t *testing.T) {
+	cause := errors.New("underlying")
+	err := ErrTaskRunning("TASK-001").WithCause(cause)
+
+	de := err.Localize("de-DE")
+	if de.What != "Aufgabe TASK-001 läuft bereits" {
+		t.Errorf("What = %q, want German message", de.What)
+	}
+	if de.Fix != "Mit 'orc pause TASK-001' pausieren oder auf den Abschluss warten" {
+		t.Errorf("Fix = %q, want German message", de.Fix)
+	}
+	if de.Code != CodeTaskRunning || de.DocsURL != err.DocsURL || de.Cause != cause {
+		t.Error("Localize should keep code, docs URL and cause")
+	}
+	if !strings.HasPrefix(de.UserMessage(), "Fehler: Aufgabe TASK-001") {
+		t.Errorf("UserMessage() = %q, want German labels", de.UserMessage())
+	}
+	if err.What != "task TASK-001 is already running" {
+		t.Errorf("original What = %q, should be unchanged", err.What)
+	}
+}
+
+func TestLocalize_CallerTextUntranslated(t *testing.T) {
+	err := ErrPhaseStuck("implement", "no progress for 10m").Localize("de")
+	if err.What != "Phase implement hängt fest" {
+		t.Errorf("What = %q, want German message", err.What)
+	}
+	if err.Why != "no progress for 10m" {
+		t.Errorf("Why = %q, want caller-supplied reason", err.Why)
+	}
+}
+
+func TestLocalize_Unknown(t *testing.T) {
+	err := Wrap(errors.New("underlying"), "operation failed")
+	if got := err.Localize("de"); got != err {
+		t.Error("Localize should return errors without catalog messages unchanged")
+	}
+}
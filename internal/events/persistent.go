@@ -94,6 +94,13 @@ func (p *PersistentPublisher) Publish(event Event) {
 	}
 }
 
+// PublishLocal delivers an event to subscribers without persisting it. It is
+// used for events that another process already persisted, such as events
+// relayed from a peer server instance.
+func (p *PersistentPublisher) PublishLocal(event Event) {
+	p.inner.Publish(event)
+}
+
 // Subscribe returns a channel that receives events for the given task.
 func (p *PersistentPublisher) Subscribe(taskID string) <-chan Event {
 	return p.inner.Subscribe(taskID)
@@ -104,6 +111,11 @@ func (p *PersistentPublisher) Unsubscribe(taskID string, ch <-chan Event) {
 	p.inner.Unsubscribe(taskID, ch)
 }
 
+// Stats returns the delivery backlog and drop counts of the subscribers.
+func (p *PersistentPublisher) Stats() PublisherStats {
+	return p.inner.Stats()
+}
+
 // Close shuts down the publisher, flushes remaining events, and releases resources.
 // Close is idempotent and safe to call multiple times.
 func (p *PersistentPublisher) Close() {
@@ -170,6 +182,9 @@ func (p *PersistentPublisher) eventToLog(e Event) *db.EventLog {
 	switch e.Type {
 	case EventThreadMessage, EventThreadTyping, EventThreadStatus, EventThreadUpdated:
 		return nil
+	// Script runs keep their own record in script_runs.
+	case EventScriptOutput, EventScriptFinished:
+		return nil
 	case EventRecommendationCreated, EventRecommendationDecided:
 		if e.TaskID == "" {
 			return nil
@@ -158,6 +158,29 @@ func TestPersistentPublisher_SkipsThreadEvents(t *testing.T) {
 	}
 }
 
+func TestPersistentPublisher_SkipsScriptEvents(t *testing.T) {
+	backend, err := storage.NewInMemoryBackend()
+	if err != nil {
+		t.Fatalf("failed to create backend: %v", err)
+	}
+	defer func() { _ = backend.Close() }()
+
+	pub := NewPersistentPublisher(backend, "test", slog.Default())
+	defer pub.Close()
+
+	pub.Publish(NewEvent(EventScriptOutput, "SR-001", ScriptOutputLine{RunID: "SR-001", Script: "vacuum", Stream: "stdout", Line: "ok"}))
+	pub.Publish(NewEvent(EventScriptFinished, "SR-001", ScriptRunFinished{RunID: "SR-001", Script: "vacuum", Status: "succeeded"}))
+	pub.flush()
+
+	results, err := backend.QueryEvents(db.QueryEventsOptions{TaskID: "SR-001", Limit: 10})
+	if err != nil {
+		t.Fatalf("failed to query events: %v", err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("expected script events to skip persistence, got %d rows", len(results))
+	}
+}
+
 func TestPersistentPublisher_SkipsThreadOnlyRecommendationEvents(t *testing.T) {
 	backend, err := storage.NewInMemoryBackend()
 	if err != nil {
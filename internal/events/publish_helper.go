@@ -90,6 +90,15 @@ func (ep *PublishHelper) TranscriptWithUsage(taskID, phase string, iteration int
 	}))
 }
 
+// TranscriptMessage publishes a stored transcript message. The line's
+// MessageUUID is the cursor clients resume from after reconnecting.
+func (ep *PublishHelper) TranscriptMessage(taskID string, line TranscriptLine) {
+	if line.Timestamp.IsZero() {
+		line.Timestamp = time.Now()
+	}
+	ep.Publish(NewEvent(EventTranscript, taskID, line))
+}
+
 // TranscriptChunk publishes a streaming transcript chunk event.
 // Database persistence is handled separately via JSONL sync.
 func (ep *PublishHelper) TranscriptChunk(taskID, phase string, iteration int, chunk string) {
@@ -157,6 +166,18 @@ func (ep *PublishHelper) Warning(taskID, phase, message string) {
 	}))
 }
 
+// BudgetExceeded publishes a budget_exceeded event for a task paused by a
+// cost budget.
+func (ep *PublishHelper) BudgetExceeded(taskID string, data BudgetExceededData) {
+	ep.Publish(NewEvent(EventBudgetExceeded, taskID, data))
+}
+
+// AccountRotated publishes an account_rotated event when a phase moves to
+// another token pool account.
+func (ep *PublishHelper) AccountRotated(taskID string, data AccountRotatedData) {
+	ep.Publish(NewEvent(EventAccountRotated, taskID, data))
+}
+
 // Session publishes a session update event with aggregate metrics.
 // Session events use an empty task ID as they represent session-level state.
 func (ep *PublishHelper) Session(update SessionUpdate) {
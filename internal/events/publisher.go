@@ -1,6 +1,8 @@
 package events
 
 import (
+	"maps"
+	"slices"
 	"sync"
 )
 
@@ -22,11 +24,59 @@ type Publisher interface {
 }
 
 // MemoryPublisher is an in-memory implementation of Publisher.
+//
+// Each subscriber has a buffered channel. When a subscriber falls behind and
+// its channel is full, events wait in a per-subscriber queue that a
+// goroutine delivers in batches as the subscriber catches up, so Publish
+// never blocks. Only when that queue is full too is an event dropped:
+// high-volume lossy events (transcript lines, heartbeats, ...) first, so
+// slow clients keep phase transitions and status changes. Stats reports
+// queue depth and drops.
 type MemoryPublisher struct {
-	subscribers map[string][]chan Event
+	subscribers map[string][]*subscription
 	mu          sync.RWMutex
 	bufferSize  int
+	queueSize   int
 	closed      bool
+
+	statsMu       sync.Mutex
+	published     uint64
+	dropped       uint64
+	droppedByType map[EventType]uint64
+	maxQueued     int
+}
+
+// subscription is one subscriber's channel and its overflow queue.
+type subscription struct {
+	ch       chan Event
+	mu       sync.Mutex
+	queue    []Event
+	flushing bool
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// PublisherStats is a snapshot of a publisher's delivery backlog and drops.
+type PublisherStats struct {
+	Subscribers     int                  `json:"subscribers"`
+	Published       uint64               `json:"published"`
+	Queued          int                  `json:"queued"`           // events waiting in overflow queues now
+	SlowSubscribers int                  `json:"slow_subscribers"` // subscribers with queued events
+	MaxQueued       int                  `json:"max_queued"`       // deepest single queue seen
+	Dropped         uint64               `json:"dropped"`
+	DroppedByType   map[EventType]uint64 `json:"dropped_by_type,omitempty"`
+}
+
+// lossyEvents are dropped first when a subscriber's queue is full: each is
+// superseded by the next one of its kind or only adds detail.
+var lossyEvents = map[EventType]bool{
+	EventTranscript:    true,
+	EventActivity:      true,
+	EventHeartbeat:     true,
+	EventTokens:        true,
+	EventSessionUpdate: true,
+	EventThreadTyping:  true,
+	EventScriptOutput:  true,
 }
 
 // PublisherOption configures a MemoryPublisher.
@@ -39,11 +89,21 @@ func WithBufferSize(size int) PublisherOption {
 	}
 }
 
+// WithQueueSize sets how many events may wait for a slow subscriber beyond
+// its channel buffer before events are dropped.
+func WithQueueSize(size int) PublisherOption {
+	return func(p *MemoryPublisher) {
+		p.queueSize = size
+	}
+}
+
 // NewMemoryPublisher creates a new in-memory publisher.
 func NewMemoryPublisher(opts ...PublisherOption) *MemoryPublisher {
 	p := &MemoryPublisher{
-		subscribers: make(map[string][]chan Event),
-		bufferSize:  100, // Default buffer size
+		subscribers:   make(map[string][]*subscription),
+		bufferSize:    100,  // Default buffer size
+		queueSize:     1000, // Default overflow queue size
+		droppedByType: make(map[EventType]uint64),
 	}
 	for _, opt := range opts {
 		opt(p)
@@ -53,7 +113,7 @@ func NewMemoryPublisher(opts ...PublisherOption) *MemoryPublisher {
 
 // Publish sends an event to all subscribers of the task.
 // Also sends to global subscribers (those subscribed to GlobalTaskID).
-// Non-blocking: skips subscribers with full buffers.
+// Non-blocking: events for slow subscribers are queued (see MemoryPublisher).
 func (p *MemoryPublisher) Publish(event Event) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
@@ -61,30 +121,102 @@ func (p *MemoryPublisher) Publish(event Event) {
 	if p.closed {
 		return
 	}
+	p.statsMu.Lock()
+	p.published++
+	p.statsMu.Unlock()
 
 	// Send to task-specific subscribers
-	subs := p.subscribers[event.TaskID]
-	for _, ch := range subs {
+	for _, sub := range p.subscribers[event.TaskID] {
+		p.deliver(sub, event)
+	}
+
+	// Also send to global subscribers (if not already a global subscription)
+	if event.TaskID != GlobalTaskID {
+		for _, sub := range p.subscribers[GlobalTaskID] {
+			p.deliver(sub, event)
+		}
+	}
+}
+
+// deliver sends event to sub's channel, or queues it behind the events
+// already waiting so the subscriber sees events in order.
+func (p *MemoryPublisher) deliver(sub *subscription, event Event) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	if !sub.flushing {
 		select {
-		case ch <- event:
+		case sub.ch <- event:
+			return
 		default:
-			// Skip if channel buffer is full (non-blocking)
 		}
 	}
 
-	// Also send to global subscribers (if not already a global subscription)
-	if event.TaskID != GlobalTaskID {
-		globalSubs := p.subscribers[GlobalTaskID]
-		for _, ch := range globalSubs {
+	if len(sub.queue) >= p.queueSize {
+		dropped := event
+		if i := slices.IndexFunc(sub.queue, func(e Event) bool { return lossyEvents[e.Type] }); i >= 0 && !lossyEvents[event.Type] {
+			dropped = sub.queue[i]
+			sub.queue = append(slices.Delete(sub.queue, i, i+1), event)
+		} else if i < 0 && !lossyEvents[event.Type] && len(sub.queue) > 0 {
+			// Nothing lossy to give up: the oldest event is the least current
+			dropped = sub.queue[0]
+			sub.queue = append(sub.queue[1:], event)
+		}
+		p.recordDrop(dropped)
+	} else {
+		sub.queue = append(sub.queue, event)
+	}
+
+	p.statsMu.Lock()
+	p.maxQueued = max(p.maxQueued, len(sub.queue))
+	p.statsMu.Unlock()
+
+	if !sub.flushing && len(sub.queue) > 0 {
+		sub.flushing = true
+		sub.wg.Add(1)
+		go sub.flush()
+	}
+}
+
+func (p *MemoryPublisher) recordDrop(event Event) {
+	p.statsMu.Lock()
+	p.dropped++
+	p.droppedByType[event.Type]++
+	p.statsMu.Unlock()
+}
+
+// flush delivers queued events in batches until the queue is empty or the
+// subscription ends.
+func (sub *subscription) flush() {
+	defer sub.wg.Done()
+	for {
+		sub.mu.Lock()
+		batch := sub.queue
+		sub.queue = nil
+		if len(batch) == 0 {
+			sub.flushing = false
+			sub.mu.Unlock()
+			return
+		}
+		sub.mu.Unlock()
+
+		for _, event := range batch {
 			select {
-			case ch <- event:
-			default:
-				// Skip if channel buffer is full (non-blocking)
+			case sub.ch <- event:
+			case <-sub.done:
+				return
 			}
 		}
 	}
 }
 
+// stop ends the subscription's delivery and closes its channel.
+func (sub *subscription) stop() {
+	close(sub.done)
+	sub.wg.Wait()
+	close(sub.ch)
+}
+
 // Subscribe returns a channel that receives events for the given task.
 func (p *MemoryPublisher) Subscribe(taskID string) <-chan Event {
 	p.mu.Lock()
@@ -97,9 +229,9 @@ func (p *MemoryPublisher) Subscribe(taskID string) <-chan Event {
 		return ch
 	}
 
-	ch := make(chan Event, p.bufferSize)
-	p.subscribers[taskID] = append(p.subscribers[taskID], ch)
-	return ch
+	sub := &subscription{ch: make(chan Event, p.bufferSize), done: make(chan struct{})}
+	p.subscribers[taskID] = append(p.subscribers[taskID], sub)
+	return sub.ch
 }
 
 // Unsubscribe removes a subscription channel.
@@ -109,11 +241,11 @@ func (p *MemoryPublisher) Unsubscribe(taskID string, ch <-chan Event) {
 
 	subs := p.subscribers[taskID]
 	for i, sub := range subs {
-		if sub == ch {
+		if sub.ch == ch {
 			// Remove from slice
 			p.subscribers[taskID] = append(subs[:i], subs[i+1:]...)
-			// Close the channel
-			close(sub)
+			// Stop delivery and close the channel
+			sub.stop()
 			break
 		}
 	}
@@ -137,13 +269,39 @@ func (p *MemoryPublisher) Close() {
 
 	// Close all subscriber channels
 	for taskID, subs := range p.subscribers {
-		for _, ch := range subs {
-			close(ch)
+		for _, sub := range subs {
+			sub.stop()
 		}
 		delete(p.subscribers, taskID)
 	}
 }
 
+// Stats returns the publisher's delivery backlog and drop counts.
+func (p *MemoryPublisher) Stats() PublisherStats {
+	p.mu.RLock()
+	var stats PublisherStats
+	for _, subs := range p.subscribers {
+		for _, sub := range subs {
+			stats.Subscribers++
+			sub.mu.Lock()
+			if len(sub.queue) > 0 || sub.flushing {
+				stats.SlowSubscribers++
+			}
+			stats.Queued += len(sub.queue)
+			sub.mu.Unlock()
+		}
+	}
+	p.mu.RUnlock()
+
+	p.statsMu.Lock()
+	defer p.statsMu.Unlock()
+	stats.Published, stats.Dropped, stats.MaxQueued = p.published, p.dropped, p.maxQueued
+	if len(p.droppedByType) > 0 {
+		stats.DroppedByType = maps.Clone(p.droppedByType)
+	}
+	return stats
+}
+
 // SubscriberCount returns the number of subscribers for a task.
 func (p *MemoryPublisher) SubscriberCount(taskID string) int {
 	p.mu.RLock()
@@ -200,6 +200,89 @@ func TestMemoryPublisher_NonBlockingPublish(t *testing.T) {
 	<-ch
 }
 
+func TestMemoryPublisher_SlowSubscriberQueue(t *testing.T) {
+	pub := NewMemoryPublisher(WithBufferSize(2), WithQueueSize(100))
+	defer pub.Close()
+
+	ch := pub.Subscribe("TASK-001")
+	for i := range 50 {
+		pub.Publish(NewEvent(EventState, "TASK-001", i))
+	}
+	if stats := pub.Stats(); stats.Published != 50 || stats.SlowSubscribers != 1 || stats.MaxQueued == 0 {
+		t.Errorf("stats while behind = %+v", stats)
+	}
+
+	// Every event arrives, in order, as the subscriber catches up
+	for i := range 50 {
+		select {
+		case e := <-ch:
+			if e.Data != i {
+				t.Fatalf("event %d data = %v", i, e.Data)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for event %d", i)
+		}
+	}
+	if stats := pub.Stats(); stats.Dropped != 0 {
+		t.Errorf("dropped = %d, want 0", stats.Dropped)
+	}
+}
+
+func TestMemoryPublisher_DropsLossyEventsFirst(t *testing.T) {
+	pub := NewMemoryPublisher(WithBufferSize(1), WithQueueSize(2))
+	defer pub.Close()
+
+	ch := pub.Subscribe("TASK-001")
+	pub.Publish(NewEvent(EventPhase, "TASK-001", "spec"))
+	for i := range 10 {
+		pub.Publish(NewEvent(EventTranscript, "TASK-001", i))
+	}
+	pub.Publish(NewEvent(EventPhase, "TASK-001", "implement"))
+	pub.Publish(NewEvent(EventPhase, "TASK-001", "review"))
+
+	var phases []any
+	for len(phases) < 3 {
+		select {
+		case e := <-ch:
+			if e.Type == EventPhase {
+				phases = append(phases, e.Data)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("phase transitions lost: got %v", phases)
+		}
+	}
+	if phases[0] != "spec" || phases[1] != "implement" || phases[2] != "review" {
+		t.Errorf("phases = %v, want in order", phases)
+	}
+	stats := pub.Stats()
+	if stats.Dropped == 0 || stats.DroppedByType[EventTranscript] != stats.Dropped {
+		t.Errorf("stats = %+v, want only transcript events dropped", stats)
+	}
+}
+
+func TestMemoryPublisher_UnsubscribeWhileQueued(t *testing.T) {
+	pub := NewMemoryPublisher(WithBufferSize(1), WithQueueSize(10))
+	defer pub.Close()
+
+	ch := pub.Subscribe("TASK-001")
+	for i := range 5 {
+		pub.Publish(NewEvent(EventState, "TASK-001", i))
+	}
+	done := make(chan struct{})
+	go func() {
+		pub.Unsubscribe("TASK-001", ch)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("unsubscribe blocked on queued events")
+	}
+	for range ch {
+		// Drains the buffered event; the channel is closed
+	}
+}
+
 func TestMemoryPublisher_Concurrent(t *testing.T) {
 	pub := NewMemoryPublisher()
 	defer pub.Close()
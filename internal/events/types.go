@@ -30,6 +30,12 @@ const (
 	EventHeartbeat EventType = "heartbeat"
 	// EventWarning indicates a non-fatal warning.
 	EventWarning EventType = "warning"
+	// EventBudgetExceeded indicates a task was paused for exceeding its own or
+	// its initiative's cost budget.
+	EventBudgetExceeded EventType = "budget_exceeded"
+	// EventAccountRotated indicates the executor switched token pool accounts
+	// after the current one hit a rate limit.
+	EventAccountRotated EventType = "account_rotated"
 
 	// File watcher events (triggered by external file changes)
 
@@ -82,6 +88,13 @@ const (
 	EventThreadStatus EventType = "thread_status"
 	// EventThreadUpdated indicates thread workspace state changed and clients should resync.
 	EventThreadUpdated EventType = "thread_updated"
+
+	// Script run events (keyed by script run ID, not a task ID)
+
+	// EventScriptOutput carries one line of output from a running script.
+	EventScriptOutput EventType = "script_output"
+	// EventScriptFinished indicates a script run completed or failed.
+	EventScriptFinished EventType = "script_finished"
 )
 
 // Event represents a published event.
@@ -91,6 +104,8 @@ type Event struct {
 	TaskID    string    `json:"task_id"`
 	Data      any       `json:"data"`
 	Time      time.Time `json:"time"`
+	// RequestID is the API request that caused the event, when known.
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // NewEvent creates a new event with the current timestamp.
@@ -114,6 +129,23 @@ func NewProjectEvent(eventType EventType, projectID string, taskID string, data
 	}
 }
 
+// ScriptOutputLine represents one line of script run output.
+type ScriptOutputLine struct {
+	RunID  string `json:"run_id"`
+	Script string `json:"script"`
+	Stream string `json:"stream"` // stdout, stderr
+	Line   string `json:"line"`
+}
+
+// ScriptRunFinished represents the final state of a script run.
+type ScriptRunFinished struct {
+	RunID    string `json:"run_id"`
+	Script   string `json:"script"`
+	Status   string `json:"status"` // succeeded, failed
+	ExitCode *int   `json:"exit_code,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
 // TranscriptLine represents a single transcript entry.
 type TranscriptLine struct {
 	Phase     string       `json:"phase"`
@@ -123,6 +155,9 @@ type TranscriptLine struct {
 	Timestamp time.Time    `json:"timestamp"`
 	Model     string       `json:"model,omitempty"`
 	Tokens    *TokenUpdate `json:"tokens,omitempty"`
+	// MessageUUID identifies the stored transcript message, so clients can
+	// resume from it after reconnecting.
+	MessageUUID string `json:"message_uuid,omitempty"`
 }
 
 // PhaseUpdate represents a phase status change.
@@ -183,6 +218,23 @@ type WarningData struct {
 	Message string `json:"message"`
 }
 
+// BudgetExceededData describes the budget a paused task exceeded.
+type BudgetExceededData struct {
+	Phase    string  `json:"phase,omitempty"`
+	Scope    string  `json:"scope"`    // "task" or "initiative"
+	ScopeID  string  `json:"scope_id"` // Task or initiative ID
+	SpentUSD float64 `json:"spent_usd"`
+	LimitUSD float64 `json:"limit_usd"`
+}
+
+// AccountRotatedData describes a token pool account switch.
+type AccountRotatedData struct {
+	Phase       string `json:"phase,omitempty"`
+	FromAccount string `json:"from_account"`
+	ToAccount   string `json:"to_account"`
+	Reason      string `json:"reason,omitempty"`
+}
+
 // SessionUpdate represents session-level metrics for real-time dashboard updates.
 // This event is broadcast:
 // - Every 10 seconds while tasks are running (heartbeat interval)
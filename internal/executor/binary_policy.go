@@ -0,0 +1,251 @@
+package executor
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/git"
+)
+
+// BinaryPolicyCheckName is the quality check that enforces binary_files.
+const BinaryPolicyCheckName = "binary_files"
+
+// binarySniffLen is how much of a file is inspected for NUL bytes, matching
+// git's own binary detection.
+const binarySniffLen = 8000
+
+// CheckViolation is one file that failed a quality check.
+type CheckViolation struct {
+	Path      string `json:"path"`
+	SizeBytes int64  `json:"size_bytes,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+// CheckBinaryPolicy inspects the files changed in the worktree — uncommitted
+// changes plus commits since baseRef, when set — and reports binary files
+// that violate the policy. Deleted files and files matching policy.Allow are
+// ignored.
+func CheckBinaryPolicy(gitCtx *git.Context, policy config.BinaryFilesConfig, baseRef string) ([]CheckViolation, error) {
+	paths, err := changedFiles(gitCtx, baseRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var binaries []string
+	sizes := make(map[string]int64)
+	for _, p := range paths {
+		if binaryPathAllowed(p, policy.Allow) {
+			continue
+		}
+		isBinary, size, err := sniffBinary(filepath.Join(gitCtx.WorkDir(), p))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("inspect %s: %w", p, err)
+		}
+		if isBinary {
+			binaries = append(binaries, p)
+			sizes[p] = size
+		}
+	}
+	if len(binaries) == 0 {
+		return nil, nil
+	}
+
+	lfs := map[string]bool{}
+	if policy.Policy != config.BinaryPolicyBlock {
+		if lfs, err = lfsTrackedFiles(gitCtx, binaries); err != nil {
+			return nil, err
+		}
+	}
+
+	capBytes := int64(policy.MaxSizeKB) * 1024
+	var violations []CheckViolation
+	for _, p := range binaries {
+		v := CheckViolation{Path: p, SizeBytes: sizes[p]}
+		switch {
+		case policy.Policy == config.BinaryPolicyBlock:
+			v.Reason = "binary files are blocked (binary_files.policy: block)"
+		case lfs[p]:
+			continue
+		case policy.Policy == config.BinaryPolicyLFS:
+			v.Reason = fmt.Sprintf("binary file not tracked by Git LFS; run: git lfs track %q", lfsTrackPattern(p))
+		case capBytes > 0 && v.SizeBytes > capBytes:
+			v.Reason = fmt.Sprintf("binary file exceeds binary_files.max_size_kb (%d KB)", policy.MaxSizeKB)
+		default:
+			continue
+		}
+		violations = append(violations, v)
+	}
+	return violations, nil
+}
+
+// binaryPolicyCheck runs CheckBinaryPolicy for a phase's working directory.
+// It returns nil when there is nothing to check.
+func (we *WorkflowExecutor) binaryPolicyCheck(workDir string) *CheckResult {
+	if we.orcConfig == nil || workDir == "" {
+		return nil
+	}
+	policy := we.orcConfig.BinaryFiles
+	if (policy.Policy == "" || policy.Policy == config.BinaryPolicyAllow) && policy.MaxSizeKB == 0 {
+		return nil
+	}
+	gitCtx, err := git.NewContext(workDir)
+	if err != nil {
+		we.logger.Debug("skipping binary file check outside a git repository", "dir", workDir, "error", err)
+		return nil
+	}
+
+	violations, err := CheckBinaryPolicy(gitCtx, policy, we.taskForkPoint(gitCtx))
+	if err != nil {
+		we.logger.Warn("binary file check failed to run", "dir", workDir, "error", err)
+		return nil
+	}
+	result := &CheckResult{Name: BinaryPolicyCheckName, Passed: len(violations) == 0, OnFailure: "block", Violations: violations}
+	if !result.Passed {
+		result.Output = formatBinaryViolations(violations, policy.Policy)
+		we.logger.Info("binary file policy violated", "policy", policy.Policy, "files", len(violations))
+	}
+	return result
+}
+
+// taskForkPoint is the commit the task branch forked from its target, so
+// changes committed by earlier phases are still checked. Empty when unknown.
+func (we *WorkflowExecutor) taskForkPoint(gitCtx *git.Context) string {
+	if we.task == nil {
+		return ""
+	}
+	target := ResolveTargetBranch(we.task, nil, we.orcConfig)
+	for _, ref := range []string{"origin/" + target, target} {
+		if base, err := gitCtx.RunGit("merge-base", "HEAD", ref); err == nil {
+			return strings.TrimSpace(base)
+		}
+	}
+	return ""
+}
+
+// changedFiles lists paths added or modified relative to HEAD (including
+// untracked files) and, when baseRef is set, in commits since baseRef.
+func changedFiles(gitCtx *git.Context, baseRef string) ([]string, error) {
+	queries := [][]string{
+		{"diff", "--name-only", "-z", "--diff-filter=d", "HEAD"},
+		{"ls-files", "--others", "--exclude-standard", "-z"},
+	}
+	if baseRef != "" {
+		queries = append(queries, []string{"diff", "--name-only", "-z", "--diff-filter=d", baseRef, "HEAD"})
+	}
+
+	seen := make(map[string]bool)
+	for _, args := range queries {
+		out, err := gitCtx.RunGit(args...)
+		if err != nil {
+			return nil, fmt.Errorf("git %s: %w", strings.Join(args[:2], " "), err)
+		}
+		for _, p := range strings.Split(out, "\x00") {
+			if p != "" {
+				seen[p] = true
+			}
+		}
+	}
+
+	paths := make([]string, 0, len(seen))
+	for p := range seen {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// sniffBinary reports whether the file contains a NUL byte in its first
+// binarySniffLen bytes, and its size.
+func sniffBinary(file string) (bool, int64, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return false, 0, err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return false, 0, err
+	}
+	if !info.Mode().IsRegular() {
+		return false, 0, nil
+	}
+	buf := make([]byte, binarySniffLen)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return false, 0, err
+	}
+	return bytes.IndexByte(buf[:n], 0) >= 0, info.Size(), nil
+}
+
+// lfsTrackedFiles reports which paths have the lfs filter attribute.
+func lfsTrackedFiles(gitCtx *git.Context, paths []string) (map[string]bool, error) {
+	args := append([]string{"check-attr", "-z", "filter", "--"}, paths...)
+	out, err := gitCtx.RunGit(args...)
+	if err != nil {
+		return nil, fmt.Errorf("git check-attr: %w", err)
+	}
+	tracked := make(map[string]bool)
+	// Output is path NUL attribute NUL value NUL, repeated.
+	fields := strings.Split(out, "\x00")
+	for i := 0; i+2 < len(fields); i += 3 {
+		if fields[i+2] == "lfs" {
+			tracked[fields[i]] = true
+		}
+	}
+	return tracked, nil
+}
+
+func binaryPathAllowed(p string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, p); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(p)); ok && !strings.Contains(pattern, "/") {
+			return true
+		}
+	}
+	return false
+}
+
+// lfsTrackPattern suggests an extension pattern for git lfs track.
+func lfsTrackPattern(p string) string {
+	if ext := path.Ext(p); ext != "" {
+		return "*" + ext
+	}
+	return p
+}
+
+func formatBinaryViolations(violations []CheckViolation, policy string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d binary file(s) violate binary_files.policy (%s):\n", len(violations), firstNonEmpty(policy, config.BinaryPolicyAllow))
+	for _, v := range violations {
+		fmt.Fprintf(&sb, "  %s (%s): %s\n", v.Path, formatFileSize(v.SizeBytes), v.Reason)
+	}
+	sb.WriteString("\nRemove generated binaries from the change (delete them or add them to .gitignore)")
+	if policy == config.BinaryPolicyLFS {
+		sb.WriteString(", or track them with Git LFS")
+	}
+	sb.WriteString(". Do not commit build outputs or other generated artifacts.")
+	return sb.String()
+}
+
+func formatFileSize(n int64) string {
+	switch {
+	case n >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	case n >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
@@ -0,0 +1,156 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/git"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// setupBinaryPolicyRepo creates a repo on main with one commit and returns
+// its git context.
+func setupBinaryPolicyRepo(t *testing.T) *git.Context {
+	t.Helper()
+	repoDir := t.TempDir()
+	runGitCmdOrFatal(t, repoDir, "init", "--initial-branch=main")
+	runGitCmdOrFatal(t, repoDir, "config", "user.email", "test@example.com")
+	runGitCmdOrFatal(t, repoDir, "config", "user.name", "Test")
+	writeTestFile(t, repoDir, "README.md", "# Initial\n")
+	runGitCmdOrFatal(t, repoDir, "add", ".")
+	runGitCmdOrFatal(t, repoDir, "commit", "-m", "Initial commit")
+	gitCtx, err := git.NewContext(repoDir)
+	if err != nil {
+		t.Fatalf("git.NewContext: %v", err)
+	}
+	return gitCtx
+}
+
+func writeBinaryFile(t *testing.T, dir, name string, size int) {
+	t.Helper()
+	data := bytes.Repeat([]byte{0x7f, 'E', 'L', 'F', 0}, size/5+1)[:size]
+	if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func violationPaths(vs []CheckViolation) []string {
+	paths := make([]string, 0, len(vs))
+	for _, v := range vs {
+		paths = append(paths, v.Path)
+	}
+	return paths
+}
+
+func TestCheckBinaryPolicy_AllowCapsSize(t *testing.T) {
+	t.Parallel()
+	gitCtx := setupBinaryPolicyRepo(t)
+	dir := gitCtx.WorkDir()
+	writeBinaryFile(t, dir, "bin/orc", 3*1024)
+	writeBinaryFile(t, dir, "icon.png", 512)
+	writeTestFile(t, dir, "big.txt", strings.Repeat("text\n", 2000))
+
+	policy := config.BinaryFilesConfig{Policy: config.BinaryPolicyAllow, MaxSizeKB: 2}
+	violations, err := CheckBinaryPolicy(gitCtx, policy, "")
+	if err != nil {
+		t.Fatalf("CheckBinaryPolicy: %v", err)
+	}
+	if len(violations) != 1 || violations[0].Path != "bin/orc" || violations[0].SizeBytes != 3*1024 {
+		t.Fatalf("violations = %+v, want only the oversized binary", violations)
+	}
+	if !strings.Contains(violations[0].Reason, "max_size_kb") {
+		t.Errorf("reason = %q", violations[0].Reason)
+	}
+}
+
+func TestCheckBinaryPolicy_BlockIncludesCommittedAndAllowList(t *testing.T) {
+	t.Parallel()
+	gitCtx := setupBinaryPolicyRepo(t)
+	dir := gitCtx.WorkDir()
+	base, err := gitCtx.HeadCommit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeBinaryFile(t, dir, "dist/app.tar.gz", 100)
+	runGitCmdOrFatal(t, dir, "add", ".")
+	runGitCmdOrFatal(t, dir, "commit", "-m", "Add build output")
+	writeBinaryFile(t, dir, "docs/images/diagram.png", 100)
+	writeBinaryFile(t, dir, "coverage.out", 100)
+
+	policy := config.BinaryFilesConfig{Policy: config.BinaryPolicyBlock, Allow: []string{"docs/images/*.png"}}
+	violations, err := CheckBinaryPolicy(gitCtx, policy, base)
+	if err != nil {
+		t.Fatalf("CheckBinaryPolicy: %v", err)
+	}
+	got := strings.Join(violationPaths(violations), ",")
+	if got != "coverage.out,dist/app.tar.gz" {
+		t.Errorf("violations = %s, want the committed and untracked binaries but not the allowed image", got)
+	}
+
+	// Without a base, only changes since HEAD count.
+	violations, err = CheckBinaryPolicy(gitCtx, policy, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(violationPaths(violations), ","); got != "coverage.out" {
+		t.Errorf("violations without base = %s, want coverage.out", got)
+	}
+}
+
+func TestCheckBinaryPolicy_LFSRequiresTracking(t *testing.T) {
+	t.Parallel()
+	gitCtx := setupBinaryPolicyRepo(t)
+	dir := gitCtx.WorkDir()
+	writeTestFile(t, dir, ".gitattributes", "*.psd filter=lfs diff=lfs merge=lfs -text\n")
+	writeBinaryFile(t, dir, "art/cover.psd", 10*1024)
+	writeBinaryFile(t, dir, "model.bin", 100)
+
+	policy := config.BinaryFilesConfig{Policy: config.BinaryPolicyLFS, MaxSizeKB: 1}
+	violations, err := CheckBinaryPolicy(gitCtx, policy, "")
+	if err != nil {
+		t.Fatalf("CheckBinaryPolicy: %v", err)
+	}
+	if len(violations) != 1 || violations[0].Path != "model.bin" {
+		t.Fatalf("violations = %+v, want only the untracked model.bin", violations)
+	}
+	if !strings.Contains(violations[0].Reason, `git lfs track "*.bin"`) {
+		t.Errorf("reason = %q, want lfs track hint", violations[0].Reason)
+	}
+}
+
+func TestRunQualityChecks_BinaryPolicyBlocks(t *testing.T) {
+	t.Parallel()
+	gitCtx := setupBinaryPolicyRepo(t)
+	dir := gitCtx.WorkDir()
+	writeBinaryFile(t, dir, "artifact.zip", 2048)
+
+	backend := storage.NewTestBackend(t)
+	cfg := config.Default()
+	cfg.BinaryFiles = config.BinaryFilesConfig{Policy: config.BinaryPolicyBlock}
+	we := NewWorkflowExecutor(backend, backend.DB(), testGlobalDBFrom(backend), cfg, dir,
+		WithWorkflowLogger(slog.Default()),
+	)
+	we.task = task.NewProtoTask("TASK-BIN-001", "Package release")
+
+	result := we.runQualityChecks(context.Background(), PhaseExecutionConfig{PhaseID: "docs", WorkingDir: dir}, nil)
+	if result == nil || result.AllPassed || !result.HasBlocks {
+		t.Fatalf("result = %+v, want a blocking failure", result)
+	}
+	check := result.Checks[len(result.Checks)-1]
+	if check.Name != BinaryPolicyCheckName || len(check.Violations) != 1 || check.Violations[0].Path != "artifact.zip" {
+		t.Errorf("binary check = %+v", check)
+	}
+	if ctx := result.AsContext(); !strings.Contains(ctx, "Binary_files Failed") || !strings.Contains(ctx, "artifact.zip (2.0 KB)") {
+		t.Errorf("prompt context missing violation:\n%s", ctx)
+	}
+}
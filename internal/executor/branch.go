@@ -2,6 +2,8 @@
 package executor
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
 
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
@@ -72,6 +74,59 @@ func ResolveTargetBranch(t *orcv1.Task, init *initiative.Initiative, cfg *config
 	return branch
 }
 
+// ErrInvalidTargetBranch is returned by ValidateTaskTargetBranch when a
+// per-task target branch override cannot be used.
+var ErrInvalidTargetBranch = errors.New("invalid target branch")
+
+// ValidateTaskTargetBranch checks a per-task target branch override before it
+// is saved. The branch must be a valid name, must already exist (on origin
+// when the repository has one, locally otherwise), and must not be a protected
+// branch when the task's workflow completes by merging directly.
+//
+// gitOps may be nil when the project is not a git repository; only the name
+// and protection checks run in that case.
+func ValidateTaskTargetBranch(gitOps *git.Git, cfg *config.Config, workflowID, branch string) error {
+	if err := git.ValidateBranchName(branch); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidTargetBranch, err)
+	}
+	if cfg != nil && cfg.ResolveCompletionAction(workflowID) == "merge" && cfg.IsProtectedBranch(branch) {
+		return fmt.Errorf("%w: %s is protected and workflow %q merges directly; use completion action 'pr'",
+			ErrInvalidTargetBranch, branch, workflowID)
+	}
+	if gitOps == nil {
+		return nil
+	}
+
+	exists, where, err := targetBranchExists(gitOps, branch)
+	if err != nil {
+		return fmt.Errorf("check target branch %s: %w", branch, err)
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s does not exist %s", ErrInvalidTargetBranch, branch, where)
+	}
+	return nil
+}
+
+// targetBranchExists looks a branch up on origin, falling back to the local
+// remote-tracking ref when origin cannot be reached and to local branches when
+// there is no origin at all. where describes the place that was checked.
+func targetBranchExists(gitOps *git.Git, branch string) (exists bool, where string, err error) {
+	if !gitOps.HasRemote("origin") {
+		exists, err = gitOps.BranchExists(branch)
+		return exists, "locally", err
+	}
+	exists, err = gitOps.RemoteBranchExists("origin", branch)
+	if err == nil {
+		return exists, "on origin", nil
+	}
+	slog.Warn("cannot reach origin to check target branch, using remote-tracking refs",
+		"branch", branch, "error", err)
+	if _, refErr := gitOps.Context().RunGit("show-ref", "--verify", "--quiet", "refs/remotes/origin/"+branch); refErr == nil {
+		return true, "on origin", nil
+	}
+	return false, "on origin", nil
+}
+
 // IsDefaultBranch returns true if the given branch name is a default/main branch
 // that typically already exists (main, master, develop).
 func IsDefaultBranch(branch string) bool {
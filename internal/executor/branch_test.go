@@ -1,7 +1,9 @@
 package executor
 
 import (
+	"errors"
 	"os/exec"
+	"path/filepath"
 	"testing"
 
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
@@ -309,3 +311,67 @@ func TestResolveBranchName(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTaskTargetBranch(t *testing.T) {
+	t.Parallel()
+
+	// A repo whose origin has main and release/1.2; hotfix/local only exists locally.
+	remoteDir := filepath.Join(t.TempDir(), "origin.git")
+	runGitCmdOrFatal(t, filepath.Dir(remoteDir), "init", "--bare", "--initial-branch=main", remoteDir)
+	repoDir := t.TempDir()
+	runGitCmdOrFatal(t, repoDir, "init", "--initial-branch=main")
+	runGitCmdOrFatal(t, repoDir, "config", "user.email", "test@example.com")
+	runGitCmdOrFatal(t, repoDir, "config", "user.name", "Test")
+	runGitCmdOrFatal(t, repoDir, "commit", "--allow-empty", "-m", "init")
+	runGitCmdOrFatal(t, repoDir, "remote", "add", "origin", remoteDir)
+	runGitCmdOrFatal(t, repoDir, "branch", "release/1.2")
+	runGitCmdOrFatal(t, repoDir, "branch", "hotfix/local")
+	runGitCmdOrFatal(t, repoDir, "push", "origin", "main", "release/1.2")
+	withRemote, err := git.New(repoDir, git.DefaultConfig())
+	if err != nil {
+		t.Fatalf("git.New: %v", err)
+	}
+	localOnly := newTestGit(t)
+	localBranch, err := localOnly.GetCurrentBranch()
+	if err != nil {
+		t.Fatalf("GetCurrentBranch: %v", err)
+	}
+
+	prCfg := config.Default()
+	prCfg.Completion.Action = "pr"
+	mergeCfg := config.Default()
+	mergeCfg.Completion.Action = "pr"
+	mergeCfg.Completion.WeightActions = map[string]string{"implement-trivial": "merge"}
+
+	tests := []struct {
+		name       string
+		gitOps     *git.Git
+		cfg        *config.Config
+		workflowID string
+		branch     string
+		wantErr    bool
+	}{
+		{"remote release branch", withRemote, prCfg, "implement-medium", "release/1.2", false},
+		{"protected branch via PR", withRemote, prCfg, "implement-medium", "main", false},
+		{"local-only branch with origin", withRemote, prCfg, "implement-medium", "hotfix/local", true},
+		{"missing branch", withRemote, prCfg, "implement-medium", "release/9.9", true},
+		{"invalid name", withRemote, prCfg, "implement-medium", "bad..name", true},
+		{"protected branch via direct merge", withRemote, mergeCfg, "implement-trivial", "main", true},
+		{"unprotected branch via direct merge", withRemote, mergeCfg, "implement-trivial", "release/1.2", false},
+		{"local branch without origin", localOnly, prCfg, "implement-medium", localBranch, false},
+		{"no repository", nil, prCfg, "implement-medium", "release/9.9", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTaskTargetBranch(tt.gitOps, tt.cfg, tt.workflowID, tt.branch)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTargetBranch) {
+					t.Errorf("ValidateTaskTargetBranch(%q) = %v, want ErrInvalidTargetBranch", tt.branch, err)
+				}
+			} else if err != nil {
+				t.Errorf("ValidateTaskTargetBranch(%q) = %v, want nil", tt.branch, err)
+			}
+		})
+	}
+}
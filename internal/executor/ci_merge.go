@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"os"
 	"os/exec"
 	"strings"
 	"time"
@@ -13,7 +14,9 @@ import (
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
 	"github.com/randalmurphal/orc/internal/config"
 	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/git"
 	"github.com/randalmurphal/orc/internal/hosting"
+	"github.com/randalmurphal/orc/internal/retry"
 	"github.com/randalmurphal/orc/internal/storage"
 	"github.com/randalmurphal/orc/internal/task"
 )
@@ -148,6 +151,14 @@ func (m *CIMerger) WaitForCIAndMerge(ctx context.Context, t *orcv1.Task) error {
 		return nil
 	}
 
+	// Initiative members merge into the integration branch in dependency order
+	orderCtx, cancel := context.WithTimeout(ctx, m.config.CITimeout())
+	err = m.waitForMergeOrder(orderCtx, t)
+	cancel()
+	if err != nil {
+		return err
+	}
+
 	// Merge the PR
 	m.publishProgress(t.Id, "CI checks passed. Merging PR...")
 
@@ -165,14 +176,12 @@ func (m *CIMerger) WaitForCIAndMerge(ctx context.Context, t *orcv1.Task) error {
 	return nil
 }
 
-// WaitForCI polls CI checks until they pass or timeout.
+// WaitForCI polls CI checks until they pass or timeout. Polls start at
+// backoff.initial and back off to the configured CI poll interval.
 func (m *CIMerger) WaitForCI(ctx context.Context, ref, taskID string) (*CICheckResult, error) {
 	timeout := m.config.CITimeout()
-	pollInterval := m.config.CIPollInterval()
-
 	deadline := time.Now().Add(timeout)
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
+	poll := retry.NewBackoff(m.config.CIPollPolicy())
 
 	// Initial check
 	result, err := m.CheckCIStatus(ctx, ref)
@@ -195,59 +204,57 @@ func (m *CIMerger) WaitForCI(ctx context.Context, ref, taskID string) (*CICheckR
 
 	// Poll loop
 	for {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-
-		case <-ticker.C:
-			if time.Now().After(deadline) {
-				m.logger.Error("CI checks timed out",
-					"task", taskID,
-					"timeout", timeout,
-					"pending_checks", result.PendingNames,
-				)
-				return result, fmt.Errorf("%w after %v: %d checks still pending (%v)",
-					ErrCITimeout, timeout, result.PendingChecks, result.PendingNames)
-			}
-
-			result, err = m.CheckCIStatus(ctx, ref)
-			if err != nil {
-				m.logger.Warn("failed to check CI status, retrying",
-					"task", taskID,
-					"error", err,
-				)
-				continue
-			}
+		if err := retry.Sleep(ctx, poll.Next()); err != nil {
+			return nil, err
+		}
+		if time.Now().After(deadline) {
+			m.logger.Error("CI checks timed out",
+				"task", taskID,
+				"timeout", timeout,
+				"pending_checks", result.PendingNames,
+			)
+			return result, fmt.Errorf("%w after %v: %d checks still pending (%v)",
+				ErrCITimeout, timeout, result.PendingChecks, result.PendingNames)
+		}
 
-			m.logger.Debug("CI status check",
+		next, err := m.CheckCIStatus(ctx, ref)
+		if err != nil {
+			m.logger.Warn("failed to check CI status, retrying",
 				"task", taskID,
-				"status", result.Status,
-				"passed", result.PassedChecks,
-				"pending", result.PendingChecks,
-				"failed", result.FailedChecks,
+				"error", err,
 			)
+			continue
+		}
+		result = next
 
-			switch result.Status {
-			case CIStatusPassed, CIStatusNoChecks:
-				m.logger.Info("CI checks passed", "task", taskID)
-				m.publishProgress(taskID,
-					fmt.Sprintf("CI checks passed (%d/%d)", result.PassedChecks, result.TotalChecks))
-				return result, nil
-
-			case CIStatusFailed:
-				m.logger.Error("CI checks failed",
-					"task", taskID,
-					"failed_checks", result.FailedNames,
-				)
-				m.publishProgress(taskID,
-					fmt.Sprintf("CI checks failed: %v", result.FailedNames))
-				return result, fmt.Errorf("%w: %v", ErrCIFailed, result.FailedNames)
-
-			case CIStatusPending:
-				m.publishProgress(taskID,
-					fmt.Sprintf("Waiting for CI... %d/%d passed, %d pending",
-						result.PassedChecks, result.TotalChecks, result.PendingChecks))
-			}
+		m.logger.Debug("CI status check",
+			"task", taskID,
+			"status", result.Status,
+			"passed", result.PassedChecks,
+			"pending", result.PendingChecks,
+			"failed", result.FailedChecks,
+		)
+
+		switch result.Status {
+		case CIStatusPassed, CIStatusNoChecks:
+			m.logger.Info("CI checks passed", "task", taskID)
+			m.publishProgress(taskID,
+				fmt.Sprintf("CI checks passed (%d/%d)", result.PassedChecks, result.TotalChecks))
+			return result, nil
+
+		case CIStatusFailed:
+			m.logger.Error("CI checks failed",
+				"task", taskID,
+				"failed_checks", result.FailedNames,
+			)
+			m.publishProgress(taskID,
+				fmt.Sprintf("CI checks failed: %v", result.FailedNames))
+			return result, fmt.Errorf("%w: %v", ErrCIFailed, result.FailedNames)
+
+		case CIStatusPending:
+			m.publishProgress(taskID,
+				fmt.Sprintf("Waiting for CI... %d/%d passed, %d pending",
+					result.PassedChecks, result.TotalChecks, result.PendingChecks))
 		}
 	}
 }
@@ -309,10 +316,10 @@ func (m *CIMerger) CheckCIStatus(ctx context.Context, ref string) (*CICheckResul
 // Implements retry logic for "Base branch was modified" errors:
 // 1. Attempt merge
 // 2. If retryable error:
-//   - Wait with exponential backoff (2^attempt seconds, max 8s)
+//   - Wait with the configured backoff (see config.BackoffConfig)
 //   - Fetch and rebase onto target branch
 //   - Push rebased branch (force-with-lease)
-//   - Retry merge (up to 3 attempts)
+//   - Retry merge (up to backoff.max_attempts tries in total)
 //
 // 3. If rebase has conflicts or max retries exceeded, return ErrMergeFailed
 func (m *CIMerger) MergePR(ctx context.Context, t *orcv1.Task) error {
@@ -320,7 +327,8 @@ func (m *CIMerger) MergePR(ctx context.Context, t *orcv1.Task) error {
 		return fmt.Errorf("hosting provider not configured")
 	}
 
-	const maxRetries = 3
+	policy := m.config.RetryPolicy()
+	maxRetries := policy.Attempts() - 1
 
 	method := m.config.MergeMethod()
 	if method == "" {
@@ -345,16 +353,14 @@ func (m *CIMerger) MergePR(ctx context.Context, t *orcv1.Task) error {
 	var lastErr error
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
-			backoff := min(time.Duration(1<<attempt)*time.Second, 8*time.Second)
+			backoff := policy.Delay(attempt)
 			m.logger.Info("waiting before merge retry",
 				"attempt", attempt,
 				"backoff", backoff,
 				"task", t.Id,
 			)
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(backoff):
+			if err := retry.Sleep(ctx, backoff); err != nil {
+				return err
 			}
 
 			if err := m.rebaseOnTarget(ctx, t); err != nil {
@@ -410,7 +416,8 @@ func (m *CIMerger) MergePR(ctx context.Context, t *orcv1.Task) error {
 
 		// Merge succeeded - update task with merge info
 		prURL := task.GetPRURLProto(t)
-		task.SetMergedInfoProto(t, prURL, m.config.Completion.TargetBranch)
+		targetBranch := ResolveTargetBranch(t, nil, m.config)
+		task.SetMergedInfoProto(t, prURL, targetBranch)
 		if m.backend != nil {
 			if saveErr := m.backend.SaveTask(t); saveErr != nil {
 				m.logger.Warn("failed to save task after merge", "error", saveErr)
@@ -418,6 +425,15 @@ func (m *CIMerger) MergePR(ctx context.Context, t *orcv1.Task) error {
 		}
 
 		m.logger.Info("PR merged successfully", "task", t.Id, "pr_number", prNumber)
+
+		if bt, err := CreateBackportTask(m.backend, m.config, t, targetBranch, ""); err != nil {
+			m.logger.Warn("failed to create back-port task", "task", t.Id, "release_branch", targetBranch, "error", err)
+		} else if bt != nil {
+			m.logger.Info("back-port task created", "task", t.Id, "backport", bt.Id, "release_branch", targetBranch)
+		}
+		if m.backend != nil {
+			SyncInitiativeBranch(ctx, NewInitiativeCompleter(nil, m.provider, m.backend, m.config, m.logger, m.workDir), t)
+		}
 		return nil
 	}
 
@@ -483,6 +499,23 @@ func (m *CIMerger) runGitCmd(ctx context.Context, args ...string) (string, error
 	if m.workDir != "" {
 		cmd.Dir = m.workDir
 	}
+	if m.config != nil && m.config.GitIdentity.IsSet() {
+		// Rebased commits get a new committer; attribute them to orc's identity.
+		cmd.Env = os.Environ()
+		for k, v := range m.config.GitIdentity.Env() {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+	}
+	if m.config != nil && len(m.config.GitHooks.BypassedHooks()) > 0 {
+		if gitCtx, err := git.NewContext(cmd.Dir, git.WithBypassedHooks(m.config.GitHooks.BypassedHooks())); err == nil {
+			if cmd.Env == nil {
+				cmd.Env = os.Environ()
+			}
+			for k, v := range gitCtx.HookBypassEnv() {
+				cmd.Env = append(cmd.Env, k+"="+v)
+			}
+		}
+	}
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -680,7 +680,11 @@ type mockProvider struct {
 	enableAutoMergeErr error
 	updatePRBranchErr  error
 	createPRFunc       func(ctx context.Context, opts hosting.PRCreateOptions) (*hosting.PR, error)
+	updatePRFunc       func(ctx context.Context, number int, opts hosting.PRUpdateOptions) error
+	findPRByBranchFunc func(ctx context.Context, branch string) (*hosting.PR, error)
 	approvePRErr       error
+	branchProtection   *hosting.BranchProtection
+	branchProtErr      error
 
 	// Track calls
 	enableAutoMergeCalls []struct {
@@ -691,6 +695,7 @@ type mockProvider struct {
 		Number int
 		Body   string
 	}
+	createdIssues []hosting.IssueCreateOptions
 }
 
 func (m *mockProvider) CreatePR(ctx context.Context, opts hosting.PRCreateOptions) (*hosting.PR, error) {
@@ -705,14 +710,20 @@ func (m *mockProvider) GetPR(ctx context.Context, number int) (*hosting.PR, erro
 	}
 	return nil, fmt.Errorf("not implemented")
 }
-func (m *mockProvider) UpdatePR(_ context.Context, _ int, _ hosting.PRUpdateOptions) error {
+func (m *mockProvider) UpdatePR(ctx context.Context, number int, opts hosting.PRUpdateOptions) error {
+	if m.updatePRFunc != nil {
+		return m.updatePRFunc(ctx, number, opts)
+	}
 	return fmt.Errorf("not implemented")
 }
 func (m *mockProvider) MergePR(_ context.Context, _ int, opts hosting.PRMergeOptions) error {
 	m.lastMergeOpts = opts
 	return m.mergeErr
 }
-func (m *mockProvider) FindPRByBranch(_ context.Context, _ string) (*hosting.PR, error) {
+func (m *mockProvider) FindPRByBranch(ctx context.Context, branch string) (*hosting.PR, error) {
+	if m.findPRByBranchFunc != nil {
+		return m.findPRByBranchFunc(ctx, branch)
+	}
 	return nil, fmt.Errorf("not implemented")
 }
 func (m *mockProvider) ListPRComments(_ context.Context, _ int) ([]hosting.PRComment, error) {
@@ -756,6 +767,20 @@ func (m *mockProvider) GetPRStatusSummary(_ context.Context, _ *hosting.PR) (*ho
 func (m *mockProvider) DeleteBranch(_ context.Context, _ string) error {
 	return fmt.Errorf("not implemented")
 }
+func (m *mockProvider) GetBranchProtection(_ context.Context, branch string) (*hosting.BranchProtection, error) {
+	if m.branchProtErr != nil {
+		return nil, m.branchProtErr
+	}
+	if m.branchProtection != nil {
+		return m.branchProtection, nil
+	}
+	return &hosting.BranchProtection{Branch: branch}, nil
+}
+func (m *mockProvider) CreateIssue(_ context.Context, opts hosting.IssueCreateOptions) (*hosting.Issue, error) {
+	m.createdIssues = append(m.createdIssues, opts)
+	n := len(m.createdIssues)
+	return &hosting.Issue{Number: n, Title: opts.Title, HTMLURL: fmt.Sprintf("https://example.com/issues/%d", n)}, nil
+}
 func (m *mockProvider) CheckAuth(_ context.Context) error {
 	return nil
 }
@@ -13,6 +13,7 @@ import (
 
 	llmkit "github.com/randalmurphal/llmkit/v2"
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/breaker"
 	"github.com/randalmurphal/orc/internal/db"
 	"github.com/randalmurphal/orc/internal/storage"
 )
@@ -30,6 +31,14 @@ type TurnExecutor interface {
 	SessionID() string
 }
 
+// PhaseTurnExecutor is an injected TurnExecutor that answers each phase
+// separately, e.g. a replay with per-phase recorded turns.
+type PhaseTurnExecutor interface {
+	TurnExecutor
+	// ForPhase returns the executor to use for turns of phaseID.
+	ForPhase(phaseID string) TurnExecutor
+}
+
 // Ensure ClaudeExecutor implements TurnExecutor
 var _ TurnExecutor = (*ClaudeExecutor)(nil)
 
@@ -322,8 +331,27 @@ func (e *ClaudeExecutor) executeStream(ctx context.Context, prompt, schema strin
 		}, err
 	}
 
+	// Fail fast while claude is known to be down; record how this call went
+	// so repeated failures trip the breaker.
+	cb := breaker.Get(breaker.Claude)
+	if err := cb.Allow(); err != nil {
+		return &TurnResult{
+			Duration:  time.Since(start),
+			IsError:   true,
+			ErrorText: err.Error(),
+		}, err
+	}
+	var claudeErr error
+	defer func() {
+		if ctx.Err() != nil {
+			claudeErr = ctx.Err() // cancelled by the caller, not a claude failure
+		}
+		cb.Record(claudeErr)
+	}()
+
 	client, err := llmkit.New(ProviderClaude, clientCfg)
 	if err != nil {
+		claudeErr = err
 		return &TurnResult{
 			Duration:  time.Since(start),
 			IsError:   true,
@@ -346,6 +374,7 @@ func (e *ClaudeExecutor) executeStream(ctx context.Context, prompt, schema strin
 
 	stream, err := client.Stream(watchCtx, req)
 	if err != nil {
+		claudeErr = err
 		return &TurnResult{
 			Duration:  time.Since(start),
 			IsError:   true,
@@ -391,11 +420,14 @@ func (e *ClaudeExecutor) executeStream(ctx context.Context, prompt, schema strin
 		}
 		if chunk.Error != nil {
 			err := chunk.Error
+			claudeErr = err
 			if transcriptErr := e.transcriptHandler.Err(); transcriptErr != nil {
 				err = transcriptErr
+				claudeErr = nil
 			}
 			if stallErr := watchdog.Error("claude"); stallErr != nil {
 				err = stallErr
+				claudeErr = stallErr
 			}
 			content := strings.TrimSpace(contentBuilder.String())
 			if finalContent != "" {
@@ -420,6 +452,7 @@ func (e *ClaudeExecutor) executeStream(ctx context.Context, prompt, schema strin
 		}, transcriptErr
 	}
 	if stallErr := watchdog.Error("claude"); stallErr != nil {
+		claudeErr = stallErr
 		return &TurnResult{
 			Duration:  time.Since(start),
 			IsError:   true,
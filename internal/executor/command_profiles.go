@@ -0,0 +1,115 @@
+package executor
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/git"
+)
+
+// projectCommandsForPaths resolves the project commands for a task that
+// changed the given repo-relative paths, keyed like GetProjectCommandsMap.
+//
+// Per-directory profiles (commands with a path) replace the whole-repository
+// command of the same name and scope when every changed file falls under a
+// profile: each file picks the most specific profile containing it, and the
+// selected profiles' commands run from their directories. If any changed file
+// is outside every profile, or nothing changed, the whole-repository command
+// is kept. Disabled profiles are ignored.
+func projectCommandsForPaths(cmds []*db.ProjectCommand, changed []string) map[string]*db.ProjectCommand {
+	result := make(map[string]*db.ProjectCommand)
+	profiles := make(map[string][]*db.ProjectCommand)
+	for _, cmd := range cmds {
+		key := db.ScopedCommandKey(cmd.Name, cmd.Scope)
+		switch {
+		case cmd.Path == "":
+			result[key] = cmd
+		case cmd.Enabled && strings.TrimSpace(cmd.Command) != "":
+			profiles[key] = append(profiles[key], cmd)
+		}
+	}
+	if len(changed) == 0 {
+		return result
+	}
+
+	for key, candidates := range profiles {
+		if selected := selectCommandProfiles(candidates, changed); len(selected) > 0 {
+			result[key] = combineCommandProfiles(selected)
+		}
+	}
+	return result
+}
+
+// selectCommandProfiles returns the most specific profile for each changed
+// file, in path order, or nil if some file is not under any profile.
+func selectCommandProfiles(candidates []*db.ProjectCommand, changed []string) []*db.ProjectCommand {
+	chosen := make(map[string]*db.ProjectCommand)
+	for _, file := range changed {
+		var best *db.ProjectCommand
+		for _, c := range candidates {
+			if (file == c.Path || strings.HasPrefix(file, c.Path+"/")) && (best == nil || len(c.Path) > len(best.Path)) {
+				best = c
+			}
+		}
+		if best == nil {
+			return nil
+		}
+		chosen[best.Path] = best
+	}
+
+	selected := make([]*db.ProjectCommand, 0, len(chosen))
+	for _, c := range chosen {
+		selected = append(selected, c)
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Path < selected[j].Path })
+	return selected
+}
+
+// combineCommandProfiles merges profiles into one command that runs each
+// profile's command from its directory, stopping at the first failure.
+func combineCommandProfiles(selected []*db.ProjectCommand) *db.ProjectCommand {
+	combined := *selected[0]
+	full := make([]string, len(selected))
+	short := make([]string, len(selected))
+	for i, c := range selected {
+		full[i] = "(cd " + quoteShellArg(c.Path) + " && " + c.Command + ")"
+		short[i] = "(cd " + quoteShellArg(c.Path) + " && " + firstNonEmpty(c.ShortCommand, c.Command) + ")"
+	}
+	combined.Command = strings.Join(full, " && ")
+	combined.ShortCommand = strings.Join(short, " && ")
+	return &combined
+}
+
+// taskProjectCommands loads the project commands for a phase running in
+// workDir, narrowed to per-directory profiles for the files the task changed.
+func (we *WorkflowExecutor) taskProjectCommands(workDir string) (map[string]*db.ProjectCommand, error) {
+	cmds, err := we.projectDB.ListProjectCommands()
+	if err != nil {
+		return nil, err
+	}
+	var changed []string
+	if hasCommandProfiles(cmds) && workDir != "" {
+		if gitCtx, err := git.NewContext(workDir); err == nil {
+			if changed, err = changedFiles(gitCtx, we.taskForkPoint(gitCtx)); err != nil {
+				we.logger.Warn("failed to list changed files, using whole-repository commands", "dir", workDir, "error", err)
+				changed = nil
+			}
+		}
+	}
+	return projectCommandsForPaths(cmds, changed), nil
+}
+
+// quoteShellArg quotes s for a POSIX shell.
+func quoteShellArg(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func hasCommandProfiles(cmds []*db.ProjectCommand) bool {
+	for _, cmd := range cmds {
+		if cmd.Path != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+func TestProjectCommandsForPaths(t *testing.T) {
+	cmds := []*db.ProjectCommand{
+		{Name: "tests", Command: "make test-all", Enabled: true},
+		{Name: "tests", Path: "services", Command: "make test-services", Enabled: true},
+		{Name: "tests", Path: "services/api", Command: "go test ./...", ShortCommand: "go test -short ./...", Enabled: true},
+		{Name: "tests", Path: "web", Command: "npm test", Enabled: true},
+		{Name: "tests", Path: "docs", Command: "make docs", Enabled: false},
+		{Name: "lint", Command: "make lint", Enabled: true},
+	}
+
+	tests := []struct {
+		name    string
+		changed []string
+		want    string
+	}{
+		{"no changes keeps whole repo", nil, "make test-all"},
+		{"most specific profile", []string{"services/api/handler.go"}, "(cd 'services/api' && go test ./...)"},
+		{"parent profile", []string{"services/worker/main.go"}, "(cd 'services' && make test-services)"},
+		{"several profiles", []string{"web/app.ts", "services/api/x.go"}, "(cd 'services/api' && go test ./...) && (cd 'web' && npm test)"},
+		{"file outside profiles", []string{"services/api/x.go", "README.md"}, "make test-all"},
+		{"disabled profile ignored", []string{"docs/index.md"}, "make test-all"},
+		{"prefix is not a directory match", []string{"website/index.html"}, "make test-all"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := projectCommandsForPaths(cmds, tt.changed)
+			if got["tests"] == nil || got["tests"].Command != tt.want {
+				t.Errorf("tests command = %+v, want %q", got["tests"], tt.want)
+			}
+			if got["lint"] == nil || got["lint"].Command != "make lint" {
+				t.Errorf("lint command = %+v, want the whole-repository command", got["lint"])
+			}
+		})
+	}
+
+	got := projectCommandsForPaths(cmds, []string{"services/api/x.go"})
+	if got["tests"].ShortCommand != "(cd 'services/api' && go test -short ./...)" {
+		t.Errorf("short command = %q", got["tests"].ShortCommand)
+	}
+}
+
+func TestCombinedCommandProfileRunsInDirectory(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "pkg a"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cmds := projectCommandsForPaths([]*db.ProjectCommand{
+		{Name: "tests", Path: "pkg a", Command: "pwd > out.txt", Enabled: true},
+	}, []string{"pkg a/file.go"})
+
+	runner := NewQualityCheckRunner(root, []db.QualityCheck{{Type: "code", Name: "tests", Enabled: true}}, cmds, nil)
+	if result := runner.Run(context.Background()); !result.AllPassed {
+		t.Fatalf("check failed: %+v", result.Checks)
+	}
+	if _, err := os.Stat(filepath.Join(root, "pkg a", "out.txt")); err != nil {
+		t.Errorf("profile command did not run from its directory: %v", err)
+	}
+}
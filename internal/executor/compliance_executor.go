@@ -0,0 +1,82 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/compliance"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/git"
+)
+
+// ComplianceError is returned by a compliance phase when dependencies the
+// task added or updated violate the compliance policy.
+type ComplianceError struct {
+	Violations []compliance.Finding `json:"violations"`
+}
+
+func (e *ComplianceError) Error() string {
+	names := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		names[i] = fmt.Sprintf("%s (%s)", v.Dependency.Name, v.Reason)
+	}
+	return fmt.Sprintf("compliance: %d dependency violation(s): %s", len(names), strings.Join(names, "; "))
+}
+
+// compliancePhaseExecutor runs the "compliance" phase type: it diffs
+// dependency manifests against the task's fork point, checks what was added
+// or updated against the compliance config, and records the SBOM delta as a
+// task attachment.
+type compliancePhaseExecutor struct {
+	we *WorkflowExecutor
+}
+
+// Name returns the executor type name.
+func (e *compliancePhaseExecutor) Name() string {
+	return "compliance"
+}
+
+// ExecutePhase implements PhaseTypeExecutor.
+func (e *compliancePhaseExecutor) ExecutePhase(_ context.Context, params PhaseTypeParams) (PhaseResult, error) {
+	start := time.Now()
+	result := PhaseResult{PhaseID: params.PhaseTemplate.ID}
+	we := e.we
+
+	workDir := we.effectiveWorkingDir()
+	gitCtx, err := git.NewContext(workDir)
+	if err != nil {
+		return result, fmt.Errorf("compliance phase: open repository: %w", err)
+	}
+	baseRef := firstNonEmpty(we.taskForkPoint(gitCtx), "HEAD")
+	delta, err := compliance.ComputeDelta(workDir, baseRef)
+	if err != nil {
+		return result, fmt.Errorf("compliance phase: %w", err)
+	}
+
+	var cfg config.ComplianceConfig
+	if we.orcConfig != nil {
+		cfg = we.orcConfig.Compliance
+	}
+	report := compliance.Evaluate(delta, cfg)
+
+	if params.Task != nil {
+		if err := saveSBOMDelta(we.backend, params.Task.Id, delta); err != nil {
+			we.logger.Warn("failed to record SBOM delta", "task", params.Task.Id, "error", err)
+		}
+	}
+
+	result.Content = compliance.FormatReport(report, delta)
+	result.DurationMS = durationMS(start)
+	storeOutputVar(params, params.PhaseTemplate.OutputVarName, result.Content)
+	we.logger.Info("compliance check complete",
+		"checked", report.Checked, "violations", len(report.Violations), "warnings", len(report.Warnings))
+
+	if !report.Passed() {
+		return result, &ComplianceError{Violations: report.Violations}
+	}
+	result.Status = orcv1.PhaseStatus_PHASE_STATUS_COMPLETED.String()
+	return result, nil
+}
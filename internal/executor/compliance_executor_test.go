@@ -0,0 +1,76 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/compliance"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+	"github.com/randalmurphal/orc/internal/variable"
+)
+
+func TestCompliancePhase_BlocksDeniedLicenseAndRecordsDelta(t *testing.T) {
+	t.Parallel()
+	gitCtx := setupBinaryPolicyRepo(t)
+	dir := gitCtx.WorkDir()
+	writeTestFile(t, dir, "package-lock.json", `{"lockfileVersion": 3, "packages": {
+		"node_modules/ok": {"version": "1.0.0", "resolved": "https://registry.npmjs.org/ok.tgz", "license": "MIT"},
+		"node_modules/copyleft": {"version": "2.0.0", "resolved": "https://registry.npmjs.org/copyleft.tgz", "license": "AGPL-3.0-only"}
+	}}`)
+
+	backend := storage.NewTestBackend(t)
+	tsk := task.NewProtoTask("TASK-COMP-001", "Add dependencies")
+	if err := backend.SaveTask(tsk); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.Default()
+	we := NewWorkflowExecutor(backend, backend.DB(), testGlobalDBFrom(backend), cfg, dir,
+		WithWorkflowLogger(slog.Default()),
+	)
+	executor, err := we.phaseTypeRegistry.Get("compliance")
+	if err != nil {
+		t.Fatalf("compliance executor not registered: %v", err)
+	}
+
+	vars := variable.VariableSet{}
+	params := PhaseTypeParams{
+		PhaseTemplate: &db.PhaseTemplate{ID: "compliance", OutputVarName: "COMPLIANCE_REPORT"},
+		Task:          tsk,
+		Vars:          vars,
+	}
+	result, err := executor.ExecutePhase(context.Background(), params)
+	var compErr *ComplianceError
+	if !errors.As(err, &compErr) || len(compErr.Violations) != 1 || compErr.Violations[0].Dependency.Name != "copyleft" {
+		t.Fatalf("err = %v, want a ComplianceError for copyleft", err)
+	}
+	if !strings.Contains(result.Content, "copyleft") || vars["COMPLIANCE_REPORT"] != result.Content {
+		t.Errorf("report content = %q", result.Content)
+	}
+
+	_, data, err := backend.GetAttachment(tsk.Id, SBOMDeltaAttachment)
+	if err != nil {
+		t.Fatalf("SBOM delta attachment: %v", err)
+	}
+	var delta compliance.Delta
+	if err := json.Unmarshal(data, &delta); err != nil {
+		t.Fatal(err)
+	}
+	if delta.Format != compliance.DeltaFormat || len(delta.Added) != 2 {
+		t.Errorf("delta = %+v, want both added packages", delta)
+	}
+
+	// Allowing the license lets the phase pass.
+	cfg.Compliance.DeniedLicenses = nil
+	result, err = executor.ExecutePhase(context.Background(), params)
+	if err != nil || result.Status != orcv1.PhaseStatus_PHASE_STATUS_COMPLETED.String() {
+		t.Fatalf("result = %+v, err = %v, want completed", result, err)
+	}
+}
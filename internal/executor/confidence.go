@@ -0,0 +1,200 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	llmkit "github.com/randalmurphal/llmkit/v2"
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/gate"
+	"github.com/randalmurphal/orc/internal/llmutil"
+)
+
+// confidenceOutputLimit caps how much phase output goes into the scoring prompt.
+const confidenceOutputLimit = 12000
+
+// PhaseConfidenceRequest is the input to a PhaseConfidenceScorer.
+type PhaseConfidenceRequest struct {
+	TaskID          string
+	TaskTitle       string
+	TaskDescription string
+	PhaseID         string
+	Output          string
+	Model           string
+}
+
+// PhaseConfidenceScore is the validation model's assessment of a phase.
+type PhaseConfidenceScore struct {
+	Confidence  float64  `json:"confidence"`
+	RiskFactors []string `json:"risk_factors"`
+	Rationale   string   `json:"rationale"`
+}
+
+// PhaseConfidenceScorer rates a completed phase's output.
+type PhaseConfidenceScorer func(ctx context.Context, req PhaseConfidenceRequest) (*PhaseConfidenceScore, error)
+
+// WithWorkflowConfidenceScorer overrides how completed phases are scored.
+func WithWorkflowConfidenceScorer(scorer PhaseConfidenceScorer) WorkflowExecutorOption {
+	return func(we *WorkflowExecutor) {
+		we.confidenceScorer = scorer
+	}
+}
+
+const phaseConfidenceSchema = `{
+  "type": "object",
+  "properties": {
+    "confidence": {
+      "type": "number",
+      "minimum": 0,
+      "maximum": 1,
+      "description": "How confident you are that the phase output is correct and complete (0 = certainly wrong, 1 = certainly right)"
+    },
+    "risk_factors": {
+      "type": "array",
+      "items": {"type": "string"},
+      "description": "Concrete risks that could make the output wrong or unsafe to build on"
+    },
+    "rationale": {
+      "type": "string",
+      "description": "One or two sentences explaining the score"
+    }
+  },
+  "required": ["confidence", "risk_factors", "rationale"]
+}`
+
+// confidenceScoringEnabled reports whether completed phases are scored.
+func (we *WorkflowExecutor) confidenceScoringEnabled() bool {
+	return we.orcConfig != nil && we.orcConfig.Validation.Enabled && we.orcConfig.Validation.Confidence.Enabled
+}
+
+// scorePhaseConfidence asks the validation model how much to trust a
+// completed phase and records the score. Scoring is advisory: failures are
+// logged and the phase proceeds without a score.
+func (we *WorkflowExecutor) scorePhaseConfidence(ctx context.Context, t *orcv1.Task, phaseID, output string) {
+	if !we.confidenceScoringEnabled() || t == nil || strings.TrimSpace(output) == "" {
+		return
+	}
+
+	scorer := we.confidenceScorer
+	if scorer == nil {
+		scorer = we.scoreWithValidationModel
+	}
+	req := PhaseConfidenceRequest{
+		TaskID:          t.Id,
+		TaskTitle:       t.GetTitle(),
+		TaskDescription: t.GetDescription(),
+		PhaseID:         phaseID,
+		Output:          output,
+		Model:           we.orcConfig.Validation.Model,
+	}
+	score, err := scorer(ctx, req)
+	if err != nil {
+		we.logger.Warn("phase confidence scoring failed", "task", t.Id, "phase", phaseID, "error", err)
+		return
+	}
+
+	record := &db.PhaseConfidence{
+		TaskID:      t.Id,
+		PhaseID:     phaseID,
+		Confidence:  min(max(score.Confidence, 0), 1),
+		RiskFactors: score.RiskFactors,
+		Rationale:   score.Rationale,
+		Model:       req.Model,
+	}
+	if we.phaseConfidence == nil {
+		we.phaseConfidence = make(map[string]*db.PhaseConfidence)
+	}
+	we.phaseConfidence[phaseID] = record
+	we.savePhaseConfidence(record)
+
+	we.logger.Info("phase confidence scored",
+		"task", t.Id,
+		"phase", phaseID,
+		"confidence", record.Confidence,
+		"risk_factors", len(record.RiskFactors),
+	)
+}
+
+// confidenceRequiresHumanGate reports whether the phase's latest confidence
+// score is below validation.confidence.escalate_below. The score is marked
+// as having escalated its gate.
+func (we *WorkflowExecutor) confidenceRequiresHumanGate(phaseID string) bool {
+	if !we.confidenceScoringEnabled() {
+		return false
+	}
+	threshold := we.orcConfig.Validation.Confidence.EscalateBelow
+	record := we.phaseConfidence[phaseID]
+	if threshold <= 0 || record == nil || record.Confidence >= threshold {
+		return false
+	}
+
+	we.logger.Info("low confidence, escalating auto gate to human",
+		"phase", phaseID,
+		"confidence", record.Confidence,
+		"threshold", threshold,
+	)
+	if !record.Escalated {
+		record.Escalated = true
+		we.savePhaseConfidence(record)
+	}
+	return true
+}
+
+func (we *WorkflowExecutor) savePhaseConfidence(record *db.PhaseConfidence) {
+	if we.projectDB == nil {
+		return
+	}
+	if err := we.projectDB.SavePhaseConfidence(record); err != nil {
+		we.logger.Warn("failed to save phase confidence", "task", record.TaskID, "phase", record.PhaseID, "error", err)
+	}
+}
+
+// scoreWithValidationModel is the default PhaseConfidenceScorer. It makes a
+// schema-constrained call to validation.model.
+func (we *WorkflowExecutor) scoreWithValidationModel(ctx context.Context, req PhaseConfidenceRequest) (*PhaseConfidenceScore, error) {
+	cfg, err := llmkit.BuildConfig(ProviderClaude, req.Model, we.effectiveWorkingDir(), llmkit.RuntimeConfig{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build validation client config: %w", err)
+	}
+	cfg.BinaryPath = we.claudePath
+
+	client, err := llmkit.New(ProviderClaude, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create validation client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	result, err := llmutil.ExecuteWithSchema[PhaseConfidenceScore](ctx, client, buildConfidencePrompt(req), phaseConfidenceSchema)
+	if err != nil {
+		return nil, err
+	}
+	return &result.Data, nil
+}
+
+func buildConfidencePrompt(req PhaseConfidenceRequest) string {
+	var sb strings.Builder
+	sb.WriteString("You are reviewing the output of one phase of an automated software task. ")
+	sb.WriteString("Rate how confident you are that it is correct, complete, and safe to build on, and list the concrete risk factors.\n\n")
+	fmt.Fprintf(&sb, "## Task %s: %s\n\n", req.TaskID, req.TaskTitle)
+	if desc := strings.TrimSpace(req.TaskDescription); desc != "" {
+		fmt.Fprintf(&sb, "%s\n\n", desc)
+	}
+	output := strings.TrimSpace(req.Output)
+	if len(output) > confidenceOutputLimit {
+		output = output[:confidenceOutputLimit] + "\n... (truncated)"
+	}
+	fmt.Fprintf(&sb, "## %s Phase Output\n\n%s\n\n", req.PhaseID, output)
+	sb.WriteString("Score low when the output skips requirements, claims work without evidence, leaves open questions, or touches risky areas (migrations, auth, concurrency, public APIs) without tests.\n")
+	return sb.String()
+}
+
+// escalateGateForConfidence turns an auto gate into a human gate when the
+// phase's confidence score is too low.
+func (we *WorkflowExecutor) escalateGateForConfidence(phaseID string, current gate.GateType) gate.GateType {
+	if current == gate.GateAuto && we.confidenceRequiresHumanGate(phaseID) {
+		return gate.GateHuman
+	}
+	return current
+}
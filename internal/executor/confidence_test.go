@@ -0,0 +1,139 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/gate"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func confidenceConfig() *config.Config {
+	return &config.Config{Validation: config.ValidationConfig{
+		Enabled:    true,
+		Model:      "haiku",
+		Confidence: config.ConfidenceConfig{Enabled: true, EscalateBelow: 0.6},
+	}}
+}
+
+func fixedScorer(confidence float64, err error) PhaseConfidenceScorer {
+	return func(ctx context.Context, req PhaseConfidenceRequest) (*PhaseConfidenceScore, error) {
+		if err != nil {
+			return nil, err
+		}
+		return &PhaseConfidenceScore{Confidence: confidence, RiskFactors: []string{"untested edge case"}, Rationale: "partial"}, nil
+	}
+}
+
+func newConfidenceTestTask(t *testing.T, backend *storage.DatabaseBackend, id string) *orcv1.Task {
+	t.Helper()
+	tsk := task.NewProtoTask(id, "Add caching")
+	if err := backend.SaveTask(tsk); err != nil {
+		t.Fatalf("save task: %v", err)
+	}
+	return tsk
+}
+
+func TestScorePhaseConfidence_LowScoreEscalatesAutoGate(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewTestBackend(t)
+	tsk := newConfidenceTestTask(t, backend, "TASK-CONF-001")
+
+	we := NewWorkflowExecutor(
+		backend, backend.DB(), testGlobalDBFrom(backend), confidenceConfig(), t.TempDir(),
+		WithWorkflowLogger(slog.Default()),
+		WithWorkflowConfidenceScorer(fixedScorer(0.3, nil)),
+	)
+	we.scorePhaseConfidence(context.Background(), tsk, "implement", "implemented the cache")
+
+	if got := we.escalateGateForConfidence("implement", gate.GateAuto); got != gate.GateHuman {
+		t.Errorf("gate = %v, want human for low confidence", got)
+	}
+	if got := we.escalateGateForConfidence("implement", gate.GateAI); got != gate.GateAI {
+		t.Errorf("gate = %v, want non-auto gates left alone", got)
+	}
+
+	scores, err := backend.DB().GetPhaseConfidences(tsk.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	score := scores["implement"]
+	if score == nil || score.Confidence != 0.3 || !score.Escalated || score.Model != "haiku" {
+		t.Fatalf("persisted score = %+v, want 0.3 escalated by haiku", score)
+	}
+	if len(score.RiskFactors) != 1 || score.RiskFactors[0] != "untested edge case" {
+		t.Errorf("risk factors = %v", score.RiskFactors)
+	}
+}
+
+func TestScorePhaseConfidence_HighScoreKeepsAutoGate(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewTestBackend(t)
+	tsk := newConfidenceTestTask(t, backend, "TASK-CONF-002")
+
+	we := NewWorkflowExecutor(
+		backend, backend.DB(), testGlobalDBFrom(backend), confidenceConfig(), t.TempDir(),
+		WithWorkflowLogger(slog.Default()),
+		WithWorkflowConfidenceScorer(fixedScorer(0.9, nil)),
+	)
+	we.scorePhaseConfidence(context.Background(), tsk, "implement", "implemented the cache")
+
+	if got := we.escalateGateForConfidence("implement", gate.GateAuto); got != gate.GateAuto {
+		t.Errorf("gate = %v, want auto for high confidence", got)
+	}
+	scores, err := backend.DB().GetPhaseConfidences(tsk.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score := scores["implement"]; score == nil || score.Escalated {
+		t.Errorf("persisted score = %+v, want unescalated score", score)
+	}
+}
+
+func TestScorePhaseConfidence_SkippedWhenDisabledOrFailing(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewTestBackend(t)
+	tsk := newConfidenceTestTask(t, backend, "TASK-CONF-003")
+
+	disabled := confidenceConfig()
+	disabled.Validation.Confidence.Enabled = false
+	for name, we := range map[string]*WorkflowExecutor{
+		"disabled": NewWorkflowExecutor(backend, backend.DB(), testGlobalDBFrom(backend), disabled, t.TempDir(),
+			WithWorkflowLogger(slog.Default()), WithWorkflowConfidenceScorer(fixedScorer(0.1, nil))),
+		"scorer error": NewWorkflowExecutor(backend, backend.DB(), testGlobalDBFrom(backend), confidenceConfig(), t.TempDir(),
+			WithWorkflowLogger(slog.Default()), WithWorkflowConfidenceScorer(fixedScorer(0, errors.New("model unavailable")))),
+	} {
+		we.scorePhaseConfidence(context.Background(), tsk, "implement", "output")
+		if got := we.escalateGateForConfidence("implement", gate.GateAuto); got != gate.GateAuto {
+			t.Errorf("%s: gate = %v, want auto", name, got)
+		}
+	}
+
+	scores, err := backend.DB().GetPhaseConfidences(tsk.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scores) != 0 {
+		t.Errorf("scores = %v, want none recorded", scores)
+	}
+}
+
+func TestBuildConfidencePrompt(t *testing.T) {
+	prompt := buildConfidencePrompt(PhaseConfidenceRequest{
+		TaskID:    "TASK-001",
+		TaskTitle: "Add caching",
+		PhaseID:   "implement",
+		Output:    strings.Repeat("x", confidenceOutputLimit+10),
+	})
+	for _, want := range []string{"## Task TASK-001: Add caching", "## implement Phase Output", "(truncated)"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
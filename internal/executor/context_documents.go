@@ -0,0 +1,81 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/lessons"
+	"github.com/randalmurphal/orc/internal/variable"
+)
+
+// populateContextDocuments sets rctx.ContextDocuments to the project context
+// documents that apply to phaseID.
+func (we *WorkflowExecutor) populateContextDocuments(rctx *variable.ResolutionContext, phaseID string) {
+	rctx.ContextDocuments = ""
+	if we.projectDB == nil {
+		return
+	}
+	docs, err := we.projectDB.ListContextDocuments()
+	if err != nil {
+		we.logger.Warn("failed to load context documents", "phase", phaseID, "error", err)
+		return
+	}
+	rctx.ContextDocuments = formatContextDocuments(docs, phaseID)
+}
+
+// refreshLessons regenerates the lessons context document from recurring
+// gate rejections and review comments (brief.lessons). Failures are logged:
+// a stale lessons document never blocks a run.
+func (we *WorkflowExecutor) refreshLessons() {
+	if we.projectDB == nil || we.orcConfig == nil || !we.orcConfig.Brief.Lessons.Enabled {
+		return
+	}
+	before, err := we.projectDB.GetContextDocument(lessons.DocumentName)
+	if err != nil {
+		we.logger.Warn("failed to load lessons document", "error", err)
+		return
+	}
+	doc, _, err := lessons.Refresh(we.projectDB, we.orcConfig.Brief.Lessons, time.Now())
+	if err != nil {
+		we.logger.Warn("failed to refresh lessons document", "error", err)
+		return
+	}
+	if doc != nil && (before == nil || before.Version != doc.Version) {
+		we.logger.Info("lessons document updated", "version", doc.Version)
+	}
+}
+
+// formatContextDocuments renders the documents that apply to phase as one
+// markdown section each, in name order.
+func formatContextDocuments(docs []*db.ContextDocument, phase string) string {
+	var sb strings.Builder
+	for _, doc := range docs {
+		if !doc.AppliesTo(phase) || strings.TrimSpace(doc.Content) == "" {
+			continue
+		}
+		if sb.Len() == 0 {
+			sb.WriteString("## Project Context Documents\n")
+		}
+		fmt.Fprintf(&sb, "\n### %s\n\n%s\n", doc.Name, strings.TrimSpace(doc.Content))
+	}
+	return sb.String()
+}
+
+// autoContextVariables are appended to a rendered prompt, in order, when
+// its template does not place them itself.
+var autoContextVariables = []string{"CONTEXT_DOCUMENTS", "RELATED_TASKS"}
+
+// withAutoContext appends the non-empty autoContextVariables that the
+// prompt template does not reference to the rendered prompt.
+func withAutoContext(rendered, template string, vars variable.VariableSet) string {
+	for _, name := range autoContextVariables {
+		value := vars[name]
+		if value == "" || strings.Contains(template, "{{"+name+"}}") {
+			continue
+		}
+		rendered = strings.TrimRight(rendered, "\n") + "\n\n" + value
+	}
+	return rendered
+}
@@ -0,0 +1,53 @@
+package executor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/variable"
+)
+
+func TestFormatContextDocuments(t *testing.T) {
+	t.Parallel()
+	docs := []*db.ContextDocument{
+		{Name: "architecture.md", Content: "Layers: api -> executor -> db\n"},
+		{Name: "conventions.md", Content: "Wrap errors with %w.", Phases: []string{"implement", "review"}},
+		{Name: "empty.md", Content: "  "},
+	}
+
+	spec := formatContextDocuments(docs, "spec")
+	if !strings.Contains(spec, "### architecture.md") || strings.Contains(spec, "conventions.md") || strings.Contains(spec, "empty.md") {
+		t.Errorf("spec phase documents:\n%s", spec)
+	}
+	implement := formatContextDocuments(docs, "implement")
+	if !strings.HasPrefix(implement, "## Project Context Documents") ||
+		strings.Index(implement, "architecture.md") > strings.Index(implement, "conventions.md") {
+		t.Errorf("implement phase documents:\n%s", implement)
+	}
+	if got := formatContextDocuments(docs[1:2], "spec"); got != "" {
+		t.Errorf("no applicable documents = %q, want empty", got)
+	}
+}
+
+func TestWithAutoContext(t *testing.T) {
+	t.Parallel()
+	vars := variable.VariableSet{"CONTEXT_DOCUMENTS": "## Project Context Documents\n"}
+
+	if got := withAutoContext("Do the task.\n", "Do the task.\n", vars); got != "Do the task.\n\n## Project Context Documents\n" {
+		t.Errorf("appended prompt = %q", got)
+	}
+	// A template that places the documents itself is left as rendered
+	tmpl := "{{CONTEXT_DOCUMENTS}}\nDo the task."
+	if got := withAutoContext("rendered", tmpl, vars); got != "rendered" {
+		t.Errorf("placed prompt = %q, want unchanged", got)
+	}
+	if got := withAutoContext("rendered", "x", variable.VariableSet{}); got != "rendered" {
+		t.Errorf("no documents prompt = %q, want unchanged", got)
+	}
+
+	vars["RELATED_TASKS"] = "## Related Recent Tasks\n"
+	if got := withAutoContext("Do it.", "Do it.", vars); got != "Do it.\n\n## Project Context Documents\n\n## Related Recent Tasks\n" {
+		t.Errorf("both sections prompt = %q", got)
+	}
+}
@@ -132,6 +132,12 @@ func (p *prTestProvider) UpdatePRBranch(context.Context, int) error {
 func (p *prTestProvider) DeleteBranch(context.Context, string) error {
 	return fmt.Errorf("not implemented")
 }
+func (p *prTestProvider) GetBranchProtection(context.Context, string) (*hosting.BranchProtection, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+func (p *prTestProvider) CreateIssue(context.Context, hosting.IssueCreateOptions) (*hosting.Issue, error) {
+	return nil, fmt.Errorf("not implemented")
+}
 func (p *prTestProvider) CheckAuth(context.Context) error { return nil }
 func (p *prTestProvider) Name() hosting.ProviderType      { return "mock" }
 func (p *prTestProvider) OwnerRepo() (string, string)     { return "owner", "repo" }
@@ -743,3 +749,31 @@ func TestCreatePR_PRTitleFormat(t *testing.T) {
 		}
 	})
 }
+
+// TestCreatePR_IncludesSBOMDelta verifies that the dependency delta recorded
+// at completion is stored as an attachment and summarized in the PR body.
+func TestCreatePR_IncludesSBOMDelta(t *testing.T) {
+	t.Parallel()
+	env := setupCreatePRTest(t)
+	env.we.orcConfig.Compliance.SBOM = true
+
+	dir := env.gitOps.Context().WorkDir()
+	if err := os.WriteFile(filepath.Join(dir, "requirements.txt"), []byte("requests==2.31.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGitInDir(t, dir, "add", ".")
+	runGitInDir(t, dir, "commit", "-m", "[orc] TASK-001: add requests")
+
+	env.we.recordCompletionSBOM(env.task, env.gitOps, "main", true)
+	if _, _, err := env.we.backend.GetAttachment(env.task.Id, SBOMDeltaAttachment); err != nil {
+		t.Fatalf("SBOM delta attachment: %v", err)
+	}
+
+	if err := env.we.createPR(context.Background(), env.task, env.gitOps, "main"); err != nil {
+		t.Fatalf("createPR() error: %v", err)
+	}
+	body := env.mock.createPRCalls[0].Body
+	if !strings.Contains(body, "## Dependency changes") || !strings.Contains(body, "`requests` 2.31.0") {
+		t.Errorf("PR body missing dependency changes:\n%s", body)
+	}
+}
@@ -0,0 +1,303 @@
+// This file contains the DoneCriteriaRunner for workflow-level definition-of-done checks.
+package executor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/randalmurphal/orc/internal/workflow"
+)
+
+// ErrDoneCriteriaFailed is returned when a blocking workflow done criterion
+// is not met at completion.
+var ErrDoneCriteriaFailed = errors.New("done criteria not met")
+
+// DefaultDoneCriterionTimeout bounds commands run by done criteria.
+const DefaultDoneCriterionTimeout = 5 * time.Minute
+
+var (
+	defaultDocsPaths      = []string{"docs/**", "*.md"}
+	defaultChangelogPaths = []string{"CHANGELOG.md"}
+	defaultTodoPattern    = `\b(TODO|FIXME)\b`
+	coveragePercentRegex  = regexp.MustCompile(`(\d+(?:\.\d+)?)%`)
+)
+
+// DoneCriterionResult holds the outcome of a single done criterion.
+type DoneCriterionResult struct {
+	Name      string        `json:"name"`
+	Type      string        `json:"type"`
+	Passed    bool          `json:"passed"`
+	Detail    string        `json:"detail,omitempty"`
+	OnFailure string        `json:"on_failure"` // "block" or "warn"
+	Duration  time.Duration `json:"duration"`
+}
+
+// DoneCriteriaResult holds the results of all done criteria for a workflow.
+type DoneCriteriaResult struct {
+	Criteria  []DoneCriterionResult `json:"criteria"`
+	AllPassed bool                  `json:"all_passed"`
+	HasBlocks bool                  `json:"has_blocks"` // True if any blocking criterion failed
+}
+
+// Failed returns the criteria that did not pass.
+func (r *DoneCriteriaResult) Failed() []DoneCriterionResult {
+	var failed []DoneCriterionResult
+	for _, c := range r.Criteria {
+		if !c.Passed {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// FailureSummary names each blocking criterion that failed and why.
+func (r *DoneCriteriaResult) FailureSummary() string {
+	var parts []string
+	for _, c := range r.Failed() {
+		if c.OnFailure == "warn" {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", c.Name, c.Detail))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// doneCriteriaGit is the subset of git.Context the runner needs.
+type doneCriteriaGit interface {
+	RunGit(args ...string) (string, error)
+	WorkDir() string
+}
+
+// DoneCriteriaRunner evaluates workflow done criteria against the changes
+// between a base ref and HEAD.
+type DoneCriteriaRunner struct {
+	git    doneCriteriaGit
+	base   string
+	shell  string
+	logger *slog.Logger
+
+	changedFiles []string
+	addedLines   []string
+	diffLoaded   bool
+	diffErr      error
+}
+
+// NewDoneCriteriaRunner creates a runner comparing HEAD against base.
+func NewDoneCriteriaRunner(g doneCriteriaGit, base string, logger *slog.Logger) *DoneCriteriaRunner {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &DoneCriteriaRunner{
+		git:    g,
+		base:   base,
+		shell:  detectShell(),
+		logger: logger,
+	}
+}
+
+// Run evaluates every criterion, in order.
+func (r *DoneCriteriaRunner) Run(ctx context.Context, criteria []workflow.DoneCriterion) *DoneCriteriaResult {
+	result := &DoneCriteriaResult{AllPassed: true}
+	for _, c := range criteria {
+		start := time.Now()
+		cr := DoneCriterionResult{
+			Name:      c.DisplayName(),
+			Type:      string(c.Type),
+			OnFailure: c.OnFailure,
+		}
+		if cr.OnFailure == "" {
+			cr.OnFailure = "block"
+		}
+		cr.Passed, cr.Detail = r.evaluate(ctx, c)
+		cr.Duration = time.Since(start)
+
+		if !cr.Passed {
+			result.AllPassed = false
+			if cr.OnFailure == "block" {
+				result.HasBlocks = true
+			}
+			r.logger.Info("done criterion failed", "name", cr.Name, "detail", cr.Detail)
+		}
+		result.Criteria = append(result.Criteria, cr)
+	}
+	return result
+}
+
+func (r *DoneCriteriaRunner) evaluate(ctx context.Context, c workflow.DoneCriterion) (bool, string) {
+	switch c.Type {
+	case workflow.DoneCriterionCoverage:
+		return r.checkCoverage(ctx, c)
+	case workflow.DoneCriterionCommand:
+		ok, output := r.runCommand(ctx, c)
+		if !ok {
+			return false, "command failed: " + truncateCheckOutput(strings.TrimSpace(output), 500)
+		}
+		return true, "command succeeded"
+	case workflow.DoneCriterionDocsUpdated:
+		return r.checkPathsChanged(c.Paths, defaultDocsPaths)
+	case workflow.DoneCriterionChangelog:
+		return r.checkPathsChanged(c.Paths, defaultChangelogPaths)
+	case workflow.DoneCriterionNoTodos:
+		return r.checkNoTodos(c.Pattern)
+	default:
+		return false, fmt.Sprintf("unknown criterion type %q", c.Type)
+	}
+}
+
+func (r *DoneCriteriaRunner) checkCoverage(ctx context.Context, c workflow.DoneCriterion) (bool, string) {
+	ok, output := r.runCommand(ctx, c)
+	if !ok {
+		return false, "coverage command failed: " + truncateCheckOutput(strings.TrimSpace(output), 500)
+	}
+	pct, found := parseCoverage(output, c.Pattern)
+	if !found {
+		return false, "no coverage percentage found in command output"
+	}
+	if pct < c.MinPercent {
+		return false, fmt.Sprintf("coverage %.1f%% is below the %.1f%% floor", pct, c.MinPercent)
+	}
+	return true, fmt.Sprintf("coverage %.1f%% (floor %.1f%%)", pct, c.MinPercent)
+}
+
+// parseCoverage extracts a coverage percentage from output. With a pattern,
+// its first capture group is used; otherwise the last "NN.N%" wins, which is
+// where most tools print the total.
+func parseCoverage(output, pattern string) (float64, bool) {
+	var value string
+	if pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return 0, false
+		}
+		matches := re.FindAllStringSubmatch(output, -1)
+		if len(matches) == 0 || len(matches[len(matches)-1]) < 2 {
+			return 0, false
+		}
+		value = matches[len(matches)-1][1]
+	} else {
+		matches := coveragePercentRegex.FindAllStringSubmatch(output, -1)
+		if len(matches) == 0 {
+			return 0, false
+		}
+		value = matches[len(matches)-1][1]
+	}
+	pct, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	if err != nil {
+		return 0, false
+	}
+	return pct, true
+}
+
+func (r *DoneCriteriaRunner) checkPathsChanged(paths, defaults []string) (bool, string) {
+	if len(paths) == 0 {
+		paths = defaults
+	}
+	changed, _, err := r.loadDiff()
+	if err != nil {
+		return false, err.Error()
+	}
+	for _, file := range changed {
+		for _, pattern := range paths {
+			if matched, _ := doublestar.Match(pattern, file); matched {
+				return true, "changed " + file
+			}
+		}
+	}
+	return false, fmt.Sprintf("no changed file matches %s", strings.Join(paths, ", "))
+}
+
+func (r *DoneCriteriaRunner) checkNoTodos(pattern string) (bool, string) {
+	if pattern == "" {
+		pattern = defaultTodoPattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, fmt.Sprintf("invalid pattern: %v", err)
+	}
+	_, added, err := r.loadDiff()
+	if err != nil {
+		return false, err.Error()
+	}
+	var hits []string
+	for _, line := range added {
+		if re.MatchString(line) {
+			hits = append(hits, strings.TrimSpace(line))
+		}
+	}
+	if len(hits) == 0 {
+		return true, "no new markers"
+	}
+	detail := fmt.Sprintf("%d added line(s) match %s", len(hits), pattern)
+	if len(hits) > 3 {
+		hits = hits[:3]
+	}
+	return false, detail + ": " + strings.Join(hits, " | ")
+}
+
+// loadDiff reads the changed file list and added lines once per run.
+func (r *DoneCriteriaRunner) loadDiff() ([]string, []string, error) {
+	if r.diffLoaded {
+		return r.changedFiles, r.addedLines, r.diffErr
+	}
+	r.diffLoaded = true
+
+	rangeSpec := r.base + "...HEAD"
+	names, err := r.git.RunGit("diff", "--name-only", rangeSpec)
+	if err != nil {
+		r.diffErr = fmt.Errorf("diff against %s: %w", r.base, err)
+		return nil, nil, r.diffErr
+	}
+	for _, line := range strings.Split(names, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			r.changedFiles = append(r.changedFiles, filepath.ToSlash(line))
+		}
+	}
+
+	patch, err := r.git.RunGit("diff", "-U0", "--no-color", rangeSpec)
+	if err != nil {
+		r.diffErr = fmt.Errorf("diff against %s: %w", r.base, err)
+		return nil, nil, r.diffErr
+	}
+	for _, line := range strings.Split(patch, "\n") {
+		if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
+			r.addedLines = append(r.addedLines, line[1:])
+		}
+	}
+	return r.changedFiles, r.addedLines, nil
+}
+
+func (r *DoneCriteriaRunner) runCommand(ctx context.Context, c workflow.DoneCriterion) (bool, string) {
+	timeout := DefaultDoneCriterionTimeout
+	if c.TimeoutMs > 0 {
+		timeout = time.Duration(c.TimeoutMs) * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r.logger.Debug("running done criterion", "name", c.DisplayName(), "command", c.Command)
+	cmd := exec.CommandContext(ctx, r.shell, "-c", c.Command)
+	cmd.Dir = r.git.WorkDir()
+	// Set GOWORK=off to avoid go.work issues in worktrees
+	cmd.Env = append(os.Environ(), "GOWORK=off")
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return false, fmt.Sprintf("%s\n[TIMEOUT] Command exceeded %v timeout", out.String(), timeout)
+	}
+	return err == nil, out.String()
+}
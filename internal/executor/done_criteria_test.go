@@ -0,0 +1,130 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/workflow"
+)
+
+// execGit runs git in a directory, satisfying doneCriteriaGit.
+type execGit struct{ dir string }
+
+func (g execGit) RunGit(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.dir
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+func (g execGit) WorkDir() string { return g.dir }
+
+func setupDoneCriteriaRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := initTestRepo(dir); err != nil {
+		t.Fatalf("init repo: %v", err)
+	}
+	if err := runGitCmd(dir, "checkout", "-q", "-b", "orc/TASK-001"); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := runGitCmd(dir, "add", "."); err != nil {
+		t.Fatal(err)
+	}
+	if err := runGitCmd(dir, "commit", "-q", "-m", "task work"); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestDoneCriteriaRunner(t *testing.T) {
+	dir := setupDoneCriteriaRepo(t, map[string]string{
+		"main.go":       "package main\n\n// TODO: handle errors\nfunc main() {}\n",
+		"docs/usage.md": "# Usage\n",
+	})
+	runner := NewDoneCriteriaRunner(execGit{dir}, "main", nil)
+
+	result := runner.Run(context.Background(), []workflow.DoneCriterion{
+		{Type: workflow.DoneCriterionDocsUpdated},
+		{Type: workflow.DoneCriterionChangelog},
+		{Type: workflow.DoneCriterionNoTodos, OnFailure: "warn"},
+		{Type: workflow.DoneCriterionCoverage, Command: "echo 'total: (statements) 72.5%'", MinPercent: 80},
+		{Type: workflow.DoneCriterionCommand, Name: "lint", Command: "true"},
+	})
+
+	want := map[string]bool{
+		"docs_updated": true,
+		"changelog":    false,
+		"no_todos":     false,
+		"coverage":     false,
+		"lint":         true,
+	}
+	if len(result.Criteria) != len(want) {
+		t.Fatalf("got %d results, want %d", len(result.Criteria), len(want))
+	}
+	for _, c := range result.Criteria {
+		if c.Passed != want[c.Name] {
+			t.Errorf("%s passed = %v, want %v (%s)", c.Name, c.Passed, want[c.Name], c.Detail)
+		}
+	}
+	if result.AllPassed || !result.HasBlocks {
+		t.Errorf("AllPassed=%v HasBlocks=%v, want false/true", result.AllPassed, result.HasBlocks)
+	}
+
+	summary := result.FailureSummary()
+	if !strings.Contains(summary, "changelog") || !strings.Contains(summary, "72.5%") {
+		t.Errorf("summary %q should name changelog and coverage", summary)
+	}
+	if strings.Contains(summary, "no_todos") {
+		t.Errorf("summary %q should not include warn-only criteria", summary)
+	}
+}
+
+func TestDoneCriteriaRunner_AllPass(t *testing.T) {
+	dir := setupDoneCriteriaRepo(t, map[string]string{
+		"CHANGELOG.md": "## Unreleased\n- Fixed things\n",
+		"lib.go":       "package lib\n",
+	})
+	runner := NewDoneCriteriaRunner(execGit{dir}, "main", nil)
+
+	result := runner.Run(context.Background(), []workflow.DoneCriterion{
+		{Type: workflow.DoneCriterionChangelog},
+		{Type: workflow.DoneCriterionNoTodos},
+		{Type: workflow.DoneCriterionCoverage, Command: "echo 'coverage=91'", Pattern: `coverage=(\d+)`, MinPercent: 90},
+	})
+	if !result.AllPassed {
+		t.Errorf("expected all criteria to pass: %+v", result.Criteria)
+	}
+}
+
+func TestParseCoverage(t *testing.T) {
+	tests := []struct {
+		output  string
+		pattern string
+		want    float64
+		found   bool
+	}{
+		{"ok pkg/a 10.0%\nok pkg/b 55.5%\n", "", 55.5, true},
+		{"Lines: 88.25% (1234/1400)", `Lines: ([\d.]+)%`, 88.25, true},
+		{"no numbers here", "", 0, false},
+	}
+	for _, tt := range tests {
+		got, found := parseCoverage(tt.output, tt.pattern)
+		if got != tt.want || found != tt.found {
+			t.Errorf("parseCoverage(%q, %q) = %v, %v; want %v, %v", tt.output, tt.pattern, got, found, tt.want, tt.found)
+		}
+	}
+}
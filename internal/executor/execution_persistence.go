@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/breaker"
 	"github.com/randalmurphal/orc/internal/db"
 )
 
@@ -41,9 +42,12 @@ func (we *WorkflowExecutor) saveTaskStrict(t *orcv1.Task, action string) error {
 	if we.backend == nil {
 		return fmt.Errorf("%s: backend not configured", action)
 	}
-	if err := we.backend.SaveTask(t); err != nil {
+	err := we.backend.SaveTask(t)
+	breaker.Get(breaker.Database).Record(err)
+	if err != nil {
 		return fmt.Errorf("%s: %w", action, err)
 	}
+	we.recordStateSnapshot(t, action)
 	return nil
 }
 
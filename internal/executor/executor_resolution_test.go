@@ -7,6 +7,7 @@ import (
 
 	"github.com/randalmurphal/orc/internal/config"
 	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/task"
 	"github.com/randalmurphal/orc/internal/workflow"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -854,3 +855,169 @@ func TestResolvePhaseProvider(t *testing.T) {
 		assert.Equal(t, "claude", provider)
 	})
 }
+
+func TestGetEffectivePhaseRuntimeConfig_AgentTools(t *testing.T) {
+	t.Run("agent tools become the allow-list", func(t *testing.T) {
+		env := setupTestExecutor(t, nil)
+
+		testAgent := &db.Agent{
+			ID:           "readonly-executor",
+			Name:         "Read-only Executor",
+			Tools:        []string{"Read", "Grep", "Glob"},
+			SystemPrompt: "You only read code.",
+		}
+		require.NoError(t, env.projectDB.SaveAgent(testAgent))
+
+		tmpl := &db.PhaseTemplate{ID: "review"}
+		phase := &db.WorkflowPhase{AgentOverride: "readonly-executor"}
+
+		cfg, err := env.executor.getEffectivePhaseRuntimeConfig(tmpl, phase)
+		require.NoError(t, err)
+
+		require.NotNil(t, cfg)
+		assert.Equal(t, []string{"Read", "Grep", "Glob"}, cfg.Shared.AllowedTools)
+		assert.Equal(t, "You only read code.", cfg.Shared.SystemPrompt)
+	})
+
+	t.Run("configured allowed_tools are narrowed to agent tools", func(t *testing.T) {
+		env := setupTestExecutor(t, nil)
+
+		testAgent := &db.Agent{
+			ID:    "readonly-executor",
+			Name:  "Read-only Executor",
+			Tools: []string{"Read", "Grep", "Glob"},
+		}
+		require.NoError(t, env.projectDB.SaveAgent(testAgent))
+
+		tmpl := &db.PhaseTemplate{ID: "review", AgentID: "readonly-executor"}
+		phase := &db.WorkflowPhase{
+			RuntimeConfigOverride: `{"shared":{"allowed_tools":["Grep","Edit","Read"]}}`,
+		}
+
+		cfg, err := env.executor.getEffectivePhaseRuntimeConfig(tmpl, phase)
+		require.NoError(t, err)
+
+		require.NotNil(t, cfg)
+		assert.Equal(t, []string{"Grep", "Read"}, cfg.Shared.AllowedTools)
+	})
+
+	t.Run("errors when allowed_tools has no overlap with agent tools", func(t *testing.T) {
+		env := setupTestExecutor(t, nil)
+
+		testAgent := &db.Agent{
+			ID:    "readonly-executor",
+			Name:  "Read-only Executor",
+			Tools: []string{"Read"},
+		}
+		require.NoError(t, env.projectDB.SaveAgent(testAgent))
+
+		tmpl := &db.PhaseTemplate{ID: "review", AgentID: "readonly-executor"}
+		phase := &db.WorkflowPhase{
+			RuntimeConfigOverride: `{"shared":{"allowed_tools":["Edit","Write"]}}`,
+		}
+
+		_, err := env.executor.getEffectivePhaseRuntimeConfig(tmpl, phase)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no overlap")
+	})
+}
+
+func TestResolvePhaseModel_AgentOverride(t *testing.T) {
+	t.Run("phase agent override model beats workflow default model", func(t *testing.T) {
+		env := setupTestExecutor(t, nil)
+
+		testAgent := &db.Agent{
+			ID:    "fast-executor",
+			Name:  "Fast Executor",
+			Model: "haiku",
+		}
+		require.NoError(t, env.projectDB.SaveAgent(testAgent))
+
+		env.executor.wf = &workflow.Workflow{
+			ID:           "test-workflow",
+			DefaultModel: "sonnet",
+		}
+
+		tmpl := &db.PhaseTemplate{ID: "docs"}
+		phase := &db.WorkflowPhase{AgentOverride: "fast-executor"}
+
+		model, err := env.executor.resolvePhaseModel(tmpl, phase)
+		require.NoError(t, err)
+
+		assert.Equal(t, "haiku", model)
+	})
+
+	t.Run("phase model override beats agent override model", func(t *testing.T) {
+		env := setupTestExecutor(t, nil)
+
+		testAgent := &db.Agent{
+			ID:    "fast-executor",
+			Name:  "Fast Executor",
+			Model: "haiku",
+		}
+		require.NoError(t, env.projectDB.SaveAgent(testAgent))
+
+		tmpl := &db.PhaseTemplate{ID: "docs"}
+		phase := &db.WorkflowPhase{AgentOverride: "fast-executor", ModelOverride: "opus"}
+
+		model, err := env.executor.resolvePhaseModel(tmpl, phase)
+		require.NoError(t, err)
+
+		assert.Equal(t, "opus", model)
+	})
+}
+
+func TestResolvePhaseModel_PhaseModels(t *testing.T) {
+	t.Run("task phase model beats workflow phase override", func(t *testing.T) {
+		env := setupTestExecutor(t, nil)
+		env.executor.task = task.NewProtoTask("TASK-001", "Models")
+		task.SetPhaseModels(env.executor.task, map[string]string{"spec": "opus"})
+
+		model, err := env.executor.resolvePhaseModel(&db.PhaseTemplate{ID: "spec"}, &db.WorkflowPhase{ModelOverride: "sonnet"})
+		require.NoError(t, err)
+		assert.Equal(t, "opus", model)
+
+		// Phases the task does not name fall through
+		model, err = env.executor.resolvePhaseModel(&db.PhaseTemplate{ID: "implement"}, &db.WorkflowPhase{ModelOverride: "sonnet"})
+		require.NoError(t, err)
+		assert.Equal(t, "sonnet", model)
+	})
+
+	t.Run("config phase models beat workflow default model", func(t *testing.T) {
+		cfg := config.Default()
+		cfg.PhaseModels = map[string]map[string]string{
+			"test-workflow":        {"implement": "sonnet"},
+			config.AllWorkflowsKey: {"docs": "haiku", "implement": "opus"},
+		}
+		env := setupTestExecutor(t, cfg)
+		env.executor.wf = &workflow.Workflow{ID: "test-workflow", DefaultModel: "opus"}
+
+		model, err := env.executor.resolvePhaseModel(&db.PhaseTemplate{ID: "implement"}, &db.WorkflowPhase{})
+		require.NoError(t, err)
+		assert.Equal(t, "sonnet", model)
+
+		model, err = env.executor.resolvePhaseModel(&db.PhaseTemplate{ID: "docs"}, &db.WorkflowPhase{})
+		require.NoError(t, err)
+		assert.Equal(t, "haiku", model)
+
+		// A workflow phase override is more specific than the config defaults
+		model, err = env.executor.resolvePhaseModel(&db.PhaseTemplate{ID: "docs"}, &db.WorkflowPhase{ModelOverride: "opus"})
+		require.NoError(t, err)
+		assert.Equal(t, "opus", model)
+	})
+
+	t.Run("provider tuple in task phase model selects provider", func(t *testing.T) {
+		env := setupTestExecutor(t, nil)
+		env.executor.task = task.NewProtoTask("TASK-001", "Models")
+		task.SetPhaseModels(env.executor.task, map[string]string{"implement": "codex:gpt-5.3-codex"})
+
+		tmpl := &db.PhaseTemplate{ID: "implement", Provider: "claude"}
+		provider, err := env.executor.resolvePhaseProvider(tmpl, &db.WorkflowPhase{})
+		require.NoError(t, err)
+		assert.Equal(t, ProviderCodex, provider)
+
+		model, err := env.executor.resolvePhaseModel(tmpl, &db.WorkflowPhase{})
+		require.NoError(t, err)
+		assert.Equal(t, "gpt-5.3-codex", model)
+	})
+}
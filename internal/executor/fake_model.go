@@ -0,0 +1,240 @@
+// fake_model.go answers phases from fixture files instead of a provider.
+//
+// With fake_model set in config, every phase turn and every AI gate is
+// answered from scripted fixtures, so the whole execute → gate → finalize
+// pipeline runs end to end (worktree, commits, verification, retries,
+// completion) with no network and no cost. Unlike an injected TurnExecutor,
+// the rest of the executor behaves exactly as with a real model.
+package executor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/gate"
+)
+
+// FakeModelName is reported as the model of every fixture response.
+const FakeModelName = "orc-fake-model"
+
+// fakeFixtureDefault is the fixture used for phases without their own file.
+const fakeFixtureDefault = "default"
+
+// FakeFixture scripts one phase. The fixture file for phase "implement" is
+// implement.yaml in the fixture directory; default.yaml covers the rest.
+type FakeFixture struct {
+	// Turns answers the phase's turns in order: the Nth turn of the phase
+	// in a run (counting retries and loops) gets Turns[N-1]. The last
+	// entry repeats once the list runs out.
+	Turns []FakeTurn `yaml:"turns"`
+	// Gates answers the phase's AI gate evaluations the same way. Without
+	// entries AI gates approve.
+	Gates []FakeGate `yaml:"gates,omitempty"`
+}
+
+// FakeTurn is one scripted model response.
+type FakeTurn struct {
+	Response string `yaml:"response"`
+	// Files are written (relative to the working directory) before the
+	// response is returned, standing in for the model's edits.
+	Files map[string]string `yaml:"files,omitempty"`
+}
+
+// FakeGate is one scripted AI gate decision.
+type FakeGate struct {
+	Approved bool   `yaml:"approved"`
+	Reason   string `yaml:"reason,omitempty"`
+}
+
+// FakeModel is a deterministic model backend driven by fixture files.
+type FakeModel struct {
+	fixtures map[string]*FakeFixture
+
+	mu    sync.Mutex
+	turns map[string]int
+	gates map[string]int
+}
+
+// LoadFakeModel reads every *.yaml fixture in dir.
+func LoadFakeModel(dir string) (*FakeModel, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read fake model fixtures: %w", err)
+	}
+	fm := &FakeModel{fixtures: map[string]*FakeFixture{}, turns: map[string]int{}, gates: map[string]int{}}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".yaml" {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read fake model fixture: %w", err)
+		}
+		fixture := &FakeFixture{}
+		if err := yaml.Unmarshal(data, fixture); err != nil {
+			return nil, fmt.Errorf("parse fake model fixture %s: %w", path, err)
+		}
+		if len(fixture.Turns) == 0 {
+			return nil, fmt.Errorf("fake model fixture %s has no turns", path)
+		}
+		fm.fixtures[strings.TrimSuffix(e.Name(), ".yaml")] = fixture
+	}
+	if len(fm.fixtures) == 0 {
+		return nil, fmt.Errorf("no fake model fixtures (*.yaml) in %s", dir)
+	}
+	return fm, nil
+}
+
+func (fm *FakeModel) fixture(phase string) (*FakeFixture, error) {
+	if f, ok := fm.fixtures[phase]; ok {
+		return f, nil
+	}
+	if f, ok := fm.fixtures[fakeFixtureDefault]; ok {
+		return f, nil
+	}
+	return nil, fmt.Errorf("no fake model fixture for phase %s (add %s.yaml or %s.yaml)", phase, phase, fakeFixtureDefault)
+}
+
+// nextTurn returns the scripted response for the phase's next turn.
+func (fm *FakeModel) nextTurn(phase string) (FakeTurn, error) {
+	f, err := fm.fixture(phase)
+	if err != nil {
+		return FakeTurn{}, err
+	}
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	n := fm.turns[phase]
+	fm.turns[phase]++
+	return f.Turns[min(n, len(f.Turns)-1)], nil
+}
+
+// nextGate returns the scripted decision for the phase's next AI gate.
+func (fm *FakeModel) nextGate(phase string) FakeGate {
+	f, err := fm.fixture(phase)
+	if err != nil || len(f.Gates) == 0 {
+		return FakeGate{Approved: true, Reason: "fake model: approved"}
+	}
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	n := fm.gates[phase]
+	fm.gates[phase]++
+	return f.Gates[min(n, len(f.Gates)-1)]
+}
+
+// TurnExecutor returns an executor answering cfg.PhaseID's turns. Like the
+// real executors it stores the prompt and response as transcripts.
+func (fm *FakeModel) TurnExecutor(cfg TurnExecutorConfig) TurnExecutor {
+	te := &fakeTurnExecutor{model: fm, phase: cfg.PhaseID, workDir: cfg.WorkingDir, sessionID: cfg.SessionID}
+	if te.sessionID == "" {
+		te.sessionID = "fake-" + cfg.TaskID + "-" + cfg.PhaseID
+	}
+	if cfg.Backend != nil && cfg.TaskID != "" {
+		te.transcripts = NewTranscriptStreamHandler(cfg.Backend, cfg.Logger, cfg.TaskID, cfg.PhaseID, te.sessionID, cfg.RunID, FakeModelName, cfg.Publisher, nil)
+	}
+	return te
+}
+
+// GateEvaluator answers AI gates from the fixtures and passes every other
+// gate type to next.
+func (fm *FakeModel) GateEvaluator(next GateEvaluatorInterface) GateEvaluatorInterface {
+	return &fakeGateEvaluator{model: fm, next: next}
+}
+
+type fakeTurnExecutor struct {
+	model       *FakeModel
+	phase       string
+	workDir     string
+	sessionID   string
+	transcripts *TranscriptStreamHandler
+}
+
+func (te *fakeTurnExecutor) ExecuteTurn(ctx context.Context, prompt string) (*TurnResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	turn, err := te.model.nextTurn(te.phase)
+	if err != nil {
+		return &TurnResult{IsError: true, ErrorText: err.Error()}, err
+	}
+	if err := te.writeFiles(turn.Files); err != nil {
+		return &TurnResult{IsError: true, ErrorText: err.Error()}, err
+	}
+
+	// Token counts follow text length so cost tracking sees stable numbers
+	usage := &orcv1.TokenUsage{
+		InputTokens:  int32(len(prompt)/4 + 1),
+		OutputTokens: int32(len(turn.Response)/4 + 1),
+	}
+	usage.TotalTokens = usage.InputTokens + usage.OutputTokens
+	if te.transcripts != nil {
+		te.transcripts.StoreUserPrompt(prompt)
+		te.transcripts.StoreAssistantTextWithUsage(turn.Response, FakeModelName, "", int(usage.InputTokens), int(usage.OutputTokens), 0, 0)
+		if err := te.transcripts.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return &TurnResult{
+		Content:   turn.Response,
+		NumTurns:  1,
+		Usage:     usage,
+		SessionID: te.sessionID,
+	}, nil
+}
+
+func (te *fakeTurnExecutor) ExecuteTurnWithoutSchema(ctx context.Context, prompt string) (*TurnResult, error) {
+	return te.ExecuteTurn(ctx, prompt)
+}
+
+func (te *fakeTurnExecutor) UpdateSessionID(id string) {
+	if id == "" {
+		return
+	}
+	te.sessionID = id
+	if te.transcripts != nil {
+		te.transcripts.UpdateSessionID(id)
+	}
+}
+
+func (te *fakeTurnExecutor) SessionID() string { return te.sessionID }
+
+// writeFiles applies a turn's file edits inside the working directory.
+func (te *fakeTurnExecutor) writeFiles(files map[string]string) error {
+	for rel, content := range files {
+		if !filepath.IsLocal(rel) {
+			return fmt.Errorf("fake model fixture file %q is outside the working directory", rel)
+		}
+		path := filepath.Join(te.workDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("write fixture file %s: %w", rel, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("write fixture file %s: %w", rel, err)
+		}
+	}
+	return nil
+}
+
+type fakeGateEvaluator struct {
+	model *FakeModel
+	next  GateEvaluatorInterface
+}
+
+func (e *fakeGateEvaluator) Evaluate(ctx context.Context, g *gate.Gate, output string) (*gate.Decision, error) {
+	return e.EvaluateWithOptions(ctx, g, output, &gate.EvaluateOptions{})
+}
+
+func (e *fakeGateEvaluator) EvaluateWithOptions(ctx context.Context, g *gate.Gate, output string, opts *gate.EvaluateOptions) (*gate.Decision, error) {
+	if g.Type != gate.GateAI {
+		return e.next.EvaluateWithOptions(ctx, g, output, opts)
+	}
+	d := e.model.nextGate(opts.Phase)
+	return &gate.Decision{Approved: d.Approved, Reason: d.Reason}, nil
+}
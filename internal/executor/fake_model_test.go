@@ -0,0 +1,171 @@
+package executor
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/gate"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+const fakeImplementComplete = `{"status": "complete", "summary": "Added greeting", "verification": {` +
+	`"tests": {"status": "PASS"}, "success_criteria": [{"id": "SC-1", "status": "PASS"}],` +
+	`"build": {"status": "PASS"}, "linting": {"status": "PASS"}, "wiring": {"status": "PASS"},` +
+	`"browser_validation": {"browser_surface_change": false, "required": false, "performed": false, "reason": "no UI", "evidence": ""},` +
+	`"canonical_associations": [], "provenance_variants": [], "ui_invalidation_paths": []}}`
+
+func writeFakeFixtures(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestFakeModel_DrivesWorkflowFromFixtures(t *testing.T) {
+	t.Parallel()
+
+	fixtures := writeFakeFixtures(t, map[string]string{
+		"implement.yaml": `turns:
+  # No verification evidence: the implement verification gate sends it back
+  - response: '{"status": "complete", "summary": "Added greeting"}'
+  - response: '` + fakeImplementComplete + `'
+    files:
+      greeting.txt: "hello\n"
+`,
+		"review.yaml": `turns:
+  - response: '{"needs_changes": true, "summary": "Missing newline handling"}'
+  - response: '{"needs_changes": false, "summary": "All good"}'
+`,
+	})
+
+	backend := storage.NewTestBackend(t)
+	setupLoopWorkflow(t, backend, replayLoopConfig)
+	tsk := setupTaskForLoop(t, backend, "TASK-FAKE-001")
+
+	workDir := t.TempDir()
+	we := NewWorkflowExecutor(
+		backend, backend.DB(), testGlobalDBFrom(backend), &config.Config{FakeModel: fixtures}, workDir,
+		WithWorkflowLogger(slog.Default()),
+		WithSkipGates(true),
+	)
+	if _, err := we.Run(context.Background(), "loop-wf", WorkflowRunOptions{
+		ContextType: ContextTask,
+		TaskID:      tsk.Id,
+		Prompt:      "add a greeting",
+	}); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+
+	got, err := backend.LoadTask(tsk.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != orcv1.TaskStatus_TASK_STATUS_COMPLETED {
+		t.Errorf("status = %s, want completed", got.Status)
+	}
+	if data, err := os.ReadFile(filepath.Join(workDir, "greeting.txt")); err != nil || string(data) != "hello\n" {
+		t.Errorf("fixture file = %q, %v", data, err)
+	}
+
+	// implement: rejected completion, accepted completion, then the loop
+	// back from review reuses the last turn
+	transcripts, err := backend.GetTranscripts(tsk.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prompts := map[string]int{}
+	for _, tr := range transcripts {
+		if tr.Type == "user" {
+			prompts[tr.Phase]++
+		}
+		if tr.Type == "assistant" && tr.Model != FakeModelName {
+			t.Errorf("assistant transcript model = %q", tr.Model)
+		}
+	}
+	if prompts["implement"] != 3 || prompts["review"] != 2 {
+		t.Errorf("turns per phase = %v, want implement 3, review 2", prompts)
+	}
+}
+
+func TestFakeModel_AIGatesFromFixtures(t *testing.T) {
+	t.Parallel()
+
+	fm, err := LoadFakeModel(writeFakeFixtures(t, map[string]string{
+		"default.yaml": `turns:
+  - response: '{"status": "complete"}'
+gates:
+  - approved: false
+    reason: needs tests
+  - approved: true
+    reason: lgtm
+`,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := &fakeGateRecorder{}
+	eval := fm.GateEvaluator(next)
+	opts := &gate.EvaluateOptions{Phase: "review"}
+
+	var reasons []string
+	for range 3 {
+		d, err := eval.EvaluateWithOptions(context.Background(), &gate.Gate{Type: gate.GateAI}, "", opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reasons = append(reasons, d.Reason)
+	}
+	if got := strings.Join(reasons, ","); got != "needs tests,lgtm,lgtm" {
+		t.Errorf("AI gate reasons = %s", got)
+	}
+
+	if _, err := eval.EvaluateWithOptions(context.Background(), &gate.Gate{Type: gate.GateHuman}, "", opts); err != nil || next.calls != 1 {
+		t.Errorf("human gate was not passed through (calls %d, err %v)", next.calls, err)
+	}
+}
+
+func TestLoadFakeModel_Errors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := LoadFakeModel(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("missing directory loaded")
+	}
+	if _, err := LoadFakeModel(writeFakeFixtures(t, map[string]string{"implement.yaml": "turns: []\n"})); err == nil {
+		t.Error("fixture without turns loaded")
+	}
+
+	fm, err := LoadFakeModel(writeFakeFixtures(t, map[string]string{"implement.yaml": "turns:\n  - response: ok\n"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	te := fm.TurnExecutor(TurnExecutorConfig{PhaseID: "review"})
+	if _, err := te.ExecuteTurn(context.Background(), "go"); err == nil || !strings.Contains(err.Error(), "review.yaml") {
+		t.Errorf("phase without fixture: err = %v", err)
+	}
+
+	escape := &fakeTurnExecutor{workDir: t.TempDir()}
+	if err := escape.writeFiles(map[string]string{"../outside": "x"}); err == nil {
+		t.Error("fixture file outside the working directory was written")
+	}
+}
+
+type fakeGateRecorder struct{ calls int }
+
+func (r *fakeGateRecorder) Evaluate(ctx context.Context, g *gate.Gate, output string) (*gate.Decision, error) {
+	return r.EvaluateWithOptions(ctx, g, output, nil)
+}
+
+func (r *fakeGateRecorder) EvaluateWithOptions(context.Context, *gate.Gate, string, *gate.EvaluateOptions) (*gate.Decision, error) {
+	r.calls++
+	return &gate.Decision{Approved: true}, nil
+}
@@ -29,6 +29,7 @@ import (
 // 5. Run full test suite
 // 6. Perform risk assessment
 // 7. Create finalization commit
+// 8. Record the SBOM delta as a task attachment (compliance.sbom)
 //
 // This executor supports retry/escalation back to the implement phase if
 // issues persist beyond configured thresholds.
@@ -304,6 +305,9 @@ func (e *FinalizeExecutor) Execute(ctx context.Context, t *orcv1.Task, p *PhaseD
 		result.Output = buildFinalizeReport(t.Id, targetBranch, finalizeResult)
 	}
 
+	// Step 7: Record the dependency delta against the target branch
+	e.recordSBOM(t, targetBranch)
+
 	result.Status = orcv1.PhaseStatus_PHASE_STATUS_COMPLETED
 	result.Duration = time.Since(start)
 
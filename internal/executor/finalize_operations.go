@@ -53,6 +53,21 @@ func (e *FinalizeExecutor) assessRisk(result *FinalizeResult, targetBranch strin
 	return nil
 }
 
+// recordSBOM stores the task's SBOM delta against the target branch as a
+// task attachment. Failures are logged and do not fail finalize.
+func (e *FinalizeExecutor) recordSBOM(t *orcv1.Task, targetBranch string) {
+	if e.orcConfig == nil || !e.orcConfig.Compliance.SBOM || e.gitSvc == nil || e.backend == nil {
+		return
+	}
+	delta, err := recordSBOMDelta(e.backend, e.gitSvc.Context(), t.Id, "origin/"+targetBranch)
+	if err != nil {
+		e.logger.Warn("failed to record SBOM delta", "task", t.Id, "error", err)
+		return
+	}
+	e.logger.Info("recorded SBOM delta", "task", t.Id,
+		"added", len(delta.Added), "updated", len(delta.Updated), "removed", len(delta.Removed))
+}
+
 // createFinalizeCommit creates a commit documenting the finalization.
 func (e *FinalizeExecutor) createFinalizeCommit(t *orcv1.Task, result *FinalizeResult) (string, error) {
 	if e.gitSvc == nil {
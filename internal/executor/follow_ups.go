@@ -0,0 +1,253 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/hosting"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// FollowUpsMetadataKey is the task metadata key holding the JSON follow-up list.
+const FollowUpsMetadataKey = "follow_ups"
+
+// Follow-up creation modes (completion.follow_ups.create).
+const (
+	FollowUpCreateNone   = "none"
+	FollowUpCreateTasks  = "tasks"
+	FollowUpCreateIssues = "issues"
+)
+
+// FollowUp is work an agent deliberately deferred ("handle pagination
+// later") instead of doing it in the current task.
+type FollowUp struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	File        string `json:"file,omitempty"`
+	Phase       string `json:"phase,omitempty"`
+	TaskID      string `json:"task_id,omitempty"`   // Pending orc task created for it
+	IssueURL    string `json:"issue_url,omitempty"` // Hosting issue created for it
+}
+
+// followUpsSchemaProperty is the "follow_ups" property shared by the
+// implement and review output schemas.
+const followUpsSchemaProperty = `"follow_ups": {
+			"type": "array",
+			"description": "Work you intentionally deferred and that should be tracked separately (instead of leaving TODO comments).",
+			"items": {
+				"type": "object",
+				"properties": {
+					"title": {"type": "string", "description": "Short imperative title, e.g. 'Handle pagination in ListUsers'"},
+					"description": {"type": "string", "description": "What remains and why it was deferred"},
+					"file": {"type": "string", "description": "Most relevant file path, if any"}
+				},
+				"required": ["title"]
+			}
+		}`
+
+// followUpPhases are the phases whose outputs may carry follow-ups.
+var followUpPhases = map[string]bool{
+	"implement":    true,
+	"review":       true,
+	"review_cross": true,
+}
+
+// ParseFollowUps extracts the follow_ups list from a phase's JSON output.
+func ParseFollowUps(content string) []FollowUp {
+	var payload struct {
+		FollowUps []FollowUp `json:"follow_ups"`
+	}
+	if err := decodeStructuredJSON(content, &payload); err != nil {
+		return nil
+	}
+	followUps := make([]FollowUp, 0, len(payload.FollowUps))
+	for _, f := range payload.FollowUps {
+		f.Title = strings.TrimSpace(f.Title)
+		if f.Title == "" {
+			continue
+		}
+		f.Description = strings.TrimSpace(f.Description)
+		f.File = strings.TrimSpace(f.File)
+		f.TaskID, f.IssueURL = "", ""
+		followUps = append(followUps, f)
+	}
+	return followUps
+}
+
+// GetFollowUpsProto returns the follow-ups recorded on a task.
+func GetFollowUpsProto(t *orcv1.Task) []FollowUp {
+	if t == nil || t.Metadata == nil || t.Metadata[FollowUpsMetadataKey] == "" {
+		return nil
+	}
+	var followUps []FollowUp
+	if err := json.Unmarshal([]byte(t.Metadata[FollowUpsMetadataKey]), &followUps); err != nil {
+		return nil
+	}
+	return followUps
+}
+
+// collectFollowUps gathers follow-ups from the run's phase outputs, merged
+// with any already recorded on the task. Entries are deduplicated by title so
+// a resumed run never tracks the same follow-up twice.
+func collectFollowUps(existing []FollowUp, outputs []*storage.PhaseOutputInfo) []FollowUp {
+	merged := append([]FollowUp(nil), existing...)
+	seen := make(map[string]bool, len(merged))
+	for _, f := range merged {
+		seen[normalizeRecommendationText(f.Title)] = true
+	}
+	for _, output := range outputs {
+		if output == nil || !followUpPhases[output.PhaseTemplateID] {
+			continue
+		}
+		for _, f := range ParseFollowUps(output.Content) {
+			key := normalizeRecommendationText(f.Title)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			f.Phase = output.PhaseTemplateID
+			merged = append(merged, f)
+		}
+	}
+	return merged
+}
+
+// processFollowUps records the run's follow-ups on the task and, depending on
+// completion.follow_ups.create, turns untracked ones into pending orc tasks
+// or hosting issues.
+func (we *WorkflowExecutor) processFollowUps(ctx context.Context, run *db.WorkflowRun, t *orcv1.Task) error {
+	if t == nil || run == nil {
+		return nil
+	}
+
+	outputs, err := we.backend.GetPhaseOutputsForTask(t.Id)
+	if err != nil {
+		return fmt.Errorf("load phase outputs for follow-ups: %w", err)
+	}
+	existing := GetFollowUpsProto(t)
+	followUps := collectFollowUps(existing, filterPhaseOutputsForRun(outputs, run.ID))
+	if len(followUps) == 0 {
+		return nil
+	}
+
+	mode := FollowUpCreateNone
+	var labels []string
+	if we.orcConfig != nil {
+		if we.orcConfig.Completion.FollowUps.Create != "" {
+			mode = we.orcConfig.Completion.FollowUps.Create
+		}
+		labels = we.orcConfig.Completion.FollowUps.Labels
+	}
+
+	var createErr error
+	switch mode {
+	case FollowUpCreateTasks:
+		createErr = we.createFollowUpTasks(t, followUps)
+	case FollowUpCreateIssues:
+		createErr = we.createFollowUpIssues(ctx, t, followUps, labels)
+	}
+
+	data, err := json.Marshal(followUps)
+	if err != nil {
+		return errors.Join(createErr, fmt.Errorf("marshal follow-ups: %w", err))
+	}
+	task.EnsureMetadataProto(t)
+	t.Metadata[FollowUpsMetadataKey] = string(data)
+	if err := we.saveTaskStrict(t, "save task follow-ups"); err != nil {
+		return errors.Join(createErr, err)
+	}
+
+	we.logger.Info("follow-ups recorded",
+		"task_id", t.Id,
+		"count", len(followUps),
+		"new", len(followUps)-len(existing),
+		"create", mode)
+	return createErr
+}
+
+// createFollowUpTasks creates a backlog task for each follow-up that has no
+// tracked work yet. Follow-ups are updated in place with the new task IDs.
+func (we *WorkflowExecutor) createFollowUpTasks(source *orcv1.Task, followUps []FollowUp) error {
+	var errs error
+	for i := range followUps {
+		f := &followUps[i]
+		if f.TaskID != "" || f.IssueURL != "" {
+			continue
+		}
+		id, err := we.backend.GetNextTaskID()
+		if err != nil {
+			return errors.Join(errs, fmt.Errorf("generate follow-up task ID: %w", err))
+		}
+
+		ft := task.NewProtoTask(id, f.Title)
+		task.SetDescriptionProto(ft, buildFollowUpBody(source, f))
+		ft.Queue = orcv1.TaskQueue_TASK_QUEUE_BACKLOG
+		ft.Category = task.GetCategoryProto(source)
+		ft.Metadata["follow_up_of"] = source.Id
+		task.SetWorkflowIDProto(ft, task.GetWorkflowIDProto(source))
+		if initiativeID := task.GetInitiativeIDProto(source); initiativeID != "" {
+			task.SetInitiativeProto(ft, initiativeID)
+		}
+		if targetBranch := task.GetTargetBranchProto(source); targetBranch != "" {
+			ft.TargetBranch = &targetBranch
+		}
+
+		if err := we.backend.SaveTask(ft); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("save follow-up task %q: %w", f.Title, err))
+			continue
+		}
+		f.TaskID = id
+		we.publishTaskUpdated(ft)
+	}
+	return errs
+}
+
+// createFollowUpIssues opens a hosting provider issue for each follow-up that
+// has no tracked work yet.
+func (we *WorkflowExecutor) createFollowUpIssues(ctx context.Context, source *orcv1.Task, followUps []FollowUp, labels []string) error {
+	provider, err := we.getHostingProvider()
+	if err != nil {
+		return fmt.Errorf("hosting provider for follow-up issues: %w", err)
+	}
+
+	var errs error
+	for i := range followUps {
+		f := &followUps[i]
+		if f.TaskID != "" || f.IssueURL != "" {
+			continue
+		}
+		issue, err := provider.CreateIssue(ctx, hosting.IssueCreateOptions{
+			Title:  f.Title,
+			Body:   buildFollowUpBody(source, f),
+			Labels: labels,
+		})
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("create follow-up issue %q: %w", f.Title, err))
+			continue
+		}
+		f.IssueURL = issue.HTMLURL
+	}
+	return errs
+}
+
+func buildFollowUpBody(source *orcv1.Task, f *FollowUp) string {
+	var sb strings.Builder
+	if f.Description != "" {
+		sb.WriteString(f.Description)
+		sb.WriteString("\n\n")
+	}
+	if f.File != "" {
+		fmt.Fprintf(&sb, "File: %s\n", f.File)
+	}
+	fmt.Fprintf(&sb, "Deferred during %s of %s: %s", firstNonEmpty(f.Phase, "execution"), source.Id, source.Title)
+	if prURL := task.GetPRURLProto(source); prURL != "" {
+		fmt.Fprintf(&sb, " (%s)", prURL)
+	}
+	return sb.String()
+}
@@ -0,0 +1,149 @@
+package executor
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+const followUpImplementOutput = `{
+	"status": "complete",
+	"summary": "Added the endpoint.",
+	"follow_ups": [
+		{"title": "Handle pagination in ListUsers", "description": "Returns the first 100 users only.", "file": "internal/api/users.go"},
+		{"title": "   "}
+	]
+}`
+
+const followUpReviewOutput = `{
+	"needs_changes": false,
+	"round": 1,
+	"summary": "Looks good.",
+	"issues": [],
+	"follow_ups": [
+		{"title": "handle  pagination in listusers"},
+		{"title": "Add metrics for user lookups"}
+	]
+}`
+
+func TestCollectFollowUps(t *testing.T) {
+	outputs := []*storage.PhaseOutputInfo{
+		{PhaseTemplateID: "implement", Content: followUpImplementOutput},
+		{PhaseTemplateID: "review", Content: followUpReviewOutput},
+		{PhaseTemplateID: "spec", Content: `{"follow_ups": [{"title": "Not collected from spec"}]}`},
+	}
+
+	got := collectFollowUps([]FollowUp{{Title: "Already tracked", TaskID: "TASK-009"}}, outputs)
+	if len(got) != 3 {
+		t.Fatalf("got %d follow-ups, want 3: %+v", len(got), got)
+	}
+	if got[0].TaskID != "TASK-009" {
+		t.Errorf("existing follow-up lost its task ID: %+v", got[0])
+	}
+	if got[1].Phase != "implement" || got[1].File != "internal/api/users.go" {
+		t.Errorf("implement follow-up = %+v", got[1])
+	}
+	if got[2].Title != "Add metrics for user lookups" || got[2].Phase != "review" {
+		t.Errorf("review follow-up = %+v (duplicate title should be dropped)", got[2])
+	}
+}
+
+func TestProcessFollowUps_CreatesTasks(t *testing.T) {
+	backend, taskItem, run := setupCompletionRecommendationContext(t)
+	saveFollowUpOutput(t, backend, run.ID, taskItem.GetId())
+	// The fixture saves TASK-001 directly; advance the sequence past it.
+	if _, err := backend.GetNextTaskID(); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default()
+	cfg.Completion.FollowUps.Create = FollowUpCreateTasks
+	we := &WorkflowExecutor{backend: backend, orcConfig: cfg, logger: slog.Default()}
+
+	if err := we.processFollowUps(context.Background(), run, taskItem); err != nil {
+		t.Fatalf("processFollowUps: %v", err)
+	}
+	followUps := GetFollowUpsProto(taskItem)
+	if len(followUps) != 1 || followUps[0].TaskID == "" {
+		t.Fatalf("follow-ups = %+v, want one with a created task", followUps)
+	}
+
+	created, err := backend.LoadTask(followUps[0].TaskID)
+	if err != nil {
+		t.Fatalf("load follow-up task: %v", err)
+	}
+	if created.GetTitle() != "Handle pagination in ListUsers" || created.GetMetadata()["follow_up_of"] != taskItem.GetId() {
+		t.Errorf("follow-up task = %q metadata=%v", created.GetTitle(), created.GetMetadata())
+	}
+
+	// Re-processing the same run does not create a second task.
+	if err := we.processFollowUps(context.Background(), run, taskItem); err != nil {
+		t.Fatal(err)
+	}
+	all, err := backend.LoadAllTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 {
+		t.Errorf("task count after re-run = %d, want 2", len(all))
+	}
+}
+
+func TestProcessFollowUps_CreatesIssues(t *testing.T) {
+	backend, taskItem, run := setupCompletionRecommendationContext(t)
+	saveFollowUpOutput(t, backend, run.ID, taskItem.GetId())
+
+	cfg := config.Default()
+	cfg.Completion.FollowUps.Create = FollowUpCreateIssues
+	cfg.Completion.FollowUps.Labels = []string{"follow-up"}
+	provider := &mockProvider{}
+	we := &WorkflowExecutor{backend: backend, orcConfig: cfg, logger: slog.Default(), hostingProvider: provider}
+
+	if err := we.processFollowUps(context.Background(), run, taskItem); err != nil {
+		t.Fatalf("processFollowUps: %v", err)
+	}
+	if len(provider.createdIssues) != 1 || provider.createdIssues[0].Labels[0] != "follow-up" {
+		t.Fatalf("created issues = %+v", provider.createdIssues)
+	}
+	if followUps := GetFollowUpsProto(taskItem); len(followUps) != 1 || followUps[0].IssueURL == "" {
+		t.Errorf("follow-ups = %+v, want issue URL recorded", followUps)
+	}
+}
+
+func TestProcessFollowUps_RecordOnly(t *testing.T) {
+	backend, taskItem, run := setupCompletionRecommendationContext(t)
+	saveFollowUpOutput(t, backend, run.ID, taskItem.GetId())
+
+	we := &WorkflowExecutor{backend: backend, orcConfig: config.Default(), logger: slog.Default()}
+	if err := we.processFollowUps(context.Background(), run, taskItem); err != nil {
+		t.Fatal(err)
+	}
+	followUps := GetFollowUpsProto(taskItem)
+	if len(followUps) != 1 || followUps[0].TaskID != "" || followUps[0].IssueURL != "" {
+		t.Errorf("follow-ups = %+v, want recorded without tracked work", followUps)
+	}
+	reloaded, err := backend.LoadTask(taskItem.GetId())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(GetFollowUpsProto(reloaded)) != 1 {
+		t.Error("follow-ups were not persisted on the task")
+	}
+}
+
+func saveFollowUpOutput(t *testing.T, backend *storage.DatabaseBackend, runID, taskID string) {
+	t.Helper()
+	if err := backend.SavePhaseOutput(&storage.PhaseOutputInfo{
+		WorkflowRunID:   runID,
+		PhaseTemplateID: "implement",
+		TaskID:          &taskID,
+		Content:         followUpImplementOutput,
+		OutputVarName:   "OUTPUT_IMPLEMENT",
+		Source:          "executor",
+	}); err != nil {
+		t.Fatalf("SavePhaseOutput: %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/automation"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/i18n"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// gateTimeoutResolver is the resolved_by of gates decided by gates.timeout.
+const gateTimeoutResolver = "gate_timeout"
+
+// escalateGateTimeout applies gates.timeout to a pending gate approval once
+// per elapsed timeout and returns the approval as it now stands. Fail and
+// approve decide the gate; notify and reassign, and approve for tasks whose
+// weight is not low-risk, raise a notification and keep waiting.
+func (we *WorkflowExecutor) escalateGateTimeout(t *orcv1.Task, approval *db.GateApproval, now time.Time) (*db.GateApproval, error) {
+	if we.orcConfig == nil || approval.Status != db.GateApprovalPending {
+		return approval, nil
+	}
+	cfg := we.orcConfig.Gates.Timeout.ForPhase(approval.Phase)
+	if cfg.After <= 0 || now.Before(approval.RequestedAt.Add(cfg.After*time.Duration(approval.Escalations+1))) {
+		return approval, nil
+	}
+
+	waited := task.FormatDuration(now.Sub(approval.RequestedAt).Truncate(time.Minute))
+	switch cfg.Action {
+	case config.GateTimeoutFail:
+		return we.decideTimedOutGate(approval, false, fmt.Sprintf("rejected: no decision after %s (gates.timeout)", waited))
+	case config.GateTimeoutApprove:
+		weight := we.orcConfig.Weights.WeightForWorkflow(task.GetWorkflowIDProto(t))
+		if weight != "" && slices.Contains(cfg.ApproveWeights, weight) {
+			return we.decideTimedOutGate(approval, true, fmt.Sprintf("auto-approved %s task: no decision after %s (gates.timeout)", weight, waited))
+		}
+	}
+
+	assignee := ""
+	if cfg.Action == config.GateTimeoutReassign {
+		assignee = cfg.ReassignTo
+	}
+	if err := we.projectDB.RecordGateApprovalEscalation(approval.ID, assignee); err != nil {
+		return nil, err
+	}
+	current, err := we.projectDB.GetGateApproval(approval.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("gate approval %s no longer exists", approval.ID)
+	}
+	we.notifyGateTimeout(t, current, waited)
+	return current, nil
+}
+
+// decideTimedOutGate approves or rejects a timed-out gate approval. A human
+// decision that lands first wins.
+func (we *WorkflowExecutor) decideTimedOutGate(approval *db.GateApproval, approved bool, comment string) (*db.GateApproval, error) {
+	resolved, err := we.projectDB.ResolveGateApproval(approval.ID, approved, comment, gateTimeoutResolver)
+	if errors.Is(err, db.ErrGateApprovalNotPending) {
+		return we.projectDB.GetGateApproval(approval.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	we.logger.Info("gate decided by timeout", "task", approval.TaskID, "phase", approval.Phase, "approved", approved)
+	return resolved, nil
+}
+
+// notifyGateTimeout raises a notification for each escalation of a gate,
+// naming its assignee when it has one.
+func (we *WorkflowExecutor) notifyGateTimeout(t *orcv1.Task, approval *db.GateApproval, waited string) {
+	message := i18n.T("notification.gate_timeout.message", t.Id, approval.Phase, waited)
+	if approval.Assignee != "" {
+		message = fmt.Sprintf("@%s: %s", approval.Assignee, message)
+	}
+	notif := &automation.Notification{
+		ID:         fmt.Sprintf("notif-gate-%s-%d", approval.ID, approval.Escalations),
+		Type:       automation.NotificationTypeGateTimeout,
+		Title:      i18n.T("notification.gate_timeout.title", t.Id, approval.Phase, t.Title),
+		Message:    message,
+		SourceType: automation.NotificationSourceTask,
+		SourceID:   t.Id,
+		CreatedAt:  time.Now(),
+	}
+	if err := automation.NewProjectDBAdapter(we.projectDB).CreateNotification(context.Background(), notif); err != nil {
+		we.logger.Warn("failed to create gate timeout notification", "task", t.Id, "phase", approval.Phase, "error", err)
+		return
+	}
+	we.logger.Info("gate timeout escalated", "task", t.Id, "phase", approval.Phase, "escalations", approval.Escalations, "assignee", approval.Assignee)
+}
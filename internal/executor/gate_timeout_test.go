@@ -0,0 +1,121 @@
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/automation"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestEscalateGateTimeout(t *testing.T) {
+	t.Parallel()
+	requested := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		timeout      config.GateTimeoutConfig
+		workflow     string
+		wantStatus   string
+		wantAssignee string
+		wantNotified bool
+	}{
+		{
+			name:         "notify",
+			timeout:      config.GateTimeoutConfig{After: time.Hour, Action: config.GateTimeoutNotify},
+			wantStatus:   db.GateApprovalPending,
+			wantNotified: true,
+		},
+		{
+			name:         "reassign",
+			timeout:      config.GateTimeoutConfig{After: time.Hour, Action: config.GateTimeoutReassign, ReassignTo: "bob"},
+			wantStatus:   db.GateApprovalPending,
+			wantAssignee: "bob",
+			wantNotified: true,
+		},
+		{
+			name:       "approve low-risk task",
+			timeout:    config.GateTimeoutConfig{After: time.Hour, Action: config.GateTimeoutApprove, ApproveWeights: []string{"small"}},
+			workflow:   "implement-small",
+			wantStatus: db.GateApprovalApproved,
+		},
+		{
+			name:         "approve notifies for other tasks",
+			timeout:      config.GateTimeoutConfig{After: time.Hour, Action: config.GateTimeoutApprove, ApproveWeights: []string{"small"}},
+			workflow:     "implement-large",
+			wantStatus:   db.GateApprovalPending,
+			wantNotified: true,
+		},
+		{
+			name:       "fail",
+			timeout:    config.GateTimeoutConfig{After: time.Hour, Action: config.GateTimeoutFail},
+			wantStatus: db.GateApprovalRejected,
+		},
+		{
+			name:       "phase override",
+			timeout:    config.GateTimeoutConfig{After: 24 * time.Hour, PhaseOverrides: map[string]config.GateTimeoutOverride{"review": {After: time.Hour, Action: config.GateTimeoutFail}}},
+			wantStatus: db.GateApprovalRejected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			we, backend, _ := newGateApprovalTestExecutor(t)
+			cfg := config.Default()
+			cfg.Gates.Timeout = tt.timeout
+			we.orcConfig = cfg
+
+			tsk := task.NewProtoTask("TASK-001", "Gated")
+			if tt.workflow != "" {
+				tsk.WorkflowId = &tt.workflow
+			}
+			if err := backend.SaveTask(tsk); err != nil {
+				t.Fatal(err)
+			}
+			pdb := backend.DB()
+			approval := &db.GateApproval{ID: "gate-1", TaskID: tsk.Id, Phase: "review", GateType: "human", RequestedAt: requested}
+			if err := pdb.CreateGateApproval(approval); err != nil {
+				t.Fatal(err)
+			}
+
+			// Before the timeout nothing happens
+			got, err := we.escalateGateTimeout(tsk, approval, requested.Add(30*time.Minute))
+			if err != nil || got.Status != db.GateApprovalPending || got.Escalations != 0 {
+				t.Fatalf("before timeout = %+v, %v", got, err)
+			}
+
+			got, err = we.escalateGateTimeout(tsk, approval, requested.Add(61*time.Minute))
+			if err != nil {
+				t.Fatalf("escalateGateTimeout: %v", err)
+			}
+			if got.Status != tt.wantStatus || got.Assignee != tt.wantAssignee {
+				t.Errorf("approval = %+v, want status %s assignee %q", got, tt.wantStatus, tt.wantAssignee)
+			}
+			if got.Status != db.GateApprovalPending && got.ResolvedBy != gateTimeoutResolver {
+				t.Errorf("resolved_by = %q, want %q", got.ResolvedBy, gateTimeoutResolver)
+			}
+			notified, err := automation.NewProjectDBAdapter(pdb).NotificationExists(context.Background(), "notif-gate-gate-1-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if notified != tt.wantNotified {
+				t.Errorf("notified = %v, want %v", notified, tt.wantNotified)
+			}
+
+			if got.Status == db.GateApprovalPending {
+				// The next escalation waits for another timeout
+				again, err := we.escalateGateTimeout(tsk, got, requested.Add(90*time.Minute))
+				if err != nil || again.Escalations != 1 {
+					t.Errorf("within second timeout = %+v, %v; want 1 escalation", again, err)
+				}
+				again, err = we.escalateGateTimeout(tsk, got, requested.Add(121*time.Minute))
+				if err != nil || again.Escalations != 2 {
+					t.Errorf("after second timeout = %+v, %v; want 2 escalations", again, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package executor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// HermeticCacheDir is the project directory, relative to the project root,
+// that holds tool caches for hermetic quality checks.
+const HermeticCacheDir = ".orc/cache"
+
+// hermeticPassthroughEnv are the variables every hermetic check inherits.
+var hermeticPassthroughEnv = []string{"PATH", "LANG", "LC_ALL", "TERM", "TZ", "USER"}
+
+// hermeticCacheDirs points tool caches at subdirectories of the cache
+// directory, so downloads survive across checks without reading the
+// developer's own caches.
+var hermeticCacheDirs = []struct{ env, dir string }{
+	{"GOPATH", "go"},
+	{"GOMODCACHE", "go/pkg/mod"},
+	{"GOCACHE", "go-build"},
+	{"npm_config_cache", "npm"},
+	{"YARN_CACHE_FOLDER", "yarn"},
+	{"npm_config_store_dir", "pnpm"},
+	{"BUN_INSTALL_CACHE_DIR", "bun"},
+	{"PIP_CACHE_DIR", "pip"},
+	{"CARGO_HOME", "cargo"},
+	{"XDG_CACHE_HOME", "xdg"},
+}
+
+// hermeticEnv builds isolated environments for check commands.
+type hermeticEnv struct {
+	cacheDir string
+	allow    []string
+}
+
+// environ returns the environment for one command: allowlisted variables
+// from base, a fresh HOME and TMPDIR, and the tool caches. cleanup removes
+// the fresh directories.
+func (h *hermeticEnv) environ(base []string) (env []string, cleanup func(), err error) {
+	root, err := os.MkdirTemp("", "orc-check-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("create check home: %w", err)
+	}
+	cleanup = func() { _ = os.RemoveAll(root) }
+	home, tmp := filepath.Join(root, "home"), filepath.Join(root, "tmp")
+	for _, dir := range []string{home, tmp} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("create check home: %w", err)
+		}
+	}
+
+	allowed := make(map[string]bool, len(hermeticPassthroughEnv)+len(h.allow))
+	for _, name := range slices.Concat(hermeticPassthroughEnv, h.allow) {
+		allowed[name] = true
+	}
+	for _, kv := range base {
+		if name, _, ok := strings.Cut(kv, "="); ok && allowed[name] {
+			env = append(env, kv)
+		}
+	}
+	env = append(env, "HOME="+home, "TMPDIR="+tmp, "GOWORK=off")
+	for _, c := range hermeticCacheDirs {
+		dir := filepath.Join(h.cacheDir, filepath.FromSlash(c.dir))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("create cache %s: %w", dir, err)
+		}
+		env = append(env, c.env+"="+dir)
+	}
+	return env, cleanup, nil
+}
@@ -0,0 +1,336 @@
+package executor
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/randalmurphal/orc/internal/git"
+)
+
+// Change kinds reported in an iteration delta.
+const (
+	iterationFileAdded    = "added"
+	iterationFileModified = "modified"
+	iterationFileDeleted  = "deleted"
+	iterationFileReverted = "reverted" // back to its content on the base branch
+)
+
+// Limits that keep continuation prompts from growing with the iteration count.
+const (
+	iterationOutcomeLimit = 120       // one line of the running summary
+	iterationSummaryLimit = 5         // iterations listed individually in the summary
+	iterationDiffLimit    = 16 * 1024 // bytes of diff sent per continuation
+)
+
+// iterationFileChange is one file that changed during an iteration.
+type iterationFileChange struct {
+	Path string
+	Kind string
+}
+
+// iterationSummary is one line of the running summary.
+type iterationSummary struct {
+	Iteration int
+	Changed   []iterationFileChange
+	Outcome   string
+}
+
+// iterationContext tracks what an implementation phase changed between
+// iterations. Continuation prompts carry the diff of the last iteration and
+// a capped running summary of earlier ones, so the agent does not re-read
+// every file on each pass.
+type iterationContext struct {
+	gitCtx   *git.Context
+	workDir  string
+	baseRef  string
+	files    map[string]iterationFileState // changed files at the last iteration boundary
+	contents map[string]string             // text of those files, for the next diff
+	history  []iterationSummary            // at most iterationSummaryLimit entries
+
+	// Iterations folded out of history
+	foldedFrom, foldedTo, foldedChanges int
+}
+
+// newIterationContext starts tracking the worktree of an implementation
+// phase. It returns nil when incremental context is disabled, the phase is
+// not an implementation phase, or the worktree is not a git repository.
+func (we *WorkflowExecutor) newIterationContext(cfg PhaseExecutionConfig) *iterationContext {
+	if we.orcConfig == nil || !we.orcConfig.Execution.IncrementalContext || !isImplementationPhase(cfg.PhaseID) || cfg.WorkingDir == "" {
+		return nil
+	}
+	gitCtx, err := git.NewContext(cfg.WorkingDir)
+	if err != nil {
+		return nil
+	}
+	ic := &iterationContext{
+		gitCtx:  gitCtx,
+		workDir: cfg.WorkingDir,
+		baseRef: firstNonEmpty(we.taskForkPoint(gitCtx), "HEAD"),
+	}
+	files, err := ic.snapshot()
+	if err != nil {
+		we.logger.Debug("incremental context disabled: cannot list changed files", "phase", cfg.PhaseID, "error", err)
+		return nil
+	}
+	ic.files = files
+	ic.contents = ic.readContents(files)
+	return ic
+}
+
+// iterationFileState is a changed file at one iteration boundary.
+type iterationFileState struct {
+	hash  string // content hash, "" when deleted
+	added bool   // absent from the base ref
+}
+
+// snapshot hashes every file that differs from the base ref, including
+// uncommitted and untracked files.
+func (ic *iterationContext) snapshot() (map[string]iterationFileState, error) {
+	return snapshotChangedFiles(ic.gitCtx, ic.workDir, ic.baseRef)
+}
+
+// snapshotChangedFiles hashes every file in workDir that differs from
+// baseRef, including uncommitted and untracked files.
+func snapshotChangedFiles(gitCtx *git.Context, workDir, baseRef string) (map[string]iterationFileState, error) {
+	files := make(map[string]iterationFileState)
+	out, err := gitCtx.RunGit("diff", "--name-status", "--no-renames", "-z", baseRef)
+	if err != nil {
+		return nil, fmt.Errorf("git diff: %w", err)
+	}
+	fields := strings.Split(strings.TrimSuffix(out, "\x00"), "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		files[fields[i+1]] = iterationFileState{added: fields[i] == "A"}
+	}
+	out, err = gitCtx.RunGit("ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, fmt.Errorf("git ls-files: %w", err)
+	}
+	for _, p := range strings.Split(out, "\x00") {
+		if p != "" {
+			files[p] = iterationFileState{added: true}
+		}
+	}
+	for p, state := range files {
+		data, err := os.ReadFile(filepath.Join(workDir, p))
+		if err != nil {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		state.hash = hex.EncodeToString(sum[:])
+		files[p] = state
+	}
+	return files, nil
+}
+
+// continuationPrompt records the iteration that just ended and appends the
+// diff of that iteration and the running summary to the prompt for the next
+// one. feedback is the prompt orc would otherwise send; its first line
+// becomes the iteration's outcome. A nil iterationContext returns feedback
+// unchanged.
+func (ic *iterationContext) continuationPrompt(iteration int, feedback string) string {
+	if ic == nil {
+		return feedback
+	}
+	current, err := ic.snapshot()
+	if err != nil {
+		return feedback
+	}
+	changed := diffIterationSnapshots(ic.files, current)
+	contents := ic.readContents(current)
+	delta := ic.formatDelta(iteration, changed, contents)
+	ic.files = current
+	ic.contents = contents
+	ic.record(iterationSummary{
+		Iteration: iteration,
+		Changed:   changed,
+		Outcome:   iterationOutcome(feedback),
+	})
+	return feedback + "\n\n" + delta + "\n" + ic.formatSummary()
+}
+
+// record appends an iteration to the running summary, folding the oldest
+// entry into the earlier count once the summary is full.
+func (ic *iterationContext) record(summary iterationSummary) {
+	ic.history = append(ic.history, summary)
+	if len(ic.history) <= iterationSummaryLimit {
+		return
+	}
+	oldest := ic.history[0]
+	ic.history = ic.history[1:]
+	if ic.foldedFrom == 0 {
+		ic.foldedFrom = oldest.Iteration
+	}
+	ic.foldedTo = oldest.Iteration
+	ic.foldedChanges += len(oldest.Changed)
+}
+
+// readContents reads the text of every file in a snapshot. Deleted and
+// binary files are left out.
+func (ic *iterationContext) readContents(files map[string]iterationFileState) map[string]string {
+	contents := make(map[string]string, len(files))
+	for p, state := range files {
+		if state.hash == "" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(ic.workDir, p))
+		if err != nil || bytes.IndexByte(data, 0) >= 0 {
+			continue
+		}
+		contents[p] = string(data)
+	}
+	return contents
+}
+
+// previousContent returns a file's text at the last iteration boundary.
+// Files that were unchanged then are read from the base ref.
+func (ic *iterationContext) previousContent(p string) string {
+	if state, ok := ic.files[p]; ok {
+		if state.hash == "" {
+			return ""
+		}
+		return ic.contents[p]
+	}
+	return ic.baseContent(p)
+}
+
+// formatDelta renders the diff of one iteration. Once the diff reaches
+// iterationDiffLimit, the remaining files are listed without their diff.
+func (ic *iterationContext) formatDelta(iteration int, changed []iterationFileChange, contents map[string]string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Changes Since Iteration %d\n\n", iteration)
+	if len(changed) == 0 {
+		sb.WriteString("No files changed in the last iteration.\n")
+		return sb.String()
+	}
+
+	var diff strings.Builder
+	var omitted []string
+	for _, c := range changed {
+		after, text := contents[c.Path]
+		switch c.Kind {
+		case iterationFileReverted:
+			after, text = ic.baseContent(c.Path), true
+		case iterationFileDeleted:
+			text = true
+		}
+		if !text {
+			omitted = append(omitted, fmt.Sprintf("- %s (%s, binary)", c.Path, c.Kind))
+			continue
+		}
+		fileDiff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitDiffLines(ic.previousContent(c.Path)),
+			B:        splitDiffLines(after),
+			FromFile: "a/" + c.Path,
+			ToFile:   "b/" + c.Path,
+			Context:  3,
+		})
+		if err != nil || fileDiff == "" || diff.Len()+len(fileDiff) > iterationDiffLimit {
+			omitted = append(omitted, fmt.Sprintf("- %s (%s)", c.Path, c.Kind))
+			continue
+		}
+		diff.WriteString(fileDiff)
+	}
+
+	sb.WriteString("This is the diff of the last iteration. Every other file is exactly as you last saw it. Work from this diff instead of re-reading files.\n")
+	if diff.Len() > 0 {
+		sb.WriteString("\n```diff\n" + diff.String() + "```\n")
+	}
+	if len(omitted) > 0 {
+		sb.WriteString("\nAlso changed (diff not included):\n\n" + strings.Join(omitted, "\n") + "\n")
+	}
+	return sb.String()
+}
+
+// splitDiffLines splits text into newline-terminated lines.
+func splitDiffLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	for i := range lines {
+		lines[i] += "\n"
+	}
+	return lines
+}
+
+// baseContent returns a file's text on the base ref, or "" when it does not
+// exist there.
+func (ic *iterationContext) baseContent(p string) string {
+	out, err := ic.gitCtx.RunGit("show", ic.baseRef+":"+p)
+	if err != nil {
+		return ""
+	}
+	return out
+}
+
+// diffIterationSnapshots lists the files whose content differs between two
+// snapshots, sorted by path.
+func diffIterationSnapshots(before, after map[string]iterationFileState) []iterationFileChange {
+	var changes []iterationFileChange
+	for p, state := range after {
+		prev, seen := before[p]
+		if seen && prev.hash == state.hash {
+			continue
+		}
+		kind := iterationFileModified
+		switch {
+		case state.hash == "":
+			kind = iterationFileDeleted
+		case state.added && (!seen || prev.hash == ""):
+			kind = iterationFileAdded
+		}
+		changes = append(changes, iterationFileChange{Path: p, Kind: kind})
+	}
+	for p := range before {
+		if _, ok := after[p]; !ok {
+			changes = append(changes, iterationFileChange{Path: p, Kind: iterationFileReverted})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// iterationOutcome summarizes a continuation prompt by its first line.
+func iterationOutcome(feedback string) string {
+	for _, line := range strings.Split(feedback, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+		if line == "" {
+			continue
+		}
+		if len(line) > iterationOutcomeLimit {
+			line = line[:iterationOutcomeLimit] + "..."
+		}
+		return line
+	}
+	return "continued"
+}
+
+// formatSummary renders the running summary: the last
+// iterationSummaryLimit iterations, plus one line for any before them.
+func (ic *iterationContext) formatSummary() string {
+	var sb strings.Builder
+	sb.WriteString("### Running Summary\n\n")
+	if ic.foldedTo > 0 {
+		fmt.Fprintf(&sb, "- Iterations %d-%d: %d file changes\n", ic.foldedFrom, ic.foldedTo, ic.foldedChanges)
+	}
+	for _, h := range ic.history {
+		files := "no file changes"
+		if len(h.Changed) > 0 {
+			names := make([]string, len(h.Changed))
+			for i, c := range h.Changed {
+				names[i] = c.Path
+			}
+			files = "changed " + strings.Join(names, ", ")
+		}
+		fmt.Fprintf(&sb, "- Iteration %d: %s; %s\n", h.Iteration, files, h.Outcome)
+	}
+	return sb.String()
+}
@@ -0,0 +1,122 @@
+package executor
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+)
+
+func TestIterationContext_ReportsDeltaAndSummary(t *testing.T) {
+	t.Parallel()
+	gitCtx := setupBinaryPolicyRepo(t)
+	dir := gitCtx.WorkDir()
+	writeTestFile(t, dir, "lib/old.go", "package lib\n")
+	runGitCmdOrFatal(t, dir, "add", ".")
+	runGitCmdOrFatal(t, dir, "commit", "-m", "add lib")
+
+	we := &WorkflowExecutor{orcConfig: config.Default(), logger: slog.Default()}
+	cfg := PhaseExecutionConfig{PhaseID: "implement", WorkingDir: dir}
+	ic := we.newIterationContext(cfg)
+	if ic == nil {
+		t.Fatal("newIterationContext() = nil for an implement phase in a git repo")
+	}
+
+	// Iteration 1 adds a file and edits a committed one
+	writeTestFile(t, dir, "lib/new.go", "package lib\n\nfunc New() {}\n")
+	writeTestFile(t, dir, "README.md", "# Changed\n")
+	prompt := ic.continuationPrompt(1, "## Quality Check Failures\n\nlint failed")
+	for _, want := range []string{
+		"## Quality Check Failures",
+		"## Changes Since Iteration 1",
+		"+++ b/README.md\n@@ -1 +1 @@\n-# Initial\n+# Changed\n",
+		"+++ b/lib/new.go\n@@ -0,0 +1,3 @@\n+package lib\n+\n+func New() {}\n",
+		"- Iteration 1: changed README.md, lib/new.go; Quality Check Failures",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("iteration 1 prompt missing %q:\n%s", want, prompt)
+		}
+	}
+
+	// Iteration 2 only touches two files; the other is not resent
+	writeTestFile(t, dir, "README.md", "# Initial\n")
+	if err := os.Remove(filepath.Join(dir, "lib/old.go")); err != nil {
+		t.Fatal(err)
+	}
+	prompt = ic.continuationPrompt(2, "Continue working. Iteration 3/5. tests pending")
+	if strings.Contains(prompt, "b/lib/new.go") {
+		t.Errorf("unchanged file resent in iteration 2 delta:\n%s", prompt)
+	}
+	for _, want := range []string{
+		"+++ b/README.md\n@@ -1 +1 @@\n-# Changed\n+# Initial\n",
+		"+++ b/lib/old.go\n@@ -1 +0,0 @@\n-package lib\n",
+		"- Iteration 1: changed README.md, lib/new.go",
+		"- Iteration 2: changed README.md, lib/old.go; Continue working.",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("iteration 2 prompt missing %q:\n%s", want, prompt)
+		}
+	}
+
+	// An idle iteration says so
+	prompt = ic.continuationPrompt(3, "Continue.")
+	if !strings.Contains(prompt, "No files changed in the last iteration.") {
+		t.Errorf("iteration 3 prompt:\n%s", prompt)
+	}
+}
+
+func TestIterationContext_CapsSummaryAndDiff(t *testing.T) {
+	t.Parallel()
+	dir := setupBinaryPolicyRepo(t).WorkDir()
+	we := &WorkflowExecutor{orcConfig: config.Default(), logger: slog.Default()}
+	ic := we.newIterationContext(PhaseExecutionConfig{PhaseID: "implement", WorkingDir: dir})
+	if ic == nil {
+		t.Fatal("newIterationContext() = nil")
+	}
+
+	var prompt string
+	for i := 1; i <= iterationSummaryLimit+2; i++ {
+		writeTestFile(t, dir, "step.txt", strings.Repeat("x", i)+"\n")
+		prompt = ic.continuationPrompt(i, "Continue.")
+	}
+	if !strings.Contains(prompt, "- Iterations 1-2: 2 file changes\n") {
+		t.Errorf("early iterations not folded:\n%s", prompt)
+	}
+	if strings.Contains(prompt, "- Iteration 1:") || strings.Contains(prompt, "- Iteration 2:") {
+		t.Errorf("folded iterations still listed:\n%s", prompt)
+	}
+	if n := strings.Count(prompt, "- Iteration "); n != iterationSummaryLimit {
+		t.Errorf("summary lists %d iterations, want %d:\n%s", n, iterationSummaryLimit, prompt)
+	}
+
+	// A diff over the limit is listed by name only
+	writeTestFile(t, dir, "big.txt", strings.Repeat("line\n", iterationDiffLimit))
+	prompt = ic.continuationPrompt(iterationSummaryLimit+3, "Continue.")
+	if !strings.Contains(prompt, "- big.txt (added)") || strings.Contains(prompt, "+++ b/big.txt") {
+		t.Errorf("oversized diff not omitted:\n%.500s", prompt)
+	}
+}
+
+func TestNewIterationContext_OnlyImplementation(t *testing.T) {
+	t.Parallel()
+	dir := setupBinaryPolicyRepo(t).WorkDir()
+	cfg := config.Default()
+	we := &WorkflowExecutor{orcConfig: cfg, logger: slog.Default()}
+
+	if ic := we.newIterationContext(PhaseExecutionConfig{PhaseID: "review", WorkingDir: dir}); ic != nil {
+		t.Error("review phase should not track iterations")
+	}
+	cfg.Execution.IncrementalContext = false
+	if ic := we.newIterationContext(PhaseExecutionConfig{PhaseID: "implement", WorkingDir: dir}); ic != nil {
+		t.Error("disabled incremental context should not track iterations")
+	}
+
+	// A nil context leaves prompts alone
+	var ic *iterationContext
+	if got := ic.continuationPrompt(1, "Continue."); got != "Continue." {
+		t.Errorf("nil continuationPrompt() = %q", got)
+	}
+}
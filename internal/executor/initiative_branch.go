@@ -0,0 +1,282 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/hosting"
+	"github.com/randalmurphal/orc/internal/initiative"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// InitiativeRollup summarizes the member tasks of an initiative with an
+// integration branch (branch_base).
+type InitiativeRollup struct {
+	InitiativeID string                 `json:"initiative_id"`
+	Branch       string                 `json:"branch"`
+	Target       string                 `json:"target"`
+	PRNumber     int                    `json:"pr_number,omitempty"`
+	PRURL        string                 `json:"pr_url,omitempty"`
+	MergeStatus  string                 `json:"merge_status,omitempty"`
+	Total        int                    `json:"total"`
+	Merged       int                    `json:"merged"`
+	Completed    int                    `json:"completed"`
+	Running      int                    `json:"running"`
+	Failed       int                    `json:"failed"`
+	Tasks        []InitiativeRollupTask `json:"tasks"`
+}
+
+// InitiativeRollupTask is one member task in an InitiativeRollup.
+type InitiativeRollupTask struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	PRURL  string `json:"pr_url,omitempty"`
+	Merged bool   `json:"merged"`
+	// WaitingOn lists the members that must merge before this one.
+	WaitingOn []string `json:"waiting_on,omitempty"`
+}
+
+// AllMerged reports whether every member task has merged into the
+// integration branch.
+func (r *InitiativeRollup) AllMerged() bool {
+	return r.Total > 0 && r.Merged == r.Total
+}
+
+// taskPRMerged reports whether a task's PR has merged.
+func taskPRMerged(t *orcv1.Task) bool {
+	return t.GetPr() != nil && (t.Pr.Merged || t.Pr.Status == orcv1.PRStatus_PR_STATUS_MERGED)
+}
+
+// BuildInitiativeRollup summarizes an initiative's member tasks in
+// initiative order.
+func BuildInitiativeRollup(backend storage.Backend, init *initiative.Initiative, target string) (*InitiativeRollup, error) {
+	r := &InitiativeRollup{
+		InitiativeID: init.ID,
+		Branch:       init.BranchBase,
+		Target:       target,
+		MergeStatus:  init.MergeStatus,
+		Tasks:        []InitiativeRollupTask{},
+	}
+	if pdb := backend.DB(); pdb != nil {
+		pr, err := pdb.GetInitiativePR(init.ID)
+		if err != nil {
+			return nil, err
+		}
+		if pr != nil {
+			r.PRNumber, r.PRURL = pr.PRNumber, pr.PRURL
+		}
+	}
+
+	members := make(map[string]*orcv1.Task, len(init.Tasks))
+	for _, ref := range init.Tasks {
+		t, err := backend.LoadTask(ref.ID)
+		if err != nil || t == nil {
+			continue
+		}
+		members[ref.ID] = t
+	}
+	for _, ref := range init.Tasks {
+		t, ok := members[ref.ID]
+		if !ok {
+			continue
+		}
+		rt := InitiativeRollupTask{
+			ID:     t.Id,
+			Title:  t.Title,
+			Status: task.StatusFromProto(t.Status),
+			PRURL:  task.GetPRURLProto(t),
+			Merged: taskPRMerged(t),
+		}
+		if !rt.Merged {
+			rt.WaitingOn = unmergedPredecessors(t, members)
+		}
+		r.Total++
+		switch {
+		case rt.Merged:
+			r.Merged++
+		case t.Status == orcv1.TaskStatus_TASK_STATUS_COMPLETED:
+			r.Completed++
+		case t.Status == orcv1.TaskStatus_TASK_STATUS_RUNNING:
+			r.Running++
+		case t.Status == orcv1.TaskStatus_TASK_STATUS_FAILED:
+			r.Failed++
+		}
+		r.Tasks = append(r.Tasks, rt)
+	}
+	return r, nil
+}
+
+// unmergedPredecessors returns the members t is blocked by whose PRs have
+// not merged. Members merge into the integration branch in dependency order.
+func unmergedPredecessors(t *orcv1.Task, members map[string]*orcv1.Task) []string {
+	var waiting []string
+	for _, dep := range t.BlockedBy {
+		if m, ok := members[dep]; ok && !taskPRMerged(m) {
+			waiting = append(waiting, dep)
+		}
+	}
+	slices.Sort(waiting)
+	return waiting
+}
+
+// renderInitiativeRollup renders a rollup as the combined PR body.
+func renderInitiativeRollup(init *initiative.Initiative, r *InitiativeRollup) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Initiative: %s\n\n", init.Title)
+	if init.Vision != "" {
+		fmt.Fprintf(&sb, "### Vision\n%s\n\n", init.Vision)
+	}
+	fmt.Fprintf(&sb, "### Status\n%d of %d tasks merged into `%s`", r.Merged, r.Total, r.Branch)
+	if r.Running > 0 || r.Failed > 0 {
+		fmt.Fprintf(&sb, " (%d running, %d failed)", r.Running, r.Failed)
+	}
+	sb.WriteString("\n\n| Task | Status | PR |\n|------|--------|----|\n")
+	for _, t := range r.Tasks {
+		status := t.Status
+		switch {
+		case t.Merged:
+			status = "✅ merged"
+		case len(t.WaitingOn) > 0:
+			status = fmt.Sprintf("%s (after %s)", status, strings.Join(t.WaitingOn, ", "))
+		}
+		pr := t.PRURL
+		if pr == "" {
+			pr = "-"
+		}
+		fmt.Fprintf(&sb, "| %s: %s | %s | %s |\n", t.ID, t.Title, status, pr)
+	}
+	sb.WriteString("\n---\n🤖 Maintained by [orc](https://github.com/randalmurphal/orc)\n")
+	return sb.String()
+}
+
+// SyncCombinedPR keeps the combined PR of an initiative's integration
+// branch current: it is opened once the first member task merges, and its
+// body tracks the status rollup.
+// Once all members have merged, the initiative completion flow takes over.
+// Initiatives without a branch base return a nil rollup.
+func (c *InitiativeCompleter) SyncCombinedPR(ctx context.Context, initiativeID string) (*InitiativeRollup, error) {
+	if c.backend == nil {
+		return nil, fmt.Errorf("storage backend is required")
+	}
+	init, err := c.backend.LoadInitiative(initiativeID)
+	if err != nil {
+		return nil, fmt.Errorf("load initiative %s: %w", initiativeID, err)
+	}
+	if init == nil || !init.HasBranchBase() {
+		return nil, nil
+	}
+
+	rollup, err := BuildInitiativeRollup(c.backend, init, c.getTargetBranch())
+	if err != nil {
+		return nil, err
+	}
+	if c.provider == nil || init.MergeStatus == initiative.MergeStatusMerged || (rollup.PRNumber == 0 && rollup.Merged == 0) {
+		return rollup, nil
+	}
+
+	body := renderInitiativeRollup(init, rollup)
+	if rollup.PRNumber != 0 {
+		if err := c.provider.UpdatePR(ctx, rollup.PRNumber, hosting.PRUpdateOptions{Body: body}); err != nil {
+			return rollup, fmt.Errorf("update combined PR #%d: %w", rollup.PRNumber, err)
+		}
+	} else {
+		pr, err := c.provider.FindPRByBranch(ctx, init.BranchBase)
+		switch {
+		case errors.Is(err, hosting.ErrNoPRFound):
+			pr, err = c.provider.CreatePR(ctx, hosting.PRCreateOptions{
+				Title:  fmt.Sprintf("[initiative] %s", init.Title),
+				Body:   body,
+				Head:   init.BranchBase,
+				Base:   rollup.Target,
+				Labels: c.getPRLabels(),
+			})
+			if err != nil {
+				return rollup, fmt.Errorf("create combined PR: %w", err)
+			}
+		case err != nil:
+			return rollup, fmt.Errorf("find combined PR: %w", err)
+		default:
+			if err := c.provider.UpdatePR(ctx, pr.Number, hosting.PRUpdateOptions{Body: body}); err != nil {
+				return rollup, fmt.Errorf("update combined PR #%d: %w", pr.Number, err)
+			}
+		}
+		record := &db.InitiativePR{
+			InitiativeID: init.ID,
+			PRNumber:     pr.Number,
+			PRURL:        pr.HTMLURL,
+			HeadBranch:   init.BranchBase,
+			BaseBranch:   rollup.Target,
+		}
+		if err := c.backend.DB().SaveInitiativePR(record); err != nil {
+			return rollup, err
+		}
+		rollup.PRNumber, rollup.PRURL = pr.Number, pr.HTMLURL
+		c.logger.Info("combined initiative PR opened", "initiative", init.ID, "pr", pr.Number, "url", pr.HTMLURL)
+	}
+
+	if rollup.AllMerged() {
+		if _, err := c.CheckAndCompleteInitiative(ctx, init.ID); err != nil {
+			return rollup, err
+		}
+	}
+	return rollup, nil
+}
+
+// SyncInitiativeBranch refreshes the combined PR of the initiative t belongs
+// to, if it has an integration branch. Errors are logged, not returned: the
+// combined PR is brought up to date again on the next member change.
+func SyncInitiativeBranch(ctx context.Context, c *InitiativeCompleter, t *orcv1.Task) {
+	initiativeID := task.GetInitiativeIDProto(t)
+	if initiativeID == "" {
+		return
+	}
+	if _, err := c.SyncCombinedPR(ctx, initiativeID); err != nil {
+		c.logger.Warn("failed to sync combined initiative PR", "task", t.Id, "initiative", initiativeID, "error", err)
+	}
+}
+
+// waitForMergeOrder blocks until the initiative members t is blocked by have
+// merged, so member PRs land on the integration branch in dependency order.
+// Tasks outside an initiative with a branch base merge immediately.
+func (m *CIMerger) waitForMergeOrder(ctx context.Context, t *orcv1.Task) error {
+	initiativeID := task.GetInitiativeIDProto(t)
+	if m.backend == nil || initiativeID == "" || len(t.BlockedBy) == 0 {
+		return nil
+	}
+	init, err := m.backend.LoadInitiative(initiativeID)
+	if err != nil || init == nil || !init.HasBranchBase() {
+		return nil
+	}
+
+	ticker := time.NewTicker(m.config.CIPollInterval())
+	defer ticker.Stop()
+	for {
+		rollup, err := BuildInitiativeRollup(m.backend, init, "")
+		if err != nil {
+			return err
+		}
+		var waiting []string
+		for _, rt := range rollup.Tasks {
+			if rt.ID == t.Id {
+				waiting = rt.WaitingOn
+			}
+		}
+		if len(waiting) == 0 {
+			return nil
+		}
+		m.publishProgress(t.Id, fmt.Sprintf("Waiting for %s to merge into %s first...", strings.Join(waiting, ", "), init.BranchBase))
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("merge order: waiting on %s: %w", strings.Join(waiting, ", "), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package executor
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/hosting"
+	"github.com/randalmurphal/orc/internal/initiative"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// newInitiativeBranchBackend saves an initiative on feature/auth with three
+// members: TASK-002 is blocked by TASK-001, TASK-003 is independent.
+func newInitiativeBranchBackend(t *testing.T) storage.Backend {
+	t.Helper()
+	backend := storage.NewTestBackend(t)
+	init := &initiative.Initiative{
+		ID:         "INIT-001",
+		Title:      "User Auth",
+		Status:     initiative.StatusActive,
+		BranchBase: "feature/auth",
+		Tasks: []initiative.TaskRef{
+			{ID: "TASK-001", Title: "Schema"},
+			{ID: "TASK-002", Title: "Login"},
+			{ID: "TASK-003", Title: "Docs"},
+		},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := backend.SaveInitiative(init); err != nil {
+		t.Fatalf("save initiative: %v", err)
+	}
+	for _, ref := range init.Tasks {
+		tk := task.NewProtoTask(ref.ID, ref.Title)
+		tk.Status = orcv1.TaskStatus_TASK_STATUS_RUNNING
+		task.SetInitiativeProto(tk, init.ID)
+		if ref.ID == "TASK-002" {
+			tk.BlockedBy = []string{"TASK-001"}
+		}
+		if err := backend.SaveTask(tk); err != nil {
+			t.Fatalf("save %s: %v", ref.ID, err)
+		}
+	}
+	return backend
+}
+
+func markTaskMerged(t *testing.T, backend storage.Backend, id string) {
+	t.Helper()
+	tk, err := backend.LoadTask(id)
+	if err != nil {
+		t.Fatalf("load %s: %v", id, err)
+	}
+	tk.Status = orcv1.TaskStatus_TASK_STATUS_COMPLETED
+	task.SetMergedInfoProto(tk, "https://github.com/o/r/pull/"+id, "feature/auth")
+	if err := backend.SaveTask(tk); err != nil {
+		t.Fatalf("save %s: %v", id, err)
+	}
+}
+
+func TestBuildInitiativeRollup(t *testing.T) {
+	t.Parallel()
+	backend := newInitiativeBranchBackend(t)
+	init, err := backend.LoadInitiative("INIT-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rollup, err := BuildInitiativeRollup(backend, init, "main")
+	if err != nil {
+		t.Fatalf("BuildInitiativeRollup() error = %v", err)
+	}
+	if rollup.Total != 3 || rollup.Merged != 0 || rollup.Running != 3 || rollup.AllMerged() {
+		t.Errorf("rollup counts = %+v", rollup)
+	}
+	if got := rollup.Tasks[1].WaitingOn; len(got) != 1 || got[0] != "TASK-001" {
+		t.Errorf("TASK-002 WaitingOn = %v, want [TASK-001]", got)
+	}
+
+	markTaskMerged(t, backend, "TASK-001")
+	rollup, err = BuildInitiativeRollup(backend, init, "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rollup.Merged != 1 || !rollup.Tasks[0].Merged || len(rollup.Tasks[1].WaitingOn) != 0 {
+		t.Errorf("after TASK-001 merged: rollup = %+v", rollup)
+	}
+}
+
+func TestSyncCombinedPR(t *testing.T) {
+	t.Parallel()
+	backend := newInitiativeBranchBackend(t)
+	var created []hosting.PRCreateOptions
+	var bodies []string
+	provider := &mockProvider{
+		findPRByBranchFunc: func(context.Context, string) (*hosting.PR, error) {
+			return nil, hosting.ErrNoPRFound
+		},
+		createPRFunc: func(_ context.Context, opts hosting.PRCreateOptions) (*hosting.PR, error) {
+			created = append(created, opts)
+			return &hosting.PR{Number: 42, HTMLURL: "https://github.com/o/r/pull/42", State: "open"}, nil
+		},
+		updatePRFunc: func(_ context.Context, number int, opts hosting.PRUpdateOptions) error {
+			if number != 42 {
+				t.Errorf("UpdatePR number = %d, want 42", number)
+			}
+			bodies = append(bodies, opts.Body)
+			return nil
+		},
+	}
+	cfg := &config.Config{Profile: config.ProfileSafe}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	completer := NewInitiativeCompleter(nil, provider, backend, cfg, logger, "")
+	ctx := context.Background()
+
+	// No member has merged yet: nothing to open
+	if _, err := completer.SyncCombinedPR(ctx, "INIT-001"); err != nil {
+		t.Fatalf("SyncCombinedPR() error = %v", err)
+	}
+	if len(created) != 0 {
+		t.Fatalf("combined PR opened before any member merged")
+	}
+
+	// The first merge opens the combined PR
+	markTaskMerged(t, backend, "TASK-001")
+	rollup, err := completer.SyncCombinedPR(ctx, "INIT-001")
+	if err != nil {
+		t.Fatalf("SyncCombinedPR() error = %v", err)
+	}
+	if len(created) != 1 || created[0].Head != "feature/auth" || created[0].Base != "main" {
+		t.Fatalf("created = %+v, want one PR feature/auth -> main", created)
+	}
+	if !strings.Contains(created[0].Body, "1 of 3 tasks merged") {
+		t.Errorf("PR body missing rollup:\n%s", created[0].Body)
+	}
+	if rollup.PRNumber != 42 {
+		t.Errorf("rollup.PRNumber = %d, want 42", rollup.PRNumber)
+	}
+	record, err := backend.DB().GetInitiativePR("INIT-001")
+	if err != nil || record == nil || record.PRNumber != 42 {
+		t.Fatalf("GetInitiativePR() = %+v, %v", record, err)
+	}
+
+	// Later merges update the same PR
+	markTaskMerged(t, backend, "TASK-002")
+	markTaskMerged(t, backend, "TASK-003")
+	rollup, err = completer.SyncCombinedPR(ctx, "INIT-001")
+	if err != nil {
+		t.Fatalf("SyncCombinedPR() error = %v", err)
+	}
+	if len(created) != 1 || len(bodies) != 1 || !strings.Contains(bodies[0], "3 of 3 tasks merged") {
+		t.Errorf("created = %d, update bodies = %q", len(created), bodies)
+	}
+	if !rollup.AllMerged() {
+		t.Errorf("rollup.AllMerged() = false, rollup = %+v", rollup)
+	}
+
+	// All members merged under a safe profile: the initiative awaits manual merge
+	init, err := backend.LoadInitiative("INIT-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if init.MergeStatus != initiative.MergeStatusPending {
+		t.Errorf("MergeStatus = %q, want %q", init.MergeStatus, initiative.MergeStatusPending)
+	}
+}
+
+func TestWaitForMergeOrder(t *testing.T) {
+	t.Parallel()
+	backend := newInitiativeBranchBackend(t)
+	cfg := &config.Config{}
+	cfg.Completion.CI.PollInterval = 10 * time.Millisecond
+	merger := NewCIMerger(cfg)
+	merger.backend = backend
+
+	tk, err := backend.LoadTask("TASK-002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := merger.waitForMergeOrder(ctx, tk); err == nil || !strings.Contains(err.Error(), "TASK-001") {
+		t.Fatalf("waitForMergeOrder() error = %v, want wait on TASK-001", err)
+	}
+
+	markTaskMerged(t, backend, "TASK-001")
+	if err := merger.waitForMergeOrder(context.Background(), tk); err != nil {
+		t.Errorf("waitForMergeOrder() after predecessor merged: %v", err)
+	}
+}
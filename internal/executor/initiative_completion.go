@@ -5,12 +5,14 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/randalmurphal/orc/internal/config"
 	"github.com/randalmurphal/orc/internal/git"
 	"github.com/randalmurphal/orc/internal/hosting"
 	"github.com/randalmurphal/orc/internal/initiative"
+	"github.com/randalmurphal/orc/internal/retry"
 	"github.com/randalmurphal/orc/internal/storage"
 	"github.com/randalmurphal/orc/internal/task"
 
@@ -199,20 +201,18 @@ func (c *InitiativeCompleter) autoMergeInitiative(ctx context.Context, init *ini
 		return result, nil
 	}
 
-	// Build PR title and body
-	prTitle := fmt.Sprintf("[initiative] %s", init.Title)
-	prBody := c.buildInitiativePRBody(init)
-
-	// Create the PR
-	prOpts := hosting.PRCreateOptions{
-		Title:  prTitle,
-		Body:   prBody,
-		Head:   init.BranchBase,
-		Base:   targetBranch,
-		Labels: c.getPRLabels(),
+	// Reuse the combined PR maintained while members merged, or create one
+	pr, err := c.combinedPR(ctx, init)
+	if err == nil && pr == nil {
+		prOpts := hosting.PRCreateOptions{
+			Title:  fmt.Sprintf("[initiative] %s", init.Title),
+			Body:   c.buildInitiativePRBody(init),
+			Head:   init.BranchBase,
+			Base:   targetBranch,
+			Labels: c.getPRLabels(),
+		}
+		pr, err = c.provider.CreatePR(ctx, prOpts)
 	}
-
-	pr, err := c.provider.CreatePR(ctx, prOpts)
 	if err != nil {
 		c.logger.Error("failed to create initiative PR",
 			"initiative", init.ID,
@@ -276,6 +276,26 @@ func (c *InitiativeCompleter) autoMergeInitiative(ctx context.Context, init *ini
 	return result, nil
 }
 
+// combinedPR returns the open combined PR recorded for an initiative, or nil.
+func (c *InitiativeCompleter) combinedPR(ctx context.Context, init *initiative.Initiative) (*hosting.PR, error) {
+	pdb := c.backend.DB()
+	if pdb == nil {
+		return nil, nil
+	}
+	record, err := pdb.GetInitiativePR(init.ID)
+	if err != nil || record == nil {
+		return nil, err
+	}
+	pr, err := c.provider.GetPR(ctx, record.PRNumber)
+	if err != nil {
+		return nil, fmt.Errorf("get combined PR #%d: %w", record.PRNumber, err)
+	}
+	if !strings.EqualFold(pr.State, "open") {
+		return nil, nil
+	}
+	return pr, nil
+}
+
 // getTargetBranch returns the target branch for merging initiative branches.
 func (c *InitiativeCompleter) getTargetBranch() string {
 	if c.cfg != nil && c.cfg.Completion.TargetBranch != "" {
@@ -406,44 +426,42 @@ func (c *InitiativeCompleter) waitAndMerge(ctx context.Context, init *initiative
 	return mergeCommit, nil
 }
 
-// waitForCI polls CI checks until they pass or timeout.
+// waitForCI polls CI checks until they pass or ctx is done, backing off from
+// backoff.initial to the configured CI poll interval.
 func (c *InitiativeCompleter) waitForCI(ctx context.Context, branch string) error {
-	ticker := time.NewTicker(30 * time.Second)
-	defer ticker.Stop()
+	poll := retry.NewBackoff(c.cfg.CIPollPolicy())
 
 	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-ticker.C:
-			checks, err := c.provider.GetCheckRuns(ctx, branch)
-			if err != nil {
-				c.logger.Warn("failed to get CI status, will retry", "error", err)
-				continue
-			}
-			if len(checks) == 0 {
-				return nil // no checks configured
-			}
+		if err := retry.Sleep(ctx, poll.Next()); err != nil {
+			return err
+		}
+		checks, err := c.provider.GetCheckRuns(ctx, branch)
+		if err != nil {
+			c.logger.Warn("failed to get CI status, will retry", "error", err)
+			continue
+		}
+		if len(checks) == 0 {
+			return nil // no checks configured
+		}
 
-			allComplete := true
-			anyFailed := false
-			for _, check := range checks {
-				if check.Status != "completed" {
-					allComplete = false
-					break
-				}
-				if check.Conclusion == "failure" || check.Conclusion == "cancelled" {
-					anyFailed = true
-				}
-			}
-			if anyFailed {
-				return fmt.Errorf("CI checks failed")
+		allComplete := true
+		anyFailed := false
+		for _, check := range checks {
+			if check.Status != "completed" {
+				allComplete = false
+				break
 			}
-			if allComplete {
-				return nil
+			if check.Conclusion == "failure" || check.Conclusion == "cancelled" {
+				anyFailed = true
 			}
-			c.logger.Debug("CI checks still pending", "branch", branch)
 		}
+		if anyFailed {
+			return fmt.Errorf("CI checks failed")
+		}
+		if allComplete {
+			return nil
+		}
+		c.logger.Debug("CI checks still pending", "branch", branch)
 	}
 }
 
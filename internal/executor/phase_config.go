@@ -35,6 +35,10 @@ type WorktreeBaseConfig struct {
 	MainRepoPath  string
 	TaskID        string
 	AdditionalEnv map[string]string
+	// Phase, when set with MainRepoPath, caches the assembled settings in the
+	// project's RuntimeSettingsDir and records them as the phase's effective
+	// settings.
+	Phase string
 }
 
 // ParsePhaseRuntimeConfig parses a JSON string into PhaseRuntimeConfig.
@@ -281,7 +281,8 @@ const ImplementCompletionSchema = `{
 			"type": "array",
 			"description": "Out-of-scope issues discovered during implementation.",
 			"items": {"type": "string"}
-		}
+		},
+		` + followUpsSchemaProperty + `
 	},
 	"required": ["status"]
 }`
@@ -356,6 +357,11 @@ func GetSchemaForPhaseWithRound(phaseID string, round int, producesArtifact bool
 		return PlanCompletionSchema
 	}
 
+	// Reproduce phase reports the command that runs its regression tests
+	if phaseID == ReproducePhaseID {
+		return ReproduceCompletionSchema
+	}
+
 	// Content-producing phases get schema with content field
 	if producesArtifact {
 		return ContentProducingPhaseSchema
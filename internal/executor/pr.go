@@ -3,6 +3,8 @@ package executor
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
 // ErrSyncConflict is returned when sync encounters merge conflicts.
@@ -24,3 +26,29 @@ const (
 
 // ErrDirectMergeBlocked is returned when direct merge to a protected branch is blocked.
 var ErrDirectMergeBlocked = errors.New("direct merge to protected branch blocked")
+
+// Sources of a DirectMergeBlockedError.
+const (
+	MergeBlockedByConfig = "config" // completion.protected_branches
+	MergeBlockedByRemote = "remote" // hosting provider branch protection
+)
+
+// DirectMergeBlockedError explains why a direct merge was refused before any
+// push was attempted. It matches ErrDirectMergeBlocked with errors.Is.
+type DirectMergeBlockedError struct {
+	Branch  string
+	Source  string
+	Reasons []string
+}
+
+func (e *DirectMergeBlockedError) Error() string {
+	msg := fmt.Sprintf("%s: %s", ErrDirectMergeBlocked, e.Branch)
+	if len(e.Reasons) > 0 {
+		msg += " (" + strings.Join(e.Reasons, "; ") + ")"
+	}
+	return msg + "; use completion action 'pr' for this branch"
+}
+
+func (e *DirectMergeBlockedError) Unwrap() error {
+	return ErrDirectMergeBlocked
+}
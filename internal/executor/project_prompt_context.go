@@ -0,0 +1,149 @@
+package executor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/randalmurphal/orc/internal/claude"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/detect"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/variable"
+)
+
+// loadProjectCommandContext fills TEST_COMMAND/LINT_COMMAND/BUILD_COMMAND
+// from the project_commands table (overriding detection), plus SCRIPTS_LIST,
+// REPO_MAP, and FILE_SUMMARIES, so prompts reference the commands the
+// project actually uses.
+func (we *WorkflowExecutor) loadProjectCommandContext(rctx *variable.ResolutionContext) {
+	var summaryCache fileSummaryCache
+	if dbBackend, ok := storage.DatabaseOf(we.backend); ok {
+		summaryCache = dbBackend.DB()
+		cmds, err := dbBackend.DB().ListProjectCommands()
+		if err != nil {
+			we.logger.Warn("failed to load project commands for prompt context", "error", err)
+		} else {
+			applyProjectCommands(rctx, cmds)
+		}
+	}
+
+	if rctx.ProjectRoot == "" {
+		return
+	}
+	if projectScripts, err := claude.NewScriptService(rctx.ProjectRoot).List(); err != nil {
+		we.logger.Warn("failed to load project scripts for prompt context", "error", err)
+	} else {
+		rctx.ScriptsList = formatScriptsList(projectScripts)
+	}
+	rctx.RepoMap = detect.RepoMap(rctx.ProjectRoot, detect.MaxRepoMapBytes)
+	summaries, err := loadFileSummaries(rctx.ProjectRoot, summaryCache)
+	if err != nil {
+		we.logger.Warn("failed to use file summary cache for prompt context", "error", err)
+	}
+	rctx.FileSummaries = summaries
+}
+
+// fileSummaryCache stores file summaries by content hash across tasks.
+// Satisfied by *db.ProjectDB.
+type fileSummaryCache interface {
+	GetFileSummaries(hashes []string) (map[string]db.FileSummary, error)
+	SaveFileSummaries(summaries []db.FileSummary) error
+}
+
+// loadFileSummaries renders FILE_SUMMARIES for the tracked source files under
+// root. Summaries come from cache by git blob hash; only files with new
+// content are read and summarized, and those are added to the cache. A nil
+// cache summarizes every file. Cache errors are returned alongside a result
+// computed without the cache.
+func loadFileSummaries(root string, cache fileSummaryCache) (string, error) {
+	files := detect.SourceFiles(root)
+	if len(files) == 0 {
+		return "", nil
+	}
+
+	summaries := make(map[string]string, len(files))
+	var cacheErr error
+	if cache != nil {
+		hashes := make([]string, len(files))
+		for i, f := range files {
+			hashes[i] = f.Hash
+		}
+		cached, err := cache.GetFileSummaries(hashes)
+		if err != nil {
+			cacheErr = err
+		}
+		for hash, s := range cached {
+			summaries[hash] = s.Summary
+		}
+	}
+
+	var fresh []db.FileSummary
+	for _, f := range files {
+		if _, ok := summaries[f.Hash]; ok {
+			continue
+		}
+		src, err := os.ReadFile(filepath.Join(root, f.Path))
+		if err != nil || detect.BlobHash(src) != f.Hash {
+			continue // changed since it was listed
+		}
+		summaries[f.Hash] = detect.SummarizeFile(f.Path, src)
+		fresh = append(fresh, db.FileSummary{ContentHash: f.Hash, Language: detect.SourceLanguage(f.Path), Summary: summaries[f.Hash]})
+	}
+	if cache != nil && cacheErr == nil {
+		cacheErr = cache.SaveFileSummaries(fresh)
+	}
+	return detect.FormatFileSummaries(files, summaries, detect.MaxFileSummariesBytes), cacheErr
+}
+
+// applyProjectCommands overrides detected commands with enabled
+// whole-repository project commands. A global (unscoped) command wins over
+// one scoped to the project's primary language.
+func applyProjectCommands(rctx *variable.ResolutionContext, cmds []*db.ProjectCommand) {
+	targets := map[string]*string{
+		"tests": &rctx.TestCommand,
+		"lint":  &rctx.LintCommand,
+		"build": &rctx.BuildCommand,
+	}
+	for name, dst := range targets {
+		var global, scoped string
+		for _, c := range cmds {
+			if c.Name != name || !c.Enabled || c.Command == "" || c.Path != "" {
+				continue
+			}
+			switch c.Scope {
+			case "":
+				global = c.Command
+			case rctx.Language:
+				scoped = c.Command
+			}
+		}
+		if global != "" {
+			*dst = global
+		} else if scoped != "" {
+			*dst = scoped
+		}
+	}
+}
+
+// formatScriptsList renders the project scripts registered in
+// .claude/settings.json (the ConfigService script registry) as a markdown list.
+func formatScriptsList(projectScripts []claude.ProjectScript) string {
+	if len(projectScripts) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	for _, s := range projectScripts {
+		fmt.Fprintf(&sb, "- `%s` (%s", s.Name, s.Path)
+		if s.Language != "" {
+			sb.WriteString(", " + s.Language)
+		}
+		sb.WriteString(")")
+		if s.Description != "" {
+			sb.WriteString(": " + s.Description)
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
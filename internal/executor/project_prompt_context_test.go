@@ -0,0 +1,102 @@
+package executor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/claude"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/variable"
+)
+
+func TestApplyProjectCommands(t *testing.T) {
+	t.Parallel()
+
+	rctx := &variable.ResolutionContext{
+		Language:     "go",
+		TestCommand:  "go test ./...",
+		LintCommand:  "golangci-lint run",
+		BuildCommand: "go build ./...",
+	}
+	applyProjectCommands(rctx, []*db.ProjectCommand{
+		{Name: "tests", Scope: "go", Command: "go test -race ./...", Enabled: true},
+		{Name: "tests", Scope: "", Command: "make test", Enabled: true},
+		{Name: "lint", Scope: "go", Command: "make lint", Enabled: true},
+		{Name: "lint", Scope: "frontend", Command: "npm run lint", Enabled: true},
+		{Name: "build", Scope: "", Command: "make build", Enabled: false},
+	})
+
+	if rctx.TestCommand != "make test" {
+		t.Errorf("TestCommand = %q, want global project command", rctx.TestCommand)
+	}
+	if rctx.LintCommand != "make lint" {
+		t.Errorf("LintCommand = %q, want language-scoped project command", rctx.LintCommand)
+	}
+	if rctx.BuildCommand != "go build ./..." {
+		t.Errorf("BuildCommand = %q, disabled command must not override detection", rctx.BuildCommand)
+	}
+}
+
+func TestFormatScriptsList(t *testing.T) {
+	t.Parallel()
+
+	if got := formatScriptsList(nil); got != "" {
+		t.Errorf("no scripts = %q", got)
+	}
+	got := formatScriptsList([]claude.ProjectScript{
+		{Name: "reset-db", Path: "scripts/reset-db.sh", Language: "bash", Description: "Recreate the dev database"},
+		{Name: "gen", Path: "scripts/gen.py"},
+	})
+	want := "- `reset-db` (scripts/reset-db.sh, bash): Recreate the dev database\n" +
+		"- `gen` (scripts/gen.py)"
+	if got != want {
+		t.Errorf("formatScriptsList =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// countingSummaryCache wraps a project database and counts summaries saved.
+type countingSummaryCache struct {
+	*db.ProjectDB
+	saved int
+}
+
+func (c *countingSummaryCache) SaveFileSummaries(summaries []db.FileSummary) error {
+	c.saved += len(summaries)
+	return c.ProjectDB.SaveFileSummaries(summaries)
+}
+
+func TestLoadFileSummaries_WarmCache(t *testing.T) {
+	t.Parallel()
+	dir := setupBinaryPolicyRepo(t).WorkDir()
+	writeTestFile(t, dir, "api/server.go", "package api\n\nfunc Serve() {}\n")
+	writeTestFile(t, dir, "api/routes.go", "package api\n\nfunc Routes() {}\n")
+	runGitCmdOrFatal(t, dir, "add", ".")
+	runGitCmdOrFatal(t, dir, "commit", "-m", "add api")
+
+	cache := &countingSummaryCache{ProjectDB: db.NewTestProjectDB(t)}
+	got, err := loadFileSummaries(dir, cache)
+	if err != nil {
+		t.Fatalf("loadFileSummaries: %v", err)
+	}
+	want := "api/routes.go: go, 3 lines; Routes\napi/server.go: go, 3 lines; Serve"
+	if got != want || cache.saved != 2 {
+		t.Fatalf("first task: saved %d, summaries =\n%s\nwant\n%s", cache.saved, got, want)
+	}
+
+	// A second task over the same content summarizes nothing
+	if got, err := loadFileSummaries(dir, cache); err != nil || got != want || cache.saved != 2 {
+		t.Errorf("second task: saved %d, err = %v, summaries =\n%s", cache.saved, err, got)
+	}
+
+	// Only the edited file is summarized again, including unstaged edits
+	writeTestFile(t, dir, "api/server.go", "package api\n\nfunc Serve() {}\n\nfunc Stop() {}\n")
+	got, err = loadFileSummaries(dir, cache)
+	if err != nil || cache.saved != 3 || !strings.Contains(got, "api/server.go: go, 5 lines; Serve, Stop") {
+		t.Errorf("after edit: saved %d, err = %v, summaries =\n%s", cache.saved, err, got)
+	}
+
+	// Without a cache every file is summarized
+	if got, err := loadFileSummaries(dir, nil); err != nil || !strings.Contains(got, "api/routes.go: go, 3 lines; Routes") {
+		t.Errorf("uncached: err = %v, summaries =\n%s", err, got)
+	}
+}
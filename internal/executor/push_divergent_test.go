@@ -334,6 +334,8 @@ func TestDirectMerge_UsesForceFallbackForDivergentBranch(t *testing.T) {
 		Completion: config.CompletionConfig{
 			TargetBranch: "main",
 			Action:       "merge",
+			// Only the task branch push is under test; keep main mergeable.
+			ProtectedBranches: []string{"production"},
 		},
 	}
 
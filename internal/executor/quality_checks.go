@@ -11,6 +11,7 @@ import (
 	"os/exec"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/randalmurphal/orc/internal/db"
@@ -45,6 +46,9 @@ type CheckResult struct {
 	Duration  time.Duration `json:"duration"`
 	OnFailure string        `json:"on_failure"` // "block", "warn", "skip"
 	Skipped   bool          `json:"skipped"`
+	// Violations lists offending files for checks orc runs itself
+	// (e.g. binary_files); Output carries the human-readable form.
+	Violations []CheckViolation `json:"violations,omitempty"`
 }
 
 // QualityCheckResult holds the results of all quality checks for a phase.
@@ -95,6 +99,33 @@ func (r *QualityCheckResult) AsContext() string {
 	return sb.String()
 }
 
+// summarize sets AllPassed and HasBlocks from the check results.
+func (r *QualityCheckResult) summarize() {
+	r.AllPassed, r.HasBlocks = true, false
+	for _, checkResult := range r.Checks {
+		if !checkResult.Passed && !checkResult.Skipped {
+			r.AllPassed = false
+			if checkResult.OnFailure == "block" || checkResult.OnFailure == "" {
+				r.HasBlocks = true
+			}
+		}
+	}
+}
+
+// replaceChecks swaps in results for checks of the same name, as when a
+// check is re-run.
+func (r *QualityCheckResult) replaceChecks(rerun *QualityCheckResult) {
+	for _, check := range rerun.Checks {
+		for i := range r.Checks {
+			if r.Checks[i].Name == check.Name {
+				r.Checks[i] = check
+			}
+		}
+	}
+	r.Duration += rerun.Duration
+	r.summarize()
+}
+
 // FailureSummary returns a brief summary of what failed.
 func (r *QualityCheckResult) FailureSummary() string {
 	if r.AllPassed {
@@ -125,6 +156,10 @@ type QualityCheckRunner struct {
 	commands map[string]*db.ProjectCommand // name -> command
 	logger   *slog.Logger
 	shell    string
+
+	parallelism    int           // checks run at once (default: 1)
+	defaultTimeout time.Duration // for checks without timeout_ms
+	hermetic       *hermeticEnv  // isolated command environment, nil for orc's own
 }
 
 // NewQualityCheckRunner creates a new quality check runner.
@@ -145,9 +180,24 @@ func NewQualityCheckRunner(
 		commands: commands,
 		logger:   logger,
 		shell:    detectShell(),
+
+		parallelism:    1,
+		defaultTimeout: DefaultCheckTimeout,
 	}
 }
 
+// WithLimits sets how many checks run at once and the timeout for checks
+// without timeout_ms of their own. Values below 1 keep the current setting.
+func (r *QualityCheckRunner) WithLimits(parallelism int, timeout time.Duration) *QualityCheckRunner {
+	if parallelism >= 1 {
+		r.parallelism = parallelism
+	}
+	if timeout > 0 {
+		r.defaultTimeout = timeout
+	}
+	return r
+}
+
 func isImplementVerificationCommand(name string) bool {
 	switch {
 	case name == "tests", strings.HasPrefix(name, "tests-"):
@@ -238,12 +288,12 @@ func findQualityCheckByName(checks []db.QualityCheck, name string) (db.QualityCh
 	return db.QualityCheck{}, false
 }
 
-// Run executes all configured quality checks sequentially.
-// Returns results for each check.
+// Run executes all configured quality checks, up to the runner's parallelism
+// at a time. Returns results for each check in configured order.
 func (r *QualityCheckRunner) Run(ctx context.Context) *QualityCheckResult {
 	start := time.Now()
 	result := &QualityCheckResult{
-		Checks:    make([]CheckResult, 0, len(r.checks)),
+		Checks:    make([]CheckResult, len(r.checks)),
 		AllPassed: true,
 		HasBlocks: false,
 	}
@@ -253,24 +303,33 @@ func (r *QualityCheckRunner) Run(ctx context.Context) *QualityCheckResult {
 		return result
 	}
 
-	for _, check := range r.checks {
-		checkResult := r.runCheck(ctx, check)
-		result.Checks = append(result.Checks, checkResult)
-
-		if !checkResult.Passed && !checkResult.Skipped {
-			result.AllPassed = false
-			if checkResult.OnFailure == "block" || checkResult.OnFailure == "" {
-				result.HasBlocks = true
-			}
+	if r.parallelism <= 1 || len(r.checks) == 1 {
+		for i, check := range r.checks {
+			result.Checks[i] = r.runCheck(ctx, check)
 		}
+	} else {
+		sem := make(chan struct{}, r.parallelism)
+		var wg sync.WaitGroup
+		for i, check := range r.checks {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				result.Checks[i] = r.runCheck(ctx, check)
+			}()
+		}
+		wg.Wait()
 	}
 
+	result.summarize()
 	result.Duration = time.Since(start)
 
 	r.logger.Info("quality checks completed",
 		"all_passed", result.AllPassed,
 		"has_blocks", result.HasBlocks,
 		"check_count", len(result.Checks),
+		"parallelism", r.parallelism,
 		"duration", result.Duration,
 	)
 
@@ -303,21 +362,7 @@ func (r *QualityCheckRunner) runCheck(ctx context.Context, check db.QualityCheck
 		return result
 	}
 
-	// Resolve the command to run
-	command := check.Command
-	if command == "" && check.Type == "code" {
-		// Look up command from project commands
-		if projCmd, ok := r.commands[check.Name]; ok {
-			if projCmd.Enabled {
-				// Use short_command if UseShort is set and short_command exists
-				if check.UseShort && projCmd.ShortCommand != "" {
-					command = projCmd.ShortCommand
-				} else {
-					command = projCmd.Command
-				}
-			}
-		}
-	}
+	command := resolveCheckCommand(check, r.commands)
 
 	// If no command found, treat as passed (check not applicable)
 	if command == "" {
@@ -332,23 +377,57 @@ func (r *QualityCheckRunner) runCheck(ctx context.Context, check db.QualityCheck
 	}
 
 	// Determine timeout
-	timeout := DefaultCheckTimeout
+	timeout := r.defaultTimeout
 	if check.TimeoutMs > 0 {
 		timeout = time.Duration(check.TimeoutMs) * time.Millisecond
 	}
 
 	// Execute the command
 	start := time.Now()
-	passed, output := r.runCommand(ctx, command, check.Name, timeout)
+	passed, timedOut, output := r.runCommand(ctx, command, check.Name, timeout)
 	result.Duration = time.Since(start)
 	result.Passed = passed
 	result.Output = output
+	if check.ExpectFailure && !timedOut {
+		result.Passed = !passed
+		if passed {
+			result.Output = "command succeeded but was expected to fail\n" + output
+		}
+	}
 
 	return result
 }
 
-// runCommand executes a shell command and returns whether it succeeded.
-func (r *QualityCheckRunner) runCommand(ctx context.Context, command, checkName string, timeout time.Duration) (bool, string) {
+// WithHermeticEnv runs commands with a fresh HOME and TMPDIR, only the
+// allowlisted environment variables, and tool caches under cacheDir.
+func (r *QualityCheckRunner) WithHermeticEnv(cacheDir string, allow []string) *QualityCheckRunner {
+	r.hermetic = &hermeticEnv{cacheDir: cacheDir, allow: allow}
+	return r
+}
+
+// resolveCheckCommand returns the shell command a check runs: its own
+// command, or for "code" checks the project command of the same name.
+func resolveCheckCommand(check db.QualityCheck, commands map[string]*db.ProjectCommand) string {
+	command := check.Command
+	if command == "" && check.Type == "code" {
+		// Look up command from project commands
+		if projCmd, ok := commands[check.Name]; ok {
+			if projCmd.Enabled {
+				// Use short_command if UseShort is set and short_command exists
+				if check.UseShort && projCmd.ShortCommand != "" {
+					command = projCmd.ShortCommand
+				} else {
+					command = projCmd.Command
+				}
+			}
+		}
+	}
+	return command
+}
+
+// runCommand executes a shell command and returns whether it succeeded and
+// whether it was killed by the timeout.
+func (r *QualityCheckRunner) runCommand(ctx context.Context, command, checkName string, timeout time.Duration) (bool, bool, string) {
 	r.logger.Debug("running quality check",
 		"name", checkName,
 		"command", command,
@@ -365,6 +444,15 @@ func (r *QualityCheckRunner) runCommand(ctx context.Context, command, checkName
 	cmd.Dir = r.workDir
 	// Set GOWORK=off to avoid go.work issues in worktrees
 	cmd.Env = append(os.Environ(), "GOWORK=off")
+	if r.hermetic != nil {
+		env, cleanup, err := r.hermetic.environ(os.Environ())
+		if err != nil {
+			r.logger.Warn("quality check environment setup failed", "name", checkName, "error", err)
+			return false, false, fmt.Sprintf("hermetic environment: %v", err)
+		}
+		defer cleanup()
+		cmd.Env = env
+	}
 
 	// Capture both stdout and stderr
 	var stdout, stderr bytes.Buffer
@@ -390,7 +478,7 @@ func (r *QualityCheckRunner) runCommand(ctx context.Context, command, checkName
 			"name", checkName,
 			"timeout", timeout,
 		)
-		return false, output
+		return false, true, output
 	}
 
 	passed := err == nil
@@ -404,7 +492,7 @@ func (r *QualityCheckRunner) runCommand(ctx context.Context, command, checkName
 		)
 	}
 
-	return passed, output
+	return passed, false, output
 }
 
 // truncateCheckOutput truncates output to a maximum length, preserving the end
@@ -2,6 +2,8 @@ package executor
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -414,6 +416,85 @@ func TestQualityCheckRunner_SetsGOWORKOff(t *testing.T) {
 	}
 }
 
+func TestQualityCheckRunner_RunsChecksConcurrently(t *testing.T) {
+	t.Parallel()
+
+	checks := []db.QualityCheck{
+		{Type: "custom", Name: "lint", Enabled: true, Command: "sleep 0.5"},
+		{Type: "custom", Name: "typecheck", Enabled: true, Command: "sleep 0.5; echo bad types; exit 1"},
+		{Type: "custom", Name: "tests", Enabled: true, Command: "sleep 0.5", OnFailure: "warn"},
+		{Type: "custom", Name: "docs", Enabled: true, Command: "exit 1", OnFailure: "warn"},
+	}
+
+	start := time.Now()
+	result := NewQualityCheckRunner(t.TempDir(), checks, nil, nil).WithLimits(4, 0).Run(context.Background())
+	if elapsed := time.Since(start); elapsed > 1400*time.Millisecond {
+		t.Errorf("checks took %v, want them to run concurrently", elapsed)
+	}
+
+	if len(result.Checks) != len(checks) {
+		t.Fatalf("got %d results, want %d", len(result.Checks), len(checks))
+	}
+	for i, check := range checks {
+		if result.Checks[i].Name != check.Name {
+			t.Errorf("result %d = %s, want %s (configured order)", i, result.Checks[i].Name, check.Name)
+		}
+	}
+	if result.AllPassed || !result.HasBlocks {
+		t.Errorf("AllPassed = %v, HasBlocks = %v; want a blocking failure", result.AllPassed, result.HasBlocks)
+	}
+	if got := result.FailureSummary(); got != "typecheck, docs (warn) failed" {
+		t.Errorf("FailureSummary = %q", got)
+	}
+}
+
+func TestQualityCheckRunner_DefaultTimeout(t *testing.T) {
+	t.Parallel()
+
+	checks := []db.QualityCheck{
+		{Type: "custom", Name: "slow", Enabled: true, Command: "sleep 5"},
+		{Type: "custom", Name: "own_timeout", Enabled: true, Command: "sleep 0.3", TimeoutMs: 5000},
+	}
+	result := NewQualityCheckRunner(t.TempDir(), checks, nil, nil).WithLimits(2, 100*time.Millisecond).Run(context.Background())
+
+	if result.Checks[0].Passed || !strings.Contains(result.Checks[0].Output, "[TIMEOUT]") {
+		t.Errorf("slow check = %+v, want a timeout failure", result.Checks[0])
+	}
+	if !result.Checks[1].Passed {
+		t.Errorf("check with its own timeout_ms failed: %s", result.Checks[1].Output)
+	}
+}
+
+func TestQualityCheckRunner_HermeticEnv(t *testing.T) {
+	t.Setenv("ORC_TEST_LEAK", "developer-state")
+	t.Setenv("ORC_TEST_ALLOWED", "passed")
+	cacheDir := filepath.Join(t.TempDir(), HermeticCacheDir)
+	checks := []db.QualityCheck{{
+		Type: "custom", Name: "env", Enabled: true,
+		Command: `echo "leak=$ORC_TEST_LEAK allowed=$ORC_TEST_ALLOWED home=$HOME gocache=$GOCACHE"; test -d "$HOME" && test -d "$TMPDIR"`,
+	}}
+
+	result := NewQualityCheckRunner(t.TempDir(), checks, nil, nil).
+		WithHermeticEnv(cacheDir, []string{"ORC_TEST_ALLOWED"}).
+		Run(context.Background())
+	if !result.AllPassed {
+		t.Fatalf("check failed: %s", result.Checks[0].Output)
+	}
+	out := result.Checks[0].Output
+	for _, want := range []string{"leak= ", "allowed=passed", "gocache=" + filepath.Join(cacheDir, "go-build")} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q: %s", want, out)
+		}
+	}
+	home := strings.TrimSpace(strings.SplitN(strings.SplitN(out, "home=", 2)[1], " ", 2)[0])
+	if real, _ := os.UserHomeDir(); home == "" || home == real {
+		t.Errorf("HOME = %q, want a fresh directory", home)
+	}
+	if _, err := os.Stat(home); !os.IsNotExist(err) {
+		t.Errorf("check HOME %s was not removed: %v", home, err)
+	}
+}
+
 // contains checks if s contains substr
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
@@ -0,0 +1,283 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/apisurface"
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+// RefactorBaselineAttachment is the task attachment holding the test results
+// and API surface recorded before a refactor task's implementation started.
+const RefactorBaselineAttachment = "refactor-baseline.json"
+
+// RefactorSafetyAttachment is the task attachment holding the latest
+// comparison against the refactor baseline.
+const RefactorSafetyAttachment = "refactor-safety.json"
+
+// refactorTestTimeout bounds one run of the test suite for the comparison.
+const refactorTestTimeout = 10 * time.Minute
+
+// RefactorTestRun is the outcome of one run of the project's tests.
+type RefactorTestRun struct {
+	Command string `json:"command"`
+	// Passed is whether the command exited successfully
+	Passed      bool     `json:"passed"`
+	PassedCount int      `json:"passed_count"`
+	Failed      []string `json:"failed,omitempty"`
+	Skipped     int      `json:"skipped,omitempty"`
+	Framework   string   `json:"framework,omitempty"`
+}
+
+// RefactorSnapshot is the observable behavior of the worktree at one point:
+// its test results and exported API.
+type RefactorSnapshot struct {
+	CapturedAt time.Time          `json:"captured_at"`
+	Tests      *RefactorTestRun   `json:"tests,omitempty"`
+	API        apisurface.Surface `json:"api,omitempty"`
+}
+
+// RefactorSafetyReport compares the worktree with the refactor baseline.
+type RefactorSafetyReport struct {
+	CheckedAt          time.Time         `json:"checked_at"`
+	BaselineCapturedAt time.Time         `json:"baseline_captured_at"`
+	Passed             bool              `json:"passed"`
+	Problems           []string          `json:"problems,omitempty"`
+	TestsBefore        *RefactorTestRun  `json:"tests_before,omitempty"`
+	TestsAfter         *RefactorTestRun  `json:"tests_after,omitempty"`
+	NewFailures        []string          `json:"new_failures,omitempty"`
+	API                *apisurface.Delta `json:"api,omitempty"`
+}
+
+// refactorSafetyApplies reports whether the phase runs under refactor
+// safety: an implementation phase of a refactor task with the checks enabled.
+func (we *WorkflowExecutor) refactorSafetyApplies(phaseID string) bool {
+	if we.orcConfig == nil || !we.orcConfig.RefactorSafety.Enabled || we.task == nil {
+		return false
+	}
+	return we.task.Category == orcv1.TaskCategory_TASK_CATEGORY_REFACTOR && isImplementationPhase(phaseID)
+}
+
+// captureRefactorBaseline records the worktree's behavior before the
+// implementation phase changes it. A baseline recorded by an earlier
+// attempt is kept, since the worktree may already hold changes.
+func (we *WorkflowExecutor) captureRefactorBaseline(ctx context.Context, cfg PhaseExecutionConfig) error {
+	if _, _, err := we.backend.GetAttachment(we.task.Id, RefactorBaselineAttachment); err == nil {
+		return nil
+	}
+	snapshot, err := we.refactorSnapshot(ctx, cfg.WorkingDir)
+	if err != nil {
+		return fmt.Errorf("capture refactor baseline: %w", err)
+	}
+	if err := we.saveRefactorAttachment(RefactorBaselineAttachment, snapshot); err != nil {
+		return err
+	}
+	attrs := []any{"phase", cfg.PhaseID, "api_entries", len(snapshot.API)}
+	if snapshot.Tests != nil {
+		attrs = append(attrs, "tests_passed", snapshot.Tests.Passed, "failing_tests", len(snapshot.Tests.Failed))
+	}
+	we.logger.Info("captured refactor baseline", attrs...)
+	return nil
+}
+
+// verifyRefactorSafety compares the worktree with the refactor baseline and
+// stores the comparison on the task. It returns the prompt to send back to
+// the agent, or "" when behavior and API are unchanged.
+func (we *WorkflowExecutor) verifyRefactorSafety(ctx context.Context, cfg PhaseExecutionConfig) (string, error) {
+	_, data, err := we.backend.GetAttachment(we.task.Id, RefactorBaselineAttachment)
+	if err != nil {
+		we.logger.Warn("no refactor baseline recorded, skipping behavior-preservation check",
+			"phase", cfg.PhaseID,
+			"error", err,
+		)
+		return "", nil
+	}
+	var baseline RefactorSnapshot
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return "", fmt.Errorf("decode refactor baseline: %w", err)
+	}
+
+	current, err := we.refactorSnapshot(ctx, cfg.WorkingDir)
+	if err != nil {
+		return "", fmt.Errorf("check refactor safety: %w", err)
+	}
+	report := compareRefactorSnapshots(&baseline, current)
+	if err := we.saveRefactorAttachment(RefactorSafetyAttachment, report); err != nil {
+		return "", err
+	}
+
+	if !report.Passed {
+		we.logger.Info("refactor changed behavior or API, continuing iteration",
+			"phase", cfg.PhaseID,
+			"problems", strings.Join(report.Problems, "; "),
+		)
+		return formatRefactorSafetyFeedback(report), nil
+	}
+	we.logger.Info("refactor safety verified: tests and API unchanged", "phase", cfg.PhaseID)
+	return "", nil
+}
+
+// refactorSnapshot runs the tests and scans the exported API of workDir.
+func (we *WorkflowExecutor) refactorSnapshot(ctx context.Context, workDir string) (*RefactorSnapshot, error) {
+	snapshot := &RefactorSnapshot{CapturedAt: time.Now().UTC()}
+
+	if command := we.refactorTestCommand(); command != "" {
+		runner := we.newQualityCheckRunner(workDir, []db.QualityCheck{{
+			Type:      "custom",
+			Name:      "refactor-tests",
+			Command:   command,
+			Enabled:   true,
+			OnFailure: "warn",
+			TimeoutMs: int(refactorTestTimeout / time.Millisecond),
+		}}, nil)
+		result := runner.Run(ctx)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		run := &RefactorTestRun{Command: command}
+		if len(result.Checks) > 0 {
+			run.Passed = result.Checks[0].Passed
+			if parsed, err := ParseTestOutput(result.Checks[0].Output); err == nil {
+				run.PassedCount = parsed.Passed
+				run.Skipped = parsed.Skipped
+				run.Framework = parsed.Framework
+				for _, f := range parsed.Failures {
+					run.Failed = append(run.Failed, testFailureName(f))
+				}
+				sort.Strings(run.Failed)
+			}
+		}
+		snapshot.Tests = run
+	}
+
+	if we.orcConfig.RefactorSafety.API {
+		surface, err := apisurface.Scan(workDir)
+		if err != nil {
+			return nil, fmt.Errorf("scan exported API: %w", err)
+		}
+		snapshot.API = surface
+	}
+	return snapshot, nil
+}
+
+// refactorTestCommand returns the configured test command, falling back to
+// the project's tests command.
+func (we *WorkflowExecutor) refactorTestCommand() string {
+	if command := strings.TrimSpace(we.orcConfig.RefactorSafety.TestCommand); command != "" {
+		return command
+	}
+	if we.projectDB == nil {
+		return ""
+	}
+	commands, err := we.projectDB.GetProjectCommandsMap()
+	if err != nil {
+		we.logger.Warn("failed to load project commands for refactor safety", "error", err)
+		return ""
+	}
+	if cmd := commands["tests"]; cmd != nil && cmd.Enabled {
+		return strings.TrimSpace(cmd.Command)
+	}
+	return ""
+}
+
+func (we *WorkflowExecutor) saveRefactorAttachment(name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", name, err)
+	}
+	if _, err := we.backend.SaveAttachment(we.task.Id, name, "application/json", data); err != nil {
+		return fmt.Errorf("save %s: %w", name, err)
+	}
+	return nil
+}
+
+func testFailureName(f TestFailure) string {
+	if f.Package != "" {
+		return f.Package + "." + f.Test
+	}
+	return f.Test
+}
+
+// compareRefactorSnapshots checks that the tests that passed before still
+// pass, that no tests disappeared, and that the exported API is identical.
+func compareRefactorSnapshots(before, after *RefactorSnapshot) *RefactorSafetyReport {
+	report := &RefactorSafetyReport{
+		CheckedAt:          after.CapturedAt,
+		BaselineCapturedAt: before.CapturedAt,
+		TestsBefore:        before.Tests,
+		TestsAfter:         after.Tests,
+	}
+
+	if before.Tests != nil && after.Tests != nil {
+		failedBefore := make(map[string]bool, len(before.Tests.Failed))
+		for _, name := range before.Tests.Failed {
+			failedBefore[name] = true
+		}
+		for _, name := range after.Tests.Failed {
+			if !failedBefore[name] {
+				report.NewFailures = append(report.NewFailures, name)
+			}
+		}
+		switch {
+		case len(report.NewFailures) > 0:
+			report.Problems = append(report.Problems, fmt.Sprintf("%d test(s) fail that passed before", len(report.NewFailures)))
+		case before.Tests.Passed && !after.Tests.Passed:
+			report.Problems = append(report.Problems, "the test command passed before and fails now")
+		}
+		if after.Tests.PassedCount < before.Tests.PassedCount {
+			report.Problems = append(report.Problems, fmt.Sprintf("%d test(s) passed before, %d pass now",
+				before.Tests.PassedCount, after.Tests.PassedCount))
+		}
+	}
+
+	if before.API != nil && after.API != nil {
+		report.API = apisurface.Diff(before.API, after.API)
+		if !report.API.Empty() {
+			report.Problems = append(report.Problems, fmt.Sprintf("exported API changed (%d added, %d removed, %d changed)",
+				len(report.API.Added), len(report.API.Removed), len(report.API.Changed)))
+		}
+	}
+
+	report.Passed = len(report.Problems) == 0
+	return report
+}
+
+// formatRefactorSafetyFeedback tells the agent how its refactor changed
+// observable behavior.
+func formatRefactorSafetyFeedback(report *RefactorSafetyReport) string {
+	var b strings.Builder
+	b.WriteString("## Refactor Changed Behavior\n\n")
+	b.WriteString("This is a refactor task: test results and the exported API must be the same as before your changes. orc compared them with the baseline recorded before implementation and found:\n\n")
+	for _, p := range report.Problems {
+		b.WriteString("- " + p + "\n")
+	}
+	if len(report.NewFailures) > 0 {
+		b.WriteString("\n### Tests that now fail\n\n")
+		for _, name := range report.NewFailures {
+			b.WriteString("- " + name + "\n")
+		}
+	}
+	if report.API != nil && !report.API.Empty() {
+		b.WriteString("\n### Exported API differences\n\n")
+		for _, name := range report.API.Removed {
+			b.WriteString("- removed `" + name + "`\n")
+		}
+		for _, name := range report.API.Added {
+			b.WriteString("- added `" + name + "`\n")
+		}
+		for _, c := range report.API.Changed {
+			fmt.Fprintf(&b, "- changed `%s`: `%s` → `%s`\n", c.Name, c.Before, c.After)
+		}
+	}
+	if report.TestsAfter != nil {
+		fmt.Fprintf(&b, "\nTests were run with `%s`.\n", report.TestsAfter.Command)
+	}
+	b.WriteString("\nRestore the previous behavior and signatures (unexported names are free to change), then output the completion JSON again. If the task genuinely requires a behavior or API change, it is not a refactor: output status \"blocked\" and explain.")
+	return b.String()
+}
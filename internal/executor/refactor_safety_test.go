@@ -0,0 +1,148 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/apisurface"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestRefactorSafetyApplies(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	refactor := &orcv1.Task{Id: "TASK-001", Category: orcv1.TaskCategory_TASK_CATEGORY_REFACTOR}
+	we := &WorkflowExecutor{orcConfig: cfg, task: refactor}
+
+	if !we.refactorSafetyApplies("implement") {
+		t.Error("refactor implement phase should run refactor safety")
+	}
+	if we.refactorSafetyApplies("review") {
+		t.Error("review phase should not run refactor safety")
+	}
+	we.task = &orcv1.Task{Id: "TASK-002", Category: orcv1.TaskCategory_TASK_CATEGORY_FEATURE}
+	if we.refactorSafetyApplies("implement") {
+		t.Error("feature task should not run refactor safety")
+	}
+	cfg.RefactorSafety.Enabled = false
+	we.task = refactor
+	if we.refactorSafetyApplies("implement") {
+		t.Error("disabled refactor safety should not apply")
+	}
+}
+
+func TestCompareRefactorSnapshots(t *testing.T) {
+	t.Parallel()
+
+	before := &RefactorSnapshot{
+		Tests: &RefactorTestRun{Passed: false, PassedCount: 10, Failed: []string{"pkg.TestFlaky"}},
+		API:   apisurface.Surface{"pkg.Load": "func Load(path string) error"},
+	}
+
+	same := &RefactorSnapshot{
+		Tests: &RefactorTestRun{Passed: false, PassedCount: 12, Failed: []string{"pkg.TestFlaky"}},
+		API:   apisurface.Surface{"pkg.Load": "func Load(path string) error"},
+	}
+	if report := compareRefactorSnapshots(before, same); !report.Passed {
+		t.Errorf("unchanged behavior reported problems: %v", report.Problems)
+	}
+
+	changed := &RefactorSnapshot{
+		Tests: &RefactorTestRun{Passed: false, PassedCount: 9, Failed: []string{"pkg.TestFlaky", "pkg.TestLoad"}},
+		API:   apisurface.Surface{"pkg.Load": "func Load(path string, strict bool) error"},
+	}
+	report := compareRefactorSnapshots(before, changed)
+	if report.Passed || len(report.Problems) != 3 {
+		t.Errorf("problems = %v, want new failure, fewer passing tests and API change", report.Problems)
+	}
+	if len(report.NewFailures) != 1 || report.NewFailures[0] != "pkg.TestLoad" {
+		t.Errorf("NewFailures = %v", report.NewFailures)
+	}
+
+	// A suite that stops passing is a behavior change even without parsed failures
+	broken := compareRefactorSnapshots(
+		&RefactorSnapshot{Tests: &RefactorTestRun{Passed: true}},
+		&RefactorSnapshot{Tests: &RefactorTestRun{Passed: false}},
+	)
+	if broken.Passed {
+		t.Error("failing test command after a passing baseline should not pass")
+	}
+}
+
+func TestRefactorSafety_BaselineAndVerify(t *testing.T) {
+	t.Parallel()
+
+	backend := storage.NewTestBackend(t)
+	tsk := task.NewProtoTask("TASK-001", "Split config loader")
+	tsk.Category = orcv1.TaskCategory_TASK_CATEGORY_REFACTOR
+	if err := backend.SaveTask(tsk); err != nil {
+		t.Fatal(err)
+	}
+
+	workDir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(workDir, name)
+		_ = os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("lib/lib.go", "package lib\n\nfunc Load(path string) error { return nil }\n")
+	write("results.txt", "ok  \texample.com/lib\t0.1s\n")
+
+	cfg := config.Default()
+	cfg.RefactorSafety.TestCommand = "cat results.txt; test ! -f broken"
+	we := &WorkflowExecutor{backend: backend, orcConfig: cfg, logger: slog.Default(), task: tsk}
+	phase := PhaseExecutionConfig{PhaseID: "implement", WorkingDir: workDir}
+	ctx := context.Background()
+
+	if err := we.captureRefactorBaseline(ctx, phase); err != nil {
+		t.Fatalf("captureRefactorBaseline() error: %v", err)
+	}
+
+	// The refactor breaks a test and changes a signature
+	write("lib/lib.go", "package lib\n\nfunc Load(path string, strict bool) error { return nil }\n")
+	write("results.txt", "--- FAIL: TestLoad (0.00s)\n    lib_test.go:12: boom\nFAIL\texample.com/lib\t0.1s\n")
+	write("broken", "")
+
+	// A second capture keeps the original baseline
+	if err := we.captureRefactorBaseline(ctx, phase); err != nil {
+		t.Fatal(err)
+	}
+
+	feedback, err := we.verifyRefactorSafety(ctx, phase)
+	if err != nil {
+		t.Fatalf("verifyRefactorSafety() error: %v", err)
+	}
+	for _, want := range []string{"TestLoad", "lib.Load", "strict bool"} {
+		if !strings.Contains(feedback, want) {
+			t.Errorf("feedback missing %q:\n%s", want, feedback)
+		}
+	}
+	_, data, err := backend.GetAttachment("TASK-001", RefactorSafetyAttachment)
+	if err != nil {
+		t.Fatalf("comparison not stored: %v", err)
+	}
+	var report RefactorSafetyReport
+	if err := json.Unmarshal(data, &report); err != nil || report.Passed {
+		t.Errorf("stored report = %+v, %v", report, err)
+	}
+
+	// Restoring behavior and API passes the gate
+	write("lib/lib.go", "package lib\n\n// Load reads path.\nfunc Load(path string) error {\n\treturn load(path)\n}\n\nfunc load(string) error { return nil }\n")
+	write("results.txt", "ok  \texample.com/lib\t0.1s\n")
+	_ = os.Remove(filepath.Join(workDir, "broken"))
+	if feedback, err := we.verifyRefactorSafety(ctx, phase); err != nil || feedback != "" {
+		t.Errorf("restored refactor: feedback = %q, err = %v", feedback, err)
+	}
+}
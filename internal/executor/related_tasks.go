@@ -0,0 +1,212 @@
+package executor
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/git"
+	"github.com/randalmurphal/orc/internal/task"
+	"github.com/randalmurphal/orc/internal/variable"
+)
+
+const (
+	// maxRecordedChangedFiles caps the changed_files metadata of a task.
+	maxRecordedChangedFiles = 200
+	// maxOutcomeSummaryLen caps the outcome_summary metadata of a task.
+	maxOutcomeSummaryLen = 600
+)
+
+// urlPattern matches URLs, whose paths are not repository paths.
+var urlPattern = regexp.MustCompile(`\b[a-z][a-z0-9+.-]*://\S+`)
+
+// mentionedPathPattern matches repository paths in a task's title and
+// description: anything with a slash, or a file name with a known extension.
+var mentionedPathPattern = regexp.MustCompile(`(?:[\w.-]+/)+[\w.-]*|[\w-]+\.(?:go|ts|tsx|js|jsx|py|rs|java|kt|rb|php|cs|c|h|cpp|swift|proto|sql|sh|yaml|yml|json|toml|md|css|scss|html|vue|svelte)\b`)
+
+// relatedTask is a completed task that touched paths the current task works
+// on, with the matching files.
+type relatedTask struct {
+	task    *orcv1.Task
+	matched []string
+}
+
+// recordChangedFiles stores the files the task changed relative to its fork
+// point, for related-task context in later tasks.
+func (we *WorkflowExecutor) recordChangedFiles(t *orcv1.Task) {
+	files := we.taskChangedFiles()
+	if len(files) == 0 {
+		return
+	}
+	if len(files) > maxRecordedChangedFiles {
+		files = files[:maxRecordedChangedFiles]
+	}
+	task.SetChangedFiles(t, files)
+}
+
+// taskChangedFiles lists the files changed in the task's worktree relative to
+// its fork point. It returns nil when the task has no worktree.
+func (we *WorkflowExecutor) taskChangedFiles() []string {
+	dir := we.worktreePath
+	if dir == "" {
+		return nil
+	}
+	gitCtx, err := git.NewContext(dir)
+	if err != nil {
+		return nil
+	}
+	base := we.taskForkPoint(gitCtx)
+	if base == "" {
+		return nil
+	}
+	files, err := netChangedFiles(gitCtx, base)
+	if err != nil {
+		we.logger.Debug("failed to list task changed files", "dir", dir, "error", err)
+		return nil
+	}
+	return files
+}
+
+// recordOutcomeSummary keeps the summary from an implement phase response as
+// the task's outcome for related-task context.
+func recordOutcomeSummary(t *orcv1.Task, rawOutput string) {
+	resp, err := ParsePhaseResponse(rawOutput)
+	if err != nil || strings.TrimSpace(resp.Summary) == "" {
+		return
+	}
+	task.EnsureMetadataProto(t)
+	t.Metadata[task.OutcomeSummaryMetadataKey] = truncate(strings.TrimSpace(resp.Summary), maxOutcomeSummaryLen)
+}
+
+// populateRelatedTasks sets rctx.RelatedTasks to the outcomes of recently
+// completed tasks that touched the files or packages t mentions or has
+// changed so far, for the phases brief.related_tasks lists.
+func (we *WorkflowExecutor) populateRelatedTasks(rctx *variable.ResolutionContext, phaseID string, t *orcv1.Task) {
+	rctx.RelatedTasks = ""
+	if we.orcConfig == nil || we.backend == nil || task.RelatedTasksDisabled(t) {
+		return
+	}
+	cfg := we.orcConfig.Brief.RelatedTasks
+	if !cfg.Enabled || !slices.Contains(cfg.Phases, phaseID) {
+		return
+	}
+	paths := append(taskMentionedPaths(t), we.taskChangedFiles()...)
+	if len(paths) == 0 && len(t.RelatedTo) == 0 {
+		return
+	}
+	tasks, err := we.backend.LoadAllTasks()
+	if err != nil {
+		we.logger.Warn("failed to load tasks for related-task context", "task", t.Id, "error", err)
+		return
+	}
+	related := findRelatedTasks(t, tasks, paths, cfg, time.Now())
+	if len(related) > 0 {
+		we.logger.Info("injecting related task outcomes", "task", t.Id, "phase", phaseID, "related", len(related))
+	}
+	rctx.RelatedTasks = formatRelatedTasks(related)
+}
+
+// taskMentionedPaths extracts repository paths from the task's title and
+// description.
+func taskMentionedPaths(t *orcv1.Task) []string {
+	text := urlPattern.ReplaceAllString(t.GetTitle()+"\n"+t.GetDescription(), " ")
+	seen := make(map[string]bool)
+	var paths []string
+	for _, m := range mentionedPathPattern.FindAllString(text, -1) {
+		p := strings.TrimRight(strings.TrimPrefix(m, "./"), "./")
+		if p == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+// findRelatedTasks returns the completed tasks (other than t) that changed
+// files matching paths within cfg.MaxAge, most matching files first, then
+// most recent. Completed tasks t is explicitly related to always qualify.
+func findRelatedTasks(t *orcv1.Task, tasks []*orcv1.Task, paths []string, cfg config.RelatedTasksConfig, now time.Time) []relatedTask {
+	var related []relatedTask
+	for _, candidate := range tasks {
+		if candidate.Id == t.Id || candidate.Status != orcv1.TaskStatus_TASK_STATUS_COMPLETED {
+			continue
+		}
+		if cfg.MaxAge > 0 && candidate.CompletedAt != nil && now.Sub(candidate.CompletedAt.AsTime()) > cfg.MaxAge {
+			continue
+		}
+		var matched []string
+		for _, f := range task.GetChangedFiles(candidate) {
+			if slices.ContainsFunc(paths, func(p string) bool { return pathTouches(f, p) }) {
+				matched = append(matched, f)
+			}
+		}
+		if len(matched) > 0 || slices.Contains(t.RelatedTo, candidate.Id) {
+			related = append(related, relatedTask{task: candidate, matched: matched})
+		}
+	}
+	sort.SliceStable(related, func(i, j int) bool {
+		if len(related[i].matched) != len(related[j].matched) {
+			return len(related[i].matched) > len(related[j].matched)
+		}
+		return related[i].task.GetCompletedAt().AsTime().After(related[j].task.GetCompletedAt().AsTime())
+	})
+	if cfg.Limit > 0 && len(related) > cfg.Limit {
+		related = related[:cfg.Limit]
+	}
+	return related
+}
+
+// pathTouches reports whether changed file f is p, lies under directory p,
+// shares p's package (directory), or has p's file name when p is a bare
+// file name.
+func pathTouches(f, p string) bool {
+	if f == p || strings.HasPrefix(f, p+"/") {
+		return true
+	}
+	if !strings.Contains(p, "/") {
+		return path.Base(f) == p
+	}
+	dir := path.Dir(p)
+	return path.Ext(p) != "" && dir != "." && path.Dir(f) == dir
+}
+
+// formatRelatedTasks renders related tasks as a prompt section.
+func formatRelatedTasks(related []relatedTask) string {
+	if len(related) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("## Related Recent Tasks\n\n")
+	sb.WriteString("These recently completed tasks touched the same files or packages. Build on what they found instead of re-discovering it.\n")
+	for _, r := range related {
+		t := r.task
+		fmt.Fprintf(&sb, "\n### %s: %s", t.Id, t.Title)
+		if t.CompletedAt != nil {
+			fmt.Fprintf(&sb, " (completed %s)", t.CompletedAt.AsTime().Format("2006-01-02"))
+		}
+		sb.WriteString("\n\n")
+		summary := strings.TrimSpace(t.Metadata[task.OutcomeSummaryMetadataKey])
+		if summary == "" {
+			summary = truncate(strings.TrimSpace(t.GetDescription()), maxOutcomeSummaryLen)
+		}
+		if summary != "" {
+			sb.WriteString(summary + "\n")
+		}
+		if len(r.matched) > 0 {
+			files := r.matched
+			more := ""
+			if len(files) > 5 {
+				files, more = files[:5], fmt.Sprintf(" (+%d more)", len(r.matched)-5)
+			}
+			fmt.Fprintf(&sb, "Files: %s%s\n", strings.Join(files, ", "), more)
+		}
+	}
+	return sb.String()
+}
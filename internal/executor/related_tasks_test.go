@@ -0,0 +1,130 @@
+package executor
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/task"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func completedTask(id string, completedAt time.Time, files ...string) *orcv1.Task {
+	t := task.NewProtoTask(id, "Task "+id)
+	t.Status = orcv1.TaskStatus_TASK_STATUS_COMPLETED
+	t.CompletedAt = timestamppb.New(completedAt)
+	task.SetChangedFiles(t, files)
+	return t
+}
+
+func TestTaskMentionedPaths(t *testing.T) {
+	t.Parallel()
+	tk := task.NewProtoTask("TASK-001", "Fix retry in internal/executor/retry.go")
+	task.SetDescriptionProto(tk, "See ./internal/hosting/ and config.yaml, not https://github.com/o/r/pull/1.")
+
+	got := taskMentionedPaths(tk)
+	want := []string{"internal/executor/retry.go", "internal/hosting", "config.yaml"}
+	if !slices.Equal(got, want) {
+		t.Errorf("taskMentionedPaths() = %v, want %v", got, want)
+	}
+}
+
+func TestPathTouches(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		file, path string
+		want       bool
+	}{
+		{"internal/executor/retry.go", "internal/executor/retry.go", true},
+		{"internal/executor/retry.go", "internal/executor", true},
+		{"internal/executor/phase.go", "internal/executor/retry.go", true},
+		{"internal/executor/retry.go", "retry.go", true},
+		{"internal/executorx/retry.go", "internal/executor", false},
+		{"internal/hosting/pr.go", "internal/executor/retry.go", false},
+		{"docs/retry.md", "retry.go", false},
+	}
+	for _, tt := range tests {
+		if got := pathTouches(tt.file, tt.path); got != tt.want {
+			t.Errorf("pathTouches(%q, %q) = %v, want %v", tt.file, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestFindRelatedTasks(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	current := task.NewProtoTask("TASK-010", "Current")
+	current.RelatedTo = []string{"TASK-005"}
+
+	running := completedTask("TASK-002", now, "internal/executor/retry.go")
+	running.Status = orcv1.TaskStatus_TASK_STATUS_RUNNING
+	tasks := []*orcv1.Task{
+		completedTask("TASK-001", now.Add(-2*time.Hour), "internal/executor/retry.go"),
+		running,
+		completedTask("TASK-003", now.Add(-time.Hour), "internal/executor/retry.go", "internal/executor/phase.go"),
+		completedTask("TASK-004", now.Add(-60*24*time.Hour), "internal/executor/retry.go"),
+		completedTask("TASK-005", now.Add(-3*time.Hour), "web/src/App.tsx"),
+		completedTask("TASK-006", now, "internal/hosting/pr.go"),
+		completedTask("TASK-010", now, "internal/executor/retry.go"),
+	}
+	cfg := config.RelatedTasksConfig{Enabled: true, Limit: 5, MaxAge: 30 * 24 * time.Hour}
+
+	related := findRelatedTasks(current, tasks, []string{"internal/executor/retry.go"}, cfg, now)
+	var ids []string
+	for _, r := range related {
+		ids = append(ids, r.task.Id)
+	}
+	if want := []string{"TASK-003", "TASK-001", "TASK-005"}; !slices.Equal(ids, want) {
+		t.Errorf("related = %v, want %v", ids, want)
+	}
+
+	cfg.Limit = 1
+	if related := findRelatedTasks(current, tasks, []string{"internal/executor/retry.go"}, cfg, now); len(related) != 1 {
+		t.Errorf("with limit 1: %d related tasks, want 1", len(related))
+	}
+}
+
+func TestFormatRelatedTasks(t *testing.T) {
+	t.Parallel()
+	if got := formatRelatedTasks(nil); got != "" {
+		t.Errorf("formatRelatedTasks(nil) = %q, want empty", got)
+	}
+
+	withSummary := completedTask("TASK-001", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	withSummary.Metadata[task.OutcomeSummaryMetadataKey] = "Retries must stay idempotent."
+	noSummary := completedTask("TASK-002", time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC))
+	task.SetDescriptionProto(noSummary, "Add backoff to retries.")
+
+	got := formatRelatedTasks([]relatedTask{
+		{task: withSummary, matched: []string{"internal/executor/retry.go"}},
+		{task: noSummary},
+	})
+	for _, want := range []string{
+		"## Related Recent Tasks",
+		"### TASK-001: Task TASK-001 (completed 2026-03-01)",
+		"Retries must stay idempotent.",
+		"Files: internal/executor/retry.go",
+		"Add backoff to retries.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatRelatedTasks() missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRecordOutcomeSummary(t *testing.T) {
+	t.Parallel()
+	tk := task.NewProtoTask("TASK-001", "Test")
+	recordOutcomeSummary(tk, `{"status": "complete", "summary": "Moved retry config into the executor."}`)
+	if got := tk.Metadata[task.OutcomeSummaryMetadataKey]; got != "Moved retry config into the executor." {
+		t.Errorf("outcome summary = %q", got)
+	}
+
+	recordOutcomeSummary(tk, "not json")
+	if got := tk.Metadata[task.OutcomeSummaryMetadataKey]; got != "Moved retry config into the executor." {
+		t.Errorf("unparseable output changed outcome summary to %q", got)
+	}
+}
@@ -0,0 +1,126 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// Task metadata keys linking a release-branch task and its back-port.
+const (
+	BackportTaskMetadataKey = "backport_task" // On the release task: the back-port task ID
+	BackportOfMetadataKey   = "backport_of"   // On the back-port task: the release task ID
+)
+
+// Back-port modes (completion.release.backport).
+const (
+	BackportCherryPick = "cherry-pick"
+	BackportMerge      = "merge"
+	BackportNone       = "none"
+)
+
+// CreateBackportTask creates the linked task that carries a change merged to
+// a release branch back to completion.release.backport_to. mergeSHA is the
+// commit the change landed as, when known.
+//
+// It returns nil without creating anything when releaseBranch is not a
+// release branch, back-ports are disabled, or the source task already has a
+// back-port. Otherwise both tasks are saved, linked through their
+// backport_of and backport_task metadata.
+func CreateBackportTask(backend storage.Backend, cfg *config.Config, source *orcv1.Task, releaseBranch, mergeSHA string) (*orcv1.Task, error) {
+	if backend == nil || cfg == nil || source == nil || !cfg.IsReleaseBranch(releaseBranch) {
+		return nil, nil
+	}
+	rel := cfg.Completion.Release
+	if rel.Backport == "" || rel.Backport == BackportNone || rel.BackportTo == "" {
+		return nil, nil
+	}
+	if source.Metadata[BackportTaskMetadataKey] != "" {
+		return nil, nil
+	}
+	if mergeSHA == "" && source.Pr != nil {
+		mergeSHA = source.Pr.GetMergeCommitSha()
+	}
+
+	id, err := backend.GetNextTaskID()
+	if err != nil {
+		return nil, fmt.Errorf("generate back-port task ID: %w", err)
+	}
+
+	title := fmt.Sprintf("Back-port %s to %s: %s", source.Id, rel.BackportTo, source.Title)
+	if rel.Backport == BackportMerge {
+		title = fmt.Sprintf("Back-merge %s into %s (%s)", releaseBranch, rel.BackportTo, source.Id)
+	}
+	bt := task.NewProtoTask(id, title)
+	task.SetDescriptionProto(bt, buildBackportBody(source, rel, releaseBranch, mergeSHA))
+	bt.Category = task.GetCategoryProto(source)
+	bt.Priority = source.Priority
+	bt.Metadata[BackportOfMetadataKey] = source.Id
+	bt.Metadata["backport_mode"] = rel.Backport
+	bt.Metadata["release_branch"] = releaseBranch
+	task.SetWorkflowIDProto(bt, firstNonEmpty(rel.Workflow, task.GetWorkflowIDProto(source)))
+	backportTo := rel.BackportTo
+	bt.TargetBranch = &backportTo
+
+	if err := backend.SaveTask(bt); err != nil {
+		return nil, fmt.Errorf("save back-port task: %w", err)
+	}
+
+	task.EnsureMetadataProto(source)
+	source.Metadata[BackportTaskMetadataKey] = id
+	if err := backend.SaveTask(source); err != nil {
+		return bt, fmt.Errorf("link back-port %s to %s: %w", id, source.Id, err)
+	}
+	return bt, nil
+}
+
+// createBackportTask creates a back-port after the executor merged t into a
+// release branch. Failures are logged: the merge itself already succeeded.
+func (we *WorkflowExecutor) createBackportTask(t *orcv1.Task, releaseBranch, mergeSHA string) {
+	bt, err := CreateBackportTask(we.backend, we.orcConfig, t, releaseBranch, mergeSHA)
+	if err != nil {
+		we.logger.Warn("failed to create back-port task", "task", t.Id, "release_branch", releaseBranch, "error", err)
+	}
+	if bt == nil {
+		return
+	}
+	we.publishTaskUpdated(bt)
+	we.logger.Info("back-port task created",
+		"task", t.Id,
+		"backport", bt.Id,
+		"release_branch", releaseBranch,
+		"target", task.GetTargetBranchProto(bt))
+}
+
+func buildBackportBody(source *orcv1.Task, rel config.ReleaseConfig, releaseBranch, mergeSHA string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s (%s) was merged to %s", source.Id, source.Title, releaseBranch)
+	if prURL := task.GetPRURLProto(source); prURL != "" {
+		fmt.Fprintf(&sb, " in %s", prURL)
+	}
+	sb.WriteString(".\n\n")
+
+	switch rel.Backport {
+	case BackportMerge:
+		fmt.Fprintf(&sb, "Merge origin/%s into %s so the release fix is not lost on the next release. ", releaseBranch, rel.BackportTo)
+		sb.WriteString("Resolve conflicts in favour of the newer code on the target branch while keeping the fix's behaviour.\n")
+	default:
+		fmt.Fprintf(&sb, "Cherry-pick the change onto %s", rel.BackportTo)
+		if mergeSHA != "" {
+			fmt.Fprintf(&sb, " (`git cherry-pick -x %s`)", mergeSHA)
+		} else {
+			fmt.Fprintf(&sb, " from branch %s", source.Branch)
+		}
+		sb.WriteString(". Adapt it where the target branch has diverged and resolve conflicts without dropping the fix.\n")
+	}
+	sb.WriteString("\nRun the tests that cover the change before finishing.")
+
+	if desc := task.GetDescriptionProto(source); desc != "" {
+		fmt.Fprintf(&sb, "\n\n## Original Task\n\n%s", desc)
+	}
+	return sb.String()
+}
@@ -0,0 +1,111 @@
+package executor
+
+import (
+	"strings"
+	"testing"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func newReleaseTask(t *testing.T, backend *storage.DatabaseBackend) *orcv1.Task {
+	t.Helper()
+	id, err := backend.GetNextTaskID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tsk := task.NewProtoTask(id, "Fix crash on empty config")
+	tsk.Branch = "orc/" + id
+	tsk.Priority = orcv1.TaskPriority_TASK_PRIORITY_HIGH
+	task.SetWorkflowIDProto(tsk, "implement-medium")
+	task.SetTargetBranchProto(tsk, "release/1.2")
+	task.SetDescriptionProto(tsk, "Loading an empty config file panics.")
+	if err := backend.SaveTask(tsk); err != nil {
+		t.Fatal(err)
+	}
+	return tsk
+}
+
+func TestCreateBackportTask_CherryPick(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewTestBackend(t)
+	source := newReleaseTask(t, backend)
+
+	bt, err := CreateBackportTask(backend, config.Default(), source, "release/1.2", "abc1234")
+	if err != nil {
+		t.Fatalf("CreateBackportTask: %v", err)
+	}
+	if bt == nil {
+		t.Fatal("no back-port task created")
+	}
+
+	if bt.GetTargetBranch() != "main" || task.GetWorkflowIDProto(bt) != "implement-small" || bt.Priority != source.Priority {
+		t.Errorf("back-port = target %q, workflow %q, priority %v", bt.GetTargetBranch(), task.GetWorkflowIDProto(bt), bt.Priority)
+	}
+	desc := task.GetDescriptionProto(bt)
+	for _, want := range []string{"merged to release/1.2", "git cherry-pick -x abc1234", "## Original Task"} {
+		if !strings.Contains(desc, want) {
+			t.Errorf("description missing %q:\n%s", want, desc)
+		}
+	}
+
+	reloaded, err := backend.LoadTask(source.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Metadata[BackportTaskMetadataKey] != bt.Id {
+		t.Errorf("source not linked: metadata %v", reloaded.Metadata)
+	}
+	if bt.Metadata[BackportOfMetadataKey] != source.Id || bt.Metadata["release_branch"] != "release/1.2" {
+		t.Errorf("back-port not linked: metadata %v", bt.Metadata)
+	}
+
+	again, err := CreateBackportTask(backend, config.Default(), reloaded, "release/1.2", "abc1234")
+	if err != nil || again != nil {
+		t.Errorf("second call = %v, %v; want no duplicate back-port", again, err)
+	}
+}
+
+func TestCreateBackportTask_BackMerge(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewTestBackend(t)
+	source := newReleaseTask(t, backend)
+	cfg := config.Default()
+	cfg.Completion.Release.Backport = BackportMerge
+
+	bt, err := CreateBackportTask(backend, cfg, source, "release/1.2", "")
+	if err != nil || bt == nil {
+		t.Fatalf("CreateBackportTask = %v, %v", bt, err)
+	}
+	if !strings.HasPrefix(bt.Title, "Back-merge release/1.2 into main") {
+		t.Errorf("title = %q", bt.Title)
+	}
+	if !strings.Contains(task.GetDescriptionProto(bt), "Merge origin/release/1.2 into main") {
+		t.Errorf("description = %q", task.GetDescriptionProto(bt))
+	}
+}
+
+func TestCreateBackportTask_Skipped(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewTestBackend(t)
+	source := newReleaseTask(t, backend)
+	disabled := config.Default()
+	disabled.Completion.Release.Backport = BackportNone
+
+	for name, tc := range map[string]struct {
+		cfg    *config.Config
+		branch string
+	}{
+		"not a release branch": {config.Default(), "main"},
+		"bare prefix":          {config.Default(), "release/"},
+		"disabled":             {disabled, "release/1.2"},
+		"no config":            {nil, "release/1.2"},
+	} {
+		bt, err := CreateBackportTask(backend, tc.cfg, source, tc.branch, "")
+		if err != nil || bt != nil {
+			t.Errorf("%s: = %v, %v; want nothing created", name, bt, err)
+		}
+	}
+}
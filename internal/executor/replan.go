@@ -0,0 +1,153 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+const (
+	// ReplanPlanKey is the task metadata key holding the latest revised plan.
+	ReplanPlanKey = "replan_plan"
+	// ReplanCountKey is the task metadata key counting re-planning steps taken.
+	ReplanCountKey = "replan_count"
+
+	// replanPhaseID identifies the re-planning turn in transcripts and schemas.
+	replanPhaseID = "replan"
+
+	// replanFeedbackLimit caps how much of the failed phase output goes into
+	// the re-planning prompt.
+	replanFeedbackLimit = 8000
+)
+
+// replanRequest is the failure context handed to the re-planning step.
+type replanRequest struct {
+	FailedPhase string
+	RetryFrom   string
+	Reason      string
+	Output      string
+	Attempts    int
+	Spec        string
+}
+
+// replanAvailable reports whether retry.replan is enabled and t still has
+// re-planning steps left.
+func (we *WorkflowExecutor) replanAvailable(t *orcv1.Task) bool {
+	if we.orcConfig == nil || t == nil || !we.orcConfig.Retry.Replan.Enabled {
+		return false
+	}
+	return replanCount(t) < we.orcConfig.Retry.Replan.MaxReplans
+}
+
+// replanRequiresApproval reports whether a revised plan must be approved by a
+// human before the task continues.
+func (we *WorkflowExecutor) replanRequiresApproval() bool {
+	return we.orcConfig == nil || we.orcConfig.Retry.Replan.Gate != "auto"
+}
+
+// replanCount returns how many re-planning steps t has taken.
+func replanCount(t *orcv1.Task) int {
+	n, _ := strconv.Atoi(t.GetMetadata()[ReplanCountKey])
+	return n
+}
+
+// replan asks the model to step back and revise the approach for a phase
+// that keeps failing. The revised plan is recorded on the task and returned;
+// the caller restarts the retry loop with it.
+func (we *WorkflowExecutor) replan(ctx context.Context, t *orcv1.Task, tmpl *db.PhaseTemplate, phase *db.WorkflowPhase, runID string, req replanRequest) (string, error) {
+	turnExec := we.turnExecutor
+	if turnExec == nil {
+		provider, err := we.resolvePhaseProvider(tmpl, phase)
+		if err != nil {
+			return "", err
+		}
+		model, err := we.resolvePhaseModel(tmpl, phase)
+		if err != nil {
+			return "", err
+		}
+		turnExec = NewTurnExecutor(TurnExecutorConfig{
+			Provider:         provider,
+			ClaudePath:       we.claudePath,
+			CodexPath:        we.resolveCodexPath(),
+			Model:            model,
+			WorkingDir:       we.effectiveWorkingDir(),
+			SessionID:        fmt.Sprintf("%s-replan-%d", t.Id, replanCount(t)+1),
+			PhaseID:          replanPhaseID,
+			TaskID:           t.Id,
+			RunID:            runID,
+			MaxTurns:         10,
+			ProducesArtifact: true,
+			Backend:          we.backend,
+			Logger:           we.logger,
+			Publisher:        we.publisher,
+		})
+	}
+
+	turn, err := turnExec.ExecuteTurn(ctx, buildReplanPrompt(t, req))
+	if err != nil {
+		return "", fmt.Errorf("re-plan phase %s: %w", req.FailedPhase, err)
+	}
+	if turn.Status == PhaseStatusBlocked {
+		return "", fmt.Errorf("re-plan phase %s: blocked: %s", req.FailedPhase, turn.Reason)
+	}
+	plan := strings.TrimSpace(ExtractContentFromOutput(turn.Content))
+	if plan == "" {
+		return "", fmt.Errorf("re-plan phase %s: response contained no revised plan", req.FailedPhase)
+	}
+
+	task.EnsureMetadataProto(t)
+	t.Metadata[ReplanPlanKey] = plan
+	t.Metadata[ReplanCountKey] = strconv.Itoa(replanCount(t) + 1)
+	return plan, nil
+}
+
+// replanRetryReason is the RETRY_REASON given to phases re-run under a
+// revised plan.
+func replanRetryReason(failedPhase string, attempts int, plan string) string {
+	return fmt.Sprintf("Phase %s failed %d times and the approach was re-planned. "+
+		"Follow the revised plan below instead of repeating the previous attempt.\n\n%s",
+		failedPhase, attempts, plan)
+}
+
+// buildReplanPrompt assembles the re-planning prompt from the failure context.
+func buildReplanPrompt(t *orcv1.Task, req replanRequest) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Re-plan: %s\n\n", t.GetTitle())
+	fmt.Fprintf(&sb, "Task %s keeps failing in phase %q. It was retried from %q %d times and was rejected every time. ",
+		t.Id, req.FailedPhase, req.RetryFrom, req.Attempts)
+	sb.WriteString("Repeating the same approach will not work. Step back and revise the plan.\n\n")
+
+	if desc := strings.TrimSpace(t.GetDescription()); desc != "" {
+		fmt.Fprintf(&sb, "## Task\n\n%s\n\n", desc)
+	}
+	if spec := strings.TrimSpace(req.Spec); spec != "" {
+		fmt.Fprintf(&sb, "## Current Plan\n\n%s\n\n", spec)
+	}
+	if prev := strings.TrimSpace(t.GetMetadata()[ReplanPlanKey]); prev != "" {
+		fmt.Fprintf(&sb, "## Previous Re-plan (also failed)\n\n%s\n\n", prev)
+	}
+	fmt.Fprintf(&sb, "## Latest Rejection\n\n%s\n\n", strings.TrimSpace(req.Reason))
+	if output := strings.TrimSpace(req.Output); output != "" {
+		if len(output) > replanFeedbackLimit {
+			output = output[:replanFeedbackLimit] + "\n... (truncated)"
+		}
+		fmt.Fprintf(&sb, "## Last %s Output\n\n%s\n\n", req.FailedPhase, output)
+	}
+
+	sb.WriteString(`## Instructions
+
+1. Identify why the previous attempts failed. Name the root cause, not the symptoms.
+2. Revise the approach. If the work is too large to land in one pass, split it into smaller ordered steps that can each be verified.
+3. Call out anything from the current plan that should be dropped or changed.
+
+Do not modify code. Put the revised plan, as markdown, in the content field. It is handed to the "`)
+	sb.WriteString(req.RetryFrom)
+	sb.WriteString(`" phase as its retry instructions. Use status "blocked" only if the task cannot be completed as specified.
+`)
+	return sb.String()
+}
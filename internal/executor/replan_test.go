@@ -0,0 +1,202 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/gate"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+const replanTestResponse = `{"status": "complete", "summary": "Done", "content": "1. Split the migration into two steps"}`
+
+// setupReplanWorkflow creates an implement -> review workflow whose review
+// gate retries from implement, plus a task to run it.
+func setupReplanWorkflow(t *testing.T, backend *storage.DatabaseBackend, taskID string) *orcv1.Task {
+	t.Helper()
+	outputCfgJSON, _ := json.Marshal(db.GateOutputConfig{OnRejected: "retry", RetryFrom: "implement"})
+	for _, tmpl := range []*db.PhaseTemplate{
+		{ID: "implement", Name: "implement", PromptSource: "db", PromptContent: "Implement. {{RETRY_REASON}}"},
+		{ID: "review", Name: "review", PromptSource: "db", PromptContent: "Review", GateType: "ai", GateOutputConfig: string(outputCfgJSON)},
+	} {
+		if err := backend.SavePhaseTemplate(tmpl); err != nil {
+			t.Fatalf("save template %s: %v", tmpl.ID, err)
+		}
+	}
+	setupTwoPhaseWorkflow(t, backend, "replan-wf", "implement", "review")
+
+	tsk := task.NewProtoTask(taskID, "Migrate the store")
+	tsk.Status = orcv1.TaskStatus_TASK_STATUS_CREATED
+	wfID := "replan-wf"
+	tsk.WorkflowId = &wfID
+	if err := backend.SaveTask(tsk); err != nil {
+		t.Fatalf("save task: %v", err)
+	}
+	return tsk
+}
+
+// reviewRejector rejects the first n review gates and approves the rest.
+func reviewRejector(n int) *configGateEvaluator {
+	reviews := 0
+	return &configGateEvaluator{
+		decisionFn: func(g *gate.Gate, output string, opts *gate.EvaluateOptions) (*gate.Decision, error) {
+			if opts.Phase != "review" {
+				return &gate.Decision{Approved: true, Reason: "ok"}, nil
+			}
+			reviews++
+			if reviews <= n {
+				return &gate.Decision{Approved: false, Reason: "migration breaks rollback"}, nil
+			}
+			return &gate.Decision{Approved: true, Reason: "approved"}, nil
+		},
+	}
+}
+
+func replanConfig(gateType string) *config.Config {
+	return &config.Config{Retry: config.RetryConfig{
+		MaxRetries: 1,
+		Replan:     config.ReplanConfig{Enabled: true, MaxReplans: 1, Gate: gateType},
+	}}
+}
+
+func TestReplan_AutoGateRetriesWithRevisedPlan(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewTestBackend(t)
+	tsk := setupReplanWorkflow(t, backend, "TASK-REPLAN-001")
+
+	// Two rejections exhaust the single retry; the third review passes.
+	mockTE := NewMockTurnExecutor(replanTestResponse)
+	we := NewWorkflowExecutor(
+		backend, backend.DB(), testGlobalDBFrom(backend), replanConfig("auto"), t.TempDir(),
+		WithWorkflowLogger(slog.Default()),
+		WithWorkflowGateEvaluator(reviewRejector(2)),
+		WithWorkflowTurnExecutor(mockTE),
+	)
+
+	if _, err := we.Run(context.Background(), "replan-wf", WorkflowRunOptions{ContextType: ContextTask, TaskID: tsk.Id}); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	var replanPrompt, retryPrompt string
+	for i, p := range mockTE.Prompts {
+		if strings.HasPrefix(p, "# Re-plan:") {
+			replanPrompt = p
+			if i+1 < len(mockTE.Prompts) {
+				retryPrompt = mockTE.Prompts[i+1]
+			}
+		}
+	}
+	if !strings.Contains(replanPrompt, "migration breaks rollback") {
+		t.Fatalf("re-plan prompt missing failure context:\n%s", replanPrompt)
+	}
+	if !strings.Contains(retryPrompt, "Split the migration into two steps") {
+		t.Errorf("implement retry prompt missing revised plan:\n%s", retryPrompt)
+	}
+
+	updated, err := backend.LoadTask(tsk.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status == orcv1.TaskStatus_TASK_STATUS_FAILED {
+		t.Error("task failed; re-planning should have recovered it")
+	}
+	if updated.Metadata[ReplanCountKey] != "1" || !strings.Contains(updated.Metadata[ReplanPlanKey], "Split the migration") {
+		t.Errorf("replan metadata = %v", updated.Metadata)
+	}
+}
+
+func TestReplan_HumanGateBlocksForReview(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewTestBackend(t)
+	tsk := setupReplanWorkflow(t, backend, "TASK-REPLAN-002")
+
+	we := NewWorkflowExecutor(
+		backend, backend.DB(), testGlobalDBFrom(backend), replanConfig("human"), t.TempDir(),
+		WithWorkflowLogger(slog.Default()),
+		WithWorkflowGateEvaluator(reviewRejector(100)),
+		WithWorkflowTurnExecutor(NewMockTurnExecutor(replanTestResponse)),
+	)
+
+	_, err := we.Run(context.Background(), "replan-wf", WorkflowRunOptions{ContextType: ContextTask, TaskID: tsk.Id})
+	if !errors.Is(err, ErrTaskBlocked) {
+		t.Fatalf("err = %v, want ErrTaskBlocked", err)
+	}
+
+	updated, err := backend.LoadTask(tsk.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status != orcv1.TaskStatus_TASK_STATUS_BLOCKED || updated.Metadata["blocked_reason"] != "replan_review" {
+		t.Fatalf("status = %v blocked_reason = %q, want blocked for replan_review", updated.Status, updated.Metadata["blocked_reason"])
+	}
+	rs := task.GetRetryState(updated)
+	if rs == nil || rs.ToPhase != "implement" || !strings.Contains(rs.Reason, "Split the migration") {
+		t.Errorf("retry state = %+v, want revised plan queued for implement", rs)
+	}
+	if ps := updated.Execution.Phases["implement"]; ps == nil || ps.Status != orcv1.PhaseStatus_PHASE_STATUS_PENDING {
+		t.Errorf("implement phase = %v, want reset to pending so resume continues there", ps)
+	}
+}
+
+func TestReplan_FailsOnceReplansAreUsedUp(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewTestBackend(t)
+	tsk := setupReplanWorkflow(t, backend, "TASK-REPLAN-003")
+
+	mockTE := NewMockTurnExecutor(replanTestResponse)
+	we := NewWorkflowExecutor(
+		backend, backend.DB(), testGlobalDBFrom(backend), replanConfig("auto"), t.TempDir(),
+		WithWorkflowLogger(slog.Default()),
+		WithWorkflowGateEvaluator(reviewRejector(100)),
+		WithWorkflowTurnExecutor(mockTE),
+	)
+
+	if _, err := we.Run(context.Background(), "replan-wf", WorkflowRunOptions{ContextType: ContextTask, TaskID: tsk.Id}); err == nil {
+		t.Fatal("expected failure once the re-plan budget is spent")
+	}
+
+	replans := 0
+	for _, p := range mockTE.Prompts {
+		if strings.HasPrefix(p, "# Re-plan:") {
+			replans++
+		}
+	}
+	if replans != 1 {
+		t.Errorf("re-planned %d times, want 1 (max_replans)", replans)
+	}
+	updated, err := backend.LoadTask(tsk.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status != orcv1.TaskStatus_TASK_STATUS_FAILED {
+		t.Errorf("status = %v, want FAILED", updated.Status)
+	}
+}
+
+func TestBuildReplanPrompt(t *testing.T) {
+	tsk := task.NewProtoTask("TASK-009", "Big change")
+	task.EnsureMetadataProto(tsk)
+	tsk.Metadata[ReplanPlanKey] = "old plan"
+
+	prompt := buildReplanPrompt(tsk, replanRequest{
+		FailedPhase: "review",
+		RetryFrom:   "implement",
+		Reason:      "tests missing",
+		Output:      strings.Repeat("x", replanFeedbackLimit+10),
+		Attempts:    3,
+		Spec:        "the spec",
+	})
+	for _, want := range []string{"retried from \"implement\" 3 times", "## Current Plan\n\nthe spec", "old plan", "tests missing", "(truncated)"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,606 @@
+// replay.go re-drives the workflow state machine from a stored run.
+//
+// A Recording captures what the model said (one response per turn, taken
+// from the stored transcripts), what the gates decided, and how the run
+// ended. Replay feeds those answers back through a real WorkflowExecutor in
+// a scratch database, so gating, retry, loop and state-transition logic runs
+// exactly as it did, without a model. Recordings are plain JSON and can be
+// attached to bug reports.
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/gate"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+	"github.com/randalmurphal/orc/internal/workflow"
+)
+
+// Recording is a stored task run reduced to what replay needs.
+type Recording struct {
+	TaskID      string         `json:"task_id"`
+	RunID       string         `json:"run_id,omitempty"`
+	WorkflowID  string         `json:"workflow_id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	Category    string         `json:"category,omitempty"`
+	Turns       []RecordedTurn `json:"turns"`
+	Gates       []RecordedGate `json:"gates,omitempty"`
+
+	// Status and Phases are how the recorded run ended.
+	Status string            `json:"status"`
+	Phases map[string]string `json:"phases,omitempty"`
+}
+
+// RecordedTurn is one model turn: the prompt orc sent and the answer.
+type RecordedTurn struct {
+	Phase        string `json:"phase"`
+	Prompt       string `json:"prompt,omitempty"`
+	Response     string `json:"response"`
+	InputTokens  int    `json:"input_tokens,omitempty"`
+	OutputTokens int    `json:"output_tokens,omitempty"`
+}
+
+// RecordedGate is a gate evaluator decision. Decisions the executor made on
+// its own (no gate configured, auto-approve, skipped) are not recorded;
+// replay makes them again.
+type RecordedGate struct {
+	Phase    string `json:"phase"`
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// executorGateReasons are the reasons evaluatePhaseGate gives when it
+// decides without calling the gate evaluator.
+var executorGateReasons = map[string]bool{
+	"no gate configured":                 true,
+	"auto-approved on success":           true,
+	"gate skipped by configuration":      true,
+	"gates skipped by --skip-gates flag": true,
+}
+
+// LoadRecording builds a Recording of a task's run from its transcripts and
+// execution state. An empty runID picks the task's latest run.
+func LoadRecording(backend storage.Backend, taskID, runID string) (*Recording, error) {
+	t, err := backend.LoadTask(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("load task %s: %w", taskID, err)
+	}
+	if t == nil {
+		return nil, fmt.Errorf("task %s not found", taskID)
+	}
+	if runID == "" {
+		runs, err := backend.ListWorkflowRuns(db.WorkflowRunListOpts{TaskID: taskID, Limit: 1})
+		if err != nil {
+			return nil, fmt.Errorf("list runs for %s: %w", taskID, err)
+		}
+		if len(runs) > 0 {
+			runID = runs[0].ID
+		}
+	}
+
+	rec := &Recording{
+		TaskID:      t.Id,
+		RunID:       runID,
+		WorkflowID:  task.GetWorkflowIDProto(t),
+		Title:       t.Title,
+		Description: task.GetDescriptionProto(t),
+		Category:    task.CategoryFromProto(t.Category),
+		Status:      task.StatusFromProto(t.Status),
+		Phases:      map[string]string{},
+	}
+	if rec.WorkflowID == "" {
+		return nil, fmt.Errorf("task %s has no workflow", taskID)
+	}
+
+	transcripts, err := backend.GetTranscripts(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("load transcripts for %s: %w", taskID, err)
+	}
+	rec.Turns = recordTurns(transcripts, runID)
+
+	for _, g := range t.GetExecution().GetGates() {
+		if g.GateType == "skip" || executorGateReasons[g.GetReason()] {
+			continue
+		}
+		rec.Gates = append(rec.Gates, RecordedGate{Phase: g.Phase, Approved: g.Approved, Reason: g.GetReason()})
+	}
+	for phase, ps := range t.GetExecution().GetPhases() {
+		rec.Phases[phase] = task.PhaseStatusFromProto(ps.Status)
+	}
+	return rec, nil
+}
+
+// recordTurns splits transcripts into turns. Each stored user prompt starts
+// a turn; the turn's response is picked from the assistant messages that
+// follow it in the same phase.
+func recordTurns(transcripts []storage.Transcript, runID string) []RecordedTurn {
+	var (
+		turns    []RecordedTurn
+		messages []string
+	)
+	flush := func() {
+		if len(turns) > 0 {
+			turns[len(turns)-1].Response = turnResponse(messages)
+		}
+		messages = nil
+	}
+	for _, tr := range transcripts {
+		if runID != "" && tr.WorkflowRunID != "" && tr.WorkflowRunID != runID {
+			continue
+		}
+		switch tr.Type {
+		case "user":
+			flush()
+			turns = append(turns, RecordedTurn{Phase: tr.Phase, Prompt: tr.Content})
+		case "assistant":
+			if len(turns) == 0 || turns[len(turns)-1].Phase != tr.Phase {
+				continue
+			}
+			cur := &turns[len(turns)-1]
+			cur.InputTokens += tr.InputTokens
+			cur.OutputTokens += tr.OutputTokens
+			messages = append(messages, tr.Content)
+		}
+	}
+	flush()
+	return turns
+}
+
+// turnResponse picks a turn's answer from its assistant messages: the last
+// JSON object among them (plain JSON text, a text block, or a tool input
+// such as structured output), or else the last text.
+func turnResponse(messages []string) string {
+	var lastJSON, lastText string
+	for _, msg := range messages {
+		for _, candidate := range messageCandidates(msg) {
+			trimmed := strings.TrimSpace(candidate)
+			if trimmed == "" {
+				continue
+			}
+			lastText = trimmed
+			if strings.HasPrefix(trimmed, "{") && json.Valid([]byte(trimmed)) {
+				lastJSON = trimmed
+			}
+		}
+	}
+	if lastJSON != "" {
+		return lastJSON
+	}
+	return lastText
+}
+
+// messageCandidates returns the texts of a stored assistant message, which
+// is either provider text or a JSON array of content blocks.
+func messageCandidates(content string) []string {
+	var blocks []struct {
+		Type  string          `json:"type"`
+		Text  string          `json:"text"`
+		Input json.RawMessage `json:"input"`
+	}
+	if !strings.HasPrefix(strings.TrimSpace(content), "[") || json.Unmarshal([]byte(content), &blocks) != nil {
+		return []string{content}
+	}
+	var out []string
+	for _, b := range blocks {
+		switch b.Type {
+		case "text":
+			out = append(out, b.Text)
+		case "tool_use":
+			if len(b.Input) > 0 {
+				out = append(out, string(b.Input))
+			}
+		}
+	}
+	return out
+}
+
+// errReplayDiverged means the replayed run asked for a turn the recording
+// does not have.
+var errReplayDiverged = errors.New("replay diverged: no recorded turn left")
+
+// ReplayTurnExecutor answers turns from a recording, per phase in recorded
+// order. It implements PhaseTurnExecutor so parallel phases each draw from
+// their own queue.
+type ReplayTurnExecutor struct {
+	mu        sync.Mutex
+	queues    map[string][]RecordedTurn
+	sessionID string
+}
+
+var _ PhaseTurnExecutor = (*ReplayTurnExecutor)(nil)
+
+// NewReplayTurnExecutor queues the recorded turns by phase.
+func NewReplayTurnExecutor(turns []RecordedTurn) *ReplayTurnExecutor {
+	r := &ReplayTurnExecutor{queues: map[string][]RecordedTurn{}, sessionID: "replay"}
+	for _, turn := range turns {
+		r.queues[turn.Phase] = append(r.queues[turn.Phase], turn)
+	}
+	return r
+}
+
+// ForPhase returns a TurnExecutor answering from phaseID's queue.
+func (r *ReplayTurnExecutor) ForPhase(phaseID string) TurnExecutor {
+	return &phaseReplayExecutor{parent: r, phase: phaseID}
+}
+
+// ExecuteTurn fails: replayed turns must be scoped to a phase.
+func (r *ReplayTurnExecutor) ExecuteTurn(context.Context, string) (*TurnResult, error) {
+	return nil, fmt.Errorf("%w (turn outside a phase)", errReplayDiverged)
+}
+
+// ExecuteTurnWithoutSchema fails like ExecuteTurn.
+func (r *ReplayTurnExecutor) ExecuteTurnWithoutSchema(ctx context.Context, prompt string) (*TurnResult, error) {
+	return r.ExecuteTurn(ctx, prompt)
+}
+
+// UpdateSessionID records the session ID.
+func (r *ReplayTurnExecutor) UpdateSessionID(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessionID = id
+}
+
+// SessionID returns the session ID.
+func (r *ReplayTurnExecutor) SessionID() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sessionID
+}
+
+// Remaining returns the number of unused turns per phase.
+func (r *ReplayTurnExecutor) Remaining() map[string]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := map[string]int{}
+	for phase, q := range r.queues {
+		if len(q) > 0 {
+			out[phase] = len(q)
+		}
+	}
+	return out
+}
+
+func (r *ReplayTurnExecutor) next(phase string) (RecordedTurn, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	q := r.queues[phase]
+	if len(q) == 0 {
+		return RecordedTurn{}, false
+	}
+	r.queues[phase] = q[1:]
+	return q[0], true
+}
+
+type phaseReplayExecutor struct {
+	parent *ReplayTurnExecutor
+	phase  string
+}
+
+func (p *phaseReplayExecutor) ExecuteTurn(ctx context.Context, _ string) (*TurnResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	turn, ok := p.parent.next(p.phase)
+	if !ok {
+		err := fmt.Errorf("%w for phase %s", errReplayDiverged, p.phase)
+		return &TurnResult{IsError: true, ErrorText: err.Error()}, err
+	}
+	return &TurnResult{
+		Content:   turn.Response,
+		NumTurns:  1,
+		SessionID: p.parent.SessionID(),
+		Usage: &orcv1.TokenUsage{
+			InputTokens:  int32(turn.InputTokens),
+			OutputTokens: int32(turn.OutputTokens),
+		},
+	}, nil
+}
+
+func (p *phaseReplayExecutor) ExecuteTurnWithoutSchema(ctx context.Context, prompt string) (*TurnResult, error) {
+	return p.ExecuteTurn(ctx, prompt)
+}
+
+func (p *phaseReplayExecutor) UpdateSessionID(id string) { p.parent.UpdateSessionID(id) }
+func (p *phaseReplayExecutor) SessionID() string         { return p.parent.SessionID() }
+
+// ReplayGateEvaluator answers gate evaluations with the recorded decisions
+// for the phase, in order. Once a phase's decisions run out it approves.
+type ReplayGateEvaluator struct {
+	mu     sync.Mutex
+	queues map[string][]RecordedGate
+	trace  *replayTrace
+}
+
+var _ GateEvaluatorInterface = (*ReplayGateEvaluator)(nil)
+
+// NewReplayGateEvaluator queues the recorded decisions by phase.
+func NewReplayGateEvaluator(gates []RecordedGate) *ReplayGateEvaluator {
+	r := &ReplayGateEvaluator{queues: map[string][]RecordedGate{}}
+	for _, g := range gates {
+		r.queues[g.Phase] = append(r.queues[g.Phase], g)
+	}
+	return r
+}
+
+// Evaluate cannot tell the phase, so it approves.
+func (r *ReplayGateEvaluator) Evaluate(ctx context.Context, g *gate.Gate, output string) (*gate.Decision, error) {
+	return r.EvaluateWithOptions(ctx, g, output, &gate.EvaluateOptions{})
+}
+
+// EvaluateWithOptions returns the next recorded decision for opts.Phase.
+func (r *ReplayGateEvaluator) EvaluateWithOptions(_ context.Context, g *gate.Gate, _ string, opts *gate.EvaluateOptions) (*gate.Decision, error) {
+	r.mu.Lock()
+	decision := &gate.Decision{Approved: true, Reason: "replay: no recorded decision"}
+	if q := r.queues[opts.Phase]; len(q) > 0 {
+		decision = &gate.Decision{Approved: q[0].Approved, Reason: q[0].Reason}
+		r.queues[opts.Phase] = q[1:]
+	}
+	r.mu.Unlock()
+
+	verdict := "approved"
+	if !decision.Approved {
+		verdict = "rejected"
+	}
+	r.trace.add(fmt.Sprintf("gate %s %s: %s", opts.Phase, verdict, decision.Reason))
+	return decision, nil
+}
+
+// replayTrace collects the state transitions of a replayed run. It is an
+// events.Publisher so the executor's own phase events feed it.
+type replayTrace struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (t *replayTrace) add(line string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lines = append(t.lines, line)
+}
+
+func (t *replayTrace) Publish(ev events.Event) {
+	switch data := ev.Data.(type) {
+	case events.PhaseUpdate:
+		line := fmt.Sprintf("phase %s %s", data.Phase, data.Status)
+		if data.LoopTo != "" {
+			line += fmt.Sprintf(" -> %s (loop %d)", data.LoopTo, data.LoopCount)
+		}
+		if data.Error != "" {
+			line += ": " + data.Error
+		}
+		t.add(line)
+	case events.CompleteData:
+		t.add("complete " + data.Status)
+	}
+}
+
+func (t *replayTrace) Subscribe(string) <-chan events.Event {
+	ch := make(chan events.Event)
+	close(ch)
+	return ch
+}
+
+func (t *replayTrace) Unsubscribe(string, <-chan events.Event) {}
+func (t *replayTrace) Close()                                  {}
+
+// ReplayOptions configures Replay.
+type ReplayOptions struct {
+	// Workflows holds the recording's workflow definition. Nil uses the
+	// built-in workflows.
+	Workflows *db.GlobalDB
+	// Config drives gate and retry resolution; nil uses an empty config.
+	Config *config.Config
+	Logger *slog.Logger
+}
+
+// ReplayResult is how a replayed run ended.
+type ReplayResult struct {
+	Status string            `json:"status"`
+	Phases map[string]string `json:"phases"`
+	// Trace lists phase transitions and gate decisions in order.
+	Trace []string `json:"trace"`
+	// UnusedTurns counts recorded turns per phase the replay never asked for.
+	UnusedTurns map[string]int `json:"unused_turns,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	// Divergences lists where the replay ended differently from the recording.
+	Divergences []string `json:"divergences,omitempty"`
+}
+
+// Replay re-runs a recording through the workflow executor in a scratch
+// in-memory database and reports how it ended. An error means the replay
+// could not be set up; execution failures are part of the result.
+func Replay(ctx context.Context, rec *Recording, opts ReplayOptions) (*ReplayResult, error) {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	}
+	cfg := opts.Config
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+
+	workDir, err := os.MkdirTemp("", "orc-replay-")
+	if err != nil {
+		return nil, fmt.Errorf("create replay work dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(workDir) }()
+
+	scratch, err := storage.NewInMemoryBackend()
+	if err != nil {
+		return nil, fmt.Errorf("create scratch backend: %w", err)
+	}
+	defer func() { _ = scratch.Close() }()
+	gdb, err := db.OpenGlobalAt(filepath.Join(workDir, "orc.db"))
+	if err != nil {
+		return nil, fmt.Errorf("create scratch global database: %w", err)
+	}
+	defer func() { _ = gdb.Close() }()
+
+	// Runs reference workflows in both databases
+	if _, err := workflow.SeedBuiltins(gdb); err != nil {
+		return nil, fmt.Errorf("seed workflows: %w", err)
+	}
+	if _, err := workflow.SeedBuiltinsToProject(scratch.DB()); err != nil {
+		return nil, fmt.Errorf("seed project workflows: %w", err)
+	}
+	if opts.Workflows != nil {
+		for _, dst := range []*db.GlobalDB{gdb, {DB: scratch.DB().DB}} {
+			if err := copyWorkflow(opts.Workflows, dst, rec.WorkflowID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	t := task.NewProtoTask(rec.TaskID, rec.Title)
+	t.Status = orcv1.TaskStatus_TASK_STATUS_CREATED
+	t.WorkflowId = &rec.WorkflowID
+	if rec.Description != "" {
+		t.Description = &rec.Description
+	}
+	if rec.Category != "" {
+		t.Category = task.CategoryToProto(rec.Category)
+	}
+	if err := scratch.SaveTask(t); err != nil {
+		return nil, fmt.Errorf("save task: %w", err)
+	}
+
+	trace := &replayTrace{}
+	turns := NewReplayTurnExecutor(rec.Turns)
+	gates := NewReplayGateEvaluator(rec.Gates)
+	gates.trace = trace
+	we := NewWorkflowExecutor(
+		scratch, scratch.DB(), gdb, cfg, workDir,
+		WithWorkflowLogger(logger),
+		WithWorkflowPublisher(trace),
+		WithWorkflowTurnExecutor(turns),
+		WithWorkflowGateEvaluator(gates),
+	)
+
+	res := &ReplayResult{Phases: map[string]string{}}
+	prompt := rec.Description
+	if prompt == "" {
+		prompt = rec.Title
+	}
+	if _, runErr := we.Run(ctx, rec.WorkflowID, WorkflowRunOptions{
+		ContextType: ContextTask,
+		TaskID:      rec.TaskID,
+		Prompt:      prompt,
+		Category:    t.Category,
+	}); runErr != nil {
+		res.Error = runErr.Error()
+	}
+
+	final, err := scratch.LoadTask(rec.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("load replayed task: %w", err)
+	}
+	res.Status = task.StatusFromProto(final.Status)
+	for phase, ps := range final.GetExecution().GetPhases() {
+		res.Phases[phase] = task.PhaseStatusFromProto(ps.Status)
+	}
+	trace.mu.Lock()
+	res.Trace = append([]string(nil), trace.lines...)
+	trace.mu.Unlock()
+	res.UnusedTurns = turns.Remaining()
+	res.Divergences = diverges(rec, res)
+	return res, nil
+}
+
+// diverges compares a replay's outcome with the recorded one.
+func diverges(rec *Recording, res *ReplayResult) []string {
+	var out []string
+	if rec.Status != "" && rec.Status != res.Status {
+		out = append(out, fmt.Sprintf("task status: recorded %s, replayed %s", rec.Status, res.Status))
+	}
+	phases := make([]string, 0, len(rec.Phases))
+	for phase := range rec.Phases {
+		phases = append(phases, phase)
+	}
+	sort.Strings(phases)
+	for _, phase := range phases {
+		if got := res.Phases[phase]; got != rec.Phases[phase] {
+			out = append(out, fmt.Sprintf("phase %s: recorded %s, replayed %s", phase, rec.Phases[phase], got))
+		}
+	}
+	unused := make([]string, 0, len(res.UnusedTurns))
+	for phase := range res.UnusedTurns {
+		unused = append(unused, phase)
+	}
+	sort.Strings(unused)
+	for _, phase := range unused {
+		out = append(out, fmt.Sprintf("phase %s: %d recorded turn(s) not replayed", phase, res.UnusedTurns[phase]))
+	}
+	return out
+}
+
+// copyWorkflow copies a workflow with its phases, templates and variables.
+func copyWorkflow(src, dst *db.GlobalDB, workflowID string) error {
+	wf, err := src.GetWorkflow(workflowID)
+	if err != nil {
+		return fmt.Errorf("load workflow %s: %w", workflowID, err)
+	}
+	if wf == nil {
+		return fmt.Errorf("workflow %s not found", workflowID)
+	}
+	phases, err := src.GetWorkflowPhases(workflowID)
+	if err != nil {
+		return fmt.Errorf("load phases of %s: %w", workflowID, err)
+	}
+	for _, p := range phases {
+		tmpl, err := src.GetPhaseTemplate(p.PhaseTemplateID)
+		if err != nil {
+			return fmt.Errorf("load phase template %s: %w", p.PhaseTemplateID, err)
+		}
+		if tmpl != nil {
+			if err := dst.SavePhaseTemplate(tmpl); err != nil {
+				return fmt.Errorf("copy phase template %s: %w", tmpl.ID, err)
+			}
+		}
+	}
+	if err := dst.SaveWorkflow(wf); err != nil {
+		return fmt.Errorf("copy workflow %s: %w", workflowID, err)
+	}
+	// A custom workflow may shadow a built-in one with different phases
+	existing, err := dst.GetWorkflowPhases(workflowID)
+	if err != nil {
+		return fmt.Errorf("load seeded phases of %s: %w", workflowID, err)
+	}
+	for _, p := range existing {
+		if err := dst.DeleteWorkflowPhase(workflowID, p.PhaseTemplateID); err != nil {
+			return fmt.Errorf("clear phase %s: %w", p.PhaseTemplateID, err)
+		}
+	}
+	for _, p := range phases {
+		if err := dst.SaveWorkflowPhase(p); err != nil {
+			return fmt.Errorf("copy phase %s: %w", p.PhaseTemplateID, err)
+		}
+	}
+	vars, err := src.GetWorkflowVariables(workflowID)
+	if err != nil {
+		return fmt.Errorf("load variables of %s: %w", workflowID, err)
+	}
+	for _, v := range vars {
+		if err := dst.SaveWorkflowVariable(v); err != nil {
+			return fmt.Errorf("copy variable %s: %w", v.Name, err)
+		}
+	}
+	return nil
+}
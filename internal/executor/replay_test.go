@@ -0,0 +1,188 @@
+package executor
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+const replayLoopConfig = `{
+	"loop_to_phase": "implement",
+	"condition": {"field": "phase_output.review.needs_changes", "op": "eq", "value": "true"},
+	"max_loops": 3
+}`
+
+func replayLoopRecording() *Recording {
+	return &Recording{
+		TaskID:     "TASK-REPLAY-001",
+		WorkflowID: "loop-wf",
+		Title:      "Replay test task",
+		Category:   "feature",
+		Turns: []RecordedTurn{
+			{Phase: "implement", Response: `{"status": "complete", "summary": "Implemented"}`},
+			{Phase: "review", Response: `{"needs_changes": true, "summary": "Found issues"}`},
+			{Phase: "implement", Response: `{"status": "complete", "summary": "Fixed"}`},
+			{Phase: "review", Response: `{"needs_changes": false, "summary": "All good"}`},
+		},
+		Status: "completed",
+		Phases: map[string]string{"implement": "completed", "review": "completed"},
+	}
+}
+
+func TestReplay_ReproducesLoop(t *testing.T) {
+	t.Parallel()
+
+	source := storage.NewTestBackend(t)
+	setupLoopWorkflow(t, source, replayLoopConfig)
+
+	res, err := Replay(context.Background(), replayLoopRecording(), ReplayOptions{Workflows: testGlobalDBFrom(source)})
+	if err != nil {
+		t.Fatalf("Replay() error: %v", err)
+	}
+	if res.Error != "" || len(res.Divergences) > 0 {
+		t.Fatalf("replay error %q, divergences %v", res.Error, res.Divergences)
+	}
+	if !slices.ContainsFunc(res.Trace, func(l string) bool { return strings.HasPrefix(l, "phase review looping -> implement") }) {
+		t.Errorf("trace has no review loop:\n%s", strings.Join(res.Trace, "\n"))
+	}
+
+	// Replays are deterministic
+	again, err := Replay(context.Background(), replayLoopRecording(), ReplayOptions{Workflows: testGlobalDBFrom(source)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(res.Trace, again.Trace) {
+		t.Errorf("traces differ:\n%s\n---\n%s", strings.Join(res.Trace, "\n"), strings.Join(again.Trace, "\n"))
+	}
+}
+
+func TestReplay_ReportsDivergence(t *testing.T) {
+	t.Parallel()
+
+	source := storage.NewTestBackend(t)
+	setupLoopWorkflow(t, source, replayLoopConfig)
+
+	rec := replayLoopRecording()
+	rec.Turns = rec.Turns[:3] // the second review never happened
+
+	res, err := Replay(context.Background(), rec, ReplayOptions{Workflows: testGlobalDBFrom(source)})
+	if err != nil {
+		t.Fatalf("Replay() error: %v", err)
+	}
+	if !strings.Contains(res.Error, "replay diverged") {
+		t.Errorf("error = %q, want a divergence", res.Error)
+	}
+	if res.Status == "completed" || len(res.Divergences) == 0 {
+		t.Errorf("status %s, divergences %v; want a failed, divergent replay", res.Status, res.Divergences)
+	}
+}
+
+func TestLoadRecording_FromTranscripts(t *testing.T) {
+	t.Parallel()
+
+	backend := storage.NewTestBackend(t)
+	tsk := task.NewProtoTask("TASK-REC-001", "Recorded task")
+	wfID := "implement-small"
+	tsk.WorkflowId = &wfID
+	tsk.Status = orcv1.TaskStatus_TASK_STATUS_FAILED
+	task.CompletePhaseProto(tsk.Execution, "implement", "abc123")
+	task.RecordGateDecisionProto(tsk.Execution, "implement", "", true, "no gate configured")
+	task.RecordGateDecisionProto(tsk.Execution, "review", "human", false, "needs tests")
+	if err := backend.SaveTask(tsk); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, tr := range []storage.Transcript{
+		{Phase: "implement", Type: "user", Content: "Implement it"},
+		{Phase: "implement", Type: "assistant", Content: "Looking at the code.", OutputTokens: 5},
+		{Phase: "implement", Type: "assistant", Content: `[{"type":"text","text":"Done."},{"type":"tool_use","name":"StructuredOutput","input":{"status":"complete"}}]`, OutputTokens: 7},
+		{Phase: "implement", Type: "tool", Content: `{"status":"ignored"}`},
+		{Phase: "review", Type: "user", Content: "Review it"},
+		{Phase: "review", Type: "assistant", Content: "No JSON here"},
+	} {
+		tr.TaskID = tsk.Id
+		tr.MessageUUID = "msg-" + string(rune('a'+i))
+		tr.Timestamp = int64(1000 + i)
+		if err := backend.AddTranscript(&tr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rec, err := LoadRecording(backend, tsk.Id, "")
+	if err != nil {
+		t.Fatalf("LoadRecording() error: %v", err)
+	}
+	want := []RecordedTurn{
+		{Phase: "implement", Prompt: "Implement it", Response: `{"status":"complete"}`, OutputTokens: 12},
+		{Phase: "review", Prompt: "Review it", Response: "No JSON here"},
+	}
+	if !slices.Equal(rec.Turns, want) {
+		t.Errorf("turns = %+v, want %+v", rec.Turns, want)
+	}
+	if len(rec.Gates) != 1 || rec.Gates[0] != (RecordedGate{Phase: "review", Reason: "needs tests"}) {
+		t.Errorf("gates = %+v, want only the human review rejection", rec.Gates)
+	}
+	if rec.Status != "failed" || rec.Phases["implement"] != "completed" || rec.WorkflowID != wfID {
+		t.Errorf("recording = %+v", rec)
+	}
+}
+
+func TestReplay_RecordedGateRetry(t *testing.T) {
+	t.Parallel()
+
+	source := storage.NewTestBackend(t)
+	pdb := source.DB()
+	if err := pdb.SaveWorkflow(&db.Workflow{ID: "replay-gate-wf", Name: "Replay Gate Workflow"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pdb.SavePhaseTemplate(&db.PhaseTemplate{
+		ID:             "gated_build",
+		Name:           "gated_build",
+		PromptSource:   "db",
+		PromptContent:  "Build it.",
+		GateType:       "human",
+		RetryFromPhase: "gated_build",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pdb.SaveWorkflowPhase(&db.WorkflowPhase{
+		WorkflowID:      "replay-gate-wf",
+		PhaseTemplateID: "gated_build",
+		Sequence:        1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := &Recording{
+		TaskID:     "TASK-REPLAY-002",
+		WorkflowID: "replay-gate-wf",
+		Title:      "Gated task",
+		Turns: []RecordedTurn{
+			{Phase: "gated_build", Response: `{"status": "complete", "summary": "Done"}`},
+			{Phase: "gated_build", Response: `{"status": "complete", "summary": "Added tests"}`},
+		},
+		Gates: []RecordedGate{
+			{Phase: "gated_build", Approved: false, Reason: "needs tests"},
+			{Phase: "gated_build", Approved: true, Reason: "lgtm"},
+		},
+		Status: "completed",
+	}
+	res, err := Replay(context.Background(), rec, ReplayOptions{Workflows: testGlobalDBFrom(source)})
+	if err != nil {
+		t.Fatalf("Replay() error: %v", err)
+	}
+	rejected := slices.Index(res.Trace, "gate gated_build rejected: needs tests")
+	approved := slices.Index(res.Trace, "gate gated_build approved: lgtm")
+	if rejected < 0 || approved < rejected {
+		t.Errorf("trace does not retry after the rejection:\n%s", strings.Join(res.Trace, "\n"))
+	}
+	if len(res.Divergences) > 0 {
+		t.Errorf("divergences: %v", res.Divergences)
+	}
+}
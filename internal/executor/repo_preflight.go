@@ -0,0 +1,151 @@
+package executor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/randalmurphal/orc/internal/config"
+	orcerrors "github.com/randalmurphal/orc/internal/errors"
+	"github.com/randalmurphal/orc/internal/git"
+	"github.com/randalmurphal/orc/internal/util"
+)
+
+// RepoPreflightError reports a failed repository hygiene check. Its message
+// carries the fix, so the error recorded on the task is actionable on its
+// own; the structured error is available through errors.As.
+type RepoPreflightError struct {
+	Check string // "disk", "clean", "remote", or "diverged"
+	Err   *orcerrors.OrcError
+}
+
+func (e *RepoPreflightError) Error() string {
+	msg := fmt.Sprintf("repository preflight (%s) failed: %s", e.Check, e.Err.Error())
+	if e.Err.Fix != "" {
+		msg += ". Fix: " + e.Err.Fix
+	}
+	return msg
+}
+
+func (e *RepoPreflightError) Unwrap() error {
+	return e.Err
+}
+
+// CheckRepoPreflight verifies the repository can host a new task worktree
+// based on targetBranch:
+//
+//   - enough free disk space where worktrees are created
+//   - no merge, rebase, or cherry-pick in progress in the main checkout, and
+//     no uncommitted changes there when it has the target branch checked out
+//   - origin, when configured, is reachable
+//   - the local target branch has not diverged from origin's
+//
+// It returns a *RepoPreflightError for the first check that fails.
+func CheckRepoPreflight(gitOps *git.Git, cfg config.PreflightConfig, targetBranch string) error {
+	if gitOps == nil || !cfg.Enabled {
+		return nil
+	}
+	if err := checkPreflightDisk(gitOps.WorktreeBaseDir(), cfg.MinFreeDiskMB); err != nil {
+		return err
+	}
+	if err := checkPreflightClean(gitOps, targetBranch); err != nil {
+		return err
+	}
+
+	if !gitOps.HasRemote("origin") {
+		return nil
+	}
+	if cfg.CheckRemote {
+		if _, err := gitOps.RemoteBranchExists("origin", targetBranch); err != nil {
+			return &RepoPreflightError{Check: "remote", Err: orcerrors.ErrGitRemoteUnreachable("origin").WithCause(err)}
+		}
+	}
+	return checkPreflightDiverged(gitOps, targetBranch)
+}
+
+func checkPreflightDisk(dir string, minFreeMB int) error {
+	if minFreeMB <= 0 || dir == "" {
+		return nil
+	}
+	// The worktree directory may not exist yet; measure its nearest ancestor.
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+	free, err := util.FreeDiskBytes(dir)
+	if err != nil {
+		// Unsupported platform or unreadable filesystem; not worth blocking on.
+		return nil
+	}
+	freeMB := free / (1024 * 1024)
+	if freeMB < uint64(minFreeMB) {
+		return &RepoPreflightError{Check: "disk", Err: orcerrors.ErrDiskSpaceLow(dir, freeMB, uint64(minFreeMB))}
+	}
+	return nil
+}
+
+func checkPreflightClean(gitOps *git.Git, targetBranch string) error {
+	if inProgress, err := gitOps.IsRebaseInProgress(); err == nil && inProgress {
+		return &RepoPreflightError{Check: "clean", Err: orcerrors.ErrGitRepoNotClean(
+			"A rebase is in progress in the main checkout",
+			"Finish it with 'git rebase --continue' or abort it with 'git rebase --abort'")}
+	}
+	if inProgress, err := gitOps.IsMergeInProgress(); err == nil && inProgress {
+		return &RepoPreflightError{Check: "clean", Err: orcerrors.ErrGitRepoNotClean(
+			"A merge is in progress in the main checkout",
+			"Commit the merge or abort it with 'git merge --abort'")}
+	}
+	if _, err := gitOps.Context().RunGit("rev-parse", "-q", "--verify", "CHERRY_PICK_HEAD"); err == nil {
+		return &RepoPreflightError{Check: "clean", Err: orcerrors.ErrGitRepoNotClean(
+			"A cherry-pick is in progress in the main checkout",
+			"Finish it with 'git cherry-pick --continue' or abort it with 'git cherry-pick --abort'")}
+	}
+
+	current, err := gitOps.GetCurrentBranch()
+	if err != nil || current != targetBranch {
+		return nil
+	}
+	out, err := gitOps.Context().RunGit("status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return fmt.Errorf("repository preflight: git status: %w", err)
+	}
+	if out != "" {
+		return &RepoPreflightError{Check: "clean", Err: orcerrors.ErrGitRepoNotClean(
+			fmt.Sprintf("The main checkout has uncommitted changes on %s, which task completion merges into", targetBranch),
+			fmt.Sprintf("Commit or stash the changes on %s ('git stash'), or check out another branch in the main checkout", targetBranch))}
+	}
+	return nil
+}
+
+func checkPreflightDiverged(gitOps *git.Git, targetBranch string) error {
+	ctx := gitOps.Context()
+	if _, err := ctx.RunGit("show-ref", "--verify", "--quiet", "refs/heads/"+targetBranch); err != nil {
+		return nil
+	}
+	remoteRef := "refs/remotes/origin/" + targetBranch
+	if _, err := ctx.RunGit("show-ref", "--verify", "--quiet", remoteRef); err != nil {
+		return nil
+	}
+	out, err := ctx.RunGit("rev-list", "--left-right", "--count", "refs/heads/"+targetBranch+"..."+remoteRef)
+	if err != nil {
+		return fmt.Errorf("repository preflight: compare %s with origin: %w", targetBranch, err)
+	}
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return fmt.Errorf("repository preflight: unexpected rev-list output %q", out)
+	}
+	ahead, _ := strconv.Atoi(fields[0])
+	behind, _ := strconv.Atoi(fields[1])
+	if ahead > 0 && behind > 0 {
+		return &RepoPreflightError{Check: "diverged", Err: orcerrors.ErrGitDiverged(targetBranch, "origin", ahead, behind)}
+	}
+	return nil
+}
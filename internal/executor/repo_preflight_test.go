@@ -0,0 +1,166 @@
+package executor
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+	orcerrors "github.com/randalmurphal/orc/internal/errors"
+	"github.com/randalmurphal/orc/internal/git"
+)
+
+// setupPreflightRepo creates a repo on main with one commit, optionally
+// pushed to a bare origin.
+func setupPreflightRepo(t *testing.T, withRemote bool) (*git.Git, string) {
+	t.Helper()
+	repoDir := t.TempDir()
+	runGitCmdOrFatal(t, repoDir, "init", "--initial-branch=main")
+	runGitCmdOrFatal(t, repoDir, "config", "user.email", "test@example.com")
+	runGitCmdOrFatal(t, repoDir, "config", "user.name", "Test")
+	writeTestFile(t, repoDir, "README.md", "# Initial\n")
+	runGitCmdOrFatal(t, repoDir, "add", ".")
+	runGitCmdOrFatal(t, repoDir, "commit", "-m", "Initial commit")
+	if withRemote {
+		remoteDir := filepath.Join(t.TempDir(), "origin.git")
+		runGitCmdOrFatal(t, repoDir, "init", "--bare", remoteDir)
+		runGitCmdOrFatal(t, repoDir, "remote", "add", "origin", remoteDir)
+		runGitCmdOrFatal(t, repoDir, "push", "-u", "origin", "main")
+	}
+
+	gitCfg := git.DefaultConfig()
+	gitCfg.WorktreeDir = filepath.Join(repoDir, ".orc", "worktrees")
+	gitOps, err := git.New(repoDir, gitCfg)
+	if err != nil {
+		t.Fatalf("git.New: %v", err)
+	}
+	return gitOps, repoDir
+}
+
+func preflightConfig() config.PreflightConfig {
+	return config.Default().Worktree.Preflight
+}
+
+// requirePreflightFailure asserts err is a preflight failure of the given
+// check carrying the given taxonomy code.
+func requirePreflightFailure(t *testing.T, err error, check string, code orcerrors.Code) {
+	t.Helper()
+	var pe *RepoPreflightError
+	if !errors.As(err, &pe) {
+		t.Fatalf("err = %v, want *RepoPreflightError", err)
+	}
+	if pe.Check != check {
+		t.Errorf("check = %q, want %q", pe.Check, check)
+	}
+	orcErr := orcerrors.AsOrcError(err)
+	if orcErr == nil || orcErr.Code != code {
+		t.Fatalf("orc error = %v, want code %s", orcErr, code)
+	}
+	if !strings.Contains(err.Error(), "Fix: ") {
+		t.Errorf("error %q does not carry the fix", err.Error())
+	}
+}
+
+func TestCheckRepoPreflight_CleanRepoPasses(t *testing.T) {
+	t.Parallel()
+	gitOps, repoDir := setupPreflightRepo(t, true)
+	writeTestFile(t, repoDir, "notes.txt", "untracked files are fine\n")
+
+	if err := CheckRepoPreflight(gitOps, preflightConfig(), "main"); err != nil {
+		t.Fatalf("CheckRepoPreflight: %v", err)
+	}
+}
+
+func TestCheckRepoPreflight_UncommittedChangesOnTarget(t *testing.T) {
+	t.Parallel()
+	gitOps, repoDir := setupPreflightRepo(t, false)
+	writeTestFile(t, repoDir, "README.md", "# Edited\n")
+
+	err := CheckRepoPreflight(gitOps, preflightConfig(), "main")
+	requirePreflightFailure(t, err, "clean", orcerrors.CodeGitDirty)
+
+	// A different target branch is unaffected by edits on main.
+	if err := CheckRepoPreflight(gitOps, preflightConfig(), "develop"); err != nil {
+		t.Errorf("target develop: %v", err)
+	}
+}
+
+func TestCheckRepoPreflight_MergeInProgress(t *testing.T) {
+	t.Parallel()
+	gitOps, repoDir := setupPreflightRepo(t, false)
+	runGitCmdOrFatal(t, repoDir, "checkout", "-b", "feature")
+	writeTestFile(t, repoDir, "README.md", "# Feature\n")
+	runGitCmdOrFatal(t, repoDir, "commit", "-am", "Feature edit")
+	runGitCmdOrFatal(t, repoDir, "checkout", "main")
+	writeTestFile(t, repoDir, "README.md", "# Main\n")
+	runGitCmdOrFatal(t, repoDir, "commit", "-am", "Main edit")
+	// Conflicting merge leaves MERGE_HEAD behind.
+	_ = runGitCmd(repoDir, "merge", "feature")
+
+	err := CheckRepoPreflight(gitOps, preflightConfig(), "develop")
+	requirePreflightFailure(t, err, "clean", orcerrors.CodeGitDirty)
+	if !strings.Contains(err.Error(), "git merge --abort") {
+		t.Errorf("error = %q, want merge abort hint", err.Error())
+	}
+}
+
+func TestCheckRepoPreflight_DivergedTarget(t *testing.T) {
+	t.Parallel()
+	gitOps, repoDir := setupPreflightRepo(t, true)
+
+	// Someone else pushes to origin/main...
+	other := t.TempDir()
+	remoteURL, err := gitOps.Context().RunGit("remote", "get-url", "origin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	runGitCmdOrFatal(t, other, "clone", "--branch", "main", remoteURL, ".")
+	runGitCmdOrFatal(t, other, "config", "user.email", "other@example.com")
+	runGitCmdOrFatal(t, other, "config", "user.name", "Other")
+	writeTestFile(t, other, "upstream.txt", "upstream\n")
+	runGitCmdOrFatal(t, other, "add", ".")
+	runGitCmdOrFatal(t, other, "commit", "-m", "Upstream change")
+	runGitCmdOrFatal(t, other, "push", "origin", "main")
+	// ...while local main gets its own commit.
+	runGitCmdOrFatal(t, repoDir, "fetch", "origin")
+	writeTestFile(t, repoDir, "local.txt", "local\n")
+	runGitCmdOrFatal(t, repoDir, "add", ".")
+	runGitCmdOrFatal(t, repoDir, "commit", "-m", "Local change")
+
+	err = CheckRepoPreflight(gitOps, preflightConfig(), "main")
+	requirePreflightFailure(t, err, "diverged", orcerrors.CodeGitDiverged)
+	if !strings.Contains(err.Error(), "1 commit(s) ahead and 1 behind") {
+		t.Errorf("error = %q, want ahead/behind counts", err.Error())
+	}
+}
+
+func TestCheckRepoPreflight_UnreachableRemote(t *testing.T) {
+	t.Parallel()
+	gitOps, repoDir := setupPreflightRepo(t, false)
+	runGitCmdOrFatal(t, repoDir, "remote", "add", "origin", filepath.Join(t.TempDir(), "missing.git"))
+
+	err := CheckRepoPreflight(gitOps, preflightConfig(), "main")
+	requirePreflightFailure(t, err, "remote", orcerrors.CodeGitRemoteUnreachable)
+
+	cfg := preflightConfig()
+	cfg.CheckRemote = false
+	if err := CheckRepoPreflight(gitOps, cfg, "main"); err != nil {
+		t.Errorf("check_remote=false: %v", err)
+	}
+}
+
+func TestCheckRepoPreflight_DiskSpace(t *testing.T) {
+	t.Parallel()
+	gitOps, _ := setupPreflightRepo(t, false)
+
+	cfg := preflightConfig()
+	cfg.MinFreeDiskMB = 1 << 40 // More than any test machine has
+	err := CheckRepoPreflight(gitOps, cfg, "main")
+	requirePreflightFailure(t, err, "disk", orcerrors.CodeDiskSpaceLow)
+
+	cfg.Enabled = false
+	if err := CheckRepoPreflight(gitOps, cfg, "main"); err != nil {
+		t.Errorf("disabled preflight: %v", err)
+	}
+}
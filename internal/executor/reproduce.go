@@ -0,0 +1,183 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// ReproducePhaseID is the phase that writes a failing regression test for a
+// bug before implementation starts.
+const ReproducePhaseID = "reproduce"
+
+// ReproductionCommandKey is the task metadata key holding the command that
+// runs the regression tests written by the reproduce phase. Implementation
+// phases must make it pass.
+const ReproductionCommandKey = "reproduction_test_command"
+
+// reproductionCheckName names the reproduction gate in quality check results.
+const reproductionCheckName = "reproduction"
+
+// reproductionCheckTimeout bounds one run of the reproduction command, which
+// may include compiling the package under test.
+const reproductionCheckTimeout = 5 * time.Minute
+
+// ReproduceCompletionSchema is the JSON schema for the reproduce phase.
+const ReproduceCompletionSchema = `{
+	"type": "object",
+	"properties": {
+		"status": {
+			"type": "string",
+			"enum": ["complete", "blocked", "continue"],
+			"description": "Phase status: complete (failing test written), blocked (cannot reproduce), continue (more work needed)"
+		},
+		"reason": {
+			"type": "string",
+			"description": "Explanation for blocked status, or progress summary for continue"
+		},
+		"summary": {
+			"type": "string",
+			"description": "Work summary for complete status"
+		},
+		"content": {
+			"type": "string",
+			"description": "Reproduction report: steps, expected vs actual behavior, root cause, and the tests written. REQUIRED when status is complete."
+		},
+		"test_command": {
+			"type": "string",
+			"description": "Shell command, run from the worktree root, that runs only the new regression tests. It must fail now and pass once the bug is fixed. REQUIRED when status is complete."
+		},
+		"tests": {
+			"type": "array",
+			"items": {
+				"type": "object",
+				"properties": {
+					"file": {"type": "string"},
+					"name": {"type": "string"}
+				},
+				"required": ["file", "name"]
+			}
+		}
+	},
+	"required": ["status"]
+}`
+
+// ReproductionTest identifies one regression test written by the reproduce
+// phase.
+type ReproductionTest struct {
+	File string `json:"file"`
+	Name string `json:"name"`
+}
+
+// ReproduceResponse is the reproduce phase's completion output.
+type ReproduceResponse struct {
+	Status      string             `json:"status"`
+	Reason      string             `json:"reason,omitempty"`
+	Summary     string             `json:"summary,omitempty"`
+	Content     string             `json:"content,omitempty"`
+	TestCommand string             `json:"test_command,omitempty"`
+	Tests       []ReproductionTest `json:"tests,omitempty"`
+}
+
+// ParseReproduceResponse parses the reproduce phase's JSON output.
+func ParseReproduceResponse(content string) (*ReproduceResponse, error) {
+	var resp ReproduceResponse
+	if err := decodeStructuredJSON(content, &resp); err != nil {
+		return nil, fmt.Errorf("invalid reproduce response JSON: %w", err)
+	}
+	resp.TestCommand = strings.TrimSpace(resp.TestCommand)
+	return &resp, nil
+}
+
+// verifyReproduction gates completion of the reproduce phase: the reported
+// test command must fail on the current code. On success the command is
+// saved to the task so implementation phases can require it to pass. It
+// returns the prompt to send back to the agent, or "" when the gate passes.
+func (we *WorkflowExecutor) verifyReproduction(ctx context.Context, cfg PhaseExecutionConfig, output string) (string, error) {
+	resp, err := ParseReproduceResponse(output)
+	if err != nil {
+		return "", err
+	}
+	if resp.TestCommand == "" {
+		return "Your completion output has no `test_command`. Provide the shell command that runs only the " +
+			"regression tests you wrote, then output the completion JSON again.", nil
+	}
+
+	runner := we.newQualityCheckRunner(cfg.WorkingDir, []db.QualityCheck{{
+		Type:          "custom",
+		Name:          reproductionCheckName,
+		Command:       resp.TestCommand,
+		Enabled:       true,
+		OnFailure:     "block",
+		TimeoutMs:     int(reproductionCheckTimeout / time.Millisecond),
+		ExpectFailure: true,
+	}}, nil)
+	result := runner.Run(ctx)
+	if !result.AllPassed {
+		we.logger.Info("reproduction test did not fail, continuing iteration",
+			"phase", cfg.PhaseID,
+			"command", resp.TestCommand,
+		)
+		return formatReproductionFeedback(resp.TestCommand, result), nil
+	}
+
+	we.logger.Info("reproduction verified: regression test fails before the fix",
+		"phase", cfg.PhaseID,
+		"command", resp.TestCommand,
+	)
+	if we.task == nil {
+		return "", nil
+	}
+	task.EnsureMetadataProto(we.task)
+	we.task.Metadata[ReproductionCommandKey] = resp.TestCommand
+	if err := we.saveTaskStrict(we.task, "save reproduction test command"); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+// formatReproductionFeedback tells the agent its regression test does not
+// reproduce the bug.
+func formatReproductionFeedback(command string, result *QualityCheckResult) string {
+	var output string
+	if len(result.Checks) > 0 {
+		output = truncateCheckOutput(result.Checks[0].Output, 3000)
+	}
+	return fmt.Sprintf(`## Reproduction Not Verified
+
+orc ran your test command and it did not fail:
+
+    %s
+
+`+"```"+`
+%s
+`+"```"+`
+
+A regression test must FAIL on the current code because of the bug. If it passes, it does not reproduce the bug; if it timed out, narrow the command to the new tests. Fix the tests (not the production code), then output the completion JSON again.`,
+		command, output)
+}
+
+// reproductionCheck returns the blocking check that implementation phases
+// run when the reproduce phase recorded a regression test, or nil.
+func reproductionCheck(t *orcv1.Task) *db.QualityCheck {
+	if t == nil {
+		return nil
+	}
+	command := t.Metadata[ReproductionCommandKey]
+	if command == "" {
+		return nil
+	}
+	return &db.QualityCheck{
+		Type:      "custom",
+		Name:      reproductionCheckName,
+		Command:   command,
+		Enabled:   true,
+		OnFailure: "block",
+		TimeoutMs: int(reproductionCheckTimeout / time.Millisecond),
+	}
+}
@@ -132,7 +132,8 @@ const (
 				"type": "array",
 				"items": {"type": "string"},
 				"description": "Positive aspects noted"
-			}
+			},
+			` + followUpsSchemaProperty + `
 		},
 		"required": ["needs_changes", "round", "summary", "issues"]
 	}`
@@ -184,7 +185,8 @@ const (
 			"recommendation": {
 				"type": "string",
 				"description": "What should happen next"
-			}
+			},
+			` + followUpsSchemaProperty + `
 		},
 		"required": ["status", "summary", "recommendation"]
 	}`
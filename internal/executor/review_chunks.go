@@ -0,0 +1,280 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/randalmurphal/orc/internal/diff"
+	"github.com/randalmurphal/orc/internal/git"
+)
+
+// ReviewChunk is a group of changed files reviewed together when a diff is
+// too large for one review pass.
+type ReviewChunk struct {
+	Index int      // 1-based position in the review
+	Dirs  []string // Directories the files belong to
+	Files []string
+	Lines int // Changed lines (additions + deletions)
+}
+
+// reviewChunkingApplies reports whether a phase may be split into chunks:
+// a findings review (the round 2 decision judges the whole change) with
+// review.chunking enabled.
+func (we *WorkflowExecutor) reviewChunkingApplies(cfg PhaseExecutionConfig) bool {
+	if we.orcConfig == nil || !we.orcConfig.Review.Chunking.Enabled {
+		return false
+	}
+	switch canonicalPhaseID(cfg.PhaseID) {
+	case "review_cross":
+		return true
+	case "review":
+		return cfg.ReviewRound != 2
+	}
+	return false
+}
+
+// reviewChunks splits the task's diff into review chunks. It returns nil
+// when chunking does not apply or the diff fits in one review.
+func (we *WorkflowExecutor) reviewChunks(ctx context.Context, cfg PhaseExecutionConfig) []ReviewChunk {
+	if !we.reviewChunkingApplies(cfg) || cfg.WorkingDir == "" {
+		return nil
+	}
+	gitCtx, err := git.NewContext(cfg.WorkingDir)
+	if err != nil {
+		we.logger.Debug("skipping review chunking outside a git repository", "dir", cfg.WorkingDir, "error", err)
+		return nil
+	}
+	base := we.taskForkPoint(gitCtx)
+	if base == "" {
+		return nil
+	}
+	files, err := diff.NewService(cfg.WorkingDir, nil).GetFileList(ctx, base, "")
+	if err != nil {
+		we.logger.Warn("failed to list changed files for review chunking, reviewing in one pass", "error", err)
+		return nil
+	}
+	limits := we.orcConfig.Review.Chunking
+	chunks := buildReviewChunks(files, limits.MaxLines, limits.MaxFiles)
+	if len(chunks) < 2 {
+		return nil
+	}
+	return chunks
+}
+
+// buildReviewChunks packs changed files into chunks of at most maxLines
+// changed lines and maxFiles files. Files in the same directory stay
+// together unless the directory alone exceeds a limit; a single file over
+// maxLines gets a chunk of its own.
+func buildReviewChunks(files []diff.FileDiff, maxLines, maxFiles int) []ReviewChunk {
+	byDir := make(map[string][]diff.FileDiff)
+	for _, f := range files {
+		dir := path.Dir(f.Path)
+		byDir[dir] = append(byDir[dir], f)
+	}
+	dirs := make([]string, 0, len(byDir))
+	for dir := range byDir {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	// A unit is placed in one chunk: a whole directory when it fits,
+	// otherwise each of its files.
+	var units [][]diff.FileDiff
+	for _, dir := range dirs {
+		group := byDir[dir]
+		sort.Slice(group, func(i, j int) bool { return group[i].Path < group[j].Path })
+		if reviewChunkLines(group) <= maxLines && len(group) <= maxFiles {
+			units = append(units, group)
+			continue
+		}
+		for _, f := range group {
+			units = append(units, []diff.FileDiff{f})
+		}
+	}
+
+	var chunks []ReviewChunk
+	var current ReviewChunk
+	for _, unit := range units {
+		lines := reviewChunkLines(unit)
+		if len(current.Files) > 0 && (current.Lines+lines > maxLines || len(current.Files)+len(unit) > maxFiles) {
+			chunks = append(chunks, current)
+			current = ReviewChunk{}
+		}
+		for _, f := range unit {
+			current.Files = append(current.Files, f.Path)
+		}
+		if dir := path.Dir(unit[0].Path); len(current.Dirs) == 0 || current.Dirs[len(current.Dirs)-1] != dir {
+			current.Dirs = append(current.Dirs, dir)
+		}
+		current.Lines += lines
+	}
+	if len(current.Files) > 0 {
+		chunks = append(chunks, current)
+	}
+	for i := range chunks {
+		chunks[i].Index = i + 1
+	}
+	return chunks
+}
+
+func reviewChunkLines(files []diff.FileDiff) int {
+	lines := 0
+	for _, f := range files {
+		lines += f.Additions + f.Deletions
+	}
+	return lines
+}
+
+// executeChunkedReview reviews each chunk in its own session, one after
+// another or concurrently with review.chunking.parallel, and merges the
+// findings into one review result. Any chunk that needs changes blocks the
+// phase with the merged findings.
+func (we *WorkflowExecutor) executeChunkedReview(ctx context.Context, cfg PhaseExecutionConfig, adapter ProviderAdapter, chunks []ReviewChunk) (*PhaseExecutionResult, error) {
+	total := &PhaseExecutionResult{}
+
+	run := we.reviewChunkRunner
+	if run == nil {
+		run = we.runVoteCandidate
+	}
+	results := make([]*PhaseExecutionResult, len(chunks))
+	errs := make([]error, len(chunks))
+	runOne := func(i int) {
+		chunkCfg := cfg
+		chunkCfg.Prompt = buildReviewChunkPrompt(cfg.Prompt, chunks[i], len(chunks))
+		results[i], errs[i] = run(ctx, chunkCfg, adapter)
+	}
+
+	we.logger.Info("reviewing large diff in chunks",
+		"task", cfg.TaskID,
+		"phase", cfg.PhaseID,
+		"chunks", len(chunks),
+		"parallel", we.orcConfig.Review.Chunking.Parallel,
+	)
+	// An injected turn executor is shared by every chunk, so it cannot run
+	// them concurrently.
+	if !we.orcConfig.Review.Chunking.Parallel || (we.turnExecutor != nil && we.reviewChunkRunner == nil) {
+		for i := range chunks {
+			runOne(i)
+			if ctx.Err() != nil {
+				break
+			}
+		}
+	} else {
+		var wg sync.WaitGroup
+		for i := range chunks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				runOne(i)
+			}()
+		}
+		wg.Wait()
+	}
+
+	findings := make([]*ReviewFindings, len(chunks))
+	for i, chunk := range chunks {
+		addVoteUsage(total, results[i])
+		if results[i] != nil {
+			total.Iterations += results[i].Iterations
+			if results[i].SessionID != "" {
+				total.SessionID = results[i].SessionID
+			}
+		}
+		if ctx.Err() != nil {
+			return total, ctx.Err()
+		}
+
+		// A chunk that needs changes reports its findings as a blocked phase.
+		var output string
+		var blocked *PhaseBlockedError
+		switch {
+		case errors.As(errs[i], &blocked):
+			output = blocked.Output
+		case errs[i] != nil:
+			return total, fmt.Errorf("review chunk %d of %d: %w", chunk.Index, len(chunks), errs[i])
+		default:
+			output = results[i].RawOutput
+		}
+		parsed, err := ParseReviewFindings(output)
+		if err != nil {
+			return total, fmt.Errorf("review chunk %d of %d: %w", chunk.Index, len(chunks), err)
+		}
+		findings[i] = parsed
+	}
+
+	merged := mergeReviewChunkFindings(chunks, findings)
+	if merged.Round == 0 {
+		merged.Round = max(cfg.ReviewRound, 1)
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return total, fmt.Errorf("marshal chunked review findings: %w", err)
+	}
+	total.RawOutput = string(data)
+	total.Content = extractPhaseOutput(total.RawOutput)
+
+	we.logger.Info("chunked review complete",
+		"task", cfg.TaskID,
+		"phase", cfg.PhaseID,
+		"chunks", len(chunks),
+		"issues", len(merged.Issues),
+		"needs_changes", merged.NeedsChanges,
+	)
+	if merged.NeedsChanges {
+		return total, &PhaseBlockedError{Phase: cfg.PhaseID, Reason: merged.Summary, Output: total.RawOutput}
+	}
+	return total, nil
+}
+
+// buildReviewChunkPrompt narrows the review prompt to one chunk's files.
+func buildReviewChunkPrompt(prompt string, chunk ReviewChunk, total int) string {
+	var sb strings.Builder
+	sb.WriteString(prompt)
+	fmt.Fprintf(&sb, "\n\n## Review Scope (chunk %d of %d)\n\n", chunk.Index, total)
+	fmt.Fprintf(&sb, "This change is too large to review in one pass, so it is split into %d chunks reviewed separately. ", total)
+	fmt.Fprintf(&sb, "Review ONLY the %d file(s) below (%d changed lines); the other chunks cover the rest of the diff. ", len(chunk.Files), chunk.Lines)
+	sb.WriteString("Read any other code you need for context, but report issues only for these files, and base needs_changes on these files alone.\n\n")
+	for _, f := range chunk.Files {
+		sb.WriteString("- " + f + "\n")
+	}
+	return sb.String()
+}
+
+// mergeReviewChunkFindings combines the findings of every chunk. The review
+// needs changes if any chunk does.
+func mergeReviewChunkFindings(chunks []ReviewChunk, findings []*ReviewFindings) *ReviewFindings {
+	merged := &ReviewFindings{
+		Issues:    []ReviewFinding{},
+		Questions: []string{},
+		Positives: []string{},
+	}
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "Reviewed in %d chunks:", len(chunks))
+	for i, f := range findings {
+		if f == nil {
+			continue
+		}
+		if f.NeedsChanges {
+			merged.NeedsChanges = true
+		}
+		if merged.Round == 0 {
+			merged.Round = f.Round
+		}
+		verdict := "approved"
+		if f.NeedsChanges {
+			verdict = "needs changes"
+		}
+		fmt.Fprintf(&summary, "\n- Chunk %d (%s): %s. %s", chunks[i].Index, strings.Join(chunks[i].Dirs, ", "), verdict, strings.TrimSpace(f.Summary))
+		merged.Issues = append(merged.Issues, f.Issues...)
+		merged.Questions = append(merged.Questions, f.Questions...)
+		merged.Positives = append(merged.Positives, f.Positives...)
+	}
+	merged.Summary = summary.String()
+	return merged
+}
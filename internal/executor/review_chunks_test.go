@@ -0,0 +1,143 @@
+package executor
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/diff"
+)
+
+func TestBuildReviewChunks(t *testing.T) {
+	t.Parallel()
+
+	files := []diff.FileDiff{
+		{Path: "internal/api/server.go", Additions: 300, Deletions: 100},
+		{Path: "internal/api/auth.go", Additions: 200},
+		{Path: "internal/db/token.go", Additions: 150},
+		{Path: "internal/executor/big.go", Additions: 900},
+		{Path: "internal/executor/small.go", Additions: 50},
+		{Path: "README.md", Additions: 10},
+	}
+
+	// Everything fits: one chunk
+	if chunks := buildReviewChunks(files, 5000, 40); len(chunks) != 1 || len(chunks[0].Files) != len(files) {
+		t.Fatalf("chunks = %+v, want one chunk with every file", chunks)
+	}
+
+	chunks := buildReviewChunks(files, 700, 40)
+	var got [][]string
+	for _, c := range chunks {
+		if c.Lines > 700 && len(c.Files) > 1 {
+			t.Errorf("chunk %d has %d lines across %d files", c.Index, c.Lines, len(c.Files))
+		}
+		got = append(got, c.Files)
+	}
+	want := [][]string{
+		{"README.md", "internal/api/auth.go", "internal/api/server.go"},
+		{"internal/db/token.go"},
+		{"internal/executor/big.go"},
+		{"internal/executor/small.go"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("chunks = %v, want %v", got, want)
+	}
+	for i := range want {
+		if strings.Join(got[i], ",") != strings.Join(want[i], ",") {
+			t.Errorf("chunk %d = %v, want %v", i+1, got[i], want[i])
+		}
+	}
+	if chunks[0].Index != 1 || strings.Join(chunks[0].Dirs, ",") != ".,internal/api" {
+		t.Errorf("first chunk = %+v", chunks[0])
+	}
+
+	// The file limit splits too
+	if chunks := buildReviewChunks(files, 5000, 2); len(chunks) != 4 {
+		t.Errorf("max_files=2: got %d chunks, want 4", len(chunks))
+	}
+}
+
+func TestReviewChunkingApplies(t *testing.T) {
+	t.Parallel()
+
+	we := &WorkflowExecutor{orcConfig: config.Default()}
+	if !we.reviewChunkingApplies(PhaseExecutionConfig{PhaseID: "review", ReviewRound: 1}) {
+		t.Error("review round 1 should be chunked")
+	}
+	if we.reviewChunkingApplies(PhaseExecutionConfig{PhaseID: "review", ReviewRound: 2}) {
+		t.Error("review round 2 decides on the whole change and should not be chunked")
+	}
+	if !we.reviewChunkingApplies(PhaseExecutionConfig{PhaseID: "review_cross"}) {
+		t.Error("review_cross should be chunked")
+	}
+	if we.reviewChunkingApplies(PhaseExecutionConfig{PhaseID: "implement"}) {
+		t.Error("implement should not be chunked")
+	}
+	we.orcConfig.Review.Chunking.Enabled = false
+	if we.reviewChunkingApplies(PhaseExecutionConfig{PhaseID: "review"}) {
+		t.Error("disabled chunking should not apply")
+	}
+}
+
+func TestExecuteChunkedReview_MergesFindings(t *testing.T) {
+	t.Parallel()
+
+	for _, parallel := range []bool{false, true} {
+		cfg := config.Default()
+		cfg.Review.Chunking.Parallel = parallel
+		we := &WorkflowExecutor{orcConfig: cfg, logger: slog.Default()}
+
+		var mu sync.Mutex
+		var prompts []string
+		we.reviewChunkRunner = func(ctx context.Context, cfg PhaseExecutionConfig, adapter ProviderAdapter) (*PhaseExecutionResult, error) {
+			mu.Lock()
+			prompts = append(prompts, cfg.Prompt)
+			mu.Unlock()
+			if strings.Contains(cfg.Prompt, "- internal/db/token.go") {
+				out := `{"needs_changes": true, "round": 1, "summary": "token is stored in plain text", "issues": [{"severity": "high", "file": "internal/db/token.go", "description": "hash the token"}]}`
+				return &PhaseExecutionResult{RawOutput: out, CostUSD: 1}, &PhaseBlockedError{Phase: cfg.PhaseID, Reason: "token is stored in plain text", Output: out}
+			}
+			return &PhaseExecutionResult{
+				RawOutput: `{"needs_changes": false, "round": 1, "summary": "looks good", "issues": [{"severity": "low", "file": "internal/api/auth.go", "description": "typo"}], "positives": ["clear errors"]}`,
+				CostUSD:   1,
+			}, nil
+		}
+
+		chunks := []ReviewChunk{
+			{Index: 1, Dirs: []string{"internal/api"}, Files: []string{"internal/api/auth.go"}, Lines: 200},
+			{Index: 2, Dirs: []string{"internal/db"}, Files: []string{"internal/db/token.go"}, Lines: 150},
+		}
+		phase := PhaseExecutionConfig{PhaseID: "review", ReviewRound: 1, Prompt: "Review the change."}
+		result, err := we.executeChunkedReview(context.Background(), phase, &claudeAdapter{}, chunks)
+
+		blocked, ok := err.(*PhaseBlockedError)
+		if !ok {
+			t.Fatalf("parallel=%v: err = %v, want PhaseBlockedError", parallel, err)
+		}
+		if !strings.Contains(blocked.Reason, "Chunk 2 (internal/db): needs changes") {
+			t.Errorf("parallel=%v: reason = %q", parallel, blocked.Reason)
+		}
+		if len(prompts) != 2 {
+			t.Fatalf("parallel=%v: ran %d chunks, want 2", parallel, len(prompts))
+		}
+		for _, p := range prompts {
+			if !strings.HasPrefix(p, "Review the change.") || !strings.Contains(p, "of 2)") {
+				t.Errorf("parallel=%v: chunk prompt missing review prompt or scope:\n%s", parallel, p)
+			}
+		}
+
+		merged, err := ParseReviewFindings(result.RawOutput)
+		if err != nil {
+			t.Fatalf("parallel=%v: merged output: %v", parallel, err)
+		}
+		if !merged.NeedsChanges || len(merged.Issues) != 2 || len(merged.Positives) != 1 || merged.Round != 1 {
+			t.Errorf("parallel=%v: merged = %+v", parallel, merged)
+		}
+		if result.CostUSD != 2 {
+			t.Errorf("parallel=%v: cost = %v, want both chunks", parallel, result.CostUSD)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package executor
 import (
 	"context"
 	"fmt"
+	"maps"
 	"path/filepath"
 	"regexp"
 
@@ -39,7 +40,14 @@ func PreparePhaseRuntime(
 	if cfg == nil {
 		cfg = &PhaseRuntimeConfig{}
 	} else {
+		// Copy what preparation mutates, so a reused config stays as given
 		clone := *cfg
+		clone.Shared.Env = maps.Clone(cfg.Shared.Env)
+		if cfg.Providers.Claude != nil {
+			claude := *cfg.Providers.Claude
+			claude.Hooks = maps.Clone(claude.Hooks)
+			clone.Providers.Claude = &claude
+		}
 		cfg = &clone
 	}
 
@@ -57,17 +65,32 @@ func PreparePhaseRuntime(
 		return nil, err
 	}
 
-	prepared, err := llmkit.PrepareRuntime(ctx, llmkit.PrepareRequest{
+	req := llmkit.PrepareRequest{
 		Provider:       provider,
 		WorkDir:        worktreePath,
 		RuntimeConfig:  cfg.ToLLMKit(),
 		Assets:         assets,
 		Tag:            baseCfg.TaskID,
 		RecoverOrphans: true,
-	})
+	}
+	// Caching and recording are best-effort: a project directory that
+	// cannot be written must not stop the phase.
+	var settings *EffectiveSettings
+	settingsDir := filepath.Join(baseCfg.MainRepoPath, RuntimeSettingsDir)
+	if baseCfg.Phase != "" && baseCfg.MainRepoPath != "" {
+		if key, err := runtimeSettingsKey(req); err == nil {
+			cached, _ := cacheRuntimeSettings(settingsDir, key, &req)
+			settings = newEffectiveSettings(baseCfg.Phase, key, cached, req, assets)
+		}
+	}
+
+	prepared, err := llmkit.PrepareRuntime(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("prepare runtime: %w", err)
 	}
+	if settings != nil {
+		_ = recordEffectiveSettings(settingsDir, baseCfg.TaskID, settings)
+	}
 	return prepared, nil
 }
 
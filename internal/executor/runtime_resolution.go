@@ -7,6 +7,7 @@ import (
 	llmkit "github.com/randalmurphal/llmkit/v2"
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
 	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/task"
 )
 
 const (
@@ -155,10 +156,26 @@ func (we *WorkflowExecutor) resolvePhaseProvider(tmpl *db.PhaseTemplate, phase *
 		return validatedProvider(we.runProvider)
 	}
 
+	if p, ok := explicitProviderFromModelTuple(we.taskPhaseModel(tmpl, phase)); ok {
+		return validatedProvider(p)
+	}
+
 	if phase != nil && phase.ProviderOverride != "" {
 		return validatedProvider(phase.ProviderOverride)
 	}
 
+	agent, err := we.resolveExecutorAgent(tmpl, phase)
+	if err != nil {
+		return "", err
+	}
+	if phase != nil && phase.AgentOverride != "" && agent != nil && agent.Provider != "" {
+		return validatedProvider(agent.Provider)
+	}
+
+	if p, ok := explicitProviderFromModelTuple(we.configPhaseModel(tmpl, phase)); ok {
+		return validatedProvider(p)
+	}
+
 	if we.wf != nil && we.wf.DefaultProvider != "" {
 		return validatedProvider(we.wf.DefaultProvider)
 	}
@@ -167,10 +184,6 @@ func (we *WorkflowExecutor) resolvePhaseProvider(tmpl *db.PhaseTemplate, phase *
 		return validatedProvider(tmpl.Provider)
 	}
 
-	agent, err := we.resolveExecutorAgent(tmpl, phase)
-	if err != nil {
-		return "", err
-	}
 	if agent != nil && agent.Provider != "" {
 		return validatedProvider(agent.Provider)
 	}
@@ -203,7 +216,18 @@ func (we *WorkflowExecutor) resolvePhaseProvider(tmpl *db.PhaseTemplate, phase *
 	return validatedProvider(ProviderClaude)
 }
 
+// resolvePhaseModel picks the model for a phase: the task's phase_models,
+// then the workflow phase's override, then an agent chosen for the phase,
+// then the config's phase_models for the workflow, then the defaults of the
+// workflow, agent, provider and config.
 func (we *WorkflowExecutor) resolvePhaseModel(tmpl *db.PhaseTemplate, phase *db.WorkflowPhase) (string, error) {
+	if model := we.taskPhaseModel(tmpl, phase); model != "" {
+		if _, m := ParseProviderModel(model); m != "" {
+			return m, nil
+		}
+		return model, nil
+	}
+
 	if phase != nil && phase.ModelOverride != "" {
 		if _, m := ParseProviderModel(phase.ModelOverride); m != "" {
 			return m, nil
@@ -211,6 +235,27 @@ func (we *WorkflowExecutor) resolvePhaseModel(tmpl *db.PhaseTemplate, phase *db.
 		return phase.ModelOverride, nil
 	}
 
+	agent, err := we.resolveExecutorAgent(tmpl, phase)
+	if err != nil {
+		return "", err
+	}
+
+	// An agent chosen explicitly for this workflow phase brings its model
+	// along; it is more specific than the workflow-wide default.
+	if phase != nil && phase.AgentOverride != "" && agent != nil && agent.Model != "" {
+		if _, m := ParseProviderModel(agent.Model); m != "" {
+			return m, nil
+		}
+		return agent.Model, nil
+	}
+
+	if model := we.configPhaseModel(tmpl, phase); model != "" {
+		if _, m := ParseProviderModel(model); m != "" {
+			return m, nil
+		}
+		return model, nil
+	}
+
 	if we.wf != nil && we.wf.DefaultModel != "" {
 		if _, m := ParseProviderModel(we.wf.DefaultModel); m != "" {
 			return m, nil
@@ -218,10 +263,6 @@ func (we *WorkflowExecutor) resolvePhaseModel(tmpl *db.PhaseTemplate, phase *db.
 		return we.wf.DefaultModel, nil
 	}
 
-	agent, err := we.resolveExecutorAgent(tmpl, phase)
-	if err != nil {
-		return "", err
-	}
 	if agent != nil && agent.Model != "" {
 		if _, m := ParseProviderModel(agent.Model); m != "" {
 			return m, nil
@@ -252,6 +293,35 @@ func (we *WorkflowExecutor) resolvePhaseModel(tmpl *db.PhaseTemplate, phase *db.
 	return "opus", nil
 }
 
+// taskPhaseModel returns the model the running task requests for the phase
+// in its phase_models metadata, or "".
+func (we *WorkflowExecutor) taskPhaseModel(tmpl *db.PhaseTemplate, phase *db.WorkflowPhase) string {
+	return task.GetPhaseModels(we.task)[phaseTemplateID(tmpl, phase)]
+}
+
+// configPhaseModel returns the config's phase_models default for the phase
+// in the running workflow, or "".
+func (we *WorkflowExecutor) configPhaseModel(tmpl *db.PhaseTemplate, phase *db.WorkflowPhase) string {
+	if we.orcConfig == nil {
+		return ""
+	}
+	workflowID := ""
+	if we.wf != nil {
+		workflowID = we.wf.ID
+	}
+	return we.orcConfig.PhaseModel(workflowID, phaseTemplateID(tmpl, phase))
+}
+
+func phaseTemplateID(tmpl *db.PhaseTemplate, phase *db.WorkflowPhase) string {
+	if tmpl != nil {
+		return tmpl.ID
+	}
+	if phase != nil {
+		return phase.PhaseTemplateID
+	}
+	return ""
+}
+
 func (we *WorkflowExecutor) getEffectivePhaseRuntimeConfig(tmpl *db.PhaseTemplate, phase *db.WorkflowPhase) (*PhaseRuntimeConfig, error) {
 	var cfg *PhaseRuntimeConfig
 
@@ -300,12 +370,46 @@ func (we *WorkflowExecutor) getEffectivePhaseRuntimeConfig(tmpl *db.PhaseTemplat
 		cfg.Shared.SystemPrompt = agent.SystemPrompt
 	}
 
+	// The executor agent's tool list is a hard constraint: template and
+	// workflow overrides may narrow it but never widen it.
+	if agent != nil && len(agent.Tools) > 0 {
+		allowed, err := constrainAllowedTools(cfg.Shared.AllowedTools, agent.Tools)
+		if err != nil {
+			return nil, fmt.Errorf("phase %s agent %s: %w", tmpl.ID, agent.ID, err)
+		}
+		cfg.Shared.AllowedTools = allowed
+	}
+
 	if cfg.IsEmpty() {
 		return nil, nil
 	}
 	return cfg, nil
 }
 
+// constrainAllowedTools returns configured restricted to agentTools. With no
+// configured list the agent's tools become the allow-list. An empty result is
+// an error because an empty allow-list would mean "all tools".
+func constrainAllowedTools(configured, agentTools []string) ([]string, error) {
+	if len(configured) == 0 {
+		return append([]string(nil), agentTools...), nil
+	}
+
+	permitted := make(map[string]struct{}, len(agentTools))
+	for _, tool := range agentTools {
+		permitted[tool] = struct{}{}
+	}
+	var result []string
+	for _, tool := range configured {
+		if _, ok := permitted[tool]; ok {
+			result = append(result, tool)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("allowed_tools %v has no overlap with agent tools %v", configured, agentTools)
+	}
+	return result, nil
+}
+
 func setTaskSessionMetadata(t *orcv1.Task, phaseID, provider, model string) {
 	if t == nil {
 		return
@@ -0,0 +1,195 @@
+package executor
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	llmkit "github.com/randalmurphal/llmkit/v2"
+	"github.com/randalmurphal/orc/internal/util"
+)
+
+// RuntimeSettingsDir is the project directory, relative to the project root,
+// holding assembled phase runtime settings and the settings each task used.
+const RuntimeSettingsDir = HermeticCacheDir + "/runtime"
+
+// EffectiveSettings are the runtime settings a phase execution ran with.
+// Secret values (MCP server env and headers) are redacted.
+type EffectiveSettings struct {
+	Phase         string               `json:"phase"`
+	Provider      string               `json:"provider"`
+	WorkDir       string               `json:"work_dir"`
+	Key           string               `json:"key"`
+	Cached        bool                 `json:"cached"`
+	PreparedAt    time.Time            `json:"prepared_at"`
+	RuntimeConfig llmkit.RuntimeConfig `json:"runtime_config"`
+	HookScripts   []string             `json:"hook_scripts,omitempty"`
+	Skills        []string             `json:"skills,omitempty"`
+}
+
+// TaskEffectiveSettings are the effective settings of a task's latest
+// execution of each phase.
+type TaskEffectiveSettings struct {
+	TaskID string                        `json:"task_id"`
+	Phases map[string]*EffectiveSettings `json:"phases"`
+}
+
+// runtimeSettingsMu serializes updates to task settings files, which vote
+// candidates write concurrently.
+var runtimeSettingsMu sync.Mutex
+
+// runtimeSettingsKey hashes everything an assembled runtime is built from.
+func runtimeSettingsKey(req llmkit.PrepareRequest) (string, error) {
+	data, err := json.Marshal(struct {
+		Provider      string                `json:"provider"`
+		WorkDir       string                `json:"work_dir"`
+		RuntimeConfig llmkit.RuntimeConfig  `json:"runtime_config"`
+		Assets        *llmkit.RuntimeAssets `json:"assets,omitempty"`
+	}{req.Provider, req.WorkDir, req.RuntimeConfig, req.Assets})
+	if err != nil {
+		return "", fmt.Errorf("hash runtime settings: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// cacheRuntimeSettings stores an assembled runtime under its key in dir. It
+// reports whether the same settings were already cached, in which case the
+// hook scripts written for them are dropped from req when still on disk, so
+// only changed settings are regenerated.
+func cacheRuntimeSettings(dir, key string, req *llmkit.PrepareRequest) (bool, error) {
+	path := filepath.Join(dir, key+".json")
+	if _, err := os.Stat(path); err == nil {
+		if req.Assets != nil && hookScriptsWritten(req.WorkDir, req.Assets.HookScripts) {
+			assets := *req.Assets
+			assets.HookScripts = nil
+			req.Assets = &assets
+		}
+		return true, nil
+	}
+	data, err := json.MarshalIndent(req, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("encode runtime settings: %w", err)
+	}
+	if err := util.AtomicWriteFile(path, data, 0o644); err != nil {
+		return false, fmt.Errorf("cache runtime settings: %w", err)
+	}
+	return false, nil
+}
+
+// hookScriptsWritten reports whether every hook script is in the worktree
+// with the given content.
+func hookScriptsWritten(workDir string, scripts map[string]string) bool {
+	for name, content := range scripts {
+		data, err := os.ReadFile(filepath.Join(workDir, ".claude", "hooks", name))
+		if err != nil || !bytes.Equal(data, []byte(content)) {
+			return false
+		}
+	}
+	return true
+}
+
+// newEffectiveSettings describes an assembled runtime for a phase.
+func newEffectiveSettings(phase, key string, cached bool, req llmkit.PrepareRequest, assets *llmkit.RuntimeAssets) *EffectiveSettings {
+	s := &EffectiveSettings{
+		Phase:         phase,
+		Provider:      req.Provider,
+		WorkDir:       req.WorkDir,
+		Key:           key,
+		Cached:        cached,
+		PreparedAt:    time.Now().UTC(),
+		RuntimeConfig: redactRuntimeConfig(req.RuntimeConfig),
+	}
+	if assets != nil {
+		for name := range assets.HookScripts {
+			s.HookScripts = append(s.HookScripts, name)
+		}
+		for name := range assets.Skills {
+			s.Skills = append(s.Skills, name)
+		}
+		sort.Strings(s.HookScripts)
+		sort.Strings(s.Skills)
+	}
+	return s
+}
+
+// redactRuntimeConfig returns cfg with MCP server env and header values
+// replaced, leaving their names visible.
+func redactRuntimeConfig(cfg llmkit.RuntimeConfig) llmkit.RuntimeConfig {
+	if len(cfg.Shared.MCPServers) == 0 {
+		return cfg
+	}
+	redact := func(m map[string]string) map[string]string {
+		if len(m) == 0 {
+			return m
+		}
+		out := make(map[string]string, len(m))
+		for k := range m {
+			out[k] = "[redacted]"
+		}
+		return out
+	}
+	servers := make(map[string]llmkit.MCPServerConfig, len(cfg.Shared.MCPServers))
+	for name, server := range cfg.Shared.MCPServers {
+		server.Args = slices.Clone(server.Args)
+		server.Env = redact(server.Env)
+		server.Headers = redact(server.Headers)
+		servers[name] = server
+	}
+	cfg.Shared.MCPServers = servers
+	return cfg
+}
+
+// taskSettingsPath is the file recording a task's effective settings.
+func taskSettingsPath(dir, taskID string) string {
+	return filepath.Join(dir, "tasks", taskID+".json")
+}
+
+// recordEffectiveSettings saves s as the task's latest settings for its phase.
+func recordEffectiveSettings(dir, taskID string, s *EffectiveSettings) error {
+	runtimeSettingsMu.Lock()
+	defer runtimeSettingsMu.Unlock()
+
+	settings, err := LoadEffectiveSettings(dir, taskID)
+	if err != nil {
+		return err
+	}
+	settings.Phases[s.Phase] = s
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode effective settings: %w", err)
+	}
+	if err := util.AtomicWriteFile(taskSettingsPath(dir, taskID), data, 0o644); err != nil {
+		return fmt.Errorf("record effective settings: %w", err)
+	}
+	return nil
+}
+
+// LoadEffectiveSettings returns the settings recorded for a task under dir,
+// the project's RuntimeSettingsDir. A task that has not run has no phases.
+func LoadEffectiveSettings(dir, taskID string) (*TaskEffectiveSettings, error) {
+	settings := &TaskEffectiveSettings{TaskID: taskID, Phases: map[string]*EffectiveSettings{}}
+	data, err := os.ReadFile(taskSettingsPath(dir, taskID))
+	if errors.Is(err, os.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read effective settings: %w", err)
+	}
+	if err := json.Unmarshal(data, settings); err != nil {
+		return nil, fmt.Errorf("parse effective settings: %w", err)
+	}
+	if settings.Phases == nil {
+		settings.Phases = map[string]*EffectiveSettings{}
+	}
+	return settings, nil
+}
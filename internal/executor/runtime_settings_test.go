@@ -0,0 +1,90 @@
+package executor
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	llmkit "github.com/randalmurphal/llmkit/v2"
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+type fakeHookScripts map[string]string
+
+func (f fakeHookScripts) GetHookScript(id string) (*db.HookScript, error) {
+	content, ok := f[id]
+	if !ok {
+		return nil, nil
+	}
+	return &db.HookScript{ID: id, Content: content}, nil
+}
+
+func TestPreparePhaseRuntime_CachesAndRecordsSettings(t *testing.T) {
+	t.Parallel()
+	worktree, mainRepo := t.TempDir(), t.TempDir()
+	settingsDir := filepath.Join(mainRepo, RuntimeSettingsDir)
+	hooks := fakeHookScripts{"orc-worktree-isolation": "print('isolated')\n"}
+	phaseCfg := &PhaseRuntimeConfig{
+		Shared: llmkit.SharedRuntimeConfig{
+			AllowedTools: []string{"Read", "Edit"},
+			MCPServers: map[string]llmkit.MCPServerConfig{
+				"search": {Command: "search-mcp", Env: map[string]string{"API_KEY": "secret"}},
+			},
+		},
+		Providers: PhaseRuntimeProviderConfig{Claude: &llmkit.ClaudeRuntimeConfig{PermissionMode: "acceptEdits"}},
+	}
+	baseCfg := &WorktreeBaseConfig{WorktreePath: worktree, MainRepoPath: mainRepo, TaskID: "TASK-001", Phase: "implement"}
+
+	prepare := func() *EffectiveSettings {
+		t.Helper()
+		prepared, err := PreparePhaseRuntime(context.Background(), ProviderClaude, worktree, phaseCfg, baseCfg, hooks, nil)
+		if err != nil {
+			t.Fatalf("PreparePhaseRuntime() error = %v", err)
+		}
+		if err := prepared.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+		settings, err := LoadEffectiveSettings(settingsDir, "TASK-001")
+		if err != nil {
+			t.Fatalf("LoadEffectiveSettings() error = %v", err)
+		}
+		if settings.Phases["implement"] == nil {
+			t.Fatalf("no settings recorded for implement: %+v", settings)
+		}
+		return settings.Phases["implement"]
+	}
+
+	first := prepare()
+	if first.Cached || first.Provider != ProviderClaude || first.WorkDir != worktree {
+		t.Errorf("first run = %+v, want uncached claude settings for the worktree", first)
+	}
+	if first.RuntimeConfig.Providers.Claude.PermissionMode != "acceptEdits" || len(first.RuntimeConfig.Shared.AllowedTools) != 2 {
+		t.Errorf("recorded config = %+v", first.RuntimeConfig)
+	}
+	if env := first.RuntimeConfig.Shared.MCPServers["search"].Env; env["API_KEY"] != "[redacted]" {
+		t.Errorf("MCP env = %v, want the secret redacted", env)
+	}
+	if len(first.HookScripts) != 1 || first.HookScripts[0] != "orc-worktree-isolation.py" {
+		t.Errorf("hook scripts = %v", first.HookScripts)
+	}
+	if len(phaseCfg.Providers.Claude.Hooks) != 0 {
+		t.Errorf("phase config mutated: hooks = %v", phaseCfg.Providers.Claude.Hooks)
+	}
+
+	if second := prepare(); !second.Cached || second.Key != first.Key {
+		t.Errorf("unchanged inputs: cached = %v, key %s, want cached key %s", second.Cached, second.Key, first.Key)
+	}
+
+	hooks["orc-worktree-isolation"] = "print('changed')\n"
+	if third := prepare(); third.Cached || third.Key == first.Key {
+		t.Errorf("changed hook script: cached = %v, key unchanged = %v", third.Cached, third.Key == first.Key)
+	}
+}
+
+func TestLoadEffectiveSettings_NoRuns(t *testing.T) {
+	t.Parallel()
+	settings, err := LoadEffectiveSettings(t.TempDir(), "TASK-001")
+	if err != nil || settings.TaskID != "TASK-001" || len(settings.Phases) != 0 {
+		t.Errorf("LoadEffectiveSettings() = %+v, %v; want no phases", settings, err)
+	}
+}
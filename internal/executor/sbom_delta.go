@@ -0,0 +1,64 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/randalmurphal/orc/internal/compliance"
+	"github.com/randalmurphal/orc/internal/git"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+// SBOMDeltaAttachment is the task attachment holding the task's dependency
+// delta, written by compliance phases and again when the task is finalized.
+const SBOMDeltaAttachment = "sbom-delta.json"
+
+// recordSBOMDelta computes the dependency delta of the checkout behind
+// gitCtx against its merge base with target and stores it as the task's
+// SBOM delta attachment.
+func recordSBOMDelta(backend storage.Backend, gitCtx *git.Context, taskID, target string) (*compliance.Delta, error) {
+	base := target
+	if out, err := gitCtx.RunGit("merge-base", target, "HEAD"); err == nil {
+		base = strings.TrimSpace(out)
+	}
+	delta, err := compliance.ComputeDelta(gitCtx.WorkDir(), base)
+	if err != nil {
+		return nil, err
+	}
+	if err := saveSBOMDelta(backend, taskID, delta); err != nil {
+		return nil, err
+	}
+	return delta, nil
+}
+
+func saveSBOMDelta(backend storage.Backend, taskID string, delta *compliance.Delta) error {
+	if backend == nil {
+		return nil
+	}
+	data, err := json.MarshalIndent(delta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal SBOM delta: %w", err)
+	}
+	if _, err := backend.SaveAttachment(taskID, SBOMDeltaAttachment, "application/json", data); err != nil {
+		return fmt.Errorf("save SBOM delta: %w", err)
+	}
+	return nil
+}
+
+// LoadSBOMDelta returns the task's recorded SBOM delta, or nil when none
+// has been recorded.
+func LoadSBOMDelta(backend storage.Backend, taskID string) *compliance.Delta {
+	if backend == nil {
+		return nil
+	}
+	_, data, err := backend.GetAttachment(taskID, SBOMDeltaAttachment)
+	if err != nil {
+		return nil
+	}
+	var delta compliance.Delta
+	if json.Unmarshal(data, &delta) != nil {
+		return nil
+	}
+	return &delta
+}
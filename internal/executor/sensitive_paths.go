@@ -0,0 +1,134 @@
+package executor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/git"
+)
+
+// SensitivePathsCheckName is the quality check that enforces sensitive_paths.
+const SensitivePathsCheckName = "sensitive_paths"
+
+// SensitivePathError is returned instead of committing when task changes touch
+// paths on the sensitive_paths deny-list.
+type SensitivePathError struct {
+	Violations []CheckViolation `json:"violations"`
+}
+
+func (e *SensitivePathError) Error() string {
+	paths := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		paths[i] = v.Path
+	}
+	return fmt.Sprintf("sensitive path guard: %d protected path(s) modified: %s", len(paths), strings.Join(paths, ", "))
+}
+
+// CheckSensitivePaths compares the worktree against baseRef (HEAD when empty)
+// and reports changed paths protected by cfg.Deny. The comparison is net, so
+// additions, edits and deletions all count, and a change that was committed
+// and later reverted does not.
+func CheckSensitivePaths(gitCtx *git.Context, cfg config.SensitivePathsConfig, baseRef string) ([]CheckViolation, error) {
+	if len(cfg.Deny) == 0 {
+		return nil, nil
+	}
+	paths, err := netChangedFiles(gitCtx, firstNonEmpty(baseRef, "HEAD"))
+	if err != nil {
+		return nil, err
+	}
+	var violations []CheckViolation
+	for _, p := range paths {
+		if pattern := cfg.DeniedBy(p); pattern != "" {
+			violations = append(violations, CheckViolation{
+				Path:   p,
+				Reason: fmt.Sprintf("matches sensitive_paths.deny pattern %q", pattern),
+			})
+		}
+	}
+	return violations, nil
+}
+
+// netChangedFiles lists tracked paths whose worktree content differs from
+// baseRef, plus untracked files. Renames are reported as delete and add.
+func netChangedFiles(gitCtx *git.Context, baseRef string) ([]string, error) {
+	seen := make(map[string]bool)
+	for _, args := range [][]string{
+		{"diff", "--name-only", "-z", "--no-renames", baseRef},
+		{"ls-files", "--others", "--exclude-standard", "-z"},
+	} {
+		out, err := gitCtx.RunGit(args...)
+		if err != nil {
+			return nil, fmt.Errorf("git %s: %w", strings.Join(args[:2], " "), err)
+		}
+		for _, p := range strings.Split(out, "\x00") {
+			if p != "" {
+				seen[p] = true
+			}
+		}
+	}
+	paths := make([]string, 0, len(seen))
+	for p := range seen {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// sensitivePaths runs CheckSensitivePaths for workDir. It returns nil when no
+// deny-list is configured or workDir is not a git repository.
+func (we *WorkflowExecutor) sensitivePaths(workDir string) ([]CheckViolation, error) {
+	if we.orcConfig == nil || len(we.orcConfig.SensitivePaths.Deny) == 0 || workDir == "" {
+		return nil, nil
+	}
+	gitCtx, err := git.NewContext(workDir)
+	if err != nil {
+		we.logger.Debug("skipping sensitive path check outside a git repository", "dir", workDir, "error", err)
+		return nil, nil
+	}
+	return CheckSensitivePaths(gitCtx, we.orcConfig.SensitivePaths, we.taskForkPoint(gitCtx))
+}
+
+// sensitivePathsCheck reports sensitive path violations as a blocking quality
+// check, so the agent reverts them while the phase is still running.
+func (we *WorkflowExecutor) sensitivePathsCheck(workDir string) *CheckResult {
+	if we.orcConfig == nil || len(we.orcConfig.SensitivePaths.Deny) == 0 {
+		return nil
+	}
+	violations, err := we.sensitivePaths(workDir)
+	if err != nil {
+		we.logger.Warn("sensitive path check failed to run", "dir", workDir, "error", err)
+		return nil
+	}
+	result := &CheckResult{Name: SensitivePathsCheckName, Passed: len(violations) == 0, OnFailure: "block", Violations: violations}
+	if !result.Passed {
+		result.Output = formatSensitivePathViolations(violations)
+		we.logger.Info("sensitive path guard violated", "files", len(violations))
+	}
+	return result
+}
+
+// guardSensitivePaths runs before orc commits task changes. Unlike the quality
+// check it fails closed: if the diff cannot be inspected, nothing is committed.
+func (we *WorkflowExecutor) guardSensitivePaths(workDir string) error {
+	violations, err := we.sensitivePaths(workDir)
+	if err != nil {
+		return fmt.Errorf("sensitive path guard: inspect changes: %w", err)
+	}
+	if len(violations) > 0 {
+		return &SensitivePathError{Violations: violations}
+	}
+	return nil
+}
+
+func formatSensitivePathViolations(violations []CheckViolation) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d change(s) touch paths protected by sensitive_paths:\n", len(violations))
+	for _, v := range violations {
+		fmt.Fprintf(&sb, "  %s: %s\n", v.Path, v.Reason)
+	}
+	sb.WriteString("\nRevert these changes (git checkout/restore the files, delete new ones, undo deletions). ")
+	sb.WriteString("These paths must not be modified by this task; describe any change they need in your output instead.")
+	return sb.String()
+}
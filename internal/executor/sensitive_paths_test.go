@@ -0,0 +1,111 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestCheckSensitivePaths_NetDiffSinceBase(t *testing.T) {
+	t.Parallel()
+	gitCtx := setupBinaryPolicyRepo(t)
+	dir := gitCtx.WorkDir()
+	writeTestFile(t, dir, ".github/workflows/ci.yml", "on: push\n")
+	writeTestFile(t, dir, "infra/main.tf", "# infra\n")
+	writeTestFile(t, dir, "infra/README.md", "# docs\n")
+	writeTestFile(t, dir, "config/prod.env", "KEY=1\n")
+	runGitCmdOrFatal(t, dir, "add", ".")
+	runGitCmdOrFatal(t, dir, "commit", "-m", "Add protected files")
+	base, err := gitCtx.HeadCommit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Committed edit, uncommitted deletion, untracked secret, allowed edit.
+	writeTestFile(t, dir, ".github/workflows/ci.yml", "on: [push, pull_request]\n")
+	runGitCmdOrFatal(t, dir, "commit", "-am", "Edit CI")
+	writeTestFile(t, dir, "secrets/api.key", "xyz\n")
+	writeTestFile(t, dir, "infra/README.md", "# updated docs\n")
+	writeTestFile(t, dir, "src/app.go", "package app\n")
+	// Committed and then reverted: no net change.
+	writeTestFile(t, dir, "config/prod.env", "KEY=2\n")
+	runGitCmdOrFatal(t, dir, "commit", "-am", "Touch env")
+	writeTestFile(t, dir, "config/prod.env", "KEY=1\n")
+	if err := os.Remove(filepath.Join(dir, "infra", "main.tf")); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.SensitivePathsConfig{
+		Deny:  []string{".github/workflows/", "infra/**", "secrets/", "*.env"},
+		Allow: []string{"infra/README.md"},
+	}
+	violations, err := CheckSensitivePaths(gitCtx, cfg, base)
+	if err != nil {
+		t.Fatalf("CheckSensitivePaths: %v", err)
+	}
+	got := strings.Join(violationPaths(violations), ",")
+	if got != ".github/workflows/ci.yml,infra/main.tf,secrets/api.key" {
+		t.Errorf("violations = %s", got)
+	}
+	if !strings.Contains(violations[0].Reason, `".github/workflows/"`) {
+		t.Errorf("reason = %q, want the matching pattern", violations[0].Reason)
+	}
+
+	// Without a base, only changes since HEAD count.
+	violations, err = CheckSensitivePaths(gitCtx, cfg, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(violationPaths(violations), ","); got != "config/prod.env,infra/main.tf,secrets/api.key" {
+		t.Errorf("violations without base = %s", got)
+	}
+}
+
+func TestSensitivePathGuard(t *testing.T) {
+	t.Parallel()
+	gitCtx := setupBinaryPolicyRepo(t)
+	dir := gitCtx.WorkDir()
+	writeTestFile(t, dir, ".github/workflows/release.yml", "on: tag\n")
+
+	backend := storage.NewTestBackend(t)
+	cfg := config.Default()
+	we := NewWorkflowExecutor(backend, backend.DB(), testGlobalDBFrom(backend), cfg, dir,
+		WithWorkflowLogger(slog.Default()),
+	)
+	we.task = task.NewProtoTask("TASK-SP-001", "Tweak release")
+
+	// No deny-list: nothing is checked.
+	if err := we.guardSensitivePaths(dir); err != nil {
+		t.Fatalf("guard without deny-list = %v", err)
+	}
+	if check := we.sensitivePathsCheck(dir); check != nil {
+		t.Fatalf("check without deny-list = %+v", check)
+	}
+
+	cfg.SensitivePaths.Deny = []string{".github/workflows/"}
+	err := we.guardSensitivePaths(dir)
+	var spErr *SensitivePathError
+	if !errors.As(err, &spErr) || len(spErr.Violations) != 1 {
+		t.Fatalf("guard err = %v, want SensitivePathError", err)
+	}
+	if !strings.Contains(err.Error(), ".github/workflows/release.yml") {
+		t.Errorf("error = %q, want the violating path", err.Error())
+	}
+
+	result := we.runQualityChecks(context.Background(), PhaseExecutionConfig{PhaseID: "implement", WorkingDir: dir}, nil)
+	if result == nil || result.AllPassed || !result.HasBlocks {
+		t.Fatalf("result = %+v, want a blocking failure", result)
+	}
+	check := result.Checks[len(result.Checks)-1]
+	if check.Name != SensitivePathsCheckName || len(check.Violations) != 1 {
+		t.Errorf("sensitive check = %+v", check)
+	}
+}
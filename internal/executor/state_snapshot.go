@@ -0,0 +1,57 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+// snapshotMarshaler renders task state with proto field names, so snapshot
+// diff paths read like execution.phases.implement.status.
+var snapshotMarshaler = protojson.MarshalOptions{UseProtoNames: true}
+
+// recordStateSnapshot stores the task's full state after a persisted step,
+// labelled with the step, for `orc debug state-diff`. Failures are logged
+// only: snapshots are a debugging aid and must never fail a run.
+func (we *WorkflowExecutor) recordStateSnapshot(t *orcv1.Task, label string) {
+	if t == nil || we.projectDB == nil {
+		return
+	}
+	state, err := snapshotState(t)
+	if err != nil {
+		we.logger.Warn("failed to encode state snapshot", "task", t.Id, "error", err)
+		return
+	}
+	snap := &db.StateSnapshot{
+		TaskID:    t.Id,
+		Label:     label,
+		PhaseID:   t.GetCurrentPhase(),
+		Iteration: int(t.GetExecution().GetCurrentIteration()),
+		State:     state,
+	}
+	if _, err := we.projectDB.RecordStateSnapshot(snap); err != nil {
+		we.logger.Warn("failed to record state snapshot", "task", t.Id, "step", label, "error", err)
+	}
+}
+
+// snapshotState returns the task as canonical JSON (sorted keys, no
+// whitespace). updated_at is left out so saves that change nothing else are
+// recognized as duplicates.
+func snapshotState(t *orcv1.Task) (json.RawMessage, error) {
+	c := proto.Clone(t).(*orcv1.Task)
+	c.UpdatedAt = nil
+	data, err := snapshotMarshaler.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("marshal task: %w", err)
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("canonicalize task: %w", err)
+	}
+	return json.Marshal(v)
+}
@@ -0,0 +1,49 @@
+package executor
+
+import (
+	"log/slog"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestSaveTaskStrict_RecordsStateSnapshots(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	we := &WorkflowExecutor{backend: backend, projectDB: backend.DB(), logger: slog.Default()}
+
+	tk := task.NewProtoTask("TASK-001", "Snapshot me")
+	tk.Status = orcv1.TaskStatus_TASK_STATUS_RUNNING
+	phase := "implement"
+	tk.CurrentPhase = &phase
+	if err := we.saveTaskStrict(tk, "save task status running"); err != nil {
+		t.Fatal(err)
+	}
+	// Only updated_at differs: not a new snapshot
+	tk.UpdatedAt = timestamppb.Now()
+	if err := we.saveTaskStrict(tk, "save task"); err != nil {
+		t.Fatal(err)
+	}
+	tk.Status = orcv1.TaskStatus_TASK_STATUS_FAILED
+	if err := we.saveTaskStrict(tk, "save task status failed"); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots, err := backend.DB().ListStateSnapshots("TASK-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 2 || snapshots[1].Label != "save task status failed" || snapshots[1].PhaseID != "implement" {
+		t.Fatalf("snapshots = %+v", snapshots)
+	}
+	diff, err := backend.DB().DiffStateSnapshots("TASK-001", "1", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Changes) != 1 || diff.Changes[0].Path != "status" || string(diff.Changes[0].To) != `"TASK_STATUS_FAILED"` {
+		t.Errorf("changes = %+v, want only status", diff.Changes)
+	}
+}
@@ -0,0 +1,114 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/controlplane"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// Budget scopes reported in taskBudgetError and budget_exceeded events.
+const (
+	BudgetScopeTask       = "task"
+	BudgetScopeInitiative = "initiative"
+)
+
+// taskBudgetError reports that a task's spend went over its own budget or
+// its initiative's. PhaseCostUSD is what the interrupted phase had spent,
+// which is not yet in the task's recorded cost.
+type taskBudgetError struct {
+	taskID       string
+	scope        string
+	scopeID      string
+	spentUSD     float64
+	limitUSD     float64
+	phaseCostUSD float64
+}
+
+func (e *taskBudgetError) Error() string {
+	return fmt.Sprintf("%s %s exceeded its $%.2f budget ($%.2f spent). Raise the budget to resume %s.",
+		e.scope, e.scopeID, e.limitUSD, e.spentUSD, e.taskID)
+}
+
+// IsTaskBudgetError returns true if the error is a task or initiative budget
+// error.
+func IsTaskBudgetError(err error) bool {
+	var tbe *taskBudgetError
+	return errors.As(err, &tbe)
+}
+
+// checkTaskBudget returns a *taskBudgetError when the running task, counting
+// phaseCostUSD spent so far in the current phase, is over its own budget or
+// its initiative's. Like the project budget check it is best-effort: DB
+// errors are logged and allow execution.
+func (we *WorkflowExecutor) checkTaskBudget(phaseCostUSD float64) error {
+	t := we.task
+	if t == nil || we.ignoreBudget {
+		return nil
+	}
+
+	spent := t.GetExecution().GetCost().GetTotalCostUsd() + phaseCostUSD
+	if limit := task.GetBudgetUSD(t); limit > 0 && spent > limit {
+		return &taskBudgetError{taskID: t.Id, scope: BudgetScopeTask, scopeID: t.Id,
+			spentUSD: spent, limitUSD: limit, phaseCostUSD: phaseCostUSD}
+	}
+
+	initiativeID := t.GetInitiativeId()
+	if initiativeID == "" || we.projectDB == nil {
+		return nil
+	}
+	limit, err := we.projectDB.GetInitiativeBudget(initiativeID)
+	if err != nil || limit <= 0 {
+		if err != nil {
+			we.logger.Warn("initiative budget check failed, proceeding anyway", "initiative", initiativeID, "error", err)
+		}
+		return nil
+	}
+	// The initiative total includes this task's recorded cost but not the
+	// current phase's
+	initiativeSpent, err := we.projectDB.GetInitiativeCost(initiativeID)
+	if err != nil {
+		we.logger.Warn("initiative budget check failed, proceeding anyway", "initiative", initiativeID, "error", err)
+		return nil
+	}
+	if spent := initiativeSpent + phaseCostUSD; spent > limit {
+		return &taskBudgetError{taskID: t.Id, scope: BudgetScopeInitiative, scopeID: initiativeID,
+			spentUSD: spent, limitUSD: limit, phaseCostUSD: phaseCostUSD}
+	}
+	return nil
+}
+
+// iterationCostUSD returns what the running phase has spent so far,
+// estimated from token usage when the provider reports no cost.
+func (we *WorkflowExecutor) iterationCostUSD(cfg PhaseExecutionConfig, result *PhaseExecutionResult) float64 {
+	if result.CostUSD > 0 || we.tokenRates == nil {
+		return result.CostUSD
+	}
+	return EstimateTokenCostUSDWithRates(we.tokenRates, cfg.Provider, cfg.Model,
+		int64(result.InputTokens), int64(result.OutputTokens),
+		int64(result.CacheReadTokens), int64(result.CacheCreationTokens))
+}
+
+// escalateTaskBudget records a budget_exceeded event for a task paused by
+// its budget and raises an attention signal so the pause shows up alongside
+// other tasks waiting on a human.
+func (we *WorkflowExecutor) escalateTaskBudget(t *orcv1.Task, currentPhase string, budgetErr *taskBudgetError) error {
+	we.logger.Warn("budget exceeded, task paused",
+		"task", t.Id,
+		"scope", budgetErr.scope,
+		"scope_id", budgetErr.scopeID,
+		"spent", budgetErr.spentUSD,
+		"limit", budgetErr.limitUSD,
+	)
+	we.publisher.BudgetExceeded(t.Id, events.BudgetExceededData{
+		Phase:    currentPhase,
+		Scope:    budgetErr.scope,
+		ScopeID:  budgetErr.scopeID,
+		SpentUSD: budgetErr.spentUSD,
+		LimitUSD: budgetErr.limitUSD,
+	})
+	return we.upsertTaskAttentionSignal(t, controlplane.AttentionSignalStatusBlocked, budgetErr.Error())
+}
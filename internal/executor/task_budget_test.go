@@ -0,0 +1,136 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/controlplane"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestCheckTaskBudget_TaskLimit(t *testing.T) {
+	taskItem := task.NewProtoTask("TASK-001", "Budgeted")
+	task.SetBudgetUSD(taskItem, 5)
+	task.AddCostProto(taskItem.Execution, "spec", 3)
+	we := &WorkflowExecutor{task: taskItem, logger: slog.Default()}
+
+	if err := we.checkTaskBudget(1.5); err != nil {
+		t.Fatalf("under budget: %v", err)
+	}
+
+	err := we.checkTaskBudget(2.5)
+	var budgetErr *taskBudgetError
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("over budget: err = %v, want taskBudgetError", err)
+	}
+	if budgetErr.scope != BudgetScopeTask || budgetErr.spentUSD != 5.5 || budgetErr.limitUSD != 5 {
+		t.Errorf("budget error = %+v", budgetErr)
+	}
+
+	we.ignoreBudget = true
+	if err := we.checkTaskBudget(2.5); err != nil {
+		t.Errorf("--ignore-budget: %v", err)
+	}
+}
+
+func TestCheckTaskBudget_InitiativeLimit(t *testing.T) {
+	backend, taskItem, _ := setupCompletionRecommendationContext(t)
+	pdb := backend.DB()
+	if err := pdb.SaveInitiative(&db.Initiative{ID: "INIT-001", Title: "Budgeted", Status: "active"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pdb.SetInitiativeBudget("INIT-001", 10); err != nil {
+		t.Fatal(err)
+	}
+
+	// A sibling task already spent most of the initiative budget
+	sibling := task.NewProtoTask("TASK-002", "Sibling")
+	sibling.InitiativeId = stringPtr("INIT-001")
+	task.AddCostProto(sibling.Execution, "implement", 8)
+	if err := backend.SaveTask(sibling); err != nil {
+		t.Fatal(err)
+	}
+	taskItem.InitiativeId = stringPtr("INIT-001")
+	if err := backend.SaveTask(taskItem); err != nil {
+		t.Fatal(err)
+	}
+
+	we := &WorkflowExecutor{task: taskItem, projectDB: pdb, logger: slog.Default()}
+	if err := we.checkTaskBudget(1); err != nil {
+		t.Fatalf("under initiative budget: %v", err)
+	}
+	var budgetErr *taskBudgetError
+	if err := we.checkTaskBudget(3); !errors.As(err, &budgetErr) || budgetErr.scope != BudgetScopeInitiative || budgetErr.scopeID != "INIT-001" {
+		t.Fatalf("over initiative budget: err = %v", err)
+	}
+}
+
+func TestInterruptRun_TaskBudgetEscalates(t *testing.T) {
+	backend, taskItem, run := setupCompletionRecommendationContext(t)
+	task.SetBudgetUSD(taskItem, 2)
+	task.StartPhaseProto(taskItem.Execution, "implement")
+
+	mockPub := &MockPublisher{}
+	we := &WorkflowExecutor{
+		backend:   backend,
+		projectDB: backend.DB(),
+		orcConfig: config.Default(),
+		publisher: events.NewPublishHelper(mockPub),
+		logger:    slog.Default(),
+		task:      taskItem,
+	}
+	budgetErr := we.checkTaskBudget(2.5)
+	if budgetErr == nil {
+		t.Fatal("expected the phase spend to exceed the task budget")
+	}
+
+	if err := we.interruptRun(run, taskItem, "implement", budgetErr); err != nil {
+		t.Fatalf("interruptRun: %v", err)
+	}
+	if !strings.Contains(run.Error, "$2.00 budget") {
+		t.Errorf("run error = %q, want budget error", run.Error)
+	}
+
+	reloaded, err := backend.LoadTask(taskItem.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Status != orcv1.TaskStatus_TASK_STATUS_PAUSED {
+		t.Errorf("status = %v, want paused", reloaded.Status)
+	}
+	if cost := reloaded.GetExecution().GetCost().GetTotalCostUsd(); cost != 2.5 {
+		t.Errorf("recorded cost = %v, want the interrupted phase's 2.5", cost)
+	}
+
+	var published *events.BudgetExceededData
+	for _, ev := range mockPub.GetEvents() {
+		if data, ok := ev.Data.(events.BudgetExceededData); ok && ev.Type == events.EventBudgetExceeded {
+			published = &data
+		}
+	}
+	if published == nil || published.Scope != BudgetScopeTask || published.LimitUSD != 2 || published.Phase != "implement" {
+		t.Errorf("budget_exceeded event = %+v", published)
+	}
+
+	signals, err := backend.LoadActiveAttentionSignals()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signals) != 1 || signals[0].Status != controlplane.AttentionSignalStatusBlocked || !strings.Contains(signals[0].Summary, "budget") {
+		t.Errorf("attention signals = %+v, want one blocked budget signal", signals)
+	}
+}
+
+func TestIsTaskBudgetError(t *testing.T) {
+	err := errors.Join(context.Canceled, &taskBudgetError{taskID: "TASK-001", scope: BudgetScopeTask, scopeID: "TASK-001", limitUSD: 1})
+	if !IsTaskBudgetError(err) || IsTaskBudgetError(context.Canceled) {
+		t.Error("IsTaskBudgetError did not match wrapped budget error")
+	}
+}
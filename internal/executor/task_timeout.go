@@ -0,0 +1,159 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/automation"
+	"github.com/randalmurphal/orc/internal/i18n"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// TaskTimeoutSummaryKey is the task metadata key holding the progress summary
+// written when a task is paused by timeouts.task_max.
+const TaskTimeoutSummaryKey = "task_timeout_summary"
+
+// taskTimeoutError reports that a task run exceeded timeouts.task_max.
+type taskTimeoutError struct {
+	taskID string
+	limit  time.Duration
+}
+
+func (e *taskTimeoutError) Error() string {
+	return fmt.Sprintf("task exceeded its %v wall-clock limit (timeouts.task_max). Run 'orc resume %s' to continue.", e.limit, e.taskID)
+}
+
+// IsTaskTimeoutError returns true if the error is a whole-task timeout error.
+func IsTaskTimeoutError(err error) bool {
+	var tte *taskTimeoutError
+	return errors.As(err, &tte)
+}
+
+// startTaskTimer arms the whole-task wall-clock limit for t. When it expires,
+// cancel interrupts the run the same way a pause does, and interruptRun
+// escalates. The returned func disarms the timer.
+func (we *WorkflowExecutor) startTaskTimer(t *orcv1.Task, cancel context.CancelFunc) func() {
+	we.taskTimeout = 0
+	we.taskTimedOut.Store(false)
+	if we.orcConfig == nil || t == nil {
+		return func() {}
+	}
+	limit := we.orcConfig.TaskMaxFor(task.GetWorkflowIDProto(t))
+	if limit <= 0 {
+		return func() {}
+	}
+
+	we.taskTimeout = limit
+	taskID := t.Id
+	timer := time.AfterFunc(limit, func() {
+		we.logger.Warn("task_max exceeded, pausing task", "task", taskID, "timeout", limit)
+		we.taskTimedOut.Store(true)
+		cancel()
+	})
+	return func() { timer.Stop() }
+}
+
+// escalateTaskTimeout records what the timed-out task finished and what is
+// left, then raises a notification so a human can decide whether to resume.
+func (we *WorkflowExecutor) escalateTaskTimeout(t *orcv1.Task, currentPhase string) error {
+	summary := buildTaskTimeoutSummary(t, we.workflowPhaseOrder(t), currentPhase, we.taskTimeout)
+
+	task.EnsureMetadataProto(t)
+	t.Metadata[TaskTimeoutSummaryKey] = summary
+	if err := we.saveTaskStrict(t, "save task timeout summary"); err != nil {
+		return err
+	}
+	we.publishTaskUpdated(t)
+
+	if we.projectDB == nil {
+		return nil
+	}
+	now := time.Now()
+	notif := &automation.Notification{
+		ID:         fmt.Sprintf("notif-timeout-%s-%d", t.Id, now.Unix()),
+		Type:       automation.NotificationTypeTaskTimeout,
+		Title:      i18n.T("notification.task_timeout.title", t.Id, we.taskTimeout),
+		Message:    summary,
+		SourceType: automation.NotificationSourceTask,
+		SourceID:   t.Id,
+		CreatedAt:  now,
+	}
+	if err := automation.NewProjectDBAdapter(we.projectDB).CreateNotification(context.Background(), notif); err != nil {
+		we.logger.Warn("failed to create task timeout notification", "task", t.Id, "error", err)
+	}
+	return nil
+}
+
+// workflowPhaseOrder returns the task's workflow phases in execution order,
+// or nil when they cannot be loaded.
+func (we *WorkflowExecutor) workflowPhaseOrder(t *orcv1.Task) []string {
+	if we.globalDB == nil {
+		return nil
+	}
+	phases, err := we.globalDB.GetWorkflowPhases(task.GetWorkflowIDProto(t))
+	if err != nil {
+		return nil
+	}
+	if sorted, err := topologicalSort(phases); err == nil {
+		phases = sorted
+	}
+	order := make([]string, 0, len(phases))
+	for _, p := range phases {
+		order = append(order, p.PhaseTemplateID)
+	}
+	return order
+}
+
+// buildTaskTimeoutSummary describes a timed-out task's progress: completed
+// phases, the interrupted phase, phases still to run, and spend so far.
+func buildTaskTimeoutSummary(t *orcv1.Task, phaseOrder []string, currentPhase string, limit time.Duration) string {
+	exec := t.GetExecution()
+	var done, remaining []string
+	for _, id := range phaseOrder {
+		ps := exec.GetPhases()[id]
+		switch {
+		case ps != nil && ps.Status == orcv1.PhaseStatus_PHASE_STATUS_COMPLETED:
+			done = append(done, id)
+		case ps != nil && ps.Status == orcv1.PhaseStatus_PHASE_STATUS_SKIPPED:
+			// Skipped phases are neither done nor remaining
+		case id != currentPhase:
+			remaining = append(remaining, id)
+		}
+	}
+	if len(phaseOrder) == 0 {
+		for id, ps := range exec.GetPhases() {
+			if ps.Status == orcv1.PhaseStatus_PHASE_STATUS_COMPLETED {
+				done = append(done, id)
+			}
+		}
+		sort.Strings(done)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Paused after exceeding the %v task limit (timeouts.task_max).\n", limit)
+	fmt.Fprintf(&sb, "Done: %s\n", joinOrNone(done))
+	if currentPhase != "" {
+		fmt.Fprintf(&sb, "Interrupted: %s\n", currentPhase)
+	}
+	fmt.Fprintf(&sb, "Remaining: %s\n", joinOrNone(remaining))
+	if tokens := exec.GetTokens().GetTotalTokens(); tokens > 0 {
+		fmt.Fprintf(&sb, "Tokens used: %d\n", tokens)
+	}
+	if cost := exec.GetCost().GetTotalCostUsd(); cost > 0 {
+		fmt.Fprintf(&sb, "Cost so far: $%.2f\n", cost)
+	}
+	fmt.Fprintf(&sb, "Run 'orc resume %s' to continue with a fresh time budget.", t.Id)
+	return sb.String()
+}
+
+func joinOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
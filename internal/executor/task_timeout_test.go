@@ -0,0 +1,107 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/automation"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func TestStartTaskTimer_CancelsOnExpiry(t *testing.T) {
+	cfg := config.Default()
+	cfg.Timeouts.TaskMax = map[string]time.Duration{"default": 20 * time.Millisecond}
+	we := &WorkflowExecutor{orcConfig: cfg, logger: slog.Default()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stop := we.startTaskTimer(task.NewProtoTask("TASK-001", "slow"), cancel)
+	defer stop()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("task timer did not cancel the run")
+	}
+	if !we.taskTimedOut.Load() || we.taskTimeout != 20*time.Millisecond {
+		t.Errorf("timedOut=%v timeout=%v", we.taskTimedOut.Load(), we.taskTimeout)
+	}
+}
+
+func TestStartTaskTimer_UnlimitedByDefault(t *testing.T) {
+	we := &WorkflowExecutor{orcConfig: config.Default(), logger: slog.Default()}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	defer we.startTaskTimer(task.NewProtoTask("TASK-001", "any"), cancel)()
+
+	if we.taskTimeout != 0 || ctx.Err() != nil {
+		t.Errorf("timer armed without timeouts.task_max: timeout=%v", we.taskTimeout)
+	}
+}
+
+func TestInterruptRun_TaskTimeoutEscalates(t *testing.T) {
+	backend, taskItem, run := setupCompletionRecommendationContext(t)
+	task.StartPhaseProto(taskItem.Execution, "spec")
+	task.CompletePhaseProto(taskItem.Execution, "spec", "")
+	task.StartPhaseProto(taskItem.Execution, "implement")
+
+	we := &WorkflowExecutor{backend: backend, projectDB: backend.DB(), orcConfig: config.Default(), logger: slog.Default()}
+	we.taskTimeout = 2 * time.Hour
+	we.taskTimedOut.Store(true)
+
+	if err := we.interruptRun(run, taskItem, "implement", context.Canceled); err != nil {
+		t.Fatalf("interruptRun: %v", err)
+	}
+	if !strings.Contains(run.Error, "timeouts.task_max") {
+		t.Errorf("run error = %q, want task timeout", run.Error)
+	}
+
+	reloaded, err := backend.LoadTask(taskItem.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Status != orcv1.TaskStatus_TASK_STATUS_PAUSED {
+		t.Errorf("status = %v, want paused", reloaded.Status)
+	}
+	summary := reloaded.Metadata[TaskTimeoutSummaryKey]
+	for _, want := range []string{"2h0m0s", "Interrupted: implement", "orc resume TASK-001"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary missing %q:\n%s", want, summary)
+		}
+	}
+
+	notifs, err := automation.NewProjectDBAdapter(backend.DB()).GetActiveNotifications(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notifs) != 1 || notifs[0].Type != automation.NotificationTypeTaskTimeout || notifs[0].SourceID != "TASK-001" {
+		t.Fatalf("notifications = %+v, want one task_timeout for TASK-001", notifs)
+	}
+}
+
+func TestBuildTaskTimeoutSummary(t *testing.T) {
+	taskItem := task.NewProtoTask("TASK-007", "Summary")
+	task.StartPhaseProto(taskItem.Execution, "spec")
+	task.CompletePhaseProto(taskItem.Execution, "spec", "")
+	task.StartPhaseProto(taskItem.Execution, "implement")
+
+	summary := buildTaskTimeoutSummary(taskItem, []string{"spec", "implement", "review", "docs"}, "implement", time.Hour)
+	for _, want := range []string{"Done: spec\n", "Interrupted: implement\n", "Remaining: review, docs\n"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary missing %q:\n%s", want, summary)
+		}
+	}
+}
+
+func TestIsTaskTimeoutError(t *testing.T) {
+	err := errors.Join(context.Canceled, &taskTimeoutError{taskID: "TASK-001", limit: time.Hour})
+	if !IsTaskTimeoutError(err) || IsTaskTimeoutError(context.Canceled) {
+		t.Error("IsTaskTimeoutError did not match wrapped timeout error")
+	}
+}
@@ -0,0 +1,308 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/git"
+)
+
+// retryTestSelection narrows the tests quality check on a phase's retry
+// iterations. After a tests check fails, the next run covers only what the
+// files changed since then can affect, plus what failed: the Go packages
+// depending on the changed packages, or the Jest tests related to the
+// changed files. When a narrowed run passes, the full suite runs before the
+// check counts as passed.
+type retryTestSelection struct {
+	gitCtx  *git.Context
+	workDir string
+	baseRef string
+	files   map[string]iterationFileState // changed files at the last run
+	failed  map[string]string             // tests check name -> output of its last failed run
+}
+
+// newRetryTestSelection starts tracking test runs for a phase. It returns nil
+// when selective retry tests are disabled or the worktree is not a git
+// repository; a nil selection runs every check in full.
+func (we *WorkflowExecutor) newRetryTestSelection(cfg PhaseExecutionConfig) *retryTestSelection {
+	if we.orcConfig == nil || !we.orcConfig.Execution.SelectiveRetryTests || cfg.WorkingDir == "" {
+		return nil
+	}
+	gitCtx, err := git.NewContext(cfg.WorkingDir)
+	if err != nil {
+		return nil
+	}
+	return &retryTestSelection{
+		gitCtx:  gitCtx,
+		workDir: cfg.WorkingDir,
+		baseRef: firstNonEmpty(we.taskForkPoint(gitCtx), "HEAD"),
+		failed:  make(map[string]string),
+	}
+}
+
+// prepare snapshots the worktree for this run and narrows each tests check
+// that failed on the last run to the affected tests. It returns the checks
+// to run and the original form of every check it narrowed.
+func (s *retryTestSelection) prepare(ctx context.Context, checks []db.QualityCheck, commands map[string]*db.ProjectCommand, logger *slog.Logger) ([]db.QualityCheck, []db.QualityCheck) {
+	if s == nil {
+		return checks, nil
+	}
+	current, err := snapshotChangedFiles(s.gitCtx, s.workDir, s.baseRef)
+	if err != nil {
+		// Without a baseline nothing can be narrowed until the full suite passes
+		s.files = nil
+		return checks, nil
+	}
+	previous := s.files
+	s.files = current
+	if previous == nil || len(s.failed) == 0 {
+		return checks, nil
+	}
+	changed := diffIterationSnapshots(previous, current)
+
+	selected := slices.Clone(checks)
+	var full []db.QualityCheck
+	for i, check := range selected {
+		lastOutput, failed := s.failed[check.Name]
+		if !failed {
+			continue
+		}
+		command, ok := selectRetryTests(ctx, s.workDir, resolveCheckCommand(check, commands), changed, lastOutput)
+		if !ok {
+			continue
+		}
+		full = append(full, check)
+		selected[i].Command = command
+		logger.Info("running affected tests only",
+			"name", check.Name,
+			"changed_files", len(changed),
+			"command", command,
+		)
+	}
+	return selected, full
+}
+
+// record notes the outcome of each tests check. A check stays recorded as
+// failed until it passes a full run, so later retries keep narrowing.
+func (s *retryTestSelection) record(result *QualityCheckResult, narrowed []db.QualityCheck) {
+	if s == nil || result == nil {
+		return
+	}
+	for _, check := range result.Checks {
+		if !isTestsCheck(check.Name) || check.Skipped {
+			continue
+		}
+		switch {
+		case !check.Passed:
+			s.failed[check.Name] = check.Output
+		case !slices.ContainsFunc(narrowed, func(c db.QualityCheck) bool { return c.Name == check.Name }):
+			delete(s.failed, check.Name)
+		}
+	}
+}
+
+// isTestsCheck reports whether a check runs the project's tests command.
+func isTestsCheck(name string) bool {
+	return name == "tests" || strings.HasPrefix(name, "tests-") || strings.HasPrefix(name, "tests:")
+}
+
+// selectRetryTests narrows a tests command to what changed can affect plus
+// what failed in lastOutput. It supports `go test ./...` and direct Jest
+// invocations; other commands, compound shell commands, and changes the
+// selection cannot trace (go.mod, package.json, deleted files, ...) report
+// false so the full suite runs.
+func selectRetryTests(ctx context.Context, workDir, command string, changed []iterationFileChange, lastOutput string) (string, bool) {
+	command = strings.TrimSpace(command)
+	if command == "" || strings.ContainsAny(command, ";&|<>`$()\n") {
+		return "", false
+	}
+	fields := strings.Fields(command)
+	switch {
+	case len(fields) >= 2 && fields[0] == "go" && fields[1] == "test":
+		return selectGoTests(ctx, workDir, command, changed, lastOutput)
+	case slices.ContainsFunc(fields, func(f string) bool { return f == "jest" || strings.HasSuffix(f, "/jest") }):
+		return selectJestTests(workDir, command, changed, lastOutput)
+	}
+	return "", false
+}
+
+// goPackage is one package from `go list`.
+type goPackage struct {
+	ImportPath   string
+	Dir          string
+	Deps         []string
+	TestImports  []string
+	XTestImports []string
+}
+
+var (
+	goAllPackagesRe   = regexp.MustCompile(`(^|\s)\./\.\.\.(\s|$)`)
+	goFailedPackageRe = regexp.MustCompile(`(?m)^FAIL\s+(\S+)`)
+)
+
+// selectGoTests replaces ./... with the packages whose code or tests depend
+// on a changed package, plus the packages that failed last time.
+func selectGoTests(ctx context.Context, workDir, command string, changed []iterationFileChange, lastOutput string) (string, bool) {
+	if !goAllPackagesRe.MatchString(command) {
+		return "", false
+	}
+	pkgs, err := listGoPackages(ctx, workDir)
+	if err != nil || len(pkgs) == 0 {
+		return "", false
+	}
+	byDir := make(map[string]*goPackage, len(pkgs))
+	byPath := make(map[string]*goPackage, len(pkgs))
+	for _, p := range pkgs {
+		byDir[filepath.Clean(p.Dir)] = p
+		byPath[p.ImportPath] = p
+	}
+
+	changedPkgs := make(map[string]bool)
+	for _, c := range changed {
+		p := goPackageForFile(byDir, workDir, c.Path)
+		if p == nil {
+			return "", false
+		}
+		changedPkgs[p.ImportPath] = true
+	}
+	dependsOnChanged := func(imports []string) bool {
+		return slices.ContainsFunc(imports, func(i string) bool { return changedPkgs[i] })
+	}
+
+	affected := make(map[string]bool)
+	for _, p := range pkgs {
+		if changedPkgs[p.ImportPath] || dependsOnChanged(p.Deps) {
+			affected[p.ImportPath] = true
+		}
+	}
+	// Test-only imports are direct, so also test packages importing an affected one
+	var viaTests []string
+	for _, p := range pkgs {
+		imports := append(slices.Clone(p.TestImports), p.XTestImports...)
+		if slices.ContainsFunc(imports, func(i string) bool { return affected[i] }) {
+			viaTests = append(viaTests, p.ImportPath)
+		}
+	}
+	for _, p := range viaTests {
+		affected[p] = true
+	}
+	for _, m := range goFailedPackageRe.FindAllStringSubmatch(lastOutput, -1) {
+		if _, ok := byPath[m[1]]; ok {
+			affected[m[1]] = true
+		}
+	}
+	if len(affected) == 0 {
+		return "", false
+	}
+
+	selected := make([]string, 0, len(affected))
+	for p := range affected {
+		selected = append(selected, p)
+	}
+	sort.Strings(selected)
+	loc := goAllPackagesRe.FindStringSubmatchIndex(command)
+	return command[:loc[3]] + strings.Join(selected, " ") + command[loc[4]:], true
+}
+
+// goPackageForFile returns the package a changed file belongs to: the
+// package in its directory for Go files, or the nearest enclosing package
+// for other files (testdata, embedded files). Files outside every package,
+// such as go.mod, return nil.
+func goPackageForFile(byDir map[string]*goPackage, workDir, file string) *goPackage {
+	dir := filepath.Join(workDir, filepath.FromSlash(path.Dir(file)))
+	if path.Ext(file) == ".go" {
+		return byDir[dir]
+	}
+	for {
+		if p, ok := byDir[dir]; ok {
+			return p
+		}
+		parent := filepath.Dir(dir)
+		if dir == workDir || parent == dir || !strings.HasPrefix(parent, workDir) {
+			return nil
+		}
+		dir = parent
+	}
+}
+
+// listGoPackages lists the packages of the module in workDir.
+func listGoPackages(ctx context.Context, workDir string) ([]*goPackage, error) {
+	cmd := exec.CommandContext(ctx, "go", "list", "-e", "-json=ImportPath,Dir,Deps,TestImports,XTestImports", "./...")
+	cmd.Dir = workDir
+	// PWD keeps package directories under workDir when it contains symlinks
+	cmd.Env = append(os.Environ(), "GOWORK=off", "PWD="+workDir)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	var pkgs []*goPackage
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var p goPackage
+		if err := dec.Decode(&p); errors.Is(err, io.EOF) {
+			return pkgs, nil
+		} else if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, &p)
+	}
+}
+
+var jestFailedFileRe = regexp.MustCompile(`(?m)^\s*FAIL\s+(\S+\.[cm]?[jt]sx?)\b`)
+
+// selectJestTests appends --findRelatedTests with the changed files and the
+// test files that failed last time.
+func selectJestTests(workDir, command string, changed []iterationFileChange, lastOutput string) (string, bool) {
+	var files []string
+	for _, c := range changed {
+		if isJestConfigFile(c.Path) {
+			return "", false
+		}
+		if _, err := os.Stat(filepath.Join(workDir, c.Path)); err != nil {
+			// Jest cannot relate tests to a deleted file
+			return "", false
+		}
+		files = append(files, c.Path)
+	}
+	for _, m := range jestFailedFileRe.FindAllStringSubmatch(lastOutput, -1) {
+		if _, err := os.Stat(filepath.Join(workDir, m[1])); err == nil && !slices.Contains(files, m[1]) {
+			files = append(files, m[1])
+		}
+	}
+	if len(files) == 0 {
+		return "", false
+	}
+	quoted := make([]string, len(files))
+	for i, f := range files {
+		quoted[i] = quoteShellArg(f)
+	}
+	return command + " --findRelatedTests " + strings.Join(quoted, " "), true
+}
+
+// isJestConfigFile reports whether a change to file can affect every test.
+func isJestConfigFile(file string) bool {
+	base := path.Base(file)
+	switch base {
+	case "package.json", "package-lock.json", "yarn.lock", "pnpm-lock.yaml", "bun.lockb", ".babelrc":
+		return true
+	}
+	for _, prefix := range []string{"jest.config.", "jest.setup.", "babel.config.", "tsconfig"} {
+		if strings.HasPrefix(base, prefix) {
+			return true
+		}
+	}
+	return false
+}
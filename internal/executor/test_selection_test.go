@@ -0,0 +1,150 @@
+package executor
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+// writeGoModule writes a module where b imports a, c stands alone, and d's
+// tests import b.
+func writeGoModule(t *testing.T, dir string) {
+	t.Helper()
+	writeTestFile(t, dir, "go.mod", "module example.com/m\n\ngo 1.24\n")
+	writeTestFile(t, dir, "a/a.go", "package a\n\nfunc A() int { return 1 }\n")
+	writeTestFile(t, dir, "b/b.go", "package b\n\nimport \"example.com/m/a\"\n\nfunc B() int { return a.A() }\n")
+	writeTestFile(t, dir, "c/c.go", "package c\n")
+	writeTestFile(t, dir, "d/d.go", "package d\n")
+	writeTestFile(t, dir, "d/d_test.go", "package d\n\nimport (\n\t\"testing\"\n\n\t\"example.com/m/b\"\n)\n\nfunc TestD(t *testing.T) { _ = b.B() }\n")
+}
+
+func TestSelectRetryTests_Go(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeGoModule(t, dir)
+	ctx := context.Background()
+	changed := []iterationFileChange{{Path: "a/a.go", Kind: iterationFileModified}}
+
+	got, ok := selectRetryTests(ctx, dir, "go test -race ./...", changed, "--- FAIL: TestC\nFAIL\texample.com/m/c\t0.01s\nFAIL\n")
+	want := "go test -race example.com/m/a example.com/m/b example.com/m/c example.com/m/d"
+	if !ok || got != want {
+		t.Errorf("selectRetryTests() = %q, %v; want %q", got, ok, want)
+	}
+
+	// Non-Go files belong to the enclosing package
+	got, ok = selectRetryTests(ctx, dir, "go test ./... -count=1", []iterationFileChange{{Path: "c/testdata/in.txt"}}, "")
+	if !ok || got != "go test example.com/m/c -count=1" {
+		t.Errorf("testdata change: selectRetryTests() = %q, %v", got, ok)
+	}
+
+	for name, tc := range map[string]struct {
+		command string
+		changed []iterationFileChange
+	}{
+		"module file":      {"go test ./...", []iterationFileChange{{Path: "go.mod"}}},
+		"no package list":  {"go test ./a", changed},
+		"compound command": {"go test ./... && go vet ./...", changed},
+		"other runner":     {"make test", changed},
+	} {
+		if got, ok := selectRetryTests(ctx, dir, tc.command, tc.changed, ""); ok {
+			t.Errorf("%s: selectRetryTests() = %q, want the full suite", name, got)
+		}
+	}
+}
+
+func TestSelectRetryTests_Jest(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeTestFile(t, dir, "src/a.ts", "export const a = 1\n")
+	writeTestFile(t, dir, "src/b.test.ts", "test('b', () => {})\n")
+
+	got, ok := selectRetryTests(context.Background(), dir, "npx jest --ci",
+		[]iterationFileChange{{Path: "src/a.ts", Kind: iterationFileModified}},
+		"PASS src/a.test.ts\nFAIL src/b.test.ts\n  ● b\n")
+	if want := "npx jest --ci --findRelatedTests 'src/a.ts' 'src/b.test.ts'"; !ok || got != want {
+		t.Errorf("selectRetryTests() = %q, %v; want %q", got, ok, want)
+	}
+
+	for name, changed := range map[string][]iterationFileChange{
+		"package.json": {{Path: "package.json"}},
+		"deleted file": {{Path: "src/gone.ts", Kind: iterationFileDeleted}},
+	} {
+		if got, ok := selectRetryTests(context.Background(), dir, "npx jest", changed, ""); ok {
+			t.Errorf("%s: selectRetryTests() = %q, want the full suite", name, got)
+		}
+	}
+}
+
+func TestRetryTestSelection_NarrowsUntilFullSuitePasses(t *testing.T) {
+	t.Parallel()
+	gitCtx := setupBinaryPolicyRepo(t)
+	dir := gitCtx.WorkDir()
+	writeGoModule(t, dir)
+	runGitCmdOrFatal(t, dir, "add", ".")
+	runGitCmdOrFatal(t, dir, "commit", "-m", "add module")
+
+	we := &WorkflowExecutor{orcConfig: config.Default(), logger: slog.Default()}
+	sel := we.newRetryTestSelection(PhaseExecutionConfig{PhaseID: "implement", WorkingDir: dir})
+	if sel == nil {
+		t.Fatal("newRetryTestSelection() = nil in a git repo")
+	}
+	ctx := context.Background()
+	checks := []db.QualityCheck{
+		{Type: "custom", Name: "tests", Command: "go test ./...", Enabled: true},
+		{Type: "custom", Name: "lint", Command: "go vet ./...", Enabled: true},
+	}
+	failed := func(output string) *QualityCheckResult {
+		return &QualityCheckResult{Checks: []CheckResult{{Name: "tests", Output: output}, {Name: "lint", Passed: true}}}
+	}
+	passed := &QualityCheckResult{Checks: []CheckResult{{Name: "tests", Passed: true}, {Name: "lint", Passed: true}}}
+
+	// The first run is always the full suite
+	selected, narrowed := sel.prepare(ctx, checks, nil, slog.Default())
+	if len(narrowed) != 0 || selected[0].Command != "go test ./..." {
+		t.Fatalf("first run narrowed: %+v", selected)
+	}
+	sel.record(failed("FAIL\texample.com/m/c\t0.01s\n"), narrowed)
+
+	writeTestFile(t, dir, "b/b.go", "package b\n\nimport \"example.com/m/a\"\n\nfunc B() int { return a.A() + 1 }\n")
+	selected, narrowed = sel.prepare(ctx, checks, nil, slog.Default())
+	if want := "go test example.com/m/b example.com/m/c example.com/m/d"; selected[0].Command != want {
+		t.Fatalf("retry command = %q, want %q", selected[0].Command, want)
+	}
+	if len(narrowed) != 1 || narrowed[0].Command != "go test ./..." || selected[1].Command != "go vet ./..." {
+		t.Errorf("narrowed = %+v, selected = %+v", narrowed, selected)
+	}
+
+	// A narrowed pass is not a full pass: the next retry still narrows
+	sel.record(passed, narrowed)
+	writeTestFile(t, dir, "a/a.go", "package a\n\nfunc A() int { return 2 }\n")
+	if selected, _ = sel.prepare(ctx, checks, nil, slog.Default()); !strings.Contains(selected[0].Command, "example.com/m/c") {
+		t.Errorf("retry after narrowed pass = %q, want previous failures kept", selected[0].Command)
+	}
+
+	// Once the full suite passes, runs are full again
+	sel.record(passed, nil)
+	if err := os.WriteFile(filepath.Join(dir, "c/c.go"), []byte("package c\n\nvar C = 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if selected, narrowed = sel.prepare(ctx, checks, nil, slog.Default()); len(narrowed) != 0 || selected[0].Command != "go test ./..." {
+		t.Errorf("run after full pass narrowed to %q", selected[0].Command)
+	}
+}
+
+func TestQualityCheckResult_ReplaceChecks(t *testing.T) {
+	t.Parallel()
+	result := &QualityCheckResult{Checks: []CheckResult{
+		{Name: "tests", Passed: true, OnFailure: "block"},
+		{Name: "lint", Passed: true, OnFailure: "block"},
+	}}
+	result.replaceChecks(&QualityCheckResult{Checks: []CheckResult{{Name: "tests", Output: "FAIL", OnFailure: "block"}}})
+	if result.AllPassed || !result.HasBlocks || result.Checks[0].Output != "FAIL" || !result.Checks[1].Passed {
+		t.Errorf("replaceChecks() = %+v", result)
+	}
+}
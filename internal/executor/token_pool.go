@@ -0,0 +1,129 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"strings"
+
+	llmkit "github.com/randalmurphal/llmkit/v2"
+
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/tokenpool"
+)
+
+// oauthTokenEnv carries the pool account's token to the Claude CLI.
+const oauthTokenEnv = "CLAUDE_CODE_OAUTH_TOKEN"
+
+// WithWorkflowTokenPool sets the token pool Claude phases draw their account
+// from. Without it, the pool at pool.config_path is used when pool.enabled
+// is set.
+func WithWorkflowTokenPool(pool *tokenpool.Pool) WorkflowExecutorOption {
+	return func(we *WorkflowExecutor) {
+		we.tokenPool = pool
+	}
+}
+
+// loadTokenPool opens the configured token pool when none was injected.
+func (we *WorkflowExecutor) loadTokenPool() {
+	if we.tokenPool != nil || we.orcConfig == nil || !we.orcConfig.Pool.Enabled {
+		return
+	}
+	pool, err := tokenpool.New(we.orcConfig.Pool.ConfigPath, tokenpool.WithLogger(we.logger))
+	if err != nil {
+		we.logger.Warn("token pool unavailable, using default credentials", "error", err)
+		return
+	}
+	we.tokenPool = pool
+}
+
+// executeWithTokenPool runs a Claude phase on the pool's current account.
+// When the account hits a rate limit and the pool switches on rate limits,
+// the account is marked exhausted and the phase continues on the next one,
+// with an account_rotated event on the task stream. Tokens and cost are
+// recorded against the account that spent them.
+func (we *WorkflowExecutor) executeWithTokenPool(ctx context.Context, cfg PhaseExecutionConfig, adapter ProviderAdapter) (*PhaseExecutionResult, error) {
+	if we.tokenPool == nil || cfg.Provider != ProviderClaude {
+		return we.executeWithProvider(ctx, cfg, adapter)
+	}
+
+	// Metrics of attempts cut short by a rate limit
+	var spent PhaseExecutionResult
+	for {
+		account := we.tokenPool.Current()
+		if account == nil {
+			return we.executeWithProvider(ctx, cfg, adapter)
+		}
+		setPhaseEnv(&cfg, oauthTokenEnv, account.Token())
+
+		result, err := we.executeWithProvider(ctx, cfg, adapter)
+		if result != nil {
+			we.tokenPool.RecordUsage(account.ID,
+				int64(result.InputTokens+result.CacheCreationTokens+result.CacheReadTokens),
+				int64(result.OutputTokens), result.CostUSD)
+		}
+		if err == nil || !isRateLimitError(err) || !we.tokenPool.SwitchOnRateLimit() {
+			addPhaseMetrics(result, &spent)
+			return result, err
+		}
+
+		we.tokenPool.MarkExhausted(err.Error())
+		next, nextErr := we.tokenPool.Next()
+		if nextErr != nil {
+			we.logger.Warn("no token pool account left to switch to",
+				"phase", cfg.PhaseID, "account", account.ID, "error", nextErr)
+			addPhaseMetrics(result, &spent)
+			return result, err
+		}
+		we.logger.Info("rate limited, switching token pool account",
+			"phase", cfg.PhaseID, "from", account.ID, "to", next.ID)
+		we.publisher.AccountRotated(cfg.TaskID, events.AccountRotatedData{
+			Phase:       cfg.PhaseID,
+			FromAccount: account.ID,
+			ToAccount:   next.ID,
+			Reason:      err.Error(),
+		})
+		addPhaseMetrics(&spent, result)
+	}
+}
+
+// addPhaseMetrics adds the iterations, tokens and cost of src to dst.
+func addPhaseMetrics(dst, src *PhaseExecutionResult) {
+	if dst == nil || src == nil {
+		return
+	}
+	dst.Iterations += src.Iterations
+	dst.InputTokens += src.InputTokens
+	dst.OutputTokens += src.OutputTokens
+	dst.CacheCreationTokens += src.CacheCreationTokens
+	dst.CacheReadTokens += src.CacheReadTokens
+	dst.CostUSD += src.CostUSD
+}
+
+// setPhaseEnv sets an environment variable for the phase's provider process
+// without changing the resolved runtime config it was copied from.
+func setPhaseEnv(cfg *PhaseExecutionConfig, key, value string) {
+	runtime := &PhaseRuntimeConfig{}
+	if cfg.RuntimeConfig != nil {
+		copied := *cfg.RuntimeConfig
+		runtime = &copied
+	}
+	env := maps.Clone(runtime.Shared.Env)
+	if env == nil {
+		env = make(map[string]string)
+	}
+	env[key] = value
+	runtime.Shared.Env = env
+	cfg.RuntimeConfig = runtime
+}
+
+// isRateLimitError reports whether err is a provider rate or usage limit.
+func isRateLimitError(err error) bool {
+	if errors.Is(err, llmkit.ErrRateLimited) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "rate limit") ||
+		strings.Contains(msg, "usage limit") ||
+		strings.Contains(msg, "status 429")
+}
@@ -0,0 +1,170 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	llmkit "github.com/randalmurphal/llmkit/v2"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/tokenpool"
+)
+
+// rateLimitedTurnExecutor fails its first limited turns with a rate limit.
+type rateLimitedTurnExecutor struct {
+	*MockTurnExecutor
+	limited int
+}
+
+func (e *rateLimitedTurnExecutor) ExecuteTurn(ctx context.Context, prompt string) (*TurnResult, error) {
+	if e.limited > 0 {
+		e.limited--
+		return &TurnResult{IsError: true, Usage: &orcv1.TokenUsage{InputTokens: 10, OutputTokens: 5}},
+			fmt.Errorf("claude complete: %w", llmkit.ErrRateLimited)
+	}
+	return e.MockTurnExecutor.ExecuteTurn(ctx, prompt)
+}
+
+func newTestTokenPool(t *testing.T, accountIDs ...string) *tokenpool.Pool {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "pool.yaml")
+	cfg := tokenpool.DefaultPoolConfig()
+	for _, id := range accountIDs {
+		cfg.Accounts = append(cfg.Accounts, &tokenpool.Account{ID: id, Name: id, AccessToken: "token-" + id, Enabled: true})
+	}
+	if err := cfg.Save(configPath); err != nil {
+		t.Fatal(err)
+	}
+	pool, err := tokenpool.New(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pool
+}
+
+func TestExecuteWithTokenPool_RotatesOnRateLimit(t *testing.T) {
+	t.Parallel()
+
+	pool := newTestTokenPool(t, "work", "personal")
+	pub := events.NewMemoryPublisher()
+	defer pub.Close()
+	sub := pub.Subscribe("TASK-001")
+
+	turns := &rateLimitedTurnExecutor{
+		MockTurnExecutor: &MockTurnExecutor{DefaultResponse: `{"status": "complete", "summary": "done"}`},
+		limited:          1,
+	}
+	we := NewWorkflowExecutor(
+		storage.NewTestBackend(t), nil, nil, &config.Config{}, t.TempDir(),
+		WithWorkflowTurnExecutor(turns),
+		WithWorkflowTokenPool(pool),
+		WithWorkflowPublisher(pub),
+		WithWorkflowLogger(slog.Default()),
+	)
+	cfg := PhaseExecutionConfig{
+		TaskID:        "TASK-001",
+		PhaseID:       "implement",
+		Provider:      ProviderClaude,
+		PhaseTemplate: &db.PhaseTemplate{ID: "implement"},
+	}
+
+	result, err := we.executeWithTokenPool(context.Background(), cfg, &claudeAdapter{})
+	if err != nil {
+		t.Fatalf("executeWithTokenPool: %v", err)
+	}
+	if result.InputTokens != 110 || result.OutputTokens != 55 || result.Iterations != 2 {
+		t.Errorf("result = %+v, want metrics of both attempts", result)
+	}
+	if current := pool.Current(); current == nil || current.ID != "personal" {
+		t.Errorf("current account = %+v, want personal", current)
+	}
+
+	statuses := map[string]tokenpool.AccountStatus{}
+	for _, s := range pool.Status() {
+		statuses[s.Account.ID] = s
+	}
+	if work := statuses["work"].State; !work.Exhausted || work.RateLimitCount != 1 || work.Usage.InputTokens != 10 {
+		t.Errorf("work state = %+v", work)
+	}
+	if personal := statuses["personal"].State; personal.Exhausted || personal.Usage.Phases != 1 || personal.Usage.InputTokens != 100 {
+		t.Errorf("personal state = %+v", personal)
+	}
+
+	select {
+	case event := <-sub:
+		data, ok := event.Data.(events.AccountRotatedData)
+		if event.Type != events.EventAccountRotated || !ok {
+			t.Fatalf("event = %+v", event)
+		}
+		if data.FromAccount != "work" || data.ToAccount != "personal" || data.Phase != "implement" {
+			t.Errorf("rotation = %+v", data)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no account_rotated event")
+	}
+}
+
+func TestExecuteWithTokenPool_AllAccountsLimited(t *testing.T) {
+	t.Parallel()
+
+	pool := newTestTokenPool(t, "only")
+	turns := &rateLimitedTurnExecutor{
+		MockTurnExecutor: &MockTurnExecutor{DefaultResponse: `{"status": "complete", "summary": "done"}`},
+		limited:          1,
+	}
+	we := NewWorkflowExecutor(
+		storage.NewTestBackend(t), nil, nil, &config.Config{}, t.TempDir(),
+		WithWorkflowTurnExecutor(turns),
+		WithWorkflowTokenPool(pool),
+	)
+	cfg := PhaseExecutionConfig{PhaseID: "implement", Provider: ProviderClaude, PhaseTemplate: &db.PhaseTemplate{ID: "implement"}}
+
+	_, err := we.executeWithTokenPool(context.Background(), cfg, &claudeAdapter{})
+	if !errors.Is(err, llmkit.ErrRateLimited) {
+		t.Fatalf("err = %v, want the rate limit error", err)
+	}
+	if pool.HasAvailable() {
+		t.Error("the only account should be exhausted")
+	}
+}
+
+func TestSetPhaseEnv_LeavesResolvedConfigUnchanged(t *testing.T) {
+	t.Parallel()
+
+	resolved := &PhaseRuntimeConfig{}
+	resolved.Shared.Env = map[string]string{"KEEP": "1"}
+	cfg := PhaseExecutionConfig{RuntimeConfig: resolved}
+
+	setPhaseEnv(&cfg, oauthTokenEnv, "token")
+	if cfg.RuntimeConfig.Shared.Env[oauthTokenEnv] != "token" || cfg.RuntimeConfig.Shared.Env["KEEP"] != "1" {
+		t.Errorf("env = %v", cfg.RuntimeConfig.Shared.Env)
+	}
+	if _, ok := resolved.Shared.Env[oauthTokenEnv]; ok {
+		t.Error("resolved runtime config was modified")
+	}
+}
+
+func TestIsRateLimitError(t *testing.T) {
+	t.Parallel()
+
+	tests := map[error]bool{
+		fmt.Errorf("claude turn 1: %w", llmkit.ErrRateLimited):       true,
+		errors.New("Claude AI usage limit reached|1760000000"):       true,
+		errors.New("API error: status 429 Too Many Requests"):        true,
+		errors.New("max orc retries (3) reached without completion"): false,
+	}
+	for err, want := range tests {
+		if got := isRateLimitError(err); got != want {
+			t.Errorf("isRateLimitError(%q) = %v, want %v", err, got, want)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	llmkit "github.com/randalmurphal/llmkit/v2"
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/git"
+	"github.com/randalmurphal/orc/internal/llmutil"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// traceabilityDiffLimit and traceabilityOutputLimit cap how much of the
+// task's diff and phase output go into the traceability prompt.
+const (
+	traceabilityDiffLimit   = 30000
+	traceabilityOutputLimit = 6000
+)
+
+// TraceabilityRequest is the input to a TraceabilityMapper.
+type TraceabilityRequest struct {
+	TaskID       string
+	TaskTitle    string
+	PhaseID      string
+	Criteria     []task.SpecCriterion
+	ChangedFiles []string
+	Diff         string
+	Output       string
+	Model        string
+}
+
+// TraceabilityMapping is the validation model's judgement of which code and
+// tests satisfy each success criterion.
+type TraceabilityMapping struct {
+	Criteria []TraceabilityJudgement `json:"criteria"`
+}
+
+// TraceabilityJudgement is the validation model's judgement of one
+// criterion.
+type TraceabilityJudgement struct {
+	ID     string   `json:"id"`
+	Status string   `json:"status"`
+	Code   []string `json:"code"`
+	Tests  []string `json:"tests"`
+	Notes  string   `json:"notes"`
+}
+
+// TraceabilityMapper maps a spec's success criteria to the task's changes.
+type TraceabilityMapper func(ctx context.Context, req TraceabilityRequest) (*TraceabilityMapping, error)
+
+// WithWorkflowTraceabilityMapper overrides how traceability reports are
+// judged.
+func WithWorkflowTraceabilityMapper(mapper TraceabilityMapper) WorkflowExecutorOption {
+	return func(we *WorkflowExecutor) {
+		we.traceabilityMapper = mapper
+	}
+}
+
+const traceabilitySchema = `{
+  "type": "object",
+  "properties": {
+    "criteria": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "string", "description": "The criterion ID, e.g. SC-1"},
+          "status": {
+            "type": "string",
+            "enum": ["satisfied", "partial", "missing"],
+            "description": "satisfied: implemented and tested; partial: implemented in part or without tests; missing: not implemented"
+          },
+          "code": {
+            "type": "array",
+            "items": {"type": "string"},
+            "description": "Files (path or path:line) whose changes implement the criterion"
+          },
+          "tests": {
+            "type": "array",
+            "items": {"type": "string"},
+            "description": "Tests (file or test name) that verify the criterion"
+          },
+          "notes": {"type": "string", "description": "One sentence explaining the status"}
+        },
+        "required": ["id", "status", "code", "tests", "notes"]
+      }
+    }
+  },
+  "required": ["criteria"]
+}`
+
+// traceabilityEnabled reports whether a traceability report is built after
+// phaseID completes.
+func (we *WorkflowExecutor) traceabilityEnabled(phaseID string) bool {
+	if we.orcConfig == nil || !we.orcConfig.Validation.Enabled {
+		return false
+	}
+	cfg := we.orcConfig.Validation.Traceability
+	return cfg.Enabled && slices.Contains(cfg.Phases, phaseID)
+}
+
+// buildTraceabilityReport asks the validation model which of the task's
+// changes satisfy each success criterion of its spec and records the
+// report. Like confidence scoring it is advisory: failures are logged and
+// the phase proceeds without a report.
+func (we *WorkflowExecutor) buildTraceabilityReport(ctx context.Context, t *orcv1.Task, runID, phaseID, output string) {
+	if t == nil || we.projectDB == nil || !we.traceabilityEnabled(phaseID) {
+		return
+	}
+	spec, err := we.projectDB.GetFullSpecForTask(t.Id)
+	if err != nil || spec == nil {
+		if err != nil {
+			we.logger.Warn("failed to load spec for traceability", "task", t.Id, "error", err)
+		}
+		return
+	}
+	criteria := task.SuccessCriteria(spec.Content)
+	if len(criteria) == 0 {
+		return
+	}
+
+	mapper := we.traceabilityMapper
+	if mapper == nil {
+		mapper = we.mapWithValidationModel
+	}
+	req := TraceabilityRequest{
+		TaskID:       t.Id,
+		TaskTitle:    t.GetTitle(),
+		PhaseID:      phaseID,
+		Criteria:     criteria,
+		ChangedFiles: we.taskChangedFiles(),
+		Diff:         we.taskDiff(),
+		Output:       output,
+		Model:        we.orcConfig.Validation.Model,
+	}
+	mapping, err := mapper(ctx, req)
+	if err != nil {
+		we.logger.Warn("traceability mapping failed", "task", t.Id, "phase", phaseID, "error", err)
+		return
+	}
+
+	report := &db.TraceabilityReport{
+		TaskID:   t.Id,
+		RunID:    runID,
+		PhaseID:  phaseID,
+		SpecHash: task.HashContent(spec.Content),
+		Entries:  traceabilityEntries(criteria, mapping),
+		Model:    req.Model,
+	}
+	if err := we.projectDB.SaveTraceabilityReport(report); err != nil {
+		we.logger.Warn("failed to save traceability report", "task", t.Id, "phase", phaseID, "error", err)
+		return
+	}
+
+	we.logger.Info("traceability report built",
+		"task", t.Id,
+		"phase", phaseID,
+		"satisfied", report.Satisfied,
+		"criteria", report.Total,
+		"dropped", len(report.Dropped()),
+	)
+}
+
+// traceabilityEntries builds one entry per spec criterion, in spec order.
+// Criteria the model skipped are recorded as unverified rather than
+// dropped, so the report always accounts for the whole spec.
+func traceabilityEntries(criteria []task.SpecCriterion, mapping *TraceabilityMapping) []db.TraceabilityEntry {
+	judged := make(map[string]TraceabilityJudgement, len(mapping.Criteria))
+	for _, j := range mapping.Criteria {
+		judged[strings.TrimSpace(j.ID)] = j
+	}
+	entries := make([]db.TraceabilityEntry, 0, len(criteria))
+	for _, c := range criteria {
+		entry := db.TraceabilityEntry{
+			CriterionID: c.ID,
+			Criterion:   c.Text,
+			Status:      db.TraceabilityUnverified,
+			Code:        []string{},
+			Tests:       []string{},
+		}
+		if j, ok := judged[c.ID]; ok {
+			switch j.Status {
+			case db.TraceabilitySatisfied, db.TraceabilityPartial, db.TraceabilityMissing:
+				entry.Status = j.Status
+			}
+			if j.Code != nil {
+				entry.Code = j.Code
+			}
+			if j.Tests != nil {
+				entry.Tests = j.Tests
+			}
+			entry.Notes = j.Notes
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// taskDiff returns the diff of the task's worktree against its fork point,
+// or "" when the task has no worktree.
+func (we *WorkflowExecutor) taskDiff() string {
+	if we.worktreePath == "" {
+		return ""
+	}
+	gitCtx, err := git.NewContext(we.worktreePath)
+	if err != nil {
+		return ""
+	}
+	base := we.taskForkPoint(gitCtx)
+	if base == "" {
+		return ""
+	}
+	diff, err := gitCtx.RunGit("diff", "--no-color", base)
+	if err != nil {
+		we.logger.Debug("failed to diff task changes", "dir", we.worktreePath, "error", err)
+		return ""
+	}
+	return diff
+}
+
+// mapWithValidationModel is the default TraceabilityMapper. It makes a
+// schema-constrained call to validation.model.
+func (we *WorkflowExecutor) mapWithValidationModel(ctx context.Context, req TraceabilityRequest) (*TraceabilityMapping, error) {
+	cfg, err := llmkit.BuildConfig(ProviderClaude, req.Model, we.effectiveWorkingDir(), llmkit.RuntimeConfig{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build validation client config: %w", err)
+	}
+	cfg.BinaryPath = we.claudePath
+
+	client, err := llmkit.New(ProviderClaude, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create validation client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	result, err := llmutil.ExecuteWithSchema[TraceabilityMapping](ctx, client, buildTraceabilityPrompt(req), traceabilitySchema)
+	if err != nil {
+		return nil, err
+	}
+	return &result.Data, nil
+}
+
+func buildTraceabilityPrompt(req TraceabilityRequest) string {
+	var sb strings.Builder
+	sb.WriteString("You are verifying that an automated software task implemented every success criterion of its specification. ")
+	sb.WriteString("For each criterion, name the changed files that implement it and the tests that verify it, and judge whether it is satisfied, partial, or missing. ")
+	sb.WriteString("Judge only from the changes shown; a criterion with no supporting change is missing.\n\n")
+	fmt.Fprintf(&sb, "## Task %s: %s\n\n", req.TaskID, req.TaskTitle)
+
+	sb.WriteString("## Success Criteria\n\n")
+	for _, c := range req.Criteria {
+		fmt.Fprintf(&sb, "- %s: %s\n", c.ID, c.Text)
+	}
+
+	sb.WriteString("\n## Changed Files\n\n")
+	if len(req.ChangedFiles) == 0 {
+		sb.WriteString("(none found)\n")
+	}
+	for _, f := range req.ChangedFiles {
+		fmt.Fprintf(&sb, "- %s\n", f)
+	}
+
+	if diff := strings.TrimSpace(req.Diff); diff != "" {
+		if len(diff) > traceabilityDiffLimit {
+			diff = diff[:traceabilityDiffLimit] + "\n... (truncated)"
+		}
+		fmt.Fprintf(&sb, "\n## Diff\n\n```diff\n%s\n```\n", diff)
+	}
+	if output := strings.TrimSpace(req.Output); output != "" {
+		if len(output) > traceabilityOutputLimit {
+			output = output[:traceabilityOutputLimit] + "\n... (truncated)"
+		}
+		fmt.Fprintf(&sb, "\n## %s Phase Output\n\n%s\n", req.PhaseID, output)
+	}
+	return sb.String()
+}
@@ -0,0 +1,131 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+const traceabilityTestSpec = "## Intent\n\nRate limit the API.\n\n## Success Criteria\n\n" +
+	"| ID | Criterion | Verification |\n|----|-----------|--------------|\n" +
+	"| SC-1 | Returns 429 past the limit | Send 6 requests |\n" +
+	"| SC-2 | Limit resets after the window | Wait |\n" +
+	"| SC-3 | Limit is configurable | Set config |\n"
+
+func traceabilityConfig() *config.Config {
+	return &config.Config{Validation: config.ValidationConfig{
+		Enabled:      true,
+		Model:        "haiku",
+		Traceability: config.TraceabilityConfig{Enabled: true, Phases: []string{"implement"}},
+	}}
+}
+
+func TestBuildTraceabilityReport(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewTestBackend(t)
+	tsk := newConfidenceTestTask(t, backend, "TASK-TRACE-001")
+	if err := backend.DB().SaveSpecForTask(tsk.Id, traceabilityTestSpec, "test"); err != nil {
+		t.Fatalf("save spec: %v", err)
+	}
+
+	var got TraceabilityRequest
+	mapper := func(ctx context.Context, req TraceabilityRequest) (*TraceabilityMapping, error) {
+		got = req
+		return &TraceabilityMapping{Criteria: []TraceabilityJudgement{
+			{ID: "SC-1", Status: "satisfied", Code: []string{"internal/api/limit.go"}, Tests: []string{"TestLimit"}, Notes: "covered"},
+			{ID: "SC-2", Status: "missing", Notes: "no reset logic"},
+			{ID: "SC-9", Status: "satisfied"},
+		}}, nil
+	}
+	we := NewWorkflowExecutor(
+		backend, backend.DB(), testGlobalDBFrom(backend), traceabilityConfig(), t.TempDir(),
+		WithWorkflowLogger(slog.Default()),
+		WithWorkflowTraceabilityMapper(mapper),
+	)
+	we.buildTraceabilityReport(context.Background(), tsk, "RUN-001", "implement", "implemented the limiter")
+
+	if len(got.Criteria) != 3 || got.Criteria[0].ID != "SC-1" || got.Model != "haiku" || got.Output != "implemented the limiter" {
+		t.Fatalf("mapper request = %+v", got)
+	}
+
+	report, err := backend.DB().GetLatestTraceabilityReport(tsk.Id)
+	if err != nil || report == nil {
+		t.Fatalf("GetLatestTraceabilityReport() = %+v, %v", report, err)
+	}
+	if report.RunID != "RUN-001" || report.PhaseID != "implement" || report.SpecHash != task.HashContent(traceabilityTestSpec) {
+		t.Errorf("report = %+v", report)
+	}
+	if report.Satisfied != 1 || report.Total != 3 {
+		t.Errorf("report coverage = %d/%d, want 1/3", report.Satisfied, report.Total)
+	}
+	// SC-3 was skipped by the model: it stays in the report as unverified
+	want := []string{db.TraceabilitySatisfied, db.TraceabilityMissing, db.TraceabilityUnverified}
+	for i, e := range report.Entries {
+		if e.Status != want[i] {
+			t.Errorf("%s status = %q, want %q", e.CriterionID, e.Status, want[i])
+		}
+	}
+	if e := report.Entries[0]; e.Code[0] != "internal/api/limit.go" || e.Tests[0] != "TestLimit" || e.Criterion != "Returns 429 past the limit" {
+		t.Errorf("SC-1 entry = %+v", e)
+	}
+}
+
+func TestBuildTraceabilityReport_Skipped(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewTestBackend(t)
+	withSpec := newConfidenceTestTask(t, backend, "TASK-TRACE-002")
+	if err := backend.DB().SaveSpecForTask(withSpec.Id, traceabilityTestSpec, "test"); err != nil {
+		t.Fatalf("save spec: %v", err)
+	}
+	noSpec := newConfidenceTestTask(t, backend, "TASK-TRACE-003")
+
+	calls := 0
+	mapper := func(ctx context.Context, req TraceabilityRequest) (*TraceabilityMapping, error) {
+		calls++
+		return nil, errors.New("model unavailable")
+	}
+	newExecutor := func(cfg *config.Config) *WorkflowExecutor {
+		return NewWorkflowExecutor(backend, backend.DB(), testGlobalDBFrom(backend), cfg, t.TempDir(),
+			WithWorkflowLogger(slog.Default()), WithWorkflowTraceabilityMapper(mapper))
+	}
+	disabled := traceabilityConfig()
+	disabled.Validation.Traceability.Enabled = false
+
+	newExecutor(disabled).buildTraceabilityReport(context.Background(), withSpec, "RUN-001", "implement", "")
+	newExecutor(traceabilityConfig()).buildTraceabilityReport(context.Background(), withSpec, "RUN-001", "review", "")
+	newExecutor(traceabilityConfig()).buildTraceabilityReport(context.Background(), noSpec, "RUN-002", "implement", "")
+	if calls != 0 {
+		t.Errorf("mapper called %d times for disabled, unlisted phase, or missing spec", calls)
+	}
+
+	newExecutor(traceabilityConfig()).buildTraceabilityReport(context.Background(), withSpec, "RUN-001", "implement", "")
+	if calls != 1 {
+		t.Errorf("mapper calls = %d, want 1", calls)
+	}
+	if report, err := backend.DB().GetLatestTraceabilityReport(withSpec.Id); err != nil || report != nil {
+		t.Errorf("report after mapper error = %+v, %v; want none", report, err)
+	}
+}
+
+func TestBuildTraceabilityPrompt(t *testing.T) {
+	prompt := buildTraceabilityPrompt(TraceabilityRequest{
+		TaskID:       "TASK-001",
+		TaskTitle:    "Rate limiting",
+		PhaseID:      "implement",
+		Criteria:     []task.SpecCriterion{{ID: "SC-1", Text: "Returns 429"}},
+		ChangedFiles: []string{"internal/api/limit.go"},
+		Diff:         strings.Repeat("x", traceabilityDiffLimit+10),
+	})
+	for _, want := range []string{"## Task TASK-001: Rate limiting", "- SC-1: Returns 429", "- internal/api/limit.go", "(truncated)"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
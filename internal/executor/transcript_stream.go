@@ -92,9 +92,7 @@ func (h *TranscriptStreamHandler) StoreUserPrompt(prompt string) {
 		h.err = fmt.Errorf("store user prompt transcript: %w", err)
 		return
 	}
-	if h.publisher != nil {
-		h.publisher.Transcript(h.taskID, h.phaseID, 1, "prompt", prompt)
-	}
+	h.publish(transcript, "prompt", prompt, nil)
 }
 
 // UpdateSessionID updates the session ID used for subsequent transcript rows.
@@ -248,24 +246,14 @@ func (h *TranscriptStreamHandler) storeAssistantMessage(chunk llmkit.StreamChunk
 		h.err = fmt.Errorf("store assistant transcript %s: %w", messageUUID, err)
 		return
 	}
-	if h.publisher != nil {
-		h.publisher.TranscriptWithUsage(
-			h.taskID,
-			h.phaseID,
-			1,
-			"response",
-			publishText,
-			model,
-			&events.TokenUpdate{
-				Phase:                    h.phaseID,
-				InputTokens:              usageValue(chunk.Usage, func(u *llmkit.TokenUsage) int { return u.InputTokens }),
-				OutputTokens:             usageValue(chunk.Usage, func(u *llmkit.TokenUsage) int { return u.OutputTokens }),
-				CacheCreationInputTokens: usageValue(chunk.Usage, func(u *llmkit.TokenUsage) int { return u.CacheCreationInputTokens }),
-				CacheReadInputTokens:     usageValue(chunk.Usage, func(u *llmkit.TokenUsage) int { return u.CacheReadInputTokens }),
-				TotalTokens:              usageValue(chunk.Usage, func(u *llmkit.TokenUsage) int { return u.TotalTokens }),
-			},
-		)
-	}
+	h.publish(transcript, "response", publishText, &events.TokenUpdate{
+		Phase:                    h.phaseID,
+		InputTokens:              usageValue(chunk.Usage, func(u *llmkit.TokenUsage) int { return u.InputTokens }),
+		OutputTokens:             usageValue(chunk.Usage, func(u *llmkit.TokenUsage) int { return u.OutputTokens }),
+		CacheCreationInputTokens: usageValue(chunk.Usage, func(u *llmkit.TokenUsage) int { return u.CacheCreationInputTokens }),
+		CacheReadInputTokens:     usageValue(chunk.Usage, func(u *llmkit.TokenUsage) int { return u.CacheReadInputTokens }),
+		TotalTokens:              usageValue(chunk.Usage, func(u *llmkit.TokenUsage) int { return u.TotalTokens }),
+	})
 }
 
 func assistantTranscriptContent(chunk llmkit.StreamChunk) (string, string, error) {
@@ -432,24 +420,14 @@ func (h *TranscriptStreamHandler) StoreAssistantTextWithUsage(
 		h.err = fmt.Errorf("store assistant transcript %s: %w", messageID, err)
 		return
 	}
-	if h.publisher != nil {
-		h.publisher.TranscriptWithUsage(
-			h.taskID,
-			h.phaseID,
-			1,
-			"response",
-			text,
-			model,
-			&events.TokenUpdate{
-				Phase:                    h.phaseID,
-				InputTokens:              inputTokens,
-				OutputTokens:             outputTokens,
-				CacheCreationInputTokens: cacheCreationTokens,
-				CacheReadInputTokens:     cacheReadTokens,
-				TotalTokens:              inputTokens + outputTokens + cacheCreationTokens + cacheReadTokens,
-			},
-		)
-	}
+	h.publish(transcript, "response", text, &events.TokenUpdate{
+		Phase:                    h.phaseID,
+		InputTokens:              inputTokens,
+		OutputTokens:             outputTokens,
+		CacheCreationInputTokens: cacheCreationTokens,
+		CacheReadInputTokens:     cacheReadTokens,
+		TotalTokens:              inputTokens + outputTokens + cacheCreationTokens + cacheReadTokens,
+	})
 }
 
 // StoreChunkText stores a streaming assistant chunk for live transcript visibility.
@@ -485,9 +463,7 @@ func (h *TranscriptStreamHandler) StoreChunkText(text, model string) {
 		h.err = fmt.Errorf("store assistant chunk transcript: %w", err)
 		return
 	}
-	if h.publisher != nil {
-		h.publisher.TranscriptChunk(h.taskID, h.phaseID, 1, text)
-	}
+	h.publish(transcript, "chunk", text, nil)
 }
 
 // StoreToolCall stores a Codex tool invocation for live transcript visibility.
@@ -524,9 +500,7 @@ func (h *TranscriptStreamHandler) StoreToolCall(name string, arguments json.RawM
 		h.err = fmt.Errorf("store tool call transcript %s: %w", name, err)
 		return
 	}
-	if h.publisher != nil {
-		h.publisher.Transcript(h.taskID, h.phaseID, 1, "tool", content)
-	}
+	h.publish(transcript, "tool", content, nil)
 }
 
 // StoreToolResult stores a Codex tool result for live transcript visibility.
@@ -578,9 +552,26 @@ func (h *TranscriptStreamHandler) StoreToolResult(name, output, status string, e
 		h.err = fmt.Errorf("store tool result transcript %s: %w", name, err)
 		return
 	}
-	if h.publisher != nil {
-		h.publisher.Transcript(h.taskID, h.phaseID, 1, "tool_result", content)
+	h.publish(transcript, "tool_result", content, nil)
+}
+
+// publish streams a stored transcript message to subscribers. content is the
+// display text, which differs from the stored content for structured
+// assistant messages.
+func (h *TranscriptStreamHandler) publish(t *storage.Transcript, msgType, content string, tokens *events.TokenUpdate) {
+	if h.publisher == nil {
+		return
 	}
+	h.publisher.TranscriptMessage(h.taskID, events.TranscriptLine{
+		Phase:       h.phaseID,
+		Iteration:   1,
+		Type:        msgType,
+		Content:     content,
+		Timestamp:   time.UnixMilli(t.Timestamp),
+		Model:       t.Model,
+		Tokens:      tokens,
+		MessageUUID: t.MessageUUID,
+	})
 }
 
 func formatToolCallContent(name string, arguments json.RawMessage) string {
@@ -9,6 +9,7 @@ import (
 
 	llmkit "github.com/randalmurphal/llmkit/v2"
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/events"
 	"github.com/randalmurphal/orc/internal/storage"
 )
 
@@ -282,3 +283,29 @@ func TestTranscriptStreamHandler_OnChunk_PreservesClaudeToolOnlyAssistantMessage
 		t.Fatal("expected structured content for tool-only assistant message")
 	}
 }
+
+func TestTranscriptStreamHandler_PublishesMessageUUID(t *testing.T) {
+	backend := &mockTranscriptBackend{}
+	pub := &MockPublisher{}
+	h := NewTranscriptStreamHandler(backend, slog.Default(), "TASK-001", "implement", "sess-1", "run-1", "sonnet", events.NewPublishHelper(pub), nil)
+
+	h.StoreUserPrompt("do the thing")
+	h.OnChunk(llmkit.StreamChunk{Type: "assistant", MessageID: "msg-1", Content: "done", Model: "sonnet"})
+
+	published := pub.GetEvents()
+	if len(published) != 2 || backend.Count() != 2 {
+		t.Fatalf("published %d events for %d transcripts, want 2", len(published), backend.Count())
+	}
+	for i, ev := range published {
+		line, ok := ev.Data.(events.TranscriptLine)
+		if !ok || ev.Type != events.EventTranscript {
+			t.Fatalf("event %d = %+v, want a transcript line", i, ev)
+		}
+		if want := backend.Transcript(i).MessageUUID; line.MessageUUID != want || want == "" {
+			t.Errorf("event %d message_uuid = %q, want stored %q", i, line.MessageUUID, want)
+		}
+	}
+	if line := published[1].Data.(events.TranscriptLine); line.Type != "response" || line.Content != "done" || line.MessageUUID != "msg-1" {
+		t.Errorf("assistant event = %+v", line)
+	}
+}
@@ -0,0 +1,539 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	llmkit "github.com/randalmurphal/llmkit/v2"
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/llmutil"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+const (
+	// voteOutputLimit and voteDiffLimit cap how much of each candidate goes
+	// into the judge prompt.
+	voteOutputLimit = 6000
+	voteDiffLimit   = 12000
+)
+
+// VoteJudgeCandidate is one candidate as presented to the judge.
+type VoteJudgeCandidate struct {
+	Index  int
+	Output string
+	Diff   string
+}
+
+// VoteJudgeRequest is the input to a VoteJudge.
+type VoteJudgeRequest struct {
+	TaskID          string
+	TaskTitle       string
+	TaskDescription string
+	PhaseID         string
+	Spec            string
+	Model           string
+	Candidates      []VoteJudgeCandidate
+}
+
+// VoteCandidateScore is the judge's assessment of one candidate.
+type VoteCandidateScore struct {
+	Candidate int     `json:"candidate"`
+	Score     float64 `json:"score"`
+	Notes     string  `json:"notes"`
+}
+
+// VoteJudgement is the judge's decision between voting candidates.
+type VoteJudgement struct {
+	Winner     int                  `json:"winner"`
+	Rationale  string               `json:"rationale"`
+	Scores     []VoteCandidateScore `json:"scores"`
+	MergeNotes string               `json:"merge_notes"`
+}
+
+// VoteJudge compares the candidates of a voting phase and picks the best.
+type VoteJudge func(ctx context.Context, req VoteJudgeRequest) (*VoteJudgement, error)
+
+// WithWorkflowVoteJudge overrides how voting candidates are compared.
+func WithWorkflowVoteJudge(judge VoteJudge) WorkflowExecutorOption {
+	return func(we *WorkflowExecutor) {
+		we.voteJudge = judge
+	}
+}
+
+// voteCandidateRunner runs one voting candidate in cfg.WorkingDir.
+type voteCandidateRunner func(ctx context.Context, cfg PhaseExecutionConfig, adapter ProviderAdapter) (*PhaseExecutionResult, error)
+
+// voteCandidate is the state of one voting attempt.
+type voteCandidate struct {
+	index        int
+	branch       string
+	worktreePath string
+	commitSHA    string
+	diff         string
+	result       *PhaseExecutionResult
+	err          error
+}
+
+const voteJudgementSchema = `{
+  "type": "object",
+  "properties": {
+    "winner": {
+      "type": "integer",
+      "description": "Number of the candidate that best satisfies the spec"
+    },
+    "rationale": {
+      "type": "string",
+      "description": "Why the winner was chosen over the others"
+    },
+    "scores": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "candidate": {"type": "integer"},
+          "score": {"type": "number", "minimum": 0, "maximum": 1},
+          "notes": {"type": "string"}
+        },
+        "required": ["candidate", "score", "notes"]
+      }
+    },
+    "merge_notes": {
+      "type": "string",
+      "description": "Specific strengths of the other candidates to fold into the winner; empty if the winner should be used as is"
+    }
+  },
+  "required": ["winner", "rationale", "scores", "merge_notes"]
+}`
+
+// votingCandidates returns how many candidates a phase runs in voting mode,
+// or 0 when the phase runs normally. Voting needs a task worktree to branch
+// candidates from.
+func (we *WorkflowExecutor) votingCandidates(phaseID string, t *orcv1.Task) int {
+	if we.orcConfig == nil || t == nil {
+		return 0
+	}
+	if !we.orcConfig.VotingApplies(phaseID, task.GetWorkflowIDProto(t), task.PriorityFromProto(t.GetPriority())) {
+		return 0
+	}
+	if we.gitOps == nil || we.worktreeGit == nil {
+		we.logger.Warn("voting mode needs a task worktree, running phase once", "task", t.Id, "phase", phaseID)
+		return 0
+	}
+	return we.orcConfig.Voting.Candidates
+}
+
+// executeVotingPhase runs a phase as k parallel candidates, each on its own
+// branch off the task branch, asks the judge to pick (and optionally merge)
+// the best one, and moves the task branch to the winner. The comparison is
+// recorded in phase_votes.
+func (we *WorkflowExecutor) executeVotingPhase(ctx context.Context, cfg PhaseExecutionConfig, adapter ProviderAdapter, t *orcv1.Task, spec string, k int) (*PhaseExecutionResult, error) {
+	total := &PhaseExecutionResult{}
+
+	// Candidates branch from a commit, so capture any loose work first.
+	if dirty, err := we.worktreeGit.HasUncommittedChanges(); err != nil {
+		return total, fmt.Errorf("voting: check worktree status: %w", err)
+	} else if dirty {
+		if _, err := we.worktreeGit.CreateCheckpoint(t.Id, cfg.PhaseID, "before voting"); err != nil {
+			return total, fmt.Errorf("voting: checkpoint before voting: %w", err)
+		}
+	}
+	baseSHA, err := we.worktreeGit.Context().HeadCommit()
+	if err != nil {
+		return total, fmt.Errorf("voting: resolve base commit: %w", err)
+	}
+	taskBranch, err := we.worktreeGit.GetCurrentBranch()
+	if err != nil {
+		return total, fmt.Errorf("voting: resolve task branch: %w", err)
+	}
+
+	candidates := make([]*voteCandidate, 0, k)
+	defer func() {
+		for _, c := range candidates {
+			we.cleanupVoteCandidate(c)
+		}
+	}()
+	for i := 1; i <= k; i++ {
+		c := &voteCandidate{index: i, branch: fmt.Sprintf("%s-vote-%d", taskBranch, i)}
+		// Clear leftovers from an interrupted vote so the branch starts at base.
+		_ = we.gitOps.CleanupWorktreeAtPath(we.gitOps.WorktreePathForCustomBranch(c.branch))
+		_ = we.gitOps.DeleteBranch(c.branch, true)
+		c.worktreePath, err = we.gitOps.CreateWorktreeWithCustomBranch(t.Id, c.branch, baseSHA)
+		if err != nil {
+			return total, fmt.Errorf("voting: create candidate %d worktree: %w", i, err)
+		}
+		candidates = append(candidates, c)
+	}
+
+	we.logger.Info("running voting candidates", "task", t.Id, "phase", cfg.PhaseID, "candidates", k)
+
+	run := we.voteCandidateRunner
+	if run == nil {
+		run = we.runVoteCandidate
+	}
+	runOne := func(c *voteCandidate) {
+		candCfg := cfg
+		candCfg.WorkingDir = c.worktreePath
+		c.result, c.err = run(ctx, candCfg, adapter)
+		if c.err == nil {
+			c.err = we.commitVoteCandidate(c, t.Id, cfg.PhaseID, baseSHA)
+		}
+	}
+	// An injected turn executor is shared by every candidate, so it cannot
+	// run them concurrently.
+	if we.turnExecutor != nil && we.voteCandidateRunner == nil {
+		for _, c := range candidates {
+			runOne(c)
+		}
+	} else {
+		var wg sync.WaitGroup
+		for _, c := range candidates {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				runOne(c)
+			}()
+		}
+		wg.Wait()
+	}
+
+	var succeeded []*voteCandidate
+	var errs []error
+	for _, c := range candidates {
+		addVoteUsage(total, c.result)
+		if c.err != nil {
+			we.logger.Warn("voting candidate failed", "task", t.Id, "phase", cfg.PhaseID, "candidate", c.index, "error", c.err)
+			errs = append(errs, fmt.Errorf("candidate %d: %w", c.index, c.err))
+			continue
+		}
+		succeeded = append(succeeded, c)
+	}
+	if ctx.Err() != nil {
+		return total, ctx.Err()
+	}
+	if len(succeeded) == 0 {
+		// Surface a blocked candidate as a blocked phase so gates can handle it.
+		for _, c := range candidates {
+			var blocked *PhaseBlockedError
+			if errors.As(c.err, &blocked) {
+				return total, c.err
+			}
+		}
+		return total, fmt.Errorf("voting: every candidate failed: %w", errors.Join(errs...))
+	}
+
+	judgeModel := we.orcConfig.Voting.JudgeModel
+	judgement := &VoteJudgement{Winner: succeeded[0].index, Rationale: "only candidate to complete"}
+	if len(succeeded) > 1 {
+		req := VoteJudgeRequest{
+			TaskID:          t.Id,
+			TaskTitle:       t.GetTitle(),
+			TaskDescription: t.GetDescription(),
+			PhaseID:         cfg.PhaseID,
+			Spec:            spec,
+			Model:           judgeModel,
+		}
+		for _, c := range succeeded {
+			req.Candidates = append(req.Candidates, VoteJudgeCandidate{Index: c.index, Output: c.result.Content, Diff: c.diff})
+		}
+		judge := we.voteJudge
+		if judge == nil {
+			judge = we.judgeWithModel
+		}
+		judged, err := judge(ctx, req)
+		if err != nil {
+			return total, fmt.Errorf("voting: judge candidates: %w", err)
+		}
+		judgement = judged
+	}
+
+	winner := succeeded[0]
+	for _, c := range succeeded {
+		if c.index == judgement.Winner {
+			winner = c
+		}
+	}
+	if winner.index != judgement.Winner {
+		we.logger.Warn("judge picked an unknown candidate, using the first that completed",
+			"task", t.Id, "phase", cfg.PhaseID, "picked", judgement.Winner, "using", winner.index)
+	}
+
+	merged := false
+	if notes := strings.TrimSpace(judgement.MergeNotes); notes != "" && len(succeeded) > 1 && we.orcConfig.Voting.AllowMerge {
+		mergeCfg := cfg
+		mergeCfg.WorkingDir = winner.worktreePath
+		mergeCfg.Prompt = buildVoteMergePrompt(cfg.Prompt, winner.index, notes, succeeded)
+		mergeResult, mergeErr := run(ctx, mergeCfg, adapter)
+		addVoteUsage(total, mergeResult)
+		if mergeErr == nil {
+			mergeErr = we.commitVoteCandidate(winner, t.Id, cfg.PhaseID, baseSHA)
+		}
+		if mergeErr != nil {
+			we.logger.Warn("voting merge pass failed, keeping the winner as is", "task", t.Id, "phase", cfg.PhaseID, "error", mergeErr)
+		} else {
+			merged = true
+			winner.result.Content = mergeResult.Content
+			winner.result.RawOutput = mergeResult.RawOutput
+		}
+	}
+
+	if err := we.worktreeGit.Rewind(winner.commitSHA); err != nil {
+		return total, fmt.Errorf("voting: adopt candidate %d: %w", winner.index, err)
+	}
+
+	we.recordPhaseVote(t.Id, cfg, judgeModel, winner.index, merged, judgement, candidates)
+	we.logger.Info("voting phase adopted candidate",
+		"task", t.Id,
+		"phase", cfg.PhaseID,
+		"winner", winner.index,
+		"merged", merged,
+		"completed", len(succeeded),
+	)
+
+	total.Iterations = winner.result.Iterations
+	total.Content = winner.result.Content
+	total.RawOutput = winner.result.RawOutput
+	total.SessionID = winner.result.SessionID
+	return total, nil
+}
+
+// runVoteCandidate is the default voteCandidateRunner. It drives one fresh
+// session in the candidate worktree until the phase reports completion.
+func (we *WorkflowExecutor) runVoteCandidate(ctx context.Context, cfg PhaseExecutionConfig, adapter ProviderAdapter) (*PhaseExecutionResult, error) {
+	result := &PhaseExecutionResult{}
+
+	if we.globalDB != nil {
+		baseCfg := &WorktreeBaseConfig{
+			WorktreePath:  cfg.WorkingDir,
+			MainRepoPath:  we.workingDir,
+			TaskID:        cfg.TaskID,
+			AdditionalEnv: we.agentEnv(cfg.TaskID),
+			Phase:         cfg.PhaseID,
+		}
+		prepared, err := PreparePhaseRuntime(ctx, cfg.Provider, cfg.WorkingDir, cfg.RuntimeConfig, baseCfg, we.globalDB, we.globalDB)
+		if err != nil {
+			return result, fmt.Errorf("prepare runtime: %w", err)
+		}
+		if prepared != nil {
+			defer func() { _ = prepared.Close() }()
+		}
+	}
+
+	pctx := &ProviderExecContext{Prompt: cfg.Prompt}
+	if !isCodexFamilyProvider(cfg.Provider) {
+		pctx.SessionID = uuid.New().String()
+	}
+	turnExec := we.turnExecutor
+	if turnExec == nil {
+		turnExec = NewTurnExecutor(adapter.BuildTurnExecutorConfig(&cfg, pctx, we))
+	}
+
+	prompt := pctx.Prompt
+	retryTests := we.newRetryTestSelection(cfg)
+	for i := 0; i < MaxOrcRetries; i++ {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		result.Iterations++
+
+		turn, err := turnExec.ExecuteTurn(ctx, prompt)
+		if turn != nil {
+			if turn.SessionID != "" {
+				result.SessionID = turn.SessionID
+			}
+			if turn.Usage != nil {
+				result.InputTokens += int(turn.Usage.InputTokens)
+				result.OutputTokens += int(turn.Usage.OutputTokens)
+				result.CacheCreationTokens += int(turn.Usage.CacheCreationInputTokens)
+				result.CacheReadTokens += int(turn.Usage.CacheReadInputTokens)
+			}
+			result.CostUSD += turn.CostUSD
+		}
+		if err != nil {
+			return result, fmt.Errorf("%s turn %d: %w", adapter.Name(), i+1, err)
+		}
+
+		reviewRound := cfg.ReviewRound
+		if reviewRound == 0 {
+			reviewRound = 1
+		}
+		status, reason, parseErr := ParsePhaseSpecificResponse(cfg.PhaseID, reviewRound, turn.Content)
+		if parseErr != nil {
+			prompt = fmt.Sprintf("Continue. Previous output was not valid JSON. Iteration %d/%d.", i+2, MaxOrcRetries)
+			continue
+		}
+
+		switch status {
+		case PhaseStatusComplete:
+			if we.turnExecutor == nil {
+				if checkResult := we.runQualityChecks(ctx, cfg, retryTests); checkResult != nil && checkResult.HasBlocks {
+					prompt = FormatQualityChecksForPrompt(checkResult)
+					continue
+				}
+			}
+			result.RawOutput = turn.Content
+			result.Content = extractPhaseOutput(turn.Content)
+			return result, nil
+		case PhaseStatusBlocked:
+			result.RawOutput = turn.Content
+			result.Content = extractPhaseOutput(turn.Content)
+			return result, &PhaseBlockedError{Phase: cfg.PhaseID, Reason: reason, Output: turn.Content}
+		case PhaseStatusContinue:
+			prompt = fmt.Sprintf("Continue working. Iteration %d/%d. %s", i+2, MaxOrcRetries, reason)
+		}
+	}
+	return result, fmt.Errorf("max orc retries (%d) reached without completion (%s)", MaxOrcRetries, adapter.Name())
+}
+
+// commitVoteCandidate checkpoints a candidate's worktree and captures its
+// diff against the voting base.
+func (we *WorkflowExecutor) commitVoteCandidate(c *voteCandidate, taskID, phaseID, baseSHA string) error {
+	candGit := we.gitOps.InWorktree(c.worktreePath)
+	checkpoint, err := candGit.CreateCheckpoint(taskID, phaseID, fmt.Sprintf("voting candidate %d", c.index))
+	if err != nil {
+		return fmt.Errorf("checkpoint candidate: %w", err)
+	}
+	c.commitSHA = checkpoint.CommitSHA
+	diff, err := candGit.Context().RunGit("diff", baseSHA, c.commitSHA)
+	if err != nil {
+		return fmt.Errorf("diff candidate: %w", err)
+	}
+	c.diff = diff
+	return nil
+}
+
+func (we *WorkflowExecutor) cleanupVoteCandidate(c *voteCandidate) {
+	if c.worktreePath != "" {
+		if err := we.gitOps.CleanupWorktreeAtPath(c.worktreePath); err != nil {
+			we.logger.Warn("failed to remove voting worktree", "path", c.worktreePath, "error", err)
+		}
+	}
+	if err := we.gitOps.DeleteBranch(c.branch, true); err != nil {
+		we.logger.Debug("failed to delete voting branch", "branch", c.branch, "error", err)
+	}
+}
+
+func (we *WorkflowExecutor) recordPhaseVote(taskID string, cfg PhaseExecutionConfig, judgeModel string, winner int, merged bool, judgement *VoteJudgement, candidates []*voteCandidate) {
+	if we.projectDB == nil {
+		return
+	}
+	vote := &db.PhaseVote{
+		TaskID:     taskID,
+		PhaseID:    cfg.PhaseID,
+		RunID:      cfg.RunID,
+		JudgeModel: judgeModel,
+		Winner:     winner,
+		Merged:     merged,
+		Rationale:  judgement.Rationale,
+	}
+	for _, c := range candidates {
+		rec := db.VoteCandidate{Index: c.index, Branch: c.branch, CommitSHA: c.commitSHA}
+		if c.result != nil {
+			rec.Summary = truncateVoteText(c.result.Content, 500)
+			rec.CostUSD = c.result.CostUSD
+		}
+		if c.err != nil {
+			rec.Error = c.err.Error()
+		}
+		for _, s := range judgement.Scores {
+			if s.Candidate == c.index {
+				rec.Score = s.Score
+				rec.Notes = s.Notes
+			}
+		}
+		vote.Candidates = append(vote.Candidates, rec)
+	}
+	if err := we.projectDB.SavePhaseVote(vote); err != nil {
+		we.logger.Warn("failed to record phase vote", "task", taskID, "phase", cfg.PhaseID, "error", err)
+	}
+}
+
+// judgeWithModel is the default VoteJudge. It makes a schema-constrained
+// call to voting.judge_model.
+func (we *WorkflowExecutor) judgeWithModel(ctx context.Context, req VoteJudgeRequest) (*VoteJudgement, error) {
+	cfg, err := llmkit.BuildConfig(ProviderClaude, req.Model, we.effectiveWorkingDir(), llmkit.RuntimeConfig{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build judge client config: %w", err)
+	}
+	cfg.BinaryPath = we.claudePath
+
+	client, err := llmkit.New(ProviderClaude, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create judge client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	result, err := llmutil.ExecuteWithSchema[VoteJudgement](ctx, client, buildVoteJudgePrompt(req), voteJudgementSchema)
+	if err != nil {
+		return nil, err
+	}
+	return &result.Data, nil
+}
+
+func buildVoteJudgePrompt(req VoteJudgeRequest) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Judge: %s phase of %s\n\n", req.PhaseID, req.TaskID)
+	fmt.Fprintf(&sb, "%d independent attempts were made at the %q phase of this task. ", len(req.Candidates), req.PhaseID)
+	sb.WriteString("Compare them against the spec and pick the one that best satisfies it.\n\n")
+	fmt.Fprintf(&sb, "## Task: %s\n\n", req.TaskTitle)
+	if desc := strings.TrimSpace(req.TaskDescription); desc != "" {
+		fmt.Fprintf(&sb, "%s\n\n", desc)
+	}
+	if spec := strings.TrimSpace(req.Spec); spec != "" {
+		fmt.Fprintf(&sb, "## Spec\n\n%s\n\n", spec)
+	}
+	for _, c := range req.Candidates {
+		fmt.Fprintf(&sb, "## Candidate %d\n\n### Summary\n\n%s\n\n", c.Index, truncateVoteText(c.Output, voteOutputLimit))
+		if diff := strings.TrimSpace(c.Diff); diff != "" {
+			fmt.Fprintf(&sb, "### Diff\n\n```diff\n%s\n```\n\n", truncateVoteText(diff, voteDiffLimit))
+		}
+	}
+	sb.WriteString(`## Instructions
+
+1. Score every candidate from 0 to 1 on correctness, completeness against the spec, test coverage, and risk.
+2. Set winner to the number of the best candidate.
+3. If another candidate has a specific strength the winner lacks (a missed edge case, a test, a cleaner approach to one part), describe it in merge_notes so it can be folded into the winner. Leave merge_notes empty if the winner should be used as is.
+`)
+	return sb.String()
+}
+
+// buildVoteMergePrompt asks the winning candidate's worktree to fold in the
+// strengths of the others. The original phase prompt is kept so the response
+// follows the phase's usual contract.
+func buildVoteMergePrompt(phasePrompt string, winner int, notes string, candidates []*voteCandidate) string {
+	var sb strings.Builder
+	sb.WriteString(phasePrompt)
+	fmt.Fprintf(&sb, "\n\n## Voting Merge\n\nThis worktree already contains candidate %d, which was chosen as the best of several attempts at this phase. ", winner)
+	sb.WriteString("Keep it as the base and fold in the following from the other candidates. Do not start over.\n\n")
+	fmt.Fprintf(&sb, "%s\n\n", notes)
+	for _, c := range candidates {
+		if c.index == winner || strings.TrimSpace(c.diff) == "" {
+			continue
+		}
+		fmt.Fprintf(&sb, "### Candidate %d Diff\n\n```diff\n%s\n```\n\n", c.index, truncateVoteText(c.diff, voteDiffLimit))
+	}
+	return sb.String()
+}
+
+func addVoteUsage(total, r *PhaseExecutionResult) {
+	if r == nil {
+		return
+	}
+	total.InputTokens += r.InputTokens
+	total.OutputTokens += r.OutputTokens
+	total.CacheCreationTokens += r.CacheCreationTokens
+	total.CacheReadTokens += r.CacheReadTokens
+	total.CostUSD += r.CostUSD
+}
+
+func truncateVoteText(s string, limit int) string {
+	s = strings.TrimSpace(s)
+	if len(s) > limit {
+		return s[:limit] + "\n... (truncated)"
+	}
+	return s
+}
@@ -0,0 +1,220 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/git"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// setupVotingExecutor creates a repo with a task worktree and an executor
+// whose voting candidates write "answer.txt" containing their worktree name.
+func setupVotingExecutor(t *testing.T, taskID string, judge VoteJudge) (*WorkflowExecutor, *storage.DatabaseBackend) {
+	t.Helper()
+	repoDir := t.TempDir()
+	runGitCmdOrFatal(t, repoDir, "init", "--initial-branch=main")
+	runGitCmdOrFatal(t, repoDir, "config", "user.email", "test@example.com")
+	runGitCmdOrFatal(t, repoDir, "config", "user.name", "Test")
+	writeTestFile(t, repoDir, "README.md", "# Initial\n")
+	runGitCmdOrFatal(t, repoDir, "add", ".")
+	runGitCmdOrFatal(t, repoDir, "commit", "-m", "Initial commit")
+
+	gitCfg := git.DefaultConfig()
+	gitCfg.WorktreeDir = filepath.Join(repoDir, ".orc", "worktrees")
+	gitOps, err := git.New(repoDir, gitCfg)
+	if err != nil {
+		t.Fatalf("git.New: %v", err)
+	}
+	wtPath, err := gitOps.CreateWorktree(taskID, "main")
+	if err != nil {
+		t.Fatalf("create worktree: %v", err)
+	}
+
+	backend := storage.NewTestBackend(t)
+	if err := backend.SaveTask(task.NewProtoTask(taskID, "Rework storage")); err != nil {
+		t.Fatalf("save task: %v", err)
+	}
+	cfg := config.Default()
+	cfg.Voting.Enabled = true
+
+	we := NewWorkflowExecutor(backend, backend.DB(), testGlobalDBFrom(backend), cfg, repoDir,
+		WithWorkflowLogger(slog.Default()),
+		WithWorkflowGitOps(gitOps),
+		WithWorkflowVoteJudge(judge),
+	)
+	we.worktreePath = wtPath
+	we.worktreeGit = gitOps.InWorktree(wtPath)
+	we.voteCandidateRunner = func(ctx context.Context, cfg PhaseExecutionConfig, adapter ProviderAdapter) (*PhaseExecutionResult, error) {
+		name := filepath.Base(cfg.WorkingDir)
+		file := "answer.txt"
+		if strings.Contains(cfg.Prompt, "## Voting Merge") {
+			file = "merged.txt"
+		}
+		if err := os.WriteFile(filepath.Join(cfg.WorkingDir, file), []byte(name+"\n"), 0o644); err != nil {
+			return nil, err
+		}
+		return &PhaseExecutionResult{Iterations: 1, Content: "built by " + name, CostUSD: 1}, nil
+	}
+	return we, backend
+}
+
+func votingTestConfig(taskID string) PhaseExecutionConfig {
+	return PhaseExecutionConfig{Prompt: "Implement it", TaskID: taskID, PhaseID: "implement", RunID: "RUN-1"}
+}
+
+func TestExecuteVotingPhase_AdoptsJudgesPick(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var judged VoteJudgeRequest
+	judge := func(ctx context.Context, req VoteJudgeRequest) (*VoteJudgement, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		judged = req
+		return &VoteJudgement{
+			Winner:    2,
+			Rationale: "candidate 2 handles rollback",
+			Scores:    []VoteCandidateScore{{Candidate: 1, Score: 0.4}, {Candidate: 2, Score: 0.9}, {Candidate: 3, Score: 0.5}},
+		}, nil
+	}
+	we, backend := setupVotingExecutor(t, "TASK-VOTE-001", judge)
+	tsk, err := backend.LoadTask("TASK-VOTE-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := we.executeVotingPhase(context.Background(), votingTestConfig(tsk.Id), &claudeAdapter{}, tsk, "the spec", 3)
+	if err != nil {
+		t.Fatalf("executeVotingPhase: %v", err)
+	}
+
+	if len(judged.Candidates) != 3 || judged.Spec != "the spec" || judged.Model != "opus" {
+		t.Fatalf("judge request = %+v", judged)
+	}
+	for _, c := range judged.Candidates {
+		if !strings.Contains(c.Diff, "answer.txt") {
+			t.Errorf("candidate %d diff missing its change:\n%s", c.Index, c.Diff)
+		}
+	}
+	if !strings.HasSuffix(result.Content, "vote-2") || result.CostUSD != 3 {
+		t.Errorf("result = %+v, want candidate 2 content and all candidates' cost", result)
+	}
+
+	answer, err := os.ReadFile(filepath.Join(we.worktreePath, "answer.txt"))
+	if err != nil || !strings.HasSuffix(strings.TrimSpace(string(answer)), "vote-2") {
+		t.Errorf("task worktree answer = %q, %v; want candidate 2", answer, err)
+	}
+	for i := 1; i <= 3; i++ {
+		branch := "orc/TASK-VOTE-001-vote-" + string(rune('0'+i))
+		if exists, _ := we.gitOps.BranchExists(branch); exists {
+			t.Errorf("candidate branch %s not cleaned up", branch)
+		}
+		if _, err := os.Stat(we.gitOps.WorktreePathForCustomBranch(branch)); !os.IsNotExist(err) {
+			t.Errorf("candidate worktree %s not removed", branch)
+		}
+	}
+
+	votes, err := backend.DB().GetPhaseVotes(tsk.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(votes) != 1 || votes[0].Winner != 2 || votes[0].Merged || votes[0].Rationale != "candidate 2 handles rollback" {
+		t.Fatalf("votes = %+v", votes)
+	}
+	if c := votes[0].Candidates; len(c) != 3 || c[1].Score != 0.9 || c[1].CommitSHA == "" {
+		t.Errorf("recorded candidates = %+v", c)
+	}
+}
+
+func TestExecuteVotingPhase_MergesStrengthsIntoWinner(t *testing.T) {
+	t.Parallel()
+	judge := func(ctx context.Context, req VoteJudgeRequest) (*VoteJudgement, error) {
+		return &VoteJudgement{Winner: 1, Rationale: "best base", MergeNotes: "Take candidate 3's error handling."}, nil
+	}
+	we, backend := setupVotingExecutor(t, "TASK-VOTE-002", judge)
+	tsk, err := backend.LoadTask("TASK-VOTE-002")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := we.executeVotingPhase(context.Background(), votingTestConfig(tsk.Id), &claudeAdapter{}, tsk, "", 3)
+	if err != nil {
+		t.Fatalf("executeVotingPhase: %v", err)
+	}
+	if result.CostUSD != 4 {
+		t.Errorf("cost = %v, want 3 candidates plus the merge pass", result.CostUSD)
+	}
+	if _, err := os.Stat(filepath.Join(we.worktreePath, "merged.txt")); err != nil {
+		t.Errorf("merge pass output not adopted: %v", err)
+	}
+
+	votes, err := backend.DB().GetPhaseVotes(tsk.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(votes) != 1 || votes[0].Winner != 1 || !votes[0].Merged {
+		t.Fatalf("votes = %+v, want merged candidate 1", votes)
+	}
+}
+
+func TestExecuteVotingPhase_FailsWhenEveryCandidateFails(t *testing.T) {
+	t.Parallel()
+	judge := func(ctx context.Context, req VoteJudgeRequest) (*VoteJudgement, error) {
+		t.Error("judge called with no completed candidates")
+		return nil, nil
+	}
+	we, backend := setupVotingExecutor(t, "TASK-VOTE-003", judge)
+	we.voteCandidateRunner = func(ctx context.Context, cfg PhaseExecutionConfig, adapter ProviderAdapter) (*PhaseExecutionResult, error) {
+		return &PhaseExecutionResult{}, errors.New("max turns reached")
+	}
+	tsk, err := backend.LoadTask("TASK-VOTE-003")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = we.executeVotingPhase(context.Background(), votingTestConfig(tsk.Id), &claudeAdapter{}, tsk, "", 2)
+	if err == nil || !strings.Contains(err.Error(), "every candidate failed") {
+		t.Fatalf("err = %v, want every candidate failed", err)
+	}
+}
+
+func TestVotingCandidates(t *testing.T) {
+	t.Parallel()
+	we, backend := setupVotingExecutor(t, "TASK-VOTE-004", nil)
+	tsk, err := backend.LoadTask("TASK-VOTE-004")
+	if err != nil {
+		t.Fatal(err)
+	}
+	large := "implement-large"
+	tsk.WorkflowId = &large
+
+	if got := we.votingCandidates("implement", tsk); got != 3 {
+		t.Errorf("large implement = %d, want 3", got)
+	}
+	if got := we.votingCandidates("review", tsk); got != 0 {
+		t.Errorf("review = %d, want 0 (not a voting phase)", got)
+	}
+
+	medium := "implement-medium"
+	tsk.WorkflowId = &medium
+	if got := we.votingCandidates("implement", tsk); got != 0 {
+		t.Errorf("medium = %d, want 0", got)
+	}
+	tsk.Priority = task.PriorityToProto("critical")
+	if got := we.votingCandidates("implement", tsk); got != 3 {
+		t.Errorf("critical medium = %d, want 3", got)
+	}
+
+	we.worktreeGit = nil
+	if got := we.votingCandidates("implement", tsk); got != 0 {
+		t.Errorf("without worktree = %d, want 0", got)
+	}
+}
@@ -5,11 +5,13 @@ package executor
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/compliance"
 	"github.com/randalmurphal/orc/internal/config"
 	"github.com/randalmurphal/orc/internal/git"
 	"github.com/randalmurphal/orc/internal/hosting"
@@ -143,6 +145,12 @@ func (we *WorkflowExecutor) runCompletion(ctx context.Context, t *orcv1.Task) er
 		}
 	}
 
+	if err := we.checkDoneCriteria(ctx, t, gitOps, targetBranch, hasRemote); err != nil {
+		return err
+	}
+
+	we.recordCompletionSBOM(t, gitOps, targetBranch, hasRemote)
+
 	// Execute completion action
 	clearCompletionSkipMetadata(t)
 	switch action {
@@ -157,6 +165,57 @@ func (we *WorkflowExecutor) runCompletion(ctx context.Context, t *orcv1.Task) er
 	}
 }
 
+// checkDoneCriteria evaluates the workflow's done criteria against the task
+// branch and records the results in task metadata. It returns an error
+// wrapping ErrDoneCriteriaFailed when a blocking criterion fails.
+func (we *WorkflowExecutor) checkDoneCriteria(ctx context.Context, t *orcv1.Task, gitOps *git.Git, targetBranch string, hasRemote bool) error {
+	if we.wf == nil || len(we.wf.DoneCriteria) == 0 {
+		return nil
+	}
+
+	base := targetBranch
+	if hasRemote {
+		base = "origin/" + targetBranch
+	}
+	result := NewDoneCriteriaRunner(gitOps.Context(), base, we.logger).Run(ctx, we.wf.DoneCriteria)
+
+	task.EnsureMetadataProto(t)
+	if data, err := json.Marshal(result); err == nil {
+		t.Metadata["done_criteria_result"] = string(data)
+	}
+	if err := we.saveTaskStrict(t, "save done criteria result"); err != nil {
+		return err
+	}
+
+	if result.HasBlocks {
+		return fmt.Errorf("%w: %s", ErrDoneCriteriaFailed, result.FailureSummary())
+	}
+	if !result.AllPassed {
+		we.logger.Warn("done criteria warnings", "task", t.Id, "failed", len(result.Failed()))
+	}
+	return nil
+}
+
+// recordCompletionSBOM stores the task's dependency delta against the target
+// branch so the PR body and security tooling see what the task brings in.
+// Failures are logged; they never block completion.
+func (we *WorkflowExecutor) recordCompletionSBOM(t *orcv1.Task, gitOps *git.Git, targetBranch string, hasRemote bool) {
+	if !we.orcConfig.Compliance.SBOM {
+		return
+	}
+	target := targetBranch
+	if hasRemote {
+		target = "origin/" + targetBranch
+	}
+	delta, err := recordSBOMDelta(we.backend, gitOps.Context(), t.Id, target)
+	if err != nil {
+		we.logger.Warn("failed to record SBOM delta", "task", t.Id, "error", err)
+		return
+	}
+	we.logger.Info("recorded SBOM delta", "task", t.Id,
+		"added", len(delta.Added), "updated", len(delta.Updated), "removed", len(delta.Removed))
+}
+
 func clearCompletionSkipMetadata(t *orcv1.Task) {
 	if t == nil {
 		return
@@ -229,6 +288,10 @@ func (we *WorkflowExecutor) directMerge(ctx context.Context, t *orcv1.Task, gitO
 		)
 	}
 
+	if err := we.checkDirectMergeAllowed(ctx, targetBranch); err != nil {
+		return err
+	}
+
 	// Push task branch first (with force fallback for divergent history from previous runs)
 	if err := gitOps.PushWithForceFallback("origin", t.Branch, false, we.logger); err != nil {
 		return fmt.Errorf("push failed: %w", err)
@@ -254,6 +317,10 @@ func (we *WorkflowExecutor) directMerge(ctx context.Context, t *orcv1.Task, gitO
 	if err := gitOps.Push("origin", targetBranch, false); err != nil {
 		return fmt.Errorf("push target: %w", err)
 	}
+	mergeSHA, err := gitOps.Context().HeadCommit()
+	if err != nil {
+		we.logger.Warn("could not read merge commit", "error", err)
+	}
 
 	// Update task with merge info
 	t.Status = orcv1.TaskStatus_TASK_STATUS_CLOSED
@@ -263,6 +330,42 @@ func (we *WorkflowExecutor) directMerge(ctx context.Context, t *orcv1.Task, gitO
 	}
 
 	we.logger.Info("direct merge completed", "task", t.Id, "target", targetBranch)
+	we.createBackportTask(t, targetBranch, mergeSHA)
+	return nil
+}
+
+// checkDirectMergeAllowed refuses a direct merge into a branch listed in
+// completion.protected_branches and, with completion.verify_branch_protection,
+// into a branch whose remote protection would reject the push. When remote
+// protection cannot be read (no token, insufficient permissions) the merge
+// proceeds and the push itself reports any rejection.
+func (we *WorkflowExecutor) checkDirectMergeAllowed(ctx context.Context, targetBranch string) error {
+	if we.orcConfig.IsProtectedBranch(targetBranch) {
+		return &DirectMergeBlockedError{
+			Branch:  targetBranch,
+			Source:  MergeBlockedByConfig,
+			Reasons: []string{"listed in completion.protected_branches"},
+		}
+	}
+	if !we.orcConfig.Completion.VerifyBranchProtection {
+		return nil
+	}
+
+	provider, err := we.getHostingProvider()
+	if err != nil {
+		we.logger.Warn("cannot verify remote branch protection, continuing with direct merge",
+			"target", targetBranch, "error", err)
+		return nil
+	}
+	protection, err := provider.GetBranchProtection(ctx, targetBranch)
+	if err != nil {
+		we.logger.Warn("cannot read remote branch protection, continuing with direct merge",
+			"target", targetBranch, "error", err)
+		return nil
+	}
+	if blockers := protection.DirectPushBlockers(); len(blockers) > 0 {
+		return &DirectMergeBlockedError{Branch: targetBranch, Source: MergeBlockedByRemote, Reasons: blockers}
+	}
 	return nil
 }
 
@@ -319,6 +422,12 @@ func (we *WorkflowExecutor) createPR(ctx context.Context, t *orcv1.Task, gitOps
 	description := task.GetDescriptionProto(t)
 	body := fmt.Sprintf("## Task: %s\n\n%s\n\n---\nCreated by orc workflow execution.",
 		t.Title, description)
+	if task.GetTargetBranchProto(t) != "" {
+		body += fmt.Sprintf("\nTargets `%s` (task override).", targetBranch)
+	}
+	if summary := compliance.FormatDeltaSummary(LoadSBOMDelta(we.backend, t.Id)); summary != "" {
+		body += "\n\n" + summary
+	}
 	prTitle := fmt.Sprintf("[orc] %s: %s", t.Id, t.Title)
 
 	// Check if an open PR already exists on this branch (handles stale/orphaned PRs)
@@ -442,6 +551,9 @@ func (we *WorkflowExecutor) setupWorktree(t *orcv1.Task) error {
 		logMsg = "reusing existing worktree"
 	}
 	we.logger.Info(logMsg, "task", t.Id, "path", result.Path, "target_branch", result.TargetBranch, "branch", t.Branch)
+	if hooks := we.worktreeGit.Context().BypassedHooks(); len(hooks) > 0 {
+		we.logger.Info("git hooks bypassed for task commits", "task", t.Id, "hooks", hooks)
+	}
 
 	// Update the resolver to use worktree path
 	we.resolver = variable.NewResolver(result.Path)
@@ -752,11 +864,15 @@ func (we *WorkflowExecutor) autoCommitBeforeCompletion(gitOps *git.Git, t *orcv1
 		return nil // Clean worktree, nothing to commit
 	}
 
+	ctx := gitOps.Context()
+	if err := we.guardSensitivePaths(ctx.WorkDir()); err != nil {
+		return err
+	}
+
 	we.logger.Info("uncommitted changes detected, auto-committing",
 		"task", t.Id)
 
 	// Stage all changes
-	ctx := gitOps.Context()
 	if _, err := ctx.RunGit("add", "-A"); err != nil {
 		return fmt.Errorf("stage changes: %w", err)
 	}
@@ -2,6 +2,7 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
 	"os/exec"
@@ -13,6 +14,7 @@ import (
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
 	"github.com/randalmurphal/orc/internal/config"
 	"github.com/randalmurphal/orc/internal/git"
+	"github.com/randalmurphal/orc/internal/hosting"
 	"github.com/randalmurphal/orc/internal/storage"
 	"github.com/randalmurphal/orc/internal/task"
 	"github.com/randalmurphal/orc/internal/workflow"
@@ -761,3 +763,56 @@ func TestRunCompletion_CommitActionWithoutRemoteKeepsLocalBranch(t *testing.T) {
 		t.Fatalf("completion_note = %q, want local-only preservation note", tsk.Metadata["completion_note"])
 	}
 }
+
+func TestCheckDirectMergeAllowed(t *testing.T) {
+	t.Parallel()
+
+	newExecutor := func(provider *mockProvider) *WorkflowExecutor {
+		cfg := config.Default()
+		cfg.Completion.ProtectedBranches = []string{"production"}
+		return &WorkflowExecutor{orcConfig: cfg, logger: slog.Default(), hostingProvider: provider}
+	}
+
+	t.Run("config protected branch", func(t *testing.T) {
+		err := newExecutor(&mockProvider{}).checkDirectMergeAllowed(context.Background(), "production")
+		var blocked *DirectMergeBlockedError
+		if !errors.As(err, &blocked) || blocked.Source != MergeBlockedByConfig {
+			t.Fatalf("err = %v, want config DirectMergeBlockedError", err)
+		}
+		if !errors.Is(err, ErrDirectMergeBlocked) {
+			t.Error("DirectMergeBlockedError should match ErrDirectMergeBlocked")
+		}
+	})
+
+	t.Run("remote protection rejects push", func(t *testing.T) {
+		provider := &mockProvider{branchProtection: &hosting.BranchProtection{
+			Branch: "main", Protected: true, RequiredReviews: 2, RequiredStatusChecks: []string{"ci"},
+		}}
+		err := newExecutor(provider).checkDirectMergeAllowed(context.Background(), "main")
+		var blocked *DirectMergeBlockedError
+		if !errors.As(err, &blocked) {
+			t.Fatalf("err = %v, want DirectMergeBlockedError", err)
+		}
+		if blocked.Source != MergeBlockedByRemote || len(blocked.Reasons) != 2 {
+			t.Errorf("source = %s, reasons = %v", blocked.Source, blocked.Reasons)
+		}
+		if !strings.Contains(err.Error(), "requires 2 approving review(s)") {
+			t.Errorf("error should describe the rule, got %q", err)
+		}
+	})
+
+	t.Run("verification disabled", func(t *testing.T) {
+		we := newExecutor(&mockProvider{branchProtection: &hosting.BranchProtection{Protected: true, Locked: true}})
+		we.orcConfig.Completion.VerifyBranchProtection = false
+		if err := we.checkDirectMergeAllowed(context.Background(), "main"); err != nil {
+			t.Errorf("err = %v, want nil", err)
+		}
+	})
+
+	t.Run("provider error does not block", func(t *testing.T) {
+		we := newExecutor(&mockProvider{branchProtErr: errors.New("forbidden")})
+		if err := we.checkDirectMergeAllowed(context.Background(), "main"); err != nil {
+			t.Errorf("err = %v, want nil", err)
+		}
+	})
+}
@@ -118,6 +118,7 @@ func (we *WorkflowExecutor) buildResolutionContext(
 
 	// Load project detection from database
 	we.loadProjectDetectionContext(rctx)
+	we.loadProjectCommandContext(rctx)
 
 	// Set testing configuration from orc config
 	if we.orcConfig != nil {
@@ -249,7 +250,7 @@ func (we *WorkflowExecutor) loadAndFormatInitiativeNotes(initiativeID string) st
 // It reads from both the legacy detection table and the newer project_languages table.
 // project_languages is the authoritative source for build_command and per-language data.
 func (we *WorkflowExecutor) loadProjectDetectionContext(rctx *variable.ResolutionContext) {
-	dbBackend, ok := we.backend.(*storage.DatabaseBackend)
+	dbBackend, ok := storage.DatabaseOf(we.backend)
 	if !ok {
 		return
 	}
@@ -326,6 +327,12 @@ func (we *WorkflowExecutor) enrichContextForPhase(
 	// Generate project brief from task history
 	we.populateProjectBrief(rctx)
 
+	// Project context documents that apply to this phase
+	we.populateContextDocuments(rctx, phaseID)
+
+	// Outcomes of recent tasks that touched the same files or packages
+	we.populateRelatedTasks(rctx, phaseID, t)
+
 	// Load scratchpad entries from prior phases for PREV_SCRATCHPAD
 	we.populateScratchpadContext(rctx, t.Id, phaseID)
 
@@ -880,9 +887,9 @@ func indexedArtifactOmissionLine(omitted int) string {
 }
 
 // populateProjectBrief generates a project brief and populates rctx.ProjectBrief.
-// Requires *storage.DatabaseBackend — silently skips for other backend types.
+// Requires a *storage.DatabaseBackend (see storage.DatabaseOf) — silently skips for other backend types.
 func (we *WorkflowExecutor) populateProjectBrief(rctx *variable.ResolutionContext) {
-	dbBackend, ok := we.backend.(*storage.DatabaseBackend)
+	dbBackend, ok := storage.DatabaseOf(we.backend)
 	if !ok {
 		return
 	}
@@ -10,13 +10,16 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"runtime/debug"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
 	"github.com/randalmurphal/orc/internal/automation"
+	"github.com/randalmurphal/orc/internal/breaker"
 	"github.com/randalmurphal/orc/internal/brief"
 	"github.com/randalmurphal/orc/internal/config"
 	"github.com/randalmurphal/orc/internal/controlplane"
@@ -27,6 +30,7 @@ import (
 	"github.com/randalmurphal/orc/internal/hosting"
 	"github.com/randalmurphal/orc/internal/storage"
 	"github.com/randalmurphal/orc/internal/task"
+	"github.com/randalmurphal/orc/internal/tokenpool"
 	"github.com/randalmurphal/orc/internal/trigger"
 	"github.com/randalmurphal/orc/internal/variable"
 	"github.com/randalmurphal/orc/internal/workflow"
@@ -171,15 +175,22 @@ type WorkflowExecutor struct {
 	heartbeat    *HeartbeatRunner
 	idleGuard    *IdleGuard
 	fileWatcher  *FileWatcher
-	isResuming   bool   // True if resuming a paused/failed/blocked task
-	skipGates    bool   // When true, bypass all gate evaluations
-	runProvider  string // Run-level provider override (from WorkflowRunOptions.Provider)
+	isResuming   bool          // True if resuming a paused/failed/blocked task
+	taskTimeout  time.Duration // Whole-task limit armed for this run (timeouts.task_max)
+	taskTimedOut atomic.Bool   // Set when taskTimeout expired and cancelled the run
+	skipGates    bool          // When true, bypass all gate evaluations
+	ignoreBudget bool          // When true, skip task and initiative budget checks
+	runProvider  string        // Run-level provider override (from WorkflowRunOptions.Provider)
 
 	// briefGenerator is lazily created for project brief generation across phases.
 	briefGenerator *brief.Generator
 
 	// turnExecutor is injected for testing to avoid spawning real Claude CLI.
 	turnExecutor TurnExecutor
+	// fakeModel answers phases from fixtures when config sets fake_model;
+	// fakeModelErr is why loading them failed, reported when a phase runs.
+	fakeModel    *FakeModel
+	fakeModelErr error
 
 	// triggerRunner evaluates before-phase and lifecycle triggers.
 	triggerRunner trigger.Runner
@@ -187,6 +198,22 @@ type WorkflowExecutor struct {
 	// completionRecommendationGenerator allows tests to override completion recommendation handling.
 	completionRecommendationGenerator CompletionRecommendationGenerator
 
+	// confidenceScorer rates completed phases (nil = validation model)
+	confidenceScorer PhaseConfidenceScorer
+	// phaseConfidence holds this run's latest confidence score per phase
+	phaseConfidence map[string]*db.PhaseConfidence
+	// traceabilityMapper judges traceability reports (nil = validation model)
+	traceabilityMapper TraceabilityMapper
+	// voteJudge compares voting candidates (nil = voting.judge_model)
+	voteJudge VoteJudge
+
+	// tokenPool supplies the OAuth account for Claude phases (nil = pool disabled)
+	tokenPool *tokenpool.Pool
+	// voteCandidateRunner runs one voting candidate (nil = runVoteCandidate)
+	voteCandidateRunner voteCandidateRunner
+	// reviewChunkRunner reviews one chunk of a large diff (nil = runVoteCandidate)
+	reviewChunkRunner voteCandidateRunner
+
 	// phaseTypeRegistry maps type strings to PhaseTypeExecutor implementations.
 	phaseTypeRegistry *PhaseTypeRegistry
 
@@ -412,6 +439,17 @@ func NewWorkflowExecutor(
 		opt(we)
 	}
 
+	if orcConfig != nil && orcConfig.FakeModel != "" && we.turnExecutor == nil {
+		dir := orcConfig.FakeModel
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(workingDir, dir)
+		}
+		we.fakeModel, we.fakeModelErr = LoadFakeModel(dir)
+		if we.fakeModel != nil {
+			we.gateEvaluator = we.fakeModel.GateEvaluator(we.gateEvaluator)
+		}
+	}
+
 	// Ensure phase type registry is initialized
 	if we.phaseTypeRegistry == nil {
 		we.phaseTypeRegistry = NewDefaultPhaseTypeRegistry()
@@ -421,6 +459,8 @@ func NewWorkflowExecutor(
 	if we.knowledgeService != nil {
 		we.phaseTypeRegistry.Register("knowledge", NewKnowledgePhaseExecutor(we.knowledgeService))
 	}
+	we.phaseTypeRegistry.Register("compliance", &compliancePhaseExecutor{we: we})
+	we.loadTokenPool()
 
 	return we
 }
@@ -482,6 +522,12 @@ func (we *WorkflowExecutor) Run(ctx context.Context, workflowID string, opts Wor
 	if err := we.checkBudget(opts.IgnoreBudget); err != nil {
 		return nil, err
 	}
+	we.ignoreBudget = opts.IgnoreBudget
+
+	// Don't start a task the database can't currently persist
+	if err := breaker.Get(breaker.Database).Check(); err != nil {
+		return nil, err
+	}
 
 	// Load workflow from database
 	wf, err := we.globalDB.GetWorkflow(workflowID)
@@ -574,6 +620,11 @@ func (we *WorkflowExecutor) Run(ctx context.Context, workflowID string, opts Wor
 		// Store task reference - execution state is in t.Execution
 		we.task = t
 
+		// Don't start a task that is already over its own or its initiative's budget
+		if err := we.checkTaskBudget(0); err != nil {
+			return nil, err
+		}
+
 		// Claim task for current user before any execution starts
 		if userID, ok := UserIDFromContext(ctx); ok && userID != "" {
 			claimed, claimErr := we.backend.ClaimTaskByUser(t.Id, userID)
@@ -662,8 +713,17 @@ func (we *WorkflowExecutor) Run(ctx context.Context, workflowID string, opts Wor
 		}
 	}()
 
+	// Enforce the whole-task wall-clock limit (timeouts.task_max)
+	if t != nil {
+		defer we.startTaskTimer(t, execCancel)()
+	}
+
 	// Setup worktree for task-based contexts
 	if t != nil && we.orcConfig.Worktree.Enabled && we.gitOps != nil {
+		// Fail fast on repository problems before touching worktrees
+		if err := CheckRepoPreflight(we.gitOps, we.orcConfig.Worktree.Preflight, we.resolveTargetBranch(t)); err != nil {
+			return nil, combineExecutionErrors(err, we.failSetup(run, t, err))
+		}
 		if err := we.setupWorktree(t); err != nil {
 			return nil, combineExecutionErrors(fmt.Errorf("setup worktree: %w", err), we.failSetup(run, t, err))
 		}
@@ -700,6 +760,9 @@ func (we *WorkflowExecutor) Run(ctx context.Context, workflowID string, opts Wor
 		return nil, err
 	}
 
+	// Fold feedback given since the last run into the lessons document
+	we.refreshLessons()
+
 	// Build resolution context
 	rctx := we.buildResolutionContext(opts, t, wf, run)
 
@@ -1025,6 +1088,10 @@ func (we *WorkflowExecutor) Run(ctx context.Context, workflowID string, opts Wor
 				if execCtx.Err() != nil {
 					return result, combineExecutionErrors(err, we.interruptRun(run, t, phase.PhaseTemplateID, execCtx.Err()))
 				}
+				// Over budget: pause so the task resumes once the budget is raised
+				if IsTaskBudgetError(err) {
+					return result, combineExecutionErrors(err, we.interruptRun(run, t, phase.PhaseTemplateID, err))
+				}
 				return result, combineExecutionErrors(err, we.failRun(run, t, err))
 			}
 		}
@@ -1237,6 +1304,13 @@ func (we *WorkflowExecutor) Run(ctx context.Context, workflowID string, opts Wor
 			return result, combineExecutionErrors(blockErr, persistErr)
 		}
 
+		// Score the completed phase so a low-confidence result can escalate its
+		// gate, and trace the spec's success criteria to the changes so far
+		if phaseResult.BlockedReason == "" {
+			we.scorePhaseConfidence(ctx, t, tmpl.ID, phaseResult.Content)
+			we.buildTraceabilityReport(ctx, t, run.ID, tmpl.ID, phaseResult.Content)
+		}
+
 		// Evaluate phase gate
 		gateResult, gateErr := we.evaluatePhaseGate(ctx, tmpl, phase, phaseResult.Content, t, rctx)
 		if gateErr != nil {
@@ -1338,6 +1412,32 @@ func (we *WorkflowExecutor) Run(ctx context.Context, workflowID string, opts Wor
 						retryMax = maxRetries
 					}
 
+					// Retries exhausted: step back and revise the plan instead of
+					// giving up, then restart the retry budget under the new plan.
+					replanned := ""
+					if gateResult.RetryPhase != "" && retryCount >= retryMax && we.task != nil && we.replanAvailable(we.task) {
+						plan, replanErr := we.replan(ctx, we.task, tmpl, phase, run.ID, replanRequest{
+							FailedPhase: tmpl.ID,
+							RetryFrom:   gateResult.RetryPhase,
+							Reason:      gateResult.Reason,
+							Output:      phaseResult.Content,
+							Attempts:    retryCount,
+							Spec:        vars["SPEC_CONTENT"],
+						})
+						if replanErr != nil {
+							we.logger.Warn("re-planning failed, giving up on retries",
+								"phase", tmpl.ID, "error", replanErr)
+						} else {
+							we.logger.Info("phase re-planned after exhausting retries",
+								"phase", tmpl.ID,
+								"retry_from", gateResult.RetryPhase,
+								"attempts", retryCount,
+							)
+							replanned = replanRetryReason(tmpl.ID, retryCount, plan)
+							retryCount = 0
+						}
+					}
+
 					// SC-2/SC-3: explicit retry action — validate preconditions
 					if rejectedAction == workflow.GateActionRetry {
 						if gateResult.RetryPhase == "" {
@@ -1388,6 +1488,9 @@ func (we *WorkflowExecutor) Run(ctx context.Context, workflowID string, opts Wor
 
 							if we.task != nil {
 								reason := fmt.Sprintf("Gate rejected for phase %s: %s", tmpl.ID, gateResult.Reason)
+								if replanned != "" {
+									reason = replanned
+								}
 								task.SetRetryState(we.task, tmpl.ID, gateResult.RetryPhase, reason, phaseResult.Content, int32(retryCount))
 								if err := we.saveTaskStrict(we.task, "save retry state"); err != nil {
 									return result, combineExecutionErrors(err, we.failRun(run, t, err))
@@ -1405,6 +1508,18 @@ func (we *WorkflowExecutor) Run(ctx context.Context, workflowID string, opts Wor
 								}
 							}
 
+							// retry.replan.gate=human: hold the revised plan for review.
+							// Resuming the task approves it and continues from the retry phase.
+							if replanned != "" && we.replanRequiresApproval() {
+								reason := fmt.Sprintf("re-planned after phase %s exhausted its retries; review the plan and resume to continue", tmpl.ID)
+								blockErr := fmt.Errorf("%w: %s", ErrTaskBlocked, reason)
+								persistErr := we.finalizeBlockedRun(run, t, reason, "replan_review")
+								result.Success = false
+								result.Error = reason
+								result.CompletedAt = run.CompletedAt
+								return result, combineExecutionErrors(blockErr, persistErr)
+							}
+
 							i = retryIdx - 1 // Will be incremented by loop
 							continue
 						}
@@ -1489,12 +1604,18 @@ func (we *WorkflowExecutor) Run(ctx context.Context, workflowID string, opts Wor
 		}
 	}
 
+	// Record the files the task changed before completion merges or syncs them
+	if t != nil {
+		we.recordChangedFiles(t)
+	}
+
 	// Run completion action (sync, PR/merge) for task-based contexts
 	if t != nil && we.gitOps != nil {
 		completionErr := we.runCompletion(execCtx, t)
 		if completionErr != nil {
 			// Check if it's a conflict or merge error
-			if errors.Is(completionErr, ErrSyncConflict) || errors.Is(completionErr, ErrMergeFailed) {
+			if errors.Is(completionErr, ErrSyncConflict) || errors.Is(completionErr, ErrMergeFailed) ||
+				errors.Is(completionErr, ErrDirectMergeBlocked) || errors.Is(completionErr, ErrDoneCriteriaFailed) {
 				we.logger.Error("completion failed",
 					"task", t.Id,
 					"error", completionErr)
@@ -1502,9 +1623,14 @@ func (we *WorkflowExecutor) Run(ctx context.Context, workflowID string, opts Wor
 				// Mark task as blocked, not completed
 				t.Status = orcv1.TaskStatus_TASK_STATUS_BLOCKED
 				task.EnsureMetadataProto(t)
-				if errors.Is(completionErr, ErrSyncConflict) {
+				switch {
+				case errors.Is(completionErr, ErrSyncConflict):
 					t.Metadata["blocked_reason"] = "sync_conflict"
-				} else {
+				case errors.Is(completionErr, ErrDirectMergeBlocked):
+					t.Metadata["blocked_reason"] = "direct_merge_blocked"
+				case errors.Is(completionErr, ErrDoneCriteriaFailed):
+					t.Metadata["blocked_reason"] = "done_criteria_failed"
+				default:
 					t.Metadata["blocked_reason"] = "merge_failed"
 				}
 				t.Metadata["blocked_error"] = completionErr.Error()
@@ -1608,6 +1734,12 @@ func (we *WorkflowExecutor) Run(ctx context.Context, workflowID string, opts Wor
 				"run_id", run.ID,
 				"error", err)
 		}
+		if err := we.processFollowUps(execCtx, run, t); err != nil {
+			we.logger.Warn("failed to process follow-ups",
+				"task_id", t.Id,
+				"run_id", run.ID,
+				"error", err)
+		}
 		if err := we.indexTaskOutcomes(t, run); err != nil {
 			we.logger.Warn("failed to index task outcomes",
 				"task_id", t.Id,
@@ -0,0 +1,123 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/gate"
+)
+
+// gateApprovalPollInterval is how often an executor waiting at a human gate
+// checks its gate approval.
+var gateApprovalPollInterval = 2 * time.Second
+
+// openGateApproval returns the gate approval an earlier run of the phase
+// left unapplied, re-listing it as a pending decision while it is pending.
+func (we *WorkflowExecutor) openGateApproval(t *orcv1.Task, phase string) *db.GateApproval {
+	if t == nil || we.projectDB == nil {
+		return nil
+	}
+	approval, err := we.projectDB.GetOpenGateApproval(t.Id, phase)
+	if err != nil {
+		we.logger.Warn("failed to load gate approval", "task", t.Id, "phase", phase, "error", err)
+		return nil
+	}
+	if approval != nil && approval.Status == db.GateApprovalPending && we.pendingDecisions != nil {
+		if _, ok := we.pendingDecisions.Get(we.projectIDForEvents(), approval.ID); !ok {
+			_ = we.pendingDecisions.Add(&gate.PendingDecision{
+				ProjectID:   we.projectIDForEvents(),
+				DecisionID:  approval.ID,
+				TaskID:      t.Id,
+				TaskTitle:   t.Title,
+				Phase:       phase,
+				GateType:    approval.GateType,
+				Question:    approval.Question,
+				Context:     approval.Context,
+				RequestedAt: approval.RequestedAt,
+			})
+		}
+	}
+	return approval
+}
+
+// awaitGateApproval blocks on a pending human gate decision until its gate
+// approval is approved or rejected from any channel (API, CLI, decision
+// UI) or by gates.timeout, then updates decision with the outcome. open is
+// an approval left by an earlier run; otherwise one is created for the
+// decision. The decision stays pending when there is no project database or
+// ctx ends first, so the run blocks and the approval is applied when the
+// task resumes.
+func (we *WorkflowExecutor) awaitGateApproval(ctx context.Context, t *orcv1.Task, phase string, open *db.GateApproval, decision *gate.Decision) error {
+	if t == nil || we.projectDB == nil || (open == nil && decision.DecisionID == "") {
+		return nil
+	}
+	approval := open
+	if approval == nil {
+		approval = &db.GateApproval{ID: decision.DecisionID, TaskID: t.Id, Phase: phase, GateType: string(gate.GateHuman)}
+		if we.pendingDecisions != nil {
+			if pending, ok := we.pendingDecisions.Get(we.projectIDForEvents(), decision.DecisionID); ok {
+				approval.Question, approval.Context = pending.Question, pending.Context
+			}
+		}
+		if err := we.projectDB.CreateGateApproval(approval); err != nil {
+			return fmt.Errorf("record gate approval: %w", err)
+		}
+	}
+
+	if approval.Status == db.GateApprovalPending {
+		we.logger.Info("waiting for gate approval", "task", t.Id, "phase", phase, "approval", approval.ID)
+	}
+	ticker := time.NewTicker(gateApprovalPollInterval)
+	defer ticker.Stop()
+	for approval.Status == db.GateApprovalPending {
+		select {
+		case <-ctx.Done():
+			decision.Pending, decision.DecisionID = true, approval.ID
+			return nil
+		case <-ticker.C:
+		}
+		current, err := we.projectDB.GetGateApproval(approval.ID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return fmt.Errorf("gate approval %s no longer exists", approval.ID)
+		}
+		if approval, err = we.escalateGateTimeout(t, current, time.Now()); err != nil {
+			return fmt.Errorf("escalate gate timeout: %w", err)
+		}
+	}
+
+	if err := we.projectDB.MarkGateApprovalApplied(approval.ID); err != nil {
+		return err
+	}
+	if we.pendingDecisions != nil {
+		we.pendingDecisions.Remove(we.projectIDForEvents(), approval.ID)
+	}
+	decision.Pending = false
+	decision.DecisionID = approval.ID
+	decision.Approved = approval.Status == db.GateApprovalApproved
+	decision.Reason = approval.Comment
+	if decision.Reason == "" {
+		decision.Reason = fmt.Sprintf("%s by %s", approval.Status, firstNonEmpty(approval.ResolvedBy, "human"))
+	}
+	resolvedAt := time.Now()
+	if approval.ResolvedAt != nil {
+		resolvedAt = *approval.ResolvedAt
+	}
+	we.publisher.DecisionResolved(t.Id, events.DecisionResolvedData{
+		DecisionID: approval.ID,
+		TaskID:     t.Id,
+		Phase:      phase,
+		Approved:   decision.Approved,
+		Reason:     approval.Comment,
+		ResolvedBy: approval.ResolvedBy,
+		ResolvedAt: resolvedAt,
+	})
+	we.logger.Info("gate approval resolved", "task", t.Id, "phase", phase, "approved", decision.Approved, "by", approval.ResolvedBy)
+	return nil
+}
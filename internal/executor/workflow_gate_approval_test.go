@@ -0,0 +1,161 @@
+package executor
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+func newGateApprovalTestExecutor(t *testing.T) (*WorkflowExecutor, storage.Backend, *loopTestPublisher) {
+	t.Helper()
+	backend, err := storage.NewDatabaseBackend(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("create backend: %v", err)
+	}
+	t.Cleanup(func() { _ = backend.Close() })
+
+	mockPub := newLoopTestPublisher()
+	we := NewWorkflowExecutor(
+		backend, backend.DB(), testGlobalDBFrom(backend), &config.Config{}, t.TempDir(),
+		WithWorkflowLogger(slog.Default()),
+		WithWorkflowPublisher(mockPub),
+	)
+	return we, backend, mockPub
+}
+
+func TestEvaluatePhaseGate_HumanGateWaitsForApproval(t *testing.T) {
+	orig := gateApprovalPollInterval
+	gateApprovalPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { gateApprovalPollInterval = orig })
+
+	we, backend, mockPub := newGateApprovalTestExecutor(t)
+	tsk := task.NewProtoTask("TASK-GATE-APPROVAL-001", "Wait for gate approval")
+	tsk.Status = orcv1.TaskStatus_TASK_STATUS_RUNNING
+	if err := backend.SaveTask(tsk); err != nil {
+		t.Fatalf("save task: %v", err)
+	}
+
+	pdb := backend.DB()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			pending, err := pdb.ListPendingGateApprovals()
+			if err == nil && len(pending) == 1 {
+				if _, err := pdb.ResolveGateApproval(pending[0].ID, true, "ship it", "api"); err != nil {
+					t.Errorf("resolve gate approval: %v", err)
+				}
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	tmpl := &db.PhaseTemplate{ID: "plan", GateType: "human"}
+	phase := &db.WorkflowPhase{WorkflowID: "wf-001", PhaseTemplateID: "plan"}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	result, err := we.evaluatePhaseGate(ctx, tmpl, phase, `{"status":"complete"}`, tsk)
+	<-done
+	if err != nil {
+		t.Fatalf("evaluatePhaseGate error: %v", err)
+	}
+	if result.Pending || !result.Approved {
+		t.Fatalf("gate result = %+v, want approved", result)
+	}
+	if result.Reason != "ship it" {
+		t.Errorf("reason = %q, want %q", result.Reason, "ship it")
+	}
+
+	open, err := pdb.GetOpenGateApproval(tsk.Id, "plan")
+	if err != nil {
+		t.Fatalf("get open gate approval: %v", err)
+	}
+	if open != nil {
+		t.Errorf("gate approval should be applied, got open %+v", open)
+	}
+	if n := len(we.pendingDecisions.List(we.projectIDForEvents())); n != 0 {
+		t.Errorf("pending decisions = %d, want 0", n)
+	}
+
+	foundResolved := false
+	for _, ev := range mockPub.events {
+		if ev.Type == events.EventDecisionResolved && ev.TaskID == tsk.Id {
+			foundResolved = true
+		}
+	}
+	if !foundResolved {
+		t.Error("expected decision_resolved event")
+	}
+}
+
+func TestEvaluatePhaseGate_ResumeAppliesResolvedApproval(t *testing.T) {
+	t.Parallel()
+	we, backend, _ := newGateApprovalTestExecutor(t)
+	tsk := task.NewProtoTask("TASK-GATE-APPROVAL-002", "Resume after gate rejection")
+	if err := backend.SaveTask(tsk); err != nil {
+		t.Fatalf("save task: %v", err)
+	}
+
+	// A rejection that arrived while no executor was waiting.
+	pdb := backend.DB()
+	if err := pdb.CreateGateApproval(&db.GateApproval{ID: "gate-resume", TaskID: tsk.Id, Phase: "plan", GateType: "human"}); err != nil {
+		t.Fatalf("create gate approval: %v", err)
+	}
+	if _, err := pdb.ResolveGateApproval("gate-resume", false, "needs work", "cli"); err != nil {
+		t.Fatalf("resolve gate approval: %v", err)
+	}
+
+	tmpl := &db.PhaseTemplate{ID: "plan", GateType: "human"}
+	phase := &db.WorkflowPhase{WorkflowID: "wf-001", PhaseTemplateID: "plan"}
+	result, err := we.evaluatePhaseGate(context.Background(), tmpl, phase, `{"status":"complete"}`, tsk)
+	if err != nil {
+		t.Fatalf("evaluatePhaseGate error: %v", err)
+	}
+	if result.Pending || result.Approved {
+		t.Fatalf("gate result = %+v, want rejected", result)
+	}
+	if result.Reason != "needs work" {
+		t.Errorf("reason = %q, want %q", result.Reason, "needs work")
+	}
+	if open, _ := pdb.GetOpenGateApproval(tsk.Id, "plan"); open != nil {
+		t.Errorf("gate approval should be applied, got open %+v", open)
+	}
+}
+
+func TestEvaluatePhaseGate_HumanGateStaysPendingOnCancel(t *testing.T) {
+	t.Parallel()
+	we, backend, _ := newGateApprovalTestExecutor(t)
+	tsk := task.NewProtoTask("TASK-GATE-APPROVAL-003", "Cancel while waiting")
+	if err := backend.SaveTask(tsk); err != nil {
+		t.Fatalf("save task: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tmpl := &db.PhaseTemplate{ID: "plan", GateType: "human"}
+	phase := &db.WorkflowPhase{WorkflowID: "wf-001", PhaseTemplateID: "plan"}
+	result, err := we.evaluatePhaseGate(ctx, tmpl, phase, `{"status":"complete"}`, tsk)
+	if err != nil {
+		t.Fatalf("evaluatePhaseGate error: %v", err)
+	}
+	if !result.Pending {
+		t.Fatalf("gate result = %+v, want pending", result)
+	}
+
+	pending, err := backend.DB().ListPendingGateApprovals()
+	if err != nil {
+		t.Fatalf("list pending gate approvals: %v", err)
+	}
+	if len(pending) != 1 || pending[0].TaskID != tsk.Id || pending[0].Phase != "plan" {
+		t.Fatalf("pending gate approvals = %+v, want one for %s/plan", pending, tsk.Id)
+	}
+}
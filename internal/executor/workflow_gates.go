@@ -49,6 +49,7 @@ func (we *WorkflowExecutor) evaluatePhaseGate(ctx context.Context, tmpl *db.Phas
 
 	// Use gate resolver if available, fall back to legacy resolution
 	gateType := we.resolveGateType(tmpl, phase, t, rctx)
+	gateType = we.escalateGateForConfidence(tmpl.ID, gateType)
 
 	// No gate type configured: auto-approve without evaluation
 	if gateType == "" {
@@ -114,9 +115,25 @@ func (we *WorkflowExecutor) evaluatePhaseGate(ctx context.Context, tmpl *db.Phas
 		opts.TaskWeight = task.GetWorkflowIDProto(t) // Use workflow ID for gate context
 	}
 
-	decision, err := we.gateEvaluator.EvaluateWithOptions(ctx, g, output, opts)
-	if err != nil {
-		return nil, fmt.Errorf("gate evaluation: %w", err)
+	// A human gate left open by an earlier run is waited on (or applied)
+	// rather than requested again
+	var open *db.GateApproval
+	if gateType == gate.GateHuman {
+		open = we.openGateApproval(t, tmpl.ID)
+	}
+	var decision *gate.Decision
+	if open != nil {
+		decision = &gate.Decision{Pending: true, Reason: "awaiting approval", DecisionID: open.ID}
+	} else {
+		var err error
+		if decision, err = we.gateEvaluator.EvaluateWithOptions(ctx, g, output, opts); err != nil {
+			return nil, fmt.Errorf("gate evaluation: %w", err)
+		}
+	}
+	if decision.Pending {
+		if err := we.awaitGateApproval(ctx, t, tmpl.ID, open, decision); err != nil {
+			return nil, fmt.Errorf("await gate approval: %w", err)
+		}
 	}
 
 	result.Approved = decision.Approved
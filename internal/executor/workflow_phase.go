@@ -237,6 +237,7 @@ func (we *WorkflowExecutor) executePhase(
 	}
 
 	startTime := time.Now()
+	defer func() { we.recordPhaseTiming(t, tmpl.ID, run, result.Status, startTime) }()
 
 	// Update phase status
 	runPhase.Status = orcv1.PhaseStatus_PHASE_STATUS_PENDING.String()
@@ -402,7 +403,7 @@ func (we *WorkflowExecutor) executePhase(
 	}
 
 	// Render template with variables
-	renderedPrompt := variable.RenderTemplate(promptContent, vars)
+	renderedPrompt := withAutoContext(variable.RenderTemplate(promptContent, vars), promptContent, vars)
 
 	// Determine model (workflow phase override > template default > config default)
 	model, err := we.resolvePhaseModel(tmpl, phase)
@@ -459,12 +460,11 @@ func (we *WorkflowExecutor) executePhase(
 	var preparedRuntime *llmkit.PreparedRuntime
 	if we.worktreePath != "" && we.globalDB != nil {
 		baseCfg := &WorktreeBaseConfig{
-			WorktreePath: we.worktreePath,
-			MainRepoPath: we.workingDir,
-			TaskID:       rctx.TaskID,
-			AdditionalEnv: map[string]string{
-				"ORC_TASK_ID": rctx.TaskID,
-			},
+			WorktreePath:  we.worktreePath,
+			MainRepoPath:  we.workingDir,
+			TaskID:        rctx.TaskID,
+			AdditionalEnv: we.agentEnv(rctx.TaskID),
+			Phase:         tmpl.ID,
 		}
 		preparedRuntime, err = PreparePhaseRuntime(ctx, provider, we.worktreePath, runtimeConfig, baseCfg, we.globalDB, we.globalDB)
 		if err != nil {
@@ -557,7 +557,17 @@ func (we *WorkflowExecutor) executePhase(
 	// Execute with provider-specific adapter
 	adapter := providerAdapterFor(provider)
 	var execResult *PhaseExecutionResult
-	execResult, err = we.executeWithProvider(ctx, execConfig, adapter)
+	if chunks := we.reviewChunks(ctx, execConfig); len(chunks) > 1 {
+		execResult, err = we.executeChunkedReview(ctx, execConfig, adapter, chunks)
+	} else if candidates := we.votingCandidates(tmpl.ID, t); candidates > 1 {
+		execResult, err = we.executeVotingPhase(ctx, execConfig, adapter, t, vars["SPEC_CONTENT"], candidates)
+	} else {
+		execResult, err = we.executeWithTokenPool(ctx, execConfig, adapter)
+	}
+	if err == nil {
+		// Refuse to checkpoint a phase whose changes touch protected paths.
+		err = we.guardSensitivePaths(execConfig.WorkingDir)
+	}
 
 	if err != nil {
 		result.Status = orcv1.PhaseStatus_PHASE_STATUS_PENDING.String()
@@ -657,6 +667,11 @@ func (we *WorkflowExecutor) executePhase(
 		}
 	}
 
+	// Keep the implement summary as the task's outcome for related-task context
+	if tmpl.ID == "implement" && t != nil && execResult.RawOutput != "" {
+		recordOutcomeSummary(t, execResult.RawOutput)
+	}
+
 	// Persist initiative notes from docs phase (SC-5: knowledge curator integration)
 	// Use execResult.RawOutput which contains the full JSON (including initiative_notes),
 	// not result.Content which only has the extracted "content" field.
@@ -773,21 +788,43 @@ func (we *WorkflowExecutor) executeWithProvider(ctx context.Context, cfg PhaseEx
 	}
 	result.SessionID = pctx.SessionID
 
+	// Refactor tasks record tests and API before anything changes
+	if we.refactorSafetyApplies(cfg.PhaseID) {
+		if err := we.captureRefactorBaseline(ctx, cfg); err != nil {
+			return result, err
+		}
+	}
+
 	// 2. Create or inject TurnExecutor
 	var turnExec TurnExecutor
+	if we.fakeModelErr != nil {
+		return result, fmt.Errorf("fake model: %w", we.fakeModelErr)
+	}
 	if we.turnExecutor != nil {
 		turnExec = we.turnExecutor
+		if pte, ok := turnExec.(PhaseTurnExecutor); ok {
+			turnExec = pte.ForPhase(cfg.PhaseID)
+		}
 		turnExec.UpdateSessionID(pctx.SessionID)
+	} else if we.fakeModel != nil {
+		turnExec = we.fakeModel.TurnExecutor(adapter.BuildTurnExecutorConfig(&cfg, pctx, we))
 	} else {
 		teCfg := adapter.BuildTurnExecutorConfig(&cfg, pctx, we)
 		turnExec = NewTurnExecutor(teCfg)
 	}
 
+	// Implementation continuations carry only what changed since the last iteration
+	iterCtx := we.newIterationContext(cfg)
+	retryTests := we.newRetryTestSelection(cfg)
+
 	// 3. Shared orchestration loop
 	for i := 0; i < MaxOrcRetries; i++ {
 		if ctx.Err() != nil {
 			return result, ctx.Err()
 		}
+		if i > 0 {
+			pctx.Prompt = iterCtx.continuationPrompt(i, pctx.Prompt)
+		}
 
 		result.Iterations++
 		we.updatePhaseIterations(cfg, result.Iterations)
@@ -797,7 +834,7 @@ func (we *WorkflowExecutor) executeWithProvider(ctx context.Context, cfg PhaseEx
 			turnResults []*TurnResult
 		)
 
-		if we.turnExecutor == nil && shouldUseClaudeStructuredFinalize(cfg, adapter) {
+		if we.turnExecutor == nil && we.fakeModel == nil && shouldUseClaudeStructuredFinalize(cfg, adapter) {
 			turnResult, turnResults, err = executeClaudeStructuredFinalize(ctx, turnExec, cfg, pctx.Prompt)
 		} else {
 			turnResult, err = turnExec.ExecuteTurn(ctx, pctx.Prompt)
@@ -836,6 +873,11 @@ func (we *WorkflowExecutor) executeWithProvider(ctx context.Context, cfg PhaseEx
 			return result, fmt.Errorf("%s turn %d: %w", adapter.Name(), i+1, err)
 		}
 
+		// Pause before the next iteration once the task or initiative is over budget
+		if budgetErr := we.checkTaskBudget(we.iterationCostUSD(cfg, result)); budgetErr != nil {
+			return result, budgetErr
+		}
+
 		// Parse status at orchestration level (authoritative for all providers + mocks).
 		// Both real executors also parse in ExecuteTurn() for internal retry logic,
 		// but orchestration-level parse is the single source of truth for completion.
@@ -869,8 +911,20 @@ func (we *WorkflowExecutor) executeWithProvider(ctx context.Context, cfg PhaseEx
 				we.logger.Info("implement verification gate passed", "phase", cfg.PhaseID)
 			}
 
+			// Reproduction gate: the regression test must fail before the fix
+			if cfg.PhaseID == ReproducePhaseID {
+				feedback, verifyErr := we.verifyReproduction(ctx, cfg, turnResult.Content)
+				if verifyErr != nil {
+					return result, verifyErr
+				}
+				if feedback != "" {
+					pctx.Prompt = feedback
+					continue
+				}
+			}
+
 			// Quality checks
-			if checkResult := we.runQualityChecks(ctx, cfg); checkResult != nil {
+			if checkResult := we.runQualityChecks(ctx, cfg, retryTests); checkResult != nil {
 				if checkResult.HasBlocks {
 					we.logger.Info("quality checks failed, continuing iteration",
 						"phase", cfg.PhaseID,
@@ -889,6 +943,18 @@ func (we *WorkflowExecutor) executeWithProvider(ctx context.Context, cfg PhaseEx
 				}
 			}
 
+			// Refactor safety: tests and exported API must match the baseline
+			if we.refactorSafetyApplies(cfg.PhaseID) {
+				feedback, verifyErr := we.verifyRefactorSafety(ctx, cfg)
+				if verifyErr != nil {
+					return result, verifyErr
+				}
+				if feedback != "" {
+					pctx.Prompt = feedback
+					continue
+				}
+			}
+
 			result.RawOutput = turnResult.Content
 			result.Content = extractPhaseOutput(turnResult.Content)
 			return result, nil
@@ -939,6 +1005,10 @@ func (we *WorkflowExecutor) updatePhaseIterations(cfg PhaseExecutionConfig, iter
 
 // loadPhasePrompt loads the prompt content for a phase template.
 func (we *WorkflowExecutor) loadPhasePrompt(tmpl *db.PhaseTemplate) (string, error) {
+	if content, ok := we.loadPromptVariant(tmpl); ok {
+		return content, nil
+	}
+
 	switch tmpl.PromptSource {
 	case "embedded":
 		// Load from embedded templates
@@ -960,6 +1030,43 @@ func (we *WorkflowExecutor) loadPhasePrompt(tmpl *db.PhaseTemplate) (string, err
 	}
 }
 
+// loadPromptVariant loads the category variant of a file-backed prompt
+// (prompt_variants), e.g. prompts/tiny_spec.bugfix.md for a bug task. It
+// reports false when the task's category has no variant or the variant file
+// does not exist, in which case the base prompt is used.
+func (we *WorkflowExecutor) loadPromptVariant(tmpl *db.PhaseTemplate) (string, bool) {
+	if we.task == nil || tmpl.PromptPath == "" {
+		return "", false
+	}
+	variant := we.orcConfig.PromptVariant(task.CategoryFromProto(task.GetCategoryProto(we.task)))
+	if variant == "" {
+		return "", false
+	}
+	ext := filepath.Ext(tmpl.PromptPath)
+	path := strings.TrimSuffix(tmpl.PromptPath, ext) + "." + variant + ext
+
+	var content string
+	var err error
+	switch tmpl.PromptSource {
+	case "embedded":
+		content, err = we.loadEmbeddedPrompt(path)
+	case "file":
+		content, err = we.loadFilePrompt(path)
+	default:
+		return "", false
+	}
+	if err != nil {
+		return "", false
+	}
+	we.logger.Info("using prompt variant",
+		"task", we.task.Id,
+		"phase", tmpl.ID,
+		"variant", variant,
+		"prompt", path,
+	)
+	return content, true
+}
+
 // loadEmbeddedPrompt loads a prompt from embedded templates.
 // Tries the embed.FS first (works in production binary), falls back to filesystem (dev).
 func (we *WorkflowExecutor) loadEmbeddedPrompt(path string) (string, error) {
@@ -1236,9 +1343,10 @@ func (we *WorkflowExecutor) executePhaseWithTimeout(
 	return result, err
 }
 
-// runQualityChecks runs quality checks configured for the phase.
-// Returns nil if no checks are configured.
-func (we *WorkflowExecutor) runQualityChecks(ctx context.Context, cfg PhaseExecutionConfig) *QualityCheckResult {
+// runQualityChecks runs quality checks configured for the phase. On retries,
+// tests narrows failed tests checks to the affected tests (nil runs them in
+// full). Returns nil if no checks are configured.
+func (we *WorkflowExecutor) runQualityChecks(ctx context.Context, cfg PhaseExecutionConfig, tests *retryTestSelection) *QualityCheckResult {
 	// Load quality checks from phase template (with workflow override)
 	checks, err := LoadQualityChecksForPhase(cfg.PhaseTemplate, cfg.WorkflowPhase)
 	if err != nil {
@@ -1252,8 +1360,9 @@ func (we *WorkflowExecutor) runQualityChecks(ctx context.Context, cfg PhaseExecu
 
 	commands := make(map[string]*db.ProjectCommand)
 	if we.projectDB != nil {
-		// Load project commands from database
-		loadedCommands, err := we.projectDB.GetProjectCommandsMap()
+		// Load project commands from database, using per-directory
+		// profiles when the task's changes fall under them
+		loadedCommands, err := we.taskProjectCommands(cfg.WorkingDir)
 		if err != nil {
 			we.logger.Warn("failed to load project commands - code checks may not run",
 				"phase", cfg.PhaseID,
@@ -1279,9 +1388,15 @@ func (we *WorkflowExecutor) runQualityChecks(ctx context.Context, cfg PhaseExecu
 			)
 		}
 		checks = mergeRequiredImplementationChecks(checks, requiredChecks)
+		// The reproduce phase's regression test must pass after the fix
+		if check := reproductionCheck(we.task); check != nil {
+			checks = append(checks, *check)
+		}
 	}
 
-	if len(checks) == 0 {
+	binaryCheck := we.binaryPolicyCheck(cfg.WorkingDir)
+	sensitiveCheck := we.sensitivePathsCheck(cfg.WorkingDir)
+	if len(checks) == 0 && binaryCheck == nil && sensitiveCheck == nil {
 		// No checks configured for this phase
 		we.logger.Debug("no quality checks configured for phase", "phase", cfg.PhaseID)
 		return nil
@@ -1290,12 +1405,77 @@ func (we *WorkflowExecutor) runQualityChecks(ctx context.Context, cfg PhaseExecu
 	we.logger.Info("running quality checks", "phase", cfg.PhaseID, "check_count", len(checks))
 
 	// Create and run the quality check runner
-	runner := NewQualityCheckRunner(
-		cfg.WorkingDir,
-		checks,
-		commands,
-		we.logger,
-	)
+	checks, narrowed := tests.prepare(ctx, checks, commands, we.logger)
+	result := we.newQualityCheckRunner(cfg.WorkingDir, checks, commands).Run(ctx)
+	if len(narrowed) > 0 && !result.HasBlocks {
+		// The affected tests pass; the full suite decides
+		we.logger.Info("affected tests passed, running full test suite", "phase", cfg.PhaseID)
+		result.replaceChecks(we.newQualityCheckRunner(cfg.WorkingDir, narrowed, commands).Run(ctx))
+		narrowed = nil
+	}
+	tests.record(result, narrowed)
+	for _, check := range []*CheckResult{binaryCheck, sensitiveCheck} {
+		if check == nil {
+			continue
+		}
+		result.Checks = append(result.Checks, *check)
+		if !check.Passed {
+			result.AllPassed = false
+			result.HasBlocks = true
+		}
+	}
+	return result
+}
+
+// newQualityCheckRunner creates a quality check runner with the configured
+// parallelism, timeout, and hermetic environment.
+func (we *WorkflowExecutor) newQualityCheckRunner(workDir string, checks []db.QualityCheck, commands map[string]*db.ProjectCommand) *QualityCheckRunner {
+	runner := NewQualityCheckRunner(workDir, checks, commands, we.logger)
+	if we.orcConfig != nil {
+		runner.WithLimits(we.orcConfig.QualityChecks.Parallelism, we.orcConfig.QualityChecks.Timeout)
+		if hermetic := we.orcConfig.QualityChecks.Hermetic; hermetic.Enabled {
+			runner.WithHermeticEnv(filepath.Join(firstNonEmpty(we.workingDir, workDir), HermeticCacheDir), hermetic.Env)
+		}
+	}
+	return runner
+}
+
+// agentEnv is the environment added to agent processes for a task. It carries
+// the configured git identity and hook bypass so commits agents make in the
+// worktree are attributed and hooked the same way as orc's own.
+func (we *WorkflowExecutor) agentEnv(taskID string) map[string]string {
+	env := map[string]string{"ORC_TASK_ID": taskID}
+	if we.orcConfig != nil {
+		maps.Copy(env, we.orcConfig.GitIdentity.Env())
+	}
+	gitOps := we.worktreeGit
+	if gitOps == nil {
+		gitOps = we.gitOps
+	}
+	if gitOps != nil {
+		maps.Copy(env, gitOps.Context().HookBypassEnv())
+	}
+	return env
+}
 
-	return runner.Run(ctx)
+// recordPhaseTiming stores the active execution time of a phase attempt for
+// time tracking. Only time spent inside executePhase counts, so queue, pause,
+// and gate waits never inflate a task's active time.
+func (we *WorkflowExecutor) recordPhaseTiming(t *orcv1.Task, phaseID string, run *db.WorkflowRun, status string, start time.Time) {
+	if t == nil || we.projectDB == nil {
+		return
+	}
+	timing := &db.PhaseTiming{
+		TaskID:    t.Id,
+		PhaseID:   phaseID,
+		Status:    status,
+		StartedAt: start,
+		ActiveMs:  time.Since(start).Milliseconds(),
+	}
+	if run != nil {
+		timing.RunID = run.ID
+	}
+	if err := we.projectDB.RecordPhaseTiming(timing); err != nil {
+		we.logger.Warn("failed to record phase timing", "task", t.Id, "phase", phaseID, "error", err)
+	}
 }
@@ -0,0 +1,73 @@
+package executor
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/templates"
+)
+
+func TestLoadPhasePrompt_CategoryVariant(t *testing.T) {
+	base, err := templates.Prompts.ReadFile("prompts/tiny_spec.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bugfix, err := templates.Prompts.ReadFile("prompts/tiny_spec.bugfix.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workDir := t.TempDir()
+	promptsDir := filepath.Join(workDir, ".orc", "prompts")
+	_ = os.MkdirAll(promptsDir, 0755)
+	_ = os.WriteFile(filepath.Join(promptsDir, "custom.md"), []byte("base"), 0644)
+	_ = os.WriteFile(filepath.Join(promptsDir, "custom.docs-only.md"), []byte("docs variant"), 0644)
+
+	cfg := config.Default()
+	cfg.PromptVariants["docs"] = "docs-only"
+
+	embedded := &db.PhaseTemplate{ID: "tiny_spec", PromptSource: "embedded", PromptPath: "prompts/tiny_spec.md"}
+	file := &db.PhaseTemplate{ID: "custom", PromptSource: "file", PromptPath: "custom.md"}
+	inline := &db.PhaseTemplate{ID: "inline", PromptSource: "db", PromptContent: "inline", PromptPath: "custom.md"}
+
+	tests := []struct {
+		name     string
+		category orcv1.TaskCategory
+		tmpl     *db.PhaseTemplate
+		want     string
+	}{
+		{"bug uses built-in bugfix variant", orcv1.TaskCategory_TASK_CATEGORY_BUG, embedded, string(bugfix)},
+		{"feature uses base prompt", orcv1.TaskCategory_TASK_CATEGORY_FEATURE, embedded, string(base)},
+		{"docs variant from file", orcv1.TaskCategory_TASK_CATEGORY_DOCS, file, "docs variant"},
+		{"missing variant falls back", orcv1.TaskCategory_TASK_CATEGORY_BUG, file, "base"},
+		{"inline prompts have no variants", orcv1.TaskCategory_TASK_CATEGORY_DOCS, inline, "inline"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			we := &WorkflowExecutor{
+				orcConfig:  cfg,
+				logger:     slog.Default(),
+				workingDir: workDir,
+				task:       &orcv1.Task{Id: "TASK-001", Category: tt.category},
+			}
+			got, err := we.loadPhasePrompt(tt.tmpl)
+			if err != nil {
+				t.Fatalf("loadPhasePrompt() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("loadPhasePrompt() = %q, want %q", firstLine(got), firstLine(tt.want))
+			}
+		})
+	}
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
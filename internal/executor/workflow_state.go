@@ -4,6 +4,7 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -165,6 +166,12 @@ func (we *WorkflowExecutor) failTaskAfterCompletionError(t *orcv1.Task, completi
 // interruptRun marks a run as cancelled (interrupted by context cancellation) and syncs task status.
 // Commits work-in-progress before updating status to preserve changes.
 func (we *WorkflowExecutor) interruptRun(run *db.WorkflowRun, t *orcv1.Task, currentPhase string, err error) error {
+	timedOut := t != nil && we.taskTimedOut.Load()
+	if timedOut {
+		err = &taskTimeoutError{taskID: t.Id, limit: we.taskTimeout}
+	}
+	var budgetErr *taskBudgetError
+	overBudget := t != nil && errors.As(err, &budgetErr)
 	we.logger.Info("run interrupted", "run_id", run.ID, "phase", currentPhase, "reason", err.Error())
 
 	// Commit work-in-progress before updating state
@@ -180,6 +187,10 @@ func (we *WorkflowExecutor) interruptRun(run *db.WorkflowRun, t *orcv1.Task, cur
 
 	// Update execution state
 	if we.task != nil {
+		if overBudget {
+			// Count what the interrupted phase spent so resumes see it
+			task.AddCostProto(we.task.Execution, currentPhase, budgetErr.phaseCostUSD)
+		}
 		task.InterruptPhaseProto(we.task.Execution, currentPhase)
 		task.SetErrorProto(we.task.Execution, fmt.Sprintf("interrupted during %s: %s", currentPhase, err.Error()))
 		persistErr = combineExecutionErrors(persistErr, we.saveTaskStrict(we.task, "save state on interrupt"))
@@ -196,6 +207,12 @@ func (we *WorkflowExecutor) interruptRun(run *db.WorkflowRun, t *orcv1.Task, cur
 		// Publish task updated event for real-time UI updates
 		we.publishTaskUpdated(t)
 	}
+	if timedOut {
+		persistErr = combineExecutionErrors(persistErr, we.escalateTaskTimeout(t, currentPhase))
+	}
+	if overBudget {
+		persistErr = combineExecutionErrors(persistErr, we.escalateTaskBudget(t, currentPhase, budgetErr))
+	}
 	return persistErr
 }
 
@@ -208,6 +225,10 @@ func (we *WorkflowExecutor) commitWIPOnInterrupt(t *orcv1.Task, phaseID string)
 	if gitSvc == nil {
 		return
 	}
+	if err := we.guardSensitivePaths(gitSvc.Context().WorkDir()); err != nil {
+		we.logger.Warn("skipping WIP commit on interrupt", "task", t.Id, "error", err)
+		return
+	}
 
 	// Use CreateCheckpoint which handles staging and committing
 	checkpoint, err := gitSvc.CreateCheckpoint(t.Id, phaseID, "interrupted (work in progress)")
@@ -13,6 +13,7 @@ import (
 
 	"github.com/randalmurphal/orc/internal/db"
 	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/i18n"
 )
 
 // GateType represents the type of gate.
@@ -41,6 +42,8 @@ type Decision struct {
 	Reason    string
 	Questions []string // For NEEDS_CLARIFICATION
 	Pending   bool     // True if decision is pending (headless mode)
+	// DecisionID identifies a pending decision in the PendingDecisionStore
+	DecisionID string
 
 	// AI gate fields
 	RetryPhase string         // Phase to retry from (if rejected)
@@ -169,15 +172,15 @@ func (e *Evaluator) requestHumanApproval(gate *Gate, opts *EvaluateOptions) (*De
 	}
 
 	// Interactive CLI mode - prompt on stdin
-	fmt.Println("\n👤 Human approval required")
+	fmt.Println("\n" + i18n.T("gate.human_approval_required"))
 	if len(gate.Criteria) > 0 {
-		fmt.Println("Please verify the following:")
+		fmt.Println(i18n.T("gate.verify_criteria"))
 		for _, c := range gate.Criteria {
 			fmt.Printf("  - %s\n", c)
 		}
 	}
 
-	fmt.Print("\nApprove? [y/n/q(questions)]: ")
+	fmt.Print("\n" + i18n.T("gate.approve_prompt"))
 
 	reader := bufio.NewReader(os.Stdin)
 	input, err := reader.ReadString('\n')
@@ -194,14 +197,14 @@ func (e *Evaluator) requestHumanApproval(gate *Gate, opts *EvaluateOptions) (*De
 			Reason:   "human approved",
 		}, nil
 	case "n", "no":
-		fmt.Print("Reason for rejection: ")
+		fmt.Print(i18n.T("gate.rejection_reason_prompt"))
 		reason, _ := reader.ReadString('\n')
 		return &Decision{
 			Approved: false,
 			Reason:   strings.TrimSpace(reason),
 		}, nil
 	case "q", "questions":
-		fmt.Println("Enter questions (empty line to finish):")
+		fmt.Println(i18n.T("gate.questions_prompt"))
 		var questions []string
 		for {
 			q, _ := reader.ReadString('\n')
@@ -277,7 +280,8 @@ func (e *Evaluator) emitDecisionRequired(gate *Gate, opts *EvaluateOptions) (*De
 
 	// Return pending decision (non-blocking)
 	return &Decision{
-		Pending: true,
-		Reason:  "awaiting approval",
+		Pending:    true,
+		Reason:     "awaiting approval",
+		DecisionID: decisionID,
 	}, nil
 }
@@ -6,6 +6,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/i18n"
 )
 
 // NewProtoGateApproval creates a new proto AttentionItem representing a gate approval.
@@ -14,7 +15,7 @@ func NewProtoGateApproval(id, taskID, phase string) *orcv1.AttentionItem {
 		Id:        id,
 		Type:      orcv1.AttentionItemType_ATTENTION_ITEM_TYPE_GATE_APPROVAL,
 		TaskId:    taskID,
-		Title:     "Gate approval for " + phase,
+		Title:     i18n.T("gate.approval_title", phase),
 		CreatedAt: timestamppb.New(time.Now()),
 		AvailableActions: []orcv1.AttentionAction{
 			orcv1.AttentionAction_ATTENTION_ACTION_APPROVE,
@@ -4,16 +4,19 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 )
 
 // Context manages git operations for a repository.
 // Absorbed from devflow/git to eliminate the external dependency.
 type Context struct {
-	repoPath    string        // Path to the main repository
-	worktreeDir string        // Directory where worktrees are created
-	workDir     string        // Current working directory for commands (defaults to repoPath)
-	runner      CommandRunner // Command runner (defaults to ExecRunner)
+	repoPath      string        // Path to the main repository
+	worktreeDir   string        // Directory where worktrees are created
+	workDir       string        // Current working directory for commands (defaults to repoPath)
+	runner        CommandRunner // Command runner (defaults to ExecRunner)
+	identity      []string      // "-c user.name=… -c user.email=…" prepended to every command (empty = git's own resolution)
+	bypassedHooks []string      // Hooks skipped by commands run through the context (see WithBypassedHooks)
 }
 
 // ContextOption configures Context.
@@ -57,6 +60,19 @@ func WithWorktreeDir(dir string) ContextOption {
 	}
 }
 
+// WithIdentity makes commits created through the context use the given
+// author/committer, overriding the repository's and user's git config.
+// Empty values leave git's identity resolution unchanged.
+func WithIdentity(name, email string) ContextOption {
+	return func(g *Context) {
+		if name == "" || email == "" {
+			g.identity = nil
+			return
+		}
+		g.identity = []string{"-c", "user.name=" + name, "-c", "user.email=" + email}
+	}
+}
+
 // RepoPath returns the path to the main repository.
 func (g *Context) RepoPath() string {
 	return g.repoPath
@@ -71,10 +87,12 @@ func (g *Context) WorkDir() string {
 // InWorktree returns a new Context that operates in the specified worktree.
 func (g *Context) InWorktree(worktreePath string) *Context {
 	return &Context{
-		repoPath:    g.repoPath,
-		worktreeDir: g.worktreeDir,
-		workDir:     worktreePath,
-		runner:      g.runner,
+		repoPath:      g.repoPath,
+		worktreeDir:   g.worktreeDir,
+		workDir:       worktreePath,
+		runner:        g.runner,
+		identity:      g.identity,
+		bypassedHooks: g.bypassedHooks,
 	}
 }
 
@@ -201,6 +219,10 @@ func (g *Context) GetRemoteURL(remote string) (string, error) {
 
 // runGit executes a git command and returns stdout.
 func (g *Context) runGit(args ...string) (string, error) {
+	args = g.withHookBypass(args)
+	if len(g.identity) > 0 {
+		args = append(slices.Clone(g.identity), args...)
+	}
 	return g.runner.Run(g.workDir, "git", args...)
 }
 
@@ -230,4 +252,3 @@ func (g *Context) CleanupWorktree(worktreePath string) error {
 	}
 	return nil
 }
-
@@ -39,6 +39,9 @@ type Config struct {
 	WorktreeDir       string   // Directory for worktrees (default: ".orc/worktrees")
 	ExecutorPrefix    string   // Executor prefix for multi-user mode (empty in solo mode)
 	ProtectedBranches []string // Branches protected from direct push (default: main, master, develop, release)
+	IdentityName      string   // Author/committer name for orc's commits (empty = git config)
+	IdentityEmail     string   // Author/committer email for orc's commits (empty = git config)
+	BypassedHooks     []string // Git hooks skipped by orc's commits, merges, and pushes
 }
 
 // DefaultConfig returns sensible defaults.
@@ -53,7 +56,7 @@ func DefaultConfig() Config {
 
 // New creates a new Git instance for the repository at workDir.
 func New(workDir string, cfg Config) (*Git, error) {
-	ctx, err := NewContext(workDir, WithWorktreeDir(cfg.WorktreeDir))
+	ctx, err := NewContext(workDir, WithWorktreeDir(cfg.WorktreeDir), WithIdentity(cfg.IdentityName, cfg.IdentityEmail), WithBypassedHooks(cfg.BypassedHooks))
 	if err != nil {
 		return nil, fmt.Errorf("init git context: %w", err)
 	}
@@ -73,6 +76,11 @@ func New(workDir string, cfg Config) (*Git, error) {
 	}, nil
 }
 
+// WorktreeBaseDir returns the absolute directory task worktrees are created in.
+func (g *Git) WorktreeBaseDir() string {
+	return g.worktreeBasePath()
+}
+
 // worktreeBasePath returns the absolute base directory for worktrees.
 // Handles both absolute and relative worktree directory configurations.
 func (g *Git) worktreeBasePath() string {
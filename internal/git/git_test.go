@@ -72,3 +72,40 @@ func TestNewInvalidPath(t *testing.T) {
 		t.Error("New() should fail for non-git directory")
 	}
 }
+
+func TestNew_IdentityOverridesGitConfig(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	cfg := DefaultConfig()
+	cfg.IdentityName = "orc-bot"
+	cfg.IdentityEmail = "bot@company.com"
+
+	g, err := New(tmpDir, cfg)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	// Worktree-scoped instances must keep the identity.
+	ctx := g.InWorktree(tmpDir).Context()
+	if _, err := ctx.RunGit("commit", "--allow-empty", "-m", "automated"); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	got, err := ctx.RunGit("log", "-1", "--format=%an <%ae>|%cn <%ce>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "orc-bot <bot@company.com>|orc-bot <bot@company.com>"; got != want {
+		t.Errorf("author|committer = %q, want %q", got, want)
+	}
+
+	// Without an identity, the repository's git config applies.
+	plain, err := New(tmpDir, DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := plain.Context().RunGit("commit", "--allow-empty", "-m", "manual"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := plain.Context().RunGit("log", "-1", "--format=%an"); got != "Test User" {
+		t.Errorf("author without identity = %q, want Test User", got)
+	}
+}
@@ -0,0 +1,159 @@
+package git
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// HooksBypassedTrailer is the commit trailer naming the hooks skipped for a
+// commit orc made, so each bypass is auditable from the history.
+const HooksBypassedTrailer = "Orc-Hooks-Bypassed"
+
+// commitHooks are the hooks a plain commit runs.
+var commitHooks = []string{"pre-commit", "prepare-commit-msg", "commit-msg", "post-commit"}
+
+// hookCommands are the git commands that can run hooks. Other commands run
+// without the bypass so they don't pay for preparing the hooks directory.
+var hookCommands = map[string]bool{
+	"commit": true, "merge": true, "push": true, "pull": true, "rebase": true,
+	"cherry-pick": true, "revert": true, "am": true, "checkout": true, "switch": true,
+	"worktree": true, "gc": true,
+}
+
+// WithBypassedHooks makes commands run through the context skip the named
+// hooks while every other hook still runs. Commits record the skipped hooks
+// in an Orc-Hooks-Bypassed trailer.
+func WithBypassedHooks(hooks []string) ContextOption {
+	return func(g *Context) {
+		g.bypassedHooks = slices.Clone(hooks)
+	}
+}
+
+// BypassedHooks returns the hooks the context skips.
+func (g *Context) BypassedHooks() []string {
+	return g.bypassedHooks
+}
+
+// HookBypassEnv returns environment variables that apply the context's hook
+// bypass to git processes started outside it (such as agents committing in a
+// worktree), or nil when no hook is bypassed or none of the bypassed hooks
+// is installed.
+func (g *Context) HookBypassEnv() map[string]string {
+	if len(g.bypassedHooks) == 0 {
+		return nil
+	}
+	dir, skipped, err := g.bypassHooksDir()
+	if err != nil || len(skipped) == 0 {
+		return nil
+	}
+	return map[string]string{
+		"GIT_CONFIG_COUNT":   "1",
+		"GIT_CONFIG_KEY_0":   "core.hooksPath",
+		"GIT_CONFIG_VALUE_0": dir,
+	}
+}
+
+// withHookBypass rewrites a git command to skip the bypassed hooks. Commands
+// are returned unchanged when nothing is bypassed, the command runs no hooks,
+// or none of the bypassed hooks is installed.
+func (g *Context) withHookBypass(args []string) []string {
+	if len(g.bypassedHooks) == 0 || len(args) == 0 || !hookCommands[args[0]] {
+		return args
+	}
+	dir, skipped, err := g.bypassHooksDir()
+	if err != nil || len(skipped) == 0 {
+		return args
+	}
+
+	out := []string{"-c", "core.hooksPath=" + dir, args[0]}
+	if args[0] == "commit" {
+		var commitSkipped []string
+		for _, h := range skipped {
+			if slices.Contains(commitHooks, h) {
+				commitSkipped = append(commitSkipped, h)
+			}
+		}
+		if len(commitSkipped) > 0 {
+			out = append(out, "--trailer", HooksBypassedTrailer+": "+strings.Join(commitSkipped, ", "))
+		}
+	}
+	return append(out, args[1:]...)
+}
+
+// bypassHooksDir prepares a hooks directory that forwards to every installed
+// hook except the bypassed ones, and returns it with the installed hooks it
+// leaves out. Wrappers exec the real hook by absolute path, so hooks that
+// locate helpers relative to $0 keep working. The directory lives under the
+// common git dir and is named by the real hooks directory and the hooks it
+// forwards, so concurrent tasks share it and never rewrite it in place.
+func (g *Context) bypassHooksDir() (string, []string, error) {
+	out, err := g.runner.Run(g.workDir, "git", "rev-parse", "--path-format=absolute", "--git-path", "hooks", "--git-common-dir")
+	if err != nil {
+		return "", nil, fmt.Errorf("resolve hooks dir: %w", err)
+	}
+	paths := strings.Split(strings.TrimSpace(out), "\n")
+	if len(paths) != 2 {
+		return "", nil, fmt.Errorf("resolve hooks dir: unexpected output %q", out)
+	}
+	hooksDir, commonDir := paths[0], paths[1]
+
+	var forwarded, skipped []string
+	entries, err := os.ReadDir(hooksDir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", nil, fmt.Errorf("read hooks dir: %w", err)
+	}
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || e.IsDir() || info.Mode()&0111 == 0 || strings.HasSuffix(e.Name(), ".sample") {
+			continue
+		}
+		if slices.Contains(g.bypassedHooks, e.Name()) {
+			skipped = append(skipped, e.Name())
+		} else {
+			forwarded = append(forwarded, e.Name())
+		}
+	}
+	if len(skipped) == 0 {
+		return "", nil, nil
+	}
+
+	sum := sha256.Sum256([]byte(hooksDir + "\x00" + strings.Join(forwarded, "\x00")))
+	dir := filepath.Join(commonDir, "orc", "hooks-"+hex.EncodeToString(sum[:8]))
+	if _, err := os.Stat(dir); err == nil {
+		return dir, skipped, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return "", nil, fmt.Errorf("create hooks dir: %w", err)
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dir), "hooks-tmp-")
+	if err != nil {
+		return "", nil, fmt.Errorf("create hooks dir: %w", err)
+	}
+	for _, name := range forwarded {
+		wrapper := fmt.Sprintf("#!/bin/sh\n# Generated by orc: forwards to the project hook\nexec %s \"$@\"\n",
+			shellQuote(filepath.Join(hooksDir, name)))
+		if err := writeExecutableFile(filepath.Join(tmp, name), wrapper); err != nil {
+			_ = os.RemoveAll(tmp)
+			return "", nil, fmt.Errorf("write hook wrapper: %w", err)
+		}
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		_ = os.RemoveAll(tmp)
+		if _, statErr := os.Stat(dir); statErr != nil {
+			return "", nil, fmt.Errorf("install hooks dir: %w", err)
+		}
+		// Another task installed the same directory first
+	}
+	return dir, skipped, nil
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
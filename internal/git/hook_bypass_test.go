@@ -0,0 +1,122 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// installTestHooks installs a pre-commit hook that always fails and a
+// commit-msg hook that records it ran through a helper found via $0.
+func installTestHooks(t *testing.T, repo string) string {
+	t.Helper()
+	hooksDir := filepath.Join(repo, ".git", "hooks")
+	marker := filepath.Join(t.TempDir(), "commit-msg-ran")
+	for name, content := range map[string]string{
+		"pre-commit": "#!/bin/sh\necho 'pre-commit needs input' >&2\nexit 1\n",
+		"commit-msg": "#!/bin/sh\n. \"$(dirname \"$0\")/helper.sh\"\n",
+		"helper.sh":  "touch '" + marker + "'\n",
+	} {
+		if err := os.WriteFile(filepath.Join(hooksDir, name), []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return marker
+}
+
+func commitFile(t *testing.T, ctx *Context, name string) error {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(ctx.WorkDir(), name), []byte(name), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.StageAll(); err != nil {
+		t.Fatal(err)
+	}
+	return ctx.Commit("add " + name)
+}
+
+func TestBypassedHooks_SkipsOnlyNamedHooks(t *testing.T) {
+	repo := setupTestRepo(t)
+	marker := installTestHooks(t, repo)
+
+	plain, err := NewContext(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := commitFile(t, plain, "a.txt"); err == nil {
+		t.Fatal("commit should fail on the pre-commit hook without a bypass")
+	}
+
+	ctx, err := NewContext(repo, WithBypassedHooks([]string{"pre-commit", "pre-push"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := commitFile(t, ctx, "a.txt"); err != nil {
+		t.Fatalf("commit with pre-commit bypassed: %v", err)
+	}
+	if _, err := os.Stat(marker); err != nil {
+		t.Error("commit-msg hook should still run when only pre-commit is bypassed")
+	}
+
+	// The skipped hook is recorded on the commit; pre-push is not installed
+	trailer, err := ctx.RunGit("log", "-1", "--format=%(trailers:key="+HooksBypassedTrailer+",valueonly)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(trailer) != "pre-commit" {
+		t.Errorf("trailer = %q, want pre-commit", trailer)
+	}
+
+	// Worktree contexts inherit the bypass
+	if got := ctx.InWorktree(repo).BypassedHooks(); len(got) != 2 {
+		t.Errorf("InWorktree BypassedHooks = %v", got)
+	}
+}
+
+func TestBypassedHooks_NoInstalledHook(t *testing.T) {
+	repo := setupTestRepo(t)
+	ctx, err := NewContext(repo, WithBypassedHooks([]string{"pre-commit"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := commitFile(t, ctx, "b.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if msg, _ := ctx.RunGit("log", "-1", "--format=%B"); strings.Contains(msg, HooksBypassedTrailer) {
+		t.Errorf("nothing was skipped, but the commit has a trailer:\n%s", msg)
+	}
+	if env := ctx.HookBypassEnv(); env != nil {
+		t.Errorf("HookBypassEnv = %v, want nil when the hook is not installed", env)
+	}
+}
+
+func TestHookBypassEnv_AppliesToExternalGit(t *testing.T) {
+	repo := setupTestRepo(t)
+	marker := installTestHooks(t, repo)
+	ctx, err := NewContext(repo, WithBypassedHooks([]string{"pre-commit"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := ctx.HookBypassEnv()
+	if env["GIT_CONFIG_KEY_0"] != "core.hooksPath" {
+		t.Fatalf("HookBypassEnv = %v", env)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "c.txt"), []byte("c"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("sh", "-c", "git add c.txt && git commit -q -m 'agent commit'")
+	cmd.Dir = repo
+	cmd.Env = os.Environ()
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("agent commit with bypass env: %v\n%s", err, out)
+	}
+	if _, err := os.Stat(marker); err != nil {
+		t.Error("commit-msg hook should run for agent commits")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+
+	"github.com/randalmurphal/orc/internal/retry"
 )
 
 // Config holds hosting provider configuration.
@@ -19,6 +21,10 @@ type Config struct {
 	// TokenEnvVar overrides the default token environment variable name.
 	// Default: ORC_GITHUB_TOKEN for GitHub, ORC_GITLAB_TOKEN for GitLab.
 	TokenEnvVar string `yaml:"token_env_var" json:"token_env_var,omitempty"`
+
+	// Retry controls retries of failed API requests. It comes from the
+	// global backoff settings; the zero value uses the retry defaults.
+	Retry retry.Policy `yaml:"-" json:"-"`
 }
 
 // NewProviderFunc is a constructor function for creating a hosting provider.
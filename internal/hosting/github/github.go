@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -11,7 +12,9 @@ import (
 
 	gogithub "github.com/google/go-github/v82/github"
 
+	"github.com/randalmurphal/orc/internal/breaker"
 	"github.com/randalmurphal/orc/internal/hosting"
+	"github.com/randalmurphal/orc/internal/retry"
 )
 
 // Compile-time interface check.
@@ -49,9 +52,13 @@ func newProvider(workDir string, cfg hosting.Config) (hosting.Provider, error) {
 		return nil, fmt.Errorf("could not parse owner/repo from remote URL: %s", remoteURL)
 	}
 
-	// Create authenticated HTTP client and go-github client.
+	// Create authenticated, retrying HTTP client and go-github client. The
+	// breaker sees each call once, after its retries.
 	httpClient := &http.Client{
-		Transport: &oauth2Transport{token: token},
+		Transport: breaker.NewTransport(
+			retry.NewTransport(&oauth2Transport{token: token}, cfg.Retry),
+			breaker.Get(breaker.Hosting),
+		),
 	}
 
 	client := gogithub.NewClient(httpClient)
@@ -536,6 +543,62 @@ func (g *GitHubProvider) DeleteBranch(ctx context.Context, branch string) error
 	return nil
 }
 
+// CreateIssue opens an issue in the repository.
+func (g *GitHubProvider) CreateIssue(ctx context.Context, opts hosting.IssueCreateOptions) (*hosting.Issue, error) {
+	req := &gogithub.IssueRequest{
+		Title: gogithub.Ptr(opts.Title),
+		Body:  gogithub.Ptr(opts.Body),
+	}
+	if len(opts.Labels) > 0 {
+		req.Labels = &opts.Labels
+	}
+	issue, _, err := g.client.Issues.Create(ctx, g.owner, g.repo, req)
+	if err != nil {
+		return nil, fmt.Errorf("create issue: %w", err)
+	}
+	return &hosting.Issue{
+		Number:  issue.GetNumber(),
+		Title:   issue.GetTitle(),
+		HTMLURL: issue.GetHTMLURL(),
+	}, nil
+}
+
+// GetBranchProtection reads classic branch protection for branch. Reading
+// protection requires admin access to the repository.
+func (g *GitHubProvider) GetBranchProtection(ctx context.Context, branch string) (*hosting.BranchProtection, error) {
+	p, _, err := g.client.Repositories.GetBranchProtection(ctx, g.owner, g.repo, branch)
+	if err != nil {
+		if errors.Is(err, gogithub.ErrBranchNotProtected) {
+			return &hosting.BranchProtection{Branch: branch}, nil
+		}
+		return nil, fmt.Errorf("get branch protection %q: %w", branch, err)
+	}
+	return convertProtection(branch, p), nil
+}
+
+func convertProtection(branch string, p *gogithub.Protection) *hosting.BranchProtection {
+	bp := &hosting.BranchProtection{Branch: branch, Protected: true}
+	if r := p.RequiredPullRequestReviews; r != nil {
+		bp.RequiredReviews = r.RequiredApprovingReviewCount
+		// A review requirement with no count still forces changes through a PR.
+		if bp.RequiredReviews == 0 {
+			bp.RequiredReviews = 1
+		}
+	}
+	if c := p.RequiredStatusChecks; c != nil {
+		if c.Checks != nil {
+			for _, check := range *c.Checks {
+				bp.RequiredStatusChecks = append(bp.RequiredStatusChecks, check.Context)
+			}
+		} else if c.Contexts != nil {
+			bp.RequiredStatusChecks = append(bp.RequiredStatusChecks, *c.Contexts...)
+		}
+	}
+	bp.PushRestricted = p.Restrictions != nil
+	bp.Locked = p.LockBranch != nil && p.LockBranch.GetEnabled()
+	return bp
+}
+
 // mapPR converts a go-github PullRequest to a hosting.PR.
 func mapPR(pr *gogithub.PullRequest) *hosting.PR {
 	state := pr.GetState()
@@ -3,6 +3,7 @@ package github
 import (
 	"testing"
 
+	gogithub "github.com/google/go-github/v82/github"
 	"github.com/randalmurphal/orc/internal/hosting"
 )
 
@@ -137,3 +138,30 @@ func searchString(s, substr string) bool {
 	}
 	return false
 }
+
+func TestConvertProtection(t *testing.T) {
+	t.Parallel()
+
+	contexts := []string{"ci/build", "ci/test"}
+	bp := convertProtection("main", &gogithub.Protection{
+		RequiredPullRequestReviews: &gogithub.PullRequestReviewsEnforcement{},
+		RequiredStatusChecks:       &gogithub.RequiredStatusChecks{Contexts: &contexts},
+	})
+	if !bp.Protected || bp.RequiredReviews != 1 {
+		t.Errorf("protected = %v, reviews = %d; want protected with 1 review", bp.Protected, bp.RequiredReviews)
+	}
+	if len(bp.RequiredStatusChecks) != 2 || bp.RequiredStatusChecks[0] != "ci/build" {
+		t.Errorf("status checks = %v, want %v", bp.RequiredStatusChecks, contexts)
+	}
+	if bp.PushRestricted || bp.Locked {
+		t.Errorf("push restricted = %v, locked = %v; want neither", bp.PushRestricted, bp.Locked)
+	}
+	if got := len(bp.DirectPushBlockers()); got != 2 {
+		t.Errorf("DirectPushBlockers() = %d entries, want 2", got)
+	}
+
+	// Protection with no review, check, or push rules allows direct pushes.
+	if blockers := convertProtection("main", &gogithub.Protection{}).DirectPushBlockers(); blockers != nil {
+		t.Errorf("DirectPushBlockers() = %v, want nil", blockers)
+	}
+}
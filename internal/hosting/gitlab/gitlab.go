@@ -2,14 +2,17 @@ package gitlab
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os/exec"
 	"strings"
 	"time"
 
 	gogitlab "gitlab.com/gitlab-org/api/client-go"
 
+	"github.com/randalmurphal/orc/internal/breaker"
 	"github.com/randalmurphal/orc/internal/hosting"
 )
 
@@ -52,13 +55,20 @@ func newProvider(workDir string, cfg hosting.Config) (hosting.Provider, error) {
 	// Project ID is the full path: "owner/repo" or "group/subgroup/repo".
 	projectID := owner + "/" + repo
 
-	var client *gogitlab.Client
+	// The client retries with its own backoff; bound it by the global policy.
+	policy := cfg.Retry.WithDefaults()
+	opts := []gogitlab.ClientOptionFunc{
+		gogitlab.WithHTTPClient(&http.Client{
+			Transport: breaker.NewTransport(nil, breaker.Get(breaker.Hosting)),
+		}),
+		gogitlab.WithCustomRetryMax(policy.MaxAttempts - 1),
+		gogitlab.WithCustomRetryWaitMinMax(policy.Initial, policy.Max),
+	}
 	if cfg.BaseURL != "" {
 		baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
-		client, err = gogitlab.NewClient(token, gogitlab.WithBaseURL(baseURL+"/api/v4"))
-	} else {
-		client, err = gogitlab.NewClient(token)
+		opts = append(opts, gogitlab.WithBaseURL(baseURL+"/api/v4"))
 	}
+	client, err := gogitlab.NewClient(token, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("create GitLab client: %w", err)
 	}
@@ -537,6 +547,52 @@ func (g *GitLabProvider) DeleteBranch(ctx context.Context, branch string) error
 	return nil
 }
 
+// CreateIssue opens an issue in the project.
+func (g *GitLabProvider) CreateIssue(ctx context.Context, opts hosting.IssueCreateOptions) (*hosting.Issue, error) {
+	createOpts := &gogitlab.CreateIssueOptions{
+		Title:       gogitlab.Ptr(opts.Title),
+		Description: gogitlab.Ptr(opts.Body),
+	}
+	if len(opts.Labels) > 0 {
+		labels := gogitlab.LabelOptions(opts.Labels)
+		createOpts.Labels = &labels
+	}
+	issue, _, err := g.client.Issues.CreateIssue(g.projectID, createOpts, gogitlab.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("create issue: %w", err)
+	}
+	return &hosting.Issue{
+		Number:  int(issue.IID),
+		Title:   issue.Title,
+		HTMLURL: issue.WebURL,
+	}, nil
+}
+
+// GetBranchProtection reads the protected branch settings for branch.
+// GitLab enforces approvals and pipelines on merge requests, not pushes, so
+// only push access levels are reported.
+func (g *GitLabProvider) GetBranchProtection(ctx context.Context, branch string) (*hosting.BranchProtection, error) {
+	pb, _, err := g.client.ProtectedBranches.GetProtectedBranch(g.projectID, branch, gogitlab.WithContext(ctx))
+	if err != nil {
+		if errors.Is(err, gogitlab.ErrNotFound) {
+			return &hosting.BranchProtection{Branch: branch}, nil
+		}
+		return nil, fmt.Errorf("get protected branch %q: %w", branch, err)
+	}
+	return convertProtectedBranch(branch, pb), nil
+}
+
+func convertProtectedBranch(branch string, pb *gogitlab.ProtectedBranch) *hosting.BranchProtection {
+	bp := &hosting.BranchProtection{Branch: branch, Protected: true, PushRestricted: true}
+	for _, level := range pb.PushAccessLevels {
+		// Role-based push access (developer/maintainer) lets regular members push.
+		if level.AccessLevel > gogitlab.NoPermissions && level.UserID == 0 && level.GroupID == 0 && level.DeployKeyID == 0 {
+			bp.PushRestricted = false
+		}
+	}
+	return bp
+}
+
 // resolveUserIDs converts a list of usernames to GitLab user IDs.
 func (g *GitLabProvider) resolveUserIDs(ctx context.Context, usernames []string) ([]int64, error) {
 	var ids []int64
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/randalmurphal/orc/internal/hosting"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
 )
 
 func TestResolveToken(t *testing.T) {
@@ -240,3 +241,28 @@ func TestGitLabProviderOwnerRepo(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertProtectedBranch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		levels         []*gogitlab.BranchAccessDescription
+		wantRestricted bool
+	}{
+		{"developers can push", []*gogitlab.BranchAccessDescription{{AccessLevel: gogitlab.DeveloperPermissions}}, false},
+		{"no one can push", []*gogitlab.BranchAccessDescription{{AccessLevel: gogitlab.NoPermissions}}, true},
+		{"single user only", []*gogitlab.BranchAccessDescription{{AccessLevel: gogitlab.MaintainerPermissions, UserID: 7}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := convertProtectedBranch("main", &gogitlab.ProtectedBranch{PushAccessLevels: tt.levels})
+			if !bp.Protected {
+				t.Error("expected branch to be reported as protected")
+			}
+			if bp.PushRestricted != tt.wantRestricted {
+				t.Errorf("PushRestricted = %v, want %v", bp.PushRestricted, tt.wantRestricted)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package hosting
 
 import (
 	"context"
+	"fmt"
+	"strings"
 )
 
 // ProviderType identifies which hosting provider is in use.
@@ -53,6 +55,12 @@ type Provider interface {
 
 	// Branch operations
 	DeleteBranch(ctx context.Context, branch string) error
+	// GetBranchProtection returns the remote protection rules for branch.
+	// Unprotected branches return a BranchProtection with Protected=false.
+	GetBranchProtection(ctx context.Context, branch string) (*BranchProtection, error)
+
+	// Issues
+	CreateIssue(ctx context.Context, opts IssueCreateOptions) (*Issue, error)
 
 	// Auth + metadata
 	CheckAuth(ctx context.Context) error
@@ -109,6 +117,20 @@ type PRMergeOptions struct {
 	DeleteBranch        bool   `json:"delete_branch"`
 }
 
+// Issue represents an issue created on the hosting provider.
+type Issue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+}
+
+// IssueCreateOptions for creating an issue.
+type IssueCreateOptions struct {
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	Labels []string `json:"labels,omitempty"`
+}
+
 // PRComment represents a PR comment / MR note.
 type PRComment struct {
 	ID        int64  `json:"id"`
@@ -146,6 +168,43 @@ type PRReview struct {
 	CreatedAt string `json:"created_at"`
 }
 
+// BranchProtection describes remote rules on a branch that can reject a
+// direct push (GitHub branch protection / GitLab protected branches).
+type BranchProtection struct {
+	Branch    string `json:"branch"`
+	Protected bool   `json:"protected"`
+	// RequiredReviews is the number of approving reviews required to merge.
+	RequiredReviews int `json:"required_reviews,omitempty"`
+	// RequiredStatusChecks lists checks that must pass before merging.
+	RequiredStatusChecks []string `json:"required_status_checks,omitempty"`
+	// PushRestricted is true when only specific users, teams, or roles may push.
+	PushRestricted bool `json:"push_restricted,omitempty"`
+	// Locked is true when the branch is read-only.
+	Locked bool `json:"locked,omitempty"`
+}
+
+// DirectPushBlockers returns the rules that would reject a direct push, or
+// nil when a direct push is allowed.
+func (b *BranchProtection) DirectPushBlockers() []string {
+	if b == nil || !b.Protected {
+		return nil
+	}
+	var blockers []string
+	if b.RequiredReviews > 0 {
+		blockers = append(blockers, fmt.Sprintf("requires %d approving review(s)", b.RequiredReviews))
+	}
+	if len(b.RequiredStatusChecks) > 0 {
+		blockers = append(blockers, "requires status checks: "+strings.Join(b.RequiredStatusChecks, ", "))
+	}
+	if b.PushRestricted {
+		blockers = append(blockers, "push access is restricted")
+	}
+	if b.Locked {
+		blockers = append(blockers, "branch is locked")
+	}
+	return blockers
+}
+
 // PRStatusSummary aggregates PR status information.
 type PRStatusSummary struct {
 	ReviewStatus  string // pending_review, changes_requested, approved
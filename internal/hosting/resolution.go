@@ -46,6 +46,16 @@ func ResolveConfig(workDir string, appCfg *config.Config) (ResolvedConfig, error
 		cfg.BaseURL = strings.TrimSpace(appCfg.Hosting.BaseURL)
 		cfg.TokenEnvVar = strings.TrimSpace(appCfg.Hosting.TokenEnvVar)
 		accountName = strings.TrimSpace(appCfg.Hosting.Account)
+		cfg.Retry = appCfg.RetryPolicy()
+
+		// completion.provider picks the provider the completion flow uses
+		// (PR/MR, CI, approval, merge); it must agree with hosting.provider.
+		if completionProvider := normalizeProviderSetting(appCfg.Completion.Provider); completionProvider != "" {
+			if current := normalizeProviderSetting(cfg.Provider); current != "" && current != completionProvider {
+				return ResolvedConfig{}, fmt.Errorf("completion.provider %q conflicts with hosting.provider %q", appCfg.Completion.Provider, cfg.Provider)
+			}
+			cfg.Provider = completionProvider
+		}
 	}
 
 	if accountName != "" {
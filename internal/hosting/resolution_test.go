@@ -125,6 +125,31 @@ func TestResolveConfigDetectsAccountConflict(t *testing.T) {
 	}
 }
 
+func TestResolveConfigCompletionProvider(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := config.Default()
+	cfg.Completion.Provider = "gitlab"
+	cfg.Hosting.BaseURL = "https://gitlab.company.com"
+
+	// No git remote: the provider comes from completion.provider alone
+	resolved, err := ResolveConfig(t.TempDir(), cfg)
+	if err != nil {
+		t.Fatalf("ResolveConfig: %v", err)
+	}
+	if resolved.ProviderType != ProviderGitLab {
+		t.Fatalf("ProviderType = %q, want %q", resolved.ProviderType, ProviderGitLab)
+	}
+	if resolved.TokenEnvVar != "ORC_GITLAB_TOKEN" {
+		t.Fatalf("TokenEnvVar = %q, want %q", resolved.TokenEnvVar, "ORC_GITLAB_TOKEN")
+	}
+
+	cfg.Hosting.Provider = "github"
+	if _, err := ResolveConfig(t.TempDir(), cfg); err == nil || !strings.Contains(err.Error(), "conflicts") {
+		t.Fatalf("ResolveConfig with differing hosting.provider error = %v, want conflict", err)
+	}
+}
+
 func TestResolveTokenFromEnv(t *testing.T) {
 	t.Setenv("ORC_GITHUB_TOKEN", "")
 	t.Setenv("ORC_GITLAB_TOKEN", "")
@@ -0,0 +1,146 @@
+// Package i18n provides translated user-facing strings for orc.
+//
+// Messages live in flat YAML catalogs under locales/, one file per locale,
+// keyed by dotted message IDs (e.g. "errors.task_not_found.what"). English is
+// the source catalog: a key missing from another locale falls back to English,
+// and a key missing from English renders as the key itself.
+//
+// Messages with arguments are fmt format strings. Messages that use only some
+// of their arguments, or reorder them, must use explicit argument indexes
+// (%[2]s) so every locale can place arguments freely.
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLocale is the source locale every catalog falls back to.
+const DefaultLocale = "en"
+
+//go:embed locales/*.yaml
+var localeFiles embed.FS
+
+// catalogs maps locale to message key to message.
+var catalogs = mustLoadCatalogs()
+
+// current is the process locale used by T.
+var current atomic.Value
+
+func init() {
+	current.Store(DefaultLocale)
+}
+
+func mustLoadCatalogs() map[string]map[string]string {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: read locales: %v", err))
+	}
+	result := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: read %s: %v", entry.Name(), err))
+		}
+		messages := make(map[string]string)
+		if err := yaml.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: parse %s: %v", entry.Name(), err))
+		}
+		result[strings.TrimSuffix(entry.Name(), ".yaml")] = messages
+	}
+	return result
+}
+
+// Supported returns the available locales, sorted.
+func Supported() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Normalize maps a locale tag such as "de-DE", "de_AT.UTF-8" or "DE" to a
+// supported locale. Returns "" when the language is not supported.
+func Normalize(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	if _, ok := catalogs[locale]; ok {
+		return locale
+	}
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		if _, ok := catalogs[locale[:i]]; ok {
+			return locale[:i]
+		}
+	}
+	return ""
+}
+
+// SetLocale sets the process locale used by T. Unsupported locales fall
+// back to DefaultLocale.
+func SetLocale(locale string) {
+	if normalized := Normalize(locale); normalized != "" {
+		current.Store(normalized)
+		return
+	}
+	current.Store(DefaultLocale)
+}
+
+// Locale returns the process locale.
+func Locale() string {
+	return current.Load().(string)
+}
+
+// T translates key into the process locale.
+func T(key string, args ...any) string {
+	return Translate(Locale(), key, args...)
+}
+
+// Translate translates key into locale, formatting args into the message.
+func Translate(locale, key string, args ...any) string {
+	msg, ok := catalogs[Normalize(locale)][key]
+	if !ok {
+		if msg, ok = catalogs[DefaultLocale][key]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 || !strings.Contains(msg, "%") {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// MatchAcceptLanguage returns the supported locale that best matches an
+// HTTP Accept-Language header, honoring q-values. Returns "" when nothing
+// in the header is supported.
+func MatchAcceptLanguage(header string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= bestQ {
+			continue
+		}
+		if locale := Normalize(tag); locale != "" {
+			best, bestQ = locale, q
+		}
+	}
+	return best
+}
@@ -0,0 +1,124 @@
+package i18n
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+)
+
+var placeholderPattern = regexp.MustCompile(`%(\[\d+\])?[a-z]`)
+
+// placeholders returns the sorted format verbs of a message.
+func placeholders(msg string) []string {
+	found := placeholderPattern.FindAllString(msg, -1)
+	sort.Strings(found)
+	return found
+}
+
+func TestCatalogsMatchSource(t *testing.T) {
+	source := catalogs[DefaultLocale]
+	if len(source) == 0 {
+		t.Fatal("source catalog is empty")
+	}
+	for locale, messages := range catalogs {
+		for key, msg := range messages {
+			want, ok := source[key]
+			if !ok {
+				t.Errorf("%s: key %q is not in the %s catalog", locale, key, DefaultLocale)
+				continue
+			}
+			got, wantVerbs := placeholders(msg), placeholders(want)
+			if len(got) != len(wantVerbs) {
+				t.Errorf("%s: %q placeholders = %v, want %v", locale, key, got, wantVerbs)
+				continue
+			}
+			for i := range got {
+				if got[i] != wantVerbs[i] {
+					t.Errorf("%s: %q placeholders = %v, want %v", locale, key, got, wantVerbs)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale string
+		key    string
+		args   []any
+		want   string
+	}{
+		{"english", "en", "errors.task_not_found.what", []any{"TASK-001"}, "task TASK-001 not found"},
+		{"german", "de", "errors.task_not_found.what", []any{"TASK-001"}, "Aufgabe TASK-001 nicht gefunden"},
+		{"region tag", "de-AT", "errors.label.error", nil, "Fehler"},
+		{"unsupported locale falls back to english", "fr", "errors.label.error", nil, "Error"},
+		{"unknown key renders the key", "de", "no.such.key", nil, "no.such.key"},
+		{"reordered arguments", "de", "notification.task_stale.message", []any{"TASK-001", "running", "2h", "1h"},
+			"TASK-001 ist seit 2h ohne Aktualisierung im Status running (Schwelle 1h)."},
+		{"message without verbs ignores args", "en", "errors.git_dirty.what", []any{"unused"},
+			"working directory has uncommitted changes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Translate(tt.locale, tt.key, tt.args...); got != tt.want {
+				t.Errorf("Translate(%q, %q) = %q, want %q", tt.locale, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"en":          "en",
+		"DE":          "de",
+		"de-DE":       "de",
+		"de_AT.UTF-8": "de",
+		"en_US@euro":  "en",
+		"fr":          "",
+		"":            "",
+	}
+	for in, want := range tests {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	defer SetLocale(DefaultLocale)
+
+	SetLocale("de-DE")
+	if got := Locale(); got != "de" {
+		t.Errorf("Locale() = %q, want de", got)
+	}
+	if got := T("errors.label.fix"); got != "Lösung" {
+		t.Errorf("T() = %q, want Lösung", got)
+	}
+
+	SetLocale("xx")
+	if got := Locale(); got != DefaultLocale {
+		t.Errorf("Locale() after unsupported = %q, want %q", got, DefaultLocale)
+	}
+}
+
+func TestMatchAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"de-DE", "de"},
+		{"fr-FR, de;q=0.8, en;q=0.5", "de"},
+		{"en;q=0.3, de;q=0.9", "de"},
+		{"de;q=0.2, en", "en"},
+		{"fr, *;q=0.5", ""},
+		{"de;q=bogus, en;q=0.1", "en"},
+	}
+	for _, tt := range tests {
+		if got := MatchAcceptLanguage(tt.header); got != tt.want {
+			t.Errorf("MatchAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
@@ -4,6 +4,8 @@ package embed
 import (
 	"context"
 	"fmt"
+
+	"github.com/randalmurphal/orc/internal/retry"
 )
 
 // Embedder is the interface for embedding providers.
@@ -16,6 +18,7 @@ type Embedder interface {
 type EmbedderConfig struct {
 	Model  string
 	APIKey string
+	Retry  retry.Policy
 }
 
 // NewEmbedder creates an embedder based on model configuration.
@@ -28,6 +31,7 @@ func NewEmbedder(cfg EmbedderConfig) (Embedder, error) {
 		return NewVoyageEmbedder(VoyageConfig{
 			APIKey: cfg.APIKey,
 			Model:  cfg.Model,
+			Retry:  cfg.Retry,
 		}), nil
 	case "voyage-4-nano":
 		return NewSidecarEmbedder(SidecarConfig{}), nil
@@ -8,12 +8,13 @@ import (
 	"net/http"
 	"os"
 	"time"
+
+	"github.com/randalmurphal/orc/internal/retry"
 )
 
 const (
 	voyageBatchSize  = 64
 	voyageDefaultURL = "https://api.voyageai.com/v1/embeddings"
-	maxRetries       = 3
 )
 
 // VoyageConfig configures the Voyage AI embedder.
@@ -21,6 +22,9 @@ type VoyageConfig struct {
 	APIKey  string
 	Model   string
 	BaseURL string
+	// Retry controls retries of rate-limited or unavailable requests
+	// (zero value: retry defaults).
+	Retry retry.Policy
 }
 
 type voyageRequest struct {
@@ -56,7 +60,10 @@ func NewVoyageEmbedder(cfg VoyageConfig) *VoyageEmbedder {
 		apiKey:  cfg.APIKey,
 		model:   cfg.Model,
 		baseURL: baseURL,
-		client:  &http.Client{Timeout: 60 * time.Second},
+		client: &http.Client{
+			Timeout:   60 * time.Second,
+			Transport: retry.NewTransport(nil, cfg.Retry),
+		},
 	}
 }
 
@@ -106,28 +113,18 @@ func (e *VoyageEmbedder) embedBatch(ctx context.Context, texts []string) ([][]fl
 		return nil, fmt.Errorf("marshal request: %w", err)
 	}
 
-	var resp *http.Response
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL, bytes.NewReader(body))
-		if err != nil {
-			return nil, fmt.Errorf("create request: %w", err)
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Authorization", "Bearer "+e.apiKey)
-
-		resp, err = e.client.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("voyage API request: %w", err)
-		}
-
-		if resp.StatusCode == http.StatusTooManyRequests {
-			_ = resp.Body.Close()
-			time.Sleep(time.Duration(attempt+1) * 100 * time.Millisecond)
-			continue
-		}
-		break
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
 	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+e.apiKey)
 
+	// The client's transport retries 429s and 503s with backoff.
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("voyage API request: %w", err)
+	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
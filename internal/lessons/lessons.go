@@ -0,0 +1,327 @@
+// Package lessons turns feedback the team keeps giving into the lessons.md
+// context document. Rejected human gates and review comments (including
+// imported PR comments) are clustered by wording; feedback that recurs
+// across tasks becomes a lesson, and the document is included in the
+// prompts of the phases it lists so agents stop repeating those mistakes.
+package lessons
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+// DocumentName is the context document holding the lessons.
+const DocumentName = "lessons.md"
+
+// The generated part of the document sits between these markers. Edits
+// outside them are kept on refresh; removing the markers stops refreshes.
+const (
+	beginMarker = "<!-- orc:lessons:begin -->"
+	endMarker   = "<!-- orc:lessons:end -->"
+)
+
+// Feedback sources.
+const (
+	SourceGate   = "gate"
+	SourceReview = "review"
+)
+
+// updatedBy is recorded as the author of generated document versions.
+const updatedBy = "orc"
+
+// similarityThreshold is the minimum Jaccard similarity of two pieces of
+// feedback's word sets for them to count as the same lesson.
+const similarityThreshold = 0.5
+
+// maxLessonText bounds the length of a lesson's text in bytes.
+const maxLessonText = 200
+
+// maxListedTasks bounds the task IDs listed with each lesson.
+const maxListedTasks = 5
+
+// Feedback is one piece of feedback given on a task.
+type Feedback struct {
+	TaskID string
+	Source string
+	Text   string
+	At     time.Time
+}
+
+// Lesson is feedback that recurs across tasks.
+type Lesson struct {
+	Text     string    `json:"text"`
+	Tasks    []string  `json:"tasks"`
+	Count    int       `json:"count"`
+	Sources  []string  `json:"sources"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// Collect returns the rejected human gate reasons and review comments
+// given at or after since, oldest first. A zero since collects everything.
+// Comments resolved as won't fix are not feedback the team stands by.
+func Collect(pdb *db.ProjectDB, since time.Time) ([]Feedback, error) {
+	decisions, err := pdb.ListRejectedGateDecisions("human")
+	if err != nil {
+		return nil, err
+	}
+	comments, err := pdb.ListAllReviewComments(since)
+	if err != nil {
+		return nil, err
+	}
+
+	var feedback []Feedback
+	for _, d := range decisions {
+		if !since.IsZero() && d.DecidedAt.Before(since) {
+			continue
+		}
+		feedback = append(feedback, Feedback{TaskID: d.TaskID, Source: SourceGate, Text: d.Reason, At: d.DecidedAt})
+	}
+	for _, c := range comments {
+		if c.Status == db.CommentStatusWontFix {
+			continue
+		}
+		feedback = append(feedback, Feedback{TaskID: c.TaskID, Source: SourceReview, Text: c.Content, At: c.CreatedAt})
+	}
+	slices.SortStableFunc(feedback, func(a, b Feedback) int { return a.At.Compare(b.At) })
+	return feedback, nil
+}
+
+type cluster struct {
+	words  map[string]bool
+	lesson Lesson
+}
+
+// Aggregate clusters similar feedback and returns the clusters given on at
+// least minOccurrences distinct tasks, most widespread first.
+func Aggregate(feedback []Feedback, minOccurrences int) []Lesson {
+	var clusters []*cluster
+	for _, f := range feedback {
+		text := normalizeText(f.Text)
+		words := wordSet(text)
+		if len(words) == 0 {
+			continue
+		}
+		var best *cluster
+		bestScore := similarityThreshold
+		for _, c := range clusters {
+			if score := jaccard(words, c.words); score >= bestScore {
+				best, bestScore = c, score
+			}
+		}
+		if best == nil {
+			best = &cluster{words: words, lesson: Lesson{Text: text}}
+			clusters = append(clusters, best)
+		}
+		l := &best.lesson
+		l.Count++
+		if !slices.Contains(l.Tasks, f.TaskID) {
+			l.Tasks = append(l.Tasks, f.TaskID)
+		}
+		if !slices.Contains(l.Sources, f.Source) {
+			l.Sources = append(l.Sources, f.Source)
+		}
+		if f.At.After(l.LastSeen) {
+			l.LastSeen = f.At
+		}
+		// The shortest wording states the lesson most directly
+		if len(text) < len(l.Text) {
+			l.Text = text
+		}
+	}
+
+	var lessons []Lesson
+	for _, c := range clusters {
+		if len(c.lesson.Tasks) < minOccurrences {
+			continue
+		}
+		slices.Sort(c.lesson.Tasks)
+		slices.Sort(c.lesson.Sources)
+		lessons = append(lessons, c.lesson)
+	}
+	slices.SortStableFunc(lessons, func(a, b Lesson) int {
+		if n := len(b.Tasks) - len(a.Tasks); n != 0 {
+			return n
+		}
+		if n := b.Count - a.Count; n != 0 {
+			return n
+		}
+		return b.LastSeen.Compare(a.LastSeen)
+	})
+	return lessons
+}
+
+// Render returns the generated section for lessons, markers included,
+// within maxBytes. The least widespread lessons are dropped first; a
+// non-positive maxBytes means no limit.
+func Render(lessons []Lesson, maxBytes int) string {
+	if len(lessons) == 0 {
+		return beginMarker + "\n" + endMarker
+	}
+	header := beginMarker + "\n## Lessons From Review Feedback\n\n" +
+		"Reviewers keep flagging these issues across tasks. Avoid repeating them.\n\n"
+	footer := endMarker
+
+	var sb strings.Builder
+	sb.WriteString(header)
+	for _, l := range lessons {
+		line := formatLesson(l)
+		if maxBytes > 0 && sb.Len()+len(line)+len(footer) > maxBytes {
+			break
+		}
+		sb.WriteString(line)
+	}
+	sb.WriteString(footer)
+	return sb.String()
+}
+
+func formatLesson(l Lesson) string {
+	tasks := l.Tasks
+	more := ""
+	if len(tasks) > maxListedTasks {
+		more = fmt.Sprintf(", +%d more", len(tasks)-maxListedTasks)
+		tasks = tasks[:maxListedTasks]
+	}
+	return fmt.Sprintf("- %s (%d tasks: %s%s)\n", l.Text, len(l.Tasks), strings.Join(tasks, ", "), more)
+}
+
+// Merge replaces the generated section of existing with section, keeping
+// everything outside the markers. It reports false, leaving existing
+// unchanged, when the markers were removed.
+func Merge(existing, section string) (string, bool) {
+	if strings.TrimSpace(existing) == "" {
+		return section, true
+	}
+	begin := strings.Index(existing, beginMarker)
+	end := strings.Index(existing, endMarker)
+	if begin < 0 || end < begin {
+		return existing, false
+	}
+	return existing[:begin] + section + existing[end+len(endMarker):], true
+}
+
+// Current returns the lessons in the project's feedback no older than
+// cfg.MaxAge.
+func Current(pdb *db.ProjectDB, cfg config.LessonsConfig, now time.Time) ([]Lesson, error) {
+	var since time.Time
+	if cfg.MaxAge > 0 {
+		since = now.Add(-cfg.MaxAge)
+	}
+	feedback, err := Collect(pdb, since)
+	if err != nil {
+		return nil, err
+	}
+	return Aggregate(feedback, cfg.MinOccurrences), nil
+}
+
+// Refresh regenerates the lessons document from the project's feedback and
+// returns it with the current lessons. A document is only created once
+// there is a lesson; a new document applies to cfg.Phases, while an
+// existing one keeps the phases it was edited to. The returned document is
+// nil if there is none.
+func Refresh(pdb *db.ProjectDB, cfg config.LessonsConfig, now time.Time) (*db.ContextDocument, []Lesson, error) {
+	lessons, err := Current(pdb, cfg, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc, err := pdb.GetContextDocument(DocumentName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if doc == nil {
+		if len(lessons) == 0 {
+			return nil, lessons, nil
+		}
+		doc = &db.ContextDocument{Name: DocumentName, Phases: slices.Clone(cfg.Phases)}
+	}
+
+	budget := cfg.MaxBytes
+	if budget > 0 {
+		// Manual edits count against the budget; at least 1 keeps it bounded
+		budget = max(budget-(len(doc.Content)-len(generatedSection(doc.Content))), 1)
+	}
+	content, ok := Merge(doc.Content, Render(lessons, budget))
+	if !ok {
+		return doc, lessons, nil
+	}
+	doc.Content = content
+	doc.UpdatedBy = updatedBy
+	if err := pdb.SaveContextDocument(doc); err != nil {
+		return nil, nil, err
+	}
+	return doc, lessons, nil
+}
+
+// generatedSection returns the marked section of content, markers
+// included, or "" if there is none.
+func generatedSection(content string) string {
+	begin := strings.Index(content, beginMarker)
+	end := strings.Index(content, endMarker)
+	if begin < 0 || end < begin {
+		return ""
+	}
+	return content[begin : end+len(endMarker)]
+}
+
+// normalizeText collapses feedback to one line of at most maxLessonText
+// bytes.
+func normalizeText(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) <= maxLessonText {
+		return text
+	}
+	cut := maxLessonText
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return strings.TrimSpace(text[:cut]) + "…"
+}
+
+// stopWords carry no meaning for matching feedback.
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "are": true, "but": true, "not": true,
+	"you": true, "all": true, "any": true, "can": true, "this": true, "that": true,
+	"with": true, "from": true, "have": true, "has": true, "was": true, "were": true,
+	"should": true, "would": true, "could": true, "please": true, "here": true,
+	"there": true, "into": true, "when": true, "its": true, "also": true, "again": true,
+	"need": true, "needs": true, "don": true, "doesn": true, "isn": true,
+}
+
+// wordSet returns the significant words of text, lowercased with plural
+// endings trimmed.
+func wordSet(text string) map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(w) < 3 || stopWords[w] {
+			continue
+		}
+		if len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+			w = w[:len(w)-1]
+		}
+		words[w] = true
+	}
+	return words
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	union := len(a) + len(b) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
@@ -0,0 +1,162 @@
+package lessons
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+)
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	feedback := []Feedback{
+		{TaskID: "TASK-001", Source: SourceReview, Text: "Missing tests for the error paths", At: now.Add(-3 * time.Hour)},
+		{TaskID: "TASK-002", Source: SourceGate, Text: "missing  tests for error paths again", At: now.Add(-2 * time.Hour)},
+		{TaskID: "TASK-002", Source: SourceReview, Text: "Missing tests for error path", At: now.Add(-time.Hour)},
+		{TaskID: "TASK-003", Source: SourceReview, Text: "Rename this variable", At: now},
+		{TaskID: "TASK-004", Source: SourceReview, Text: "...", At: now},
+	}
+
+	lessons := Aggregate(feedback, 2)
+	if len(lessons) != 1 {
+		t.Fatalf("Aggregate() = %+v, want one lesson", lessons)
+	}
+	l := lessons[0]
+	if l.Text != "Missing tests for error path" || l.Count != 3 {
+		t.Errorf("lesson = %+v", l)
+	}
+	if strings.Join(l.Tasks, ",") != "TASK-001,TASK-002" || strings.Join(l.Sources, ",") != "gate,review" {
+		t.Errorf("lesson tasks = %v, sources = %v", l.Tasks, l.Sources)
+	}
+	if !l.LastSeen.Equal(now.Add(-time.Hour)) {
+		t.Errorf("LastSeen = %v", l.LastSeen)
+	}
+
+	// Feedback repeated on one task alone is not a lesson
+	if got := Aggregate(feedback[1:3], 2); len(got) != 0 {
+		t.Errorf("single-task feedback = %+v, want none", got)
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+	lessons := []Lesson{
+		{Text: "Missing tests for error paths", Tasks: []string{"TASK-001", "TASK-002", "TASK-003"}},
+		{Text: "Log errors instead of dropping them", Tasks: []string{"TASK-004", "TASK-005"}},
+	}
+
+	full := Render(lessons, 0)
+	if !strings.HasPrefix(full, beginMarker) || !strings.HasSuffix(full, endMarker) {
+		t.Errorf("Render() missing markers:\n%s", full)
+	}
+	if !strings.Contains(full, "- Missing tests for error paths (3 tasks: TASK-001, TASK-002, TASK-003)\n") {
+		t.Errorf("Render() =\n%s", full)
+	}
+
+	bounded := Render(lessons, len(full)-10)
+	if len(bounded) > len(full)-10 || !strings.Contains(bounded, "Missing tests") || strings.Contains(bounded, "Log errors") {
+		t.Errorf("bounded Render() should drop the least widespread lesson:\n%s", bounded)
+	}
+
+	if got := Render(nil, 0); got != beginMarker+"\n"+endMarker {
+		t.Errorf("Render(nil) = %q", got)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+	section := beginMarker + "\nnew\n" + endMarker
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+		ok       bool
+	}{
+		{"empty", "", section, true},
+		{"keeps manual edits", "Team notes\n\n" + beginMarker + "\nold\n" + endMarker + "\nFooter", "Team notes\n\n" + section + "\nFooter", true},
+		{"markers removed", "Hand-written lessons", "Hand-written lessons", false},
+	}
+	for _, tt := range tests {
+		got, ok := Merge(tt.existing, section)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s: Merge() = %q, %v; want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	t.Parallel()
+	pdb := db.NewTestProjectDB(t)
+	cfg := config.Default().Brief.Lessons
+	now := time.Now()
+	for _, id := range []string{"TASK-001", "TASK-002", "TASK-003"} {
+		if err := pdb.SaveTask(&db.Task{ID: id, Title: id, Status: "completed"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// No recurring feedback yet: no document
+	if err := pdb.AddGateDecision(&db.GateDecision{TaskID: "TASK-001", Phase: "review", GateType: "human", Reason: "Missing tests for error paths", DecidedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	doc, _, err := Refresh(pdb, cfg, now)
+	if err != nil || doc != nil {
+		t.Fatalf("Refresh() = %+v, %v; want no document", doc, err)
+	}
+
+	comments := []*db.ReviewComment{
+		{TaskID: "TASK-002", Content: "missing tests for the error paths", Severity: db.SeverityIssue, Status: db.CommentStatusOpen},
+		{TaskID: "TASK-003", Content: "Missing tests for error paths", Severity: db.SeverityIssue, Status: db.CommentStatusWontFix},
+	}
+	for _, c := range comments {
+		if err := pdb.CreateReviewComment(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pdb.AddGateDecision(&db.GateDecision{TaskID: "TASK-003", Phase: "review", GateType: "ai", Reason: "Missing tests for error paths", DecidedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, lessons, err := Refresh(pdb, cfg, now)
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if len(lessons) != 1 || strings.Join(lessons[0].Tasks, ",") != "TASK-001,TASK-002" {
+		t.Fatalf("lessons = %+v, want the human gate and open comment only", lessons)
+	}
+	if doc == nil || doc.Version != 1 || doc.UpdatedBy != "orc" || strings.Join(doc.Phases, ",") != "implement,review" {
+		t.Fatalf("document = %+v", doc)
+	}
+
+	// Manual edits outside the markers and edited phases survive a refresh
+	doc.Content = "Always run make lint.\n\n" + doc.Content
+	doc.Phases = []string{"implement"}
+	if err := pdb.SaveContextDocument(doc); err != nil {
+		t.Fatal(err)
+	}
+	if err := pdb.CreateReviewComment(&db.ReviewComment{TaskID: "TASK-003", Content: "Missing tests for error paths", Severity: db.SeverityIssue, Status: db.CommentStatusOpen}); err != nil {
+		t.Fatal(err)
+	}
+	doc, _, err = Refresh(pdb, cfg, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(doc.Content, "Always run make lint.") || !strings.Contains(doc.Content, "(3 tasks:") || strings.Join(doc.Phases, ",") != "implement" {
+		t.Errorf("refreshed document = %+v", doc)
+	}
+
+	// An unchanged refresh keeps the version
+	version := doc.Version
+	if doc, _, err = Refresh(pdb, cfg, now); err != nil || doc.Version != version {
+		t.Errorf("unchanged Refresh() version = %d, want %d (err %v)", doc.Version, version, err)
+	}
+
+	// Feedback older than max_age does not count
+	doc, lessons, err = Refresh(pdb, cfg, now.Add(cfg.MaxAge+time.Hour))
+	if err != nil || len(lessons) != 0 || strings.Contains(doc.Content, "Missing tests") {
+		t.Errorf("aged Refresh() = %+v, %v, %v", doc, lessons, err)
+	}
+}
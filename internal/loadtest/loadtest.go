@@ -0,0 +1,422 @@
+// Package loadtest backs `orc bench server`: it drives an API server with
+// concurrent clients creating, updating and listing tasks while other clients
+// stream the resulting events, and reports latency percentiles per operation.
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/gen/proto/orc/v1/orcv1connect"
+	"github.com/randalmurphal/orc/internal/events"
+)
+
+// Operation names, in report order.
+const (
+	OpCreate = "create_task"
+	OpUpdate = "update_task"
+	OpList   = "list_tasks"
+	OpEvent  = "event_delivery"
+)
+
+// Options configures a load test run.
+type Options struct {
+	// Tasks is how many tasks are created (each is also updated once and
+	// followed by one list call).
+	Tasks int
+	// Concurrency is the number of client workers.
+	Concurrency int
+	// Subscribers is the number of event streams open during the run
+	// (default: Concurrency).
+	Subscribers int
+	// PageSize is the ListTasks page size (default: 50).
+	PageSize int32
+	// EventTimeout bounds how long subscribers wait for the last events
+	// after the workers finish (default: 10s).
+	EventTimeout time.Duration
+}
+
+func (o *Options) normalize() error {
+	if o.Tasks <= 0 {
+		return errors.New("tasks must be positive")
+	}
+	if o.Concurrency <= 0 {
+		return errors.New("concurrency must be positive")
+	}
+	if o.Subscribers < 0 {
+		return errors.New("subscribers must not be negative")
+	}
+	if o.Subscribers == 0 {
+		o.Subscribers = o.Concurrency
+	}
+	if o.PageSize <= 0 {
+		o.PageSize = 50
+	}
+	if o.EventTimeout <= 0 {
+		o.EventTimeout = 10 * time.Second
+	}
+	return nil
+}
+
+// OpStats summarizes the latencies of one operation.
+type OpStats struct {
+	Name      string  `json:"name"`
+	Count     int     `json:"count"`
+	Errors    int     `json:"errors"`
+	PerSecond float64 `json:"per_second"`
+	MeanMs    float64 `json:"mean_ms"`
+	P50Ms     float64 `json:"p50_ms"`
+	P90Ms     float64 `json:"p90_ms"`
+	P99Ms     float64 `json:"p99_ms"`
+	MaxMs     float64 `json:"max_ms"`
+}
+
+// Report is the result of a load test run.
+type Report struct {
+	Tasks       int       `json:"tasks"`
+	Concurrency int       `json:"concurrency"`
+	Subscribers int       `json:"subscribers"`
+	DurationMs  float64   `json:"duration_ms"`
+	Operations  []OpStats `json:"operations"`
+	// FirstError is a sample failure message, to tell what went wrong.
+	FirstError string `json:"first_error,omitempty"`
+}
+
+// Errors is the number of failed operations, including events a subscriber
+// never received.
+func (r *Report) Errors() int {
+	n := 0
+	for _, op := range r.Operations {
+		n += op.Errors
+	}
+	return n
+}
+
+// recorder collects latencies for every operation.
+type recorder struct {
+	mu         sync.Mutex
+	samples    map[string][]time.Duration
+	errors     map[string]int
+	firstError string
+}
+
+func newRecorder() *recorder {
+	return &recorder{samples: map[string][]time.Duration{}, errors: map[string]int{}}
+}
+
+func (r *recorder) record(op string, d time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.errors[op]++
+		if r.firstError == "" {
+			r.firstError = fmt.Sprintf("%s: %v", op, err)
+		}
+		return
+	}
+	r.samples[op] = append(r.samples[op], d)
+}
+
+func (r *recorder) fail(op string, n int, msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors[op] += n
+	if r.firstError == "" {
+		r.firstError = fmt.Sprintf("%s: %s", op, msg)
+	}
+}
+
+// Run load-tests the server at baseURL using the given project, which the
+// created tasks are added to. Point it at a test instance only.
+func Run(ctx context.Context, client *http.Client, baseURL, projectID string, opts Options) (*Report, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	tasks := orcv1connect.NewTaskServiceClient(client, baseURL)
+	eventsClient := orcv1connect.NewEventServiceClient(client, baseURL)
+
+	// Every subscriber sees every task_created event; latency is measured
+	// from the start of the CreateTask call, keyed by the unique title.
+	var starts sync.Map // title -> time.Time
+	rec := newRecorder()
+	subCtx, stopSubs := context.WithCancel(ctx)
+	defer stopSubs()
+
+	// Subscribe blocks until the first event arrives, so the streams are
+	// opened in their goroutines and warmUp waits for them
+	subs := make([]*subscriber, opts.Subscribers)
+	var subWG sync.WaitGroup
+	for i := range subs {
+		subs[i] = newSubscriber()
+		subWG.Add(1)
+		go func(s *subscriber) {
+			defer subWG.Done()
+			s.run(subCtx, eventsClient, &starts, rec)
+		}(subs[i])
+	}
+
+	runID := time.Now().UnixNano()
+	if err := warmUp(ctx, tasks, projectID, runID, subs); err != nil {
+		return nil, err
+	}
+
+	work := make(chan int)
+	var workWG sync.WaitGroup
+	start := time.Now()
+	for w := 0; w < opts.Concurrency; w++ {
+		workWG.Add(1)
+		go func() {
+			defer workWG.Done()
+			for i := range work {
+				title := fmt.Sprintf("load test %d #%d", runID, i)
+				runOne(ctx, tasks, projectID, title, opts.PageSize, &starts, rec)
+			}
+		}()
+	}
+	for i := 0; i < opts.Tasks; i++ {
+		if ctx.Err() != nil {
+			break
+		}
+		work <- i
+	}
+	close(work)
+	workWG.Wait()
+	elapsed := time.Since(start)
+
+	// Give the streams time to deliver the last events, then count the rest
+	// as dropped
+	created := len(rec.samplesOf(OpCreate))
+	deadline := time.After(opts.EventTimeout)
+	for _, s := range subs {
+		select {
+		case <-s.waitFor(created):
+		case <-deadline:
+		case <-ctx.Done():
+		}
+	}
+	stopSubs()
+	subWG.Wait()
+	for _, s := range subs {
+		if missing := created - s.count(); missing > 0 {
+			rec.fail(OpEvent, missing, fmt.Sprintf("%d task_created event(s) never arrived", missing))
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return rec.report(opts, elapsed), nil
+}
+
+// runOne creates one task, updates it, and lists the project's tasks.
+func runOne(ctx context.Context, tasks orcv1connect.TaskServiceClient, projectID, title string, pageSize int32, starts *sync.Map, rec *recorder) {
+	desc := "Created by orc bench server."
+	begin := time.Now()
+	starts.Store(title, begin)
+	created, err := tasks.CreateTask(ctx, connect.NewRequest(&orcv1.CreateTaskRequest{
+		ProjectId:   projectID,
+		Title:       title,
+		Description: &desc,
+	}))
+	rec.record(OpCreate, time.Since(begin), err)
+	if err != nil {
+		return
+	}
+
+	updated := desc + " Updated."
+	begin = time.Now()
+	_, err = tasks.UpdateTask(ctx, connect.NewRequest(&orcv1.UpdateTaskRequest{
+		ProjectId:   projectID,
+		TaskId:      created.Msg.GetTask().GetId(),
+		Description: &updated,
+	}))
+	rec.record(OpUpdate, time.Since(begin), err)
+
+	begin = time.Now()
+	_, err = tasks.ListTasks(ctx, connect.NewRequest(&orcv1.ListTasksRequest{
+		ProjectId: projectID,
+		Page:      &orcv1.PageRequest{Limit: pageSize},
+	}))
+	rec.record(OpList, time.Since(begin), err)
+}
+
+// warmUp creates probe tasks until every subscriber has received one, so the
+// streams are known to be registered before anything is timed.
+func warmUp(ctx context.Context, tasks orcv1connect.TaskServiceClient, projectID string, runID int64, subs []*subscriber) error {
+	for attempt := 0; attempt < 10; attempt++ {
+		_, err := tasks.CreateTask(ctx, connect.NewRequest(&orcv1.CreateTaskRequest{
+			ProjectId: projectID,
+			Title:     fmt.Sprintf("load test %d warm-up %d", runID, attempt),
+		}))
+		if err != nil {
+			return fmt.Errorf("warm-up task: %w", err)
+		}
+		ready := true
+		for _, s := range subs {
+			select {
+			case <-s.ready:
+			case <-s.done:
+				if s.err == nil {
+					return errors.New("event stream closed before the warm-up event")
+				}
+				return fmt.Errorf("subscribe to events: %w", s.err)
+			case <-time.After(time.Second):
+				ready = false
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if ready {
+			return nil
+		}
+	}
+	return errors.New("event subscribers never received a warm-up event")
+}
+
+// subscriber consumes one event stream.
+type subscriber struct {
+	ready     chan struct{}
+	readyOnce sync.Once
+	done      chan struct{}
+	err       error // why the stream ended, read after done is closed
+
+	mu       sync.Mutex
+	received int
+	notify   chan struct{}
+}
+
+func newSubscriber() *subscriber {
+	return &subscriber{ready: make(chan struct{}), done: make(chan struct{}), notify: make(chan struct{}, 1)}
+}
+
+func (s *subscriber) run(ctx context.Context, client orcv1connect.EventServiceClient, starts *sync.Map, rec *recorder) {
+	defer close(s.done)
+	stream, err := client.Subscribe(ctx, connect.NewRequest(&orcv1.SubscribeRequest{
+		EventTypes: []string{string(events.EventTaskCreated)},
+	}))
+	if err != nil {
+		s.err = err
+		return
+	}
+	defer func() { _ = stream.Close() }()
+	for stream.Receive() {
+		created := stream.Msg().GetEvent().GetTaskCreated()
+		if created == nil {
+			continue
+		}
+		s.readyOnce.Do(func() { close(s.ready) })
+		begin, ok := starts.Load(created.GetTitle())
+		if !ok {
+			continue // warm-up probe, or another client's task
+		}
+		rec.record(OpEvent, time.Since(begin.(time.Time)), nil)
+		s.mu.Lock()
+		s.received++
+		s.mu.Unlock()
+		select {
+		case s.notify <- struct{}{}:
+		default:
+		}
+	}
+	s.err = stream.Err()
+}
+
+func (s *subscriber) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.received
+}
+
+// waitFor returns a channel closed once n events have been received or the
+// stream has ended.
+func (s *subscriber) waitFor(n int) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for s.count() < n {
+			select {
+			case <-s.notify:
+			case <-s.done:
+				return
+			}
+		}
+	}()
+	return done
+}
+
+func (r *recorder) samplesOf(op string) []time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]time.Duration(nil), r.samples[op]...)
+}
+
+func (r *recorder) report(opts Options, elapsed time.Duration) *Report {
+	rep := &Report{
+		Tasks:       opts.Tasks,
+		Concurrency: opts.Concurrency,
+		Subscribers: opts.Subscribers,
+		DurationMs:  ms(elapsed),
+		FirstError:  r.firstError,
+	}
+	for _, op := range []string{OpCreate, OpUpdate, OpList, OpEvent} {
+		stats := summarize(op, r.samplesOf(op), elapsed)
+		r.mu.Lock()
+		stats.Errors = r.errors[op]
+		r.mu.Unlock()
+		rep.Operations = append(rep.Operations, stats)
+	}
+	return rep
+}
+
+// summarize computes the latency distribution of one operation.
+func summarize(op string, samples []time.Duration, elapsed time.Duration) OpStats {
+	stats := OpStats{Name: op, Count: len(samples)}
+	if len(samples) == 0 {
+		return stats
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	var total time.Duration
+	for _, d := range samples {
+		total += d
+	}
+	if elapsed > 0 {
+		stats.PerSecond = float64(len(samples)) / elapsed.Seconds()
+	}
+	stats.MeanMs = ms(total / time.Duration(len(samples)))
+	stats.P50Ms = ms(percentile(samples, 0.50))
+	stats.P90Ms = ms(percentile(samples, 0.90))
+	stats.P99Ms = ms(percentile(samples, 0.99))
+	stats.MaxMs = ms(samples[len(samples)-1])
+	return stats
+}
+
+// percentile returns the q-th quantile of sorted samples (nearest rank).
+func percentile(sorted []time.Duration, q float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(q*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,70 @@
+package loadtest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	samples := make([]time.Duration, 100)
+	for i := range samples {
+		samples[i] = time.Duration(i+1) * time.Millisecond
+	}
+	for _, tc := range []struct {
+		q    float64
+		want time.Duration
+	}{
+		{0.50, 50 * time.Millisecond},
+		{0.90, 90 * time.Millisecond},
+		{0.99, 99 * time.Millisecond},
+		{1, 100 * time.Millisecond},
+	} {
+		if got := percentile(samples, tc.q); got != tc.want {
+			t.Errorf("percentile(%v) = %v, want %v", tc.q, got, tc.want)
+		}
+	}
+	if got := percentile(nil, 0.5); got != 0 {
+		t.Errorf("percentile(nil) = %v, want 0", got)
+	}
+}
+
+func TestRun_AgainstSandbox(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("HOME", filepath.Join(root, "home"))
+
+	inst, err := Start(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = inst.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	rep, err := Run(ctx, nil, inst.URL, inst.ProjectID, Options{Tasks: 12, Concurrency: 4, Subscribers: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.Errors() != 0 {
+		t.Fatalf("errors = %d, first: %s", rep.Errors(), rep.FirstError)
+	}
+	want := map[string]int{OpCreate: 12, OpUpdate: 12, OpList: 12, OpEvent: 24}
+	for _, op := range rep.Operations {
+		if op.Count != want[op.Name] {
+			t.Errorf("%s count = %d, want %d", op.Name, op.Count, want[op.Name])
+		}
+		if op.Count > 0 && (op.P50Ms > op.P99Ms || op.P99Ms > op.MaxMs) {
+			t.Errorf("%s percentiles out of order: %+v", op.Name, op)
+		}
+	}
+}
+
+func TestRun_RejectsBadOptions(t *testing.T) {
+	if _, err := Run(context.Background(), nil, "http://127.0.0.1:1", "p", Options{Tasks: 0, Concurrency: 1}); err == nil {
+		t.Error("expected error for zero tasks")
+	}
+	if _, err := Run(context.Background(), nil, "http://127.0.0.1:1", "p", Options{Tasks: 1, Concurrency: 0}); err == nil {
+		t.Error("expected error for zero concurrency")
+	}
+}
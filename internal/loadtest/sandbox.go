@@ -0,0 +1,111 @@
+package loadtest
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/randalmurphal/orc/internal/api"
+	"github.com/randalmurphal/orc/internal/bootstrap"
+	"github.com/randalmurphal/orc/internal/config"
+)
+
+// Instance is an API server on a throwaway project, started by Start.
+type Instance struct {
+	URL       string
+	ProjectID string
+	Dir       string
+
+	server *api.Server
+	http   *http.Server
+}
+
+// Start creates an empty project under root (a temporary directory the
+// caller removes), and serves it on a random loopback port. HOME should point
+// inside root too, so the registry and global database are sandboxed as well.
+//
+// Only the request handlers run: background pollers stay off so they don't
+// skew the numbers.
+func Start(root string) (*Instance, error) {
+	dir := filepath.Join(root, "load-test")
+	if err := writeRepo(dir); err != nil {
+		return nil, fmt.Errorf("create load test repo: %w", err)
+	}
+	result, err := bootstrap.Run(bootstrap.Options{
+		WorkDir:          dir,
+		SkipClaudeMD:     true,
+		SkipHooks:        true,
+		SkipGitignore:    true,
+		SkipConstitution: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("init load test project: %w", err)
+	}
+	cfg, err := config.LoadFile(result.ConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("load load test config: %w", err)
+	}
+	cfg.FeatureFlags = map[string]bool{"automation": false}
+	if err := cfg.SaveTo(result.ConfigPath); err != nil {
+		return nil, fmt.Errorf("write load test config: %w", err)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("listen: %w", err)
+	}
+	server := api.New(&api.Config{
+		Addr:    ln.Addr().String(),
+		WorkDir: dir,
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	inst := &Instance{
+		URL:       "http://" + ln.Addr().String(),
+		ProjectID: result.ProjectID,
+		Dir:       dir,
+		server:    server,
+		http:      &http.Server{Handler: server.Handler()},
+	}
+	go func() { _ = inst.http.Serve(ln) }()
+	return inst, nil
+}
+
+// Close stops the server and closes its databases.
+func (i *Instance) Close() error {
+	err := i.http.Close()
+	if cache := i.server.ProjectCache(); cache != nil {
+		_ = cache.Close()
+	}
+	if gdb := i.server.GlobalDB(); gdb != nil {
+		_ = gdb.Close()
+	}
+	_ = i.server.Backend().Close()
+	return err
+}
+
+// writeRepo creates an empty git repository for the load test project.
+func writeRepo(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# load-test\n"), 0644); err != nil {
+		return err
+	}
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"add", "-A"},
+		{"-c", "user.name=orc bench", "-c", "user.email=bench@orc.invalid", "commit", "-q", "-m", "Initial commit"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("git %s: %w: %s", args[0], err, out)
+		}
+	}
+	return nil
+}
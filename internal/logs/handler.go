@@ -0,0 +1,225 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Options configures a Handler.
+type Options struct {
+	// Path receives every record (e.g. ServerPath). Optional.
+	Path string
+	// Root routes records that carry a task ID to TaskPath(Root, id).
+	// Optional.
+	Root string
+	// TaskID is used for records that don't name a task themselves.
+	TaskID string
+	// Component is recorded when a record has no "component" attribute.
+	Component string
+	// Level is the minimum level written to the files. The zero value is
+	// info; use slog.LevelDebug to keep everything.
+	Level slog.Level
+}
+
+// Handler writes records as JSON lines to the configured log files and
+// forwards them unchanged to the next handler (usually the console).
+type Handler struct {
+	next   slog.Handler
+	sink   *sink
+	attrs  []slog.Attr
+	prefix string // Group prefix for attributes added from here on
+}
+
+// sink holds the open files shared by a Handler and its WithAttrs/WithGroup
+// children.
+type sink struct {
+	opts  Options
+	mu    sync.Mutex
+	main  *os.File
+	tasks map[string]*os.File
+}
+
+// NewHandler opens the files for opts. next may be nil.
+func NewHandler(next slog.Handler, opts Options) (*Handler, error) {
+	s := &sink{opts: opts, tasks: make(map[string]*os.File)}
+	if opts.Path != "" {
+		f, err := openAppend(opts.Path)
+		if err != nil {
+			return nil, err
+		}
+		s.main = f
+	}
+	return &Handler{next: next, sink: s}, nil
+}
+
+// Close closes the log files. Records handled afterwards are only forwarded.
+func (h *Handler) Close() error {
+	h.sink.mu.Lock()
+	defer h.sink.mu.Unlock()
+	var firstErr error
+	if h.sink.main != nil {
+		firstErr = h.sink.main.Close()
+		h.sink.main = nil
+	}
+	for id, f := range h.sink.tasks {
+		if err := f.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(h.sink.tasks, id)
+	}
+	h.sink.opts.Path, h.sink.opts.Root = "", ""
+	return firstErr
+}
+
+// Enabled implements slog.Handler.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.sink.opts.Level || (h.next != nil && h.next.Enabled(ctx, level))
+}
+
+// Handle implements slog.Handler.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	fields := map[string]any{
+		"time":  r.Time.Format(time.RFC3339Nano),
+		"level": r.Level.String(),
+		"msg":   r.Message,
+	}
+	for _, a := range h.attrs {
+		addAttr(fields, "", a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		addAttr(fields, h.prefix, a)
+		return true
+	})
+	if _, ok := fields["component"]; !ok && h.sink.opts.Component != "" {
+		fields["component"] = h.sink.opts.Component
+		r = r.Clone()
+		r.AddAttrs(slog.String("component", h.sink.opts.Component))
+	}
+
+	if h.next != nil && h.next.Enabled(ctx, r.Level) {
+		if err := h.next.Handle(ctx, r); err != nil {
+			return err
+		}
+	}
+	if r.Level < h.sink.opts.Level {
+		return nil
+	}
+	taskID, _ := fields["task_id"].(string)
+	if taskID == "" {
+		taskID, _ = fields["task"].(string) // Older call sites log "task"
+	}
+	if taskID == "" {
+		taskID = h.sink.opts.TaskID
+	}
+	if taskID != "" {
+		fields["task_id"] = taskID
+	}
+	line, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("encode log record: %w", err)
+	}
+	return h.sink.write(taskID, append(line, '\n'))
+}
+
+// WithAttrs implements slog.Handler.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	child := *h
+	child.attrs = append([]slog.Attr{}, h.attrs...)
+	for _, a := range attrs {
+		if h.prefix != "" {
+			a.Key = h.prefix + a.Key
+		}
+		child.attrs = append(child.attrs, a)
+	}
+	if h.next != nil {
+		child.next = h.next.WithAttrs(attrs)
+	}
+	return &child
+}
+
+// WithGroup implements slog.Handler. Grouped attributes are flattened to
+// dotted keys.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	child := *h
+	child.prefix = h.prefix + name + "."
+	if h.next != nil {
+		child.next = h.next.WithGroup(name)
+	}
+	return &child
+}
+
+func (s *sink) write(taskID string, line []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.main != nil {
+		if _, err := s.main.Write(line); err != nil {
+			return fmt.Errorf("write log: %w", err)
+		}
+	}
+	if s.opts.Root == "" || taskID == "" {
+		return nil
+	}
+	f, ok := s.tasks[taskID]
+	if !ok {
+		path, err := TaskPath(s.opts.Root, taskID)
+		if err != nil {
+			return nil // Not a usable task ID; the record still reached the main log
+		}
+		if f, err = openAppend(path); err != nil {
+			return err
+		}
+		s.tasks[taskID] = f
+	}
+	if _, err := f.Write(line); err != nil {
+		return fmt.Errorf("write task log: %w", err)
+	}
+	return nil
+}
+
+// addAttr flattens a into fields, resolving LogValuers and groups.
+func addAttr(fields map[string]any, prefix string, a slog.Attr) {
+	v := a.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		p := prefix
+		if a.Key != "" {
+			p = prefix + a.Key + "."
+		}
+		for _, ga := range v.Group() {
+			addAttr(fields, p, ga)
+		}
+		return
+	}
+	if a.Key == "" {
+		return
+	}
+	key := prefix + a.Key
+	switch v.Kind() {
+	case slog.KindTime:
+		fields[key] = v.Time().Format(time.RFC3339Nano)
+	case slog.KindDuration:
+		fields[key] = v.Duration().String()
+	case slog.KindAny:
+		switch x := v.Any().(type) {
+		case error:
+			fields[key] = x.Error()
+		case fmt.Stringer:
+			fields[key] = x.String()
+		default:
+			if _, err := json.Marshal(x); err != nil {
+				fields[key] = fmt.Sprint(x)
+			} else {
+				fields[key] = x
+			}
+		}
+	default:
+		fields[key] = v.Any()
+	}
+}
@@ -0,0 +1,210 @@
+// Package logs persists structured server and executor logs as JSON lines
+// in the project's runtime data directory, so they can be tailed and
+// filtered by `orc logs` and GET /api/logs instead of grepping server stdout.
+//
+// Layout (see project.ProjectLogsDir):
+//
+//	~/.orc/projects/<id>/logs/server.log          every record logged by `orc serve`
+//	~/.orc/projects/<id>/logs/tasks/<task-id>.log  records for one task's execution
+//
+// Projects not in the registry yet fall back to <project>/.orc/logs.
+package logs
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/project"
+)
+
+const (
+	// ServerFile is the server log file name.
+	ServerFile = "server.log"
+
+	// maxFileSize is the size at which a log file is rotated to <name>.1 when
+	// it is opened. Only one previous generation is kept.
+	maxFileSize = 10 << 20
+)
+
+// Entry is one parsed log record. Component, TaskID and Phase are lifted out
+// of the record's attributes; everything else stays in Attrs.
+type Entry struct {
+	Time      time.Time      `json:"time"`
+	Level     string         `json:"level"`
+	Msg       string         `json:"msg"`
+	Component string         `json:"component,omitempty"`
+	TaskID    string         `json:"task_id,omitempty"`
+	Phase     string         `json:"phase,omitempty"`
+	Attrs     map[string]any `json:"attrs,omitempty"`
+}
+
+// Page is a batch of entries and the offset to continue from. It is the
+// GET /api/logs response body.
+type Page struct {
+	Entries []Entry `json:"entries"`
+	Offset  int64   `json:"offset"`
+}
+
+// Dir returns the log directory for the project at projectRoot.
+func Dir(projectRoot string) string {
+	if id, err := project.ResolveProjectID(projectRoot); err == nil {
+		if dir, err := project.ProjectLogsDir(id); err == nil {
+			return dir
+		}
+	}
+	return filepath.Join(projectRoot, ".orc", "logs")
+}
+
+// ServerPath returns the server log path for a project.
+func ServerPath(projectRoot string) string {
+	return filepath.Join(Dir(projectRoot), ServerFile)
+}
+
+// TaskPath returns the execution log path for a task. The task ID becomes a
+// file name, so anything that could escape the directory is rejected.
+func TaskPath(projectRoot, taskID string) (string, error) {
+	if taskID == "" || taskID != filepath.Base(taskID) || strings.HasPrefix(taskID, ".") {
+		return "", fmt.Errorf("invalid task ID %q", taskID)
+	}
+	return filepath.Join(Dir(projectRoot), "tasks", taskID+".log"), nil
+}
+
+// ParseLevel parses a level name (debug, info, warn, error). Empty means debug,
+// so an unset filter shows everything.
+func ParseLevel(s string) (slog.Level, error) {
+	if s == "" {
+		return slog.LevelDebug, nil
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("invalid level %q (valid: debug, info, warn, error)", s)
+	}
+	return level, nil
+}
+
+// Filter selects entries. Empty string fields match everything.
+type Filter struct {
+	// Level is the minimum level. Its zero value is info; ParseLevel("")
+	// gives debug, which matches every entry.
+	Level     slog.Level
+	TaskID    string
+	Phase     string
+	Component string
+}
+
+// Match reports whether e passes the filter.
+func (f Filter) Match(e Entry) bool {
+	if level, err := ParseLevel(e.Level); err == nil && level < f.Level {
+		return false
+	}
+	if f.TaskID != "" && e.TaskID != f.TaskID {
+		return false
+	}
+	if f.Phase != "" && !strings.EqualFold(e.Phase, f.Phase) {
+		return false
+	}
+	if f.Component != "" && !strings.EqualFold(e.Component, f.Component) {
+		return false
+	}
+	return true
+}
+
+// Read returns the entries matching f that start at or after byte offset in
+// path, and the offset to pass next time to pick up only newer entries. A
+// trailing partial line is left for the next read. A missing file yields no
+// entries; an offset past the end (the file was rotated) starts over.
+func Read(path string, offset int64, f Filter) ([]Entry, int64, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, offset, fmt.Errorf("open log: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, offset, fmt.Errorf("stat log: %w", err)
+	}
+	if offset < 0 || offset > info.Size() {
+		offset = 0
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, fmt.Errorf("seek log: %w", err)
+	}
+
+	var entries []Entry
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break // Partial line (or nothing): wait for the writer to finish it
+		}
+		if err != nil {
+			return entries, offset, fmt.Errorf("read log: %w", err)
+		}
+		offset += int64(len(line))
+		e, ok := parseLine(line)
+		if ok && f.Match(e) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, offset, nil
+}
+
+// Tail returns the last n entries (all of them when n <= 0).
+func Tail(entries []Entry, n int) []Entry {
+	if n <= 0 || len(entries) <= n {
+		return entries
+	}
+	return entries[len(entries)-n:]
+}
+
+// parseLine decodes one JSON log line. Lines that aren't log records are
+// skipped rather than failing the read.
+func parseLine(line []byte) (Entry, bool) {
+	var raw map[string]any
+	if err := json.Unmarshal(line, &raw); err != nil {
+		return Entry{}, false
+	}
+	var e Entry
+	if s, ok := raw["time"].(string); ok {
+		e.Time, _ = time.Parse(time.RFC3339Nano, s)
+	}
+	e.Level, _ = raw["level"].(string)
+	e.Msg, _ = raw["msg"].(string)
+	e.Component, _ = raw["component"].(string)
+	e.TaskID, _ = raw["task_id"].(string)
+	e.Phase, _ = raw["phase"].(string)
+	for _, k := range []string{"time", "level", "msg", "component", "task_id", "phase"} {
+		delete(raw, k)
+	}
+	if len(raw) > 0 {
+		e.Attrs = raw
+	}
+	return e, true
+}
+
+// openAppend opens path for appending, creating its directory and rotating
+// it first when it has grown past maxFileSize.
+func openAppend(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("create log dir: %w", err)
+	}
+	if info, err := os.Stat(path); err == nil && info.Size() > maxFileSize {
+		_ = os.Rename(path, path+".1")
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open log: %w", err)
+	}
+	return f, nil
+}
@@ -0,0 +1,106 @@
+package logs
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHandler_WritesServerAndTaskLogs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+
+	h, err := NewHandler(nil, Options{Path: ServerPath(root), Root: root, Component: "server", Level: slog.LevelDebug})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := slog.New(h)
+	logger.Debug("starting")
+	logger.With("component", "executor", "task_id", "TASK-001").Info("phase started", "phase", "implement", "attempt", 2)
+	logger.WithGroup("req").Warn("slow", "ms", 900)
+	logger.Error("phase failed", "task", "TASK-001", "error", errors.New("boom"))
+	if err := h.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	server, _, err := Read(ServerPath(root), 0, Filter{Level: slog.LevelDebug})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(server) != 4 {
+		t.Fatalf("server log has %d entries, want 4: %+v", len(server), server)
+	}
+	if server[0].Component != "server" || server[0].Level != "DEBUG" {
+		t.Errorf("first entry = %+v", server[0])
+	}
+	if e := server[1]; e.Component != "executor" || e.TaskID != "TASK-001" || e.Phase != "implement" || e.Attrs["attempt"] != float64(2) {
+		t.Errorf("executor entry = %+v", e)
+	}
+	if server[2].Attrs["req.ms"] != float64(900) {
+		t.Errorf("grouped attrs = %v", server[2].Attrs)
+	}
+
+	taskPath, _ := TaskPath(root, "TASK-001")
+	taskEntries, _, err := Read(taskPath, 0, Filter{Level: slog.LevelDebug})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(taskEntries) != 2 || taskEntries[1].Attrs["error"] != "boom" || taskEntries[1].TaskID != "TASK-001" {
+		t.Errorf("task log = %+v", taskEntries)
+	}
+}
+
+func TestRead_OffsetAndFilter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	h, err := NewHandler(nil, Options{Path: path, Level: slog.LevelDebug})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := slog.New(h)
+	logger.Debug("noise")
+	logger.Info("one", "phase", "implement")
+
+	entries, offset, err := Read(path, 0, Filter{Level: slog.LevelInfo})
+	if err != nil || len(entries) != 1 || entries[0].Msg != "one" {
+		t.Fatalf("entries = %+v, err = %v", entries, err)
+	}
+
+	// A partial line is left for the next read
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"level":"INFO","msg":"half`)
+	entries, next, err := Read(path, offset, Filter{Level: slog.LevelDebug})
+	if err != nil || len(entries) != 0 || next != offset {
+		t.Fatalf("partial read = %+v, offset %d (want %d), err %v", entries, next, offset, err)
+	}
+	_, _ = f.WriteString(`"}` + "\n")
+	_ = f.Close()
+	logger.Info("two", "phase", "review")
+	_ = h.Close()
+
+	entries, _, err = Read(path, offset, Filter{Level: slog.LevelDebug, Phase: "REVIEW"})
+	if err != nil || len(entries) != 1 || entries[0].Msg != "two" {
+		t.Errorf("filtered entries = %+v, err = %v", entries, err)
+	}
+
+	// An offset past the end means the file was rotated: start over
+	entries, _, _ = Read(path, 1<<40, Filter{Level: slog.LevelDebug})
+	if len(entries) != 4 {
+		t.Errorf("after rotation got %d entries, want 4", len(entries))
+	}
+	if entries, offset, err := Read(filepath.Join(t.TempDir(), "missing.log"), 0, Filter{Level: slog.LevelDebug}); err != nil || len(entries) != 0 || offset != 0 {
+		t.Errorf("missing file = %v, %d, %v", entries, offset, err)
+	}
+}
+
+func TestTaskPath_RejectsTraversal(t *testing.T) {
+	for _, id := range []string{"", "../x", "a/b", ".hidden"} {
+		if _, err := TaskPath(t.TempDir(), id); err == nil {
+			t.Errorf("TaskPath(%q) succeeded", id)
+		}
+	}
+}
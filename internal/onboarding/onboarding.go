@@ -0,0 +1,374 @@
+// Package onboarding builds the report shown after `orc init`: what was
+// detected in the project, the commands orc will run, a recommended
+// automation profile, and the prerequisites still missing for a working
+// setup. It is saved to .orc/onboarding.md and served by
+// GET /api/projects/{id}/onboarding.
+package onboarding
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/detect"
+	"github.com/randalmurphal/orc/internal/hosting"
+)
+
+// FileName is the report's name inside .orc/.
+const FileName = "onboarding.md"
+
+// Overridable for tests.
+var (
+	execCommand = exec.Command
+	lookPath    = exec.LookPath
+)
+
+// Language is one detected language and where it lives.
+type Language struct {
+	Name       string   `json:"name"`
+	Path       string   `json:"path"` // Relative to the project root; "" is the root
+	Frameworks []string `json:"frameworks,omitempty"`
+	BuildTool  string   `json:"build_tool,omitempty"`
+}
+
+// Command is a suggested quality command.
+type Command struct {
+	Kind     string `json:"kind"` // test, lint, build
+	Command  string `json:"command"`
+	Path     string `json:"path,omitempty"`
+	Runnable bool   `json:"runnable"` // Its binary is on PATH
+}
+
+// Check is one prerequisite.
+type Check struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail"`
+	// Fix is the command or step that resolves a failed check.
+	Fix string `json:"fix,omitempty"`
+	// Required checks block running tasks; the others only affect PRs,
+	// hosting features or frontend testing.
+	Required bool `json:"required"`
+}
+
+// Report is the onboarding report for one project.
+type Report struct {
+	ProjectPath        string     `json:"project_path"`
+	GeneratedAt        time.Time  `json:"generated_at"`
+	Languages          []Language `json:"languages"`
+	HasTests           bool       `json:"has_tests"`
+	HasCI              bool       `json:"has_ci"`
+	HasFrontend        bool       `json:"has_frontend"`
+	Commands           []Command  `json:"commands"`
+	CurrentProfile     string     `json:"current_profile"`
+	RecommendedProfile string     `json:"recommended_profile"`
+	ProfileReason      string     `json:"profile_reason"`
+	Prerequisites      []Check    `json:"prerequisites"`
+	NextSteps          []string   `json:"next_steps"`
+}
+
+// Missing returns the failed prerequisite checks.
+func (r *Report) Missing() []Check {
+	var out []Check
+	for _, c := range r.Prerequisites {
+		if !c.OK {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Path returns where the report for projectPath is saved.
+func Path(projectPath string) string {
+	return filepath.Join(projectPath, ".orc", FileName)
+}
+
+// Generate inspects projectPath. cfg is the project's loaded config; nil
+// uses the defaults.
+func Generate(projectPath string, cfg *config.Config) (*Report, error) {
+	if cfg == nil {
+		cfg = config.Default()
+	}
+	md, err := detect.DetectMulti(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("detect project: %w", err)
+	}
+	single, err := detect.Detect(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("detect project: %w", err)
+	}
+
+	r := &Report{
+		ProjectPath:    projectPath,
+		GeneratedAt:    time.Now().UTC(),
+		HasTests:       single.HasTests,
+		HasCI:          md.HasCI,
+		HasFrontend:    md.HasFrontend,
+		CurrentProfile: string(cfg.Profile),
+	}
+	if r.CurrentProfile == "" {
+		r.CurrentProfile = string(config.ProfileAuto)
+	}
+
+	for _, l := range md.Languages {
+		lang := Language{Name: string(l.Language), Path: l.RootPath, BuildTool: string(l.BuildTool)}
+		for _, f := range l.Frameworks {
+			lang.Frameworks = append(lang.Frameworks, string(f))
+		}
+		r.Languages = append(r.Languages, lang)
+		for _, c := range []struct{ kind, cmd string }{
+			{"test", l.TestCommand}, {"lint", l.LintCommand}, {"build", l.BuildCommand},
+		} {
+			if c.cmd != "" {
+				r.Commands = append(r.Commands, Command{Kind: c.kind, Command: c.cmd, Path: l.RootPath, Runnable: runnable(c.cmd)})
+			}
+		}
+	}
+
+	r.RecommendedProfile, r.ProfileReason = recommendProfile(r)
+	r.Prerequisites = checkPrerequisites(projectPath, cfg, r.HasFrontend)
+	r.NextSteps = nextSteps(r)
+	return r, nil
+}
+
+// recommendProfile picks the automation profile for what was detected.
+func recommendProfile(r *Report) (string, string) {
+	switch {
+	case !r.HasTests:
+		return string(config.ProfileSafe), "no tests were detected, so merges wait for human approval until a test suite can gate them"
+	case r.HasCI:
+		return string(config.ProfileAuto), "tests and CI are in place to catch regressions, so tasks can run and merge unattended"
+	default:
+		return string(config.ProfileAuto), "tests were detected and run as quality checks; consider 'safe' until CI also runs them"
+	}
+}
+
+func checkPrerequisites(projectPath string, cfg *config.Config, frontend bool) []Check {
+	var checks []Check
+
+	git := Check{Name: "git", Required: true}
+	if _, err := lookPath("git"); err != nil {
+		git.Detail = "git not found in PATH"
+		git.Fix = "Install git"
+	} else {
+		git.OK, git.Detail = true, "git available"
+	}
+	checks = append(checks, git)
+
+	repo := Check{Name: "git repository", Required: true}
+	if !git.OK {
+		repo.Detail = "skipped: git is not installed"
+	} else if err := execCommand("git", "-C", projectPath, "rev-parse", "--verify", "HEAD").Run(); err != nil {
+		repo.Detail = "no commits yet; worktrees branch from an existing commit"
+		repo.Fix = `git add -A && git commit -m "Initial commit"`
+	} else {
+		repo.OK, repo.Detail = true, "repository has commits"
+	}
+	checks = append(checks, repo)
+
+	claudeBin := "claude"
+	if strings.TrimSpace(cfg.ClaudePath) != "" {
+		claudeBin = cfg.ClaudePath
+	}
+	claude := Check{Name: "claude CLI", Required: true}
+	if _, err := lookPath(claudeBin); err != nil {
+		claude.Detail = fmt.Sprintf("%q not found in PATH; phases cannot run", claudeBin)
+		claude.Fix = "npm install -g @anthropic-ai/claude-code"
+	} else {
+		claude.OK, claude.Detail = true, fmt.Sprintf("%q available", claudeBin)
+	}
+	checks = append(checks, claude)
+
+	checks = append(checks, hostingChecks(projectPath, cfg)...)
+
+	if frontend {
+		npx := Check{Name: "npx (Playwright)"}
+		if _, err := lookPath("npx"); err != nil {
+			npx.Detail = "frontend detected but npx is missing; browser QA phases cannot run"
+			npx.Fix = "Install Node.js, then: npx playwright install"
+		} else {
+			npx.OK, npx.Detail = true, "npx available for Playwright"
+		}
+		checks = append(checks, npx)
+	}
+	return checks
+}
+
+// hostingChecks covers what PR creation needs: a recognised remote, the
+// API token orc uses, and the provider CLI login.
+func hostingChecks(projectPath string, cfg *config.Config) []Check {
+	resolved, err := hosting.ResolveConfig(projectPath, cfg)
+	if err != nil {
+		return []Check{{
+			Name:   "hosting provider",
+			Detail: err.Error(),
+			Fix:    "git remote add origin <url>, or set hosting.provider",
+		}}
+	}
+	checks := []Check{{
+		Name:   "hosting provider",
+		OK:     true,
+		Detail: fmt.Sprintf("%s via the origin remote", resolved.ProviderType),
+	}}
+
+	cli, login := "gh", "gh auth login"
+	tokenCmd := "gh auth token"
+	if resolved.ProviderType == hosting.ProviderGitLab {
+		cli, login = "glab", "glab auth login"
+		tokenCmd = ""
+	}
+
+	auth := Check{Name: cli + " auth"}
+	if _, err := lookPath(cli); err != nil {
+		auth.Detail = cli + " not installed"
+		auth.Fix = fmt.Sprintf("Install the %s CLI, then: %s", cli, login)
+	} else if err := execCommand(cli, "auth", "status").Run(); err != nil {
+		auth.Detail = cli + " is not logged in"
+		auth.Fix = login
+	} else {
+		auth.OK, auth.Detail = true, cli+" is logged in"
+	}
+	checks = append(checks, auth)
+
+	token := Check{Name: "hosting token"}
+	if _, err := hosting.ResolveTokenFromEnv(resolved.Config, resolved.ProviderType); err != nil {
+		token.Detail = err.Error()
+		token.Fix = fmt.Sprintf("export %s=<token>", resolved.TokenEnvVar)
+		if auth.OK && tokenCmd != "" {
+			token.Fix = fmt.Sprintf("export %s=$(%s)", resolved.TokenEnvVar, tokenCmd)
+		}
+	} else {
+		token.OK, token.Detail = true, resolved.TokenEnvVar+" is set"
+	}
+	checks = append(checks, token)
+	return checks
+}
+
+func nextSteps(r *Report) []string {
+	var steps []string
+	for _, c := range r.Missing() {
+		if c.Required && c.Fix != "" {
+			steps = append(steps, fmt.Sprintf("Fix %s: %s", c.Name, c.Fix))
+		}
+	}
+	for _, c := range r.Missing() {
+		if !c.Required && c.Fix != "" {
+			steps = append(steps, fmt.Sprintf("For PRs and hosting features, fix %s: %s", c.Name, c.Fix))
+		}
+	}
+	if r.RecommendedProfile != r.CurrentProfile {
+		steps = append(steps, "Switch to the recommended profile: orc config set profile "+r.RecommendedProfile)
+	}
+	if len(r.Commands) == 0 {
+		steps = append(steps, "Register the commands quality checks run: orc config commands set tests \"<command>\"")
+	}
+	steps = append(steps,
+		"Check a workflow end to end: orc doctor",
+		`Create your first task: orc new "Describe the change"`,
+		"Open the dashboard: orc serve",
+	)
+	return steps
+}
+
+// runnable reports whether a command's binary is on PATH.
+func runnable(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return false
+	}
+	_, err := lookPath(fields[0])
+	return err == nil
+}
+
+// Markdown renders the report.
+func (r *Report) Markdown() []byte {
+	var b bytes.Buffer
+	b.WriteString("# orc onboarding\n\n")
+	fmt.Fprintf(&b, "Generated by `orc init` on %s for `%s`.\n", r.GeneratedAt.Format("2006-01-02 15:04 MST"), r.ProjectPath)
+	b.WriteString("Prerequisites reflect the machine that generated this report.\n\n")
+
+	b.WriteString("## Detected\n\n")
+	if len(r.Languages) == 0 {
+		b.WriteString("No languages detected.\n")
+	}
+	for _, l := range r.Languages {
+		where := "project root"
+		if l.Path != "" {
+			where = "`" + l.Path + "`"
+		}
+		line := fmt.Sprintf("- **%s** in %s", l.Name, where)
+		if len(l.Frameworks) > 0 {
+			line += " (" + strings.Join(l.Frameworks, ", ") + ")"
+		}
+		if l.BuildTool != "" {
+			line += ", built with " + l.BuildTool
+		}
+		b.WriteString(line + "\n")
+	}
+	fmt.Fprintf(&b, "- Tests: %s, CI: %s, frontend: %s\n\n", yesNo(r.HasTests), yesNo(r.HasCI), yesNo(r.HasFrontend))
+
+	b.WriteString("## Suggested commands\n\n")
+	if len(r.Commands) == 0 {
+		b.WriteString("None inferred. Register them with `orc config commands set <name> <command>`.\n\n")
+	} else {
+		b.WriteString("| Kind | Command | Directory | Runnable |\n|------|---------|-----------|----------|\n")
+		for _, c := range r.Commands {
+			dir := c.Path
+			if dir == "" {
+				dir = "."
+			}
+			fmt.Fprintf(&b, "| %s | `%s` | %s | %s |\n", c.Kind, c.Command, dir, yesNo(c.Runnable))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Recommended profile\n\n")
+	fmt.Fprintf(&b, "**%s**: %s.", r.RecommendedProfile, r.ProfileReason)
+	if r.RecommendedProfile != r.CurrentProfile {
+		fmt.Fprintf(&b, " Currently `%s`.", r.CurrentProfile)
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString("## Prerequisites\n\n")
+	for _, c := range r.Prerequisites {
+		mark := "[x]"
+		if !c.OK {
+			mark = "[ ]"
+		}
+		line := fmt.Sprintf("- %s **%s**: %s", mark, c.Name, c.Detail)
+		if !c.OK && c.Fix != "" {
+			line += ". Fix: " + c.Fix
+		}
+		if !c.OK && !c.Required {
+			line += " (optional)"
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n## Next steps\n\n")
+	for i, s := range r.NextSteps {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, s)
+	}
+	return b.Bytes()
+}
+
+// Save writes the Markdown report to .orc/onboarding.md.
+func (r *Report) Save() (string, error) {
+	path := Path(r.ProjectPath)
+	if err := os.WriteFile(path, r.Markdown(), 0644); err != nil {
+		return "", fmt.Errorf("write onboarding report: %w", err)
+	}
+	return path, nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
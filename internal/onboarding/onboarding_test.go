@@ -0,0 +1,166 @@
+package onboarding
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/config"
+)
+
+// fakeTools makes lookPath find only the named binaries and every command
+// succeed or fail as given.
+func fakeTools(t *testing.T, commandsSucceed bool, available ...string) {
+	t.Helper()
+	lookPath = func(name string) (string, error) {
+		for _, a := range available {
+			if a == name {
+				return "/usr/bin/" + name, nil
+			}
+		}
+		return "", errors.New("not found")
+	}
+	execCommand = func(name string, args ...string) *exec.Cmd {
+		if commandsSucceed {
+			return exec.Command("true")
+		}
+		return exec.Command("false")
+	}
+	t.Cleanup(func() {
+		lookPath = exec.LookPath
+		execCommand = exec.Command
+	})
+}
+
+func newGoProject(t *testing.T, withTests bool) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":  "module example.com/app\n\ngo 1.22\n",
+		"main.go": "package main\n\nfunc main() {}\n",
+	}
+	if withTests {
+		files["main_test.go"] = "package main\n"
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(dir, ".orc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func findCheck(r *Report, name string) Check {
+	for _, c := range r.Prerequisites {
+		if c.Name == name {
+			return c
+		}
+	}
+	return Check{}
+}
+
+func TestGenerate_MissingPrerequisites(t *testing.T) {
+	fakeTools(t, false, "git")
+	t.Setenv("ORC_GITHUB_TOKEN", "")
+	dir := newGoProject(t, false)
+
+	r, err := Generate(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Languages) != 1 || r.Languages[0].Name != "go" {
+		t.Errorf("languages = %+v", r.Languages)
+	}
+	if len(r.Commands) == 0 || r.Commands[0].Kind != "test" || r.Commands[0].Runnable {
+		t.Errorf("commands = %+v, want an unrunnable go test command", r.Commands)
+	}
+	if r.RecommendedProfile != string(config.ProfileSafe) {
+		t.Errorf("recommended = %s, want safe without tests", r.RecommendedProfile)
+	}
+
+	if c := findCheck(r, "git"); !c.OK {
+		t.Errorf("git check = %+v", c)
+	}
+	for _, name := range []string{"git repository", "claude CLI", "hosting provider"} {
+		if c := findCheck(r, name); c.OK || c.Fix == "" {
+			t.Errorf("%s check = %+v, want failed with a fix", name, c)
+		}
+	}
+
+	steps := strings.Join(r.NextSteps, "\n")
+	for _, want := range []string{"npm install -g @anthropic-ai/claude-code", "orc config set profile safe", "orc doctor"} {
+		if !strings.Contains(steps, want) {
+			t.Errorf("next steps missing %q:\n%s", want, steps)
+		}
+	}
+}
+
+func TestGenerate_GitHubReady(t *testing.T) {
+	fakeTools(t, true, "git", "claude", "gh", "go")
+	t.Setenv("ORC_GITHUB_TOKEN", "ghp_test")
+	dir := newGoProject(t, true)
+	cfg := config.Default()
+	cfg.Hosting.Provider = "github"
+
+	r, err := Generate(dir, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if missing := r.Missing(); len(missing) != 0 {
+		t.Errorf("missing = %+v, want none", missing)
+	}
+	if r.RecommendedProfile != string(config.ProfileAuto) {
+		t.Errorf("recommended = %s, want auto with tests", r.RecommendedProfile)
+	}
+	for _, s := range r.NextSteps {
+		if strings.HasPrefix(s, "Fix ") || strings.Contains(s, "config set profile") {
+			t.Errorf("unexpected next step %q", s)
+		}
+	}
+}
+
+func TestGenerate_TokenFixUsesGhAuth(t *testing.T) {
+	fakeTools(t, true, "git", "claude", "gh")
+	t.Setenv("ORC_GITHUB_TOKEN", "")
+	cfg := config.Default()
+	cfg.Hosting.Provider = "github"
+
+	r, err := Generate(newGoProject(t, true), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := findCheck(r, "hosting token"); c.OK || c.Fix != "export ORC_GITHUB_TOKEN=$(gh auth token)" {
+		t.Errorf("hosting token check = %+v", c)
+	}
+}
+
+func TestReportSave(t *testing.T) {
+	fakeTools(t, false)
+	dir := newGoProject(t, false)
+	r, err := Generate(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err := r.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join(dir, ".orc", FileName) {
+		t.Errorf("path = %s", path)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# orc onboarding", "## Detected", "**go** in project root", "| test | `go test ./...`", "## Recommended profile", "- [ ] **claude CLI**", "## Next steps"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("report missing %q:\n%s", want, content)
+		}
+	}
+}
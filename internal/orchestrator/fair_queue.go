@@ -0,0 +1,269 @@
+package orchestrator
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// FairnessPolicy selects which project's queued task runs next.
+type FairnessPolicy string
+
+const (
+	// FairnessRoundRobin rotates through projects with queued tasks.
+	FairnessRoundRobin FairnessPolicy = "round_robin"
+	// FairnessWeighted gives each project a share of run slots proportional
+	// to its weight.
+	FairnessWeighted FairnessPolicy = "weighted"
+)
+
+// FairQueue shares a fixed number of run slots between projects. Tasks
+// submitted while every slot is busy wait in a FIFO queue per project, and
+// the policy decides which project gets the next free slot, so one project's
+// burst of tasks cannot starve the others.
+type FairQueue struct {
+	mu       sync.Mutex
+	capacity int // 0 = unlimited
+	policy   FairnessPolicy
+	weights  map[string]int
+
+	projects map[string]*fairProject
+	order    []string // projects in first-seen order, for round-robin
+	cursor   int      // next round-robin position in order
+	running  int
+	served   uint64 // dispatch counter, orders least-recently-served ties
+}
+
+type fairProject struct {
+	id         string
+	running    int
+	queue      []*fairEntry
+	dispatched int
+	lastServed uint64
+}
+
+type fairEntry struct {
+	taskID     string
+	start      func()
+	enqueuedAt time.Time
+}
+
+// NewFairQueue creates a queue with capacity run slots (0 = unlimited).
+// Projects missing from weights, or with a weight below 1, weigh 1.
+func NewFairQueue(capacity int, policy FairnessPolicy, weights map[string]int) *FairQueue {
+	if policy == "" {
+		policy = FairnessRoundRobin
+	}
+	w := make(map[string]int, len(weights))
+	for id, weight := range weights {
+		w[id] = weight
+	}
+	return &FairQueue{
+		capacity: capacity,
+		policy:   policy,
+		weights:  w,
+		projects: make(map[string]*fairProject),
+	}
+}
+
+// Submit runs start now if a slot is free, or queues it behind the
+// project's earlier tasks. It reports whether the task was queued. Every
+// started task must be followed by exactly one call to Done.
+func (q *FairQueue) Submit(projectID, taskID string, start func()) bool {
+	q.mu.Lock()
+	p := q.project(projectID)
+	if q.capacity > 0 && q.running >= q.capacity {
+		p.queue = append(p.queue, &fairEntry{taskID: taskID, start: start, enqueuedAt: time.Now()})
+		q.mu.Unlock()
+		return true
+	}
+	q.markRunning(p)
+	q.mu.Unlock()
+
+	start()
+	return false
+}
+
+// Done releases a slot held by projectID and starts as many queued tasks as
+// now fit.
+func (q *FairQueue) Done(projectID string) {
+	q.mu.Lock()
+	if p := q.projects[projectID]; p != nil && p.running > 0 {
+		p.running--
+		q.running--
+	}
+	starts := q.dispatchLocked()
+	q.mu.Unlock()
+
+	for _, start := range starts {
+		start()
+	}
+}
+
+// Remove drops a task that is still waiting for a slot. It reports whether
+// the task was found in the queue.
+func (q *FairQueue) Remove(taskID string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, p := range q.projects {
+		for i, e := range p.queue {
+			if e.taskID == taskID {
+				p.queue = append(p.queue[:i], p.queue[i+1:]...)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsQueued reports whether taskID is waiting for a slot.
+func (q *FairQueue) IsQueued(taskID string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, p := range q.projects {
+		for _, e := range p.queue {
+			if e.taskID == taskID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (q *FairQueue) project(id string) *fairProject {
+	p, ok := q.projects[id]
+	if !ok {
+		p = &fairProject{id: id}
+		q.projects[id] = p
+		q.order = append(q.order, id)
+	}
+	return p
+}
+
+func (q *FairQueue) markRunning(p *fairProject) {
+	p.running++
+	p.dispatched++
+	q.running++
+	q.served++
+	p.lastServed = q.served
+}
+
+func (q *FairQueue) weight(projectID string) int {
+	if w := q.weights[projectID]; w > 0 {
+		return w
+	}
+	return 1
+}
+
+// dispatchLocked pops queued tasks into free slots and returns their start
+// functions, to be called once the lock is released.
+func (q *FairQueue) dispatchLocked() []func() {
+	var starts []func()
+	for q.capacity <= 0 || q.running < q.capacity {
+		p := q.nextLocked()
+		if p == nil {
+			break
+		}
+		e := p.queue[0]
+		p.queue = p.queue[1:]
+		q.markRunning(p)
+		starts = append(starts, e.start)
+	}
+	return starts
+}
+
+// nextLocked picks the project whose queued task should run next, or nil
+// when nothing is queued.
+func (q *FairQueue) nextLocked() *fairProject {
+	if q.policy == FairnessWeighted {
+		var best *fairProject
+		for _, id := range q.order {
+			p := q.projects[id]
+			if len(p.queue) == 0 {
+				continue
+			}
+			if best == nil || q.lessLoaded(p, best) {
+				best = p
+			}
+		}
+		return best
+	}
+
+	for i := 0; i < len(q.order); i++ {
+		idx := (q.cursor + i) % len(q.order)
+		p := q.projects[q.order[idx]]
+		if len(p.queue) > 0 {
+			q.cursor = (idx + 1) % len(q.order)
+			return p
+		}
+	}
+	return nil
+}
+
+// lessLoaded reports whether a is further below its weighted share than b.
+// Ties go to the project served least recently.
+func (q *FairQueue) lessLoaded(a, b *fairProject) bool {
+	// Compare running/weight without floating point.
+	left := a.running * q.weight(b.id)
+	right := b.running * q.weight(a.id)
+	if left != right {
+		return left < right
+	}
+	return a.lastServed < b.lastServed
+}
+
+// FairQueueMetrics is a point-in-time view of the queue.
+type FairQueueMetrics struct {
+	Capacity int                     `json:"capacity"` // 0 = unlimited
+	Policy   string                  `json:"policy"`
+	Running  int                     `json:"running"`
+	Queued   int                     `json:"queued"`
+	Projects []FairQueueProjectStats `json:"projects"`
+}
+
+// FairQueueProjectStats describes one project's share of the queue.
+type FairQueueProjectStats struct {
+	ProjectID     string   `json:"project_id"`
+	Weight        int      `json:"weight"`
+	Running       int      `json:"running"`
+	Queued        int      `json:"queued"`
+	Dispatched    int      `json:"dispatched"`
+	QueuedTaskIDs []string `json:"queued_task_ids,omitempty"`
+	// OldestWaitSeconds is how long the project's first queued task has waited.
+	OldestWaitSeconds float64 `json:"oldest_wait_seconds"`
+}
+
+// Metrics returns the current queue state, with projects sorted by ID.
+func (q *FairQueue) Metrics() FairQueueMetrics {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	m := FairQueueMetrics{
+		Capacity: q.capacity,
+		Policy:   string(q.policy),
+		Running:  q.running,
+		Projects: make([]FairQueueProjectStats, 0, len(q.projects)),
+	}
+	now := time.Now()
+	for _, p := range q.projects {
+		stats := FairQueueProjectStats{
+			ProjectID:  p.id,
+			Weight:     q.weight(p.id),
+			Running:    p.running,
+			Queued:     len(p.queue),
+			Dispatched: p.dispatched,
+		}
+		for _, e := range p.queue {
+			stats.QueuedTaskIDs = append(stats.QueuedTaskIDs, e.taskID)
+		}
+		if len(p.queue) > 0 {
+			stats.OldestWaitSeconds = now.Sub(p.queue[0].enqueuedAt).Seconds()
+		}
+		m.Queued += len(p.queue)
+		m.Projects = append(m.Projects, stats)
+	}
+	sort.Slice(m.Projects, func(i, j int) bool {
+		return m.Projects[i].ProjectID < m.Projects[j].ProjectID
+	})
+	return m
+}
@@ -0,0 +1,118 @@
+package orchestrator
+
+import (
+	"testing"
+)
+
+// recorder collects the order in which queued tasks are started.
+type recorder struct {
+	started []string
+}
+
+func (r *recorder) start(taskID string) func() {
+	return func() { r.started = append(r.started, taskID) }
+}
+
+func TestFairQueue_Unlimited(t *testing.T) {
+	q := NewFairQueue(0, FairnessRoundRobin, nil)
+	r := &recorder{}
+	for _, id := range []string{"A-1", "A-2", "A-3"} {
+		if q.Submit("a", id, r.start(id)) {
+			t.Fatalf("%s queued with unlimited capacity", id)
+		}
+	}
+	if len(r.started) != 3 {
+		t.Errorf("started %v, want all three", r.started)
+	}
+}
+
+func TestFairQueue_RoundRobin(t *testing.T) {
+	q := NewFairQueue(1, FairnessRoundRobin, nil)
+	r := &recorder{}
+
+	// Project a bursts first, then b and c each submit one task.
+	for _, id := range []string{"A-1", "A-2", "A-3", "A-4"} {
+		q.Submit("a", id, r.start(id))
+	}
+	q.Submit("b", "B-1", r.start("B-1"))
+	q.Submit("c", "C-1", r.start("C-1"))
+
+	if got := q.Metrics(); got.Running != 1 || got.Queued != 5 {
+		t.Fatalf("metrics running=%d queued=%d, want 1/5", got.Running, got.Queued)
+	}
+
+	for _, project := range []string{"a", "a", "b", "c", "a", "a"} {
+		q.Done(project)
+	}
+
+	want := []string{"A-1", "A-2", "B-1", "C-1", "A-3", "A-4"}
+	if len(r.started) != len(want) {
+		t.Fatalf("started %v, want %v", r.started, want)
+	}
+	for i := range want {
+		if r.started[i] != want[i] {
+			t.Fatalf("started %v, want %v", r.started, want)
+		}
+	}
+}
+
+func TestFairQueue_Weighted(t *testing.T) {
+	q := NewFairQueue(4, FairnessWeighted, map[string]int{"big": 3})
+	r := &recorder{}
+
+	// Fill every slot with the small project, then queue plenty of both.
+	for i, id := range []string{"S-1", "S-2", "S-3", "S-4", "S-5", "S-6"} {
+		queued := q.Submit("small", id, r.start(id))
+		if queued != (i >= 4) {
+			t.Fatalf("%s queued=%v", id, queued)
+		}
+	}
+	for _, id := range []string{"B-1", "B-2", "B-3", "B-4"} {
+		q.Submit("big", id, r.start(id))
+	}
+
+	// As the small project's tasks finish, slots go to big until it holds
+	// its 3-of-4 share, then small resumes.
+	for i := 0; i < 4; i++ {
+		q.Done("small")
+	}
+
+	stats := projectStats(q.Metrics())
+	if stats["big"].Running != 3 || stats["small"].Running != 1 {
+		t.Errorf("running big=%d small=%d, want 3/1", stats["big"].Running, stats["small"].Running)
+	}
+	if stats["big"].Weight != 3 || stats["small"].Weight != 1 {
+		t.Errorf("weights big=%d small=%d", stats["big"].Weight, stats["small"].Weight)
+	}
+	if stats["small"].Queued != 1 || stats["big"].Queued != 1 {
+		t.Errorf("queued big=%d small=%d, want 1/1", stats["big"].Queued, stats["small"].Queued)
+	}
+}
+
+func TestFairQueue_Remove(t *testing.T) {
+	q := NewFairQueue(1, FairnessRoundRobin, nil)
+	r := &recorder{}
+	q.Submit("a", "A-1", r.start("A-1"))
+	q.Submit("a", "A-2", r.start("A-2"))
+	q.Submit("a", "A-3", r.start("A-3"))
+
+	if !q.IsQueued("A-2") || !q.Remove("A-2") {
+		t.Fatal("A-2 should be removable while queued")
+	}
+	if q.Remove("A-2") || q.Remove("A-1") {
+		t.Error("Remove should only find queued tasks")
+	}
+
+	q.Done("a")
+	if len(r.started) != 2 || r.started[1] != "A-3" {
+		t.Errorf("started %v, want A-1 then A-3", r.started)
+	}
+}
+
+func projectStats(m FairQueueMetrics) map[string]FairQueueProjectStats {
+	stats := make(map[string]FairQueueProjectStats, len(m.Projects))
+	for _, p := range m.Projects {
+		stats[p.ProjectID] = p
+	}
+	return stats
+}
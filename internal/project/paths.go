@@ -46,6 +46,16 @@ func ProjectExportDir(projectID string) (string, error) {
 	return filepath.Join(dataDir, "exports"), nil
 }
 
+// ProjectLogsDir returns the server and task log directory for a project.
+// Path: ~/.orc/projects/<project-id>/logs/
+func ProjectLogsDir(projectID string) (string, error) {
+	dataDir, err := ProjectDataDir(projectID)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "logs"), nil
+}
+
 // ProjectLocalConfigPath returns the personal config path for a project.
 // Path: ~/.orc/projects/<project-id>/config.yaml
 func ProjectLocalConfigPath(projectID string) (string, error) {
@@ -0,0 +1,447 @@
+// Package projectsync pushes selected configuration keys and prompt
+// overrides from one project, or from a template directory laid out like
+// .orc/, to other registered projects. Plans carry a unified diff per file so
+// callers can preview before applying.
+package projectsync
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v3"
+
+	"github.com/randalmurphal/orc/internal/project"
+)
+
+const (
+	orcDir     = ".orc"
+	configFile = "config.yaml"
+	promptsDir = "prompts"
+)
+
+// AllProjects selects every registered project whose path still exists.
+const AllProjects = "all"
+
+// Change actions.
+const (
+	ActionCreate    = "create"
+	ActionUpdate    = "update"
+	ActionUnchanged = "unchanged"
+)
+
+// Options selects what to sync.
+type Options struct {
+	// Config copies keys from the source config.yaml.
+	Config bool `json:"config"`
+	// Keys are dotted config keys to copy (e.g. "gates", "completion.pr.labels").
+	// Empty copies every top-level key in the source file. Setting Keys
+	// implies Config.
+	Keys []string `json:"keys,omitempty"`
+	// Prompts copies prompt overrides from the source prompts/ directory.
+	Prompts bool `json:"prompts"`
+	// PromptNames are phase names to copy. Empty copies every override.
+	// Setting PromptNames implies Prompts.
+	PromptNames []string `json:"prompt_names,omitempty"`
+}
+
+// Change is a planned write to one file in a target project.
+type Change struct {
+	// Path is relative to the project root, e.g. ".orc/config.yaml".
+	Path   string `json:"path"`
+	Action string `json:"action"`
+	Diff   string `json:"diff,omitempty"`
+
+	content []byte
+}
+
+// ProjectPlan is the set of changes for one target project.
+type ProjectPlan struct {
+	ProjectID string   `json:"project_id"`
+	Name      string   `json:"name"`
+	Path      string   `json:"path"`
+	Changes   []Change `json:"changes"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// Changed reports whether applying the plan would write anything.
+func (p ProjectPlan) Changed() bool {
+	for _, c := range p.Changes {
+		if c.Action != ActionUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// SourceDir resolves a source to the directory holding config.yaml and
+// prompts/: the .orc directory of a project root, or the path itself for a
+// template directory.
+func SourceDir(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("resolve source %s: %w", path, err)
+	}
+	if info, err := os.Stat(filepath.Join(abs, orcDir)); err == nil && info.IsDir() {
+		return filepath.Join(abs, orcDir), nil
+	}
+	if info, err := os.Stat(abs); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("source %s is not a directory", path)
+	}
+	return abs, nil
+}
+
+// ResolveTargets returns the registered projects named by refs, each an ID,
+// unique name, or path. A single "all" selects every valid project.
+func ResolveTargets(reg *project.Registry, refs []string) ([]project.Project, error) {
+	if len(refs) == 1 && refs[0] == AllProjects {
+		return reg.ValidProjects(), nil
+	}
+	var targets []project.Project
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		p, err := resolveTarget(reg, ref)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[p.ID] {
+			seen[p.ID] = true
+			targets = append(targets, *p)
+		}
+	}
+	return targets, nil
+}
+
+func resolveTarget(reg *project.Registry, ref string) (*project.Project, error) {
+	if p, err := reg.Get(ref); err == nil {
+		return p, nil
+	}
+	var match *project.Project
+	for i, p := range reg.Projects {
+		if p.Name != ref {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("ambiguous project name %q; use the project ID", ref)
+		}
+		match = &reg.Projects[i]
+	}
+	if match == nil {
+		return nil, fmt.Errorf("project not found: %s", ref)
+	}
+	return match, nil
+}
+
+// Plan computes the changes needed to bring each target in line with the
+// source. A target that is the source itself is skipped. Problems with one
+// target are recorded in its plan; problems with the source fail the plan.
+func Plan(sourceDir string, targets []project.Project, opts Options) ([]ProjectPlan, error) {
+	opts.Config = opts.Config || len(opts.Keys) > 0
+	opts.Prompts = opts.Prompts || len(opts.PromptNames) > 0
+	if !opts.Config && !opts.Prompts {
+		return nil, fmt.Errorf("nothing to sync: select config and/or prompts")
+	}
+
+	var sourceConfig *yaml.Node
+	keys := opts.Keys
+	if opts.Config {
+		doc, err := readYAML(filepath.Join(sourceDir, configFile))
+		if err != nil {
+			return nil, fmt.Errorf("read source config: %w", err)
+		}
+		if doc == nil {
+			return nil, fmt.Errorf("source has no %s", configFile)
+		}
+		sourceConfig = doc
+		if len(keys) == 0 {
+			keys = topLevelKeys(doc)
+		}
+		for _, key := range keys {
+			if lookup(doc, key) == nil {
+				return nil, fmt.Errorf("config key %s is not set in the source", key)
+			}
+		}
+	}
+
+	var prompts map[string][]byte
+	if opts.Prompts {
+		var err error
+		if prompts, err = readPrompts(filepath.Join(sourceDir, promptsDir), opts.PromptNames); err != nil {
+			return nil, err
+		}
+	}
+
+	plans := make([]ProjectPlan, 0, len(targets))
+	for _, t := range targets {
+		targetDir := filepath.Join(t.Path, orcDir)
+		if sameDir(targetDir, sourceDir) {
+			continue
+		}
+		plan := ProjectPlan{ProjectID: t.ID, Name: t.Name, Path: t.Path, Changes: []Change{}}
+		if opts.Config {
+			change, err := planConfig(targetDir, sourceConfig, keys)
+			if err != nil {
+				plan.Error = err.Error()
+				plans = append(plans, plan)
+				continue
+			}
+			plan.Changes = append(plan.Changes, change)
+		}
+		for _, name := range sortedKeys(prompts) {
+			change, err := planFile(targetDir, promptsDir+"/"+name+".md", prompts[name])
+			if err != nil {
+				plan.Error = err.Error()
+				break
+			}
+			plan.Changes = append(plan.Changes, change)
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+// Apply writes every change in plans that have no error.
+func Apply(plans []ProjectPlan) error {
+	for _, plan := range plans {
+		if plan.Error != "" {
+			continue
+		}
+		for _, c := range plan.Changes {
+			if c.Action == ActionUnchanged {
+				continue
+			}
+			path := filepath.Join(plan.Path, filepath.FromSlash(c.Path))
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return fmt.Errorf("%s: create %s: %w", plan.Name, filepath.Dir(c.Path), err)
+			}
+			if err := os.WriteFile(path, c.content, 0644); err != nil {
+				return fmt.Errorf("%s: write %s: %w", plan.Name, c.Path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// planConfig copies keys from source into the target config.yaml, keeping
+// the target's other keys and comments.
+func planConfig(targetDir string, source *yaml.Node, keys []string) (Change, error) {
+	target, err := readYAML(filepath.Join(targetDir, configFile))
+	if err != nil {
+		return Change{}, fmt.Errorf("read %s: %w", configFile, err)
+	}
+	if target == nil {
+		target = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+
+	changed := false
+	for _, key := range keys {
+		want := lookup(source, key)
+		if have := lookup(target, key); have != nil && nodesEqual(have, want) {
+			continue
+		}
+		if err := set(target, key, want); err != nil {
+			return Change{}, err
+		}
+		changed = true
+	}
+	if !changed {
+		return Change{Path: relPath(configFile), Action: ActionUnchanged}, nil
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(target); err != nil {
+		return Change{}, fmt.Errorf("encode %s: %w", configFile, err)
+	}
+	if err := enc.Close(); err != nil {
+		return Change{}, fmt.Errorf("encode %s: %w", configFile, err)
+	}
+	return planFile(targetDir, configFile, buf.Bytes())
+}
+
+// planFile compares a file under the target's .orc directory with the
+// content it should have.
+func planFile(targetDir, name string, want []byte) (Change, error) {
+	rel := relPath(name)
+	have, err := os.ReadFile(filepath.Join(targetDir, filepath.FromSlash(name)))
+	if err != nil && !os.IsNotExist(err) {
+		return Change{}, fmt.Errorf("read %s: %w", rel, err)
+	}
+	action := ActionUpdate
+	if os.IsNotExist(err) {
+		action = ActionCreate
+	} else if bytes.Equal(have, want) {
+		return Change{Path: rel, Action: ActionUnchanged}, nil
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(have)),
+		B:        difflib.SplitLines(string(want)),
+		FromFile: "a/" + rel,
+		ToFile:   "b/" + rel,
+		Context:  3,
+	})
+	if err != nil {
+		return Change{}, fmt.Errorf("diff %s: %w", rel, err)
+	}
+	return Change{Path: rel, Action: action, Diff: diff, content: want}, nil
+}
+
+// readPrompts loads prompt overrides from dir, limited to names when given.
+func readPrompts(dir string, names []string) (map[string][]byte, error) {
+	prompts := make(map[string][]byte)
+	if len(names) > 0 {
+		for _, name := range names {
+			data, err := os.ReadFile(filepath.Join(dir, name+".md"))
+			if err != nil {
+				return nil, fmt.Errorf("source has no prompt override for %s", name)
+			}
+			prompts[name] = data
+		}
+		return prompts, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("read source prompts: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".md" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read source prompt %s: %w", e.Name(), err)
+		}
+		prompts[strings.TrimSuffix(e.Name(), ".md")] = data
+	}
+	if len(prompts) == 0 {
+		return nil, fmt.Errorf("source has no prompt overrides in %s/", promptsDir)
+	}
+	return prompts, nil
+}
+
+// readYAML parses a YAML file into a document node. A missing or empty
+// file returns nil.
+func readYAML(path string) (*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, nil
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s: top level is not a mapping", filepath.Base(path))
+	}
+	return &doc, nil
+}
+
+func topLevelKeys(doc *yaml.Node) []string {
+	root := doc.Content[0]
+	keys := make([]string, 0, len(root.Content)/2)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keys = append(keys, root.Content[i].Value)
+	}
+	return keys
+}
+
+// lookup returns the value node at a dotted key, or nil.
+func lookup(doc *yaml.Node, key string) *yaml.Node {
+	node := doc.Content[0]
+	for _, part := range strings.Split(key, ".") {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == part {
+				next = node.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		node = next
+	}
+	return node
+}
+
+// set replaces or adds the value at a dotted key, creating intermediate
+// mappings as needed.
+func set(doc *yaml.Node, key string, value *yaml.Node) error {
+	node := doc.Content[0]
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		var next *yaml.Node
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if node.Content[j].Value == part {
+				next = node.Content[j+1]
+				if i == len(parts)-1 {
+					node.Content[j+1] = value
+					return nil
+				}
+				break
+			}
+		}
+		if i == len(parts)-1 {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: part}, value)
+			return nil
+		}
+		if next == nil {
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: part}, next)
+		}
+		if next.Kind != yaml.MappingNode {
+			return fmt.Errorf("cannot set %s: %s is not a mapping in the target config", key, strings.Join(parts[:i+1], "."))
+		}
+		node = next
+	}
+	return nil
+}
+
+// nodesEqual compares two YAML values by their decoded form, ignoring
+// comments and style.
+func nodesEqual(a, b *yaml.Node) bool {
+	var av, bv any
+	if a.Decode(&av) != nil || b.Decode(&bv) != nil {
+		return false
+	}
+	ab, errA := yaml.Marshal(av)
+	bb, errB := yaml.Marshal(bv)
+	return errA == nil && errB == nil && bytes.Equal(ab, bb)
+}
+
+func sameDir(a, b string) bool {
+	ra, errA := filepath.EvalSymlinks(a)
+	rb, errB := filepath.EvalSymlinks(b)
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	return ra == rb
+}
+
+func relPath(name string) string {
+	return orcDir + "/" + name
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,185 @@
+package projectsync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/project"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func newProject(t *testing.T, id string) project.Project {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, orcDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return project.Project{ID: id, Name: id, Path: dir}
+}
+
+func TestPlanAndApply_ConfigKeys(t *testing.T) {
+	t.Parallel()
+
+	source := newProject(t, "src")
+	writeFile(t, filepath.Join(source.Path, orcDir, configFile), `gates:
+  default_type: ai
+completion:
+  pr:
+    labels: [orc, automated]
+model: opus
+`)
+	target := newProject(t, "api")
+	writeFile(t, filepath.Join(target.Path, orcDir, configFile), `# API service settings
+model: sonnet # keep this
+completion:
+  target_branch: develop
+`)
+	fresh := newProject(t, "web")
+
+	srcDir, err := SourceDir(source.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := Options{Config: true, Keys: []string{"gates", "completion.pr.labels"}}
+	plans, err := Plan(srcDir, []project.Project{source, target, fresh}, opts)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	if len(plans) != 2 {
+		t.Fatalf("plans = %d, want 2 (source skipped)", len(plans))
+	}
+	got := plans[0].Changes[0]
+	if got.Action != ActionUpdate || !strings.Contains(got.Diff, "+  pr:") || !strings.Contains(got.Diff, "+gates:") {
+		t.Errorf("api change = %+v", got)
+	}
+	if plans[1].Changes[0].Action != ActionCreate {
+		t.Errorf("web action = %s, want create", plans[1].Changes[0].Action)
+	}
+
+	if err := Apply(plans); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	cfg := readFile(t, filepath.Join(target.Path, orcDir, configFile))
+	for _, want := range []string{"# API service settings", "model: sonnet # keep this", "target_branch: develop", "default_type: ai", "labels: [orc, automated]"} {
+		if !strings.Contains(cfg, want) {
+			t.Errorf("target config missing %q:\n%s", want, cfg)
+		}
+	}
+	if strings.Contains(cfg, "opus") {
+		t.Errorf("unselected key model was synced:\n%s", cfg)
+	}
+
+	// A second plan finds nothing to do.
+	plans, err = Plan(srcDir, []project.Project{target, fresh}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range plans {
+		if p.Changed() {
+			t.Errorf("%s still changes after apply: %+v", p.Name, p.Changes)
+		}
+	}
+}
+
+func TestPlan_PromptsFromTemplate(t *testing.T) {
+	t.Parallel()
+
+	template := t.TempDir()
+	writeFile(t, filepath.Join(template, promptsDir, "implement.md"), "Implement carefully.\n")
+	writeFile(t, filepath.Join(template, promptsDir, "review.md"), "Review strictly.\n")
+	target := newProject(t, "api")
+	writeFile(t, filepath.Join(target.Path, orcDir, promptsDir, "review.md"), "Review strictly.\n")
+
+	srcDir, err := SourceDir(template)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if srcDir != template {
+		t.Fatalf("SourceDir = %s, want template dir itself", srcDir)
+	}
+	plans, err := Plan(srcDir, []project.Project{target}, Options{Prompts: true})
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	changes := plans[0].Changes
+	if len(changes) != 2 || changes[0].Path != ".orc/prompts/implement.md" || changes[0].Action != ActionCreate ||
+		changes[1].Action != ActionUnchanged {
+		t.Fatalf("changes = %+v", changes)
+	}
+	if err := Apply(plans); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, filepath.Join(target.Path, orcDir, promptsDir, "implement.md")); got != "Implement carefully.\n" {
+		t.Errorf("implement.md = %q", got)
+	}
+
+	if _, err := Plan(srcDir, []project.Project{target}, Options{PromptNames: []string{"plan"}, Prompts: true}); err == nil {
+		t.Error("expected error for a prompt the source does not override")
+	}
+}
+
+func TestPlan_Errors(t *testing.T) {
+	t.Parallel()
+
+	source := newProject(t, "src")
+	writeFile(t, filepath.Join(source.Path, orcDir, configFile), "model: opus\n")
+	target := newProject(t, "api")
+	writeFile(t, filepath.Join(target.Path, orcDir, configFile), "gates: manual\n")
+	srcDir := filepath.Join(source.Path, orcDir)
+
+	if _, err := Plan(srcDir, nil, Options{}); err == nil {
+		t.Error("expected error when nothing is selected")
+	}
+	if _, err := Plan(srcDir, nil, Options{Config: true, Keys: []string{"gates"}}); err == nil {
+		t.Error("expected error for a key missing from the source")
+	}
+
+	writeFile(t, filepath.Join(source.Path, orcDir, configFile), "gates:\n  default_type: ai\n")
+	plans, err := Plan(srcDir, []project.Project{target}, Options{Config: true, Keys: []string{"gates.default_type"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plans[0].Error == "" {
+		t.Errorf("expected a per-project error when the target has a scalar at gates")
+	}
+}
+
+func TestResolveTargets(t *testing.T) {
+	t.Parallel()
+
+	a, b := newProject(t, "aaa11111"), newProject(t, "bbb22222")
+	b.Name = "web"
+	reg := &project.Registry{Projects: []project.Project{a, b, {ID: "gone", Name: "gone", Path: "/nonexistent/orc"}}}
+
+	all, err := ResolveTargets(reg, []string{AllProjects})
+	if err != nil || len(all) != 2 {
+		t.Fatalf("all = %v, %v; want the two existing projects", all, err)
+	}
+	picked, err := ResolveTargets(reg, []string{"web", "aaa11111", "web"})
+	if err != nil || len(picked) != 2 || picked[0].ID != "bbb22222" {
+		t.Fatalf("picked = %v, %v", picked, err)
+	}
+	if _, err := ResolveTargets(reg, []string{"missing"}); err == nil {
+		t.Error("expected error for an unknown project")
+	}
+}
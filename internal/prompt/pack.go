@@ -0,0 +1,309 @@
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Prompt pack layout (repository root):
+//
+//	orc-pack.yaml      manifest (name, version, description)
+//	prompts/*.md       phase prompt overrides, installed into .orc/prompts/
+//	workflows/*.yaml   workflow / phase template exports (orc export format)
+const (
+	PackManifestFile = "orc-pack.yaml"
+	PackLockFile     = "prompt-packs.yaml"
+	packPromptsDir   = "prompts"
+	packWorkflowsDir = "workflows"
+)
+
+// PackManifest describes a prompt pack.
+type PackManifest struct {
+	Name        string `yaml:"name"`
+	Version     string `yaml:"version,omitempty"`
+	Description string `yaml:"description,omitempty"`
+}
+
+// InstalledPack records an installed pack and the exact commit it was pinned to.
+type InstalledPack struct {
+	Name        string    `yaml:"name" json:"name"`
+	Version     string    `yaml:"version,omitempty" json:"version,omitempty"`
+	Repo        string    `yaml:"repo" json:"repo"`
+	Ref         string    `yaml:"ref,omitempty" json:"ref,omitempty"`
+	Commit      string    `yaml:"commit" json:"commit"`
+	Prompts     []string  `yaml:"prompts,omitempty" json:"prompts,omitempty"`
+	Workflows   []string  `yaml:"workflows,omitempty" json:"workflows,omitempty"`
+	InstalledAt time.Time `yaml:"installed_at" json:"installed_at"`
+}
+
+// PackLock is the .orc/prompt-packs.yaml file. It is committed with the
+// project so every clone resolves the same pack versions.
+type PackLock struct {
+	Packs []InstalledPack `yaml:"packs"`
+}
+
+// FetchedPack is a pack checked out into a temporary directory.
+type FetchedPack struct {
+	Dir      string
+	Repo     string
+	Ref      string
+	Commit   string
+	Manifest PackManifest
+	// Prompts are the phase names provided by the pack.
+	Prompts []string
+	// WorkflowFiles are absolute paths to workflow export files in Dir.
+	WorkflowFiles []string
+}
+
+// Cleanup removes the temporary checkout.
+func (p *FetchedPack) Cleanup() {
+	if p.Dir != "" {
+		_ = os.RemoveAll(p.Dir)
+	}
+}
+
+// ParsePackSpec splits "repo@ref" into its parts. A ref after the final "@"
+// is only recognised when it contains no path separator or colon, so
+// "git@github.com:org/pack.git" parses as a bare repo.
+func ParsePackSpec(spec string) (repo, ref string) {
+	idx := strings.LastIndex(spec, "@")
+	if idx <= 0 {
+		return spec, ""
+	}
+	candidate := spec[idx+1:]
+	if candidate == "" || strings.ContainsAny(candidate, "/:") {
+		return spec, ""
+	}
+	return spec[:idx], candidate
+}
+
+// FetchPack clones repo, checks out ref (branch, tag, or commit; empty means
+// the default branch) and validates the pack layout. Callers must Cleanup.
+func FetchPack(repo, ref string) (*FetchedPack, error) {
+	dir, err := os.MkdirTemp("", "orc-pack-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+	pack := &FetchedPack{Dir: dir, Repo: repo, Ref: ref}
+
+	if out, err := exec.Command("git", "clone", "--quiet", repo, dir).CombinedOutput(); err != nil {
+		pack.Cleanup()
+		return nil, fmt.Errorf("git clone %s: %s: %w", repo, strings.TrimSpace(string(out)), err)
+	}
+	if ref != "" {
+		if out, err := runGit(dir, "checkout", "--quiet", ref); err != nil {
+			pack.Cleanup()
+			return nil, fmt.Errorf("git checkout %s: %s: %w", ref, out, err)
+		}
+	}
+	commit, err := runGit(dir, "rev-parse", "HEAD")
+	if err != nil {
+		pack.Cleanup()
+		return nil, fmt.Errorf("resolve pack commit: %s: %w", commit, err)
+	}
+	pack.Commit = commit
+
+	if err := pack.load(); err != nil {
+		pack.Cleanup()
+		return nil, err
+	}
+	return pack, nil
+}
+
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(out)), err
+}
+
+// load reads the manifest and enumerates prompts and workflow files.
+func (p *FetchedPack) load() error {
+	data, err := os.ReadFile(filepath.Join(p.Dir, PackManifestFile))
+	if err != nil {
+		return fmt.Errorf("read %s: %w", PackManifestFile, err)
+	}
+	if err := yaml.Unmarshal(data, &p.Manifest); err != nil {
+		return fmt.Errorf("parse %s: %w", PackManifestFile, err)
+	}
+	if p.Manifest.Name == "" {
+		return fmt.Errorf("%s: name is required", PackManifestFile)
+	}
+	if strings.ContainsAny(p.Manifest.Name, `/\`) {
+		return fmt.Errorf("%s: invalid pack name %q", PackManifestFile, p.Manifest.Name)
+	}
+
+	promptFiles, err := filepath.Glob(filepath.Join(p.Dir, packPromptsDir, "*.md"))
+	if err != nil {
+		return fmt.Errorf("list pack prompts: %w", err)
+	}
+	for _, f := range promptFiles {
+		p.Prompts = append(p.Prompts, strings.TrimSuffix(filepath.Base(f), ".md"))
+	}
+
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		files, err := filepath.Glob(filepath.Join(p.Dir, packWorkflowsDir, pattern))
+		if err != nil {
+			return fmt.Errorf("list pack workflows: %w", err)
+		}
+		p.WorkflowFiles = append(p.WorkflowFiles, files...)
+	}
+	sort.Strings(p.WorkflowFiles)
+
+	if len(p.Prompts) == 0 && len(p.WorkflowFiles) == 0 {
+		return fmt.Errorf("pack %s contains no prompts/ or workflows/ files", p.Manifest.Name)
+	}
+	return nil
+}
+
+// InstallPrompts copies the pack's prompts into orcDir/prompts. Existing
+// prompt files that do not belong to a previous install of the same pack are
+// treated as conflicts unless force is set.
+func (s *Service) InstallPrompts(pack *FetchedPack, force bool) error {
+	lock, err := s.LoadPackLock()
+	if err != nil {
+		return err
+	}
+	owned := make(map[string]bool)
+	if prev := lock.Find(pack.Manifest.Name); prev != nil {
+		for _, phase := range prev.Prompts {
+			owned[phase] = true
+		}
+	}
+
+	if !force {
+		var conflicts []string
+		for _, phase := range pack.Prompts {
+			if owned[phase] {
+				continue
+			}
+			if _, err := os.Stat(s.projectPromptPath(phase)); err == nil {
+				conflicts = append(conflicts, phase)
+			}
+		}
+		if len(conflicts) > 0 {
+			return fmt.Errorf("prompt overrides already exist for %s (use --force to replace)", strings.Join(conflicts, ", "))
+		}
+	}
+
+	for _, phase := range pack.Prompts {
+		content, err := os.ReadFile(filepath.Join(pack.Dir, packPromptsDir, phase+".md"))
+		if err != nil {
+			return fmt.Errorf("read pack prompt %s: %w", phase, err)
+		}
+		if err := s.Save(phase, string(content)); err != nil {
+			return fmt.Errorf("install prompt %s: %w", phase, err)
+		}
+		delete(owned, phase)
+	}
+
+	// Prompts the previous version installed but this version dropped.
+	for phase := range owned {
+		if err := s.Delete(phase); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordPack writes (or replaces) the lock entry for an installed pack.
+func (s *Service) RecordPack(entry InstalledPack) error {
+	lock, err := s.LoadPackLock()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i := range lock.Packs {
+		if lock.Packs[i].Name == entry.Name {
+			lock.Packs[i] = entry
+			replaced = true
+		}
+	}
+	if !replaced {
+		lock.Packs = append(lock.Packs, entry)
+	}
+	sort.Slice(lock.Packs, func(i, j int) bool { return lock.Packs[i].Name < lock.Packs[j].Name })
+	return s.savePackLock(lock)
+}
+
+// RemovePack deletes the prompts a pack installed and drops its lock entry.
+// Workflows imported from the pack are left in the database.
+func (s *Service) RemovePack(name string) (*InstalledPack, error) {
+	lock, err := s.LoadPackLock()
+	if err != nil {
+		return nil, err
+	}
+	entry := lock.Find(name)
+	if entry == nil {
+		return nil, fmt.Errorf("prompt pack %s is not installed", name)
+	}
+	removed := *entry
+	for _, phase := range removed.Prompts {
+		if err := s.Delete(phase); err != nil {
+			return nil, err
+		}
+	}
+
+	kept := lock.Packs[:0]
+	for _, p := range lock.Packs {
+		if p.Name != name {
+			kept = append(kept, p)
+		}
+	}
+	lock.Packs = kept
+	if err := s.savePackLock(lock); err != nil {
+		return nil, err
+	}
+	return &removed, nil
+}
+
+// LoadPackLock reads .orc/prompt-packs.yaml. A missing file is an empty lock.
+func (s *Service) LoadPackLock() (*PackLock, error) {
+	lock := &PackLock{}
+	data, err := os.ReadFile(s.packLockPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return lock, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", PackLockFile, err)
+	}
+	if err := yaml.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", PackLockFile, err)
+	}
+	return lock, nil
+}
+
+func (s *Service) savePackLock(lock *PackLock) error {
+	data, err := yaml.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", PackLockFile, err)
+	}
+	if err := os.MkdirAll(s.orcDir, 0755); err != nil {
+		return fmt.Errorf("create %s: %w", s.orcDir, err)
+	}
+	if err := os.WriteFile(s.packLockPath(), data, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", PackLockFile, err)
+	}
+	return nil
+}
+
+func (s *Service) packLockPath() string {
+	return filepath.Join(s.orcDir, PackLockFile)
+}
+
+// Find returns the lock entry for name, or nil.
+func (l *PackLock) Find(name string) *InstalledPack {
+	for i := range l.Packs {
+		if l.Packs[i].Name == name {
+			return &l.Packs[i]
+		}
+	}
+	return nil
+}
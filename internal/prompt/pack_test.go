@@ -0,0 +1,185 @@
+package prompt
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newPackRepo creates a local git repo laid out as a prompt pack and returns
+// its path and the commit of the first version.
+func newPackRepo(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, PackManifestFile), "name: team-prompts\nversion: 1.0.0\n")
+	writeFile(t, filepath.Join(dir, "prompts", "implement.md"), "v1 implement")
+	writeFile(t, filepath.Join(dir, "prompts", "review.md"), "v1 review")
+	gitRun(t, dir, "init", "--quiet")
+	gitRun(t, dir, "add", "-A")
+	gitRun(t, dir, "commit", "--quiet", "-m", "v1")
+	gitRun(t, dir, "tag", "v1")
+	commit := gitRun(t, dir, "rev-parse", "HEAD")
+
+	writeFile(t, filepath.Join(dir, PackManifestFile), "name: team-prompts\nversion: 2.0.0\n")
+	writeFile(t, filepath.Join(dir, "prompts", "implement.md"), "v2 implement")
+	if err := os.Remove(filepath.Join(dir, "prompts", "review.md")); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "add", "-A")
+	gitRun(t, dir, "commit", "--quiet", "-m", "v2")
+	return dir, commit
+}
+
+func gitRun(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %s: %v", args, out, err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParsePackSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		spec, repo, ref string
+	}{
+		{"https://github.com/acme/pack", "https://github.com/acme/pack", ""},
+		{"https://github.com/acme/pack@v1.2.0", "https://github.com/acme/pack", "v1.2.0"},
+		{"git@github.com:acme/pack.git", "git@github.com:acme/pack.git", ""},
+		{"git@github.com:acme/pack.git@main", "git@github.com:acme/pack.git", "main"},
+		{"/tmp/pack@", "/tmp/pack@", ""},
+	}
+	for _, tt := range tests {
+		repo, ref := ParsePackSpec(tt.spec)
+		if repo != tt.repo || ref != tt.ref {
+			t.Errorf("ParsePackSpec(%q) = (%q, %q), want (%q, %q)", tt.spec, repo, ref, tt.repo, tt.ref)
+		}
+	}
+}
+
+func TestFetchPack_PinnedRef(t *testing.T) {
+	t.Parallel()
+	repo, v1Commit := newPackRepo(t)
+
+	pack, err := FetchPack(repo, "v1")
+	if err != nil {
+		t.Fatalf("FetchPack: %v", err)
+	}
+	defer pack.Cleanup()
+
+	if pack.Commit != v1Commit {
+		t.Errorf("Commit = %s, want %s", pack.Commit, v1Commit)
+	}
+	if pack.Manifest.Version != "1.0.0" {
+		t.Errorf("Version = %q, want 1.0.0", pack.Manifest.Version)
+	}
+	if len(pack.Prompts) != 2 {
+		t.Errorf("Prompts = %v, want implement and review", pack.Prompts)
+	}
+}
+
+func TestFetchPack_MissingManifest(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "prompts", "implement.md"), "x")
+	gitRun(t, dir, "init", "--quiet")
+	gitRun(t, dir, "add", "-A")
+	gitRun(t, dir, "commit", "--quiet", "-m", "init")
+
+	if _, err := FetchPack(dir, ""); err == nil {
+		t.Fatal("FetchPack should fail without a manifest")
+	}
+}
+
+func TestInstallPrompts_UpgradeAndConflicts(t *testing.T) {
+	t.Parallel()
+	repo, _ := newPackRepo(t)
+	orcDir := filepath.Join(t.TempDir(), ".orc")
+	svc := &Service{orcDir: orcDir, resolver: NewResolver()}
+
+	// A hand-written override conflicts with the pack.
+	if err := svc.Save("implement", "local"); err != nil {
+		t.Fatal(err)
+	}
+
+	v1, err := FetchPack(repo, "v1")
+	if err != nil {
+		t.Fatalf("FetchPack v1: %v", err)
+	}
+	defer v1.Cleanup()
+
+	if err := svc.InstallPrompts(v1, false); err == nil {
+		t.Fatal("InstallPrompts should refuse to overwrite a local override")
+	}
+	if err := svc.InstallPrompts(v1, true); err != nil {
+		t.Fatalf("InstallPrompts --force: %v", err)
+	}
+	if err := svc.RecordPack(InstalledPack{Name: v1.Manifest.Name, Repo: repo, Commit: v1.Commit, Prompts: v1.Prompts}); err != nil {
+		t.Fatalf("RecordPack: %v", err)
+	}
+
+	// Upgrading replaces owned prompts without --force and drops removed ones.
+	v2, err := FetchPack(repo, "")
+	if err != nil {
+		t.Fatalf("FetchPack v2: %v", err)
+	}
+	defer v2.Cleanup()
+	if err := svc.InstallPrompts(v2, false); err != nil {
+		t.Fatalf("InstallPrompts v2: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(orcDir, "prompts", "implement.md"))
+	if err != nil || string(got) != "v2 implement" {
+		t.Errorf("implement.md = %q (%v), want v2 implement", got, err)
+	}
+	if _, err := os.Stat(filepath.Join(orcDir, "prompts", "review.md")); !os.IsNotExist(err) {
+		t.Error("review.md should be removed when the pack drops it")
+	}
+}
+
+func TestRemovePack(t *testing.T) {
+	t.Parallel()
+	orcDir := filepath.Join(t.TempDir(), ".orc")
+	svc := &Service{orcDir: orcDir, resolver: NewResolver()}
+
+	if err := svc.Save("implement", "from pack"); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RecordPack(InstalledPack{Name: "p", Commit: "abc", Prompts: []string{"implement"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.RemovePack("p"); err != nil {
+		t.Fatalf("RemovePack: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(orcDir, "prompts", "implement.md")); !os.IsNotExist(err) {
+		t.Error("pack prompt should be deleted")
+	}
+	lock, err := svc.LoadPackLock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lock.Packs) != 0 {
+		t.Errorf("lock still has %d packs", len(lock.Packs))
+	}
+	if _, err := svc.RemovePack("p"); err == nil {
+		t.Error("removing an uninstalled pack should fail")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/randalmurphal/orc/internal/controlplane"
+	"github.com/randalmurphal/orc/internal/detect"
 	"github.com/randalmurphal/orc/templates"
 )
 
@@ -272,10 +273,19 @@ func GetVariableReference() map[string]string {
 		"{{HAS_TESTS}}":    "Whether project has existing tests",
 		"{{FRAMEWORKS}}":   "Detected frameworks",
 
-		// Commands
-		"{{TEST_COMMAND}}":  "Project test command",
-		"{{LINT_COMMAND}}":  "Project lint command",
-		"{{BUILD_COMMAND}}": "Project build command",
+		// Commands (project_commands entries override detected commands)
+		"{{TEST_COMMAND}}":  "Project test command: the enabled 'tests' project command, else the detected one",
+		"{{LINT_COMMAND}}":  "Project lint command: the enabled 'lint' project command, else the detected one",
+		"{{BUILD_COMMAND}}": "Project build command: the enabled 'build' project command, else the detected one",
+		"{{SCRIPTS_LIST}}":  "Markdown list of project scripts registered through the scripts API (.claude/settings.json). Empty when none are registered.",
+		"{{REPO_MAP}}": fmt.Sprintf(
+			"Top two directory levels of the repository with file counts, then top-level files. Truncated to %d KiB.",
+			detect.MaxRepoMapBytes/1024,
+		),
+		"{{FILE_SUMMARIES}}": fmt.Sprintf(
+			"One line per git-tracked source file: language, line count, and top-level declarations. Cached by content hash and shared across tasks. Truncated to %d KiB.",
+			detect.MaxFileSummariesBytes/1024,
+		),
 
 		// Control-plane context
 		"{{PENDING_RECOMMENDATIONS}}": fmt.Sprintf(
@@ -318,6 +318,7 @@ func TestGetVariableReference(t *testing.T) {
 		"{{WORKTREE_PATH}}", "{{PROJECT_ROOT}}", "{{TASK_BRANCH}}", "{{TARGET_BRANCH}}",
 		"{{LANGUAGE}}", "{{HAS_FRONTEND}}", "{{HAS_TESTS}}", "{{FRAMEWORKS}}",
 		"{{TEST_COMMAND}}", "{{LINT_COMMAND}}", "{{BUILD_COMMAND}}",
+		"{{SCRIPTS_LIST}}", "{{REPO_MAP}}", "{{FILE_SUMMARIES}}",
 		"{{OUTPUT_<PHASE_ID>}}",
 	}
 	for _, v := range required {
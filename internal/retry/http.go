@@ -0,0 +1,109 @@
+package retry
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Transport is an http.RoundTripper that retries requests answered with 429
+// Too Many Requests or 503 Service Unavailable and, for idempotent methods,
+// requests failing with a network error or a 502/504 gateway error. A
+// Retry-After header is honored when it does not exceed the policy's Max.
+// Requests with a body are retried only if it can be replayed
+// (http.NewRequest sets GetBody for in-memory bodies).
+type Transport struct {
+	// Base performs the requests (default: http.DefaultTransport).
+	Base http.RoundTripper
+	// Policy controls attempts and delays.
+	Policy Policy
+}
+
+// NewTransport wraps base with retries under p.
+func NewTransport(base http.RoundTripper, p Policy) *Transport {
+	return &Transport{Base: base, Policy: p}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	attempts := t.Policy.Attempts()
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		attempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		r := req
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(req.Context())
+			r.Body = body
+		}
+
+		resp, err := base.RoundTrip(r)
+		if attempt >= attempts || !retryableResponse(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.Policy.Delay(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok && after <= t.Policy.WithDefaults().Max {
+				wait = after
+			}
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			_ = resp.Body.Close()
+		}
+		if serr := Sleep(req.Context(), wait); serr != nil {
+			return nil, serr
+		}
+	}
+}
+
+// retryableResponse reports whether a round trip result is worth retrying.
+// A POST that failed in transit may have been applied, so only rejections
+// the server made before doing any work are retried for it.
+func retryableResponse(req *http.Request, resp *http.Response, err error) bool {
+	idempotent := req.Method != http.MethodPost && req.Method != http.MethodPatch
+	if err != nil {
+		return idempotent && req.Context().Err() == nil
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		return true
+	}
+	return idempotent && RetryableStatus(resp.StatusCode)
+}
+
+// RetryableStatus reports whether an HTTP status signals a transient
+// failure: rate limiting or an unavailable upstream.
+func RetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
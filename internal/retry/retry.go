@@ -0,0 +1,186 @@
+// Package retry runs calls to external services (hosting APIs, CI, model
+// APIs, webhooks) with exponential backoff and jitter, and paces polling
+// loops the same way.
+package retry
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Policy describes how failed calls are retried. The delay before retry n
+// (1-based) is Initial*Multiplier^(n-1), capped at Max, then spread by up to
+// ±Jitter of itself so that many clients do not retry in lockstep.
+type Policy struct {
+	// MaxAttempts is the total number of tries, including the first.
+	MaxAttempts int
+	// Initial is the delay before the first retry.
+	Initial time.Duration
+	// Max caps the delay between tries.
+	Max time.Duration
+	// Multiplier is the growth factor between consecutive delays.
+	Multiplier float64
+	// Jitter is the fraction (0-1) by which each delay is randomized.
+	Jitter float64
+}
+
+// Defaults used for unset (zero) Policy fields.
+const (
+	DefaultMaxAttempts = 4
+	DefaultInitial     = time.Second
+	DefaultMax         = 30 * time.Second
+	DefaultMultiplier  = 2.0
+	DefaultJitter      = 0.2
+)
+
+// DefaultPolicy returns the policy used when nothing is configured.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts: DefaultMaxAttempts,
+		Initial:     DefaultInitial,
+		Max:         DefaultMax,
+		Multiplier:  DefaultMultiplier,
+		Jitter:      DefaultJitter,
+	}
+}
+
+// WithDefaults returns p with unset fields filled from the defaults and the
+// rest clamped into range.
+func (p Policy) WithDefaults() Policy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultMaxAttempts
+	}
+	if p.Initial <= 0 {
+		p.Initial = DefaultInitial
+	}
+	if p.Max <= 0 {
+		p.Max = DefaultMax
+	}
+	if p.Max < p.Initial {
+		p.Max = p.Initial
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = DefaultMultiplier
+	}
+	if p.Jitter < 0 {
+		p.Jitter = 0
+	}
+	if p.Jitter > 1 {
+		p.Jitter = 1
+	}
+	return p
+}
+
+// Delay returns the jittered wait before retry n (1-based).
+func (p Policy) Delay(n int) time.Duration {
+	p = p.WithDefaults()
+	if n < 1 {
+		n = 1
+	}
+	d := float64(p.Initial) * math.Pow(p.Multiplier, float64(n-1))
+	if d > float64(p.Max) {
+		d = float64(p.Max)
+	}
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(d)
+}
+
+// Attempts returns the total number of tries the policy allows.
+func (p Policy) Attempts() int {
+	return p.WithDefaults().MaxAttempts
+}
+
+// permanentError marks an error that retrying cannot fix.
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so that Do returns it immediately instead of retrying.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// Do calls fn until it succeeds, returns a Permanent error, the policy's
+// attempts run out, or ctx is done. onRetry, if non-nil, is called with the
+// failed attempt number, its error, and the wait before the next try. The
+// last error is returned, unwrapped from Permanent.
+func Do(ctx context.Context, p Policy, fn func(ctx context.Context) error, onRetry func(attempt int, err error, wait time.Duration)) error {
+	attempts := p.Attempts()
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn(ctx)
+		if err == nil {
+			return nil
+		}
+		var perm *permanentError
+		if errors.As(err, &perm) {
+			return perm.err
+		}
+		if attempt >= attempts {
+			return err
+		}
+		wait := p.Delay(attempt)
+		if onRetry != nil {
+			onRetry(attempt, err, wait)
+		}
+		if serr := Sleep(ctx, wait); serr != nil {
+			return err
+		}
+	}
+}
+
+// Sleep waits for d or until ctx is done, returning ctx's error in the
+// latter case.
+func Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Backoff paces an open-ended polling loop: each Next wait grows by the
+// policy's multiplier up to its Max. MaxAttempts does not apply; the caller
+// decides when to stop.
+type Backoff struct {
+	policy Policy
+	n      int
+}
+
+// NewBackoff starts a backoff sequence for p.
+func NewBackoff(p Policy) *Backoff {
+	return &Backoff{policy: p}
+}
+
+// Next returns the wait before the next poll.
+func (b *Backoff) Next() time.Duration {
+	b.n++
+	return b.policy.Delay(b.n)
+}
+
+// Reset restarts the sequence at the policy's initial delay, e.g. after
+// progress is observed.
+func (b *Backoff) Reset() {
+	b.n = 0
+}
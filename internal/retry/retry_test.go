@@ -0,0 +1,172 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPolicy_Delay(t *testing.T) {
+	p := Policy{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}
+	for n, want := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		if got := p.Delay(n); got != want {
+			t.Errorf("Delay(%d) = %v, want %v", n, got, want)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.Delay(2); got < 100*time.Millisecond || got > 300*time.Millisecond {
+			t.Fatalf("jittered Delay(2) = %v, want within 100ms-300ms", got)
+		}
+	}
+}
+
+func TestPolicy_ZeroValueUsesDefaults(t *testing.T) {
+	var p Policy
+	if got := p.Attempts(); got != DefaultMaxAttempts {
+		t.Errorf("Attempts() = %d, want %d", got, DefaultMaxAttempts)
+	}
+	got := p.Delay(1)
+	lo := time.Duration(float64(DefaultInitial) * (1 - DefaultJitter))
+	hi := time.Duration(float64(DefaultInitial) * (1 + DefaultJitter))
+	if got < lo || got > hi {
+		t.Errorf("Delay(1) = %v, want within %v-%v", got, lo, hi)
+	}
+}
+
+func fastPolicy(attempts int) Policy {
+	return Policy{MaxAttempts: attempts, Initial: time.Millisecond, Max: time.Millisecond, Multiplier: 1}
+}
+
+func TestDo(t *testing.T) {
+	errFlaky := errors.New("flaky")
+
+	t.Run("retries until success", func(t *testing.T) {
+		calls := 0
+		var retries []int
+		err := Do(context.Background(), fastPolicy(5), func(context.Context) error {
+			calls++
+			if calls < 3 {
+				return errFlaky
+			}
+			return nil
+		}, func(attempt int, err error, _ time.Duration) { retries = append(retries, attempt) })
+		if err != nil {
+			t.Fatalf("Do() = %v", err)
+		}
+		if calls != 3 || len(retries) != 2 {
+			t.Errorf("calls = %d, retries = %v; want 3 calls, 2 retries", calls, retries)
+		}
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		calls := 0
+		err := Do(context.Background(), fastPolicy(3), func(context.Context) error {
+			calls++
+			return errFlaky
+		}, nil)
+		if !errors.Is(err, errFlaky) || calls != 3 {
+			t.Errorf("Do() = %v after %d calls, want flaky after 3", err, calls)
+		}
+	})
+
+	t.Run("stops on permanent error", func(t *testing.T) {
+		calls := 0
+		err := Do(context.Background(), fastPolicy(5), func(context.Context) error {
+			calls++
+			return Permanent(errFlaky)
+		}, nil)
+		if !errors.Is(err, errFlaky) || IsPermanent(err) || calls != 1 {
+			t.Errorf("Do() = %v after %d calls, want unwrapped flaky after 1", err, calls)
+		}
+	})
+
+	t.Run("stops when context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		err := Do(ctx, Policy{MaxAttempts: 5, Initial: time.Hour}, func(context.Context) error {
+			calls++
+			cancel()
+			return errFlaky
+		}, nil)
+		if !errors.Is(err, errFlaky) || calls != 1 {
+			t.Errorf("Do() = %v after %d calls, want flaky after 1", err, calls)
+		}
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	b := NewBackoff(Policy{Initial: time.Second, Max: 4 * time.Second, Multiplier: 2})
+	var got []time.Duration
+	for i := 0; i < 4; i++ {
+		got = append(got, b.Next())
+	}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Next() sequence = %v, want %v", got, want)
+		}
+	}
+	b.Reset()
+	if d := b.Next(); d != time.Second {
+		t.Errorf("Next() after Reset = %v, want 1s", d)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		if strings.HasSuffix(r.URL.Path, "/bad-gateway") && n < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/limited") && n < 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewTransport(nil, fastPolicy(4))}
+	for _, tc := range []struct {
+		method, path string
+		wantStatus   int
+		wantCalls    int32
+	}{
+		{http.MethodGet, "/bad-gateway", http.StatusOK, 3},
+		{http.MethodPost, "/bad-gateway", http.StatusBadGateway, 1}, // not idempotent
+		{http.MethodPost, "/limited", http.StatusOK, 2},             // rejected before any work
+		{http.MethodGet, "/missing", http.StatusNotFound, 1},
+	} {
+		calls.Store(0)
+		req, _ := http.NewRequest(tc.method, srv.URL+tc.path, strings.NewReader("body"))
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s: %v", tc.method, tc.path, err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != tc.wantStatus || calls.Load() != tc.wantCalls {
+			t.Errorf("%s %s: status %d after %d calls, want %d after %d",
+				tc.method, tc.path, resp.StatusCode, calls.Load(), tc.wantStatus, tc.wantCalls)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Package scripts runs the project scripts registered through the
+// ConfigService script RPCs (.claude/settings.json) with validated
+// parameters. Only registered scripts can be run.
+package scripts
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds a script run.
+const DefaultTimeout = 10 * time.Minute
+
+// Parameter limits keep a run's environment and record small.
+const (
+	MaxParams          = 32
+	MaxParamValueBytes = 4096
+)
+
+var paramNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// BindParams validates run parameters. Names must be identifiers and values
+// must be short and free of NUL bytes; values reach the script as
+// ORC_PARAM_<NAME> environment variables and are never interpolated into a
+// shell.
+func BindParams(values map[string]string) (map[string]string, error) {
+	if len(values) > MaxParams {
+		return nil, fmt.Errorf("too many parameters: %d (max %d)", len(values), MaxParams)
+	}
+	bound := make(map[string]string, len(values))
+	seen := make(map[string]string, len(values))
+	for name, value := range values {
+		if !paramNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid parameter name %q", name)
+		}
+		upper := strings.ToUpper(name)
+		if other, ok := seen[upper]; ok {
+			return nil, fmt.Errorf("parameters %s and %s both map to ORC_PARAM_%s", other, name, upper)
+		}
+		seen[upper] = name
+		if len(value) > MaxParamValueBytes {
+			return nil, fmt.Errorf("parameter %s exceeds %d bytes", name, MaxParamValueBytes)
+		}
+		if strings.ContainsRune(value, 0) {
+			return nil, fmt.Errorf("parameter %s contains a NUL byte", name)
+		}
+		bound[name] = value
+	}
+	return bound, nil
+}
+
+// Env returns the ORC_PARAM_* environment entries for bound parameters,
+// sorted by name.
+func Env(bound map[string]string) []string {
+	env := make([]string, 0, len(bound))
+	for name, value := range bound {
+		env = append(env, "ORC_PARAM_"+strings.ToUpper(name)+"="+value)
+	}
+	sort.Strings(env)
+	return env
+}
@@ -0,0 +1,165 @@
+package scripts
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/randalmurphal/orc/internal/claude"
+)
+
+// MaxCapturedOutput bounds the output kept in a run record. Streaming
+// callbacks still receive every line.
+const MaxCapturedOutput = 1 << 20
+
+// Output stream names passed to line callbacks.
+const (
+	StreamStdout = "stdout"
+	StreamStderr = "stderr"
+)
+
+// Result is the outcome of a finished run.
+type Result struct {
+	ExitCode int
+	// Output interleaves stdout and stderr lines in arrival order.
+	Output    string
+	Truncated bool
+}
+
+// LineFunc receives each output line as it is produced.
+type LineFunc func(stream, line string)
+
+// interpreters runs registered scripts that are not executable, keyed by
+// the ProjectScript language.
+var interpreters = map[string]string{
+	"bash":       "bash",
+	"python":     "python3",
+	"javascript": "node",
+	"ruby":       "ruby",
+	"perl":       "perl",
+	"php":        "php",
+}
+
+// ResolvePath returns the absolute path of a registered script, rejecting
+// paths that escape the project root.
+func ResolvePath(projectRoot string, s *claude.ProjectScript) (string, error) {
+	path := filepath.Join(projectRoot, filepath.FromSlash(s.Path))
+	rel, err := filepath.Rel(projectRoot, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("script %s: path %s is outside the project", s.Name, s.Path)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("script %s: %s not found", s.Name, s.Path)
+		}
+		return "", fmt.Errorf("script %s: %w", s.Name, err)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("script %s: %s is a directory", s.Name, s.Path)
+	}
+	if info.Mode()&0111 == 0 && interpreters[s.Language] == "" {
+		return "", fmt.Errorf("script %s: %s is not executable and has no known interpreter", s.Name, s.Path)
+	}
+	return path, nil
+}
+
+// Run executes a registered script from projectRoot with bound parameters,
+// calling onLine for every output line. Executable files run directly;
+// others run through the interpreter for their language. A non-zero exit is
+// reported in the result, not as an error; errors mean the script could not
+// be run or timed out.
+func Run(ctx context.Context, projectRoot string, s *claude.ProjectScript, bound map[string]string, onLine LineFunc) (*Result, error) {
+	path, err := ResolvePath(projectRoot, s)
+	if err != nil {
+		return nil, err
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if info, err := os.Stat(path); err == nil && info.Mode()&0111 != 0 {
+		cmd = exec.CommandContext(runCtx, path)
+	} else {
+		cmd = exec.CommandContext(runCtx, interpreters[s.Language], path)
+	}
+	cmd.Dir = projectRoot
+	cmd.Env = append(os.Environ(),
+		"ORC_PROJECT_ROOT="+projectRoot,
+		"ORC_SCRIPT_NAME="+s.Name,
+	)
+	cmd.Env = append(cmd.Env, Env(bound)...)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("script %s: stdout pipe: %w", s.Name, err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("script %s: stderr pipe: %w", s.Name, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start script %s: %w", s.Name, err)
+	}
+
+	capture := &outputCapture{onLine: onLine}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); capture.read(StreamStdout, stdout) }()
+	go func() { defer wg.Done(); capture.read(StreamStderr, stderr) }()
+	wg.Wait()
+
+	waitErr := cmd.Wait()
+	result := &Result{Output: capture.buf.String(), Truncated: capture.truncated}
+
+	if runCtx.Err() == context.DeadlineExceeded {
+		return result, fmt.Errorf("script %s timed out after %s", s.Name, DefaultTimeout)
+	}
+	if waitErr != nil {
+		var exitErr *exec.ExitError
+		if errors.As(waitErr, &exitErr) {
+			result.ExitCode = exitErr.ExitCode()
+			return result, nil
+		}
+		return result, fmt.Errorf("run script %s: %w", s.Name, waitErr)
+	}
+	return result, nil
+}
+
+// outputCapture collects lines from both streams into one bounded buffer.
+type outputCapture struct {
+	mu        sync.Mutex
+	buf       strings.Builder
+	truncated bool
+	onLine    LineFunc
+}
+
+func (c *outputCapture) read(stream string, r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), MaxCapturedOutput)
+	for scanner.Scan() {
+		line := scanner.Text()
+		c.mu.Lock()
+		if c.buf.Len()+len(line)+1 <= MaxCapturedOutput {
+			c.buf.WriteString(line)
+			c.buf.WriteByte('\n')
+		} else {
+			c.truncated = true
+		}
+		if c.onLine != nil {
+			c.onLine(stream, line)
+		}
+		c.mu.Unlock()
+	}
+	// Drain anything the scanner could not tokenize so the process never
+	// blocks on a full pipe.
+	_, _ = io.Copy(io.Discard, r)
+}
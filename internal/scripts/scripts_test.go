@@ -0,0 +1,104 @@
+package scripts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/randalmurphal/orc/internal/claude"
+)
+
+func writeScript(t *testing.T, root, path, body string, mode os.FileMode) {
+	t.Helper()
+	full := filepath.Join(root, path)
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte(body), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBindParams(t *testing.T) {
+	bound, err := BindParams(map[string]string{"mode": "apply", "days": "7"})
+	if err != nil {
+		t.Fatalf("BindParams: %v", err)
+	}
+	if bound["days"] != "7" || bound["mode"] != "apply" {
+		t.Errorf("bound = %v", bound)
+	}
+	if env := Env(bound); len(env) != 2 || env[0] != "ORC_PARAM_DAYS=7" || env[1] != "ORC_PARAM_MODE=apply" {
+		t.Errorf("Env = %v", env)
+	}
+
+	tooMany := make(map[string]string, MaxParams+1)
+	for i := 0; i <= MaxParams; i++ {
+		tooMany["p"+strings.Repeat("x", i)] = "1"
+	}
+	for name, values := range map[string]map[string]string{
+		"bad name":  {"x-y": "1"},
+		"collision": {"mode": "a", "MODE": "b"},
+		"nul":       {"mode": "a\x00b"},
+		"too long":  {"mode": strings.Repeat("a", MaxParamValueBytes+1)},
+		"too many":  tooMany,
+	} {
+		if _, err := BindParams(values); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestRun_StreamsOutputAndPassesParams(t *testing.T) {
+	root := t.TempDir()
+	writeScript(t, root, ".claude/scripts/cleanup.sh", "#!/bin/sh\necho \"mode=$ORC_PARAM_MODE days=$ORC_PARAM_DAYS\"\necho warn >&2\nexit 3\n", 0755)
+	script := &claude.ProjectScript{Name: "cleanup", Path: ".claude/scripts/cleanup.sh", Description: "Clean up"}
+
+	var mu sync.Mutex
+	var lines []string
+	result, err := Run(context.Background(), root, script, map[string]string{"mode": "dry-run", "days": "3"}, func(stream, line string) {
+		mu.Lock()
+		defer mu.Unlock()
+		lines = append(lines, stream+":"+line)
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.ExitCode != 3 {
+		t.Errorf("exit code = %d, want 3", result.ExitCode)
+	}
+	if !strings.Contains(result.Output, "mode=dry-run days=3") || !strings.Contains(result.Output, "warn") {
+		t.Errorf("output = %q", result.Output)
+	}
+	if len(lines) != 2 {
+		t.Errorf("streamed lines = %v, want 2", lines)
+	}
+}
+
+func TestRun_NonExecutableUsesInterpreter(t *testing.T) {
+	root := t.TempDir()
+	writeScript(t, root, "scripts/hello.sh", "echo hello from bash\n", 0644)
+	script := &claude.ProjectScript{Name: "hello", Path: "scripts/hello.sh", Language: "bash"}
+
+	result, err := Run(context.Background(), root, script, nil, nil)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.ExitCode != 0 || !strings.Contains(result.Output, "hello from bash") {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestResolvePath_RejectsEscapes(t *testing.T) {
+	root := t.TempDir()
+	writeScript(t, root, "notes.txt", "x", 0644)
+
+	for _, path := range []string{"../../etc/passwd", "missing.sh", "notes.txt", "."} {
+		s := &claude.ProjectScript{Name: "x", Path: path}
+		if _, err := ResolvePath(root, s); err == nil {
+			t.Errorf("ResolvePath(%q) should fail", path)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	// ChecksumsFile lists "<sha256>  <asset>" for every release archive.
+	ChecksumsFile = "checksums.txt"
+	// SignatureFile is the ed25519 signature of ChecksumsFile (raw or base64).
+	SignatureFile = "checksums.txt.sig"
+
+	// maxArchiveSize bounds downloaded release archives.
+	maxArchiveSize = 256 << 20
+)
+
+// ErrNoAsset means the release has no archive for this platform.
+var ErrNoAsset = errors.New("release has no build for this platform")
+
+// ArchiveName returns the release archive name for a platform, as produced
+// by the release workflow: orc-<tag>-<os>-<arch>.tar.gz.
+func ArchiveName(tag, goos, goarch string) string {
+	return fmt.Sprintf("orc-%s-%s-%s.tar.gz", tag, goos, goarch)
+}
+
+// ParsePublicKey decodes a base64 ed25519 public key. An empty key returns
+// nil, which disables signature verification.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	if s == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("updates.public_key is not a base64 ed25519 public key")
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// Download fetches the release archive for the running platform, verifies
+// it against the release checksums (and their signature when publicKey is
+// set) and writes the extracted binary to dest.
+func (c *Client) Download(ctx context.Context, rel *Release, publicKey ed25519.PublicKey, dest string) error {
+	name := ArchiveName(rel.Tag, runtime.GOOS, runtime.GOARCH)
+	archive := rel.Asset(name)
+	if archive == nil {
+		return fmt.Errorf("%w: %s", ErrNoAsset, name)
+	}
+	sums := rel.Asset(ChecksumsFile)
+	if sums == nil {
+		return fmt.Errorf("release %s has no %s; refusing to install unverified binary", rel.Tag, ChecksumsFile)
+	}
+
+	sumsData, err := c.fetch(ctx, sums.URL, 1<<20)
+	if err != nil {
+		return err
+	}
+	if publicKey != nil {
+		sig := rel.Asset(SignatureFile)
+		if sig == nil {
+			return fmt.Errorf("release %s has no %s but updates.public_key is set", rel.Tag, SignatureFile)
+		}
+		sigData, err := c.fetch(ctx, sig.URL, 4<<10)
+		if err != nil {
+			return err
+		}
+		if err := VerifySignature(publicKey, sumsData, sigData); err != nil {
+			return err
+		}
+	}
+	want, err := checksumFor(sumsData, name)
+	if err != nil {
+		return err
+	}
+
+	data, err := c.fetch(ctx, archive.URL, maxArchiveSize)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("checksum mismatch for %s: got %s, want %s", name, got, want)
+	}
+
+	binary, err := extractBinary(data, strings.TrimSuffix(name, ".tar.gz"))
+	if err != nil {
+		return err
+	}
+	return writeExecutable(dest, binary)
+}
+
+// VerifySignature checks an ed25519 signature, raw or base64-encoded, over data.
+func VerifySignature(publicKey ed25519.PublicKey, data, sig []byte) error {
+	if len(sig) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+		if err != nil {
+			return fmt.Errorf("%s is not a valid signature", SignatureFile)
+		}
+		sig = decoded
+	}
+	if !ed25519.Verify(publicKey, data, sig) {
+		return fmt.Errorf("signature verification of %s failed", ChecksumsFile)
+	}
+	return nil
+}
+
+func (c *Client) fetch(ctx context.Context, url string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download %s: %w", path.Base(url), err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download %s: %s", path.Base(url), resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("download %s: %w", path.Base(url), err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("download %s: larger than %d bytes", path.Base(url), limit)
+	}
+	return data, nil
+}
+
+// checksumFor finds the digest for name in a sha256sum-format file.
+func checksumFor(sums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s has no entry for %s", ChecksumsFile, name)
+}
+
+// extractBinary returns the regular file named name from a .tar.gz archive.
+func extractBinary(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("read archive: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("archive has no %s", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read archive: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg && path.Base(hdr.Name) == name {
+			return io.ReadAll(io.LimitReader(tr, maxArchiveSize))
+		}
+	}
+}
+
+// writeExecutable atomically writes an executable file at dest.
+func writeExecutable(dest string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dest), ".orc-update-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dest)
+}
+
+// StagedPath is where a background download of tag is kept until installed.
+func StagedPath(dir, tag string) string {
+	return filepath.Join(dir, "orc-"+tag)
+}
+
+// Install replaces the binary at exe with the one at src. The new binary is
+// copied next to exe first so the final rename is atomic.
+func Install(src, exe string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := writeExecutable(exe, data); err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return fmt.Errorf("replace %s: %w (re-run with permission to write there, or reinstall with install.sh)", exe, err)
+		}
+		return fmt.Errorf("replace %s: %w", exe, err)
+	}
+	return nil
+}
+
+// Executable returns the resolved path of the running binary.
+func Executable() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(exe)
+}
@@ -0,0 +1,246 @@
+// Package selfupdate checks the release feed for newer orc versions and
+// replaces the running binary with a downloaded, verified release.
+package selfupdate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultFeedURL is orc's GitHub releases feed.
+const DefaultFeedURL = "https://api.github.com/repos/randalmurphal/orc/releases"
+
+// CheckInterval is how long a startup check result is reused.
+const CheckInterval = 24 * time.Hour
+
+// Asset is a file attached to a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Release is one entry of the releases feed (GitHub releases API format).
+type Release struct {
+	Tag         string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Notes       string    `json:"body"`
+	URL         string    `json:"html_url"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+	Assets      []Asset   `json:"assets"`
+}
+
+// Version returns the release version without the leading "v".
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.Tag, "v")
+}
+
+// Asset returns the asset with the given name, or nil.
+func (r *Release) Asset(name string) *Asset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// Client reads a releases feed and downloads release assets.
+type Client struct {
+	FeedURL string
+	HTTP    *http.Client
+}
+
+// NewClient returns a client for feedURL (DefaultFeedURL when empty).
+func NewClient(feedURL string) *Client {
+	if feedURL == "" {
+		feedURL = DefaultFeedURL
+	}
+	return &Client{FeedURL: feedURL, HTTP: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// Releases returns the published, non-prerelease releases, newest first.
+func (c *Client) Releases(ctx context.Context) ([]Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.FeedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch releases: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch releases: %s", resp.Status)
+	}
+
+	var all []Release
+	if err := json.NewDecoder(resp.Body).Decode(&all); err != nil {
+		return nil, fmt.Errorf("parse releases: %w", err)
+	}
+	releases := all[:0]
+	for _, r := range all {
+		if r.Draft || r.Prerelease || !IsRelease(r.Version()) {
+			continue
+		}
+		releases = append(releases, r)
+	}
+	sort.SliceStable(releases, func(i, j int) bool {
+		return Compare(releases[i].Version(), releases[j].Version()) > 0
+	})
+	return releases, nil
+}
+
+// Result is the outcome of a version check.
+type Result struct {
+	Current string
+	// Latest is the newest release, nil when the feed has none.
+	Latest *Release
+	// Newer lists the releases after Current, newest first. Their notes make
+	// up the changelog for the update.
+	Newer []Release
+}
+
+// UpdateAvailable reports whether a newer release exists.
+func (r *Result) UpdateAvailable() bool {
+	return len(r.Newer) > 0
+}
+
+// Check compares current against the feed.
+func (c *Client) Check(ctx context.Context, current string) (*Result, error) {
+	releases, err := c.Releases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{Current: strings.TrimPrefix(current, "v")}
+	if len(releases) > 0 {
+		res.Latest = &releases[0]
+	}
+	for _, r := range releases {
+		if Compare(r.Version(), res.Current) > 0 {
+			res.Newer = append(res.Newer, r)
+		}
+	}
+	return res, nil
+}
+
+// semver is a parsed MAJOR.MINOR.PATCH[-PRERELEASE] version.
+type semver struct {
+	parts      [3]int
+	prerelease string
+}
+
+func parseVersion(v string) (semver, bool) {
+	v = strings.TrimPrefix(v, "v")
+	var sv semver
+	core, pre, _ := strings.Cut(v, "-")
+	core, _, _ = strings.Cut(core, "+")
+	fields := strings.Split(core, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return sv, false
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return sv, false
+		}
+		sv.parts[i] = n
+	}
+	sv.prerelease = pre
+	return sv, true
+}
+
+// IsRelease reports whether v is a plain release version. Development
+// builds ("dev", "0.1.0-dev", git describe output) are not, and never
+// prompt for updates.
+func IsRelease(v string) bool {
+	sv, ok := parseVersion(v)
+	return ok && sv.prerelease == ""
+}
+
+// Compare returns -1, 0 or 1 as a is older than, equal to or newer than b.
+// A prerelease is older than its release; unparsable versions are oldest.
+func Compare(a, b string) int {
+	va, okA := parseVersion(a)
+	vb, okB := parseVersion(b)
+	switch {
+	case !okA && !okB:
+		return strings.Compare(a, b)
+	case !okA:
+		return -1
+	case !okB:
+		return 1
+	}
+	for i := range va.parts {
+		if va.parts[i] != vb.parts[i] {
+			if va.parts[i] < vb.parts[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case va.prerelease == vb.prerelease:
+		return 0
+	case va.prerelease == "":
+		return 1
+	case vb.prerelease == "":
+		return -1
+	}
+	return strings.Compare(va.prerelease, vb.prerelease)
+}
+
+// State caches the last startup check so it runs at most once per
+// CheckInterval.
+type State struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Latest    string    `json:"latest,omitempty"`
+	URL       string    `json:"url,omitempty"`
+}
+
+// Fresh reports whether the cached check is recent enough to reuse.
+func (s *State) Fresh(now time.Time) bool {
+	return !s.CheckedAt.IsZero() && now.Sub(s.CheckedAt) < CheckInterval
+}
+
+// Dir returns ~/.orc/updates, where the check state and staged downloads live.
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".orc", "updates"), nil
+}
+
+// LoadState reads the check state from dir. A missing or corrupt file
+// yields an empty state.
+func LoadState(dir string) *State {
+	state := &State{}
+	data, err := os.ReadFile(filepath.Join(dir, "state.json"))
+	if err == nil {
+		_ = json.Unmarshal(data, state)
+	}
+	return state
+}
+
+// SaveState writes the check state to dir.
+func SaveState(dir string, state *State) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "state.json"), data, 0644)
+}
@@ -0,0 +1,134 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "v1.2.3", 0},
+		{"1.10.0", "1.9.9", 1},
+		{"0.2.0", "0.2.0-rc1", 1},
+		{"0.2.0-rc1", "0.2.0-rc2", -1},
+		{"1.0", "1.0.1", -1},
+		{"dev", "0.0.1", -1},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+	for v, want := range map[string]bool{"v0.3.0": true, "0.1.0-dev": false, "dev": false, "v0.3.0-4-gabc123-dirty": false} {
+		if IsRelease(v) != want {
+			t.Errorf("IsRelease(%q) = %v", v, !want)
+		}
+	}
+}
+
+func makeArchive(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = tw.Write(content)
+	_ = tw.Close()
+	_ = gz.Close()
+	return buf.Bytes()
+}
+
+// newFeed serves a release feed with v0.2.0 (current) and v0.3.0, whose
+// archive for this platform contains binary.
+func newFeed(t *testing.T, binary []byte, priv ed25519.PrivateKey, tamper bool) *httptest.Server {
+	t.Helper()
+	tag := "v0.3.0"
+	name := ArchiveName(tag, runtime.GOOS, runtime.GOARCH)
+	archive := makeArchive(t, strings.TrimSuffix(name, ".tar.gz"), binary)
+	sum := sha256.Sum256(archive)
+	sums := []byte(fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), name))
+	if tamper {
+		archive = makeArchive(t, strings.TrimSuffix(name, ".tar.gz"), []byte("evil"))
+	}
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	assets := []Asset{{Name: name, URL: srv.URL + "/dl/" + name}, {Name: ChecksumsFile, URL: srv.URL + "/dl/" + ChecksumsFile}}
+	if priv != nil {
+		assets = append(assets, Asset{Name: SignatureFile, URL: srv.URL + "/dl/" + SignatureFile})
+	}
+	mux.HandleFunc("/releases", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]Release{
+			{Tag: "v0.2.0", Notes: "old"},
+			{Tag: "v0.4.0-rc1", Prerelease: true},
+			{Tag: tag, Notes: "- faster runs", URL: "https://example.com/v0.3.0", Assets: assets},
+		})
+	})
+	mux.HandleFunc("/dl/"+name, func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write(archive) })
+	mux.HandleFunc("/dl/"+ChecksumsFile, func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write(sums) })
+	mux.HandleFunc("/dl/"+SignatureFile, func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write(ed25519.Sign(priv, sums)) })
+	return srv
+}
+
+func TestCheckAndDownload(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	srv := newFeed(t, []byte("#!orc v0.3.0"), priv, false)
+	c := NewClient(srv.URL + "/releases")
+
+	res, err := c.Check(context.Background(), "v0.2.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.UpdateAvailable() || res.Latest.Tag != "v0.3.0" || len(res.Newer) != 1 {
+		t.Fatalf("result = %+v", res)
+	}
+
+	dest := filepath.Join(t.TempDir(), "orc")
+	if err := c.Download(context.Background(), res.Latest, pub, dest); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(dest); string(got) != "#!orc v0.3.0" {
+		t.Errorf("binary = %q", got)
+	}
+
+	otherPub, _, _ := ed25519.GenerateKey(nil)
+	if err := c.Download(context.Background(), res.Latest, otherPub, dest); err == nil || !strings.Contains(err.Error(), "signature") {
+		t.Errorf("wrong key err = %v", err)
+	}
+}
+
+func TestDownload_ChecksumMismatch(t *testing.T) {
+	srv := newFeed(t, []byte("good"), nil, true)
+	c := NewClient(srv.URL + "/releases")
+	releases, err := c.Releases(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dest := filepath.Join(t.TempDir(), "orc")
+	if err := c.Download(context.Background(), &releases[0], nil, dest); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("err = %v", err)
+	}
+	if _, err := os.Stat(dest); !os.IsNotExist(err) {
+		t.Error("binary written despite checksum mismatch")
+	}
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// execCommand is a variable to allow test mocking of exec.Command.
+var execCommand = exec.Command
+
+// ErrNotInstalled means the unit file does not exist.
+var ErrNotInstalled = errors.New("service is not installed")
+
+// InstallResult reports what Install did.
+type InstallResult struct {
+	Path       string
+	EnvFile    string
+	EnvCreated bool
+	LogFile    string
+	// Commands are the systemctl/launchctl invocations that were run.
+	Commands [][]string
+}
+
+// Install writes the unit for o, creates the env file (from
+// EnvFileTemplate, mode 0600) if it is missing, and registers the unit with
+// the service manager. With start, the server is also started now;
+// otherwise it starts at the next boot or login. An existing unit is only
+// replaced with force.
+func Install(o Options, start, force bool) (*InstallResult, error) {
+	content, err := Render(o)
+	if err != nil {
+		return nil, err
+	}
+	path, err := o.Path()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); err == nil && !force {
+		return nil, fmt.Errorf("%s already exists (use --force to replace it)", path)
+	}
+
+	res := &InstallResult{Path: path, EnvFile: o.EnvFile, LogFile: o.LogFile}
+	if err := os.MkdirAll(filepath.Dir(o.LogFile), 0755); err != nil {
+		return nil, fmt.Errorf("create log directory: %w", err)
+	}
+	if _, err := os.Stat(o.EnvFile); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(o.EnvFile), 0755); err != nil {
+			return nil, fmt.Errorf("create env file directory: %w", err)
+		}
+		if err := os.WriteFile(o.EnvFile, []byte(EnvFileTemplate), 0600); err != nil {
+			return nil, fmt.Errorf("write env file: %w", err)
+		}
+		res.EnvCreated = true
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("create unit directory: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return nil, fmt.Errorf("write unit: %w", err)
+	}
+
+	for _, args := range installCommands(o.Unit, path, start) {
+		res.Commands = append(res.Commands, args)
+		if out, err := run(args); err != nil {
+			return res, fmt.Errorf("%s: %w: %s", strings.Join(args, " "), err, out)
+		}
+	}
+	return res, nil
+}
+
+// Uninstall stops the service, unregisters it and removes the unit file.
+// The env and log files are kept.
+func Uninstall(u Unit) (string, error) {
+	path, err := u.Path()
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return path, ErrNotInstalled
+	}
+	// Stopping a unit that isn't running fails; that's fine
+	for _, args := range stopCommands(u) {
+		_, _ = run(args)
+	}
+	if err := os.Remove(path); err != nil {
+		return path, fmt.Errorf("remove unit: %w", err)
+	}
+	if u.Manager == Systemd {
+		_, _ = run(systemctl(u, "daemon-reload"))
+	}
+	return path, nil
+}
+
+func installCommands(u Unit, path string, start bool) [][]string {
+	if u.Manager == Launchd {
+		if !start {
+			return nil
+		}
+		// bootstrap fails for an already-loaded job, so unload it first
+		return [][]string{
+			{"launchctl", "bootout", launchdTarget(u)},
+			{"launchctl", "bootstrap", launchdDomain(), path},
+		}
+	}
+	enable := systemctl(u, "enable", u.ServiceName())
+	if start {
+		enable = systemctl(u, "enable", "--now", u.ServiceName())
+	}
+	cmds := [][]string{systemctl(u, "daemon-reload"), enable}
+	if start {
+		// Pick up a changed unit when reinstalling over a running service
+		cmds = append(cmds, systemctl(u, "restart", u.ServiceName()))
+	}
+	return cmds
+}
+
+func stopCommands(u Unit) [][]string {
+	if u.Manager == Launchd {
+		return [][]string{{"launchctl", "bootout", launchdTarget(u)}}
+	}
+	return [][]string{systemctl(u, "disable", "--now", u.ServiceName())}
+}
+
+// run executes args, except that a launchd bootout of a job that isn't
+// loaded is not an error.
+func run(args []string) (string, error) {
+	out, err := execCommand(args[0], args[1:]...).CombinedOutput()
+	if err != nil && len(args) > 1 && args[0] == "launchctl" && args[1] == "bootout" {
+		err = nil
+	}
+	return strings.TrimSpace(string(out)), err
+}
+
+func systemctl(u Unit, args ...string) []string {
+	cmd := []string{"systemctl"}
+	if !u.System {
+		cmd = append(cmd, "--user")
+	}
+	return append(cmd, args...)
+}
+
+func launchdDomain() string { return "gui/" + strconv.Itoa(os.Getuid()) }
+
+func launchdTarget(u Unit) string { return launchdDomain() + "/" + u.Label() }
+
+// Status is the state of an installed service.
+type Status struct {
+	Manager   Manager `json:"manager"`
+	Name      string  `json:"name"`
+	Path      string  `json:"path"`
+	Installed bool    `json:"installed"`
+	// Loaded is whether the service manager knows the unit.
+	Loaded bool `json:"loaded"`
+	// State is the manager's own wording: active/inactive/failed for
+	// systemd, running/not running for launchd.
+	State    string `json:"state,omitempty"`
+	SubState string `json:"sub_state,omitempty"`
+	PID      int    `json:"pid,omitempty"`
+	Since    string `json:"since,omitempty"`
+	Restarts int    `json:"restarts,omitempty"`
+	// LastExit is launchd's last exit code.
+	LastExit string `json:"last_exit,omitempty"`
+	// Port is parsed from the unit's command line.
+	Port int `json:"port,omitempty"`
+}
+
+// Running reports whether the server process is up.
+func (s *Status) Running() bool {
+	return s.State == "active" || s.State == "running"
+}
+
+// GetStatus inspects the unit file and asks the service manager about it.
+func GetStatus(u Unit) (*Status, error) {
+	path, err := u.Path()
+	if err != nil {
+		return nil, err
+	}
+	st := &Status{Manager: u.Manager, Name: u.name(), Path: path}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	st.Installed = true
+	st.Port = PortFromUnit(content)
+
+	if u.Manager == Launchd {
+		out, err := execCommand("launchctl", "print", launchdTarget(u)).Output()
+		if err != nil {
+			// launchctl print fails for jobs that aren't loaded
+			st.State = "not loaded"
+			return st, nil
+		}
+		parseLaunchctlPrint(st, out)
+		return st, nil
+	}
+
+	args := systemctl(u, "show", u.ServiceName(),
+		"--property=LoadState,ActiveState,SubState,MainPID,NRestarts,ActiveEnterTimestamp")
+	out, err := execCommand(args[0], args[1:]...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", strings.Join(args, " "), err)
+	}
+	parseSystemctlShow(st, out)
+	return st, nil
+}
+
+func parseSystemctlShow(st *Status, out []byte) {
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		key, value, ok := strings.Cut(sc.Text(), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "LoadState":
+			st.Loaded = value == "loaded"
+		case "ActiveState":
+			st.State = value
+		case "SubState":
+			st.SubState = value
+		case "MainPID":
+			st.PID, _ = strconv.Atoi(value)
+		case "NRestarts":
+			st.Restarts, _ = strconv.Atoi(value)
+		case "ActiveEnterTimestamp":
+			st.Since = value
+		}
+	}
+}
+
+func parseLaunchctlPrint(st *Status, out []byte) {
+	st.Loaded = true
+	st.State = "not running"
+	// Nested blocks (endpoints, sockets) reuse keys like "state"; only the
+	// job's own, first, values count.
+	seen := map[string]bool{}
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(sc.Text()), " = ")
+		if !ok || seen[key] {
+			continue
+		}
+		seen[key] = true
+		switch key {
+		case "state":
+			st.State = value
+		case "pid":
+			st.PID, _ = strconv.Atoi(value)
+		case "runs":
+			if runs, err := strconv.Atoi(value); err == nil && runs > 0 {
+				st.Restarts = runs - 1
+			}
+		case "last exit code":
+			st.LastExit = value
+		}
+	}
+}
@@ -0,0 +1,302 @@
+// Package service generates the OS service definition that keeps `orc serve`
+// running — a systemd unit on Linux, a launchd agent on macOS — and installs,
+// inspects and removes it with systemctl or launchctl.
+package service
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Manager is the service manager a unit is written for.
+type Manager string
+
+const (
+	// Systemd writes a .service unit managed with systemctl.
+	Systemd Manager = "systemd"
+	// Launchd writes a LaunchAgent plist managed with launchctl.
+	Launchd Manager = "launchd"
+)
+
+// DefaultName is the unit name used when none is given.
+const DefaultName = "orc"
+
+// labelPrefix namespaces launchd labels.
+const labelPrefix = "dev.orc."
+
+// RestartDelaySeconds is how long the manager waits before restarting a
+// crashed server.
+const RestartDelaySeconds = 5
+
+// DefaultManager returns the service manager for this OS.
+func DefaultManager() (Manager, error) {
+	switch runtime.GOOS {
+	case "linux":
+		return Systemd, nil
+	case "darwin":
+		return Launchd, nil
+	default:
+		return "", fmt.Errorf("orc service supports systemd (Linux) and launchd (macOS), not %s", runtime.GOOS)
+	}
+}
+
+// Unit identifies an installed (or to-be-installed) service.
+type Unit struct {
+	Manager Manager
+	Name    string
+	// System installs a system-wide systemd unit (/etc/systemd/system)
+	// instead of a user unit. Launchd agents are always per-user.
+	System bool
+}
+
+// ServiceName is the name systemctl knows the unit by.
+func (u Unit) ServiceName() string { return u.name() + ".service" }
+
+// Label is the launchd job label.
+func (u Unit) Label() string { return labelPrefix + u.name() }
+
+// Path returns where the unit file lives.
+func (u Unit) Path() (string, error) {
+	if u.Manager == Systemd && u.System {
+		return filepath.Join("/etc/systemd/system", u.ServiceName()), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	if u.Manager == Launchd {
+		return filepath.Join(home, "Library", "LaunchAgents", u.Label()+".plist"), nil
+	}
+	return filepath.Join(home, ".config", "systemd", "user", u.ServiceName()), nil
+}
+
+func (u Unit) name() string {
+	if u.Name == "" {
+		return DefaultName
+	}
+	return u.Name
+}
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// Options describes the server a unit runs.
+type Options struct {
+	Unit
+	// Binary is the absolute path of the orc executable.
+	Binary string
+	// WorkDir is the project directory `orc serve` runs in.
+	WorkDir string
+	Port    int
+	// ReadOnly adds --read-only.
+	ReadOnly bool
+	// EnvFile holds KEY=value lines (tokens, database settings) loaded
+	// before the server starts. It may be missing.
+	EnvFile string
+	// LogFile receives the server's stdout and stderr.
+	LogFile string
+	// RunAs is the account a system unit runs as.
+	RunAs string
+	// SearchPath is the PATH the server runs with. Service managers start
+	// jobs with a minimal PATH, which would hide the claude and git CLIs.
+	SearchPath string
+}
+
+// DefaultEnvFile returns ~/.orc/service/<name>.env.
+func DefaultEnvFile(name string) (string, error) {
+	return serviceFile(name, ".env")
+}
+
+// DefaultLogFile returns ~/.orc/service/<name>.log.
+func DefaultLogFile(name string) (string, error) {
+	return serviceFile(name, ".log")
+}
+
+func serviceFile(name, ext string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		name = DefaultName
+	}
+	return filepath.Join(home, ".orc", "service", name+ext), nil
+}
+
+// Validate checks the options before a unit is rendered.
+func (o Options) Validate() error {
+	if !validName.MatchString(o.name()) {
+		return fmt.Errorf("invalid service name %q: use letters, digits, '.', '_' or '-'", o.name())
+	}
+	if o.Manager != Systemd && o.Manager != Launchd {
+		return fmt.Errorf("unknown service manager %q", o.Manager)
+	}
+	if o.System && o.Manager != Systemd {
+		return fmt.Errorf("--system needs systemd; launchd agents are per-user")
+	}
+	for field, path := range map[string]string{"binary": o.Binary, "working directory": o.WorkDir, "env file": o.EnvFile, "log file": o.LogFile} {
+		if !filepath.IsAbs(path) {
+			return fmt.Errorf("%s must be an absolute path, got %q", field, path)
+		}
+	}
+	if o.Port <= 0 || o.Port > 65535 {
+		return fmt.Errorf("invalid port %d", o.Port)
+	}
+	return nil
+}
+
+// serveArgs is the command line the unit runs. A service listens on exactly
+// the configured port so probes and proxies can find it.
+func (o Options) serveArgs() []string {
+	args := []string{o.Binary, "serve", "--port", strconv.Itoa(o.Port), "--max-port-attempts", "1"}
+	if o.ReadOnly {
+		args = append(args, "--read-only")
+	}
+	return args
+}
+
+// Render returns the unit file content for o.
+func Render(o Options) ([]byte, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	if o.Manager == Launchd {
+		return renderLaunchd(o), nil
+	}
+	return renderSystemd(o), nil
+}
+
+func renderSystemd(o Options) []byte {
+	var b bytes.Buffer
+	b.WriteString("# Generated by `orc service install`. Rerun it with --force to change\n")
+	b.WriteString("# options; put tokens and database settings in the EnvironmentFile.\n")
+	b.WriteString("[Unit]\n")
+	fmt.Fprintf(&b, "Description=orc API server (%s)\n", o.WorkDir)
+	b.WriteString("Documentation=https://github.com/randalmurphal/orc\n")
+	b.WriteString("After=network-online.target\n")
+	b.WriteString("Wants=network-online.target\n")
+	// Give up after 5 crashes in 5 minutes instead of looping forever
+	b.WriteString("StartLimitIntervalSec=300\n")
+	b.WriteString("StartLimitBurst=5\n\n")
+
+	b.WriteString("[Service]\n")
+	b.WriteString("Type=simple\n")
+	if o.System && o.RunAs != "" {
+		fmt.Fprintf(&b, "User=%s\n", o.RunAs)
+	}
+	fmt.Fprintf(&b, "WorkingDirectory=%s\n", o.WorkDir)
+	if o.SearchPath != "" {
+		fmt.Fprintf(&b, "Environment=%s\n", systemdQuote("PATH="+o.SearchPath))
+	}
+	// The leading '-' tolerates a missing file
+	fmt.Fprintf(&b, "EnvironmentFile=-%s\n", o.EnvFile)
+	quoted := make([]string, 0, len(o.serveArgs()))
+	for _, arg := range o.serveArgs() {
+		quoted = append(quoted, systemdQuote(arg))
+	}
+	fmt.Fprintf(&b, "ExecStart=%s\n", strings.Join(quoted, " "))
+	b.WriteString("Restart=on-failure\n")
+	fmt.Fprintf(&b, "RestartSec=%d\n", RestartDelaySeconds)
+	// orc serve drains running requests on SIGTERM
+	b.WriteString("KillSignal=SIGTERM\n")
+	b.WriteString("TimeoutStopSec=30\n")
+	fmt.Fprintf(&b, "StandardOutput=append:%s\n", o.LogFile)
+	fmt.Fprintf(&b, "StandardError=append:%s\n\n", o.LogFile)
+
+	b.WriteString("[Install]\n")
+	if o.System {
+		b.WriteString("WantedBy=multi-user.target\n")
+	} else {
+		b.WriteString("WantedBy=default.target\n")
+	}
+	return b.Bytes()
+}
+
+// systemdQuote double-quotes a word for ExecStart/Environment when needed.
+func systemdQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\"'\\$%;") {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `$$`, `%`, `%%`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// launchdLoader sources the env file (passed as $0) and execs the server,
+// since launchd has no EnvironmentFile equivalent.
+const launchdLoader = `set -a; if [ -f "$0" ]; then . "$0"; fi; set +a; exec "$@"`
+
+func renderLaunchd(o Options) []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString("<!-- Generated by orc service install; reinstall to change options. -->\n")
+	b.WriteString(`<plist version="1.0">` + "\n<dict>\n")
+	plistKey(&b, "Label", o.Label())
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, arg := range append([]string{"/bin/sh", "-c", launchdLoader, o.EnvFile}, o.serveArgs()...) {
+		fmt.Fprintf(&b, "\t\t<string>%s</string>\n", xmlEscape(arg))
+	}
+	b.WriteString("\t</array>\n")
+	plistKey(&b, "WorkingDirectory", o.WorkDir)
+	if o.SearchPath != "" {
+		b.WriteString("\t<key>EnvironmentVariables</key>\n\t<dict>\n")
+		fmt.Fprintf(&b, "\t\t<key>PATH</key>\n\t\t<string>%s</string>\n", xmlEscape(o.SearchPath))
+		b.WriteString("\t</dict>\n")
+	}
+	b.WriteString("\t<key>RunAtLoad</key>\n\t<true/>\n")
+	// Restart after crashes, not after a clean exit
+	b.WriteString("\t<key>KeepAlive</key>\n\t<dict>\n\t\t<key>SuccessfulExit</key>\n\t\t<false/>\n\t</dict>\n")
+	fmt.Fprintf(&b, "\t<key>ThrottleInterval</key>\n\t<integer>%d</integer>\n", RestartDelaySeconds)
+	plistKey(&b, "StandardOutPath", o.LogFile)
+	plistKey(&b, "StandardErrorPath", o.LogFile)
+	b.WriteString("</dict>\n</plist>\n")
+	return b.Bytes()
+}
+
+func plistKey(b *bytes.Buffer, key, value string) {
+	fmt.Fprintf(b, "\t<key>%s</key>\n\t<string>%s</string>\n", key, xmlEscape(value))
+}
+
+func xmlEscape(s string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// EnvFileTemplate is written to a new env file. Every line is commented out
+// so an untouched file changes nothing.
+const EnvFileTemplate = `# Environment for the orc service: one KEY=value per line.
+# Created by 'orc service install'; restart the service after editing.
+# Keep this file private (mode 0600): it holds credentials.
+
+# Hosting API token (or the variable named by hosting.token_env_var)
+#ORC_GITHUB_TOKEN=
+#ORC_GITLAB_TOKEN=
+
+# PostgreSQL instead of SQLite
+#ORC_DB_DRIVER=postgres
+#ORC_DB_HOST=localhost
+#ORC_DB_PORT=5432
+#ORC_DB_NAME=orc
+#ORC_DB_USER=orc
+#ORC_DB_PASSWORD=
+`
+
+// portFlag finds the --port argument in a rendered unit.
+var portFlag = regexp.MustCompile(`--port(?:</string>\s*<string>|"?\s+"?)(\d+)`)
+
+// PortFromUnit returns the port an installed unit serves on, or 0.
+func PortFromUnit(content []byte) int {
+	m := portFlag.FindSubmatch(content)
+	if m == nil {
+		return 0
+	}
+	port, _ := strconv.Atoi(string(m[1]))
+	return port
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"encoding/xml"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testOptions(t *testing.T, m Manager) Options {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	return Options{
+		Unit:       Unit{Manager: m},
+		Binary:     "/usr/local/bin/orc",
+		WorkDir:    "/srv/my project",
+		Port:       8080,
+		EnvFile:    filepath.Join(home, ".orc", "service", "orc.env"),
+		LogFile:    filepath.Join(home, ".orc", "service", "orc.log"),
+		SearchPath: "/usr/local/bin:/usr/bin",
+	}
+}
+
+// mockCommands records every command run and answers with output.
+func mockCommands(t *testing.T, output string) *[][]string {
+	t.Helper()
+	var calls [][]string
+	execCommand = func(name string, args ...string) *exec.Cmd {
+		calls = append(calls, append([]string{name}, args...))
+		return exec.Command("printf", "%s", output)
+	}
+	t.Cleanup(func() { execCommand = exec.Command })
+	return &calls
+}
+
+func TestRender_Systemd(t *testing.T) {
+	o := testOptions(t, Systemd)
+	o.ReadOnly = true
+	content, err := Render(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unit := string(content)
+	for _, want := range []string{
+		"WorkingDirectory=/srv/my project\n",
+		"EnvironmentFile=-" + o.EnvFile + "\n",
+		`Environment=PATH=/usr/local/bin:/usr/bin` + "\n",
+		"ExecStart=/usr/local/bin/orc serve --port 8080 --max-port-attempts 1 --read-only\n",
+		"Restart=on-failure\n",
+		"StandardOutput=append:" + o.LogFile + "\n",
+		"WantedBy=default.target\n",
+	} {
+		if !strings.Contains(unit, want) {
+			t.Errorf("unit missing %q:\n%s", want, unit)
+		}
+	}
+	if strings.Contains(unit, "User=") {
+		t.Error("user units must not set User=")
+	}
+	if got := PortFromUnit(content); got != 8080 {
+		t.Errorf("PortFromUnit = %d, want 8080", got)
+	}
+
+	o.System, o.RunAs = true, "orc"
+	content, _ = Render(o)
+	if !strings.Contains(string(content), "User=orc\n") || !strings.Contains(string(content), "WantedBy=multi-user.target\n") {
+		t.Errorf("system unit:\n%s", content)
+	}
+}
+
+func TestRender_Launchd(t *testing.T) {
+	o := testOptions(t, Launchd)
+	o.WorkDir = "/Users/me/a&b"
+	content, err := Render(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Must be well-formed XML
+	dec := xml.NewDecoder(strings.NewReader(string(content)))
+	for {
+		if _, err := dec.Token(); err != nil {
+			if err != io.EOF {
+				t.Fatalf("plist is not valid XML: %v\n%s", err, content)
+			}
+			break
+		}
+	}
+	plist := string(content)
+	for _, want := range []string{
+		"<string>dev.orc.orc</string>",
+		"<string>/Users/me/a&amp;b</string>",
+		"<string>" + o.EnvFile + "</string>",
+		"<key>SuccessfulExit</key>",
+	} {
+		if !strings.Contains(plist, want) {
+			t.Errorf("plist missing %q:\n%s", want, plist)
+		}
+	}
+	if got := PortFromUnit(content); got != 8080 {
+		t.Errorf("PortFromUnit = %d, want 8080", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for name, mutate := range map[string]func(*Options){
+		"bad name":        func(o *Options) { o.Name = "../x" },
+		"relative binary": func(o *Options) { o.Binary = "orc" },
+		"bad port":        func(o *Options) { o.Port = 0 },
+		"system launchd":  func(o *Options) { o.Manager, o.System = Launchd, true },
+	} {
+		o := testOptions(t, Systemd)
+		mutate(&o)
+		if _, err := Render(o); err == nil {
+			t.Errorf("%s: want error", name)
+		}
+	}
+}
+
+func TestSystemdQuote(t *testing.T) {
+	for in, want := range map[string]string{
+		"/usr/bin/orc":    "/usr/bin/orc",
+		"/opt/my orc/orc": `"/opt/my orc/orc"`,
+		`PATH=$HOME/bin`:  `"PATH=$$HOME/bin"`,
+		`say "hi" 100%`:   `"say \"hi\" 100%%"`,
+	} {
+		if got := systemdQuote(in); got != want {
+			t.Errorf("systemdQuote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestInstall_Systemd(t *testing.T) {
+	o := testOptions(t, Systemd)
+	calls := mockCommands(t, "")
+
+	res, err := Install(o, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(res.Path, filepath.Join(".config", "systemd", "user", "orc.service")) {
+		t.Errorf("path = %s", res.Path)
+	}
+	info, err := os.Stat(o.EnvFile)
+	if err != nil || !res.EnvCreated || info.Mode().Perm() != 0600 {
+		t.Errorf("env file: created=%v err=%v", res.EnvCreated, err)
+	}
+	want := []string{"systemctl --user daemon-reload", "systemctl --user enable --now orc.service", "systemctl --user restart orc.service"}
+	if len(*calls) != len(want) {
+		t.Fatalf("commands = %v, want %v", *calls, want)
+	}
+	for i, c := range *calls {
+		if strings.Join(c, " ") != want[i] {
+			t.Errorf("command %d = %v, want %s", i, c, want[i])
+		}
+	}
+
+	// Reinstalling needs --force and keeps the edited env file
+	if err := os.WriteFile(o.EnvFile, []byte("ORC_GITHUB_TOKEN=x\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Install(o, false, false); err == nil {
+		t.Fatal("reinstall without force should fail")
+	}
+	res, err = Install(o, false, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(o.EnvFile); res.EnvCreated || string(data) != "ORC_GITHUB_TOKEN=x\n" {
+		t.Errorf("env file overwritten: %q", data)
+	}
+
+	if _, err := Uninstall(o.Unit); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(res.Path); !os.IsNotExist(err) {
+		t.Error("unit file not removed")
+	}
+	if _, err := Uninstall(o.Unit); err != ErrNotInstalled {
+		t.Errorf("second uninstall: %v, want ErrNotInstalled", err)
+	}
+}
+
+func TestGetStatus_Systemd(t *testing.T) {
+	o := testOptions(t, Systemd)
+	st, err := GetStatus(o.Unit)
+	if err != nil || st.Installed {
+		t.Fatalf("before install: %+v %v", st, err)
+	}
+
+	mockCommands(t, "")
+	if _, err := Install(o, false, false); err != nil {
+		t.Fatal(err)
+	}
+	mockCommands(t, "LoadState=loaded\nActiveState=active\nSubState=running\nMainPID=4242\nNRestarts=2\nActiveEnterTimestamp=Mon 2026-01-05 10:00:00 UTC\n")
+	st, err = GetStatus(o.Unit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !st.Installed || !st.Loaded || !st.Running() || st.PID != 4242 || st.Restarts != 2 || st.Port != 8080 {
+		t.Errorf("status = %+v", st)
+	}
+}
+
+func TestParseLaunchctlPrint(t *testing.T) {
+	out := `gui/501/dev.orc.orc = {
+	active count = 1
+	state = running
+	program = /bin/sh
+	runs = 3
+	pid = 812
+	last exit code = 1
+	endpoints = {
+		"com.example" = {
+			state = active
+		}
+	}
+}`
+	st := &Status{}
+	parseLaunchctlPrint(st, []byte(out))
+	if !st.Running() || st.PID != 812 || st.Restarts != 2 || st.LastExit != "1" {
+		t.Errorf("status = %+v", st)
+	}
+}
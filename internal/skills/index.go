@@ -0,0 +1,288 @@
+// Package skills installs vetted SKILL.md bundles from a central index into
+// a project's .claude/skills directory and tracks where each one came from.
+package skills
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IndexFile is the index filename at the root of a git index repository.
+const IndexFile = "index.yaml"
+
+// SkillFile is the required entry point of every skill bundle.
+const SkillFile = "SKILL.md"
+
+// maxSkillFileSize bounds files downloaded from HTTP indexes.
+const maxSkillFileSize = 1 << 20
+
+// IndexEntry describes one installable skill.
+type IndexEntry struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Version     string `yaml:"version,omitempty" json:"version,omitempty"`
+	// Path is the bundle directory, relative to the index root.
+	Path string `yaml:"path" json:"path"`
+	// Files lists the bundle's files. Required for HTTP indexes, which cannot
+	// be listed; git indexes default to every file in Path.
+	Files []string `yaml:"files,omitempty" json:"files,omitempty"`
+	// SHA256 is the expected hex digest of SKILL.md. When set, installs fail
+	// if the fetched content does not match.
+	SHA256 string `yaml:"sha256,omitempty" json:"sha256,omitempty"`
+}
+
+// Index is a parsed skill index.
+type Index struct {
+	Skills []IndexEntry `yaml:"skills" json:"skills"`
+}
+
+// Find returns the entry for name, or nil.
+func (i *Index) Find(name string) *IndexEntry {
+	for idx := range i.Skills {
+		if i.Skills[idx].Name == name {
+			return &i.Skills[idx]
+		}
+	}
+	return nil
+}
+
+// Source is an opened skill index (git checkout or HTTP base URL).
+type Source struct {
+	// Location is the configured index (repo URL/path or index.yaml URL).
+	Location string
+	// Commit is the resolved commit for git indexes; empty for HTTP.
+	Commit string
+	Index  *Index
+
+	dir     string // git checkout
+	baseURL *url.URL
+	client  *http.Client
+}
+
+// Close removes any temporary checkout.
+func (s *Source) Close() {
+	if s.dir != "" {
+		_ = os.RemoveAll(s.dir)
+	}
+}
+
+// isHTTPIndex reports whether location points at an index.yaml over HTTP(S).
+// Git hosting URLs without a .yaml suffix are treated as git repositories.
+func isHTTPIndex(location string) bool {
+	lower := strings.ToLower(location)
+	return (strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")) &&
+		(strings.HasSuffix(lower, ".yaml") || strings.HasSuffix(lower, ".yml"))
+}
+
+// Open fetches the index at location. Callers must Close the source.
+func Open(location string) (*Source, error) {
+	if location == "" {
+		return nil, fmt.Errorf("no skill index configured (set skills.index or pass --index)")
+	}
+	if isHTTPIndex(location) {
+		return openHTTP(location)
+	}
+	return openGit(location)
+}
+
+func openHTTP(location string) (*Source, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("parse index URL: %w", err)
+	}
+	src := &Source{
+		Location: location,
+		baseURL:  u,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+	data, err := src.fetchHTTP(location)
+	if err != nil {
+		return nil, fmt.Errorf("fetch index: %w", err)
+	}
+	if src.Index, err = parseIndex(data); err != nil {
+		return nil, err
+	}
+	return src, nil
+}
+
+func openGit(location string) (*Source, error) {
+	dir, err := os.MkdirTemp("", "orc-skills-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+	src := &Source{Location: location, dir: dir}
+
+	if out, err := exec.Command("git", "clone", "--quiet", "--depth", "1", location, dir).CombinedOutput(); err != nil {
+		src.Close()
+		return nil, fmt.Errorf("git clone %s: %s: %w", location, strings.TrimSpace(string(out)), err)
+	}
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		src.Close()
+		return nil, fmt.Errorf("resolve index commit: %w", err)
+	}
+	src.Commit = strings.TrimSpace(string(out))
+
+	data, err := os.ReadFile(filepath.Join(dir, IndexFile))
+	if err != nil {
+		src.Close()
+		return nil, fmt.Errorf("read %s: %w", IndexFile, err)
+	}
+	if src.Index, err = parseIndex(data); err != nil {
+		src.Close()
+		return nil, err
+	}
+	return src, nil
+}
+
+func parseIndex(data []byte) (*Index, error) {
+	var idx Index
+	if err := yaml.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("parse skill index: %w", err)
+	}
+	for _, e := range idx.Skills {
+		if err := validateName(e.Name); err != nil {
+			return nil, fmt.Errorf("skill index: %w", err)
+		}
+		if e.Path == "" {
+			return nil, fmt.Errorf("skill index: %s has no path", e.Name)
+		}
+	}
+	return &idx, nil
+}
+
+// validateName rejects names that could escape .claude/skills.
+func validateName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid skill name %q", name)
+	}
+	return nil
+}
+
+// validateRelPath rejects absolute or parent-escaping bundle paths.
+func validateRelPath(p string) error {
+	clean := path.Clean(filepath.ToSlash(p))
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return fmt.Errorf("invalid path %q", p)
+	}
+	return nil
+}
+
+// Fetch returns the bundle for entry as filename -> content, with SKILL.md
+// verified against the index checksum when one is present.
+func (s *Source) Fetch(entry *IndexEntry) (map[string][]byte, error) {
+	if err := validateRelPath(entry.Path); err != nil {
+		return nil, fmt.Errorf("skill %s: %w", entry.Name, err)
+	}
+
+	var files map[string][]byte
+	var err error
+	if s.dir != "" {
+		files, err = s.fetchGitBundle(entry)
+	} else {
+		files, err = s.fetchHTTPBundle(entry)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	skillMD, ok := files[SkillFile]
+	if !ok {
+		return nil, fmt.Errorf("skill %s: bundle has no %s", entry.Name, SkillFile)
+	}
+	if entry.SHA256 != "" && !strings.EqualFold(entry.SHA256, Checksum(skillMD)) {
+		return nil, fmt.Errorf("skill %s: %s checksum mismatch (index %s, fetched %s)", entry.Name, SkillFile, entry.SHA256, Checksum(skillMD))
+	}
+	return files, nil
+}
+
+func (s *Source) fetchGitBundle(entry *IndexEntry) (map[string][]byte, error) {
+	bundleDir := filepath.Join(s.dir, filepath.FromSlash(entry.Path))
+	names := entry.Files
+	if len(names) == 0 {
+		dirEntries, err := os.ReadDir(bundleDir)
+		if err != nil {
+			return nil, fmt.Errorf("skill %s: read bundle: %w", entry.Name, err)
+		}
+		for _, de := range dirEntries {
+			if !de.IsDir() {
+				names = append(names, de.Name())
+			}
+		}
+	}
+
+	files := make(map[string][]byte, len(names))
+	for _, name := range names {
+		if err := validateName(name); err != nil {
+			return nil, fmt.Errorf("skill %s: file: %w", entry.Name, err)
+		}
+		data, err := os.ReadFile(filepath.Join(bundleDir, name))
+		if err != nil {
+			return nil, fmt.Errorf("skill %s: read %s: %w", entry.Name, name, err)
+		}
+		files[name] = data
+	}
+	return files, nil
+}
+
+func (s *Source) fetchHTTPBundle(entry *IndexEntry) (map[string][]byte, error) {
+	names := entry.Files
+	if len(names) == 0 {
+		names = []string{SkillFile}
+	}
+	files := make(map[string][]byte, len(names))
+	for _, name := range names {
+		if err := validateName(name); err != nil {
+			return nil, fmt.Errorf("skill %s: file: %w", entry.Name, err)
+		}
+		ref, err := url.Parse(path.Join(entry.Path, name))
+		if err != nil {
+			return nil, fmt.Errorf("skill %s: %w", entry.Name, err)
+		}
+		data, err := s.fetchHTTP(s.baseURL.ResolveReference(ref).String())
+		if err != nil {
+			return nil, fmt.Errorf("skill %s: fetch %s: %w", entry.Name, name, err)
+		}
+		files[name] = data
+	}
+	return files, nil
+}
+
+func (s *Source) fetchHTTP(target string) ([]byte, error) {
+	resp, err := s.client.Get(target)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", target, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSkillFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", target, err)
+	}
+	if len(data) > maxSkillFileSize {
+		return nil, fmt.Errorf("GET %s: response exceeds %d bytes", target, maxSkillFileSize)
+	}
+	return data, nil
+}
+
+// Checksum returns the hex SHA-256 of data.
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,262 @@
+package skills
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProvenanceFile is written into each installed skill directory. It is not
+// part of the skill itself and is ignored when scanning supporting files.
+const ProvenanceFile = ".orc-provenance.yaml"
+
+// Provenance records where an installed skill came from.
+type Provenance struct {
+	Name        string    `yaml:"name" json:"name"`
+	Version     string    `yaml:"version,omitempty" json:"version,omitempty"`
+	Index       string    `yaml:"index" json:"index"`
+	Path        string    `yaml:"path" json:"path"`
+	Commit      string    `yaml:"commit,omitempty" json:"commit,omitempty"`
+	SHA256      string    `yaml:"sha256" json:"sha256"`
+	InstalledAt time.Time `yaml:"installed_at" json:"installed_at"`
+}
+
+// InstalledSkill is a skill directory under .claude/skills.
+type InstalledSkill struct {
+	Name string `json:"name"`
+	Dir  string `json:"dir"`
+	// Provenance is nil for skills not installed from an index.
+	Provenance *Provenance `json:"provenance,omitempty"`
+}
+
+// Installer manages skills under <root>/.claude/skills.
+type Installer struct {
+	skillsDir string
+}
+
+// NewInstaller returns an installer for the project (or home) directory root.
+func NewInstaller(root string) *Installer {
+	return &Installer{skillsDir: filepath.Join(root, ".claude", "skills")}
+}
+
+// SkillsDir returns the managed .claude/skills directory.
+func (i *Installer) SkillsDir() string {
+	return i.skillsDir
+}
+
+// Install fetches name from src and writes it with provenance metadata.
+// An existing skill without provenance is only replaced when force is set,
+// so hand-written skills are never silently overwritten.
+func (i *Installer) Install(src *Source, name string, force bool) (*Provenance, error) {
+	entry := src.Index.Find(name)
+	if entry == nil {
+		return nil, fmt.Errorf("skill %s not found in index %s", name, src.Location)
+	}
+
+	existing, err := i.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.Provenance == nil && !force {
+		return nil, fmt.Errorf("skill %s already exists and was not installed from an index (use --force to replace)", name)
+	}
+
+	files, err := src.Fetch(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	prov := newProvenance(src, entry, files)
+	if err := i.write(name, files, prov); err != nil {
+		return nil, err
+	}
+	return prov, nil
+}
+
+func newProvenance(src *Source, entry *IndexEntry, files map[string][]byte) *Provenance {
+	return &Provenance{
+		Name:        entry.Name,
+		Version:     entry.Version,
+		Index:       src.Location,
+		Path:        entry.Path,
+		Commit:      src.Commit,
+		SHA256:      Checksum(files[SkillFile]),
+		InstalledAt: time.Now().UTC(),
+	}
+}
+
+// write replaces the skill directory atomically: files go to a staging dir
+// which is then renamed over the old one.
+func (i *Installer) write(name string, files map[string][]byte, prov *Provenance) error {
+	if err := os.MkdirAll(i.skillsDir, 0755); err != nil {
+		return fmt.Errorf("create skills directory: %w", err)
+	}
+	staging, err := os.MkdirTemp(i.skillsDir, "."+name+"-*")
+	if err != nil {
+		return fmt.Errorf("create staging directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(staging) }()
+
+	for filename, content := range files {
+		if err := os.WriteFile(filepath.Join(staging, filename), content, 0644); err != nil {
+			return fmt.Errorf("write %s: %w", filename, err)
+		}
+	}
+	provData, err := yaml.Marshal(prov)
+	if err != nil {
+		return fmt.Errorf("marshal provenance: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(staging, ProvenanceFile), provData, 0644); err != nil {
+		return fmt.Errorf("write provenance: %w", err)
+	}
+	if err := os.Chmod(staging, 0755); err != nil {
+		return fmt.Errorf("chmod skill directory: %w", err)
+	}
+
+	target := filepath.Join(i.skillsDir, name)
+	if err := os.RemoveAll(target); err != nil {
+		return fmt.Errorf("remove previous skill %s: %w", name, err)
+	}
+	if err := os.Rename(staging, target); err != nil {
+		return fmt.Errorf("install skill %s: %w", name, err)
+	}
+	return nil
+}
+
+// UpdateResult describes the outcome of updating one skill.
+type UpdateResult struct {
+	Name    string `json:"name"`
+	From    string `json:"from,omitempty"`
+	To      string `json:"to,omitempty"`
+	Updated bool   `json:"updated"`
+}
+
+// Update reinstalls index-installed skills whose upstream content changed.
+// An empty names slice updates every skill with provenance from src.
+func (i *Installer) Update(src *Source, names []string) ([]UpdateResult, error) {
+	if len(names) == 0 {
+		installed, err := i.List()
+		if err != nil {
+			return nil, err
+		}
+		for _, sk := range installed {
+			if sk.Provenance != nil && sk.Provenance.Index == src.Location {
+				names = append(names, sk.Name)
+			}
+		}
+	}
+
+	results := make([]UpdateResult, 0, len(names))
+	for _, name := range names {
+		sk, err := i.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		if sk == nil || sk.Provenance == nil {
+			return nil, fmt.Errorf("skill %s was not installed from an index", name)
+		}
+		entry := src.Index.Find(name)
+		if entry == nil {
+			return nil, fmt.Errorf("skill %s is no longer in index %s", name, src.Location)
+		}
+
+		files, err := src.Fetch(entry)
+		if err != nil {
+			return nil, err
+		}
+		result := UpdateResult{Name: name, From: sk.Provenance.Version, To: entry.Version}
+		if Checksum(files[SkillFile]) != sk.Provenance.SHA256 || entry.Version != sk.Provenance.Version {
+			if err := i.write(name, files, newProvenance(src, entry, files)); err != nil {
+				return nil, err
+			}
+			result.Updated = true
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// Remove deletes an installed skill. Skills without provenance require force.
+func (i *Installer) Remove(name string, force bool) error {
+	sk, err := i.Get(name)
+	if err != nil {
+		return err
+	}
+	if sk == nil {
+		return fmt.Errorf("skill %s is not installed", name)
+	}
+	if sk.Provenance == nil && !force {
+		return fmt.Errorf("skill %s was not installed from an index (use --force to remove)", name)
+	}
+	if err := os.RemoveAll(sk.Dir); err != nil {
+		return fmt.Errorf("remove skill %s: %w", name, err)
+	}
+	return nil
+}
+
+// Get returns the installed skill name, or nil if it does not exist.
+func (i *Installer) Get(name string) (*InstalledSkill, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(i.skillsDir, name)
+	if _, err := os.Stat(filepath.Join(dir, SkillFile)); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("stat skill %s: %w", name, err)
+	}
+	prov, err := ReadProvenance(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &InstalledSkill{Name: name, Dir: dir, Provenance: prov}, nil
+}
+
+// List returns every skill directory containing SKILL.md, sorted by name.
+func (i *Installer) List() ([]InstalledSkill, error) {
+	entries, err := os.ReadDir(i.skillsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read skills directory: %w", err)
+	}
+
+	var result []InstalledSkill
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name()[0] == '.' {
+			continue
+		}
+		sk, err := i.Get(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if sk != nil {
+			result = append(result, *sk)
+		}
+	}
+	sort.Slice(result, func(a, b int) bool { return result[a].Name < result[b].Name })
+	return result, nil
+}
+
+// ReadProvenance reads the provenance file in skillDir. Returns nil, nil
+// when the skill was not installed from an index.
+func ReadProvenance(skillDir string) (*Provenance, error) {
+	data, err := os.ReadFile(filepath.Join(skillDir, ProvenanceFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read skill provenance: %w", err)
+	}
+	var prov Provenance
+	if err := yaml.Unmarshal(data, &prov); err != nil {
+		return nil, fmt.Errorf("parse skill provenance %s: %w", skillDir, err)
+	}
+	return &prov, nil
+}
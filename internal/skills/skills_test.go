@@ -0,0 +1,199 @@
+package skills
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func gitCommitAll(t *testing.T, dir, msg string) {
+	t.Helper()
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", msg},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s: %v", args, out, err)
+		}
+	}
+}
+
+// newGitIndex creates a git skill index with one skill at the given version.
+func newGitIndex(t *testing.T, version, body string) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, IndexFile), "skills:\n  - name: go-testing\n    version: "+version+"\n    path: skills/go-testing\n")
+	writeTestFile(t, filepath.Join(dir, "skills", "go-testing", SkillFile), body)
+	writeTestFile(t, filepath.Join(dir, "skills", "go-testing", "checklist.md"), "- table tests")
+	gitCommitAll(t, dir, "index "+version)
+	return dir
+}
+
+func TestInstall_FromGitIndex(t *testing.T) {
+	t.Parallel()
+	indexDir := newGitIndex(t, "1.0.0", "# Go testing")
+	root := t.TempDir()
+
+	src, err := Open(indexDir)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer src.Close()
+
+	inst := NewInstaller(root)
+	prov, err := inst.Install(src, "go-testing", false)
+	if err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	if prov.Commit == "" || prov.Version != "1.0.0" || prov.Index != indexDir {
+		t.Errorf("provenance = %+v", prov)
+	}
+
+	skillDir := filepath.Join(root, ".claude", "skills", "go-testing")
+	for _, f := range []string{SkillFile, "checklist.md", ProvenanceFile} {
+		if _, err := os.Stat(filepath.Join(skillDir, f)); err != nil {
+			t.Errorf("missing %s: %v", f, err)
+		}
+	}
+
+	list, err := inst.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(list) != 1 || list[0].Provenance == nil || list[0].Provenance.SHA256 != Checksum([]byte("# Go testing")) {
+		t.Errorf("List = %+v", list)
+	}
+}
+
+func TestInstall_RefusesLocalSkillWithoutForce(t *testing.T) {
+	t.Parallel()
+	indexDir := newGitIndex(t, "1.0.0", "# Go testing")
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, ".claude", "skills", "go-testing", SkillFile), "hand written")
+
+	src, err := Open(indexDir)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer src.Close()
+
+	inst := NewInstaller(root)
+	if _, err := inst.Install(src, "go-testing", false); err == nil {
+		t.Fatal("Install should refuse to overwrite a local skill")
+	}
+	if err := inst.Remove("go-testing", false); err == nil {
+		t.Fatal("Remove should refuse a local skill without force")
+	}
+	if _, err := inst.Install(src, "go-testing", true); err != nil {
+		t.Fatalf("Install --force: %v", err)
+	}
+}
+
+func TestUpdate_ReinstallsChangedSkill(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	inst := NewInstaller(root)
+
+	v1 := newGitIndex(t, "1.0.0", "# v1")
+	src, err := Open(v1)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if _, err := inst.Install(src, "go-testing", false); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	results, err := inst.Update(src, nil)
+	src.Close()
+	if err != nil {
+		t.Fatalf("Update (unchanged): %v", err)
+	}
+	if len(results) != 1 || results[0].Updated {
+		t.Errorf("unchanged update results = %+v", results)
+	}
+
+	// Same index location, new content.
+	writeTestFile(t, filepath.Join(v1, IndexFile), "skills:\n  - name: go-testing\n    version: 2.0.0\n    path: skills/go-testing\n")
+	writeTestFile(t, filepath.Join(v1, "skills", "go-testing", SkillFile), "# v2")
+	gitCommitAll(t, v1, "v2")
+
+	src, err = Open(v1)
+	if err != nil {
+		t.Fatalf("Open v2: %v", err)
+	}
+	defer src.Close()
+	results, err = inst.Update(src, nil)
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if len(results) != 1 || !results[0].Updated || results[0].To != "2.0.0" {
+		t.Errorf("update results = %+v", results)
+	}
+	got, _ := os.ReadFile(filepath.Join(root, ".claude", "skills", "go-testing", SkillFile))
+	if string(got) != "# v2" {
+		t.Errorf("SKILL.md = %q, want # v2", got)
+	}
+}
+
+func TestInstall_FromHTTPIndexVerifiesChecksum(t *testing.T) {
+	t.Parallel()
+	body := "# Review SQL"
+	index := "skills:\n" +
+		"  - name: sql-review\n    path: bundles/sql-review\n    sha256: " + Checksum([]byte(body)) + "\n" +
+		"  - name: tampered\n    path: bundles/tampered\n    sha256: " + strings.Repeat("0", 64) + "\n"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/idx/index.yaml", func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte(index)) })
+	mux.HandleFunc("/idx/bundles/sql-review/SKILL.md", func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte(body)) })
+	mux.HandleFunc("/idx/bundles/tampered/SKILL.md", func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("evil")) })
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	src, err := Open(ts.URL + "/idx/index.yaml")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer src.Close()
+
+	inst := NewInstaller(t.TempDir())
+	if _, err := inst.Install(src, "sql-review", false); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	if _, err := inst.Install(src, "tampered", false); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("tampered install error = %v, want checksum mismatch", err)
+	}
+}
+
+func TestParseIndex_RejectsTraversal(t *testing.T) {
+	t.Parallel()
+	if _, err := parseIndex([]byte("skills:\n  - name: ../evil\n    path: x\n")); err == nil {
+		t.Error("parseIndex should reject path separators in names")
+	}
+
+	src := &Source{Index: &Index{}, dir: t.TempDir()}
+	if _, err := src.Fetch(&IndexEntry{Name: "x", Path: "../../etc"}); err == nil {
+		t.Error("Fetch should reject parent-escaping paths")
+	}
+}
+
+func TestOpen_RequiresLocation(t *testing.T) {
+	t.Parallel()
+	if _, err := Open(""); err == nil {
+		t.Error("Open(\"\") should fail")
+	}
+}
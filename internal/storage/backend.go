@@ -223,12 +223,18 @@ type PhaseOutputInfo struct {
 type Backend interface {
 	// Task operations (using orcv1.Task - the ONLY task type)
 	SaveTask(t *orcv1.Task) error
+	// SaveTasks saves all tasks in one transaction (all or none).
+	SaveTasks(tasks []*orcv1.Task) error
 	LoadTask(id string) (*orcv1.Task, error)
 	LoadAllTasks() ([]*orcv1.Task, error)
 	DeleteTask(id string) error
 	TaskExists(id string) (bool, error)
 	GetNextTaskID() (string, error)
 
+	// Plan operations (one execution plan per task)
+	SavePlan(p *db.Plan) error
+	LoadPlan(taskID string) (*db.Plan, error) // nil if the task has no plan
+
 	// Task heartbeat (for orphan detection during long-running phases)
 	UpdateTaskHeartbeat(taskID string) error
 
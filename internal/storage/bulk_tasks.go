@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/git"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// ErrInvalidBulkTasks wraps problems with the task definitions passed to
+// CreateBulkTasks, as opposed to storage failures.
+var ErrInvalidBulkTasks = errors.New("invalid task definitions")
+
+// CreateBulkTasks validates specs against the backend's tasks and
+// initiatives, allocates IDs, resolves refs between specs, and saves all
+// tasks in one transaction. Nothing is saved when any spec is invalid or
+// the blocked_by graph has a cycle. Tasks are returned in spec order.
+func CreateBulkTasks(backend Backend, specs []task.BulkTaskSpec) ([]*orcv1.Task, error) {
+	existing, err := backend.LoadAllTasks()
+	if err != nil {
+		return nil, fmt.Errorf("load tasks: %w", err)
+	}
+	ids := make(map[string]bool, len(existing))
+	for _, t := range existing {
+		ids[t.Id] = true
+	}
+	if err := task.ValidateBulkTasks(specs, func(id string) bool { return ids[id] }); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBulkTasks, err)
+	}
+
+	initiatives := make(map[string]bool)
+	for i, spec := range specs {
+		if spec.TargetBranch != "" {
+			if err := git.ValidateBranchName(spec.TargetBranch); err != nil {
+				return nil, fmt.Errorf("%w: task %d: invalid target_branch: %w", ErrInvalidBulkTasks, i+1, err)
+			}
+		}
+		if spec.InitiativeID == "" || initiatives[spec.InitiativeID] {
+			continue
+		}
+		ok, err := backend.InitiativeExists(spec.InitiativeID)
+		if err != nil {
+			return nil, fmt.Errorf("check initiative: %w", err)
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: task %d: initiative %s not found", ErrInvalidBulkTasks, i+1, spec.InitiativeID)
+		}
+		initiatives[spec.InitiativeID] = true
+	}
+
+	tasks, err := task.BuildBulkTasks(specs, backend.GetNextTaskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := backend.SaveTasks(tasks); err != nil {
+		return nil, fmt.Errorf("save tasks: %w", err)
+	}
+	return tasks, nil
+}
@@ -21,6 +21,25 @@ func (d *DatabaseBackend) DeleteTask(id string) error {
 	return nil
 }
 
+// SavePlan creates or replaces the execution plan of a task.
+func (d *DatabaseBackend) SavePlan(p *db.Plan) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := d.db.SavePlan(p); err != nil {
+		return fmt.Errorf("save plan: %w", err)
+	}
+	return nil
+}
+
+// LoadPlan returns the execution plan of a task, or nil if it has none.
+func (d *DatabaseBackend) LoadPlan(taskID string) (*db.Plan, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.db.GetPlan(taskID)
+}
+
 // TaskExists checks if a task exists in the database.
 func (d *DatabaseBackend) TaskExists(id string) (bool, error) {
 	d.mu.RLock()
@@ -262,44 +281,82 @@ func (d *DatabaseBackend) SaveTaskCtx(ctx context.Context, t *orcv1.Task) error
 		if err := db.SaveTaskTx(tx, dbTask); err != nil {
 			return fmt.Errorf("save task: %w", err)
 		}
+		return saveTaskRelationsTx(tx, t)
+	})
+}
+
+// SaveTasks saves new or existing tasks in a single transaction: either
+// all of them are saved or none is. Task rows are written before any
+// dependency, so tasks may be blocked by others in the same batch.
+func (d *DatabaseBackend) SaveTasks(tasks []*orcv1.Task) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 
-		// Save dependencies
-		if err := db.ClearTaskDependenciesTx(tx, t.Id); err != nil {
-			return fmt.Errorf("clear task dependencies: %w", err)
+	dbTasks := make([]*db.Task, len(tasks))
+	for i, t := range tasks {
+		dbTasks[i] = protoTaskToDBTask(t)
+		if existingTask, err := d.db.GetTask(t.Id); err == nil && existingTask != nil {
+			dbTasks[i].ExecutorPID = existingTask.ExecutorPID
+			dbTasks[i].ExecutorHostname = existingTask.ExecutorHostname
+			dbTasks[i].ExecutorStartedAt = existingTask.ExecutorStartedAt
+			dbTasks[i].LastHeartbeat = existingTask.LastHeartbeat
 		}
-		for _, depID := range t.BlockedBy {
-			if err := db.AddTaskDependencyTx(tx, t.Id, depID); err != nil {
-				return fmt.Errorf("add task dependency %s: %w", depID, err)
+	}
+
+	return d.db.RunInTx(context.Background(), func(tx *db.TxOps) error {
+		for _, dbTask := range dbTasks {
+			if err := db.SaveTaskTx(tx, dbTask); err != nil {
+				return fmt.Errorf("save task %s: %w", dbTask.ID, err)
 			}
 		}
-
-		// Save execution state: phases
-		if err := db.ClearPhasesTx(tx, t.Id); err != nil {
-			return fmt.Errorf("clear phases: %w", err)
+		for _, t := range tasks {
+			if err := saveTaskRelationsTx(tx, t); err != nil {
+				return fmt.Errorf("task %s: %w", t.Id, err)
+			}
 		}
-		// Clear gate decisions before re-adding (prevents duplicate accumulation)
-		if err := db.ClearGateDecisionsTx(tx, t.Id); err != nil {
-			return fmt.Errorf("clear gate decisions: %w", err)
+		return nil
+	})
+}
+
+// saveTaskRelationsTx replaces the dependencies, phases and gate decisions
+// of a task whose row is already saved in tx.
+func saveTaskRelationsTx(tx *db.TxOps, t *orcv1.Task) error {
+	// Save dependencies
+	if err := db.ClearTaskDependenciesTx(tx, t.Id); err != nil {
+		return fmt.Errorf("clear task dependencies: %w", err)
+	}
+	for _, depID := range t.BlockedBy {
+		if err := db.AddTaskDependencyTx(tx, t.Id, depID); err != nil {
+			return fmt.Errorf("add task dependency %s: %w", depID, err)
 		}
-		if t.Execution != nil {
-			for phaseID, ps := range t.Execution.Phases {
-				dbPhase := protoPhaseToDBPhase(t.Id, phaseID, ps)
-				if err := db.SavePhaseTx(tx, dbPhase); err != nil {
-					return fmt.Errorf("save phase %s: %w", phaseID, err)
-				}
+	}
+
+	// Save execution state: phases
+	if err := db.ClearPhasesTx(tx, t.Id); err != nil {
+		return fmt.Errorf("clear phases: %w", err)
+	}
+	// Clear gate decisions before re-adding (prevents duplicate accumulation)
+	if err := db.ClearGateDecisionsTx(tx, t.Id); err != nil {
+		return fmt.Errorf("clear gate decisions: %w", err)
+	}
+	if t.Execution != nil {
+		for phaseID, ps := range t.Execution.Phases {
+			dbPhase := protoPhaseToDBPhase(t.Id, phaseID, ps)
+			if err := db.SavePhaseTx(tx, dbPhase); err != nil {
+				return fmt.Errorf("save phase %s: %w", phaseID, err)
 			}
+		}
 
-			// Save execution state: gate decisions
-			for _, gate := range t.Execution.Gates {
-				dbGate := protoGateToDBGate(t.Id, gate)
-				if err := db.AddGateDecisionTx(tx, dbGate); err != nil {
-					return fmt.Errorf("save gate decision: %w", err)
-				}
+		// Save execution state: gate decisions
+		for _, gate := range t.Execution.Gates {
+			dbGate := protoGateToDBGate(t.Id, gate)
+			if err := db.AddGateDecisionTx(tx, dbGate); err != nil {
+				return fmt.Errorf("save gate decision: %w", err)
 			}
 		}
+	}
 
-		return nil
-	})
+	return nil
 }
 
 // LoadTask loads a task and its execution state from the database.
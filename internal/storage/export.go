@@ -17,18 +17,26 @@ import (
 
 // ExportService handles exporting task artifacts to branches.
 type ExportService struct {
-	backend Backend
-	cfg     *config.StorageConfig
+	backend    Backend
+	projectDir string
+	cfg        *config.StorageConfig
 }
 
-// NewExportService creates a new export service.
-func NewExportService(backend Backend, cfg *config.StorageConfig) *ExportService {
+// NewExportService creates a new export service. Artifacts are written to
+// .orc/exports/ under projectDir, and git runs in projectDir.
+func NewExportService(backend Backend, projectDir string, cfg *config.StorageConfig) *ExportService {
 	return &ExportService{
-		backend: backend,
-		cfg:     cfg,
+		backend:    backend,
+		projectDir: projectDir,
+		cfg:        cfg,
 	}
 }
 
+// exportDir returns the directory a task's artifacts are exported to.
+func (e *ExportService) exportDir(taskID string) string {
+	return filepath.Join(e.projectDir, task.OrcDir, task.ExportsDir, taskID)
+}
+
 // Export exports task artifacts based on the provided options.
 // If opts is nil, uses the configuration defaults.
 func (e *ExportService) Export(taskID string, opts *ExportOptions) error {
@@ -44,7 +52,7 @@ func (e *ExportService) Export(taskID string, opts *ExportOptions) error {
 	}
 
 	// Create export directory
-	exportDir := filepath.Join(task.OrcDir, "exports", taskID)
+	exportDir := e.exportDir(taskID)
 	if err := os.MkdirAll(exportDir, 0755); err != nil {
 		return fmt.Errorf("create export directory: %w", err)
 	}
@@ -87,10 +95,10 @@ func (e *ExportService) ExportToBranch(taskID, branch string, opts *ExportOption
 		return err
 	}
 
-	exportDir := filepath.Join(task.OrcDir, "exports", taskID)
+	exportDir := e.exportDir(taskID)
 
 	// Check if we're on the correct branch
-	currentBranch, err := getCurrentBranch()
+	currentBranch, err := getCurrentBranch(e.projectDir)
 	if err != nil {
 		return fmt.Errorf("get current branch: %w", err)
 	}
@@ -101,12 +109,14 @@ func (e *ExportService) ExportToBranch(taskID, branch string, opts *ExportOption
 
 	// Stage the exported files
 	cmd := exec.Command("git", "add", exportDir)
+	cmd.Dir = e.projectDir
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("stage export files: %w: %s", err, string(output))
 	}
 
 	// Check if there are changes to commit
 	cmd = exec.Command("git", "diff", "--cached", "--quiet", exportDir)
+	cmd.Dir = e.projectDir
 	if err := cmd.Run(); err == nil {
 		// No changes - nothing to commit
 		return nil
@@ -115,6 +125,7 @@ func (e *ExportService) ExportToBranch(taskID, branch string, opts *ExportOption
 	// Commit the changes
 	commitMsg := fmt.Sprintf("[orc] Export artifacts for %s", taskID)
 	cmd = exec.Command("git", "commit", "-m", commitMsg, "--", exportDir)
+	cmd.Dir = e.projectDir
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("commit export files: %w: %s", err, string(output))
 	}
@@ -293,9 +304,11 @@ func generateContextSummary(t *orcv1.Task) (string, error) {
 	return buf.String(), nil
 }
 
-// getCurrentBranch returns the current git branch name.
-func getCurrentBranch() (string, error) {
+// getCurrentBranch returns the current git branch name of the repository
+// at dir.
+func getCurrentBranch(dir string) (string, error) {
 	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+	cmd.Dir = dir
 	out, err := cmd.Output()
 	if err != nil {
 		return "", err
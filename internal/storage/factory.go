@@ -5,8 +5,14 @@ import (
 )
 
 // NewBackend creates a storage backend based on the configuration.
-// All storage modes now use the DatabaseBackend - SQLite is the source of truth.
+// The project database is the source for reads in every mode. The files
+// and hybrid modes also keep task directories under TasksDir (see
+// FileBackend), but only when storage.files.write_through is set; without
+// it they behave like database mode. A nil config selects database mode.
 func NewBackend(projectPath string, cfg *config.StorageConfig) (Backend, error) {
-	// All modes now use database backend - SQLite is the source of truth
+	if cfg != nil && cfg.Files.WriteThrough &&
+		(cfg.Mode == config.StorageModeFiles || cfg.Mode == config.StorageModeHybrid) {
+		return NewFileBackend(projectPath, cfg)
+	}
 	return NewDatabaseBackend(projectPath, cfg)
 }
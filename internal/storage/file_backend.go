@@ -0,0 +1,543 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// FileBackend serves the files and hybrid storage modes. The project
+// database answers every read and query, as in database mode; tasks, plans,
+// specs, transcripts and attachments are also written through to a
+// directory per task under TasksDir (see taskFileStore).
+//
+// In files mode every task keeps its directory. In hybrid mode a task's
+// directory is removed when the task completes, unless
+// storage.files.cleanup_on_complete is off.
+//
+// Directories are only read back by Sync, which the server runs once at
+// start and `orc storage sync` runs on demand.
+type FileBackend struct {
+	*DatabaseBackend
+	files             *taskFileStore
+	mode              config.StorageMode
+	cleanupOnComplete bool
+	mu                sync.Mutex // serializes writes with their write-through
+}
+
+// NewFileBackend creates the backend for the files or hybrid storage mode.
+func NewFileBackend(projectPath string, cfg *config.StorageConfig) (*FileBackend, error) {
+	d, err := NewDatabaseBackend(projectPath, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &FileBackend{
+		DatabaseBackend:   d,
+		files:             newTaskFileStore(projectPath),
+		mode:              cfg.Mode,
+		cleanupOnComplete: cfg.Files.CleanupOnComplete,
+	}, nil
+}
+
+// DatabaseOf returns the DatabaseBackend behind b: b itself, or the
+// database a FileBackend writes through.
+func DatabaseOf(b Backend) (*DatabaseBackend, bool) {
+	switch v := b.(type) {
+	case *DatabaseBackend:
+		return v, true
+	case *FileBackend:
+		return v.DatabaseBackend, true
+	default:
+		return nil, false
+	}
+}
+
+// SyncTaskFiles runs FileBackend.Sync if b writes task directories, and
+// does nothing otherwise.
+func SyncTaskFiles(b Backend) error {
+	if fb, ok := b.(*FileBackend); ok {
+		return fb.Sync()
+	}
+	return nil
+}
+
+// keepsFiles reports whether a task should have a directory.
+func (b *FileBackend) keepsFiles(t *orcv1.Task) bool {
+	if b.mode == config.StorageModeFiles || !b.cleanupOnComplete {
+		return true
+	}
+	return t.Status != orcv1.TaskStatus_TASK_STATUS_COMPLETED
+}
+
+// SaveTask saves a task and writes it to its directory.
+func (b *FileBackend) SaveTask(t *orcv1.Task) error {
+	return b.SaveTaskCtx(context.Background(), t)
+}
+
+// SaveTaskCtx saves a task and writes it to its directory.
+func (b *FileBackend) SaveTaskCtx(ctx context.Context, t *orcv1.Task) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.DatabaseBackend.SaveTaskCtx(ctx, t); err != nil {
+		return err
+	}
+	return b.writeTask(t.Id)
+}
+
+// SaveTasks saves tasks in one transaction and writes them to their
+// directories.
+func (b *FileBackend) SaveTasks(tasks []*orcv1.Task) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.DatabaseBackend.SaveTasks(tasks); err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		if err := b.writeTask(t.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TryClaimTaskExecution claims a task for execution and rewrites
+// task.yaml with the new status.
+func (b *FileBackend) TryClaimTaskExecution(ctx context.Context, taskID string, pid int, hostname string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.DatabaseBackend.TryClaimTaskExecution(ctx, taskID, pid, hostname); err != nil {
+		return err
+	}
+	return b.writeTask(taskID)
+}
+
+// ClaimTaskByUser claims a task for a user and rewrites task.yaml.
+func (b *FileBackend) ClaimTaskByUser(taskID, userID string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	claimed, err := b.DatabaseBackend.ClaimTaskByUser(taskID, userID)
+	if err != nil || !claimed {
+		return claimed, err
+	}
+	return true, b.writeTask(taskID)
+}
+
+// ForceClaimTaskByUser takes over a task's claim and rewrites task.yaml.
+func (b *FileBackend) ForceClaimTaskByUser(taskID, userID string) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	previous, err := b.DatabaseBackend.ForceClaimTaskByUser(taskID, userID)
+	if err != nil {
+		return "", err
+	}
+	return previous, b.writeTask(taskID)
+}
+
+// ReleaseUserClaim releases a user's claim and rewrites task.yaml.
+func (b *FileBackend) ReleaseUserClaim(taskID, userID string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	released, err := b.DatabaseBackend.ReleaseUserClaim(taskID, userID)
+	if err != nil || !released {
+		return released, err
+	}
+	return true, b.writeTask(taskID)
+}
+
+// DeleteTask removes a task and its directory.
+func (b *FileBackend) DeleteTask(id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.DatabaseBackend.DeleteTask(id); err != nil {
+		return err
+	}
+	return b.files.removeTask(id)
+}
+
+// SavePlan saves a plan and writes plan.yaml.
+func (b *FileBackend) SavePlan(p *db.Plan) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.DatabaseBackend.SavePlan(p); err != nil {
+		return err
+	}
+	return b.writePlan(p.TaskID)
+}
+
+// SaveSpecForTask saves a spec and writes spec.md.
+func (b *FileBackend) SaveSpecForTask(taskID, content, source string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.DatabaseBackend.SaveSpecForTask(taskID, content, source); err != nil {
+		return err
+	}
+	return b.writeSpec(taskID)
+}
+
+// SavePhaseOutput saves a phase output, writing spec.md when it is a
+// task's spec.
+func (b *FileBackend) SavePhaseOutput(output *PhaseOutputInfo) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.DatabaseBackend.SavePhaseOutput(output); err != nil {
+		return err
+	}
+	if output.OutputVarName != specOutputVar || output.TaskID == nil {
+		return nil
+	}
+	return b.writeSpec(*output.TaskID)
+}
+
+// DeletePhaseOutput deletes a phase output, updating spec.md when it was a
+// task's spec.
+func (b *FileBackend) DeletePhaseOutput(runID, phaseTemplateID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	output, err := b.DatabaseBackend.GetPhaseOutput(runID, phaseTemplateID)
+	if err != nil {
+		return err
+	}
+	if err := b.DatabaseBackend.DeletePhaseOutput(runID, phaseTemplateID); err != nil {
+		return err
+	}
+	if output == nil || output.OutputVarName != specOutputVar || output.TaskID == nil {
+		return nil
+	}
+	return b.writeSpec(*output.TaskID)
+}
+
+// AddTranscript adds a transcript entry and appends it to the task's
+// transcripts.
+func (b *FileBackend) AddTranscript(t *Transcript) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.DatabaseBackend.AddTranscript(t); err != nil {
+		return err
+	}
+	if !b.files.hasTask(t.TaskID) {
+		return nil
+	}
+	return b.files.appendTranscripts([]Transcript{*t})
+}
+
+// AddTranscriptBatch adds transcript entries and appends them to their
+// tasks' transcripts.
+func (b *FileBackend) AddTranscriptBatch(ctx context.Context, transcripts []Transcript) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.DatabaseBackend.AddTranscriptBatch(ctx, transcripts); err != nil {
+		return err
+	}
+	kept := make([]Transcript, 0, len(transcripts))
+	for _, t := range transcripts {
+		if b.files.hasTask(t.TaskID) {
+			kept = append(kept, t)
+		}
+	}
+	return b.files.appendTranscripts(kept)
+}
+
+// SaveAttachment saves an attachment and writes it to the task's
+// attachments.
+func (b *FileBackend) SaveAttachment(taskID, filename, contentType string, data []byte) (*task.Attachment, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	a, err := b.DatabaseBackend.SaveAttachment(taskID, filename, contentType, data)
+	if err != nil {
+		return nil, err
+	}
+	if b.files.hasTask(taskID) {
+		if err := b.files.writeAttachment(taskID, filename, data); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// DeleteAttachment deletes an attachment and its file.
+func (b *FileBackend) DeleteAttachment(taskID, filename string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.DatabaseBackend.DeleteAttachment(taskID, filename); err != nil {
+		return err
+	}
+	return b.files.deleteAttachment(taskID, filename)
+}
+
+// Sync reconciles the database with the task directories. Directories the
+// database does not know are imported; task.yaml, plan.yaml and spec.md
+// files whose content differs from what orc last wrote are applied as
+// edits. Tasks that should have a directory but have none get one, and
+// directories of tasks that no longer keep one are removed. A directory
+// that cannot be imported is skipped with a warning.
+func (b *FileBackend) Sync() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ids, err := b.files.taskIDs()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := b.importTaskDir(id); err != nil {
+			slog.Warn("task directory not imported", "dir", TasksDir+"/"+id, "error", err)
+		}
+	}
+
+	tasks, err := b.DatabaseBackend.LoadAllTasks()
+	if err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		if !b.keepsFiles(t) {
+			if err := b.files.removeTask(t.Id); err != nil {
+				return err
+			}
+			continue
+		}
+		if b.files.hasTask(t.Id) {
+			continue
+		}
+		if err := b.exportTask(t); err != nil {
+			return fmt.Errorf("write task %s: %w", t.Id, err)
+		}
+	}
+	return nil
+}
+
+// specOutputVar is the phase output variable holding a task's spec.
+const specOutputVar = "SPEC_CONTENT"
+
+// writeTask writes a saved task to its directory, or removes the directory
+// once the task no longer keeps one. A task without a directory gets all
+// of its data written.
+func (b *FileBackend) writeTask(id string) error {
+	t, err := b.DatabaseBackend.LoadTask(id)
+	if err != nil {
+		return err
+	}
+	if !b.keepsFiles(t) {
+		return b.files.removeTask(id)
+	}
+	if !b.files.hasTask(id) {
+		return b.exportTask(t)
+	}
+	return b.writeTaskFile(t)
+}
+
+// writeTaskFile writes task.yaml and records its hash.
+func (b *FileBackend) writeTaskFile(t *orcv1.Task) error {
+	hash, err := b.files.writeTask(t)
+	if err != nil {
+		return err
+	}
+	return b.setFileHash(t.Id, "task.yaml", hash)
+}
+
+// writePlan writes plan.yaml if the task has a directory.
+func (b *FileBackend) writePlan(taskID string) error {
+	if !b.files.hasTask(taskID) {
+		return nil
+	}
+	p, err := b.DatabaseBackend.LoadPlan(taskID)
+	if err != nil || p == nil {
+		return err
+	}
+	hash, err := b.files.writePlan(p)
+	if err != nil {
+		return err
+	}
+	return b.setFileHash(taskID, "plan.yaml", hash)
+}
+
+// writeSpec writes the task's latest spec to spec.md, or removes spec.md
+// when the task has no spec, if the task has a directory.
+func (b *FileBackend) writeSpec(taskID string) error {
+	if !b.files.hasTask(taskID) {
+		return nil
+	}
+	spec, err := b.DatabaseBackend.GetFullSpecForTask(taskID)
+	if err != nil {
+		return err
+	}
+	if spec == nil {
+		if err := b.files.remove(taskID, "spec.md"); err != nil {
+			return err
+		}
+		return b.setFileHash(taskID, "spec.md", "")
+	}
+	hash, err := b.files.writeSpec(taskID, spec.Content)
+	if err != nil {
+		return err
+	}
+	return b.setFileHash(taskID, "spec.md", hash)
+}
+
+// exportTask writes all data of a task to its directory.
+func (b *FileBackend) exportTask(t *orcv1.Task) error {
+	if err := b.writeTaskFile(t); err != nil {
+		return err
+	}
+	if err := b.writePlan(t.Id); err != nil {
+		return err
+	}
+	if err := b.writeSpec(t.Id); err != nil {
+		return err
+	}
+
+	transcripts, err := b.DatabaseBackend.GetTranscripts(t.Id)
+	if err != nil {
+		return err
+	}
+	if err := b.files.writeTranscripts(t.Id, transcripts); err != nil {
+		return err
+	}
+
+	attachments, err := b.DatabaseBackend.ListAttachments(t.Id)
+	if err != nil {
+		return err
+	}
+	for _, a := range attachments {
+		_, data, err := b.DatabaseBackend.GetAttachment(t.Id, a.Filename)
+		if err != nil {
+			return err
+		}
+		if err := b.files.writeAttachment(t.Id, a.Filename, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setFileHash records the hash of a task file orc wrote, or forgets it
+// when hash is empty.
+func (b *FileBackend) setFileHash(taskID, name, hash string) error {
+	d := b.DatabaseBackend
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if hash == "" {
+		return d.db.DeleteTaskFileHash(taskID, name)
+	}
+	return d.db.SetTaskFileHash(taskID, name, hash)
+}
+
+// importTaskDir imports a task directory the database does not know, or
+// the files of a known task that were edited since orc wrote them.
+func (b *FileBackend) importTaskDir(id string) error {
+	dir, err := b.files.taskDir(id)
+	if err != nil {
+		return err
+	}
+	exists, err := b.DatabaseBackend.TaskExists(id)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		files, err := readTaskDir(dir)
+		if err != nil {
+			return err
+		}
+		if files.task.Id != id {
+			return fmt.Errorf("task.yaml is for %s", files.task.Id)
+		}
+		if err := saveTaskDir(b.DatabaseBackend, files); err != nil {
+			return err
+		}
+		t, err := b.DatabaseBackend.LoadTask(id)
+		if err != nil {
+			return err
+		}
+		return b.exportTask(t)
+	}
+
+	b.DatabaseBackend.mu.RLock()
+	recorded, err := b.DatabaseBackend.db.GetTaskFileHashes(id)
+	b.DatabaseBackend.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	taskChanged := b.edited(id, "task.yaml", recorded)
+	planChanged := b.edited(id, "plan.yaml", recorded)
+	specChanged := b.edited(id, "spec.md", recorded)
+	if !taskChanged && !planChanged && !specChanged {
+		return nil
+	}
+
+	existing, err := b.DatabaseBackend.LoadTask(id)
+	if err != nil {
+		return err
+	}
+	if taskChanged && existing.Status == orcv1.TaskStatus_TASK_STATUS_RUNNING {
+		// The executor owns a running task; its next save rewrites task.yaml.
+		slog.Warn("task.yaml edited while the task is running; edit ignored", "task", id)
+		taskChanged = false
+	}
+
+	files, err := readTaskDir(dir)
+	if err != nil {
+		return err
+	}
+	if taskChanged {
+		if err := b.DatabaseBackend.SaveTask(files.task); err != nil {
+			return fmt.Errorf("save task: %w", err)
+		}
+		if err := b.writeTask(id); err != nil {
+			return err
+		}
+	}
+	if planChanged && files.plan != nil {
+		if err := b.DatabaseBackend.SavePlan(files.plan); err != nil {
+			return fmt.Errorf("save plan: %w", err)
+		}
+		if err := b.writePlan(id); err != nil {
+			return err
+		}
+	}
+	if specChanged && files.spec != "" {
+		if err := b.DatabaseBackend.SaveSpecForTask(id, files.spec, "files"); err != nil {
+			return fmt.Errorf("save spec: %w", err)
+		}
+		if err := b.writeSpec(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// edited reports whether a file in a task's directory differs from what orc
+// last wrote. A file orc has no record of writing, such as one left by an
+// earlier version, is not treated as an edit.
+func (b *FileBackend) edited(id, name string, recorded map[string]string) bool {
+	want, ok := recorded[name]
+	if !ok {
+		return false
+	}
+	got, ok := b.files.hash(id, name)
+	return ok && got != want
+}
+
+// Ensure FileBackend implements Backend
+var _ Backend = (*FileBackend)(nil)
@@ -0,0 +1,229 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// openFileBackend opens the files or hybrid backend of root and syncs its
+// task directories, as the server does at start.
+func openFileBackend(t *testing.T, root string, mode config.StorageMode) *FileBackend {
+	t.Helper()
+	backend, err := NewBackend(root, &config.StorageConfig{
+		Mode:  mode,
+		Files: config.FileStorageConfig{CleanupOnComplete: true, WriteThrough: true},
+	})
+	if err != nil {
+		t.Fatalf("NewBackend: %v", err)
+	}
+	fb, ok := backend.(*FileBackend)
+	if !ok {
+		t.Fatalf("NewBackend(%s) = %T, want *FileBackend", mode, backend)
+	}
+	if err := fb.Sync(); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	return fb
+}
+
+func TestNewBackend_TaskDirectoriesNeedWriteThrough(t *testing.T) {
+	root := t.TempDir()
+	backend, err := NewBackend(root, &config.StorageConfig{Mode: config.StorageModeHybrid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = backend.Close() }()
+
+	if _, ok := backend.(*DatabaseBackend); !ok {
+		t.Fatalf("hybrid without write_through = %T, want *DatabaseBackend", backend)
+	}
+	if err := backend.SaveTask(task.NewProtoTask("TASK-001", "No files")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(root, TasksDir)); !os.IsNotExist(err) {
+		t.Errorf("%s written without write_through: %v", TasksDir, err)
+	}
+}
+
+func TestFileBackend_WritesThroughAndImports(t *testing.T) {
+	root := t.TempDir()
+	taskDir := filepath.Join(root, TasksDir, "TASK-001")
+	backend := openFileBackend(t, root, config.StorageModeFiles)
+
+	tk := task.NewProtoTask("TASK-001", "Fix auth timeout")
+	tk.Priority = orcv1.TaskPriority_TASK_PRIORITY_HIGH
+	if err := backend.SaveTask(tk); err != nil {
+		t.Fatalf("SaveTask: %v", err)
+	}
+	if err := backend.SavePlan(&db.Plan{TaskID: "TASK-001", Version: 1, Weight: "small", Phases: `[{"id":"implement"}]`}); err != nil {
+		t.Fatalf("SavePlan: %v", err)
+	}
+	if err := backend.SaveSpecForTask("TASK-001", "## Intent\nRefresh tokens early.", "test"); err != nil {
+		t.Fatalf("SaveSpecForTask: %v", err)
+	}
+	if err := backend.AddTranscript(&Transcript{TaskID: "TASK-001", Phase: "implement", MessageUUID: "msg-1", Type: "assistant", Content: "done", Timestamp: time.Now().UnixMilli()}); err != nil {
+		t.Fatalf("AddTranscript: %v", err)
+	}
+	if _, err := backend.SaveAttachment("TASK-001", "trace.txt", "text/plain", []byte("stack")); err != nil {
+		t.Fatalf("SaveAttachment: %v", err)
+	}
+	for _, name := range []string{"task.yaml", "plan.yaml", "spec.md", "transcripts/implement.jsonl", "attachments/trace.txt"} {
+		if _, err := os.Stat(filepath.Join(taskDir, name)); err != nil {
+			t.Errorf("%s not written: %v", name, err)
+		}
+	}
+	if err := backend.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A file rewritten with the same content, as a checkout does, is not an
+	// edit even though it is newer than the database record.
+	taskYAML := filepath.Join(taskDir, "task.yaml")
+	data, err := os.ReadFile(taskYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend = openFileBackend(t, root, config.StorageModeFiles)
+	desc := "Only in the database"
+	tk.Description = &desc
+	if err := backend.DatabaseBackend.SaveTask(tk); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(taskYAML, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := backend.LoadTask("TASK-001"); got.GetDescription() != "Only in the database" {
+		t.Errorf("touched task.yaml overwrote the database: description %q", got.GetDescription())
+	}
+
+	// Hand edits are picked up by the next sync.
+	edited := strings.Replace(string(data), "Fix auth timeout", "Fix auth token expiry", 1)
+	if err := os.WriteFile(taskYAML, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := backend.LoadTask("TASK-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "Fix auth token expiry" || got.Priority != orcv1.TaskPriority_TASK_PRIORITY_HIGH {
+		t.Errorf("task after edit = %q/%v", got.Title, got.Priority)
+	}
+	if err := backend.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A task directory copied into another project is imported whole.
+	other := t.TempDir()
+	if err := os.CopyFS(filepath.Join(other, TasksDir, "TASK-001"), os.DirFS(taskDir)); err != nil {
+		t.Fatal(err)
+	}
+	imported := openFileBackend(t, other, config.StorageModeFiles)
+	defer func() { _ = imported.Close() }()
+
+	if got, err := imported.LoadTask("TASK-001"); err != nil || got.Title != "Fix auth token expiry" {
+		t.Fatalf("imported task = %v, %v", got, err)
+	}
+	if plan, err := imported.LoadPlan("TASK-001"); err != nil || plan == nil || plan.Weight != "small" {
+		t.Errorf("imported plan = %+v, %v", plan, err)
+	}
+	if spec, _ := imported.GetSpecForTask("TASK-001"); !strings.Contains(spec, "Refresh tokens early.") {
+		t.Errorf("imported spec = %q", spec)
+	}
+	if transcripts, _ := imported.GetTranscripts("TASK-001"); len(transcripts) != 1 || transcripts[0].MessageUUID != "msg-1" {
+		t.Errorf("imported transcripts = %+v", transcripts)
+	}
+	if _, data, err := imported.GetAttachment("TASK-001", "trace.txt"); err != nil || string(data) != "stack" {
+		t.Errorf("imported attachment = %q, %v", data, err)
+	}
+}
+
+func TestFileBackend_ModesDecideWhichTasksKeepFiles(t *testing.T) {
+	root := t.TempDir()
+	taskDir := filepath.Join(root, TasksDir, "TASK-001")
+	backend := openFileBackend(t, root, config.StorageModeHybrid)
+
+	tk := task.NewProtoTask("TASK-001", "Ship it")
+	if err := backend.SaveTask(tk); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(taskDir, "task.yaml")); err != nil {
+		t.Fatalf("active task has no task.yaml: %v", err)
+	}
+
+	tk.Status = orcv1.TaskStatus_TASK_STATUS_COMPLETED
+	if err := backend.SaveTask(tk); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(taskDir); !os.IsNotExist(err) {
+		t.Errorf("hybrid mode kept the directory of a completed task: %v", err)
+	}
+	if err := backend.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Files mode keeps every task, writing directories that are missing.
+	backend = openFileBackend(t, root, config.StorageModeFiles)
+	defer func() { _ = backend.Close() }()
+	if _, err := os.Stat(filepath.Join(taskDir, "task.yaml")); err != nil {
+		t.Errorf("files mode did not write the completed task: %v", err)
+	}
+
+	if err := backend.DeleteTask("TASK-001"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(taskDir); !os.IsNotExist(err) {
+		t.Errorf("deleted task still has a directory: %v", err)
+	}
+}
+
+func TestFileBackend_ConvertsLegacyTaskDir(t *testing.T) {
+	root := t.TempDir()
+	writeLegacyTask(t, filepath.Join(root, TasksDir), "TASK-001", map[string]string{
+		"task.yaml":               "id: TASK-001\ntitle: Old task\nstatus: running\n",
+		"state.yaml":              "phases:\n  spec:\n    status: completed\n",
+		"transcripts/spec-1.md":   "# Spec session",
+		"attachments/diagram.png": "png",
+	})
+
+	backend := openFileBackend(t, root, config.StorageModeFiles)
+	defer func() { _ = backend.Close() }()
+
+	got, err := backend.LoadTask("TASK-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != orcv1.TaskStatus_TASK_STATUS_PAUSED || got.GetExecution().GetPhases()["spec"] == nil {
+		t.Errorf("imported task = %v, phases %v", got.Status, got.GetExecution().GetPhases())
+	}
+
+	// The directory is rewritten in the current layout.
+	taskDir := filepath.Join(root, TasksDir, "TASK-001")
+	if _, err := os.Stat(filepath.Join(taskDir, "state.yaml")); !os.IsNotExist(err) {
+		t.Errorf("state.yaml left behind: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(taskDir, "transcripts", "spec.jsonl")); err != nil {
+		t.Errorf("transcripts not converted: %v", err)
+	}
+	files, err := readTaskDir(taskDir)
+	if err != nil {
+		t.Fatalf("readTaskDir: %v", err)
+	}
+	if files.task.Title != "Old task" || len(files.transcripts) != 1 || len(files.attachments) != 1 {
+		t.Errorf("rewritten dir = %q, %d transcripts, %d attachments", files.task.Title, len(files.transcripts), len(files.attachments))
+	}
+}
@@ -0,0 +1,619 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v3"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// Actions recorded for each task in a FilesMigrationReport.
+const (
+	FilesMigrationActionMigrate = "migrate" // imported (or would be, on a dry run)
+	FilesMigrationActionSkip    = "skip"    // already in the database
+	FilesMigrationActionError   = "error"   // unreadable or failed verification
+)
+
+// FilesMigrationOptions controls MigrateFilesToDatabase.
+type FilesMigrationOptions struct {
+	// DryRun parses and reports without writing to the database.
+	DryRun bool
+	// Force overwrites tasks that already exist in the database.
+	Force bool
+}
+
+// FilesMigrationTask is the outcome for one task directory.
+type FilesMigrationTask struct {
+	TaskID      string `json:"task_id"`
+	Title       string `json:"title,omitempty"`
+	Action      string `json:"action"`
+	Reason      string `json:"reason,omitempty"`
+	HasState    bool   `json:"has_state"`
+	HasPlan     bool   `json:"has_plan"`
+	HasSpec     bool   `json:"has_spec"`
+	Transcripts int    `json:"transcripts"`
+	Attachments int    `json:"attachments"`
+	Verified    bool   `json:"verified"`
+}
+
+// FilesMigrationReport summarizes a files-to-database migration.
+type FilesMigrationReport struct {
+	Source   string               `json:"source"`
+	DryRun   bool                 `json:"dry_run"`
+	Tasks    []FilesMigrationTask `json:"tasks"`
+	Migrated int                  `json:"migrated"`
+	Skipped  int                  `json:"skipped"`
+	Failed   int                  `json:"failed"`
+}
+
+// legacyTask is the task.yaml layout written by files-mode storage before
+// task.yaml held the whole task (see taskFileStore).
+type legacyTask struct {
+	ID           string            `yaml:"id"`
+	Title        string            `yaml:"title"`
+	Description  string            `yaml:"description"`
+	Weight       string            `yaml:"weight"`
+	Status       string            `yaml:"status"`
+	CurrentPhase string            `yaml:"current_phase"`
+	Branch       string            `yaml:"branch"`
+	Queue        string            `yaml:"queue"`
+	Priority     string            `yaml:"priority"`
+	Category     string            `yaml:"category"`
+	InitiativeID string            `yaml:"initiative_id"`
+	TargetBranch string            `yaml:"target_branch"`
+	BlockedBy    []string          `yaml:"blocked_by"`
+	RelatedTo    []string          `yaml:"related_to"`
+	Metadata     map[string]string `yaml:"metadata"`
+	CreatedAt    time.Time         `yaml:"created_at"`
+	UpdatedAt    time.Time         `yaml:"updated_at"`
+	StartedAt    *time.Time        `yaml:"started_at"`
+	CompletedAt  *time.Time        `yaml:"completed_at"`
+}
+
+// legacyTokens is the token usage block of state.yaml.
+type legacyTokens struct {
+	InputTokens              int `yaml:"input_tokens"`
+	OutputTokens             int `yaml:"output_tokens"`
+	CacheCreationInputTokens int `yaml:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `yaml:"cache_read_input_tokens"`
+	TotalTokens              int `yaml:"total_tokens"`
+}
+
+// legacyState is the state.yaml layout that went with legacyTask.
+type legacyState struct {
+	CurrentPhase     string `yaml:"current_phase"`
+	CurrentIteration int    `yaml:"current_iteration"`
+	Status           string `yaml:"status"`
+	Error            string `yaml:"error"`
+	Phases           map[string]struct {
+		Status      string       `yaml:"status"`
+		StartedAt   *time.Time   `yaml:"started_at"`
+		CompletedAt *time.Time   `yaml:"completed_at"`
+		Iterations  int          `yaml:"iterations"`
+		CommitSHA   string       `yaml:"commit_sha"`
+		Artifacts   []string     `yaml:"artifacts"`
+		Error       string       `yaml:"error"`
+		Tokens      legacyTokens `yaml:"tokens"`
+	} `yaml:"phases"`
+	Gates []struct {
+		Phase     string    `yaml:"phase"`
+		GateType  string    `yaml:"gate_type"`
+		Approved  bool      `yaml:"approved"`
+		Reason    string    `yaml:"reason"`
+		Timestamp time.Time `yaml:"timestamp"`
+	} `yaml:"gates"`
+	Tokens legacyTokens `yaml:"tokens"`
+	Cost   struct {
+		TotalCostUSD float64            `yaml:"total_cost_usd"`
+		PhaseCosts   map[string]float64 `yaml:"phase_costs"`
+	} `yaml:"cost"`
+	Session *struct {
+		ID     string `yaml:"id"`
+		Model  string `yaml:"model"`
+		Status string `yaml:"status"`
+	} `yaml:"session"`
+}
+
+// planFile is the plan.yaml layout.
+type planFile struct {
+	Version     int              `yaml:"version"`
+	Weight      string           `yaml:"weight"`
+	Description string           `yaml:"description"`
+	Phases      []map[string]any `yaml:"phases"`
+}
+
+// taskDirFiles is everything read from one task directory.
+type taskDirFiles struct {
+	task        *orcv1.Task
+	plan        *db.Plan
+	spec        string
+	transcripts []Transcript
+	attachments []taskFileAttachment
+}
+
+// taskFileAttachment is one file under attachments/.
+type taskFileAttachment struct {
+	filename    string
+	contentType string
+	data        []byte
+}
+
+// MigrateFilesToDatabase imports the task directories under tasksDir (see
+// TasksDir) into backend. Each migrated task is read back and checked
+// against the source files. Source files are never modified.
+func MigrateFilesToDatabase(backend Backend, tasksDir string, opts FilesMigrationOptions) (*FilesMigrationReport, error) {
+	entries, err := os.ReadDir(tasksDir)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", tasksDir, err)
+	}
+
+	report := &FilesMigrationReport{Source: tasksDir, DryRun: opts.DryRun}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(tasksDir, entry.Name())
+		if _, err := os.Stat(filepath.Join(dir, "task.yaml")); err != nil {
+			continue
+		}
+
+		result := migrateTaskDir(backend, dir, opts)
+		switch result.Action {
+		case FilesMigrationActionMigrate:
+			report.Migrated++
+		case FilesMigrationActionSkip:
+			report.Skipped++
+		default:
+			report.Failed++
+		}
+		report.Tasks = append(report.Tasks, result)
+	}
+	return report, nil
+}
+
+func migrateTaskDir(backend Backend, dir string, opts FilesMigrationOptions) FilesMigrationTask {
+	result := FilesMigrationTask{TaskID: filepath.Base(dir), Action: FilesMigrationActionError}
+
+	files, err := readTaskDir(dir)
+	if err != nil {
+		result.Reason = err.Error()
+		return result
+	}
+	t := files.task
+	result.TaskID = t.Id
+	result.Title = t.Title
+	result.HasState = len(t.Execution.Phases) > 0 || t.Execution.Tokens.TotalTokens > 0
+	result.HasPlan = files.plan != nil
+	result.HasSpec = files.spec != ""
+	result.Transcripts = len(files.transcripts)
+	result.Attachments = len(files.attachments)
+
+	exists, err := backend.TaskExists(t.Id)
+	if err != nil {
+		result.Reason = fmt.Sprintf("check existing task: %v", err)
+		return result
+	}
+	if exists && !opts.Force {
+		result.Action = FilesMigrationActionSkip
+		result.Reason = "already in database (use --force to overwrite)"
+		return result
+	}
+	if opts.DryRun {
+		result.Action = FilesMigrationActionMigrate
+		return result
+	}
+
+	if err := saveTaskDir(backend, files); err != nil {
+		result.Reason = err.Error()
+		return result
+	}
+	if err := verifyTaskDir(backend, files); err != nil {
+		result.Reason = fmt.Sprintf("verification failed: %v", err)
+		return result
+	}
+	result.Action = FilesMigrationActionMigrate
+	result.Verified = true
+	return result
+}
+
+// readTaskDir parses one task directory, in the current layout or the
+// legacy one. Only task.yaml is required.
+func readTaskDir(dir string) (*taskDirFiles, error) {
+	t, legacyWeight, err := readTaskDirTask(dir)
+	if err != nil {
+		return nil, err
+	}
+	// No executor survives the import; resume picks a paused task up again.
+	if t.Status == orcv1.TaskStatus_TASK_STATUS_RUNNING {
+		t.Status = orcv1.TaskStatus_TASK_STATUS_PAUSED
+	}
+	files := &taskDirFiles{task: t}
+
+	var lp planFile
+	switch err := readLegacyYAML(filepath.Join(dir, "plan.yaml"), &lp); {
+	case err == nil:
+		phases, err := json.Marshal(lp.Phases)
+		if err != nil {
+			return nil, fmt.Errorf("encode plan phases: %w", err)
+		}
+		weight := lp.Weight
+		if weight == "" {
+			weight = legacyWeight
+		}
+		files.plan = &db.Plan{
+			TaskID:      t.Id,
+			Version:     max(lp.Version, 1),
+			Weight:      weight,
+			Description: lp.Description,
+			Phases:      string(phases),
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "spec.md")); err == nil {
+		files.spec = strings.TrimSpace(string(data))
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read spec.md: %w", err)
+	}
+
+	transcripts, err := readTranscripts(t.Id, filepath.Join(dir, "transcripts"))
+	if err != nil {
+		return nil, err
+	}
+	files.transcripts = transcripts
+
+	attachments, err := readAttachments(filepath.Join(dir, "attachments"))
+	if err != nil {
+		return nil, err
+	}
+	files.attachments = attachments
+	return files, nil
+}
+
+// readTaskDirTask reads the task of a task directory. A directory with a
+// state.yaml, or whose task.yaml is not a protobuf task, is in the legacy
+// layout; for those the task weight is returned too, as the plan may need
+// it.
+func readTaskDirTask(dir string) (*orcv1.Task, string, error) {
+	_, statErr := os.Stat(filepath.Join(dir, "state.yaml"))
+	if statErr != nil {
+		t, err := readTask(filepath.Join(dir, "task.yaml"))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, "", err
+		}
+		if err == nil {
+			if t.Id == "" {
+				t.Id = filepath.Base(dir)
+			}
+			return t, "", nil
+		}
+	}
+
+	var lt legacyTask
+	if err := readLegacyYAML(filepath.Join(dir, "task.yaml"), &lt); err != nil {
+		return nil, "", err
+	}
+	if lt.ID == "" {
+		lt.ID = filepath.Base(dir)
+	}
+	t := legacyTaskToProto(&lt)
+
+	var ls legacyState
+	switch err := readLegacyYAML(filepath.Join(dir, "state.yaml"), &ls); {
+	case err == nil:
+		applyLegacyState(t, &ls)
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, "", err
+	}
+	return t, lt.Weight, nil
+}
+
+func readLegacyYAML(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+func legacyTaskToProto(lt *legacyTask) *orcv1.Task {
+	t := task.NewProtoTask(lt.ID, lt.Title)
+	if lt.Description != "" {
+		task.SetDescriptionProto(t, lt.Description)
+	}
+	if s := task.StatusToProto(lt.Status); s != orcv1.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		t.Status = s
+	}
+	if lt.Branch != "" {
+		t.Branch = lt.Branch
+	}
+	if lt.Queue != "" {
+		t.Queue = task.QueueToProto(lt.Queue)
+	}
+	if p, ok := task.ParsePriorityProto(lt.Priority); ok {
+		t.Priority = p
+	}
+	if c, ok := task.ParseCategoryProto(lt.Category); ok {
+		t.Category = c
+	}
+	if lt.CurrentPhase != "" {
+		task.SetCurrentPhaseProto(t, lt.CurrentPhase)
+	}
+	if lt.InitiativeID != "" {
+		task.SetInitiativeProto(t, lt.InitiativeID)
+	}
+	if lt.TargetBranch != "" {
+		task.SetTargetBranchProto(t, lt.TargetBranch)
+	}
+	t.BlockedBy = lt.BlockedBy
+	t.RelatedTo = lt.RelatedTo
+	for k, v := range lt.Metadata {
+		t.Metadata[k] = v
+	}
+	// Weight was dropped from tasks; keep it visible for workflow selection.
+	if lt.Weight != "" {
+		t.Metadata["legacy_weight"] = lt.Weight
+	}
+	if !lt.CreatedAt.IsZero() {
+		t.CreatedAt = timestamppb.New(lt.CreatedAt)
+	}
+	if !lt.UpdatedAt.IsZero() {
+		t.UpdatedAt = timestamppb.New(lt.UpdatedAt)
+	}
+	if lt.StartedAt != nil {
+		t.StartedAt = timestamppb.New(*lt.StartedAt)
+	}
+	if lt.CompletedAt != nil {
+		t.CompletedAt = timestamppb.New(*lt.CompletedAt)
+	}
+	return t
+}
+
+func applyLegacyState(t *orcv1.Task, ls *legacyState) {
+	exec := t.Execution
+	exec.CurrentIteration = int32(ls.CurrentIteration)
+	exec.Tokens = ls.Tokens.proto()
+	exec.Cost = &orcv1.CostTracking{TotalCostUsd: ls.Cost.TotalCostUSD, PhaseCosts: ls.Cost.PhaseCosts}
+	if ls.Error != "" {
+		exec.Error = &ls.Error
+	}
+	if ls.Session != nil && ls.Session.ID != "" {
+		exec.Session = &orcv1.SessionInfo{Id: ls.Session.ID, Model: ls.Session.Model, Status: ls.Session.Status}
+	}
+	if ls.CurrentPhase != "" && task.GetCurrentPhaseProto(t) == "" {
+		task.SetCurrentPhaseProto(t, ls.CurrentPhase)
+	}
+
+	for id, p := range ls.Phases {
+		ps := &orcv1.PhaseState{
+			Status:     task.PhaseStatusToProto(p.Status),
+			Iterations: int32(p.Iterations),
+			Artifacts:  p.Artifacts,
+			Tokens:     p.Tokens.proto(),
+		}
+		if p.StartedAt != nil {
+			ps.StartedAt = timestamppb.New(*p.StartedAt)
+		}
+		if p.CompletedAt != nil {
+			ps.CompletedAt = timestamppb.New(*p.CompletedAt)
+		}
+		if p.CommitSHA != "" {
+			ps.CommitSha = &p.CommitSHA
+		}
+		if p.Error != "" {
+			ps.Error = &p.Error
+		}
+		exec.Phases[id] = ps
+	}
+
+	for _, g := range ls.Gates {
+		gate := &orcv1.GateDecision{Phase: g.Phase, GateType: g.GateType, Approved: g.Approved}
+		if g.Reason != "" {
+			gate.Reason = &g.Reason
+		}
+		if !g.Timestamp.IsZero() {
+			gate.Timestamp = timestamppb.New(g.Timestamp)
+		}
+		exec.Gates = append(exec.Gates, gate)
+	}
+}
+
+func (lt legacyTokens) proto() *orcv1.TokenUsage {
+	total := lt.TotalTokens
+	if total == 0 {
+		total = lt.InputTokens + lt.OutputTokens
+	}
+	return &orcv1.TokenUsage{
+		InputTokens:              int32(lt.InputTokens),
+		OutputTokens:             int32(lt.OutputTokens),
+		CacheCreationInputTokens: int32(lt.CacheCreationInputTokens),
+		CacheReadInputTokens:     int32(lt.CacheReadInputTokens),
+		TotalTokens:              int32(total),
+	}
+}
+
+// readTranscripts reads transcripts/<phase>.jsonl files and legacy
+// transcripts/<phase>-<iteration>.md files. Each .md file becomes one
+// transcript entry with a stable message UUID, so a repeated migration does
+// not duplicate it.
+func readTranscripts(taskID, dir string) ([]Transcript, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read transcripts: %w", err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var transcripts []Transcript
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if filepath.Ext(name) == ".jsonl" {
+			lines, err := readTranscriptsJSONL(path)
+			if err != nil {
+				return nil, err
+			}
+			for i := range lines {
+				lines[i].TaskID = taskID
+			}
+			transcripts = append(transcripts, lines...)
+			continue
+		}
+		if filepath.Ext(name) != ".md" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read transcript %s: %w", name, err)
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("stat transcript %s: %w", name, err)
+		}
+
+		phase := strings.TrimSuffix(name, ".md")
+		if i := strings.LastIndex(phase, "-"); i > 0 {
+			if _, err := strconv.Atoi(phase[i+1:]); err == nil {
+				phase = phase[:i]
+			}
+		}
+		transcripts = append(transcripts, Transcript{
+			TaskID:      taskID,
+			Phase:       phase,
+			MessageUUID: "legacy-" + taskID + "-" + name,
+			Type:        "assistant",
+			Role:        "assistant",
+			Content:     string(data),
+			Timestamp:   info.ModTime().UnixMilli(),
+		})
+	}
+	return transcripts, nil
+}
+
+func saveTaskDir(backend Backend, files *taskDirFiles) error {
+	t := files.task
+	if err := backend.SaveTask(t); err != nil {
+		return fmt.Errorf("save task: %w", err)
+	}
+	if files.plan != nil {
+		if err := backend.SavePlan(files.plan); err != nil {
+			return fmt.Errorf("save plan: %w", err)
+		}
+	}
+	if files.spec != "" {
+		if err := backend.SaveSpecForTask(t.Id, files.spec, "files-migration"); err != nil {
+			return fmt.Errorf("save spec: %w", err)
+		}
+	}
+
+	existing, err := backend.GetTranscripts(t.Id)
+	if err != nil {
+		return fmt.Errorf("load existing transcripts: %w", err)
+	}
+	seen := make(map[string]bool, len(existing))
+	for _, tr := range existing {
+		seen[tr.MessageUUID] = true
+	}
+	runs := make(map[string]bool)
+	for i := range files.transcripts {
+		tr := files.transcripts[i]
+		if seen[tr.MessageUUID] {
+			continue
+		}
+		// Workflow runs are not kept in task directories; drop links to
+		// runs this database does not have.
+		if tr.WorkflowRunID != "" {
+			exists, ok := runs[tr.WorkflowRunID]
+			if !ok {
+				run, err := backend.GetWorkflowRun(tr.WorkflowRunID)
+				if err != nil {
+					return fmt.Errorf("check workflow run %s: %w", tr.WorkflowRunID, err)
+				}
+				exists = run != nil
+				runs[tr.WorkflowRunID] = exists
+			}
+			if !exists {
+				tr.WorkflowRunID = ""
+			}
+		}
+		if err := backend.AddTranscript(&tr); err != nil {
+			return fmt.Errorf("save transcript %s: %w", tr.MessageUUID, err)
+		}
+	}
+
+	for _, a := range files.attachments {
+		if _, err := backend.SaveAttachment(t.Id, a.filename, a.contentType, a.data); err != nil {
+			return fmt.Errorf("save attachment %s: %w", a.filename, err)
+		}
+	}
+	return nil
+}
+
+// verifyTaskDir reads a migrated task back and compares it to the parsed
+// source files.
+func verifyTaskDir(backend Backend, files *taskDirFiles) error {
+	want := files.task
+	got, err := backend.LoadTask(want.Id)
+	if err != nil {
+		return fmt.Errorf("load task: %w", err)
+	}
+	if got.Title != want.Title || got.Status != want.Status {
+		return fmt.Errorf("task mismatch: got %q/%s, want %q/%s", got.Title, got.Status, want.Title, want.Status)
+	}
+	if n := len(got.GetExecution().GetPhases()); n != len(want.Execution.Phases) {
+		return fmt.Errorf("phase state mismatch: got %d phases, want %d", n, len(want.Execution.Phases))
+	}
+	if files.plan != nil {
+		plan, err := backend.LoadPlan(want.Id)
+		if err != nil || plan == nil {
+			return fmt.Errorf("plan missing after migration")
+		}
+	}
+	if files.spec != "" {
+		if ok, err := backend.SpecExistsForTask(want.Id); err != nil || !ok {
+			return fmt.Errorf("spec missing after migration")
+		}
+	}
+	if len(files.transcripts) > 0 {
+		transcripts, err := backend.GetTranscripts(want.Id)
+		if err != nil {
+			return fmt.Errorf("load transcripts: %w", err)
+		}
+		stored := make(map[string]bool, len(transcripts))
+		for _, tr := range transcripts {
+			stored[tr.MessageUUID] = true
+		}
+		for _, tr := range files.transcripts {
+			if !stored[tr.MessageUUID] {
+				return fmt.Errorf("transcript %s missing after migration", tr.MessageUUID)
+			}
+		}
+	}
+	for _, a := range files.attachments {
+		if _, _, err := backend.GetAttachment(want.Id, a.filename); err != nil {
+			return fmt.Errorf("attachment %s missing after migration", a.filename)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+)
+
+func writeLegacyTask(t *testing.T, tasksDir, id string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(tasksDir, id, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMigrateFilesToDatabase(t *testing.T) {
+	backend, err := NewInMemoryBackend()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = backend.Close() }()
+
+	tasksDir := t.TempDir()
+	writeLegacyTask(t, tasksDir, "TASK-001", map[string]string{
+		"task.yaml": `id: TASK-001
+title: Fix auth timeout
+description: Tokens expire too early
+weight: small
+status: running
+category: bug
+priority: high
+created_at: 2026-01-10T14:30:00Z
+`,
+		"state.yaml": `current_phase: implement
+current_iteration: 2
+phases:
+  spec:
+    status: completed
+    iterations: 1
+    commit_sha: abc123
+    tokens:
+      input_tokens: 100
+      output_tokens: 50
+  implement:
+    status: running
+tokens:
+  input_tokens: 100
+  output_tokens: 50
+cost:
+  total_cost_usd: 0.25
+gates:
+  - phase: spec
+    gate_type: auto
+    approved: true
+`,
+		"plan.yaml": `version: 1
+weight: small
+phases:
+  - id: spec
+  - id: implement
+`,
+		"spec.md":                      "# Spec\n\nDo the thing.\n",
+		"transcripts/spec-001.md":      "spec transcript",
+		"transcripts/implement-001.md": "implement transcript",
+	})
+	writeLegacyTask(t, tasksDir, "TASK-002", map[string]string{
+		"task.yaml": "id: TASK-002\ntitle: Minimal\nstatus: created\n",
+	})
+	writeLegacyTask(t, tasksDir, "TASK-003", map[string]string{
+		"task.yaml": "id: [not valid\n",
+	})
+
+	// Dry run writes nothing.
+	report, err := MigrateFilesToDatabase(backend, tasksDir, FilesMigrationOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if report.Migrated != 2 || report.Failed != 1 {
+		t.Errorf("dry run migrated=%d failed=%d, want 2/1", report.Migrated, report.Failed)
+	}
+	if exists, _ := backend.TaskExists("TASK-001"); exists {
+		t.Fatal("dry run saved a task")
+	}
+
+	report, err = MigrateFilesToDatabase(backend, tasksDir, FilesMigrationOptions{})
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if report.Migrated != 2 || report.Failed != 1 {
+		t.Fatalf("migrated=%d failed=%d, want 2/1: %+v", report.Migrated, report.Failed, report.Tasks)
+	}
+	for _, r := range report.Tasks {
+		if r.TaskID == "TASK-001" && (!r.Verified || !r.HasState || !r.HasPlan || !r.HasSpec || r.Transcripts != 2) {
+			t.Errorf("TASK-001 report = %+v", r)
+		}
+	}
+
+	got, err := backend.LoadTask("TASK-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != orcv1.TaskStatus_TASK_STATUS_PAUSED {
+		t.Errorf("status = %v, want paused", got.Status)
+	}
+	if got.Category != orcv1.TaskCategory_TASK_CATEGORY_BUG || got.Priority != orcv1.TaskPriority_TASK_PRIORITY_HIGH {
+		t.Errorf("category/priority = %v/%v", got.Category, got.Priority)
+	}
+	if got.GetCurrentPhase() != "implement" {
+		t.Errorf("current phase = %q, want implement", got.GetCurrentPhase())
+	}
+	if spec := got.GetExecution().GetPhases()["spec"]; spec == nil || spec.GetCommitSha() != "abc123" {
+		t.Errorf("spec phase state = %+v", spec)
+	}
+	if got.GetExecution().GetCost().GetTotalCostUsd() != 0.25 {
+		t.Errorf("cost = %v, want 0.25", got.GetExecution().GetCost().GetTotalCostUsd())
+	}
+	if got.GetCreatedAt().AsTime().Year() != 2026 {
+		t.Errorf("created_at = %v, want source timestamp", got.GetCreatedAt().AsTime())
+	}
+	if spec, _ := backend.GetSpecForTask("TASK-001"); spec == "" {
+		t.Error("spec not migrated")
+	}
+	if plan, err := backend.LoadPlan("TASK-001"); err != nil || plan == nil || plan.Weight != "small" {
+		t.Errorf("plan = %+v, %v", plan, err)
+	}
+	transcripts, err := backend.GetTranscripts("TASK-001")
+	if err != nil || len(transcripts) != 2 {
+		t.Fatalf("transcripts = %d, %v; want 2", len(transcripts), err)
+	}
+
+	// A second run skips what is already there.
+	report, err = MigrateFilesToDatabase(backend, tasksDir, FilesMigrationOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Skipped != 2 {
+		t.Errorf("second run skipped=%d, want 2", report.Skipped)
+	}
+
+	// --force re-imports without duplicating transcripts.
+	if _, err := MigrateFilesToDatabase(backend, tasksDir, FilesMigrationOptions{Force: true}); err != nil {
+		t.Fatal(err)
+	}
+	if transcripts, _ := backend.GetTranscripts("TASK-001"); len(transcripts) != 2 {
+		t.Errorf("after forced re-run: %d transcripts, want 2", len(transcripts))
+	}
+}
+
+func TestMigrateFilesToDatabase_MissingDir(t *testing.T) {
+	backend, err := NewInMemoryBackend()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = backend.Close() }()
+
+	if _, err := MigrateFilesToDatabase(backend, filepath.Join(t.TempDir(), "nope"), FilesMigrationOptions{}); err == nil {
+		t.Error("expected error for missing tasks directory")
+	}
+}
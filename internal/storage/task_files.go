@@ -0,0 +1,369 @@
+package storage
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/task"
+	"github.com/randalmurphal/orc/internal/util"
+)
+
+// TasksDir is where the files and hybrid storage modes keep task data,
+// relative to the project root. See taskFileStore for the layout.
+const TasksDir = ".orc/tasks"
+
+// Task files use the protobuf JSON mapping with proto field names, so
+// task.yaml reads like the API's task objects.
+var (
+	taskFileMarshaler   = protojson.MarshalOptions{UseProtoNames: true}
+	taskFileUnmarshaler = protojson.UnmarshalOptions{}
+)
+
+// taskFileStore reads and writes one directory per task under TasksDir:
+//
+//	task.yaml              the task with its execution state
+//	plan.yaml              the execution plan
+//	spec.md                the latest spec
+//	transcripts/<phase>.jsonl  one transcript entry per line
+//	attachments/<name>     attachment files
+//
+// Directories written by earlier versions, with the task split into
+// task.yaml and state.yaml and transcripts as transcripts/*.md, are still
+// read.
+//
+// Writing task.yaml, plan.yaml or spec.md returns the file's content hash,
+// which FileBackend records to tell an edit from a file that was only
+// touched. The store does no locking of its own.
+type taskFileStore struct {
+	dir string
+}
+
+// newTaskFileStore returns the store for the project at projectPath.
+func newTaskFileStore(projectPath string) *taskFileStore {
+	return &taskFileStore{dir: filepath.Join(projectPath, TasksDir)}
+}
+
+// taskDir returns the directory of a task, rejecting IDs that would
+// escape the store.
+func (s *taskFileStore) taskDir(id string) (string, error) {
+	if id == "" || id == "." || id == ".." || filepath.Base(id) != id {
+		return "", fmt.Errorf("invalid task id %q", id)
+	}
+	return filepath.Join(s.dir, id), nil
+}
+
+// hasTask reports whether the task has a task.yaml.
+func (s *taskFileStore) hasTask(id string) bool {
+	dir, err := s.taskDir(id)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(filepath.Join(dir, "task.yaml"))
+	return err == nil
+}
+
+// taskIDs lists the tasks that have a task.yaml, sorted.
+func (s *taskFileStore) taskIDs() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", s.dir, err)
+	}
+	var ids []string
+	for _, entry := range entries {
+		if entry.IsDir() && s.hasTask(entry.Name()) {
+			ids = append(ids, entry.Name())
+		}
+	}
+	return ids, nil
+}
+
+// contentHash returns the hex SHA-256 of a task file's content.
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hash returns the content hash of a file in a task's directory.
+func (s *taskFileStore) hash(id, name string) (string, bool) {
+	dir, err := s.taskDir(id)
+	if err != nil {
+		return "", false
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return "", false
+	}
+	return contentHash(data), true
+}
+
+// write atomically writes a file in a task's directory and returns its
+// content hash.
+func (s *taskFileStore) write(id, name string, data []byte) (string, error) {
+	dir, err := s.taskDir(id)
+	if err != nil {
+		return "", err
+	}
+	if err := util.AtomicWriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+		return "", err
+	}
+	return contentHash(data), nil
+}
+
+// writeTask writes task.yaml. Executor fields and the dependency fields
+// computed on load are left out: they only mean something to the database
+// they were read from.
+func (s *taskFileStore) writeTask(t *orcv1.Task) (string, error) {
+	if _, err := s.taskDir(t.Id); err != nil {
+		return "", err
+	}
+	t = proto.Clone(t).(*orcv1.Task)
+	t.ExecutorPid = 0
+	t.ExecutorHostname = nil
+	t.LastHeartbeat = nil
+	t.Blocks = nil
+	t.ReferencedBy = nil
+	t.IsBlocked = false
+	t.UnmetBlockers = nil
+	t.DependencyStatus = orcv1.DependencyStatus_DEPENDENCY_STATUS_UNSPECIFIED
+
+	data, err := taskFileMarshaler.Marshal(t)
+	if err != nil {
+		return "", fmt.Errorf("marshal task %s: %w", t.Id, err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return "", fmt.Errorf("convert task %s: %w", t.Id, err)
+	}
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("encode task %s: %w", t.Id, err)
+	}
+	hash, err := s.write(t.Id, "task.yaml", out)
+	if err != nil {
+		return "", err
+	}
+	// state.yaml marks the legacy layout; task.yaml now holds the state.
+	return hash, s.remove(t.Id, "state.yaml")
+}
+
+// readTask parses a task.yaml written by writeTask.
+func readTask(path string) (*orcv1.Task, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	jsonData, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("convert %s: %w", filepath.Base(path), err)
+	}
+	t := &orcv1.Task{}
+	if err := taskFileUnmarshaler.Unmarshal(jsonData, t); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	if t.Execution == nil {
+		t.Execution = &orcv1.ExecutionState{}
+	}
+	if t.Execution.Phases == nil {
+		t.Execution.Phases = make(map[string]*orcv1.PhaseState)
+	}
+	if t.Execution.Tokens == nil {
+		t.Execution.Tokens = &orcv1.TokenUsage{}
+	}
+	if t.Metadata == nil {
+		t.Metadata = make(map[string]string)
+	}
+	return t, nil
+}
+
+// writePlan writes plan.yaml.
+func (s *taskFileStore) writePlan(p *db.Plan) (string, error) {
+	pf := planFile{Version: p.Version, Weight: p.Weight, Description: p.Description}
+	if p.Phases != "" {
+		if err := json.Unmarshal([]byte(p.Phases), &pf.Phases); err != nil {
+			return "", fmt.Errorf("decode plan phases for %s: %w", p.TaskID, err)
+		}
+	}
+	out, err := yaml.Marshal(pf)
+	if err != nil {
+		return "", fmt.Errorf("encode plan for %s: %w", p.TaskID, err)
+	}
+	return s.write(p.TaskID, "plan.yaml", out)
+}
+
+// writeSpec writes spec.md.
+func (s *taskFileStore) writeSpec(taskID, content string) (string, error) {
+	return s.write(taskID, "spec.md", []byte(strings.TrimSpace(content)+"\n"))
+}
+
+// writeTranscripts replaces the transcripts/ directory of a task.
+func (s *taskFileStore) writeTranscripts(taskID string, transcripts []Transcript) error {
+	dir, err := s.taskDir(taskID)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(filepath.Join(dir, "transcripts")); err != nil {
+		return fmt.Errorf("clear transcripts: %w", err)
+	}
+	return s.appendTranscripts(transcripts)
+}
+
+// appendTranscripts appends entries to transcripts/<phase>.jsonl of their
+// tasks.
+func (s *taskFileStore) appendTranscripts(transcripts []Transcript) error {
+	for i := range transcripts {
+		tr := &transcripts[i]
+		dir, err := s.taskDir(tr.TaskID)
+		if err != nil {
+			return err
+		}
+		phase := tr.Phase
+		if phase == "" || filepath.Base(phase) != phase {
+			phase = "unknown"
+		}
+		line, err := json.Marshal(tr)
+		if err != nil {
+			return fmt.Errorf("encode transcript %s: %w", tr.MessageUUID, err)
+		}
+		path := filepath.Join(dir, "transcripts", phase+".jsonl")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("create transcripts dir: %w", err)
+		}
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return fmt.Errorf("open %s: %w", path, err)
+		}
+		_, err = f.Write(append(line, '\n'))
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// readTranscriptsJSONL reads a transcripts/<phase>.jsonl file.
+func readTranscriptsJSONL(path string) ([]Transcript, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var transcripts []Transcript
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxTranscriptLine)
+	for scanner.Scan() {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var tr Transcript
+		if err := json.Unmarshal(scanner.Bytes(), &tr); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+		}
+		tr.ID = 0 // IDs are local to each database
+		transcripts = append(transcripts, tr)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", filepath.Base(path), err)
+	}
+	return transcripts, nil
+}
+
+// maxTranscriptLine bounds one transcript entry in a .jsonl file.
+const maxTranscriptLine = 64 * 1024 * 1024
+
+// writeAttachment writes attachments/<filename>.
+func (s *taskFileStore) writeAttachment(taskID, filename string, data []byte) error {
+	dir, err := s.taskDir(taskID)
+	if err != nil {
+		return err
+	}
+	if filename == "" || filepath.Base(filename) != filename {
+		return fmt.Errorf("invalid attachment name %q", filename)
+	}
+	return util.AtomicWriteFile(filepath.Join(dir, "attachments", filename), data, 0644)
+}
+
+// deleteAttachment removes attachments/<filename>, if present.
+func (s *taskFileStore) deleteAttachment(taskID, filename string) error {
+	if filename == "" || filepath.Base(filename) != filename {
+		return fmt.Errorf("invalid attachment name %q", filename)
+	}
+	return s.remove(taskID, filepath.Join("attachments", filename))
+}
+
+// readAttachments reads every file under attachments/.
+func readAttachments(dir string) ([]taskFileAttachment, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read attachments: %w", err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var attachments []taskFileAttachment
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".tmp-") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read attachment %s: %w", entry.Name(), err)
+		}
+		attachments = append(attachments, taskFileAttachment{
+			filename:    entry.Name(),
+			contentType: task.DetectContentType(entry.Name()),
+			data:        data,
+		})
+	}
+	return attachments, nil
+}
+
+// remove deletes a file in a task's directory, if present.
+func (s *taskFileStore) remove(id, name string) error {
+	dir, err := s.taskDir(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(dir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove %s: %w", name, err)
+	}
+	return nil
+}
+
+// removeTask deletes a task's directory.
+func (s *taskFileStore) removeTask(id string) error {
+	dir, err := s.taskDir(id)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("remove %s: %w", dir, err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package task
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+)
+
+// BudgetMetadataKey is the task metadata key holding the task's spending
+// limit in USD. Create and update requests set it through their metadata.
+const BudgetMetadataKey = "budget_usd"
+
+// ParseBudgetUSD parses a budget in USD. An empty string means no budget.
+func ParseBudgetUSD(s string) (float64, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "$")
+	if s == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+		return 0, fmt.Errorf("invalid budget %q: must be a non-negative amount in USD", s)
+	}
+	return v, nil
+}
+
+// GetBudgetUSD returns the task's spending limit in USD, or 0 when it has
+// none (or the stored value does not parse).
+func GetBudgetUSD(t *orcv1.Task) float64 {
+	if t == nil {
+		return 0
+	}
+	v, err := ParseBudgetUSD(t.Metadata[BudgetMetadataKey])
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// SetBudgetUSD sets the task's spending limit in USD. A limit of 0 removes it.
+func SetBudgetUSD(t *orcv1.Task, limitUSD float64) {
+	if t == nil {
+		return
+	}
+	if limitUSD <= 0 {
+		delete(t.Metadata, BudgetMetadataKey)
+		return
+	}
+	EnsureMetadataProto(t)
+	t.Metadata[BudgetMetadataKey] = strconv.FormatFloat(limitUSD, 'f', -1, 64)
+}
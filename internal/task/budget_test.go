@@ -0,0 +1,37 @@
+package task
+
+import "testing"
+
+func TestParseBudgetUSD(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"12.5", 12.5, false},
+		{" $20 ", 20, false},
+		{"0", 0, false},
+		{"-1", 0, true},
+		{"lots", 0, true},
+		{"Inf", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseBudgetUSD(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseBudgetUSD(%q) = %v, %v; want %v (error %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSetBudgetUSD(t *testing.T) {
+	tk := NewProtoTask("TASK-001", "Budgeted")
+	SetBudgetUSD(tk, 7.25)
+	if tk.Metadata[BudgetMetadataKey] != "7.25" || GetBudgetUSD(tk) != 7.25 {
+		t.Errorf("metadata = %v", tk.Metadata)
+	}
+	SetBudgetUSD(tk, 0)
+	if _, ok := tk.Metadata[BudgetMetadataKey]; ok || GetBudgetUSD(tk) != 0 {
+		t.Errorf("budget not removed: %v", tk.Metadata)
+	}
+}
@@ -0,0 +1,220 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+)
+
+// MaxBulkTasks caps the number of tasks in one bulk creation request.
+const MaxBulkTasks = 500
+
+// BulkTaskSpec is one task in a bulk creation request
+// (POST /api/tasks/bulk, `orc task create --from-file`).
+//
+// BlockedBy and RelatedTo entries name either the Ref of another spec in
+// the same request or the ID of an existing task.
+type BulkTaskSpec struct {
+	// Ref is a request-local name other specs use to reference this task
+	// before it has an ID. Optional when nothing references the task.
+	Ref          string   `json:"ref,omitempty" yaml:"ref,omitempty"`
+	Title        string   `json:"title" yaml:"title"`
+	Description  string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Workflow     string   `json:"workflow,omitempty" yaml:"workflow,omitempty"`
+	Category     string   `json:"category,omitempty" yaml:"category,omitempty"`
+	Priority     string   `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Queue        string   `json:"queue,omitempty" yaml:"queue,omitempty"`
+	InitiativeID string   `json:"initiative_id,omitempty" yaml:"initiative_id,omitempty"`
+	TargetBranch string   `json:"target_branch,omitempty" yaml:"target_branch,omitempty"`
+	BlockedBy    []string `json:"blocked_by,omitempty" yaml:"blocked_by,omitempty"`
+	RelatedTo    []string `json:"related_to,omitempty" yaml:"related_to,omitempty"`
+}
+
+// label names a spec in error messages.
+func (s BulkTaskSpec) label(i int) string {
+	if s.Ref != "" {
+		return fmt.Sprintf("task %d (%s)", i+1, s.Ref)
+	}
+	return fmt.Sprintf("task %d", i+1)
+}
+
+// ValidateBulkTasks checks specs before any ID is allocated: titles, enum
+// values, unique refs, that every reference resolves to a spec or to a task
+// for which exists returns true, and that blocked_by has no cycles.
+func ValidateBulkTasks(specs []BulkTaskSpec, exists func(id string) bool) error {
+	if len(specs) == 0 {
+		return fmt.Errorf("no tasks to create")
+	}
+	if len(specs) > MaxBulkTasks {
+		return fmt.Errorf("too many tasks: %d (max %d)", len(specs), MaxBulkTasks)
+	}
+
+	refs := make(map[string]int, len(specs))
+	for i, s := range specs {
+		if strings.TrimSpace(s.Title) == "" {
+			return fmt.Errorf("%s: title is required", s.label(i))
+		}
+		if s.Ref == "" {
+			continue
+		}
+		if j, dup := refs[s.Ref]; dup {
+			return fmt.Errorf("%s: ref %q is already used by task %d", s.label(i), s.Ref, j+1)
+		}
+		if exists(s.Ref) {
+			return fmt.Errorf("%s: ref %q is also an existing task ID", s.label(i), s.Ref)
+		}
+		refs[s.Ref] = i
+	}
+
+	blockers := make([][]int, len(specs))
+	for i, s := range specs {
+		if s.Category != "" {
+			if _, ok := ParseCategoryProto(s.Category); !ok {
+				return fmt.Errorf("%s: invalid category %q (valid: feature, bug, refactor, chore, docs, test)", s.label(i), s.Category)
+			}
+		}
+		if s.Priority != "" {
+			if _, ok := ParsePriorityProto(s.Priority); !ok {
+				return fmt.Errorf("%s: invalid priority %q (valid: critical, high, normal, low)", s.label(i), s.Priority)
+			}
+		}
+		if s.Queue != "" && s.Queue != "active" && s.Queue != "backlog" {
+			return fmt.Errorf("%s: invalid queue %q (valid: active, backlog)", s.label(i), s.Queue)
+		}
+		for _, field := range []struct {
+			name string
+			ids  []string
+		}{{"blocked_by", s.BlockedBy}, {"related_to", s.RelatedTo}} {
+			for _, ref := range field.ids {
+				if j, ok := refs[ref]; ok {
+					if j == i {
+						return fmt.Errorf("%s: %s references itself", s.label(i), field.name)
+					}
+					if field.name == "blocked_by" {
+						blockers[i] = append(blockers[i], j)
+					}
+					continue
+				}
+				if !exists(ref) {
+					return fmt.Errorf("%s: %s %q is neither a ref in this request nor an existing task", s.label(i), field.name, ref)
+				}
+			}
+		}
+	}
+
+	if cycle := bulkCycle(blockers); cycle != nil {
+		names := make([]string, len(cycle))
+		for k, i := range cycle {
+			names[k] = specs[i].Ref
+		}
+		return fmt.Errorf("dependency cycle: %s", strings.Join(names, " -> "))
+	}
+	return nil
+}
+
+// bulkCycle returns the spec indices of a blocked_by cycle, first index
+// repeated at the end, or nil. Existing tasks cannot be blocked by tasks
+// that do not exist yet, so only edges between specs can form a cycle.
+func bulkCycle(blockers [][]int) []int {
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := make([]int, len(blockers))
+	var path []int
+	var visit func(i int) []int
+	visit = func(i int) []int {
+		state[i] = onPath
+		path = append(path, i)
+		for _, j := range blockers[i] {
+			switch state[j] {
+			case onPath:
+				for k, p := range path {
+					if p == j {
+						return append(append([]int(nil), path[k:]...), j)
+					}
+				}
+			case unvisited:
+				if cycle := visit(j); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = done
+		return nil
+	}
+	for i := range blockers {
+		if state[i] == unvisited {
+			if cycle := visit(i); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// BuildBulkTasks allocates an ID for every spec with nextID, in order, and
+// returns the tasks with refs replaced by the allocated IDs. Specs must
+// have passed ValidateBulkTasks.
+func BuildBulkTasks(specs []BulkTaskSpec, nextID func() (string, error)) ([]*orcv1.Task, error) {
+	ids := make(map[string]string, len(specs))
+	tasks := make([]*orcv1.Task, len(specs))
+	for i, s := range specs {
+		id, err := nextID()
+		if err != nil {
+			return nil, fmt.Errorf("generate task ID: %w", err)
+		}
+		if s.Ref != "" {
+			ids[s.Ref] = id
+		}
+		tasks[i] = NewProtoTask(id, strings.TrimSpace(s.Title))
+	}
+
+	resolve := func(refs []string) []string {
+		if len(refs) == 0 {
+			return nil
+		}
+		out := make([]string, len(refs))
+		for k, ref := range refs {
+			if id, ok := ids[ref]; ok {
+				out[k] = id
+			} else {
+				out[k] = ref
+			}
+		}
+		return out
+	}
+
+	for i, s := range specs {
+		t := tasks[i]
+		if s.Description != "" {
+			desc := s.Description
+			t.Description = &desc
+		}
+		if s.Workflow != "" {
+			wf := s.Workflow
+			t.WorkflowId = &wf
+		}
+		if s.Category != "" {
+			t.Category, _ = ParseCategoryProto(s.Category)
+		}
+		if s.Priority != "" {
+			t.Priority, _ = ParsePriorityProto(s.Priority)
+		}
+		if s.Queue != "" {
+			t.Queue = QueueToProto(s.Queue)
+		}
+		if s.InitiativeID != "" {
+			SetInitiativeProto(t, s.InitiativeID)
+		}
+		if s.TargetBranch != "" {
+			SetTargetBranchProto(t, s.TargetBranch)
+		}
+		t.BlockedBy = resolve(s.BlockedBy)
+		t.RelatedTo = resolve(s.RelatedTo)
+	}
+	return tasks, nil
+}
@@ -0,0 +1,96 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidateBulkTasks(t *testing.T) {
+	existing := func(id string) bool { return id == "TASK-001" }
+	tests := []struct {
+		name    string
+		specs   []BulkTaskSpec
+		wantErr string
+	}{
+		{"empty", nil, "no tasks"},
+		{"missing title", []BulkTaskSpec{{Ref: "a"}}, "task 1 (a): title is required"},
+		{"duplicate ref", []BulkTaskSpec{{Ref: "a", Title: "A"}, {Ref: "a", Title: "B"}}, `ref "a" is already used by task 1`},
+		{"ref shadows task", []BulkTaskSpec{{Ref: "TASK-001", Title: "A"}}, "existing task ID"},
+		{"bad category", []BulkTaskSpec{{Title: "A", Category: "epic"}}, "invalid category"},
+		{"bad priority", []BulkTaskSpec{{Title: "A", Priority: "urgent"}}, "invalid priority"},
+		{"bad queue", []BulkTaskSpec{{Title: "A", Queue: "later"}}, "invalid queue"},
+		{"unknown ref", []BulkTaskSpec{{Title: "A", BlockedBy: []string{"b"}}}, `blocked_by "b" is neither`},
+		{"unknown related", []BulkTaskSpec{{Title: "A", RelatedTo: []string{"TASK-999"}}}, `related_to "TASK-999"`},
+		{"self", []BulkTaskSpec{{Ref: "a", Title: "A", BlockedBy: []string{"a"}}}, "references itself"},
+		{"cycle", []BulkTaskSpec{
+			{Ref: "a", Title: "A", BlockedBy: []string{"c"}},
+			{Ref: "b", Title: "B", BlockedBy: []string{"a"}},
+			{Ref: "c", Title: "C", BlockedBy: []string{"b", "TASK-001"}},
+		}, "dependency cycle: a -> c -> b -> a"},
+		{"valid", []BulkTaskSpec{
+			{Ref: "a", Title: "A", Category: "feature", Priority: "high", Queue: "backlog"},
+			{Ref: "b", Title: "B", BlockedBy: []string{"a", "TASK-001"}},
+			{Title: "C", BlockedBy: []string{"a", "b"}, RelatedTo: []string{"a"}},
+		}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBulkTasks(tt.specs, existing)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateBulkTasks_Limit(t *testing.T) {
+	specs := make([]BulkTaskSpec, MaxBulkTasks+1)
+	for i := range specs {
+		specs[i].Title = fmt.Sprintf("Task %d", i)
+	}
+	if err := ValidateBulkTasks(specs, func(string) bool { return false }); err == nil {
+		t.Error("expected error above MaxBulkTasks")
+	}
+}
+
+func TestBuildBulkTasks(t *testing.T) {
+	n := 40
+	nextID := func() (string, error) {
+		n++
+		return fmt.Sprintf("TASK-%03d", n), nil
+	}
+	specs := []BulkTaskSpec{
+		{Ref: "schema", Title: " Add table ", Category: "feature", Priority: "high", Workflow: "implement-small"},
+		{Ref: "api", Title: "Endpoints", BlockedBy: []string{"schema", "TASK-001"}, RelatedTo: []string{"ui"}},
+		{Ref: "ui", Title: "Page", BlockedBy: []string{"api"}, InitiativeID: "INIT-001", TargetBranch: "release/1.2"},
+	}
+	tasks, err := BuildBulkTasks(specs, nextID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := []string{tasks[0].Id, tasks[1].Id, tasks[2].Id}; strings.Join(got, ",") != "TASK-041,TASK-042,TASK-043" {
+		t.Fatalf("ids = %v", got)
+	}
+	if tasks[0].Title != "Add table" || GetWorkflowIDProto(tasks[0]) != "implement-small" {
+		t.Errorf("task 0 = %q / %q", tasks[0].Title, GetWorkflowIDProto(tasks[0]))
+	}
+	if got := strings.Join(tasks[1].BlockedBy, ","); got != "TASK-041,TASK-001" {
+		t.Errorf("api blocked_by = %s", got)
+	}
+	if got := strings.Join(tasks[1].RelatedTo, ","); got != "TASK-043" {
+		t.Errorf("api related_to = %s", got)
+	}
+	if got := strings.Join(tasks[2].BlockedBy, ","); got != "TASK-042" {
+		t.Errorf("ui blocked_by = %s", got)
+	}
+	if GetInitiativeIDProto(tasks[2]) != "INIT-001" || GetTargetBranchProto(tasks[2]) != "release/1.2" {
+		t.Errorf("ui initiative/target = %q/%q", GetInitiativeIDProto(tasks[2]), GetTargetBranchProto(tasks[2]))
+	}
+}
@@ -138,7 +138,7 @@ func CategoryToProto(c string) orcv1.TaskCategory {
 	switch c {
 	case "feature":
 		return orcv1.TaskCategory_TASK_CATEGORY_FEATURE
-	case "bug":
+	case "bug", "bugfix":
 		return orcv1.TaskCategory_TASK_CATEGORY_BUG
 	case "refactor":
 		return orcv1.TaskCategory_TASK_CATEGORY_REFACTOR
@@ -311,7 +311,7 @@ func ParsePriorityProto(s string) (orcv1.TaskPriority, bool) {
 // ParseCategoryProto parses a category string and returns the proto enum with validity.
 func ParseCategoryProto(s string) (orcv1.TaskCategory, bool) {
 	switch s {
-	case "feature", "bug", "refactor", "chore", "docs", "test":
+	case "feature", "bug", "bugfix", "refactor", "chore", "docs", "test":
 		return CategoryToProto(s), true
 	default:
 		return orcv1.TaskCategory_TASK_CATEGORY_UNSPECIFIED, false
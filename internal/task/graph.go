@@ -0,0 +1,219 @@
+package task
+
+import (
+	"sort"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+)
+
+// GraphNode is one task in a DependencyGraph.
+type GraphNode struct {
+	ID           string `json:"id"`
+	Title        string `json:"title"`
+	Status       string `json:"status"`
+	Priority     string `json:"priority"`
+	Category     string `json:"category"`
+	WorkflowID   string `json:"workflow_id,omitempty"`
+	InitiativeID string `json:"initiative_id,omitempty"`
+	Done         bool   `json:"done"`
+	// Ready is true for unfinished tasks whose blockers are all done.
+	Ready bool `json:"ready"`
+	// UnmetBlockers lists blockers that are not done, including ones outside
+	// the graph and ones that do not exist.
+	UnmetBlockers []string `json:"unmet_blockers,omitempty"`
+	// Depth is the length of the longest blocked_by chain leading to the task
+	// within the graph; 0 for tasks with no blockers in it. Usable as a
+	// Gantt/layer column.
+	Depth        int  `json:"depth"`
+	CriticalPath bool `json:"critical_path"`
+	// InCycle marks tasks on a blocked_by cycle. They have no depth and are
+	// never on the critical path.
+	InCycle bool `json:"in_cycle,omitempty"`
+}
+
+// GraphEdge is a blocked_by relationship: From must finish before To.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"`
+	// Satisfied is true once From is done.
+	Satisfied bool `json:"satisfied"`
+}
+
+// DependencyGraph is the blocked_by graph of a set of tasks.
+type DependencyGraph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+	// CriticalPath is the longest chain of unfinished tasks, first blocker
+	// first. Its length is the minimum number of sequential tasks still
+	// needed to finish everything in the graph.
+	CriticalPath []string `json:"critical_path"`
+	// Cycles lists each set of tasks that block each other.
+	Cycles [][]string `json:"cycles,omitempty"`
+}
+
+// BuildDependencyGraph returns the blocked_by graph of the tasks for which
+// include returns true (all tasks when include is nil). Edges only join
+// included tasks; readiness and unmet blockers are judged against all tasks.
+// Nodes are ordered by depth, then ID.
+func BuildDependencyGraph(all []*orcv1.Task, include func(*orcv1.Task) bool) *DependencyGraph {
+	byID := make(map[string]*orcv1.Task, len(all))
+	for _, t := range all {
+		byID[t.Id] = t
+	}
+
+	var tasks []*orcv1.Task
+	index := make(map[string]int)
+	for _, t := range all {
+		if include == nil || include(t) {
+			index[t.Id] = len(tasks)
+			tasks = append(tasks, t)
+		}
+	}
+
+	g := &DependencyGraph{
+		Nodes:        make([]GraphNode, len(tasks)),
+		Edges:        []GraphEdge{},
+		CriticalPath: []string{},
+	}
+	blockers := make([][]int, len(tasks))
+	for i, t := range tasks {
+		unmet := GetUnmetDependenciesProto(t, byID)
+		g.Nodes[i] = GraphNode{
+			ID:            t.Id,
+			Title:         t.Title,
+			Status:        StatusFromProto(t.Status),
+			Priority:      PriorityFromProto(GetPriorityProto(t)),
+			Category:      CategoryFromProto(GetCategoryProto(t)),
+			WorkflowID:    GetWorkflowIDProto(t),
+			InitiativeID:  GetInitiativeIDProto(t),
+			Done:          IsDoneProto(t.Status),
+			UnmetBlockers: unmet,
+		}
+		g.Nodes[i].Ready = !g.Nodes[i].Done && len(unmet) == 0
+		for _, b := range t.BlockedBy {
+			j, ok := index[b]
+			if !ok {
+				continue
+			}
+			blockers[i] = append(blockers[i], j)
+			g.Edges = append(g.Edges, GraphEdge{From: b, To: t.Id, Type: "blocks", Satisfied: g.Nodes[j].Done})
+		}
+	}
+
+	order, cyclic := graphOrder(blockers)
+	for _, scc := range cyclic {
+		ids := make([]string, len(scc))
+		for k, i := range scc {
+			g.Nodes[i].InCycle = true
+			ids[k] = g.Nodes[i].ID
+		}
+		sort.Strings(ids)
+		g.Cycles = append(g.Cycles, ids)
+	}
+
+	// Longest chains, visiting blockers before the tasks they block. remaining
+	// counts unfinished tasks only: finished ones no longer delay anything.
+	remaining := make([]int, len(tasks))
+	prev := make([]int, len(tasks))
+	best := -1
+	for _, i := range order {
+		prev[i] = -1
+		for _, j := range blockers[i] {
+			if g.Nodes[j].InCycle {
+				continue
+			}
+			if d := g.Nodes[j].Depth + 1; d > g.Nodes[i].Depth {
+				g.Nodes[i].Depth = d
+			}
+			if prev[i] < 0 || remaining[j] > remaining[prev[i]] {
+				prev[i] = j
+			}
+		}
+		if prev[i] >= 0 {
+			remaining[i] = remaining[prev[i]]
+		}
+		if !g.Nodes[i].Done {
+			remaining[i]++
+		}
+		if remaining[i] > 0 && (best < 0 || remaining[i] > remaining[best]) {
+			best = i
+		}
+	}
+	for i := best; i >= 0; i = prev[i] {
+		if g.Nodes[i].Done {
+			continue
+		}
+		g.Nodes[i].CriticalPath = true
+		g.CriticalPath = append([]string{g.Nodes[i].ID}, g.CriticalPath...)
+	}
+
+	sort.SliceStable(g.Nodes, func(a, b int) bool {
+		if g.Nodes[a].Depth != g.Nodes[b].Depth {
+			return g.Nodes[a].Depth < g.Nodes[b].Depth
+		}
+		return g.Nodes[a].ID < g.Nodes[b].ID
+	})
+	return g
+}
+
+// graphOrder returns the nodes that are not on a cycle in topological order
+// (blockers first) and the strongly connected components that form cycles.
+// Nodes downstream of a cycle are still ordered; their cyclic blockers are
+// skipped by the caller.
+func graphOrder(blockers [][]int) (order []int, cycles [][]int) {
+	n := len(blockers)
+	// Tarjan's algorithm emits components with every blocker's component
+	// before the components it blocks.
+	idx := make([]int, n)
+	low := make([]int, n)
+	onStack := make([]bool, n)
+	for i := range idx {
+		idx[i] = -1
+	}
+	var stack []int
+	next := 0
+	var visit func(v int)
+	visit = func(v int) {
+		idx[v], low[v] = next, next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+		selfLoop := false
+		for _, w := range blockers[v] {
+			switch {
+			case w == v:
+				selfLoop = true
+			case idx[w] < 0:
+				visit(w)
+				low[v] = min(low[v], low[w])
+			case onStack[w]:
+				low[v] = min(low[v], idx[w])
+			}
+		}
+		if low[v] != idx[v] {
+			return
+		}
+		var scc []int
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			scc = append(scc, w)
+			if w == v {
+				break
+			}
+		}
+		if len(scc) > 1 || selfLoop {
+			cycles = append(cycles, scc)
+		} else {
+			order = append(order, v)
+		}
+	}
+	for i := 0; i < n; i++ {
+		if idx[i] < 0 {
+			visit(i)
+		}
+	}
+	return order, cycles
+}
@@ -0,0 +1,123 @@
+package task
+
+import (
+	"reflect"
+	"testing"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+)
+
+func graphTask(id string, status orcv1.TaskStatus, blockedBy ...string) *orcv1.Task {
+	t := NewProtoTask(id, "Task "+id)
+	t.Status = status
+	t.BlockedBy = blockedBy
+	return t
+}
+
+func graphNode(t *testing.T, g *DependencyGraph, id string) GraphNode {
+	t.Helper()
+	for _, n := range g.Nodes {
+		if n.ID == id {
+			return n
+		}
+	}
+	t.Fatalf("node %s missing", id)
+	return GraphNode{}
+}
+
+func TestBuildDependencyGraph(t *testing.T) {
+	const (
+		done    = orcv1.TaskStatus_TASK_STATUS_COMPLETED
+		planned = orcv1.TaskStatus_TASK_STATUS_PLANNED
+	)
+	// 001 (done) -> 002 -> 004 -> 005 is the longest unfinished chain;
+	// 003 also blocks 005 but is a shorter branch.
+	tasks := []*orcv1.Task{
+		graphTask("TASK-005", planned, "TASK-004", "TASK-003"),
+		graphTask("TASK-001", done),
+		graphTask("TASK-002", planned, "TASK-001"),
+		graphTask("TASK-003", planned),
+		graphTask("TASK-004", planned, "TASK-002", "TASK-099"),
+	}
+
+	g := BuildDependencyGraph(tasks, nil)
+
+	if want := []string{"TASK-002", "TASK-004", "TASK-005"}; !reflect.DeepEqual(g.CriticalPath, want) {
+		t.Errorf("critical path = %v, want %v", g.CriticalPath, want)
+	}
+	var order []string
+	for _, n := range g.Nodes {
+		order = append(order, n.ID)
+	}
+	if want := []string{"TASK-001", "TASK-003", "TASK-002", "TASK-004", "TASK-005"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("node order = %v, want %v", order, want)
+	}
+	if len(g.Edges) != 4 {
+		t.Errorf("edges = %+v, want 4 (TASK-099 does not exist)", g.Edges)
+	}
+
+	n2 := graphNode(t, g, "TASK-002")
+	if !n2.Ready || n2.Depth != 1 || !n2.CriticalPath {
+		t.Errorf("TASK-002 = %+v", n2)
+	}
+	n4 := graphNode(t, g, "TASK-004")
+	if n4.Ready || !reflect.DeepEqual(n4.UnmetBlockers, []string{"TASK-002", "TASK-099"}) {
+		t.Errorf("TASK-004 = %+v", n4)
+	}
+	if n1 := graphNode(t, g, "TASK-001"); !n1.Done || n1.Ready || n1.CriticalPath {
+		t.Errorf("TASK-001 = %+v", n1)
+	}
+	if n3 := graphNode(t, g, "TASK-003"); n3.CriticalPath || n3.Depth != 0 {
+		t.Errorf("TASK-003 = %+v", n3)
+	}
+	for _, e := range g.Edges {
+		if e.From == "TASK-001" && !e.Satisfied {
+			t.Errorf("edge from a done task should be satisfied: %+v", e)
+		}
+	}
+}
+
+func TestBuildDependencyGraph_FilterKeepsOutsideBlockersUnmet(t *testing.T) {
+	tasks := []*orcv1.Task{
+		graphTask("TASK-001", orcv1.TaskStatus_TASK_STATUS_PLANNED),
+		graphTask("TASK-002", orcv1.TaskStatus_TASK_STATUS_PLANNED, "TASK-001"),
+	}
+	g := BuildDependencyGraph(tasks, func(t *orcv1.Task) bool { return t.Id == "TASK-002" })
+
+	if len(g.Nodes) != 1 || len(g.Edges) != 0 {
+		t.Fatalf("graph = %+v", g)
+	}
+	if n := g.Nodes[0]; n.Ready || n.Depth != 0 || !reflect.DeepEqual(n.UnmetBlockers, []string{"TASK-001"}) {
+		t.Errorf("TASK-002 = %+v", n)
+	}
+}
+
+func TestBuildDependencyGraph_Cycle(t *testing.T) {
+	planned := orcv1.TaskStatus_TASK_STATUS_PLANNED
+	tasks := []*orcv1.Task{
+		graphTask("TASK-001", planned, "TASK-002"),
+		graphTask("TASK-002", planned, "TASK-001"),
+		graphTask("TASK-003", planned, "TASK-002"),
+		graphTask("TASK-004", planned),
+	}
+	g := BuildDependencyGraph(tasks, nil)
+
+	if want := [][]string{{"TASK-001", "TASK-002"}}; !reflect.DeepEqual(g.Cycles, want) {
+		t.Errorf("cycles = %v, want %v", g.Cycles, want)
+	}
+	if !graphNode(t, g, "TASK-001").InCycle || graphNode(t, g, "TASK-003").InCycle {
+		t.Error("only TASK-001 and TASK-002 are on the cycle")
+	}
+	for _, id := range g.CriticalPath {
+		if id == "TASK-001" || id == "TASK-002" {
+			t.Errorf("critical path %v includes a cyclic task", g.CriticalPath)
+		}
+	}
+}
+
+func TestBuildDependencyGraph_Empty(t *testing.T) {
+	g := BuildDependencyGraph(nil, nil)
+	if g.Nodes == nil || g.Edges == nil || g.CriticalPath == nil {
+		t.Errorf("empty graph should have empty, non-nil slices: %+v", g)
+	}
+}
@@ -0,0 +1,80 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+)
+
+// PhaseModelsMetadataKey is the task metadata key holding per-phase model
+// overrides as a JSON object of phase ID to model, e.g.
+// {"spec":"opus","implement":"sonnet"}. A model may name its provider
+// ("codex:gpt-5.3-codex"). Create and update requests set it through their
+// metadata.
+const PhaseModelsMetadataKey = "phase_models"
+
+// ParsePhaseModels parses a phase_models JSON object. An empty string means
+// no overrides.
+func ParsePhaseModels(s string) (map[string]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var models map[string]string
+	if err := json.Unmarshal([]byte(s), &models); err != nil {
+		return nil, fmt.Errorf("invalid phase models: must be a JSON object of phase ID to model: %w", err)
+	}
+	for phase, model := range models {
+		if strings.TrimSpace(phase) == "" || strings.TrimSpace(model) == "" {
+			return nil, fmt.Errorf("invalid phase models: phase %q has an empty phase ID or model", phase)
+		}
+	}
+	return models, nil
+}
+
+// ParsePhaseModelFlags parses phase=model pairs, as given to
+// `orc new --phase-model`.
+func ParsePhaseModelFlags(specs []string) (map[string]string, error) {
+	models := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		phase, model, ok := strings.Cut(spec, "=")
+		phase, model = strings.TrimSpace(phase), strings.TrimSpace(model)
+		if !ok || phase == "" || model == "" {
+			return nil, fmt.Errorf("invalid phase model %q (expected phase=model, e.g. spec=opus)", spec)
+		}
+		models[phase] = model
+	}
+	return models, nil
+}
+
+// GetPhaseModels returns the task's per-phase model overrides, or nil when it
+// has none (or the stored value does not parse).
+func GetPhaseModels(t *orcv1.Task) map[string]string {
+	if t == nil {
+		return nil
+	}
+	models, err := ParsePhaseModels(t.Metadata[PhaseModelsMetadataKey])
+	if err != nil {
+		return nil
+	}
+	return models
+}
+
+// SetPhaseModels sets the task's per-phase model overrides. An empty map
+// removes them.
+func SetPhaseModels(t *orcv1.Task, models map[string]string) {
+	if t == nil {
+		return
+	}
+	if len(models) == 0 {
+		delete(t.Metadata, PhaseModelsMetadataKey)
+		return
+	}
+	data, err := json.Marshal(models)
+	if err != nil {
+		return
+	}
+	EnsureMetadataProto(t)
+	t.Metadata[PhaseModelsMetadataKey] = string(data)
+}
@@ -0,0 +1,40 @@
+package task
+
+import "testing"
+
+func TestParsePhaseModels(t *testing.T) {
+	models, err := ParsePhaseModels(`{"spec":"opus","implement":"codex:gpt-5.3-codex"}`)
+	if err != nil || models["spec"] != "opus" || models["implement"] != "codex:gpt-5.3-codex" {
+		t.Errorf("ParsePhaseModels = %v, %v", models, err)
+	}
+	if models, err := ParsePhaseModels(""); err != nil || models != nil {
+		t.Errorf("empty = %v, %v; want no overrides", models, err)
+	}
+	for _, bad := range []string{`["opus"]`, `{"spec":""}`, `spec=opus`} {
+		if _, err := ParsePhaseModels(bad); err == nil {
+			t.Errorf("ParsePhaseModels(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestParsePhaseModelFlags(t *testing.T) {
+	models, err := ParsePhaseModelFlags([]string{"spec=opus", "docs = haiku"})
+	if err != nil || len(models) != 2 || models["docs"] != "haiku" {
+		t.Errorf("ParsePhaseModelFlags = %v, %v", models, err)
+	}
+	if _, err := ParsePhaseModelFlags([]string{"spec:opus"}); err == nil {
+		t.Error("phase:model accepted, want phase=model")
+	}
+}
+
+func TestSetPhaseModels(t *testing.T) {
+	tk := NewProtoTask("TASK-001", "Models")
+	SetPhaseModels(tk, map[string]string{"spec": "opus"})
+	if got := GetPhaseModels(tk); got["spec"] != "opus" {
+		t.Errorf("GetPhaseModels = %v, metadata = %v", got, tk.Metadata)
+	}
+	SetPhaseModels(tk, nil)
+	if _, ok := tk.Metadata[PhaseModelsMetadataKey]; ok {
+		t.Errorf("phase models not removed: %v", tk.Metadata)
+	}
+}
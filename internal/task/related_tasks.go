@@ -0,0 +1,69 @@
+package task
+
+import (
+	"strings"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+)
+
+const (
+	// ChangedFilesMetadataKey holds the comma-separated files a completed
+	// task changed relative to its target branch.
+	ChangedFilesMetadataKey = "changed_files"
+
+	// OutcomeSummaryMetadataKey holds the implement phase's summary of the
+	// work done.
+	OutcomeSummaryMetadataKey = "outcome_summary"
+
+	// RelatedTasksMetadataKey set to "off" keeps related-task context out of
+	// the task's prompts.
+	RelatedTasksMetadataKey = "related_tasks"
+)
+
+// GetChangedFiles returns the files recorded in the task's changed_files
+// metadata.
+func GetChangedFiles(t *orcv1.Task) []string {
+	if t == nil || t.Metadata == nil {
+		return nil
+	}
+	var files []string
+	for f := range strings.SplitSeq(t.Metadata[ChangedFilesMetadataKey], ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// SetChangedFiles records the files a task changed.
+func SetChangedFiles(t *orcv1.Task, files []string) {
+	if t == nil {
+		return
+	}
+	if len(files) == 0 {
+		delete(t.Metadata, ChangedFilesMetadataKey)
+		return
+	}
+	EnsureMetadataProto(t)
+	t.Metadata[ChangedFilesMetadataKey] = strings.Join(files, ",")
+}
+
+// RelatedTasksDisabled reports whether the task opted out of related-task
+// context.
+func RelatedTasksDisabled(t *orcv1.Task) bool {
+	return t != nil && strings.EqualFold(t.Metadata[RelatedTasksMetadataKey], "off")
+}
+
+// SetRelatedTasksDisabled opts the task out of related-task context, or back
+// in.
+func SetRelatedTasksDisabled(t *orcv1.Task, disabled bool) {
+	if t == nil {
+		return
+	}
+	if !disabled {
+		delete(t.Metadata, RelatedTasksMetadataKey)
+		return
+	}
+	EnsureMetadataProto(t)
+	t.Metadata[RelatedTasksMetadataKey] = "off"
+}
@@ -0,0 +1,33 @@
+package task
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSetChangedFiles(t *testing.T) {
+	tk := NewProtoTask("TASK-001", "Changes")
+	SetChangedFiles(tk, []string{"internal/a.go", "web/b.ts"})
+	if got := GetChangedFiles(tk); !slices.Equal(got, []string{"internal/a.go", "web/b.ts"}) {
+		t.Errorf("GetChangedFiles() = %v", got)
+	}
+	SetChangedFiles(tk, nil)
+	if _, ok := tk.Metadata[ChangedFilesMetadataKey]; ok {
+		t.Errorf("changed files not removed: %v", tk.Metadata)
+	}
+}
+
+func TestSetRelatedTasksDisabled(t *testing.T) {
+	tk := NewProtoTask("TASK-001", "Opt out")
+	if RelatedTasksDisabled(tk) {
+		t.Fatal("new task has related tasks disabled")
+	}
+	SetRelatedTasksDisabled(tk, true)
+	if !RelatedTasksDisabled(tk) || tk.Metadata[RelatedTasksMetadataKey] != "off" {
+		t.Errorf("metadata = %v", tk.Metadata)
+	}
+	SetRelatedTasksDisabled(tk, false)
+	if RelatedTasksDisabled(tk) {
+		t.Errorf("related tasks still disabled: %v", tk.Metadata)
+	}
+}
@@ -5,6 +5,7 @@ package task
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"regexp"
 	"strings"
 	"time"
@@ -115,6 +116,71 @@ func ValidateSpec(content string, workflowID string) *SpecValidation {
 	return result
 }
 
+// SpecCriterion is one success criterion of a spec.
+type SpecCriterion struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+var (
+	criterionIDPattern   = regexp.MustCompile(`^[A-Z]+-\d+$`)
+	criterionItemPattern = regexp.MustCompile(`^(?:[-*+]|\d+[.)])\s+(?:\[[ xX]\]\s+)?(.+)$`)
+	criterionLeadPattern = regexp.MustCompile(`^\**([A-Z]+-\d+)\**\s*[:.)-]?\s*(.+)$`)
+	tableSeparatorRow    = regexp.MustCompile(`^\|[\s:|-]+\|$`)
+)
+
+// SuccessCriteria extracts the items of the spec's Success Criteria section,
+// written as a table with an ID column (| SC-1 | criterion | ... |) or as a
+// list. Items without an ID are numbered SC-1, SC-2, ... in order.
+func SuccessCriteria(content string) []SpecCriterion {
+	section := extractSection(content, "success criteria")
+	var criteria []SpecCriterion
+	add := func(id, text string) {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return
+		}
+		if id == "" {
+			id = fmt.Sprintf("SC-%d", len(criteria)+1)
+		}
+		criteria = append(criteria, SpecCriterion{ID: id, Text: text})
+	}
+
+	header := true
+	for _, line := range strings.Split(section, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "|"):
+			if tableSeparatorRow.MatchString(line) {
+				header = false
+				continue
+			}
+			if header {
+				continue
+			}
+			cells := strings.Split(strings.Trim(line, "|"), "|")
+			for i := range cells {
+				cells[i] = strings.TrimSpace(cells[i])
+			}
+			if criterionIDPattern.MatchString(cells[0]) && len(cells) > 1 {
+				add(cells[0], cells[1])
+			} else {
+				add("", cells[0])
+			}
+		case criterionItemPattern.MatchString(line):
+			item := criterionItemPattern.FindStringSubmatch(line)[1]
+			if m := criterionLeadPattern.FindStringSubmatch(item); m != nil {
+				add(m[1], m[2])
+			} else {
+				add("", item)
+			}
+		default:
+			header = true
+		}
+	}
+	return criteria
+}
+
 // hasSection checks if a markdown section with the given name exists.
 func hasSection(content, sectionName string) bool {
 	pattern := regexp.MustCompile(`(?im)^##?\s*` + regexp.QuoteMeta(sectionName))
@@ -713,3 +713,45 @@ func TestHasMeaningfulContent(t *testing.T) {
 		})
 	}
 }
+
+func TestSuccessCriteria(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []SpecCriterion
+	}{
+		{
+			name: "table",
+			content: "## Intent\n\nRate limit.\n\n## Success Criteria\n\n" +
+				"| ID | Criterion | Verification |\n|----|-----------|--------------|\n" +
+				"| SC-1 | Returns 429 past the limit | Send 6 requests |\n| SC-2 | Limit resets | Wait |\n\n## Testing\n\n- unit",
+			want: []SpecCriterion{{ID: "SC-1", Text: "Returns 429 past the limit"}, {ID: "SC-2", Text: "Limit resets"}},
+		},
+		{
+			name:    "list",
+			content: "## Success Criteria\n\n- [ ] Config key is documented\n- **SC-7**: Errors are logged\n1. Tests pass\n",
+			want: []SpecCriterion{
+				{ID: "SC-1", Text: "Config key is documented"},
+				{ID: "SC-7", Text: "Errors are logged"},
+				{ID: "SC-3", Text: "Tests pass"},
+			},
+		},
+		{
+			name:    "no section",
+			content: "## Intent\n\n- Not a criterion",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SuccessCriteria(tt.content)
+			if len(got) != len(tt.want) {
+				t.Fatalf("SuccessCriteria() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("criterion %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package task
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
@@ -51,3 +52,56 @@ func FormatHeartbeatStatus(t *orcv1.Task) string {
 	age := time.Since(t.LastHeartbeat.AsTime())
 	return fmt.Sprintf("heartbeat: healthy (last update %s ago)", age.Truncate(time.Second))
 }
+
+// StaleTask is a planned or paused task that has gone untouched for longer
+// than the stale threshold.
+type StaleTask struct {
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	Status       string    `json:"status"`
+	WorkflowID   string    `json:"workflow_id,omitempty"`
+	LastActivity time.Time `json:"last_activity"`
+	IdleFor      string    `json:"idle_for"`
+	AssignedTo   string    `json:"assigned_to,omitempty"`
+}
+
+// FindStaleTasks returns the planned or paused tasks whose last update is
+// more than after before now, longest idle first. Last activity is the task's
+// updated_at, falling back to created_at. A non-positive after finds nothing.
+func FindStaleTasks(tasks []*orcv1.Task, after time.Duration, now time.Time) []StaleTask {
+	if after <= 0 {
+		return nil
+	}
+	var stale []StaleTask
+	for _, t := range tasks {
+		if t.Status != orcv1.TaskStatus_TASK_STATUS_PLANNED && t.Status != orcv1.TaskStatus_TASK_STATUS_PAUSED {
+			continue
+		}
+		last := lastActivity(t)
+		if last.IsZero() || now.Sub(last) <= after {
+			continue
+		}
+		stale = append(stale, StaleTask{
+			ID:           t.Id,
+			Title:        t.Title,
+			Status:       StatusFromProto(t.Status),
+			WorkflowID:   t.GetWorkflowId(),
+			LastActivity: last,
+			IdleFor:      FormatDuration(now.Sub(last).Truncate(time.Hour)),
+		})
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i].LastActivity.Before(stale[j].LastActivity) })
+	return stale
+}
+
+func lastActivity(t *orcv1.Task) time.Time {
+	if t.UpdatedAt != nil {
+		if updated := t.UpdatedAt.AsTime(); !updated.IsZero() {
+			return updated
+		}
+	}
+	if t.CreatedAt != nil {
+		return t.CreatedAt.AsTime()
+	}
+	return time.Time{}
+}
@@ -314,3 +314,49 @@ func searchSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestFindStaleTasks(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	newTask := func(id string, status orcv1.TaskStatus, updated time.Duration) *orcv1.Task {
+		tk := NewProtoTask(id, "Task "+id)
+		tk.Status = status
+		tk.CreatedAt = timestamppb.New(now.Add(-30 * 24 * time.Hour))
+		tk.UpdatedAt = timestamppb.New(now.Add(-updated))
+		return tk
+	}
+	noUpdate := newTask("TASK-005", orcv1.TaskStatus_TASK_STATUS_PLANNED, 0)
+	noUpdate.UpdatedAt = nil
+
+	tasks := []*orcv1.Task{
+		newTask("TASK-001", orcv1.TaskStatus_TASK_STATUS_PLANNED, 3*24*time.Hour),
+		newTask("TASK-002", orcv1.TaskStatus_TASK_STATUS_PAUSED, 10*24*time.Hour),
+		newTask("TASK-003", orcv1.TaskStatus_TASK_STATUS_PLANNED, time.Hour),
+		newTask("TASK-004", orcv1.TaskStatus_TASK_STATUS_FAILED, 10*24*time.Hour),
+		noUpdate,
+	}
+
+	stale := FindStaleTasks(tasks, 48*time.Hour, now)
+	var ids []string
+	for _, st := range stale {
+		ids = append(ids, st.ID)
+	}
+	// Longest idle first; TASK-005 falls back to created_at.
+	want := []string{"TASK-005", "TASK-002", "TASK-001"}
+	if len(ids) != len(want) {
+		t.Fatalf("stale = %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("stale = %v, want %v", ids, want)
+		}
+	}
+	if stale[1].Status != "paused" || stale[1].IdleFor != "10d" {
+		t.Errorf("TASK-002 = %+v, want paused for 10d", stale[1])
+	}
+
+	if got := FindStaleTasks(tasks, 0, now); got != nil {
+		t.Errorf("FindStaleTasks with zero threshold = %v, want nil", got)
+	}
+}
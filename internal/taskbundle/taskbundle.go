@@ -0,0 +1,153 @@
+// Package taskbundle is the wire format of single-task bundles, written by
+// `orc task export` and read by `orc task import` and POST /api/tasks/import.
+// A bundle is either a tar.gz archive in the export archive layout or one
+// JSON document; this package detects the form and encodes the JSON one.
+package taskbundle
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+)
+
+// gzipMagic starts a tar.gz bundle; JSON bundles start with '{'.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// IsArchive reports whether the bundle read by r is the tar.gz form. It
+// peeks without consuming input.
+func IsArchive(r *bufio.Reader) bool {
+	magic, _ := r.Peek(len(gzipMagic))
+	return bytes.Equal(magic, gzipMagic)
+}
+
+// Task messages in JSON bundles use protojson, which round-trips enums,
+// optional fields, and timestamps that encoding/json does not.
+var (
+	taskMarshaler   = protojson.MarshalOptions{UseProtoNames: true}
+	taskUnmarshaler = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// TaskExport is implemented by a pointer to the task export type E of a
+// Bundle, so the task message, held in its "task" JSON field, can be
+// encoded with protojson.
+type TaskExport interface {
+	TaskMessage() *orcv1.Task
+	SetTaskMessage(t *orcv1.Task)
+}
+
+// Bundle is a single-task bundle: the manifest, the task export, and the
+// task's workflow runs. The API and the CLI each keep their own export
+// types, so they are type parameters; *E must implement TaskExport.
+type Bundle[M, E, R any] struct {
+	Manifest M   `json:"manifest"`
+	Task     *E  `json:"task"`
+	Runs     []R `json:"workflow_runs,omitempty"`
+}
+
+// bundleJSON is the JSON form of a Bundle, with the task export already
+// encoded by marshalExport.
+type bundleJSON[M, R any] struct {
+	Manifest M               `json:"manifest"`
+	Task     json.RawMessage `json:"task"`
+	Runs     []R             `json:"workflow_runs,omitempty"`
+}
+
+// MarshalJSON encodes the bundle with its task message in protojson.
+func (b Bundle[M, E, R]) MarshalJSON() ([]byte, error) {
+	task, err := marshalExport(b.Task)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(bundleJSON[M, R]{Manifest: b.Manifest, Task: task, Runs: b.Runs})
+}
+
+// UnmarshalJSON decodes a bundle written by MarshalJSON.
+func (b *Bundle[M, E, R]) UnmarshalJSON(data []byte) error {
+	var wire bundleJSON[M, R]
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	task, err := unmarshalExport[E](wire.Task)
+	if err != nil {
+		return err
+	}
+	*b = Bundle[M, E, R]{Manifest: wire.Manifest, Task: task, Runs: wire.Runs}
+	return nil
+}
+
+// taskExport returns export as a TaskExport.
+func taskExport[E any](export *E) (TaskExport, error) {
+	te, ok := any(export).(TaskExport)
+	if !ok {
+		return nil, fmt.Errorf("task bundle: %T does not implement TaskExport", export)
+	}
+	return te, nil
+}
+
+// marshalExport encodes a task export with encoding/json, except for the
+// task message itself, which is encoded with protojson.
+func marshalExport[E any](export *E) (json.RawMessage, error) {
+	if export == nil {
+		return json.RawMessage("null"), nil
+	}
+	rest := *export
+	restTE, err := taskExport(&rest)
+	if err != nil {
+		return nil, err
+	}
+	task := restTE.TaskMessage()
+	restTE.SetTaskMessage(nil)
+
+	data, err := json.Marshal(rest)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if task != nil {
+		if fields["task"], err = taskMarshaler.Marshal(task); err != nil {
+			return nil, fmt.Errorf("marshal task %s: %w", task.Id, err)
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// unmarshalExport decodes a task export written by marshalExport.
+func unmarshalExport[E any](data json.RawMessage) (*E, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	taskJSON := fields["task"]
+	delete(fields, "task")
+	rest, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	export := new(E)
+	if err := json.Unmarshal(rest, export); err != nil {
+		return nil, err
+	}
+	if len(taskJSON) > 0 && string(taskJSON) != "null" {
+		te, err := taskExport(export)
+		if err != nil {
+			return nil, err
+		}
+		task := &orcv1.Task{}
+		if err := taskUnmarshaler.Unmarshal(taskJSON, task); err != nil {
+			return nil, fmt.Errorf("parse task: %w", err)
+		}
+		te.SetTaskMessage(task)
+	}
+	return export, nil
+}
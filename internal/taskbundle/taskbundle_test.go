@@ -0,0 +1,106 @@
+package taskbundle
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+)
+
+type testManifest struct {
+	Version int `json:"version"`
+}
+
+type testExport struct {
+	Task  *orcv1.Task `json:"task"`
+	Notes []string    `json:"notes,omitempty"`
+}
+
+func (e *testExport) TaskMessage() *orcv1.Task     { return e.Task }
+func (e *testExport) SetTaskMessage(t *orcv1.Task) { e.Task = t }
+
+type testRun struct {
+	ID string `json:"id"`
+}
+
+func TestBundle_JSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	bundle := Bundle[testManifest, testExport, testRun]{
+		Manifest: testManifest{Version: 3},
+		Task: &testExport{
+			Task:  &orcv1.Task{Id: "TASK-001", Status: orcv1.TaskStatus_TASK_STATUS_RUNNING},
+			Notes: []string{"kept"},
+		},
+		Runs: []testRun{{ID: "RUN-1"}},
+	}
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	// protojson writes enums by name; encoding/json would write a number.
+	if !strings.Contains(string(data), `"TASK_STATUS_RUNNING"`) {
+		t.Errorf("task status not encoded with protojson: %s", data)
+	}
+
+	var got Bundle[testManifest, testExport, testRun]
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.Manifest.Version != 3 {
+		t.Errorf("manifest version = %d, want 3", got.Manifest.Version)
+	}
+	if got.Task == nil || got.Task.Task == nil {
+		t.Fatal("task missing after round trip")
+	}
+	if got.Task.Task.Id != "TASK-001" || got.Task.Task.Status != orcv1.TaskStatus_TASK_STATUS_RUNNING {
+		t.Errorf("task = %v, want TASK-001 running", got.Task.Task)
+	}
+	if len(got.Task.Notes) != 1 || got.Task.Notes[0] != "kept" {
+		t.Errorf("notes = %v, want [kept]", got.Task.Notes)
+	}
+	if len(got.Runs) != 1 || got.Runs[0].ID != "RUN-1" {
+		t.Errorf("runs = %v, want [RUN-1]", got.Runs)
+	}
+	// The caller's export is left untouched.
+	if bundle.Task.Task == nil {
+		t.Error("marshal cleared the caller's task")
+	}
+}
+
+func TestIsArchive(t *testing.T) {
+	t.Parallel()
+
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  bool
+	}{
+		{"tar.gz", archive.Bytes(), true},
+		{"json", []byte(`{"manifest":{}}`), false},
+		{"empty", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := bufio.NewReader(bytes.NewReader(tt.input))
+			if got := IsArchive(r); got != tt.want {
+				t.Errorf("IsArchive() = %v, want %v", got, tt.want)
+			}
+			if rest, _ := io.ReadAll(r); !bytes.Equal(rest, tt.input) {
+				t.Error("IsArchive consumed input")
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package telemetry
+
+import (
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+	"github.com/randalmurphal/orc/internal/task"
+	"github.com/randalmurphal/orc/internal/workflow"
+)
+
+// SchemaVersion is bumped whenever the report fields change.
+const SchemaVersion = 1
+
+// customName replaces user-defined workflow and phase names, which could
+// identify a project.
+const customName = "custom"
+
+// Report is one anonymous usage report. Every field is listed here; nothing
+// else is sent.
+type Report struct {
+	SchemaVersion int       `json:"schema_version"`
+	InstallID     string    `json:"install_id"`
+	OrcVersion    string    `json:"orc_version"`
+	OS            string    `json:"os"`
+	Arch          string    `json:"arch"`
+	PeriodStart   time.Time `json:"period_start"`
+	PeriodEnd     time.Time `json:"period_end"`
+	Projects      int       `json:"projects"`
+
+	Tasks TaskStats `json:"tasks"`
+	// Phases is keyed by built-in phase template ID, or "custom".
+	Phases map[string]*PhaseStats `json:"phases"`
+	// Errors counts failed phases by category (see ErrorCategory).
+	Errors map[string]int `json:"errors"`
+}
+
+// TaskStats counts tasks created and finished during the period.
+type TaskStats struct {
+	Created   int `json:"created"`
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+	// ByCategory and ByWorkflow count created tasks; workflows are built-in
+	// IDs or "custom".
+	ByCategory map[string]int `json:"by_category"`
+	ByWorkflow map[string]int `json:"by_workflow"`
+}
+
+// PhaseStats aggregates the phase runs of one phase template.
+type PhaseStats struct {
+	Runs         int     `json:"runs"`
+	Failed       int     `json:"failed"`
+	TotalSeconds float64 `json:"total_seconds"`
+	MaxSeconds   float64 `json:"max_seconds"`
+}
+
+// NewReport returns an empty report for the period.
+func NewReport(installID, version string, start, end time.Time) *Report {
+	return &Report{
+		SchemaVersion: SchemaVersion,
+		InstallID:     installID,
+		OrcVersion:    version,
+		OS:            runtime.GOOS,
+		Arch:          runtime.GOARCH,
+		PeriodStart:   start,
+		PeriodEnd:     end,
+		Tasks:         TaskStats{ByCategory: map[string]int{}, ByWorkflow: map[string]int{}},
+		Phases:        map[string]*PhaseStats{},
+		Errors:        map[string]int{},
+	}
+}
+
+// Add aggregates one project's tasks and workflow runs in the period.
+func (r *Report) Add(backend storage.Backend) error {
+	builtinWorkflows := workflow.ListBuiltinWorkflowIDs()
+	builtinPhases := workflow.ListBuiltinPhaseIDs()
+
+	tasks, err := backend.LoadAllTasks()
+	if err != nil {
+		return err
+	}
+	r.Projects++
+	for _, t := range tasks {
+		if r.inPeriod(t.CreatedAt.AsTime()) {
+			r.Tasks.Created++
+			r.Tasks.ByCategory[task.CategoryFromProto(t.Category)]++
+			r.Tasks.ByWorkflow[anonymize(t.GetWorkflowId(), builtinWorkflows)]++
+		}
+		if t.CompletedAt == nil || !r.inPeriod(t.CompletedAt.AsTime()) {
+			continue
+		}
+		switch t.Status {
+		case orcv1.TaskStatus_TASK_STATUS_COMPLETED:
+			r.Tasks.Completed++
+		case orcv1.TaskStatus_TASK_STATUS_FAILED:
+			r.Tasks.Failed++
+		}
+	}
+
+	runs, err := backend.ListWorkflowRuns(db.WorkflowRunListOpts{})
+	if err != nil {
+		return err
+	}
+	for _, run := range runs {
+		if run.StartedAt == nil || run.StartedAt.Before(r.PeriodStart) {
+			continue
+		}
+		phases, err := backend.GetWorkflowRunPhases(run.ID)
+		if err != nil {
+			return err
+		}
+		for _, p := range phases {
+			if p.StartedAt == nil || !r.inPeriod(*p.StartedAt) {
+				continue
+			}
+			name := anonymize(p.PhaseTemplateID, builtinPhases)
+			stats := r.Phases[name]
+			if stats == nil {
+				stats = &PhaseStats{}
+				r.Phases[name] = stats
+			}
+			stats.Runs++
+			if p.CompletedAt != nil {
+				seconds := p.CompletedAt.Sub(*p.StartedAt).Seconds()
+				stats.TotalSeconds += seconds
+				stats.MaxSeconds = max(stats.MaxSeconds, seconds)
+			}
+			if p.Error != "" || p.Status == "failed" {
+				stats.Failed++
+				r.Errors[ErrorCategory(p.Error)]++
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Report) inPeriod(t time.Time) bool {
+	return !t.Before(r.PeriodStart) && t.Before(r.PeriodEnd)
+}
+
+// anonymize keeps built-in IDs and replaces user-defined ones.
+func anonymize(id string, builtin []string) string {
+	if id == "" {
+		return "none"
+	}
+	if slices.Contains(builtin, id) {
+		return id
+	}
+	return customName
+}
+
+// errorCategories map message fragments to categories, checked in order.
+var errorCategories = []struct {
+	category  string
+	fragments []string
+}{
+	{"rate_limited", []string{"rate limit", "rate_limit", "429", "usage limit"}},
+	{"timeout", []string{"timeout", "timed out", "deadline exceeded"}},
+	{"cancelled", []string{"context canceled", "interrupted", "cancelled"}},
+	{"max_retries", []string{"max retries", "max_retries", "retries exceeded"}},
+	{"gate", []string{"gate", "rejected"}},
+	{"git", []string{"git", "merge conflict", "worktree", "rebase"}},
+	{"budget", []string{"budget", "cost limit"}},
+	{"provider", []string{"claude", "codex", "provider", "api error", "overloaded"}},
+	{"validation", []string{"test", "lint", "build failed", "check failed"}},
+}
+
+// ErrorCategory buckets an error message into a fixed category. Only the
+// category is reported, never the message.
+func ErrorCategory(msg string) string {
+	if msg == "" {
+		return "unknown"
+	}
+	lower := strings.ToLower(msg)
+	for _, c := range errorCategories {
+		for _, f := range c.fragments {
+			if strings.Contains(lower, f) {
+				return c.category
+			}
+		}
+	}
+	return "other"
+}
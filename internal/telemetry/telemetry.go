@@ -0,0 +1,184 @@
+// Package telemetry reports anonymous, aggregate usage metrics to the orc
+// maintainers. It is off until the user runs `orc telemetry enable`, and
+// reports only counts and durations (see Report) — never code, prompts,
+// task titles, paths or error messages.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+// DefaultEndpoint receives reports unless ORC_TELEMETRY_ENDPOINT overrides it.
+const DefaultEndpoint = "https://telemetry.orc.dev/v1/reports"
+
+// ReportInterval is the time between reports.
+const ReportInterval = 7 * 24 * time.Hour
+
+// State is the user's telemetry choice, kept in ~/.orc/telemetry.json.
+type State struct {
+	Enabled bool `json:"enabled"`
+	// InstallID is random and regenerated on every enable, so reports can be
+	// de-duplicated but not linked to a person or to earlier opt-ins.
+	InstallID  string    `json:"install_id,omitempty"`
+	DecidedAt  time.Time `json:"decided_at,omitempty"`
+	LastSentAt time.Time `json:"last_sent_at,omitempty"`
+	// LastReport is exactly what was last sent, for `orc telemetry status`.
+	LastReport *Report `json:"last_report,omitempty"`
+}
+
+// Path returns the telemetry state file path.
+func Path() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".orc", "telemetry.json"), nil
+}
+
+// Load reads the state at path. A missing file means telemetry was never
+// enabled.
+func Load(path string) (*State, error) {
+	state := &State{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// Save writes the state to path.
+func (s *State) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// Enable opts in with a fresh install ID.
+func (s *State) Enable(now time.Time) error {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return err
+	}
+	*s = State{Enabled: true, InstallID: hex.EncodeToString(id), DecidedAt: now}
+	return nil
+}
+
+// Disable opts out and forgets the install ID and report history.
+func (s *State) Disable(now time.Time) {
+	*s = State{DecidedAt: now}
+}
+
+// EnvDisabled reports whether the environment turns telemetry off
+// regardless of the saved choice (DO_NOT_TRACK=1 or ORC_TELEMETRY=off),
+// and which variable did.
+func EnvDisabled() (string, bool) {
+	if v := os.Getenv("DO_NOT_TRACK"); v != "" && v != "0" {
+		return "DO_NOT_TRACK", true
+	}
+	switch strings.ToLower(os.Getenv("ORC_TELEMETRY")) {
+	case "0", "false", "off", "no":
+		return "ORC_TELEMETRY", true
+	}
+	return "", false
+}
+
+// Active reports whether reports may be sent.
+func (s *State) Active() bool {
+	_, off := EnvDisabled()
+	return s.Enabled && s.InstallID != "" && !off
+}
+
+// Due reports whether a report should be sent now: ReportInterval after
+// the last report, or after opting in.
+func (s *State) Due(now time.Time) bool {
+	last := s.LastSentAt
+	if last.IsZero() {
+		last = s.DecidedAt
+	}
+	return s.Active() && now.Sub(last) >= ReportInterval
+}
+
+// PeriodStart is the start of the next report's period: the last report,
+// or at most ReportInterval ago.
+func (s *State) PeriodStart(now time.Time) time.Time {
+	start := now.Add(-ReportInterval)
+	if s.LastSentAt.After(start) {
+		return s.LastSentAt
+	}
+	if s.DecidedAt.After(start) && s.LastSentAt.IsZero() {
+		// Nothing from before the user opted in
+		return s.DecidedAt
+	}
+	return start
+}
+
+// Endpoint returns where reports are sent.
+func Endpoint() string {
+	if v := os.Getenv("ORC_TELEMETRY_ENDPOINT"); v != "" {
+		return v
+	}
+	return DefaultEndpoint
+}
+
+// Send posts a report to the endpoint.
+func Send(ctx context.Context, endpoint string, report *Report) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send telemetry: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("send telemetry: %s", resp.Status)
+	}
+	return nil
+}
+
+// MarkSent records a sent report.
+func (s *State) MarkSent(report *Report) {
+	s.LastSentAt = report.PeriodEnd
+	s.LastReport = report
+}
+
+// Build aggregates the next report from the given project backends.
+func (s *State) Build(version string, backends []storage.Backend, now time.Time) (*Report, error) {
+	report := NewReport(s.InstallID, version, s.PeriodStart(now), now)
+	for _, b := range backends {
+		if err := report.Add(b); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,115 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/db"
+	"github.com/randalmurphal/orc/internal/storage"
+)
+
+func TestState_OptInLifecycle(t *testing.T) {
+	t.Setenv("DO_NOT_TRACK", "")
+	t.Setenv("ORC_TELEMETRY", "")
+	path := filepath.Join(t.TempDir(), "telemetry.json")
+	state, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	if state.Active() || state.Due(now) {
+		t.Fatal("telemetry must be off until enabled")
+	}
+
+	if err := state.Enable(now); err != nil {
+		t.Fatal(err)
+	}
+	firstID := state.InstallID
+	if !state.Active() || state.Due(now) || !state.Due(now.Add(ReportInterval)) {
+		t.Errorf("after enable: active=%v due now=%v due in a week=%v", state.Active(), state.Due(now), state.Due(now.Add(ReportInterval)))
+	}
+	t.Setenv("DO_NOT_TRACK", "1")
+	if state.Active() {
+		t.Error("DO_NOT_TRACK should win over the saved choice")
+	}
+	t.Setenv("DO_NOT_TRACK", "")
+
+	if err := state.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	state.Disable(now)
+	_ = state.Enable(now)
+	if state.InstallID == firstID {
+		t.Error("re-enabling should use a new install ID")
+	}
+}
+
+func TestReport_AggregatesWithoutIdentifyingData(t *testing.T) {
+	backend := storage.NewTestBackend(t)
+	start := time.Now().Add(-time.Hour)
+	phaseStart := start.Add(10 * time.Minute)
+	phaseEnd := phaseStart.Add(90 * time.Second)
+
+	if err := backend.SavePhaseTemplate(&db.PhaseTemplate{ID: "acme-secret-phase", Name: "Secret", PromptSource: "db"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.SaveWorkflow(&db.Workflow{ID: "acme-internal", Name: "Acme"}); err != nil {
+		t.Fatal(err)
+	}
+	wf := "acme-internal"
+	task := &orcv1.Task{
+		Id:          "TASK-001",
+		Title:       "Rotate Acme payment keys",
+		Status:      orcv1.TaskStatus_TASK_STATUS_FAILED,
+		Category:    orcv1.TaskCategory_TASK_CATEGORY_BUG,
+		WorkflowId:  &wf,
+		CreatedAt:   timestamppb.New(start.Add(time.Minute)),
+		CompletedAt: timestamppb.New(phaseEnd),
+	}
+	if err := backend.SaveTask(task); err != nil {
+		t.Fatal(err)
+	}
+	taskID := task.Id
+	if err := backend.SaveWorkflowRun(&db.WorkflowRun{ID: "RUN-001", WorkflowID: wf, ContextType: "task", TaskID: &taskID, Prompt: "secret prompt", Status: "failed", StartedAt: &phaseStart}); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []*db.WorkflowRunPhase{
+		{WorkflowRunID: "RUN-001", PhaseTemplateID: "implement", Status: "completed", StartedAt: &phaseStart, CompletedAt: &phaseEnd},
+		{WorkflowRunID: "RUN-001", PhaseTemplateID: "acme-secret-phase", Status: "failed", StartedAt: &phaseStart, CompletedAt: &phaseEnd, Error: "git push to acme.internal failed"},
+	} {
+		if err := backend.SaveWorkflowRunPhase(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	state := &State{Enabled: true, InstallID: "abc", DecidedAt: start}
+	report, err := state.Build("0.3.0", []storage.Backend{backend}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Tasks.Created != 1 || report.Tasks.Failed != 1 || report.Tasks.ByWorkflow["custom"] != 1 || report.Tasks.ByCategory["bug"] != 1 {
+		t.Errorf("tasks = %+v", report.Tasks)
+	}
+	if p := report.Phases["implement"]; p == nil || p.Runs != 1 || p.TotalSeconds != 90 {
+		t.Errorf("implement phase = %+v", p)
+	}
+	if p := report.Phases["custom"]; p == nil || p.Failed != 1 {
+		t.Errorf("custom phase = %+v", p)
+	}
+	if report.Errors["git"] != 1 {
+		t.Errorf("errors = %v", report.Errors)
+	}
+
+	data, _ := json.Marshal(report)
+	for _, leak := range []string{"acme", "Acme", "TASK-001", "secret", "push"} {
+		if strings.Contains(string(data), leak) {
+			t.Errorf("report leaks %q: %s", leak, data)
+		}
+	}
+}
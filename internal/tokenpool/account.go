@@ -34,6 +34,31 @@ type AccountState struct {
 
 	// LastError is the last error message from this account
 	LastError string `yaml:"last_error,omitempty"`
+
+	// RateLimitCount is how many times the account has hit a rate limit
+	RateLimitCount int `yaml:"rate_limit_count,omitempty"`
+
+	// LastRateLimitAt is when the account last hit a rate limit
+	LastRateLimitAt *time.Time `yaml:"last_rate_limit_at,omitempty"`
+
+	// Usage accumulates the work run on this account
+	Usage AccountUsage `yaml:"usage,omitempty"`
+}
+
+// AccountUsage is the work run on an account since it joined the pool.
+type AccountUsage struct {
+	// Phases is the number of phase executions that used the account
+	Phases int `yaml:"phases,omitempty"`
+
+	// InputTokens and OutputTokens are the tokens consumed
+	InputTokens  int64 `yaml:"input_tokens,omitempty"`
+	OutputTokens int64 `yaml:"output_tokens,omitempty"`
+
+	// CostUSD is the reported cost
+	CostUSD float64 `yaml:"cost_usd,omitempty"`
+
+	// LastUsedAt is when a phase last ran on the account
+	LastUsedAt *time.Time `yaml:"last_used_at,omitempty"`
 }
 
 // IsUsable returns true if the account can be used (enabled and not exhausted).
@@ -158,6 +158,18 @@ func (p *Pool) MarkExhausted(reason string) {
 		"reason", reason)
 }
 
+// RecordUsage adds one phase execution's tokens and cost to an account's
+// usage totals.
+func (p *Pool) RecordUsage(accountID string, inputTokens, outputTokens int64, costUSD float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.state.RecordUsage(accountID, inputTokens, outputTokens, costUSD)
+	if err := p.state.Save(); err != nil {
+		p.logger.Warn("failed to save pool state", "error", err)
+	}
+}
+
 // ResetExhausted clears exhausted flags for all accounts.
 func (p *Pool) ResetExhausted() {
 	p.mu.Lock()
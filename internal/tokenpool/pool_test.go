@@ -501,6 +501,53 @@ func TestPool_MarkExhausted(t *testing.T) {
 	}
 }
 
+func TestPool_RecordUsage(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "pool.yaml")
+
+	cfg := DefaultPoolConfig()
+	cfg.Accounts = []*Account{
+		{ID: "acc1", Enabled: true},
+	}
+	if err := cfg.Save(configPath); err != nil {
+		t.Fatalf("Save config error = %v", err)
+	}
+
+	pool, err := New(configPath)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	pool.RecordUsage("acc1", 100, 50, 0.25)
+	pool.RecordUsage("acc1", 200, 80, 0.50)
+	pool.MarkExhausted("rate limit hit")
+
+	// Usage and rate-limit counts persist across reloads
+	reloaded, err := New(configPath)
+	if err != nil {
+		t.Fatalf("New() reload error = %v", err)
+	}
+	state := reloaded.state.GetAccountState("acc1")
+	if state.Usage.Phases != 2 || state.Usage.InputTokens != 300 || state.Usage.OutputTokens != 130 {
+		t.Errorf("Usage = %+v, want 2 phases, 300 in, 130 out", state.Usage)
+	}
+	if state.Usage.CostUSD != 0.75 {
+		t.Errorf("CostUSD = %v, want 0.75", state.Usage.CostUSD)
+	}
+	if state.Usage.LastUsedAt == nil {
+		t.Error("LastUsedAt should be set")
+	}
+	if state.RateLimitCount != 1 || state.LastRateLimitAt == nil {
+		t.Errorf("RateLimitCount = %d, LastRateLimitAt = %v", state.RateLimitCount, state.LastRateLimitAt)
+	}
+
+	// Resetting exhaustion keeps the history
+	reloaded.ResetExhausted()
+	if state := reloaded.state.GetAccountState("acc1"); state.RateLimitCount != 1 || state.Usage.Phases != 2 {
+		t.Errorf("after reset: %+v", state)
+	}
+}
+
 func TestPool_ResetExhausted(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "pool.yaml")
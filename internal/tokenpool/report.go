@@ -0,0 +1,61 @@
+package tokenpool
+
+import "time"
+
+// Report is the pool's status without any tokens, as returned by /api/pool
+// and `orc pool status --json`.
+type Report struct {
+	Strategy          Strategy        `json:"strategy"`
+	SwitchOnRateLimit bool            `json:"switch_on_rate_limit"`
+	Accounts          []AccountReport `json:"accounts"`
+}
+
+// AccountReport is one account's rate-limit state and usage.
+type AccountReport struct {
+	ID              string      `json:"id"`
+	Name            string      `json:"name"`
+	Enabled         bool        `json:"enabled"`
+	Current         bool        `json:"current"`
+	Exhausted       bool        `json:"exhausted"`
+	ExhaustedAt     *time.Time  `json:"exhausted_at,omitempty"`
+	LastError       string      `json:"last_error,omitempty"`
+	RateLimitCount  int         `json:"rate_limit_count"`
+	LastRateLimitAt *time.Time  `json:"last_rate_limit_at,omitempty"`
+	Usage           UsageReport `json:"usage"`
+}
+
+// UsageReport is AccountUsage in the report's JSON schema.
+type UsageReport struct {
+	Phases       int        `json:"phases"`
+	InputTokens  int64      `json:"input_tokens"`
+	OutputTokens int64      `json:"output_tokens"`
+	CostUSD      float64    `json:"cost_usd"`
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty"`
+}
+
+// Report returns the status of the pool and all of its accounts.
+func (p *Pool) Report() Report {
+	report := Report{
+		Strategy:          p.Strategy(),
+		SwitchOnRateLimit: p.SwitchOnRateLimit(),
+		Accounts:          []AccountReport{},
+	}
+	for _, s := range p.Status() {
+		account := AccountReport{
+			ID:      s.Account.ID,
+			Name:    s.Account.Name,
+			Enabled: s.Account.Enabled,
+			Current: s.IsCurrent,
+		}
+		if s.State != nil {
+			account.Exhausted = s.State.Exhausted
+			account.ExhaustedAt = s.State.ExhaustedAt
+			account.LastError = s.State.LastError
+			account.RateLimitCount = s.State.RateLimitCount
+			account.LastRateLimitAt = s.State.LastRateLimitAt
+			account.Usage = UsageReport(s.State.Usage)
+		}
+		report.Accounts = append(report.Accounts, account)
+	}
+	return report
+}
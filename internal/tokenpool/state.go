@@ -109,6 +109,19 @@ func (s *State) MarkExhausted(accountID string, reason string) {
 	now := time.Now()
 	state.ExhaustedAt = &now
 	state.LastError = reason
+	state.RateLimitCount++
+	state.LastRateLimitAt = &now
+}
+
+// RecordUsage adds one phase execution's tokens and cost to an account.
+func (s *State) RecordUsage(accountID string, inputTokens, outputTokens int64, costUSD float64) {
+	usage := &s.GetAccountState(accountID).Usage
+	usage.Phases++
+	usage.InputTokens += inputTokens
+	usage.OutputTokens += outputTokens
+	usage.CostUSD += costUSD
+	now := time.Now()
+	usage.LastUsedAt = &now
 }
 
 // ClearExhausted clears the exhausted flag for an account.
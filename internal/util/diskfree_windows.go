@@ -0,0 +1,11 @@
+//go:build windows
+
+package util
+
+import "errors"
+
+// FreeDiskBytes is not implemented on Windows; disk space checks are
+// skipped there.
+func FreeDiskBytes(path string) (uint64, error) {
+	return 0, errors.ErrUnsupported
+}
@@ -365,6 +365,9 @@ func (r *Resolver) addBuiltinVariables(vars VariableSet, rctx *ResolutionContext
 	vars["LINT_COMMAND"] = rctx.LintCommand
 	vars["BUILD_COMMAND"] = rctx.BuildCommand
 	vars["FRAMEWORKS"] = strings.Join(rctx.Frameworks, ", ")
+	vars["SCRIPTS_LIST"] = rctx.ScriptsList
+	vars["REPO_MAP"] = rctx.RepoMap
+	vars["FILE_SUMMARIES"] = rctx.FileSummaries
 
 	// Testing configuration
 	if rctx.CoverageThreshold > 0 {
@@ -387,6 +390,8 @@ func (r *Resolver) addBuiltinVariables(vars VariableSet, rctx *ResolutionContext
 
 	// Project brief (auto-generated context from task history)
 	vars["PROJECT_BRIEF"] = rctx.ProjectBrief
+	vars["CONTEXT_DOCUMENTS"] = rctx.ContextDocuments
+	vars["RELATED_TASKS"] = rctx.RelatedTasks
 
 	// Control-plane context
 	vars["PENDING_RECOMMENDATIONS"] = rctx.PendingRecommendations
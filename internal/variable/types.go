@@ -250,13 +250,16 @@ type ResolutionContext struct {
 	LoopIteration int // Current loop iteration (0 = not in a loop, 1 = first iteration, etc.)
 
 	// Project detection context
-	Language     string   // Primary language (go, typescript, python, etc.)
-	HasFrontend  bool     // Whether project has a frontend
-	HasTests     bool     // Whether project has existing tests
-	TestCommand  string   // Command to run tests
-	LintCommand  string   // Command to run linting
-	BuildCommand string   // Command to build project
-	Frameworks   []string // Detected frameworks
+	Language      string   // Primary language (go, typescript, python, etc.)
+	HasFrontend   bool     // Whether project has a frontend
+	HasTests      bool     // Whether project has existing tests
+	TestCommand   string   // Command to run tests
+	LintCommand   string   // Command to run linting
+	BuildCommand  string   // Command to build project
+	Frameworks    []string // Detected frameworks
+	ScriptsList   string   // Registered .orc/scripts as a markdown list
+	RepoMap       string   // Two-level directory overview with file counts
+	FileSummaries string   // One line per tracked source file: language, size, declarations
 
 	// Testing configuration
 	CoverageThreshold int // Minimum test coverage percentage (default: 85)
@@ -280,6 +283,14 @@ type ResolutionContext struct {
 	// Project brief (auto-generated context from task history)
 	ProjectBrief string
 
+	// Project context documents (architecture.md, conventions.md, ...) that
+	// apply to the current phase
+	ContextDocuments string
+
+	// Outcomes of recently completed tasks that touched the same files or
+	// packages
+	RelatedTasks string
+
 	// Control-plane context injected through builtin variables.
 	PendingRecommendations    string
 	CompletionRecommendations string
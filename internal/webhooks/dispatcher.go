@@ -0,0 +1,320 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/retry"
+	"github.com/randalmurphal/orc/internal/task"
+)
+
+// Delivery headers.
+const (
+	HeaderEvent     = "X-Orc-Event"
+	HeaderDelivery  = "X-Orc-Delivery"
+	HeaderSignature = "X-Orc-Signature"
+)
+
+// Payload is the JSON body POSTed to a hook.
+type Payload struct {
+	// ID identifies the delivery; retries of it reuse the ID.
+	ID        string    `json:"id"`
+	Event     string    `json:"event"`
+	TaskID    string    `json:"task_id"`
+	ProjectID string    `json:"project_id,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	Data      any       `json:"data,omitempty"`
+}
+
+// TaskSummary describes the task in created and failed payloads.
+type TaskSummary struct {
+	ID           string `json:"id"`
+	Title        string `json:"title"`
+	Status       string `json:"status"`
+	WorkflowID   string `json:"workflow_id,omitempty"`
+	CurrentPhase string `json:"current_phase,omitempty"`
+	Branch       string `json:"branch,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// MergeSummary describes the pull request in merged payloads.
+type MergeSummary struct {
+	URL            string `json:"url,omitempty"`
+	Number         int32  `json:"number,omitempty"`
+	TargetBranch   string `json:"target_branch,omitempty"`
+	MergeCommitSHA string `json:"merge_commit_sha,omitempty"`
+}
+
+// Sign returns the X-Orc-Signature value for body: "sha256=" followed by the
+// hex HMAC-SHA256 of the body keyed with secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher turns published task events into webhook deliveries.
+type Dispatcher struct {
+	hooks  func() ([]Hook, error)
+	policy retry.Policy
+	client *http.Client
+	logger *slog.Logger
+
+	wg sync.WaitGroup
+
+	// Last status seen per project and task, so failed and merged fire once
+	// per transition rather than on every later update
+	mu             sync.Mutex
+	failed         map[string]bool
+	merged         map[string]bool
+	defaultProject string
+}
+
+// NewDispatcher returns a dispatcher delivering to the hooks returned by
+// hooks at the time of each event. Each attempt is limited to timeout, and
+// failed attempts are retried per policy.
+func NewDispatcher(hooks func() ([]Hook, error), policy retry.Policy, timeout time.Duration, logger *slog.Logger) *Dispatcher {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &Dispatcher{
+		hooks:  hooks,
+		policy: policy,
+		client: &http.Client{Timeout: timeout},
+		logger: logger,
+		failed: make(map[string]bool),
+		merged: make(map[string]bool),
+	}
+}
+
+// Run delivers events from pub until ctx is cancelled, then waits for
+// deliveries in flight.
+func (d *Dispatcher) Run(ctx context.Context, pub events.Publisher) {
+	ch := pub.Subscribe(events.GlobalTaskID)
+	defer pub.Unsubscribe(events.GlobalTaskID, ch)
+	defer d.wg.Wait()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-ch:
+			if !ok {
+				return
+			}
+			d.Handle(ctx, ev)
+		}
+	}
+}
+
+// Handle starts deliveries of ev to the hooks subscribed to it. Events that
+// are not task lifecycle events are ignored.
+func (d *Dispatcher) Handle(ctx context.Context, ev events.Event) {
+	payloads := d.payloads(ev)
+	if len(payloads) == 0 {
+		return
+	}
+	hooks, err := d.hooks()
+	if err != nil {
+		d.logger.Warn("load webhooks", "error", err)
+		return
+	}
+	for _, p := range payloads {
+		for _, h := range hooks {
+			if !h.Wants(p.Event) {
+				continue
+			}
+			p := p
+			p.ID = uuid.NewString()
+			d.wg.Add(1)
+			go func(h Hook) {
+				defer d.wg.Done()
+				if err := d.Deliver(ctx, h, p); err != nil {
+					d.logger.Warn("webhook delivery failed", "hook", h.ID, "url", h.URL, "event", p.Event, "task", p.TaskID, "error", err)
+				}
+			}(h)
+		}
+	}
+}
+
+// SetDefaultProject sets the project of events published without a project
+// ID. Call it before Run.
+func (d *Dispatcher) SetDefaultProject(projectID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.defaultProject = projectID
+}
+
+// Seed records which tasks of a project are already failed or merged, so
+// their first update after a restart does not deliver those events again.
+func (d *Dispatcher) Seed(projectID string, tasks []*orcv1.Task) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, t := range tasks {
+		key := d.key(projectID, t.Id)
+		if t.Status == orcv1.TaskStatus_TASK_STATUS_FAILED {
+			d.failed[key] = true
+		}
+		if isMerged(t.Pr) {
+			d.merged[key] = true
+		}
+	}
+}
+
+// Wait blocks until deliveries in flight finish.
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// Deliver POSTs p to h, retrying network errors, 429s, and 5xx responses
+// with backoff. Other non-2xx responses fail at once.
+func (d *Dispatcher) Deliver(ctx context.Context, h Hook, p Payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+	return retry.Do(ctx, d.policy, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(body))
+		if err != nil {
+			return retry.Permanent(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "orc-webhooks")
+		req.Header.Set(HeaderEvent, p.Event)
+		req.Header.Set(HeaderDelivery, p.ID)
+		if h.Secret != "" {
+			req.Header.Set(HeaderSignature, Sign(h.Secret, body))
+		}
+		resp, err := d.client.Do(req)
+		if err != nil {
+			return err
+		}
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		_ = resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		err = fmt.Errorf("%s returned %s", h.URL, resp.Status)
+		if !retry.RetryableStatus(resp.StatusCode) {
+			return retry.Permanent(err)
+		}
+		return err
+	}, func(attempt int, err error, wait time.Duration) {
+		d.logger.Debug("retrying webhook delivery", "hook", h.ID, "event", p.Event, "attempt", attempt, "wait", wait, "error", err)
+	})
+}
+
+// payloads maps ev to the webhook payloads it produces.
+func (d *Dispatcher) payloads(ev events.Event) []Payload {
+	newPayload := func(name string, data any) Payload {
+		ts := ev.Time
+		if ts.IsZero() {
+			ts = time.Now()
+		}
+		return Payload{Event: name, TaskID: ev.TaskID, ProjectID: ev.ProjectID, Timestamp: ts.UTC(), Data: data}
+	}
+
+	switch ev.Type {
+	case events.EventTaskCreated:
+		if t, ok := ev.Data.(*orcv1.Task); ok {
+			return []Payload{newPayload(config.WebhookEventCreated, summarizeTask(t))}
+		}
+		return []Payload{newPayload(config.WebhookEventCreated, nil)}
+
+	case events.EventPhase:
+		if u, ok := ev.Data.(events.PhaseUpdate); ok && u.Status == "completed" {
+			return []Payload{newPayload(config.WebhookEventPhaseCompleted, u)}
+		}
+
+	case events.EventDecisionRequired:
+		return []Payload{newPayload(config.WebhookEventGatePending, ev.Data)}
+
+	case events.EventTaskUpdated:
+		var out []Payload
+		var pr *orcv1.PRInfo
+		switch data := ev.Data.(type) {
+		case *orcv1.Task:
+			failed := data.Status == orcv1.TaskStatus_TASK_STATUS_FAILED
+			if d.transition(d.failed, ev.ProjectID, ev.TaskID, failed) {
+				out = append(out, newPayload(config.WebhookEventFailed, summarizeTask(data)))
+			}
+			pr = data.Pr
+		case map[string]any:
+			// PR poller updates carry only the PR
+			pr, _ = data["pr"].(*orcv1.PRInfo)
+			if pr == nil {
+				return nil
+			}
+		default:
+			return nil
+		}
+		if d.transition(d.merged, ev.ProjectID, ev.TaskID, isMerged(pr)) {
+			out = append(out, newPayload(config.WebhookEventMerged, MergeSummary{
+				URL:            pr.GetUrl(),
+				Number:         pr.GetNumber(),
+				TargetBranch:   pr.GetTargetBranch(),
+				MergeCommitSHA: pr.GetMergeCommitSha(),
+			}))
+		}
+		return out
+	}
+	return nil
+}
+
+// transition records state for the task and reports whether it just
+// became true.
+func (d *Dispatcher) transition(seen map[string]bool, projectID, taskID string, state bool) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	key := d.key(projectID, taskID)
+	if !state {
+		delete(seen, key)
+		return false
+	}
+	if seen[key] {
+		return false
+	}
+	seen[key] = true
+	return true
+}
+
+// key identifies a task across projects. Callers hold d.mu.
+func (d *Dispatcher) key(projectID, taskID string) string {
+	if projectID == "" {
+		projectID = d.defaultProject
+	}
+	return projectID + "/" + taskID
+}
+
+func isMerged(pr *orcv1.PRInfo) bool {
+	return pr != nil && (pr.Status == orcv1.PRStatus_PR_STATUS_MERGED || pr.Merged)
+}
+
+func summarizeTask(t *orcv1.Task) TaskSummary {
+	return TaskSummary{
+		ID:           t.Id,
+		Title:        t.Title,
+		Status:       task.StatusFromProto(t.Status),
+		WorkflowID:   t.GetWorkflowId(),
+		CurrentPhase: t.GetCurrentPhase(),
+		Branch:       t.Branch,
+		Error:        t.GetExecution().GetError(),
+	}
+}
@@ -0,0 +1,221 @@
+// Package webhooks notifies external services (Slack, CI, chat bots) of task
+// lifecycle events by POSTing signed JSON to registered URLs. Endpoints come
+// from the webhooks config section and from ~/.orc/webhooks.yaml, which the
+// /api/webhooks endpoints manage.
+package webhooks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/project"
+)
+
+// StoreFile is the filename of registered webhooks inside ~/.orc.
+const StoreFile = "webhooks.yaml"
+
+// Hook sources.
+const (
+	SourceConfig = "config" // webhooks.endpoints; read-only through the API
+	SourceAPI    = "api"    // Registered through /api/webhooks
+)
+
+// Hook is one webhook receiver.
+type Hook struct {
+	ID   string `yaml:"id" json:"id"`
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	URL  string `yaml:"url" json:"url"`
+	// Secret signs deliveries with HMAC-SHA256. Never returned by the API.
+	Secret string `yaml:"secret,omitempty" json:"-"`
+	// Events limits deliveries to these events. Empty means all of them.
+	Events    []string  `yaml:"events,omitempty" json:"events"`
+	Source    string    `yaml:"-" json:"source"`
+	CreatedAt time.Time `yaml:"created_at,omitempty" json:"created_at,omitzero"`
+}
+
+// Wants reports whether the hook subscribes to event.
+func (h *Hook) Wants(event string) bool {
+	return len(h.Events) == 0 || slices.Contains(h.Events, event)
+}
+
+// Validate checks the URL and event names.
+func (h *Hook) Validate() error {
+	if err := config.ValidateWebhookURL(h.URL); err != nil {
+		return err
+	}
+	for _, ev := range h.Events {
+		if !slices.Contains(config.ValidWebhookEvents, ev) {
+			return fmt.Errorf("unknown event %q (valid: %v)", ev, config.ValidWebhookEvents)
+		}
+	}
+	return nil
+}
+
+// FromConfig returns the hooks declared in webhooks.endpoints. Their IDs are
+// "config-1", "config-2", ... in file order, and secrets are read from each
+// endpoint's secret_env.
+func FromConfig(cfg *config.Config) []Hook {
+	if cfg == nil {
+		return nil
+	}
+	hooks := make([]Hook, 0, len(cfg.Webhooks.Endpoints))
+	for i, ep := range cfg.Webhooks.Endpoints {
+		h := Hook{
+			ID:     "config-" + strconv.Itoa(i+1),
+			Name:   ep.Name,
+			URL:    ep.URL,
+			Events: ep.Events,
+			Source: SourceConfig,
+		}
+		if ep.SecretEnv != "" {
+			h.Secret = os.Getenv(ep.SecretEnv)
+		}
+		hooks = append(hooks, h)
+	}
+	return hooks
+}
+
+// Store persists hooks registered through the API in a YAML file.
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewStore returns a store backed by path.
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// DefaultStore returns the store at ~/.orc/webhooks.yaml.
+func DefaultStore() (*Store, error) {
+	dir, err := project.GlobalPath()
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(filepath.Join(dir, StoreFile)), nil
+}
+
+type storeFile struct {
+	Hooks []Hook `yaml:"hooks"`
+}
+
+// List returns the registered hooks. A missing file is an empty list.
+func (s *Store) List() ([]Hook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+// Create validates h, assigns its ID and creation time, and saves it.
+func (s *Store) Create(h Hook) (*Hook, error) {
+	if err := h.Validate(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hooks, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	h.ID = "wh-" + uuid.NewString()[:8]
+	h.Source = SourceAPI
+	h.CreatedAt = time.Now().UTC()
+	hooks = append(hooks, h)
+	if err := s.save(hooks); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+// Update replaces the hook with h.ID, keeping its creation time. It returns
+// nil when no such hook exists.
+func (s *Store) Update(h Hook) (*Hook, error) {
+	if err := h.Validate(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hooks, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	for i := range hooks {
+		if hooks[i].ID != h.ID {
+			continue
+		}
+		h.Source = SourceAPI
+		h.CreatedAt = hooks[i].CreatedAt
+		hooks[i] = h
+		if err := s.save(hooks); err != nil {
+			return nil, err
+		}
+		return &h, nil
+	}
+	return nil, nil
+}
+
+// Delete removes the hook with id and reports whether it existed.
+func (s *Store) Delete(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hooks, err := s.load()
+	if err != nil {
+		return false, err
+	}
+	for i := range hooks {
+		if hooks[i].ID == id {
+			return true, s.save(slices.Delete(hooks, i, i+1))
+		}
+	}
+	return false, nil
+}
+
+func (s *Store) load() ([]Hook, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Hook{}, nil
+		}
+		return nil, fmt.Errorf("read webhooks: %w", err)
+	}
+	var f storeFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse webhooks: %w", err)
+	}
+	for i := range f.Hooks {
+		f.Hooks[i].Source = SourceAPI
+	}
+	if f.Hooks == nil {
+		f.Hooks = []Hook{}
+	}
+	return f.Hooks, nil
+}
+
+func (s *Store) save(hooks []Hook) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("create global directory: %w", err)
+	}
+	data, err := yaml.Marshal(storeFile{Hooks: hooks})
+	if err != nil {
+		return fmt.Errorf("marshal webhooks: %w", err)
+	}
+	// Secrets live in the file, so only the owner may read it
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("write webhooks: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("save webhooks: %w", err)
+	}
+	return nil
+}
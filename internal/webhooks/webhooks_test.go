@@ -0,0 +1,335 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	orcv1 "github.com/randalmurphal/orc/gen/proto/orc/v1"
+	"github.com/randalmurphal/orc/internal/config"
+	"github.com/randalmurphal/orc/internal/events"
+	"github.com/randalmurphal/orc/internal/retry"
+)
+
+func fastPolicy(attempts int) retry.Policy {
+	return retry.Policy{MaxAttempts: attempts, Initial: time.Millisecond, Max: time.Millisecond}
+}
+
+func TestStore_CRUD(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), StoreFile)
+	store := NewStore(path)
+
+	hooks, err := store.List()
+	if err != nil || len(hooks) != 0 {
+		t.Fatalf("List() on missing file = %v, %v; want empty", hooks, err)
+	}
+
+	if _, err := store.Create(Hook{URL: "not a url"}); err == nil {
+		t.Error("Create() with invalid URL succeeded")
+	}
+	if _, err := store.Create(Hook{URL: "https://example.com", Events: []string{"bogus"}}); err == nil {
+		t.Error("Create() with unknown event succeeded")
+	}
+
+	created, err := store.Create(Hook{Name: "ci", URL: "https://example.com/hook", Secret: "s3cret", Events: []string{config.WebhookEventFailed}})
+	if err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if created.ID == "" || created.Source != SourceAPI || created.CreatedAt.IsZero() {
+		t.Errorf("Create() = %+v, want ID, api source and creation time", created)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat store: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("store permissions = %o, want 0600", perm)
+	}
+
+	created.URL = "https://example.com/other"
+	created.Events = nil
+	updated, err := store.Update(*created)
+	if err != nil || updated == nil {
+		t.Fatalf("Update() = %v, %v", updated, err)
+	}
+	if !updated.CreatedAt.Equal(created.CreatedAt) {
+		t.Errorf("Update() changed creation time")
+	}
+	if missing, err := store.Update(Hook{ID: "wh-missing", URL: "https://example.com"}); err != nil || missing != nil {
+		t.Errorf("Update() of missing hook = %v, %v; want nil, nil", missing, err)
+	}
+
+	hooks, err = NewStore(path).List()
+	if err != nil || len(hooks) != 1 {
+		t.Fatalf("List() after reload = %v, %v", hooks, err)
+	}
+	if hooks[0].URL != "https://example.com/other" || hooks[0].Secret != "s3cret" {
+		t.Errorf("reloaded hook = %+v", hooks[0])
+	}
+
+	if ok, err := store.Delete(created.ID); err != nil || !ok {
+		t.Fatalf("Delete() = %v, %v", ok, err)
+	}
+	if ok, _ := store.Delete(created.ID); ok {
+		t.Error("second Delete() reported the hook as existing")
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	t.Setenv("ORC_TEST_WEBHOOK_SECRET", "from-env")
+
+	cfg := config.Default()
+	cfg.Webhooks.Endpoints = []config.WebhookEndpoint{
+		{Name: "slack", URL: "https://hooks.example.com/a", SecretEnv: "ORC_TEST_WEBHOOK_SECRET"},
+		{URL: "https://hooks.example.com/b", Events: []string{config.WebhookEventMerged}},
+	}
+	hooks := FromConfig(cfg)
+	if len(hooks) != 2 {
+		t.Fatalf("FromConfig() returned %d hooks, want 2", len(hooks))
+	}
+	if hooks[0].ID != "config-1" || hooks[0].Secret != "from-env" || hooks[0].Source != SourceConfig {
+		t.Errorf("hooks[0] = %+v", hooks[0])
+	}
+	if hooks[1].Wants(config.WebhookEventFailed) || !hooks[1].Wants(config.WebhookEventMerged) {
+		t.Errorf("hooks[1] event filter not applied: %+v", hooks[1])
+	}
+	if !hooks[0].Wants(config.WebhookEventCreated) {
+		t.Error("hook without events should want every event")
+	}
+}
+
+// receiver records deliveries and answers with the queued status codes,
+// then 200.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, body)
+	status := http.StatusOK
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (rc *receiver) payloads(t *testing.T) []Payload {
+	t.Helper()
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	var out []Payload
+	for _, b := range rc.bodies {
+		var p Payload
+		if err := json.Unmarshal(b, &p); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+func TestDispatcher_Deliver_SignsAndRetries(t *testing.T) {
+	t.Parallel()
+
+	rc := &receiver{statuses: []int{http.StatusBadGateway, http.StatusTooManyRequests}}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	d := NewDispatcher(nil, fastPolicy(4), time.Second, nil)
+	hook := Hook{ID: "wh-1", URL: srv.URL, Secret: "s3cret"}
+	p := Payload{ID: "d-1", Event: config.WebhookEventFailed, TaskID: "TASK-001", Timestamp: time.Now()}
+	if err := d.Deliver(context.Background(), hook, p); err != nil {
+		t.Fatalf("Deliver() error: %v", err)
+	}
+
+	if len(rc.requests) != 3 {
+		t.Fatalf("receiver got %d requests, want 3 (two retried failures)", len(rc.requests))
+	}
+	last := rc.requests[2]
+	if got := last.Header.Get(HeaderEvent); got != config.WebhookEventFailed {
+		t.Errorf("%s = %q", HeaderEvent, got)
+	}
+	if got := last.Header.Get(HeaderDelivery); got != "d-1" {
+		t.Errorf("%s = %q, want the payload ID on every attempt", HeaderDelivery, got)
+	}
+	if got, want := last.Header.Get(HeaderSignature), Sign("s3cret", rc.bodies[2]); got != want {
+		t.Errorf("%s = %q, want %q", HeaderSignature, got, want)
+	}
+}
+
+func TestDispatcher_Deliver_ClientErrorIsPermanent(t *testing.T) {
+	t.Parallel()
+
+	rc := &receiver{statuses: []int{http.StatusNotFound}}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	d := NewDispatcher(nil, fastPolicy(4), time.Second, nil)
+	err := d.Deliver(context.Background(), Hook{URL: srv.URL}, Payload{Event: config.WebhookEventCreated})
+	if err == nil {
+		t.Fatal("Deliver() succeeded on 404")
+	}
+	if len(rc.requests) != 1 {
+		t.Errorf("receiver got %d requests, want 1 (404 is not retried)", len(rc.requests))
+	}
+	if rc.requests[0].Header.Get(HeaderSignature) != "" {
+		t.Error("unsigned hook sent a signature header")
+	}
+}
+
+func TestDispatcher_Handle(t *testing.T) {
+	t.Parallel()
+
+	rc := &receiver{}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	var loads atomic.Int32
+	hooks := func() ([]Hook, error) {
+		loads.Add(1)
+		return []Hook{
+			{ID: "all", URL: srv.URL + "/all"},
+			{ID: "merged-only", URL: srv.URL + "/merged", Events: []string{config.WebhookEventMerged}},
+		}, nil
+	}
+	d := NewDispatcher(hooks, fastPolicy(1), time.Second, nil)
+	ctx := context.Background()
+
+	failedTask := &orcv1.Task{Id: "TASK-001", Title: "Fix login", Status: orcv1.TaskStatus_TASK_STATUS_FAILED}
+	mergedPR := &orcv1.PRInfo{Status: orcv1.PRStatus_PR_STATUS_MERGED}
+	for _, ev := range []events.Event{
+		events.NewEvent(events.EventTaskCreated, "TASK-001", &orcv1.Task{Id: "TASK-001", Title: "Fix login"}),
+		events.NewEvent(events.EventPhase, "TASK-001", events.PhaseUpdate{Phase: "implement", Status: "running"}),
+		events.NewEvent(events.EventPhase, "TASK-001", events.PhaseUpdate{Phase: "implement", Status: "completed"}),
+		events.NewEvent(events.EventDecisionRequired, "TASK-001", events.DecisionRequiredData{Phase: "review", GateType: "human"}),
+		events.NewEvent(events.EventTaskUpdated, "TASK-001", failedTask),
+		// Later updates of the failed task are not new failures
+		events.NewEvent(events.EventTaskUpdated, "TASK-001", failedTask),
+		events.NewEvent(events.EventTaskUpdated, "TASK-001", map[string]any{"pr": mergedPR}),
+		events.NewEvent(events.EventTranscript, "TASK-001", nil),
+	} {
+		d.Handle(ctx, ev)
+	}
+	d.Wait()
+
+	if got := loads.Load(); got != 5 {
+		t.Errorf("hooks loaded %d times, want 5 (only for lifecycle events)", got)
+	}
+
+	counts := map[string]int{}
+	for i, p := range rc.payloads(t) {
+		counts[rc.requests[i].URL.Path+" "+p.Event]++
+		if p.TaskID != "TASK-001" || p.ID == "" {
+			t.Errorf("payload %+v missing task or delivery ID", p)
+		}
+	}
+	want := map[string]int{
+		"/all created":         1,
+		"/all phase_completed": 1,
+		"/all gate_pending":    1,
+		"/all failed":          1,
+		"/all merged":          1,
+		"/merged merged":       1,
+	}
+	if len(counts) != len(want) {
+		t.Errorf("deliveries = %v, want %v", counts, want)
+	}
+	for k, n := range want {
+		if counts[k] != n {
+			t.Errorf("deliveries[%q] = %d, want %d (all: %v)", k, counts[k], n, counts)
+		}
+	}
+}
+
+func TestDispatcher_Run(t *testing.T) {
+	t.Parallel()
+
+	rc := &receiver{}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	pub := events.NewMemoryPublisher()
+	defer pub.Close()
+	d := NewDispatcher(func() ([]Hook, error) {
+		return []Hook{{ID: "all", URL: srv.URL}}, nil
+	}, fastPolicy(1), time.Second, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx, pub)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(rc.payloads(t)) == 0 && time.Now().Before(deadline) {
+		// The subscription may not exist yet; publish until delivered
+		pub.Publish(events.NewEvent(events.EventTaskCreated, "TASK-002", &orcv1.Task{Id: "TASK-002"}))
+		time.Sleep(20 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	payloads := rc.payloads(t)
+	if len(payloads) == 0 {
+		t.Fatal("no delivery from published event")
+	}
+	if payloads[0].Event != config.WebhookEventCreated || payloads[0].TaskID != "TASK-002" {
+		t.Errorf("payload = %+v", payloads[0])
+	}
+}
+
+func TestDispatcher_Handle_KeysByProjectAndSeeds(t *testing.T) {
+	t.Parallel()
+
+	rc := &receiver{}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	d := NewDispatcher(func() ([]Hook, error) {
+		return []Hook{{ID: "all", URL: srv.URL}}, nil
+	}, fastPolicy(1), time.Second, nil)
+	d.SetDefaultProject("proj-a")
+	failed := &orcv1.Task{Id: "TASK-001", Status: orcv1.TaskStatus_TASK_STATUS_FAILED}
+	// TASK-002 failed before the restart
+	d.Seed("proj-a", []*orcv1.Task{{Id: "TASK-002", Status: orcv1.TaskStatus_TASK_STATUS_FAILED}})
+
+	ctx := context.Background()
+	for _, ev := range []events.Event{
+		events.NewProjectEvent(events.EventTaskUpdated, "proj-a", "TASK-001", failed),
+		// The same task ID in another project fails on its own
+		events.NewProjectEvent(events.EventTaskUpdated, "proj-b", "TASK-001", failed),
+		// Events without a project ID belong to the default project
+		events.NewEvent(events.EventTaskUpdated, "TASK-001", failed),
+		events.NewEvent(events.EventTaskUpdated, "TASK-002", &orcv1.Task{Id: "TASK-002", Status: orcv1.TaskStatus_TASK_STATUS_FAILED}),
+	} {
+		d.Handle(ctx, ev)
+	}
+	d.Wait()
+
+	got := map[string]int{}
+	for _, p := range rc.payloads(t) {
+		got[p.ProjectID+"/"+p.TaskID+" "+p.Event]++
+	}
+	want := map[string]int{"proj-a/TASK-001 failed": 1, "proj-b/TASK-001 failed": 1}
+	if len(got) != len(want) || got["proj-a/TASK-001 failed"] != 1 || got["proj-b/TASK-001 failed"] != 1 {
+		t.Errorf("deliveries = %v, want %v", got, want)
+	}
+}
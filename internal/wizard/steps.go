@@ -20,12 +20,13 @@ type SelectOption struct {
 
 // SelectStep allows the user to choose one option from a list.
 type SelectStep struct {
-	id          string
-	title       string
-	description string
-	options     []SelectOption
-	stateKey    string
-	skipFunc    func(State) bool
+	id           string
+	title        string
+	description  string
+	options      []SelectOption
+	defaultValue string
+	stateKey     string
+	skipFunc     func(State) bool
 }
 
 // NewSelectStep creates a new select step.
@@ -44,6 +45,12 @@ func (s *SelectStep) WithDescription(desc string) *SelectStep {
 	return s
 }
 
+// WithDefault places the cursor on the option with this value.
+func (s *SelectStep) WithDefault(value string) *SelectStep {
+	s.defaultValue = value
+	return s
+}
+
 // WithStateKey sets the key where the result is stored in state.
 func (s *SelectStep) WithStateKey(key string) *SelectStep {
 	s.stateKey = key
@@ -68,9 +75,16 @@ func (s *SelectStep) Skip(state State) bool {
 }
 
 func (s *SelectStep) Init(state State) tea.Model {
+	cursor := 0
+	for i, opt := range s.options {
+		if opt.Value == s.defaultValue {
+			cursor = i
+			break
+		}
+	}
 	return &selectModel{
 		options:  s.options,
-		cursor:   0,
+		cursor:   cursor,
 		selected: -1,
 	}
 }
@@ -257,10 +271,12 @@ func (m *confirmModel) View() string {
 type InputStep struct {
 	id           string
 	title        string
+	description  string
 	placeholder  string
 	defaultValue string
 	stateKey     string
 	validate     func(string) error
+	skipFunc     func(State) bool
 }
 
 // NewInputStep creates a new text input step.
@@ -284,6 +300,42 @@ func (s *InputStep) WithDefault(val string) *InputStep {
 	return s
 }
 
+// WithDescription sets the step description.
+func (s *InputStep) WithDescription(desc string) *InputStep {
+	s.description = desc
+	return s
+}
+
+// WithStateKey sets the key where the result is stored in state.
+func (s *InputStep) WithStateKey(key string) *InputStep {
+	s.stateKey = key
+	return s
+}
+
+// WithValidate sets a function that must accept the value before the step
+// completes. Its error is shown under the input.
+func (s *InputStep) WithValidate(fn func(string) error) *InputStep {
+	s.validate = fn
+	return s
+}
+
+// WithSkipFunc sets a function to determine if this step should be skipped.
+func (s *InputStep) WithSkipFunc(fn func(State) bool) *InputStep {
+	s.skipFunc = fn
+	return s
+}
+
+func (s *InputStep) ID() string          { return s.id }
+func (s *InputStep) Title() string       { return s.title }
+func (s *InputStep) Description() string { return s.description }
+
+func (s *InputStep) Skip(state State) bool {
+	if s.skipFunc != nil {
+		return s.skipFunc(state)
+	}
+	return false
+}
+
 func (s *InputStep) Init(state State) tea.Model {
 	ti := textinput.New()
 	ti.Placeholder = s.placeholder
@@ -297,6 +349,13 @@ func (s *InputStep) Init(state State) tea.Model {
 	}
 }
 
+// Result stores the entered text with surrounding whitespace trimmed.
+func (s *InputStep) Result(model tea.Model, state State) {
+	if m, ok := model.(*inputModel); ok {
+		state[s.stateKey] = strings.TrimSpace(m.textInput.Value())
+	}
+}
+
 type inputModel struct {
 	textInput textinput.Model
 	validate  func(string) error
@@ -32,6 +32,12 @@ func TestSelectStep(t *testing.T) {
 	if !stepWithSkip.Skip(nil) {
 		t.Error("expected Skip to return true when skipFunc returns true")
 	}
+
+	// Default places the cursor on the matching option
+	model := NewSelectStep("default", "Default Step", options).WithDefault("b").Init(nil)
+	if m, ok := model.(*selectModel); !ok || m.cursor != 1 {
+		t.Errorf("expected cursor on option B, got %+v", model)
+	}
 }
 
 func TestConfirmStep(t *testing.T) {
@@ -65,6 +71,20 @@ func TestInputStep(t *testing.T) {
 	} else {
 		t.Error("expected inputModel type")
 	}
+
+	if step.ID() != "input" || step.Skip(nil) {
+		t.Errorf("expected ID 'input' and no skip, got %s", step.ID())
+	}
+
+	// Result stores the trimmed value under the state key
+	step.WithStateKey("value")
+	m := step.Init(nil).(*inputModel)
+	m.textInput.SetValue("  typed  ")
+	state := make(State)
+	step.Result(m, state)
+	if state["value"] != "typed" {
+		t.Errorf("expected state value 'typed', got %v", state["value"])
+	}
 }
 
 func TestMultiSelectStep(t *testing.T) {
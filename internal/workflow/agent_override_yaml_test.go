@@ -0,0 +1,52 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentOverrideYAML_Parse(t *testing.T) {
+	t.Parallel()
+
+	yamlData := []byte(`
+id: test-agent-override
+name: Agent Override Test
+phases:
+  - template: review
+    sequence: 1
+    agent_override: readonly-executor
+  - template: docs
+    sequence: 2
+`)
+
+	wf, err := parseWorkflowYAML(yamlData)
+	require.NoError(t, err)
+
+	require.Len(t, wf.Phases, 2)
+	assert.Equal(t, "readonly-executor", wf.Phases[0].AgentOverride)
+	assert.Equal(t, "", wf.Phases[1].AgentOverride)
+}
+
+func TestAgentOverrideYAML_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := &Workflow{
+		ID:   "round-trip",
+		Name: "Round Trip",
+		Phases: []WorkflowPhase{
+			{PhaseTemplateID: "review", Sequence: 1, AgentOverride: "readonly-executor"},
+		},
+	}
+
+	data, err := marshalWorkflowYAML(original)
+	require.NoError(t, err)
+
+	parsed, err := parseWorkflowYAML(data)
+	require.NoError(t, err)
+
+	require.Len(t, parsed.Phases, 1)
+	assert.Equal(t, "readonly-executor", parsed.Phases[0].AgentOverride)
+	assert.Equal(t, "readonly-executor", workflowPhaseToDBWorkflowPhase(&parsed.Phases[0]).AgentOverride)
+}
@@ -359,6 +359,20 @@ func (c *CacheService) shouldUpdateWorkflow(existing *db.Workflow, rw ResolvedWo
 		return true
 	}
 
+	// Derived workflows are stale when any definition in the chain changed,
+	// so edits to a base propagate to everything that extends it.
+	for _, layer := range rw.Inheritance {
+		if layer.Source == SourceEmbedded {
+			return true
+		}
+		if layer.FilePath != "" {
+			info, err := os.Stat(layer.FilePath)
+			if err != nil || info.ModTime().After(existing.UpdatedAt) {
+				return true
+			}
+		}
+	}
+
 	// For file-based, check modification time
 	if rw.FilePath != "" {
 		info, err := os.Stat(rw.FilePath)
@@ -400,6 +414,13 @@ func workflowToDBWorkflow(wf *Workflow, source Source) *db.Workflow {
 			triggersJSON = string(data)
 		}
 	}
+	var doneCriteriaJSON string
+	if len(wf.DoneCriteria) > 0 {
+		data, err := json.Marshal(wf.DoneCriteria)
+		if err == nil {
+			doneCriteriaJSON = string(data)
+		}
+	}
 
 	return &db.Workflow{
 		ID:               wf.ID,
@@ -413,6 +434,7 @@ func workflowToDBWorkflow(wf *Workflow, source Source) *db.Workflow {
 		IsBuiltin:        source == SourceEmbedded,
 		BasedOn:          wf.BasedOn,
 		Triggers:         triggersJSON,
+		DoneCriteria:     doneCriteriaJSON,
 		CreatedAt:        wf.CreatedAt,
 		UpdatedAt:        time.Now(),
 	}
@@ -449,6 +471,7 @@ func workflowPhaseToDBPhase(pt *PhaseTemplate, source Source) *db.PhaseTemplate
 		RetryPromptPath:  pt.RetryPromptPath,
 		RuntimeConfig:     pt.RuntimeConfig,
 		Provider:         pt.Provider,
+		Type:             pt.Type,
 		IsBuiltin:        source == SourceEmbedded,
 		CreatedAt:        pt.CreatedAt,
 		UpdatedAt:        time.Now(),
@@ -471,6 +494,7 @@ func workflowPhaseToDBWorkflowPhase(wp *WorkflowPhase) *db.WorkflowPhase {
 		DependsOn:       dependsOnJSON,
 		ModelOverride:    wp.ModelOverride,
 		ProviderOverride: wp.ProviderOverride,
+		AgentOverride:    wp.AgentOverride,
 		Condition:        wp.Condition,
 		LoopConfig:      wp.LoopConfig,
 	}
@@ -506,6 +530,9 @@ func workflowVariableToDBVariable(wv *WorkflowVariable) *db.WorkflowVariable {
 
 // DBWorkflowToWorkflow converts a db.Workflow to workflow.Workflow.
 func DBWorkflowToWorkflow(dbWf *db.Workflow) *Workflow {
+	// Stored criteria were validated when the workflow was loaded; a parse
+	// failure here means a hand-edited row and leaves the criteria empty.
+	doneCriteria, _ := ParseDoneCriteria(dbWf.DoneCriteria)
 	return &Workflow{
 		ID:               dbWf.ID,
 		Name:             dbWf.Name,
@@ -517,6 +544,7 @@ func DBWorkflowToWorkflow(dbWf *db.Workflow) *Workflow {
 		TargetBranch:     dbWf.TargetBranch,
 		IsBuiltin:        dbWf.IsBuiltin,
 		BasedOn:          dbWf.BasedOn,
+		DoneCriteria:     doneCriteria,
 		CreatedAt:        dbWf.CreatedAt,
 		UpdatedAt:        dbWf.UpdatedAt,
 	}
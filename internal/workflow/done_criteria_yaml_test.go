@@ -0,0 +1,69 @@
+package workflow
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseWorkflowYAML_DoneCriteria(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`id: with-dod
+name: With DoD
+phases:
+  - template: implement
+    sequence: 0
+done_criteria:
+  - type: coverage
+    command: go test -cover ./...
+    min_percent: 80
+  - type: changelog
+  - type: no_todos
+    on_failure: warn
+`)
+	wf, err := parseWorkflowYAML(data)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(wf.DoneCriteria) != 3 {
+		t.Fatalf("got %d done criteria, want 3", len(wf.DoneCriteria))
+	}
+	if wf.DoneCriteria[0].MinPercent != 80 || wf.DoneCriteria[2].OnFailure != "warn" {
+		t.Errorf("done criteria = %+v", wf.DoneCriteria)
+	}
+
+	// Round trip through the DB representation.
+	back := DBWorkflowToWorkflow(workflowToDBWorkflow(wf, SourceProject))
+	if len(back.DoneCriteria) != 3 || back.DoneCriteria[1].Type != DoneCriterionChangelog {
+		t.Errorf("round trip done criteria = %+v", back.DoneCriteria)
+	}
+}
+
+func TestDoneCriterion_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		c    DoneCriterion
+		err  string
+	}{
+		{"coverage needs command", DoneCriterion{Type: DoneCriterionCoverage, MinPercent: 80}, "command is required"},
+		{"coverage needs floor", DoneCriterion{Type: DoneCriterionCoverage, Command: "make cover"}, "min_percent"},
+		{"bad pattern", DoneCriterion{Type: DoneCriterionNoTodos, Pattern: "("}, "invalid pattern"},
+		{"bad on_failure", DoneCriterion{Type: DoneCriterionChangelog, OnFailure: "skip"}, "on_failure"},
+		{"unknown type", DoneCriterion{Type: "vibes"}, "unknown type"},
+		{"valid docs", DoneCriterion{Type: DoneCriterionDocsUpdated, Paths: []string{"docs/**"}}, ""},
+	}
+	for _, tt := range tests {
+		err := tt.c.Validate()
+		if tt.err == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: error = %v, want containing %q", tt.name, err, tt.err)
+		}
+	}
+}
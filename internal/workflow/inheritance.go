@@ -0,0 +1,211 @@
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// WorkflowLayer is one definition in a workflow's inheritance chain and the
+// phase changes it made on top of the layers before it.
+type WorkflowLayer struct {
+	ID               string   `json:"id"`
+	Source           Source   `json:"source"`
+	FilePath         string   `json:"file_path,omitempty"`
+	AddedPhases      []string `json:"added_phases,omitempty"`
+	OverriddenPhases []string `json:"overridden_phases,omitempty"`
+	RemovedPhases    []string `json:"removed_phases,omitempty"`
+}
+
+// sourceLevel returns the position of a source in resolution priority order,
+// matching the levels returned by findWorkflow.
+func (r *Resolver) sourceLevel(source Source) int {
+	sources := r.workflowSources()
+	for i, s := range sources {
+		if s.source == source {
+			return i
+		}
+	}
+	return len(sources)
+}
+
+// resolveInheritance replaces rw.Workflow with the result of layering it on
+// its base, recursively, and records the chain in rw.Inheritance. Workflows
+// without extends are left untouched.
+func (r *Resolver) resolveInheritance(rw *ResolvedWorkflow, level int, visiting map[string]bool) error {
+	wf := rw.Workflow
+	if wf.Extends == "" {
+		return nil
+	}
+
+	key := wf.ID + "@" + string(rw.Source)
+	if visiting[key] {
+		return fmt.Errorf("workflow %s: inheritance cycle through %s", wf.ID, wf.Extends)
+	}
+	visiting[key] = true
+
+	// Extending yourself means "the definition I shadow".
+	from := 0
+	if wf.Extends == wf.ID {
+		from = level + 1
+	}
+	base, baseLevel, err := r.findWorkflow(wf.Extends, from)
+	if err != nil {
+		// Not %w: the derived workflow exists, so callers should not see ErrNotFound.
+		return fmt.Errorf("workflow %s extends %s: %v", wf.ID, wf.Extends, err)
+	}
+	if err := r.resolveInheritance(base, baseLevel, visiting); err != nil {
+		return err
+	}
+
+	merged, layer, err := mergeWorkflow(base.Workflow, wf)
+	if err != nil {
+		return err
+	}
+	layer.ID, layer.Source, layer.FilePath = wf.ID, rw.Source, rw.FilePath
+
+	chain := base.Inheritance
+	if len(chain) == 0 {
+		root := WorkflowLayer{ID: base.Workflow.ID, Source: base.Source, FilePath: base.FilePath}
+		for _, p := range base.Workflow.Phases {
+			root.AddedPhases = append(root.AddedPhases, p.PhaseTemplateID)
+		}
+		chain = []WorkflowLayer{root}
+	}
+	rw.Workflow = merged
+	rw.Inheritance = append(slices.Clone(chain), layer)
+	return nil
+}
+
+// mergeWorkflow layers derived on top of a resolved base. Non-empty scalar
+// fields in derived win; phases are matched by template ID.
+func mergeWorkflow(base, derived *Workflow) (*Workflow, WorkflowLayer, error) {
+	var layer WorkflowLayer
+
+	out := *base
+	out.ID = derived.ID
+	out.IsBuiltin = false
+	out.BasedOn = derived.BasedOn
+	out.Extends = derived.Extends
+	out.RemovePhases = nil
+	if derived.Name != "" {
+		out.Name = derived.Name
+	}
+	if derived.Description != "" {
+		out.Description = derived.Description
+	}
+	if derived.DefaultModel != "" {
+		out.DefaultModel = derived.DefaultModel
+	}
+	if derived.DefaultProvider != "" {
+		out.DefaultProvider = derived.DefaultProvider
+	}
+	out.DefaultThinking = base.DefaultThinking || derived.DefaultThinking
+	if derived.CompletionAction != "" {
+		out.CompletionAction = derived.CompletionAction
+	}
+	if derived.TargetBranch != "" {
+		out.TargetBranch = derived.TargetBranch
+	}
+	if len(derived.DoneCriteria) > 0 {
+		out.DoneCriteria = derived.DoneCriteria
+	}
+
+	// Phases: copy the base, drop removals, then apply overrides and additions.
+	removed := make(map[string]bool, len(derived.RemovePhases))
+	for _, id := range derived.RemovePhases {
+		if !slices.ContainsFunc(base.Phases, func(p WorkflowPhase) bool { return p.PhaseTemplateID == id }) {
+			return nil, layer, fmt.Errorf("workflow %s: remove_phases: %s is not a phase of %s", derived.ID, id, base.ID)
+		}
+		removed[id] = true
+		layer.RemovedPhases = append(layer.RemovedPhases, id)
+	}
+	out.Phases = nil
+	for _, p := range base.Phases {
+		if removed[p.PhaseTemplateID] {
+			continue
+		}
+		p.WorkflowID = derived.ID
+		p.DependsOn = slices.DeleteFunc(slices.Clone(p.DependsOn), func(dep string) bool { return removed[dep] })
+		out.Phases = append(out.Phases, p)
+	}
+	for _, p := range derived.Phases {
+		i := slices.IndexFunc(out.Phases, func(bp WorkflowPhase) bool { return bp.PhaseTemplateID == p.PhaseTemplateID })
+		if i < 0 {
+			p.WorkflowID = derived.ID
+			out.Phases = append(out.Phases, p)
+			layer.AddedPhases = append(layer.AddedPhases, p.PhaseTemplateID)
+			continue
+		}
+		overridePhase(&out.Phases[i], p)
+		layer.OverriddenPhases = append(layer.OverriddenPhases, p.PhaseTemplateID)
+	}
+	sort.SliceStable(out.Phases, func(i, j int) bool {
+		return out.Phases[i].Sequence < out.Phases[j].Sequence
+	})
+
+	// Variables and triggers: derived entries replace base entries with the
+	// same key and are otherwise appended.
+	out.Variables = nil
+	for _, v := range base.Variables {
+		v.WorkflowID = derived.ID
+		out.Variables = append(out.Variables, v)
+	}
+	for _, v := range derived.Variables {
+		if i := slices.IndexFunc(out.Variables, func(bv WorkflowVariable) bool { return bv.Name == v.Name }); i >= 0 {
+			out.Variables[i] = v
+		} else {
+			out.Variables = append(out.Variables, v)
+		}
+	}
+	out.Triggers = slices.Clone(base.Triggers)
+	for _, t := range derived.Triggers {
+		if i := slices.IndexFunc(out.Triggers, func(bt WorkflowTrigger) bool {
+			return bt.Event == t.Event && bt.AgentID == t.AgentID
+		}); i >= 0 {
+			out.Triggers[i] = t
+		} else {
+			out.Triggers = append(out.Triggers, t)
+		}
+	}
+
+	return &out, layer, nil
+}
+
+// overridePhase applies the non-empty fields of a derived phase entry to the
+// matching base phase. A zero sequence keeps the base position.
+func overridePhase(dst *WorkflowPhase, p WorkflowPhase) {
+	if p.Sequence != 0 {
+		dst.Sequence = p.Sequence
+	}
+	if p.DependsOn != nil {
+		dst.DependsOn = p.DependsOn
+	}
+	if p.ModelOverride != "" {
+		dst.ModelOverride = p.ModelOverride
+	}
+	if p.ProviderOverride != "" {
+		dst.ProviderOverride = p.ProviderOverride
+	}
+	if p.ThinkingOverride != nil {
+		dst.ThinkingOverride = p.ThinkingOverride
+	}
+	if p.GateTypeOverride != "" {
+		dst.GateTypeOverride = p.GateTypeOverride
+	}
+	if p.Condition != "" {
+		dst.Condition = p.Condition
+	}
+	if p.AgentOverride != "" {
+		dst.AgentOverride = p.AgentOverride
+	}
+	if p.RuntimeConfigOverride != "" {
+		dst.RuntimeConfigOverride = p.RuntimeConfigOverride
+	}
+	if p.LoopConfig != "" {
+		dst.LoopConfig = p.LoopConfig
+	}
+	if len(p.BeforeTriggers) > 0 {
+		dst.BeforeTriggers = p.BeforeTriggers
+	}
+}
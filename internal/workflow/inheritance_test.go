@@ -0,0 +1,217 @@
+package workflow
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeWorkflowFile(t *testing.T, dir, id, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, "workflows", id+".yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func phaseIDs(wf *Workflow) []string {
+	ids := make([]string, 0, len(wf.Phases))
+	for _, p := range wf.Phases {
+		ids = append(ids, p.PhaseTemplateID)
+	}
+	return ids
+}
+
+func TestResolveWorkflow_ExtendsBase(t *testing.T) {
+	t.Parallel()
+
+	personal, project := t.TempDir(), t.TempDir()
+	writeWorkflowFile(t, personal, "org-standard", `id: org-standard
+name: Org Standard
+default_model: sonnet
+completion_action: pr
+phases:
+  - template: spec
+    sequence: 0
+  - template: implement
+    sequence: 1
+    depends_on: [spec]
+  - template: review
+    sequence: 2
+    depends_on: [implement]
+  - template: docs
+    sequence: 3
+    depends_on: [review, spec]
+variables:
+  - name: TEAM
+    source_type: static
+    source_config: '{"value":"platform"}'
+`)
+	writeWorkflowFile(t, project, "api-standard", `id: api-standard
+name: API Standard
+extends: org-standard
+remove_phases: [spec]
+phases:
+  - template: review
+    model_override: opus
+    gate_type: human
+  - template: security_scan
+    sequence: 4
+    depends_on: [review]
+variables:
+  - name: TEAM
+    source_type: static
+    source_config: '{"value":"api"}'
+`)
+
+	r := NewResolver(WithPersonalDir(personal), WithProjectDir(project), WithEmbedded(false))
+	rw, err := r.ResolveWorkflow("api-standard")
+	if err != nil {
+		t.Fatalf("ResolveWorkflow: %v", err)
+	}
+	wf := rw.Workflow
+
+	if got, want := phaseIDs(wf), []string{"implement", "review", "docs", "security_scan"}; !slices.Equal(got, want) {
+		t.Errorf("phases = %v, want %v", got, want)
+	}
+	if wf.Name != "API Standard" || wf.DefaultModel != "sonnet" || wf.CompletionAction != "pr" {
+		t.Errorf("scalars = %q %q %q, want derived name with inherited model/action", wf.Name, wf.DefaultModel, wf.CompletionAction)
+	}
+	review := wf.Phases[1]
+	if review.ModelOverride != "opus" || review.GateTypeOverride != GateHuman || review.Sequence != 2 ||
+		!slices.Equal(review.DependsOn, []string{"implement"}) || review.WorkflowID != "api-standard" {
+		t.Errorf("review override = %+v", review)
+	}
+	if !slices.Equal(wf.Phases[0].DependsOn, []string{}) || !slices.Equal(wf.Phases[2].DependsOn, []string{"review"}) {
+		t.Errorf("removed phase still referenced: %v / %v", wf.Phases[0].DependsOn, wf.Phases[2].DependsOn)
+	}
+	if len(wf.Variables) != 1 || !strings.Contains(wf.Variables[0].SourceConfig, "api") {
+		t.Errorf("variables = %+v", wf.Variables)
+	}
+
+	if len(rw.Inheritance) != 2 {
+		t.Fatalf("inheritance = %+v, want base and derived layers", rw.Inheritance)
+	}
+	base, derived := rw.Inheritance[0], rw.Inheritance[1]
+	if base.ID != "org-standard" || base.Source != SourcePersonalGlobal || len(base.AddedPhases) != 4 {
+		t.Errorf("base layer = %+v", base)
+	}
+	if derived.Source != SourceProject || !slices.Equal(derived.RemovedPhases, []string{"spec"}) ||
+		!slices.Equal(derived.OverriddenPhases, []string{"review"}) || !slices.Equal(derived.AddedPhases, []string{"security_scan"}) {
+		t.Errorf("derived layer = %+v", derived)
+	}
+}
+
+func TestResolveWorkflow_ExtendsShadowedEmbedded(t *testing.T) {
+	t.Parallel()
+
+	project := t.TempDir()
+	writeWorkflowFile(t, project, "implement-small", `id: implement-small
+extends: implement-small
+remove_phases: [docs]
+`)
+
+	r := NewResolver(WithProjectDir(project))
+	rw, err := r.ResolveWorkflow("implement-small")
+	if err != nil {
+		t.Fatalf("ResolveWorkflow: %v", err)
+	}
+	if slices.Contains(phaseIDs(rw.Workflow), "docs") || !slices.Contains(phaseIDs(rw.Workflow), "implement") {
+		t.Errorf("phases = %v, want embedded implement-small without docs", phaseIDs(rw.Workflow))
+	}
+	if rw.Workflow.Name == "" || rw.Inheritance[0].Source != SourceEmbedded {
+		t.Errorf("expected embedded base, got name %q chain %+v", rw.Workflow.Name, rw.Inheritance)
+	}
+
+	// ListWorkflows resolves the same way.
+	all, err := r.ListWorkflows()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range all {
+		if w.Workflow.ID == "implement-small" && slices.Contains(phaseIDs(w.Workflow), "docs") {
+			t.Error("ListWorkflows returned the unresolved definition")
+		}
+	}
+}
+
+func TestResolveWorkflow_InheritanceErrors(t *testing.T) {
+	t.Parallel()
+
+	project := t.TempDir()
+	writeWorkflowFile(t, project, "a", "id: a\nextends: b\n")
+	writeWorkflowFile(t, project, "b", "id: b\nextends: a\n")
+	writeWorkflowFile(t, project, "orphan", "id: orphan\nextends: missing\n")
+	writeWorkflowFile(t, project, "base", "id: base\nphases:\n  - template: implement\n    sequence: 0\n")
+	writeWorkflowFile(t, project, "bad-remove", "id: bad-remove\nextends: base\nremove_phases: [review]\n")
+
+	r := NewResolver(WithProjectDir(project), WithEmbedded(false))
+	if _, err := r.ResolveWorkflow("a"); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("cycle err = %v", err)
+	}
+	if _, err := r.ResolveWorkflow("orphan"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("missing base err = %v, want an error that is not ErrNotFound", err)
+	}
+	if _, err := r.ResolveWorkflow("nope"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing workflow err = %v, want ErrNotFound", err)
+	}
+	if _, err := r.ResolveWorkflow("bad-remove"); err == nil || !strings.Contains(err.Error(), "review") {
+		t.Errorf("bad remove err = %v", err)
+	}
+
+	all, err := r.ListWorkflows()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || all[0].Workflow.ID != "base" {
+		t.Errorf("ListWorkflows = %d entries, want only the valid base", len(all))
+	}
+}
+
+func TestCacheService_BaseChangePropagates(t *testing.T) {
+	t.Parallel()
+
+	project := t.TempDir()
+	basePath := writeWorkflowFile(t, project, "base", "id: base\nname: Base\nphases:\n  - template: implement\n    sequence: 0\n")
+	derivedPath := writeWorkflowFile(t, project, "derived", "id: derived\nextends: base\n")
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(derivedPath, past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	gdb := openTestGlobalDB(t)
+	if _, err := SeedBuiltins(gdb); err != nil {
+		t.Fatal(err)
+	}
+	cache := NewCacheService(NewResolver(WithProjectDir(project), WithEmbedded(false)), gdb)
+	if _, err := cache.SyncAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Editing only the base makes the derived workflow stale too.
+	if err := os.WriteFile(basePath, []byte("id: base\nname: Base\nphases:\n  - template: implement\n    sequence: 0\n  - template: review\n    sequence: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(basePath, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.SyncAll(); err != nil {
+		t.Fatal(err)
+	}
+	phases, err := gdb.GetWorkflowPhases("derived")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(phases) != 2 {
+		t.Errorf("derived phases in DB = %d, want 2 after base change", len(phases))
+	}
+}
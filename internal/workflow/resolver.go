@@ -22,6 +22,10 @@ type ResolvedWorkflow struct {
 	Workflow *Workflow `json:"workflow"`
 	Source   Source    `json:"source"`
 	FilePath string    `json:"file_path,omitempty"` // For file sources
+
+	// Inheritance lists the definitions a workflow using extends was built
+	// from, base first and this workflow last. Empty for standalone workflows.
+	Inheritance []WorkflowLayer `json:"inheritance,omitempty"`
 }
 
 // ResolvedPhase contains the resolved phase template and its source.
@@ -109,24 +113,47 @@ func NewResolverFromOrcDir(orcDir string) *Resolver {
 }
 
 // ResolveWorkflow returns the workflow for an ID, checking sources in priority order.
+// Workflows that extend a base are returned fully resolved, with the chain of
+// definitions that produced them in Inheritance.
 func (r *Resolver) ResolveWorkflow(id string) (*ResolvedWorkflow, error) {
-	filename := id + ".yaml"
+	rw, level, err := r.findWorkflow(id, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.resolveInheritance(rw, level, map[string]bool{}); err != nil {
+		return nil, err
+	}
+	return rw, nil
+}
 
-	sources := []struct {
+// workflowSources returns the file-based workflow sources in priority order.
+// Embedded templates come after the last entry.
+func (r *Resolver) workflowSources() []struct {
+	dir    string
+	source Source
+} {
+	return []struct {
 		dir    string
-		subdir string
 		source Source
 	}{
-		{r.personalDir, "workflows", SourcePersonalGlobal},
-		{r.localDir, "workflows", SourceProjectLocal},
-		{r.projectDir, "workflows", SourceProject},
+		{r.personalDir, SourcePersonalGlobal},
+		{r.localDir, SourceProjectLocal},
+		{r.projectDir, SourceProject},
 	}
+}
 
-	for _, s := range sources {
+// findWorkflow returns the unresolved workflow definition for an ID, skipping
+// sources above level, along with the level it was found at.
+func (r *Resolver) findWorkflow(id string, level int) (*ResolvedWorkflow, int, error) {
+	filename := id + ".yaml"
+
+	sources := r.workflowSources()
+	for i := level; i < len(sources); i++ {
+		s := sources[i]
 		if s.dir == "" {
 			continue
 		}
-		path := filepath.Join(s.dir, s.subdir, filename)
+		path := filepath.Join(s.dir, "workflows", filename)
 		data, err := os.ReadFile(path)
 		if err != nil {
 			continue // File doesn't exist, try next
@@ -142,22 +169,21 @@ func (r *Resolver) ResolveWorkflow(id string) (*ResolvedWorkflow, error) {
 			Workflow: workflow,
 			Source:   s.source,
 			FilePath: path,
-		}, nil
+		}, i, nil
 	}
 
 	// Fall back to embedded
 	if r.embedded {
 		workflow, err := r.readEmbeddedWorkflow(id)
-		if err != nil {
-			return nil, fmt.Errorf("workflow not found: %s", id)
+		if err == nil {
+			return &ResolvedWorkflow{
+				Workflow: workflow,
+				Source:   SourceEmbedded,
+			}, len(sources), nil
 		}
-		return &ResolvedWorkflow{
-			Workflow: workflow,
-			Source:   SourceEmbedded,
-		}, nil
 	}
 
-	return nil, fmt.Errorf("workflow not found: %s", id)
+	return nil, 0, fmt.Errorf("workflow %w: %s", ErrNotFound, id)
 }
 
 // ResolvePhase returns the phase template for an ID, checking sources in priority order.
@@ -289,9 +315,13 @@ func (r *Resolver) ListWorkflows() ([]ResolvedWorkflow, error) {
 		}
 	}
 
-	// Convert to sorted slice
+	// Convert to sorted slice, resolving workflows that extend a base
 	result := make([]ResolvedWorkflow, 0, len(seen))
-	for _, rw := range seen {
+	for id, rw := range seen {
+		if err := r.resolveInheritance(rw, r.sourceLevel(rw.Source), map[string]bool{}); err != nil {
+			slog.Warn("failed to resolve workflow inheritance", "workflow", id, "error", err)
+			continue
+		}
 		result = append(result, *rw)
 	}
 	sort.Slice(result, func(i, j int) bool {
@@ -461,6 +491,8 @@ func parseWorkflowYAML(data []byte) (*Workflow, error) {
 		CompletionAction: wf.CompletionAction,
 		TargetBranch:     wf.TargetBranch,
 		BasedOn:          wf.BasedOn,
+		Extends:          wf.Extends,
+		RemovePhases:     wf.RemovePhases,
 	}
 
 	// Convert phases
@@ -472,6 +504,7 @@ func parseWorkflowYAML(data []byte) (*Workflow, error) {
 			DependsOn:            p.DependsOn,
 			ModelOverride:        p.ModelOverride,
 			ProviderOverride:     p.ProviderOverride,
+			AgentOverride:        p.AgentOverride,
 			RuntimeConfigOverride: p.RuntimeConfigOverride,
 		}
 		if p.Thinking != nil {
@@ -504,6 +537,13 @@ func parseWorkflowYAML(data []byte) (*Workflow, error) {
 		workflow.Triggers = append(workflow.Triggers, wt)
 	}
 
+	for _, c := range wf.DoneCriteria {
+		if err := c.Validate(); err != nil {
+			return nil, fmt.Errorf("workflow %s: %w", wf.ID, err)
+		}
+	}
+	workflow.DoneCriteria = wf.DoneCriteria
+
 	// Convert variables
 	for _, v := range wf.Variables {
 		wv := WorkflowVariable{
@@ -550,6 +590,7 @@ func parsePhaseYAML(data []byte) (*PhaseTemplate, error) {
 		RetryPromptPath:  pt.RetryPromptPath,
 		RuntimeConfig:     pt.RuntimeConfig,
 		Provider:         pt.Provider,
+		Type:             pt.Type,
 	}
 
 	if pt.Thinking != nil {
@@ -578,9 +619,12 @@ type workflowYAML struct {
 	CompletionAction string                `yaml:"completion_action,omitempty"`
 	TargetBranch     string                `yaml:"target_branch,omitempty"`
 	BasedOn          string                `yaml:"based_on,omitempty"`
+	Extends          string                `yaml:"extends,omitempty"`
+	RemovePhases     []string              `yaml:"remove_phases,omitempty"`
 	Phases           []workflowPhaseYAML   `yaml:"phases,omitempty"`
 	Variables        []variableYAML        `yaml:"variables,omitempty"`
 	Triggers         []workflowTriggerYAML `yaml:"triggers,omitempty"`
+	DoneCriteria     []DoneCriterion       `yaml:"done_criteria,omitempty"`
 }
 
 type workflowTriggerYAML struct {
@@ -596,6 +640,7 @@ type workflowPhaseYAML struct {
 	DependsOn            []string `yaml:"depends_on,omitempty"`
 	ModelOverride        string   `yaml:"model_override,omitempty"`
 	ProviderOverride     string   `yaml:"provider_override,omitempty"`
+	AgentOverride        string   `yaml:"agent_override,omitempty"`
 	RuntimeConfigOverride string   `yaml:"runtime_config_override,omitempty"`
 	Thinking             *bool    `yaml:"thinking,omitempty"`
 	GateType             string   `yaml:"gate_type,omitempty"`
@@ -644,4 +689,5 @@ type phaseYAML struct {
 	QualityChecks   string `yaml:"quality_checks,omitempty"`
 	RuntimeConfig    string `yaml:"runtime_config,omitempty"`
 	Provider        string `yaml:"provider,omitempty"`
+	Type            string `yaml:"type,omitempty"` // Executor type; empty means llm
 }
@@ -102,3 +102,24 @@ func TestSeedBuiltins_ImplementPhaseHasStopHook(t *testing.T) {
 	assert.Contains(t, tmpl.RuntimeConfig, "orc-verify-completion",
 		"implement runtime_config should reference orc-verify-completion hook")
 }
+
+func TestSeedBuiltins_CompliancePhaseType(t *testing.T) {
+	tmpDir := t.TempDir()
+	gdb, err := db.OpenGlobalAt(filepath.Join(tmpDir, "orc.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = gdb.Close() })
+
+	_, err = SeedBuiltins(gdb)
+	require.NoError(t, err)
+
+	// The executor type from the phase YAML must reach the database so the
+	// phase dispatches to the compliance executor instead of an LLM.
+	tmpl, err := gdb.GetPhaseTemplate("compliance")
+	require.NoError(t, err)
+	require.NotNil(t, tmpl, "compliance phase template should exist after SeedBuiltins")
+	assert.Equal(t, "compliance", tmpl.Type)
+
+	implement, err := gdb.GetPhaseTemplate("implement")
+	require.NoError(t, err)
+	assert.Equal(t, "llm", implement.Type)
+}
@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/randalmurphal/orc/internal/db"
@@ -342,4 +343,15 @@ func TestSeedBuiltins_CrossModelStandardPersistsPhaseOverrides(t *testing.T) {
 	if got := docs.ModelOverride; got != "gpt-5.4" {
 		t.Fatalf("docs model_override = %q, want %q", got, "gpt-5.4")
 	}
+
+	reproduce := byID["reproduce"]
+	if reproduce == nil {
+		t.Fatal("reproduce phase missing from crossmodel-standard")
+	}
+	if !strings.Contains(reproduce.Condition, `"task.category"`) || !strings.Contains(reproduce.Condition, `"bug"`) {
+		t.Fatalf("reproduce condition = %q, want bug tasks only", reproduce.Condition)
+	}
+	if reproduce.Sequence >= implement.Sequence {
+		t.Fatalf("reproduce sequence %d should precede implement_codex %d", reproduce.Sequence, implement.Sequence)
+	}
 }
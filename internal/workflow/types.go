@@ -5,6 +5,8 @@ package workflow
 
 import (
 	"encoding/json"
+	"fmt"
+	"regexp"
 	"time"
 )
 
@@ -100,6 +102,98 @@ type WorkflowTrigger struct {
 	Enabled      bool                 `json:"enabled,omitempty" yaml:"enabled,omitempty"`
 }
 
+// DoneCriterionType identifies a workflow-level definition-of-done check.
+type DoneCriterionType string
+
+const (
+	DoneCriterionCoverage    DoneCriterionType = "coverage"     // Command output reports coverage >= min_percent
+	DoneCriterionDocsUpdated DoneCriterionType = "docs_updated" // Branch changes a file matching paths (default docs/**, *.md)
+	DoneCriterionChangelog   DoneCriterionType = "changelog"    // Branch changes the changelog (default CHANGELOG.md)
+	DoneCriterionNoTodos     DoneCriterionType = "no_todos"     // Branch adds no lines matching pattern (default TODO|FIXME)
+	DoneCriterionCommand     DoneCriterionType = "command"      // Command exits 0
+)
+
+// ValidDoneCriterionTypes lists the accepted done criterion types.
+var ValidDoneCriterionTypes = []DoneCriterionType{
+	DoneCriterionCoverage,
+	DoneCriterionDocsUpdated,
+	DoneCriterionChangelog,
+	DoneCriterionNoTodos,
+	DoneCriterionCommand,
+}
+
+// DoneCriterion is a workflow-level definition-of-done check. Done criteria
+// run once, before the completion action, against the task branch's changes
+// relative to the target branch.
+type DoneCriterion struct {
+	Type DoneCriterionType `json:"type" yaml:"type"`
+	// Name labels the criterion in results (defaults to the type).
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Command runs in the worktree (coverage, command).
+	Command string `json:"command,omitempty" yaml:"command,omitempty"`
+	// MinPercent is the coverage floor (coverage).
+	MinPercent float64 `json:"min_percent,omitempty" yaml:"min_percent,omitempty"`
+	// Pattern is a regexp: the coverage value with one capture group
+	// (coverage, default: last "NN.N%" in the output) or the marker that
+	// added lines must not contain (no_todos).
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	// Paths are glob patterns of files that must change (docs_updated, changelog).
+	Paths []string `json:"paths,omitempty" yaml:"paths,omitempty"`
+	// OnFailure is "block" (default) or "warn".
+	OnFailure string `json:"on_failure,omitempty" yaml:"on_failure,omitempty"`
+	// TimeoutMs bounds command execution (default 5 minutes).
+	TimeoutMs int `json:"timeout_ms,omitempty" yaml:"timeout_ms,omitempty"`
+}
+
+// DisplayName returns the criterion's name, falling back to its type.
+func (c DoneCriterion) DisplayName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return string(c.Type)
+}
+
+// Validate checks that the criterion is well formed.
+func (c DoneCriterion) Validate() error {
+	switch c.Type {
+	case DoneCriterionCoverage:
+		if c.Command == "" {
+			return fmt.Errorf("done criterion %s: command is required", c.DisplayName())
+		}
+		if c.MinPercent <= 0 || c.MinPercent > 100 {
+			return fmt.Errorf("done criterion %s: min_percent must be in (0, 100]", c.DisplayName())
+		}
+	case DoneCriterionCommand:
+		if c.Command == "" {
+			return fmt.Errorf("done criterion %s: command is required", c.DisplayName())
+		}
+	case DoneCriterionDocsUpdated, DoneCriterionChangelog, DoneCriterionNoTodos:
+	default:
+		return fmt.Errorf("done criterion %s: unknown type %q (valid: %v)", c.DisplayName(), c.Type, ValidDoneCriterionTypes)
+	}
+	if c.Pattern != "" {
+		if _, err := regexp.Compile(c.Pattern); err != nil {
+			return fmt.Errorf("done criterion %s: invalid pattern: %w", c.DisplayName(), err)
+		}
+	}
+	if c.OnFailure != "" && c.OnFailure != "block" && c.OnFailure != "warn" {
+		return fmt.Errorf("done criterion %s: on_failure must be block or warn", c.DisplayName())
+	}
+	return nil
+}
+
+// ParseDoneCriteria parses the JSON stored in db.Workflow.DoneCriteria.
+func ParseDoneCriteria(jsonStr string) ([]DoneCriterion, error) {
+	if jsonStr == "" || jsonStr == "null" {
+		return nil, nil
+	}
+	var criteria []DoneCriterion
+	if err := json.Unmarshal([]byte(jsonStr), &criteria); err != nil {
+		return nil, fmt.Errorf("parse done criteria: %w", err)
+	}
+	return criteria, nil
+}
+
 // RunStatus represents the execution state of a workflow run.
 type RunStatus string
 
@@ -184,6 +278,9 @@ type PhaseTemplate struct {
 	// LLM provider override (empty = inherit from workflow/config)
 	Provider string `json:"provider,omitempty" db:"provider"`
 
+	// Executor type: llm (default), knowledge, script, api, or compliance
+	Type string `json:"type,omitempty" db:"type"`
+
 	// Metadata
 	IsBuiltin bool      `json:"is_builtin" db:"is_builtin"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
@@ -206,9 +303,20 @@ type Workflow struct {
 	CreatedAt        time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
 
+	// Extends names a base workflow this definition is layered on. Phases
+	// listed here override the base phase with the same template, or are
+	// added; RemovePhases drops base phases. Extending the workflow's own ID
+	// layers on the next lower-priority definition (e.g. the embedded one).
+	// Resolution happens in the Resolver; stored workflows are flattened.
+	Extends      string   `json:"extends,omitempty"`
+	RemovePhases []string `json:"remove_phases,omitempty"`
+
 	// Workflow-level lifecycle triggers
 	Triggers []WorkflowTrigger `json:"triggers,omitempty"`
 
+	// Definition-of-done checks evaluated before the completion action
+	DoneCriteria []DoneCriterion `json:"done_criteria,omitempty"`
+
 	// Loaded relations (not stored directly)
 	Phases    []WorkflowPhase    `json:"phases,omitempty"`
 	Variables []WorkflowVariable `json:"variables,omitempty"`
@@ -230,6 +338,10 @@ type WorkflowPhase struct {
 	GateTypeOverride GateType `json:"gate_type_override,omitempty" db:"gate_type_override"`
 	Condition        string   `json:"condition,omitempty" db:"condition"` // JSON skip conditions
 
+	// AgentOverride runs this phase with a different executor agent (model,
+	// tool allow-list, and system prompt) than the phase template's agent_id.
+	AgentOverride string `json:"agent_override,omitempty" db:"agent_override"`
+
 	// Claude CLI configuration override (JSON)
 	// Merged with PhaseTemplate.RuntimeConfig, with this taking precedence
 	RuntimeConfigOverride string `json:"runtime_config_override,omitempty" db:"runtime_config_override"`
@@ -257,6 +257,7 @@ func marshalWorkflowYAML(workflow *Workflow) ([]byte, error) {
 		DefaultThinking:  workflow.DefaultThinking,
 		CompletionAction: workflow.CompletionAction,
 		BasedOn:          workflow.BasedOn,
+		DoneCriteria:     workflow.DoneCriteria,
 	}
 
 	for _, p := range workflow.Phases {
@@ -266,6 +267,7 @@ func marshalWorkflowYAML(workflow *Workflow) ([]byte, error) {
 			DependsOn:            p.DependsOn,
 			ModelOverride:        p.ModelOverride,
 			ProviderOverride:     p.ProviderOverride,
+			AgentOverride:        p.AgentOverride,
 			RuntimeConfigOverride: p.RuntimeConfigOverride,
 			Condition:            p.Condition,
 		}
@@ -315,6 +317,7 @@ func marshalPhaseYAML(phase *PhaseTemplate) ([]byte, error) {
 		RetryFromPhase:   phase.RetryFromPhase,
 		RetryPromptPath:  phase.RetryPromptPath,
 		Provider:         phase.Provider,
+		Type:             phase.Type,
 	}
 
 	return yaml.Marshal(pt)